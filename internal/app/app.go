@@ -0,0 +1,1313 @@
+// Package app wires the repositories, use cases and handlers of the app into an HTTP
+// handler and its background jobs, so the entrypoint and the integration tests share the
+// same composition.
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	grpcgo "google.golang.org/grpc"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/activitylog"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/backup"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/cache"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/captcha"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/database"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/filestore"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/graphql"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/grpc"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/http/handler"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/http/middleware"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/linkcheck"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/mail"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/passkey"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/scheduler"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/tracing"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/ulid"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/webhook"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// adminRecentErrors is how many failed requests the admin dashboard lists
+const adminRecentErrors = 50
+
+// App is the wired app: the HTTP handler with every route and middleware, and the
+// background jobs, which only run once started
+type App struct {
+	handler         http.Handler
+	grpcServer      *grpcgo.Server // nil when the gRPC API is disabled
+	db              *sql.DB
+	addr            string
+	grpcAddr        string
+	shutdownTimeout time.Duration
+	backups         *backup.Service
+	tracer          *sdktrace.TracerProvider // nil when tracing is disabled
+	jobs            []job
+	running         sync.WaitGroup // Started jobs that haven't returned yet
+	closers         []func()
+	closeOnce       sync.Once
+	closeErr        error
+}
+
+// job is a background job run periodically by the scheduler
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+// New opens the database and builds the app from cfg
+func New(cfg Config) (*App, error) {
+	if cfg.JWTKeys == nil {
+		return nil, errors.New("app: JWT keys are required")
+	}
+	jwtKeys := cfg.JWTKeys
+
+	// Mutations of the web routes must come from the public URL or a trusted origin
+	webOrigins, err := middleware.ParseOrigins(append([]string{cfg.PublicURL}, cfg.TrustedOrigins...))
+	if err != nil {
+		return nil, fmt.Errorf("app: PUBLIC_URL and TRUSTED_ORIGINS: %w", err)
+	}
+
+	location := time.Local
+	if cfg.Location != nil {
+		location = cfg.Location
+	}
+	cookies := cfg.Cookies
+	if cookies.Name == "" {
+		cookies = handler.DefaultCookieConfig()
+	}
+	pages := handler.NewPageTemplates(cfg.TemplatesDir)
+
+	// Initialize database
+	db, err := database.NewSQLiteDBWithHook(cfg.DatabasePath, cfg.DBBusyTimeout, cfg.DBQueryHook)
+	if err != nil {
+		return nil, err
+	}
+	database.ConfigurePool(db, cfg.DatabasePath, cfg.DBMaxOpenConns)
+	a := &App{
+		db:              db,
+		addr:            cfg.Addr,
+		grpcAddr:        cfg.GRPCAddr,
+		shutdownTimeout: cfg.ShutdownTimeout,
+		backups:         backup.NewService(db, cfg.BackupDir, backupFileDirs(cfg)),
+	}
+	built := false
+	defer func() {
+		if !built {
+			a.Close()
+		}
+	}()
+
+	// Initialize repositories, bounding every query with a deadline
+	var taskRepo repository.TaskRepository = database.NewTimeoutTaskRepository(database.NewSQLiteTaskRepository(db), cfg.QueryTimeout)
+	userRepo := database.NewTimeoutUserRepository(database.NewSQLiteUserRepository(db), cfg.QueryTimeout)
+	shareRepo := database.NewTimeoutShareRepository(database.NewSQLiteShareRepository(db), cfg.QueryTimeout)
+	linkRepo := database.NewTimeoutLinkRepository(database.NewSQLiteLinkRepository(db), cfg.QueryTimeout)
+	notificationRepo := database.NewTimeoutNotificationRepository(database.NewSQLiteNotificationRepository(db), cfg.QueryTimeout)
+	reportRepo := database.NewTimeoutReportRepository(database.NewSQLiteReportRepository(db), cfg.ReportQueryTimeout)
+	taskViewRepo := database.NewTimeoutTaskViewRepository(database.NewSQLiteTaskViewRepository(db), cfg.QueryTimeout)
+	taskOverviewQuery := database.NewTimeoutTaskOverviewQuery(database.NewSQLiteTaskOverviewQuery(db), cfg.QueryTimeout)
+	exportUsageRepo := database.NewTimeoutExportUsageRepository(database.NewSQLiteExportUsageRepository(db), cfg.QueryTimeout)
+	exportJobRepo := database.NewTimeoutExportJobRepository(database.NewSQLiteExportJobRepository(db), cfg.QueryTimeout)
+	attachmentRepo := database.NewTimeoutAttachmentRepository(database.NewSQLiteAttachmentRepository(db), cfg.QueryTimeout)
+	transactor := database.NewTimeoutTransactor(database.NewSQLiteTransactor(db), cfg.QueryTimeout)
+	loginEventRepo := database.NewTimeoutLoginEventRepository(database.NewSQLiteLoginEventRepository(db), cfg.QueryTimeout)
+	reminderRepo := database.NewTimeoutReminderRepository(database.NewSQLiteReminderRepository(db), cfg.QueryTimeout)
+	commentRepo := database.NewTimeoutCommentRepository(database.NewSQLiteCommentRepository(db), cfg.QueryTimeout)
+	digestRepo := database.NewTimeoutDigestRepository(database.NewSQLiteDigestRepository(db), cfg.QueryTimeout)
+	dependencyRepo := database.NewTimeoutDependencyRepository(database.NewSQLiteDependencyRepository(db), cfg.QueryTimeout)
+	credentialRepo := database.NewTimeoutCredentialRepository(database.NewSQLiteCredentialRepository(db), cfg.QueryTimeout)
+	twoFactorRepo := database.NewTimeoutTwoFactorRepository(database.NewSQLiteTwoFactorRepository(db), cfg.QueryTimeout)
+	projectRepo := database.NewTimeoutProjectRepository(database.NewSQLiteProjectRepository(db), cfg.QueryTimeout)
+	overdueRepo := database.NewTimeoutOverdueRepository(database.NewSQLiteOverdueRepository(db), cfg.QueryTimeout)
+	storedFileRepo := database.NewTimeoutStoredFileRepository(database.NewSQLiteStoredFileRepository(db), cfg.QueryTimeout)
+	jobRepo := database.NewTimeoutJobRepository(database.NewSQLiteJobRepository(db), cfg.QueryTimeout)
+	orgRepo := database.NewTimeoutOrgRepository(database.NewSQLiteOrgRepository(db), cfg.QueryTimeout)
+	idempotencyRepo := database.NewTimeoutIdempotencyRepository(database.NewSQLiteIdempotencyRepository(db), cfg.QueryTimeout)
+
+	// Queue of the background jobs; each job type registers its handler before the workers start
+	jobQueue := usecases.NewJobQueue(jobRepo, cfg.Queue.MaxAttempts)
+	adminRepo := database.NewTimeoutAdminRepository(database.NewSQLiteAdminRepository(db), cfg.QueryTimeout)
+	shareLinkRepo := database.NewTimeoutShareLinkRepository(database.NewSQLiteShareLinkRepository(db), cfg.QueryTimeout)
+	timeEntryRepo := database.NewTimeoutTimeEntryRepository(database.NewSQLiteTimeEntryRepository(db), cfg.QueryTimeout)
+	taskUndoRepo := database.NewTimeoutTaskUndoRepository(database.NewSQLiteTaskUndoRepository(db), cfg.QueryTimeout)
+	feedTokenRepo := database.NewTimeoutFeedTokenRepository(database.NewSQLiteFeedTokenRepository(db), cfg.QueryTimeout)
+	accountDeletionRepo := database.NewTimeoutAccountDeletionRepository(database.NewSQLiteAccountDeletionRepository(db), cfg.QueryTimeout)
+	creationUsageRepo := database.NewTimeoutCreationUsageRepository(database.NewSQLiteCreationUsageRepository(db), cfg.QueryTimeout)
+	userSettingsRepo := database.NewTimeoutUserSettingsRepository(database.NewSQLiteUserSettingsRepository(db), cfg.QueryTimeout)
+	taskRevisionRepo := database.NewTimeoutTaskRevisionRepository(database.NewSQLiteTaskRevisionRepository(db), cfg.QueryTimeout)
+
+	// Task list cache: the owned and shared task lists are read on every page load and HTMX swap
+	var taskCache *cache.TaskRepository
+	if cfg.Cache.Enabled {
+		taskCache = cache.NewTaskRepository(taskRepo, cfg.Cache.MaxEntries, cfg.Cache.TTL)
+		taskRepo = taskCache
+		log.Printf("Task cache enabled: TTL %s", cfg.Cache.TTL)
+	}
+
+	// Initialize services
+	taskService := service.NewTaskService(taskRepo, shareRepo)
+
+	// Domain events: use cases publish, subscribers react (notifications, webhook, activity log)
+	events := event.NewDispatcher()
+	taskEventNotifier := usecases.NewTaskEventNotifier(notificationRepo, shareRepo, userSettingsRepo)
+	events.Subscribe(event.TaskSharedName, taskEventNotifier.NotifyTaskShared)
+	events.Subscribe(event.TaskCompletedName, taskEventNotifier.NotifyTaskCompleted)
+	events.Subscribe(event.TaskOverdueName, taskEventNotifier.NotifyTaskOverdue)
+	events.Subscribe(event.TaskTransferredName, taskEventNotifier.NotifyTaskTransferred)
+	events.SubscribeAll(activitylog.Handler(log.Default()))
+	if taskCache != nil {
+		// The overdue job flags tasks without going through the task repository
+		events.Subscribe(event.TaskOverdueName, func(ctx context.Context, e event.DomainEvent) error {
+			taskCache.Invalidate(e.(event.TaskOverdue).OwnerID)
+			return nil
+		})
+	}
+	if cfg.Tracing.Endpoint != "" {
+		tracer, err := tracing.New(cfg.Tracing)
+		if err != nil {
+			return nil, err
+		}
+		a.tracer = tracer
+		a.closers = append(a.closers, func() { tracer.Shutdown(context.Background()) })
+		usecases.SetTracer(tracing.UseCases{})
+		log.Printf("Tracing enabled: %s", cfg.Tracing.Endpoint)
+	}
+	if cfg.Webhook.URL != "" {
+		webhookSender := webhook.NewSender(cfg.Webhook)
+		a.closers = append(a.closers, webhookSender.Close)
+		events.SubscribeAll(webhookSender.Handle)
+		log.Printf("Webhook enabled: %s", cfg.Webhook.URL)
+	}
+
+	// Initialize use cases; task IDs are ULIDs, which sort by creation time
+	taskIDs := ulid.NewGenerator()
+	creationQuota := usecases.NewCreationQuotaUseCase(creationUsageRepo, cfg.CreationLimits)
+	createTask := usecases.NewCreateTaskUseCase(taskRepo, projectRepo, events, taskIDs, creationQuota)
+	quickAddTask := usecases.NewQuickAddTaskUseCase(taskRepo, projectRepo, events, taskIDs, creationQuota)
+	taskRevisions := usecases.NewTaskRevisions(taskRevisionRepo, cfg.TaskRevisionsKeep)
+	updateTask := usecases.NewUpdateTaskUseCase(taskRepo, projectRepo, dependencyRepo, taskService, events, taskRevisions)
+	deleteTask := usecases.NewDeleteTaskUseCase(taskRepo, shareRepo, attachmentRepo, transactor, taskService, events)
+	completeTask := usecases.NewCompleteTaskUseCase(taskRepo, dependencyRepo, taskService, events, taskRevisions)
+	getTask := usecases.NewGetTaskUseCase(taskRepo, taskService)
+	listTasks := usecases.NewListTasksUseCase(taskRepo)
+	listSharedTasks := usecases.NewListSharedTasksUseCase(taskViewRepo)
+	ownerNames := usecases.NewGetOwnerNamesUseCase(userRepo)
+	shareTask := usecases.NewShareTaskUseCase(taskRepo, shareRepo, taskService, events)
+	shareTaskWithUsers := usecases.NewShareTaskWithUsersUseCase(taskRepo, shareRepo, userRepo, transactor, taskService, events)
+	exportTasksPDF := usecases.NewExportTasksPDFUseCase(taskRepo, attachmentRepo, projectRepo, timeEntryRepo)
+	exportTaskPDF := usecases.NewExportTaskPDFUseCase(taskRepo, attachmentRepo, dependencyRepo, timeEntryRepo, taskService)
+	unshareTask := usecases.NewUnshareTaskUseCase(taskRepo, shareRepo, taskService, events)
+	deleteTaskImage := usecases.NewDeleteTaskImageUseCase(taskRepo, taskService)
+	replaceTaskImage := usecases.NewReplaceTaskImageUseCase(taskRepo, taskService)
+	listBrokenLinks := usecases.NewListBrokenLinksUseCase(linkRepo)
+	listNotifications := usecases.NewListNotificationsUseCase(notificationRepo)
+	addAttachment := usecases.NewAddAttachmentUseCase(attachmentRepo, taskRepo, taskService)
+	listAttachments := usecases.NewListAttachmentsUseCase(attachmentRepo, taskRepo, taskService)
+	getAttachment := usecases.NewGetAttachmentUseCase(attachmentRepo, taskRepo, taskService)
+	deleteAttachment := usecases.NewDeleteAttachmentUseCase(attachmentRepo, taskRepo, taskService)
+	listOwnerAttachments := usecases.NewListOwnerAttachmentsUseCase(attachmentRepo)
+	importTasks := usecases.NewImportTasksUseCase(taskRepo, events, taskIDs, creationQuota)
+	exportQuota := usecases.NewExportQuotaUseCase(exportUsageRepo, cfg.ExportQuotaLimit, cfg.ExportQuotaWindow)
+	storageQuota := usecases.NewStorageQuotaUseCase(storedFileRepo, cfg.StorageQuotaBytes, cfg.StorageGlobalQuotaBytes, creationQuota)
+	if usage, err := storageQuota.TotalUsage(context.Background()); err != nil {
+		log.Printf("failed to read storage usage: %v", err)
+	} else {
+		log.Printf("Storage: %d files, %.1f MB used by uploads", usage.Files, float64(usage.Bytes)/(1<<20))
+	}
+	listProjects := usecases.NewListProjectsUseCase(projectRepo)
+	listProjectTasks := usecases.NewListProjectTasksUseCase(projectRepo, taskRepo)
+	overdueReport := usecases.NewOverdueReportUseCase(userRepo, reportRepo, cfg.OverdueReportIncludeTitles)
+
+	// Auth use cases
+	passwordHasher, err := service.NewPasswordHasher(cfg.PasswordHashAlgorithm, cfg.PasswordHashParams)
+	if err != nil {
+		return nil, err
+	}
+	loginUseCase := usecases.NewLoginUseCase(userRepo, twoFactorRepo, jwtKeys, passwordHasher, cfg.Sessions, cfg.LoginFailureDelay)
+
+	// Repeated failed logins require a CAPTCHA, once a provider is configured; the pages then
+	// load its widget
+	bruteForce := func(next http.Handler) http.Handler { return next }
+	securityHeaders := middleware.SecurityHeadersMiddleware
+	if cfg.Captcha.Provider != "" {
+		verifier, err := captcha.New(cfg.Captcha)
+		if err != nil {
+			return nil, err
+		}
+		provider := verifier.Provider()
+		bruteForce = middleware.BruteForceMiddleware(middleware.BruteForceConfig{
+			Threshold: cfg.BruteForce.Threshold,
+			Window:    cfg.BruteForce.Window,
+			Verifier:  verifier,
+			Widget: handler.CaptchaWidget{
+				Provider:  provider.Name,
+				SiteKey:   verifier.SiteKey(),
+				ScriptURL: provider.ScriptURL,
+				Global:    provider.Global,
+			},
+			Pages: pages,
+		})
+		securityHeaders = middleware.SecurityHeaders(provider.Origins)
+		log.Printf("CAPTCHA enabled: %s, after %d failed logins", provider.Name, cfg.BruteForce.Threshold)
+	}
+	registerUseCase := usecases.NewRegisterUseCase(userRepo, jwtKeys, passwordHasher, cfg.PasswordPolicy)
+
+	// Upload handler
+	uploadHandler := handler.NewUploadHandler(cfg.UploadsDir, storageQuota)
+	imageHandler := handler.NewImageHandler(cfg.UploadsDir, usecases.NewCanViewTaskImageUseCase(taskRepo, taskService))
+	avatarHandler := handler.NewAvatarHandler(uploadHandler, usecases.NewGetUserAvatarUseCase(userRepo), usecases.NewUpdateUserAvatarUseCase(userRepo))
+
+	// Files of deleted tasks (image and attachments), removed once the deletion is committed
+	taskFiles := handler.NewTaskFileStorage(uploadHandler, cfg.AttachmentsDir, storageQuota)
+
+	// Initialize handlers
+	taskHandler := handler.NewTaskHandler(
+		createTask,
+		updateTask,
+		deleteTask,
+		getTask,
+		listTasks,
+		listSharedTasks,
+		taskFiles,
+		ownerNames,
+		usecases.NewGetShareeIDsUseCase(shareRepo),
+		location,
+	)
+
+	// Web handlers (for HTMX forms)
+	quickAddHandler := handler.NewQuickAddHandler(quickAddTask, pages, location)
+	webTaskHandler := handler.NewWebTaskHandler(createTask, deleteTask, completeTask, shareTaskWithUsers, deleteTaskImage, replaceTaskImage, taskFiles, ownerNames, pages, location)
+
+	// Deletions and completions made on the web can be undone for a short while
+	var undoHandler *handler.UndoHandler
+	if cfg.Undo.Enabled {
+		undoHandler = handler.NewUndoHandler(
+			usecases.NewTrashTaskUseCase(taskRepo, taskUndoRepo, transactor, taskService, cfg.Undo.Window),
+			usecases.NewCompleteTaskWithUndoUseCase(completeTask, taskRepo, taskUndoRepo, cfg.Undo.Window),
+			usecases.NewUndoTaskUseCase(taskRepo, taskUndoRepo, transactor, taskRevisions),
+			ownerNames,
+			pages,
+		)
+	}
+
+	// Tasks page (pre-rendered on web login, short-lived cache invalidated on the user's writes)
+	var tasksPageCache *cache.TTL[[]byte]
+	if cfg.TasksPageCacheTTL > 0 {
+		tasksPageCache = cache.NewTTL[[]byte](cfg.TasksPageCacheTTL)
+	}
+	getUserTheme := usecases.NewGetUserThemeUseCase(userRepo)
+	updateUserTheme := usecases.NewUpdateUserThemeUseCase(userRepo)
+	listTaskViews := usecases.NewListTaskViewsUseCase(projectRepo, taskViewRepo, userSettingsRepo)
+	getTaskOverview := usecases.NewGetTaskOverviewUseCase(taskOverviewQuery, userSettingsRepo)
+	countersHandler := handler.NewCountersHandler(usecases.NewGetTaskCountersUseCase(taskViewRepo), pages)
+	taskOverviewHandler := handler.NewTaskOverviewHandler(getTaskOverview)
+	tasksPageHandler := handler.NewTasksPageHandler(getTaskOverview, listTaskViews, listProjects, listBrokenLinks, listOwnerAttachments, usecases.NewListOpenBlockersUseCase(dependencyRepo), usecases.NewListTaskTimesUseCase(timeEntryRepo), getUserTheme, tasksPageCache, service.NewAuthServiceWithKeys(jwtKeys, nil), pages)
+	invalidateUserCaches := func(userID string) {
+		tasksPageHandler.Invalidate(userID)
+		// Shares and projects change task lists without going through the task repository
+		if taskCache != nil {
+			taskCache.Invalidate(userID)
+		}
+	}
+	invalidateTasksPage := middleware.InvalidateOnWriteMiddleware(invalidateUserCaches)
+	// The owner's cards list who accepted to share each task, which the sharee changes
+	events.Subscribe(event.TaskShareAcceptedName, func(ctx context.Context, e event.DomainEvent) error {
+		tasksPageHandler.Invalidate(e.(event.TaskShareAccepted).OwnerID)
+		return nil
+	})
+	// A transferred task moves to the new owner's list
+	events.Subscribe(event.TaskTransferredName, func(ctx context.Context, e event.DomainEvent) error {
+		tasksPageHandler.Invalidate(e.(event.TaskTransferred).OwnerID)
+		return nil
+	})
+
+	// Auth handlers (every login attempt is recorded for incident investigation)
+	recordLogin := usecases.NewRecordLoginEventUseCase(userRepo, loginEventRepo)
+	authHandler := handler.NewAuthHandler(loginUseCase, registerUseCase, tasksPageHandler, recordLogin, pages, cookies)
+	passwordHandler := handler.NewPasswordHandler(usecases.NewChangePasswordUseCase(userRepo, passwordHasher, cfg.PasswordPolicy))
+	accountHandler := handler.NewAccountHandler(
+		usecases.NewRequestAccountDeletionUseCase(userRepo, accountDeletionRepo, passwordHasher, cfg.AccountPurge.Grace),
+		usecases.NewGetAccountDeletionUseCase(accountDeletionRepo),
+		usecases.NewCancelAccountDeletionUseCase(accountDeletionRepo),
+		usecases.NewExportAccountDataUseCase(userRepo, taskRepo),
+		cfg.UploadsDir,
+	)
+
+	// Passkey (WebAuthn) handler
+	passkeys, err := passkey.NewService(cfg.WebAuthn, userRepo, credentialRepo)
+	if err != nil {
+		return nil, err
+	}
+	listCredentials := usecases.NewListCredentialsUseCase(credentialRepo)
+	passkeyHandler := handler.NewPasskeyHandler(
+		passkeys,
+		usecases.NewPasskeyLoginUseCase(userRepo, jwtKeys, cfg.Sessions),
+		listCredentials,
+		usecases.NewDeleteCredentialUseCase(credentialRepo),
+		tasksPageHandler,
+		recordLogin,
+		cookies,
+	)
+
+	// Two-factor (TOTP) handler
+	totpService := service.NewTOTPService(cfg.TOTPIssuer)
+	twoFactorStatus := usecases.NewGetTwoFactorStatusUseCase(twoFactorRepo)
+	twoFactorHandler := handler.NewTwoFactorHandler(
+		usecases.NewVerifyTwoFactorUseCase(userRepo, twoFactorRepo, totpService, jwtKeys, cfg.Sessions),
+		usecases.NewBeginTwoFactorEnrollmentUseCase(userRepo, twoFactorRepo, totpService),
+		usecases.NewEnableTwoFactorUseCase(twoFactorRepo, totpService),
+		usecases.NewDisableTwoFactorUseCase(twoFactorRepo, totpService),
+		twoFactorStatus,
+		tasksPageHandler,
+		recordLogin,
+		pages,
+		cookies,
+	)
+
+	// Profile handler (last login, login activity, passkeys, two-factor, overdue preference and weekly digest)
+	getOverduePreference := usecases.NewGetOverduePreferenceUseCase(userRepo)
+	getDigestSubscription := usecases.NewGetDigestSubscriptionUseCase(digestRepo)
+	profileHandler := handler.NewProfileHandler(usecases.NewListLoginEventsUseCase(loginEventRepo), usecases.NewGetLastLoginUseCase(loginEventRepo), getUserTheme, listCredentials, twoFactorStatus, getOverduePreference, getDigestSubscription, pages, location)
+
+	// PDF handler
+	pdfHandler := handler.NewPDFHandler(exportTasksPDF, exportTaskPDF, pages)
+
+	// Export job handler: lists of up to ExportAsyncThreshold tasks are exported in the
+	// request, larger ones by the background export worker
+	exportJobHandler := handler.NewExportJobHandler(
+		usecases.NewRequestTaskExportUseCase(exportJobRepo, taskRepo, exportTasksPDF, cfg.ExportAsyncThreshold),
+		usecases.NewGetExportJobUseCase(exportJobRepo),
+		usecases.NewDownloadExportUseCase(exportJobRepo),
+		pages,
+	)
+
+	// Import handler (CSV/JSON)
+	importHandler := handler.NewImportHandler(importTasks, pages, location)
+
+	// Theme preference handler
+	themeHandler := handler.NewThemeHandler(updateUserTheme, pages, cookies)
+
+	// Storage usage handler
+	storageHandler := handler.NewStorageHandler(usecases.NewGetStorageUsageUseCase(storedFileRepo, cfg.StorageQuotaBytes))
+
+	// Overdue preference handler
+	overdueHandler := handler.NewOverduePreferenceHandler(getOverduePreference, usecases.NewUpdateOverduePreferenceUseCase(userRepo), pages)
+
+	// Weekly digest subscription handler
+	digestHandler := handler.NewDigestHandler(
+		getDigestSubscription,
+		usecases.NewSubscribeDigestUseCase(digestRepo, userRepo, location),
+		usecases.NewUnsubscribeDigestUseCase(digestRepo),
+		pages,
+	)
+
+	// Backups requested by admins, written while the server runs
+	backupHandler := handler.NewBackupHandler(usecases.NewCreateBackupUseCase(userRepo, a.backups))
+
+	// Failed background jobs, inspected and retried by admins
+	jobAdminHandler := handler.NewJobAdminHandler(
+		usecases.NewListFailedJobsUseCase(userRepo, jobRepo),
+		usecases.NewRetryJobUseCase(userRepo, jobRepo),
+	)
+
+	// Orgs: workspaces shared by their members, picked in the navbar or with the X-Org-ID header
+	orgHandler := handler.NewOrgHandler(
+		usecases.NewCreateOrgUseCase(orgRepo, transactor),
+		usecases.NewListOrgsUseCase(orgRepo),
+		usecases.NewUpdateOrgUseCase(orgRepo),
+		usecases.NewListOrgMembersUseCase(orgRepo),
+		usecases.NewSaveOrgMemberUseCase(orgRepo, userRepo, transactor),
+		usecases.NewRemoveOrgMemberUseCase(orgRepo, transactor),
+		pages,
+		cookies,
+	)
+	orgScope := middleware.OrgScopeMiddleware(orgRepo)
+
+	// POSTs to the API sent with an Idempotency-Key answer their retries with the first response
+	idempotency := usecases.NewIdempotencyUseCase(idempotencyRepo, cfg.Idempotency.TTL)
+	idempotent := func(next http.Handler) http.Handler { return next }
+	if cfg.Idempotency.TTL > 0 {
+		idempotent = middleware.IdempotencyMiddleware(idempotency)
+	}
+
+	// Admin dashboard: the rate limiters count their rejections and the 5xx responses are kept
+	// in memory for it
+	rateLimitRejections := &middleware.RejectionCounter{}
+	errorLog := middleware.NewErrorLog(adminRecentErrors)
+	adminHandler := handler.NewAdminHandler(
+		usecases.NewGetAdminDashboardUseCase(userRepo, adminRepo, storedFileRepo, rateLimitRejections, errorLog),
+		usecases.NewListUsersForAdminUseCase(userRepo, adminRepo),
+		usecases.NewSetUserDisabledUseCase(userRepo),
+		usecases.NewResetUserPasswordUseCase(userRepo, passwordHasher),
+		usecases.NewDeleteUserUseCase(userRepo),
+		pages,
+		location,
+	)
+
+	// Language preference handler
+	localeHandler := handler.NewLocaleHandler(usecases.NewUpdateUserLocaleUseCase(userRepo), pages, cookies)
+
+	// Settings handler (every preference of a user as one document, read and put back whole)
+	settingsHandler := handler.NewSettingsHandler(
+		usecases.NewGetUserPreferencesUseCase(userRepo, userSettingsRepo),
+		usecases.NewUpdateUserPreferencesUseCase(userRepo, userSettingsRepo, transactor),
+		cookies,
+	)
+
+	// Attachment handler
+	attachmentHandler := handler.NewAttachmentHandler(addAttachment, listAttachments, getAttachment, deleteAttachment, cfg.AttachmentsDir, storageQuota, pages)
+
+	// User search handler (share dialog autocomplete)
+	userHandler := handler.NewUserHandler(usecases.NewSearchUsersUseCase(userRepo), pages)
+
+	// Reminder handler
+	reminderHandler := handler.NewReminderHandler(
+		usecases.NewCreateReminderUseCase(reminderRepo, taskRepo, taskService),
+		usecases.NewListRemindersUseCase(reminderRepo, taskRepo, taskService),
+		usecases.NewDeleteReminderUseCase(reminderRepo, taskRepo, taskService),
+		location,
+	)
+
+	// Comment handler (comments on tasks, notifying the users they mention in the app and, when
+	// SMTP is configured and the job queue runs, by email)
+	var mentionEmails usecases.JobEnqueuer
+	if cfg.Queue.Enabled && cfg.SMTP.Host != "" {
+		jobQueue.Register(usecases.MentionEmailJobType, usecases.NewSendMentionEmailUseCase(userRepo, mail.NewSMTPSender(cfg.SMTP)).Execute)
+		mentionEmails = jobQueue
+	}
+	commentHandler := handler.NewCommentHandler(
+		usecases.NewAddCommentUseCase(commentRepo, taskRepo, shareRepo, userRepo, notificationRepo, userSettingsRepo, transactor, taskService, mentionEmails),
+		usecases.NewListCommentsUseCase(commentRepo, taskRepo, taskService),
+	)
+
+	// Timer handler (time tracked on tasks)
+	timerHandler := handler.NewTimerHandler(
+		usecases.NewStartTimerUseCase(timeEntryRepo, taskRepo, taskService),
+		usecases.NewStopTimerUseCase(timeEntryRepo, taskRepo, taskService),
+		usecases.NewGetTaskTimeUseCase(timeEntryRepo, taskRepo, taskService),
+		pages,
+	)
+
+	// Snooze handler (tasks hidden from the list until a later date)
+	snoozeHandler := handler.NewSnoozeHandler(
+		usecases.NewSnoozeTaskUseCase(taskRepo, taskService, events),
+		ownerNames,
+		pages,
+		location,
+	)
+
+	// Transfer handler (owners handing tasks over to a user they share them with)
+	taskTransferHandler := handler.NewTaskTransferHandler(
+		usecases.NewTransferTaskUseCase(taskRepo, shareRepo, transactor, taskService, events),
+		ownerNames,
+	)
+
+	// Share link handler (public, read-only links to tasks)
+	shareLinkHandler := handler.NewShareLinkHandler(
+		usecases.NewCreateShareLinkUseCase(shareLinkRepo, taskRepo, taskService),
+		usecases.NewListShareLinksUseCase(shareLinkRepo, taskRepo, taskService),
+		usecases.NewRevokeShareLinkUseCase(shareLinkRepo, taskRepo),
+		usecases.NewViewSharedTaskUseCase(shareLinkRepo, taskRepo),
+		cfg.PublicURL,
+		pages,
+		location,
+	)
+
+	// Feed handler (Atom feed of the tasks of a user, read with a feed token)
+	feedHandler := handler.NewFeedHandler(
+		usecases.NewCreateFeedTokenUseCase(feedTokenRepo),
+		usecases.NewRevokeFeedTokenUseCase(feedTokenRepo),
+		usecases.NewGetTaskFeedUseCase(feedTokenRepo, userRepo, taskRepo),
+		ownerNames,
+		cfg.PublicURL,
+	)
+
+	// Task share handler (the panel where owners see and remove who a task is shared with)
+	taskShareHandler := handler.NewTaskShareHandler(usecases.NewListTaskSharesUseCase(taskRepo, shareRepo, taskService), unshareTask, pages, location)
+	taskRevisionHandler := handler.NewTaskRevisionHandler(usecases.NewListTaskRevisionsUseCase(taskRepo, taskRevisionRepo, userRepo, taskService), pages, location)
+
+	// GraphQL handler (read-only queries over tasks, their owners and sharees)
+	graphqlHandler := handler.NewGraphQLHandler(graphql.NewTodoSchema(taskRepo, userRepo, shareRepo, commentRepo))
+
+	// gRPC API for internal integrations, on its own port
+	if cfg.GRPCAddr != "" {
+		grpcServer := grpc.NewServer(cfg.Timeouts.Default,
+			grpc.RecoveryInterceptor,
+			grpc.AuthInterceptor(jwtKeys, userRepo),
+			grpc.OrgScopeInterceptor(orgRepo),
+			grpc.InvalidateOnWriteInterceptor(invalidateUserCaches),
+		)
+		grpc.NewTaskService(createTask, getTask, updateTask, deleteTask, listTasks, listSharedTasks, shareTask, taskFiles).Register(grpcServer)
+		a.grpcServer = grpcServer
+	}
+
+	// Dependency handler ("blocked by" relationships between tasks)
+	dependencyHandler := handler.NewDependencyHandler(
+		usecases.NewAddDependencyUseCase(dependencyRepo, taskRepo, taskService),
+		usecases.NewListDependenciesUseCase(dependencyRepo, taskRepo, taskService),
+		usecases.NewRemoveDependencyUseCase(dependencyRepo, taskRepo, taskService),
+	)
+
+	// Project handler
+	projectHandler := handler.NewProjectHandler(
+		usecases.NewCreateProjectUseCase(projectRepo),
+		listProjects,
+		usecases.NewGetProjectUseCase(projectRepo),
+		usecases.NewUpdateProjectUseCase(projectRepo),
+		usecases.NewDeleteProjectUseCase(projectRepo),
+		listProjectTasks,
+		usecases.NewShareProjectUseCase(projectRepo, userRepo),
+		usecases.NewUnshareProjectUseCase(projectRepo),
+		pages,
+	)
+
+	// Notification handler
+	notificationHandler := handler.NewNotificationHandler(listNotifications)
+
+	// Share invitation handler: shared tasks are only accessible once the invitation is accepted
+	shareInvitationHandler := handler.NewShareInvitationHandler(
+		usecases.NewListShareInvitationsUseCase(shareRepo),
+		usecases.NewAcceptShareInvitationUseCase(taskRepo, shareRepo, events),
+		usecases.NewDeclineShareInvitationUseCase(taskRepo, shareRepo, events),
+		ownerNames,
+		pages,
+	)
+
+	// Report handler (managers)
+	reportHandler := handler.NewReportHandler(overdueReport)
+
+	// Background link checker (broken links in task descriptions)
+	if cfg.LinkCheck.Enabled {
+		checkTaskLinks := usecases.NewCheckTaskLinksUseCase(linkRepo, notificationRepo, linkcheck.NewHTTPProber(cfg.LinkCheck.Prober), cfg.LinkCheck.BatchSize)
+		a.addJob("link-check", cfg.LinkCheck.Interval, func(ctx context.Context) error {
+			summary, err := checkTaskLinks.Execute(ctx)
+			log.Printf("Link check: checked=%d broken=%d skipped=%d notified=%d", summary.Checked, summary.Broken, summary.Skipped, summary.Notified)
+			return err
+		})
+		log.Printf("Link checker enabled: every %s", cfg.LinkCheck.Interval)
+	}
+
+	// Background reminder delivery (in-app notification, plus email when SMTP is configured)
+	if cfg.Reminders.Enabled {
+		var mailer usecases.Mailer
+		if cfg.SMTP.Host != "" {
+			mailer = mail.NewSMTPSender(cfg.SMTP)
+			log.Printf("Reminder emails enabled via %s", cfg.SMTP.Host)
+		}
+		deliverReminders := usecases.NewDeliverRemindersUseCase(reminderRepo, taskRepo, userRepo, notificationRepo, taskService, mailer, cfg.Reminders.BatchSize, location)
+		a.addJob("reminders", cfg.Reminders.Interval, func(ctx context.Context) error {
+			summary, err := deliverReminders.Execute(ctx)
+			if summary != (usecases.ReminderDeliverySummary{}) {
+				log.Printf("Reminders: delivered=%d skipped=%d emailed=%d email_failed=%d", summary.Delivered, summary.Skipped, summary.Emailed, summary.EmailFailed)
+			}
+			return err
+		})
+		log.Printf("Reminder scheduler enabled: every %s", cfg.Reminders.Interval)
+	}
+
+	// Background weekly digest (summary emails of the users who opted in, at the time they chose)
+	if cfg.Digest.Enabled && cfg.SMTP.Host != "" {
+		sendWeeklyDigests := usecases.NewSendWeeklyDigestsUseCase(digestRepo, userRepo, mail.NewSMTPSender(cfg.SMTP), cfg.PublicURL, cfg.Digest.BatchSize, location)
+		a.addJob("digest", cfg.Digest.Interval, func(ctx context.Context) error {
+			summary, err := sendWeeklyDigests.Execute(ctx)
+			if summary != (usecases.DigestSummary{}) {
+				log.Printf("Digests: sent=%d skipped=%d failed=%d", summary.Sent, summary.Skipped, summary.Failed)
+			}
+			return err
+		})
+		log.Printf("Weekly digest enabled: checked every %s", cfg.Digest.Interval)
+	}
+
+	// Background overdue flagging (pending tasks past their due date, unless the owner opted out)
+	if cfg.Overdue.Enabled {
+		flagOverdueTasks := usecases.NewFlagOverdueTasksUseCase(overdueRepo, events, cfg.Overdue.BatchSize)
+
+		// The flag shows on the owner's tasks page, which may be cached
+		events.Subscribe(event.TaskOverdueName, func(ctx context.Context, e event.DomainEvent) error {
+			tasksPageHandler.Invalidate(e.(event.TaskOverdue).OwnerID)
+			return nil
+		})
+
+		a.addJob("overdue", cfg.Overdue.Interval, func(ctx context.Context) error {
+			flagged, err := flagOverdueTasks.Execute(ctx)
+			if flagged > 0 {
+				log.Printf("Overdue: flagged=%d", flagged)
+			}
+			return err
+		})
+		log.Printf("Overdue job enabled: every %s", cfg.Overdue.Interval)
+	}
+
+	// Background export worker (PDFs too large to generate in the request). It always runs
+	// unless disabled, since requested exports would otherwise never finish.
+	if cfg.ExportJobs.Enabled {
+		processExportJobs := usecases.NewProcessExportJobsUseCase(exportJobRepo, exportTasksPDF, cfg.ExportJobs.StaleAfter, cfg.ExportJobs.TTL)
+		a.addJob("exports", cfg.ExportJobs.Interval, func(ctx context.Context) error {
+			summary, err := processExportJobs.Execute(ctx)
+			if summary != (usecases.ExportJobSummary{}) {
+				log.Printf("Exports: done=%d failed=%d expired=%d", summary.Done, summary.Failed, summary.Expired)
+			}
+			return err
+		})
+		log.Printf("Export worker enabled: every %s", cfg.ExportJobs.Interval)
+	}
+
+	// Workers running the queued jobs. Each claims the due jobs one at a time, so a slow job only
+	// holds up its own worker; on shutdown the running jobs are given time to finish.
+	if cfg.Queue.Enabled {
+		runJobs := usecases.NewRunJobsUseCase(jobQueue, cfg.Queue.Backoff, cfg.Queue.StaleAfter, cfg.Queue.TTL)
+		for i := 1; i <= cfg.Queue.Workers; i++ {
+			a.addJob(fmt.Sprintf("queue-worker-%d", i), cfg.Queue.Interval, func(ctx context.Context) error {
+				summary, err := runJobs.Execute(ctx)
+				if summary != (usecases.JobRunSummary{}) {
+					log.Printf("Jobs: done=%d retried=%d failed=%d expired=%d", summary.Done, summary.Retried, summary.Failed, summary.Expired)
+				}
+				return err
+			})
+		}
+		log.Printf("Job queue enabled: %d workers, every %s", cfg.Queue.Workers, cfg.Queue.Interval)
+	}
+
+	// Background cleanup of the images and attachments nothing refers to anymore
+	if cfg.OrphanCleanup.Enabled {
+		cleanOrphanFiles := usecases.NewCleanOrphanFilesUseCase(
+			database.NewTimeoutUploadReferenceRepository(database.NewSQLiteUploadReferenceRepository(db), cfg.QueryTimeout),
+			storedFileRepo,
+			filestore.NewDir(cfg.UploadsDir),
+			filestore.NewDir(cfg.AttachmentsDir),
+			cfg.OrphanCleanup.GracePeriod,
+			cfg.OrphanCleanup.DryRun,
+		)
+		dryRun := cfg.OrphanCleanup.DryRun
+		a.addJob("orphan-cleanup", cfg.OrphanCleanup.Interval, func(ctx context.Context) error {
+			summary, err := cleanOrphanFiles.Execute(ctx)
+			if dryRun {
+				for _, orphan := range summary.Orphans {
+					log.Printf("Orphan cleanup (dry run): would remove %s %s (%d bytes)", orphan.Kind, orphan.Name, orphan.Size)
+				}
+			}
+			log.Printf("Orphan cleanup: scanned=%d orphans=%d removed=%d failed=%d bytes=%d dry_run=%t", summary.Scanned, len(summary.Orphans), summary.Removed, summary.Failed, summary.Bytes, dryRun)
+			return err
+		})
+		log.Printf("Orphan cleanup enabled: every %s, grace period %s", cfg.OrphanCleanup.Interval, cfg.OrphanCleanup.GracePeriod)
+	}
+
+	// Deletions and completions made on the web become final once their undo expires
+	if cfg.Undo.Enabled {
+		finalizeTaskUndos := usecases.NewFinalizeTaskUndosUseCase(taskUndoRepo, deleteTask, transactor, taskFiles, events, cfg.Undo.BatchSize)
+		a.addJob("undo", cfg.Undo.Interval, func(ctx context.Context) error {
+			summary, err := finalizeTaskUndos.Execute(ctx)
+			if summary != (usecases.TaskUndoSummary{}) {
+				log.Printf("Undo: deleted=%d completed=%d", summary.Deleted, summary.Completed)
+			}
+			return err
+		})
+		log.Printf("Undo enabled: %s window", cfg.Undo.Window)
+	}
+
+	// Accounts their users asked to delete are purged once their grace period is over
+	if cfg.AccountPurge.Enabled {
+		purgeAccounts := usecases.NewPurgeAccountsUseCase(accountDeletionRepo, userRepo, taskRepo, deleteTask, transactor, taskFiles, events, cfg.AccountPurge.BatchSize)
+		a.addJob("account-purge", cfg.AccountPurge.Interval, func(ctx context.Context) error {
+			purged, err := purgeAccounts.Execute(ctx)
+			if purged > 0 {
+				log.Printf("Account purge: purged=%d", purged)
+			}
+			return err
+		})
+		log.Printf("Account purge enabled: %s grace period", cfg.AccountPurge.Grace)
+	}
+
+	// Idempotency-Keys are deleted once their responses expire
+	if cfg.Idempotency.TTL > 0 {
+		a.addJob("idempotency-cleanup", cfg.Idempotency.Interval, func(ctx context.Context) error {
+			deleted, err := idempotency.Purge(ctx)
+			if deleted > 0 {
+				log.Printf("Idempotency cleanup: deleted=%d", deleted)
+			}
+			return err
+		})
+	}
+
+	// Task cache hit rate, logged while the cache is in use
+	if taskCache != nil && cfg.Cache.StatsInterval > 0 {
+		var last cache.TaskCacheStats
+		a.addJob("cache-stats", cfg.Cache.StatsInterval, func(ctx context.Context) error {
+			stats := taskCache.Stats()
+			if stats.Hits+stats.Misses != last.Hits+last.Misses {
+				log.Printf("Task cache: hits=%d misses=%d hit_rate=%.1f%% invalidations=%d entries=%d", stats.Hits, stats.Misses, stats.HitRate()*100, stats.Invalidations, stats.Entries)
+			}
+			last = stats
+			return nil
+		})
+	}
+
+	// Setup router
+	mux := http.NewServeMux()
+	routes := middleware.NewRouteTable(mux)
+
+	// Each route group has a deadline, past which it answers 503: a short one for the JSON
+	// API and the pages, longer ones for the routes rendering PDFs or reading uploads. Those
+	// are slow by design, so they are kept out of the latency the load shedding watches.
+	longTimeout := func(timeout time.Duration) func(http.Handler) http.Handler {
+		withTimeout := middleware.TimeoutMiddleware(timeout)
+		return func(next http.Handler) http.Handler {
+			return middleware.ExcludeFromLatency(withTimeout(next))
+		}
+	}
+	defaultTimeout := middleware.TimeoutMiddleware(cfg.Timeouts.Default)
+	exportTimeout := longTimeout(cfg.Timeouts.Export)
+	uploadTimeout := longTimeout(cfg.Timeouts.Upload)
+
+	// Each rate limiter sweeps its idle clients in the background until the app is closed
+	rateLimit := func(config middleware.RateLimitConfig) func(http.Handler) http.Handler {
+		config.Window = cfg.RateLimit.Window
+		config.TrustedProxies = cfg.RateLimit.TrustedProxies
+		config.Rejections = rateLimitRejections
+		config.MaxClients = cfg.RateLimit.MaxClients
+		config.CleanupInterval = cfg.RateLimit.CleanupInterval
+		limiter := middleware.NewRateLimiter(config)
+		a.closers = append(a.closers, limiter.Stop)
+		return limiter.Middleware
+	}
+
+	// User search has its own, stricter rate limit so it can't be used to enumerate accounts.
+	// The API and the web autocomplete share the same limiter.
+	userSearchRateLimiter := rateLimit(middleware.RateLimitConfig{RequestsPerMinute: cfg.RateLimit.UserSearch})
+
+	// API routes (protected with JWT)
+	apiMux := http.NewServeMux()
+	apiMux.HandleFunc("POST /tasks", taskHandler.CreateTask)
+	apiMux.HandleFunc("POST /tasks/quick", quickAddHandler.QuickAdd)
+	apiMux.HandleFunc("GET /tasks", taskHandler.ListTasks)
+	apiMux.HandleFunc("GET /tasks/shared", taskHandler.ListSharedTasks)
+	apiMux.HandleFunc("GET /tasks/overview", taskOverviewHandler.GetOverview)
+	apiMux.HandleFunc("GET /tasks/{id}", taskHandler.GetTask)
+	apiMux.HandleFunc("DELETE /tasks/{id}", taskHandler.DeleteTask)
+	apiMux.HandleFunc("GET /tasks/{id}/shares", taskShareHandler.ListShares)
+	apiMux.HandleFunc("GET /exports/{id}", exportJobHandler.GetExport)
+	apiMux.HandleFunc("GET /tasks/{id}/attachments", attachmentHandler.ListAttachments)
+	apiMux.HandleFunc("DELETE /tasks/{id}/attachments/{attachmentID}", attachmentHandler.DeleteAttachment)
+	apiMux.HandleFunc("POST /tasks/{id}/reminders", reminderHandler.CreateReminder)
+	apiMux.HandleFunc("GET /tasks/{id}/reminders", reminderHandler.ListReminders)
+	apiMux.HandleFunc("DELETE /tasks/{id}/reminders/{reminderID}", reminderHandler.DeleteReminder)
+	apiMux.HandleFunc("POST /tasks/{id}/comments", commentHandler.AddComment)
+	apiMux.HandleFunc("GET /tasks/{id}/comments", commentHandler.ListComments)
+	apiMux.HandleFunc("POST /tasks/{id}/timer/start", timerHandler.StartTimer)
+	apiMux.HandleFunc("POST /tasks/{id}/timer/stop", timerHandler.StopTimer)
+	apiMux.HandleFunc("GET /tasks/{id}/timer", timerHandler.GetTimer)
+	apiMux.HandleFunc("POST /tasks/{id}/snooze", snoozeHandler.Snooze)
+	apiMux.HandleFunc("DELETE /tasks/{id}/snooze", snoozeHandler.Wake)
+	apiMux.HandleFunc("POST /tasks/{id}/transfer", taskTransferHandler.Transfer)
+	apiMux.HandleFunc("POST /tasks/{id}/share-links", shareLinkHandler.CreateShareLink)
+	apiMux.HandleFunc("GET /tasks/{id}/share-links", shareLinkHandler.ListShareLinks)
+	apiMux.HandleFunc("DELETE /share-links/{id}", shareLinkHandler.RevokeShareLink)
+	apiMux.HandleFunc("POST /tasks/{id}/dependencies", dependencyHandler.AddDependency)
+	apiMux.HandleFunc("GET /tasks/{id}/dependencies", dependencyHandler.ListDependencies)
+	apiMux.HandleFunc("DELETE /tasks/{id}/dependencies/{blockerID}", dependencyHandler.RemoveDependency)
+	apiMux.HandleFunc("GET /tasks/{id}/revisions", taskRevisionHandler.ListRevisions)
+	apiMux.HandleFunc("GET /projects", projectHandler.ListProjects)
+	apiMux.HandleFunc("POST /projects", projectHandler.CreateProject)
+	apiMux.HandleFunc("GET /projects/{id}", projectHandler.GetProject)
+	apiMux.HandleFunc("PUT /projects/{id}", projectHandler.UpdateProject)
+	apiMux.HandleFunc("DELETE /projects/{id}", projectHandler.DeleteProject)
+	apiMux.HandleFunc("GET /projects/{id}/tasks", projectHandler.ListProjectTasks)
+	apiMux.HandleFunc("POST /projects/{id}/shares", projectHandler.ShareProject)
+	apiMux.HandleFunc("DELETE /projects/{id}/shares/{userID}", projectHandler.UnshareProject)
+	apiMux.HandleFunc("GET /orgs", orgHandler.ListOrgs)
+	apiMux.HandleFunc("POST /orgs", orgHandler.CreateOrg)
+	apiMux.HandleFunc("PUT /orgs/{id}", orgHandler.UpdateOrg)
+	apiMux.HandleFunc("GET /orgs/{id}/members", orgHandler.ListOrgMembers)
+	apiMux.HandleFunc("POST /orgs/{id}/members", orgHandler.SaveOrgMember)
+	apiMux.HandleFunc("DELETE /orgs/{id}/members/{userID}", orgHandler.RemoveOrgMember)
+	apiMux.HandleFunc("GET /notifications", notificationHandler.ListNotifications)
+	apiMux.HandleFunc("GET /invitations", shareInvitationHandler.ListInvitations)
+	apiMux.HandleFunc("POST /invitations/{id}/accept", shareInvitationHandler.AcceptInvitation)
+	apiMux.HandleFunc("POST /invitations/{id}/decline", shareInvitationHandler.DeclineInvitation)
+	apiMux.HandleFunc("GET /me/security/logins", profileHandler.ListLogins)
+	apiMux.HandleFunc("GET /me/preferences/overdue", overdueHandler.GetPreference)
+	apiMux.HandleFunc("GET /me/storage", storageHandler.GetStorage)
+	apiMux.HandleFunc("PUT /me/preferences/overdue", overdueHandler.UpdatePreference)
+	apiMux.HandleFunc("GET /me/preferences/digest", digestHandler.GetPreference)
+	apiMux.HandleFunc("PUT /me/preferences/digest", digestHandler.UpdatePreference)
+	apiMux.HandleFunc("GET /me/settings", settingsHandler.GetSettings)
+	apiMux.HandleFunc("PUT /me/settings", settingsHandler.UpdateSettings)
+	apiMux.HandleFunc("POST /me/feed-token", feedHandler.CreateFeedToken)
+	apiMux.HandleFunc("DELETE /me/feed-token", feedHandler.RevokeFeedToken)
+	apiMux.HandleFunc("PUT /me/password", passwordHandler.ChangePassword)
+	apiMux.HandleFunc("DELETE /me", accountHandler.DeleteAccount)
+	apiMux.HandleFunc("GET /me/deletion", accountHandler.GetDeletion)
+	apiMux.HandleFunc("DELETE /me/deletion", accountHandler.CancelDeletion)
+	apiMux.HandleFunc("GET /admin/jobs/failed", jobAdminHandler.ListFailedJobs)
+	apiMux.HandleFunc("POST /admin/jobs/{id}/retry", jobAdminHandler.RetryJob)
+	apiMux.Handle("GET /users/search", userSearchRateLimiter(http.HandlerFunc(userHandler.SearchUsers)))
+
+	// Apply auth middleware to API routes
+	mux.Handle("/api/", http.StripPrefix("/api", middleware.Chain(
+		apiMux,
+		defaultTimeout,
+		middleware.AuthMiddleware(jwtKeys, userRepo, cookies.AuthCookieName()),
+		orgScope,
+		invalidateTasksPage,
+		middleware.ContentNegotiation(middleware.JSONOrJSONAPI),
+		idempotent,
+	)))
+
+	// Attachment downloads answer with the type of the uploaded file, so any Accept goes
+	attachmentDownloads := newRouteGroup("/api")
+	attachmentDownloads.HandleFunc("GET /tasks/{id}/attachments/{attachmentID}", attachmentHandler.DownloadAttachment)
+	attachmentDownloads.mount(mux, routes,
+		defaultTimeout,
+		middleware.AuthMiddleware(jwtKeys, userRepo, cookies.AuthCookieName()),
+		orgScope,
+		invalidateTasksPage,
+		middleware.ContentNegotiation(middleware.Negotiation{Consumes: []string{"application/json"}}),
+	)
+
+	// The GraphQL schema is plain text; queries are JSON like the rest of the API
+	graphqlRoutes := newRouteGroup("/api")
+	graphqlRoutes.HandleFunc("POST /graphql", graphqlHandler.Query)
+	graphqlRoutes.HandleFunc("GET /graphql/schema", graphqlHandler.Schema)
+	graphqlRoutes.mount(mux, routes,
+		defaultTimeout,
+		middleware.AuthMiddleware(jwtKeys, userRepo, cookies.AuthCookieName()),
+		orgScope,
+		middleware.ContentNegotiation(middleware.Negotiation{
+			Consumes: []string{"application/json"},
+			Produces: []string{"application/json", "text/plain"},
+		}),
+	)
+
+	// API exports render PDFs and reports, and backups copy the whole data, so they get the
+	// export timeout
+	apiExports := newRouteGroup("/api")
+	apiExports.Handle("GET /tasks/export/pdf", middleware.ExportQuotaMiddleware(exportQuota, "tasks_pdf", nil)(http.HandlerFunc(pdfHandler.ExportTasks)))
+	apiExports.Handle("POST /exports", middleware.ExportQuotaMiddleware(exportQuota, "tasks_pdf", nil)(http.HandlerFunc(exportJobHandler.RequestExport)))
+	apiExports.HandleFunc("GET /exports/{id}/download", exportJobHandler.DownloadExport)
+	apiExports.HandleFunc("GET /tasks/{id}/export/pdf", pdfHandler.ExportTask)
+	apiExports.Handle("GET /admin/reports/overdue", middleware.ExportQuotaMiddleware(exportQuota, "overdue_report", isFileExport)(http.HandlerFunc(reportHandler.OverdueReport)))
+	apiExports.HandleFunc("POST /admin/backups", backupHandler.CreateBackup)
+	apiExports.Handle("GET /me/export", middleware.ExportQuotaMiddleware(exportQuota, "account_data", nil)(http.HandlerFunc(accountHandler.ExportData)))
+	apiExports.mount(mux, routes,
+		exportTimeout,
+		middleware.AuthMiddleware(jwtKeys, userRepo, cookies.AuthCookieName()),
+		orgScope,
+		invalidateTasksPage,
+		middleware.ContentNegotiation(middleware.Negotiation{
+			Consumes: []string{"application/json"},
+			Produces: []string{"application/json", "application/pdf", "text/csv", "application/zip"},
+		}),
+	)
+
+	// Task import accepts CSV, JSON and multipart uploads, task updates JSON or a multipart form
+	// with the image, and attachment and avatar uploads are multipart, so they get their own
+	// content types and the upload timeout
+	apiUploads := newRouteGroup("/api")
+	apiUploads.HandleFunc("POST /tasks/import", importHandler.ImportTasks)
+	apiUploads.HandleFunc("PUT /tasks/{id}", taskHandler.UpdateTask)
+	apiUploads.HandleFunc("POST /tasks/{id}/attachments", attachmentHandler.UploadAttachment)
+	apiUploads.HandleFunc("PUT /me/avatar", avatarHandler.UpdateAvatar)
+	apiUploads.mount(mux, routes,
+		uploadTimeout,
+		middleware.AuthMiddleware(jwtKeys, userRepo, cookies.AuthCookieName()),
+		orgScope,
+		invalidateTasksPage,
+		middleware.ContentNegotiation(middleware.Negotiation{
+			Consumes: []string{"multipart/form-data", "text/csv", "application/json"},
+			Produces: []string{"application/json"},
+		}),
+	)
+
+	// Auth API routes (no auth required, stricter rate limit)
+	authMux := http.NewServeMux()
+	authMux.Handle("POST /login", bruteForce(http.HandlerFunc(authHandler.Login)))
+	authMux.HandleFunc("POST /register", authHandler.Register)
+	authMux.HandleFunc("POST /webauthn/login/begin", passkeyHandler.BeginLogin)
+	authMux.HandleFunc("POST /webauthn/login/finish", passkeyHandler.FinishLogin)
+	authMux.HandleFunc("POST /2fa/verify", twoFactorHandler.Verify)
+
+	// Passkey registration and management need a session: passwords stay the first factor
+	requireAuth := middleware.AuthMiddleware(jwtKeys, userRepo, cookies.AuthCookieName())
+	authMux.Handle("POST /webauthn/register/begin", requireAuth(http.HandlerFunc(passkeyHandler.BeginRegistration)))
+	authMux.Handle("POST /webauthn/register/finish", requireAuth(http.HandlerFunc(passkeyHandler.FinishRegistration)))
+	authMux.Handle("GET /webauthn/credentials", requireAuth(http.HandlerFunc(passkeyHandler.ListCredentials)))
+	authMux.Handle("DELETE /webauthn/credentials/{id}", requireAuth(http.HandlerFunc(passkeyHandler.DeleteCredential)))
+	authMux.Handle("GET /2fa", requireAuth(http.HandlerFunc(twoFactorHandler.Status)))
+	authMux.Handle("POST /2fa/setup", requireAuth(http.HandlerFunc(twoFactorHandler.Setup)))
+	authMux.Handle("POST /2fa/enable", requireAuth(http.HandlerFunc(twoFactorHandler.Enable)))
+	authMux.Handle("POST /2fa/disable", requireAuth(http.HandlerFunc(twoFactorHandler.Disable)))
+	mux.Handle("/api/auth/", http.StripPrefix("/api/auth", middleware.Chain(
+		authMux,
+		defaultTimeout,
+		rateLimit(middleware.RateLimitConfig{RequestsPerMinute: cfg.RateLimit.Auth}),
+		middleware.ContentNegotiation(middleware.JSONOnly),
+	)))
+
+	// The Atom feed of tasks is read by feed readers, which can't send a JWT: its token, in the
+	// query string, is the only authorization
+	feeds := newRouteGroup("/api")
+	feeds.HandleFunc("GET /tasks.atom", feedHandler.TaskFeed)
+	feeds.mount(mux, routes,
+		defaultTimeout,
+		middleware.ContentNegotiation(middleware.Negotiation{Produces: []string{"application/atom+xml", "application/xml"}}),
+	)
+
+	// Web routes (HTML - no auth required)
+	webMux := http.NewServeMux()
+	webMux.HandleFunc("/", handleIndex)
+	webMux.HandleFunc("/login", loginPage(pages))
+	webMux.HandleFunc("/register", registerPage(pages, cfg.PasswordPolicy))
+	mux.Handle("/", defaultTimeout(webMux))
+
+	// Health check (database reachable), for load balancers and orchestrators
+	mux.HandleFunc("GET /health", handler.NewHealthHandler(db.PingContext).Health)
+
+	// Public task links: the token in the URL is the only authorization
+	shareMux := http.NewServeMux()
+	shareMux.HandleFunc("GET /share/{token}", shareLinkHandler.SharedTaskPage)
+	mux.Handle("/share/", defaultTimeout(shareMux))
+
+	// Web auth routes (no auth required, stricter rate limit)
+	webAuthMux := http.NewServeMux()
+	webAuthMux.Handle("POST /login", bruteForce(http.HandlerFunc(authHandler.WebLogin)))
+	webAuthMux.HandleFunc("POST /2fa", twoFactorHandler.WebVerify)
+	webAuthMux.HandleFunc("POST /register", authHandler.WebRegister)
+	webAuthMux.HandleFunc("POST /logout", authHandler.Logout)
+	mux.Handle("/web/auth/", http.StripPrefix("/web/auth", middleware.Chain(
+		webAuthMux,
+		defaultTimeout,
+		rateLimit(middleware.RateLimitConfig{RequestsPerMinute: cfg.RateLimit.Auth}),
+	)))
+
+	// Protected web routes (require JWT)
+	protectedWebMux := http.NewServeMux()
+	protectedWebMux.HandleFunc("/tasks", tasksPageHandler.TasksPage)
+	protectedWebMux.HandleFunc("GET /profile", profileHandler.ProfilePage)
+	protectedWebMux.HandleFunc("GET /admin", adminHandler.DashboardPage)
+	protectedWeb := middleware.Chain(protectedWebMux, defaultTimeout, middleware.AuthMiddleware(jwtKeys, userRepo, cookies.AuthCookieName()), orgScope)
+	mux.Handle("/tasks", protectedWeb)
+	mux.Handle("/profile", protectedWeb)
+	mux.Handle("/admin", protectedWeb)
+
+	// Web API routes (for HTMX - require JWT)
+	protectedWebAPIMux := http.NewServeMux()
+	protectedWebAPIMux.HandleFunc("GET /tasks", tasksPageHandler.TaskCards)
+	protectedWebAPIMux.HandleFunc("POST /tasks/quick", quickAddHandler.WebQuickAdd)
+	protectedWebAPIMux.HandleFunc("POST /tasks/preview", webTaskHandler.PreviewDescription)
+	if undoHandler != nil {
+		protectedWebAPIMux.HandleFunc("POST /tasks/{id}/complete", undoHandler.CompleteTask)
+		protectedWebAPIMux.HandleFunc("DELETE /tasks/{id}", undoHandler.DeleteTask)
+		protectedWebAPIMux.HandleFunc("POST /tasks/{id}/undo", undoHandler.Undo)
+	} else {
+		protectedWebAPIMux.HandleFunc("POST /tasks/{id}/complete", webTaskHandler.CompleteTask)
+		protectedWebAPIMux.HandleFunc("DELETE /tasks/{id}", webTaskHandler.DeleteTask)
+	}
+	protectedWebAPIMux.HandleFunc("POST /tasks/{id}/share", webTaskHandler.ShareTask)
+	protectedWebAPIMux.HandleFunc("GET /tasks/{id}/shares", taskShareHandler.WebListShares)
+	protectedWebAPIMux.HandleFunc("DELETE /tasks/{id}/shares/{userID}", taskShareHandler.WebUnshare)
+	protectedWebAPIMux.HandleFunc("GET /tasks/{id}/revisions", taskRevisionHandler.WebListRevisions)
+	protectedWebAPIMux.HandleFunc("POST /tasks/{id}/share-links", shareLinkHandler.WebCreateShareLink)
+	protectedWebAPIMux.HandleFunc("GET /tasks/{id}/share-links", shareLinkHandler.WebListShareLinks)
+	protectedWebAPIMux.HandleFunc("DELETE /share-links/{id}", shareLinkHandler.WebRevokeShareLink)
+	protectedWebAPIMux.HandleFunc("DELETE /tasks/{id}/image", webTaskHandler.DeleteTaskImage)
+	protectedWebAPIMux.HandleFunc("POST /tasks/{id}/timer/start", timerHandler.WebStartTimer)
+	protectedWebAPIMux.HandleFunc("POST /tasks/{id}/timer/stop", timerHandler.WebStopTimer)
+	protectedWebAPIMux.HandleFunc("POST /tasks/{id}/snooze", snoozeHandler.WebSnooze)
+	protectedWebAPIMux.HandleFunc("GET /tasks/{id}/attachments/{attachmentID}", attachmentHandler.WebDownloadAttachment)
+	protectedWebAPIMux.HandleFunc("DELETE /tasks/{id}/attachments/{attachmentID}", attachmentHandler.WebDeleteAttachment)
+	protectedWebAPIMux.HandleFunc("POST /projects", projectHandler.WebCreateProject)
+	protectedWebAPIMux.HandleFunc("GET /exports/{id}", exportJobHandler.WebGetExport)
+	protectedWebAPIMux.HandleFunc("GET /invitations", shareInvitationHandler.WebListInvitations)
+	protectedWebAPIMux.HandleFunc("POST /invitations/{id}/accept", shareInvitationHandler.WebAcceptInvitation)
+	protectedWebAPIMux.HandleFunc("POST /invitations/{id}/decline", shareInvitationHandler.WebDeclineInvitation)
+	protectedWebAPIMux.HandleFunc("GET /fragments/counters", countersHandler.WebCounters)
+	protectedWebAPIMux.HandleFunc("GET /fragments/orgs", orgHandler.WebOrgSwitcher)
+	protectedWebAPIMux.HandleFunc("POST /preferences/theme", themeHandler.UpdateTheme)
+	protectedWebAPIMux.HandleFunc("POST /preferences/overdue", overdueHandler.WebUpdatePreference)
+	protectedWebAPIMux.HandleFunc("POST /preferences/digest", digestHandler.WebUpdatePreference)
+	protectedWebAPIMux.HandleFunc("POST /preferences/locale", localeHandler.UpdateLocale)
+	protectedWebAPIMux.HandleFunc("POST /preferences/org", orgHandler.WebSwitchOrg)
+	protectedWebAPIMux.Handle("GET /users/search", userSearchRateLimiter(http.HandlerFunc(userHandler.WebSearchUsers)))
+	protectedWebAPIMux.HandleFunc("GET /admin/users", adminHandler.WebListUsers)
+	protectedWebAPIMux.HandleFunc("POST /admin/users/{id}/disable", adminHandler.WebDisableUser)
+	protectedWebAPIMux.HandleFunc("POST /admin/users/{id}/enable", adminHandler.WebEnableUser)
+	protectedWebAPIMux.HandleFunc("POST /admin/users/{id}/password", adminHandler.WebResetPassword)
+	protectedWebAPIMux.HandleFunc("DELETE /admin/users/{id}", adminHandler.WebDeleteUser)
+
+	protectedWebAPI := middleware.Chain(
+		http.StripPrefix("/web", protectedWebAPIMux),
+		defaultTimeout,
+		middleware.AuthMiddleware(jwtKeys, userRepo, cookies.AuthCookieName()),
+		orgScope,
+		invalidateTasksPage,
+	)
+	mux.Handle("/web/tasks", protectedWebAPI)
+	mux.Handle("/web/tasks/", protectedWebAPI)
+	mux.Handle("/web/projects", protectedWebAPI)
+	mux.Handle("/web/exports", protectedWebAPI)
+	mux.Handle("/web/exports/", protectedWebAPI)
+	mux.Handle("/web/invitations", protectedWebAPI)
+	mux.Handle("/web/invitations/", protectedWebAPI)
+	mux.Handle("/web/preferences/", protectedWebAPI)
+	mux.Handle("/web/fragments/", protectedWebAPI)
+	mux.Handle("/web/users/", protectedWebAPI)
+	mux.Handle("/web/admin/", protectedWebAPI)
+	mux.Handle("/web/share-links/", protectedWebAPI)
+
+	// Web exports and the forms sending files, with the same timeouts as in the API
+	webExports := newRouteGroup("/web")
+	webExports.Handle("POST /exports", middleware.ExportQuotaMiddleware(exportQuota, "tasks_pdf", nil)(http.HandlerFunc(exportJobHandler.WebRequestExport)))
+	webExports.HandleFunc("GET /exports/{id}/download", exportJobHandler.WebDownloadExport)
+	webExports.HandleFunc("GET /tasks/{id}/export/pdf", pdfHandler.WebExportTask)
+	webExports.mount(mux, routes, exportTimeout, middleware.AuthMiddleware(jwtKeys, userRepo, cookies.AuthCookieName()), orgScope, invalidateTasksPage)
+
+	webUploads := newRouteGroup("/web")
+	webUploads.HandleFunc("POST /tasks", webTaskHandler.CreateTask)
+	webUploads.HandleFunc("POST /tasks/import", importHandler.WebImportTasks)
+	webUploads.HandleFunc("PUT /tasks/{id}/image", webTaskHandler.ReplaceTaskImage)
+	webUploads.HandleFunc("POST /tasks/{id}/attachments", attachmentHandler.WebUploadAttachment)
+	webUploads.mount(mux, routes, uploadTimeout, middleware.AuthMiddleware(jwtKeys, userRepo, cookies.AuthCookieName()), orgScope, invalidateTasksPage)
+
+	// Upload route (protected with JWT)
+	uploadMux := http.NewServeMux()
+	uploadMux.HandleFunc("POST /image", uploadHandler.UploadImage)
+	mux.Handle("/upload/", http.StripPrefix("/upload", middleware.Chain(uploadMux, uploadTimeout, middleware.AuthMiddleware(jwtKeys, userRepo, cookies.AuthCookieName()))))
+
+	// Uploaded images, served only to the users who can access their task, and user avatars
+	uploadsMux := http.NewServeMux()
+	uploadsMux.HandleFunc("GET /images/{name}", imageHandler.ServeImage)
+	uploadsMux.HandleFunc("GET /avatars/{id}", avatarHandler.ServeAvatar)
+	mux.Handle("/uploads/", http.StripPrefix("/uploads", middleware.Chain(uploadsMux, defaultTimeout, middleware.AuthMiddleware(jwtKeys, userRepo, cookies.AuthCookieName()))))
+
+	// Sub-muxes mounted above, so OPTIONS and 405 responses list the methods of each route
+	routes.Mount("/api/", "/api", apiMux)
+	routes.Mount("/api/auth/", "/api/auth", authMux)
+	routes.Mount("/web/auth/", "/web/auth", webAuthMux)
+	routes.Mount("/tasks", "", protectedWebMux)
+	routes.Mount("/profile", "", protectedWebMux)
+	routes.Mount("/admin", "", protectedWebMux)
+	routes.Mount("/share/", "", shareMux)
+	for _, pattern := range []string{"/web/tasks", "/web/tasks/", "/web/projects", "/web/exports", "/web/exports/", "/web/invitations", "/web/invitations/", "/web/preferences/", "/web/fragments/", "/web/users/", "/web/admin/", "/web/share-links/"} {
+		routes.Mount(pattern, "/web", protectedWebAPIMux)
+	}
+	routes.Mount("/upload/", "/upload", uploadMux)
+	routes.Mount("/uploads/", "/uploads", uploadsMux)
+
+	// Load shedding: reject non-essential traffic with 503 while the server is saturated
+	loadShedding := func(next http.Handler) http.Handler { return next }
+	if cfg.LoadShedEnabled {
+		loadShedConfig := cfg.LoadShed
+		loadShedConfig.QueueDepth = func() int { return db.Stats().InUse }
+		loadShedding = middleware.LoadSheddingMiddleware(loadShedConfig)
+	}
+
+	// Response compression (gzip/deflate) for text, HTML and JSON; images and PDFs are sent as they are
+	compression := func(next http.Handler) http.Handler { return next }
+	if cfg.CompressEnabled {
+		compression = middleware.CompressMiddleware(cfg.Compress)
+	}
+
+	// Request spans, outermost to time the whole request
+	tracingMiddleware := func(next http.Handler) http.Handler { return next }
+	if a.tracer != nil {
+		tracingMiddleware = middleware.TracingMiddleware(a.tracer, routes)
+	}
+
+	// Apply global middlewares
+	a.handler = middleware.Chain(
+		mux,
+		tracingMiddleware,
+		rateLimit(middleware.RateLimitConfig{
+			RequestsPerMinute: cfg.RateLimit.General,
+			ExemptIPs:         cfg.RateLimit.ExemptIPs,
+			ExemptPaths:       cfg.RateLimit.ExemptPaths,
+		}),
+		// Outside RecoverMiddleware, to log the panics it turns into 500s
+		middleware.ErrorLogMiddleware(errorLog),
+		middleware.RecoverMiddleware,
+		middleware.ClientIPMiddleware(cfg.RateLimit.TrustedProxies),
+		middleware.LoggingMiddleware,
+		loadShedding,
+		compression,
+		securityHeaders,
+		middleware.CORSMiddleware,
+		middleware.SameOriginMiddleware(webOrigins),
+		// Outside MethodsMiddleware, which must see the method overridden by forms without JavaScript
+		middleware.MethodOverrideMiddleware,
+		middleware.MethodsMiddleware(routes),
+	)
+
+	built = true
+	return a, nil
+}
+
+// Handler returns the HTTP handler serving every route of the app
+func (a *App) Handler() http.Handler {
+	return a.handler
+}
+
+// Run serves the app on the configured address until ctx is cancelled; see Serve
+func (a *App) Run(ctx context.Context) error {
+	ln, err := net.Listen("tcp", a.addr)
+	if err != nil {
+		a.Close()
+		return err
+	}
+	return a.Serve(ctx, ln)
+}
+
+// Serve serves the app on ln, e.g. a listener inherited through systemd socket activation,
+// and the gRPC API on its own address when enabled, and runs the background jobs until ctx
+// is cancelled. It then stops accepting requests,
+// gives the in-flight ones up to the shutdown timeout to finish, stops the jobs and closes
+// the app.
+func (a *App) Serve(ctx context.Context, ln net.Listener) error {
+	servers := []server{&http.Server{Handler: a.handler}}
+	listeners := []net.Listener{ln}
+	if a.grpcServer != nil {
+		grpcLn, err := net.Listen("tcp", a.grpcAddr)
+		if err != nil {
+			ln.Close()
+			a.Close()
+			return err
+		}
+		servers = append(servers, gracefulGRPCServer{a.grpcServer})
+		listeners = append(listeners, grpcLn)
+	}
+
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+	a.StartJobs(jobsCtx)
+
+	served := make(chan error, len(servers))
+	for i, srv := range servers {
+		go func() {
+			served <- srv.Serve(listeners[i])
+		}()
+	}
+	log.Printf("Server listening on %s", ln.Addr())
+	if len(listeners) > 1 {
+		log.Printf("gRPC API listening on %s", listeners[1].Addr())
+	}
+
+	// Once a server fails or ctx is cancelled, every server stops
+	pending := len(servers)
+	var err error
+	select {
+	case err = <-served:
+		pending--
+	case <-ctx.Done():
+		log.Println("Shutting down: waiting for in-flight requests")
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), a.shutdownTimeout)
+	for _, srv := range servers {
+		if shutdownErr := srv.Shutdown(shutdownCtx); shutdownErr != nil {
+			srv.Close()
+			if err == nil {
+				err = shutdownErr
+			}
+		}
+	}
+	cancel()
+	for ; pending > 0; pending-- {
+		<-served
+	}
+
+	stopJobs()
+	a.running.Wait()
+	if closeErr := a.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// server is a server Serve runs and shuts down: the HTTP server, and the gRPC one when enabled
+type server interface {
+	Serve(ln net.Listener) error
+	Shutdown(ctx context.Context) error
+	Close() error
+}
+
+// gracefulGRPCServer shuts a gRPC server down like an HTTP server
+type gracefulGRPCServer struct {
+	*grpcgo.Server
+}
+
+// Shutdown stops accepting calls and waits for the in-flight ones until ctx is done, when it
+// cancels them
+func (s gracefulGRPCServer) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.Stop()
+		return ctx.Err()
+	}
+}
+
+// Close cancels the in-flight calls and closes the connections
+func (s gracefulGRPCServer) Close() error {
+	s.Stop()
+	return nil
+}
+
+// StartJobs starts the enabled background jobs; they stop when ctx is cancelled
+func (a *App) StartJobs(ctx context.Context) {
+	for _, j := range a.jobs {
+		a.running.Add(1)
+		go func(j job) {
+			defer a.running.Done()
+			scheduler.Every(ctx, j.name, j.interval, j.run)
+		}(j)
+	}
+}
+
+// Close flushes the pending webhook deliveries, stops the rate limiters and closes the database.
+// Jobs must have been stopped first. Only the first call closes; the later ones return its error.
+func (a *App) Close() error {
+	a.closeOnce.Do(func() {
+		for _, closer := range a.closers {
+			closer()
+		}
+		a.closeErr = a.db.Close()
+	})
+	return a.closeErr
+}
+
+// addJob registers a background job; jobs without a positive interval are skipped, since
+// the scheduler can't tick them. Each run is the root span of a trace when tracing is enabled.
+func (a *App) addJob(name string, interval time.Duration, run func(ctx context.Context) error) {
+	if interval <= 0 {
+		log.Printf("Background job %s disabled: interval %s", name, interval)
+		return
+	}
+	if a.tracer != nil {
+		untraced := run
+		run = func(ctx context.Context) error {
+			ctx, span := a.tracer.Tracer(tracing.ScopeName).Start(ctx, "job "+name)
+			err := untraced(ctx)
+			tracing.End(span, err)
+			return err
+		}
+	}
+	a.jobs = append(a.jobs, job{name: name, interval: interval, run: run})
+}
@@ -0,0 +1,73 @@
+package app_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/app"
+)
+
+func TestApp_ServeShutsDownWhenCancelled(t *testing.T) {
+	todo, err := app.New(newTestConfig(t))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- todo.Serve(ctx, ln)
+	}()
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/api/tasks")
+	if err != nil {
+		t.Fatalf("GET /api/tasks error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("GET /api/tasks without a token = %d, want 401", resp.StatusCode)
+	}
+
+	cancel()
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("Serve() error: %v, want a clean shutdown", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve() didn't return after the context was cancelled")
+	}
+
+	// The app is closed: the listener no longer accepts connections
+	if _, err := http.Get("http://" + ln.Addr().String() + "/api/tasks"); err == nil {
+		t.Error("GET /api/tasks after shutdown succeeded, want a connection error")
+	}
+	if err := todo.Close(); err != nil {
+		t.Errorf("Close() after Serve() error: %v, want the first close's result", err)
+	}
+}
+
+func TestApp_RunFailsOnBusyAddress(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+	defer ln.Close()
+
+	cfg := newTestConfig(t)
+	cfg.Addr = ln.Addr().String()
+	todo, err := app.New(cfg)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	if err := todo.Run(context.Background()); err == nil {
+		t.Error("Run() on a busy address succeeded, want an error")
+	}
+}
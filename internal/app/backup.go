@@ -0,0 +1,29 @@
+package app
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/backup"
+)
+
+// backupFileDirs are the upload directories saved with the database, by their name in the
+// archive of a backup
+func backupFileDirs(cfg Config) []backup.FileDir {
+	return []backup.FileDir{
+		{Name: "uploads", Path: cfg.UploadsDir},
+		{Name: "attachments", Path: cfg.AttachmentsDir},
+	}
+}
+
+// Backup writes a backup of the database and the uploads into the backup directory; the
+// server may be running
+func (a *App) Backup(ctx context.Context) (*application.Backup, error) {
+	return a.backups.Create(ctx)
+}
+
+// Restore replaces the database and, unless filesArchive is empty, the uploads of cfg with a
+// backup, once it is validated. The server must be stopped.
+func Restore(ctx context.Context, cfg Config, databaseBackup, filesArchive string) error {
+	return backup.Restore(ctx, databaseBackup, filesArchive, cfg.DatabasePath, backupFileDirs(cfg))
+}
@@ -0,0 +1,524 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/captcha"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/database"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/http/handler"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/http/middleware"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/linkcheck"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/mail"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/passkey"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/tracing"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/webhook"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// Config holds the settings of the app. LoadConfig reads them from environment variables;
+// tests build one from LoadConfig with an empty environment and override what they need.
+type Config struct {
+	// JWTKeys signs and validates auth tokens. It isn't read by LoadConfig: the caller owns
+	// the key set so it can reload it.
+	JWTKeys *service.JWTKeySet
+
+	Addr            string        // Address Run listens on
+	GRPCAddr        string        // Address the gRPC API listens on; empty disables it
+	PublicURL       string        // URL the app is reached at, used in the links it hands out
+	TrustedOrigins  []string      // Origins allowed to send mutations to /web/ besides the one of PublicURL
+	ShutdownTimeout time.Duration // How long in-flight requests get to finish once Run is cancelled
+	Env             string        // Deployment environment; only development ones may send cookies over plain HTTP
+
+	DatabasePath       string // SQLite file, or ":memory:" for a throwaway database
+	DBBusyTimeout      time.Duration
+	DBMaxOpenConns     int                // Size of the connection pool
+	DBQueryHook        database.QueryHook // Called with every statement run, to audit them in tests; not read by LoadConfig
+	QueryTimeout       time.Duration
+	ReportQueryTimeout time.Duration // The report scans a whole unit, so it gets a longer timeout
+
+	UploadsDir     string         // Task images, served to the users who can access their task
+	AttachmentsDir string         // Task attachments, kept outside the public uploads directory
+	BackupDir      string         // Backups of the database and the uploads
+	Location       *time.Location // Timezone of due dates sent without one; nil means the local timezone
+
+	RateLimit RateLimitConfig
+	Timeouts  TimeoutConfig
+	Cache     CacheConfig
+
+	TasksPageCacheTTL time.Duration // 0 disables pre-rendering the tasks page
+	TemplatesDir      string        // Page templates read from disk and reloaded on change; empty uses the ones embedded in the binary
+
+	Webhook webhook.Config // An empty URL disables the webhook
+	SMTP    mail.Config    // An empty host disables reminder emails
+	Tracing tracing.Config // An empty endpoint disables tracing
+
+	Sessions              usecases.SessionDurations
+	Cookies               handler.CookieConfig // Attributes of the auth, theme and language cookies
+	LoginFailureDelay     time.Duration        // Minimum duration of a failed login
+	PasswordHashAlgorithm string
+	PasswordHashParams    service.PasswordHashParams
+	PasswordPolicy        service.PasswordPolicy // Rules of the passwords chosen on registration or changed
+	WebAuthn              passkey.Config
+	TOTPIssuer            string
+	Captcha               captcha.Config // An empty provider never asks for a CAPTCHA
+	BruteForce            BruteForceConfig
+
+	ExportQuotaLimit           int
+	ExportQuotaWindow          time.Duration
+	ExportAsyncThreshold       int // Lists of up to this many tasks are exported in the request
+	StorageQuotaBytes          int64
+	StorageGlobalQuotaBytes    int64
+	CreationLimits             usecases.CreationLimits // Tasks and images each user may create per day
+	OverdueReportIncludeTitles bool
+	TaskRevisionsKeep          int // Previous versions kept of each task; 0 keeps none
+
+	LinkCheck     LinkCheckConfig
+	Reminders     JobConfig
+	Digest        JobConfig // Weekly summary emails, sent only when SMTP is configured
+	Overdue       JobConfig
+	ExportJobs    ExportJobsConfig
+	Queue         QueueConfig
+	OrphanCleanup OrphanCleanupConfig
+	Undo          UndoConfig
+	AccountPurge  AccountPurgeConfig
+	Idempotency   IdempotencyConfig
+
+	LoadShedEnabled bool
+	LoadShed        middleware.LoadShedConfig // QueueDepth is set to the busy database connections
+	CompressEnabled bool
+	Compress        middleware.CompressConfig
+}
+
+// RateLimitConfig holds the per-client request limits
+type RateLimitConfig struct {
+	General         int // Requests per window on every route
+	Auth            int // Requests per window on the login and register routes
+	UserSearch      int // Requests per window on the user search, so it can't enumerate accounts
+	Window          time.Duration
+	TrustedProxies  []string
+	ExemptIPs       []string      // Client IPs and CIDR ranges the general limit skips, like internal monitoring
+	ExemptPaths     []string      // Path prefixes the general limit skips, like the health check
+	MaxClients      int           // Clients each limiter tracks at once, the least recently seen evicted beyond
+	CleanupInterval time.Duration // Time between sweeps of the idle clients
+}
+
+// TimeoutConfig holds how long each group of routes has to answer before getting 503; 0
+// disables the timeout of a group
+type TimeoutConfig struct {
+	Default time.Duration // JSON API, pages and HTMX fragments
+	Export  time.Duration // PDF exports, their downloads and the reports
+	Upload  time.Duration // Images, attachments and imports, read from slow clients
+}
+
+// CacheConfig holds the settings of the task list cache
+type CacheConfig struct {
+	Enabled       bool
+	TTL           time.Duration
+	MaxEntries    int
+	StatsInterval time.Duration // How often the hit rate is logged; 0 disables it
+}
+
+// JobConfig holds the settings of a background job processing batches
+type JobConfig struct {
+	Enabled   bool
+	Interval  time.Duration
+	BatchSize int
+}
+
+// LinkCheckConfig holds the settings of the background link checker
+type LinkCheckConfig struct {
+	JobConfig
+	Prober linkcheck.Config
+}
+
+// UndoConfig holds how long the deletions and completions made on the web can be undone, and
+// the settings of the job making them final afterwards
+type UndoConfig struct {
+	JobConfig
+	Window time.Duration
+}
+
+// AccountPurgeConfig holds how long accounts their users asked to delete are kept, and the
+// settings of the job purging them afterwards
+type AccountPurgeConfig struct {
+	JobConfig
+	Grace time.Duration
+}
+
+// BruteForceConfig holds when the login asks for a CAPTCHA, once one is configured
+type BruteForceConfig struct {
+	Threshold int           // Failed logins from an IP or for an email before a CAPTCHA is required
+	Window    time.Duration // Failures are forgotten once there were none for this long
+}
+
+// IdempotencyConfig holds how long the responses of the POSTs sent with an Idempotency-Key are
+// replayed to their retries, and how often the expired ones are deleted
+type IdempotencyConfig struct {
+	TTL      time.Duration // 0 disables the Idempotency-Key support
+	Interval time.Duration
+}
+
+// ExportJobsConfig holds the settings of the background export worker
+type ExportJobsConfig struct {
+	Enabled    bool
+	Interval   time.Duration
+	StaleAfter time.Duration // Running jobs older than this are failed
+	TTL        time.Duration // Finished jobs are deleted after this
+}
+
+// QueueConfig holds the settings of the workers running the queued background jobs
+type QueueConfig struct {
+	Enabled     bool
+	Workers     int
+	Interval    time.Duration // Idle workers look for due jobs this often
+	MaxAttempts int
+	Backoff     time.Duration // Wait before the first retry, doubled on each later one
+	StaleAfter  time.Duration // Running jobs older than this are claimed again
+	TTL         time.Duration // Done jobs are deleted after this
+}
+
+// OrphanCleanupConfig holds the settings of the background cleanup of orphaned uploads
+type OrphanCleanupConfig struct {
+	Enabled     bool
+	Interval    time.Duration
+	GracePeriod time.Duration // Unreferenced files younger than this are kept
+	DryRun      bool          // Only log the orphans, without removing them
+}
+
+// LoadConfig reads the app settings from environment variables through getenv, usually
+// os.Getenv, using the documented defaults for the unset ones
+func LoadConfig(getenv func(key string) string) (Config, error) {
+	e := env(getenv)
+
+	// The load shedding backlog defaults to most of the pool being busy, since the number of
+	// connections in use can't go past the pool size
+	dbMaxOpenConns := e.Int("DB_MAX_OPEN_CONNS", database.DefaultMaxOpenConns)
+
+	// Cookies are Secure everywhere but in development, where the app is served over plain HTTP
+	// and the page templates are reloaded from the source tree as they are edited
+	appEnv := e.String("ENV", "development")
+	templatesDir := ""
+	if isDevEnv(appEnv) {
+		templatesDir = "internal/infrastructure/templates"
+	}
+	sameSite, err := handler.ParseSameSite(e.String("COOKIE_SAMESITE", "lax"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid COOKIE_SAMESITE: %w", err)
+	}
+
+	cfg := Config{
+		Addr:               e.String("ADDR", ":8080"),
+		GRPCAddr:           e.String("GRPC_ADDR", ""),
+		PublicURL:          e.String("PUBLIC_URL", "http://localhost:8080"),
+		TrustedOrigins:     e.StringSlice("TRUSTED_ORIGINS", []string{}),
+		ShutdownTimeout:    time.Duration(e.Int("SHUTDOWN_TIMEOUT", 10)) * time.Second,
+		Env:                appEnv,
+		DatabasePath:       "todo.db",
+		DBBusyTimeout:      time.Duration(e.Int("DB_BUSY_TIMEOUT_MS", 5000)) * time.Millisecond,
+		DBMaxOpenConns:     dbMaxOpenConns,
+		QueryTimeout:       time.Duration(e.Int("DB_QUERY_TIMEOUT_MS", 10000)) * time.Millisecond,
+		ReportQueryTimeout: time.Duration(e.Int("DB_REPORT_QUERY_TIMEOUT_MS", 30000)) * time.Millisecond,
+		UploadsDir:         "uploads/images",
+		AttachmentsDir:     "attachments",
+		BackupDir:          e.String("BACKUP_DIR", "backups"),
+		RateLimit: RateLimitConfig{
+			General:         e.Int("RATE_LIMIT_GENERAL", 100),
+			Auth:            e.Int("RATE_LIMIT_AUTH", 5),
+			UserSearch:      e.Int("RATE_LIMIT_USER_SEARCH", 30),
+			Window:          time.Duration(e.Int("RATE_LIMIT_WINDOW", 60)) * time.Second,
+			TrustedProxies:  e.StringSlice("TRUSTED_PROXIES", []string{}),
+			ExemptIPs:       e.StringSlice("RATE_LIMIT_EXEMPT_IPS", []string{}),
+			ExemptPaths:     e.StringSlice("RATE_LIMIT_EXEMPT_PATHS", []string{"/health"}),
+			MaxClients:      e.Int("RATE_LIMIT_MAX_CLIENTS", 10000),
+			CleanupInterval: time.Duration(e.Int("RATE_LIMIT_CLEANUP_INTERVAL", 300)) * time.Second,
+		},
+		Timeouts: TimeoutConfig{
+			Default: time.Duration(e.Int("REQUEST_TIMEOUT", 15)) * time.Second,
+			Export:  time.Duration(e.Int("EXPORT_REQUEST_TIMEOUT", 120)) * time.Second,
+			Upload:  time.Duration(e.Int("UPLOAD_REQUEST_TIMEOUT", 120)) * time.Second,
+		},
+		Cache: CacheConfig{
+			Enabled:       e.Bool("CACHE_ENABLED", false),
+			TTL:           time.Duration(e.Int("CACHE_TTL", 60)) * time.Second,
+			MaxEntries:    e.Int("CACHE_MAX_ENTRIES", 1000),
+			StatsInterval: time.Duration(e.Int("CACHE_STATS_INTERVAL", 5)) * time.Minute,
+		},
+		TasksPageCacheTTL: time.Duration(e.Int("TASKS_PAGE_CACHE_TTL", 30)) * time.Second,
+		TemplatesDir:      e.String("TEMPLATES_DIR", templatesDir),
+		Webhook: webhook.Config{
+			URL:       getenv("WEBHOOK_URL"),
+			Secret:    getenv("WEBHOOK_SECRET"),
+			Timeout:   time.Duration(e.Int("WEBHOOK_TIMEOUT", 5)) * time.Second,
+			QueueSize: e.Int("WEBHOOK_QUEUE_SIZE", 100),
+		},
+		SMTP: mail.Config{
+			Host:     getenv("SMTP_HOST"),
+			Port:     e.Int("SMTP_PORT", 587),
+			Username: getenv("SMTP_USERNAME"),
+			Password: getenv("SMTP_PASSWORD"),
+			From:     getenv("SMTP_FROM"),
+			Timeout:  time.Duration(e.Int("SMTP_TIMEOUT", 10)) * time.Second,
+		},
+		// The standard variables of the OpenTelemetry SDKs
+		Tracing: tracing.Config{
+			Endpoint:    getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+			ServiceName: e.String("OTEL_SERVICE_NAME", "todo"),
+			SampleRatio: e.Float("OTEL_TRACES_SAMPLER_ARG", 1),
+			Timeout:     time.Duration(e.Int("OTEL_EXPORTER_OTLP_TIMEOUT", 10000)) * time.Millisecond,
+		},
+		LoginFailureDelay: time.Duration(e.Int("LOGIN_FAILURE_DELAY_MS", 300)) * time.Millisecond,
+		Cookies: handler.CookieConfig{
+			Name:       e.String("COOKIE_NAME", handler.AuthCookieName),
+			Secure:     e.Bool("COOKIE_SECURE", !isDevEnv(appEnv)),
+			SameSite:   sameSite,
+			Domain:     getenv("COOKIE_DOMAIN"),
+			HostPrefix: e.Bool("COOKIE_HOST_PREFIX", false),
+		},
+		Sessions: usecases.SessionDurations{
+			Default:    time.Duration(e.Int("SESSION_DURATION_HOURS", 24)) * time.Hour,
+			RememberMe: time.Duration(e.Int("SESSION_REMEMBER_ME_DAYS", 30)) * 24 * time.Hour,
+		},
+		// Existing hashes of another algorithm or cost are upgraded on login
+		PasswordHashAlgorithm: e.String("PASSWORD_HASH_ALGORITHM", service.AlgorithmBcrypt),
+		PasswordHashParams: service.PasswordHashParams{
+			BcryptCost:        e.Int("BCRYPT_COST", service.DefaultPasswordHashParams.BcryptCost),
+			Argon2MemoryKiB:   uint32(e.Int("ARGON2_MEMORY_KB", int(service.DefaultPasswordHashParams.Argon2MemoryKiB))),
+			Argon2Iterations:  uint32(e.Int("ARGON2_ITERATIONS", int(service.DefaultPasswordHashParams.Argon2Iterations))),
+			Argon2Parallelism: uint8(e.Int("ARGON2_PARALLELISM", int(service.DefaultPasswordHashParams.Argon2Parallelism))),
+		},
+		PasswordPolicy: service.PasswordPolicy{
+			MinLength:      e.Int("PASSWORD_MIN_LENGTH", service.DefaultPasswordPolicy.MinLength),
+			RequireUpper:   e.Bool("PASSWORD_REQUIRE_UPPERCASE", false),
+			RequireLower:   e.Bool("PASSWORD_REQUIRE_LOWERCASE", false),
+			RequireDigit:   e.Bool("PASSWORD_REQUIRE_DIGIT", false),
+			RequireSymbol:  e.Bool("PASSWORD_REQUIRE_SYMBOL", false),
+			RejectCommon:   e.Bool("PASSWORD_REJECT_COMMON", false),
+			RejectPersonal: e.Bool("PASSWORD_REJECT_PERSONAL", false),
+		},
+		// Passkeys are bound to the relying party domain, so WEBAUTHN_RP_ID and
+		// WEBAUTHN_RP_ORIGINS must match the public URL of the app
+		WebAuthn: passkey.Config{
+			RPID:          e.String("WEBAUTHN_RP_ID", "localhost"),
+			RPDisplayName: e.String("WEBAUTHN_RP_NAME", "Todo App"),
+			RPOrigins:     e.StringSlice("WEBAUTHN_RP_ORIGINS", []string{"http://localhost:8080"}),
+			Timeout:       time.Duration(e.Int("WEBAUTHN_TIMEOUT", 300)) * time.Second,
+		},
+		Captcha: captcha.Config{
+			Provider: getenv("CAPTCHA_PROVIDER"),
+			SiteKey:  getenv("CAPTCHA_SITE_KEY"),
+			Secret:   getenv("CAPTCHA_SECRET"),
+			Timeout:  time.Duration(e.Int("CAPTCHA_TIMEOUT", 5)) * time.Second,
+		},
+		BruteForce: BruteForceConfig{
+			Threshold: e.Int("CAPTCHA_THRESHOLD", 5),
+			Window:    time.Duration(e.Int("CAPTCHA_WINDOW", 15)) * time.Minute,
+		},
+		// TOTP_ISSUER names the app in authenticator apps
+		TOTPIssuer:                 e.String("TOTP_ISSUER", "Todo App"),
+		ExportQuotaLimit:           e.Int("EXPORT_QUOTA_LIMIT", 5),
+		ExportQuotaWindow:          time.Duration(e.Int("EXPORT_QUOTA_WINDOW", 60)) * time.Minute,
+		ExportAsyncThreshold:       e.Int("EXPORT_ASYNC_THRESHOLD", 100),
+		StorageQuotaBytes:          int64(e.Int("STORAGE_QUOTA_MB", 100)) << 20,
+		StorageGlobalQuotaBytes:    int64(e.Int("STORAGE_GLOBAL_QUOTA_MB", 0)) << 20,
+		OverdueReportIncludeTitles: e.Bool("OVERDUE_REPORT_INCLUDE_TITLES", false),
+		TaskRevisionsKeep:          e.Int("TASK_REVISIONS_KEEP", 50),
+		CreationLimits: usecases.CreationLimits{
+			Tasks:  e.Int("TASK_QUOTA_PER_DAY", 1000),
+			Images: e.Int("IMAGE_QUOTA_PER_DAY", 100),
+		},
+		LinkCheck: LinkCheckConfig{
+			JobConfig: JobConfig{
+				Enabled:   e.Bool("LINK_CHECK_ENABLED", true),
+				Interval:  time.Duration(e.Int("LINK_CHECK_INTERVAL", 60)) * time.Minute,
+				BatchSize: e.Int("LINK_CHECK_MAX_PER_RUN", 100),
+			},
+			Prober: linkcheck.Config{
+				Timeout:      time.Duration(e.Int("LINK_CHECK_TIMEOUT", 10)) * time.Second,
+				HostInterval: time.Duration(e.Int("LINK_CHECK_HOST_INTERVAL", 2)) * time.Second,
+			},
+		},
+		Reminders: JobConfig{
+			Enabled:   e.Bool("REMINDERS_ENABLED", true),
+			Interval:  time.Duration(e.Int("REMINDER_CHECK_INTERVAL", 30)) * time.Second,
+			BatchSize: e.Int("REMINDER_BATCH_SIZE", 100),
+		},
+		Digest: JobConfig{
+			Enabled:   e.Bool("DIGEST_ENABLED", true),
+			Interval:  time.Duration(e.Int("DIGEST_CHECK_INTERVAL", 5)) * time.Minute,
+			BatchSize: e.Int("DIGEST_BATCH_SIZE", 50),
+		},
+		Overdue: JobConfig{
+			Enabled:   e.Bool("OVERDUE_ENABLED", true),
+			Interval:  time.Duration(e.Int("OVERDUE_CHECK_INTERVAL", 5)) * time.Minute,
+			BatchSize: e.Int("OVERDUE_BATCH_SIZE", 100),
+		},
+		ExportJobs: ExportJobsConfig{
+			Enabled:    e.Bool("EXPORT_JOBS_ENABLED", true),
+			Interval:   time.Duration(e.Int("EXPORT_JOB_INTERVAL", 2)) * time.Second,
+			StaleAfter: time.Duration(e.Int("EXPORT_JOB_STALE_AFTER", 10)) * time.Minute,
+			TTL:        time.Duration(e.Int("EXPORT_JOB_TTL", 24)) * time.Hour,
+		},
+		Queue: QueueConfig{
+			Enabled:     e.Bool("JOBS_ENABLED", true),
+			Workers:     e.Int("JOBS_WORKERS", 2),
+			Interval:    time.Duration(e.Int("JOBS_INTERVAL", 1)) * time.Second,
+			MaxAttempts: e.Int("JOBS_MAX_ATTEMPTS", 5),
+			Backoff:     time.Duration(e.Int("JOBS_BACKOFF", 30)) * time.Second,
+			StaleAfter:  time.Duration(e.Int("JOBS_STALE_AFTER", 10)) * time.Minute,
+			TTL:         time.Duration(e.Int("JOBS_TTL", 24)) * time.Hour,
+		},
+		Undo: UndoConfig{
+			JobConfig: JobConfig{
+				Enabled:   e.Bool("UNDO_ENABLED", true),
+				Interval:  time.Duration(e.Int("UNDO_FINALIZE_INTERVAL", 5)) * time.Second,
+				BatchSize: e.Int("UNDO_BATCH_SIZE", 100),
+			},
+			Window: time.Duration(e.Int("UNDO_WINDOW", 10)) * time.Second,
+		},
+		AccountPurge: AccountPurgeConfig{
+			JobConfig: JobConfig{
+				Enabled:   e.Bool("ACCOUNT_PURGE_ENABLED", true),
+				Interval:  time.Duration(e.Int("ACCOUNT_PURGE_INTERVAL", 60)) * time.Minute,
+				BatchSize: e.Int("ACCOUNT_PURGE_BATCH_SIZE", 10),
+			},
+			Grace: time.Duration(e.Int("ACCOUNT_DELETION_GRACE_DAYS", 7)) * 24 * time.Hour,
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:      time.Duration(e.Int("IDEMPOTENCY_TTL", 24)) * time.Hour,
+			Interval: time.Duration(e.Int("IDEMPOTENCY_CLEANUP_INTERVAL", 60)) * time.Minute,
+		},
+		OrphanCleanup: OrphanCleanupConfig{
+			Enabled:     e.Bool("ORPHAN_CLEANUP_ENABLED", true),
+			Interval:    time.Duration(e.Int("ORPHAN_CLEANUP_INTERVAL", 6)) * time.Hour,
+			GracePeriod: time.Duration(e.Int("ORPHAN_CLEANUP_GRACE_PERIOD", 24)) * time.Hour,
+			DryRun:      e.Bool("ORPHAN_CLEANUP_DRY_RUN", false),
+		},
+		LoadShedEnabled: e.Bool("LOAD_SHED_ENABLED", true),
+		LoadShed: middleware.LoadShedConfig{
+			MaxInFlight:      e.Int("LOAD_SHED_MAX_IN_FLIGHT", 200),
+			MaxGoroutines:    e.Int("LOAD_SHED_MAX_GOROUTINES", 5000),
+			LatencyThreshold: time.Duration(e.Int("LOAD_SHED_LATENCY_MS", 2000)) * time.Millisecond,
+			MaxQueueDepth:    e.Int("LOAD_SHED_MAX_DB_IN_USE", dbMaxOpenConns*3/4),
+			RetryAfter:       time.Duration(e.Int("LOAD_SHED_RETRY_AFTER", 5)) * time.Second,
+		},
+		CompressEnabled: e.Bool("COMPRESS_ENABLED", true),
+		Compress: middleware.CompressConfig{
+			MinSize: e.Int("COMPRESS_MIN_SIZE", 1024),
+			Level:   e.Int("COMPRESS_LEVEL", 0),
+		},
+	}
+
+	if tz := getenv("APP_TIMEZONE"); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid APP_TIMEZONE %q: %w", tz, err)
+		}
+		cfg.Location = loc
+	}
+	if !cfg.Cookies.Secure && !isDevEnv(cfg.Env) {
+		return Config{}, fmt.Errorf("COOKIE_SECURE can only be disabled in development, not with ENV=%s", cfg.Env)
+	}
+	if err := cfg.Cookies.Validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid cookie settings: %w", err)
+	}
+	if cfg.DBMaxOpenConns < 1 {
+		return Config{}, errors.New("DB_MAX_OPEN_CONNS must be at least 1")
+	}
+	if cfg.PasswordPolicy.MinLength < 1 {
+		return Config{}, errors.New("PASSWORD_MIN_LENGTH must be at least 1")
+	}
+	if cfg.Queue.MaxAttempts < 1 {
+		return Config{}, errors.New("JOBS_MAX_ATTEMPTS must be at least 1")
+	}
+	if cfg.Queue.Enabled && cfg.Queue.Workers < 1 {
+		return Config{}, errors.New("JOBS_WORKERS must be at least 1 while JOBS_ENABLED is set")
+	}
+	if cfg.SMTP.Host != "" && cfg.SMTP.From == "" {
+		return Config{}, errors.New("SMTP_FROM must be set when SMTP_HOST is configured")
+	}
+	if cfg.Captcha.Provider != "" {
+		if err := cfg.Captcha.Validate(); err != nil {
+			return Config{}, fmt.Errorf("invalid CAPTCHA settings: %w", err)
+		}
+		if cfg.BruteForce.Threshold < 1 {
+			return Config{}, errors.New("CAPTCHA_THRESHOLD must be at least 1")
+		}
+	}
+
+	return cfg, nil
+}
+
+// isDevEnv reports whether ENV names a development environment, served over plain HTTP
+func isDevEnv(name string) bool {
+	switch strings.ToLower(name) {
+	case "development", "dev", "local", "test":
+		return true
+	}
+	return false
+}
+
+// env reads settings from environment variables, falling back to a default when a
+// variable is unset or invalid
+type env func(key string) string
+
+// String returns the variable as is
+func (e env) String(key, defaultValue string) string {
+	if value := e(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// Int returns the variable as an int
+func (e env) Int(key string, defaultValue int) int {
+	if value := e(key); value != "" {
+		if intVal, err := strconv.Atoi(value); err == nil {
+			return intVal
+		}
+	}
+	return defaultValue
+}
+
+// Float returns the variable as a float64
+func (e env) Float(key string, defaultValue float64) float64 {
+	if value := e(key); value != "" {
+		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatVal
+		}
+	}
+	return defaultValue
+}
+
+// Bool returns the variable as a bool
+func (e env) Bool(key string, defaultValue bool) bool {
+	if value := e(key); value != "" {
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			return boolVal
+		}
+	}
+	return defaultValue
+}
+
+// StringSlice returns the variable as comma-separated values
+func (e env) StringSlice(key string, defaultValue []string) []string {
+	if value := e(key); value != "" {
+		// Split by comma and trim whitespace
+		parts := strings.Split(value, ",")
+		result := make([]string, 0, len(parts))
+		for _, part := range parts {
+			trimmed := strings.TrimSpace(part)
+			if trimmed != "" {
+				result = append(result, trimmed)
+			}
+		}
+		if len(result) > 0 {
+			return result
+		}
+	}
+	return defaultValue
+}
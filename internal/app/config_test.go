@@ -0,0 +1,99 @@
+package app_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/app"
+)
+
+func TestLoadConfig_Cookies(t *testing.T) {
+	tests := []struct {
+		name         string
+		env          map[string]string
+		wantErr      bool
+		wantSecure   bool
+		wantSameSite http.SameSite
+	}{
+		{"development by default", nil, false, false, http.SameSiteLaxMode},
+		{"production is secure", map[string]string{"ENV": "production"}, false, true, http.SameSiteLaxMode},
+		{"staging is secure too", map[string]string{"ENV": "staging"}, false, true, http.SameSiteLaxMode},
+		{"secure in development", map[string]string{"COOKIE_SECURE": "true", "COOKIE_SAMESITE": "strict"}, false, true, http.SameSiteStrictMode},
+		{"insecure in production", map[string]string{"ENV": "production", "COOKIE_SECURE": "false"}, true, false, 0},
+		{"unknown SameSite", map[string]string{"COOKIE_SAMESITE": "sometimes"}, true, false, 0},
+		{"host prefix over HTTP", map[string]string{"COOKIE_HOST_PREFIX": "true"}, true, false, 0},
+		{"host prefix with a domain", map[string]string{"ENV": "production", "COOKIE_HOST_PREFIX": "true", "COOKIE_DOMAIN": "example.com"}, true, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := app.LoadConfig(func(key string) string { return tt.env[key] })
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (cfg.Cookies.Secure != tt.wantSecure || cfg.Cookies.SameSite != tt.wantSameSite) {
+				t.Errorf("cookies = %+v, want Secure %v and SameSite %v", cfg.Cookies, tt.wantSecure, tt.wantSameSite)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_TemplatesDir(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"reloaded from the source tree in development", nil, "internal/infrastructure/templates"},
+		{"embedded in production", map[string]string{"ENV": "production"}, ""},
+		{"chosen directory", map[string]string{"ENV": "production", "TEMPLATES_DIR": "/srv/todo/templates"}, "/srv/todo/templates"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := app.LoadConfig(func(key string) string { return tt.env[key] })
+			if err != nil {
+				t.Fatalf("LoadConfig() error: %v", err)
+			}
+			if cfg.TemplatesDir != tt.want {
+				t.Errorf("TemplatesDir = %q, want %q", cfg.TemplatesDir, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Captcha(t *testing.T) {
+	keys := map[string]string{"CAPTCHA_SITE_KEY": "site", "CAPTCHA_SECRET": "secret"}
+	with := func(env map[string]string) map[string]string {
+		merged := map[string]string{}
+		for k, v := range keys {
+			merged[k] = v
+		}
+		for k, v := range env {
+			merged[k] = v
+		}
+		return merged
+	}
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr bool
+	}{
+		{"disabled by default", nil, false},
+		{"hcaptcha", with(map[string]string{"CAPTCHA_PROVIDER": "hcaptcha"}), false},
+		{"turnstile", with(map[string]string{"CAPTCHA_PROVIDER": "turnstile", "CAPTCHA_THRESHOLD": "3"}), false},
+		{"unknown provider", with(map[string]string{"CAPTCHA_PROVIDER": "recaptcha"}), true},
+		{"missing secret", map[string]string{"CAPTCHA_PROVIDER": "hcaptcha", "CAPTCHA_SITE_KEY": "site"}, true},
+		{"no threshold", with(map[string]string{"CAPTCHA_PROVIDER": "hcaptcha", "CAPTCHA_THRESHOLD": "0"}), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := app.LoadConfig(func(key string) string { return tt.env[key] })
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,911 @@
+package app_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/app"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// newTestConfig returns the settings of an app on an in-memory database, with the export
+// worker polling fast and every export handed to it
+func newTestConfig(t testing.TB) app.Config {
+	t.Helper()
+
+	cfg, err := app.LoadConfig(func(string) string { return "" })
+	if err != nil {
+		t.Fatalf("LoadConfig() error: %v", err)
+	}
+	cfg.JWTKeys = service.NewJWTKeySet("integration-test-secret")
+	cfg.DatabasePath = ":memory:"
+	cfg.UploadsDir = t.TempDir()
+	cfg.AttachmentsDir = t.TempDir()
+	cfg.PasswordHashParams.BcryptCost = 4
+	cfg.RateLimit.Auth = 100
+	cfg.TasksPageCacheTTL = 0
+	cfg.LinkCheck.Enabled = false
+	cfg.Reminders.Enabled = false
+	cfg.Overdue.Enabled = false
+	cfg.ExportAsyncThreshold = 0
+	cfg.ExportJobs.Interval = 20 * time.Millisecond
+	return cfg
+}
+
+// newTestServer starts the fully wired app and its jobs behind a test HTTP server
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return newTestServerWithConfig(t, newTestConfig(t))
+}
+
+// newTestServerWithConfig starts the app wired with cfg and its jobs behind a test HTTP server
+func newTestServerWithConfig(t testing.TB, cfg app.Config) *httptest.Server {
+	t.Helper()
+
+	// The server listens before the app is built, so its URL is the public one web mutations
+	// must come from
+	ts := httptest.NewUnstartedServer(nil)
+	cfg.PublicURL = "http://" + ts.Listener.Addr().String()
+	todo, err := app.New(cfg)
+	if err != nil {
+		ts.Close()
+		t.Fatalf("New() error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	todo.StartJobs(ctx)
+	ts.Config.Handler = todo.Handler()
+	ts.Start()
+	t.Cleanup(func() {
+		ts.Close()
+		cancel()
+		todo.Close()
+	})
+	return ts
+}
+
+// client sends requests to the test server as a user
+type client struct {
+	t     testing.TB
+	base  string
+	token string
+	http  *http.Client // nil uses http.DefaultClient
+}
+
+// do sends a request and returns the response with its body read
+func (c *client) do(method, path, contentType string, body io.Reader) (*http.Response, []byte) {
+	c.t.Helper()
+	return c.send(c.newRequest(method, path, contentType, body))
+}
+
+// newRequest creates a request to the test server, authenticated when the client has a token
+func (c *client) newRequest(method, path, contentType string, body io.Reader) *http.Request {
+	c.t.Helper()
+
+	req, err := http.NewRequest(method, c.base+path, body)
+	if err != nil {
+		c.t.Fatalf("NewRequest(%s %s) error: %v", method, path, err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	// Like a browser, which sends the origin of the page along with mutations
+	if method != http.MethodGet && method != http.MethodHead {
+		req.Header.Set("Origin", c.base)
+	}
+	return req
+}
+
+// send sends a request and returns the response with its body read
+func (c *client) send(req *http.Request) (*http.Response, []byte) {
+	c.t.Helper()
+
+	httpClient := c.http
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		c.t.Fatalf("%s %s error: %v", req.Method, req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.t.Fatalf("%s %s: reading body: %v", req.Method, req.URL.Path, err)
+	}
+	return resp, data
+}
+
+// json sends a JSON request, expects status want and decodes the response into out, if set
+func (c *client) json(method, path string, in any, want int, out any) *http.Response {
+	c.t.Helper()
+
+	body := []byte("{}")
+	if in != nil {
+		body, _ = json.Marshal(in)
+	}
+	resp, data := c.do(method, path, "application/json", bytes.NewReader(body))
+	if resp.StatusCode != want {
+		c.t.Fatalf("%s %s = %d, want %d: %s", method, path, resp.StatusCode, want, data)
+	}
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			c.t.Fatalf("%s %s: decoding %s: %v", method, path, data, err)
+		}
+	}
+	return resp
+}
+
+// form sends an HTMX form request and expects status want
+func (c *client) form(method, path string, values url.Values, want int) string {
+	c.t.Helper()
+
+	req := c.newRequest(method, path, "application/x-www-form-urlencoded", strings.NewReader(values.Encode()))
+	req.Header.Set("HX-Request", "true")
+	resp, data := c.send(req)
+	if resp.StatusCode != want {
+		c.t.Fatalf("%s %s = %d, want %d: %s", method, path, resp.StatusCode, want, data)
+	}
+	return string(data)
+}
+
+// signUp registers a user and logs them in
+func signUp(t testing.TB, base, name, email string) (*client, string) {
+	t.Helper()
+
+	anonymous := &client{t: t, base: base}
+	credentials := map[string]string{"name": name, "email": email, "password": "Senha123!forte"}
+
+	var user struct {
+		ID string `json:"id"`
+	}
+	anonymous.json("POST", "/api/auth/register", credentials, http.StatusCreated, &user)
+
+	var login struct {
+		Token string `json:"token"`
+	}
+	delete(credentials, "name")
+	anonymous.json("POST", "/api/auth/login", credentials, http.StatusOK, &login)
+	if login.Token == "" {
+		t.Fatalf("login of %s returned no token", email)
+	}
+
+	return &client{t: t, base: base, token: login.Token}, user.ID
+}
+
+func TestIntegration_RegisterShareCompleteExport(t *testing.T) {
+	ts := newTestServer(t)
+
+	ana, _ := signUp(t, ts.URL, "Ana Integração", "ana@integracao.test")
+	bia, biaID := signUp(t, ts.URL, "Bia Integração", "bia@integracao.test")
+
+	// Ana creates a task and invites Bia, found through the user search
+	var task struct {
+		ID      string
+		Title   string
+		OwnerID string
+	}
+	ana.json("POST", "/api/tasks", map[string]string{"title": "Relatório anual", "description": "Fechar o **balanço**"}, http.StatusCreated, &task)
+	if task.ID == "" || task.Title != "Relatório anual" {
+		t.Fatalf("created task = %+v", task)
+	}
+
+	var found []struct {
+		ID string `json:"id"`
+	}
+	ana.json("GET", "/api/users/search?q=bia@integracao", nil, http.StatusOK, &found)
+	if len(found) != 1 || found[0].ID != biaID {
+		t.Fatalf("user search = %+v, want Bia (%s)", found, biaID)
+	}
+	ana.form("POST", "/web/tasks/"+task.ID+"/share", url.Values{"share_with_user_id": {biaID}}, http.StatusOK)
+
+	// Bia only sees the task once she accepts the invitation
+	var shared []struct{ ID string }
+	bia.json("GET", "/api/tasks/shared", nil, http.StatusOK, &shared)
+	if len(shared) != 0 {
+		t.Fatalf("shared tasks before accepting = %+v, want none", shared)
+	}
+	var invitations []struct {
+		TaskID    string `json:"task_id"`
+		OwnerName string `json:"owner_name"`
+	}
+	bia.json("GET", "/api/invitations", nil, http.StatusOK, &invitations)
+	if len(invitations) != 1 || invitations[0].TaskID != task.ID || invitations[0].OwnerName != "Ana Integração" {
+		t.Fatalf("invitations = %+v, want Ana's task", invitations)
+	}
+	bia.json("POST", "/api/invitations/"+task.ID+"/accept", nil, http.StatusOK, nil)
+	bia.json("GET", "/api/tasks/shared", nil, http.StatusOK, &shared)
+	if len(shared) != 1 || shared[0].ID != task.ID {
+		t.Fatalf("shared tasks after accepting = %+v, want %s", shared, task.ID)
+	}
+
+	// Only the owner completes it, and the sharee is notified
+	bia.form("POST", "/web/tasks/"+task.ID+"/complete", nil, http.StatusForbidden)
+	ana.form("POST", "/web/tasks/"+task.ID+"/complete", nil, http.StatusOK)
+
+	var completed struct{ Status string }
+	bia.json("GET", "/api/tasks/"+task.ID, nil, http.StatusOK, &completed)
+	if completed.Status != string(application.StatusCompleted) {
+		t.Errorf("task status = %q, want completed", completed.Status)
+	}
+	var notifications []struct {
+		Type   string
+		TaskID string `json:"task_id"`
+	}
+	bia.json("GET", "/api/notifications", nil, http.StatusOK, &notifications)
+	if !hasNotification(notifications, string(application.NotificationTaskCompleted), task.ID) {
+		t.Errorf("notifications = %+v, want the completion of %s", notifications, task.ID)
+	}
+
+	// The export is handed to the background worker; the job is polled until it finishes
+	var job struct {
+		ID          string `json:"id"`
+		Status      string `json:"status"`
+		Error       string `json:"error"`
+		DownloadURL string `json:"download_url"`
+	}
+	resp := ana.json("POST", "/api/exports", nil, http.StatusAccepted, &job)
+	location := resp.Header.Get("Location")
+	if location == "" {
+		t.Fatal("export request returned no Location")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for job.Status != "done" {
+		if job.Status == "failed" || time.Now().After(deadline) {
+			t.Fatalf("export job = %+v, want done", job)
+		}
+		time.Sleep(20 * time.Millisecond)
+		ana.json("GET", location, nil, http.StatusOK, &job)
+	}
+
+	resp, pdf := ana.do("GET", job.DownloadURL, "", nil)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/pdf" || !bytes.HasPrefix(pdf, []byte("%PDF")) {
+		t.Fatalf("download = %d %s (%d bytes), want a PDF", resp.StatusCode, resp.Header.Get("Content-Type"), len(pdf))
+	}
+
+	// Exports belong to whoever requested them
+	resp, _ = bia.do("GET", job.DownloadURL, "", nil)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("download by another user = %d, want 404", resp.StatusCode)
+	}
+}
+
+func TestIntegration_RequiresAuthentication(t *testing.T) {
+	ts := newTestServer(t)
+	anonymous := &client{t: t, base: ts.URL}
+
+	for _, path := range []string{"/api/tasks", "/api/tasks/shared", "/api/exports/job-1"} {
+		resp, _ := anonymous.do("GET", path, "", nil)
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("GET %s without a token = %d, want 401", path, resp.StatusCode)
+		}
+	}
+}
+
+// hasNotification reports whether a notification of a type about a task is in the list
+func hasNotification(notifications []struct {
+	Type   string
+	TaskID string `json:"task_id"`
+}, kind, taskID string) bool {
+	for _, n := range notifications {
+		if n.Type == kind && n.TaskID == taskID {
+			return true
+		}
+	}
+	return false
+}
+
+func TestIntegration_RejectsCrossSiteWebMutations(t *testing.T) {
+	ts := newTestServer(t)
+	ana, _ := signUp(t, ts.URL, "Ana Origem", "ana@origem.test")
+
+	tests := []struct {
+		name   string
+		origin string
+	}{
+		{"another site", "https://evil.example.com"},
+		{"no origin nor referer", ""},
+	}
+	for _, tt := range tests {
+		req := ana.newRequest("POST", "/web/tasks/quick", "application/x-www-form-urlencoded", strings.NewReader("text=Comprar+pão"))
+		req.Header.Set("HX-Request", "true")
+		req.Header.Del("Origin")
+		if tt.origin != "" {
+			req.Header.Set("Origin", tt.origin)
+		}
+		if resp, body := ana.send(req); resp.StatusCode != http.StatusForbidden {
+			t.Errorf("%s: POST /web/tasks/quick = %d, want 403: %s", tt.name, resp.StatusCode, body)
+		}
+	}
+
+	var tasks []struct{ ID string }
+	ana.json("GET", "/api/tasks", nil, http.StatusOK, &tasks)
+	if len(tasks) != 0 {
+		t.Errorf("tasks = %+v, want none created by the rejected requests", tasks)
+	}
+}
+
+func TestIntegration_RouteGroupsListTheirMethods(t *testing.T) {
+	ts := newTestServer(t)
+	anonymous := &client{t: t, base: ts.URL}
+
+	// Routes served through the export and upload groups still show up in OPTIONS and 405
+	tests := []struct {
+		path      string
+		wantAllow string
+	}{
+		{"/api/exports", "POST, OPTIONS"},
+		{"/api/tasks/export/pdf", "GET, HEAD, OPTIONS"},
+		{"/api/tasks/task-1/attachments", "GET, HEAD, POST, OPTIONS"},
+		{"/web/tasks", "GET, HEAD, POST, OPTIONS"},
+		{"/web/tasks/task-1/image", "PUT, DELETE, OPTIONS"},
+	}
+	for _, tt := range tests {
+		resp, _ := anonymous.do("OPTIONS", tt.path, "", nil)
+		if resp.StatusCode != http.StatusNoContent || resp.Header.Get("Allow") != tt.wantAllow {
+			t.Errorf("OPTIONS %s = %d Allow %q, want 204 Allow %q", tt.path, resp.StatusCode, resp.Header.Get("Allow"), tt.wantAllow)
+		}
+	}
+
+	resp, _ := anonymous.do("DELETE", "/api/exports", "", nil)
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE /api/exports = %d, want 405", resp.StatusCode)
+	}
+}
+
+func TestIntegration_FormsWithoutJavaScript(t *testing.T) {
+	// The pages are rendered from the templates, found relative to the repository root
+	t.Chdir("../..")
+	ts := newTestServer(t)
+	ana, _ := signUp(t, ts.URL, "Ana Sem Script", "ana@semscript.test")
+	ana.http = &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+
+	var first, second struct{ ID string }
+	ana.json("POST", "/api/tasks", map[string]string{"title": "Relatório"}, http.StatusCreated, &first)
+	ana.json("POST", "/api/tasks", map[string]string{"title": "Balanço"}, http.StatusCreated, &second)
+
+	// A plain form post, without HX-Request, is answered with a redirect back to its page
+	post := func(path, referer string) (*http.Response, []byte) {
+		t.Helper()
+		req := ana.newRequest("POST", path, "application/x-www-form-urlencoded", strings.NewReader(""))
+		if referer != "" {
+			req.Header.Set("Referer", ts.URL+referer)
+		}
+		return ana.send(req)
+	}
+
+	resp, _ := post("/web/tasks/"+first.ID+"/complete", "/tasks?project=proj-1")
+	if resp.StatusCode != http.StatusSeeOther || resp.Header.Get("Location") != "/tasks?project=proj-1" {
+		t.Fatalf("complete = %d to %q, want 303 to the referring page", resp.StatusCode, resp.Header.Get("Location"))
+	}
+	var completed struct{ Status string }
+	ana.json("GET", "/api/tasks/"+first.ID, nil, http.StatusOK, &completed)
+	if completed.Status != string(application.StatusCompleted) {
+		t.Errorf("task status = %q, want completed", completed.Status)
+	}
+
+	// Forms can't send DELETE, so they name it in the query; a POST would be refused with 405
+	resp, _ = post("/web/tasks/"+second.ID+"/attachments/missing?_method=DELETE", "/tasks")
+	if resp.StatusCode != http.StatusSeeOther {
+		t.Errorf("removing an attachment = %d, want 303", resp.StatusCode)
+	}
+
+	// Errors are shown as a page instead
+	resp, body := post("/web/tasks/"+first.ID+"/complete", "/tasks")
+	if resp.StatusCode < 400 || !strings.Contains(string(body), `role="alert"`) || !strings.Contains(string(body), `href="/tasks"`) {
+		t.Errorf("completing a task twice = %d, want an error page:\n%s", resp.StatusCode, body)
+	}
+
+	// A fragment opened directly, or restored from the HTMX history, is shown in a full page
+	for _, restore := range []bool{false, true} {
+		req := ana.newRequest("GET", "/web/invitations", "", nil)
+		if restore {
+			req.Header.Set("HX-Request", "true")
+			req.Header.Set("HX-History-Restore-Request", "true")
+		}
+		resp, body = ana.send(req)
+		if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "<!DOCTYPE html>") || !strings.Contains(string(body), `id="main-content"`) {
+			t.Errorf("GET /web/invitations (history restore %v) = %d, want a full page:\n%s", restore, resp.StatusCode, body)
+		}
+	}
+
+	// The web login sets the session cookie along with the redirect
+	anonymous := &client{t: t, base: ts.URL, http: ana.http}
+	credentials := url.Values{"email": {"ana@semscript.test"}, "password": {"Senha123!forte"}}
+	resp, _ = anonymous.do("POST", "/web/auth/login", "application/x-www-form-urlencoded", strings.NewReader(credentials.Encode()))
+	if resp.StatusCode != http.StatusSeeOther || resp.Header.Get("Location") != "/tasks" || len(resp.Cookies()) == 0 {
+		t.Errorf("login = %d to %q with %d cookies, want 303 to /tasks with the session", resp.StatusCode, resp.Header.Get("Location"), len(resp.Cookies()))
+	}
+}
+
+func TestIntegration_UserSettings(t *testing.T) {
+	t.Chdir("../..")
+	ts := newTestServer(t)
+	ana, _ := signUp(t, ts.URL, "Ana Preferências", "ana@preferencias.test")
+	bia, biaID := signUp(t, ts.URL, "Bia Preferências", "bia@preferencias.test")
+
+	type settings struct {
+		Theme         string          `json:"theme"`
+		Locale        string          `json:"locale,omitempty"`
+		DefaultSort   string          `json:"default_sort"`
+		Notifications map[string]bool `json:"notifications"`
+	}
+	var got settings
+	bia.json("GET", "/api/me/settings", nil, http.StatusOK, &got)
+	if got.Theme != "light" || got.DefaultSort != "-created_at" || !got.Notifications["task_shared"] {
+		t.Errorf("default settings = %+v", got)
+	}
+
+	// The document read back can be put back as is, here with Bia's changes
+	got.Theme, got.DefaultSort, got.Notifications["task_shared"] = "dark", "created_at", false
+	bia.json("PUT", "/api/me/settings", got, http.StatusOK, nil)
+	var saved settings
+	bia.json("GET", "/api/me/settings", nil, http.StatusOK, &saved)
+	if !reflect.DeepEqual(saved, got) {
+		t.Errorf("settings after PUT = %+v, want %+v", saved, got)
+	}
+	bia.json("PUT", "/api/me/settings", map[string]string{"default_sort": "title"}, http.StatusBadRequest, nil)
+
+	// Bia turned off share notifications, so Ana's invitation doesn't notify her
+	var task struct{ ID string }
+	ana.json("POST", "/api/tasks", map[string]string{"title": "Relatório"}, http.StatusCreated, &task)
+	ana.form("POST", "/web/tasks/"+task.ID+"/share", url.Values{"share_with_user_id": {biaID}}, http.StatusOK)
+	var notifications []struct {
+		Type   string
+		TaskID string `json:"task_id"`
+	}
+	bia.json("GET", "/api/notifications", nil, http.StatusOK, &notifications)
+	if hasNotification(notifications, string(application.NotificationTaskShared), task.ID) {
+		t.Errorf("notifications = %+v, want none for the share", notifications)
+	}
+
+	// The web task list follows the default sort, oldest first
+	bia.json("POST", "/api/tasks", map[string]string{"title": "Primeira"}, http.StatusCreated, nil)
+	bia.json("POST", "/api/tasks", map[string]string{"title": "Segunda"}, http.StatusCreated, nil)
+	req := bia.newRequest("GET", "/web/tasks", "", nil)
+	req.Header.Set("HX-Request", "true")
+	resp, body := bia.send(req)
+	first, second := strings.Index(string(body), "Primeira"), strings.Index(string(body), "Segunda")
+	if resp.StatusCode != http.StatusOK || first < 0 || second < first {
+		t.Errorf("GET /web/tasks = %d, want Primeira before Segunda:\n%s", resp.StatusCode, body)
+	}
+}
+
+func TestIntegration_NavbarCounters(t *testing.T) {
+	ts := newTestServer(t)
+	ana, _ := signUp(t, ts.URL, "Ana Contadores", "ana@contadores.test")
+	bia, biaID := signUp(t, ts.URL, "Bia Contadores", "bia@contadores.test")
+
+	// Ana has two pending tasks, one of them shared with Bia
+	var shared struct{ ID string }
+	ana.json("POST", "/api/tasks", map[string]string{"title": "Relatório"}, http.StatusCreated, nil)
+	ana.json("POST", "/api/tasks", map[string]string{"title": "Balanço"}, http.StatusCreated, &shared)
+	ana.form("POST", "/web/tasks/"+shared.ID+"/share", url.Values{"share_with_user_id": {biaID}}, http.StatusOK)
+	bia.json("POST", "/api/invitations/"+shared.ID+"/accept", nil, http.StatusOK, nil)
+
+	tests := []struct {
+		user *client
+		want string
+	}{
+		{ana, "2 pendentes, 0 atrasadas, 0 compartilhadas"},
+		{bia, "0 pendentes, 0 atrasadas, 1 compartilhadas"},
+	}
+	for _, tt := range tests {
+		req := tt.user.newRequest("GET", "/web/fragments/counters", "", nil)
+		req.Header.Set("HX-Request", "true")
+		resp, body := tt.user.send(req)
+		if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), tt.want) {
+			t.Errorf("GET /web/fragments/counters = %d, want 200 with %q:\n%s", resp.StatusCode, tt.want, body)
+		}
+	}
+}
+
+func TestIntegration_TaskOverview(t *testing.T) {
+	// The tasks page is rendered from the templates, found relative to the repository root
+	t.Chdir("../..")
+	ts := newTestServer(t)
+	ana, _ := signUp(t, ts.URL, "Ana Visão", "ana@visao.test")
+	bia, biaID := signUp(t, ts.URL, "Bia Visão", "bia@visao.test")
+
+	// Bia owns one task and gets Ana's shared with her
+	var shared struct{ ID string }
+	ana.json("POST", "/api/tasks", map[string]string{"title": "Relatório"}, http.StatusCreated, nil)
+	ana.json("POST", "/api/tasks", map[string]string{"title": "Balanço"}, http.StatusCreated, &shared)
+	ana.form("POST", "/web/tasks/"+shared.ID+"/share", url.Values{"share_with_user_id": {biaID}}, http.StatusOK)
+	bia.json("POST", "/api/invitations/"+shared.ID+"/accept", nil, http.StatusOK, nil)
+	bia.json("POST", "/api/tasks", map[string]string{"title": "Orçamento"}, http.StatusCreated, nil)
+
+	var overview struct {
+		Items []struct {
+			Title      string
+			OwnerName  string `json:"owner_name"`
+			Owned      bool
+			SharedWith []struct{ Name string } `json:"shared_with"`
+		}
+		Counters   struct{ Pending, Overdue, Shared int }
+		NextCursor *string `json:"next_cursor"`
+	}
+	bia.json("GET", "/api/tasks/overview?limit=1", nil, http.StatusOK, &overview)
+	if len(overview.Items) != 1 || overview.Items[0].Title != "Orçamento" || !overview.Items[0].Owned || overview.NextCursor == nil {
+		t.Fatalf("GET /api/tasks/overview?limit=1 = %+v, want Bia's task and a next cursor", overview)
+	}
+	if overview.Counters.Pending != 1 || overview.Counters.Shared != 1 {
+		t.Errorf("counters = %+v, want 1 pending and 1 shared", overview.Counters)
+	}
+
+	bia.json("GET", "/api/tasks/overview?limit=1&cursor="+url.QueryEscape(*overview.NextCursor), nil, http.StatusOK, &overview)
+	if len(overview.Items) != 1 || overview.Items[0].Title != "Balanço" || overview.Items[0].Owned || overview.Items[0].OwnerName != "Ana Visão" {
+		t.Errorf("second page = %+v, want Ana's shared task", overview.Items)
+	}
+	if len(overview.Items) == 1 && (len(overview.Items[0].SharedWith) != 1 || overview.Items[0].SharedWith[0].Name != "Bia Visão") {
+		t.Errorf("shared_with = %+v, want Bia", overview.Items[0].SharedWith)
+	}
+
+	// The tasks page lists both, with the counters in the navbar
+	resp, body := bia.send(bia.newRequest("GET", "/tasks", "", nil))
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /tasks = %d, want 200", resp.StatusCode)
+	}
+	for _, want := range []string{"Orçamento", "Balanço", "Compartilhada por Ana Visão", "1 pendentes, 0 atrasadas, 1 compartilhadas"} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("GET /tasks is missing %q", want)
+		}
+	}
+}
+
+func TestIntegration_TransferTask(t *testing.T) {
+	ts := newTestServer(t)
+	ana, anaID := signUp(t, ts.URL, "Ana Dona", "ana@dona.test")
+	bia, biaID := signUp(t, ts.URL, "Bia Dona", "bia@dona.test")
+
+	var task struct{ ID string }
+	ana.json("POST", "/api/tasks", map[string]string{"title": "Relatório"}, http.StatusCreated, &task)
+	transfer := map[string]any{"new_owner_id": biaID, "confirm": true}
+
+	// Only users the task is shared with can receive it, and only once confirmed
+	ana.json("POST", "/api/tasks/"+task.ID+"/transfer", transfer, http.StatusBadRequest, nil)
+	ana.form("POST", "/web/tasks/"+task.ID+"/share", url.Values{"share_with_user_id": {biaID}}, http.StatusOK)
+	bia.json("POST", "/api/invitations/"+task.ID+"/accept", nil, http.StatusOK, nil)
+	ana.json("POST", "/api/tasks/"+task.ID+"/transfer", map[string]any{"new_owner_id": biaID}, http.StatusBadRequest, nil)
+
+	var transferred struct {
+		OwnerID string `json:"owner_id"`
+	}
+	ana.json("POST", "/api/tasks/"+task.ID+"/transfer", transfer, http.StatusOK, &transferred)
+	if transferred.OwnerID != biaID {
+		t.Fatalf("owner_id = %q, want Bia", transferred.OwnerID)
+	}
+
+	// Ana keeps the task as a sharee, so she can no longer transfer it
+	var shared []struct{ ID string }
+	ana.json("GET", "/api/tasks/shared", nil, http.StatusOK, &shared)
+	if len(shared) != 1 || shared[0].ID != task.ID {
+		t.Errorf("Ana's shared tasks = %+v, want the transferred task", shared)
+	}
+	ana.json("POST", "/api/tasks/"+task.ID+"/transfer", map[string]any{"new_owner_id": anaID, "confirm": true}, http.StatusForbidden, nil)
+
+	var notifications []struct {
+		Type   string
+		TaskID string `json:"task_id"`
+	}
+	bia.json("GET", "/api/notifications", nil, http.StatusOK, &notifications)
+	if !hasNotification(notifications, string(application.NotificationTaskTransferred), task.ID) {
+		t.Errorf("notifications = %+v, want the transfer of %s", notifications, task.ID)
+	}
+
+	// Completed tasks need force to go back
+	bia.json("PUT", "/api/tasks/"+task.ID, map[string]string{"title": "Relatório", "status": "completed"}, http.StatusNoContent, nil)
+	back := map[string]any{"new_owner_id": anaID, "confirm": true}
+	bia.json("POST", "/api/tasks/"+task.ID+"/transfer", back, http.StatusConflict, nil)
+	back["force"] = true
+	bia.json("POST", "/api/tasks/"+task.ID+"/transfer", back, http.StatusOK, &transferred)
+	if transferred.OwnerID != anaID {
+		t.Errorf("owner_id = %q, want Ana back", transferred.OwnerID)
+	}
+}
+
+func TestIntegration_CommentMentions(t *testing.T) {
+	ts := newTestServer(t)
+	ana, _ := signUp(t, ts.URL, "Ana Nota", "ana@nota.test")
+	bia, biaID := signUp(t, ts.URL, "Bia Nota", "bia@nota.test")
+	cris, _ := signUp(t, ts.URL, "Cris Nota", "cris@nota.test")
+
+	var task struct{ ID string }
+	ana.json("POST", "/api/tasks", map[string]string{"title": "Orçamento"}, http.StatusCreated, &task)
+	ana.form("POST", "/web/tasks/"+task.ID+"/share", url.Values{"share_with_user_id": {biaID}}, http.StatusOK)
+	bia.json("POST", "/api/invitations/"+task.ID+"/accept", nil, http.StatusOK, nil)
+
+	// Bia is mentioned by name and Cris by email, but only Bia can see the task
+	var comment struct {
+		ID       string
+		Mentions []string
+	}
+	ana.json("POST", "/api/tasks/"+task.ID+"/comments", map[string]string{"body": "@bia.nota e @cris@nota.test, revisem"}, http.StatusCreated, &comment)
+	if len(comment.Mentions) != 1 || comment.Mentions[0] != biaID {
+		t.Fatalf("mentions = %v, want only Bia", comment.Mentions)
+	}
+	cris.json("POST", "/api/tasks/"+task.ID+"/comments", map[string]string{"body": "@ana.nota"}, http.StatusNotFound, nil)
+	cris.json("GET", "/api/tasks/"+task.ID+"/comments", nil, http.StatusNotFound, nil)
+
+	var notifications []struct {
+		Type   string
+		TaskID string `json:"task_id"`
+	}
+	bia.json("GET", "/api/notifications", nil, http.StatusOK, &notifications)
+	if !hasNotification(notifications, string(application.NotificationCommentMention), task.ID) {
+		t.Errorf("notifications = %+v, want the mention in %s", notifications, task.ID)
+	}
+	cris.json("GET", "/api/notifications", nil, http.StatusOK, &notifications)
+	if hasNotification(notifications, string(application.NotificationCommentMention), task.ID) {
+		t.Errorf("Cris was notified of a task she can't see: %+v", notifications)
+	}
+
+	var comments []struct{ ID string }
+	bia.json("GET", "/api/tasks/"+task.ID+"/comments", nil, http.StatusOK, &comments)
+	if len(comments) != 1 || comments[0].ID != comment.ID {
+		t.Errorf("comments = %+v, want Ana's comment", comments)
+	}
+}
+
+func TestIntegration_IdempotentTaskCreation(t *testing.T) {
+	ts := newTestServer(t)
+	ana, _ := signUp(t, ts.URL, "Ana Retry", "ana@retry.test")
+	bia, _ := signUp(t, ts.URL, "Bia Retry", "bia@retry.test")
+
+	create := func(c *client, key, title string) (*http.Response, []byte) {
+		req := c.newRequest("POST", "/api/tasks", "application/json", strings.NewReader(`{"title":"`+title+`"}`))
+		req.Header.Set("Idempotency-Key", key)
+		return c.send(req)
+	}
+
+	// A retry gets the task created by the first request, which isn't created twice
+	first, firstBody := create(ana, "create-1", "Comprar pão")
+	retry, retryBody := create(ana, "create-1", "Comprar pão")
+	if first.StatusCode != http.StatusCreated || retry.StatusCode != http.StatusCreated || !bytes.Equal(firstBody, retryBody) {
+		t.Fatalf("retry = %d %s, want the first response %d %s", retry.StatusCode, retryBody, first.StatusCode, firstBody)
+	}
+	if retry.Header.Get("Idempotent-Replayed") != "true" {
+		t.Errorf("Expected the retry marked as replayed")
+	}
+	var tasks []struct{ ID string }
+	ana.json("GET", "/api/tasks", nil, http.StatusOK, &tasks)
+	if len(tasks) != 1 {
+		t.Errorf("Ana has %d tasks, want 1", len(tasks))
+	}
+
+	// The key can't be reused for another task, but other users have their own keys
+	if resp, body := create(ana, "create-1", "Pagar contas"); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("reused key = %d %s, want 422", resp.StatusCode, body)
+	}
+	if resp, body := create(bia, "create-1", "Comprar pão"); resp.StatusCode != http.StatusCreated || bytes.Equal(body, firstBody) {
+		t.Errorf("Bia's request = %d %s, want a task of her own", resp.StatusCode, body)
+	}
+}
+
+func TestIntegration_ProgressAndCompletionNote(t *testing.T) {
+	ts := newTestServer(t)
+	ana, _ := signUp(t, ts.URL, "Ana Progresso", "ana@progresso.test")
+
+	var task struct{ ID string }
+	ana.json("POST", "/api/tasks", map[string]string{"title": "Relatório"}, http.StatusCreated, &task)
+	var got struct {
+		Progress       int    `json:"progress"`
+		CompletionNote string `json:"completion_note"`
+	}
+	check := func(wantProgress int, wantNote string) {
+		t.Helper()
+		ana.json("GET", "/api/tasks/"+task.ID, nil, http.StatusOK, &got)
+		if got.Progress != wantProgress || got.CompletionNote != wantNote {
+			t.Errorf("task = %d%% with note %q, want %d%% with %q", got.Progress, got.CompletionNote, wantProgress, wantNote)
+		}
+	}
+
+	ana.json("PUT", "/api/tasks/"+task.ID, map[string]any{"title": "Relatório", "status": "in_progress", "progress": 40}, http.StatusNoContent, nil)
+	check(40, "")
+	ana.json("PUT", "/api/tasks/"+task.ID, map[string]any{"title": "Relatório", "status": "in_progress", "progress": 150}, http.StatusBadRequest, nil)
+	ana.json("PUT", "/api/tasks/"+task.ID, map[string]any{"title": "Relatório", "status": "in_progress", "progress": 40, "completion_note": "Pronto"}, http.StatusBadRequest, nil)
+
+	// Completing from the card takes the note, and reports the task as done
+	ana.form("POST", "/web/tasks/"+task.ID+"/complete", url.Values{"completion_note": {"Entregue ao cliente"}}, http.StatusOK)
+	check(100, "Entregue ao cliente")
+
+	// Reopening brings the progress back and drops the note
+	ana.json("PUT", "/api/tasks/"+task.ID, map[string]any{"title": "Relatório", "status": "in_progress", "progress": 40}, http.StatusNoContent, nil)
+	check(40, "")
+}
+
+func TestIntegration_TaskCardsPage(t *testing.T) {
+	// The cards are rendered from the templates, found relative to the repository root
+	t.Chdir("../..")
+	ts := newTestServer(t)
+	ana, _ := signUp(t, ts.URL, "Ana Rolagem", "ana@rolagem.test")
+	ana.json("POST", "/api/tasks", map[string]string{"title": "Relatório"}, http.StatusCreated, nil)
+
+	req := ana.newRequest("GET", "/web/tasks", "", nil)
+	req.Header.Set("HX-Request", "true")
+	resp, body := ana.send(req)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "Relatório") || strings.Contains(string(body), "<!DOCTYPE html>") {
+		t.Errorf("GET /web/tasks = %d, want the task cards alone:\n%s", resp.StatusCode, body)
+	}
+}
+
+func TestIntegration_UndoDeleteAndComplete(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Undo.Window = 300 * time.Millisecond
+	cfg.Undo.Interval = 20 * time.Millisecond
+	ts := newTestServerWithConfig(t, cfg)
+	ana, _ := signUp(t, ts.URL, "Ana Desfaz", "ana@desfaz.test")
+
+	var task struct{ ID, Status string }
+	ana.json("POST", "/api/tasks", map[string]string{"title": "Relatório"}, http.StatusCreated, &task)
+
+	listed := func() int {
+		t.Helper()
+		var tasks []struct{ ID string }
+		ana.json("GET", "/api/tasks", nil, http.StatusOK, &tasks)
+		return len(tasks)
+	}
+
+	// A deleted task is hidden, and back with the undo
+	if body := ana.form("DELETE", "/web/tasks/"+task.ID, nil, http.StatusOK); !strings.Contains(body, `hx-post="/web/tasks/`+task.ID+`/undo"`) {
+		t.Fatalf("delete answered without an undo button:\n%s", body)
+	}
+	if n := listed(); n != 0 {
+		t.Errorf("listed %d tasks after the deletion, want 0", n)
+	}
+	if body := ana.form("POST", "/web/tasks/"+task.ID+"/undo", nil, http.StatusOK); !strings.Contains(body, "Relatório") {
+		t.Fatalf("undo answered without the task card:\n%s", body)
+	}
+	if n := listed(); n != 1 {
+		t.Errorf("listed %d tasks after the undo, want 1", n)
+	}
+
+	// A completed task goes back to its previous status
+	if body := ana.form("POST", "/web/tasks/"+task.ID+"/complete", nil, http.StatusOK); !strings.Contains(body, "/undo") {
+		t.Fatalf("complete answered without an undo button:\n%s", body)
+	}
+	ana.form("POST", "/web/tasks/"+task.ID+"/undo", nil, http.StatusOK)
+	ana.json("GET", "/api/tasks/"+task.ID, nil, http.StatusOK, &task)
+	if task.Status != string(application.StatusPending) {
+		t.Errorf("task status after undo = %q, want pending", task.Status)
+	}
+
+	// Left alone, the deletion becomes final and can't be undone anymore
+	ana.form("DELETE", "/web/tasks/"+task.ID, nil, http.StatusOK)
+	time.Sleep(cfg.Undo.Window)
+	ana.form("POST", "/web/tasks/"+task.ID+"/undo", nil, http.StatusGone)
+	if n := listed(); n != 0 {
+		t.Errorf("listed %d tasks after the final deletion, want 0", n)
+	}
+}
+
+func TestIntegration_TaskFeed(t *testing.T) {
+	ts := newTestServer(t)
+	ana, _ := signUp(t, ts.URL, "Ana Feed", "ana@feed.test")
+	ana.json("POST", "/api/tasks", map[string]string{"title": "Relatório <mensal>", "description": "Enviar **hoje**"}, http.StatusCreated, nil)
+
+	var feedToken struct{ Token, URL string }
+	ana.json("POST", "/api/me/feed-token", nil, http.StatusCreated, &feedToken)
+	if !strings.HasSuffix(feedToken.URL, "/api/tasks.atom?token="+feedToken.Token) {
+		t.Errorf("feed URL = %q, want the feed with the token", feedToken.URL)
+	}
+
+	// Feed readers send no JWT: the token is enough
+	reader := &client{t: t, base: ts.URL}
+	resp, body := reader.do("GET", "/api/tasks.atom?token="+url.QueryEscape(feedToken.Token), "", nil)
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/atom+xml") {
+		t.Fatalf("GET feed = %d %s, want an Atom feed: %s", resp.StatusCode, resp.Header.Get("Content-Type"), body)
+	}
+	var feed struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		Title   string   `xml:"title"`
+		Entries []struct {
+			Title   string `xml:"title"`
+			Content string `xml:"content"`
+		} `xml:"entry"`
+	}
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		t.Fatalf("invalid feed: %v\n%s", err, body)
+	}
+	if feed.Title != "Tarefas de Ana Feed" || len(feed.Entries) != 1 || feed.Entries[0].Title != "Relatório <mensal>" || !strings.Contains(feed.Entries[0].Content, "<strong>hoje</strong>") {
+		t.Errorf("feed = %+v, want the task of Ana", feed)
+	}
+
+	// Revoked tokens stop working
+	ana.json("DELETE", "/api/me/feed-token", nil, http.StatusNoContent, nil)
+	if resp, _ := reader.do("GET", "/api/tasks.atom?token="+url.QueryEscape(feedToken.Token), "", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GET feed with a revoked token = %d, want 404", resp.StatusCode)
+	}
+}
+
+func TestIntegration_AccountDeletion(t *testing.T) {
+	ts := newTestServer(t)
+	ana, _ := signUp(t, ts.URL, "Ana Conta", "ana@account.test")
+	ana.json("POST", "/api/tasks", map[string]string{"title": "Relatório anual"}, http.StatusCreated, nil)
+
+	// The password confirms the deletion
+	ana.json("DELETE", "/api/me", map[string]string{"password": "errada"}, http.StatusForbidden, nil)
+	var deletion struct {
+		PurgeAt   time.Time `json:"purge_at"`
+		ExportURL string    `json:"export_url"`
+	}
+	ana.json("DELETE", "/api/me", map[string]string{"password": "Senha123!forte"}, http.StatusAccepted, &deletion)
+	if until := time.Until(deletion.PurgeAt); until < 6*24*time.Hour || deletion.ExportURL != "/api/me/export" {
+		t.Errorf("deletion = %+v, want a purge in 7 days and the export URL", deletion)
+	}
+
+	// The data can be downloaded during the grace period
+	resp, body := ana.do("GET", deletion.ExportURL, "", nil)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/zip" {
+		t.Fatalf("GET export = %d %s, want a zip: %s", resp.StatusCode, resp.Header.Get("Content-Type"), body)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil || len(archive.File) != 1 || archive.File[0].Name != "tasks.json" {
+		t.Fatalf("export archive = %v, %v; want tasks.json", archive, err)
+	}
+	file, _ := archive.File[0].Open()
+	content, _ := io.ReadAll(file)
+	if !strings.Contains(string(content), "Relatório anual") || strings.Contains(strings.ToLower(string(content)), "password") {
+		t.Errorf("tasks.json = %s, want the task without the password hash", content)
+	}
+
+	// Cancelled, the account is kept
+	ana.json("GET", "/api/me/deletion", nil, http.StatusOK, nil)
+	ana.json("DELETE", "/api/me/deletion", nil, http.StatusNoContent, nil)
+	ana.json("GET", "/api/me/deletion", nil, http.StatusNotFound, nil)
+}
+
+func TestIntegration_AccountExportCoversEveryWorkspace(t *testing.T) {
+	ts := newTestServer(t)
+	ana, _ := signUp(t, ts.URL, "Ana Org", "ana@orgexport.test")
+	ana.json("POST", "/api/tasks", map[string]string{"title": "Tarefa pessoal"}, http.StatusCreated, nil)
+
+	var org struct{ ID string }
+	ana.json("POST", "/api/orgs", map[string]string{"name": "Financeiro"}, http.StatusCreated, &org)
+	req := ana.newRequest("POST", "/api/tasks", "application/json", strings.NewReader(`{"title":"Tarefa do financeiro"}`))
+	req.Header.Set("X-Org-ID", org.ID)
+	if resp, body := ana.send(req); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST org task = %d %s, want 201", resp.StatusCode, body)
+	}
+
+	// The request is scoped to the personal workspace, but deleting the account deletes both
+	resp, body := ana.do("GET", "/api/me/export", "", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET export = %d %s, want 200", resp.StatusCode, body)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil || len(archive.File) != 1 {
+		t.Fatalf("export archive = %v, %v; want tasks.json", archive, err)
+	}
+	file, _ := archive.File[0].Open()
+	content, _ := io.ReadAll(file)
+	for _, title := range []string{"Tarefa pessoal", "Tarefa do financeiro"} {
+		if !strings.Contains(string(content), title) {
+			t.Errorf("tasks.json = %s, want %q", content, title)
+		}
+	}
+}
+
+func TestIntegration_Health(t *testing.T) {
+	ts := newTestServer(t)
+	anonymous := &client{t: t, base: ts.URL}
+
+	resp, body := anonymous.do("GET", "/health", "", nil)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), `"database":"ok"`) {
+		t.Errorf("GET /health = %d %s, want 200 with the database ok", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/http/handler"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+)
+
+// isFileExport reports whether a report request asks for a CSV or PDF file instead of JSON
+func isFileExport(r *http.Request) bool {
+	format := r.URL.Query().Get("format")
+	return format == "csv" || format == "pdf"
+}
+
+func handleIndex(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, "/login", http.StatusFound)
+}
+
+// loginPage returns the handler of the login page
+func loginPage(pages *handler.PageTemplates) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		locale := handler.RequestLocale(r)
+		tmpl, err := pages.Page(locale, nil, "base.html", "login.html", "passkey.html")
+		if err != nil {
+			log.Printf("failed to parse the login page: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		data := handler.PageData(locale, map[string]interface{}{
+			"Title": i18n.T(locale, "title.login"),
+			"Theme": string(handler.ThemeFromRequest(r)),
+		})
+
+		if err := tmpl.Execute(w, data); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
+// registerPage returns the handler of the register page, telling the minimum length of
+// policy next to the password field
+func registerPage(pages *handler.PageTemplates, policy service.PasswordPolicy) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		locale := handler.RequestLocale(r)
+		tmpl, err := pages.Page(locale, nil, "base.html", "register.html", "passkey.html")
+		if err != nil {
+			log.Printf("failed to parse the register page: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		data := handler.PageData(locale, map[string]interface{}{
+			"Title":             i18n.T(locale, "title.register"),
+			"Theme":             string(handler.ThemeFromRequest(r)),
+			"PasswordMinLength": policy.MinLength,
+		})
+
+		if err := tmpl.Execute(w, data); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/http/middleware"
+)
+
+// routeGroup holds routes served straight from the root mux behind their own middlewares,
+// rather than through the sub-mux of their prefix, so they can get another timeout than
+// the routes around them
+type routeGroup struct {
+	prefix   string
+	mux      *http.ServeMux
+	patterns []string
+}
+
+// newRouteGroup creates a route group whose patterns are relative to prefix
+func newRouteGroup(prefix string) *routeGroup {
+	return &routeGroup{prefix: prefix, mux: http.NewServeMux()}
+}
+
+// Handle registers a route; pattern must have a method ("POST /tasks/import")
+func (g *routeGroup) Handle(pattern string, h http.Handler) {
+	g.mux.Handle(pattern, h)
+	g.patterns = append(g.patterns, pattern)
+}
+
+// HandleFunc registers a route function; pattern must have a method
+func (g *routeGroup) HandleFunc(pattern string, h http.HandlerFunc) {
+	g.Handle(pattern, h)
+}
+
+// mount serves the routes of the group on root behind middlewares, the first one outermost,
+// and registers them in routes so OPTIONS and 405 responses list their methods
+func (g *routeGroup) mount(root *http.ServeMux, routes *middleware.RouteTable, middlewares ...func(http.Handler) http.Handler) {
+	h := http.StripPrefix(g.prefix, middleware.Chain(g.mux, middlewares...))
+	for _, pattern := range g.patterns {
+		method, path, _ := strings.Cut(pattern, " ")
+		rootPattern := method + " " + g.prefix + path
+		root.Handle(rootPattern, h)
+		routes.Mount(rootPattern, g.prefix, g.mux)
+	}
+}
@@ -0,0 +1,83 @@
+package app_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/database"
+)
+
+// FuzzIntegration_HostileInput sends hostile strings through the handlers, use cases and
+// repositories of the app, on an in-memory database logging its statements: they must be
+// refused or stored unchanged, never fail the server, and never show in the text of a statement
+func FuzzIntegration_HostileInput(f *testing.F) {
+	for _, s := range []string{
+		"' OR '1'='1",
+		"'; DROP TABLE tasks; --",
+		`") UNION SELECT password_hash, email FROM users --`,
+		"a\x00b",
+		`100% _done_ \ back\slash`,
+		strings.Repeat("é𝄞漢", 4000),
+		"\xff\xfe invalid",
+	} {
+		f.Add(s)
+	}
+
+	log := &database.QueryLog{}
+	cfg := newTestConfig(f)
+	cfg.DBQueryHook = log.Hook
+	cfg.RateLimit.General = 1 << 20
+	cfg.RateLimit.UserSearch = 1 << 20
+	cfg.CreationLimits.Tasks = 1 << 30
+	ts := newTestServerWithConfig(f, cfg)
+	ana, _ := signUp(f, ts.URL, "Ana Fuzz", "ana@fuzz.test")
+
+	runs := 0
+	f.Fuzz(func(t *testing.T, s string) {
+		// The marker makes every value unique and, found in a statement, points at it
+		runs++
+		marker := fmt.Sprintf("fuzz-%d:", runs)
+		value := marker + s
+		ana := &client{t: t, base: ts.URL, token: ana.token}
+
+		body, _ := json.Marshal(map[string]string{"title": value, "description": value})
+		resp, data := ana.do("POST", "/api/tasks", "application/json", bytes.NewReader(body))
+		switch resp.StatusCode {
+		case http.StatusCreated:
+			var created, found struct {
+				ID          string `json:"id"`
+				Title       string `json:"title"`
+				Description string `json:"description"`
+			}
+			if err := json.Unmarshal(data, &created); err != nil {
+				t.Fatalf("decoding %s: %v", data, err)
+			}
+			ana.json("GET", "/api/tasks/"+url.PathEscape(created.ID), nil, http.StatusOK, &found)
+			if found != created {
+				t.Errorf("GET /api/tasks/%s = %+v, want the task created %+v", created.ID, found, created)
+			}
+		case http.StatusBadRequest, http.StatusRequestEntityTooLarge:
+			// Refused by validation, like a title too long, or for its size
+		default:
+			t.Fatalf("POST /api/tasks = %d, want 201, 400 or 413: %s", resp.StatusCode, data)
+		}
+
+		resp, data = ana.do("GET", "/api/users/search?q="+url.QueryEscape(value), "", nil)
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("GET /api/users/search = %d, want 200 or 400: %s", resp.StatusCode, data)
+		}
+		ana.json("GET", "/api/tasks", nil, http.StatusOK, nil)
+
+		// No task has the value for ID, whatever it holds
+		ana.json("GET", "/api/tasks/"+url.PathEscape(value), nil, http.StatusNotFound, nil)
+
+		if inlined := log.Containing(marker); len(inlined) > 0 {
+			t.Errorf("statements built from client input: %q", inlined)
+		}
+	})
+}
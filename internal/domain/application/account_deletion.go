@@ -0,0 +1,31 @@
+package application
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrAccountDeletionNotFound is returned when cancelling the deletion of an account that isn't
+// scheduled for deletion
+var ErrAccountDeletionNotFound = errors.New("account deletion is not scheduled")
+
+// AccountDeletion schedules the purge of an account, requested by its user. Until PurgeAt the
+// user can still sign in, download their data and cancel the deletion.
+type AccountDeletion struct {
+	UserID      string
+	RequestedAt time.Time
+	PurgeAt     time.Time
+}
+
+// NewAccountDeletion creates a new AccountDeletion purging the account after the grace period
+func NewAccountDeletion(userID string, now time.Time, grace time.Duration) (*AccountDeletion, error) {
+	if userID == "" {
+		return nil, errors.New("account deletion user cannot be empty")
+	}
+
+	if grace < 0 {
+		return nil, errors.New("account deletion grace period cannot be negative")
+	}
+
+	return &AccountDeletion{UserID: userID, RequestedAt: now, PurgeAt: now.Add(grace)}, nil
+}
@@ -0,0 +1,38 @@
+package application
+
+import "time"
+
+// DailyCount is the number of events of a day, like the tasks created on it
+type DailyCount struct {
+	Day   time.Time
+	Count int
+}
+
+// RequestError is a request the server failed to answer, with a 5xx status
+type RequestError struct {
+	At     time.Time
+	Method string
+	Path   string
+	Status int
+}
+
+// AdminDashboard holds the figures of the admin dashboard
+type AdminDashboard struct {
+	Users               int
+	TasksPerDay         []DailyCount // oldest day first, days without tasks included
+	Storage             StorageUsage
+	RateLimitRejections int64 // since the server started
+	RateLimitEvictions  int64 // clients forgotten by full rate limiters, since the server started
+	RecentErrors        []RequestError
+}
+
+// MaxTasksPerDay returns the highest count of TasksPerDay, to scale its chart
+func (d *AdminDashboard) MaxTasksPerDay() int {
+	max := 0
+	for _, day := range d.TasksPerDay {
+		if day.Count > max {
+			max = day.Count
+		}
+	}
+	return max
+}
@@ -0,0 +1,67 @@
+package application
+
+import (
+	"errors"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxAttachmentsPerTask limits how many files can be attached to a single task
+const MaxAttachmentsPerTask = 10
+
+var (
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrTooManyAttachments = errors.New("task cannot have more than 10 attachments")
+)
+
+// Attachment represents a file attached to a task
+type Attachment struct {
+	ID        string
+	TaskID    string
+	Filename  string // original name shown to users and used on download
+	MimeType  string
+	Size      int64
+	Path      string // name of the stored file inside the attachments directory
+	CreatedAt time.Time
+}
+
+// NewAttachment creates a new Attachment with validation
+func NewAttachment(id, taskID, filename, mimeType string, size int64, path string) (*Attachment, error) {
+	if id == "" {
+		return nil, errors.New("attachment id cannot be empty")
+	}
+
+	if taskID == "" {
+		return nil, errors.New("attachment task id cannot be empty")
+	}
+
+	if filename == "" {
+		return nil, errors.New("attachment filename cannot be empty")
+	}
+
+	if utf8.RuneCountInString(filename) > 255 {
+		return nil, errors.New("attachment filename cannot exceed 255 characters")
+	}
+
+	if mimeType == "" {
+		return nil, errors.New("attachment mime type cannot be empty")
+	}
+
+	if size <= 0 {
+		return nil, errors.New("attachment cannot be empty")
+	}
+
+	if path == "" {
+		return nil, errors.New("attachment path cannot be empty")
+	}
+
+	return &Attachment{
+		ID:        id,
+		TaskID:    taskID,
+		Filename:  filename,
+		MimeType:  mimeType,
+		Size:      size,
+		Path:      path,
+		CreatedAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,46 @@
+package application
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewAttachment(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		taskID   string
+		filename string
+		mimeType string
+		size     int64
+		path     string
+		errMsg   string
+	}{
+		{"valid attachment", "att-1", "task-1", "relatório.pdf", "application/pdf", 1024, "abc.pdf", ""},
+		{"empty id", "", "task-1", "a.pdf", "application/pdf", 1024, "abc.pdf", "attachment id cannot be empty"},
+		{"empty task id", "att-1", "", "a.pdf", "application/pdf", 1024, "abc.pdf", "attachment task id cannot be empty"},
+		{"empty filename", "att-1", "task-1", "", "application/pdf", 1024, "abc.pdf", "attachment filename cannot be empty"},
+		{"filename too long", "att-1", "task-1", strings.Repeat("a", 252) + ".pdf", "application/pdf", 1024, "abc.pdf", "attachment filename cannot exceed 255 characters"},
+		{"empty mime type", "att-1", "task-1", "a.pdf", "", 1024, "abc.pdf", "attachment mime type cannot be empty"},
+		{"empty file", "att-1", "task-1", "a.pdf", "application/pdf", 0, "abc.pdf", "attachment cannot be empty"},
+		{"empty path", "att-1", "task-1", "a.pdf", "application/pdf", 1024, "", "attachment path cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachment, err := NewAttachment(tt.id, tt.taskID, tt.filename, tt.mimeType, tt.size, tt.path)
+			if tt.errMsg != "" {
+				if err == nil || err.Error() != tt.errMsg {
+					t.Fatalf("NewAttachment() error = %v, want %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewAttachment() unexpected error: %v", err)
+			}
+			if attachment.Filename != tt.filename || attachment.Size != tt.size || attachment.CreatedAt.IsZero() {
+				t.Errorf("NewAttachment() = %+v", attachment)
+			}
+		})
+	}
+}
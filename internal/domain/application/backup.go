@@ -0,0 +1,10 @@
+package application
+
+import "time"
+
+// Backup is a copy of the data of the app: the database, and an archive of the uploaded files
+type Backup struct {
+	Database  string // path of the database copy
+	Files     string // path of the .tar.gz archive of the upload directories
+	CreatedAt time.Time
+}
@@ -0,0 +1,64 @@
+package application
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxCommentLength limits the length of a comment, in characters
+	MaxCommentLength = 2000
+
+	// MaxMentionsPerComment limits how many users a single comment can notify
+	MaxMentionsPerComment = 20
+)
+
+var (
+	ErrCommentEmpty   = errors.New("comment cannot be empty")
+	ErrCommentTooLong = errors.New("comment cannot exceed 2000 characters")
+)
+
+// Comment is a message left on a task by a user who can see it. Mentions holds the IDs of the
+// users it mentions, who were notified.
+type Comment struct {
+	ID        string
+	TaskID    string
+	UserID    string
+	Body      string
+	Mentions  []string
+	CreatedAt time.Time
+}
+
+// NewComment creates a new Comment with validation. The body is trimmed.
+func NewComment(id, taskID, userID, body string, now time.Time) (*Comment, error) {
+	if id == "" {
+		return nil, errors.New("comment id cannot be empty")
+	}
+
+	if taskID == "" {
+		return nil, errors.New("comment task id cannot be empty")
+	}
+
+	if userID == "" {
+		return nil, errors.New("comment user id cannot be empty")
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, ErrCommentEmpty
+	}
+
+	if utf8.RuneCountInString(body) > MaxCommentLength {
+		return nil, ErrCommentTooLong
+	}
+
+	return &Comment{
+		ID:        id,
+		TaskID:    taskID,
+		UserID:    userID,
+		Body:      body,
+		CreatedAt: now,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package application
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewComment(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		id       string
+		taskID   string
+		userID   string
+		body     string
+		wantBody string
+		wantErr  bool
+		errIs    error
+	}{
+		{"valid comment", "comment-1", "task-1", "user-1", "Pode revisar, @ana?", "Pode revisar, @ana?", false, nil},
+		{"body is trimmed", "comment-1", "task-1", "user-1", "  feito \n", "feito", false, nil},
+		{"longest body", "comment-1", "task-1", "user-1", strings.Repeat("é", MaxCommentLength), strings.Repeat("é", MaxCommentLength), false, nil},
+		{"empty id", "", "task-1", "user-1", "feito", "", true, nil},
+		{"empty task id", "comment-1", "", "user-1", "feito", "", true, nil},
+		{"empty user id", "comment-1", "task-1", "", "feito", "", true, nil},
+		{"blank body", "comment-1", "task-1", "user-1", "   ", "", true, ErrCommentEmpty},
+		{"body too long", "comment-1", "task-1", "user-1", strings.Repeat("a", MaxCommentLength+1), "", true, ErrCommentTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comment, err := NewComment(tt.id, tt.taskID, tt.userID, tt.body, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewComment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.errIs != nil && !errors.Is(err, tt.errIs) {
+				t.Errorf("NewComment() error = %v, want %v", err, tt.errIs)
+			}
+			if tt.wantErr {
+				return
+			}
+			if comment.Body != tt.wantBody {
+				t.Errorf("Body = %q, want %q", comment.Body, tt.wantBody)
+			}
+			if !comment.CreatedAt.Equal(now) {
+				t.Errorf("CreatedAt = %v, want %v", comment.CreatedAt, now)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// CreationKind is something a user creates that counts against a daily creation quota
+type CreationKind string
+
+const (
+	CreationTask  CreationKind = "task"  // tasks, however they are created (form, quick add, import)
+	CreationImage CreationKind = "image" // uploaded images, task images and avatars alike
+)
+
+// CreationUsage records things created by a user, counted against the creation quota of their kind
+type CreationUsage struct {
+	ID        string
+	UserID    string
+	Kind      CreationKind
+	Count     int // an import creates many tasks at once
+	CreatedAt time.Time
+}
+
+// NewCreationUsage creates a new CreationUsage with validation
+func NewCreationUsage(id, userID string, kind CreationKind, count int) (*CreationUsage, error) {
+	if id == "" {
+		return nil, errors.New("creation usage id cannot be empty")
+	}
+
+	if userID == "" {
+		return nil, errors.New("creation usage user id cannot be empty")
+	}
+
+	if kind != CreationTask && kind != CreationImage {
+		return nil, errors.New("invalid creation kind")
+	}
+
+	if count < 1 {
+		return nil, errors.New("creation usage count must be positive")
+	}
+
+	return &CreationUsage{
+		ID:        id,
+		UserID:    userID,
+		Kind:      kind,
+		Count:     count,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// CreationQuotaExceededError is returned when creating would take a user over the creation
+// quota of the current window
+type CreationQuotaExceededError struct {
+	Kind    CreationKind
+	Limit   int
+	Window  time.Duration
+	RetryAt time.Time
+}
+
+// Error implements the error interface
+func (e *CreationQuotaExceededError) Error() string {
+	return fmt.Sprintf("%s creation quota of %d per %s exceeded, available again at %s", e.Kind, e.Limit, e.Window, e.RetryAt.Format(time.RFC3339))
+}
+
+// RetryAfter returns how long until creating is allowed again, rounded up to whole seconds
+func (e *CreationQuotaExceededError) RetryAfter(now time.Time) time.Duration {
+	wait := e.RetryAt.Sub(now)
+	if wait <= 0 {
+		return 0
+	}
+	return wait.Truncate(time.Second) + time.Second
+}
@@ -0,0 +1,86 @@
+package application
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultCredentialName names passkeys registered without a name
+	DefaultCredentialName = "Passkey"
+
+	// maxCredentialNameLength bounds the name given to a passkey
+	maxCredentialNameLength = 64
+)
+
+var (
+	ErrCredentialNotFound = errors.New("passkey not found")
+
+	// ErrPasskeySessionExpired is returned when a WebAuthn ceremony is finished without a
+	// matching, unexpired begin step
+	ErrPasskeySessionExpired = errors.New("passkey session expired, please try again")
+
+	// ErrPasskeyVerificationFailed is returned when the authenticator response doesn't verify
+	ErrPasskeyVerificationFailed = errors.New("passkey verification failed")
+)
+
+// Credential is a WebAuthn public key credential (passkey) registered by a user. Only the
+// public key is stored: the private key never leaves the authenticator.
+type Credential struct {
+	ID              string
+	UserID          string
+	Name            string
+	CredentialID    []byte // identifier chosen by the authenticator
+	PublicKey       []byte // COSE encoded public key
+	AttestationType string
+	Transports      []string
+	AAGUID          []byte
+	SignCount       uint32
+	BackupEligible  bool
+	BackupState     bool
+	CreatedAt       time.Time
+	LastUsedAt      *time.Time // nil until the passkey is used to log in
+}
+
+// NewCredential creates a new Credential with validation. An empty name falls back to
+// DefaultCredentialName and longer names are truncated.
+func NewCredential(id, userID, name string, credentialID, publicKey []byte) (*Credential, error) {
+	if id == "" {
+		return nil, errors.New("credential id cannot be empty")
+	}
+
+	if userID == "" {
+		return nil, errors.New("credential user id cannot be empty")
+	}
+
+	if len(credentialID) == 0 {
+		return nil, errors.New("credential must have an authenticator id")
+	}
+
+	if len(publicKey) == 0 {
+		return nil, errors.New("credential must have a public key")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = DefaultCredentialName
+	}
+
+	return &Credential{
+		ID:           id,
+		UserID:       userID,
+		Name:         truncate(name, maxCredentialNameLength),
+		CredentialID: credentialID,
+		PublicKey:    publicKey,
+		CreatedAt:    time.Now(),
+	}, nil
+}
+
+// RecordUse updates the credential after a successful login with the signature counter and
+// backup state reported by the authenticator
+func (c *Credential) RecordUse(signCount uint32, backupState bool, usedAt time.Time) {
+	c.SignCount = signCount
+	c.BackupState = backupState
+	c.LastUsedAt = &usedAt
+}
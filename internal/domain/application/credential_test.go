@@ -0,0 +1,73 @@
+package application
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewCredential(t *testing.T) {
+	tests := []struct {
+		name         string
+		id           string
+		userID       string
+		credName     string
+		credentialID []byte
+		publicKey    []byte
+		wantName     string
+		wantErr      bool
+		errMsg       string
+	}{
+		{name: "valid credential", id: "cred-1", userID: "user-1", credName: "Laptop", credentialID: []byte{1}, publicKey: []byte{2}, wantName: "Laptop"},
+		{name: "name is trimmed", id: "cred-1", userID: "user-1", credName: "  Phone  ", credentialID: []byte{1}, publicKey: []byte{2}, wantName: "Phone"},
+		{name: "empty name uses default", id: "cred-1", userID: "user-1", credName: " ", credentialID: []byte{1}, publicKey: []byte{2}, wantName: DefaultCredentialName},
+		{name: "long name is truncated", id: "cred-1", userID: "user-1", credName: strings.Repeat("a", 100), credentialID: []byte{1}, publicKey: []byte{2}, wantName: strings.Repeat("a", maxCredentialNameLength)},
+		{name: "empty id", userID: "user-1", credentialID: []byte{1}, publicKey: []byte{2}, wantErr: true, errMsg: "credential id cannot be empty"},
+		{name: "empty user id", id: "cred-1", credentialID: []byte{1}, publicKey: []byte{2}, wantErr: true, errMsg: "credential user id cannot be empty"},
+		{name: "missing authenticator id", id: "cred-1", userID: "user-1", publicKey: []byte{2}, wantErr: true, errMsg: "credential must have an authenticator id"},
+		{name: "missing public key", id: "cred-1", userID: "user-1", credentialID: []byte{1}, wantErr: true, errMsg: "credential must have a public key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			credential, err := NewCredential(tt.id, tt.userID, tt.credName, tt.credentialID, tt.publicKey)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("NewCredential() expected error but got nil")
+				}
+				if err.Error() != tt.errMsg {
+					t.Errorf("NewCredential() error = %v, want %v", err.Error(), tt.errMsg)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewCredential() unexpected error: %v", err)
+			}
+			if credential.Name != tt.wantName {
+				t.Errorf("NewCredential() name = %q, want %q", credential.Name, tt.wantName)
+			}
+			if credential.CreatedAt.IsZero() || credential.LastUsedAt != nil {
+				t.Errorf("NewCredential() = %+v, want CreatedAt set and LastUsedAt nil", credential)
+			}
+		})
+	}
+}
+
+func TestCredential_RecordUse(t *testing.T) {
+	credential, err := NewCredential("cred-1", "user-1", "", []byte{1}, []byte{2})
+	if err != nil {
+		t.Fatalf("NewCredential() unexpected error: %v", err)
+	}
+
+	usedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	credential.RecordUse(7, true, usedAt)
+
+	if credential.SignCount != 7 || !credential.BackupState {
+		t.Errorf("RecordUse() = %+v, want sign count 7 and backed up", credential)
+	}
+	if credential.LastUsedAt == nil || !credential.LastUsedAt.Equal(usedAt) {
+		t.Errorf("RecordUse() LastUsedAt = %v, want %v", credential.LastUsedAt, usedAt)
+	}
+}
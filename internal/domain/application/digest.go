@@ -0,0 +1,84 @@
+package application
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DigestUpcomingDays is how far ahead the digest lists the tasks coming due
+	DigestUpcomingDays = 7
+
+	// DigestMaxUpcoming limits how many tasks coming due the digest lists
+	DigestMaxUpcoming = 10
+)
+
+var (
+	ErrInvalidDigestHour = errors.New("digest hour must be between 0 and 23")
+	ErrInvalidDigestDay  = errors.New("digest weekday must be between 0 (Sunday) and 6 (Saturday)")
+
+	// ErrDigestSubscriptionNotFound is returned for a user who hasn't opted in to the digest
+	ErrDigestSubscriptionNotFound = errors.New("digest subscription not found")
+)
+
+// DigestSubscription is a user's opt-in to the weekly summary email, sent on a weekday at
+// an hour of the user's choosing. NextSendAt is precomputed so the digest job only reads the
+// subscriptions that are due.
+type DigestSubscription struct {
+	UserID     string
+	Weekday    time.Weekday
+	Hour       int
+	NextSendAt time.Time
+	LastSentAt *time.Time // set once a digest is sent
+	CreatedAt  time.Time
+}
+
+// NewDigestSubscription creates a new DigestSubscription with validation, scheduling the
+// first digest after now. Weekday and hour are taken in loc.
+func NewDigestSubscription(userID string, weekday time.Weekday, hour int, loc *time.Location, now time.Time) (*DigestSubscription, error) {
+	if userID == "" {
+		return nil, errors.New("digest user id cannot be empty")
+	}
+
+	if weekday < time.Sunday || weekday > time.Saturday {
+		return nil, ErrInvalidDigestDay
+	}
+
+	if hour < 0 || hour > 23 {
+		return nil, ErrInvalidDigestHour
+	}
+
+	return &DigestSubscription{
+		UserID:     userID,
+		Weekday:    weekday,
+		Hour:       hour,
+		NextSendAt: NextDigestAt(weekday, hour, loc, now),
+		CreatedAt:  now,
+	}, nil
+}
+
+// NextDigestAt returns the first time after after falling on weekday at hour:00 in loc
+func NextDigestAt(weekday time.Weekday, hour int, loc *time.Location, after time.Time) time.Time {
+	local := after.In(loc)
+	days := (int(weekday) - int(local.Weekday()) + 7) % 7
+	next := time.Date(local.Year(), local.Month(), local.Day()+days, hour, 0, 0, 0, loc)
+	if !next.After(after) {
+		next = time.Date(local.Year(), local.Month(), local.Day()+days+7, hour, 0, 0, 0, loc)
+	}
+	return next
+}
+
+// WeeklyDigest summarizes the tasks of a user over the week before To
+type WeeklyDigest struct {
+	From      time.Time
+	To        time.Time
+	Pending   int // pending and in progress
+	Overdue   int // not completed, past their due date at To
+	Completed int // completed since From
+	Upcoming  []*Task
+}
+
+// IsEmpty reports whether the user has nothing to be told about
+func (d *WeeklyDigest) IsEmpty() bool {
+	return d.Pending == 0 && d.Overdue == 0 && d.Completed == 0 && len(d.Upcoming) == 0
+}
@@ -0,0 +1,71 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNextDigestAt(t *testing.T) {
+	saoPaulo := time.FixedZone("BRT", -3*60*60)
+	// Tuesday, 10 March 2026, 12:00 in São Paulo
+	tuesdayNoon := time.Date(2026, 3, 10, 12, 0, 0, 0, saoPaulo)
+
+	tests := []struct {
+		name    string
+		weekday time.Weekday
+		hour    int
+		after   time.Time
+		want    time.Time
+	}{
+		{"later this week", time.Friday, 18, tuesdayNoon, time.Date(2026, 3, 13, 18, 0, 0, 0, saoPaulo)},
+		{"later today", time.Tuesday, 18, tuesdayNoon, time.Date(2026, 3, 10, 18, 0, 0, 0, saoPaulo)},
+		{"earlier today", time.Tuesday, 8, tuesdayNoon, time.Date(2026, 3, 17, 8, 0, 0, 0, saoPaulo)},
+		{"exactly now", time.Tuesday, 12, tuesdayNoon, time.Date(2026, 3, 17, 12, 0, 0, 0, saoPaulo)},
+		{"earlier this week", time.Monday, 8, tuesdayNoon, time.Date(2026, 3, 16, 8, 0, 0, 0, saoPaulo)},
+		{"after given in another zone", time.Wednesday, 0, time.Date(2026, 3, 11, 2, 0, 0, 0, time.UTC), time.Date(2026, 3, 11, 0, 0, 0, 0, saoPaulo)},
+		{"end of month", time.Sunday, 9, time.Date(2026, 3, 30, 9, 0, 0, 0, saoPaulo), time.Date(2026, 4, 5, 9, 0, 0, 0, saoPaulo)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextDigestAt(tt.weekday, tt.hour, saoPaulo, tt.after); !got.Equal(tt.want) {
+				t.Errorf("NextDigestAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDigestSubscription(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		userID  string
+		weekday time.Weekday
+		hour    int
+		wantErr error
+	}{
+		{"valid", "user-1", time.Monday, 8, nil},
+		{"midnight on Sunday", "user-1", time.Sunday, 0, nil},
+		{"hour too late", "user-1", time.Monday, 24, ErrInvalidDigestHour},
+		{"negative hour", "user-1", time.Monday, -1, ErrInvalidDigestHour},
+		{"unknown weekday", "user-1", time.Weekday(7), 8, ErrInvalidDigestDay},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription, err := NewDigestSubscription(tt.userID, tt.weekday, tt.hour, time.UTC, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewDigestSubscription() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !subscription.NextSendAt.After(now) {
+				t.Errorf("NextSendAt = %v, want after %v", subscription.NextSendAt, now)
+			}
+		})
+	}
+
+	if _, err := NewDigestSubscription("", time.Monday, 8, time.UTC, now); err == nil {
+		t.Error("NewDigestSubscription() without user should fail")
+	}
+}
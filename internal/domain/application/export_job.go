@@ -0,0 +1,73 @@
+package application
+
+import (
+	"errors"
+	"time"
+)
+
+// ExportJobStatus represents the progress of an export job
+type ExportJobStatus string
+
+const (
+	ExportJobPending ExportJobStatus = "pending"
+	ExportJobRunning ExportJobStatus = "running"
+	ExportJobDone    ExportJobStatus = "done"
+	ExportJobFailed  ExportJobStatus = "failed"
+)
+
+// ExportKindTasksPDF is the kind of the job exporting the tasks of a user to PDF
+const ExportKindTasksPDF = "tasks_pdf"
+
+var (
+	ErrExportJobNotFound = errors.New("export not found")
+	ErrExportNotReady    = errors.New("export is not ready yet")
+)
+
+// ExportJob is a file export generated in the background. The file is kept with the job until
+// the job expires.
+type ExportJob struct {
+	ID         string
+	UserID     string
+	Kind       string
+	OrgID      string // workspace the export was asked in, empty for the personal tasks
+	Status     ExportJobStatus
+	Error      string // set when the job failed
+	Size       int    // size of the generated file in bytes
+	CreatedAt  time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// NewExportJob creates a new pending ExportJob with validation
+func NewExportJob(id, userID, kind string, now time.Time) (*ExportJob, error) {
+	if id == "" {
+		return nil, errors.New("export job id cannot be empty")
+	}
+
+	if userID == "" {
+		return nil, errors.New("export job user id cannot be empty")
+	}
+
+	if kind != ExportKindTasksPDF {
+		return nil, errors.New("unknown export kind")
+	}
+
+	return &ExportJob{
+		ID:        id,
+		UserID:    userID,
+		Kind:      kind,
+		Status:    ExportJobPending,
+		CreatedAt: now,
+	}, nil
+}
+
+// IsFinished reports whether the job is done or failed
+func (j *ExportJob) IsFinished() bool {
+	return j.Status == ExportJobDone || j.Status == ExportJobFailed
+}
+
+// Start marks a pending job as running
+func (j *ExportJob) Start(now time.Time) {
+	j.Status = ExportJobRunning
+	j.StartedAt = &now
+}
@@ -0,0 +1,38 @@
+package application
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewExportJob(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		id      string
+		userID  string
+		kind    string
+		wantErr bool
+	}{
+		{"valid job", "job-1", "user-1", ExportKindTasksPDF, false},
+		{"empty id", "", "user-1", ExportKindTasksPDF, true},
+		{"empty user id", "job-1", "", ExportKindTasksPDF, true},
+		{"unknown kind", "job-1", "user-1", "tasks_xls", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, err := NewExportJob(tt.id, tt.userID, tt.kind, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewExportJob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if job.Status != ExportJobPending || !job.CreatedAt.Equal(now) || job.IsFinished() {
+				t.Errorf("NewExportJob() = %+v, want a pending job created now", job)
+			}
+		})
+	}
+}
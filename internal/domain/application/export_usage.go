@@ -0,0 +1,58 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ExportUsage records a heavy export (PDF, CSV report...) made by a user, counted against the export quota
+type ExportUsage struct {
+	ID        string
+	UserID    string
+	Kind      string
+	CreatedAt time.Time
+}
+
+// NewExportUsage creates a new ExportUsage with validation
+func NewExportUsage(id, userID, kind string) (*ExportUsage, error) {
+	if id == "" {
+		return nil, errors.New("export usage id cannot be empty")
+	}
+
+	if userID == "" {
+		return nil, errors.New("export usage user id cannot be empty")
+	}
+
+	if kind == "" || len(kind) > 50 {
+		return nil, errors.New("export kind must have between 1 and 50 characters")
+	}
+
+	return &ExportUsage{
+		ID:        id,
+		UserID:    userID,
+		Kind:      kind,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// QuotaExceededError is returned when a user has used up the export quota of the current window
+type QuotaExceededError struct {
+	Limit   int
+	Window  time.Duration
+	RetryAt time.Time
+}
+
+// Error implements the error interface
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("export quota of %d per %s exceeded, available again at %s", e.Limit, e.Window, e.RetryAt.Format(time.RFC3339))
+}
+
+// RetryAfter returns how long until a new export is allowed, rounded up to whole seconds
+func (e *QuotaExceededError) RetryAfter(now time.Time) time.Duration {
+	wait := e.RetryAt.Sub(now)
+	if wait <= 0 {
+		return 0
+	}
+	return wait.Truncate(time.Second) + time.Second
+}
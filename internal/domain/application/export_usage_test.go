@@ -0,0 +1,57 @@
+package application
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewExportUsage(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		userID  string
+		kind    string
+		wantErr bool
+	}{
+		{"valid", "usage-1", "user-1", "tasks_pdf", false},
+		{"empty id", "", "user-1", "tasks_pdf", true},
+		{"empty user", "usage-1", "", "tasks_pdf", true},
+		{"empty kind", "usage-1", "user-1", "", true},
+		{"kind too long", "usage-1", "user-1", strings.Repeat("k", 51), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage, err := NewExportUsage(tt.id, tt.userID, tt.kind)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewExportUsage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && usage.CreatedAt.IsZero() {
+				t.Error("ExportUsage.CreatedAt should not be zero")
+			}
+		})
+	}
+}
+
+func TestQuotaExceededError_RetryAfter(t *testing.T) {
+	now := time.Date(2025, 6, 11, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		retryAt time.Time
+		want    time.Duration
+	}{
+		{"already free", now.Add(-time.Second), 0},
+		{"rounds up to whole seconds", now.Add(90*time.Second + 200*time.Millisecond), 91 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &QuotaExceededError{Limit: 5, Window: time.Hour, RetryAt: tt.retryAt}
+			if got := err.RetryAfter(now); got != tt.want {
+				t.Errorf("RetryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package application
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrFeedTokenNotFound is returned when a feed is read with a token that doesn't exist or was
+// replaced, or whose user was disabled
+var ErrFeedTokenNotFound = errors.New("feed token not found")
+
+// FeedToken authorizes reading the task feed of a user without signing in, from feed readers
+// that can't send a JWT. A user has at most one; creating another replaces it. Only the hash
+// of the token is stored: the token itself is shown once, when it is created.
+type FeedToken struct {
+	UserID    string
+	TokenHash string
+	CreatedAt time.Time
+}
+
+// NewFeedToken creates a new FeedToken with validation
+func NewFeedToken(userID, tokenHash string, now time.Time) (*FeedToken, error) {
+	if userID == "" {
+		return nil, errors.New("feed token user cannot be empty")
+	}
+
+	if tokenHash == "" {
+		return nil, errors.New("feed token hash cannot be empty")
+	}
+
+	return &FeedToken{UserID: userID, TokenHash: tokenHash, CreatedAt: now}, nil
+}
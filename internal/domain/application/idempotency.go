@@ -0,0 +1,67 @@
+package application
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxIdempotencyKeyLength is the longest Idempotency-Key accepted
+const MaxIdempotencyKeyLength = 255
+
+var (
+	ErrInvalidIdempotencyKey    = errors.New("idempotency key must have 1 to 255 printable ASCII characters")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used with another request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still being processed")
+)
+
+// IdempotencyRecord is a request a user sent with an Idempotency-Key and, once it's answered,
+// its response, kept so a retry of the request gets the same response instead of running
+// again. Keys are scoped per user.
+type IdempotencyRecord struct {
+	UserID      string
+	Key         string
+	RequestHash string // Hash of the method, path, workspace and body of the request
+	Status      int    // Status of the response; 0 while the first request is still running
+	ContentType string
+	Location    string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// NewIdempotencyRecord creates the record of a request that is about to run, kept for ttl
+func NewIdempotencyRecord(userID, key, requestHash string, now time.Time, ttl time.Duration) (*IdempotencyRecord, error) {
+	if userID == "" {
+		return nil, errors.New("user id cannot be empty")
+	}
+	if !ValidIdempotencyKey(key) {
+		return nil, ErrInvalidIdempotencyKey
+	}
+
+	return &IdempotencyRecord{
+		UserID:      userID,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}, nil
+}
+
+// ValidIdempotencyKey reports whether a key has 1 to MaxIdempotencyKeyLength printable ASCII
+// characters, as keys are usually UUIDs
+func ValidIdempotencyKey(key string) bool {
+	if key == "" || len(key) > MaxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// Answered reports whether the response of the request was stored
+func (r *IdempotencyRecord) Answered() bool {
+	return r.Status != 0
+}
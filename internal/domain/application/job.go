@@ -0,0 +1,71 @@
+package application
+
+import (
+	"errors"
+	"time"
+)
+
+// JobStatus represents the progress of a queued job
+type JobStatus string
+
+const (
+	JobPending JobStatus = "pending"
+	JobRunning JobStatus = "running"
+	JobDone    JobStatus = "done"
+	JobFailed  JobStatus = "failed" // gave up after its last attempt
+)
+
+var (
+	// ErrJobNotFound is returned for a job that doesn't exist, or isn't in the state an action
+	// needs, such as retrying a job that didn't fail, and when no job is due to be claimed
+	ErrJobNotFound = errors.New("job not found")
+
+	// ErrUnknownJobType is returned for a job whose type has no handler
+	ErrUnknownJobType = errors.New("unknown job type")
+)
+
+// Job is work queued to run in the background, such as sending an email. A failed run is
+// retried later until the job runs out of attempts.
+type Job struct {
+	ID          string
+	Type        string // Picks the handler running the job
+	Payload     []byte // JSON given to the handler
+	Status      JobStatus
+	Attempts    int // Runs started so far
+	MaxAttempts int
+	Error       string    // Error of the last failed run
+	RunAt       time.Time // The job isn't run before this
+	CreatedAt   time.Time
+	StartedAt   *time.Time // Start of the last run
+	FinishedAt  *time.Time // Set once the job is done or failed
+}
+
+// NewJob creates a new pending Job with validation, to run as soon as possible
+func NewJob(id, jobType string, payload []byte, maxAttempts int, now time.Time) (*Job, error) {
+	if id == "" {
+		return nil, errors.New("job id cannot be empty")
+	}
+
+	if jobType == "" {
+		return nil, errors.New("job type cannot be empty")
+	}
+
+	if maxAttempts < 1 {
+		return nil, errors.New("job must have at least one attempt")
+	}
+
+	return &Job{
+		ID:          id,
+		Type:        jobType,
+		Payload:     payload,
+		Status:      JobPending,
+		MaxAttempts: maxAttempts,
+		RunAt:       now,
+		CreatedAt:   now,
+	}, nil
+}
+
+// CanRetry reports whether the job has attempts left after its current run
+func (j *Job) CanRetry() bool {
+	return j.Attempts < j.MaxAttempts
+}
@@ -0,0 +1,56 @@
+package application
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewJob(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		id          string
+		jobType     string
+		maxAttempts int
+		wantErr     bool
+	}{
+		{"valid job", "job-1", "send_email", 3, false},
+		{"empty id", "", "send_email", 3, true},
+		{"empty type", "job-1", "", 3, true},
+		{"no attempts", "job-1", "send_email", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, err := NewJob(tt.id, tt.jobType, []byte(`{}`), tt.maxAttempts, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewJob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if job.Status != JobPending || !job.RunAt.Equal(now) || job.Attempts != 0 {
+				t.Errorf("NewJob() = %+v, want a pending job due now", job)
+			}
+		})
+	}
+}
+
+func TestJob_CanRetry(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     bool
+	}{
+		{1, true},
+		{2, true},
+		{3, false},
+	}
+
+	for _, tt := range tests {
+		job := &Job{Attempts: tt.attempts, MaxAttempts: 3}
+		if got := job.CanRetry(); got != tt.want {
+			t.Errorf("CanRetry() after attempt %d of 3 = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
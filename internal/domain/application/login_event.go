@@ -0,0 +1,62 @@
+package application
+
+import (
+	"errors"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// maxLoginEventEmailLength bounds the email stored for an attempt; failed attempts carry arbitrary input
+	maxLoginEventEmailLength = 254
+
+	// maxLoginEventUserAgentLength bounds the stored user agent
+	maxLoginEventUserAgentLength = 255
+)
+
+// ErrLoginEventNotFound is returned when a user has no login of the kind looked for
+var ErrLoginEventNotFound = errors.New("login event not found")
+
+// LoginEvent records a login attempt for incident investigation
+type LoginEvent struct {
+	ID        string
+	UserID    string // empty when the email doesn't belong to any user
+	Email     string
+	IP        string
+	UserAgent string
+	Success   bool
+	CreatedAt time.Time
+}
+
+// NewLoginEvent creates a new LoginEvent with validation. Email and user agent come from the
+// client and are truncated instead of rejected, so oversized input is still recorded.
+func NewLoginEvent(id, userID, email, ip, userAgent string, success bool) (*LoginEvent, error) {
+	if id == "" {
+		return nil, errors.New("login event id cannot be empty")
+	}
+
+	if success && userID == "" {
+		return nil, errors.New("successful login event must have a user id")
+	}
+
+	return &LoginEvent{
+		ID:        id,
+		UserID:    userID,
+		Email:     truncate(email, maxLoginEventEmailLength),
+		IP:        ip,
+		UserAgent: truncate(userAgent, maxLoginEventUserAgentLength),
+		Success:   success,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// truncate cuts s to at most max bytes without splitting a UTF-8 character
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max]
+}
@@ -0,0 +1,70 @@
+package application
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestNewLoginEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		userID  string
+		success bool
+		wantErr bool
+		errMsg  string
+	}{
+		{name: "successful login", id: "login-1", userID: "user-1", success: true},
+		{name: "failed login of a known user", id: "login-1", userID: "user-1", success: false},
+		{name: "failed login of an unknown email", id: "login-1", success: false},
+		{name: "empty id", userID: "user-1", success: true, wantErr: true, errMsg: "login event id cannot be empty"},
+		{name: "successful login without user", id: "login-1", success: true, wantErr: true, errMsg: "successful login event must have a user id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := NewLoginEvent(tt.id, tt.userID, "user@example.com", "10.0.0.1", "Mozilla/5.0", tt.success)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("NewLoginEvent() expected error but got nil")
+				}
+				if err.Error() != tt.errMsg {
+					t.Errorf("NewLoginEvent() error = %v, want %v", err.Error(), tt.errMsg)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewLoginEvent() unexpected error: %v", err)
+			}
+			if event.UserID != tt.userID || event.Success != tt.success {
+				t.Errorf("NewLoginEvent() = %+v", event)
+			}
+			if event.CreatedAt.IsZero() {
+				t.Error("NewLoginEvent() expected CreatedAt to be set")
+			}
+		})
+	}
+}
+
+func TestNewLoginEvent_TruncatesClientInput(t *testing.T) {
+	email := strings.Repeat("a", 300) + "@example.com"
+	userAgent := strings.Repeat("é", 200)
+
+	event, err := NewLoginEvent("login-1", "", email, "10.0.0.1", userAgent, false)
+	if err != nil {
+		t.Fatalf("NewLoginEvent() unexpected error: %v", err)
+	}
+
+	if len(event.Email) != maxLoginEventEmailLength {
+		t.Errorf("len(Email) = %d, want %d", len(event.Email), maxLoginEventEmailLength)
+	}
+	if len(event.UserAgent) > maxLoginEventUserAgentLength {
+		t.Errorf("len(UserAgent) = %d, want at most %d", len(event.UserAgent), maxLoginEventUserAgentLength)
+	}
+	if !utf8.ValidString(event.UserAgent) {
+		t.Error("UserAgent was cut in the middle of a character")
+	}
+}
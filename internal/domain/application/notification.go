@@ -0,0 +1,62 @@
+package application
+
+import (
+	"errors"
+	"time"
+)
+
+// NotificationType identifies what triggered a notification
+type NotificationType string
+
+const (
+	NotificationBrokenLink      NotificationType = "broken_link"
+	NotificationTaskShared      NotificationType = "task_shared"
+	NotificationTaskCompleted   NotificationType = "task_completed"
+	NotificationTaskReminder    NotificationType = "task_reminder"
+	NotificationTaskOverdue     NotificationType = "task_overdue"
+	NotificationTaskTransferred NotificationType = "task_transferred"
+	NotificationCommentMention  NotificationType = "comment_mention"
+)
+
+// Notification represents an in-app message delivered to a user
+type Notification struct {
+	ID        string
+	UserID    string
+	Type      NotificationType
+	TaskID    string
+	Message   string
+	Read      bool
+	CreatedAt time.Time
+}
+
+// NewNotification creates a new Notification with validation
+func NewNotification(id, userID string, notificationType NotificationType, taskID, message string) (*Notification, error) {
+	if id == "" {
+		return nil, errors.New("notification id cannot be empty")
+	}
+
+	if userID == "" {
+		return nil, errors.New("notification user id cannot be empty")
+	}
+
+	if notificationType == "" {
+		return nil, errors.New("notification type cannot be empty")
+	}
+
+	if message == "" {
+		return nil, errors.New("notification message cannot be empty")
+	}
+
+	if len(message) > 500 {
+		return nil, errors.New("notification message cannot exceed 500 characters")
+	}
+
+	return &Notification{
+		ID:        id,
+		UserID:    userID,
+		Type:      notificationType,
+		TaskID:    taskID,
+		Message:   message,
+		CreatedAt: time.Now(),
+	}, nil
+}
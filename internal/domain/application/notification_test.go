@@ -0,0 +1,86 @@
+package application
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewNotification(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               string
+		userID           string
+		notificationType NotificationType
+		message          string
+		wantErr          bool
+		errMsg           string
+	}{
+		{
+			name:             "valid notification",
+			id:               "notif-1",
+			userID:           "user-1",
+			notificationType: NotificationBrokenLink,
+			message:          "Link quebrado",
+		},
+		{
+			name:             "empty id",
+			userID:           "user-1",
+			notificationType: NotificationBrokenLink,
+			message:          "Link quebrado",
+			wantErr:          true,
+			errMsg:           "notification id cannot be empty",
+		},
+		{
+			name:             "empty user id",
+			id:               "notif-1",
+			notificationType: NotificationBrokenLink,
+			message:          "Link quebrado",
+			wantErr:          true,
+			errMsg:           "notification user id cannot be empty",
+		},
+		{
+			name:    "empty type",
+			id:      "notif-1",
+			userID:  "user-1",
+			message: "Link quebrado",
+			wantErr: true,
+			errMsg:  "notification type cannot be empty",
+		},
+		{
+			name:             "empty message",
+			id:               "notif-1",
+			userID:           "user-1",
+			notificationType: NotificationBrokenLink,
+			wantErr:          true,
+			errMsg:           "notification message cannot be empty",
+		},
+		{
+			name:             "message too long",
+			id:               "notif-1",
+			userID:           "user-1",
+			notificationType: NotificationBrokenLink,
+			message:          strings.Repeat("a", 501),
+			wantErr:          true,
+			errMsg:           "notification message cannot exceed 500 characters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notification, err := NewNotification(tt.id, tt.userID, tt.notificationType, "task-1", tt.message)
+			if tt.wantErr {
+				if err == nil || err.Error() != tt.errMsg {
+					t.Errorf("NewNotification() error = %v, want %v", err, tt.errMsg)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewNotification() unexpected error = %v", err)
+			}
+			if notification.Read {
+				t.Error("new notification should be unread")
+			}
+		})
+	}
+}
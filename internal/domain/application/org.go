@@ -0,0 +1,100 @@
+package application
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// OrgRole is the role of a member in an org
+type OrgRole string
+
+const (
+	OrgRoleOwner  OrgRole = "owner"  // manages the org, its members and who else owns it
+	OrgRoleAdmin  OrgRole = "admin"  // manages the org and its members
+	OrgRoleMember OrgRole = "member" // works on the tasks of the org
+)
+
+var (
+	ErrOrgNotFound       = errors.New("org not found")
+	ErrOrgMemberNotFound = errors.New("user is not a member of the org")
+	ErrOrgNameRequired   = errors.New("org name cannot be empty")
+	ErrOrgNameTooLong    = errors.New("org name cannot exceed 100 characters")
+	ErrInvalidOrgRole    = errors.New("role must be owner, admin or member")
+	ErrOrgAdminRequired  = errors.New("only org owners and admins can manage the org")
+	ErrOrgOwnerRequired  = errors.New("only org owners can grant or take the owner role")
+	ErrLastOrgOwner      = errors.New("an org must keep at least one owner")
+)
+
+// Org is a workspace shared by its members, such as a department. Tasks created in an org
+// belong to it and are listed apart from the personal tasks of their owners.
+type Org struct {
+	ID         string
+	Name       string
+	ShareTasks bool // Every member sees the tasks of the org, not only those shared with them
+	CreatedAt  time.Time
+}
+
+// NewOrg creates a new Org with validation. Its tasks are shared with all its members.
+func NewOrg(id, name string, now time.Time) (*Org, error) {
+	if id == "" {
+		return nil, errors.New("org id cannot be empty")
+	}
+
+	org := &Org{
+		ID:         id,
+		ShareTasks: true,
+		CreatedAt:  now,
+	}
+	if err := org.Update(name, true); err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+// Update renames the org and sets whether its tasks are shared with all its members
+func (o *Org) Update(name string, shareTasks bool) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrOrgNameRequired
+	}
+
+	if len(name) > 100 {
+		return ErrOrgNameTooLong
+	}
+
+	o.Name = name
+	o.ShareTasks = shareTasks
+	return nil
+}
+
+// IsValid checks if the role is one of the org roles
+func (r OrgRole) IsValid() bool {
+	switch r {
+	case OrgRoleOwner, OrgRoleAdmin, OrgRoleMember:
+		return true
+	}
+	return false
+}
+
+// CanManage reports whether members with the role manage the org and its members
+func (r OrgRole) CanManage() bool {
+	return r == OrgRoleOwner || r == OrgRoleAdmin
+}
+
+// OrgMember is a user belonging to an org, with their role in it
+type OrgMember struct {
+	OrgID    string
+	UserID   string
+	Name     string // Name of the user, filled in when listing members
+	Email    string // Email of the user, filled in when listing members
+	Role     OrgRole
+	JoinedAt time.Time
+}
+
+// OrgMembership is an org a user belongs to, with their role in it
+type OrgMembership struct {
+	Org  *Org
+	Role OrgRole
+}
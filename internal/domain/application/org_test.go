@@ -0,0 +1,85 @@
+package application
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewOrg(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		orgName string
+		wantErr bool
+		errIs   error
+	}{
+		{"valid org", "org-1", "Receita", false, nil},
+		{"name is trimmed", "org-1", "  Receita  ", false, nil},
+		{"empty id", "", "Receita", true, nil},
+		{"blank name", "org-1", "   ", true, ErrOrgNameRequired},
+		{"name too long", "org-1", strings.Repeat("a", 101), true, ErrOrgNameTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			org, err := NewOrg(tt.id, tt.orgName, time.Now())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewOrg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.errIs != nil && !errors.Is(err, tt.errIs) {
+				t.Errorf("NewOrg() error = %v, want %v", err, tt.errIs)
+			}
+			if tt.wantErr {
+				return
+			}
+			if org.Name != strings.TrimSpace(tt.orgName) || !org.ShareTasks {
+				t.Errorf("NewOrg() = %+v, want the trimmed name and its tasks shared", org)
+			}
+		})
+	}
+}
+
+func TestOrg_Update(t *testing.T) {
+	org, _ := NewOrg("org-1", "Receita", time.Now())
+
+	if err := org.Update(" ", false); !errors.Is(err, ErrOrgNameRequired) {
+		t.Fatalf("Update() error = %v, want ErrOrgNameRequired", err)
+	}
+	if org.Name != "Receita" || !org.ShareTasks {
+		t.Errorf("Update() changed the org on error: %+v", org)
+	}
+
+	if err := org.Update("Diretoria", false); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	if org.Name != "Diretoria" || org.ShareTasks {
+		t.Errorf("Update() = %+v", org)
+	}
+}
+
+func TestOrgRole(t *testing.T) {
+	tests := []struct {
+		role      OrgRole
+		valid     bool
+		canManage bool
+	}{
+		{OrgRoleOwner, true, true},
+		{OrgRoleAdmin, true, true},
+		{OrgRoleMember, true, false},
+		{OrgRole("guest"), false, false},
+		{OrgRole(""), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role), func(t *testing.T) {
+			if got := tt.role.IsValid(); got != tt.valid {
+				t.Errorf("IsValid() = %v, want %v", got, tt.valid)
+			}
+			if got := tt.role.CanManage(); got != tt.canManage {
+				t.Errorf("CanManage() = %v, want %v", got, tt.canManage)
+			}
+		})
+	}
+}
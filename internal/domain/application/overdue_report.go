@@ -0,0 +1,90 @@
+package application
+
+import (
+	"sort"
+	"time"
+)
+
+// OverdueTask holds the metadata of an overdue task used in team reports.
+// Descriptions are deliberately absent so private content never reaches managers.
+type OverdueTask struct {
+	TaskID     string
+	Title      string
+	Status     TaskStatus
+	DueDate    time.Time
+	OwnerID    string
+	OwnerName  string
+	OwnerEmail string
+}
+
+// DaysOverdue returns the number of whole days since the task was due
+func (t *OverdueTask) DaysOverdue(now time.Time) int {
+	if !now.After(t.DueDate) {
+		return 0
+	}
+	return int(now.Sub(t.DueDate).Hours() / 24)
+}
+
+// UserOverdueSummary aggregates the overdue tasks of a single user
+type UserOverdueSummary struct {
+	UserID        string
+	Name          string
+	Email         string
+	OverdueCount  int
+	OldestDueDate time.Time
+	Tasks         []*OverdueTask
+}
+
+// OverdueReport lists overdue tasks of a unit grouped by user
+type OverdueReport struct {
+	Unit        string
+	GeneratedAt time.Time
+	Users       []*UserOverdueSummary
+}
+
+// NewOverdueReport groups overdue tasks by owner, listing users with most overdue tasks first
+func NewOverdueReport(unit string, tasks []*OverdueTask, generatedAt time.Time) *OverdueReport {
+	byUser := make(map[string]*UserOverdueSummary)
+	var users []*UserOverdueSummary
+
+	for _, task := range tasks {
+		summary, ok := byUser[task.OwnerID]
+		if !ok {
+			summary = &UserOverdueSummary{
+				UserID: task.OwnerID,
+				Name:   task.OwnerName,
+				Email:  task.OwnerEmail,
+			}
+			byUser[task.OwnerID] = summary
+			users = append(users, summary)
+		}
+
+		summary.OverdueCount++
+		summary.Tasks = append(summary.Tasks, task)
+		if summary.OldestDueDate.IsZero() || task.DueDate.Before(summary.OldestDueDate) {
+			summary.OldestDueDate = task.DueDate
+		}
+	}
+
+	sort.SliceStable(users, func(i, j int) bool {
+		if users[i].OverdueCount != users[j].OverdueCount {
+			return users[i].OverdueCount > users[j].OverdueCount
+		}
+		return users[i].Name < users[j].Name
+	})
+
+	return &OverdueReport{
+		Unit:        unit,
+		GeneratedAt: generatedAt,
+		Users:       users,
+	}
+}
+
+// TotalOverdue returns the number of overdue tasks in the report
+func (r *OverdueReport) TotalOverdue() int {
+	total := 0
+	for _, user := range r.Users {
+		total += user.OverdueCount
+	}
+	return total
+}
@@ -0,0 +1,67 @@
+package application
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewOverdueReport(t *testing.T) {
+	now := time.Date(2025, 6, 11, 12, 0, 0, 0, time.UTC)
+	tasks := []*OverdueTask{
+		{TaskID: "t1", OwnerID: "u1", OwnerName: "Ana", DueDate: now.Add(-48 * time.Hour)},
+		{TaskID: "t2", OwnerID: "u2", OwnerName: "Bruno", DueDate: now.Add(-24 * time.Hour)},
+		{TaskID: "t3", OwnerID: "u2", OwnerName: "Bruno", DueDate: now.Add(-72 * time.Hour)},
+	}
+
+	report := NewOverdueReport("fiscal", tasks, now)
+
+	if report.Unit != "fiscal" {
+		t.Errorf("Unit = %q, want fiscal", report.Unit)
+	}
+	if report.TotalOverdue() != 3 {
+		t.Errorf("TotalOverdue() = %d, want 3", report.TotalOverdue())
+	}
+	if len(report.Users) != 2 {
+		t.Fatalf("expected 2 users, got %d", len(report.Users))
+	}
+
+	// Users with more overdue tasks come first
+	first := report.Users[0]
+	if first.UserID != "u2" || first.OverdueCount != 2 {
+		t.Errorf("expected u2 with 2 tasks first, got %s with %d", first.UserID, first.OverdueCount)
+	}
+	if !first.OldestDueDate.Equal(now.Add(-72 * time.Hour)) {
+		t.Errorf("OldestDueDate = %v, want 3 days ago", first.OldestDueDate)
+	}
+}
+
+func TestNewOverdueReport_Empty(t *testing.T) {
+	report := NewOverdueReport("fiscal", nil, time.Now())
+
+	if len(report.Users) != 0 || report.TotalOverdue() != 0 {
+		t.Errorf("expected empty report, got %+v", report)
+	}
+}
+
+func TestOverdueTask_DaysOverdue(t *testing.T) {
+	now := time.Date(2025, 6, 11, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		dueDate time.Time
+		want    int
+	}{
+		{"due in the future", now.Add(time.Hour), 0},
+		{"due less than a day ago", now.Add(-time.Hour), 0},
+		{"due two days ago", now.Add(-49 * time.Hour), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &OverdueTask{DueDate: tt.dueDate}
+			if got := task.DaysOverdue(now); got != tt.want {
+				t.Errorf("DaysOverdue() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package application
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultProjectColor is the color of projects created without one
+const DefaultProjectColor = "#3b82f6"
+
+var (
+	ErrProjectNotFound          = errors.New("project not found")
+	ErrProjectPermissionDenied  = errors.New("only the project owner can change the project")
+	ErrProjectNameRequired      = errors.New("project name cannot be empty")
+	ErrProjectNameTooLong       = errors.New("project name cannot exceed 100 characters")
+	ErrInvalidProjectColor      = errors.New("project color must be a hex color like #3b82f6")
+	ErrCannotShareProjectToSelf = errors.New("cannot share project with yourself")
+)
+
+// projectColorPattern matches the #rrggbb colors accepted for projects
+var projectColorPattern = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+// Project groups tasks of a user into a list. Sharing a project gives access to all its tasks.
+type Project struct {
+	ID        string
+	Name      string
+	Color     string
+	OwnerID   string
+	OrgID     string // empty for a personal project, in no org
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewProject creates a new Project with validation. An empty color uses DefaultProjectColor.
+func NewProject(id, name, color, ownerID string) (*Project, error) {
+	if id == "" {
+		return nil, errors.New("project id cannot be empty")
+	}
+
+	if ownerID == "" {
+		return nil, errors.New("project owner id cannot be empty")
+	}
+
+	now := time.Now()
+	project := &Project{
+		ID:        id,
+		OwnerID:   ownerID,
+		CreatedAt: now,
+	}
+	if err := project.Update(name, color); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// Update renames and recolors the project with validation
+func (p *Project) Update(name, color string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrProjectNameRequired
+	}
+
+	if len(name) > 100 {
+		return ErrProjectNameTooLong
+	}
+
+	color = strings.ToLower(strings.TrimSpace(color))
+	if color == "" {
+		color = DefaultProjectColor
+	}
+	if !projectColorPattern.MatchString(color) {
+		return ErrInvalidProjectColor
+	}
+
+	p.Name = name
+	p.Color = color
+	p.UpdatedAt = time.Now()
+	return nil
+}
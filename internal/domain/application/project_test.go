@@ -0,0 +1,70 @@
+package application
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewProject(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		projName  string
+		color     string
+		ownerID   string
+		wantColor string
+		wantErr   bool
+		errIs     error
+	}{
+		{"valid project", "proj-1", "Work", "#10B981", "user-1", "#10b981", false, nil},
+		{"default color", "proj-1", "Work", "", "user-1", DefaultProjectColor, false, nil},
+		{"name is trimmed", "proj-1", "  Home  ", "", "user-1", DefaultProjectColor, false, nil},
+		{"empty id", "", "Work", "", "user-1", "", true, nil},
+		{"empty owner", "proj-1", "Work", "", "", "", true, nil},
+		{"blank name", "proj-1", "   ", "", "user-1", "", true, nil},
+		{"name too long", "proj-1", strings.Repeat("a", 101), "", "user-1", "", true, nil},
+		{"color name", "proj-1", "Work", "red", "user-1", "", true, ErrInvalidProjectColor},
+		{"short hex color", "proj-1", "Work", "#fff", "user-1", "", true, ErrInvalidProjectColor},
+		{"css injection", "proj-1", "Work", "#000000;background:url(x)", "user-1", "", true, ErrInvalidProjectColor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, err := NewProject(tt.id, tt.projName, tt.color, tt.ownerID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewProject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.errIs != nil && !errors.Is(err, tt.errIs) {
+				t.Errorf("NewProject() error = %v, want %v", err, tt.errIs)
+			}
+			if tt.wantErr {
+				return
+			}
+			if project.Name != strings.TrimSpace(tt.projName) || project.Color != tt.wantColor {
+				t.Errorf("NewProject() = %q %q, want %q %q", project.Name, project.Color, strings.TrimSpace(tt.projName), tt.wantColor)
+			}
+		})
+	}
+}
+
+func TestProject_Update(t *testing.T) {
+	project, err := NewProject("proj-1", "Work", "", "user-1")
+	if err != nil {
+		t.Fatalf("NewProject() error: %v", err)
+	}
+
+	if err := project.Update("Personal", "#ef4444"); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	if project.Name != "Personal" || project.Color != "#ef4444" {
+		t.Errorf("Update() = %q %q", project.Name, project.Color)
+	}
+
+	if err := project.Update("", "#ef4444"); err == nil {
+		t.Error("Update() with an empty name should fail")
+	}
+	if project.Name != "Personal" {
+		t.Errorf("a failed Update() changed the name to %q", project.Name)
+	}
+}
@@ -0,0 +1,57 @@
+package application
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxRemindersPerTask limits how many pending reminders a user can set on a single task
+const MaxRemindersPerTask = 10
+
+var (
+	ErrReminderNotFound = errors.New("reminder not found")
+	ErrReminderInPast   = errors.New("reminder time must be in the future")
+	ErrTooManyReminders = errors.New("task cannot have more than 10 reminders per user")
+)
+
+// Reminder represents a point in time when a user wants to be reminded of a task
+type Reminder struct {
+	ID        string
+	TaskID    string
+	UserID    string
+	RemindAt  time.Time
+	SentAt    *time.Time // set once the reminder is delivered
+	CreatedAt time.Time
+}
+
+// NewReminder creates a new Reminder with validation
+func NewReminder(id, taskID, userID string, remindAt, now time.Time) (*Reminder, error) {
+	if id == "" {
+		return nil, errors.New("reminder id cannot be empty")
+	}
+
+	if taskID == "" {
+		return nil, errors.New("reminder task id cannot be empty")
+	}
+
+	if userID == "" {
+		return nil, errors.New("reminder user id cannot be empty")
+	}
+
+	if !remindAt.After(now) {
+		return nil, ErrReminderInPast
+	}
+
+	return &Reminder{
+		ID:        id,
+		TaskID:    taskID,
+		UserID:    userID,
+		RemindAt:  remindAt,
+		CreatedAt: now,
+	}, nil
+}
+
+// IsSent reports whether the reminder was already delivered
+func (r *Reminder) IsSent() bool {
+	return r.SentAt != nil
+}
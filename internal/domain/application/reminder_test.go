@@ -0,0 +1,49 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewReminder(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		id       string
+		taskID   string
+		userID   string
+		remindAt time.Time
+		wantErr  bool
+		errIs    error
+	}{
+		{"valid reminder", "rem-1", "task-1", "user-1", now.Add(time.Hour), false, nil},
+		{"empty id", "", "task-1", "user-1", now.Add(time.Hour), true, nil},
+		{"empty task id", "rem-1", "", "user-1", now.Add(time.Hour), true, nil},
+		{"empty user id", "rem-1", "task-1", "", now.Add(time.Hour), true, nil},
+		{"time in the past", "rem-1", "task-1", "user-1", now.Add(-time.Minute), true, ErrReminderInPast},
+		{"time equal to now", "rem-1", "task-1", "user-1", now, true, ErrReminderInPast},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reminder, err := NewReminder(tt.id, tt.taskID, tt.userID, tt.remindAt, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewReminder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.errIs != nil && !errors.Is(err, tt.errIs) {
+				t.Errorf("NewReminder() error = %v, want %v", err, tt.errIs)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reminder.RemindAt.Equal(tt.remindAt) || !reminder.CreatedAt.Equal(now) {
+				t.Errorf("NewReminder() times = %v, %v", reminder.RemindAt, reminder.CreatedAt)
+			}
+			if reminder.IsSent() {
+				t.Error("new reminder should not be sent")
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package application
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrInvitationNotFound is returned when a user answers an invitation they don't have
+	ErrInvitationNotFound = errors.New("invitation not found")
+
+	// ErrCannotShareTaskToSelf is returned when owners invite themselves to their task
+	ErrCannotShareTaskToSelf = errors.New("cannot share task with yourself")
+
+	// ErrTaskAlreadyShared is returned when a user is invited to a task they already share or
+	// were already invited to
+	ErrTaskAlreadyShared = errors.New("task already shared")
+)
+
+// ShareInvitation is an invitation to collaborate on a task. The task is shared with the
+// invited user only once they accept it.
+type ShareInvitation struct {
+	TaskID    string
+	TaskTitle string
+	OwnerID   string
+	OwnerName string
+	UserID    string // the invited user
+	InvitedAt time.Time
+}
+
+// ShareResult is the outcome of inviting one of several users to a task at once
+type ShareResult struct {
+	Recipient string // the email or user ID the user was given by
+	User      *User  // nil when no user matches Recipient
+	Err       error  // why the user wasn't invited; nil when they were
+}
@@ -0,0 +1,75 @@
+package application
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrShareLinkNotFound is returned when a public link doesn't exist, has expired or was
+	// revoked, so the public page doesn't tell them apart
+	ErrShareLinkNotFound = errors.New("share link not found")
+
+	ErrInvalidShareLinkExpiry = errors.New("share link must expire in 1, 7 or 30 days")
+)
+
+// ShareLinkExpiryDays are the lifetimes, in days, a public link can be created with
+var ShareLinkExpiryDays = []int{1, 7, 30}
+
+// ShareLink is a public, read-only link to a task, opened without signing in. Only the hash
+// of its token is stored; the token itself is shown once, when the link is created.
+type ShareLink struct {
+	ID        string
+	TaskID    string
+	CreatedBy string
+	TokenHash string
+	ExpiresAt time.Time
+	RevokedAt *time.Time // set once the owner revokes the link
+	CreatedAt time.Time
+}
+
+// NewShareLink creates a new ShareLink with validation, expiring expiryDays after now
+func NewShareLink(id, taskID, createdBy, tokenHash string, expiryDays int, now time.Time) (*ShareLink, error) {
+	if id == "" {
+		return nil, errors.New("share link id cannot be empty")
+	}
+
+	if taskID == "" {
+		return nil, errors.New("share link task id cannot be empty")
+	}
+
+	if createdBy == "" {
+		return nil, errors.New("share link creator cannot be empty")
+	}
+
+	if tokenHash == "" {
+		return nil, errors.New("share link token hash cannot be empty")
+	}
+
+	if !validShareLinkExpiry(expiryDays) {
+		return nil, ErrInvalidShareLinkExpiry
+	}
+
+	return &ShareLink{
+		ID:        id,
+		TaskID:    taskID,
+		CreatedBy: createdBy,
+		TokenHash: tokenHash,
+		ExpiresAt: now.AddDate(0, 0, expiryDays),
+		CreatedAt: now,
+	}, nil
+}
+
+func validShareLinkExpiry(days int) bool {
+	for _, allowed := range ShareLinkExpiryDays {
+		if days == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActive reports whether the link still opens its task at now
+func (l *ShareLink) IsActive(now time.Time) bool {
+	return l.RevokedAt == nil && now.Before(l.ExpiresAt)
+}
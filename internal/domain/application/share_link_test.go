@@ -0,0 +1,77 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewShareLink(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		id         string
+		taskID     string
+		createdBy  string
+		tokenHash  string
+		expiryDays int
+		wantErr    bool
+		errIs      error
+	}{
+		{"valid link", "link-1", "task-1", "user-1", "hash", 7, false, nil},
+		{"one day", "link-1", "task-1", "user-1", "hash", 1, false, nil},
+		{"thirty days", "link-1", "task-1", "user-1", "hash", 30, false, nil},
+		{"empty id", "", "task-1", "user-1", "hash", 7, true, nil},
+		{"empty task id", "link-1", "", "user-1", "hash", 7, true, nil},
+		{"empty creator", "link-1", "task-1", "", "hash", 7, true, nil},
+		{"empty token hash", "link-1", "task-1", "user-1", "", 7, true, nil},
+		{"unsupported expiry", "link-1", "task-1", "user-1", "hash", 2, true, ErrInvalidShareLinkExpiry},
+		{"no expiry", "link-1", "task-1", "user-1", "hash", 0, true, ErrInvalidShareLinkExpiry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			link, err := NewShareLink(tt.id, tt.taskID, tt.createdBy, tt.tokenHash, tt.expiryDays, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewShareLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.errIs != nil && !errors.Is(err, tt.errIs) {
+				t.Errorf("NewShareLink() error = %v, want %v", err, tt.errIs)
+			}
+			if tt.wantErr {
+				return
+			}
+			if want := now.AddDate(0, 0, tt.expiryDays); !link.ExpiresAt.Equal(want) {
+				t.Errorf("ExpiresAt = %v, want %v", link.ExpiresAt, want)
+			}
+			if !link.IsActive(now) {
+				t.Error("new link should be active")
+			}
+		})
+	}
+}
+
+func TestShareLink_IsActive(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	revokedAt := now.Add(-time.Minute)
+
+	tests := []struct {
+		name string
+		link ShareLink
+		want bool
+	}{
+		{"before expiry", ShareLink{ExpiresAt: now.Add(time.Hour)}, true},
+		{"at expiry", ShareLink{ExpiresAt: now}, false},
+		{"expired", ShareLink{ExpiresAt: now.Add(-time.Hour)}, false},
+		{"revoked", ShareLink{ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.link.IsActive(now); got != tt.want {
+				t.Errorf("IsActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
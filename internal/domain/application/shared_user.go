@@ -0,0 +1,11 @@
+package application
+
+import "time"
+
+// SharedUser is a user a task is shared with, as its owner sees them when managing the shares
+type SharedUser struct {
+	UserID   string
+	Name     string
+	Email    string
+	SharedAt time.Time // when the user accepted the invitation
+}
@@ -0,0 +1,72 @@
+package application
+
+import (
+	"errors"
+	"time"
+)
+
+// SnoozePreset is a common snooze duration, resolved in the user's timezone
+type SnoozePreset string
+
+const (
+	SnoozeTomorrow SnoozePreset = "tomorrow"  // 8:00 tomorrow
+	SnoozeNextWeek SnoozePreset = "next_week" // 8:00 next Monday
+)
+
+// snoozeHour is when snoozed tasks come back on the day a preset ends
+const snoozeHour = 8
+
+var (
+	ErrInvalidSnooze       = errors.New("snooze must end in the future, before 2100")
+	ErrUnknownSnoozePreset = errors.New("snooze preset must be tomorrow or next_week")
+)
+
+// Until returns when a snooze with the preset started at now ends, in the location of now
+func (p SnoozePreset) Until(now time.Time) (time.Time, error) {
+	day := time.Date(now.Year(), now.Month(), now.Day(), snoozeHour, 0, 0, 0, now.Location())
+	switch p {
+	case SnoozeTomorrow:
+		return day.AddDate(0, 0, 1), nil
+	case SnoozeNextWeek:
+		days := (int(time.Monday) - int(day.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return day.AddDate(0, 0, days), nil
+	default:
+		return time.Time{}, ErrUnknownSnoozePreset
+	}
+}
+
+// Snooze hides the task from its owner's task list until until, which must be after now
+func (t *Task) Snooze(until, now time.Time) error {
+	if !until.After(now) || !until.Before(maxDueDate) {
+		return ErrInvalidSnooze
+	}
+
+	t.SnoozedUntil = &until
+	t.UpdatedAt = now
+	return nil
+}
+
+// Wake ends the snooze of the task, bringing it back to the task list
+func (t *Task) Wake(now time.Time) {
+	t.SnoozedUntil = nil
+	t.UpdatedAt = now
+}
+
+// IsSnoozed reports whether the task is hidden from its owner's task list at now
+func (t *Task) IsSnoozed(now time.Time) bool {
+	return t.SnoozedUntil != nil && t.SnoozedUntil.After(now)
+}
+
+// WithoutSnoozed returns the tasks that aren't snoozed at now
+func WithoutSnoozed(tasks []*Task, now time.Time) []*Task {
+	awake := make([]*Task, 0, len(tasks))
+	for _, task := range tasks {
+		if !task.IsSnoozed(now) {
+			awake = append(awake, task)
+		}
+	}
+	return awake
+}
@@ -0,0 +1,102 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSnoozePreset_Until(t *testing.T) {
+	saoPaulo := time.FixedZone("BRT", -3*60*60)
+
+	tests := []struct {
+		name    string
+		preset  SnoozePreset
+		now     time.Time
+		want    time.Time
+		wantErr error
+	}{
+		{"tomorrow", SnoozeTomorrow, time.Date(2026, 10, 14, 22, 30, 0, 0, saoPaulo), time.Date(2026, 10, 15, 8, 0, 0, 0, saoPaulo), nil},
+		{"tomorrow crosses the month", SnoozeTomorrow, time.Date(2026, 10, 31, 7, 0, 0, 0, saoPaulo), time.Date(2026, 11, 1, 8, 0, 0, 0, saoPaulo), nil},
+		{"next week from a Wednesday", SnoozeNextWeek, time.Date(2026, 10, 14, 10, 0, 0, 0, saoPaulo), time.Date(2026, 10, 19, 8, 0, 0, 0, saoPaulo), nil},
+		{"next week from a Sunday", SnoozeNextWeek, time.Date(2026, 10, 18, 10, 0, 0, 0, saoPaulo), time.Date(2026, 10, 19, 8, 0, 0, 0, saoPaulo), nil},
+		{"next week from a Monday", SnoozeNextWeek, time.Date(2026, 10, 19, 7, 0, 0, 0, saoPaulo), time.Date(2026, 10, 26, 8, 0, 0, 0, saoPaulo), nil},
+		{"unknown preset", SnoozePreset("forever"), time.Date(2026, 10, 14, 10, 0, 0, 0, saoPaulo), time.Time{}, ErrUnknownSnoozePreset},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.preset.Until(tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Until() error = %v, want %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Until() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_Snooze(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		until   time.Time
+		wantErr error
+	}{
+		{"future", now.Add(time.Hour), nil},
+		{"now", now, ErrInvalidSnooze},
+		{"past", now.Add(-time.Hour), ErrInvalidSnooze},
+		{"too far", time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), ErrInvalidSnooze},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{ID: "task-1"}
+			err := task.Snooze(tt.until, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Snooze() error = %v, want %v", err, tt.wantErr)
+			}
+			if task.IsSnoozed(now) != (tt.wantErr == nil) {
+				t.Errorf("IsSnoozed() = %v after Snooze() error %v", task.IsSnoozed(now), err)
+			}
+		})
+	}
+}
+
+func TestTask_IsSnoozed(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	until := now.Add(time.Hour)
+	task := &Task{ID: "task-1"}
+
+	if err := task.Snooze(until, now); err != nil {
+		t.Fatal(err)
+	}
+	if !task.IsSnoozed(now) {
+		t.Error("task should be snoozed before the snooze ends")
+	}
+	if task.IsSnoozed(until) {
+		t.Error("task should be awake once the snooze ends")
+	}
+
+	task.Wake(now)
+	if task.IsSnoozed(now) || task.SnoozedUntil != nil {
+		t.Errorf("woken task should not be snoozed, SnoozedUntil = %v", task.SnoozedUntil)
+	}
+}
+
+func TestWithoutSnoozed(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	later, earlier := now.Add(time.Hour), now.Add(-time.Hour)
+	tasks := []*Task{
+		{ID: "awake"},
+		{ID: "snoozed", SnoozedUntil: &later},
+		{ID: "expired", SnoozedUntil: &earlier},
+	}
+
+	got := WithoutSnoozed(tasks, now)
+	if len(got) != 2 || got[0].ID != "awake" || got[1].ID != "expired" {
+		t.Errorf("WithoutSnoozed() = %v, want the awake and expired tasks", got)
+	}
+}
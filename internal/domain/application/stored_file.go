@@ -0,0 +1,77 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// StoredFileKind tells where an uploaded file is kept
+type StoredFileKind string
+
+const (
+	StoredFileImage      StoredFileKind = "image"      // task image, served from /uploads/images
+	StoredFileAttachment StoredFileKind = "attachment" // task attachment, kept in the attachments directory
+)
+
+// ErrStoredFileNotFound is returned when an uploaded file has no record
+var ErrStoredFileNotFound = errors.New("stored file not found")
+
+// StoredFile records an uploaded file kept on disk, counted against the storage quota of the user who uploaded it
+type StoredFile struct {
+	Kind      StoredFileKind
+	Name      string // name of the file inside the directory of its kind
+	UserID    string
+	Size      int64
+	CreatedAt time.Time
+}
+
+// NewStoredFile creates a new StoredFile with validation
+func NewStoredFile(kind StoredFileKind, name, userID string, size int64) (*StoredFile, error) {
+	if kind != StoredFileImage && kind != StoredFileAttachment {
+		return nil, errors.New("invalid stored file kind")
+	}
+
+	if name == "" {
+		return nil, errors.New("stored file name cannot be empty")
+	}
+
+	if userID == "" {
+		return nil, errors.New("stored file user id cannot be empty")
+	}
+
+	if size < 0 {
+		return nil, errors.New("stored file size cannot be negative")
+	}
+
+	return &StoredFile{
+		Kind:      kind,
+		Name:      name,
+		UserID:    userID,
+		Size:      size,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// StorageUsage is the disk space taken by uploaded files
+type StorageUsage struct {
+	Bytes int64
+	Files int
+}
+
+// StorageQuotaExceededError is returned when storing a file would take a user, or the whole
+// server, over its storage quota
+type StorageQuotaExceededError struct {
+	Limit  int64
+	Used   int64
+	Size   int64 // size of the rejected file
+	Global bool  // the server quota was reached, not the user's
+}
+
+// Error implements the error interface
+func (e *StorageQuotaExceededError) Error() string {
+	if e.Global {
+		return fmt.Sprintf("server storage quota of %d bytes exceeded", e.Limit)
+	}
+	return fmt.Sprintf("storage quota of %d bytes exceeded: %d bytes used, file has %d bytes", e.Limit, e.Used, e.Size)
+}
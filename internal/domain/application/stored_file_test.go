@@ -0,0 +1,33 @@
+package application
+
+import "testing"
+
+func TestNewStoredFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    StoredFileKind
+		file    string
+		userID  string
+		size    int64
+		wantErr bool
+	}{
+		{"image", StoredFileImage, "1700000000_abc.png", "user-1", 1024, false},
+		{"attachment", StoredFileAttachment, "f3a9.pdf", "user-1", 1024, false},
+		{"unknown kind", "video", "a.mp4", "user-1", 1024, true},
+		{"empty name", StoredFileImage, "", "user-1", 1024, true},
+		{"empty user", StoredFileImage, "a.png", "", 1024, true},
+		{"negative size", StoredFileImage, "a.png", "user-1", -1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := NewStoredFile(tt.kind, tt.file, tt.userID, tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewStoredFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && file.CreatedAt.IsZero() {
+				t.Error("StoredFile.CreatedAt should not be zero")
+			}
+		})
+	}
+}
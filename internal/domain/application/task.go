@@ -2,6 +2,10 @@ package application
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -14,46 +18,94 @@ const (
 	StatusCompleted  TaskStatus = "completed"
 )
 
+// TaskPriority represents how urgent a task is
+type TaskPriority string
+
+const (
+	PriorityLow    TaskPriority = "low"
+	PriorityNormal TaskPriority = "normal"
+	PriorityHigh   TaskPriority = "high"
+)
+
+// TaskColor is the color a task is flagged with for visual grouping
+type TaskColor string
+
+const (
+	ColorNone   TaskColor = ""
+	ColorRed    TaskColor = "red"
+	ColorOrange TaskColor = "orange"
+	ColorYellow TaskColor = "yellow"
+	ColorGreen  TaskColor = "green"
+	ColorBlue   TaskColor = "blue"
+	ColorPurple TaskColor = "purple"
+	ColorPink   TaskColor = "pink"
+	ColorGray   TaskColor = "gray"
+)
+
+// TaskColors is the palette tasks can be colored with, in the order it is offered
+var TaskColors = []TaskColor{ColorRed, ColorOrange, ColorYellow, ColorGreen, ColorBlue, ColorPurple, ColorPink, ColorGray}
+
+// IsValid reports whether the color is in the palette or ColorNone
+func (c TaskColor) IsValid() bool {
+	return c == ColorNone || slices.Contains(TaskColors, c)
+}
+
+const (
+	// MaxTaskTags is the number of tags a task can have
+	MaxTaskTags = 10
+
+	// maxTagLength is the length of a tag in characters
+	maxTagLength = 30
+)
+
+// tagRegex matches a tag: letters, digits, hyphens and underscores
+var tagRegex = regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)
+
 // Task represents a todo task entity
 type Task struct {
-	ID          string
-	Title       string
-	Description string
-	Status      TaskStatus
-	OwnerID     string
-	ImagePath   string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID             string
+	Title          string
+	Description    string
+	Status         TaskStatus
+	OwnerID        string
+	ImagePath      string
+	ProjectID      string // empty when the task isn't in a project
+	OrgID          string // empty for a personal task, in no org
+	Priority       TaskPriority
+	Tags           []string  // lowercase, without the leading #
+	Color          TaskColor // ColorNone when the task isn't colored
+	DueDate        *time.Time
+	OverdueAt      *time.Time // set by the overdue job when a pending task passes its due date
+	SnoozedUntil   *time.Time // hidden from the owner's task list until then; nil when not snoozed
+	Progress       int        // percent of an open task that is done, 0 to 100; see PercentComplete
+	CompletionNote string     // how the task was completed; empty unless it is completed
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
-// NewTask creates a new Task with validation
-func NewTask(id, title, description string, status TaskStatus, ownerID, imagePath string) (*Task, error) {
-	if id == "" {
-		return nil, errors.New("task id cannot be empty")
-	}
-
-	if title == "" {
-		return nil, errors.New("task title cannot be empty")
-	}
+// ErrTaskNotFound is returned for a task that doesn't exist. Use cases also return it for a task
+// the user can't see, so responses don't reveal which task IDs exist.
+var ErrTaskNotFound = errors.New("task not found")
 
-	if len(title) > 200 {
-		return nil, errors.New("task title cannot exceed 200 characters")
-	}
+var (
+	// minDueDate and maxDueDate bound accepted due dates to catch typos like year 0202
+	minDueDate = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	maxDueDate = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+)
 
-	if len(description) > 1000 {
-		return nil, errors.New("task description cannot exceed 1000 characters")
+// NewTask creates a new Task with validation. A rejected task returns *ValidationError listing
+// every invalid field.
+func NewTask(id, title, description string, status TaskStatus, ownerID, imagePath string) (*Task, error) {
+	var invalid ValidationError
+	if id == "" {
+		invalid.add("id", CodeRequired, "task id cannot be empty")
 	}
-
+	validateTaskFields(&invalid, title, description, status, imagePath)
 	if ownerID == "" {
-		return nil, errors.New("task owner id cannot be empty")
-	}
-
-	if len(imagePath) > 500 {
-		return nil, errors.New("image path cannot exceed 500 characters")
+		invalid.add("owner_id", CodeRequired, "task owner id cannot be empty")
 	}
-
-	if !isValidStatus(status) {
-		return nil, errors.New("invalid task status")
+	if err := invalid.err(); err != nil {
+		return nil, err
 	}
 
 	now := time.Now()
@@ -64,42 +116,138 @@ func NewTask(id, title, description string, status TaskStatus, ownerID, imagePat
 		Status:      status,
 		OwnerID:     ownerID,
 		ImagePath:   imagePath,
+		Priority:    PriorityNormal,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}, nil
 }
 
-// Update updates task fields with validation
+// Update updates task fields with validation. A rejected update returns *ValidationError
+// listing every invalid field and leaves the task unchanged. Leaving the completed status drops
+// the completion note.
 func (t *Task) Update(title, description string, status TaskStatus, imagePath string) error {
+	var invalid ValidationError
+	validateTaskFields(&invalid, title, description, status, imagePath)
+	if err := invalid.err(); err != nil {
+		return err
+	}
+
+	t.Title = title
+	t.Description = description
+	t.Status = status
+	t.ImagePath = imagePath
+	if status != StatusCompleted {
+		t.CompletionNote = ""
+	}
+	t.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// validateTaskFields records the errors of the fields a task is created and updated with
+func validateTaskFields(invalid *ValidationError, title, description string, status TaskStatus, imagePath string) {
 	if title == "" {
-		return errors.New("task title cannot be empty")
+		invalid.add("title", CodeRequired, "task title cannot be empty")
 	}
 
 	if len(title) > 200 {
-		return errors.New("task title cannot exceed 200 characters")
+		invalid.add("title", CodeTooLong, "task title cannot exceed 200 characters")
 	}
 
 	if len(description) > 1000 {
-		return errors.New("task description cannot exceed 1000 characters")
+		invalid.add("description", CodeTooLong, "task description cannot exceed 1000 characters")
 	}
 
 	if len(imagePath) > 500 {
-		return errors.New("image path cannot exceed 500 characters")
+		invalid.add("image_path", CodeTooLong, "image path cannot exceed 500 characters")
 	}
 
 	if !isValidStatus(status) {
-		return errors.New("invalid task status")
+		invalid.add("status", CodeInvalid, "invalid task status")
 	}
+}
 
-	t.Title = title
-	t.Description = description
-	t.Status = status
-	t.ImagePath = imagePath
+// SetDueDate sets or clears (nil) the task due date with validation. Moving the due date to
+// the future, or clearing it, drops the overdue flag.
+func (t *Task) SetDueDate(dueDate *time.Time) error {
+	if dueDate != nil && (dueDate.Before(minDueDate) || !dueDate.Before(maxDueDate)) {
+		return errors.New("due date must be between 2000 and 2099")
+	}
+
+	t.DueDate = dueDate
+	if dueDate == nil || dueDate.After(time.Now()) {
+		t.OverdueAt = nil
+	}
 	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetProject moves the task to a project, or out of any project when projectID is empty.
+// Checking that the project belongs to the task owner is up to the caller.
+func (t *Task) SetProject(projectID string) {
+	t.ProjectID = projectID
+	t.UpdatedAt = time.Now()
+}
 
+// SetPriority changes the task priority with validation
+func (t *Task) SetPriority(priority TaskPriority) error {
+	if priority != PriorityLow && priority != PriorityNormal && priority != PriorityHigh {
+		return errors.New("invalid task priority")
+	}
+
+	t.Priority = priority
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetColor colors the task with a color of the palette, or clears its color with ColorNone. An
+// unknown color returns *ValidationError.
+func (t *Task) SetColor(color TaskColor) error {
+	if !color.IsValid() {
+		var invalid ValidationError
+		invalid.add("color", CodeInvalid, "invalid task color")
+		return invalid.err()
+	}
+
+	t.Color = color
+	t.UpdatedAt = time.Now()
 	return nil
 }
 
+// SetTags replaces the task tags with validation. Tags are stored lowercase, without a leading
+// #, and duplicates are dropped.
+func (t *Task) SetTags(tags []string) error {
+	var normalized []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if len([]rune(tag)) > maxTagLength {
+			return fmt.Errorf("tag cannot exceed %d characters", maxTagLength)
+		}
+		if !tagRegex.MatchString(tag) {
+			return fmt.Errorf("invalid tag %q: use only letters, digits, hyphens and underscores", tag)
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > MaxTaskTags {
+		return fmt.Errorf("a task cannot have more than %d tags", MaxTaskTags)
+	}
+
+	t.Tags = normalized
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsOverdue reports whether the task is not completed and its due date has passed
+func (t *Task) IsOverdue(now time.Time) bool {
+	return t.DueDate != nil && t.Status != StatusCompleted && t.DueDate.Before(now)
+}
+
 // CompleteTask marks the task as completed
 func (t *Task) CompleteTask() error {
 	if t.Status == StatusCompleted {
@@ -0,0 +1,45 @@
+package application
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxBlockersPerTask limits how many tasks can block a single task
+const MaxBlockersPerTask = 20
+
+var (
+	ErrDependencyNotFound = errors.New("dependency not found")
+	ErrDependencyCycle    = errors.New("dependency would create a cycle")
+	ErrTooManyBlockers    = errors.New("task cannot be blocked by more than 20 tasks")
+	ErrTaskBlocked        = errors.New("task is blocked by open tasks")
+)
+
+// TaskDependency records that a task is blocked by another: the task cannot be completed
+// while its blocker is open
+type TaskDependency struct {
+	TaskID    string
+	BlockerID string
+	CreatedAt time.Time
+}
+
+// NewTaskDependency creates a new TaskDependency with validation
+func NewTaskDependency(taskID, blockerID string, now time.Time) (*TaskDependency, error) {
+	if taskID == "" {
+		return nil, errors.New("dependency task id cannot be empty")
+	}
+
+	if blockerID == "" {
+		return nil, errors.New("dependency blocker id cannot be empty")
+	}
+
+	if taskID == blockerID {
+		return nil, ErrDependencyCycle
+	}
+
+	return &TaskDependency{
+		TaskID:    taskID,
+		BlockerID: blockerID,
+		CreatedAt: now,
+	}, nil
+}
@@ -0,0 +1,42 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewTaskDependency(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		taskID    string
+		blockerID string
+		wantErr   bool
+		errIs     error
+	}{
+		{"valid dependency", "task-1", "task-2", false, nil},
+		{"empty task id", "", "task-2", true, nil},
+		{"empty blocker id", "task-1", "", true, nil},
+		{"blocked by itself", "task-1", "task-1", true, ErrDependencyCycle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dependency, err := NewTaskDependency(tt.taskID, tt.blockerID, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewTaskDependency() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.errIs != nil && !errors.Is(err, tt.errIs) {
+				t.Errorf("NewTaskDependency() error = %v, want %v", err, tt.errIs)
+			}
+			if tt.wantErr {
+				return
+			}
+			if dependency.TaskID != tt.taskID || dependency.BlockerID != tt.blockerID || !dependency.CreatedAt.Equal(now) {
+				t.Errorf("NewTaskDependency() = %+v", dependency)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package application
+
+import (
+	"errors"
+	"net/url"
+	"time"
+)
+
+// LinkStatus represents the result of the last check of an external link
+type LinkStatus string
+
+const (
+	LinkStatusOK     LinkStatus = "ok"
+	LinkStatusBroken LinkStatus = "broken"
+)
+
+// TaskLink represents an external URL found in a task description
+type TaskLink struct {
+	TaskID     string
+	URL        string
+	Status     LinkStatus
+	StatusCode int
+	CheckedAt  time.Time
+}
+
+// NewTaskLink creates a new TaskLink with validation
+func NewTaskLink(taskID, rawURL string, status LinkStatus, statusCode int) (*TaskLink, error) {
+	if taskID == "" {
+		return nil, errors.New("task link task id cannot be empty")
+	}
+
+	if rawURL == "" {
+		return nil, errors.New("task link url cannot be empty")
+	}
+
+	if len(rawURL) > 2048 {
+		return nil, errors.New("task link url cannot exceed 2048 characters")
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, errors.New("task link url must be an absolute http or https url")
+	}
+
+	if status != LinkStatusOK && status != LinkStatusBroken {
+		return nil, errors.New("invalid task link status")
+	}
+
+	return &TaskLink{
+		TaskID:     taskID,
+		URL:        rawURL,
+		Status:     status,
+		StatusCode: statusCode,
+		CheckedAt:  time.Now(),
+	}, nil
+}
+
+// IsBroken reports whether the link failed its last check
+func (l *TaskLink) IsBroken() bool {
+	return l.Status == LinkStatusBroken
+}
@@ -0,0 +1,105 @@
+package application
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewTaskLink(t *testing.T) {
+	tests := []struct {
+		name    string
+		taskID  string
+		url     string
+		status  LinkStatus
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:   "valid ok link",
+			taskID: "task-1",
+			url:    "https://example.com/doc",
+			status: LinkStatusOK,
+		},
+		{
+			name:   "valid broken link",
+			taskID: "task-1",
+			url:    "http://example.com",
+			status: LinkStatusBroken,
+		},
+		{
+			name:    "empty task id",
+			taskID:  "",
+			url:     "https://example.com",
+			status:  LinkStatusOK,
+			wantErr: true,
+			errMsg:  "task link task id cannot be empty",
+		},
+		{
+			name:    "empty url",
+			taskID:  "task-1",
+			url:     "",
+			status:  LinkStatusOK,
+			wantErr: true,
+			errMsg:  "task link url cannot be empty",
+		},
+		{
+			name:    "url too long",
+			taskID:  "task-1",
+			url:     "https://example.com/" + strings.Repeat("a", 2048),
+			status:  LinkStatusOK,
+			wantErr: true,
+			errMsg:  "task link url cannot exceed 2048 characters",
+		},
+		{
+			name:    "non http scheme",
+			taskID:  "task-1",
+			url:     "ftp://example.com/file",
+			status:  LinkStatusOK,
+			wantErr: true,
+			errMsg:  "task link url must be an absolute http or https url",
+		},
+		{
+			name:    "missing host",
+			taskID:  "task-1",
+			url:     "http://",
+			status:  LinkStatusOK,
+			wantErr: true,
+			errMsg:  "task link url must be an absolute http or https url",
+		},
+		{
+			name:    "invalid status",
+			taskID:  "task-1",
+			url:     "https://example.com",
+			status:  "unknown",
+			wantErr: true,
+			errMsg:  "invalid task link status",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			link, err := NewTaskLink(tt.taskID, tt.url, tt.status, 200)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NewTaskLink() expected error, got nil")
+					return
+				}
+				if err.Error() != tt.errMsg {
+					t.Errorf("NewTaskLink() error = %v, want %v", err.Error(), tt.errMsg)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("NewTaskLink() unexpected error = %v", err)
+				return
+			}
+			if link.IsBroken() != (tt.status == LinkStatusBroken) {
+				t.Errorf("IsBroken() = %v, want %v", link.IsBroken(), tt.status == LinkStatusBroken)
+			}
+			if link.CheckedAt.IsZero() {
+				t.Error("CheckedAt should be set")
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package application
+
+// TaskListItem is a task as listed on the tasks page of a user, owned by them or shared with
+// them, directly or through a project
+type TaskListItem struct {
+	*TaskView
+	Owned bool
+}
+
+// TaskOverview is the read model of the tasks page: a page of the tasks a user owns and of
+// those shared with them, mixed in the same order, with the counters of the whole list
+type TaskOverview struct {
+	Items    []*TaskListItem
+	Counters TaskCounters
+
+	NextCursor *TaskCursor // where the next page starts; nil on the last page
+}
+
+// Views returns the views of the items of the overview, in order
+func (o *TaskOverview) Views() []*TaskView {
+	views := make([]*TaskView, len(o.Items))
+	for i, item := range o.Items {
+		views[i] = item.TaskView
+	}
+	return views
+}
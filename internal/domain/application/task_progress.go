@@ -0,0 +1,53 @@
+package application
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxCompletionNoteLength is the length of a completion note in characters
+const maxCompletionNoteLength = 500
+
+// SetProgress records how much of the task is done, in percent from 0 to 100. Progress out of
+// range returns *ValidationError.
+func (t *Task) SetProgress(progress int) error {
+	if progress < 0 || progress > 100 {
+		var invalid ValidationError
+		invalid.add("progress", CodeInvalid, "progress must be between 0 and 100")
+		return invalid.err()
+	}
+
+	t.Progress = progress
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetCompletionNote records how a completed task was done, or clears the note when empty.
+// Notes on tasks that aren't completed, or longer than 500 characters, return *ValidationError.
+func (t *Task) SetCompletionNote(note string) error {
+	note = strings.TrimSpace(note)
+	var invalid ValidationError
+	if note != "" && t.Status != StatusCompleted {
+		invalid.add("completion_note", CodeInvalid, "only completed tasks can have a completion note")
+	}
+	if utf8.RuneCountInString(note) > maxCompletionNoteLength {
+		invalid.add("completion_note", CodeTooLong, "completion note cannot exceed 500 characters")
+	}
+	if err := invalid.err(); err != nil {
+		return err
+	}
+
+	t.CompletionNote = note
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// PercentComplete returns how much of the task is done: 100 once completed, its progress
+// otherwise. The progress is kept on completion, so reopening the task brings it back.
+func (t *Task) PercentComplete() int {
+	if t.Status == StatusCompleted {
+		return 100
+	}
+	return t.Progress
+}
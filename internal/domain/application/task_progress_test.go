@@ -0,0 +1,98 @@
+package application
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTask_SetProgress(t *testing.T) {
+	tests := []struct {
+		name     string
+		progress int
+		wantErr  bool
+	}{
+		{"none", 0, false},
+		{"partial", 40, false},
+		{"all", 100, false},
+		{"negative", -1, true},
+		{"over 100", 101, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{Status: StatusInProgress, Progress: 10}
+
+			err := task.SetProgress(tt.progress)
+			if tt.wantErr {
+				var invalid *ValidationError
+				if !errors.As(err, &invalid) || invalid.Fields[0].Field != "progress" {
+					t.Fatalf("SetProgress() error = %v, want a validation error on progress", err)
+				}
+				if task.Progress != 10 {
+					t.Errorf("Progress = %d, want it unchanged", task.Progress)
+				}
+				return
+			}
+			if err != nil || task.Progress != tt.progress {
+				t.Errorf("SetProgress() = %v with progress %d, want %d", err, task.Progress, tt.progress)
+			}
+		})
+	}
+}
+
+func TestTask_SetCompletionNote(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   TaskStatus
+		note     string
+		want     string
+		wantCode string
+	}{
+		{"completed task", StatusCompleted, "  Entregue ao cliente ", "Entregue ao cliente", ""},
+		{"cleared", StatusCompleted, "", "", ""},
+		{"open task", StatusInProgress, "Quase lá", "", CodeInvalid},
+		{"open task without a note", StatusPending, "", "", ""},
+		{"too long", StatusCompleted, strings.Repeat("é", 501), "", CodeTooLong},
+		{"longest", StatusCompleted, strings.Repeat("é", 500), strings.Repeat("é", 500), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{Status: tt.status}
+
+			err := task.SetCompletionNote(tt.note)
+			if tt.wantCode != "" {
+				var invalid *ValidationError
+				if !errors.As(err, &invalid) || invalid.Fields[0].Code != tt.wantCode {
+					t.Fatalf("SetCompletionNote() error = %v, want %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil || task.CompletionNote != tt.want {
+				t.Errorf("SetCompletionNote() = %v with note %q, want %q", err, task.CompletionNote, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_PercentComplete(t *testing.T) {
+	task := &Task{Title: "Relatório", Status: StatusInProgress, Progress: 40}
+	if got := task.PercentComplete(); got != 40 {
+		t.Errorf("PercentComplete() of an open task = %d, want 40", got)
+	}
+
+	task.CompleteTask()
+	task.SetCompletionNote("Entregue")
+	if got := task.PercentComplete(); got != 100 {
+		t.Errorf("PercentComplete() of a completed task = %d, want 100", got)
+	}
+
+	// Reopening brings the progress back and drops the note
+	if err := task.Update(task.Title, "", StatusInProgress, ""); err != nil {
+		t.Fatal(err)
+	}
+	if task.PercentComplete() != 40 || task.CompletionNote != "" {
+		t.Errorf("reopened task = %d%% with note %q, want 40%% without a note", task.PercentComplete(), task.CompletionNote)
+	}
+}
@@ -0,0 +1,226 @@
+package application
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TaskSort is the order of a task list
+type TaskSort string
+
+const (
+	SortNewest  TaskSort = "-created_at" // Newest first, the default
+	SortOldest  TaskSort = "created_at"
+	SortDueDate TaskSort = "due_date" // Soonest due first, tasks without a due date last
+	SortTitle   TaskSort = "title"
+)
+
+const (
+	// DefaultTasksPerPage is the page size of task lists that don't ask for one
+	DefaultTasksPerPage = 50
+
+	// MaxTasksPerPage caps the page size of task lists
+	MaxTasksPerPage = 100
+
+	// maxTaskSearchLength caps the text searched in task titles and descriptions
+	maxTaskSearchLength = 100
+)
+
+var (
+	ErrInvalidStatusFilter = errors.New("status must be pending, in_progress or completed")
+	ErrInvalidColorFilter  = errors.New("color must be red, orange, yellow, green, blue, purple, pink or gray")
+	ErrInvalidTaskSort     = errors.New("sort must be -created_at, created_at, due_date or title")
+	ErrInvalidTaskPage     = errors.New("page and per_page must be positive, with per_page up to 100")
+	ErrTaskSearchTooLong   = errors.New("search cannot exceed 100 characters")
+	ErrInvalidTaskLimit    = errors.New("limit must be between 1 and 100")
+	ErrInvalidTaskCursor   = errors.New("invalid cursor")
+	ErrCursorTaskSort      = errors.New("cursor pagination supports only the -created_at and created_at sorts")
+)
+
+// TaskListQuery filters, orders and paginates a task list. Empty filters match every task.
+type TaskListQuery struct {
+	Status  TaskStatus
+	Tag     string // Lowercase, without the leading #
+	Color   TaskColor
+	Search  string // Matched against the title and the description, ignoring ASCII case
+	Sort    TaskSort
+	Page    int // 1-based
+	PerPage int
+
+	// Cursor pagination, set by WithCursor: the page holds the PerPage tasks after After,
+	// and Page stays 1. Unlike offsets, cursors don't skip or repeat tasks when tasks are
+	// created or deleted between pages.
+	Cursor bool
+	After  *TaskCursor // nil starts at the first task
+}
+
+// NewTaskListQuery creates a task list query with validation. Empty values take the
+// defaults: every status and tag, newest first, the first page of DefaultTasksPerPage.
+func NewTaskListQuery(status, tag, search, sort string, page, perPage int) (TaskListQuery, error) {
+	query := TaskListQuery{
+		Status:  TaskStatus(status),
+		Tag:     strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#")),
+		Search:  strings.TrimSpace(search),
+		Sort:    TaskSort(sort),
+		Page:    page,
+		PerPage: perPage,
+	}
+	if query.Sort == "" {
+		query.Sort = SortNewest
+	}
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PerPage == 0 {
+		query.PerPage = DefaultTasksPerPage
+	}
+
+	if query.Status != "" && !isValidStatus(query.Status) {
+		return TaskListQuery{}, ErrInvalidStatusFilter
+	}
+	switch query.Sort {
+	case SortNewest, SortOldest, SortDueDate, SortTitle:
+	default:
+		return TaskListQuery{}, ErrInvalidTaskSort
+	}
+	if query.Page < 1 || query.PerPage < 1 || query.PerPage > MaxTasksPerPage {
+		return TaskListQuery{}, ErrInvalidTaskPage
+	}
+	if len([]rune(query.Search)) > maxTaskSearchLength {
+		return TaskListQuery{}, ErrTaskSearchTooLong
+	}
+
+	return query, nil
+}
+
+// WithCursor switches the query to cursor pagination, the page starting after the task after
+// points to (nil for the first page) and holding up to limit tasks; limit 0 takes
+// DefaultTasksPerPage. Cursors only follow the creation time, so the list must be sorted by it.
+func (q TaskListQuery) WithCursor(after *TaskCursor, limit int) (TaskListQuery, error) {
+	if limit == 0 {
+		limit = DefaultTasksPerPage
+	}
+	if limit < 1 || limit > MaxTasksPerPage {
+		return TaskListQuery{}, ErrInvalidTaskLimit
+	}
+	if q.Sort != SortNewest && q.Sort != SortOldest {
+		return TaskListQuery{}, ErrCursorTaskSort
+	}
+
+	q.Cursor, q.After = true, after
+	q.Page, q.PerPage = 1, limit
+	return q, nil
+}
+
+// WithColor narrows the query to the tasks colored with color, one of the palette; an empty
+// color matches every task
+func (q TaskListQuery) WithColor(color string) (TaskListQuery, error) {
+	q.Color = TaskColor(strings.ToLower(strings.TrimSpace(color)))
+	if !q.Color.IsValid() {
+		return TaskListQuery{}, ErrInvalidColorFilter
+	}
+	return q, nil
+}
+
+// Offset returns how many tasks come before the page
+func (q TaskListQuery) Offset() int {
+	return (q.Page - 1) * q.PerPage
+}
+
+// TaskPage is a page of a task list and the size of the whole list
+type TaskPage struct {
+	Views   []*TaskView
+	Total   int // Not counted in cursor pagination
+	Page    int
+	PerPage int
+
+	NextCursor *TaskCursor // Cursor pagination: where the next page starts; nil on the last page
+}
+
+// HasNext reports whether there are tasks after the page
+func (p *TaskPage) HasNext() bool {
+	return p.Page*p.PerPage < p.Total
+}
+
+// HasPrevious reports whether there are tasks before the page
+func (p *TaskPage) HasPrevious() bool {
+	return p.Page > 1
+}
+
+// TaskCursor points to a task in a list sorted by creation time, with the ID breaking ties
+type TaskCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// Cursor returns the cursor pointing to the task
+func (t *Task) Cursor() *TaskCursor {
+	return &TaskCursor{CreatedAt: t.CreatedAt, ID: t.ID}
+}
+
+// follows reports whether the task comes after the cursor in a list sorted by order, which
+// must be SortNewest or SortOldest; ties on the creation time are sorted by ID
+func (c *TaskCursor) follows(t *Task, order TaskSort) bool {
+	if t.CreatedAt.Equal(c.CreatedAt) {
+		return t.ID > c.ID
+	}
+	if order == SortOldest {
+		return t.CreatedAt.After(c.CreatedAt)
+	}
+	return t.CreatedAt.Before(c.CreatedAt)
+}
+
+// CursorPage returns the page of tasks selected by a query in cursor pagination, and the
+// cursor of the next page, nil on the last one. tasks is the whole list, in any order.
+func CursorPage(tasks []*Task, query TaskListQuery) ([]*Task, *TaskCursor) {
+	sorted := make([]*Task, 0, len(tasks))
+	for _, task := range tasks {
+		if query.After == nil || query.After.follows(task, query.Sort) {
+			sorted = append(sorted, task)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cursor().follows(sorted[j], query.Sort)
+	})
+
+	if len(sorted) <= query.PerPage {
+		return sorted, nil
+	}
+	page := sorted[:query.PerPage]
+	return page, page[len(page)-1].Cursor()
+}
+
+// TaskExportFilter scopes an export of the tasks of a user. Empty fields match every task.
+type TaskExportFilter struct {
+	ProjectID string
+	Tag       string // Lowercase, without the leading #
+	Status    TaskStatus
+}
+
+// NewTaskExportFilter creates a task export filter with validation. The tag is normalized
+// like the tag filter of task lists.
+func NewTaskExportFilter(projectID, tag, status string) (TaskExportFilter, error) {
+	filter := TaskExportFilter{
+		ProjectID: strings.TrimSpace(projectID),
+		Tag:       strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#")),
+		Status:    TaskStatus(status),
+	}
+	if filter.Status != "" && !isValidStatus(filter.Status) {
+		return TaskExportFilter{}, ErrInvalidStatusFilter
+	}
+	return filter, nil
+}
+
+// Matches reports whether a task is in the scope of the filter
+func (f TaskExportFilter) Matches(task *Task) bool {
+	if f.ProjectID != "" && task.ProjectID != f.ProjectID {
+		return false
+	}
+	if f.Status != "" && task.Status != f.Status {
+		return false
+	}
+	return f.Tag == "" || slices.Contains(task.Tags, f.Tag)
+}
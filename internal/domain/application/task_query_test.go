@@ -0,0 +1,214 @@
+package application
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewTaskListQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		tag     string
+		search  string
+		sort    string
+		page    int
+		perPage int
+		want    TaskListQuery
+		wantErr error
+	}{
+		{name: "defaults", want: TaskListQuery{Sort: SortNewest, Page: 1, PerPage: DefaultTasksPerPage}},
+		{name: "tag is normalized", tag: " #Work ", want: TaskListQuery{Tag: "work", Sort: SortNewest, Page: 1, PerPage: DefaultTasksPerPage}},
+		{name: "every filter", status: "completed", search: " report ", sort: "title", page: 3, perPage: 10, want: TaskListQuery{Status: StatusCompleted, Search: "report", Sort: SortTitle, Page: 3, PerPage: 10}},
+		{name: "unknown status", status: "done", wantErr: ErrInvalidStatusFilter},
+		{name: "unknown sort", sort: "owner", wantErr: ErrInvalidTaskSort},
+		{name: "negative page", page: -1, wantErr: ErrInvalidTaskPage},
+		{name: "page too large", perPage: MaxTasksPerPage + 1, wantErr: ErrInvalidTaskPage},
+		{name: "search too long", search: strings.Repeat("a", 101), wantErr: ErrTaskSearchTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := NewTaskListQuery(tt.status, tt.tag, tt.search, tt.sort, tt.page, tt.perPage)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewTaskListQuery() error = %v, want %v", err, tt.wantErr)
+			}
+			if query != tt.want {
+				t.Errorf("NewTaskListQuery() = %+v, want %+v", query, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskPage_Neighbours(t *testing.T) {
+	tests := []struct {
+		name         string
+		page         TaskPage
+		wantNext     bool
+		wantPrevious bool
+	}{
+		{"only page", TaskPage{Total: 3, Page: 1, PerPage: 50}, false, false},
+		{"first of two", TaskPage{Total: 60, Page: 1, PerPage: 50}, true, false},
+		{"last of two", TaskPage{Total: 60, Page: 2, PerPage: 50}, false, true},
+		{"past the end", TaskPage{Total: 60, Page: 4, PerPage: 50}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.page.HasNext(); got != tt.wantNext {
+				t.Errorf("HasNext() = %v, want %v", got, tt.wantNext)
+			}
+			if got := tt.page.HasPrevious(); got != tt.wantPrevious {
+				t.Errorf("HasPrevious() = %v, want %v", got, tt.wantPrevious)
+			}
+		})
+	}
+}
+
+func TestTaskListQuery_WithCursor(t *testing.T) {
+	after := &TaskCursor{CreatedAt: time.Now(), ID: "task-1"}
+
+	tests := []struct {
+		name        string
+		sort        string
+		limit       int
+		wantPerPage int
+		wantErr     error
+	}{
+		{name: "default limit", wantPerPage: DefaultTasksPerPage},
+		{name: "oldest first", sort: "created_at", limit: 10, wantPerPage: 10},
+		{name: "limit too big", limit: 101, wantErr: ErrInvalidTaskLimit},
+		{name: "negative limit", limit: -1, wantErr: ErrInvalidTaskLimit},
+		{name: "sort without cursors", sort: "title", wantErr: ErrCursorTaskSort},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _ := NewTaskListQuery("", "", "", tt.sort, 3, 20)
+			query, err := query.WithCursor(after, tt.limit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WithCursor() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !query.Cursor || query.After != after || query.Page != 1 || query.PerPage != tt.wantPerPage {
+				t.Errorf("WithCursor() = %+v", query)
+			}
+		})
+	}
+}
+
+func TestTaskListQuery_WithColor(t *testing.T) {
+	tests := []struct {
+		color   string
+		want    TaskColor
+		wantErr error
+	}{
+		{"", ColorNone, nil},
+		{"red", ColorRed, nil},
+		{" Blue ", ColorBlue, nil},
+		{"teal", "", ErrInvalidColorFilter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.color, func(t *testing.T) {
+			query, _ := NewTaskListQuery("", "", "", "", 0, 0)
+			query, err := query.WithColor(tt.color)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WithColor() error = %v, want %v", err, tt.wantErr)
+			}
+			if query.Color != tt.want {
+				t.Errorf("WithColor() color = %q, want %q", query.Color, tt.want)
+			}
+		})
+	}
+}
+
+func TestCursorPage(t *testing.T) {
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	tasks := []*Task{
+		{ID: "b", CreatedAt: base.Add(time.Minute)},
+		{ID: "d", CreatedAt: base.Add(2 * time.Minute)},
+		{ID: "a", CreatedAt: base},
+		{ID: "c", CreatedAt: base.Add(time.Minute)}, // Same time as b: the ID breaks the tie
+	}
+
+	tests := []struct {
+		name     string
+		sort     string
+		after    *TaskCursor
+		limit    int
+		wantIDs  string
+		wantNext string
+	}{
+		{name: "newest first", limit: 2, wantIDs: "d,b", wantNext: "b"},
+		{name: "after a tie", after: &TaskCursor{CreatedAt: base.Add(time.Minute), ID: "b"}, limit: 2, wantIDs: "c,a"},
+		{name: "oldest first", sort: "created_at", limit: 3, wantIDs: "a,b,c", wantNext: "c"},
+		{name: "after the last task", sort: "created_at", after: &TaskCursor{CreatedAt: base.Add(2 * time.Minute), ID: "d"}, limit: 3},
+		{name: "cursor of a deleted task", after: &TaskCursor{CreatedAt: base.Add(90 * time.Second), ID: "x"}, limit: 5, wantIDs: "b,c,a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _ := NewTaskListQuery("", "", "", tt.sort, 0, 0)
+			query, err := query.WithCursor(tt.after, tt.limit)
+			if err != nil {
+				t.Fatalf("WithCursor() error: %v", err)
+			}
+
+			page, next := CursorPage(tasks, query)
+			var ids []string
+			for _, task := range page {
+				ids = append(ids, task.ID)
+			}
+			if strings.Join(ids, ",") != tt.wantIDs {
+				t.Errorf("CursorPage() = %v, want %s", ids, tt.wantIDs)
+			}
+			var nextID string
+			if next != nil {
+				nextID = next.ID
+			}
+			if nextID != tt.wantNext {
+				t.Errorf("next cursor at %q, want %q", nextID, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestTaskExportFilter(t *testing.T) {
+	task := &Task{ID: "task-1", Status: StatusPending, ProjectID: "project-1", Tags: []string{"receita"}}
+
+	tests := []struct {
+		name      string
+		projectID string
+		tag       string
+		status    string
+		wantErr   error
+		wantMatch bool
+	}{
+		{name: "no filter", wantMatch: true},
+		{name: "matching scope", projectID: "project-1", tag: " #Receita ", status: "pending", wantMatch: true},
+		{name: "other project", projectID: "project-2"},
+		{name: "other tag", tag: "urgente"},
+		{name: "other status", status: "completed"},
+		{name: "invalid status", status: "done", wantErr: ErrInvalidStatusFilter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewTaskExportFilter(tt.projectID, tt.tag, tt.status)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewTaskExportFilter() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := filter.Matches(task); got != tt.wantMatch {
+				t.Errorf("Matches() = %v, want %v", got, tt.wantMatch)
+			}
+		})
+	}
+}
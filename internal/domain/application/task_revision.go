@@ -0,0 +1,58 @@
+package application
+
+import "time"
+
+// TaskRevision is a version of the title, description and status of a task, kept when an edit
+// changes one of them. The history of a task is its revisions followed by the task as it is.
+type TaskRevision struct {
+	ID          int64 // Increases with the edits of the task
+	TaskID      string
+	Title       string
+	Description string
+	Status      TaskStatus
+	EditedBy    string    // User whose edit replaced this version
+	EditorName  string    // Name of EditedBy, set when listing; empty once the user is gone
+	ReplacedAt  time.Time // When the edit replaced this version
+}
+
+// NewTaskRevision returns the version of a task an edit of editedBy is about to replace at now
+func NewTaskRevision(task *Task, editedBy string, now time.Time) *TaskRevision {
+	return &TaskRevision{
+		TaskID:      task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      task.Status,
+		EditedBy:    editedBy,
+		ReplacedAt:  now,
+	}
+}
+
+// ChangedBy reports whether task, once edited, differs from the revision in its title,
+// description or status, the fields the history keeps
+func (r *TaskRevision) ChangedBy(task *Task) bool {
+	return r.Title != task.Title || r.Description != task.Description || r.Status != task.Status
+}
+
+// TaskHistory is a task as it is with its revisions, newest first
+type TaskHistory struct {
+	Task      *Task
+	Revisions []*TaskRevision
+}
+
+// TaskChange is an edit of a task: the version it replaced and the one it made
+type TaskChange struct {
+	Before *TaskRevision
+	After  *TaskRevision
+}
+
+// Changes returns the edits kept in the history, newest first. Each revision was replaced by
+// the next newer one, and the newest by the task as it is.
+func (h *TaskHistory) Changes() []TaskChange {
+	changes := make([]TaskChange, 0, len(h.Revisions))
+	after := NewTaskRevision(h.Task, "", h.Task.UpdatedAt)
+	for _, revision := range h.Revisions {
+		changes = append(changes, TaskChange{Before: revision, After: after})
+		after = revision
+	}
+	return changes
+}
@@ -0,0 +1,58 @@
+package application
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTaskRevision_ChangedBy(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		edit func(task *Task)
+		want bool
+	}{
+		{"title", func(task *Task) { task.Title = "Relatório final" }, true},
+		{"description", func(task *Task) { task.Description = "Enviar ao cliente" }, true},
+		{"status", func(task *Task) { task.Status = StatusCompleted }, true},
+		{"other fields", func(task *Task) { task.Progress = 50; task.Color = ColorGreen }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, _ := NewTask("task-1", "Relatório", "Revisar", StatusPending, "user-1", "")
+			revision := NewTaskRevision(task, "user-2", now)
+			tt.edit(task)
+
+			if got := revision.ChangedBy(task); got != tt.want {
+				t.Errorf("ChangedBy() = %v, want %v", got, tt.want)
+			}
+			if revision.Title != "Relatório" || revision.EditedBy != "user-2" || !revision.ReplacedAt.Equal(now) {
+				t.Errorf("NewTaskRevision() = %+v, want the version before the edit", revision)
+			}
+		})
+	}
+}
+
+func TestTaskHistory_Changes(t *testing.T) {
+	task, _ := NewTask("task-1", "v3", "", StatusCompleted, "user-1", "")
+	v2 := &TaskRevision{ID: 2, Title: "v2", EditedBy: "user-1"}
+	v1 := &TaskRevision{ID: 1, Title: "v1", EditedBy: "user-2"}
+	history := &TaskHistory{Task: task, Revisions: []*TaskRevision{v2, v1}}
+
+	changes := history.Changes()
+	if len(changes) != 2 {
+		t.Fatalf("Changes() = %d changes, want 2", len(changes))
+	}
+	if changes[0].Before != v2 || changes[0].After.Title != "v3" || changes[0].After.Status != StatusCompleted {
+		t.Errorf("newest change = %+v -> %+v, want v2 -> the task", changes[0].Before, changes[0].After)
+	}
+	if changes[1].Before != v1 || changes[1].After != v2 {
+		t.Errorf("oldest change = %+v -> %+v, want v1 -> v2", changes[1].Before, changes[1].After)
+	}
+
+	if changes := (&TaskHistory{Task: task}).Changes(); len(changes) != 0 {
+		t.Errorf("Changes() without revisions = %v, want none", changes)
+	}
+}
@@ -1,6 +1,7 @@
 package application
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -478,3 +479,173 @@ func TestTask_ReplaceImage(t *testing.T) {
 	}
 }
 
+
+func TestTask_SetDueDate(t *testing.T) {
+	valid := time.Date(2026, 5, 10, 23, 59, 59, 0, time.UTC)
+	tooEarly := time.Date(1999, 12, 31, 0, 0, 0, 0, time.UTC)
+	tooLate := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		dueDate *time.Time
+		wantErr bool
+	}{
+		{name: "valid due date", dueDate: &valid},
+		{name: "clear due date", dueDate: nil},
+		{name: "due date before 2000", dueDate: &tooEarly, wantErr: true},
+		{name: "due date from 2100", dueDate: &tooLate, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, _ := NewTask("task-1", "Title", "", StatusPending, "user-1", "")
+			err := task.SetDueDate(tt.dueDate)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("SetDueDate() expected error, got nil")
+				}
+				if task.DueDate != nil {
+					t.Error("SetDueDate() should not change the due date on error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetDueDate() unexpected error = %v", err)
+			}
+			if task.DueDate != tt.dueDate {
+				t.Errorf("DueDate = %v, want %v", task.DueDate, tt.dueDate)
+			}
+		})
+	}
+}
+
+func TestTask_SetDueDate_ClearsOverdueFlag(t *testing.T) {
+	flagged := time.Now().Add(-time.Hour)
+	past := time.Now().Add(-24 * time.Hour)
+	future := time.Now().Add(24 * time.Hour)
+
+	tests := []struct {
+		name        string
+		dueDate     *time.Time
+		wantFlagged bool
+	}{
+		{name: "moved to the future", dueDate: &future, wantFlagged: false},
+		{name: "cleared", dueDate: nil, wantFlagged: false},
+		{name: "still in the past", dueDate: &past, wantFlagged: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, _ := NewTask("task-1", "Title", "", StatusPending, "user-1", "")
+			task.OverdueAt = &flagged
+
+			if err := task.SetDueDate(tt.dueDate); err != nil {
+				t.Fatalf("SetDueDate() unexpected error = %v", err)
+			}
+			if got := task.OverdueAt != nil; got != tt.wantFlagged {
+				t.Errorf("flagged = %v, want %v", got, tt.wantFlagged)
+			}
+		})
+	}
+}
+
+func TestTask_IsOverdue(t *testing.T) {
+	now := time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		status  TaskStatus
+		dueDate *time.Time
+		want    bool
+	}{
+		{name: "no due date", status: StatusPending, dueDate: nil, want: false},
+		{name: "pending past due", status: StatusPending, dueDate: &past, want: true},
+		{name: "in progress past due", status: StatusInProgress, dueDate: &past, want: true},
+		{name: "completed past due", status: StatusCompleted, dueDate: &past, want: false},
+		{name: "pending future due", status: StatusPending, dueDate: &future, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, _ := NewTask("task-1", "Title", "", tt.status, "user-1", "")
+			task.DueDate = tt.dueDate
+			if got := task.IsOverdue(now); got != tt.want {
+				t.Errorf("IsOverdue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_SetPriority(t *testing.T) {
+	task, _ := NewTask("task-1", "Title", "", StatusPending, "user-1", "")
+	if task.Priority != PriorityNormal {
+		t.Fatalf("new task priority = %q, want normal", task.Priority)
+	}
+
+	if err := task.SetPriority(PriorityHigh); err != nil || task.Priority != PriorityHigh {
+		t.Errorf("SetPriority(high) = %v, priority %q", err, task.Priority)
+	}
+	if err := task.SetPriority("urgent"); err == nil {
+		t.Error("SetPriority(urgent) should fail")
+	}
+	if task.Priority != PriorityHigh {
+		t.Errorf("invalid priority changed the task to %q", task.Priority)
+	}
+}
+
+func TestTask_SetTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "normalizes case and #", tags: []string{"#Compras", "casa"}, want: []string{"compras", "casa"}},
+		{name: "drops duplicates and blanks", tags: []string{"casa", "#casa", " ", "CASA"}, want: []string{"casa"}},
+		{name: "accents and separators", tags: []string{"reunião", "q3-2025", "sem_prazo"}, want: []string{"reunião", "q3-2025", "sem_prazo"}},
+		{name: "clears tags", tags: nil, want: nil},
+		{name: "invalid character", tags: []string{"a,b"}, wantErr: true},
+		{name: "too long", tags: []string{strings.Repeat("a", 31)}, wantErr: true},
+		{name: "too many", tags: []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, _ := NewTask("task-1", "Title", "", StatusPending, "user-1", "")
+			err := task.SetTags(tt.tags)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if strings.Join(task.Tags, ",") != strings.Join(tt.want, ",") || len(task.Tags) != len(tt.want) {
+				t.Errorf("Tags = %q, want %q", task.Tags, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_SetColor(t *testing.T) {
+	task, _ := NewTask("task-1", "Title", "", StatusPending, "user-1", "")
+	if task.Color != ColorNone {
+		t.Fatalf("new task color = %q, want none", task.Color)
+	}
+
+	if err := task.SetColor(ColorPurple); err != nil || task.Color != ColorPurple {
+		t.Errorf("SetColor(purple) = %v, color %q", err, task.Color)
+	}
+	err := task.SetColor("teal")
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) || len(invalid.Fields) != 1 || invalid.Fields[0].Field != "color" || invalid.Fields[0].Code != CodeInvalid {
+		t.Errorf("SetColor(teal) = %v, want an invalid color field error", err)
+	}
+	if task.Color != ColorPurple {
+		t.Errorf("invalid color changed the task to %q", task.Color)
+	}
+	if err := task.SetColor(ColorNone); err != nil || task.Color != ColorNone {
+		t.Errorf("SetColor(none) = %v, color %q", err, task.Color)
+	}
+}
@@ -0,0 +1,32 @@
+package application
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrCannotTransferToSelf is returned when the owner of a task tries to transfer it to themselves
+	ErrCannotTransferToSelf = errors.New("task is already owned by this user")
+
+	// ErrTransferCompletedTask is returned when a completed task is transferred without forcing it
+	ErrTransferCompletedTask = errors.New("completed tasks can only be transferred when forced")
+)
+
+// TransferTo makes newOwnerID the owner of the task. Completed tasks are only transferred when
+// force is set. The task leaves its project and its snooze ends, since both belong to the
+// previous owner.
+func (t *Task) TransferTo(newOwnerID string, force bool, now time.Time) error {
+	if newOwnerID == t.OwnerID {
+		return ErrCannotTransferToSelf
+	}
+	if t.Status == StatusCompleted && !force {
+		return ErrTransferCompletedTask
+	}
+
+	t.OwnerID = newOwnerID
+	t.ProjectID = ""
+	t.SnoozedUntil = nil
+	t.UpdatedAt = now
+	return nil
+}
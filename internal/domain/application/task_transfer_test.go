@@ -0,0 +1,45 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTask_TransferTo(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		status   TaskStatus
+		newOwner string
+		force    bool
+		wantErr  error
+	}{
+		{"pending task", StatusPending, "user-2", false, nil},
+		{"completed task", StatusCompleted, "user-2", false, ErrTransferCompletedTask},
+		{"completed task forced", StatusCompleted, "user-2", true, nil},
+		{"to the owner", StatusPending, "user-1", true, ErrCannotTransferToSelf},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snoozed := now.Add(time.Hour)
+			task := &Task{ID: "task-1", OwnerID: "user-1", Status: tt.status, ProjectID: "project-1", SnoozedUntil: &snoozed}
+
+			err := task.TransferTo(tt.newOwner, tt.force, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TransferTo() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if task.OwnerID != "user-1" || task.ProjectID != "project-1" {
+					t.Errorf("task = %+v, want it unchanged", task)
+				}
+				return
+			}
+			if task.OwnerID != tt.newOwner || task.ProjectID != "" || task.SnoozedUntil != nil || !task.UpdatedAt.Equal(now) {
+				t.Errorf("task = %+v, want it owned by %s, out of the project and awake", task, tt.newOwner)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package application
+
+import (
+	"errors"
+	"time"
+)
+
+// UndoAction is an action on a task that can be undone for a short while
+type UndoAction string
+
+const (
+	UndoDelete   UndoAction = "delete"
+	UndoComplete UndoAction = "complete"
+)
+
+var ErrUndoExpired = errors.New("this action can no longer be undone")
+
+// TaskUndo lets the user who deleted or completed a task take the action back until ExpiresAt.
+// A deleted task is only hidden until then; a completed task goes back to PreviousStatus.
+type TaskUndo struct {
+	TaskID         string
+	UserID         string
+	Action         UndoAction
+	PreviousStatus TaskStatus // Status of the task before it was completed
+	ExpiresAt      time.Time
+}
+
+// NewTaskUndo creates the undo of an action a user just took on a task, available for window
+func NewTaskUndo(taskID, userID string, action UndoAction, previousStatus TaskStatus, now time.Time, window time.Duration) (*TaskUndo, error) {
+	if taskID == "" || userID == "" {
+		return nil, errors.New("undo task and user ids cannot be empty")
+	}
+
+	if action != UndoDelete && action != UndoComplete {
+		return nil, errors.New("invalid undo action")
+	}
+
+	if window <= 0 {
+		return nil, errors.New("undo window must be positive")
+	}
+
+	return &TaskUndo{
+		TaskID:         taskID,
+		UserID:         userID,
+		Action:         action,
+		PreviousStatus: previousStatus,
+		ExpiresAt:      now.Add(window),
+	}, nil
+}
+
+// IsExpired reports whether the action can no longer be undone at now
+func (u *TaskUndo) IsExpired(now time.Time) bool {
+	return !now.Before(u.ExpiresAt)
+}
+
+// SecondsLeft returns how many whole seconds are left to undo the action at now, rounded up
+func (u *TaskUndo) SecondsLeft(now time.Time) int {
+	if u.IsExpired(now) {
+		return 0
+	}
+	return int((u.ExpiresAt.Sub(now) + time.Second - 1) / time.Second)
+}
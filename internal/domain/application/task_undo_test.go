@@ -0,0 +1,65 @@
+package application
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTaskUndo(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		taskID  string
+		action  UndoAction
+		window  time.Duration
+		wantErr bool
+	}{
+		{"delete", "task-1", UndoDelete, 10 * time.Second, false},
+		{"complete", "task-1", UndoComplete, 10 * time.Second, false},
+		{"missing task", "", UndoDelete, 10 * time.Second, true},
+		{"unknown action", "task-1", UndoAction("share"), 10 * time.Second, true},
+		{"no window", "task-1", UndoDelete, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			undo, err := NewTaskUndo(tt.taskID, "user-1", tt.action, StatusPending, now, tt.window)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewTaskUndo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !undo.ExpiresAt.Equal(now.Add(tt.window)) {
+				t.Errorf("ExpiresAt = %v, want %v", undo.ExpiresAt, now.Add(tt.window))
+			}
+		})
+	}
+}
+
+func TestTaskUndo_Expiry(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	undo, _ := NewTaskUndo("task-1", "user-1", UndoDelete, StatusPending, now, 10*time.Second)
+
+	tests := []struct {
+		name        string
+		at          time.Time
+		wantExpired bool
+		wantLeft    int
+	}{
+		{"right away", now, false, 10},
+		{"half a second later", now.Add(500 * time.Millisecond), false, 10},
+		{"last second", now.Add(9500 * time.Millisecond), false, 1},
+		{"at expiry", now.Add(10 * time.Second), true, 0},
+		{"after expiry", now.Add(time.Minute), true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := undo.IsExpired(tt.at); got != tt.wantExpired {
+				t.Errorf("IsExpired() = %v, want %v", got, tt.wantExpired)
+			}
+			if got := undo.SecondsLeft(tt.at); got != tt.wantLeft {
+				t.Errorf("SecondsLeft() = %d, want %d", got, tt.wantLeft)
+			}
+		})
+	}
+}
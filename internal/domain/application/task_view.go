@@ -0,0 +1,50 @@
+package application
+
+// Sharee is a user a task is shared with
+type Sharee struct {
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+}
+
+// TaskView is a task as listed to a user, with the ownership and sharing details shown on
+// its card. Views are loaded together with their tasks so lists don't query them per task.
+type TaskView struct {
+	*Task
+	OwnerName  string
+	SharedWith []Sharee // users that accepted to share the task, by name
+}
+
+// IsOwnedBy reports whether the task belongs to the user
+func (v *TaskView) IsOwnedBy(userID string) bool {
+	return v.OwnerID == userID
+}
+
+// ShareCount returns the number of users the task is shared with
+func (v *TaskView) ShareCount() int {
+	return len(v.SharedWith)
+}
+
+// ShareeNames returns the names of the users the task is shared with, in order
+func (v *TaskView) ShareeNames() []string {
+	names := make([]string, len(v.SharedWith))
+	for i, sharee := range v.SharedWith {
+		names[i] = sharee.Name
+	}
+	return names
+}
+
+// TasksOf returns the tasks of a list of views, in order
+func TasksOf(views []*TaskView) []*Task {
+	tasks := make([]*Task, len(views))
+	for i, view := range views {
+		tasks[i] = view.Task
+	}
+	return tasks
+}
+
+// TaskCounters holds the open task counts shown in the navbar
+type TaskCounters struct {
+	Pending int // open tasks owned by the user that aren't snoozed
+	Overdue int // pending tasks past their due date
+	Shared  int // open tasks of other owners shared with the user, directly or through a project
+}
@@ -0,0 +1,100 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrTimerRunning    = errors.New("a timer is already running on this task")
+	ErrTimerNotRunning = errors.New("no timer is running on this task")
+)
+
+// TimeEntry is a period a user spent working on a task, tracked with a timer. Timers are
+// personal: the owner and the users the task is shared with each start and stop their own,
+// and a user has at most one timer running per task.
+type TimeEntry struct {
+	ID        string
+	TaskID    string
+	UserID    string
+	StartedAt time.Time
+	StoppedAt *time.Time // nil while the timer is running
+}
+
+// NewTimeEntry creates a new running TimeEntry with validation, started at now
+func NewTimeEntry(id, taskID, userID string, now time.Time) (*TimeEntry, error) {
+	if id == "" {
+		return nil, errors.New("time entry id cannot be empty")
+	}
+
+	if taskID == "" {
+		return nil, errors.New("time entry task id cannot be empty")
+	}
+
+	if userID == "" {
+		return nil, errors.New("time entry user id cannot be empty")
+	}
+
+	return &TimeEntry{
+		ID:        id,
+		TaskID:    taskID,
+		UserID:    userID,
+		StartedAt: now,
+	}, nil
+}
+
+// IsRunning reports whether the timer of the entry wasn't stopped yet
+func (e *TimeEntry) IsRunning() bool {
+	return e.StoppedAt == nil
+}
+
+// Stop stops the timer of the entry at now. A clock running behind the start time stops it
+// at the start, so durations are never negative.
+func (e *TimeEntry) Stop(now time.Time) error {
+	if !e.IsRunning() {
+		return ErrTimerNotRunning
+	}
+	if now.Before(e.StartedAt) {
+		now = e.StartedAt
+	}
+	e.StoppedAt = &now
+	return nil
+}
+
+// Duration returns the time tracked by the entry, up to now while it is running
+func (e *TimeEntry) Duration(now time.Time) time.Duration {
+	end := now
+	if e.StoppedAt != nil {
+		end = *e.StoppedAt
+	}
+	if end.Before(e.StartedAt) {
+		return 0
+	}
+	return end.Sub(e.StartedAt)
+}
+
+// TaskTime is the time tracked on a task by all of its users, as seen by one of them
+type TaskTime struct {
+	TaskID       string
+	Tracked      time.Duration // The stopped entries, plus the running ones up to now
+	RunningSince *time.Time    // Start of the timer of the user, nil when they have none running
+}
+
+// IsRunning reports whether the user has a timer running on the task
+func (t *TaskTime) IsRunning() bool {
+	return t != nil && t.RunningSince != nil
+}
+
+// FormatDuration formats a tracked time in hours and minutes, like "2h 05m" or "45m".
+// Seconds are truncated.
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	hours, minutes := int(d/time.Hour), int(d%time.Hour/time.Minute)
+	if hours == 0 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %02dm", hours, minutes)
+}
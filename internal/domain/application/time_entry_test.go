@@ -0,0 +1,94 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewTimeEntry(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		id      string
+		taskID  string
+		userID  string
+		wantErr bool
+	}{
+		{"valid entry", "entry-1", "task-1", "user-1", false},
+		{"empty id", "", "task-1", "user-1", true},
+		{"empty task id", "entry-1", "", "user-1", true},
+		{"empty user id", "entry-1", "task-1", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := NewTimeEntry(tt.id, tt.taskID, tt.userID, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewTimeEntry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !entry.StartedAt.Equal(now) || !entry.IsRunning() {
+				t.Errorf("NewTimeEntry() = %+v, want a running entry started at %v", entry, now)
+			}
+		})
+	}
+}
+
+func TestTimeEntry_Stop(t *testing.T) {
+	start := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		stopAt       time.Time
+		wantDuration time.Duration
+	}{
+		{"after the start", start.Add(90 * time.Minute), 90 * time.Minute},
+		{"clock behind the start", start.Add(-time.Minute), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, _ := NewTimeEntry("entry-1", "task-1", "user-1", start)
+			if got := entry.Duration(start.Add(time.Hour)); got != time.Hour {
+				t.Errorf("Duration() of a running entry = %v, want 1h", got)
+			}
+
+			if err := entry.Stop(tt.stopAt); err != nil {
+				t.Fatalf("Stop() error = %v", err)
+			}
+			if entry.IsRunning() {
+				t.Error("stopped entry should not be running")
+			}
+			if got := entry.Duration(start.Add(24 * time.Hour)); got != tt.wantDuration {
+				t.Errorf("Duration() = %v, want %v", got, tt.wantDuration)
+			}
+			if err := entry.Stop(tt.stopAt); !errors.Is(err, ErrTimerNotRunning) {
+				t.Errorf("second Stop() error = %v, want %v", err, ErrTimerNotRunning)
+			}
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{0, "0m"},
+		{59 * time.Second, "0m"},
+		{45 * time.Minute, "45m"},
+		{2*time.Hour + 5*time.Minute + 30*time.Second, "2h 05m"},
+		{30 * time.Hour, "30h 00m"},
+		{-time.Minute, "0m"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatDuration(tt.duration); got != tt.want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", tt.duration, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package application
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxTwoFactorAttempts is how many wrong codes a login challenge accepts before it is discarded
+const MaxTwoFactorAttempts = 5
+
+var (
+	// ErrInvalidTwoFactorCode is returned when a TOTP or recovery code doesn't verify
+	ErrInvalidTwoFactorCode = errors.New("invalid two-factor code")
+
+	// ErrTwoFactorChallengeExpired is returned when the second step of a login is attempted
+	// without a matching, unexpired challenge
+	ErrTwoFactorChallengeExpired = errors.New("two-factor challenge expired, please sign in again")
+
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+
+	// ErrTwoFactorNotFound is returned for a user who never started the enrollment
+	ErrTwoFactorNotFound = errors.New("two-factor authentication not found")
+
+	// ErrTwoFactorChallengeNotFound is returned for a login challenge that was never created,
+	// or was already used or dropped
+	ErrTwoFactorChallengeNotFound = errors.New("two-factor challenge not found")
+
+	// ErrTwoFactorNotEnrolled is returned when enrollment is confirmed before it was started
+	ErrTwoFactorNotEnrolled = errors.New("start two-factor enrollment first")
+)
+
+// TwoFactor is the TOTP (RFC 6238) second factor of a user. It is created disabled when the
+// user starts the enrollment and only enforced on login once a first code confirms it.
+type TwoFactor struct {
+	UserID    string
+	Secret    string // base32 encoded shared secret
+	Enabled   bool
+	LastStep  int64 // time step of the last accepted code, so a code can't be replayed
+	CreatedAt time.Time
+}
+
+// NewTwoFactor creates a new, not yet enabled, TwoFactor with validation
+func NewTwoFactor(userID, secret string) (*TwoFactor, error) {
+	if userID == "" {
+		return nil, errors.New("two-factor user id cannot be empty")
+	}
+
+	if secret == "" {
+		return nil, errors.New("two-factor secret cannot be empty")
+	}
+
+	return &TwoFactor{
+		UserID:    userID,
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// TwoFactorChallenge is a login whose password was verified and that waits for the second
+// factor. Only the hash of its token is stored.
+type TwoFactorChallenge struct {
+	TokenHash  string
+	UserID     string
+	RememberMe bool
+	Attempts   int
+	ExpiresAt  time.Time
+}
+
+// NewTwoFactorChallenge creates a new TwoFactorChallenge valid for ttl
+func NewTwoFactorChallenge(tokenHash, userID string, rememberMe bool, ttl time.Duration) (*TwoFactorChallenge, error) {
+	if tokenHash == "" {
+		return nil, errors.New("two-factor challenge token cannot be empty")
+	}
+
+	if userID == "" {
+		return nil, errors.New("two-factor challenge user id cannot be empty")
+	}
+
+	if ttl <= 0 {
+		return nil, errors.New("two-factor challenge must expire in the future")
+	}
+
+	return &TwoFactorChallenge{
+		TokenHash:  tokenHash,
+		UserID:     userID,
+		RememberMe: rememberMe,
+		ExpiresAt:  time.Now().Add(ttl),
+	}, nil
+}
+
+// Usable reports whether the challenge can still be answered at now
+func (c *TwoFactorChallenge) Usable(now time.Time) bool {
+	return now.Before(c.ExpiresAt) && c.Attempts < MaxTwoFactorAttempts
+}
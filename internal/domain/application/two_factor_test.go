@@ -0,0 +1,113 @@
+package application
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTwoFactor(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		secret  string
+		wantErr bool
+		errMsg  string
+	}{
+		{name: "valid two-factor", userID: "user-1", secret: "JBSWY3DPEHPK3PXP"},
+		{name: "empty user id", secret: "JBSWY3DPEHPK3PXP", wantErr: true, errMsg: "two-factor user id cannot be empty"},
+		{name: "empty secret", userID: "user-1", wantErr: true, errMsg: "two-factor secret cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			twoFactor, err := NewTwoFactor(tt.userID, tt.secret)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("NewTwoFactor() expected error but got nil")
+				}
+				if err.Error() != tt.errMsg {
+					t.Errorf("NewTwoFactor() error = %v, want %v", err.Error(), tt.errMsg)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewTwoFactor() unexpected error: %v", err)
+			}
+			if twoFactor.Enabled {
+				t.Error("NewTwoFactor() should start disabled until a code confirms it")
+			}
+			if twoFactor.CreatedAt.IsZero() {
+				t.Error("NewTwoFactor() should set CreatedAt")
+			}
+		})
+	}
+}
+
+func TestNewTwoFactorChallenge(t *testing.T) {
+	tests := []struct {
+		name      string
+		tokenHash string
+		userID    string
+		ttl       time.Duration
+		wantErr   bool
+		errMsg    string
+	}{
+		{name: "valid challenge", tokenHash: "hash", userID: "user-1", ttl: time.Minute},
+		{name: "empty token", userID: "user-1", ttl: time.Minute, wantErr: true, errMsg: "two-factor challenge token cannot be empty"},
+		{name: "empty user id", tokenHash: "hash", ttl: time.Minute, wantErr: true, errMsg: "two-factor challenge user id cannot be empty"},
+		{name: "no ttl", tokenHash: "hash", userID: "user-1", wantErr: true, errMsg: "two-factor challenge must expire in the future"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			challenge, err := NewTwoFactorChallenge(tt.tokenHash, tt.userID, true, tt.ttl)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("NewTwoFactorChallenge() expected error but got nil")
+				}
+				if err.Error() != tt.errMsg {
+					t.Errorf("NewTwoFactorChallenge() error = %v, want %v", err.Error(), tt.errMsg)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewTwoFactorChallenge() unexpected error: %v", err)
+			}
+			if !challenge.RememberMe {
+				t.Error("NewTwoFactorChallenge() should keep rememberMe")
+			}
+			if !challenge.Usable(time.Now()) {
+				t.Error("new challenge should be usable")
+			}
+		})
+	}
+}
+
+func TestTwoFactorChallenge_Usable(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		attempts  int
+		expiresAt time.Time
+		want      bool
+	}{
+		{name: "fresh", expiresAt: now.Add(time.Minute), want: true},
+		{name: "last attempt left", attempts: MaxTwoFactorAttempts - 1, expiresAt: now.Add(time.Minute), want: true},
+		{name: "attempts exhausted", attempts: MaxTwoFactorAttempts, expiresAt: now.Add(time.Minute), want: false},
+		{name: "expired", expiresAt: now.Add(-time.Second), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			challenge := &TwoFactorChallenge{Attempts: tt.attempts, ExpiresAt: tt.expiresAt}
+			if got := challenge.Usable(now); got != tt.want {
+				t.Errorf("Usable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,43 +8,117 @@ import (
 
 var (
 	ErrUserNotFound = errors.New("user not found")
+	ErrInvalidTheme = errors.New("theme must be light or dark")
+
+	// ErrInvalidLocale is returned for languages the interface isn't translated to
+	ErrInvalidLocale = errors.New("locale must be pt-BR or en")
+
+	// ErrInvalidLinkToken is returned when the token of a link sent by email is unknown, used or expired
+	ErrInvalidLinkToken = errors.New("link is invalid or has expired")
+
+	// ErrInvalidCredentials is the only error of a failed login, whether the email is unknown
+	// or the password is wrong, so the response doesn't tell which accounts exist
+	ErrInvalidCredentials = errors.New("invalid credentials")
+
+	// ErrUserDisabled is returned when the credentials are right but an admin disabled the account
+	ErrUserDisabled = errors.New("account is disabled")
+)
+
+// UserRole represents the access level of a user
+type UserRole string
+
+const (
+	RoleMember  UserRole = "member"
+	RoleManager UserRole = "manager"
+	RoleAdmin   UserRole = "admin"
+)
+
+// Theme is the color scheme of the web interface preferred by a user
+type Theme string
+
+const (
+	ThemeLight Theme = "light"
+	ThemeDark  Theme = "dark"
+)
+
+// ParseTheme validates a theme name
+func ParseTheme(name string) (Theme, error) {
+	switch theme := Theme(name); theme {
+	case ThemeLight, ThemeDark:
+		return theme, nil
+	default:
+		return "", ErrInvalidTheme
+	}
+}
+
+// Locale is the language of the interface preferred by a user, as a BCP 47 tag
+type Locale string
+
+const (
+	LocalePtBR Locale = "pt-BR"
+	LocaleEN   Locale = "en"
 )
 
+// ParseLocale validates a locale tag
+func ParseLocale(tag string) (Locale, error) {
+	switch locale := Locale(tag); locale {
+	case LocalePtBR, LocaleEN:
+		return locale, nil
+	default:
+		return "", ErrInvalidLocale
+	}
+}
+
 // User represents a user entity
 type User struct {
 	ID           string
 	Name         string
 	Email        string
 	PasswordHash string
+	Role         UserRole
+	Unit         string
+	Theme        Theme
+	Locale       Locale // empty until the user picks a language
+	// OverdueOptOut stops the overdue job from flagging the user's late tasks
+	OverdueOptOut bool
+	AvatarPath    string // uploaded image shown next to the user's name; empty for the identicon
+	// Disabled accounts can't log in; set from the admin dashboard
+	Disabled bool
+	// TokenVersion is carried by the tokens issued to the user; incrementing it revokes them
+	TokenVersion int
 	CreatedAt    time.Time
 }
 
 var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
 
-// NewUser creates a new User with validation
+// NewUser creates a new User with validation. A rejected user returns *ValidationError listing
+// every invalid field.
 func NewUser(id, name, email, passwordHash string) (*User, error) {
+	var invalid ValidationError
 	if id == "" {
-		return nil, errors.New("user id cannot be empty")
+		invalid.add("id", CodeRequired, "user id cannot be empty")
 	}
 
 	if name == "" {
-		return nil, errors.New("user name cannot be empty")
+		invalid.add("name", CodeRequired, "user name cannot be empty")
 	}
 
 	if len(name) > 100 {
-		return nil, errors.New("user name cannot exceed 100 characters")
+		invalid.add("name", CodeTooLong, "user name cannot exceed 100 characters")
 	}
 
 	if email == "" {
-		return nil, errors.New("user email cannot be empty")
+		invalid.add("email", CodeRequired, "user email cannot be empty")
+	} else if !emailRegex.MatchString(email) {
+		invalid.add("email", CodeInvalid, "invalid email format")
 	}
 
-	if !emailRegex.MatchString(email) {
-		return nil, errors.New("invalid email format")
+	if passwordHash == "" {
+		invalid.add("password", CodeRequired, "password hash cannot be empty")
 	}
 
-	if passwordHash == "" {
-		return nil, errors.New("password hash cannot be empty")
+	if err := invalid.err(); err != nil {
+		return nil, err
 	}
 
 	return &User{
@@ -52,6 +126,26 @@ func NewUser(id, name, email, passwordHash string) (*User, error) {
 		Name:         name,
 		Email:        email,
 		PasswordHash: passwordHash,
+		Role:         RoleMember,
+		Theme:        ThemeLight,
 		CreatedAt:    time.Now(),
 	}, nil
 }
+
+// CanViewUnitReports checks if the user may see team reports of a unit:
+// admins see every unit, managers only their own
+func (u *User) CanViewUnitReports(unit string) bool {
+	switch u.Role {
+	case RoleAdmin:
+		return true
+	case RoleManager:
+		return u.Unit != "" && u.Unit == unit
+	default:
+		return false
+	}
+}
+
+// IsAdmin checks if the user may use the admin dashboard and manage other users
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
@@ -0,0 +1,97 @@
+package application
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrInvalidDefaultSort is returned for sorts the web task list can't page through
+	ErrInvalidDefaultSort = errors.New("default sort must be -created_at or created_at")
+
+	// ErrUnknownNotificationSetting is returned for notification types that can't be turned off
+	ErrUnknownNotificationSetting = errors.New("notifications must be task_shared, task_completed, task_overdue or comment_mention")
+
+	// ErrUserSettingsNotFound is returned for a user who never saved any settings
+	ErrUserSettingsNotFound = errors.New("user settings not found")
+)
+
+// UserSettings are the preferences of a user kept apart from the account: the order of the
+// web task list and the in-app notifications they get. Users who never saved theirs get
+// DefaultUserSettings.
+type UserSettings struct {
+	UserID        string
+	DefaultSort   TaskSort
+	Notifications NotificationSettings
+	UpdatedAt     time.Time
+}
+
+// NotificationSettings tells which notifications triggered by other users or by the jobs a user
+// gets. Reminders and broken link alerts are asked for by the user and always delivered.
+// CommentMention covers both the in-app notification and the email of a mention.
+type NotificationSettings struct {
+	TaskShared     bool
+	TaskCompleted  bool
+	TaskOverdue    bool
+	CommentMention bool
+}
+
+// DefaultUserSettings returns the settings of a user who never changed them: newest tasks
+// first and every notification
+func DefaultUserSettings(userID string) *UserSettings {
+	return &UserSettings{
+		UserID:      userID,
+		DefaultSort: SortNewest,
+		Notifications: NotificationSettings{
+			TaskShared:     true,
+			TaskCompleted:  true,
+			TaskOverdue:    true,
+			CommentMention: true,
+		},
+	}
+}
+
+// ParseDefaultSort validates the default sort of the web task list, which pages with cursors
+// and so only follows the creation time
+func ParseDefaultSort(sort string) (TaskSort, error) {
+	switch parsed := TaskSort(sort); parsed {
+	case SortNewest, SortOldest:
+		return parsed, nil
+	default:
+		return "", ErrInvalidDefaultSort
+	}
+}
+
+// Allows reports whether a notification of a type is delivered. Types without a setting are.
+func (s NotificationSettings) Allows(notificationType NotificationType) bool {
+	switch notificationType {
+	case NotificationTaskShared:
+		return s.TaskShared
+	case NotificationTaskCompleted:
+		return s.TaskCompleted
+	case NotificationTaskOverdue:
+		return s.TaskOverdue
+	case NotificationCommentMention:
+		return s.CommentMention
+	default:
+		return true
+	}
+}
+
+// Set turns the notifications of a type on or off, failing with ErrUnknownNotificationSetting
+// for types without a setting
+func (s *NotificationSettings) Set(notificationType NotificationType, enabled bool) error {
+	switch notificationType {
+	case NotificationTaskShared:
+		s.TaskShared = enabled
+	case NotificationTaskCompleted:
+		s.TaskCompleted = enabled
+	case NotificationTaskOverdue:
+		s.TaskOverdue = enabled
+	case NotificationCommentMention:
+		s.CommentMention = enabled
+	default:
+		return ErrUnknownNotificationSetting
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package application
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseDefaultSort(t *testing.T) {
+	tests := []struct {
+		sort    string
+		want    TaskSort
+		wantErr error
+	}{
+		{"-created_at", SortNewest, nil},
+		{"created_at", SortOldest, nil},
+		{"due_date", "", ErrInvalidDefaultSort},
+		{"", "", ErrInvalidDefaultSort},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			got, err := ParseDefaultSort(tt.sort)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseDefaultSort(%q) error = %v, want %v", tt.sort, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDefaultSort(%q) = %q, want %q", tt.sort, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotificationSettings_Set(t *testing.T) {
+	settings := DefaultUserSettings("user-123").Notifications
+
+	if err := settings.Set(NotificationTaskCompleted, false); err != nil {
+		t.Fatalf("Set() error: %v", err)
+	}
+	if settings.Allows(NotificationTaskCompleted) {
+		t.Error("Allows(task_completed) = true after turning it off")
+	}
+	if !settings.Allows(NotificationTaskShared) || !settings.Allows(NotificationTaskOverdue) || !settings.Allows(NotificationCommentMention) {
+		t.Errorf("settings = %+v, want the other types still on", settings)
+	}
+	if err := settings.Set(NotificationCommentMention, false); err != nil || settings.Allows(NotificationCommentMention) {
+		t.Errorf("Set(comment_mention, false) = %v, Allows() = %v; want mentions turned off", err, settings.Allows(NotificationCommentMention))
+	}
+
+	// Reminders have no setting, so they can't be turned off and are always delivered
+	if err := settings.Set(NotificationTaskReminder, false); !errors.Is(err, ErrUnknownNotificationSetting) {
+		t.Errorf("Set(task_reminder) error = %v, want ErrUnknownNotificationSetting", err)
+	}
+	if !settings.Allows(NotificationTaskReminder) {
+		t.Error("Allows(task_reminder) = false, want true")
+	}
+}
@@ -116,3 +116,80 @@ func TestNewUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_CanViewUnitReports(t *testing.T) {
+	tests := []struct {
+		name string
+		user User
+		unit string
+		want bool
+	}{
+		{"admin sees any unit", User{Role: RoleAdmin}, "fiscal", true},
+		{"manager sees own unit", User{Role: RoleManager, Unit: "fiscal"}, "fiscal", true},
+		{"manager cannot see other unit", User{Role: RoleManager, Unit: "fiscal"}, "juridico", false},
+		{"manager without unit sees nothing", User{Role: RoleManager}, "", false},
+		{"member cannot see reports", User{Role: RoleMember, Unit: "fiscal"}, "fiscal", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.user.CanViewUnitReports(tt.unit); got != tt.want {
+				t.Errorf("CanViewUnitReports(%q) = %v, want %v", tt.unit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Theme
+		wantErr bool
+	}{
+		{"light", "light", ThemeLight, false},
+		{"dark", "dark", ThemeDark, false},
+		{"empty", "", "", true},
+		{"unknown", "blue", "", true},
+		{"case sensitive", "Dark", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTheme(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTheme(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTheme(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Locale
+		wantErr bool
+	}{
+		{"portuguese", "pt-BR", LocalePtBR, false},
+		{"english", "en", LocaleEN, false},
+		{"empty", "", "", true},
+		{"unsupported", "es", "", true},
+		{"not canonical", "pt-br", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLocale(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLocale(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLocale(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
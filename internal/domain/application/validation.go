@@ -0,0 +1,45 @@
+package application
+
+import "strings"
+
+// Codes of the field errors, telling clients what is wrong with a field without parsing messages
+const (
+	CodeRequired = "required"
+	CodeTooLong  = "too_long"
+	CodeInvalid  = "invalid"
+)
+
+// FieldError is why one field of an entity was rejected
+type FieldError struct {
+	Field   string // name of the field in the API, like "title"
+	Code    string // CodeRequired, CodeTooLong or CodeInvalid
+	Message string // such as "task title cannot be empty"
+}
+
+// ValidationError lists every field an entity was rejected for, so clients can point out all
+// of them at once
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Error implements the error interface, joining the messages of the fields
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		messages[i] = f.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// add records a field error
+func (e *ValidationError) add(field, code, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Code: code, Message: message})
+}
+
+// err returns the validation error, or nil when no field was rejected
+func (e *ValidationError) err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
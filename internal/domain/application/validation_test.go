@@ -0,0 +1,77 @@
+package application
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidationError_Fields(t *testing.T) {
+	task := &Task{Title: "Buy groceries", Status: StatusPending}
+
+	tests := []struct {
+		name string
+		run  func() error
+		want []FieldError
+	}{
+		{
+			name: "task with every field invalid",
+			run: func() error {
+				_, err := NewTask("task-1", "", strings.Repeat("a", 1001), "archived", "user-1", "")
+				return err
+			},
+			want: []FieldError{
+				{Field: "title", Code: CodeRequired, Message: "task title cannot be empty"},
+				{Field: "description", Code: CodeTooLong, Message: "task description cannot exceed 1000 characters"},
+				{Field: "status", Code: CodeInvalid, Message: "invalid task status"},
+			},
+		},
+		{
+			name: "task update",
+			run: func() error {
+				return task.Update(strings.Repeat("a", 201), "", StatusPending, strings.Repeat("a", 501))
+			},
+			want: []FieldError{
+				{Field: "title", Code: CodeTooLong, Message: "task title cannot exceed 200 characters"},
+				{Field: "image_path", Code: CodeTooLong, Message: "image path cannot exceed 500 characters"},
+			},
+		},
+		{
+			name: "user",
+			run: func() error {
+				_, err := NewUser("user-1", "", "ana@", "hash")
+				return err
+			},
+			want: []FieldError{
+				{Field: "name", Code: CodeRequired, Message: "user name cannot be empty"},
+				{Field: "email", Code: CodeInvalid, Message: "invalid email format"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run()
+			var invalid *ValidationError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("error = %v, want a *ValidationError", err)
+			}
+			if !reflect.DeepEqual(invalid.Fields, tt.want) {
+				t.Errorf("Fields = %+v, want %+v", invalid.Fields, tt.want)
+			}
+		})
+	}
+
+	// The task isn't changed by a rejected update
+	if task.Title != "Buy groceries" {
+		t.Errorf("Title = %q, want the title before the rejected update", task.Title)
+	}
+}
+
+func TestValidationError_Error(t *testing.T) {
+	_, err := NewUser("user-1", "", "", "hash")
+	if want := "user name cannot be empty; user email cannot be empty"; err == nil || err.Error() != want {
+		t.Errorf("Error() = %v, want %q", err, want)
+	}
+}
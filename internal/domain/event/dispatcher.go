@@ -0,0 +1,73 @@
+package event
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// Handler reacts to a published event
+type Handler func(ctx context.Context, e DomainEvent) error
+
+// Publisher publishes domain events. Use cases depend on this interface only.
+type Publisher interface {
+	// Publish delivers events to their subscribers
+	Publish(ctx context.Context, events ...DomainEvent)
+}
+
+// Dispatcher is an in-process, synchronous Publisher. Handlers are registered at startup
+// and run in subscription order. Events are published after the change was persisted, so
+// a failing handler is logged and never fails or rolls back the operation that emitted it.
+// Handlers doing slow work (network calls) should hand it off to their own goroutine.
+type Dispatcher struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	all      []Handler
+}
+
+// NewDispatcher creates a Dispatcher with no subscribers
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		handlers: make(map[string][]Handler),
+	}
+}
+
+// Subscribe registers a handler for the events with the given name
+func (d *Dispatcher) Subscribe(name string, handler Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[name] = append(d.handlers[name], handler)
+}
+
+// SubscribeAll registers a handler for every event
+func (d *Dispatcher) SubscribeAll(handler Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.all = append(d.all, handler)
+}
+
+// Publish runs the handlers of each event, isolating them from each other's errors and panics
+func (d *Dispatcher) Publish(ctx context.Context, events ...DomainEvent) {
+	for _, e := range events {
+		d.mu.RLock()
+		handlers := append(append([]Handler(nil), d.handlers[e.EventName()]...), d.all...)
+		d.mu.RUnlock()
+
+		for _, handler := range handlers {
+			d.run(ctx, handler, e)
+		}
+	}
+}
+
+// run calls a single handler, logging its error or panic
+func (d *Dispatcher) run(ctx context.Context, handler Handler, e DomainEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("event %s: handler panicked: %v", e.EventName(), r)
+		}
+	}()
+
+	if err := handler(ctx, e); err != nil {
+		log.Printf("event %s: handler failed: %v", e.EventName(), err)
+	}
+}
@@ -0,0 +1,83 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func newTestTask() *application.Task {
+	return &application.Task{ID: "task-1", Title: "Relatório", OwnerID: "owner-1"}
+}
+
+func TestDispatcher_RoutesEventsByName(t *testing.T) {
+	d := NewDispatcher()
+	var calls []string
+
+	d.Subscribe(TaskCreatedName, func(ctx context.Context, e DomainEvent) error {
+		calls = append(calls, "created:"+e.(TaskCreated).TaskID)
+		return nil
+	})
+	d.Subscribe(TaskSharedName, func(ctx context.Context, e DomainEvent) error {
+		calls = append(calls, "shared:"+e.(TaskShared).SharedWithID)
+		return nil
+	})
+	d.SubscribeAll(func(ctx context.Context, e DomainEvent) error {
+		calls = append(calls, "all:"+e.EventName())
+		return nil
+	})
+
+	task := newTestTask()
+	d.Publish(context.Background(),
+		TaskCreated{TaskEvent: NewTaskEvent(task, "owner-1")},
+		TaskShared{TaskEvent: NewTaskEvent(task, "owner-1"), SharedWithID: "user-2"},
+		TaskDeleted{TaskEvent: NewTaskEvent(task, "owner-1")},
+	)
+
+	want := []string{
+		"created:task-1", "all:task.created",
+		"shared:user-2", "all:task.shared",
+		"all:task.deleted",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("Expected calls %v, got %v", want, calls)
+	}
+}
+
+func TestDispatcher_IsolatesFailingHandlers(t *testing.T) {
+	d := NewDispatcher()
+	reached := 0
+
+	d.Subscribe(TaskCompletedName, func(ctx context.Context, e DomainEvent) error {
+		return errors.New("smtp down")
+	})
+	d.Subscribe(TaskCompletedName, func(ctx context.Context, e DomainEvent) error {
+		panic("nil map")
+	})
+	d.Subscribe(TaskCompletedName, func(ctx context.Context, e DomainEvent) error {
+		reached++
+		return nil
+	})
+
+	d.Publish(context.Background(), TaskCompleted{TaskEvent: NewTaskEvent(newTestTask(), "owner-1")})
+
+	if reached != 1 {
+		t.Errorf("Expected the last handler to run despite earlier failures, ran %d times", reached)
+	}
+}
+
+func TestNewTaskEvent_SnapshotsTask(t *testing.T) {
+	task := newTestTask()
+	e := NewTaskEvent(task, "actor-1")
+	task.Title = "Changed later"
+
+	if e.TaskID != "task-1" || e.OwnerID != "owner-1" || e.ActorID != "actor-1" || e.Title != "Relatório" {
+		t.Errorf("Unexpected event data %+v", e)
+	}
+	if e.OccurredAt().IsZero() {
+		t.Error("Expected OccurredAt to be set")
+	}
+}
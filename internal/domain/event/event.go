@@ -0,0 +1,151 @@
+// Package event defines the domain events emitted by use cases and the in-process
+// dispatcher that delivers them to subscribers (notifications, webhooks, activity log).
+package event
+
+import (
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// Event names, used to subscribe to a specific event type
+const (
+	TaskCreatedName       = "task.created"
+	TaskUpdatedName       = "task.updated"
+	TaskCompletedName     = "task.completed"
+	TaskDeletedName       = "task.deleted"
+	TaskSharedName        = "task.shared"
+	TaskShareAcceptedName = "task.share_accepted"
+	TaskShareDeclinedName = "task.share_declined"
+	TaskUnsharedName      = "task.unshared"
+	TaskOverdueName       = "task.overdue"
+	TaskTransferredName   = "task.transferred"
+)
+
+// DomainEvent is something that happened in the domain, published after it was persisted
+type DomainEvent interface {
+	// EventName identifies the event type, e.g. "task.created"
+	EventName() string
+
+	// OccurredAt returns when the event happened
+	OccurredAt() time.Time
+}
+
+// TaskEvent holds the data common to all task events
+type TaskEvent struct {
+	TaskID    string    `json:"task_id"`
+	OwnerID   string    `json:"owner_id"`
+	ActorID   string    `json:"actor_id"`
+	Title     string    `json:"title"`
+	Timestamp time.Time `json:"occurred_at"`
+}
+
+// NewTaskEvent snapshots a task for an event performed by actorID
+func NewTaskEvent(task *application.Task, actorID string) TaskEvent {
+	return TaskEvent{
+		TaskID:    task.ID,
+		OwnerID:   task.OwnerID,
+		ActorID:   actorID,
+		Title:     task.Title,
+		Timestamp: time.Now(),
+	}
+}
+
+// OccurredAt returns when the event happened
+func (e TaskEvent) OccurredAt() time.Time {
+	return e.Timestamp
+}
+
+// Data returns the common task data; it is promoted to every task event, so subscribers
+// handling all events can read it without switching on the concrete type
+func (e TaskEvent) Data() TaskEvent {
+	return e
+}
+
+// TaskCreated is published when a task is created (directly or by an import)
+type TaskCreated struct {
+	TaskEvent
+}
+
+// EventName returns TaskCreatedName
+func (TaskCreated) EventName() string { return TaskCreatedName }
+
+// TaskUpdated is published when a task's content or status is changed
+type TaskUpdated struct {
+	TaskEvent
+}
+
+// EventName returns TaskUpdatedName
+func (TaskUpdated) EventName() string { return TaskUpdatedName }
+
+// TaskCompleted is published when a task moves to the completed status
+type TaskCompleted struct {
+	TaskEvent
+}
+
+// EventName returns TaskCompletedName
+func (TaskCompleted) EventName() string { return TaskCompletedName }
+
+// TaskDeleted is published after a task is deleted
+type TaskDeleted struct {
+	TaskEvent
+}
+
+// EventName returns TaskDeletedName
+func (TaskDeleted) EventName() string { return TaskDeletedName }
+
+// TaskShared is published when a task is shared with a user, which invites them to it
+type TaskShared struct {
+	TaskEvent
+	SharedWithID string `json:"shared_with_id"`
+}
+
+// EventName returns TaskSharedName
+func (TaskShared) EventName() string { return TaskSharedName }
+
+// TaskUnshared is published when sharing of a task with a user is removed
+type TaskUnshared struct {
+	TaskEvent
+	UnsharedWithID string `json:"unshared_with_id"`
+}
+
+// EventName returns TaskUnsharedName
+func (TaskUnshared) EventName() string { return TaskUnsharedName }
+
+// TaskShareAccepted is published when a user accepts the invitation to a task; the user is
+// the actor and gets access to the task from then on
+type TaskShareAccepted struct {
+	TaskEvent
+}
+
+// EventName returns TaskShareAcceptedName
+func (TaskShareAccepted) EventName() string { return TaskShareAcceptedName }
+
+// TaskShareDeclined is published when a user declines the invitation to a task; the user is
+// the actor
+type TaskShareDeclined struct {
+	TaskEvent
+}
+
+// EventName returns TaskShareDeclinedName
+func (TaskShareDeclined) EventName() string { return TaskShareDeclinedName }
+
+// TaskTransferred is published when the owner of a task transfers it to another user. OwnerID
+// is the new owner; the previous owner is the actor and keeps access as a sharee.
+type TaskTransferred struct {
+	TaskEvent
+	PreviousOwnerID string `json:"previous_owner_id"`
+}
+
+// EventName returns TaskTransferredName
+func (TaskTransferred) EventName() string { return TaskTransferredName }
+
+// TaskOverdue is published when the overdue job flags a pending task past its due date.
+// It has no actor: ActorID is empty.
+type TaskOverdue struct {
+	TaskEvent
+	DueDate time.Time `json:"due_date"`
+}
+
+// EventName returns TaskOverdueName
+func (TaskOverdue) EventName() string { return TaskOverdueName }
@@ -0,0 +1,118 @@
+// Package quickadd parses the one-line task descriptions typed in the quick-add box, such as
+// "Comprar pão amanhã 9h !alta #compras", into the fields of a task.
+package quickadd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// ErrEmptyTitle is returned when nothing is left for the title once the due date, priority
+// and tags are taken out of the text
+var ErrEmptyTitle = errors.New("quick add text has no title")
+
+// UnknownPriorityError is returned for a "!word" that isn't a known priority
+type UnknownPriorityError struct {
+	Priority string
+}
+
+// Error implements the error interface, listing the accepted priorities
+func (e *UnknownPriorityError) Error() string {
+	return fmt.Sprintf("unknown priority %q; use !alta, !media or !baixa", e.Priority)
+}
+
+// Task holds the fields read from a quick-add text
+type Task struct {
+	Title    string
+	DueDate  *time.Time // nil when the text has no due date
+	Priority application.TaskPriority
+	Tags     []string // as typed, without the #; application.Task.SetTags normalizes them
+}
+
+// maxDateWords is the number of words of the longest due date, like "próxima sexta às 14h"
+const maxDateWords = 4
+
+var (
+	priorities = map[string]application.TaskPriority{
+		"alta":    application.PriorityHigh,
+		"alto":    application.PriorityHigh,
+		"urgente": application.PriorityHigh,
+		"high":    application.PriorityHigh,
+		"media":   application.PriorityNormal,
+		"medio":   application.PriorityNormal,
+		"normal":  application.PriorityNormal,
+		"medium":  application.PriorityNormal,
+		"baixa":   application.PriorityLow,
+		"baixo":   application.PriorityLow,
+		"low":     application.PriorityLow,
+	}
+
+	// dateConnectors are dropped from the title when they come right before the due date,
+	// as in "Pagar boleto até sexta"
+	dateConnectors = map[string]bool{
+		"até":  true,
+		"ate":  true,
+		"em":   true,
+		"para": true,
+		"pra":  true,
+		"dia":  true,
+	}
+
+	accentReplacer = strings.NewReplacer("á", "a", "é", "e", "ê", "e", "í", "i", "ó", "o")
+)
+
+// Parse reads a quick-add text. Words starting with "!" set the priority (!alta, !media,
+// !baixa), words starting with "#" are tags, and the longest run of words ParseDueDate
+// accepts, the last one on ties, is the due date. The remaining words, in order, are the title.
+func Parse(input string, now time.Time, loc *time.Location) (*Task, error) {
+	task := &Task{Priority: application.PriorityNormal}
+
+	var words []string
+	for _, word := range strings.Fields(input) {
+		switch {
+		case len(word) > 1 && word[0] == '#':
+			task.Tags = append(task.Tags, strings.TrimRight(word[1:], ",.;:"))
+		case len(word) > 1 && word[0] == '!':
+			priority, ok := priorities[accentReplacer.Replace(strings.ToLower(word[1:]))]
+			if !ok {
+				return nil, &UnknownPriorityError{Priority: word}
+			}
+			task.Priority = priority
+		default:
+			words = append(words, word)
+		}
+	}
+
+	if start, end, due, ok := findDueDate(words, now, loc); ok {
+		task.DueDate = &due
+		if start > 0 && dateConnectors[strings.ToLower(words[start-1])] {
+			start--
+		}
+		words = append(words[:start:start], words[end:]...)
+	}
+
+	task.Title = strings.Join(words, " ")
+	if task.Title == "" {
+		return nil, ErrEmptyTitle
+	}
+	return task, nil
+}
+
+// findDueDate finds the longest run of words that reads as a due date, preferring the last one
+// among runs of the same length, and returns its bounds
+func findDueDate(words []string, now time.Time, loc *time.Location) (int, int, time.Time, bool) {
+	for size := min(maxDateWords, len(words)); size > 0; size-- {
+		for start := len(words) - size; start >= 0; start-- {
+			due, err := service.ParseDueDate(strings.Join(words[start:start+size], " "), now, loc)
+			if err == nil {
+				return start, start + size, due, true
+			}
+		}
+	}
+	return 0, 0, time.Time{}, false
+}
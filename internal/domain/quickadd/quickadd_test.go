@@ -0,0 +1,129 @@
+package quickadd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestParse(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	// Wednesday, 2025-06-11 10:00 in São Paulo
+	now := time.Date(2025, 6, 11, 10, 0, 0, 0, loc)
+	endOf := func(year int, month time.Month, day int) *time.Time {
+		d := time.Date(year, month, day, 23, 59, 59, 0, loc)
+		return &d
+	}
+	at := func(year int, month time.Month, day, hour, minute int) *time.Time {
+		d := time.Date(year, month, day, hour, minute, 0, 0, loc)
+		return &d
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		title    string
+		due      *time.Time
+		priority application.TaskPriority
+		tags     []string
+	}{
+		{"title only", "Comprar pão", "Comprar pão", nil, application.PriorityNormal, nil},
+		{"extra spaces", "  Comprar   pão  ", "Comprar pão", nil, application.PriorityNormal, nil},
+		{"due date at the end", "Comprar pão amanhã", "Comprar pão", endOf(2025, 6, 12), application.PriorityNormal, nil},
+		{"due date without accent", "Comprar pão amanha", "Comprar pão", endOf(2025, 6, 12), application.PriorityNormal, nil},
+		{"weekday with time", "Reunião com Ana sexta 14h", "Reunião com Ana", at(2025, 6, 13, 14, 0), application.PriorityNormal, nil},
+		{"due date in the middle", "Ligar amanhã para o banco", "Ligar para o banco", endOf(2025, 6, 12), application.PriorityNormal, nil},
+		{"due date first", "Amanhã 9h dentista", "dentista", at(2025, 6, 12, 9, 0), application.PriorityNormal, nil},
+		{"longest due date wins", "Entregar relatório depois de amanhã", "Entregar relatório", endOf(2025, 6, 13), application.PriorityNormal, nil},
+		{"próxima weekday with às", "Revisão próxima sexta às 14h", "Revisão", at(2025, 6, 13, 14, 0), application.PriorityNormal, nil},
+		{"numeric date with time", "Natal 25/12 às 9:30", "Natal", at(2025, 12, 25, 9, 30), application.PriorityNormal, nil},
+		{"time only means today", "Reunião às 16h", "Reunião", at(2025, 6, 11, 16, 0), application.PriorityNormal, nil},
+		{"connector before due date", "Pagar boleto até sexta", "Pagar boleto", endOf(2025, 6, 13), application.PriorityNormal, nil},
+		{"dia before date", "Consulta dia 20/06", "Consulta", endOf(2025, 6, 20), application.PriorityNormal, nil},
+		{"connector is kept when not before the date", "Presente para Ana sexta", "Presente para Ana", endOf(2025, 6, 13), application.PriorityNormal, nil},
+		{"last of two dates wins", "Mover reunião de hoje para amanhã", "Mover reunião de hoje", endOf(2025, 6, 12), application.PriorityNormal, nil},
+		{"numbers are not dates", "Comprar 2 pães", "Comprar 2 pães", nil, application.PriorityNormal, nil},
+		{"high priority", "Pagar luz !alta", "Pagar luz", nil, application.PriorityHigh, nil},
+		{"priority is case and accent insensitive", "Pagar luz !MÉDIA", "Pagar luz", nil, application.PriorityNormal, nil},
+		{"low priority", "!baixa Organizar gaveta", "Organizar gaveta", nil, application.PriorityLow, nil},
+		{"english priority", "Pay bill !high", "Pay bill", nil, application.PriorityHigh, nil},
+		{"last priority wins", "Pagar luz !baixa !urgente", "Pagar luz", nil, application.PriorityHigh, nil},
+		{"tags", "Comprar pão #compras #Casa", "Comprar pão", nil, application.PriorityNormal, []string{"compras", "Casa"}},
+		{"tag with trailing punctuation", "Comprar pão #compras, leite", "Comprar pão leite", nil, application.PriorityNormal, []string{"compras"}},
+		{"lone symbols stay in the title", "Item # 1 !", "Item # 1 !", nil, application.PriorityNormal, nil},
+		{"everything", "Comprar pão amanhã 9h !alta #compras #casa", "Comprar pão", at(2025, 6, 12, 9, 0), application.PriorityHigh, []string{"compras", "casa"}},
+		{"everything mixed", "#trabalho Enviar proposta !alta até sexta 18h", "Enviar proposta", at(2025, 6, 13, 18, 0), application.PriorityHigh, []string{"trabalho"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input, now, loc)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.input, err)
+			}
+			if got.Title != tt.title {
+				t.Errorf("Title = %q, want %q", got.Title, tt.title)
+			}
+			if (got.DueDate == nil) != (tt.due == nil) || (got.DueDate != nil && !got.DueDate.Equal(*tt.due)) {
+				t.Errorf("DueDate = %v, want %v", got.DueDate, tt.due)
+			}
+			if got.Priority != tt.priority {
+				t.Errorf("Priority = %q, want %q", got.Priority, tt.priority)
+			}
+			if strings.Join(got.Tags, ",") != strings.Join(tt.tags, ",") {
+				t.Errorf("Tags = %q, want %q", got.Tags, tt.tags)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	now := time.Date(2025, 6, 11, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		input string
+		check func(error) bool
+	}{
+		{"empty", "", func(err error) bool { return errors.Is(err, ErrEmptyTitle) }},
+		{"only a due date", "amanhã 9h", func(err error) bool { return errors.Is(err, ErrEmptyTitle) }},
+		{"only a connector and a due date", "até sexta", func(err error) bool { return errors.Is(err, ErrEmptyTitle) }},
+		{"only tags and priority", "#compras !alta", func(err error) bool { return errors.Is(err, ErrEmptyTitle) }},
+		{"unknown priority", "Pagar luz !altíssima", func(err error) bool {
+			var unknown *UnknownPriorityError
+			return errors.As(err, &unknown) && unknown.Priority == "!altíssima"
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input, now, time.UTC)
+			if err == nil {
+				t.Fatalf("Parse(%q) = %+v, want an error", tt.input, got)
+			}
+			if !tt.check(err) {
+				t.Errorf("Parse(%q) error = %v", tt.input, err)
+			}
+		})
+	}
+}
+
+func TestParse_UsesLocation(t *testing.T) {
+	loc := time.FixedZone("UTC-3", -3*60*60)
+	now := time.Date(2025, 6, 11, 23, 30, 0, 0, time.UTC) // already the 11th at 20:30 in UTC-3
+
+	got, err := Parse("Ligar amanhã 9h", now, loc)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := time.Date(2025, 6, 12, 9, 0, 0, 0, loc)
+	if got.DueDate == nil || !got.DueDate.Equal(want) {
+		t.Errorf("DueDate = %v, want %v", got.DueDate, want)
+	}
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// AccountDeletionRepository defines the interface for persisting scheduled account deletions
+type AccountDeletionRepository interface {
+	// Create schedules the deletion of an account, unless it is already scheduled. It returns
+	// false when it was, leaving the first deletion unchanged.
+	Create(ctx context.Context, deletion *application.AccountDeletion) (bool, error)
+
+	// FindByUserID finds the scheduled deletion of an account, or returns
+	// application.ErrAccountDeletionNotFound
+	FindByUserID(ctx context.Context, userID string) (*application.AccountDeletion, error)
+
+	// FindDue returns up to limit deletions whose purge time is not after now, oldest first
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*application.AccountDeletion, error)
+
+	// Delete cancels the deletion of an account, reporting whether it was scheduled
+	Delete(ctx context.Context, userID string) (bool, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// AdminRepository defines the interface for the read-only queries of the admin dashboard
+type AdminRepository interface {
+	// CountUsers returns the number of registered users
+	CountUsers(ctx context.Context) (int, error)
+
+	// ListUsers returns up to limit users ordered by creation, skipping the first offset
+	ListUsers(ctx context.Context, limit, offset int) ([]*application.User, error)
+
+	// CountTasksCreatedSince returns the number of tasks created on each day since since, in
+	// UTC, oldest first. Days without tasks are left out.
+	CountTasksCreatedSince(ctx context.Context, since time.Time) ([]application.DailyCount, error)
+}
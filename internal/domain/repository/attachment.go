@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// AttachmentRepository defines the interface for task attachment persistence
+type AttachmentRepository interface {
+	// Create creates a new attachment
+	Create(ctx context.Context, attachment *application.Attachment) error
+
+	// FindByID finds an attachment by ID. It returns application.ErrAttachmentNotFound when
+	// there is none.
+	FindByID(ctx context.Context, id string) (*application.Attachment, error)
+
+	// FindByTaskID finds all attachments of a task
+	FindByTaskID(ctx context.Context, taskID string) ([]*application.Attachment, error)
+
+	// FindByOwnerID finds the attachments of all tasks owned by a user
+	FindByOwnerID(ctx context.Context, ownerID string) ([]*application.Attachment, error)
+
+	// CountByTaskID counts the attachments of a task
+	CountByTaskID(ctx context.Context, taskID string) (int, error)
+
+	// Delete deletes an attachment by ID
+	Delete(ctx context.Context, id string) error
+}
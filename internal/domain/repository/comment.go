@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// CommentRepository defines the interface for the persistence of task comments and the
+// users they mention
+type CommentRepository interface {
+	// Create creates a new comment, recording a mention of each user in comment.Mentions
+	Create(ctx context.Context, comment *application.Comment) error
+
+	// FindByTaskID finds the comments of a task with their mentions, oldest first
+	FindByTaskID(ctx context.Context, taskID string) ([]*application.Comment, error)
+
+	// FindByTaskIDs finds the comments of several tasks at once, by task ID, oldest first
+	FindByTaskIDs(ctx context.Context, taskIDs []string) (map[string][]*application.Comment, error)
+}
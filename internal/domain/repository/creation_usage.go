@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// CreationUsageRepository defines the interface for creation quota persistence
+type CreationUsageRepository interface {
+	// CreateIfBelowLimit atomically records a usage unless it would take the user over limit
+	// creations of its kind since the given time
+	CreateIfBelowLimit(ctx context.Context, usage *application.CreationUsage, since time.Time, limit int) (bool, error)
+
+	// FindOldestSince finds the time of the oldest usage of a kind by a user since the given time
+	FindOldestSince(ctx context.Context, userID string, kind application.CreationKind, since time.Time) (time.Time, error)
+
+	// Delete deletes a usage record, giving the quota back
+	Delete(ctx context.Context, id string) error
+
+	// DeleteBefore deletes usage records older than the given time
+	DeleteBefore(ctx context.Context, before time.Time) error
+}
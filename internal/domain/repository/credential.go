@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// CredentialRepository defines the interface for passkey (WebAuthn credential) persistence
+type CredentialRepository interface {
+	// Create creates a new credential
+	Create(ctx context.Context, credential *application.Credential) error
+
+	// FindByID finds a credential by ID. It returns application.ErrCredentialNotFound when
+	// there is none.
+	FindByID(ctx context.Context, id string) (*application.Credential, error)
+
+	// FindByUserID finds the credentials of a user, oldest first
+	FindByUserID(ctx context.Context, userID string) ([]*application.Credential, error)
+
+	// UpdateUsage stores the sign count, backup state and last use of a credential
+	UpdateUsage(ctx context.Context, credential *application.Credential) error
+
+	// Delete deletes a credential by ID
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// DependencyRepository defines the interface for persisting "blocked by" relationships
+// between tasks
+type DependencyRepository interface {
+	// Add records a dependency; adding an existing dependency does nothing
+	Add(ctx context.Context, dependency *application.TaskDependency) error
+
+	// Remove removes the dependency of a task on a blocker, returning
+	// application.ErrDependencyNotFound when there is none
+	Remove(ctx context.Context, taskID, blockerID string) error
+
+	// FindBlockers finds the tasks blocking a task, in the order they were added
+	FindBlockers(ctx context.Context, taskID string) ([]*application.Task, error)
+
+	// FindOpenBlockers finds the tasks that block each of taskIDs and aren't completed yet,
+	// keyed by the blocked task ID. Tasks without open blockers are left out.
+	FindOpenBlockers(ctx context.Context, taskIDs []string) (map[string][]*application.Task, error)
+
+	// IsBlockedBy reports whether a task depends on a blocker, directly or through other tasks
+	IsBlockedBy(ctx context.Context, taskID, blockerID string) (bool, error)
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// DigestRepository defines the interface for weekly digest subscriptions and the task
+// statistics they summarize
+type DigestRepository interface {
+	// Save creates the subscription of a user, or replaces its schedule
+	Save(ctx context.Context, subscription *application.DigestSubscription) error
+
+	// FindByUserID finds the subscription of a user, or returns
+	// application.ErrDigestSubscriptionNotFound when they haven't opted in
+	FindByUserID(ctx context.Context, userID string) (*application.DigestSubscription, error)
+
+	// Delete deletes the subscription of a user
+	Delete(ctx context.Context, userID string) error
+
+	// FindDue finds up to limit subscriptions whose next digest is not after now, oldest first
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*application.DigestSubscription, error)
+
+	// Reschedule moves the next digest of a subscription from previous to next, recording
+	// sentAt. It returns false when the subscription was already moved or deleted, so
+	// concurrent runs send each digest once.
+	Reschedule(ctx context.Context, userID string, previous, next, sentAt time.Time) (bool, error)
+
+	// Summarize counts the tasks owned by a user for the week from since to now, and finds
+	// up to limit tasks not completed coming due within the next DigestUpcomingDays days
+	Summarize(ctx context.Context, userID string, since, now time.Time, limit int) (*application.WeeklyDigest, error)
+}
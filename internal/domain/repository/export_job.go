@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// ExportJobRepository defines the interface for persisting background export jobs
+type ExportJobRepository interface {
+	// Create creates a new job
+	Create(ctx context.Context, job *application.ExportJob) error
+
+	// FindByID finds a job by ID without its file, or returns application.ErrExportJobNotFound
+	FindByID(ctx context.Context, id string) (*application.ExportJob, error)
+
+	// FindResult returns the file generated by a job. It returns
+	// application.ErrExportJobNotFound until the job is done, and once it expires.
+	FindResult(ctx context.Context, id string) ([]byte, error)
+
+	// ClaimNext marks the oldest pending job as running and returns it, or returns
+	// application.ErrExportJobNotFound when there is none. Running jobs started before
+	// staleBefore are claimed again, so jobs interrupted by a restart are not lost.
+	ClaimNext(ctx context.Context, now, staleBefore time.Time) (*application.ExportJob, error)
+
+	// Finish stores the file of a running job and marks it as done
+	Finish(ctx context.Context, id string, result []byte, finishedAt time.Time) error
+
+	// Fail marks a running job as failed with a message for its owner
+	Fail(ctx context.Context, id, message string, finishedAt time.Time) error
+
+	// DeleteFinishedBefore deletes the jobs that finished before a time, with their files
+	DeleteFinishedBefore(ctx context.Context, before time.Time) (int, error)
+}
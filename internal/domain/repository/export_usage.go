@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// ExportUsageRepository defines the interface for export quota persistence
+type ExportUsageRepository interface {
+	// CreateIfBelowLimit atomically records an export unless the user already has limit exports since the given time
+	CreateIfBelowLimit(ctx context.Context, usage *application.ExportUsage, since time.Time, limit int) (bool, error)
+
+	// FindOldestSince finds the time of the oldest export of a user made since the given time
+	FindOldestSince(ctx context.Context, userID string, since time.Time) (time.Time, error)
+
+	// Delete deletes an export record, giving the quota back
+	Delete(ctx context.Context, id string) error
+
+	// DeleteBefore deletes export records older than the given time
+	DeleteBefore(ctx context.Context, before time.Time) error
+}
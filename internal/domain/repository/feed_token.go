@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// FeedTokenRepository defines the interface for the persistence of the task feed tokens
+type FeedTokenRepository interface {
+	// Save stores the feed token of a user, replacing the one they had
+	Save(ctx context.Context, token *application.FeedToken) error
+
+	// FindByTokenHash finds a feed token by its hash, or returns application.ErrFeedTokenNotFound
+	FindByTokenHash(ctx context.Context, tokenHash string) (*application.FeedToken, error)
+
+	// Delete removes the feed token of a user. It returns false when they had none.
+	Delete(ctx context.Context, userID string) (bool, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// IdempotencyRepository defines the interface for persisting the requests sent with an
+// Idempotency-Key and their responses
+type IdempotencyRepository interface {
+	// Reserve stores the record of a request about to run and returns nil, unless the user
+	// already has a record with its key; then that record is returned and nothing changes.
+	// Records expired by the creation time of the new one, and those still running since
+	// before staleBefore, are replaced.
+	Reserve(ctx context.Context, record *application.IdempotencyRecord, staleBefore time.Time) (*application.IdempotencyRecord, error)
+
+	// Complete stores the response of a reserved request
+	Complete(ctx context.Context, record *application.IdempotencyRecord) error
+
+	// Delete deletes the record of a key, so the request can run again
+	Delete(ctx context.Context, userID, key string) error
+
+	// DeleteExpired deletes the records expired by a time
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// JobRepository defines the interface for persisting the queue of background jobs
+type JobRepository interface {
+	// Create queues a new job. Within a transaction, the job is only queued if it commits.
+	Create(ctx context.Context, job *application.Job) error
+
+	// ClaimNext marks the oldest pending job due by now as running, counts the attempt and
+	// returns the job. It returns application.ErrJobNotFound when no job is due. Running jobs
+	// started before staleBefore are claimed again, so jobs interrupted by a restart are not lost.
+	ClaimNext(ctx context.Context, now, staleBefore time.Time) (*application.Job, error)
+
+	// Finish marks a running job as done
+	Finish(ctx context.Context, id string, finishedAt time.Time) error
+
+	// Retry puts a running job back in the queue, to run again at runAt
+	Retry(ctx context.Context, id, message string, runAt time.Time) error
+
+	// Fail marks a running job as failed for good
+	Fail(ctx context.Context, id, message string, finishedAt time.Time) error
+
+	// FindFailed finds the failed jobs, most recently failed first, up to limit
+	FindFailed(ctx context.Context, limit int) ([]*application.Job, error)
+
+	// Requeue puts a failed job back in the queue with its attempts reset, to run at runAt.
+	// It returns application.ErrJobNotFound when there is no failed job with the ID.
+	Requeue(ctx context.Context, id string, runAt time.Time) (*application.Job, error)
+
+	// DeleteDoneBefore deletes the jobs done before a time; failed jobs are kept for inspection
+	DeleteDoneBefore(ctx context.Context, before time.Time) (int, error)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// LinkRepository defines the interface for persisting external link checks
+type LinkRepository interface {
+	// Save creates or updates the check result of a task link
+	Save(ctx context.Context, link *application.TaskLink) error
+
+	// FindByTaskID finds all checked links of a task
+	FindByTaskID(ctx context.Context, taskID string) ([]*application.TaskLink, error)
+
+	// FindBrokenByOwnerID finds broken links still present in the tasks of a user
+	FindBrokenByOwnerID(ctx context.Context, ownerID string) ([]*application.TaskLink, error)
+
+	// FindTasksWithLinks finds tasks whose description contains external links, the least recently
+	// checked first
+	FindTasksWithLinks(ctx context.Context) ([]*application.Task, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// LoginEventRepository defines the interface for login audit persistence
+type LoginEventRepository interface {
+	// Create records a login attempt
+	Create(ctx context.Context, event *application.LoginEvent) error
+
+	// FindByUserID finds the most recent login attempts of a user, successful or not
+	FindByUserID(ctx context.Context, userID string, limit int) ([]*application.LoginEvent, error)
+
+	// FindLastSuccessful finds the most recent successful login of a user, or returns
+	// application.ErrLoginEventNotFound if there is none
+	FindLastSuccessful(ctx context.Context, userID string) (*application.LoginEvent, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// NotificationRepository defines the interface for notification persistence
+type NotificationRepository interface {
+	// Create creates a new notification
+	Create(ctx context.Context, notification *application.Notification) error
+
+	// FindByUserID finds the most recent notifications of a user
+	FindByUserID(ctx context.Context, userID string, limit int) ([]*application.Notification, error)
+
+	// MarkAsRead marks a notification of a user as read
+	MarkAsRead(ctx context.Context, id, userID string) error
+}
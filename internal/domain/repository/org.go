@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// OrgRepository defines the interface for persisting orgs and their members
+type OrgRepository interface {
+	// Create creates an org, without members
+	Create(ctx context.Context, org *application.Org) error
+
+	// Update updates the name and the sharing default of an org
+	Update(ctx context.Context, org *application.Org) error
+
+	// FindByID finds an org by ID. It returns application.ErrOrgNotFound when there is none.
+	FindByID(ctx context.Context, id string) (*application.Org, error)
+
+	// FindByUserID finds the orgs a user belongs to, with their role, ordered by name
+	FindByUserID(ctx context.Context, userID string) ([]*application.OrgMembership, error)
+
+	// FindMember finds the membership of a user in an org. It returns
+	// application.ErrOrgMemberNotFound when the user isn't a member.
+	FindMember(ctx context.Context, orgID, userID string) (*application.OrgMember, error)
+
+	// FindMembers finds the members of an org with their names and emails, ordered by name
+	FindMembers(ctx context.Context, orgID string) ([]*application.OrgMember, error)
+
+	// SaveMember adds a user to an org, or changes their role when they already belong to it
+	SaveMember(ctx context.Context, member *application.OrgMember) error
+
+	// RemoveMember removes a user from an org. Their tasks in the org stay in it.
+	RemoveMember(ctx context.Context, orgID, userID string) error
+
+	// CountOwners counts the owners of an org
+	CountOwners(ctx context.Context, orgID string) (int, error)
+}
+
+// orgScopeKey is the context key of the workspace the task lists are scoped to
+type orgScopeKey struct{}
+
+// WithOrgScope scopes the task lists read with the returned context to a workspace: the tasks
+// of the org orgID, or the personal tasks, in no org, when orgID is empty. Tasks created with
+// it belong to that workspace.
+func WithOrgScope(ctx context.Context, orgID string) context.Context {
+	return context.WithValue(ctx, orgScopeKey{}, orgID)
+}
+
+// WithoutOrgScope lifts the workspace scope of ctx, so the task lists read with the returned
+// context hold the tasks of every workspace, as for the background jobs
+func WithoutOrgScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, orgScopeKey{}, nil)
+}
+
+// OrgScope returns the workspace the task lists of ctx are scoped to, if any. Without a scope,
+// as in the background jobs, task lists hold the tasks of every workspace.
+func OrgScope(ctx context.Context) (orgID string, scoped bool) {
+	orgID, scoped = ctx.Value(orgScopeKey{}).(string)
+	return orgID, scoped
+}
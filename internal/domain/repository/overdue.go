@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// OverdueRepository defines the interface for the batch updates of the overdue job
+type OverdueRepository interface {
+	// FlagOverdue flags, with overdue_at set to now, up to limit pending tasks whose due date
+	// is before now and that aren't flagged yet, skipping the users who opted out. It returns
+	// the flagged tasks.
+	FlagOverdue(ctx context.Context, now time.Time, limit int) ([]*application.Task, error)
+}
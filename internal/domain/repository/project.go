@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// ProjectRepository defines the interface for project persistence
+type ProjectRepository interface {
+	// Create creates a new project
+	Create(ctx context.Context, project *application.Project) error
+
+	// Update updates the name and color of a project
+	Update(ctx context.Context, project *application.Project) error
+
+	// Delete deletes a project by ID. Its tasks are kept, without a project.
+	Delete(ctx context.Context, id string) error
+
+	// FindByID finds a project by ID. It returns application.ErrProjectNotFound when there is
+	// none.
+	FindByID(ctx context.Context, id string) (*application.Project, error)
+
+	// FindByOwnerID finds all projects owned by a user, ordered by name; like the task lists, they
+	// are only those of the workspace of ctx when it is scoped (see WithOrgScope)
+	FindByOwnerID(ctx context.Context, ownerID string) ([]*application.Project, error)
+
+	// FindSharedWithUser finds all projects shared with a user, ordered by name, in the workspace
+	// of ctx when it is scoped
+	FindSharedWithUser(ctx context.Context, userID string) ([]*application.Project, error)
+
+	// Share shares a project, and so all its tasks, with a user
+	Share(ctx context.Context, projectID, userID string) error
+
+	// Unshare removes sharing of a project with a user
+	Unshare(ctx context.Context, projectID, userID string) error
+
+	// IsSharedWith checks if a project is shared with a user
+	IsSharedWith(ctx context.Context, projectID, userID string) (bool, error)
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// ReminderRepository defines the interface for reminder persistence
+type ReminderRepository interface {
+	// Create creates a new reminder
+	Create(ctx context.Context, reminder *application.Reminder) error
+
+	// FindByID finds a reminder by ID. It returns application.ErrReminderNotFound when there
+	// is none.
+	FindByID(ctx context.Context, id string) (*application.Reminder, error)
+
+	// FindByTaskID finds the reminders a user set on a task, ordered by time
+	FindByTaskID(ctx context.Context, taskID, userID string) ([]*application.Reminder, error)
+
+	// CountPending counts the reminders of a user on a task that weren't delivered yet
+	CountPending(ctx context.Context, taskID, userID string) (int, error)
+
+	// Delete deletes a reminder by ID
+	Delete(ctx context.Context, id string) error
+
+	// FindDue finds up to limit undelivered reminders whose time is not after now, oldest first
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*application.Reminder, error)
+
+	// MarkSent records the delivery of a reminder. It returns false when the reminder was
+	// already marked or deleted, so concurrent runs deliver each reminder once.
+	MarkSent(ctx context.Context, id string, sentAt time.Time) (bool, error)
+}
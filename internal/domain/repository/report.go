@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// ReportRepository defines the interface for read-only team report queries
+type ReportRepository interface {
+	// FindOverdueByUnit finds tasks not completed whose due date is before now, owned by users of a unit
+	FindOverdueByUnit(ctx context.Context, unit string, now time.Time) ([]*application.OverdueTask, error)
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
 )
 
 // TaskShare represents a task sharing relationship
@@ -12,15 +14,40 @@ type TaskShare struct {
 
 // ShareRepository defines the interface for task sharing persistence
 type ShareRepository interface {
-	// Share shares a task with a user
+	// Share invites a user to a task. The share is pending, without access to the task, until
+	// the user accepts it. Inviting a user already invited or sharing the task changes nothing.
 	Share(ctx context.Context, taskID, userID string) error
 
-	// Unshare removes sharing of a task with a user
+	// Unshare removes sharing of a task with a user, accepted or still pending
 	Unshare(ctx context.Context, taskID, userID string) error
 
-	// FindSharedUsers finds all users a task is shared with
-	FindSharedUsers(ctx context.Context, taskID string) ([]string, error)
+	// FindSharedUsers finds all users that accepted to share a task, with their names and
+	// emails, in the order they accepted
+	FindSharedUsers(ctx context.Context, taskID string) ([]*application.SharedUser, error)
+
+	// FindSharedUsersByTaskIDs finds the users that accepted to share each of several tasks, by
+	// task ID. Tasks without shares are left out.
+	FindSharedUsersByTaskIDs(ctx context.Context, taskIDs []string) (map[string][]string, error)
+
+	// FindInvitedUserIDs finds the IDs of the users a task is shared with, accepted or still
+	// pending
+	FindInvitedUserIDs(ctx context.Context, taskID string) ([]string, error)
+
+	// FindPendingInvitations finds the invitations a user hasn't answered, newest first
+	FindPendingInvitations(ctx context.Context, userID string) ([]*application.ShareInvitation, error)
+
+	// AcceptInvitation turns the pending invitation of a user into a share. It returns
+	// application.ErrInvitationNotFound when there is no such pending invitation.
+	AcceptInvitation(ctx context.Context, taskID, userID string) error
+
+	// DeclineInvitation removes the pending invitation of a user. It returns
+	// application.ErrInvitationNotFound when there is no such pending invitation.
+	DeclineInvitation(ctx context.Context, taskID, userID string) error
+
+	// DeleteAllShares removes every share of a task
+	DeleteAllShares(ctx context.Context, taskID string) error
 
-	// IsSharedWith checks if a task is shared with a user
+	// IsSharedWith checks if a task is shared with a user, through an accepted share, its project
+	// or its org
 	IsSharedWith(ctx context.Context, taskID, userID string) (bool, error)
 }
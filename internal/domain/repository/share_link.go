@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// ShareLinkRepository defines the interface for the persistence of public task links
+type ShareLinkRepository interface {
+	// Create creates a new share link
+	Create(ctx context.Context, link *application.ShareLink) error
+
+	// FindByID finds a share link by ID. It returns application.ErrShareLinkNotFound when there
+	// is none.
+	FindByID(ctx context.Context, id string) (*application.ShareLink, error)
+
+	// FindByTokenHash finds a share link by the hash of its token. It returns
+	// application.ErrShareLinkNotFound when there is none.
+	FindByTokenHash(ctx context.Context, tokenHash string) (*application.ShareLink, error)
+
+	// FindActiveByTaskID finds the links of a task that are neither revoked nor expired at
+	// now, newest first
+	FindActiveByTaskID(ctx context.Context, taskID string, now time.Time) ([]*application.ShareLink, error)
+
+	// Revoke records the revocation of a share link. It returns false when the link doesn't
+	// exist or was already revoked.
+	Revoke(ctx context.Context, id string, revokedAt time.Time) (bool, error)
+}
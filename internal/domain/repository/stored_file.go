@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// StoredFileRepository defines the interface for tracking the disk space taken by uploads
+type StoredFileRepository interface {
+	// CreateIfWithinQuota atomically records a file unless it would take its user over userLimit
+	// bytes or all users over globalLimit bytes. A limit of zero or less is not enforced.
+	CreateIfWithinQuota(ctx context.Context, file *application.StoredFile, userLimit, globalLimit int64) (bool, error)
+
+	// FindByName returns the record of a file, or application.ErrStoredFileNotFound
+	FindByName(ctx context.Context, kind application.StoredFileKind, name string) (*application.StoredFile, error)
+
+	// Delete deletes the record of a file; a file that isn't recorded is not an error
+	Delete(ctx context.Context, kind application.StoredFileKind, name string) error
+
+	// UsageByUser returns the space taken by the files of a user
+	UsageByUser(ctx context.Context, userID string) (*application.StorageUsage, error)
+
+	// TotalUsage returns the space taken by the files of all users
+	TotalUsage(ctx context.Context) (*application.StorageUsage, error)
+}
@@ -11,18 +11,29 @@ type TaskRepository interface {
 	// Create creates a new task
 	Create(ctx context.Context, task *application.Task) error
 
-	// Update updates an existing task
+	// Update updates an existing task, including its owner when it was transferred
 	Update(ctx context.Context, task *application.Task) error
 
 	// Delete deletes a task by ID
 	Delete(ctx context.Context, id string) error
 
-	// FindByID finds a task by ID
+	// FindByID finds a task by ID. It returns application.ErrTaskNotFound when there is none,
+	// including a task in the trash.
 	FindByID(ctx context.Context, id string) (*application.Task, error)
 
-	// FindByOwnerID finds all tasks owned by a user
+	// FindByOwnerID finds all tasks owned by a user, in the workspace ctx is scoped to, if any
 	FindByOwnerID(ctx context.Context, ownerID string) ([]*application.Task, error)
 
-	// FindSharedWithUser finds all tasks shared with a user
+	// FindByProjectID finds all tasks of a project, in the workspace ctx is scoped to, if any
+	FindByProjectID(ctx context.Context, projectID string) ([]*application.Task, error)
+
+	// FindByImagePath finds the tasks whose image is stored at imagePath
+	FindByImagePath(ctx context.Context, imagePath string) ([]*application.Task, error)
+
+	// FindSharedWithUser finds all tasks shared with a user, directly or through their project or
+	// org, in the workspace ctx is scoped to, if any
 	FindSharedWithUser(ctx context.Context, userID string) ([]*application.Task, error)
+
+	// CreateMany creates several tasks atomically: either all are persisted or none
+	CreateMany(ctx context.Context, tasks []*application.Task) error
 }
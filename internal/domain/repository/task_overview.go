@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// TaskOverviewQuery defines the read model of the tasks page, kept apart from the repositories
+// of the entities: it joins what the page shows in a single query
+type TaskOverviewQuery interface {
+	// FindOverview finds up to limit items of the tasks owned by a user that aren't snoozed at
+	// now and of the tasks shared with them, in the workspace ctx is scoped to if any, sorted by sort (-created_at or created_at) and
+	// starting after the cursor, nil for the first page, with the counters of every such task.
+	// The overview has no NextCursor: callers page with the cursor of the last item.
+	FindOverview(ctx context.Context, userID string, now time.Time, sort application.TaskSort, after *application.TaskCursor, limit int) (*application.TaskOverview, error)
+}
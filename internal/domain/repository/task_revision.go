@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// TaskRevisionRepository defines the interface for the previous versions of tasks
+type TaskRevisionRepository interface {
+	// Add stores a revision, setting its ID, and deletes the oldest revisions of its task
+	// beyond the keep newest
+	Add(ctx context.Context, revision *application.TaskRevision, keep int) error
+
+	// FindByTaskID finds the revisions of a task, newest first
+	FindByTaskID(ctx context.Context, taskID string) ([]*application.TaskRevision, error)
+}
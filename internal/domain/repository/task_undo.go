@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// TaskUndoRepository defines the interface for the undo of task deletions and completions,
+// and for the trash holding deleted tasks until their deletion can't be undone anymore.
+// Trashed tasks are left out of every task lookup and listing.
+type TaskUndoRepository interface {
+	// Save records the undo of an action on a task, replacing the previous one of the task
+	Save(ctx context.Context, undo *application.TaskUndo) error
+
+	// Take removes and returns the undo a user can still take on a task at now. It returns
+	// application.ErrUndoExpired when there is none or it expired.
+	Take(ctx context.Context, taskID, userID string, now time.Time) (*application.TaskUndo, error)
+
+	// FindExpired finds up to limit undos expired at now, oldest first
+	FindExpired(ctx context.Context, now time.Time, limit int) ([]*application.TaskUndo, error)
+
+	// DeleteExpired removes the undo of a task if it expired at now. It returns false when the
+	// undo was taken or replaced meanwhile, so an action being undone isn't finalized.
+	DeleteExpired(ctx context.Context, taskID string, now time.Time) (bool, error)
+
+	// Trash hides a task until it is restored or deleted
+	Trash(ctx context.Context, taskID string, deletedAt time.Time) error
+
+	// Restore shows a trashed task again
+	Restore(ctx context.Context, taskID string) error
+
+	// FindTrashed finds a trashed task by ID, or returns application.ErrTaskNotFound when it
+	// isn't in the trash
+	FindTrashed(ctx context.Context, taskID string) (*application.Task, error)
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// TaskViewRepository defines the interface for read-only task list queries that load the
+// ownership and sharing details of every task in the same query. The lists only hold the tasks
+// of the workspace ctx is scoped to, if any; see WithOrgScope.
+type TaskViewRepository interface {
+	// FindByOwnerID finds up to limit views of the tasks owned by a user that aren't snoozed at
+	// now, sorted by sort (-created_at or created_at), starting after the cursor; nil starts at
+	// the first task
+	FindByOwnerID(ctx context.Context, ownerID string, now time.Time, sort application.TaskSort, after *application.TaskCursor, limit int) ([]*application.TaskView, error)
+
+	// FindByProjectID finds up to limit views of the tasks of a project, sorted by sort
+	// (-created_at or created_at), starting after the cursor; nil starts at the first task
+	FindByProjectID(ctx context.Context, projectID string, sort application.TaskSort, after *application.TaskCursor, limit int) ([]*application.TaskView, error)
+
+	// FindSharedWithUser finds a page of the views of the tasks shared with a user, directly or
+	// through their project or org, that match query, and how many tasks match it in total. In cursor
+	// pagination the total isn't counted and is 0.
+	FindSharedWithUser(ctx context.Context, userID string, query application.TaskListQuery) ([]*application.TaskView, int, error)
+
+	// CountForUser counts the open tasks owned by a user that aren't snoozed at now, those
+	// of them past their due date, and the open tasks shared with the user, in one query
+	CountForUser(ctx context.Context, userID string, now time.Time) (*application.TaskCounters, error)
+}
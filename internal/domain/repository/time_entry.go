@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// TimeEntryRepository defines the interface for time entry persistence
+type TimeEntryRepository interface {
+	// Start creates a running time entry. It returns false, creating nothing, when the user
+	// already has a timer running on the task, so concurrent starts create a single entry.
+	Start(ctx context.Context, entry *application.TimeEntry) (bool, error)
+
+	// FindRunning finds the running entry of a user on a task, or returns
+	// application.ErrTimerNotRunning when there is none
+	FindRunning(ctx context.Context, taskID, userID string) (*application.TimeEntry, error)
+
+	// FindRunningByUserID finds the running entries of a user, on any task
+	FindRunningByUserID(ctx context.Context, userID string) ([]*application.TimeEntry, error)
+
+	// Stop records the end of a running entry. It returns false when the entry was already
+	// stopped or deleted, so concurrent stops record a single end.
+	Stop(ctx context.Context, id string, stoppedAt time.Time) (bool, error)
+
+	// SumByTaskIDs sums the time tracked on several tasks by all of their users, counting the
+	// running entries up to now, keyed by task ID. Tasks without entries are left out.
+	SumByTaskIDs(ctx context.Context, taskIDs []string, now time.Time) (map[string]time.Duration, error)
+}
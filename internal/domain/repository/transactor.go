@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+)
+
+// Transactor runs units of work spanning several repositories atomically
+type Transactor interface {
+	// WithinTransaction runs fn in a transaction, committed when fn returns nil and rolled
+	// back otherwise. Repository calls made with the context passed to fn take part in it.
+	// fn may run again when the transaction has to start over, so it must set anew whatever
+	// it leaves for the caller.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// TwoFactorRepository defines the interface for the persistence of TOTP second factors, their
+// recovery codes and the login challenges waiting for them
+type TwoFactorRepository interface {
+	// FindByUserID finds the second factor of a user. It returns
+	// application.ErrTwoFactorNotFound when the user never started the enrollment.
+	FindByUserID(ctx context.Context, userID string) (*application.TwoFactor, error)
+
+	// Save creates or replaces the second factor of a user
+	Save(ctx context.Context, twoFactor *application.TwoFactor) error
+
+	// AdvanceLastStep stores step as the last accepted time step if it is newer than the
+	// stored one, reporting whether it was. Used to reject replayed codes atomically.
+	AdvanceLastStep(ctx context.Context, userID string, step int64) (bool, error)
+
+	// Delete deletes the second factor and the recovery codes of a user
+	Delete(ctx context.Context, userID string) error
+
+	// ReplaceRecoveryCodes replaces the recovery codes of a user with the given hashes
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error
+
+	// UseRecoveryCode deletes a recovery code of a user, reporting whether it existed
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error)
+
+	// CountRecoveryCodes counts the unused recovery codes of a user
+	CountRecoveryCodes(ctx context.Context, userID string) (int, error)
+
+	// CreateChallenge creates a login challenge
+	CreateChallenge(ctx context.Context, challenge *application.TwoFactorChallenge) error
+
+	// FindChallenge finds a login challenge by token hash, or returns
+	// application.ErrTwoFactorChallengeNotFound
+	FindChallenge(ctx context.Context, tokenHash string) (*application.TwoFactorChallenge, error)
+
+	// RecordChallengeAttempt counts a wrong code against a login challenge
+	RecordChallengeAttempt(ctx context.Context, tokenHash string) error
+
+	// DeleteChallenge deletes a login challenge, reporting whether it existed, so concurrent
+	// answers can't both succeed
+	DeleteChallenge(ctx context.Context, tokenHash string) (bool, error)
+}
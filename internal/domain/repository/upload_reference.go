@@ -0,0 +1,12 @@
+package repository
+
+import "context"
+
+// UploadReferenceRepository defines the interface for finding the uploaded files still in use
+type UploadReferenceRepository interface {
+	// ImagePaths returns the image paths of all tasks and the avatar paths of all users
+	ImagePaths(ctx context.Context) ([]string, error)
+
+	// AttachmentPaths returns the file paths of all task attachments
+	AttachmentPaths(ctx context.Context) ([]string, error)
+}
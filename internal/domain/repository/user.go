@@ -11,10 +11,15 @@ type UserRepository interface {
 	// Create creates a new user
 	Create(ctx context.Context, user *application.User) error
 
-	// FindByID finds a user by ID
+	// FindByID finds a user by ID. It returns application.ErrUserNotFound when there is none.
 	FindByID(ctx context.Context, id string) (*application.User, error)
 
-	// FindByEmail finds a user by email
+	// FindByIDs finds the users with the given IDs, in no particular order. IDs of users that
+	// don't exist are left out.
+	FindByIDs(ctx context.Context, ids []string) ([]*application.User, error)
+
+	// FindByEmail finds a user by email. It returns application.ErrUserNotFound when there is
+	// none.
 	FindByEmail(ctx context.Context, email string) (*application.User, error)
 
 	// Update updates an existing user
@@ -22,4 +27,11 @@ type UserRepository interface {
 
 	// Delete deletes a user by ID
 	Delete(ctx context.Context, id string) error
+
+	// RevokeSessions invalidates the tokens issued to a user so far by incrementing their
+	// token version. Update leaves the version alone.
+	RevokeSessions(ctx context.Context, id string) error
+
+	// Search finds up to limit users whose name or email contains the query, except excludeID
+	Search(ctx context.Context, query, excludeID string, limit int) ([]*application.User, error)
 }
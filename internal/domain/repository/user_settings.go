@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// UserSettingsRepository defines the interface for the persistence of user settings
+type UserSettingsRepository interface {
+	// Save stores the settings of a user, replacing the ones they had
+	Save(ctx context.Context, settings *application.UserSettings) error
+
+	// FindByUserID finds the settings of a user, or returns application.ErrUserSettingsNotFound
+	// when they never saved any
+	FindByUserID(ctx context.Context, userID string) (*application.UserSettings, error)
+}
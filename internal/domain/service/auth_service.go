@@ -2,34 +2,73 @@ package service
 
 import (
 	"errors"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
-	"golang.org/x/crypto/bcrypt"
 )
 
 // JWTClaims represents the claims in a JWT token
 type JWTClaims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
+	UserID       string `json:"user_id"`
+	Email        string `json:"email"`
+	TokenVersion int    `json:"token_version,omitempty"` // Token version of the user when issued
 	jwt.RegisteredClaims
 }
 
 // AuthService handles authentication operations
 type AuthService struct {
-	secretKey []byte
+	keys      *JWTKeySet
+	hasher    PasswordHasher
+	verifiers map[string]PasswordHasher
+
+	decoyOnce sync.Once
+	decoyHash string // Hash of a throwaway password, made on the first VerifyDecoyPassword
 }
 
-// NewAuthService creates a new AuthService
+// NewAuthService creates a new AuthService signing tokens with a single secret and hashing
+// passwords with bcrypt at the default cost
 func NewAuthService(secretKey string) *AuthService {
+	return NewAuthServiceWithKeys(NewJWTKeySet(secretKey), nil)
+}
+
+// NewAuthServiceWithKeys creates a new AuthService signing and validating tokens with a key
+// set shared with the other services, and hashing new passwords with hasher. A nil hasher
+// means bcrypt at the default cost. Hashes of the other algorithm are still verified.
+func NewAuthServiceWithKeys(keys *JWTKeySet, hasher PasswordHasher) *AuthService {
+	bcryptHasher := &BcryptHasher{cost: DefaultPasswordHashParams.BcryptCost}
+	if hasher == nil {
+		hasher = bcryptHasher
+	}
+
+	verifiers := map[string]PasswordHasher{
+		AlgorithmBcrypt: bcryptHasher,
+		AlgorithmArgon2id: &Argon2idHasher{
+			memory:      DefaultPasswordHashParams.Argon2MemoryKiB,
+			iterations:  DefaultPasswordHashParams.Argon2Iterations,
+			parallelism: DefaultPasswordHashParams.Argon2Parallelism,
+		},
+	}
+	verifiers[hasher.Algorithm()] = hasher
+
 	return &AuthService{
-		secretKey: []byte(secretKey),
+		keys:      keys,
+		hasher:    hasher,
+		verifiers: verifiers,
 	}
 }
 
-// GenerateToken generates a JWT token for a user
+// GenerateToken generates a JWT token for a user, signed with the newest key
 func (s *AuthService) GenerateToken(userID, email string, duration time.Duration) (string, error) {
-	if len(s.secretKey) == 0 {
+	return s.GenerateVersionedToken(userID, email, 0, duration)
+}
+
+// GenerateVersionedToken generates a JWT token for a user carrying their token version, so the
+// token stops being accepted once the version is incremented (see CheckSession)
+func (s *AuthService) GenerateVersionedToken(userID, email string, tokenVersion int, duration time.Duration) (string, error) {
+	key := s.keys.signingKey()
+	if len(key.Secret) == 0 {
 		return "", errors.New("secret key cannot be empty")
 	}
 	if userID == "" {
@@ -37,8 +76,9 @@ func (s *AuthService) GenerateToken(userID, email string, duration time.Duration
 	}
 
 	claims := JWTClaims{
-		UserID: userID,
-		Email:  email,
+		UserID:       userID,
+		Email:        email,
+		TokenVersion: tokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(duration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -46,7 +86,10 @@ func (s *AuthService) GenerateToken(userID, email string, duration time.Duration
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString(s.secretKey)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+	signedToken, err := token.SignedString(key.Secret)
 	if err != nil {
 		return "", err
 	}
@@ -54,7 +97,8 @@ func (s *AuthService) GenerateToken(userID, email string, duration time.Duration
 	return signedToken, nil
 }
 
-// ValidateToken validates a JWT token and returns the claims
+// ValidateToken validates a JWT token and returns the claims. Tokens naming a key in their
+// "kid" header must be signed with that key; tokens without one may be signed with any key.
 func (s *AuthService) ValidateToken(tokenString string) (*JWTClaims, error) {
 	if tokenString == "" {
 		return nil, errors.New("token cannot be empty")
@@ -65,7 +109,18 @@ func (s *AuthService) ValidateToken(tokenString string) (*JWTClaims, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("invalid signing method")
 		}
-		return s.secretKey, nil
+
+		kid, _ := token.Header["kid"].(string)
+		keys := s.keys.validationKeys(kid)
+		if len(keys) == 0 {
+			return nil, errors.New("unknown signing key")
+		}
+
+		set := jwt.VerificationKeySet{Keys: make([]jwt.VerificationKey, len(keys))}
+		for i, key := range keys {
+			set.Keys[i] = key.Secret
+		}
+		return set, nil
 	})
 
 	if err != nil {
@@ -79,21 +134,59 @@ func (s *AuthService) ValidateToken(tokenString string) (*JWTClaims, error) {
 	return nil, errors.New("invalid token")
 }
 
-// HashPassword hashes a password using bcrypt
+// HashPassword hashes a password with the configured algorithm. The hash starts with the
+// algorithm name in braces, as in "{argon2id}$argon2id$v=19$...".
 func (s *AuthService) HashPassword(password string) (string, error) {
 	if password == "" {
 		return "", errors.New("password cannot be empty")
 	}
 
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hash, err := s.hasher.Hash(password)
 	if err != nil {
 		return "", err
 	}
 
-	return string(hash), nil
+	return "{" + s.hasher.Algorithm() + "}" + hash, nil
 }
 
-// VerifyPassword verifies a password against a hash
+// VerifyPassword verifies a password against a hash. Hashes without an algorithm prefix are
+// legacy bcrypt hashes.
 func (s *AuthService) VerifyPassword(hash, password string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	algorithm, encoded := splitPasswordHash(hash)
+	verifier, ok := s.verifiers[algorithm]
+	if !ok {
+		return errors.New("unknown password hash algorithm")
+	}
+	return verifier.Verify(encoded, password)
+}
+
+// VerifyDecoyPassword checks password against the hash of a throwaway password, made with
+// the configured algorithm and parameters, and discards the result. Rejecting a login for an
+// unknown account with it costs as much as rejecting a wrong password, so response times
+// don't tell which accounts exist.
+func (s *AuthService) VerifyDecoyPassword(password string) {
+	s.decoyOnce.Do(func() {
+		s.decoyHash, _ = s.HashPassword("decoy password, never matched")
+	})
+	s.VerifyPassword(s.decoyHash, password)
+}
+
+// NeedsRehash reports whether a hash should be replaced by a new one: legacy hashes without
+// a prefix, hashes of another algorithm and hashes made with other parameters
+func (s *AuthService) NeedsRehash(hash string) bool {
+	if !strings.HasPrefix(hash, "{") {
+		return true
+	}
+	algorithm, encoded := splitPasswordHash(hash)
+	return algorithm != s.hasher.Algorithm() || s.hasher.NeedsRehash(encoded)
+}
+
+// splitPasswordHash separates the algorithm prefix from a stored hash
+func splitPasswordHash(hash string) (string, string) {
+	if rest, ok := strings.CutPrefix(hash, "{"); ok {
+		if algorithm, encoded, ok := strings.Cut(rest, "}"); ok {
+			return algorithm, encoded
+		}
+	}
+	return AlgorithmBcrypt, hash
 }
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -211,3 +212,49 @@ func TestAuthService_VerifyPassword(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthService_PasswordHashPrefixAndRehash(t *testing.T) {
+	argon2Hasher, _ := NewPasswordHasher(AlgorithmArgon2id, fastParams)
+	bcryptHasher, _ := NewPasswordHasher(AlgorithmBcrypt, fastParams)
+	argon2Service := NewAuthServiceWithKeys(NewJWTKeySet("test-secret"), argon2Hasher)
+	bcryptService := NewAuthServiceWithKeys(NewJWTKeySet("test-secret"), bcryptHasher)
+
+	argon2Hash, err := argon2Service.HashPassword("password123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bcryptHash, _ := bcryptService.HashPassword("password123")
+	legacyHash := strings.TrimPrefix(bcryptHash, "{bcrypt}")
+
+	if !strings.HasPrefix(argon2Hash, "{argon2id}$argon2id$") || !strings.HasPrefix(bcryptHash, "{bcrypt}$2a$") {
+		t.Fatalf("hashes = %q and %q, want them prefixed with the algorithm", argon2Hash, bcryptHash)
+	}
+
+	tests := []struct {
+		name        string
+		hash        string
+		needsRehash bool
+	}{
+		{"same algorithm and parameters", argon2Hash, false},
+		{"other algorithm", bcryptHash, true},
+		{"legacy hash without prefix", legacyHash, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := argon2Service.VerifyPassword(tt.hash, "password123"); err != nil {
+				t.Errorf("VerifyPassword() error = %v", err)
+			}
+			if err := argon2Service.VerifyPassword(tt.hash, "wrong"); err == nil {
+				t.Error("VerifyPassword() with a wrong password returned nil")
+			}
+			if got := argon2Service.NeedsRehash(tt.hash); got != tt.needsRehash {
+				t.Errorf("NeedsRehash() = %v, want %v", got, tt.needsRehash)
+			}
+		})
+	}
+
+	if err := argon2Service.VerifyPassword("{md5}abc", "password123"); err == nil {
+		t.Error("VerifyPassword() of an unknown algorithm returned nil")
+	}
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DueDateExamples lists input formats accepted by ParseDueDate, shown to users when parsing fails
+var DueDateExamples = []string{"25/12/2025", "25/12", "2025-12-25", "hoje", "amanhã", "sexta", "sexta 14h", "25/12 às 9:30"}
+
+// DateParseError is returned when a due date input isn't recognized
+type DateParseError struct {
+	Input  string
+	Reason string
+}
+
+// Error implements the error interface, listing accepted examples
+func (e *DateParseError) Error() string {
+	reason := e.Reason
+	if reason == "" {
+		reason = "unrecognized date"
+	}
+	return fmt.Sprintf("%s %q; examples: %s", reason, e.Input, strings.Join(DueDateExamples, ", "))
+}
+
+var (
+	accentReplacer = strings.NewReplacer("á", "a", "à", "a", "â", "a", "ã", "a", "é", "e", "ê", "e", "í", "i", "ó", "o", "ô", "o", "õ", "o", "ú", "u", "ç", "c")
+
+	// timeSuffixRegex matches an optional trailing time: "14h", "14h30", "14:30", "às 9h"
+	timeSuffixRegex = regexp.MustCompile(`^(.*?)\s*(?:\bas\s+)?(\d{1,2})(?:h(\d{2})?|:(\d{2}))$`)
+	dayMonthRegex   = regexp.MustCompile(`^(\d{1,2})[/.-](\d{1,2})(?:[/.-](\d{2}|\d{4}))?$`)
+	isoDateRegex    = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})$`)
+
+	weekdays = map[string]time.Weekday{
+		"domingo": time.Sunday,
+		"segunda": time.Monday,
+		"terca":   time.Tuesday,
+		"quarta":  time.Wednesday,
+		"quinta":  time.Thursday,
+		"sexta":   time.Friday,
+		"sabado":  time.Saturday,
+	}
+)
+
+// ParseDueDate converts a user-provided due date into a time in the user's location.
+//
+// Accepted inputs are dd/mm/aaaa, dd/mm/aa, dd/mm, aaaa-mm-dd, RFC 3339 timestamps,
+// "hoje", "amanhã", "depois de amanhã" and weekday names ("sexta", "próxima segunda"),
+// optionally followed by a time ("14h", "14h30", "às 9:30"). Weekday names refer to the
+// next such day after today, and dd/mm without a year to its next occurrence. Dates
+// without a time are due at the end of the day.
+func ParseDueDate(input string, now time.Time, loc *time.Location) (time.Time, error) {
+	raw := strings.TrimSpace(input)
+	if raw == "" {
+		return time.Time{}, &DateParseError{Input: input, Reason: "empty date"}
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	now = now.In(loc)
+
+	// Machine clients may send full timestamps
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t.In(loc), nil
+	}
+
+	text := accentReplacer.Replace(strings.ToLower(raw))
+	text = strings.Join(strings.Fields(text), " ")
+
+	hour, minute, hasTime := 23, 59, false
+	if m := timeSuffixRegex.FindStringSubmatch(text); m != nil && !isBareDate(text) {
+		hour, _ = strconv.Atoi(m[2])
+		minute = 0
+		if m[3] != "" {
+			minute, _ = strconv.Atoi(m[3])
+		} else if m[4] != "" {
+			minute, _ = strconv.Atoi(m[4])
+		}
+		if hour > 23 || minute > 59 {
+			return time.Time{}, &DateParseError{Input: input, Reason: "invalid time"}
+		}
+		hasTime = true
+		text = strings.TrimSpace(m[1])
+	}
+
+	year, month, day, err := parseDatePart(text, now)
+	if err != nil {
+		return time.Time{}, &DateParseError{Input: input, Reason: err.Error()}
+	}
+
+	second := 0
+	if !hasTime {
+		second = 59
+	}
+	due := time.Date(year, month, day, hour, minute, second, 0, loc)
+	if due.Day() != day || due.Month() != month {
+		return time.Time{}, &DateParseError{Input: input, Reason: "invalid date"}
+	}
+
+	return due, nil
+}
+
+// isBareDate reports whether text is a numeric date that could be mistaken for a time (e.g. "25/12")
+func isBareDate(text string) bool {
+	return dayMonthRegex.MatchString(text) || isoDateRegex.MatchString(text)
+}
+
+// parseDatePart resolves the date portion of an input relative to now
+func parseDatePart(text string, now time.Time) (int, time.Month, int, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch text {
+	case "", "hoje":
+		return today.Year(), today.Month(), today.Day(), nil
+	case "amanha":
+		d := today.AddDate(0, 0, 1)
+		return d.Year(), d.Month(), d.Day(), nil
+	case "depois de amanha":
+		d := today.AddDate(0, 0, 2)
+		return d.Year(), d.Month(), d.Day(), nil
+	}
+
+	if weekday, ok := parseWeekday(text); ok {
+		days := (int(weekday) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		d := today.AddDate(0, 0, days)
+		return d.Year(), d.Month(), d.Day(), nil
+	}
+
+	if m := isoDateRegex.FindStringSubmatch(text); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		day, _ := strconv.Atoi(m[3])
+		return checkDate(year, month, day)
+	}
+
+	if m := dayMonthRegex.FindStringSubmatch(text); m != nil {
+		day, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+
+		if m[3] == "" {
+			year := today.Year()
+			if candidate := time.Date(year, time.Month(month), day, 0, 0, 0, 0, today.Location()); candidate.Before(today) {
+				year++
+			}
+			return checkDate(year, month, day)
+		}
+
+		year, _ := strconv.Atoi(m[3])
+		if len(m[3]) == 2 {
+			year += 2000
+		}
+		return checkDate(year, month, day)
+	}
+
+	return 0, 0, 0, fmt.Errorf("unrecognized date")
+}
+
+// parseWeekday parses "sexta", "sexta-feira", "proxima sexta", "na sexta"
+func parseWeekday(text string) (time.Weekday, bool) {
+	for _, prefix := range []string{"proxima ", "proximo ", "na ", "no ", "nesta ", "neste "} {
+		text = strings.TrimPrefix(text, prefix)
+	}
+	text = strings.TrimSuffix(text, "-feira")
+	text = strings.TrimSuffix(text, " feira")
+
+	weekday, ok := weekdays[text]
+	return weekday, ok
+}
+
+// checkDate validates numeric date components
+func checkDate(year, month, day int) (int, time.Month, int, error) {
+	if month < 1 || month > 12 || day < 1 || day > 31 {
+		return 0, 0, 0, fmt.Errorf("invalid date")
+	}
+	return year, time.Month(month), day, nil
+}
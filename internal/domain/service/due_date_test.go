@@ -0,0 +1,92 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDueDate(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	// Wednesday, 2025-06-11 10:00 in São Paulo
+	now := time.Date(2025, 6, 11, 10, 0, 0, 0, loc)
+
+	tests := []struct {
+		name  string
+		input string
+		want  time.Time
+	}{
+		{"dd/mm/aaaa", "25/12/2025", time.Date(2025, 12, 25, 23, 59, 59, 0, loc)},
+		{"dd/mm/aa", "25/12/26", time.Date(2026, 12, 25, 23, 59, 59, 0, loc)},
+		{"dd/mm in the future", "20/06", time.Date(2025, 6, 20, 23, 59, 59, 0, loc)},
+		{"dd/mm already passed rolls to next year", "01/02", time.Date(2026, 2, 1, 23, 59, 59, 0, loc)},
+		{"aaaa-mm-dd", "2025-07-01", time.Date(2025, 7, 1, 23, 59, 59, 0, loc)},
+		{"dd-mm-aaaa", "01-07-2025", time.Date(2025, 7, 1, 23, 59, 59, 0, loc)},
+		{"hoje", "hoje", time.Date(2025, 6, 11, 23, 59, 59, 0, loc)},
+		{"amanhã", "Amanhã", time.Date(2025, 6, 12, 23, 59, 59, 0, loc)},
+		{"amanha without accent", "amanha", time.Date(2025, 6, 12, 23, 59, 59, 0, loc)},
+		{"depois de amanhã", "depois de amanhã", time.Date(2025, 6, 13, 23, 59, 59, 0, loc)},
+		{"weekday", "sexta", time.Date(2025, 6, 13, 23, 59, 59, 0, loc)},
+		{"weekday with feira", "segunda-feira", time.Date(2025, 6, 16, 23, 59, 59, 0, loc)},
+		{"same weekday means next week", "quarta", time.Date(2025, 6, 18, 23, 59, 59, 0, loc)},
+		{"próxima weekday", "próxima terça", time.Date(2025, 6, 17, 23, 59, 59, 0, loc)},
+		{"weekday with time", "sexta 14h", time.Date(2025, 6, 13, 14, 0, 0, 0, loc)},
+		{"date with às and minutes", "25/12 às 9:30", time.Date(2025, 12, 25, 9, 30, 0, 0, loc)},
+		{"time with h and minutes", "amanhã 18h45", time.Date(2025, 6, 12, 18, 45, 0, 0, loc)},
+		{"time only means today", "16h", time.Date(2025, 6, 11, 16, 0, 0, 0, loc)},
+		{"rfc3339", "2025-07-01T12:00:00Z", time.Date(2025, 7, 1, 9, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDueDate(tt.input, now, loc)
+			if err != nil {
+				t.Fatalf("ParseDueDate(%q) error = %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDueDate(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDueDate_Invalid(t *testing.T) {
+	now := time.Date(2025, 6, 11, 10, 0, 0, 0, time.UTC)
+
+	inputs := []string{"", "   ", "semana que vem", "31/02/2025", "13/13/2025", "2025-00-10", "sexta 25h", "amanhã 10h75"}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseDueDate(input, now, time.UTC)
+			if err == nil {
+				t.Fatalf("ParseDueDate(%q) expected error", input)
+			}
+
+			var parseErr *DateParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("expected *DateParseError, got %T", err)
+			}
+			if !strings.Contains(err.Error(), "25/12/2025") {
+				t.Errorf("error should list examples, got %q", err.Error())
+			}
+		})
+	}
+}
+
+func TestParseDueDate_UsesLocation(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	// 20:00 UTC on the 11th is already the 12th in Tokyo
+	now := time.Date(2025, 6, 11, 20, 0, 0, 0, time.UTC)
+
+	got, err := ParseDueDate("hoje", now, tokyo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Day() != 12 || got.Location() != tokyo {
+		t.Errorf("expected end of 12th in JST, got %v", got)
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// JWTKey is a secret that signs or validates tokens. ID goes in the "kid" header of the
+// tokens it signs; the key set of a single JWT_SECRET has none.
+type JWTKey struct {
+	ID     string
+	Secret []byte
+}
+
+// JWTKeySet holds the keys accepted for tokens, newest first. Tokens are signed with the
+// newest key and validated against the key named by their "kid" header, or against every key
+// when they have none. The keys can be replaced while in use, which revokes at once every
+// token signed with a removed key.
+type JWTKeySet struct {
+	mu   sync.RWMutex
+	keys []JWTKey
+}
+
+// NewJWTKeySet creates a key set with a single secret and no key IDs
+func NewJWTKeySet(secret string) *JWTKeySet {
+	return &JWTKeySet{keys: []JWTKey{{Secret: []byte(secret)}}}
+}
+
+// NewJWTKeySetWithKeys creates a key set from keys, newest first
+func NewJWTKeySetWithKeys(keys []JWTKey) (*JWTKeySet, error) {
+	if err := validateJWTKeys(keys); err != nil {
+		return nil, err
+	}
+	return &JWTKeySet{keys: keys}, nil
+}
+
+// Replace swaps the keys of the set, newest first
+func (s *JWTKeySet) Replace(keys []JWTKey) error {
+	if err := validateJWTKeys(keys); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+	return nil
+}
+
+// IDs returns the IDs of the keys, newest first
+func (s *JWTKeySet) IDs() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]string, len(s.keys))
+	for i, key := range s.keys {
+		ids[i] = key.ID
+	}
+	return ids
+}
+
+// signingKey returns the newest key
+func (s *JWTKeySet) signingKey() JWTKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.keys[0]
+}
+
+// validationKeys returns the keys a token with the given "kid" header may be signed with
+func (s *JWTKeySet) validationKeys(kid string) []JWTKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if kid == "" {
+		return s.keys
+	}
+	for _, key := range s.keys {
+		if key.ID == kid {
+			return []JWTKey{key}
+		}
+	}
+	return nil
+}
+
+// ParseJWTKeys reads a key file: one "<id> <secret>" pair per line, newest first. Blank
+// lines and lines starting with "#" are ignored.
+func ParseJWTKeys(r io.Reader) ([]JWTKey, error) {
+	var keys []JWTKey
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: want \"<id> <secret>\"", line)
+		}
+		keys = append(keys, JWTKey{ID: fields[0], Secret: []byte(fields[1])})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateJWTKeys(keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// validateJWTKeys checks there is at least one key and every key has a secret and a unique ID
+func validateJWTKeys(keys []JWTKey) error {
+	if len(keys) == 0 {
+		return errors.New("at least one JWT key is required")
+	}
+
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if len(key.Secret) == 0 {
+			return fmt.Errorf("JWT key %q has an empty secret", key.ID)
+		}
+		if len(keys) > 1 && key.ID == "" {
+			return errors.New("every JWT key needs an ID when there are several")
+		}
+		if seen[key.ID] {
+			return fmt.Errorf("duplicate JWT key ID %q", key.ID)
+		}
+		seen[key.ID] = true
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestParseJWTKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantIDs []string
+		wantErr bool
+	}{
+		{"newest first", "2025-06 new-secret\n2025-01 old-secret\n", []string{"2025-06", "2025-01"}, false},
+		{"comments and blank lines", "# rotated in June\n\n  2025-06   new-secret  \n", []string{"2025-06"}, false},
+		{"empty file", "# no keys\n", nil, true},
+		{"missing secret", "2025-06\n", nil, true},
+		{"extra field", "2025-06 new secret\n", nil, true},
+		{"duplicate id", "k1 a\nk1 b\n", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := ParseJWTKeys(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseJWTKeys() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			set, err := NewJWTKeySetWithKeys(keys)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.Join(set.IDs(), ","); got != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("IDs() = %s, want %s", got, strings.Join(tt.wantIDs, ","))
+			}
+		})
+	}
+}
+
+func TestJWTKeySet_Replace(t *testing.T) {
+	set := NewJWTKeySet("secret")
+
+	tests := []struct {
+		name string
+		keys []JWTKey
+	}{
+		{"no keys", nil},
+		{"empty secret", []JWTKey{{ID: "k1"}}},
+		{"several keys without ids", []JWTKey{{Secret: []byte("a")}, {ID: "k2", Secret: []byte("b")}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := set.Replace(tt.keys); err == nil {
+				t.Error("Replace() error = nil, want an error")
+			}
+			if ids := set.IDs(); len(ids) != 1 || ids[0] != "" {
+				t.Errorf("IDs() = %q after a failed Replace, want the previous key", ids)
+			}
+		})
+	}
+}
+
+func TestAuthService_KeyRotation(t *testing.T) {
+	oldKey := JWTKey{ID: "2025-01", Secret: []byte("old-secret")}
+	newKey := JWTKey{ID: "2025-06", Secret: []byte("new-secret")}
+
+	keys, _ := NewJWTKeySetWithKeys([]JWTKey{oldKey})
+	authService := NewAuthServiceWithKeys(keys, nil)
+	oldToken, err := authService.GenerateToken("user-1", "user@example.com", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacyToken, _ := NewAuthService("old-secret").GenerateToken("user-1", "user@example.com", time.Hour)
+
+	// Rotate: sign with the new key, still accept the old one
+	if err := keys.Replace([]JWTKey{newKey, oldKey}); err != nil {
+		t.Fatal(err)
+	}
+	newToken, _ := authService.GenerateToken("user-1", "user@example.com", time.Hour)
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &JWTClaims{})
+	if err != nil || parsed.Header["kid"] != "2025-06" {
+		t.Errorf("new token kid = %v, want 2025-06", parsed.Header["kid"])
+	}
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, JWTClaims{UserID: "user-1"})
+	forged.Header["kid"] = "2025-06"
+	forgedToken, _ := forged.SignedString(oldKey.Secret)
+
+	for _, tt := range []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{"new token", newToken, true},
+		{"token signed with the previous key", oldToken, true},
+		{"token without kid", legacyToken, true},
+		{"token signed with another key than its kid", forgedToken, false},
+	} {
+		if _, err := authService.ValidateToken(tt.token); (err == nil) != tt.valid {
+			t.Errorf("%s: ValidateToken() error = %v, want valid %v", tt.name, err, tt.valid)
+		}
+	}
+
+	// Revoke the old key: its tokens stop working at once
+	if err := keys.Replace([]JWTKey{newKey}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := authService.ValidateToken(oldToken); err == nil {
+		t.Error("ValidateToken() accepted a token of a removed key")
+	}
+	if _, err := authService.ValidateToken(legacyToken); err == nil {
+		t.Error("ValidateToken() accepted a token without kid signed with a removed key")
+	}
+	if _, err := authService.ValidateToken(newToken); err != nil {
+		t.Errorf("ValidateToken() of the current key error = %v", err)
+	}
+}
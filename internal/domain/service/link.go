@@ -0,0 +1,27 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+)
+
+var urlRegex = regexp.MustCompile(`https?://[^\s<>"'` + "`" + `]+`)
+
+// ExtractURLs returns the distinct http(s) URLs found in a text, in order of appearance.
+// Trailing punctuation that usually belongs to the sentence (".", ",", ")") is dropped.
+func ExtractURLs(text string) []string {
+	matches := urlRegex.FindAllString(text, -1)
+
+	seen := make(map[string]bool, len(matches))
+	urls := make([]string, 0, len(matches))
+	for _, match := range matches {
+		match = strings.TrimRight(match, ".,;:!?)]}")
+		if match == "" || seen[match] {
+			continue
+		}
+		seen[match] = true
+		urls = append(urls, match)
+	}
+
+	return urls
+}
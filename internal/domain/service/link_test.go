@@ -0,0 +1,54 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractURLs(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "no links",
+			text: "Comprar pão e leite",
+			want: []string{},
+		},
+		{
+			name: "single link",
+			text: "Ver https://example.com/doc",
+			want: []string{"https://example.com/doc"},
+		},
+		{
+			name: "trailing punctuation is dropped",
+			text: "Leia http://example.com/a. Depois (https://example.org/b), ok?",
+			want: []string{"http://example.com/a", "https://example.org/b"},
+		},
+		{
+			name: "duplicates are removed",
+			text: "https://example.com e de novo https://example.com",
+			want: []string{"https://example.com"},
+		},
+		{
+			name: "query strings are kept",
+			text: "https://example.com/search?q=go&page=2",
+			want: []string{"https://example.com/search?q=go&page=2"},
+		},
+		{
+			name: "html is not part of the url",
+			text: `<a href="https://example.com/x">link</a>`,
+			want: []string{"https://example.com/x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractURLs(tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractURLs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mentionRegex matches an @ that starts a word, followed by a name handle or an email
+var mentionRegex = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@.])@([\p{L}\p{N}_.%+'-]+(?:@[\p{L}\p{N}.-]+)?)`)
+
+// ExtractMentions returns the distinct users mentioned in a text, in order of appearance and
+// lowercased: "@ana@example.com" mentions an email, "@ana.souza" the handle of a name (see
+// MentionHandle). Trailing punctuation that usually belongs to the sentence is dropped, and
+// addresses written without the leading @ are not mentions.
+func ExtractMentions(text string) []string {
+	matches := mentionRegex.FindAllStringSubmatch(text, -1)
+
+	seen := make(map[string]bool, len(matches))
+	mentions := make([]string, 0, len(matches))
+	for _, match := range matches {
+		mention := strings.ToLower(strings.TrimRight(match[1], ".,;:!?'-"))
+		if mention == "" || strings.HasSuffix(mention, "@") || seen[mention] {
+			continue
+		}
+		seen[mention] = true
+		mentions = append(mentions, mention)
+	}
+
+	return mentions
+}
+
+// IsEmailMention reports whether a mention returned by ExtractMentions is an email
+func IsEmailMention(mention string) bool {
+	return strings.Contains(mention, "@")
+}
+
+// MentionHandle returns the handle mentioning a user by name: the name lowercased, with its
+// words joined by dots, so "Ana Souza" is mentioned as @ana.souza
+func MentionHandle(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), "."))
+}
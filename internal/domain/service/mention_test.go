@@ -0,0 +1,83 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "no mentions",
+			text: "Comprar pão e leite",
+			want: []string{},
+		},
+		{
+			name: "name handle",
+			text: "@ana.souza pode revisar?",
+			want: []string{"ana.souza"},
+		},
+		{
+			name: "email",
+			text: "Pergunte a @Bia@Example.com",
+			want: []string{"bia@example.com"},
+		},
+		{
+			name: "trailing punctuation is dropped",
+			text: "Feito, @ana. Obrigado (@bia@example.com)!",
+			want: []string{"ana", "bia@example.com"},
+		},
+		{
+			name: "duplicates are removed",
+			text: "@ana e de novo @ANA",
+			want: []string{"ana"},
+		},
+		{
+			name: "adjacent mentions",
+			text: "@ana,@bia",
+			want: []string{"ana", "bia"},
+		},
+		{
+			name: "accented names",
+			text: "@joão.conceição",
+			want: []string{"joão.conceição"},
+		},
+		{
+			name: "plain emails are not mentions",
+			text: "Escreva para ana@example.com",
+			want: []string{},
+		},
+		{
+			name: "a lone @ is not a mention",
+			text: "Reunião @ 10h",
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractMentions(tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractMentions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMentionHandle(t *testing.T) {
+	tests := map[string]string{
+		"Ana":               "ana",
+		"Ana Souza":         "ana.souza",
+		"  João   da Silva": "joão.da.silva",
+	}
+
+	for name, want := range tests {
+		if got := MentionHandle(name); got != want {
+			t.Errorf("MentionHandle(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// AlgorithmBcrypt hashes passwords with bcrypt
+	AlgorithmBcrypt = "bcrypt"
+
+	// AlgorithmArgon2id hashes passwords with Argon2id
+	AlgorithmArgon2id = "argon2id"
+)
+
+// ErrPasswordMismatch is returned when a password doesn't match its hash
+var ErrPasswordMismatch = errors.New("password does not match")
+
+// PasswordHasher hashes passwords with one algorithm. Hashes carry their parameters, so any
+// hasher of an algorithm verifies the hashes of that algorithm made with other parameters.
+type PasswordHasher interface {
+	// Algorithm is the name stored as the prefix of the hashes, as in "{bcrypt}"
+	Algorithm() string
+
+	// Hash hashes a password
+	Hash(password string) (string, error)
+
+	// Verify checks a password against a hash of this algorithm, returning ErrPasswordMismatch
+	// when it doesn't match
+	Verify(hash, password string) error
+
+	// NeedsRehash reports whether a hash of this algorithm was made with other parameters
+	NeedsRehash(hash string) bool
+}
+
+// PasswordHashParams tunes the password hashing algorithms
+type PasswordHashParams struct {
+	BcryptCost        int    // bcrypt.MinCost to bcrypt.MaxCost
+	Argon2MemoryKiB   uint32 // memory used by Argon2id, in KiB
+	Argon2Iterations  uint32 // passes over the memory
+	Argon2Parallelism uint8  // threads
+}
+
+// DefaultPasswordHashParams are the parameters used when none are configured
+var DefaultPasswordHashParams = PasswordHashParams{
+	BcryptCost:        bcrypt.DefaultCost,
+	Argon2MemoryKiB:   64 * 1024,
+	Argon2Iterations:  3,
+	Argon2Parallelism: 2,
+}
+
+// NewPasswordHasher creates the hasher of an algorithm with validated parameters
+func NewPasswordHasher(algorithm string, params PasswordHashParams) (PasswordHasher, error) {
+	switch algorithm {
+	case AlgorithmBcrypt:
+		if params.BcryptCost < bcrypt.MinCost || params.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+		return &BcryptHasher{cost: params.BcryptCost}, nil
+	case AlgorithmArgon2id:
+		if params.Argon2MemoryKiB < 8*uint32(params.Argon2Parallelism) || params.Argon2Iterations < 1 || params.Argon2Parallelism < 1 {
+			return nil, errors.New("argon2id needs at least 1 iteration, 1 thread and 8 KiB of memory per thread")
+		}
+		return &Argon2idHasher{
+			memory:      params.Argon2MemoryKiB,
+			iterations:  params.Argon2Iterations,
+			parallelism: params.Argon2Parallelism,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown password hash algorithm %q", algorithm)
+	}
+}
+
+// BcryptHasher hashes passwords with bcrypt
+type BcryptHasher struct {
+	cost int
+}
+
+// Algorithm implements PasswordHasher
+func (h *BcryptHasher) Algorithm() string {
+	return AlgorithmBcrypt
+}
+
+// Hash implements PasswordHasher
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Verify implements PasswordHasher
+func (h *BcryptHasher) Verify(hash, password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return ErrPasswordMismatch
+	}
+	return err
+}
+
+// NeedsRehash implements PasswordHasher
+func (h *BcryptHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != h.cost
+}
+
+// argon2KeyLength and argon2SaltLength are the sizes of the Argon2id key and salt in bytes
+const (
+	argon2KeyLength  = 32
+	argon2SaltLength = 16
+)
+
+// Argon2idHasher hashes passwords with Argon2id, encoded in the PHC string format:
+// $argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>
+type Argon2idHasher struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+// Algorithm implements PasswordHasher
+func (h *Argon2idHasher) Algorithm() string {
+	return AlgorithmArgon2id
+}
+
+// Hash implements PasswordHasher
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.iterations, h.memory, h.parallelism, argon2KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.memory, h.iterations, h.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify implements PasswordHasher
+func (h *Argon2idHasher) Verify(hash, password string) error {
+	params, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		return err
+	}
+
+	computed := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(computed, key) != 1 {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+// NeedsRehash implements PasswordHasher
+func (h *Argon2idHasher) NeedsRehash(hash string) bool {
+	params, _, _, err := decodeArgon2id(hash)
+	return err != nil || *params != *h
+}
+
+// decodeArgon2id parses a PHC encoded Argon2id hash
+func decodeArgon2id(hash string) (*Argon2idHasher, []byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != AlgorithmArgon2id {
+		return nil, nil, nil, errors.New("invalid argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, nil, nil, errors.New("unsupported argon2id version")
+	}
+
+	params := &Argon2idHasher{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return nil, nil, nil, errors.New("invalid argon2id parameters")
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, errors.New("invalid argon2id salt")
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return nil, nil, nil, errors.New("invalid argon2id key")
+	}
+
+	return params, salt, key, nil
+}
+
+// GenerateTemporaryPassword generates a random password formatted as xxxxx-xxxxx-xxxxx-xxxxx,
+// with 100 bits of entropy, for an admin to hand to a user who lost theirs
+func GenerateTemporaryPassword() (string, error) {
+	raw := make([]byte, 13)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	encoded := strings.ToLower(recoveryCodeEncoding.EncodeToString(raw))[:20]
+	return encoded[:5] + "-" + encoded[5:10] + "-" + encoded[10:15] + "-" + encoded[15:], nil
+}
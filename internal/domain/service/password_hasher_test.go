@@ -0,0 +1,134 @@
+package service
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fastParams keeps the hashing cheap in tests
+var fastParams = PasswordHashParams{
+	BcryptCost:        bcrypt.MinCost,
+	Argon2MemoryKiB:   64,
+	Argon2Iterations:  1,
+	Argon2Parallelism: 1,
+}
+
+func TestNewPasswordHasher(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		params    func(p PasswordHashParams) PasswordHashParams
+		wantErr   bool
+	}{
+		{"bcrypt", AlgorithmBcrypt, nil, false},
+		{"argon2id", AlgorithmArgon2id, nil, false},
+		{"unknown algorithm", "md5", nil, true},
+		{"bcrypt cost too low", AlgorithmBcrypt, func(p PasswordHashParams) PasswordHashParams { p.BcryptCost = 3; return p }, true},
+		{"bcrypt cost too high", AlgorithmBcrypt, func(p PasswordHashParams) PasswordHashParams { p.BcryptCost = 32; return p }, true},
+		{"argon2id without iterations", AlgorithmArgon2id, func(p PasswordHashParams) PasswordHashParams { p.Argon2Iterations = 0; return p }, true},
+		{"argon2id without threads", AlgorithmArgon2id, func(p PasswordHashParams) PasswordHashParams { p.Argon2Parallelism = 0; return p }, true},
+		{"argon2id memory too low", AlgorithmArgon2id, func(p PasswordHashParams) PasswordHashParams { p.Argon2MemoryKiB = 4; return p }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := fastParams
+			if tt.params != nil {
+				params = tt.params(params)
+			}
+			hasher, err := NewPasswordHasher(tt.algorithm, params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPasswordHasher() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && hasher.Algorithm() != tt.algorithm {
+				t.Errorf("Algorithm() = %q, want %q", hasher.Algorithm(), tt.algorithm)
+			}
+		})
+	}
+}
+
+func TestPasswordHasher_HashAndVerify(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmBcrypt, AlgorithmArgon2id} {
+		t.Run(algorithm, func(t *testing.T) {
+			hasher, err := NewPasswordHasher(algorithm, fastParams)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			hash, err := hasher.Hash("password123")
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+			if other, _ := hasher.Hash("password123"); other == hash {
+				t.Error("Hash() returned the same hash twice, want a random salt")
+			}
+
+			if err := hasher.Verify(hash, "password123"); err != nil {
+				t.Errorf("Verify() with the right password error = %v", err)
+			}
+			if err := hasher.Verify(hash, "wrong"); !errors.Is(err, ErrPasswordMismatch) {
+				t.Errorf("Verify() with a wrong password error = %v, want ErrPasswordMismatch", err)
+			}
+			if hasher.NeedsRehash(hash) {
+				t.Error("NeedsRehash() = true for a hash made with the same parameters")
+			}
+		})
+	}
+}
+
+func TestArgon2idHasher_Format(t *testing.T) {
+	hasher, _ := NewPasswordHasher(AlgorithmArgon2id, fastParams)
+	hash, err := hasher.Hash("password123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$") {
+		t.Errorf("hash = %q, want the PHC format with the parameters", hash)
+	}
+
+	// Hashes made with other parameters still verify, but need a rehash
+	stronger, _ := NewPasswordHasher(AlgorithmArgon2id, PasswordHashParams{Argon2MemoryKiB: 128, Argon2Iterations: 2, Argon2Parallelism: 1})
+	if err := stronger.Verify(hash, "password123"); err != nil {
+		t.Errorf("Verify() with other parameters error = %v", err)
+	}
+	if !stronger.NeedsRehash(hash) {
+		t.Error("NeedsRehash() = false for a hash made with weaker parameters")
+	}
+
+	for _, invalid := range []string{"", "$argon2id$v=19$m=64,t=1,p=1$salt", "$argon2i$v=19$m=64,t=1,p=1$c2FsdA$a2V5", "$argon2id$v=18$m=64,t=1,p=1$c2FsdA$a2V5", "$argon2id$v=19$m=x$c2FsdA$a2V5"} {
+		if err := hasher.Verify(invalid, "password123"); err == nil || errors.Is(err, ErrPasswordMismatch) {
+			t.Errorf("Verify(%q) error = %v, want a malformed hash error", invalid, err)
+		}
+	}
+}
+
+func TestBcryptHasher_NeedsRehash(t *testing.T) {
+	hasher, _ := NewPasswordHasher(AlgorithmBcrypt, fastParams)
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost+1)
+
+	if err := hasher.Verify(string(hash), "password123"); err != nil {
+		t.Errorf("Verify() of a hash with another cost error = %v", err)
+	}
+	if !hasher.NeedsRehash(string(hash)) {
+		t.Error("NeedsRehash() = false for a hash with another cost")
+	}
+}
+
+func TestGenerateTemporaryPassword(t *testing.T) {
+	format := regexp.MustCompile(`^[a-z2-7]{5}-[a-z2-7]{5}-[a-z2-7]{5}-[a-z2-7]{5}$`)
+	first, err := GenerateTemporaryPassword()
+	if err != nil {
+		t.Fatalf("GenerateTemporaryPassword() error = %v", err)
+	}
+	if !format.MatchString(first) {
+		t.Errorf("password = %q, want xxxxx-xxxxx-xxxxx-xxxxx", first)
+	}
+	if second, _ := GenerateTemporaryPassword(); second == first {
+		t.Errorf("two passwords are both %q", first)
+	}
+}
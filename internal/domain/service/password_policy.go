@@ -0,0 +1,152 @@
+package service
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PasswordRule names a rule of the password policy, as reported to clients
+type PasswordRule string
+
+const (
+	RuleMinLength PasswordRule = "min_length"
+	RuleUppercase PasswordRule = "uppercase"
+	RuleLowercase PasswordRule = "lowercase"
+	RuleDigit     PasswordRule = "digit"
+	RuleSymbol    PasswordRule = "symbol"
+	RuleNotCommon PasswordRule = "not_common"
+	// RuleNotPersonal rejects passwords built from the name or the email of the user
+	RuleNotPersonal PasswordRule = "not_personal"
+)
+
+// PasswordPolicy holds the rules new passwords must follow
+type PasswordPolicy struct {
+	MinLength      int // In characters, not bytes
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSymbol  bool // Anything but letters and digits
+	RejectCommon   bool // Rejects the passwords of common_passwords.txt
+	RejectPersonal bool // Rejects passwords containing the name or the email of the user
+}
+
+// DefaultPasswordPolicy only asks for 8 characters, as before the policy was configurable
+var DefaultPasswordPolicy = PasswordPolicy{MinLength: 8}
+
+// PasswordViolation is a rule of the policy a password doesn't follow
+type PasswordViolation struct {
+	Rule    PasswordRule
+	Message string
+}
+
+// PasswordPolicyError lists every rule a password doesn't follow, so the user can fix them
+// all at once
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+// Error joins the messages of the violations
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+// commonPasswords is the set of the passwords of common_passwords.txt
+var commonPasswords = parseCommonPasswords(commonPasswordsFile)
+
+// parseCommonPasswords reads a list with one password per line, skipping blank lines and
+// comments
+func parseCommonPasswords(list string) map[string]bool {
+	passwords := make(map[string]bool)
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		passwords[strings.ToLower(line)] = true
+	}
+	return passwords
+}
+
+// personalMinLength is the length below which parts of the name or the email are too short
+// to tell anything about a password, as in "Ana" or "jo@example.com"
+const personalMinLength = 4
+
+// Validate checks a password against the policy. The email and the name of the user are only
+// used by RejectPersonal. It returns a *PasswordPolicyError listing the rules the password
+// doesn't follow, or nil.
+func (p PasswordPolicy) Validate(password, email, name string) error {
+	var violations []PasswordViolation
+	fail := func(rule PasswordRule, message string) {
+		violations = append(violations, PasswordViolation{Rule: rule, Message: message})
+	}
+
+	if utf8.RuneCountInString(password) < p.MinLength {
+		fail(RuleMinLength, fmt.Sprintf("password must be at least %d characters", p.MinLength))
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsLetter(r):
+			symbol = true
+		}
+	}
+	if p.RequireUpper && !upper {
+		fail(RuleUppercase, "password must contain an uppercase letter")
+	}
+	if p.RequireLower && !lower {
+		fail(RuleLowercase, "password must contain a lowercase letter")
+	}
+	if p.RequireDigit && !digit {
+		fail(RuleDigit, "password must contain a digit")
+	}
+	if p.RequireSymbol && !symbol {
+		fail(RuleSymbol, "password must contain a symbol")
+	}
+
+	lowered := strings.ToLower(password)
+	if p.RejectCommon && commonPasswords[lowered] {
+		fail(RuleNotCommon, "password is too common")
+	}
+	if p.RejectPersonal && isPersonal(lowered, email, name) {
+		fail(RuleNotPersonal, "password must not contain your name or email")
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// isPersonal reports whether a lowercase password contains the local part of the email, a
+// word of the name or the whole name without spaces, or is contained in them
+func isPersonal(password, email, name string) bool {
+	local, _, _ := strings.Cut(strings.ToLower(email), "@")
+	words := strings.Fields(strings.ToLower(name))
+	parts := append(words, strings.Join(words, ""), local)
+	for _, part := range parts {
+		if utf8.RuneCountInString(part) < personalMinLength {
+			continue
+		}
+		if strings.Contains(password, part) || strings.Contains(part, password) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	strict := PasswordPolicy{
+		MinLength:      10,
+		RequireUpper:   true,
+		RequireLower:   true,
+		RequireDigit:   true,
+		RequireSymbol:  true,
+		RejectCommon:   true,
+		RejectPersonal: true,
+	}
+
+	tests := []struct {
+		name      string
+		policy    PasswordPolicy
+		password  string
+		wantRules []PasswordRule
+	}{
+		{"default accepts 8 characters", DefaultPasswordPolicy, "password", nil},
+		{"default rejects 7 characters", DefaultPasswordPolicy, "passwor", []PasswordRule{RuleMinLength}},
+		{"length counts characters, not bytes", DefaultPasswordPolicy, "ãçéíõúâê", nil},
+		{"strict accepts a strong password", strict, "Correct-Horse-9", nil},
+		{"strict lists every missing class", strict, "abcdefghijk", []PasswordRule{RuleUppercase, RuleDigit, RuleSymbol}},
+		{"short and without classes", strict, "ABC", []PasswordRule{RuleMinLength, RuleLowercase, RuleDigit, RuleSymbol}},
+		{"common password in any case", PasswordPolicy{MinLength: 8, RejectCommon: true}, "PassWord123", []PasswordRule{RuleNotCommon}},
+		{"email local part", PasswordPolicy{MinLength: 8, RejectPersonal: true}, "joaosilva!2024", []PasswordRule{RuleNotPersonal}},
+		{"word of the name", PasswordPolicy{MinLength: 8, RejectPersonal: true}, "xx-Carvalho-xx", []PasswordRule{RuleNotPersonal}},
+		{"name without spaces", PasswordPolicy{MinLength: 8, RejectPersonal: true}, "mariacarvalho", []PasswordRule{RuleNotPersonal}},
+		{"short name words are ignored", PasswordPolicy{MinLength: 8, RejectPersonal: true}, "dedosverdes", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate(tt.password, "joaosilva@example.com", "Maria de Carvalho")
+			if tt.wantRules == nil {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var policyErr *PasswordPolicyError
+			if !errors.As(err, &policyErr) {
+				t.Fatalf("Validate() error = %v, want a *PasswordPolicyError", err)
+			}
+			var rules []PasswordRule
+			for _, v := range policyErr.Violations {
+				rules = append(rules, v.Rule)
+			}
+			if !reflect.DeepEqual(rules, tt.wantRules) {
+				t.Errorf("rules = %v, want %v", rules, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyError_Error(t *testing.T) {
+	err := DefaultPasswordPolicy.Validate("short", "", "")
+	if err == nil || err.Error() != "password must be at least 8 characters" {
+		t.Errorf("Error() = %v, want the message of the only violation", err)
+	}
+
+	err = PasswordPolicy{MinLength: 8, RequireDigit: true}.Validate("short", "", "")
+	if want := "password must be at least 8 characters; password must contain a digit"; err == nil || err.Error() != want {
+		t.Errorf("Error() = %v, want %q", err, want)
+	}
+}
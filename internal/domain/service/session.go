@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// ErrSessionRevoked is returned by CheckSession for a valid token that must no longer be accepted
+var ErrSessionRevoked = errors.New("session revoked")
+
+// SessionUsers finds the users tokens were issued to, like a repository.UserRepository
+type SessionUsers interface {
+	FindByID(ctx context.Context, id string) (*application.User, error)
+}
+
+// CheckSession checks that the user of a validated token can still use it: tokens of deleted or
+// disabled users, or issued before their token version was incremented by a password reset,
+// give ErrSessionRevoked. Other errors mean the user couldn't be loaded.
+func CheckSession(ctx context.Context, users SessionUsers, claims *JWTClaims) error {
+	user, err := users.FindByID(ctx, claims.UserID)
+	if errors.Is(err, application.ErrUserNotFound) {
+		return ErrSessionRevoked
+	}
+	if err != nil {
+		return err
+	}
+	if user.Disabled || user.TokenVersion != claims.TokenVersion {
+		return ErrSessionRevoked
+	}
+	return nil
+}
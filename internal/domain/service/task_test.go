@@ -35,7 +35,7 @@ func (m *mockTaskRepository) FindByID(ctx context.Context, id string) (*applicat
 	}
 	task, ok := m.tasks[id]
 	if !ok {
-		return nil, errors.New("task not found")
+		return nil, application.ErrTaskNotFound
 	}
 	return task, nil
 }
@@ -54,6 +54,18 @@ func (m *mockTaskRepository) FindSharedWithUser(ctx context.Context, userID stri
 	return nil, nil
 }
 
+func (m *mockTaskRepository) FindByProjectID(ctx context.Context, projectID string) ([]*application.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskRepository) FindByImagePath(ctx context.Context, imagePath string) ([]*application.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskRepository) CreateMany(ctx context.Context, tasks []*application.Task) error {
+	return nil
+}
+
 func TestTaskService_CanUserAccessTask(t *testing.T) {
 	task, _ := application.NewTask("task-1", "Test Task", "Description", application.StatusPending, "user-1", "")
 
@@ -143,7 +155,25 @@ func (m *mockShareRepository) Unshare(ctx context.Context, taskID, userID string
 	return nil
 }
 
-func (m *mockShareRepository) FindSharedUsers(ctx context.Context, taskID string) ([]string, error) {
+func (m *mockShareRepository) FindSharedUsers(ctx context.Context, taskID string) ([]*application.SharedUser, error) {
+	var users []*application.SharedUser
+	for _, userID := range m.shares[taskID] {
+		users = append(users, &application.SharedUser{UserID: userID})
+	}
+	return users, nil
+}
+
+func (m *mockShareRepository) FindSharedUsersByTaskIDs(ctx context.Context, taskIDs []string) (map[string][]string, error) {
+	shared := make(map[string][]string)
+	for _, taskID := range taskIDs {
+		if users, ok := m.shares[taskID]; ok {
+			shared[taskID] = users
+		}
+	}
+	return shared, nil
+}
+
+func (m *mockShareRepository) FindInvitedUserIDs(ctx context.Context, taskID string) ([]string, error) {
 	return m.shares[taskID], nil
 }
 
@@ -160,6 +190,18 @@ func (m *mockShareRepository) IsSharedWith(ctx context.Context, taskID, userID s
 	return false, nil
 }
 
+func (m *mockShareRepository) FindPendingInvitations(ctx context.Context, userID string) ([]*application.ShareInvitation, error) {
+	return nil, nil
+}
+
+func (m *mockShareRepository) AcceptInvitation(ctx context.Context, taskID, userID string) error {
+	return nil
+}
+
+func (m *mockShareRepository) DeclineInvitation(ctx context.Context, taskID, userID string) error {
+	return nil
+}
+
 func TestTaskService_CanUserModifyTask(t *testing.T) {
 	task, _ := application.NewTask("task-1", "Test Task", "Description", application.StatusPending, "user-1", "")
 
@@ -220,3 +262,19 @@ func TestTaskService_CanUserModifyTask(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskService_UnknownTask(t *testing.T) {
+	service := NewTaskService(&mockTaskRepository{tasks: map[string]*application.Task{}}, &mockShareRepository{shares: map[string][]string{}})
+
+	if _, err := service.CanUserAccessTask(context.Background(), "missing", "user-1"); !errors.Is(err, application.ErrTaskNotFound) {
+		t.Errorf("CanUserAccessTask() error = %v, want ErrTaskNotFound", err)
+	}
+	if _, err := service.CanUserModifyTask(context.Background(), "missing", "user-1"); !errors.Is(err, application.ErrTaskNotFound) {
+		t.Errorf("CanUserModifyTask() error = %v, want ErrTaskNotFound", err)
+	}
+}
+
+func (m *mockShareRepository) DeleteAllShares(ctx context.Context, taskID string) error {
+	delete(m.shares, taskID)
+	return nil
+}
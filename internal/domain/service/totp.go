@@ -0,0 +1,155 @@
+package service
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"image/png"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	// totpPeriod is the lifetime of a TOTP code, the default of authenticator apps
+	totpPeriod = 30 * time.Second
+
+	// totpSkew is how many time steps before and after the current one are accepted, to
+	// tolerate clock drift between the server and the phone
+	totpSkew = 1
+
+	// RecoveryCodeCount is how many recovery codes are issued when two-factor is enabled
+	RecoveryCodeCount = 10
+)
+
+// recoveryCodeEncoding encodes recovery codes without ambiguous padding, in lowercase
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTPService generates and verifies time-based one-time passwords (RFC 6238) compatible
+// with authenticator apps: HMAC-SHA1, 6 digits and 30 second steps
+type TOTPService struct {
+	issuer string
+}
+
+// NewTOTPService creates a new TOTPService. issuer names the app in authenticator apps.
+func NewTOTPService(issuer string) *TOTPService {
+	return &TOTPService{issuer: issuer}
+}
+
+// GenerateSecret generates a new shared secret for accountName and returns it base32
+// encoded, along with its otpauth:// provisioning URI
+func (s *TOTPService) GenerateSecret(accountName string) (secret, uri string, err error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      s.issuer,
+		AccountName: accountName,
+		Period:      uint(totpPeriod / time.Second),
+		SecretSize:  20,
+		Digits:      otp.DigitsSix,
+		Algorithm:   otp.AlgorithmSHA1,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return key.Secret(), key.URL(), nil
+}
+
+// Verify checks a code against secret at now, tolerating totpSkew steps of drift. It returns
+// the time step the code belongs to, so callers can refuse steps that were already used.
+func (s *TOTPService) Verify(secret, code string, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != otp.DigitsSix.Length() {
+		return 0, false
+	}
+
+	opts := totp.ValidateOpts{
+		Period:    uint(totpPeriod / time.Second),
+		Digits:    otp.DigitsSix,
+		Algorithm: otp.AlgorithmSHA1,
+	}
+
+	current := now.Unix() / int64(totpPeriod/time.Second)
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(step*int64(totpPeriod/time.Second), 0), opts)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// QRCodePNG renders a provisioning URI as a PNG QR code of size x size pixels
+func (s *TOTPService) QRCodePNG(uri string, size int) ([]byte, error) {
+	key, err := otp.NewKeyFromURL(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := key.Image(size, size)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GenerateRecoveryCodes generates RecoveryCodeCount single-use recovery codes formatted as
+// xxxxx-xxxxx, each with 50 bits of entropy
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		raw := make([]byte, 7)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, err
+		}
+		encoded := strings.ToLower(recoveryCodeEncoding.EncodeToString(raw))[:10]
+		codes[i] = encoded[:5] + "-" + encoded[5:]
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode hashes a recovery code for storage. Codes are random, so a fast hash is
+// enough; case, spaces and dashes are ignored so codes can be typed loosely.
+func HashRecoveryCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(code)))
+
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewChallengeToken generates the random token of a login challenge, handed to the client,
+// along with the hash under which it is stored
+func NewChallengeToken() (token, hash string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(raw)
+	return token, HashChallengeToken(token), nil
+}
+
+// HashChallengeToken hashes the token of a login challenge for lookup, so a leaked database
+// doesn't expose pending logins
+func HashChallengeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base32"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA1 seed of the RFC 6238 test vectors, "12345678901234567890"
+var rfc6238Secret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPService_Verify_RFC6238Vectors(t *testing.T) {
+	s := NewTOTPService("Todo App")
+
+	// RFC 6238 appendix B lists 8 digit codes; authenticator apps use their last 6 digits
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		step, ok := s.Verify(rfc6238Secret, tt.code, time.Unix(tt.unix, 0))
+		if !ok {
+			t.Errorf("Verify(%s at %d) = false, want true", tt.code, tt.unix)
+			continue
+		}
+		if want := tt.unix / 30; step != want {
+			t.Errorf("Verify(%s at %d) step = %d, want %d", tt.code, tt.unix, step, want)
+		}
+	}
+}
+
+func TestTOTPService_Verify_Skew(t *testing.T) {
+	s := NewTOTPService("Todo App")
+	issuedAt := time.Unix(1111111109, 0) // code 081804, step 37037036
+
+	tests := []struct {
+		name string
+		now  time.Time
+		code string
+		want bool
+	}{
+		{name: "same step", now: issuedAt, code: "081804", want: true},
+		{name: "one step later", now: issuedAt.Add(30 * time.Second), code: "081804", want: true},
+		{name: "one step earlier", now: issuedAt.Add(-30 * time.Second), code: "081804", want: true},
+		{name: "two steps later", now: issuedAt.Add(60 * time.Second), code: "081804", want: false},
+		{name: "surrounding spaces", now: issuedAt, code: " 081804 ", want: true},
+		{name: "wrong code", now: issuedAt, code: "123456", want: false},
+		{name: "too short", now: issuedAt, code: "08180", want: false},
+		{name: "empty", now: issuedAt, code: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := s.Verify(rfc6238Secret, tt.code, tt.now); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTOTPService_GenerateSecret(t *testing.T) {
+	s := NewTOTPService("Todo App")
+
+	secret, uri, err := s.GenerateSecret("ana@example.com")
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+
+	if _, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret); err != nil {
+		t.Errorf("secret %q is not base32: %v", secret, err)
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		t.Fatalf("invalid provisioning URI %q: %v", uri, err)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" {
+		t.Errorf("provisioning URI = %q, want otpauth://totp/...", uri)
+	}
+	query := u.Query()
+	if query.Get("secret") != secret || query.Get("issuer") != "Todo App" {
+		t.Errorf("provisioning URI query = %v, want the secret and issuer", query)
+	}
+
+	other, _, err := s.GenerateSecret("ana@example.com")
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+	if other == secret {
+		t.Error("GenerateSecret() should generate a different secret each time")
+	}
+}
+
+func TestTOTPService_QRCodePNG(t *testing.T) {
+	s := NewTOTPService("Todo App")
+	_, uri, err := s.GenerateSecret("ana@example.com")
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+
+	img, err := s.QRCodePNG(uri, 200)
+	if err != nil {
+		t.Fatalf("QRCodePNG() error = %v", err)
+	}
+	if !bytes.HasPrefix(img, []byte("\x89PNG")) {
+		t.Error("QRCodePNG() should return a PNG image")
+	}
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	if err != nil {
+		t.Fatalf("GenerateRecoveryCodes() error = %v", err)
+	}
+
+	if len(codes) != RecoveryCodeCount {
+		t.Fatalf("GenerateRecoveryCodes() returned %d codes, want %d", len(codes), RecoveryCodeCount)
+	}
+
+	format := regexp.MustCompile(`^[a-z2-7]{5}-[a-z2-7]{5}$`)
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if !format.MatchString(code) {
+			t.Errorf("recovery code %q doesn't match xxxxx-xxxxx", code)
+		}
+		if seen[code] {
+			t.Errorf("recovery code %q generated twice", code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	want := HashRecoveryCode("abcde-fghij")
+
+	for _, typed := range []string{"abcde-fghij", "ABCDE-FGHIJ", "abcdefghij", " abcde fghij "} {
+		if got := HashRecoveryCode(typed); got != want {
+			t.Errorf("HashRecoveryCode(%q) should match the hash of abcde-fghij", typed)
+		}
+	}
+
+	if HashRecoveryCode("abcde-fghik") == want {
+		t.Error("different codes should have different hashes")
+	}
+}
+
+func TestNewChallengeToken(t *testing.T) {
+	token, hash, err := NewChallengeToken()
+	if err != nil {
+		t.Fatalf("NewChallengeToken() error = %v", err)
+	}
+
+	if token == "" || hash == token {
+		t.Errorf("NewChallengeToken() = %q, %q, want a token and a different hash", token, hash)
+	}
+	if HashChallengeToken(token) != hash {
+		t.Error("HashChallengeToken() should match the hash returned with the token")
+	}
+}
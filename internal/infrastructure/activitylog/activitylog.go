@@ -0,0 +1,45 @@
+// Package activitylog records domain events as an audit trail of task activity.
+package activitylog
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+)
+
+// Handler returns an event.Handler writing one line per event to logger. Only IDs are
+// logged: titles are user content and stay out of the logs.
+func Handler(logger *log.Logger) event.Handler {
+	return func(ctx context.Context, e event.DomainEvent) error {
+		logger.Print(Format(e))
+		return nil
+	}
+}
+
+// Format renders an event as a single "activity <name> key=value..." line
+func Format(e event.DomainEvent) string {
+	var line strings.Builder
+	fmt.Fprintf(&line, "activity %s", e.EventName())
+
+	if task, ok := e.(interface{ Data() event.TaskEvent }); ok {
+		data := task.Data()
+		fmt.Fprintf(&line, " task=%s owner=%s actor=%s", data.TaskID, data.OwnerID, data.ActorID)
+	}
+
+	switch e := e.(type) {
+	case event.TaskShared:
+		fmt.Fprintf(&line, " shared_with=%s", e.SharedWithID)
+	case event.TaskUnshared:
+		fmt.Fprintf(&line, " unshared_with=%s", e.UnsharedWithID)
+	case event.TaskTransferred:
+		fmt.Fprintf(&line, " previous_owner=%s", e.PreviousOwnerID)
+	case event.TaskOverdue:
+		fmt.Fprintf(&line, " due=%s", e.DueDate.UTC().Format(time.RFC3339))
+	}
+
+	return line.String()
+}
@@ -0,0 +1,71 @@
+package activitylog
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+)
+
+func TestFormat(t *testing.T) {
+	task := &application.Task{ID: "task-1", Title: "Salário da Ana", OwnerID: "user-1"}
+
+	tests := []struct {
+		name  string
+		event event.DomainEvent
+		want  string
+	}{
+		{
+			name:  "created",
+			event: event.TaskCreated{TaskEvent: event.NewTaskEvent(task, "user-1")},
+			want:  "activity task.created task=task-1 owner=user-1 actor=user-1",
+		},
+		{
+			name:  "shared",
+			event: event.TaskShared{TaskEvent: event.NewTaskEvent(task, "user-1"), SharedWithID: "user-2"},
+			want:  "activity task.shared task=task-1 owner=user-1 actor=user-1 shared_with=user-2",
+		},
+		{
+			name:  "unshared",
+			event: event.TaskUnshared{TaskEvent: event.NewTaskEvent(task, "user-1"), UnsharedWithID: "user-2"},
+			want:  "activity task.unshared task=task-1 owner=user-1 actor=user-1 unshared_with=user-2",
+		},
+		{
+			name:  "transferred",
+			event: event.TaskTransferred{TaskEvent: event.NewTaskEvent(task, "user-2"), PreviousOwnerID: "user-2"},
+			want:  "activity task.transferred task=task-1 owner=user-1 actor=user-2 previous_owner=user-2",
+		},
+		{
+			name:  "overdue",
+			event: event.TaskOverdue{TaskEvent: event.NewTaskEvent(task, ""), DueDate: time.Date(2026, 3, 10, 8, 30, 0, 0, time.FixedZone("BRT", -3*60*60))},
+			want:  "activity task.overdue task=task-1 owner=user-1 actor= due=2026-03-10T11:30:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Format(tt.event); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandler_KeepsTitlesOutOfLogs(t *testing.T) {
+	var buf bytes.Buffer
+	task := &application.Task{ID: "task-1", Title: "Salário da Ana", OwnerID: "user-1"}
+
+	Handler(log.New(&buf, "", 0))(context.Background(), event.TaskCompleted{TaskEvent: event.NewTaskEvent(task, "user-1")})
+
+	if !strings.Contains(buf.String(), "activity task.completed task=task-1") {
+		t.Errorf("Expected activity line, got %q", buf.String())
+	}
+	if strings.Contains(buf.String(), "Salário") {
+		t.Errorf("Expected title to be left out, got %q", buf.String())
+	}
+}
@@ -0,0 +1,291 @@
+// Package backup copies the SQLite database and the upload directories to timestamped files,
+// and restores them once they are validated
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/database"
+)
+
+// stampLayout is the timestamp in the names of the backup files
+const stampLayout = "20060102-150405"
+
+// FileDir is an upload directory saved in the archive of a backup under Name
+type FileDir struct {
+	Name string // top directory of its files in the archive, like "uploads"
+	Path string
+}
+
+// Service backs up a database and its upload directories into a directory; it implements
+// usecases.Backuper
+type Service struct {
+	db       *sql.DB
+	dir      string
+	fileDirs []FileDir
+	now      func() time.Time
+	mu       sync.Mutex // one backup at a time
+}
+
+// NewService creates a Service writing the backups of db and fileDirs into dir
+func NewService(db *sql.DB, dir string, fileDirs []FileDir) *Service {
+	return &Service{
+		db:       db,
+		dir:      dir,
+		fileDirs: fileDirs,
+		now:      time.Now,
+	}
+}
+
+// Create writes todo-<timestamp>.db, a copy of the database taken while the app runs, and
+// todo-<timestamp>-files.tar.gz, the archive of the upload directories
+func (s *Service) Create(ctx context.Context) (*application.Backup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return nil, err
+	}
+
+	createdAt := s.now().UTC()
+	prefix := filepath.Join(s.dir, "todo-"+createdAt.Format(stampLayout))
+	backup := &application.Backup{
+		Database:  prefix + ".db",
+		Files:     prefix + "-files.tar.gz",
+		CreatedAt: createdAt,
+	}
+
+	if err := database.BackupSQLite(ctx, s.db, backup.Database); err != nil {
+		return nil, err
+	}
+	if err := writeArchive(ctx, backup.Files, s.fileDirs); err != nil {
+		os.Remove(backup.Database)
+		return nil, fmt.Errorf("failed to archive the uploads: %w", err)
+	}
+	return backup, nil
+}
+
+// Restore replaces the database at dbPath with the copy at databaseBackup and, unless
+// filesArchive is empty, the upload directories with the ones in the archive. Both are
+// validated, and the archive extracted, before anything is replaced; what is replaced is
+// kept with a ".before-restore" suffix. The app must be stopped.
+func Restore(ctx context.Context, databaseBackup, filesArchive, dbPath string, fileDirs []FileDir) error {
+	if err := database.ValidateSQLiteBackup(ctx, databaseBackup); err != nil {
+		return err
+	}
+
+	var restored []FileDir
+	if filesArchive != "" {
+		for _, dir := range fileDirs {
+			restored = append(restored, FileDir{Name: dir.Name, Path: dir.Path + ".restoring"})
+		}
+		removeAll(restored)
+		if err := extractArchive(ctx, filesArchive, restored); err != nil {
+			removeAll(restored)
+			return fmt.Errorf("invalid archive %s: %w", filesArchive, err)
+		}
+	}
+
+	if err := database.RestoreSQLite(ctx, databaseBackup, dbPath); err != nil {
+		removeAll(restored)
+		return err
+	}
+
+	for i, dir := range restored {
+		target := fileDirs[i].Path
+		if err := os.RemoveAll(target + ".before-restore"); err != nil {
+			return err
+		}
+		if err := os.Rename(target, target+".before-restore"); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		if err := os.Rename(dir.Path, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeArchive writes the files of dirs to a .tar.gz at name, through a temporary file so a
+// failed backup leaves no partial archive
+func writeArchive(ctx context.Context, name string, dirs []FileDir) (err error) {
+	f, err := os.OpenFile(name+".tmp", os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, dir := range dirs {
+		if err := archiveDir(ctx, tw, dir); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
+
+// archiveDir adds the regular files of dir to tw under dir.Name; a missing directory adds none
+func archiveDir(ctx context.Context, tw *tar.Writer, dir FileDir) error {
+	err := filepath.WalkDir(dir.Path, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir.Path, p)
+		if err != nil {
+			return err
+		}
+		info, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil // Removed since the directory was read
+		}
+		if err != nil {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(dir.Name, filepath.ToSlash(rel))
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		file, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = io.Copy(tw, file)
+		return err
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// extractArchive extracts the .tar.gz at name into dirs, by the top directory of each entry.
+// Entries outside dirs, escaping them or that aren't regular files make the archive invalid.
+func extractArchive(ctx context.Context, name string, dirs []FileDir) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(gz)
+
+	for _, dir := range dirs {
+		if err := os.MkdirAll(dir.Path, 0755); err != nil {
+			return err
+		}
+	}
+
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag == tar.TypeDir {
+			continue
+		}
+		if header.Typeflag != tar.TypeReg {
+			return fmt.Errorf("%s is not a regular file", header.Name)
+		}
+
+		target, err := entryPath(header.Name, dirs)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, tr); err != nil {
+			out.Close()
+			return err
+		}
+		if err := out.Close(); err != nil {
+			return err
+		}
+	}
+}
+
+// entryPath returns where an archive entry is extracted: the directory of dirs named by its
+// first element, joined with the rest
+func entryPath(name string, dirs []FileDir) (string, error) {
+	cleaned := path.Clean(name)
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("%s escapes the upload directories", name)
+	}
+	top, rest, ok := strings.Cut(cleaned, "/")
+	if !ok {
+		return "", fmt.Errorf("%s is outside the upload directories", name)
+	}
+	for _, dir := range dirs {
+		if dir.Name == top {
+			return filepath.Join(dir.Path, filepath.FromSlash(rest)), nil
+		}
+	}
+	return "", fmt.Errorf("%s is outside the upload directories", name)
+}
+
+// removeAll removes dirs and everything in them
+func removeAll(dirs []FileDir) {
+	for _, dir := range dirs {
+		os.RemoveAll(dir.Path)
+	}
+}
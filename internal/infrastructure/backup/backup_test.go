@@ -0,0 +1,151 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/database"
+)
+
+// writeFiles creates files, by path relative to dir, with their content
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCreateAndRestore(t *testing.T) {
+	root := t.TempDir()
+	ctx := context.Background()
+	dbPath := filepath.Join(root, "todo.db")
+	dirs := []FileDir{
+		{Name: "uploads", Path: filepath.Join(root, "uploads")},
+		{Name: "attachments", Path: filepath.Join(root, "attachments")},
+	}
+	writeFiles(t, dirs[0].Path, map[string]string{"a.png": "image", ".gitkeep": ""})
+	writeFiles(t, dirs[1].Path, map[string]string{"b.pdf": "document"})
+
+	db, err := database.NewSQLiteDB(dbPath, time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	service := NewService(db, filepath.Join(root, "backups"), dirs)
+	service.now = func() time.Time { return time.Date(2026, 10, 16, 15, 4, 5, 0, time.UTC) }
+
+	backup, err := service.Create(ctx)
+	db.Close()
+	if err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	if filepath.Base(backup.Database) != "todo-20261016-150405.db" || filepath.Base(backup.Files) != "todo-20261016-150405-files.tar.gz" {
+		t.Errorf("Unexpected backup files %s and %s", backup.Database, backup.Files)
+	}
+
+	// Files added and removed after the backup are undone by the restore
+	os.Remove(filepath.Join(dirs[0].Path, "a.png"))
+	writeFiles(t, dirs[1].Path, map[string]string{"new.pdf": "later"})
+
+	if err := Restore(ctx, backup.Database, backup.Files, dbPath, dirs); err != nil {
+		t.Fatalf("Restore() error: %v", err)
+	}
+
+	for path, want := range map[string]string{
+		filepath.Join(dirs[0].Path, "a.png"):    "image",
+		filepath.Join(dirs[0].Path, ".gitkeep"): "",
+		filepath.Join(dirs[1].Path, "b.pdf"):    "document",
+	} {
+		if data, err := os.ReadFile(path); err != nil || string(data) != want {
+			t.Errorf("Expected %s with %q, got %q, %v", path, want, data, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dirs[1].Path, "new.pdf")); !os.IsNotExist(err) {
+		t.Error("Expected the file added after the backup to be gone")
+	}
+	if _, err := os.Stat(filepath.Join(dirs[1].Path+".before-restore", "new.pdf")); err != nil {
+		t.Errorf("Expected the replaced uploads to be kept: %v", err)
+	}
+	if _, err := os.Stat(dbPath + ".before-restore"); err != nil {
+		t.Errorf("Expected the replaced database to be kept: %v", err)
+	}
+}
+
+func TestRestore_InvalidArchive(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries map[string]string
+		symlink bool
+		wantErr string
+	}{
+		{name: "path traversal", entries: map[string]string{"uploads/../../evil": "x"}, wantErr: "escapes"},
+		{name: "unknown directory", entries: map[string]string{"secrets/key": "x"}, wantErr: "outside"},
+		{name: "top level file", entries: map[string]string{"evil": "x"}, wantErr: "outside"},
+		{name: "symlink", symlink: true, wantErr: "not a regular file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			ctx := context.Background()
+			dbPath := filepath.Join(root, "todo.db")
+			dirs := []FileDir{{Name: "uploads", Path: filepath.Join(root, "uploads")}}
+			writeFiles(t, dirs[0].Path, map[string]string{"current.png": "current"})
+
+			db, err := database.NewSQLiteDB(dbPath, time.Second)
+			if err != nil {
+				t.Fatalf("Failed to open database: %v", err)
+			}
+			databaseBackup := filepath.Join(root, "backup.db")
+			if err := database.BackupSQLite(ctx, db, databaseBackup); err != nil {
+				t.Fatal(err)
+			}
+			db.Close()
+
+			archive := filepath.Join(root, "files.tar.gz")
+			f, err := os.Create(archive)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gz := gzip.NewWriter(f)
+			tw := tar.NewWriter(gz)
+			for name, content := range tt.entries {
+				tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+				tw.Write([]byte(content))
+			}
+			if tt.symlink {
+				tw.WriteHeader(&tar.Header{Name: "uploads/link", Linkname: "/etc/passwd", Typeflag: tar.TypeSymlink})
+			}
+			tw.Close()
+			gz.Close()
+			f.Close()
+
+			err = Restore(ctx, databaseBackup, archive, dbPath, dirs)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Restore() error = %v, want %q", err, tt.wantErr)
+			}
+
+			// Nothing is replaced when the archive is invalid
+			if _, err := os.Stat(dbPath + ".before-restore"); !os.IsNotExist(err) {
+				t.Error("Expected the database to be untouched")
+			}
+			if data, err := os.ReadFile(filepath.Join(dirs[0].Path, "current.png")); err != nil || string(data) != "current" {
+				t.Error("Expected the uploads to be untouched")
+			}
+			if _, err := os.Stat(dirs[0].Path + ".restoring"); !os.IsNotExist(err) {
+				t.Error("Expected the partial extraction to be removed")
+			}
+		})
+	}
+}
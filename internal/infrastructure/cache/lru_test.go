@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRU[int](2, time.Minute)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a") // b is now the least recently used
+	c.Set("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("Expected b to be evicted")
+	}
+	for key, want := range map[string]int{"a": 1, "c": 3} {
+		if v, ok := c.Get(key); !ok || v != want {
+			t.Errorf("Get(%q) = %d (ok=%v), want %d", key, v, ok, want)
+		}
+	}
+	if c.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", c.Len())
+	}
+
+	// Replacing a value doesn't grow the cache
+	c.Set("a", 10)
+	if v, _ := c.Get("a"); v != 10 || c.Len() != 2 {
+		t.Errorf("after replace: a = %d, Len() = %d", v, c.Len())
+	}
+}
+
+func TestLRU_Expiration(t *testing.T) {
+	now := time.Now()
+	c := NewLRU[string](10, 30*time.Second)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", "x")
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("Expected hit before expiration")
+	}
+
+	now = now.Add(31 * time.Second)
+	if _, ok := c.Get("a"); ok {
+		t.Error("Expected miss after expiration")
+	}
+	if c.Len() != 0 {
+		t.Errorf("Len() = %d, want expired entry removed", c.Len())
+	}
+}
+
+func TestLRU_DeleteAndPurge(t *testing.T) {
+	c := NewLRU[int](10, time.Minute)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Error("Expected miss after Delete")
+	}
+
+	c.DeleteFunc(func(key string) bool { return key == "b" })
+	if _, ok := c.Get("b"); ok {
+		t.Error("Expected miss after DeleteFunc")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Error("Expected DeleteFunc to keep the keys it doesn't match")
+	}
+
+	c.Purge()
+	if _, ok := c.Get("b"); ok || c.Len() != 0 {
+		t.Errorf("Expected empty cache after Purge, Len() = %d", c.Len())
+	}
+}
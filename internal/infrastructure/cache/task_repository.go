@@ -0,0 +1,214 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// TaskRepository decorates a repository.TaskRepository, caching the task lists read on every
+// page load: the tasks owned by a user and the tasks shared with them. Writes through the
+// repository invalidate the owner's list and every shared list, since the users a task is shared
+// with aren't known here. Changes made elsewhere (shares, projects, the overdue job) must call
+// Invalidate; the TTL bounds how long anything missed stays stale.
+//
+// Lists are kept per workspace, since the repository scopes them to the workspace of ctx.
+//
+// The cache is local to the process, so every instance serving the same database keeps its own.
+type TaskRepository struct {
+	next    repository.TaskRepository
+	owned   *LRU[[]*application.Task]
+	shared  *LRU[[]*application.Task]
+	version atomic.Uint64 // bumped on every invalidation
+
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+// NewTaskRepository creates a new TaskRepository keeping up to maxEntries lists of each kind
+// for ttl
+func NewTaskRepository(next repository.TaskRepository, maxEntries int, ttl time.Duration) *TaskRepository {
+	return &TaskRepository{
+		next:   next,
+		owned:  NewLRU[[]*application.Task](maxEntries, ttl),
+		shared: NewLRU[[]*application.Task](maxEntries, ttl),
+	}
+}
+
+// TaskCacheStats reports how well the task cache is doing since the server started
+type TaskCacheStats struct {
+	Hits          uint64
+	Misses        uint64
+	Invalidations uint64
+	Entries       int
+}
+
+// HitRate returns the fraction of lookups answered from the cache, 0 before any lookup
+func (s TaskCacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// Stats returns the cache counters
+func (r *TaskRepository) Stats() TaskCacheStats {
+	return TaskCacheStats{
+		Hits:          r.hits.Load(),
+		Misses:        r.misses.Load(),
+		Invalidations: r.invalidations.Load(),
+		Entries:       r.owned.Len() + r.shared.Len(),
+	}
+}
+
+// Invalidate drops the lists that may change when a user changes their tasks, projects or
+// shares: the user's own lists, in every workspace, and every shared list
+func (r *TaskRepository) Invalidate(ownerID string) {
+	r.bump()
+	r.owned.DeleteFunc(func(key string) bool {
+		return strings.HasPrefix(key, ownerID+listKeySeparator)
+	})
+	r.shared.Purge()
+}
+
+// bump marks that cached lists changed, so lists read before it are not stored
+func (r *TaskRepository) bump() {
+	r.version.Add(1)
+	r.invalidations.Add(1)
+}
+
+// Create creates a task and invalidates the lists that will show it
+func (r *TaskRepository) Create(ctx context.Context, task *application.Task) error {
+	err := r.next.Create(ctx, task)
+	r.Invalidate(task.OwnerID)
+	return err
+}
+
+// Update updates a task and invalidates the lists showing it
+func (r *TaskRepository) Update(ctx context.Context, task *application.Task) error {
+	err := r.next.Update(ctx, task)
+	r.Invalidate(task.OwnerID)
+	return err
+}
+
+// Delete deletes a task and invalidates the lists showing it. The owner is looked up first;
+// when that fails every list is dropped, unless there is no such task to show.
+func (r *TaskRepository) Delete(ctx context.Context, id string) error {
+	task, findErr := r.next.FindByID(ctx, id)
+	err := r.next.Delete(ctx, id)
+	switch {
+	case errors.Is(findErr, application.ErrTaskNotFound):
+	case findErr != nil:
+		r.bump()
+		r.owned.Purge()
+		r.shared.Purge()
+	default:
+		r.Invalidate(task.OwnerID)
+	}
+	return err
+}
+
+// CreateMany creates several tasks and invalidates the lists that will show them
+func (r *TaskRepository) CreateMany(ctx context.Context, tasks []*application.Task) error {
+	err := r.next.CreateMany(ctx, tasks)
+	for _, task := range tasks {
+		r.Invalidate(task.OwnerID)
+	}
+	return err
+}
+
+// FindByID is not cached: it backs every permission check and must see the latest state
+func (r *TaskRepository) FindByID(ctx context.Context, id string) (*application.Task, error) {
+	return r.next.FindByID(ctx, id)
+}
+
+// FindByOwnerID finds all tasks owned by a user, from the cache when possible
+func (r *TaskRepository) FindByOwnerID(ctx context.Context, ownerID string) ([]*application.Task, error) {
+	return r.cached(r.owned, listKey(ctx, ownerID), func() ([]*application.Task, error) {
+		return r.next.FindByOwnerID(ctx, ownerID)
+	})
+}
+
+// FindSharedWithUser finds all tasks shared with a user, from the cache when possible
+func (r *TaskRepository) FindSharedWithUser(ctx context.Context, userID string) ([]*application.Task, error) {
+	return r.cached(r.shared, listKey(ctx, userID), func() ([]*application.Task, error) {
+		return r.next.FindSharedWithUser(ctx, userID)
+	})
+}
+
+// FindByProjectID is not cached
+func (r *TaskRepository) FindByProjectID(ctx context.Context, projectID string) ([]*application.Task, error) {
+	return r.next.FindByProjectID(ctx, projectID)
+}
+
+// FindByImagePath is not cached
+func (r *TaskRepository) FindByImagePath(ctx context.Context, imagePath string) ([]*application.Task, error) {
+	return r.next.FindByImagePath(ctx, imagePath)
+}
+
+// listKeySeparator separates the user ID from the workspace in list keys; it can't appear in IDs
+const listKeySeparator = "\x00"
+
+// listKey returns the key of the list of a user in the workspace of ctx
+func listKey(ctx context.Context, userID string) string {
+	orgID, scoped := repository.OrgScope(ctx)
+	if !scoped {
+		return userID + listKeySeparator + "*"
+	}
+	return userID + listKeySeparator + orgID
+}
+
+// cached returns the list stored for key, or loads and stores it. A list loaded while an
+// invalidation happened may predate the change, so it is returned but not stored. Callers get
+// copies, since use cases modify the tasks they read.
+func (r *TaskRepository) cached(lists *LRU[[]*application.Task], key string, load func() ([]*application.Task, error)) ([]*application.Task, error) {
+	if tasks, ok := lists.Get(key); ok {
+		r.hits.Add(1)
+		return cloneTasks(tasks), nil
+	}
+	r.misses.Add(1)
+
+	version := r.version.Load()
+	tasks, err := load()
+	if err != nil {
+		return nil, err
+	}
+	if r.version.Load() == version {
+		lists.Set(key, cloneTasks(tasks))
+	}
+	return tasks, nil
+}
+
+// cloneTasks deep-copies a task list
+func cloneTasks(tasks []*application.Task) []*application.Task {
+	if tasks == nil {
+		return nil
+	}
+	clones := make([]*application.Task, len(tasks))
+	for i, task := range tasks {
+		clone := *task
+		if task.Tags != nil {
+			clone.Tags = append(make([]string, 0, len(task.Tags)), task.Tags...)
+		}
+		if task.DueDate != nil {
+			dueDate := *task.DueDate
+			clone.DueDate = &dueDate
+		}
+		if task.OverdueAt != nil {
+			overdueAt := *task.OverdueAt
+			clone.OverdueAt = &overdueAt
+		}
+		if task.SnoozedUntil != nil {
+			snoozedUntil := *task.SnoozedUntil
+			clone.SnoozedUntil = &snoozedUntil
+		}
+		clones[i] = &clone
+	}
+	return clones
+}
@@ -0,0 +1,255 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// countingTaskRepository keeps tasks in memory and counts the list queries
+type countingTaskRepository struct {
+	tasks   map[string]*application.Task
+	shared  map[string][]string // user ID -> task IDs
+	queries int
+	onQuery func()
+}
+
+func (m *countingTaskRepository) Create(ctx context.Context, task *application.Task) error {
+	stored := *task
+	m.tasks[task.ID] = &stored
+	return nil
+}
+
+func (m *countingTaskRepository) Update(ctx context.Context, task *application.Task) error {
+	return m.Create(ctx, task)
+}
+
+func (m *countingTaskRepository) Delete(ctx context.Context, id string) error {
+	delete(m.tasks, id)
+	return nil
+}
+
+func (m *countingTaskRepository) CreateMany(ctx context.Context, tasks []*application.Task) error {
+	for _, task := range tasks {
+		m.Create(ctx, task)
+	}
+	return nil
+}
+
+func (m *countingTaskRepository) FindByID(ctx context.Context, id string) (*application.Task, error) {
+	task, ok := m.tasks[id]
+	if !ok {
+		return nil, application.ErrTaskNotFound
+	}
+	found := *task
+	return &found, nil
+}
+
+func (m *countingTaskRepository) FindByOwnerID(ctx context.Context, ownerID string) ([]*application.Task, error) {
+	m.queries++
+	if m.onQuery != nil {
+		m.onQuery()
+	}
+	orgID, scoped := repository.OrgScope(ctx)
+	var tasks []*application.Task
+	for _, task := range m.tasks {
+		if task.OwnerID == ownerID && (!scoped || task.OrgID == orgID) {
+			found := *task
+			tasks = append(tasks, &found)
+		}
+	}
+	return tasks, nil
+}
+
+func (m *countingTaskRepository) FindSharedWithUser(ctx context.Context, userID string) ([]*application.Task, error) {
+	m.queries++
+	var tasks []*application.Task
+	for _, id := range m.shared[userID] {
+		if task, ok := m.tasks[id]; ok {
+			found := *task
+			tasks = append(tasks, &found)
+		}
+	}
+	return tasks, nil
+}
+
+func (m *countingTaskRepository) FindByProjectID(ctx context.Context, projectID string) ([]*application.Task, error) {
+	return nil, nil
+}
+
+func (m *countingTaskRepository) FindByImagePath(ctx context.Context, imagePath string) ([]*application.Task, error) {
+	return nil, nil
+}
+
+func newCachedTaskRepository(t *testing.T) (*TaskRepository, *countingTaskRepository) {
+	t.Helper()
+	next := &countingTaskRepository{tasks: map[string]*application.Task{}, shared: map[string][]string{}}
+	repo := NewTaskRepository(next, 100, time.Minute)
+	for _, id := range []string{"t1", "t2"} {
+		task, err := application.NewTask(id, "Tarefa "+id, "", application.StatusPending, "ana", "")
+		if err != nil {
+			t.Fatalf("NewTask() error: %v", err)
+		}
+		next.Create(context.Background(), task)
+	}
+	next.shared["bia"] = []string{"t1"}
+	return repo, next
+}
+
+func TestTaskRepository_CachesLists(t *testing.T) {
+	ctx := context.Background()
+	repo, next := newCachedTaskRepository(t)
+
+	for i := 0; i < 3; i++ {
+		if tasks, _ := repo.FindByOwnerID(ctx, "ana"); len(tasks) != 2 {
+			t.Fatalf("FindByOwnerID() = %d tasks, want 2", len(tasks))
+		}
+		if tasks, _ := repo.FindSharedWithUser(ctx, "bia"); len(tasks) != 1 {
+			t.Fatalf("FindSharedWithUser() = %d tasks, want 1", len(tasks))
+		}
+	}
+	if next.queries != 2 {
+		t.Errorf("queries = %d, want 2", next.queries)
+	}
+
+	stats := repo.Stats()
+	if stats.Hits != 4 || stats.Misses != 2 || stats.Entries != 2 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+	if rate := stats.HitRate(); rate < 0.66 || rate > 0.67 {
+		t.Errorf("HitRate() = %v, want 4/6", rate)
+	}
+
+	// Callers get copies: changing a task they read doesn't change the cache
+	tasks, _ := repo.FindByOwnerID(ctx, "ana")
+	tasks[0].Title = "changed"
+	for _, task := range mustFindByOwner(t, repo, "ana") {
+		if task.Title == "changed" {
+			t.Error("cached task was modified through a returned copy")
+		}
+	}
+}
+
+func TestTaskRepository_WritesInvalidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(ctx context.Context, repo *TaskRepository) error
+		want  int // tasks of ana after the write
+	}{
+		{name: "create", want: 3, write: func(ctx context.Context, repo *TaskRepository) error {
+			task, _ := application.NewTask("t3", "Nova", "", application.StatusPending, "ana", "")
+			return repo.Create(ctx, task)
+		}},
+		{name: "create many", want: 4, write: func(ctx context.Context, repo *TaskRepository) error {
+			t3, _ := application.NewTask("t3", "Nova", "", application.StatusPending, "ana", "")
+			t4, _ := application.NewTask("t4", "Outra", "", application.StatusPending, "ana", "")
+			return repo.CreateMany(ctx, []*application.Task{t3, t4})
+		}},
+		{name: "delete", want: 1, write: func(ctx context.Context, repo *TaskRepository) error {
+			return repo.Delete(ctx, "t2")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo, _ := newCachedTaskRepository(t)
+			mustFindByOwner(t, repo, "ana")
+
+			if err := tt.write(ctx, repo); err != nil {
+				t.Fatalf("write error: %v", err)
+			}
+			if tasks := mustFindByOwner(t, repo, "ana"); len(tasks) != tt.want {
+				t.Errorf("FindByOwnerID() = %d tasks, want %d", len(tasks), tt.want)
+			}
+		})
+	}
+
+	t.Run("update reaches shared lists", func(t *testing.T) {
+		ctx := context.Background()
+		repo, _ := newCachedTaskRepository(t)
+		repo.FindSharedWithUser(ctx, "bia")
+
+		task, _ := repo.FindByID(ctx, "t1")
+		task.Title = "Renomeada"
+		if err := repo.Update(ctx, task); err != nil {
+			t.Fatalf("Update() error: %v", err)
+		}
+		if tasks, _ := repo.FindSharedWithUser(ctx, "bia"); tasks[0].Title != "Renomeada" {
+			t.Errorf("shared task title = %q, want the updated one", tasks[0].Title)
+		}
+	})
+
+	t.Run("invalidate after a share", func(t *testing.T) {
+		ctx := context.Background()
+		repo, next := newCachedTaskRepository(t)
+		repo.FindSharedWithUser(ctx, "bia")
+
+		// Ana shares another task with Bia outside the task repository
+		next.shared["bia"] = append(next.shared["bia"], "t2")
+		repo.Invalidate("ana")
+		if tasks, _ := repo.FindSharedWithUser(ctx, "bia"); len(tasks) != 2 {
+			t.Errorf("FindSharedWithUser() = %d tasks, want 2", len(tasks))
+		}
+	})
+}
+
+func TestTaskRepository_DoesNotStoreListsReadDuringAWrite(t *testing.T) {
+	ctx := context.Background()
+	repo, next := newCachedTaskRepository(t)
+
+	// A write lands while the list is being read, so the list read may be outdated
+	next.onQuery = func() {
+		next.onQuery = nil
+		repo.Invalidate("ana")
+	}
+	repo.FindByOwnerID(ctx, "ana")
+	repo.FindByOwnerID(ctx, "ana")
+
+	if next.queries != 2 {
+		t.Errorf("queries = %d, want the list read during the write not to be cached", next.queries)
+	}
+}
+
+func TestTaskRepository_ListsPerWorkspace(t *testing.T) {
+	repo, next := newCachedTaskRepository(t)
+	personal := repository.WithOrgScope(context.Background(), "")
+	org := repository.WithOrgScope(context.Background(), "org-1")
+
+	if tasks, _ := repo.FindByOwnerID(personal, "ana"); len(tasks) != 2 {
+		t.Fatalf("personal tasks = %d, want 2", len(tasks))
+	}
+	if tasks, _ := repo.FindByOwnerID(org, "ana"); len(tasks) != 0 {
+		t.Fatalf("org tasks = %d, want 0", len(tasks))
+	}
+
+	task, _ := application.NewTask("t3", "Da equipe", "", application.StatusPending, "ana", "")
+	task.OrgID = "org-1"
+	if err := repo.Create(org, task); err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	if tasks, _ := repo.FindByOwnerID(org, "ana"); len(tasks) != 1 {
+		t.Errorf("org tasks after create = %d, want 1", len(tasks))
+	}
+	if tasks, _ := repo.FindByOwnerID(personal, "ana"); len(tasks) != 2 {
+		t.Errorf("personal tasks after create = %d, want 2", len(tasks))
+	}
+	if tasks, _ := repo.FindByOwnerID(context.Background(), "ana"); len(tasks) != 3 {
+		t.Errorf("unscoped tasks = %d, want 3", len(tasks))
+	}
+	if next.queries != 5 {
+		t.Errorf("queries = %d, want every workspace read from the repository after the create", next.queries)
+	}
+}
+
+func mustFindByOwner(t *testing.T, repo *TaskRepository, ownerID string) []*application.Task {
+	t.Helper()
+	tasks, err := repo.FindByOwnerID(context.Background(), ownerID)
+	if err != nil {
+		t.Fatalf("FindByOwnerID() error: %v", err)
+	}
+	return tasks
+}
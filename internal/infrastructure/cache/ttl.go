@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// entry is a cached value with its expiration time
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// TTL is a concurrency-safe in-memory cache whose entries expire after a fixed duration
+type TTL[V any] struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]entry[V]
+	now     func() time.Time
+}
+
+// NewTTL creates a new TTL cache
+func NewTTL[V any](ttl time.Duration) *TTL[V] {
+	return &TTL[V]{
+		ttl:     ttl,
+		entries: make(map[string]entry[V]),
+		now:     time.Now,
+	}
+}
+
+// Get returns the value stored for key if it has not expired
+func (c *TTL[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if c.now().After(e.expiresAt) {
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Take returns the value stored for key and removes it, so concurrent callers never get
+// the same value twice
+func (c *TTL[V]) Take(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	delete(c.entries, key)
+	if !ok || c.now().After(e.expiresAt) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Set stores a value for key, replacing any previous value
+func (c *TTL[V]) Set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Opportunistically drop expired entries so the map doesn't grow unbounded
+	now := c.now()
+	for k, e := range c.entries {
+		if now.After(e.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+
+	c.entries[key] = entry[V]{value: value, expiresAt: now.Add(c.ttl)}
+}
+
+// Delete removes the value stored for key
+func (c *TTL[V]) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTL_GetSetDelete(t *testing.T) {
+	c := NewTTL[string](time.Minute)
+
+	if _, ok := c.Get("user-1"); ok {
+		t.Error("Expected miss on empty cache")
+	}
+
+	c.Set("user-1", "page")
+	if v, ok := c.Get("user-1"); !ok || v != "page" {
+		t.Errorf("Expected hit with 'page', got %q (ok=%v)", v, ok)
+	}
+
+	c.Delete("user-1")
+	if _, ok := c.Get("user-1"); ok {
+		t.Error("Expected miss after Delete")
+	}
+}
+
+func TestTTL_Expiration(t *testing.T) {
+	now := time.Now()
+	c := NewTTL[int](30 * time.Second)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", 1)
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("Expected hit before expiration")
+	}
+
+	now = now.Add(31 * time.Second)
+	if _, ok := c.Get("a"); ok {
+		t.Error("Expected miss after expiration")
+	}
+
+	c.Set("b", 2)
+	if len(c.entries) != 1 {
+		t.Errorf("Expected expired entries to be purged on Set, got %d entries", len(c.entries))
+	}
+}
+
+func TestTTL_Take(t *testing.T) {
+	now := time.Now()
+	c := NewTTL[string](time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", "challenge")
+	if v, ok := c.Take("a"); !ok || v != "challenge" {
+		t.Errorf("Expected first Take to return 'challenge', got %q (ok=%v)", v, ok)
+	}
+	if _, ok := c.Take("a"); ok {
+		t.Error("Expected second Take to miss")
+	}
+
+	c.Set("b", "expired")
+	now = now.Add(2 * time.Minute)
+	if _, ok := c.Take("b"); ok {
+		t.Error("Expected Take to miss after expiration")
+	}
+}
@@ -0,0 +1,154 @@
+// Package captcha verifies the answers to CAPTCHA challenges with hCaptcha or Cloudflare
+// Turnstile, whose widgets post a token the server checks against the provider.
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Names of the supported providers
+const (
+	HCaptcha  = "hcaptcha"
+	Turnstile = "turnstile"
+)
+
+// Config holds the configuration for the CAPTCHA verifier
+type Config struct {
+	Provider string        // HCaptcha or Turnstile; empty disables the CAPTCHA
+	SiteKey  string        // Public key, rendered in the widget
+	Secret   string        // Key of the server, sent with each verification
+	Timeout  time.Duration // Timeout for each verification
+}
+
+// Provider describes a CAPTCHA service: where its answers are verified and how its widget is
+// rendered in the pages
+type Provider struct {
+	Name      string
+	VerifyURL string
+	// ScriptURL loads the widget script for explicit rendering, calling window.onCaptchaLoad
+	// once loaded
+	ScriptURL string
+	Global    string   // Object the script defines, with render(element, {sitekey}) and reset(id)
+	Field     string   // Form field the widget posts its answer in
+	Origins   []string // Origins the widget loads its scripts and frames from, for the CSP
+}
+
+// providers are the supported CAPTCHA services by name
+var providers = map[string]Provider{
+	HCaptcha: {
+		Name:      HCaptcha,
+		VerifyURL: "https://api.hcaptcha.com/siteverify",
+		ScriptURL: "https://js.hcaptcha.com/1/api.js?render=explicit&onload=onCaptchaLoad",
+		Global:    "hcaptcha",
+		Field:     "h-captcha-response",
+		Origins:   []string{"https://hcaptcha.com", "https://*.hcaptcha.com"},
+	},
+	Turnstile: {
+		Name:      Turnstile,
+		VerifyURL: "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+		ScriptURL: "https://challenges.cloudflare.com/turnstile/v0/api.js?render=explicit&onload=onCaptchaLoad",
+		Global:    "turnstile",
+		Field:     "cf-turnstile-response",
+		Origins:   []string{"https://challenges.cloudflare.com"},
+	},
+}
+
+// Validate checks that the provider is supported and both keys are set
+func (c Config) Validate() error {
+	if _, ok := providers[c.Provider]; !ok {
+		return fmt.Errorf("unknown CAPTCHA provider %q, expected %s or %s", c.Provider, HCaptcha, Turnstile)
+	}
+	if c.SiteKey == "" || c.Secret == "" {
+		return errors.New("the CAPTCHA site key and secret are required")
+	}
+	return nil
+}
+
+// Verifier checks the tokens posted by the widget of a provider
+type Verifier struct {
+	config    Config
+	provider  Provider
+	verifyURL string
+	client    *http.Client
+}
+
+// New creates a new Verifier for a valid Config
+func New(config Config) (*Verifier, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+
+	provider := providers[config.Provider]
+	return &Verifier{
+		config:    config,
+		provider:  provider,
+		verifyURL: provider.VerifyURL,
+		client:    &http.Client{Timeout: config.Timeout},
+	}, nil
+}
+
+// Provider returns the provider of the verifier
+func (v *Verifier) Provider() Provider {
+	return v.provider
+}
+
+// SiteKey returns the public key rendered in the widget
+func (v *Verifier) SiteKey() string {
+	return v.config.SiteKey
+}
+
+// ResponseField returns the form field the widget posts its answer in
+func (v *Verifier) ResponseField() string {
+	return v.provider.Field
+}
+
+// verifyResponse is the answer of the siteverify endpoint, the same for both providers
+type verifyResponse struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// Verify reports whether token answers a challenge solved by the client at remoteIP. Tokens are
+// good for a single verification. An error means the provider couldn't tell, not that the
+// answer is wrong.
+func (v *Verifier) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+
+	form := url.Values{"secret": {v.config.Secret}, "response": {token}}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("%s verification failed: %w", v.provider.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("%s verification responded %d", v.provider.Name, resp.StatusCode)
+	}
+	var result verifyResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&result); err != nil {
+		return false, fmt.Errorf("%s verification: invalid response: %w", v.provider.Name, err)
+	}
+	return result.Success, nil
+}
@@ -0,0 +1,93 @@
+package captcha
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{"hcaptcha", Config{Provider: HCaptcha, SiteKey: "site", Secret: "secret"}, false},
+		{"turnstile", Config{Provider: Turnstile, SiteKey: "site", Secret: "secret"}, false},
+		{"unknown provider", Config{Provider: "recaptcha", SiteKey: "site", Secret: "secret"}, true},
+		{"no provider", Config{SiteKey: "site", Secret: "secret"}, true},
+		{"no secret", Config{Provider: HCaptcha, SiteKey: "site"}, true},
+		{"no site key", Config{Provider: Turnstile, Secret: "secret"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		status   int
+		body     string
+		wantOK   bool
+		wantErr  bool
+		wantCall bool
+	}{
+		{"solved", "token-1", http.StatusOK, `{"success": true}`, true, false, true},
+		{"wrong answer", "token-1", http.StatusOK, `{"success": false, "error-codes": ["invalid-input-response"]}`, false, false, true},
+		{"no token", "", http.StatusOK, `{"success": true}`, false, false, false},
+		{"provider failing", "token-1", http.StatusInternalServerError, ``, false, true, true},
+		{"invalid response", "token-1", http.StatusOK, `<html>`, false, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				if r.FormValue("secret") != "s3cret" || r.FormValue("response") != tt.token || r.FormValue("remoteip") != "203.0.113.7" {
+					t.Errorf("Unexpected verification form %v", r.Form)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			verifier, err := New(Config{Provider: Turnstile, SiteKey: "site", Secret: "s3cret"})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			verifier.verifyURL = server.URL
+
+			ok, err := verifier.Verify(context.Background(), tt.token, "203.0.113.7")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ok != tt.wantOK {
+				t.Errorf("Verify() = %v, want %v", ok, tt.wantOK)
+			}
+			if called != tt.wantCall {
+				t.Errorf("Expected the provider called: %v, got %v", tt.wantCall, called)
+			}
+		})
+	}
+}
+
+func TestVerifier_ResponseField(t *testing.T) {
+	for provider, field := range map[string]string{HCaptcha: "h-captcha-response", Turnstile: "cf-turnstile-response"} {
+		verifier, err := New(Config{Provider: provider, SiteKey: "site", Secret: "secret"})
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		if got := verifier.ResponseField(); got != field {
+			t.Errorf("%s: expected field %q, got %q", provider, field, got)
+		}
+	}
+}
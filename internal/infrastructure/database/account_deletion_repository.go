@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteAccountDeletionRepository implements repository.AccountDeletionRepository using SQLite
+type SQLiteAccountDeletionRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteAccountDeletionRepository creates a new SQLiteAccountDeletionRepository
+func NewSQLiteAccountDeletionRepository(db *sql.DB) *SQLiteAccountDeletionRepository {
+	return &SQLiteAccountDeletionRepository{db: db}
+}
+
+// Create schedules the deletion of an account unless it already is, using prepared statement
+func (r *SQLiteAccountDeletionRepository) Create(ctx context.Context, deletion *application.AccountDeletion) (bool, error) {
+	query := `INSERT INTO account_deletions (user_id, requested_at, purge_at) VALUES (?, ?, ?)
+	          ON CONFLICT (user_id) DO NOTHING`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query,
+		deletion.UserID,
+		deletion.RequestedAt.UTC().Format(sortableTimeLayout),
+		deletion.PurgeAt.UTC().Format(sortableTimeLayout),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// FindByUserID finds the scheduled deletion of an account using prepared statement
+func (r *SQLiteAccountDeletionRepository) FindByUserID(ctx context.Context, userID string) (*application.AccountDeletion, error) {
+	query := `SELECT user_id, requested_at, purge_at FROM account_deletions WHERE user_id = ?`
+
+	deletion, err := scanAccountDeletion(conn(ctx, r.db).QueryRowContext(ctx, query, userID))
+	if err == sql.ErrNoRows {
+		return nil, application.ErrAccountDeletionNotFound
+	}
+	return deletion, err
+}
+
+// FindDue finds a batch of deletions whose grace period is over, oldest first, using
+// prepared statement
+func (r *SQLiteAccountDeletionRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*application.AccountDeletion, error) {
+	query := `SELECT user_id, requested_at, purge_at
+	          FROM account_deletions WHERE purge_at <= ?
+	          ORDER BY purge_at
+	          LIMIT ?`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, now.UTC().Format(sortableTimeLayout), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deletions []*application.AccountDeletion
+	for rows.Next() {
+		deletion, err := scanAccountDeletion(rows)
+		if err != nil {
+			return nil, err
+		}
+		deletions = append(deletions, deletion)
+	}
+
+	return deletions, rows.Err()
+}
+
+// Delete cancels the deletion of an account using prepared statement
+func (r *SQLiteAccountDeletionRepository) Delete(ctx context.Context, userID string) (bool, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM account_deletions WHERE user_id = ?`, userID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// scanAccountDeletion reads an account deletion from a row
+func scanAccountDeletion(row rowScanner) (*application.AccountDeletion, error) {
+	var deletion application.AccountDeletion
+	var requestedAt, purgeAt string
+	if err := row.Scan(&deletion.UserID, &requestedAt, &purgeAt); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if deletion.RequestedAt, err = time.Parse(sortableTimeLayout, requestedAt); err != nil {
+		return nil, err
+	}
+	if deletion.PurgeAt, err = time.Parse(sortableTimeLayout, purgeAt); err != nil {
+		return nil, err
+	}
+	return &deletion, nil
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteAccountDeletionRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	for _, id := range []string{"u-ana", "u-bia"} {
+		if err := users.Create(ctx, &application.User{ID: id, Name: id, Email: id + "@example.com", CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	repo := NewSQLiteAccountDeletionRepository(db)
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	ana, _ := application.NewAccountDeletion("u-ana", now, 24*time.Hour)
+	bia, _ := application.NewAccountDeletion("u-bia", now, 7*24*time.Hour)
+	for _, deletion := range []*application.AccountDeletion{ana, bia} {
+		if created, err := repo.Create(ctx, deletion); err != nil || !created {
+			t.Fatalf("Create(%s) = %v, %v; want true", deletion.UserID, created, err)
+		}
+	}
+
+	// Requesting again keeps the first purge time
+	again, _ := application.NewAccountDeletion("u-ana", now.Add(time.Hour), 24*time.Hour)
+	if created, err := repo.Create(ctx, again); err != nil || created {
+		t.Errorf("Create() of a scheduled deletion = %v, %v; want false", created, err)
+	}
+	found, err := repo.FindByUserID(ctx, "u-ana")
+	if err != nil || found == nil || !found.PurgeAt.Equal(ana.PurgeAt) || !found.RequestedAt.Equal(now) {
+		t.Fatalf("FindByUserID() = %+v, %v; want the first deletion", found, err)
+	}
+
+	due, err := repo.FindDue(ctx, now.Add(48*time.Hour), 10)
+	if err != nil || len(due) != 1 || due[0].UserID != "u-ana" {
+		t.Fatalf("FindDue() = %+v, %v; want only u-ana", due, err)
+	}
+
+	if deleted, err := repo.Delete(ctx, "u-bia"); err != nil || !deleted {
+		t.Errorf("Delete() = %v, %v; want true", deleted, err)
+	}
+	if deleted, err := repo.Delete(ctx, "u-bia"); err != nil || deleted {
+		t.Errorf("Delete() of a cancelled deletion = %v, %v; want false", deleted, err)
+	}
+
+	// The deletion goes with the account
+	if err := users.Delete(ctx, "u-ana"); err != nil {
+		t.Fatalf("Failed to delete user: %v", err)
+	}
+	if found, err := repo.FindByUserID(ctx, "u-ana"); !errors.Is(err, application.ErrAccountDeletionNotFound) || found != nil {
+		t.Errorf("FindByUserID() of a purged account = %+v, %v; want nil, ErrAccountDeletionNotFound", found, err)
+	}
+}
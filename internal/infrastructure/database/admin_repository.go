@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// dayLayout is the layout of the days returned by SQLite's date()
+const dayLayout = "2006-01-02"
+
+// SQLiteAdminRepository implements repository.AdminRepository using SQLite
+type SQLiteAdminRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteAdminRepository creates a new SQLiteAdminRepository
+func NewSQLiteAdminRepository(db *sql.DB) *SQLiteAdminRepository {
+	return &SQLiteAdminRepository{db: db}
+}
+
+// CountUsers returns the number of registered users
+func (r *SQLiteAdminRepository) CountUsers(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users`).Scan(&count)
+	return count, err
+}
+
+// ListUsers returns a page of users ordered by creation using prepared statement
+func (r *SQLiteAdminRepository) ListUsers(ctx context.Context, limit, offset int) ([]*application.User, error) {
+	query := `SELECT id, name, email, password_hash, role, unit, theme, locale, overdue_opt_out, avatar_path, disabled, token_version, created_at
+	          FROM users
+	          ORDER BY created_at, email
+	          LIMIT ? OFFSET ?`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*application.User
+	for rows.Next() {
+		var user application.User
+		var role, theme, locale, createdAt string
+
+		if err := rows.Scan(
+			&user.ID,
+			&user.Name,
+			&user.Email,
+			&user.PasswordHash,
+			&role,
+			&user.Unit,
+			&theme,
+			&locale,
+			&user.OverdueOptOut,
+			&user.AvatarPath,
+			&user.Disabled,
+			&user.TokenVersion,
+			&createdAt,
+		); err != nil {
+			return nil, err
+		}
+
+		user.Role = application.UserRole(role)
+		user.Theme = application.Theme(theme)
+		user.Locale = application.Locale(locale)
+		user.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		users = append(users, &user)
+	}
+
+	return users, rows.Err()
+}
+
+// CountTasksCreatedSince counts the tasks created on each day since a time. The creation
+// times are stored with their offset, which date() converts to UTC.
+func (r *SQLiteAdminRepository) CountTasksCreatedSince(ctx context.Context, since time.Time) ([]application.DailyCount, error) {
+	query := `SELECT date(created_at) AS day, COUNT(*)
+	          FROM tasks
+	          WHERE date(created_at) >= ?
+	          GROUP BY day
+	          ORDER BY day`
+
+	rows, err := r.db.QueryContext(ctx, query, since.UTC().Format(dayLayout))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []application.DailyCount
+	for rows.Next() {
+		var day string
+		var count int
+		if err := rows.Scan(&day, &count); err != nil {
+			return nil, err
+		}
+
+		parsed, err := time.Parse(dayLayout, day)
+		if err != nil {
+			continue
+		}
+		counts = append(counts, application.DailyCount{Day: parsed, Count: count})
+	}
+
+	return counts, rows.Err()
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteAdminRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	users := NewSQLiteUserRepository(db)
+	tasks := NewSQLiteTaskRepository(db)
+	repo := NewSQLiteAdminRepository(db)
+	ctx := context.Background()
+
+	// The database comes with the seed users, created now
+	seeded, err := repo.CountUsers(ctx)
+	if err != nil {
+		t.Fatalf("CountUsers() error: %v", err)
+	}
+
+	start := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	for i, user := range []*application.User{
+		{ID: "u-ana", Name: "Ana", Email: "ana@example.com"},
+		{ID: "u-bruno", Name: "Bruno", Email: "bruno@example.com", Disabled: true},
+		{ID: "u-carla", Name: "Carla", Email: "carla@example.com"},
+	} {
+		user.PasswordHash = "hash"
+		user.CreatedAt = start.Add(time.Duration(i) * time.Hour)
+		if err := users.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	// 22:30 in São Paulo is already the next day in UTC
+	saoPaulo := time.FixedZone("BRT", -3*60*60)
+	for id, createdAt := range map[string]time.Time{
+		"t-old":   time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+		"t-1":     time.Date(2026, 3, 9, 10, 0, 0, 0, time.UTC),
+		"t-2":     time.Date(2026, 3, 9, 23, 0, 0, 0, time.UTC),
+		"t-local": time.Date(2026, 3, 9, 22, 30, 0, 0, saoPaulo),
+	} {
+		task, _ := application.NewTask(id, id, "", application.StatusPending, "u-ana", "")
+		task.CreatedAt, task.UpdatedAt = createdAt, createdAt
+		if err := tasks.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	count, err := repo.CountUsers(ctx)
+	if err != nil || count != seeded+3 {
+		t.Errorf("CountUsers() = %d, %v, want %d", count, err, seeded+3)
+	}
+
+	page, err := repo.ListUsers(ctx, 2, 1)
+	if err != nil {
+		t.Fatalf("ListUsers() error: %v", err)
+	}
+	if len(page) != 2 || page[0].ID != "u-bruno" || page[1].ID != "u-carla" {
+		t.Fatalf("ListUsers(2, 1) = %v, want u-bruno and u-carla", page)
+	}
+	if !page[0].Disabled || page[1].Disabled {
+		t.Errorf("Disabled = %v, %v, want true, false", page[0].Disabled, page[1].Disabled)
+	}
+
+	days, err := repo.CountTasksCreatedSince(ctx, time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("CountTasksCreatedSince() error: %v", err)
+	}
+	want := []application.DailyCount{
+		{Day: time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), Count: 2},
+		{Day: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), Count: 1},
+	}
+	if len(days) != len(want) {
+		t.Fatalf("CountTasksCreatedSince() = %v, want %v", days, want)
+	}
+	for i := range want {
+		if !days[i].Day.Equal(want[i].Day) || days[i].Count != want[i].Count {
+			t.Errorf("day %d = %v, want %v", i, days[i], want[i])
+		}
+	}
+}
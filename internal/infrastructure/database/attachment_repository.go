@@ -0,0 +1,120 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteAttachmentRepository implements repository.AttachmentRepository using SQLite
+type SQLiteAttachmentRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteAttachmentRepository creates a new SQLiteAttachmentRepository
+func NewSQLiteAttachmentRepository(db *sql.DB) *SQLiteAttachmentRepository {
+	return &SQLiteAttachmentRepository{db: db}
+}
+
+// Create creates a new attachment using prepared statement
+func (r *SQLiteAttachmentRepository) Create(ctx context.Context, attachment *application.Attachment) error {
+	query := `INSERT INTO attachments (id, task_id, filename, mime, size, path, created_at)
+	          VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		attachment.ID,
+		attachment.TaskID,
+		attachment.Filename,
+		attachment.MimeType,
+		attachment.Size,
+		attachment.Path,
+		attachment.CreatedAt,
+	)
+	return err
+}
+
+// FindByID finds an attachment by ID using prepared statement
+func (r *SQLiteAttachmentRepository) FindByID(ctx context.Context, id string) (*application.Attachment, error) {
+	query := `SELECT id, task_id, filename, mime, size, path, created_at
+	          FROM attachments WHERE id = ?`
+
+	attachments, err := r.queryAttachments(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments) == 0 {
+		return nil, application.ErrAttachmentNotFound
+	}
+	return attachments[0], nil
+}
+
+// FindByTaskID finds all attachments of a task using prepared statement
+func (r *SQLiteAttachmentRepository) FindByTaskID(ctx context.Context, taskID string) ([]*application.Attachment, error) {
+	query := `SELECT id, task_id, filename, mime, size, path, created_at
+	          FROM attachments WHERE task_id = ?
+	          ORDER BY created_at, filename`
+
+	return r.queryAttachments(ctx, query, taskID)
+}
+
+// FindByOwnerID finds the attachments of all tasks owned by a user using prepared statement
+func (r *SQLiteAttachmentRepository) FindByOwnerID(ctx context.Context, ownerID string) ([]*application.Attachment, error) {
+	query := `SELECT a.id, a.task_id, a.filename, a.mime, a.size, a.path, a.created_at
+	          FROM attachments a
+	          INNER JOIN tasks t ON t.id = a.task_id
+	          WHERE t.owner_id = ? AND t.deleted_at IS NULL
+	          ORDER BY a.task_id, a.created_at, a.filename`
+
+	return r.queryAttachments(ctx, query, ownerID)
+}
+
+// CountByTaskID counts the attachments of a task using prepared statement
+func (r *SQLiteAttachmentRepository) CountByTaskID(ctx context.Context, taskID string) (int, error) {
+	query := `SELECT COUNT(*) FROM attachments WHERE task_id = ?`
+
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, taskID).Scan(&count)
+	return count, err
+}
+
+// Delete deletes an attachment using prepared statement
+func (r *SQLiteAttachmentRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM attachments WHERE id = ?`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	return err
+}
+
+// queryAttachments runs a query returning attachment rows
+func (r *SQLiteAttachmentRepository) queryAttachments(ctx context.Context, query string, args ...interface{}) ([]*application.Attachment, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []*application.Attachment
+	for rows.Next() {
+		var attachment application.Attachment
+		var createdAt string
+
+		err := rows.Scan(
+			&attachment.ID,
+			&attachment.TaskID,
+			&attachment.Filename,
+			&attachment.MimeType,
+			&attachment.Size,
+			&attachment.Path,
+			&createdAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		attachment.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		attachments = append(attachments, &attachment)
+	}
+
+	return attachments, rows.Err()
+}
@@ -0,0 +1,123 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// backupTables are the tables a backup must have to be restored: without them it isn't a
+// database of this app
+var backupTables = []string{"users", "tasks", "task_shares"}
+
+// BackupSQLite writes a consistent copy of the database behind db to path with VACUUM INTO,
+// while the app keeps reading and writing it. path must not exist yet.
+func BackupSQLite(ctx context.Context, db *sql.DB, path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("backup %s already exists", path)
+	}
+	if _, err := db.ExecContext(ctx, "VACUUM INTO ?", path); err != nil {
+		os.Remove(path)
+		return fmt.Errorf("failed to back up the database: %w", err)
+	}
+	return nil
+}
+
+// ValidateSQLiteBackup checks that the file at path is an intact SQLite database with the
+// tables of the app, opening it read-only
+func ValidateSQLiteBackup(ctx context.Context, path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	db, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var result string
+	if err := db.QueryRowContext(ctx, "PRAGMA integrity_check").Scan(&result); err != nil {
+		return fmt.Errorf("backup %s is not a readable SQLite database: %w", path, err)
+	}
+	if result != "ok" {
+		return fmt.Errorf("backup %s is corrupt: %s", path, result)
+	}
+
+	for _, table := range backupTables {
+		var name string
+		err := db.QueryRowContext(ctx, "SELECT name FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&name)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("backup %s has no %s table", path, table)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreSQLite replaces the database at dbPath with the backup at backupPath, once it is
+// validated. The replaced database is kept as dbPath plus ".before-restore". The app must not
+// have dbPath open.
+func RestoreSQLite(ctx context.Context, backupPath, dbPath string) error {
+	if isMemoryDatabase(dbPath) {
+		return errors.New("an in-memory database can't be restored")
+	}
+	if err := ValidateSQLiteBackup(ctx, backupPath); err != nil {
+		return err
+	}
+
+	// The copy is written next to the database, so the swap is a rename on the same filesystem
+	restoring := dbPath + ".restoring"
+	if err := copyFile(backupPath, restoring); err != nil {
+		os.Remove(restoring)
+		return fmt.Errorf("failed to copy the backup: %w", err)
+	}
+
+	if _, err := os.Stat(dbPath); err == nil {
+		if err := os.Rename(dbPath, dbPath+".before-restore"); err != nil {
+			os.Remove(restoring)
+			return fmt.Errorf("failed to set the current database aside: %w", err)
+		}
+	}
+	// The WAL of the replaced database would be replayed into the restored one
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(dbPath + suffix); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(restoring, dbPath)
+}
+
+// copyFile copies the file at src to dst, flushed to disk
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// isMemoryDatabase reports whether dbPath opens a throwaway in-memory database, which can't
+// be backed up to or restored over
+func isMemoryDatabase(dbPath string) bool {
+	return dbPath == ":memory:" || strings.Contains(dbPath, "mode=memory")
+}
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestBackupAndRestoreSQLite(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	db, err := NewSQLiteDB(filepath.Join(dir, "live.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	users := NewSQLiteUserRepository(db)
+	if err := users.Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", PasswordHash: "hash", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	backupPath := filepath.Join(dir, "backup.db")
+	if err := BackupSQLite(ctx, db, backupPath); err != nil {
+		t.Fatalf("BackupSQLite() error: %v", err)
+	}
+	if err := BackupSQLite(ctx, db, backupPath); err == nil {
+		t.Error("BackupSQLite() over an existing backup should fail")
+	}
+	if err := ValidateSQLiteBackup(ctx, backupPath); err != nil {
+		t.Fatalf("ValidateSQLiteBackup() error: %v", err)
+	}
+
+	// The database restored over is a different one, which is set aside
+	target := filepath.Join(dir, "restored.db")
+	other, err := NewSQLiteDB(target, time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	other.Close()
+
+	if err := RestoreSQLite(ctx, backupPath, target); err != nil {
+		t.Fatalf("RestoreSQLite() error: %v", err)
+	}
+	if _, err := os.Stat(target + ".before-restore"); err != nil {
+		t.Errorf("Expected the replaced database to be kept: %v", err)
+	}
+
+	restored, err := NewSQLiteDB(target, time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open the restored database: %v", err)
+	}
+	defer restored.Close()
+	user, err := NewSQLiteUserRepository(restored).FindByID(ctx, "u-ana")
+	if err != nil || user == nil {
+		t.Fatalf("Expected the user in the restored database, got %v, %v", user, err)
+	}
+}
+
+func TestValidateSQLiteBackup_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	garbage := filepath.Join(dir, "garbage.db")
+	if err := os.WriteFile(garbage, []byte("not a database, not at all"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	foreign := filepath.Join(dir, "foreign.db")
+	db, err := sql.Open("sqlite3", foreign)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("CREATE TABLE notes (id TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{name: "missing file", path: filepath.Join(dir, "missing.db"), wantErr: "no such file"},
+		{name: "not SQLite", path: garbage, wantErr: "not a readable SQLite database"},
+		{name: "other app", path: foreign, wantErr: "has no users table"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSQLiteBackup(ctx, tt.path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateSQLiteBackup() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// An invalid backup leaves the database in place
+	target := filepath.Join(dir, "live.db")
+	if err := os.WriteFile(target, []byte("live"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := RestoreSQLite(ctx, foreign, target); err == nil {
+		t.Fatal("RestoreSQLite() of an invalid backup should fail")
+	}
+	if data, _ := os.ReadFile(target); string(data) != "live" {
+		t.Error("Expected the database to be untouched")
+	}
+}
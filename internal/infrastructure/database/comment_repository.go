@@ -0,0 +1,124 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteCommentRepository implements repository.CommentRepository using SQLite
+type SQLiteCommentRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteCommentRepository creates a new SQLiteCommentRepository
+func NewSQLiteCommentRepository(db *sql.DB) *SQLiteCommentRepository {
+	return &SQLiteCommentRepository{db: db}
+}
+
+// Create creates a new comment and its mentions using prepared statements. Callers run it in a
+// transaction so a comment is never left without its mentions.
+func (r *SQLiteCommentRepository) Create(ctx context.Context, comment *application.Comment) error {
+	db := conn(ctx, r.db)
+
+	query := `INSERT INTO comments (id, task_id, user_id, body, created_at)
+	          VALUES (?, ?, ?, ?, ?)`
+	_, err := db.ExecContext(ctx, query,
+		comment.ID,
+		comment.TaskID,
+		comment.UserID,
+		comment.Body,
+		comment.CreatedAt.UTC().Format(sortableTimeLayout),
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range comment.Mentions {
+		query := `INSERT OR IGNORE INTO comment_mentions (comment_id, user_id) VALUES (?, ?)`
+		if _, err := db.ExecContext(ctx, query, comment.ID, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindByTaskID finds the comments of a task, oldest first, using prepared statements
+func (r *SQLiteCommentRepository) FindByTaskID(ctx context.Context, taskID string) ([]*application.Comment, error) {
+	comments, err := r.FindByTaskIDs(ctx, []string{taskID})
+	if err != nil {
+		return nil, err
+	}
+	return comments[taskID], nil
+}
+
+// FindByTaskIDs finds the comments of several tasks at once, by task ID and oldest first, using
+// prepared statements
+func (r *SQLiteCommentRepository) FindByTaskIDs(ctx context.Context, taskIDs []string) (map[string][]*application.Comment, error) {
+	comments := make(map[string][]*application.Comment)
+	if len(taskIDs) == 0 {
+		return comments, nil
+	}
+	db := conn(ctx, r.db)
+
+	args := make([]any, len(taskIDs))
+	for i, id := range taskIDs {
+		args[i] = id
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(taskIDs)), ", ")
+
+	query := `SELECT id, task_id, user_id, body, created_at
+	          FROM comments WHERE task_id IN (` + placeholders + `)
+	          ORDER BY created_at, rowid`
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byID := make(map[string]*application.Comment)
+	for rows.Next() {
+		var comment application.Comment
+		var createdAt string
+		if err := rows.Scan(&comment.ID, &comment.TaskID, &comment.UserID, &comment.Body, &createdAt); err != nil {
+			return nil, err
+		}
+		if comment.CreatedAt, err = time.Parse(sortableTimeLayout, createdAt); err != nil {
+			return nil, err
+		}
+		comments[comment.TaskID] = append(comments[comment.TaskID], &comment)
+		byID[comment.ID] = &comment
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(byID) == 0 {
+		return comments, nil
+	}
+
+	query = `SELECT m.comment_id, m.user_id
+	         FROM comment_mentions m JOIN comments c ON c.id = m.comment_id
+	         WHERE c.task_id IN (` + placeholders + `)
+	         ORDER BY m.rowid`
+	mentionRows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer mentionRows.Close()
+
+	for mentionRows.Next() {
+		var commentID, userID string
+		if err := mentionRows.Scan(&commentID, &userID); err != nil {
+			return nil, err
+		}
+		if comment, ok := byID[commentID]; ok {
+			comment.Mentions = append(comment.Mentions, userID)
+		}
+	}
+
+	return comments, mentionRows.Err()
+}
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteCommentRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	for _, user := range []*application.User{
+		{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()},
+		{ID: "u-bia", Name: "Bia", Email: "bia@example.com", CreatedAt: time.Now()},
+	} {
+		if err := users.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	tasks := NewSQLiteTaskRepository(db)
+	for _, id := range []string{"task-1", "task-2"} {
+		task, err := application.NewTask(id, "Pagar contas", "", application.StatusPending, "u-ana", "")
+		if err != nil {
+			t.Fatalf("NewTask() error: %v", err)
+		}
+		if err := tasks.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	repo := NewSQLiteCommentRepository(db)
+
+	empty, err := repo.FindByTaskID(ctx, "task-1")
+	if err != nil || len(empty) != 0 {
+		t.Fatalf("FindByTaskID() without comments = %v, %v; want none", empty, err)
+	}
+
+	now := time.Now()
+	for _, c := range []struct {
+		id       string
+		taskID   string
+		userID   string
+		offset   time.Duration
+		mentions []string
+	}{
+		{"comment-2", "task-1", "u-bia", time.Minute, nil},
+		{"comment-1", "task-1", "u-ana", 0, []string{"u-bia", "u-ana"}},
+		{"comment-3", "task-2", "u-ana", 0, []string{"u-bia"}},
+	} {
+		comment, err := application.NewComment(c.id, c.taskID, c.userID, "Comentário "+c.id, now.Add(c.offset))
+		if err != nil {
+			t.Fatalf("NewComment() error: %v", err)
+		}
+		comment.Mentions = c.mentions
+		if err := repo.Create(ctx, comment); err != nil {
+			t.Fatalf("Create() error: %v", err)
+		}
+	}
+
+	comments, err := repo.FindByTaskID(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("FindByTaskID() error: %v", err)
+	}
+	if len(comments) != 2 || comments[0].ID != "comment-1" || comments[1].ID != "comment-2" {
+		t.Fatalf("Expected the comments of task-1 oldest first, got %v", comments)
+	}
+	if got := comments[0].Mentions; !slices.Equal(got, []string{"u-bia", "u-ana"}) {
+		t.Errorf("Mentions of comment-1 = %v, want [u-bia u-ana]", got)
+	}
+	if len(comments[1].Mentions) != 0 {
+		t.Errorf("Expected no mentions in comment-2, got %v", comments[1].Mentions)
+	}
+	if comments[0].Body != "Comentário comment-1" || comments[0].UserID != "u-ana" || comments[0].CreatedAt.IsZero() {
+		t.Errorf("Unexpected comment-1: %+v", comments[0])
+	}
+
+	byTask, err := repo.FindByTaskIDs(ctx, []string{"task-1", "task-2", "task-3"})
+	if err != nil {
+		t.Fatalf("FindByTaskIDs() error: %v", err)
+	}
+	if len(byTask) != 2 || len(byTask["task-1"]) != 2 || byTask["task-1"][0].ID != "comment-1" || len(byTask["task-2"]) != 1 {
+		t.Fatalf("Expected the comments of task-1 and task-2, got %v", byTask)
+	}
+	if got := byTask["task-2"][0].Mentions; !slices.Equal(got, []string{"u-bia"}) {
+		t.Errorf("Mentions of comment-3 = %v, want [u-bia]", got)
+	}
+
+	// Comments go with their task, and mentions with the users mentioned
+	if err := users.Delete(ctx, "u-bia"); err != nil {
+		t.Fatalf("Failed to delete user: %v", err)
+	}
+	if err := tasks.Delete(ctx, "task-2"); err != nil {
+		t.Fatalf("Failed to delete task: %v", err)
+	}
+
+	comments, err = repo.FindByTaskID(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("FindByTaskID() error: %v", err)
+	}
+	if len(comments) != 1 || !slices.Equal(comments[0].Mentions, []string{"u-ana"}) {
+		t.Errorf("Expected comment-1 left, mentioning u-ana only, got %v", comments)
+	}
+	if comments, err := repo.FindByTaskID(ctx, "task-2"); err != nil || len(comments) != 0 {
+		t.Errorf("Expected the comments of the deleted task gone, got %v, %v", comments, err)
+	}
+}
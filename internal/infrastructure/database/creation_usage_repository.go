@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteCreationUsageRepository implements repository.CreationUsageRepository using SQLite
+type SQLiteCreationUsageRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteCreationUsageRepository creates a new SQLiteCreationUsageRepository
+func NewSQLiteCreationUsageRepository(db *sql.DB) *SQLiteCreationUsageRepository {
+	return &SQLiteCreationUsageRepository{db: db}
+}
+
+// CreateIfBelowLimit records a usage using a single conditional insert, so concurrent requests
+// can't both take the last slots
+func (r *SQLiteCreationUsageRepository) CreateIfBelowLimit(ctx context.Context, usage *application.CreationUsage, since time.Time, limit int) (bool, error) {
+	query := `INSERT INTO creation_usage (id, user_id, kind, count, created_at)
+	          SELECT ?, ?, ?, ?, ?
+	          WHERE (SELECT COALESCE(SUM(count), 0) FROM creation_usage
+	                 WHERE user_id = ? AND kind = ? AND created_at > ?) + ? <= ?`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query,
+		usage.ID,
+		usage.UserID,
+		usage.Kind,
+		usage.Count,
+		usage.CreatedAt.UTC().Format(sortableTimeLayout),
+		usage.UserID,
+		usage.Kind,
+		since.UTC().Format(sortableTimeLayout),
+		usage.Count,
+		limit,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// FindOldestSince finds the oldest usage of a kind by a user in the window using prepared statement
+func (r *SQLiteCreationUsageRepository) FindOldestSince(ctx context.Context, userID string, kind application.CreationKind, since time.Time) (time.Time, error) {
+	query := `SELECT MIN(created_at) FROM creation_usage WHERE user_id = ? AND kind = ? AND created_at > ?`
+
+	var oldest sql.NullString
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query, userID, kind, since.UTC().Format(sortableTimeLayout)).Scan(&oldest); err != nil {
+		return time.Time{}, err
+	}
+	if !oldest.Valid {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(sortableTimeLayout, oldest.String)
+}
+
+// Delete deletes a usage record using prepared statement
+func (r *SQLiteCreationUsageRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM creation_usage WHERE id = ?`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	return err
+}
+
+// DeleteBefore deletes expired usage records using prepared statement
+func (r *SQLiteCreationUsageRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	query := `DELETE FROM creation_usage WHERE created_at <= ?`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, before.UTC().Format(sortableTimeLayout))
+	return err
+}
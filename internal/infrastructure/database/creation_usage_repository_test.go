@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteCreationUsageRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := NewSQLiteUserRepository(db).Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	repo := NewSQLiteCreationUsageRepository(db)
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	since := now.Add(-24 * time.Hour)
+	usage := func(id string, kind application.CreationKind, count int, at time.Time) *application.CreationUsage {
+		u, err := application.NewCreationUsage(id, "u-ana", kind, count)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u.CreatedAt = at
+		return u
+	}
+
+	// Besides an expired usage, an import of 8 tasks and a single task use up a quota of 10
+	// but for one task
+	for _, u := range []*application.CreationUsage{
+		usage("expired", application.CreationTask, 5, since.Add(-time.Minute)),
+		usage("import", application.CreationTask, 8, now.Add(-time.Hour)),
+		usage("task", application.CreationTask, 1, now),
+	} {
+		if created, err := repo.CreateIfBelowLimit(ctx, u, since, 10); err != nil || !created {
+			t.Fatalf("CreateIfBelowLimit(%s) = %v, %v; want true", u.ID, created, err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		usage *application.CreationUsage
+		want  bool
+	}{
+		{"over the limit", usage("too-many", application.CreationTask, 2, now), false},
+		{"up to the limit", usage("last", application.CreationTask, 1, now), true},
+		{"other kind", usage("image", application.CreationImage, 10, now), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created, err := repo.CreateIfBelowLimit(ctx, tt.usage, since, 10)
+			if err != nil || created != tt.want {
+				t.Errorf("CreateIfBelowLimit() = %v, %v; want %v", created, err, tt.want)
+			}
+		})
+	}
+
+	oldest, err := repo.FindOldestSince(ctx, "u-ana", application.CreationTask, since)
+	if err != nil || !oldest.Equal(now.Add(-time.Hour)) {
+		t.Errorf("FindOldestSince() = %v, %v; want the import", oldest, err)
+	}
+
+	// Giving back the import and pruning the expired usage frees the quota
+	if err := repo.Delete(ctx, "import"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.DeleteBefore(ctx, since); err != nil {
+		t.Fatal(err)
+	}
+	if created, err := repo.CreateIfBelowLimit(ctx, usage("after", application.CreationTask, 8, now), now.Add(-48*time.Hour), 10); err != nil || !created {
+		t.Errorf("CreateIfBelowLimit() after Delete = %v, %v; want true", created, err)
+	}
+}
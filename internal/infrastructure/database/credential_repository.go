@@ -0,0 +1,151 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteCredentialRepository implements repository.CredentialRepository using SQLite
+type SQLiteCredentialRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteCredentialRepository creates a new SQLiteCredentialRepository
+func NewSQLiteCredentialRepository(db *sql.DB) *SQLiteCredentialRepository {
+	return &SQLiteCredentialRepository{db: db}
+}
+
+// credentialColumns lists the columns scanned by scanCredential
+const credentialColumns = `id, user_id, name, credential_id, public_key, attestation_type, transports,
+	aaguid, sign_count, backup_eligible, backup_state, created_at, last_used_at`
+
+// Create creates a new credential using prepared statement
+func (r *SQLiteCredentialRepository) Create(ctx context.Context, credential *application.Credential) error {
+	query := `INSERT INTO credentials (` + credentialColumns + `)
+	          VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		credential.ID,
+		credential.UserID,
+		credential.Name,
+		credential.CredentialID,
+		credential.PublicKey,
+		credential.AttestationType,
+		strings.Join(credential.Transports, ","),
+		credential.AAGUID,
+		credential.SignCount,
+		credential.BackupEligible,
+		credential.BackupState,
+		credential.CreatedAt.UTC().Format(sortableTimeLayout),
+		nullableTime(credential.LastUsedAt),
+	)
+	return err
+}
+
+// FindByID finds a credential by ID using prepared statement
+func (r *SQLiteCredentialRepository) FindByID(ctx context.Context, id string) (*application.Credential, error) {
+	query := `SELECT ` + credentialColumns + ` FROM credentials WHERE id = ?`
+
+	credential, err := scanCredential(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, application.ErrCredentialNotFound
+	}
+	return credential, err
+}
+
+// FindByUserID finds the credentials of a user using prepared statement
+func (r *SQLiteCredentialRepository) FindByUserID(ctx context.Context, userID string) ([]*application.Credential, error) {
+	query := `SELECT ` + credentialColumns + `
+	          FROM credentials WHERE user_id = ?
+	          ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var credentials []*application.Credential
+	for rows.Next() {
+		credential, err := scanCredential(rows)
+		if err != nil {
+			return nil, err
+		}
+		credentials = append(credentials, credential)
+	}
+
+	return credentials, rows.Err()
+}
+
+// UpdateUsage stores the sign count, backup state and last use of a credential using prepared statement
+func (r *SQLiteCredentialRepository) UpdateUsage(ctx context.Context, credential *application.Credential) error {
+	query := `UPDATE credentials SET sign_count = ?, backup_state = ?, last_used_at = ? WHERE id = ?`
+
+	_, err := r.db.ExecContext(ctx, query,
+		credential.SignCount,
+		credential.BackupState,
+		nullableTime(credential.LastUsedAt),
+		credential.ID,
+	)
+	return err
+}
+
+// Delete deletes a credential using prepared statement
+func (r *SQLiteCredentialRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM credentials WHERE id = ?`, id)
+	return err
+}
+
+// nullableTime formats an optional time as sortable UTC text
+func nullableTime(t *time.Time) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: t.UTC().Format(sortableTimeLayout), Valid: true}
+}
+
+// scanCredential scans a row selected with credentialColumns
+func scanCredential(row rowScanner) (*application.Credential, error) {
+	var credential application.Credential
+	var transports, createdAt string
+	var lastUsedAt sql.NullString
+
+	err := row.Scan(
+		&credential.ID,
+		&credential.UserID,
+		&credential.Name,
+		&credential.CredentialID,
+		&credential.PublicKey,
+		&credential.AttestationType,
+		&transports,
+		&credential.AAGUID,
+		&credential.SignCount,
+		&credential.BackupEligible,
+		&credential.BackupState,
+		&createdAt,
+		&lastUsedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if transports != "" {
+		credential.Transports = strings.Split(transports, ",")
+	}
+	if credential.CreatedAt, err = time.Parse(sortableTimeLayout, createdAt); err != nil {
+		return nil, err
+	}
+	if lastUsedAt.Valid {
+		used, err := time.Parse(sortableTimeLayout, lastUsedAt.String)
+		if err != nil {
+			return nil, err
+		}
+		credential.LastUsedAt = &used
+	}
+
+	return &credential, nil
+}
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteCredentialRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	if err := users.Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	repo := NewSQLiteCredentialRepository(db)
+
+	missing, err := repo.FindByID(ctx, "missing")
+	if !errors.Is(err, application.ErrCredentialNotFound) || missing != nil {
+		t.Fatalf("FindByID() of missing credential = %v, %v; want nil, ErrCredentialNotFound", missing, err)
+	}
+
+	for i, id := range []string{"cred-laptop", "cred-phone"} {
+		credential, err := application.NewCredential(id, "u-ana", id, []byte{byte(i + 1)}, []byte{0xa5, byte(i)})
+		if err != nil {
+			t.Fatalf("NewCredential() error: %v", err)
+		}
+		credential.Transports = []string{"internal", "hybrid"}
+		credential.BackupEligible = true
+		credential.CreatedAt = credential.CreatedAt.Add(time.Duration(i) * time.Second)
+		if err := repo.Create(ctx, credential); err != nil {
+			t.Fatalf("Create() error: %v", err)
+		}
+	}
+
+	duplicate, _ := application.NewCredential("cred-copy", "u-ana", "", []byte{1}, []byte{1})
+	if err := repo.Create(ctx, duplicate); err == nil {
+		t.Error("Create() with a registered authenticator id expected error but got nil")
+	}
+
+	credentials, err := repo.FindByUserID(ctx, "u-ana")
+	if err != nil || len(credentials) != 2 || credentials[0].ID != "cred-laptop" || credentials[1].ID != "cred-phone" {
+		t.Fatalf("FindByUserID() = %v, %v; want cred-laptop and cred-phone", credentials, err)
+	}
+	laptop := credentials[0]
+	if !bytes.Equal(laptop.CredentialID, []byte{1}) || !bytes.Equal(laptop.PublicKey, []byte{0xa5, 0}) {
+		t.Errorf("FindByUserID() keys = %v, %v", laptop.CredentialID, laptop.PublicKey)
+	}
+	if len(laptop.Transports) != 2 || laptop.Transports[1] != "hybrid" || !laptop.BackupEligible || laptop.LastUsedAt != nil {
+		t.Errorf("FindByUserID() = %+v", laptop)
+	}
+
+	usedAt := time.Now()
+	laptop.RecordUse(3, true, usedAt)
+	if err := repo.UpdateUsage(ctx, laptop); err != nil {
+		t.Fatalf("UpdateUsage() error: %v", err)
+	}
+	updated, err := repo.FindByID(ctx, "cred-laptop")
+	if err != nil || updated == nil {
+		t.Fatalf("FindByID() = %v, %v", updated, err)
+	}
+	if updated.SignCount != 3 || !updated.BackupState || updated.LastUsedAt == nil || !updated.LastUsedAt.Equal(usedAt) {
+		t.Errorf("FindByID() after UpdateUsage = %+v", updated)
+	}
+
+	if err := repo.Delete(ctx, "cred-phone"); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	credentials, err = repo.FindByUserID(ctx, "u-ana")
+	if err != nil || len(credentials) != 1 {
+		t.Fatalf("FindByUserID() after Delete = %v, %v; want 1 credential", credentials, err)
+	}
+
+	if err := users.Delete(ctx, "u-ana"); err != nil {
+		t.Fatalf("Failed to delete user: %v", err)
+	}
+	credentials, err = repo.FindByUserID(ctx, "u-ana")
+	if err != nil || len(credentials) != 0 {
+		t.Errorf("FindByUserID() after user deletion = %v, %v; want none", credentials, err)
+	}
+}
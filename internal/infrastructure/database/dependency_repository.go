@@ -0,0 +1,144 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteDependencyRepository implements repository.DependencyRepository using SQLite
+type SQLiteDependencyRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteDependencyRepository creates a new SQLiteDependencyRepository
+func NewSQLiteDependencyRepository(db *sql.DB) *SQLiteDependencyRepository {
+	return &SQLiteDependencyRepository{db: db}
+}
+
+// Add records a dependency using prepared statement
+func (r *SQLiteDependencyRepository) Add(ctx context.Context, dependency *application.TaskDependency) error {
+	query := `INSERT INTO task_dependencies (task_id, blocker_id, created_at)
+	          VALUES (?, ?, ?)
+	          ON CONFLICT (task_id, blocker_id) DO NOTHING`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		dependency.TaskID,
+		dependency.BlockerID,
+		dependency.CreatedAt.UTC().Format(sortableTimeLayout),
+	)
+	return err
+}
+
+// Remove removes a dependency using prepared statement
+func (r *SQLiteDependencyRepository) Remove(ctx context.Context, taskID, blockerID string) error {
+	query := `DELETE FROM task_dependencies WHERE task_id = ? AND blocker_id = ?`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, taskID, blockerID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return application.ErrDependencyNotFound
+	}
+	return nil
+}
+
+// FindBlockers finds the tasks blocking a task using prepared statement
+func (r *SQLiteDependencyRepository) FindBlockers(ctx context.Context, taskID string) ([]*application.Task, error) {
+	query := `SELECT ` + taskColumns + `
+	          FROM tasks
+	          INNER JOIN (SELECT blocker_id, created_at AS added_at FROM task_dependencies WHERE task_id = ?) d
+	                  ON d.blocker_id = tasks.id
+	          WHERE tasks.deleted_at IS NULL
+	          ORDER BY d.added_at, tasks.id`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*application.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, rows.Err()
+}
+
+// FindOpenBlockers finds the open blockers of several tasks using prepared statement
+func (r *SQLiteDependencyRepository) FindOpenBlockers(ctx context.Context, taskIDs []string) (map[string][]*application.Task, error) {
+	blockers := make(map[string][]*application.Task)
+	if len(taskIDs) == 0 {
+		return blockers, nil
+	}
+
+	args := make([]any, 0, len(taskIDs)+1)
+	for _, id := range taskIDs {
+		args = append(args, id)
+	}
+	args = append(args, string(application.StatusCompleted))
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(taskIDs)), ", ")
+	query := `SELECT d.blocked_id, ` + taskColumns + `
+	          FROM tasks
+	          INNER JOIN (SELECT task_id AS blocked_id, blocker_id, created_at AS added_at
+	                      FROM task_dependencies WHERE task_id IN (` + placeholders + `)) d
+	                  ON d.blocker_id = tasks.id
+	          WHERE tasks.status <> ? AND tasks.deleted_at IS NULL
+	          ORDER BY d.added_at, tasks.id`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var blockedID string
+		task, err := scanTask(prefixedScanner{row: rows, prefix: []any{&blockedID}})
+		if err != nil {
+			return nil, err
+		}
+		blockers[blockedID] = append(blockers[blockedID], task)
+	}
+
+	return blockers, rows.Err()
+}
+
+// IsBlockedBy walks the dependencies of a task with a recursive query using prepared statement
+func (r *SQLiteDependencyRepository) IsBlockedBy(ctx context.Context, taskID, blockerID string) (bool, error) {
+	query := `WITH RECURSIVE blockers(id) AS (
+	              SELECT blocker_id FROM task_dependencies WHERE task_id = ?
+	              UNION
+	              SELECT d.blocker_id FROM task_dependencies d INNER JOIN blockers b ON d.task_id = b.id
+	          )
+	          SELECT EXISTS (SELECT 1 FROM blockers WHERE id = ?)`
+
+	var blocked bool
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, taskID, blockerID).Scan(&blocked)
+	return blocked, err
+}
+
+// prefixedScanner scans leading columns into prefix before handing the rest to dest
+type prefixedScanner struct {
+	row    rowScanner
+	prefix []any
+}
+
+// Scan implements rowScanner
+func (s prefixedScanner) Scan(dest ...any) error {
+	return s.row.Scan(append(s.prefix, dest...)...)
+}
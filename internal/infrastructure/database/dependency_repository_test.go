@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteDependencyRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := NewSQLiteUserRepository(db).Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	taskRepo := NewSQLiteTaskRepository(db)
+	for _, id := range []string{"a", "b", "c", "d"} {
+		task, err := application.NewTask(id, "Tarefa "+id, "", application.StatusPending, "u-ana", "")
+		if err != nil {
+			t.Fatalf("NewTask() error: %v", err)
+		}
+		if err := taskRepo.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	repo := NewSQLiteDependencyRepository(db)
+
+	// a is blocked by b and c; b is blocked by d
+	now := time.Now()
+	for i, d := range [][2]string{{"a", "b"}, {"a", "c"}, {"b", "d"}, {"a", "b"}} {
+		dependency, err := application.NewTaskDependency(d[0], d[1], now.Add(time.Duration(i)*time.Second))
+		if err != nil {
+			t.Fatalf("NewTaskDependency() error: %v", err)
+		}
+		if err := repo.Add(ctx, dependency); err != nil {
+			t.Fatalf("Add(%v) error: %v", d, err)
+		}
+	}
+
+	blockers, err := repo.FindBlockers(ctx, "a")
+	if err != nil {
+		t.Fatalf("FindBlockers() error: %v", err)
+	}
+	if len(blockers) != 2 || blockers[0].ID != "b" || blockers[1].ID != "c" || blockers[0].Title != "Tarefa b" {
+		t.Errorf("FindBlockers() = %v, want b and c in the order they were added", blockers)
+	}
+
+	for _, tt := range []struct {
+		taskID, blockerID string
+		want              bool
+	}{
+		{"a", "b", true},
+		{"a", "d", true},
+		{"b", "a", false},
+		{"d", "a", false},
+		{"c", "d", false},
+	} {
+		got, err := repo.IsBlockedBy(ctx, tt.taskID, tt.blockerID)
+		if err != nil || got != tt.want {
+			t.Errorf("IsBlockedBy(%s, %s) = %v, %v; want %v", tt.taskID, tt.blockerID, got, err, tt.want)
+		}
+	}
+
+	// Completed blockers no longer block
+	c, _ := taskRepo.FindByID(ctx, "c")
+	if err := c.CompleteTask(); err != nil {
+		t.Fatalf("CompleteTask() error: %v", err)
+	}
+	if err := taskRepo.Update(ctx, c); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+
+	open, err := repo.FindOpenBlockers(ctx, []string{"a", "b", "c", "d"})
+	if err != nil {
+		t.Fatalf("FindOpenBlockers() error: %v", err)
+	}
+	if len(open) != 2 || len(open["a"]) != 1 || open["a"][0].ID != "b" || len(open["b"]) != 1 || open["b"][0].ID != "d" {
+		t.Errorf("FindOpenBlockers() = %v, want a blocked by b and b blocked by d", open)
+	}
+	if empty, err := repo.FindOpenBlockers(ctx, nil); err != nil || len(empty) != 0 {
+		t.Errorf("FindOpenBlockers(nil) = %v, %v; want an empty map", empty, err)
+	}
+
+	if err := repo.Remove(ctx, "a", "b"); err != nil {
+		t.Fatalf("Remove() error: %v", err)
+	}
+	if err := repo.Remove(ctx, "a", "b"); !errors.Is(err, application.ErrDependencyNotFound) {
+		t.Errorf("Remove() of a removed dependency = %v, want %v", err, application.ErrDependencyNotFound)
+	}
+
+	// Deleting a task removes the dependencies on it
+	if err := taskRepo.Delete(ctx, "d"); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if blockers, err := repo.FindBlockers(ctx, "b"); err != nil || len(blockers) != 0 {
+		t.Errorf("FindBlockers() after deleting the blocker = %v, %v; want none", blockers, err)
+	}
+}
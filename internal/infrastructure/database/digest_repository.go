@@ -0,0 +1,188 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteDigestRepository implements repository.DigestRepository using SQLite
+type SQLiteDigestRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteDigestRepository creates a new SQLiteDigestRepository
+func NewSQLiteDigestRepository(db *sql.DB) *SQLiteDigestRepository {
+	return &SQLiteDigestRepository{db: db}
+}
+
+// digestColumns lists the columns scanned by scanDigestSubscription
+const digestColumns = `user_id, weekday, hour, next_send_at, last_sent_at, created_at`
+
+// Save creates or replaces the subscription of a user using prepared statement. Replacing
+// keeps when the last digest was sent.
+func (r *SQLiteDigestRepository) Save(ctx context.Context, subscription *application.DigestSubscription) error {
+	query := `INSERT INTO digest_subscriptions (` + digestColumns + `)
+	          VALUES (?, ?, ?, ?, ?, ?)
+	          ON CONFLICT(user_id) DO UPDATE SET
+	              weekday = excluded.weekday, hour = excluded.hour, next_send_at = excluded.next_send_at`
+
+	var lastSentAt sql.NullString
+	if subscription.LastSentAt != nil {
+		lastSentAt = sql.NullString{String: subscription.LastSentAt.UTC().Format(sortableTimeLayout), Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		subscription.UserID,
+		int(subscription.Weekday),
+		subscription.Hour,
+		subscription.NextSendAt.UTC().Format(sortableTimeLayout),
+		lastSentAt,
+		subscription.CreatedAt.UTC().Format(sortableTimeLayout),
+	)
+	return err
+}
+
+// FindByUserID finds the subscription of a user using prepared statement
+func (r *SQLiteDigestRepository) FindByUserID(ctx context.Context, userID string) (*application.DigestSubscription, error) {
+	query := `SELECT ` + digestColumns + ` FROM digest_subscriptions WHERE user_id = ?`
+
+	subscription, err := scanDigestSubscription(r.db.QueryRowContext(ctx, query, userID))
+	if err == sql.ErrNoRows {
+		return nil, application.ErrDigestSubscriptionNotFound
+	}
+	return subscription, err
+}
+
+// Delete deletes the subscription of a user using prepared statement
+func (r *SQLiteDigestRepository) Delete(ctx context.Context, userID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM digest_subscriptions WHERE user_id = ?`, userID)
+	return err
+}
+
+// FindDue finds the subscriptions due at now using prepared statement
+func (r *SQLiteDigestRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*application.DigestSubscription, error) {
+	query := `SELECT ` + digestColumns + `
+	          FROM digest_subscriptions WHERE next_send_at <= ?
+	          ORDER BY next_send_at LIMIT ?`
+
+	rows, err := r.db.QueryContext(ctx, query, now.UTC().Format(sortableTimeLayout), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []*application.DigestSubscription
+	for rows.Next() {
+		subscription, err := scanDigestSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+// Reschedule moves the next digest of a subscription still due at previous using prepared statement
+func (r *SQLiteDigestRepository) Reschedule(ctx context.Context, userID string, previous, next, sentAt time.Time) (bool, error) {
+	query := `UPDATE digest_subscriptions SET next_send_at = ?, last_sent_at = ?
+	          WHERE user_id = ? AND next_send_at = ?`
+
+	result, err := r.db.ExecContext(ctx, query,
+		next.UTC().Format(sortableTimeLayout),
+		sentAt.UTC().Format(sortableTimeLayout),
+		userID,
+		previous.UTC().Format(sortableTimeLayout),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// Summarize counts the tasks of a user with a single aggregate query, then loads the tasks
+// coming due, both using prepared statements. Due dates keep the offset they were entered
+// with, so they are compared with julianday, which converts them to UTC. Tasks have no
+// completion time: completed tasks last updated in the week count as completed in it.
+func (r *SQLiteDigestRepository) Summarize(ctx context.Context, userID string, since, now time.Time, limit int) (*application.WeeklyDigest, error) {
+	query := `SELECT
+	              COALESCE(SUM(status != ?), 0),
+	              COALESCE(SUM(status != ? AND due_date IS NOT NULL AND julianday(due_date) < julianday(?)), 0),
+	              COALESCE(SUM(status = ? AND julianday(updated_at) >= julianday(?)), 0)
+	          FROM tasks WHERE owner_id = ? AND deleted_at IS NULL`
+
+	completed := string(application.StatusCompleted)
+	digest := &application.WeeklyDigest{From: since, To: now}
+	err := r.db.QueryRowContext(ctx, query, completed, completed, now, completed, since, userID).
+		Scan(&digest.Pending, &digest.Overdue, &digest.Completed)
+	if err != nil {
+		return nil, err
+	}
+
+	upcoming := `SELECT ` + taskColumns + `
+	             FROM tasks
+	             WHERE owner_id = ? AND deleted_at IS NULL AND status != ? AND due_date IS NOT NULL
+	               AND julianday(due_date) >= julianday(?) AND julianday(due_date) < julianday(?)
+	             ORDER BY julianday(due_date) LIMIT ?`
+
+	rows, err := r.db.QueryContext(ctx, upcoming, userID, completed, now, now.AddDate(0, 0, application.DigestUpcomingDays), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		digest.Upcoming = append(digest.Upcoming, task)
+	}
+
+	return digest, rows.Err()
+}
+
+// scanDigestSubscription scans a row selected with digestColumns
+func scanDigestSubscription(row rowScanner) (*application.DigestSubscription, error) {
+	var subscription application.DigestSubscription
+	var weekday int
+	var nextSendAt, createdAt string
+	var lastSentAt sql.NullString
+
+	err := row.Scan(
+		&subscription.UserID,
+		&weekday,
+		&subscription.Hour,
+		&nextSendAt,
+		&lastSentAt,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Weekday = time.Weekday(weekday)
+	if subscription.NextSendAt, err = time.Parse(sortableTimeLayout, nextSendAt); err != nil {
+		return nil, err
+	}
+	if subscription.CreatedAt, err = time.Parse(sortableTimeLayout, createdAt); err != nil {
+		return nil, err
+	}
+	if lastSentAt.Valid {
+		sent, err := time.Parse(sortableTimeLayout, lastSentAt.String)
+		if err != nil {
+			return nil, err
+		}
+		subscription.LastSentAt = &sent
+	}
+
+	return &subscription, nil
+}
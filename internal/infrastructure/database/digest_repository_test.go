@@ -0,0 +1,136 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteDigestRepository_Subscriptions(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	for _, id := range []string{"u-ana", "u-bia"} {
+		if err := users.Create(ctx, &application.User{ID: id, Name: id, Email: id + "@example.com", CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	repo := NewSQLiteDigestRepository(db)
+	if missing, err := repo.FindByUserID(ctx, "u-ana"); !errors.Is(err, application.ErrDigestSubscriptionNotFound) || missing != nil {
+		t.Fatalf("FindByUserID() before opting in = %v, %v; want nil, ErrDigestSubscriptionNotFound", missing, err)
+	}
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC) // Tuesday
+	ana, _ := application.NewDigestSubscription("u-ana", time.Monday, 8, time.UTC, now.AddDate(0, 0, -7))
+	bia, _ := application.NewDigestSubscription("u-bia", time.Friday, 18, time.UTC, now)
+	for _, subscription := range []*application.DigestSubscription{ana, bia} {
+		if err := repo.Save(ctx, subscription); err != nil {
+			t.Fatalf("Save() error: %v", err)
+		}
+	}
+
+	due, err := repo.FindDue(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("FindDue() error: %v", err)
+	}
+	if len(due) != 1 || due[0].UserID != "u-ana" || due[0].Weekday != time.Monday || due[0].Hour != 8 || !due[0].NextSendAt.Equal(ana.NextSendAt) {
+		t.Fatalf("FindDue() = %+v, want the subscription of u-ana", due)
+	}
+
+	next := application.NextDigestAt(time.Monday, 8, time.UTC, now)
+	if moved, err := repo.Reschedule(ctx, "u-ana", ana.NextSendAt, next, now); err != nil || !moved {
+		t.Fatalf("Reschedule() = %v, %v; want true", moved, err)
+	}
+	if moved, err := repo.Reschedule(ctx, "u-ana", ana.NextSendAt, next, now); err != nil || moved {
+		t.Fatalf("second Reschedule() = %v, %v; want false", moved, err)
+	}
+
+	got, err := repo.FindByUserID(ctx, "u-ana")
+	if err != nil {
+		t.Fatalf("FindByUserID() error: %v", err)
+	}
+	if !got.NextSendAt.Equal(next) || got.LastSentAt == nil || !got.LastSentAt.Equal(now) {
+		t.Errorf("rescheduled subscription = %+v, want next at %v, sent at %v", got, next, now)
+	}
+
+	// Changing the schedule keeps when the last digest was sent
+	changed, _ := application.NewDigestSubscription("u-ana", time.Wednesday, 7, time.UTC, now)
+	if err := repo.Save(ctx, changed); err != nil {
+		t.Fatalf("Save() of a new schedule error: %v", err)
+	}
+	got, _ = repo.FindByUserID(ctx, "u-ana")
+	if got.Weekday != time.Wednesday || got.Hour != 7 || got.LastSentAt == nil {
+		t.Errorf("subscription after a new schedule = %+v", got)
+	}
+
+	if err := repo.Delete(ctx, "u-ana"); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if got, err := repo.FindByUserID(ctx, "u-ana"); !errors.Is(err, application.ErrDigestSubscriptionNotFound) {
+		t.Errorf("FindByUserID() after Delete() = %+v, %v; want ErrDigestSubscriptionNotFound", got, err)
+	}
+}
+
+func TestSQLiteDigestRepository_Summarize(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	for _, id := range []string{"u-ana", "u-bia"} {
+		if err := users.Create(ctx, &application.User{ID: id, Name: id, Email: id + "@example.com", CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+	day := 24 * time.Hour
+	tasks := NewSQLiteTaskRepository(db)
+	for _, task := range []*application.Task{
+		{ID: "overdue", Title: "Atrasada", Status: application.StatusPending, OwnerID: "u-ana", DueDate: at(-day), CreatedAt: now.Add(-10 * day), UpdatedAt: now.Add(-10 * day)},
+		{ID: "soon", Title: "Em breve", Status: application.StatusInProgress, OwnerID: "u-ana", DueDate: at(2 * day), CreatedAt: now, UpdatedAt: now},
+		{ID: "later", Title: "Depois", Status: application.StatusPending, OwnerID: "u-ana", DueDate: at(10 * day), CreatedAt: now, UpdatedAt: now},
+		{ID: "done-this-week", Title: "Feita", Status: application.StatusCompleted, OwnerID: "u-ana", DueDate: at(day), CreatedAt: now.Add(-10 * day), UpdatedAt: now.Add(-day)},
+		{ID: "done-before", Title: "Feita antes", Status: application.StatusCompleted, OwnerID: "u-ana", CreatedAt: now.Add(-20 * day), UpdatedAt: now.Add(-10 * day)},
+		{ID: "other-owner", Title: "Da Bia", Status: application.StatusPending, OwnerID: "u-bia", DueDate: at(day), CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := tasks.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task %s: %v", task.ID, err)
+		}
+	}
+
+	repo := NewSQLiteDigestRepository(db)
+	digest, err := repo.Summarize(ctx, "u-ana", now.Add(-7*day), now, application.DigestMaxUpcoming)
+	if err != nil {
+		t.Fatalf("Summarize() error: %v", err)
+	}
+
+	if digest.Pending != 3 || digest.Overdue != 1 || digest.Completed != 1 {
+		t.Errorf("digest = %d pending, %d overdue, %d completed; want 3, 1, 1", digest.Pending, digest.Overdue, digest.Completed)
+	}
+	if len(digest.Upcoming) != 1 || digest.Upcoming[0].ID != "soon" {
+		t.Errorf("upcoming = %v, want only the task due in 2 days", digest.Upcoming)
+	}
+
+	empty, err := repo.Summarize(ctx, "u-nobody", now.Add(-7*day), now, application.DigestMaxUpcoming)
+	if err != nil || !empty.IsEmpty() {
+		t.Errorf("Summarize() of a user without tasks = %+v, %v; want an empty digest", empty, err)
+	}
+}
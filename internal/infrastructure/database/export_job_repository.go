@@ -0,0 +1,176 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteExportJobRepository implements repository.ExportJobRepository using SQLite
+type SQLiteExportJobRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteExportJobRepository creates a new SQLiteExportJobRepository
+func NewSQLiteExportJobRepository(db *sql.DB) *SQLiteExportJobRepository {
+	return &SQLiteExportJobRepository{db: db}
+}
+
+// exportJobColumns lists the columns scanned by scanExportJob
+const exportJobColumns = `id, user_id, kind, org_id, status, error, COALESCE(length(result), 0), created_at, started_at, finished_at`
+
+// Create creates a new job using prepared statement
+func (r *SQLiteExportJobRepository) Create(ctx context.Context, job *application.ExportJob) error {
+	query := `INSERT INTO export_jobs (id, user_id, kind, org_id, status, created_at, started_at)
+	          VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	var startedAt sql.NullString
+	if job.StartedAt != nil {
+		startedAt = sql.NullString{String: job.StartedAt.UTC().Format(sortableTimeLayout), Valid: true}
+	}
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		job.ID,
+		job.UserID,
+		job.Kind,
+		nullableID(job.OrgID),
+		string(job.Status),
+		job.CreatedAt.UTC().Format(sortableTimeLayout),
+		startedAt,
+	)
+	return err
+}
+
+// FindByID finds a job by ID using prepared statement
+func (r *SQLiteExportJobRepository) FindByID(ctx context.Context, id string) (*application.ExportJob, error) {
+	query := `SELECT ` + exportJobColumns + ` FROM export_jobs WHERE id = ?`
+
+	job, err := scanExportJob(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, application.ErrExportJobNotFound
+	}
+	return job, err
+}
+
+// FindResult returns the file of a done job using prepared statement
+func (r *SQLiteExportJobRepository) FindResult(ctx context.Context, id string) ([]byte, error) {
+	query := `SELECT result FROM export_jobs WHERE id = ? AND status = ?`
+
+	var result []byte
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id, string(application.ExportJobDone)).Scan(&result)
+	if err == sql.ErrNoRows {
+		return nil, application.ErrExportJobNotFound
+	}
+	return result, err
+}
+
+// ClaimNext claims the oldest pending or stale job with a single UPDATE using prepared
+// statement, so concurrent workers never claim the same job
+func (r *SQLiteExportJobRepository) ClaimNext(ctx context.Context, now, staleBefore time.Time) (*application.ExportJob, error) {
+	query := `UPDATE export_jobs SET status = ?, started_at = ?
+	          WHERE id = (
+	              SELECT id FROM export_jobs
+	              WHERE status = ? OR (status = ? AND started_at < ?)
+	              ORDER BY created_at
+	              LIMIT 1
+	          )
+	          RETURNING ` + exportJobColumns
+
+	job, err := scanExportJob(conn(ctx, r.db).QueryRowContext(ctx, query,
+		string(application.ExportJobRunning),
+		now.UTC().Format(sortableTimeLayout),
+		string(application.ExportJobPending),
+		string(application.ExportJobRunning),
+		staleBefore.UTC().Format(sortableTimeLayout),
+	))
+	if err == sql.ErrNoRows {
+		return nil, application.ErrExportJobNotFound
+	}
+	return job, err
+}
+
+// Finish stores the file of a job using prepared statement
+func (r *SQLiteExportJobRepository) Finish(ctx context.Context, id string, result []byte, finishedAt time.Time) error {
+	query := `UPDATE export_jobs SET status = ?, result = ?, finished_at = ? WHERE id = ? AND status = ?`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		string(application.ExportJobDone), result, finishedAt.UTC().Format(sortableTimeLayout),
+		id, string(application.ExportJobRunning),
+	)
+	return err
+}
+
+// Fail marks a job as failed using prepared statement
+func (r *SQLiteExportJobRepository) Fail(ctx context.Context, id, message string, finishedAt time.Time) error {
+	query := `UPDATE export_jobs SET status = ?, error = ?, finished_at = ? WHERE id = ? AND status = ?`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		string(application.ExportJobFailed), message, finishedAt.UTC().Format(sortableTimeLayout),
+		id, string(application.ExportJobRunning),
+	)
+	return err
+}
+
+// DeleteFinishedBefore deletes expired jobs using prepared statement
+func (r *SQLiteExportJobRepository) DeleteFinishedBefore(ctx context.Context, before time.Time) (int, error) {
+	query := `DELETE FROM export_jobs WHERE finished_at IS NOT NULL AND finished_at < ?`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, before.UTC().Format(sortableTimeLayout))
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	return int(deleted), err
+}
+
+// scanExportJob scans a row selected with exportJobColumns
+func scanExportJob(row rowScanner) (*application.ExportJob, error) {
+	var job application.ExportJob
+	var status, createdAt string
+	var orgID, startedAt, finishedAt sql.NullString
+
+	err := row.Scan(
+		&job.ID,
+		&job.UserID,
+		&job.Kind,
+		&orgID,
+		&status,
+		&job.Error,
+		&job.Size,
+		&createdAt,
+		&startedAt,
+		&finishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	job.OrgID = orgID.String
+	job.Status = application.ExportJobStatus(status)
+	if job.CreatedAt, err = time.Parse(sortableTimeLayout, createdAt); err != nil {
+		return nil, err
+	}
+	if job.StartedAt, err = parseOptionalTime(startedAt); err != nil {
+		return nil, err
+	}
+	if job.FinishedAt, err = parseOptionalTime(finishedAt); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// parseOptionalTime parses a nullable sortable UTC time
+func parseOptionalTime(value sql.NullString) (*time.Time, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	t, err := time.Parse(sortableTimeLayout, value.String)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteExportJobRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := NewSQLiteUserRepository(db).Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := NewSQLiteOrgRepository(db).Create(ctx, &application.Org{ID: "org-1", Name: "Financeiro", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create org: %v", err)
+	}
+
+	repo := NewSQLiteExportJobRepository(db)
+	start := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	for i, id := range []string{"first", "second"} {
+		job, err := application.NewExportJob(id, "u-ana", application.ExportKindTasksPDF, start.Add(time.Duration(i)*time.Second))
+		if err != nil {
+			t.Fatalf("NewExportJob() error: %v", err)
+		}
+		if id == "second" {
+			job.OrgID = "org-1"
+		}
+		if err := repo.Create(ctx, job); err != nil {
+			t.Fatalf("Create() error: %v", err)
+		}
+	}
+
+	if job, err := repo.FindByID(ctx, "missing"); !errors.Is(err, application.ErrExportJobNotFound) || job != nil {
+		t.Errorf("FindByID(missing) = %v, %v; want nil, ErrExportJobNotFound", job, err)
+	}
+
+	// Jobs are claimed oldest first, and a running job isn't claimed again until it's stale
+	claimAt := start.Add(time.Minute)
+	claimed, err := repo.ClaimNext(ctx, claimAt, claimAt.Add(-10*time.Minute))
+	if err != nil || claimed == nil || claimed.ID != "first" || claimed.Status != application.ExportJobRunning {
+		t.Fatalf("ClaimNext() = %+v, %v; want first running", claimed, err)
+	}
+	if claimed.StartedAt == nil || !claimed.StartedAt.Equal(claimAt) {
+		t.Errorf("StartedAt = %v, want %v", claimed.StartedAt, claimAt)
+	}
+	if claimed.OrgID != "" {
+		t.Errorf("OrgID = %q, want the personal workspace", claimed.OrgID)
+	}
+	if next, _ := repo.ClaimNext(ctx, claimAt, claimAt.Add(-10*time.Minute)); next == nil || next.ID != "second" || next.OrgID != "org-1" {
+		t.Fatalf("ClaimNext() = %+v, want second in org-1", next)
+	}
+	if next, err := repo.ClaimNext(ctx, claimAt, claimAt.Add(-10*time.Minute)); !errors.Is(err, application.ErrExportJobNotFound) || next != nil {
+		t.Fatalf("ClaimNext() with nothing pending = %+v, %v; want nil, ErrExportJobNotFound", next, err)
+	}
+
+	if result, err := repo.FindResult(ctx, "first"); !errors.Is(err, application.ErrExportJobNotFound) || result != nil {
+		t.Errorf("FindResult() of a running job = %v, %v; want nil, ErrExportJobNotFound", result, err)
+	}
+
+	finishedAt := start.Add(2 * time.Minute)
+	if err := repo.Finish(ctx, "first", []byte("%PDF-1.4"), finishedAt); err != nil {
+		t.Fatalf("Finish() error: %v", err)
+	}
+	done, err := repo.FindByID(ctx, "first")
+	if err != nil {
+		t.Fatalf("FindByID() error: %v", err)
+	}
+	if done.Status != application.ExportJobDone || done.Size != 8 || done.FinishedAt == nil || !done.FinishedAt.Equal(finishedAt) {
+		t.Errorf("FindByID() = %+v, want done with 8 bytes", done)
+	}
+	if result, err := repo.FindResult(ctx, "first"); err != nil || string(result) != "%PDF-1.4" {
+		t.Errorf("FindResult() = %q, %v", result, err)
+	}
+
+	// A stale job is reclaimed by another worker, and the first worker can no longer finish it
+	staleAt := claimAt.Add(20 * time.Minute)
+	reclaimed, err := repo.ClaimNext(ctx, staleAt, staleAt.Add(-10*time.Minute))
+	if err != nil || reclaimed == nil || reclaimed.ID != "second" {
+		t.Fatalf("ClaimNext() of a stale job = %+v, %v; want second", reclaimed, err)
+	}
+	if err := repo.Fail(ctx, "second", "Failed to generate PDF", staleAt); err != nil {
+		t.Fatalf("Fail() error: %v", err)
+	}
+	if err := repo.Finish(ctx, "second", []byte("late"), staleAt); err != nil {
+		t.Fatalf("Finish() error: %v", err)
+	}
+	failed, _ := repo.FindByID(ctx, "second")
+	if failed.Status != application.ExportJobFailed || failed.Error != "Failed to generate PDF" || failed.Size != 0 {
+		t.Errorf("FindByID() = %+v, want failed without a file", failed)
+	}
+
+	deleted, err := repo.DeleteFinishedBefore(ctx, finishedAt.Add(time.Second))
+	if err != nil || deleted != 1 {
+		t.Fatalf("DeleteFinishedBefore() = %d, %v; want 1", deleted, err)
+	}
+	if job, _ := repo.FindByID(ctx, "first"); job != nil {
+		t.Errorf("expired job still found: %+v", job)
+	}
+	if job, _ := repo.FindByID(ctx, "second"); job == nil {
+		t.Error("job finished after the cutoff was deleted")
+	}
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// sortableTimeLayout is a fixed-width UTC layout whose text order matches time order
+const sortableTimeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// SQLiteExportUsageRepository implements repository.ExportUsageRepository using SQLite
+type SQLiteExportUsageRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteExportUsageRepository creates a new SQLiteExportUsageRepository
+func NewSQLiteExportUsageRepository(db *sql.DB) *SQLiteExportUsageRepository {
+	return &SQLiteExportUsageRepository{db: db}
+}
+
+// CreateIfBelowLimit records an export using a single conditional insert, so concurrent
+// requests can't both take the last slot
+func (r *SQLiteExportUsageRepository) CreateIfBelowLimit(ctx context.Context, usage *application.ExportUsage, since time.Time, limit int) (bool, error) {
+	query := `INSERT INTO export_usage (id, user_id, kind, created_at)
+	          SELECT ?, ?, ?, ?
+	          WHERE (SELECT COUNT(*) FROM export_usage WHERE user_id = ? AND created_at > ?) < ?`
+
+	result, err := r.db.ExecContext(ctx, query,
+		usage.ID,
+		usage.UserID,
+		usage.Kind,
+		usage.CreatedAt.UTC().Format(sortableTimeLayout),
+		usage.UserID,
+		since.UTC().Format(sortableTimeLayout),
+		limit,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// FindOldestSince finds the oldest export of a user in the window using prepared statement
+func (r *SQLiteExportUsageRepository) FindOldestSince(ctx context.Context, userID string, since time.Time) (time.Time, error) {
+	query := `SELECT MIN(created_at) FROM export_usage WHERE user_id = ? AND created_at > ?`
+
+	var oldest sql.NullString
+	if err := r.db.QueryRowContext(ctx, query, userID, since.UTC().Format(sortableTimeLayout)).Scan(&oldest); err != nil {
+		return time.Time{}, err
+	}
+	if !oldest.Valid {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(sortableTimeLayout, oldest.String)
+}
+
+// Delete deletes an export record using prepared statement
+func (r *SQLiteExportUsageRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM export_usage WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// DeleteBefore deletes expired export records using prepared statement
+func (r *SQLiteExportUsageRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	query := `DELETE FROM export_usage WHERE created_at <= ?`
+	_, err := r.db.ExecContext(ctx, query, before.UTC().Format(sortableTimeLayout))
+	return err
+}
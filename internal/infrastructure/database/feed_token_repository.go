@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteFeedTokenRepository implements repository.FeedTokenRepository using SQLite
+type SQLiteFeedTokenRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteFeedTokenRepository creates a new SQLiteFeedTokenRepository
+func NewSQLiteFeedTokenRepository(db *sql.DB) *SQLiteFeedTokenRepository {
+	return &SQLiteFeedTokenRepository{db: db}
+}
+
+// Save stores the feed token of a user, replacing the one they had, using prepared statement
+func (r *SQLiteFeedTokenRepository) Save(ctx context.Context, token *application.FeedToken) error {
+	query := `INSERT OR REPLACE INTO feed_tokens (user_id, token_hash, created_at) VALUES (?, ?, ?)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		token.UserID,
+		token.TokenHash,
+		token.CreatedAt.UTC().Format(sortableTimeLayout),
+	)
+	return err
+}
+
+// FindByTokenHash finds a feed token by its hash using prepared statement
+func (r *SQLiteFeedTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*application.FeedToken, error) {
+	query := `SELECT user_id, token_hash, created_at FROM feed_tokens WHERE token_hash = ?`
+
+	var token application.FeedToken
+	var createdAt string
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(&token.UserID, &token.TokenHash, &createdAt)
+	if err == sql.ErrNoRows {
+		return nil, application.ErrFeedTokenNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if token.CreatedAt, err = time.Parse(sortableTimeLayout, createdAt); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Delete removes the feed token of a user using prepared statement
+func (r *SQLiteFeedTokenRepository) Delete(ctx context.Context, userID string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM feed_tokens WHERE user_id = ?`, userID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
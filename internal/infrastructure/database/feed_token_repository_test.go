@@ -0,0 +1,51 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteFeedTokenRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := NewSQLiteUserRepository(db).Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	repo := NewSQLiteFeedTokenRepository(db)
+	created := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	for _, hash := range []string{"hash-1", "hash-2"} {
+		if err := repo.Save(ctx, &application.FeedToken{UserID: "u-ana", TokenHash: hash, CreatedAt: created}); err != nil {
+			t.Fatalf("Save(%s) error: %v", hash, err)
+		}
+	}
+
+	// The second token replaced the first
+	if replaced, err := repo.FindByTokenHash(ctx, "hash-1"); !errors.Is(err, application.ErrFeedTokenNotFound) || replaced != nil {
+		t.Errorf("FindByTokenHash() of the replaced token = %v, %v; want nil, ErrFeedTokenNotFound", replaced, err)
+	}
+	token, err := repo.FindByTokenHash(ctx, "hash-2")
+	if err != nil || token == nil || token.UserID != "u-ana" || !token.CreatedAt.Equal(created) {
+		t.Fatalf("FindByTokenHash() = %+v, %v; want the token of u-ana", token, err)
+	}
+
+	if deleted, err := repo.Delete(ctx, "u-ana"); err != nil || !deleted {
+		t.Errorf("Delete() = %v, %v; want true", deleted, err)
+	}
+	if deleted, err := repo.Delete(ctx, "u-ana"); err != nil || deleted {
+		t.Errorf("Delete() without a token = %v, %v; want false", deleted, err)
+	}
+	if token, err := repo.FindByTokenHash(ctx, "hash-2"); !errors.Is(err, application.ErrFeedTokenNotFound) || token != nil {
+		t.Errorf("FindByTokenHash() after Delete = %v, %v; want nil, ErrFeedTokenNotFound", token, err)
+	}
+}
@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteIdempotencyRepository implements repository.IdempotencyRepository using SQLite
+type SQLiteIdempotencyRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteIdempotencyRepository creates a new SQLiteIdempotencyRepository
+func NewSQLiteIdempotencyRepository(db *sql.DB) *SQLiteIdempotencyRepository {
+	return &SQLiteIdempotencyRepository{db: db}
+}
+
+// Reserve stores the record of a request with a single upsert, so concurrent retries can't
+// both run it; the existing record is only replaced when it expired or went stale
+func (r *SQLiteIdempotencyRepository) Reserve(ctx context.Context, record *application.IdempotencyRecord, staleBefore time.Time) (*application.IdempotencyRecord, error) {
+	query := `INSERT INTO idempotency_keys (user_id, idempotency_key, request_hash, created_at, expires_at)
+	          VALUES (?, ?, ?, ?, ?)
+	          ON CONFLICT (user_id, idempotency_key) DO UPDATE SET
+	              request_hash = excluded.request_hash, status = 0, content_type = '', location = '', body = x'',
+	              created_at = excluded.created_at, expires_at = excluded.expires_at
+	          WHERE idempotency_keys.expires_at <= excluded.created_at
+	             OR (idempotency_keys.status = 0 AND idempotency_keys.created_at < ?)`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query,
+		record.UserID,
+		record.Key,
+		record.RequestHash,
+		record.CreatedAt.UTC().Format(sortableTimeLayout),
+		record.ExpiresAt.UTC().Format(sortableTimeLayout),
+		staleBefore.UTC().Format(sortableTimeLayout),
+	)
+	if err != nil {
+		return nil, err
+	}
+	reserved, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if reserved > 0 {
+		return nil, nil
+	}
+
+	existing, err := r.find(ctx, record.UserID, record.Key)
+	if err == sql.ErrNoRows {
+		// Deleted by the request holding the key since the upsert
+		return nil, application.ErrIdempotencyKeyInProgress
+	}
+	return existing, err
+}
+
+// Complete stores the response of a reserved request using prepared statement
+func (r *SQLiteIdempotencyRepository) Complete(ctx context.Context, record *application.IdempotencyRecord) error {
+	query := `UPDATE idempotency_keys SET status = ?, content_type = ?, location = ?, body = ?
+	          WHERE user_id = ? AND idempotency_key = ? AND status = 0`
+
+	body := record.Body
+	if body == nil {
+		body = []byte{}
+	}
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		record.Status,
+		record.ContentType,
+		record.Location,
+		body,
+		record.UserID,
+		record.Key,
+	)
+	return err
+}
+
+// Delete deletes the record of a key using prepared statement
+func (r *SQLiteIdempotencyRepository) Delete(ctx context.Context, userID, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE user_id = ? AND idempotency_key = ?`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, userID, key)
+	return err
+}
+
+// DeleteExpired deletes the expired records using prepared statement
+func (r *SQLiteIdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	query := `DELETE FROM idempotency_keys WHERE expires_at <= ?`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, now.UTC().Format(sortableTimeLayout))
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	return int(deleted), err
+}
+
+// find finds the record of a key using prepared statement
+func (r *SQLiteIdempotencyRepository) find(ctx context.Context, userID, key string) (*application.IdempotencyRecord, error) {
+	query := `SELECT user_id, idempotency_key, request_hash, status, content_type, location, body, created_at, expires_at
+	          FROM idempotency_keys WHERE user_id = ? AND idempotency_key = ?`
+
+	var record application.IdempotencyRecord
+	var createdAt, expiresAt string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, userID, key).Scan(
+		&record.UserID,
+		&record.Key,
+		&record.RequestHash,
+		&record.Status,
+		&record.ContentType,
+		&record.Location,
+		&record.Body,
+		&createdAt,
+		&expiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if record.CreatedAt, err = time.Parse(sortableTimeLayout, createdAt); err != nil {
+		return nil, err
+	}
+	if record.ExpiresAt, err = time.Parse(sortableTimeLayout, expiresAt); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteIdempotencyRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	for _, id := range []string{"u-ana", "u-bia"} {
+		if err := users.Create(ctx, &application.User{ID: id, Name: id, Email: id + "@example.com", CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	repo := NewSQLiteIdempotencyRepository(db)
+	start := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	record := func(userID, hash string, now time.Time) *application.IdempotencyRecord {
+		r, err := application.NewIdempotencyRecord(userID, "key-1", hash, now, time.Hour)
+		if err != nil {
+			t.Fatalf("NewIdempotencyRecord() error: %v", err)
+		}
+		return r
+	}
+	staleBefore := func(now time.Time) time.Time { return now.Add(-time.Minute) }
+
+	// The first request reserves the key; a retry meanwhile finds it running
+	if existing, err := repo.Reserve(ctx, record("u-ana", "hash-1", start), staleBefore(start)); err != nil || existing != nil {
+		t.Fatalf("Reserve() = %+v, %v; want nil, nil", existing, err)
+	}
+	retryAt := start.Add(10 * time.Second)
+	existing, err := repo.Reserve(ctx, record("u-ana", "hash-2", retryAt), staleBefore(retryAt))
+	if err != nil || existing == nil || existing.Answered() || existing.RequestHash != "hash-1" {
+		t.Fatalf("Reserve() while running = %+v, %v; want the running record of hash-1", existing, err)
+	}
+
+	// Keys are scoped per user
+	if existing, err := repo.Reserve(ctx, record("u-bia", "hash-1", start), staleBefore(start)); err != nil || existing != nil {
+		t.Fatalf("Reserve() by another user = %+v, %v; want nil, nil", existing, err)
+	}
+
+	// Once answered, retries get the stored response
+	answered := record("u-ana", "hash-1", start)
+	answered.Status = 201
+	answered.ContentType = "application/json"
+	answered.Location = "/api/tasks/task-1"
+	answered.Body = []byte(`{"id":"task-1"}`)
+	if err := repo.Complete(ctx, answered); err != nil {
+		t.Fatalf("Complete() error: %v", err)
+	}
+	existing, err = repo.Reserve(ctx, record("u-ana", "hash-1", retryAt), staleBefore(retryAt))
+	if err != nil || existing == nil || existing.Status != 201 || existing.ContentType != "application/json" ||
+		existing.Location != "/api/tasks/task-1" || string(existing.Body) != `{"id":"task-1"}` || !existing.ExpiresAt.Equal(start.Add(time.Hour)) {
+		t.Fatalf("Reserve() after Complete() = %+v, %v; want the stored response", existing, err)
+	}
+
+	// A request left running past staleBefore is taken over by the retry
+	staleAt := start.Add(5 * time.Minute)
+	if existing, err := repo.Reserve(ctx, record("u-bia", "hash-1", staleAt), staleBefore(staleAt)); err != nil || existing != nil {
+		t.Fatalf("Reserve() over a stale record = %+v, %v; want nil, nil", existing, err)
+	}
+
+	// Deleting the record frees the key right away
+	if err := repo.Delete(ctx, "u-bia", "key-1"); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if existing, err := repo.Reserve(ctx, record("u-bia", "hash-2", staleAt), staleBefore(staleAt)); err != nil || existing != nil {
+		t.Fatalf("Reserve() after Delete() = %+v, %v; want nil, nil", existing, err)
+	}
+
+	// Expired records are replaced when their key is used again, and deleted by DeleteExpired
+	expiredAt := start.Add(time.Hour)
+	if existing, err := repo.Reserve(ctx, record("u-ana", "hash-3", expiredAt), staleBefore(expiredAt)); err != nil || existing != nil {
+		t.Fatalf("Reserve() over an expired record = %+v, %v; want nil, nil", existing, err)
+	}
+	deleted, err := repo.DeleteExpired(ctx, staleAt.Add(time.Hour))
+	if err != nil || deleted != 1 {
+		t.Fatalf("DeleteExpired() = %d, %v; want the record of u-bia", deleted, err)
+	}
+	existing, err = repo.Reserve(ctx, record("u-ana", "hash-4", expiredAt), staleBefore(expiredAt))
+	if err != nil || existing == nil || existing.RequestHash != "hash-3" {
+		t.Errorf("Reserve() = %+v, %v; want the record of hash-3 kept", existing, err)
+	}
+}
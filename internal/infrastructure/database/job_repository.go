@@ -0,0 +1,197 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteJobRepository implements repository.JobRepository using SQLite
+type SQLiteJobRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteJobRepository creates a new SQLiteJobRepository
+func NewSQLiteJobRepository(db *sql.DB) *SQLiteJobRepository {
+	return &SQLiteJobRepository{db: db}
+}
+
+// jobColumns lists the columns scanned by scanJob
+const jobColumns = `id, type, payload, status, attempts, max_attempts, error, run_at, created_at, started_at, finished_at`
+
+// Create queues a new job using prepared statement
+func (r *SQLiteJobRepository) Create(ctx context.Context, job *application.Job) error {
+	query := `INSERT INTO jobs (id, type, payload, status, max_attempts, run_at, created_at)
+	          VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	payload := job.Payload
+	if payload == nil {
+		payload = []byte{}
+	}
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		job.ID,
+		job.Type,
+		payload,
+		string(job.Status),
+		job.MaxAttempts,
+		job.RunAt.UTC().Format(sortableTimeLayout),
+		job.CreatedAt.UTC().Format(sortableTimeLayout),
+	)
+	return err
+}
+
+// ClaimNext claims the oldest due or stale job with a single UPDATE using prepared statement,
+// so concurrent workers never claim the same job
+func (r *SQLiteJobRepository) ClaimNext(ctx context.Context, now, staleBefore time.Time) (*application.Job, error) {
+	query := `UPDATE jobs SET status = ?, attempts = attempts + 1, started_at = ?
+	          WHERE id = (
+	              SELECT id FROM jobs
+	              WHERE (status = ? AND run_at <= ?) OR (status = ? AND started_at < ?)
+	              ORDER BY run_at, created_at
+	              LIMIT 1
+	          )
+	          RETURNING ` + jobColumns
+
+	nowText := now.UTC().Format(sortableTimeLayout)
+	job, err := scanJob(conn(ctx, r.db).QueryRowContext(ctx, query,
+		string(application.JobRunning),
+		nowText,
+		string(application.JobPending),
+		nowText,
+		string(application.JobRunning),
+		staleBefore.UTC().Format(sortableTimeLayout),
+	))
+	if err == sql.ErrNoRows {
+		return nil, application.ErrJobNotFound
+	}
+	return job, err
+}
+
+// Finish marks a job as done using prepared statement
+func (r *SQLiteJobRepository) Finish(ctx context.Context, id string, finishedAt time.Time) error {
+	query := `UPDATE jobs SET status = ?, error = '', finished_at = ? WHERE id = ? AND status = ?`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		string(application.JobDone), finishedAt.UTC().Format(sortableTimeLayout),
+		id, string(application.JobRunning),
+	)
+	return err
+}
+
+// Retry puts a job back in the queue using prepared statement
+func (r *SQLiteJobRepository) Retry(ctx context.Context, id, message string, runAt time.Time) error {
+	query := `UPDATE jobs SET status = ?, error = ?, run_at = ? WHERE id = ? AND status = ?`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		string(application.JobPending), message, runAt.UTC().Format(sortableTimeLayout),
+		id, string(application.JobRunning),
+	)
+	return err
+}
+
+// Fail marks a job as failed using prepared statement
+func (r *SQLiteJobRepository) Fail(ctx context.Context, id, message string, finishedAt time.Time) error {
+	query := `UPDATE jobs SET status = ?, error = ?, finished_at = ? WHERE id = ? AND status = ?`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		string(application.JobFailed), message, finishedAt.UTC().Format(sortableTimeLayout),
+		id, string(application.JobRunning),
+	)
+	return err
+}
+
+// FindFailed finds the failed jobs using prepared statement
+func (r *SQLiteJobRepository) FindFailed(ctx context.Context, limit int) ([]*application.Job, error) {
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE status = ?
+	          ORDER BY finished_at DESC, id
+	          LIMIT ?`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, string(application.JobFailed), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []*application.Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
+
+// Requeue puts a failed job back in the queue using prepared statement
+func (r *SQLiteJobRepository) Requeue(ctx context.Context, id string, runAt time.Time) (*application.Job, error) {
+	query := `UPDATE jobs SET status = ?, attempts = 0, run_at = ?, started_at = NULL, finished_at = NULL
+	          WHERE id = ? AND status = ?
+	          RETURNING ` + jobColumns
+
+	job, err := scanJob(conn(ctx, r.db).QueryRowContext(ctx, query,
+		string(application.JobPending), runAt.UTC().Format(sortableTimeLayout),
+		id, string(application.JobFailed),
+	))
+	if err == sql.ErrNoRows {
+		return nil, application.ErrJobNotFound
+	}
+	return job, err
+}
+
+// DeleteDoneBefore deletes the expired done jobs using prepared statement
+func (r *SQLiteJobRepository) DeleteDoneBefore(ctx context.Context, before time.Time) (int, error) {
+	query := `DELETE FROM jobs WHERE status = ? AND finished_at < ?`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, string(application.JobDone), before.UTC().Format(sortableTimeLayout))
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	return int(deleted), err
+}
+
+// scanJob scans a row selected with jobColumns
+func scanJob(row rowScanner) (*application.Job, error) {
+	var job application.Job
+	var status, runAt, createdAt string
+	var startedAt, finishedAt sql.NullString
+
+	err := row.Scan(
+		&job.ID,
+		&job.Type,
+		&job.Payload,
+		&status,
+		&job.Attempts,
+		&job.MaxAttempts,
+		&job.Error,
+		&runAt,
+		&createdAt,
+		&startedAt,
+		&finishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	job.Status = application.JobStatus(status)
+	if job.RunAt, err = time.Parse(sortableTimeLayout, runAt); err != nil {
+		return nil, err
+	}
+	if job.CreatedAt, err = time.Parse(sortableTimeLayout, createdAt); err != nil {
+		return nil, err
+	}
+	if job.StartedAt, err = parseOptionalTime(startedAt); err != nil {
+		return nil, err
+	}
+	if job.FinishedAt, err = parseOptionalTime(finishedAt); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteJobRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewSQLiteJobRepository(db)
+	start := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	for i, id := range []string{"first", "second", "later"} {
+		job, err := application.NewJob(id, "send_email", []byte(`{"to":"ana@example.com"}`), 2, start.Add(time.Duration(i)*time.Second))
+		if err != nil {
+			t.Fatalf("NewJob() error: %v", err)
+		}
+		if id == "later" {
+			job.RunAt = start.Add(time.Hour)
+		}
+		if err := repo.Create(ctx, job); err != nil {
+			t.Fatalf("Create() error: %v", err)
+		}
+	}
+
+	// Due jobs are claimed oldest first, counting the attempt; jobs due later are left alone
+	claimAt := start.Add(time.Minute)
+	staleBefore := claimAt.Add(-10 * time.Minute)
+	claimed, err := repo.ClaimNext(ctx, claimAt, staleBefore)
+	if err != nil || claimed == nil || claimed.ID != "first" || claimed.Status != application.JobRunning || claimed.Attempts != 1 {
+		t.Fatalf("ClaimNext() = %+v, %v; want first running, attempt 1", claimed, err)
+	}
+	if string(claimed.Payload) != `{"to":"ana@example.com"}` || claimed.StartedAt == nil || !claimed.StartedAt.Equal(claimAt) {
+		t.Errorf("ClaimNext() = %+v, want the payload and the start of the run", claimed)
+	}
+	if next, _ := repo.ClaimNext(ctx, claimAt, staleBefore); next == nil || next.ID != "second" {
+		t.Fatalf("ClaimNext() = %+v, want second", next)
+	}
+	if next, err := repo.ClaimNext(ctx, claimAt, staleBefore); !errors.Is(err, application.ErrJobNotFound) || next != nil {
+		t.Fatalf("ClaimNext() with nothing due = %+v, %v; want nil, ErrJobNotFound", next, err)
+	}
+
+	// A failed run is retried once due again
+	if err := repo.Retry(ctx, "first", "smtp unavailable", claimAt.Add(time.Minute)); err != nil {
+		t.Fatalf("Retry() error: %v", err)
+	}
+	if next, _ := repo.ClaimNext(ctx, claimAt, staleBefore); next != nil {
+		t.Fatalf("ClaimNext() before the retry is due = %+v, want nil", next)
+	}
+	retryAt := claimAt.Add(2 * time.Minute)
+	retried, err := repo.ClaimNext(ctx, retryAt, staleBefore)
+	if err != nil || retried == nil || retried.ID != "first" || retried.Attempts != 2 || retried.Error != "smtp unavailable" {
+		t.Fatalf("ClaimNext() = %+v, %v; want first on attempt 2 with the last error", retried, err)
+	}
+	if err := repo.Fail(ctx, "first", "smtp unavailable", retryAt); err != nil {
+		t.Fatalf("Fail() error: %v", err)
+	}
+
+	// A running job whose worker went away is claimed again once stale
+	stale, err := repo.ClaimNext(ctx, claimAt.Add(time.Hour), claimAt.Add(time.Second))
+	if err != nil || stale == nil || stale.ID != "second" || stale.Attempts != 2 {
+		t.Fatalf("ClaimNext() of a stale job = %+v, %v; want second on attempt 2", stale, err)
+	}
+	doneAt := claimAt.Add(time.Hour)
+	if err := repo.Finish(ctx, "second", doneAt); err != nil {
+		t.Fatalf("Finish() error: %v", err)
+	}
+
+	failed, err := repo.FindFailed(ctx, 10)
+	if err != nil || len(failed) != 1 || failed[0].ID != "first" || failed[0].Status != application.JobFailed {
+		t.Fatalf("FindFailed() = %+v, %v; want first", failed, err)
+	}
+	if failed[0].FinishedAt == nil || !failed[0].FinishedAt.Equal(retryAt) {
+		t.Errorf("FinishedAt = %v, want %v", failed[0].FinishedAt, retryAt)
+	}
+
+	// Only failed jobs can be requeued, with their attempts reset
+	if _, err := repo.Requeue(ctx, "second", doneAt); !errors.Is(err, application.ErrJobNotFound) {
+		t.Errorf("Requeue() of a done job error = %v, want ErrJobNotFound", err)
+	}
+	if _, err := repo.Requeue(ctx, "missing", doneAt); !errors.Is(err, application.ErrJobNotFound) {
+		t.Errorf("Requeue() of a missing job error = %v, want ErrJobNotFound", err)
+	}
+	requeued, err := repo.Requeue(ctx, "first", doneAt)
+	if err != nil || requeued.Status != application.JobPending || requeued.Attempts != 0 || requeued.FinishedAt != nil || !requeued.RunAt.Equal(doneAt) {
+		t.Fatalf("Requeue() = %+v, %v; want first pending with no attempts, due now", requeued, err)
+	}
+	if failed, _ := repo.FindFailed(ctx, 10); len(failed) != 0 {
+		t.Errorf("FindFailed() after requeue = %+v, want none", failed)
+	}
+
+	// Only the done jobs finished before the cutoff are deleted
+	if deleted, err := repo.DeleteDoneBefore(ctx, doneAt); err != nil || deleted != 0 {
+		t.Errorf("DeleteDoneBefore() = %d, %v; want 0", deleted, err)
+	}
+	if deleted, err := repo.DeleteDoneBefore(ctx, doneAt.Add(time.Second)); err != nil || deleted != 1 {
+		t.Errorf("DeleteDoneBefore() = %d, %v; want 1", deleted, err)
+	}
+}
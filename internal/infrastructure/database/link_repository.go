@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteLinkRepository implements repository.LinkRepository using SQLite
+type SQLiteLinkRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteLinkRepository creates a new SQLiteLinkRepository
+func NewSQLiteLinkRepository(db *sql.DB) *SQLiteLinkRepository {
+	return &SQLiteLinkRepository{db: db}
+}
+
+// Save creates or updates a link check result using prepared statement
+func (r *SQLiteLinkRepository) Save(ctx context.Context, link *application.TaskLink) error {
+	query := `INSERT INTO task_links (task_id, url, status, status_code, checked_at)
+	          VALUES (?, ?, ?, ?, ?)
+	          ON CONFLICT(task_id, url) DO UPDATE SET
+	              status = excluded.status,
+	              status_code = excluded.status_code,
+	              checked_at = excluded.checked_at`
+
+	_, err := r.db.ExecContext(ctx, query,
+		link.TaskID,
+		link.URL,
+		string(link.Status),
+		link.StatusCode,
+		link.CheckedAt,
+	)
+	return err
+}
+
+// FindByTaskID finds all checked links of a task using prepared statement
+func (r *SQLiteLinkRepository) FindByTaskID(ctx context.Context, taskID string) ([]*application.TaskLink, error) {
+	query := `SELECT task_id, url, status, status_code, checked_at
+	          FROM task_links WHERE task_id = ?`
+
+	return r.queryLinks(ctx, query, taskID)
+}
+
+// FindBrokenByOwnerID finds broken links that still appear in the descriptions of a user's tasks
+func (r *SQLiteLinkRepository) FindBrokenByOwnerID(ctx context.Context, ownerID string) ([]*application.TaskLink, error) {
+	query := `SELECT tl.task_id, tl.url, tl.status, tl.status_code, tl.checked_at
+	          FROM task_links tl
+	          INNER JOIN tasks t ON t.id = tl.task_id
+	          WHERE t.owner_id = ? AND t.deleted_at IS NULL AND tl.status = 'broken' AND instr(t.description, tl.url) > 0
+	          ORDER BY tl.task_id, tl.url`
+
+	return r.queryLinks(ctx, query, ownerID)
+}
+
+// FindTasksWithLinks finds tasks whose description contains http(s) links using prepared statement,
+// the least recently checked first, so a run capped at some links picks up where the previous one
+// stopped: tasks never checked or edited since, which may have new links, then by the oldest check
+// of their links
+func (r *SQLiteLinkRepository) FindTasksWithLinks(ctx context.Context) ([]*application.Task, error) {
+	query := `SELECT t.id, t.title, t.description, t.status, t.owner_id, t.image_path, t.created_at, t.updated_at
+	          FROM tasks t
+	          LEFT JOIN (SELECT task_id, MIN(julianday(checked_at)) AS checked_at FROM task_links GROUP BY task_id) tl ON tl.task_id = t.id
+	          WHERE (t.description LIKE '%http://%' OR t.description LIKE '%https://%') AND t.deleted_at IS NULL
+	          ORDER BY CASE WHEN julianday(t.updated_at) > tl.checked_at THEN NULL ELSE tl.checked_at END NULLS FIRST, t.created_at`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*application.Task
+	for rows.Next() {
+		var task application.Task
+		var status string
+		var createdAt, updatedAt string
+		var description, imagePath sql.NullString
+
+		err := rows.Scan(
+			&task.ID,
+			&task.Title,
+			&description,
+			&status,
+			&task.OwnerID,
+			&imagePath,
+			&createdAt,
+			&updatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		task.Status = application.TaskStatus(status)
+		task.Description = description.String
+		task.ImagePath = imagePath.String
+		task.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		task.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+
+		tasks = append(tasks, &task)
+	}
+
+	return tasks, rows.Err()
+}
+
+// queryLinks runs a link query and scans the result rows
+func (r *SQLiteLinkRepository) queryLinks(ctx context.Context, query string, args ...interface{}) ([]*application.TaskLink, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []*application.TaskLink
+	for rows.Next() {
+		var link application.TaskLink
+		var status, checkedAt string
+
+		if err := rows.Scan(&link.TaskID, &link.URL, &status, &link.StatusCode, &checkedAt); err != nil {
+			return nil, err
+		}
+
+		link.Status = application.LinkStatus(status)
+		link.CheckedAt, _ = time.Parse(time.RFC3339, checkedAt)
+
+		links = append(links, &link)
+	}
+
+	return links, rows.Err()
+}
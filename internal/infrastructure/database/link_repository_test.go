@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteLinkRepository_FindTasksWithLinks(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := NewSQLiteUserRepository(db).Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	taskRepo := NewSQLiteTaskRepository(db)
+	created := time.Now().Add(-time.Hour)
+	for i, id := range []string{"a", "b", "c", "d", "e"} {
+		description := "Ver https://" + id + ".example.com"
+		if id == "e" {
+			description = "Sem links"
+		}
+		task, err := application.NewTask(id, "Tarefa "+id, description, application.StatusPending, "u-ana", "")
+		if err != nil {
+			t.Fatalf("NewTask() error: %v", err)
+		}
+		task.CreatedAt = created.Add(time.Duration(i) * time.Minute)
+		task.UpdatedAt = task.CreatedAt
+		if id == "d" {
+			// Edited after its link was checked, so it may have new ones
+			task.UpdatedAt = time.Now().Add(2 * time.Minute)
+		}
+		if err := taskRepo.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	// d was checked last, but edited since; a was checked before it, then c; b was never checked
+	repo := NewSQLiteLinkRepository(db)
+	checked := time.Now()
+	for id, at := range map[string]time.Time{"a": checked, "c": checked.Add(-time.Minute), "d": checked.Add(30 * time.Second)} {
+		link, err := application.NewTaskLink(id, "https://"+id+".example.com", application.LinkStatusOK, 200)
+		if err != nil {
+			t.Fatalf("NewTaskLink() error: %v", err)
+		}
+		link.CheckedAt = at
+		if err := repo.Save(ctx, link); err != nil {
+			t.Fatalf("Save() error: %v", err)
+		}
+	}
+
+	tasks, err := repo.FindTasksWithLinks(ctx)
+	if err != nil {
+		t.Fatalf("FindTasksWithLinks() error: %v", err)
+	}
+	var got []string
+	for _, task := range tasks {
+		got = append(got, task.ID)
+	}
+	want := []string{"b", "d", "c", "a"}
+	if len(got) != len(want) {
+		t.Fatalf("FindTasksWithLinks() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("FindTasksWithLinks() = %v, want the never checked or edited since first, then the least recently checked: %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteLoginEventRepository implements repository.LoginEventRepository using SQLite
+type SQLiteLoginEventRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteLoginEventRepository creates a new SQLiteLoginEventRepository
+func NewSQLiteLoginEventRepository(db *sql.DB) *SQLiteLoginEventRepository {
+	return &SQLiteLoginEventRepository{db: db}
+}
+
+// loginEventColumns lists the columns scanned by scanLoginEvent
+const loginEventColumns = `id, user_id, email, ip, user_agent, success, created_at`
+
+// Create records a login attempt using prepared statement
+func (r *SQLiteLoginEventRepository) Create(ctx context.Context, event *application.LoginEvent) error {
+	query := `INSERT INTO login_events (` + loginEventColumns + `)
+	          VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	var userID sql.NullString
+	if event.UserID != "" {
+		userID = sql.NullString{String: event.UserID, Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		event.ID,
+		userID,
+		event.Email,
+		event.IP,
+		event.UserAgent,
+		event.Success,
+		event.CreatedAt.UTC().Format(sortableTimeLayout),
+	)
+	return err
+}
+
+// FindByUserID finds the most recent login attempts of a user using prepared statement
+func (r *SQLiteLoginEventRepository) FindByUserID(ctx context.Context, userID string, limit int) ([]*application.LoginEvent, error) {
+	query := `SELECT ` + loginEventColumns + `
+	          FROM login_events WHERE user_id = ?
+	          ORDER BY created_at DESC LIMIT ?`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*application.LoginEvent
+	for rows.Next() {
+		event, err := scanLoginEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// FindLastSuccessful finds the most recent successful login of a user using prepared statement
+func (r *SQLiteLoginEventRepository) FindLastSuccessful(ctx context.Context, userID string) (*application.LoginEvent, error) {
+	query := `SELECT ` + loginEventColumns + `
+	          FROM login_events WHERE user_id = ? AND success = 1
+	          ORDER BY created_at DESC LIMIT 1`
+
+	event, err := scanLoginEvent(r.db.QueryRowContext(ctx, query, userID))
+	if err == sql.ErrNoRows {
+		return nil, application.ErrLoginEventNotFound
+	}
+	return event, err
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanLoginEvent scans a row selected with loginEventColumns
+func scanLoginEvent(row rowScanner) (*application.LoginEvent, error) {
+	var event application.LoginEvent
+	var userID sql.NullString
+	var createdAt string
+
+	err := row.Scan(
+		&event.ID,
+		&userID,
+		&event.Email,
+		&event.IP,
+		&event.UserAgent,
+		&event.Success,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	event.UserID = userID.String
+	event.CreatedAt, err = time.Parse(sortableTimeLayout, createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteLoginEventRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := NewSQLiteUserRepository(db).Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	repo := NewSQLiteLoginEventRepository(db)
+
+	last, err := repo.FindLastSuccessful(ctx, "u-ana")
+	if !errors.Is(err, application.ErrLoginEventNotFound) || last != nil {
+		t.Fatalf("FindLastSuccessful() without logins = %v, %v; want nil, ErrLoginEventNotFound", last, err)
+	}
+
+	base := time.Now().Add(-time.Hour)
+	for i, e := range []struct {
+		id      string
+		userID  string
+		success bool
+	}{
+		{"login-1", "u-ana", true},
+		{"login-2", "u-ana", false},
+		{"login-3", "", false},
+		{"login-4", "u-ana", true},
+		{"login-5", "u-ana", false},
+	} {
+		event, err := application.NewLoginEvent(e.id, e.userID, "ana@example.com", "10.0.0.1", "Mozilla/5.0", e.success)
+		if err != nil {
+			t.Fatalf("NewLoginEvent() error: %v", err)
+		}
+		event.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		if err := repo.Create(ctx, event); err != nil {
+			t.Fatalf("Create() error: %v", err)
+		}
+	}
+
+	events, err := repo.FindByUserID(ctx, "u-ana", 3)
+	if err != nil {
+		t.Fatalf("FindByUserID() error: %v", err)
+	}
+	var ids []string
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+	if want := []string{"login-5", "login-4", "login-2"}; len(ids) != len(want) || ids[0] != want[0] || ids[1] != want[1] || ids[2] != want[2] {
+		t.Errorf("FindByUserID() = %v, want %v", ids, want)
+	}
+
+	last, err = repo.FindLastSuccessful(ctx, "u-ana")
+	if err != nil {
+		t.Fatalf("FindLastSuccessful() error: %v", err)
+	}
+	if last == nil || last.ID != "login-4" {
+		t.Fatalf("FindLastSuccessful() = %+v, want login-4", last)
+	}
+	if !last.Success || last.IP != "10.0.0.1" || last.UserAgent != "Mozilla/5.0" {
+		t.Errorf("FindLastSuccessful() fields = %+v", last)
+	}
+	if !last.CreatedAt.Equal(base.Add(3 * time.Minute)) {
+		t.Errorf("CreatedAt = %v, want %v", last.CreatedAt, base.Add(3*time.Minute))
+	}
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// columnMigration adds a column to a table created by an older schema version
+type columnMigration struct {
+	table      string
+	column     string
+	definition string
+}
+
+// columnMigrations lists columns added after the initial schema. CREATE TABLE IF NOT EXISTS
+// doesn't alter existing tables, so these are applied to databases created before them.
+var columnMigrations = []columnMigration{
+	{table: "tasks", column: "due_date", definition: "DATETIME"},
+	{table: "users", column: "role", definition: "TEXT NOT NULL DEFAULT 'member'"},
+	{table: "users", column: "unit", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: "users", column: "theme", definition: "TEXT NOT NULL DEFAULT 'light'"},
+	{table: "users", column: "locale", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: "tasks", column: "project_id", definition: "TEXT REFERENCES projects(id) ON DELETE SET NULL"},
+	{table: "users", column: "overdue_opt_out", definition: "INTEGER NOT NULL DEFAULT 0"},
+	{table: "tasks", column: "overdue_at", definition: "DATETIME"},
+	{table: "tasks", column: "priority", definition: "TEXT NOT NULL DEFAULT 'normal'"},
+	{table: "tasks", column: "tags", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: "task_shares", column: "status", definition: "TEXT NOT NULL DEFAULT 'accepted' CHECK(status IN ('pending', 'accepted'))"},
+	{table: "users", column: "avatar_path", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: "users", column: "disabled", definition: "INTEGER NOT NULL DEFAULT 0"},
+	{table: "users", column: "token_version", definition: "INTEGER NOT NULL DEFAULT 0"},
+	{table: "tasks", column: "deleted_at", definition: "TEXT"},
+	{table: "tasks", column: "color", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: "tasks", column: "snoozed_until", definition: "DATETIME"},
+	{table: "tasks", column: "progress", definition: "INTEGER NOT NULL DEFAULT 0 CHECK(progress BETWEEN 0 AND 100)"},
+	{table: "tasks", column: "completion_note", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: "tasks", column: "org_id", definition: "TEXT REFERENCES orgs(id) ON DELETE SET NULL"},
+	{table: "projects", column: "org_id", definition: "TEXT REFERENCES orgs(id) ON DELETE SET NULL"},
+	{table: "user_settings", column: "notify_comment_mention", definition: "INTEGER NOT NULL DEFAULT 1"},
+	{table: "export_jobs", column: "org_id", definition: "TEXT REFERENCES orgs(id) ON DELETE CASCADE"},
+}
+
+// indexMigrations creates indexes on migrated columns, which can't live in schema.sql
+// because it runs before the columns exist on older databases
+var indexMigrations = []string{
+	`CREATE INDEX IF NOT EXISTS idx_users_unit ON users(unit)`,
+	`CREATE INDEX IF NOT EXISTS idx_tasks_due_date ON tasks(due_date)`,
+	`CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks(project_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_tasks_org_id ON tasks(org_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_projects_org_id ON projects(org_id)`,
+}
+
+// migrate applies pending column and index migrations
+func migrate(db *sql.DB) error {
+	for _, m := range columnMigrations {
+		exists, err := columnExists(db, m.table, m.column)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		// Table and column names come from the static list above, never from user input
+		stmt := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", m.table, m.column, m.definition)
+		if _, err := db.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to add column %s.%s: %w", m.table, m.column, err)
+		}
+	}
+
+	for _, stmt := range indexMigrations {
+		if _, err := db.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to create index: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// columnExists checks whether a table has a column
+func columnExists(db *sql.DB, table, column string) (bool, error) {
+	var count int
+	err := db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, table, column).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteNotificationRepository implements repository.NotificationRepository using SQLite
+type SQLiteNotificationRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteNotificationRepository creates a new SQLiteNotificationRepository
+func NewSQLiteNotificationRepository(db *sql.DB) *SQLiteNotificationRepository {
+	return &SQLiteNotificationRepository{db: db}
+}
+
+// Create creates a new notification using prepared statement
+func (r *SQLiteNotificationRepository) Create(ctx context.Context, notification *application.Notification) error {
+	query := `INSERT INTO notifications (id, user_id, type, task_id, message, read, created_at)
+	          VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	var taskID sql.NullString
+	if notification.TaskID != "" {
+		taskID = sql.NullString{String: notification.TaskID, Valid: true}
+	}
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		notification.ID,
+		notification.UserID,
+		string(notification.Type),
+		taskID,
+		notification.Message,
+		notification.Read,
+		notification.CreatedAt,
+	)
+	return err
+}
+
+// FindByUserID finds the most recent notifications of a user using prepared statement
+func (r *SQLiteNotificationRepository) FindByUserID(ctx context.Context, userID string, limit int) ([]*application.Notification, error) {
+	query := `SELECT id, user_id, type, task_id, message, read, created_at
+	          FROM notifications WHERE user_id = ?
+	          ORDER BY created_at DESC LIMIT ?`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []*application.Notification
+	for rows.Next() {
+		var notification application.Notification
+		var notificationType, createdAt string
+		var taskID sql.NullString
+
+		err := rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&notificationType,
+			&taskID,
+			&notification.Message,
+			&notification.Read,
+			&createdAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		notification.Type = application.NotificationType(notificationType)
+		notification.TaskID = taskID.String
+		notification.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+
+		notifications = append(notifications, &notification)
+	}
+
+	return notifications, rows.Err()
+}
+
+// MarkAsRead marks a notification of a user as read using prepared statement
+func (r *SQLiteNotificationRepository) MarkAsRead(ctx context.Context, id, userID string) error {
+	query := `UPDATE notifications SET read = 1 WHERE id = ? AND user_id = ?`
+	_, err := r.db.ExecContext(ctx, query, id, userID)
+	return err
+}
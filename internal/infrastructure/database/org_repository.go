@@ -0,0 +1,204 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// SQLiteOrgRepository implements repository.OrgRepository using SQLite
+type SQLiteOrgRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteOrgRepository creates a new SQLiteOrgRepository
+func NewSQLiteOrgRepository(db *sql.DB) *SQLiteOrgRepository {
+	return &SQLiteOrgRepository{db: db}
+}
+
+// orgColumns lists the columns scanned by scanOrg
+const orgColumns = `orgs.id, orgs.name, orgs.share_tasks, orgs.created_at`
+
+// sharedOrgIDs selects the orgs whose tasks are shared with all their members, among those of a
+// user; its argument is the user ID
+const sharedOrgIDs = `SELECT org_members.org_id FROM org_members
+	                  INNER JOIN orgs ON orgs.id = org_members.org_id
+	                  WHERE org_members.user_id = ? AND orgs.share_tasks = 1`
+
+// orgScopeCondition keeps the tasks of the workspace a task list is scoped to, or every task
+// when it isn't scoped. Its arguments come from orgScopeArgs.
+const orgScopeCondition = `(? = 0 OR tasks.org_id IS ?)`
+
+// projectOrgScopeCondition is orgScopeCondition for project lists
+const projectOrgScopeCondition = `(? = 0 OR projects.org_id IS ?)`
+
+// orgScopeArgs returns the arguments of orgScopeCondition for the workspace of ctx
+func orgScopeArgs(ctx context.Context) []any {
+	orgID, scoped := repository.OrgScope(ctx)
+	return []any{scoped, nullableID(orgID)}
+}
+
+// Create creates a new org using prepared statement
+func (r *SQLiteOrgRepository) Create(ctx context.Context, org *application.Org) error {
+	query := `INSERT INTO orgs (id, name, share_tasks, created_at) VALUES (?, ?, ?, ?)`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		org.ID,
+		org.Name,
+		org.ShareTasks,
+		org.CreatedAt.UTC().Format(sortableTimeLayout),
+	)
+	return err
+}
+
+// Update updates the name and the sharing default of an org using prepared statement
+func (r *SQLiteOrgRepository) Update(ctx context.Context, org *application.Org) error {
+	query := `UPDATE orgs SET name = ?, share_tasks = ? WHERE id = ?`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, org.Name, org.ShareTasks, org.ID)
+	return err
+}
+
+// FindByID finds an org by ID using prepared statement
+func (r *SQLiteOrgRepository) FindByID(ctx context.Context, id string) (*application.Org, error) {
+	query := `SELECT ` + orgColumns + ` FROM orgs WHERE id = ?`
+
+	org, err := scanOrg(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, application.ErrOrgNotFound
+	}
+	return org, err
+}
+
+// FindByUserID finds the orgs of a user with their role using prepared statement
+func (r *SQLiteOrgRepository) FindByUserID(ctx context.Context, userID string) ([]*application.OrgMembership, error) {
+	query := `SELECT org_members.role, ` + orgColumns + `
+	          FROM org_members
+	          INNER JOIN orgs ON orgs.id = org_members.org_id
+	          WHERE org_members.user_id = ?
+	          ORDER BY orgs.name COLLATE NOCASE, orgs.created_at`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var memberships []*application.OrgMembership
+	for rows.Next() {
+		var role string
+		org, err := scanOrg(prefixedScanner{row: rows, prefix: []any{&role}})
+		if err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, &application.OrgMembership{Org: org, Role: application.OrgRole(role)})
+	}
+
+	return memberships, rows.Err()
+}
+
+// FindMember finds the membership of a user in an org using prepared statement
+func (r *SQLiteOrgRepository) FindMember(ctx context.Context, orgID, userID string) (*application.OrgMember, error) {
+	query := `SELECT org_members.org_id, org_members.user_id, users.name, users.email, org_members.role, org_members.joined_at
+	          FROM org_members
+	          INNER JOIN users ON users.id = org_members.user_id
+	          WHERE org_members.org_id = ? AND org_members.user_id = ?`
+
+	member, err := scanOrgMember(conn(ctx, r.db).QueryRowContext(ctx, query, orgID, userID))
+	if err == sql.ErrNoRows {
+		return nil, application.ErrOrgMemberNotFound
+	}
+	return member, err
+}
+
+// FindMembers finds the members of an org using prepared statement
+func (r *SQLiteOrgRepository) FindMembers(ctx context.Context, orgID string) ([]*application.OrgMember, error) {
+	query := `SELECT org_members.org_id, org_members.user_id, users.name, users.email, org_members.role, org_members.joined_at
+	          FROM org_members
+	          INNER JOIN users ON users.id = org_members.user_id
+	          WHERE org_members.org_id = ?
+	          ORDER BY users.name COLLATE NOCASE, users.email`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*application.OrgMember
+	for rows.Next() {
+		member, err := scanOrgMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	return members, rows.Err()
+}
+
+// SaveMember adds a member to an org, or changes the role of a member, using prepared statement
+func (r *SQLiteOrgRepository) SaveMember(ctx context.Context, member *application.OrgMember) error {
+	query := `INSERT INTO org_members (org_id, user_id, role, joined_at) VALUES (?, ?, ?, ?)
+	          ON CONFLICT (org_id, user_id) DO UPDATE SET role = excluded.role`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		member.OrgID,
+		member.UserID,
+		string(member.Role),
+		member.JoinedAt.UTC().Format(sortableTimeLayout),
+	)
+	return err
+}
+
+// RemoveMember removes a member from an org using prepared statement
+func (r *SQLiteOrgRepository) RemoveMember(ctx context.Context, orgID, userID string) error {
+	query := `DELETE FROM org_members WHERE org_id = ? AND user_id = ?`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, orgID, userID)
+	return err
+}
+
+// CountOwners counts the owners of an org using prepared statement
+func (r *SQLiteOrgRepository) CountOwners(ctx context.Context, orgID string) (int, error) {
+	query := `SELECT COUNT(*) FROM org_members WHERE org_id = ? AND role = ?`
+
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, orgID, string(application.OrgRoleOwner)).Scan(&count)
+	return count, err
+}
+
+// scanOrg scans a row holding orgColumns
+func scanOrg(row rowScanner) (*application.Org, error) {
+	var org application.Org
+	var createdAt string
+
+	if err := row.Scan(&org.ID, &org.Name, &org.ShareTasks, &createdAt); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if org.CreatedAt, err = time.Parse(sortableTimeLayout, createdAt); err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+// scanOrgMember scans a row of a member with their name and email
+func scanOrgMember(row rowScanner) (*application.OrgMember, error) {
+	var member application.OrgMember
+	var role, joinedAt string
+
+	if err := row.Scan(&member.OrgID, &member.UserID, &member.Name, &member.Email, &role, &joinedAt); err != nil {
+		return nil, err
+	}
+
+	member.Role = application.OrgRole(role)
+	var err error
+	if member.JoinedAt, err = time.Parse(sortableTimeLayout, joinedAt); err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
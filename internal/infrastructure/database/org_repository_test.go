@@ -0,0 +1,267 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+func TestSQLiteOrgRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	for _, user := range []*application.User{
+		{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()},
+		{ID: "u-bia", Name: "Bia", Email: "bia@example.com", CreatedAt: time.Now()},
+	} {
+		if err := users.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	repo := NewSQLiteOrgRepository(db)
+	created := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	for _, name := range []string{"Receita", "Diretoria"} {
+		org, _ := application.NewOrg("o-"+name, name, created)
+		if err := repo.Create(ctx, org); err != nil {
+			t.Fatalf("Create() error: %v", err)
+		}
+		if err := repo.SaveMember(ctx, &application.OrgMember{OrgID: org.ID, UserID: "u-ana", Role: application.OrgRoleOwner, JoinedAt: created}); err != nil {
+			t.Fatalf("SaveMember() error: %v", err)
+		}
+	}
+
+	org, err := repo.FindByID(ctx, "o-Receita")
+	if err != nil || org.Name != "Receita" || !org.ShareTasks || !org.CreatedAt.Equal(created) {
+		t.Fatalf("FindByID() = %+v, %v", org, err)
+	}
+	org.Update("Receita Federal", false)
+	if err := repo.Update(ctx, org); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	if org, _ := repo.FindByID(ctx, "o-Receita"); org.Name != "Receita Federal" || org.ShareTasks {
+		t.Errorf("FindByID() after Update = %+v", org)
+	}
+	if _, err := repo.FindByID(ctx, "o-missing"); !errors.Is(err, application.ErrOrgNotFound) {
+		t.Errorf("FindByID() of an unknown org error = %v, want ErrOrgNotFound", err)
+	}
+
+	memberships, err := repo.FindByUserID(ctx, "u-ana")
+	if err != nil || len(memberships) != 2 || memberships[0].Org.Name != "Diretoria" || memberships[0].Role != application.OrgRoleOwner {
+		t.Fatalf("FindByUserID() = %+v, %v; want both orgs by name", memberships, err)
+	}
+
+	// Saving a member again changes their role
+	for _, role := range []application.OrgRole{application.OrgRoleMember, application.OrgRoleAdmin} {
+		if err := repo.SaveMember(ctx, &application.OrgMember{OrgID: "o-Receita", UserID: "u-bia", Role: role, JoinedAt: created}); err != nil {
+			t.Fatalf("SaveMember() error: %v", err)
+		}
+	}
+	member, err := repo.FindMember(ctx, "o-Receita", "u-bia")
+	if err != nil || member.Role != application.OrgRoleAdmin {
+		t.Fatalf("FindMember() = %+v, %v; want admin", member, err)
+	}
+	members, err := repo.FindMembers(ctx, "o-Receita")
+	if err != nil || len(members) != 2 || members[0].Name != "Ana" || members[1].Email != "bia@example.com" {
+		t.Fatalf("FindMembers() = %+v, %v", members, err)
+	}
+	if owners, err := repo.CountOwners(ctx, "o-Receita"); err != nil || owners != 1 {
+		t.Errorf("CountOwners() = %d, %v; want 1", owners, err)
+	}
+
+	if err := repo.RemoveMember(ctx, "o-Receita", "u-bia"); err != nil {
+		t.Fatalf("RemoveMember() error: %v", err)
+	}
+	if _, err := repo.FindMember(ctx, "o-Receita", "u-bia"); !errors.Is(err, application.ErrOrgMemberNotFound) {
+		t.Errorf("FindMember() after RemoveMember error = %v, want ErrOrgMemberNotFound", err)
+	}
+}
+
+func TestSQLiteOrgRepository_ScopesTaskLists(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	for _, user := range []*application.User{
+		{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()},
+		{ID: "u-bia", Name: "Bia", Email: "bia@example.com", CreatedAt: time.Now()},
+		{ID: "u-caio", Name: "Caio", Email: "caio@example.com", CreatedAt: time.Now()},
+	} {
+		if err := users.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	orgs := NewSQLiteOrgRepository(db)
+	org, _ := application.NewOrg("o-1", "Receita", time.Now())
+	if err := orgs.Create(ctx, org); err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	for _, userID := range []string{"u-ana", "u-bia"} {
+		if err := orgs.SaveMember(ctx, &application.OrgMember{OrgID: "o-1", UserID: userID, Role: application.OrgRoleMember, JoinedAt: time.Now()}); err != nil {
+			t.Fatalf("SaveMember() error: %v", err)
+		}
+	}
+
+	// t-1 is Ana's personal task, t-2 hers in the org and t-3 Bia's in the org
+	tasks := NewSQLiteTaskRepository(db)
+	for i, spec := range []struct{ id, owner, org string }{
+		{"t-1", "u-ana", ""},
+		{"t-2", "u-ana", "o-1"},
+		{"t-3", "u-bia", "o-1"},
+	} {
+		task, _ := application.NewTask(spec.id, spec.id, "", application.StatusPending, spec.owner, "")
+		task.CreatedAt = time.Now().Add(time.Duration(i) * time.Minute)
+		task.OrgID = spec.org
+		if err := tasks.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+	if task, _ := tasks.FindByID(ctx, "t-2"); task.OrgID != "o-1" {
+		t.Errorf("FindByID() org = %q, want o-1", task.OrgID)
+	}
+
+	views := NewSQLiteTaskViewRepository(db)
+	overviews := NewSQLiteTaskOverviewQuery(db)
+	shares := NewSQLiteShareRepository(db)
+	now := time.Now()
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		userID string
+		owned  []string
+		shared []string
+	}{
+		{"unscoped", ctx, "u-ana", []string{"t-1", "t-2"}, []string{"t-3"}},
+		{"personal", repository.WithOrgScope(ctx, ""), "u-ana", []string{"t-1"}, nil},
+		{"org", repository.WithOrgScope(ctx, "o-1"), "u-ana", []string{"t-2"}, []string{"t-3"}},
+		{"outsider", repository.WithOrgScope(ctx, "o-1"), "u-caio", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owned, err := tasks.FindByOwnerID(tt.ctx, tt.userID)
+			if err != nil {
+				t.Fatalf("FindByOwnerID() error: %v", err)
+			}
+			if got := taskIDs(owned); !sameIDs(got, tt.owned) {
+				t.Errorf("FindByOwnerID() = %v, want %v", got, tt.owned)
+			}
+
+			shared, err := tasks.FindSharedWithUser(tt.ctx, tt.userID)
+			if err != nil {
+				t.Fatalf("FindSharedWithUser() error: %v", err)
+			}
+			if got := taskIDs(shared); !sameIDs(got, tt.shared) {
+				t.Errorf("FindSharedWithUser() = %v, want %v", got, tt.shared)
+			}
+
+			sharedViews, total, err := views.FindSharedWithUser(tt.ctx, tt.userID, application.TaskListQuery{Sort: application.SortNewest, Page: 1, PerPage: 10})
+			if err != nil || total != len(tt.shared) || len(sharedViews) != len(tt.shared) {
+				t.Errorf("task views FindSharedWithUser() = %d views of %d, %v; want %d", len(sharedViews), total, err, len(tt.shared))
+			}
+
+			counters, err := views.CountForUser(tt.ctx, tt.userID, now)
+			if err != nil || counters.Pending != len(tt.owned) || counters.Shared != len(tt.shared) {
+				t.Errorf("CountForUser() = %+v, %v", counters, err)
+			}
+
+			overview, err := overviews.FindOverview(tt.ctx, tt.userID, now, application.SortNewest, nil, 10)
+			if err != nil {
+				t.Fatalf("FindOverview() error: %v", err)
+			}
+			if got, want := len(overview.Items), len(tt.owned)+len(tt.shared); got != want {
+				t.Errorf("FindOverview() = %d items, want %d", got, want)
+			}
+		})
+	}
+
+	// Project lists are scoped like the task lists: p-1 is Ana's personal project, p-2 hers in the
+	// org and p-3 Bia's in the org, shared with Ana
+	projects := NewSQLiteProjectRepository(db)
+	for _, spec := range []struct{ id, owner, org string }{
+		{"p-1", "u-ana", ""},
+		{"p-2", "u-ana", "o-1"},
+		{"p-3", "u-bia", "o-1"},
+	} {
+		project, _ := application.NewProject(spec.id, spec.id, "", spec.owner)
+		project.OrgID = spec.org
+		if err := projects.Create(ctx, project); err != nil {
+			t.Fatalf("Failed to create project: %v", err)
+		}
+	}
+	if err := projects.Share(ctx, "p-3", "u-ana"); err != nil {
+		t.Fatalf("Share() error: %v", err)
+	}
+	if project, _ := projects.FindByID(ctx, "p-2"); project.OrgID != "o-1" {
+		t.Errorf("project FindByID() org = %q, want o-1", project.OrgID)
+	}
+	for _, tt := range []struct {
+		name          string
+		ctx           context.Context
+		owned, shared []string
+	}{
+		{"unscoped", ctx, []string{"p-1", "p-2"}, []string{"p-3"}},
+		{"personal", repository.WithOrgScope(ctx, ""), []string{"p-1"}, nil},
+		{"org", repository.WithOrgScope(ctx, "o-1"), []string{"p-2"}, []string{"p-3"}},
+	} {
+		owned, err := projects.FindByOwnerID(tt.ctx, "u-ana")
+		if err != nil {
+			t.Fatalf("%s: project FindByOwnerID() error: %v", tt.name, err)
+		}
+		shared, err := projects.FindSharedWithUser(tt.ctx, "u-ana")
+		if err != nil {
+			t.Fatalf("%s: project FindSharedWithUser() error: %v", tt.name, err)
+		}
+		if got := projectIDs(owned); !sameIDs(got, tt.owned) {
+			t.Errorf("%s: project FindByOwnerID() = %v, want %v", tt.name, got, tt.owned)
+		}
+		if got := projectIDs(shared); !sameIDs(got, tt.shared) {
+			t.Errorf("%s: project FindSharedWithUser() = %v, want %v", tt.name, got, tt.shared)
+		}
+	}
+
+	// Members can open the tasks of the org; once it stops sharing them, only explicit shares count
+	if shared, err := shares.IsSharedWith(ctx, "t-3", "u-ana"); err != nil || !shared {
+		t.Errorf("IsSharedWith() = %v, %v; want the org task shared with its member", shared, err)
+	}
+	if shared, _ := shares.IsSharedWith(ctx, "t-3", "u-caio"); shared {
+		t.Error("IsSharedWith() = true for a user outside the org")
+	}
+	org.Update(org.Name, false)
+	if err := orgs.Update(ctx, org); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	if shared, _ := shares.IsSharedWith(ctx, "t-3", "u-ana"); shared {
+		t.Error("IsSharedWith() = true after the org stopped sharing its tasks")
+	}
+}
+
+// projectIDs returns the IDs of projects
+func projectIDs(projects []*application.Project) []string {
+	var ids []string
+	for _, project := range projects {
+		ids = append(ids, project.ID)
+	}
+	return ids
+}
+
+// sameIDs reports whether two lists hold the same IDs, in any order
+func sameIDs(got, want []string) bool {
+	got, want = slices.Sorted(slices.Values(got)), slices.Sorted(slices.Values(want))
+	return slices.Equal(got, want)
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteOverdueRepository implements repository.OverdueRepository using SQLite
+type SQLiteOverdueRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteOverdueRepository creates a new SQLiteOverdueRepository
+func NewSQLiteOverdueRepository(db *sql.DB) *SQLiteOverdueRepository {
+	return &SQLiteOverdueRepository{db: db}
+}
+
+// FlagOverdue flags a batch of overdue pending tasks with a single UPDATE using prepared
+// statement, then loads the flagged tasks. Flagging changes the task, so it bumps updated_at
+// like any other change, which the ETags of the task routes rely on. Due dates keep the
+// offset they were entered with, so they are compared with julianday, which converts them
+// to UTC.
+func (r *SQLiteOverdueRepository) FlagOverdue(ctx context.Context, now time.Time, limit int) ([]*application.Task, error) {
+	query := `UPDATE tasks SET overdue_at = ?, updated_at = ?
+	          WHERE id IN (
+	              SELECT t.id FROM tasks t
+	              INNER JOIN users u ON u.id = t.owner_id
+	              WHERE t.status = ? AND t.overdue_at IS NULL AND t.due_date IS NOT NULL AND t.deleted_at IS NULL
+	                AND julianday(t.due_date) < julianday(?) AND u.overdue_opt_out = 0
+	              ORDER BY julianday(t.due_date)
+	              LIMIT ?
+	          )
+	          RETURNING id`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, now, now, string(application.StatusPending), now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []any
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	tasks, err := conn(ctx, r.db).QueryContext(ctx, `SELECT `+taskColumns+`
+	          FROM tasks WHERE id IN (`+placeholders+`)
+	          ORDER BY julianday(due_date)`, ids...)
+	if err != nil {
+		return nil, err
+	}
+	defer tasks.Close()
+
+	var flagged []*application.Task
+	for tasks.Next() {
+		task, err := scanTask(tasks)
+		if err != nil {
+			return nil, err
+		}
+		flagged = append(flagged, task)
+	}
+
+	return flagged, tasks.Err()
+}
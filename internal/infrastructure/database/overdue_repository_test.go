@@ -0,0 +1,99 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteOverdueRepository_FlagOverdue(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	for _, user := range []*application.User{
+		{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()},
+		{ID: "u-bia", Name: "Bia", Email: "bia@example.com", OverdueOptOut: true, CreatedAt: time.Now()},
+	} {
+		if err := users.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	// Due dates keep the offset of the user who typed them
+	saoPaulo := time.FixedZone("BRT", -3*60*60)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tasks := NewSQLiteTaskRepository(db)
+	for _, tc := range []struct {
+		id, ownerID string
+		status      application.TaskStatus
+		due         time.Time
+	}{
+		{"t-late", "u-ana", application.StatusPending, now.Add(-time.Hour)},
+		{"t-later", "u-ana", application.StatusPending, now.Add(-2 * time.Hour).In(saoPaulo)},
+		{"t-offset", "u-ana", application.StatusPending, time.Date(2026, 3, 10, 8, 30, 0, 0, saoPaulo)},
+		// 10:00 in São Paulo is 13:00 UTC: not due yet, although its text sorts before now
+		{"t-future", "u-ana", application.StatusPending, time.Date(2026, 3, 10, 10, 0, 0, 0, saoPaulo)},
+		{"t-started", "u-ana", application.StatusInProgress, now.Add(-time.Hour)},
+		{"t-done", "u-ana", application.StatusCompleted, now.Add(-time.Hour)},
+		{"t-opted-out", "u-bia", application.StatusPending, now.Add(-time.Hour)},
+	} {
+		task, _ := application.NewTask(tc.id, tc.id, "", tc.status, tc.ownerID, "")
+		due := tc.due
+		task.SetDueDate(&due)
+		if err := tasks.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	repo := NewSQLiteOverdueRepository(db)
+
+	first, err := repo.FlagOverdue(ctx, now, 2)
+	if err != nil {
+		t.Fatalf("FlagOverdue() error: %v", err)
+	}
+	if got := taskIDs(first); len(got) != 2 || got[0] != "t-later" || got[1] != "t-late" {
+		t.Fatalf("FlagOverdue() = %v, want the two oldest due dates", got)
+	}
+	if first[0].OverdueAt == nil || !first[0].OverdueAt.Equal(now) {
+		t.Errorf("OverdueAt = %v, want %v", first[0].OverdueAt, now)
+	}
+	if !first[0].UpdatedAt.Equal(now) {
+		t.Errorf("UpdatedAt = %v, want %v", first[0].UpdatedAt, now)
+	}
+
+	second, err := repo.FlagOverdue(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("FlagOverdue() error: %v", err)
+	}
+	if got := taskIDs(second); len(got) != 1 || got[0] != "t-offset" {
+		t.Fatalf("FlagOverdue() = %v, want only the task not flagged yet", got)
+	}
+
+	if third, err := repo.FlagOverdue(ctx, now, 10); err != nil || len(third) != 0 {
+		t.Fatalf("FlagOverdue() = %v, %v; want nothing left to flag", taskIDs(third), err)
+	}
+
+	stored, _ := tasks.FindByID(ctx, "t-late")
+	if stored.OverdueAt == nil {
+		t.Error("Expected the flag to be persisted")
+	}
+	if future, _ := tasks.FindByID(ctx, "t-future"); future.OverdueAt != nil {
+		t.Error("Expected a task due later not to be flagged")
+	}
+}
+
+func taskIDs(tasks []*application.Task) []string {
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
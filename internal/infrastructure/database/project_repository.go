@@ -0,0 +1,162 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteProjectRepository implements repository.ProjectRepository using SQLite
+type SQLiteProjectRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteProjectRepository creates a new SQLiteProjectRepository
+func NewSQLiteProjectRepository(db *sql.DB) *SQLiteProjectRepository {
+	return &SQLiteProjectRepository{db: db}
+}
+
+// projectColumns lists the columns scanned by scanProject
+const projectColumns = `projects.id, projects.name, projects.color, projects.owner_id, projects.org_id, projects.created_at, projects.updated_at`
+
+// Create creates a new project using prepared statement
+func (r *SQLiteProjectRepository) Create(ctx context.Context, project *application.Project) error {
+	query := `INSERT INTO projects (id, name, color, owner_id, org_id, created_at, updated_at)
+	          VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		project.ID,
+		project.Name,
+		project.Color,
+		project.OwnerID,
+		nullableID(project.OrgID),
+		project.CreatedAt.UTC().Format(sortableTimeLayout),
+		project.UpdatedAt.UTC().Format(sortableTimeLayout),
+	)
+	return err
+}
+
+// Update updates the name and color of a project using prepared statement
+func (r *SQLiteProjectRepository) Update(ctx context.Context, project *application.Project) error {
+	query := `UPDATE projects SET name = ?, color = ?, updated_at = ? WHERE id = ?`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		project.Name,
+		project.Color,
+		project.UpdatedAt.UTC().Format(sortableTimeLayout),
+		project.ID,
+	)
+	return err
+}
+
+// Delete deletes a project using prepared statement. The foreign keys detach its tasks and
+// drop its shares.
+func (r *SQLiteProjectRepository) Delete(ctx context.Context, id string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM projects WHERE id = ?`, id)
+	return err
+}
+
+// FindByID finds a project by ID using prepared statement
+func (r *SQLiteProjectRepository) FindByID(ctx context.Context, id string) (*application.Project, error) {
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE id = ?`
+
+	project, err := scanProject(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, application.ErrProjectNotFound
+	}
+	return project, err
+}
+
+// FindByOwnerID finds all projects owned by a user in the workspace of ctx using prepared statement
+func (r *SQLiteProjectRepository) FindByOwnerID(ctx context.Context, ownerID string) ([]*application.Project, error) {
+	query := `SELECT ` + projectColumns + `
+	          FROM projects WHERE owner_id = ? AND ` + projectOrgScopeCondition + `
+	          ORDER BY name COLLATE NOCASE, created_at`
+
+	return r.query(ctx, query, append([]any{ownerID}, orgScopeArgs(ctx)...)...)
+}
+
+// FindSharedWithUser finds all projects shared with a user in the workspace of ctx using prepared
+// statement
+func (r *SQLiteProjectRepository) FindSharedWithUser(ctx context.Context, userID string) ([]*application.Project, error) {
+	query := `SELECT ` + projectColumns + `
+	          FROM projects
+	          INNER JOIN project_shares ps ON projects.id = ps.project_id
+	          WHERE ps.user_id = ? AND ` + projectOrgScopeCondition + `
+	          ORDER BY projects.name COLLATE NOCASE, projects.created_at`
+
+	return r.query(ctx, query, append([]any{userID}, orgScopeArgs(ctx)...)...)
+}
+
+// Share shares a project with a user using prepared statement
+func (r *SQLiteProjectRepository) Share(ctx context.Context, projectID, userID string) error {
+	query := `INSERT INTO project_shares (project_id, user_id) VALUES (?, ?)`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, projectID, userID)
+	return err
+}
+
+// Unshare removes sharing of a project with a user using prepared statement
+func (r *SQLiteProjectRepository) Unshare(ctx context.Context, projectID, userID string) error {
+	query := `DELETE FROM project_shares WHERE project_id = ? AND user_id = ?`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, projectID, userID)
+	return err
+}
+
+// IsSharedWith checks if a project is shared with a user using prepared statement
+func (r *SQLiteProjectRepository) IsSharedWith(ctx context.Context, projectID, userID string) (bool, error) {
+	query := `SELECT COUNT(*) FROM project_shares WHERE project_id = ? AND user_id = ?`
+
+	var count int
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query, projectID, userID).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// query runs a project listing query using prepared statement
+func (r *SQLiteProjectRepository) query(ctx context.Context, query string, args ...any) ([]*application.Project, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []*application.Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+
+	return projects, rows.Err()
+}
+
+// scanProject scans a row holding projectColumns
+func scanProject(row rowScanner) (*application.Project, error) {
+	var project application.Project
+	var orgID sql.NullString
+	var createdAt, updatedAt string
+
+	err := row.Scan(
+		&project.ID,
+		&project.Name,
+		&project.Color,
+		&project.OwnerID,
+		&orgID,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	project.OrgID = orgID.String
+	project.CreatedAt, _ = time.Parse(sortableTimeLayout, createdAt)
+	project.UpdatedAt, _ = time.Parse(sortableTimeLayout, updatedAt)
+	return &project, nil
+}
@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteProjectRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	for _, user := range []*application.User{
+		{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()},
+		{ID: "u-bia", Name: "Bia", Email: "bia@example.com", CreatedAt: time.Now()},
+	} {
+		if err := users.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	repo := NewSQLiteProjectRepository(db)
+
+	if missing, err := repo.FindByID(ctx, "nope"); !errors.Is(err, application.ErrProjectNotFound) || missing != nil {
+		t.Fatalf("FindByID() of a missing project = %v, %v; want nil, ErrProjectNotFound", missing, err)
+	}
+
+	work, _ := application.NewProject("p-work", "work", "#10b981", "u-ana")
+	home, _ := application.NewProject("p-home", "Home", "", "u-ana")
+	for _, project := range []*application.Project{work, home} {
+		if err := repo.Create(ctx, project); err != nil {
+			t.Fatalf("Create() error: %v", err)
+		}
+	}
+
+	owned, err := repo.FindByOwnerID(ctx, "u-ana")
+	if err != nil || len(owned) != 2 || owned[0].ID != "p-home" || owned[1].ID != "p-work" {
+		t.Fatalf("FindByOwnerID() = %v, %v; want Home then work, ignoring case", owned, err)
+	}
+
+	work.Update("Work", "#ef4444")
+	if err := repo.Update(ctx, work); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	found, err := repo.FindByID(ctx, "p-work")
+	if err != nil || found == nil || found.Name != "Work" || found.Color != "#ef4444" || found.OwnerID != "u-ana" {
+		t.Fatalf("FindByID() = %+v, %v; want the updated project", found, err)
+	}
+
+	tasks := NewSQLiteTaskRepository(db)
+	inProject, _ := application.NewTask("t-1", "Report", "", application.StatusPending, "u-ana", "")
+	inProject.SetProject("p-work")
+	loose, _ := application.NewTask("t-2", "Groceries", "", application.StatusPending, "u-ana", "")
+	for _, task := range []*application.Task{inProject, loose} {
+		if err := tasks.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	projectTasks, err := tasks.FindByProjectID(ctx, "p-work")
+	if err != nil || len(projectTasks) != 1 || projectTasks[0].ID != "t-1" || projectTasks[0].ProjectID != "p-work" {
+		t.Fatalf("FindByProjectID() = %v, %v; want only t-1", projectTasks, err)
+	}
+
+	// Sharing the project shares its tasks, and only its tasks
+	if err := repo.Share(ctx, "p-work", "u-bia"); err != nil {
+		t.Fatalf("Share() error: %v", err)
+	}
+	if shared, err := repo.IsSharedWith(ctx, "p-work", "u-bia"); err != nil || !shared {
+		t.Errorf("IsSharedWith() = %v, %v; want true", shared, err)
+	}
+	if sharedProjects, err := repo.FindSharedWithUser(ctx, "u-bia"); err != nil || len(sharedProjects) != 1 || sharedProjects[0].ID != "p-work" {
+		t.Errorf("FindSharedWithUser() = %v, %v", sharedProjects, err)
+	}
+
+	shares := NewSQLiteShareRepository(db)
+	if shared, err := shares.IsSharedWith(ctx, "t-1", "u-bia"); err != nil || !shared {
+		t.Errorf("task IsSharedWith() through the project = %v, %v; want true", shared, err)
+	}
+	if shared, err := shares.IsSharedWith(ctx, "t-2", "u-bia"); err != nil || shared {
+		t.Errorf("task IsSharedWith() outside the project = %v, %v; want false", shared, err)
+	}
+	if sharedTasks, err := tasks.FindSharedWithUser(ctx, "u-bia"); err != nil || len(sharedTasks) != 1 || sharedTasks[0].ID != "t-1" {
+		t.Errorf("task FindSharedWithUser() = %v, %v; want t-1", sharedTasks, err)
+	}
+
+	if err := repo.Unshare(ctx, "p-work", "u-bia"); err != nil {
+		t.Fatalf("Unshare() error: %v", err)
+	}
+	if shared, err := shares.IsSharedWith(ctx, "t-1", "u-bia"); err != nil || shared {
+		t.Errorf("task IsSharedWith() after Unshare() = %v, %v; want false", shared, err)
+	}
+
+	// Deleting the project keeps its tasks, without a project
+	if err := repo.Delete(ctx, "p-work"); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if found, err := repo.FindByID(ctx, "p-work"); !errors.Is(err, application.ErrProjectNotFound) || found != nil {
+		t.Errorf("FindByID() after Delete() = %v, %v; want nil, ErrProjectNotFound", found, err)
+	}
+	task, err := tasks.FindByID(ctx, "t-1")
+	if err != nil || task == nil || task.ProjectID != "" {
+		t.Errorf("task after project Delete() = %+v, %v; want it kept without project", task, err)
+	}
+}
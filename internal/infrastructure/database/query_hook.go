@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"sync"
+)
+
+// QueryHook is called with the text of every statement a database runs or prepares, before it
+// runs. The arguments aren't passed: a query built from user input shows in its text, so the
+// text alone tells whether a query was parameterized.
+type QueryHook func(ctx context.Context, query string)
+
+// hookedConnector calls a hook with the statements of the connections it opens
+type hookedConnector struct {
+	driver.Connector
+	hook QueryHook
+}
+
+func (c *hookedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &hookedConn{Conn: conn, hook: c.hook}, nil
+}
+
+// hookedConn calls the hook with the statements of a connection. Executions of a prepared
+// statement were reported when it was prepared.
+type hookedConn struct {
+	driver.Conn
+	hook QueryHook
+}
+
+func (c *hookedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	c.hook(ctx, query)
+	return c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+}
+
+func (c *hookedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.hook(ctx, query)
+	return c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+}
+
+func (c *hookedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.hook(ctx, query)
+	return c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+}
+
+func (c *hookedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+func (c *hookedConn) Ping(ctx context.Context) error {
+	return c.Conn.(driver.Pinger).Ping(ctx)
+}
+
+// QueryLog records the statements run on a database, to audit them: its Hook is given to
+// NewSQLiteDBWithHook
+type QueryLog struct {
+	mu      sync.Mutex
+	queries []string
+}
+
+// Hook records a statement
+func (l *QueryLog) Hook(ctx context.Context, query string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.queries = append(l.queries, query)
+}
+
+// Queries returns the statements recorded so far, in the order they ran
+func (l *QueryLog) Queries() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.queries...)
+}
+
+// Containing returns the recorded statements whose text contains s. Given a value sent by a
+// client, any statement returned was built from it instead of receiving it as an argument.
+func (l *QueryLog) Containing(s string) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var found []string
+	for _, query := range l.queries {
+		if strings.Contains(query, s) {
+			found = append(found, query)
+		}
+	}
+	return found
+}
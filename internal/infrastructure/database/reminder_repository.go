@@ -0,0 +1,159 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteReminderRepository implements repository.ReminderRepository using SQLite
+type SQLiteReminderRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteReminderRepository creates a new SQLiteReminderRepository
+func NewSQLiteReminderRepository(db *sql.DB) *SQLiteReminderRepository {
+	return &SQLiteReminderRepository{db: db}
+}
+
+// reminderColumns lists the columns scanned by scanReminder
+const reminderColumns = `id, task_id, user_id, remind_at, sent_at, created_at`
+
+// Create creates a new reminder using prepared statement
+func (r *SQLiteReminderRepository) Create(ctx context.Context, reminder *application.Reminder) error {
+	query := `INSERT INTO reminders (` + reminderColumns + `)
+	          VALUES (?, ?, ?, ?, ?, ?)`
+
+	var sentAt sql.NullString
+	if reminder.SentAt != nil {
+		sentAt = sql.NullString{String: reminder.SentAt.UTC().Format(sortableTimeLayout), Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		reminder.ID,
+		reminder.TaskID,
+		reminder.UserID,
+		reminder.RemindAt.UTC().Format(sortableTimeLayout),
+		sentAt,
+		reminder.CreatedAt.UTC().Format(sortableTimeLayout),
+	)
+	return err
+}
+
+// FindByID finds a reminder by ID using prepared statement
+func (r *SQLiteReminderRepository) FindByID(ctx context.Context, id string) (*application.Reminder, error) {
+	query := `SELECT ` + reminderColumns + ` FROM reminders WHERE id = ?`
+
+	reminder, err := scanReminder(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, application.ErrReminderNotFound
+	}
+	return reminder, err
+}
+
+// FindByTaskID finds the reminders of a user on a task using prepared statement
+func (r *SQLiteReminderRepository) FindByTaskID(ctx context.Context, taskID, userID string) ([]*application.Reminder, error) {
+	query := `SELECT ` + reminderColumns + `
+	          FROM reminders WHERE task_id = ? AND user_id = ?
+	          ORDER BY remind_at`
+
+	return r.query(ctx, query, taskID, userID)
+}
+
+// CountPending counts the undelivered reminders of a user on a task using prepared statement
+func (r *SQLiteReminderRepository) CountPending(ctx context.Context, taskID, userID string) (int, error) {
+	query := `SELECT COUNT(*) FROM reminders
+	          WHERE task_id = ? AND user_id = ? AND sent_at IS NULL`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, taskID, userID).Scan(&count)
+	return count, err
+}
+
+// Delete deletes a reminder using prepared statement
+func (r *SQLiteReminderRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM reminders WHERE id = ?`, id)
+	return err
+}
+
+// FindDue finds the undelivered reminders due at now using prepared statement
+func (r *SQLiteReminderRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*application.Reminder, error) {
+	query := `SELECT ` + reminderColumns + `
+	          FROM reminders WHERE sent_at IS NULL AND remind_at <= ?
+	          ORDER BY remind_at LIMIT ?`
+
+	return r.query(ctx, query, now.UTC().Format(sortableTimeLayout), limit)
+}
+
+// MarkSent marks an undelivered reminder as sent using prepared statement
+func (r *SQLiteReminderRepository) MarkSent(ctx context.Context, id string, sentAt time.Time) (bool, error) {
+	query := `UPDATE reminders SET sent_at = ? WHERE id = ? AND sent_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, sentAt.UTC().Format(sortableTimeLayout), id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// query runs a select of reminderColumns and scans every row
+func (r *SQLiteReminderRepository) query(ctx context.Context, query string, args ...any) ([]*application.Reminder, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reminders []*application.Reminder
+	for rows.Next() {
+		reminder, err := scanReminder(rows)
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	return reminders, rows.Err()
+}
+
+// scanReminder scans a row selected with reminderColumns
+func scanReminder(row rowScanner) (*application.Reminder, error) {
+	var reminder application.Reminder
+	var remindAt, createdAt string
+	var sentAt sql.NullString
+
+	err := row.Scan(
+		&reminder.ID,
+		&reminder.TaskID,
+		&reminder.UserID,
+		&remindAt,
+		&sentAt,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if reminder.RemindAt, err = time.Parse(sortableTimeLayout, remindAt); err != nil {
+		return nil, err
+	}
+	if reminder.CreatedAt, err = time.Parse(sortableTimeLayout, createdAt); err != nil {
+		return nil, err
+	}
+	if sentAt.Valid {
+		sent, err := time.Parse(sortableTimeLayout, sentAt.String)
+		if err != nil {
+			return nil, err
+		}
+		reminder.SentAt = &sent
+	}
+
+	return &reminder, nil
+}
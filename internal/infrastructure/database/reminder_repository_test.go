@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteReminderRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := NewSQLiteUserRepository(db).Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	task, err := application.NewTask("task-1", "Pagar contas", "", application.StatusPending, "u-ana", "")
+	if err != nil {
+		t.Fatalf("NewTask() error: %v", err)
+	}
+	if err := NewSQLiteTaskRepository(db).Create(ctx, task); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	repo := NewSQLiteReminderRepository(db)
+
+	missing, err := repo.FindByID(ctx, "missing")
+	if !errors.Is(err, application.ErrReminderNotFound) || missing != nil {
+		t.Fatalf("FindByID() of missing reminder = %v, %v; want nil, ErrReminderNotFound", missing, err)
+	}
+
+	now := time.Now()
+	for _, r := range []struct {
+		id     string
+		offset time.Duration
+	}{
+		{"rem-late", -time.Hour},
+		{"rem-due", -time.Minute},
+		{"rem-future", time.Hour},
+	} {
+		reminder, err := application.NewReminder(r.id, "task-1", "u-ana", now.Add(r.offset), now.Add(-2*time.Hour))
+		if err != nil {
+			t.Fatalf("NewReminder() error: %v", err)
+		}
+		if err := repo.Create(ctx, reminder); err != nil {
+			t.Fatalf("Create() error: %v", err)
+		}
+	}
+
+	due, err := repo.FindDue(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("FindDue() error: %v", err)
+	}
+	if len(due) != 2 || due[0].ID != "rem-late" || due[1].ID != "rem-due" {
+		t.Fatalf("FindDue() = %v, want rem-late and rem-due", reminderIDs(due))
+	}
+
+	marked, err := repo.MarkSent(ctx, "rem-late", now)
+	if err != nil || !marked {
+		t.Fatalf("MarkSent() = %v, %v; want true", marked, err)
+	}
+	marked, err = repo.MarkSent(ctx, "rem-late", now)
+	if err != nil || marked {
+		t.Fatalf("second MarkSent() = %v, %v; want false", marked, err)
+	}
+
+	due, err = repo.FindDue(ctx, now, 10)
+	if err != nil || len(due) != 1 || due[0].ID != "rem-due" {
+		t.Fatalf("FindDue() after MarkSent = %v, %v; want rem-due", reminderIDs(due), err)
+	}
+
+	count, err := repo.CountPending(ctx, "task-1", "u-ana")
+	if err != nil || count != 2 {
+		t.Errorf("CountPending() = %d, %v; want 2", count, err)
+	}
+
+	sent, err := repo.FindByID(ctx, "rem-late")
+	if err != nil || sent == nil || !sent.IsSent() {
+		t.Fatalf("FindByID() = %+v, %v; want sent reminder", sent, err)
+	}
+	if !sent.RemindAt.Equal(now.Add(-time.Hour)) {
+		t.Errorf("RemindAt = %v, want %v", sent.RemindAt, now.Add(-time.Hour))
+	}
+
+	if err := repo.Delete(ctx, "rem-due"); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	reminders, err := repo.FindByTaskID(ctx, "task-1", "u-ana")
+	if err != nil || len(reminders) != 2 || reminders[0].ID != "rem-late" || reminders[1].ID != "rem-future" {
+		t.Fatalf("FindByTaskID() = %v, %v; want rem-late and rem-future", reminderIDs(reminders), err)
+	}
+
+	if err := NewSQLiteTaskRepository(db).Delete(ctx, "task-1"); err != nil {
+		t.Fatalf("Failed to delete task: %v", err)
+	}
+	reminders, err = repo.FindByTaskID(ctx, "task-1", "u-ana")
+	if err != nil || len(reminders) != 0 {
+		t.Errorf("FindByTaskID() after task deletion = %v, %v; want none", reminderIDs(reminders), err)
+	}
+}
+
+func reminderIDs(reminders []*application.Reminder) []string {
+	var ids []string
+	for _, reminder := range reminders {
+		ids = append(ids, reminder.ID)
+	}
+	return ids
+}
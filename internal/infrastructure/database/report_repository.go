@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteReportRepository implements repository.ReportRepository using SQLite
+type SQLiteReportRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteReportRepository creates a new SQLiteReportRepository
+func NewSQLiteReportRepository(db *sql.DB) *SQLiteReportRepository {
+	return &SQLiteReportRepository{db: db}
+}
+
+// FindOverdueByUnit finds overdue tasks of the users of a unit using prepared statement.
+// Only metadata is selected; descriptions never leave the tasks table.
+func (r *SQLiteReportRepository) FindOverdueByUnit(ctx context.Context, unit string, now time.Time) ([]*application.OverdueTask, error) {
+	query := `SELECT t.id, t.title, t.status, t.due_date, u.id, u.name, u.email
+	          FROM tasks t
+	          INNER JOIN users u ON u.id = t.owner_id
+	          WHERE u.unit = ? AND t.status != ? AND t.due_date IS NOT NULL AND t.deleted_at IS NULL
+	          ORDER BY u.name, t.due_date`
+
+	rows, err := r.db.QueryContext(ctx, query, unit, string(application.StatusCompleted))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*application.OverdueTask
+	for rows.Next() {
+		var task application.OverdueTask
+		var status string
+		var dueDate sql.NullString
+
+		err := rows.Scan(
+			&task.TaskID,
+			&task.Title,
+			&status,
+			&dueDate,
+			&task.OwnerID,
+			&task.OwnerName,
+			&task.OwnerEmail,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// Due dates are stored with their original offset, so compare instants in Go
+		due := parseNullTime(dueDate)
+		if due == nil || !due.Before(now) {
+			continue
+		}
+
+		task.Status = application.TaskStatus(status)
+		task.DueDate = *due
+		tasks = append(tasks, &task)
+	}
+
+	return tasks, rows.Err()
+}
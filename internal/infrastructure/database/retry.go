@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// busyRetry retries database operations that fail with SQLITE_BUSY or SQLITE_LOCKED. The busy
+// timeout of the connections already waits for locks, but SQLite fails at once, without it,
+// when waiting can't help: a transaction that read an older snapshot can't start writing and
+// has to start over. Each attempt waits a random time up to a backoff that doubles from base
+// to max, so writers that collided don't collide again.
+type busyRetry struct {
+	attempts int
+	base     time.Duration
+	max      time.Duration
+}
+
+// defaultBusyRetry is the retry of the statements and transactions of the repositories
+var defaultBusyRetry = busyRetry{attempts: 5, base: 5 * time.Millisecond, max: 100 * time.Millisecond}
+
+// do runs op until it succeeds, fails with an error other than a busy one, runs out of
+// attempts or ctx is done, and returns its last error
+func (r busyRetry) do(ctx context.Context, op func() error) error {
+	var err error
+	for attempt := 0; attempt < r.attempts; attempt++ {
+		if attempt > 0 {
+			backoff := min(r.base<<(attempt-1), r.max)
+			timer := time.NewTimer(rand.N(backoff) + 1)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+		if err = op(); !isBusy(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("%w (gave up after %d attempts)", err, r.attempts)
+}
+
+// isBusy reports whether err comes from a lock held by another connection
+func isBusy(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && (sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked)
+}
+
+// queryError adds the statement and table of a failed query to its error, such as "insert
+// into tasks: database is locked", so logs tell which query failed
+func queryError(query string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", queryOperation(query), err)
+}
+
+// queryOperation names the statement of a query and the first table it names outside of
+// subqueries, in lower case
+func queryOperation(query string) string {
+	fields := strings.Fields(strings.ToLower(query))
+	if len(fields) == 0 {
+		return "query"
+	}
+
+	operation := fields[0]
+	if operation == "with" {
+		operation = "select"
+	}
+	depth := 0
+	for i, field := range fields[:len(fields)-1] {
+		if depth == 0 {
+			switch field {
+			case "into", "from", "update":
+				table := strings.TrimLeft(fields[i+1], "(")
+				if end := strings.IndexAny(table, "(),;"); end >= 0 {
+					table = table[:end]
+				}
+				if field == "update" {
+					return "update " + table
+				}
+				return operation + " " + field + " " + table
+			}
+		}
+		depth += strings.Count(field, "(") - strings.Count(field, ")")
+	}
+	return operation
+}
@@ -0,0 +1,154 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+func TestBusyRetry_Do(t *testing.T) {
+	busy := sqlite3.Error{Code: sqlite3.ErrBusy}
+	failure := errors.New("disk I/O error")
+	retry := busyRetry{attempts: 3, base: time.Millisecond, max: 2 * time.Millisecond}
+
+	tests := []struct {
+		name      string
+		errs      []error // returned by each attempt, then nil
+		wantCalls int
+		wantErr   error
+		wantBusy  bool
+	}{
+		{"succeeds at once", nil, 1, nil, false},
+		{"succeeds once the lock is gone", []error{busy, sqlite3.Error{Code: sqlite3.ErrLocked}}, 3, nil, false},
+		{"gives up after the attempts", []error{busy, busy, busy, busy}, 3, nil, true},
+		{"other errors aren't retried", []error{failure}, 1, failure, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := retry.do(context.Background(), func() error {
+				calls++
+				if calls <= len(tt.errs) {
+					return tt.errs[calls-1]
+				}
+				return nil
+			})
+
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("do() error = %v, want %v", err, tt.wantErr)
+			}
+			if isBusy(err) != tt.wantBusy || (err == nil) == (tt.wantErr != nil || tt.wantBusy) {
+				t.Errorf("do() error = %v, want busy %v", err, tt.wantBusy)
+			}
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	err := busyRetry{attempts: 3, base: time.Hour, max: time.Hour}.do(ctx, func() error {
+		calls++
+		return busy
+	})
+	if calls != 1 || !isBusy(err) {
+		t.Errorf("do() with a done context = %d calls, %v; want 1 call and the busy error", calls, err)
+	}
+}
+
+func TestQueryOperation(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"INSERT INTO tasks (id, title) VALUES (?, ?)", "insert into tasks"},
+		{"UPDATE users SET theme = ? WHERE id = ?", "update users"},
+		{"DELETE FROM task_shares WHERE task_id = ?", "delete from task_shares"},
+		{"SELECT (SELECT name FROM users WHERE users.id = tasks.owner_id), id FROM tasks WHERE id = ?", "select from tasks"},
+		{"WITH visible(id) AS (SELECT id FROM tasks) SELECT * FROM visible", "select from visible"},
+		{"SELECT COUNT(*)\n\t          FROM\tprojects", "select from projects"},
+		{"PRAGMA optimize", "pragma"},
+	}
+
+	for _, tt := range tests {
+		if got := queryOperation(tt.query); got != tt.want {
+			t.Errorf("queryOperation(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+// lockDatabase holds the write lock of the database at path from another connection until
+// release is called
+func lockDatabase(t *testing.T, path string) (release func()) {
+	t.Helper()
+	holder, err := NewSQLiteDB(path, 0)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	conn, err := holder.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to get a connection: %v", err)
+	}
+	if _, err := conn.ExecContext(context.Background(), "BEGIN IMMEDIATE"); err != nil {
+		t.Fatalf("Failed to lock the database: %v", err)
+	}
+	return func() {
+		conn.ExecContext(context.Background(), "ROLLBACK")
+		conn.Close()
+		holder.Close()
+	}
+}
+
+func TestPreparedStatements_RetriesWhileLocked(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	// Without a busy timeout, writers fail at once while the lock is held
+	db, err := NewSQLiteDB(path, 0)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	saved := defaultBusyRetry
+	defaultBusyRetry = busyRetry{attempts: 200, base: time.Millisecond, max: 5 * time.Millisecond}
+	t.Cleanup(func() { defaultBusyRetry = saved })
+
+	ctx := context.Background()
+	stmts := newPreparedStatements(db)
+	insert := `INSERT INTO users (id, name, email, password_hash, created_at) VALUES (?, ?, ?, '', ?)`
+
+	release := lockDatabase(t, path)
+	time.AfterFunc(20*time.Millisecond, release)
+	if _, err := stmts.ExecContext(ctx, insert, "u-ana", "Ana", "ana@example.com", time.Now()); err != nil {
+		t.Fatalf("ExecContext() while locked error: %v", err)
+	}
+
+	// Within a transaction the statement fails, so the transaction starts over
+	release = lockDatabase(t, path)
+	time.AfterFunc(20*time.Millisecond, release)
+	var runs atomic.Int32
+	err = NewSQLiteTransactor(db).WithinTransaction(ctx, func(ctx context.Context) error {
+		runs.Add(1)
+		_, err := stmts.ExecContext(ctx, insert, "u-bia", "Bia", "bia@example.com", time.Now())
+		return err
+	})
+	if err != nil {
+		t.Fatalf("WithinTransaction() while locked error: %v", err)
+	}
+	if runs.Load() < 2 {
+		t.Errorf("transaction ran %d times, want it to start over while locked", runs.Load())
+	}
+
+	// Errors name the statement that failed
+	_, err = stmts.ExecContext(ctx, insert, "u-ana", "Ana", "ana@example.com", time.Now())
+	if err == nil || !strings.HasPrefix(err.Error(), "insert into users: ") {
+		t.Errorf("ExecContext() of a duplicate error = %v, want it prefixed with the operation", err)
+	}
+}
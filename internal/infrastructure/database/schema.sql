@@ -4,6 +4,13 @@ CREATE TABLE IF NOT EXISTS users (
     name TEXT NOT NULL,
     email TEXT NOT NULL UNIQUE,
     password_hash TEXT NOT NULL,
+    role TEXT NOT NULL DEFAULT 'member',
+    unit TEXT NOT NULL DEFAULT '',
+    theme TEXT NOT NULL DEFAULT 'light',
+    locale TEXT NOT NULL DEFAULT '',
+    overdue_opt_out INTEGER NOT NULL DEFAULT 0,
+    avatar_path TEXT NOT NULL DEFAULT '',
+    disabled INTEGER NOT NULL DEFAULT 0,
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 
@@ -15,6 +22,16 @@ CREATE TABLE IF NOT EXISTS tasks (
     status TEXT NOT NULL CHECK(status IN ('pending', 'in_progress', 'completed')),
     owner_id TEXT NOT NULL,
     image_path TEXT,
+    project_id TEXT REFERENCES projects(id) ON DELETE SET NULL,
+    due_date DATETIME,
+    overdue_at DATETIME,
+    priority TEXT NOT NULL DEFAULT 'normal',
+    tags TEXT NOT NULL DEFAULT '',
+    color TEXT NOT NULL DEFAULT '',
+    snoozed_until DATETIME,
+    progress INTEGER NOT NULL DEFAULT 0 CHECK(progress BETWEEN 0 AND 100),
+    completion_note TEXT NOT NULL DEFAULT '',
+    deleted_at TEXT,
     created_at DATETIME NOT NULL,
     updated_at DATETIME NOT NULL,
     FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE
@@ -25,6 +42,8 @@ CREATE TABLE IF NOT EXISTS task_shares (
     task_id TEXT NOT NULL,
     user_id TEXT NOT NULL,
     shared_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- Shares are invitations until the user accepts them; only accepted shares grant access
+    status TEXT NOT NULL DEFAULT 'accepted' CHECK(status IN ('pending', 'accepted')),
     PRIMARY KEY (task_id, user_id),
     FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
@@ -32,6 +51,422 @@ CREATE TABLE IF NOT EXISTS task_shares (
 
 -- Indexes for better performance
 CREATE INDEX IF NOT EXISTS idx_tasks_owner_id ON tasks(owner_id);
+-- Pages of a user's task list, newest first
+CREATE INDEX IF NOT EXISTS idx_tasks_owner_created ON tasks(owner_id, created_at);
 CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status);
+CREATE INDEX IF NOT EXISTS idx_tasks_image_path ON tasks(image_path);
 CREATE INDEX IF NOT EXISTS idx_task_shares_user_id ON task_shares(user_id);
 CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
+
+-- Task links table (results of the external link checker)
+CREATE TABLE IF NOT EXISTS task_links (
+    task_id TEXT NOT NULL,
+    url TEXT NOT NULL,
+    status TEXT NOT NULL CHECK(status IN ('ok', 'broken')),
+    status_code INTEGER NOT NULL DEFAULT 0,
+    checked_at DATETIME NOT NULL,
+    PRIMARY KEY (task_id, url),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
+
+-- Notifications table (in-app notifications)
+CREATE TABLE IF NOT EXISTS notifications (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    type TEXT NOT NULL,
+    task_id TEXT,
+    message TEXT NOT NULL,
+    read INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(user_id, created_at);
+
+-- Export usage table (persisted quota for heavy exports)
+-- created_at is stored as fixed-width UTC text so it can be compared lexically
+CREATE TABLE IF NOT EXISTS export_usage (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_export_usage_user_id ON export_usage(user_id, created_at);
+
+-- Export jobs table (files generated in the background, kept with the job until it expires)
+-- Times are sortable UTC text; result holds the file once the job is done
+CREATE TABLE IF NOT EXISTS export_jobs (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK(status IN ('pending', 'running', 'done', 'failed')),
+    error TEXT NOT NULL DEFAULT '',
+    result BLOB,
+    created_at TEXT NOT NULL,
+    started_at TEXT,
+    finished_at TEXT,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_export_jobs_status ON export_jobs(status, created_at);
+
+-- Attachments table (files attached to tasks; the files themselves live on disk)
+CREATE TABLE IF NOT EXISTS attachments (
+    id TEXT PRIMARY KEY,
+    task_id TEXT NOT NULL,
+    filename TEXT NOT NULL,
+    mime TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    path TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_task_id ON attachments(task_id, created_at);
+
+-- Login events table (audit of login attempts, successful or not)
+-- user_id is NULL when the email doesn't belong to any user; created_at is sortable UTC text
+CREATE TABLE IF NOT EXISTS login_events (
+    id TEXT PRIMARY KEY,
+    user_id TEXT,
+    email TEXT NOT NULL,
+    ip TEXT NOT NULL,
+    user_agent TEXT NOT NULL,
+    success INTEGER NOT NULL,
+    created_at TEXT NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_events_user_id ON login_events(user_id, created_at);
+
+-- Reminders table (points in time when a user is reminded of a task)
+-- Times are sortable UTC text; sent_at stays NULL until the scheduler delivers the reminder
+CREATE TABLE IF NOT EXISTS reminders (
+    id TEXT PRIMARY KEY,
+    task_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    remind_at TEXT NOT NULL,
+    sent_at TEXT,
+    created_at TEXT NOT NULL,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminders_task_id ON reminders(task_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_reminders_due ON reminders(remind_at) WHERE sent_at IS NULL;
+
+-- Credentials table (WebAuthn passkeys registered by users, next to their passwords)
+-- credential_id is the authenticator's identifier; public_key is COSE encoded; times are sortable UTC text
+CREATE TABLE IF NOT EXISTS credentials (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    credential_id BLOB NOT NULL UNIQUE,
+    public_key BLOB NOT NULL,
+    attestation_type TEXT NOT NULL DEFAULT '',
+    transports TEXT NOT NULL DEFAULT '',
+    aaguid BLOB,
+    sign_count INTEGER NOT NULL DEFAULT 0,
+    backup_eligible INTEGER NOT NULL DEFAULT 0,
+    backup_state INTEGER NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL,
+    last_used_at TEXT,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_credentials_user_id ON credentials(user_id, created_at);
+
+-- Two-factor table (TOTP second factor of users; enforced on login once enabled)
+-- last_step is the time step of the last accepted code, so codes can't be replayed
+CREATE TABLE IF NOT EXISTS two_factor (
+    user_id TEXT PRIMARY KEY,
+    secret TEXT NOT NULL,
+    enabled INTEGER NOT NULL DEFAULT 0,
+    last_step INTEGER NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Recovery codes table (single-use codes replacing a lost authenticator; only SHA-256 hashes are stored)
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    user_id TEXT NOT NULL,
+    code_hash TEXT NOT NULL,
+    PRIMARY KEY (user_id, code_hash),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Two-factor challenges table (logins whose password was verified, waiting for the second factor)
+-- token_hash is the SHA-256 of the token handed to the client; expires_at is sortable UTC text
+CREATE TABLE IF NOT EXISTS two_factor_challenges (
+    token_hash TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    remember_me INTEGER NOT NULL DEFAULT 0,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TEXT NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Projects table (lists grouping the tasks of their owner; deleting one keeps its tasks)
+-- created_at and updated_at are sortable UTC text
+CREATE TABLE IF NOT EXISTS projects (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    color TEXT NOT NULL,
+    owner_id TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_projects_owner_id ON projects(owner_id);
+
+-- Project shares table (sharing a project gives access to all of its tasks)
+CREATE TABLE IF NOT EXISTS project_shares (
+    project_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    shared_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (project_id, user_id),
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_shares_user_id ON project_shares(user_id);
+
+-- Orgs table (workspaces shared by their members, such as departments); tasks.org_id and
+-- projects.org_id hold the org of a task or project, NULL for personal ones. share_tasks shows the tasks of an org to all its members.
+-- created_at is sortable UTC text
+CREATE TABLE IF NOT EXISTS orgs (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    share_tasks INTEGER NOT NULL DEFAULT 1,
+    created_at TEXT NOT NULL
+);
+
+-- Org members table (users belonging to an org, with their role in it); joined_at is sortable UTC text
+CREATE TABLE IF NOT EXISTS org_members (
+    org_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    role TEXT NOT NULL CHECK(role IN ('owner', 'admin', 'member')),
+    joined_at TEXT NOT NULL,
+    PRIMARY KEY (org_id, user_id),
+    FOREIGN KEY (org_id) REFERENCES orgs(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_org_members_user_id ON org_members(user_id);
+
+-- Stored files table (disk space taken by the uploads of each user, for the storage quota)
+-- Rows are added when a file is written and removed when it is deleted; created_at is sortable UTC text
+CREATE TABLE IF NOT EXISTS stored_files (
+    kind TEXT NOT NULL CHECK(kind IN ('image', 'attachment')),
+    name TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    created_at TEXT NOT NULL,
+    PRIMARY KEY (kind, name),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_stored_files_user_id ON stored_files(user_id);
+
+-- Task dependencies table (task_id is blocked by blocker_id until the blocker is completed)
+-- The dependency graph is kept acyclic by the use cases; created_at is sortable UTC text
+CREATE TABLE IF NOT EXISTS task_dependencies (
+    task_id TEXT NOT NULL,
+    blocker_id TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    PRIMARY KEY (task_id, blocker_id),
+    CHECK (task_id <> blocker_id),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (blocker_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_dependencies_blocker_id ON task_dependencies(blocker_id);
+
+-- Share links table (public, read-only links to a task, opened without signing in)
+-- Only the sha256 of the token is stored; times are sortable UTC text; revoked_at stays NULL until the owner revokes the link
+CREATE TABLE IF NOT EXISTS share_links (
+    id TEXT PRIMARY KEY,
+    task_id TEXT NOT NULL,
+    created_by TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at TEXT NOT NULL,
+    revoked_at TEXT,
+    created_at TEXT NOT NULL,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_share_links_task_id ON share_links(task_id);
+
+-- Time entries table (periods users spent on tasks, tracked with start/stop timers)
+-- Times are sortable UTC text; stopped_at stays NULL while the timer runs, and a user runs at most one timer per task
+CREATE TABLE IF NOT EXISTS time_entries (
+    id TEXT PRIMARY KEY,
+    task_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    started_at TEXT NOT NULL,
+    stopped_at TEXT,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_time_entries_task_id ON time_entries(task_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_running ON time_entries(user_id, task_id) WHERE stopped_at IS NULL;
+
+-- Task undos table (deletions and completions made on the web, undoable for a short while)
+-- Deleted tasks stay hidden in the trash (tasks.deleted_at) until their undo expires; times are sortable UTC text
+CREATE TABLE IF NOT EXISTS task_undos (
+    task_id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    action TEXT NOT NULL CHECK(action IN ('delete', 'complete')),
+    previous_status TEXT NOT NULL DEFAULT '',
+    expires_at TEXT NOT NULL,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_undos_expires_at ON task_undos(expires_at);
+
+-- Feed tokens table (per-user tokens authorizing the Atom feed of tasks, read without signing in)
+-- Only the sha256 of the token is stored; a user has at most one, replaced when they create another
+CREATE TABLE IF NOT EXISTS feed_tokens (
+    user_id TEXT PRIMARY KEY,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_at TEXT NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Account deletions table (accounts their users asked to delete, purged once purge_at is past)
+-- Times are sortable UTC text; the row goes with the account when it is purged
+CREATE TABLE IF NOT EXISTS account_deletions (
+    user_id TEXT PRIMARY KEY,
+    requested_at TEXT NOT NULL,
+    purge_at TEXT NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_deletions_purge_at ON account_deletions(purge_at);
+
+-- Creation usage table (tasks and images created by each user, counted against the daily creation quotas)
+-- count is how many were created at once; created_at is sortable UTC text
+CREATE TABLE IF NOT EXISTS creation_usage (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK(kind IN ('task', 'image')),
+    count INTEGER NOT NULL DEFAULT 1,
+    created_at TEXT NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_creation_usage_user_id ON creation_usage(user_id, kind, created_at);
+
+-- Digest subscriptions table (users who opted in to the weekly summary email)
+-- weekday (0 = Sunday) and hour are in the application timezone; next_send_at is precomputed
+-- from them so the digest job only reads the due rows; times are sortable UTC text
+CREATE TABLE IF NOT EXISTS digest_subscriptions (
+    user_id TEXT PRIMARY KEY,
+    weekday INTEGER NOT NULL CHECK(weekday BETWEEN 0 AND 6),
+    hour INTEGER NOT NULL CHECK(hour BETWEEN 0 AND 23),
+    next_send_at TEXT NOT NULL,
+    last_sent_at TEXT,
+    created_at TEXT NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_digest_subscriptions_next_send_at ON digest_subscriptions(next_send_at);
+
+-- User settings table (preferences kept apart from the account; theme and locale stay in users)
+-- A missing row means the defaults: newest tasks first and every notification; updated_at is
+-- sortable UTC text
+CREATE TABLE IF NOT EXISTS user_settings (
+    user_id TEXT PRIMARY KEY,
+    default_sort TEXT NOT NULL DEFAULT '-created_at' CHECK(default_sort IN ('-created_at', 'created_at')),
+    notify_task_shared INTEGER NOT NULL DEFAULT 1,
+    notify_task_completed INTEGER NOT NULL DEFAULT 1,
+    notify_task_overdue INTEGER NOT NULL DEFAULT 1,
+    notify_comment_mention INTEGER NOT NULL DEFAULT 1,
+    updated_at TEXT NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Task revisions table (previous versions of the title, description and status of tasks)
+-- edited_by is the user whose edit replaced the version, kept when their account is deleted;
+-- replaced_at is sortable UTC text. Only the newest revisions of each task are kept.
+CREATE TABLE IF NOT EXISTS task_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    task_id TEXT NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL,
+    edited_by TEXT NOT NULL,
+    replaced_at TEXT NOT NULL,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_revisions_task_id ON task_revisions(task_id, id);
+
+-- Jobs table (the queue of background jobs run by the workers); payload is the JSON given to the
+-- handler of the type, and times are sortable UTC text. Done jobs are deleted after JOBS_TTL;
+-- failed ones are kept until an admin retries them.
+CREATE TABLE IF NOT EXISTS jobs (
+    id TEXT PRIMARY KEY,
+    type TEXT NOT NULL,
+    payload BLOB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK(status IN ('pending', 'running', 'done', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    run_at TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    started_at TEXT,
+    finished_at TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs(status, run_at);
+
+-- Idempotency keys table (POST requests sent with an Idempotency-Key header and, once answered,
+-- their responses, replayed to retries); keys are scoped per user. status is 0 while the first
+-- request runs, and times are sortable UTC text. Expired keys are deleted by a cleanup job.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    request_hash TEXT NOT NULL,
+    status INTEGER NOT NULL DEFAULT 0,
+    content_type TEXT NOT NULL DEFAULT '',
+    location TEXT NOT NULL DEFAULT '',
+    body BLOB NOT NULL DEFAULT x'',
+    created_at TEXT NOT NULL,
+    expires_at TEXT NOT NULL,
+    PRIMARY KEY (user_id, idempotency_key),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+
+-- Comments table (messages left on tasks by the users who can see them); created_at is sortable UTC text
+CREATE TABLE IF NOT EXISTS comments (
+    id TEXT PRIMARY KEY,
+    task_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_comments_task_id ON comments(task_id, created_at);
+
+-- Comment mentions table (users mentioned in a comment with @email or @name, who were notified)
+-- Only users who could see the task when the comment was left are recorded
+CREATE TABLE IF NOT EXISTS comment_mentions (
+    comment_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    PRIMARY KEY (comment_id, user_id),
+    FOREIGN KEY (comment_id) REFERENCES comments(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_mentions_user_id ON comment_mentions(user_id);
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// hostileStrings seed the fuzz tests feeding client input to the database: SQL injections,
+// NUL bytes, LIKE wildcards, very long unicode and invalid UTF-8
+var hostileStrings = []string{
+	"",
+	"' OR '1'='1",
+	"'; DROP TABLE tasks; --",
+	`") UNION SELECT password_hash, email FROM users --`,
+	"a\x00b",
+	"\x00",
+	`100% _done_ \ back\slash`,
+	strings.Repeat("é𝄞漢", 4000),
+	"\xff\xfe invalid",
+	"x' /*",
+}
+
+func TestQueryLog_Containing(t *testing.T) {
+	log := &QueryLog{}
+	db, err := NewSQLiteDBWithHook(":memory:", time.Second, log.Hook)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx, "UPDATE tasks SET title = ? WHERE id = ?", "parameterized", "t-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, "UPDATE tasks SET title = 'inlined' WHERE id = 't-1'"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := log.Containing("parameterized"); len(got) != 0 {
+		t.Errorf("Containing() = %v, want no query for an argument", got)
+	}
+	if got := log.Containing("inlined"); len(got) != 1 {
+		t.Errorf("Containing() = %v, want the query built from the value", got)
+	}
+	if len(log.Queries()) < 3 {
+		t.Errorf("Queries() = %d, want the schema and both updates", len(log.Queries()))
+	}
+}
+
+// FuzzSQLiteRepositories_HostileStrings stores and looks up hostile strings: they must come
+// back unchanged, leave the tables in place, and never show in the text of a statement
+func FuzzSQLiteRepositories_HostileStrings(f *testing.F) {
+	for _, s := range hostileStrings {
+		f.Add(s)
+	}
+
+	log := &QueryLog{}
+	db, err := NewSQLiteDBWithHook(":memory:", time.Second, log.Hook)
+	if err != nil {
+		f.Fatalf("Failed to open database: %v", err)
+	}
+	f.Cleanup(func() { db.Close() })
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	tasks := NewSQLiteTaskRepository(db)
+	if err := users.Create(ctx, &application.User{ID: "u-owner", Name: "Owner", Email: "owner@example.com", CreatedAt: time.Now()}); err != nil {
+		f.Fatalf("Failed to create user: %v", err)
+	}
+
+	runs := 0
+	f.Fuzz(func(t *testing.T, s string) {
+		// The marker makes every value unique and, found in a statement, points at it
+		runs++
+		marker := fmt.Sprintf("fuzz-%d:", runs)
+		value := marker + s
+
+		task := &application.Task{
+			ID:             value,
+			Title:          value,
+			Description:    value,
+			Status:         application.StatusPending,
+			Priority:       application.PriorityNormal,
+			OwnerID:        "u-owner",
+			ImagePath:      value,
+			CompletionNote: value,
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
+		}
+		if err := tasks.Create(ctx, task); err != nil {
+			t.Fatalf("Create() error: %v", err)
+		}
+		found, err := tasks.FindByID(ctx, value)
+		if err != nil || found == nil {
+			t.Fatalf("FindByID() = %v, %v; want the task", found, err)
+		}
+		if found.Title != value || found.Description != value || found.CompletionNote != value {
+			t.Errorf("FindByID() changed the strings: %q, %q, %q", found.Title, found.Description, found.CompletionNote)
+		}
+		if withImage, err := tasks.FindByImagePath(ctx, value); err != nil || len(withImage) != 1 {
+			t.Errorf("FindByImagePath() = %d tasks, %v; want the task", len(withImage), err)
+		}
+
+		if err := users.Create(ctx, &application.User{ID: value, Name: value, Email: value, CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("Create() user error: %v", err)
+		}
+		if _, err := users.Search(ctx, value, "u-owner", 10); err != nil {
+			t.Errorf("Search() error: %v", err)
+		}
+		if user, err := users.FindByEmail(ctx, value); err != nil || user == nil || user.Name != value {
+			t.Errorf("FindByEmail() = %v, %v; want the user", user, err)
+		}
+
+		if owned, err := tasks.FindByOwnerID(ctx, "u-owner"); err != nil || len(owned) != runs {
+			t.Errorf("FindByOwnerID() = %d tasks, %v; want %d", len(owned), err, runs)
+		}
+		if inlined := log.Containing(marker); len(inlined) > 0 {
+			t.Errorf("statements built from client input: %q", inlined)
+		}
+	})
+}
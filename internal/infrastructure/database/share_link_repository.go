@@ -0,0 +1,142 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteShareLinkRepository implements repository.ShareLinkRepository using SQLite
+type SQLiteShareLinkRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteShareLinkRepository creates a new SQLiteShareLinkRepository
+func NewSQLiteShareLinkRepository(db *sql.DB) *SQLiteShareLinkRepository {
+	return &SQLiteShareLinkRepository{db: db}
+}
+
+// shareLinkColumns lists the columns scanned by scanShareLink
+const shareLinkColumns = `id, task_id, created_by, token_hash, expires_at, revoked_at, created_at`
+
+// Create creates a new share link using prepared statement
+func (r *SQLiteShareLinkRepository) Create(ctx context.Context, link *application.ShareLink) error {
+	query := `INSERT INTO share_links (` + shareLinkColumns + `)
+	          VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	var revokedAt sql.NullString
+	if link.RevokedAt != nil {
+		revokedAt = sql.NullString{String: link.RevokedAt.UTC().Format(sortableTimeLayout), Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		link.ID,
+		link.TaskID,
+		link.CreatedBy,
+		link.TokenHash,
+		link.ExpiresAt.UTC().Format(sortableTimeLayout),
+		revokedAt,
+		link.CreatedAt.UTC().Format(sortableTimeLayout),
+	)
+	return err
+}
+
+// FindByID finds a share link by ID using prepared statement
+func (r *SQLiteShareLinkRepository) FindByID(ctx context.Context, id string) (*application.ShareLink, error) {
+	query := `SELECT ` + shareLinkColumns + ` FROM share_links WHERE id = ?`
+
+	link, err := scanShareLink(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, application.ErrShareLinkNotFound
+	}
+	return link, err
+}
+
+// FindByTokenHash finds a share link by token hash using prepared statement
+func (r *SQLiteShareLinkRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*application.ShareLink, error) {
+	query := `SELECT ` + shareLinkColumns + ` FROM share_links WHERE token_hash = ?`
+
+	link, err := scanShareLink(r.db.QueryRowContext(ctx, query, tokenHash))
+	if err == sql.ErrNoRows {
+		return nil, application.ErrShareLinkNotFound
+	}
+	return link, err
+}
+
+// FindActiveByTaskID finds the unrevoked, unexpired links of a task using prepared statement
+func (r *SQLiteShareLinkRepository) FindActiveByTaskID(ctx context.Context, taskID string, now time.Time) ([]*application.ShareLink, error) {
+	query := `SELECT ` + shareLinkColumns + `
+	          FROM share_links WHERE task_id = ? AND revoked_at IS NULL AND expires_at > ?
+	          ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, taskID, now.UTC().Format(sortableTimeLayout))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []*application.ShareLink
+	for rows.Next() {
+		link, err := scanShareLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
+
+// Revoke marks an unrevoked share link as revoked using prepared statement
+func (r *SQLiteShareLinkRepository) Revoke(ctx context.Context, id string, revokedAt time.Time) (bool, error) {
+	query := `UPDATE share_links SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, revokedAt.UTC().Format(sortableTimeLayout), id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// scanShareLink scans a row selected with shareLinkColumns
+func scanShareLink(row rowScanner) (*application.ShareLink, error) {
+	var link application.ShareLink
+	var expiresAt, createdAt string
+	var revokedAt sql.NullString
+
+	err := row.Scan(
+		&link.ID,
+		&link.TaskID,
+		&link.CreatedBy,
+		&link.TokenHash,
+		&expiresAt,
+		&revokedAt,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if link.ExpiresAt, err = time.Parse(sortableTimeLayout, expiresAt); err != nil {
+		return nil, err
+	}
+	if link.CreatedAt, err = time.Parse(sortableTimeLayout, createdAt); err != nil {
+		return nil, err
+	}
+	if revokedAt.Valid {
+		revoked, err := time.Parse(sortableTimeLayout, revokedAt.String)
+		if err != nil {
+			return nil, err
+		}
+		link.RevokedAt = &revoked
+	}
+
+	return &link, nil
+}
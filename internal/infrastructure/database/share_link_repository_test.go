@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteShareLinkRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := NewSQLiteUserRepository(db).Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	task, err := application.NewTask("task-1", "Pagar contas", "", application.StatusPending, "u-ana", "")
+	if err != nil {
+		t.Fatalf("NewTask() error: %v", err)
+	}
+	if err := NewSQLiteTaskRepository(db).Create(ctx, task); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	repo := NewSQLiteShareLinkRepository(db)
+
+	missing, err := repo.FindByTokenHash(ctx, "missing")
+	if !errors.Is(err, application.ErrShareLinkNotFound) || missing != nil {
+		t.Fatalf("FindByTokenHash() of missing link = %v, %v; want nil, ErrShareLinkNotFound", missing, err)
+	}
+
+	now := time.Now()
+	for _, l := range []struct {
+		id      string
+		created time.Duration
+	}{
+		{"link-expired", -8 * 24 * time.Hour},
+		{"link-old", -time.Hour},
+		{"link-new", -time.Minute},
+	} {
+		link, err := application.NewShareLink(l.id, "task-1", "u-ana", "hash-"+l.id, 7, now.Add(l.created))
+		if err != nil {
+			t.Fatalf("NewShareLink() error: %v", err)
+		}
+		if err := repo.Create(ctx, link); err != nil {
+			t.Fatalf("Create() error: %v", err)
+		}
+	}
+
+	found, err := repo.FindByTokenHash(ctx, "hash-link-old")
+	if err != nil || found == nil || found.ID != "link-old" || found.RevokedAt != nil {
+		t.Fatalf("FindByTokenHash() = %+v, %v; want link-old", found, err)
+	}
+
+	active, err := repo.FindActiveByTaskID(ctx, "task-1", now)
+	if err != nil {
+		t.Fatalf("FindActiveByTaskID() error: %v", err)
+	}
+	if len(active) != 2 || active[0].ID != "link-new" || active[1].ID != "link-old" {
+		t.Fatalf("FindActiveByTaskID() = %v, want link-new and link-old", shareLinkIDs(active))
+	}
+
+	revoked, err := repo.Revoke(ctx, "link-old", now)
+	if err != nil || !revoked {
+		t.Fatalf("Revoke() = %v, %v; want true", revoked, err)
+	}
+	revoked, err = repo.Revoke(ctx, "link-old", now)
+	if err != nil || revoked {
+		t.Fatalf("second Revoke() = %v, %v; want false", revoked, err)
+	}
+
+	found, err = repo.FindByID(ctx, "link-old")
+	if err != nil || found == nil || found.RevokedAt == nil {
+		t.Fatalf("FindByID() after Revoke = %+v, %v; want a revoked link", found, err)
+	}
+	active, err = repo.FindActiveByTaskID(ctx, "task-1", now)
+	if err != nil || len(active) != 1 || active[0].ID != "link-new" {
+		t.Fatalf("FindActiveByTaskID() after Revoke = %v, %v; want link-new", shareLinkIDs(active), err)
+	}
+
+	if err := NewSQLiteTaskRepository(db).Delete(ctx, "task-1"); err != nil {
+		t.Fatalf("Failed to delete task: %v", err)
+	}
+	found, err = repo.FindByTokenHash(ctx, "hash-link-new")
+	if !errors.Is(err, application.ErrShareLinkNotFound) || found != nil {
+		t.Errorf("FindByTokenHash() after task deletion = %+v, %v; want nil, ErrShareLinkNotFound", found, err)
+	}
+}
+
+func shareLinkIDs(links []*application.ShareLink) []string {
+	var ids []string
+	for _, link := range links {
+		ids = append(ids, link.ID)
+	}
+	return ids
+}
@@ -3,37 +3,71 @@ package database
 import (
 	"context"
 	"database/sql"
+	"strings"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
 )
 
 // SQLiteShareRepository implements repository.ShareRepository using SQLite
 type SQLiteShareRepository struct {
-	db *sql.DB
+	db    *sql.DB
+	stmts *preparedStatements
 }
 
 // NewSQLiteShareRepository creates a new SQLiteShareRepository
 func NewSQLiteShareRepository(db *sql.DB) *SQLiteShareRepository {
-	return &SQLiteShareRepository{db: db}
+	return &SQLiteShareRepository{db: db, stmts: newPreparedStatements(db)}
 }
 
-// Share shares a task with a user using prepared statement
+// Share invites a user to a task using prepared statement. The primary key keeps a single row
+// per task and user, so inviting again leaves the share, accepted or pending, as it was.
 func (r *SQLiteShareRepository) Share(ctx context.Context, taskID, userID string) error {
-	query := `INSERT INTO task_shares (task_id, user_id) VALUES (?, ?)`
-	_, err := r.db.ExecContext(ctx, query, taskID, userID)
+	query := `INSERT INTO task_shares (task_id, user_id, status) VALUES (?, ?, 'pending')
+	          ON CONFLICT (task_id, user_id) DO NOTHING`
+	_, err := r.stmts.ExecContext(ctx, query, taskID, userID)
 	return err
 }
 
 // Unshare removes sharing of a task with a user using prepared statement
 func (r *SQLiteShareRepository) Unshare(ctx context.Context, taskID, userID string) error {
 	query := `DELETE FROM task_shares WHERE task_id = ? AND user_id = ?`
-	_, err := r.db.ExecContext(ctx, query, taskID, userID)
+	_, err := r.stmts.ExecContext(ctx, query, taskID, userID)
 	return err
 }
 
-// FindSharedUsers finds all users a task is shared with using prepared statement
-func (r *SQLiteShareRepository) FindSharedUsers(ctx context.Context, taskID string) ([]string, error) {
+// FindSharedUsers finds all users that accepted to share a task, with their names and emails,
+// using prepared statement
+func (r *SQLiteShareRepository) FindSharedUsers(ctx context.Context, taskID string) ([]*application.SharedUser, error) {
+	query := `SELECT u.id, u.name, u.email, ts.shared_at
+	          FROM task_shares ts
+	          INNER JOIN users u ON u.id = ts.user_id
+	          WHERE ts.task_id = ? AND ts.status = 'accepted'
+	          ORDER BY ts.shared_at, u.name`
+
+	rows, err := r.stmts.QueryContext(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*application.SharedUser
+	for rows.Next() {
+		var user application.SharedUser
+		if err := rows.Scan(&user.UserID, &user.Name, &user.Email, &user.SharedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+
+	return users, rows.Err()
+}
+
+// FindInvitedUserIDs finds the IDs of the users a task is shared with, accepted or still pending,
+// using prepared statement
+func (r *SQLiteShareRepository) FindInvitedUserIDs(ctx context.Context, taskID string) ([]string, error) {
 	query := `SELECT user_id FROM task_shares WHERE task_id = ?`
 
-	rows, err := r.db.QueryContext(ctx, query, taskID)
+	rows, err := r.stmts.QueryContext(ctx, query, taskID)
 	if err != nil {
 		return nil, err
 	}
@@ -51,12 +85,116 @@ func (r *SQLiteShareRepository) FindSharedUsers(ctx context.Context, taskID stri
 	return userIDs, rows.Err()
 }
 
-// IsSharedWith checks if a task is shared with a user using prepared statement
+// FindSharedUsersByTaskIDs finds the users that accepted to share several tasks in a single query
+func (r *SQLiteShareRepository) FindSharedUsersByTaskIDs(ctx context.Context, taskIDs []string) (map[string][]string, error) {
+	shared := make(map[string][]string)
+	if len(taskIDs) == 0 {
+		return shared, nil
+	}
+
+	args := make([]any, len(taskIDs))
+	for i, id := range taskIDs {
+		args[i] = id
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(taskIDs)), ", ")
+	query := `SELECT task_id, user_id FROM task_shares
+	          WHERE task_id IN (` + placeholders + `) AND status = 'accepted'
+	          ORDER BY shared_at, user_id`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID, userID string
+		if err := rows.Scan(&taskID, &userID); err != nil {
+			return nil, err
+		}
+		shared[taskID] = append(shared[taskID], userID)
+	}
+
+	return shared, rows.Err()
+}
+
+// FindPendingInvitations finds the invitations a user hasn't answered, newest first, using
+// prepared statement
+func (r *SQLiteShareRepository) FindPendingInvitations(ctx context.Context, userID string) ([]*application.ShareInvitation, error) {
+	query := `SELECT ts.task_id, t.title, t.owner_id, u.name, ts.user_id, ts.shared_at
+	          FROM task_shares ts
+	          INNER JOIN tasks t ON t.id = ts.task_id
+	          INNER JOIN users u ON u.id = t.owner_id
+	          WHERE ts.user_id = ? AND ts.status = 'pending' AND t.deleted_at IS NULL
+	          ORDER BY ts.shared_at DESC`
+
+	rows, err := r.stmts.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations []*application.ShareInvitation
+	for rows.Next() {
+		var invitation application.ShareInvitation
+		if err := rows.Scan(&invitation.TaskID, &invitation.TaskTitle, &invitation.OwnerID, &invitation.OwnerName, &invitation.UserID, &invitation.InvitedAt); err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, &invitation)
+	}
+
+	return invitations, rows.Err()
+}
+
+// AcceptInvitation turns a pending invitation into a share using prepared statement
+func (r *SQLiteShareRepository) AcceptInvitation(ctx context.Context, taskID, userID string) error {
+	query := `UPDATE task_shares SET status = 'accepted', shared_at = CURRENT_TIMESTAMP
+	          WHERE task_id = ? AND user_id = ? AND status = 'pending'`
+	return r.answerInvitation(ctx, query, taskID, userID)
+}
+
+// DeclineInvitation removes a pending invitation using prepared statement
+func (r *SQLiteShareRepository) DeclineInvitation(ctx context.Context, taskID, userID string) error {
+	query := `DELETE FROM task_shares WHERE task_id = ? AND user_id = ? AND status = 'pending'`
+	return r.answerInvitation(ctx, query, taskID, userID)
+}
+
+// answerInvitation runs a statement on a pending invitation, reporting
+// application.ErrInvitationNotFound when it matched none
+func (r *SQLiteShareRepository) answerInvitation(ctx context.Context, query, taskID, userID string) error {
+	result, err := r.stmts.ExecContext(ctx, query, taskID, userID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return application.ErrInvitationNotFound
+	}
+	return nil
+}
+
+// DeleteAllShares removes every share of a task using prepared statement
+func (r *SQLiteShareRepository) DeleteAllShares(ctx context.Context, taskID string) error {
+	query := `DELETE FROM task_shares WHERE task_id = ?`
+	_, err := r.stmts.ExecContext(ctx, query, taskID)
+	return err
+}
+
+// IsSharedWith checks if a task is shared with a user, through an accepted share, its project or
+// its org, using prepared statement
 func (r *SQLiteShareRepository) IsSharedWith(ctx context.Context, taskID, userID string) (bool, error) {
-	query := `SELECT COUNT(*) FROM task_shares WHERE task_id = ? AND user_id = ?`
+	query := `SELECT (SELECT COUNT(*) FROM task_shares WHERE task_id = ? AND user_id = ? AND status = 'accepted')
+	               + (SELECT COUNT(*) FROM tasks t
+	                  INNER JOIN project_shares ps ON ps.project_id = t.project_id
+	                  WHERE t.id = ? AND ps.user_id = ?)
+	               + (SELECT COUNT(*) FROM tasks WHERE id = ? AND org_id IN (` + sharedOrgIDs + `))`
 
 	var count int
-	err := r.db.QueryRowContext(ctx, query, taskID, userID).Scan(&count)
+	err := r.stmts.QueryRowContext(ctx, query, taskID, userID, taskID, userID, taskID, userID).Scan(&count)
 	if err != nil {
 		return false, err
 	}
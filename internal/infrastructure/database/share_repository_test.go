@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteShareRepository_Invitations(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	for _, user := range []*application.User{
+		{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()},
+		{ID: "u-bia", Name: "Bia", Email: "bia@example.com", CreatedAt: time.Now()},
+	} {
+		if err := users.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	tasks := NewSQLiteTaskRepository(db)
+	for _, id := range []string{"t-1", "t-2"} {
+		task, _ := application.NewTask(id, "Relatório "+id, "", application.StatusPending, "u-ana", "")
+		if err := tasks.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	shares := NewSQLiteShareRepository(db)
+	for _, id := range []string{"t-1", "t-2"} {
+		if err := shares.Share(ctx, id, "u-bia"); err != nil {
+			t.Fatalf("Share() error: %v", err)
+		}
+	}
+
+	// Inviting again keeps a single invitation
+	if err := shares.Share(ctx, "t-1", "u-bia"); err != nil {
+		t.Fatalf("Share() again error: %v", err)
+	}
+
+	// Pending invitations count as invited
+	if userIDs, err := shares.FindInvitedUserIDs(ctx, "t-1"); err != nil || len(userIDs) != 1 || userIDs[0] != "u-bia" {
+		t.Errorf("FindInvitedUserIDs() = %v, %v; want u-bia", userIDs, err)
+	}
+
+	// Pending invitations grant no access
+	if shared, err := shares.IsSharedWith(ctx, "t-1", "u-bia"); err != nil || shared {
+		t.Errorf("IsSharedWith() before accepting = %v, %v; want false", shared, err)
+	}
+	if sharedTasks, err := tasks.FindSharedWithUser(ctx, "u-bia"); err != nil || len(sharedTasks) != 0 {
+		t.Errorf("FindSharedWithUser() before accepting = %v, %v; want none", sharedTasks, err)
+	}
+	invitations, err := shares.FindPendingInvitations(ctx, "u-bia")
+	if err != nil || len(invitations) != 2 {
+		t.Fatalf("FindPendingInvitations() = %v, %v; want 2", invitations, err)
+	}
+	if invitations[0].OwnerName != "Ana" || invitations[0].TaskTitle == "" || invitations[0].InvitedAt.IsZero() {
+		t.Errorf("invitation = %+v, want the task title, owner name and date", invitations[0])
+	}
+
+	// Accepting shares the task, declining drops the invitation
+	if err := shares.AcceptInvitation(ctx, "t-1", "u-bia"); err != nil {
+		t.Fatalf("AcceptInvitation() error: %v", err)
+	}
+	if err := shares.DeclineInvitation(ctx, "t-2", "u-bia"); err != nil {
+		t.Fatalf("DeclineInvitation() error: %v", err)
+	}
+	if shared, err := shares.IsSharedWith(ctx, "t-1", "u-bia"); err != nil || !shared {
+		t.Errorf("IsSharedWith() after accepting = %v, %v; want true", shared, err)
+	}
+	if err := shares.Share(ctx, "t-1", "u-bia"); err != nil {
+		t.Fatalf("Share() after accepting error: %v", err)
+	}
+	if shared, err := shares.IsSharedWith(ctx, "t-1", "u-bia"); err != nil || !shared {
+		t.Errorf("IsSharedWith() after inviting again = %v, %v; want the share still accepted", shared, err)
+	}
+	if sharedTasks, err := tasks.FindSharedWithUser(ctx, "u-bia"); err != nil || len(sharedTasks) != 1 || sharedTasks[0].ID != "t-1" {
+		t.Errorf("FindSharedWithUser() = %v, %v; want t-1", sharedTasks, err)
+	}
+	if users, err := shares.FindSharedUsers(ctx, "t-1"); err != nil || len(users) != 1 || users[0].UserID != "u-bia" || users[0].Name == "" || users[0].Email == "" || users[0].SharedAt.IsZero() {
+		t.Errorf("FindSharedUsers() = %v, %v; want u-bia with name, email and date", users, err)
+	}
+	byTask, err := shares.FindSharedUsersByTaskIDs(ctx, []string{"t-1", "t-2", "t-3"})
+	if err != nil || len(byTask) != 1 || len(byTask["t-1"]) != 1 || byTask["t-1"][0] != "u-bia" {
+		t.Errorf("FindSharedUsersByTaskIDs() = %v, %v; want only t-1 shared with u-bia", byTask, err)
+	}
+	if userIDs, err := shares.FindInvitedUserIDs(ctx, "t-2"); err != nil || len(userIDs) != 0 {
+		t.Errorf("FindInvitedUserIDs() after declining = %v, %v; want none", userIDs, err)
+	}
+	if invitations, err := shares.FindPendingInvitations(ctx, "u-bia"); err != nil || len(invitations) != 0 {
+		t.Errorf("FindPendingInvitations() after answering = %v, %v; want none", invitations, err)
+	}
+
+	// Only pending invitations can be answered
+	for name, answer := range map[string]func(context.Context, string, string) error{
+		"AcceptInvitation":  shares.AcceptInvitation,
+		"DeclineInvitation": shares.DeclineInvitation,
+	} {
+		for _, id := range []string{"t-1", "t-2", "t-3"} {
+			if err := answer(ctx, id, "u-bia"); !errors.Is(err, application.ErrInvitationNotFound) {
+				t.Errorf("%s(%s) error = %v, want ErrInvitationNotFound", name, id, err)
+			}
+		}
+	}
+
+	// The declined task can be shared again
+	if err := shares.Share(ctx, "t-2", "u-bia"); err != nil {
+		t.Errorf("Share() after declining error: %v", err)
+	}
+}
@@ -3,8 +3,12 @@ package database
 import (
 	"database/sql"
 	_ "embed"
+	"fmt"
+	"strings"
+	"time"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/tracing"
+	"github.com/mattn/go-sqlite3"
 )
 
 //go:embed schema.sql
@@ -13,17 +17,35 @@ var schema string
 //go:embed seed.sql
 var seed string
 
-// NewSQLiteDB creates a new SQLite database connection
-func NewSQLiteDB(dbPath string) (*sql.DB, error) {
-	db, err := sql.Open("sqlite3", dbPath)
-	if err != nil {
-		return nil, err
+// DefaultMaxOpenConns is the connection pool size NewSQLiteDB starts with
+const DefaultMaxOpenConns = 16
+
+// connMaxIdleTime is how long a connection above the needs of the current load stays open
+const connMaxIdleTime = 5 * time.Minute
+
+// NewSQLiteDB creates a new SQLite database connection. busyTimeout is how long a query
+// waits for a lock held by another connection before failing with SQLITE_BUSY. A dbPath of
+// ":memory:" opens an empty database that is gone once closed. Queries made within a traced
+// request or job are recorded as spans of its trace. The pool holds up to
+// DefaultMaxOpenConns connections; ConfigurePool resizes it.
+func NewSQLiteDB(dbPath string, busyTimeout time.Duration) (*sql.DB, error) {
+	return NewSQLiteDBWithHook(dbPath, busyTimeout, nil)
+}
+
+// NewSQLiteDBWithHook opens a database like NewSQLiteDB, calling hook with every statement
+// run on it, the schema included. A nil hook adds nothing to the queries.
+func NewSQLiteDBWithHook(dbPath string, busyTimeout time.Duration, hook QueryHook) (*sql.DB, error) {
+	connector := tracing.Connector(&sqlite3.SQLiteDriver{}, sqliteDSN(dbPath, busyTimeout), "sqlite")
+	if hook != nil {
+		connector = &hookedConnector{Connector: connector, hook: hook}
 	}
+	db := sql.OpenDB(connector)
+	ConfigurePool(db, dbPath, DefaultMaxOpenConns)
 
-	// Enable foreign keys
-	if _, err := db.Exec("PRAGMA foreign_keys = ON"); err != nil {
+	// Fail on an unreadable file or a bad path now rather than on the first request
+	if err := db.Ping(); err != nil {
 		db.Close()
-		return nil, err
+		return nil, fmt.Errorf("failed to open database %s: %w", dbPath, err)
 	}
 
 	// Create tables
@@ -32,6 +54,12 @@ func NewSQLiteDB(dbPath string) (*sql.DB, error) {
 		return nil, err
 	}
 
+	// Upgrade tables created by older schema versions
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
 	// Seed demo users
 	if _, err := db.Exec(seed); err != nil {
 		db.Close()
@@ -40,3 +68,36 @@ func NewSQLiteDB(dbPath string) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// ConfigurePool sizes the connection pool of a database opened by NewSQLiteDB. SQLite has a
+// single writer: under WAL more connections let more readers run at once, while writers
+// queue on the database lock for up to the busy timeout whatever the pool size. Idle
+// connections are kept up to the pool size, with their prepared statements and page cache,
+// and closed once unused for a while after a burst.
+func ConfigurePool(db *sql.DB, dbPath string, maxOpenConns int) {
+	// Every connection to ":memory:" opens its own empty database, so a throwaway in-memory
+	// database (integration tests) must live in a single connection
+	if dbPath == ":memory:" {
+		maxOpenConns = 1
+	}
+	db.SetMaxOpenConns(maxOpenConns)
+	db.SetMaxIdleConns(maxOpenConns)
+	if dbPath != ":memory:" {
+		db.SetConnMaxIdleTime(connMaxIdleTime)
+	}
+}
+
+// sqliteDSN adds the connection pragmas to a database path. They go in the DSN rather than
+// in a PRAGMA statement so that every connection of the pool gets them, not only the first:
+// foreign keys, a busy timeout so writers wait for locks instead of failing immediately,
+// WAL journaling so readers don't block on writers, and NORMAL synchronous mode, which
+// under WAL only syncs at checkpoints: a power loss may lose the last commits but never
+// corrupts the database.
+func sqliteDSN(dbPath string, busyTimeout time.Duration) string {
+	separator := "?"
+	if strings.Contains(dbPath, "?") {
+		separator = "&"
+	}
+	return fmt.Sprintf("%s%s_foreign_keys=on&_busy_timeout=%d&_journal_mode=WAL&_synchronous=NORMAL",
+		dbPath, separator, busyTimeout.Milliseconds())
+}
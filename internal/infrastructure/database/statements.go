@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// preparedStatements prepares each query of a repository once and reuses the *sql.Stmt, so the
+// hot queries skip SQL parsing and planning on every call. database/sql re-prepares a statement
+// transparently on each pooled connection it runs on. Inside a transaction started by
+// SQLiteTransactor the statement is bound to the transaction with Tx.StmtContext.
+//
+// Only queries with a fixed text may go through it: each distinct query stays prepared for the
+// lifetime of the database.
+type preparedStatements struct {
+	db    *sql.DB
+	mu    sync.RWMutex
+	stmts map[string]*sql.Stmt
+}
+
+// newPreparedStatements creates a statement cache for db
+func newPreparedStatements(db *sql.DB) *preparedStatements {
+	return &preparedStatements{db: db, stmts: make(map[string]*sql.Stmt)}
+}
+
+// prepare returns the prepared statement of a query, preparing it on first use
+func (p *preparedStatements) prepare(ctx context.Context, query string) (*sql.Stmt, error) {
+	p.mu.RLock()
+	stmt, ok := p.stmts[query]
+	p.mu.RUnlock()
+	if ok {
+		return stmt, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if stmt, ok := p.stmts[query]; ok {
+		return stmt, nil
+	}
+	stmt, err := p.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	p.stmts[query] = stmt
+	return stmt, nil
+}
+
+// stmt returns the prepared statement of a query, bound to the transaction carried by ctx.
+// A query not prepared yet is prepared on the transaction itself: preparing it on the pool
+// would wait for a connection the transaction may be holding, forever with a single one.
+func (p *preparedStatements) stmt(ctx context.Context, query string) (*sql.Stmt, error) {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		p.mu.RLock()
+		stmt, cached := p.stmts[query]
+		p.mu.RUnlock()
+		if !cached {
+			return tx.PrepareContext(ctx, query)
+		}
+		return tx.StmtContext(ctx, stmt), nil
+	}
+	return p.prepare(ctx, query)
+}
+
+// ExecContext runs a prepared query without returning rows. Outside of a transaction a query
+// that finds the database locked is retried; within one the transaction is retried instead.
+func (p *preparedStatements) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	var result sql.Result
+	err := p.retry(ctx, func() error {
+		stmt, err := p.stmt(ctx, query)
+		if err != nil {
+			return err
+		}
+		result, err = stmt.ExecContext(ctx, args...)
+		return err
+	})
+	return result, queryError(query, err)
+}
+
+// QueryContext runs a prepared query returning rows, retried like ExecContext
+func (p *preparedStatements) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := p.retry(ctx, func() error {
+		stmt, err := p.stmt(ctx, query)
+		if err != nil {
+			return err
+		}
+		rows, err = stmt.QueryContext(ctx, args...)
+		return err
+	})
+	return rows, queryError(query, err)
+}
+
+// retry runs op with defaultBusyRetry, or once within a transaction, which must start over
+// as a whole
+func (p *preparedStatements) retry(ctx context.Context, op func() error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return op()
+	}
+	return defaultBusyRetry.do(ctx, op)
+}
+
+// QueryRowContext runs a prepared query returning at most one row. When the query can't be
+// prepared it runs unprepared, so the error surfaces from Scan like it does for *sql.DB.
+func (p *preparedStatements) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	stmt, err := p.stmt(ctx, query)
+	if err != nil {
+		return conn(ctx, p.db).QueryRowContext(ctx, query, args...)
+	}
+	return stmt.QueryRowContext(ctx, args...)
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestPreparedStatements(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	stmts := newPreparedStatements(db)
+	query := `SELECT COUNT(*) FROM users WHERE name = ?`
+
+	first, err := stmts.prepare(ctx, query)
+	if err != nil {
+		t.Fatalf("prepare() error: %v", err)
+	}
+	second, _ := stmts.prepare(ctx, query)
+	if first != second {
+		t.Error("Expected the statement to be prepared once and reused")
+	}
+
+	if _, err := stmts.prepare(ctx, `SELECT * FROM missing_table`); err == nil {
+		t.Error("Expected an invalid query to fail to prepare")
+	}
+	var count int
+	if err := stmts.QueryRowContext(ctx, `SELECT * FROM missing_table`).Scan(&count); err == nil {
+		t.Error("Expected Scan to report the invalid query")
+	}
+
+	// Statements run in the transaction carried by the context
+	users := NewSQLiteUserRepository(db)
+	rollback := errors.New("rollback")
+	err = NewSQLiteTransactor(db).WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := users.Create(ctx, &application.User{ID: "u-tx", Name: "Tx", Email: "tx@example.com", CreatedAt: time.Now()}); err != nil {
+			return err
+		}
+		if err := stmts.QueryRowContext(ctx, query, "Tx").Scan(&count); err != nil || count != 1 {
+			t.Errorf("count in transaction = %d, %v; want 1", count, err)
+		}
+		return rollback
+	})
+	if !errors.Is(err, rollback) {
+		t.Fatalf("WithinTransaction() error = %v", err)
+	}
+	if err := stmts.QueryRowContext(ctx, query, "Tx").Scan(&count); err != nil || count != 0 {
+		t.Errorf("count after rollback = %d, %v; want 0", count, err)
+	}
+}
+
+func TestPreparedStatements_FirstUseInTransaction(t *testing.T) {
+	// An in-memory database has a single connection, held by the transaction
+	db, err := NewSQLiteDB(":memory:", time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	users := NewSQLiteUserRepository(db)
+	err = NewSQLiteTransactor(db).WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := users.Create(ctx, &application.User{ID: "u-tx", Name: "Tx", Email: "tx@example.com", CreatedAt: time.Now()}); err != nil {
+			return err
+		}
+		user, err := users.FindByID(ctx, "u-tx")
+		if err == nil && user == nil {
+			err = errors.New("user not found in the transaction")
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("WithinTransaction() error = %v, want the statements prepared on the transaction", err)
+	}
+}
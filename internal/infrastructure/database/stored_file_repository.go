@@ -0,0 +1,99 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteStoredFileRepository implements repository.StoredFileRepository using SQLite
+type SQLiteStoredFileRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteStoredFileRepository creates a new SQLiteStoredFileRepository
+func NewSQLiteStoredFileRepository(db *sql.DB) *SQLiteStoredFileRepository {
+	return &SQLiteStoredFileRepository{db: db}
+}
+
+// CreateIfWithinQuota records a file using a single conditional insert, so concurrent uploads
+// can't both take the last bytes of a quota. Uploading the same image again in the same second
+// produces the same name and overwrites the file on disk, so its record is updated instead.
+func (r *SQLiteStoredFileRepository) CreateIfWithinQuota(ctx context.Context, file *application.StoredFile, userLimit, globalLimit int64) (bool, error) {
+	query := `INSERT INTO stored_files (kind, name, user_id, size, created_at)
+	          SELECT ?, ?, ?, ?, ?
+	          WHERE (? <= 0 OR (SELECT COALESCE(SUM(size), 0) FROM stored_files WHERE user_id = ?) + ? <= ?)
+	            AND (? <= 0 OR (SELECT COALESCE(SUM(size), 0) FROM stored_files) + ? <= ?)
+	          ON CONFLICT (kind, name) DO UPDATE SET user_id = excluded.user_id, size = excluded.size, created_at = excluded.created_at`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query,
+		string(file.Kind),
+		file.Name,
+		file.UserID,
+		file.Size,
+		file.CreatedAt.UTC().Format(sortableTimeLayout),
+		userLimit, file.UserID, file.Size, userLimit,
+		globalLimit, file.Size, globalLimit,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// FindByName finds the record of a file using prepared statement
+func (r *SQLiteStoredFileRepository) FindByName(ctx context.Context, kind application.StoredFileKind, name string) (*application.StoredFile, error) {
+	query := `SELECT kind, name, user_id, size, created_at FROM stored_files WHERE kind = ? AND name = ?`
+
+	var file application.StoredFile
+	var fileKind, createdAt string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, string(kind), name).Scan(&fileKind, &file.Name, &file.UserID, &file.Size, &createdAt)
+	if err == sql.ErrNoRows {
+		return nil, application.ErrStoredFileNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file.Kind = application.StoredFileKind(fileKind)
+	if file.CreatedAt, err = time.Parse(sortableTimeLayout, createdAt); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// Delete deletes the record of a file using prepared statement
+func (r *SQLiteStoredFileRepository) Delete(ctx context.Context, kind application.StoredFileKind, name string) error {
+	query := `DELETE FROM stored_files WHERE kind = ? AND name = ?`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, string(kind), name)
+	return err
+}
+
+// UsageByUser sums the files of a user using prepared statement
+func (r *SQLiteStoredFileRepository) UsageByUser(ctx context.Context, userID string) (*application.StorageUsage, error) {
+	query := `SELECT COALESCE(SUM(size), 0), COUNT(*) FROM stored_files WHERE user_id = ?`
+
+	usage := &application.StorageUsage{}
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(&usage.Bytes, &usage.Files); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// TotalUsage sums the files of all users
+func (r *SQLiteStoredFileRepository) TotalUsage(ctx context.Context) (*application.StorageUsage, error) {
+	query := `SELECT COALESCE(SUM(size), 0), COUNT(*) FROM stored_files`
+
+	usage := &application.StorageUsage{}
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query).Scan(&usage.Bytes, &usage.Files); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
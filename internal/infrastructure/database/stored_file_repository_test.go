@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteStoredFileRepository_Quota(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	for _, id := range []string{"u-ana", "u-bia"} {
+		if err := users.Create(ctx, &application.User{ID: id, Name: id, Email: id + "@example.com", CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	repo := NewSQLiteStoredFileRepository(db)
+	store := func(kind application.StoredFileKind, name, userID string, size, userLimit, globalLimit int64) bool {
+		t.Helper()
+		file, err := application.NewStoredFile(kind, name, userID, size)
+		if err != nil {
+			t.Fatalf("NewStoredFile() error: %v", err)
+		}
+		created, err := repo.CreateIfWithinQuota(ctx, file, userLimit, globalLimit)
+		if err != nil {
+			t.Fatalf("CreateIfWithinQuota() error: %v", err)
+		}
+		return created
+	}
+
+	tests := []struct {
+		name        string
+		kind        application.StoredFileKind
+		file        string
+		userID      string
+		size        int64
+		userLimit   int64
+		globalLimit int64
+		want        bool
+	}{
+		{"first image", application.StoredFileImage, "a.png", "u-ana", 60, 100, 0, true},
+		{"attachment filling the quota", application.StoredFileAttachment, "b.pdf", "u-ana", 40, 100, 0, true},
+		{"over the user quota", application.StoredFileImage, "c.png", "u-ana", 1, 100, 0, false},
+		{"no quota", application.StoredFileImage, "d.png", "u-ana", 1, 0, 0, true},
+		{"other user", application.StoredFileImage, "e.png", "u-bia", 50, 100, 200, true},
+		{"over the global quota", application.StoredFileImage, "f.png", "u-bia", 50, 100, 200, false},
+		{"same image again", application.StoredFileImage, "a.png", "u-ana", 60, 200, 0, true},
+	}
+
+	for _, tt := range tests {
+		if got := store(tt.kind, tt.file, tt.userID, tt.size, tt.userLimit, tt.globalLimit); got != tt.want {
+			t.Errorf("%s: created = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	usage, err := repo.UsageByUser(ctx, "u-ana")
+	if err != nil {
+		t.Fatalf("UsageByUser() error: %v", err)
+	}
+	if usage.Bytes != 101 || usage.Files != 3 {
+		t.Errorf("UsageByUser() = %+v, want 101 bytes in 3 files", usage)
+	}
+
+	found, err := repo.FindByName(ctx, application.StoredFileImage, "e.png")
+	if err != nil {
+		t.Fatalf("FindByName() error: %v", err)
+	}
+	if found.UserID != "u-bia" || found.Size != 50 || found.Kind != application.StoredFileImage {
+		t.Errorf("FindByName() = %+v, want the image of u-bia", found)
+	}
+
+	if err := repo.Delete(ctx, application.StoredFileAttachment, "b.pdf"); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if _, err := repo.FindByName(ctx, application.StoredFileAttachment, "b.pdf"); !errors.Is(err, application.ErrStoredFileNotFound) {
+		t.Errorf("FindByName() of a deleted file error = %v, want ErrStoredFileNotFound", err)
+	}
+	if err := repo.Delete(ctx, application.StoredFileImage, "b.pdf"); err != nil {
+		t.Fatalf("Delete() of an unknown file error: %v", err)
+	}
+
+	total, err := repo.TotalUsage(ctx)
+	if err != nil {
+		t.Fatalf("TotalUsage() error: %v", err)
+	}
+	if total.Bytes != 111 || total.Files != 3 {
+		t.Errorf("TotalUsage() = %+v, want 111 bytes in 3 files", total)
+	}
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteTaskOverviewQuery implements repository.TaskOverviewQuery using SQLite
+type SQLiteTaskOverviewQuery struct {
+	stmts *preparedStatements
+}
+
+// NewSQLiteTaskOverviewQuery creates a new SQLiteTaskOverviewQuery
+func NewSQLiteTaskOverviewQuery(db *sql.DB) *SQLiteTaskOverviewQuery {
+	return &SQLiteTaskOverviewQuery{stmts: newPreparedStatements(db)}
+}
+
+// taskOverviewQuery returns the query of a page of the task list of a user, made of the tasks
+// after the cursor matching condition, in order. visible gathers the IDs of the tasks owned by
+// the user and of those shared with them, directly or through a project or an org; listed
+// keeps those of the workspace of the context, dropping the deleted ones and the owned ones
+// that are snoozed; counts adds up the whole list and page
+// holds the items. The counts come with every item, or, when the page is empty, on a single
+// row of taskOverviewEmptyColumns.
+func taskOverviewQuery(condition, order string) string {
+	return `WITH visible(task_id) AS (
+	              SELECT id FROM tasks WHERE owner_id = ?
+	              UNION
+	              SELECT task_id FROM task_shares WHERE user_id = ? AND status = 'accepted'
+	              UNION
+	              SELECT tasks.id FROM tasks
+	              INNER JOIN project_shares ON project_shares.project_id = tasks.project_id
+	              WHERE project_shares.user_id = ?
+	              UNION
+	              SELECT id FROM tasks WHERE org_id IN (` + sharedOrgIDs + `)
+	          ),
+	          listed(task_id, owned) AS (
+	              SELECT tasks.id, tasks.owner_id = ? FROM visible
+	              INNER JOIN tasks ON tasks.id = visible.task_id
+	              WHERE tasks.deleted_at IS NULL AND ` + orgScopeCondition + `
+	              AND NOT (tasks.owner_id = ? AND tasks.snoozed_until IS NOT NULL
+	                       AND julianday(tasks.snoozed_until) > julianday(?))
+	          ),
+	          counts(pending, overdue, shared) AS (
+	              SELECT COALESCE(SUM(listed.owned AND tasks.status != ?), 0),
+	                     COALESCE(SUM(listed.owned AND tasks.status != ?
+	                                  AND tasks.due_date IS NOT NULL AND julianday(tasks.due_date) < julianday(?)), 0),
+	                     COALESCE(SUM(NOT listed.owned AND tasks.status != ?), 0)
+	              FROM listed INNER JOIN tasks ON tasks.id = listed.task_id
+	          ),
+	          page(owned, owner_name, sharees, ` + taskColumns + `) AS (
+	              SELECT listed.owned, ` + taskViewColumns + `
+	              FROM listed INNER JOIN tasks ON tasks.id = listed.task_id
+	              WHERE ` + condition + `
+	              ORDER BY ` + order + ` LIMIT ?
+	          )
+	          SELECT counts.pending, counts.overdue, counts.shared, page.* FROM page CROSS JOIN counts
+	          UNION ALL
+	          SELECT counts.pending, counts.overdue, counts.shared, ` + taskOverviewEmptyColumns + `
+	          FROM counts WHERE NOT EXISTS (SELECT 1 FROM page)
+	          ORDER BY ` + order
+}
+
+// taskOverviewEmptyColumns fills the item columns of the row of an empty page, which scans as
+// an item without ID. The page comes first in the query, so the item columns keep the types
+// declared on tasks that the driver scans times by.
+const taskOverviewEmptyColumns = `0, NULL, '[]', ` + emptyTaskColumns
+
+// FindOverview finds a page of the task list of a user and its counters in a single query
+// using prepared statement. Sorts without cursor pagination fall back to newest first.
+func (q *SQLiteTaskOverviewQuery) FindOverview(ctx context.Context, userID string, now time.Time, sort application.TaskSort, after *application.TaskCursor, limit int) (*application.TaskOverview, error) {
+	if _, ok := taskCursorConditions[sort]; !ok {
+		sort = application.SortNewest
+	}
+	completed := string(application.StatusCompleted)
+	args := []any{userID, userID, userID, userID, userID}
+	args = append(args, orgScopeArgs(ctx)...)
+	args = append(args, userID, now, completed, completed, now, completed)
+
+	condition := `1`
+	if after != nil {
+		condition = taskCursorConditions[sort]
+		args = append(args, after.CreatedAt, after.CreatedAt, after.ID)
+	}
+	rows, err := q.stmts.QueryContext(ctx, taskOverviewQuery(condition, taskSortOrders[sort]), append(args, limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	overview := &application.TaskOverview{Items: []*application.TaskListItem{}}
+	for rows.Next() {
+		var owned bool
+		view, err := scanTaskView(prefixedScanner{row: rows, prefix: []any{
+			&overview.Counters.Pending, &overview.Counters.Overdue, &overview.Counters.Shared, &owned,
+		}})
+		if err != nil {
+			return nil, err
+		}
+		if view.ID != "" {
+			overview.Items = append(overview.Items, &application.TaskListItem{TaskView: view, Owned: owned})
+		}
+	}
+
+	return overview, rows.Err()
+}
@@ -0,0 +1,154 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteTaskOverviewQuery_FindOverview(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	for _, user := range []*application.User{
+		{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()},
+		{ID: "u-bia", Name: "Bia", Email: "bia@example.com", CreatedAt: time.Now()},
+	} {
+		if err := users.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	projects := NewSQLiteProjectRepository(db)
+	project, _ := application.NewProject("p-1", "Trabalho", "", "u-bia")
+	if err := projects.Create(ctx, project); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if err := projects.Share(ctx, "p-1", "u-ana"); err != nil {
+		t.Fatalf("Failed to share project: %v", err)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	yesterday := now.Add(-24 * time.Hour)
+	tomorrow := now.Add(24 * time.Hour)
+	tasks := NewSQLiteTaskRepository(db)
+	for i, spec := range []struct {
+		id, owner string
+		status    application.TaskStatus
+		due       *time.Time
+		snoozed   *time.Time
+	}{
+		{"t-1", "u-ana", application.StatusPending, nil, nil},
+		{"t-2", "u-ana", application.StatusInProgress, &yesterday, nil},
+		{"t-3", "u-ana", application.StatusPending, &tomorrow, nil},
+		{"t-4", "u-ana", application.StatusPending, &yesterday, &tomorrow},
+		{"t-5", "u-ana", application.StatusCompleted, &yesterday, nil},
+		{"t-6", "u-ana", application.StatusPending, nil, nil},
+		{"t-7", "u-bia", application.StatusPending, nil, nil},
+		{"t-8", "u-bia", application.StatusCompleted, nil, nil},
+		{"t-9", "u-bia", application.StatusPending, nil, nil},
+		{"t-10", "u-bia", application.StatusPending, nil, nil},
+	} {
+		task, _ := application.NewTask(spec.id, spec.id, "", spec.status, spec.owner, "")
+		task.SetDueDate(spec.due)
+		task.SnoozedUntil = spec.snoozed
+		task.CreatedAt = now.Add(time.Duration(i-10) * time.Minute)
+		if spec.id == "t-9" {
+			task.SetProject("p-1")
+		}
+		if err := tasks.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+	if _, err := db.Exec(`UPDATE tasks SET deleted_at = ? WHERE id = 't-6'`, now); err != nil {
+		t.Fatalf("Failed to trash task: %v", err)
+	}
+	// t-7 and t-8 are shared with Ana and t-9 through Bia's project; t-10 is only invited.
+	// Bia sees the tasks of her own project once.
+	shares := NewSQLiteShareRepository(db)
+	for _, taskID := range []string{"t-7", "t-8", "t-10"} {
+		if err := shares.Share(ctx, taskID, "u-ana"); err != nil {
+			t.Fatalf("Share() error: %v", err)
+		}
+		if taskID == "t-10" {
+			continue
+		}
+		if err := shares.AcceptInvitation(ctx, taskID, "u-ana"); err != nil {
+			t.Fatalf("AcceptInvitation() error: %v", err)
+		}
+	}
+
+	query := NewSQLiteTaskOverviewQuery(db)
+	views := NewSQLiteTaskViewRepository(db)
+	tests := []struct {
+		userID    string
+		sort      application.TaskSort
+		wantItems []string
+	}{
+		{"u-ana", application.SortNewest, []string{"t-9", "t-8", "t-7", "t-5", "t-3", "t-2", "t-1"}},
+		{"u-ana", application.SortOldest, []string{"t-1", "t-2", "t-3", "t-5", "t-7", "t-8", "t-9"}},
+		{"u-bia", application.SortNewest, []string{"t-10", "t-9", "t-8", "t-7"}},
+		{"u-none", application.SortNewest, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.userID+" "+string(tt.sort), func(t *testing.T) {
+			// The counters match the navbar's, whatever the page
+			wantCounters, err := views.CountForUser(ctx, tt.userID, now)
+			if err != nil {
+				t.Fatalf("CountForUser() error: %v", err)
+			}
+
+			var got []string
+			var after *application.TaskCursor
+			for range len(tt.wantItems) + 1 {
+				overview, err := query.FindOverview(ctx, tt.userID, now, tt.sort, after, 3)
+				if err != nil {
+					t.Fatalf("FindOverview() error: %v", err)
+				}
+				if overview.Counters != *wantCounters {
+					t.Errorf("FindOverview() counters = %+v, want %+v", overview.Counters, *wantCounters)
+				}
+				for _, item := range overview.Items {
+					got = append(got, item.ID)
+					if item.Owned != item.IsOwnedBy(tt.userID) {
+						t.Errorf("item %s Owned = %v, want %v", item.ID, item.Owned, !item.Owned)
+					}
+				}
+				if len(overview.Items) < 3 {
+					break
+				}
+				after = overview.Items[len(overview.Items)-1].Cursor()
+			}
+
+			if !reflect.DeepEqual(got, tt.wantItems) {
+				t.Errorf("FindOverview() pages = %v, want %v", got, tt.wantItems)
+			}
+		})
+	}
+
+	overview, err := query.FindOverview(ctx, "u-ana", now, application.SortNewest, nil, 1)
+	if err != nil {
+		t.Fatalf("FindOverview() error: %v", err)
+	}
+	if item := overview.Items[0]; item.OwnerName != "Bia" || item.Owned || item.ProjectID != "p-1" {
+		t.Errorf("t-9 item = %+v, want Bia's task of p-1", item.TaskView)
+	}
+
+	// Past the last item the page is empty, but still counted
+	oldest := &application.TaskCursor{CreatedAt: now.Add(-time.Hour), ID: "t-0"}
+	overview, err = query.FindOverview(ctx, "u-ana", now, application.SortNewest, oldest, 3)
+	if err != nil {
+		t.Fatalf("FindOverview() past the end error: %v", err)
+	}
+	if len(overview.Items) != 0 || overview.Counters != (application.TaskCounters{Pending: 3, Overdue: 1, Shared: 2}) {
+		t.Errorf("FindOverview() past the end = %d items, %+v; want no items and Ana's counters", len(overview.Items), overview.Counters)
+	}
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
@@ -10,42 +11,114 @@ import (
 
 // SQLiteTaskRepository implements repository.TaskRepository using SQLite
 type SQLiteTaskRepository struct {
-	db *sql.DB
+	db    *sql.DB
+	stmts *preparedStatements
 }
 
 // NewSQLiteTaskRepository creates a new SQLiteTaskRepository
 func NewSQLiteTaskRepository(db *sql.DB) *SQLiteTaskRepository {
-	return &SQLiteTaskRepository{db: db}
+	return &SQLiteTaskRepository{db: db, stmts: newPreparedStatements(db)}
 }
 
+// taskColumns lists the columns scanned by scanTask
+const taskColumns = `id, title, description, status, owner_id, image_path, project_id, org_id, due_date, overdue_at, priority, tags, color, snoozed_until, progress, completion_note, created_at, updated_at`
+
+// emptyTaskColumns is a row of zero values scanTask accepts, for queries that must return a row
+// without a task
+const emptyTaskColumns = `'', '', '', '', '', '', '', '', '', '', '', '', '', '', 0, '', '', ''`
+
+// insertTaskQuery inserts a task with taskColumns
+const insertTaskQuery = `INSERT INTO tasks (` + taskColumns + `)
+	          VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
 // Create creates a new task using prepared statement
 func (r *SQLiteTaskRepository) Create(ctx context.Context, task *application.Task) error {
-	query := `INSERT INTO tasks (id, title, description, status, owner_id, image_path, created_at, updated_at)
-	          VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
-
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.stmts.ExecContext(ctx, insertTaskQuery,
 		task.ID,
 		task.Title,
 		task.Description,
 		string(task.Status),
 		task.OwnerID,
 		task.ImagePath,
+		nullableID(task.ProjectID),
+		nullableID(task.OrgID),
+		task.DueDate,
+		task.OverdueAt,
+		string(task.Priority),
+		joinTags(task.Tags),
+		string(task.Color),
+		task.SnoozedUntil,
+		task.Progress,
+		task.CompletionNote,
 		task.CreatedAt,
 		task.UpdatedAt,
 	)
 	return err
 }
 
+// CreateMany creates several tasks in a single transaction using a prepared statement
+func (r *SQLiteTaskRepository) CreateMany(ctx context.Context, tasks []*application.Task) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	prepared, err := r.stmts.prepare(ctx, insertTaskQuery)
+	if err != nil {
+		return err
+	}
+	stmt := tx.StmtContext(ctx, prepared)
+
+	for _, task := range tasks {
+		_, err := stmt.ExecContext(ctx,
+			task.ID,
+			task.Title,
+			task.Description,
+			string(task.Status),
+			task.OwnerID,
+			task.ImagePath,
+			nullableID(task.ProjectID),
+			nullableID(task.OrgID),
+			task.DueDate,
+			task.OverdueAt,
+			string(task.Priority),
+			joinTags(task.Tags),
+			string(task.Color),
+			task.SnoozedUntil,
+			task.Progress,
+			task.CompletionNote,
+			task.CreatedAt,
+			task.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
 // Update updates an existing task using prepared statement
 func (r *SQLiteTaskRepository) Update(ctx context.Context, task *application.Task) error {
-	query := `UPDATE tasks SET title = ?, description = ?, status = ?, image_path = ?, updated_at = ?
+	query := `UPDATE tasks SET title = ?, description = ?, status = ?, owner_id = ?, image_path = ?, project_id = ?, due_date = ?, overdue_at = ?, priority = ?, tags = ?, color = ?, snoozed_until = ?, progress = ?, completion_note = ?, updated_at = ?
 	          WHERE id = ?`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.stmts.ExecContext(ctx, query,
 		task.Title,
 		task.Description,
 		string(task.Status),
+		task.OwnerID,
 		task.ImagePath,
+		nullableID(task.ProjectID),
+		task.DueDate,
+		task.OverdueAt,
+		string(task.Priority),
+		joinTags(task.Tags),
+		string(task.Color),
+		task.SnoozedUntil,
+		task.Progress,
+		task.CompletionNote,
 		task.UpdatedAt,
 		task.ID,
 	)
@@ -55,34 +128,110 @@ func (r *SQLiteTaskRepository) Update(ctx context.Context, task *application.Tas
 // Delete deletes a task using prepared statement
 func (r *SQLiteTaskRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM tasks WHERE id = ?`
-	_, err := r.db.ExecContext(ctx, query, id)
+	_, err := r.stmts.ExecContext(ctx, query, id)
 	return err
 }
 
-// FindByID finds a task by ID using prepared statement
+// FindByID finds a task by ID using prepared statement. It returns application.ErrTaskNotFound
+// when there is none.
 func (r *SQLiteTaskRepository) FindByID(ctx context.Context, id string) (*application.Task, error) {
-	query := `SELECT id, title, description, status, owner_id, image_path, created_at, updated_at
-	          FROM tasks WHERE id = ?`
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE id = ? AND deleted_at IS NULL`
+
+	task, err := scanTask(r.stmts.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, application.ErrTaskNotFound
+	}
+	return task, err
+}
+
+// FindByOwnerID finds all tasks owned by a user in the workspace of ctx using prepared statement
+func (r *SQLiteTaskRepository) FindByOwnerID(ctx context.Context, ownerID string) ([]*application.Task, error) {
+	query := `SELECT ` + taskColumns + `
+	          FROM tasks WHERE owner_id = ? AND deleted_at IS NULL AND ` + orgScopeCondition + `
+	          ORDER BY created_at DESC`
+
+	return r.query(ctx, query, append([]any{ownerID}, orgScopeArgs(ctx)...)...)
+}
+
+// FindByProjectID finds all tasks of a project in the workspace of ctx using prepared statement
+func (r *SQLiteTaskRepository) FindByProjectID(ctx context.Context, projectID string) ([]*application.Task, error) {
+	query := `SELECT ` + taskColumns + `
+	          FROM tasks WHERE project_id = ? AND deleted_at IS NULL AND ` + orgScopeCondition + `
+	          ORDER BY created_at DESC`
+
+	return r.query(ctx, query, append([]any{projectID}, orgScopeArgs(ctx)...)...)
+}
+
+// FindByImagePath finds the tasks whose image is stored at imagePath using prepared statement
+func (r *SQLiteTaskRepository) FindByImagePath(ctx context.Context, imagePath string) ([]*application.Task, error) {
+	query := `SELECT ` + taskColumns + `
+	          FROM tasks WHERE image_path = ?`
+
+	return r.query(ctx, query, imagePath)
+}
+
+// FindSharedWithUser finds all tasks shared with a user, through an accepted share, their
+// project or their org, in the workspace of ctx using prepared statement
+func (r *SQLiteTaskRepository) FindSharedWithUser(ctx context.Context, userID string) ([]*application.Task, error) {
+	query := `SELECT ` + taskColumns + `
+	          FROM tasks
+	          WHERE (id IN (SELECT task_id FROM task_shares WHERE user_id = ? AND status = 'accepted')
+	             OR project_id IN (SELECT project_id FROM project_shares WHERE user_id = ?)
+	             OR (org_id IN (` + sharedOrgIDs + `) AND owner_id != ?))
+	            AND deleted_at IS NULL AND ` + orgScopeCondition + `
+	          ORDER BY created_at DESC`
 
+	return r.query(ctx, query, append([]any{userID, userID, userID, userID}, orgScopeArgs(ctx)...)...)
+}
+
+// query runs a task listing query using prepared statement
+func (r *SQLiteTaskRepository) query(ctx context.Context, query string, args ...any) ([]*application.Task, error) {
+	rows, err := r.stmts.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*application.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, rows.Err()
+}
+
+// scanTask scans a row holding taskColumns
+func scanTask(row rowScanner) (*application.Task, error) {
 	var task application.Task
-	var status string
+	var status, priority, tags, color string
 	var createdAt, updatedAt string
-	var imagePath sql.NullString
+	var imagePath, projectID, orgID, dueDate, overdueAt, snoozedUntil sql.NullString
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := row.Scan(
 		&task.ID,
 		&task.Title,
 		&task.Description,
 		&status,
 		&task.OwnerID,
 		&imagePath,
+		&projectID,
+		&orgID,
+		&dueDate,
+		&overdueAt,
+		&priority,
+		&tags,
+		&color,
+		&snoozedUntil,
+		&task.Progress,
+		&task.CompletionNote,
 		&createdAt,
 		&updatedAt,
 	)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
 		return nil, err
 	}
 
@@ -90,101 +239,46 @@ func (r *SQLiteTaskRepository) FindByID(ctx context.Context, id string) (*applic
 	if imagePath.Valid {
 		task.ImagePath = imagePath.String
 	}
+	task.ProjectID = projectID.String
+	task.OrgID = orgID.String
+	task.DueDate = parseNullTime(dueDate)
+	task.OverdueAt = parseNullTime(overdueAt)
+	task.Priority = application.TaskPriority(priority)
+	task.Tags = splitTags(tags)
+	task.Color = application.TaskColor(color)
+	task.SnoozedUntil = parseNullTime(snoozedUntil)
 	task.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
 	task.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
 
 	return &task, nil
 }
 
-// FindByOwnerID finds all tasks owned by a user using prepared statement
-func (r *SQLiteTaskRepository) FindByOwnerID(ctx context.Context, ownerID string) ([]*application.Task, error) {
-	query := `SELECT id, title, description, status, owner_id, image_path, created_at, updated_at
-	          FROM tasks WHERE owner_id = ? ORDER BY created_at DESC`
-
-	rows, err := r.db.QueryContext(ctx, query, ownerID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var tasks []*application.Task
-	for rows.Next() {
-		var task application.Task
-		var status string
-		var createdAt, updatedAt string
-		var imagePath sql.NullString
-
-		err := rows.Scan(
-			&task.ID,
-			&task.Title,
-			&task.Description,
-			&status,
-			&task.OwnerID,
-			&imagePath,
-			&createdAt,
-			&updatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		task.Status = application.TaskStatus(status)
-		if imagePath.Valid {
-			task.ImagePath = imagePath.String
-		}
-		task.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
-		task.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
-
-		tasks = append(tasks, &task)
-	}
-
-	return tasks, rows.Err()
+// nullableID stores an empty optional reference as NULL, which foreign keys require
+func nullableID(id string) sql.NullString {
+	return sql.NullString{String: id, Valid: id != ""}
 }
 
-// FindSharedWithUser finds all tasks shared with a user using prepared statement
-func (r *SQLiteTaskRepository) FindSharedWithUser(ctx context.Context, userID string) ([]*application.Task, error) {
-	query := `SELECT t.id, t.title, t.description, t.status, t.owner_id, t.image_path, t.created_at, t.updated_at
-	          FROM tasks t
-	          INNER JOIN task_shares ts ON t.id = ts.task_id
-	          WHERE ts.user_id = ?
-	          ORDER BY t.created_at DESC`
-
-	rows, err := r.db.QueryContext(ctx, query, userID)
+// parseNullTime parses an optional RFC 3339 column value
+func parseNullTime(value sql.NullString) *time.Time {
+	if !value.Valid || value.String == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value.String)
 	if err != nil {
-		return nil, err
+		return nil
 	}
-	defer rows.Close()
-
-	var tasks []*application.Task
-	for rows.Next() {
-		var task application.Task
-		var status string
-		var createdAt, updatedAt string
-		var imagePath sql.NullString
-
-		err := rows.Scan(
-			&task.ID,
-			&task.Title,
-			&task.Description,
-			&status,
-			&task.OwnerID,
-			&imagePath,
-			&createdAt,
-			&updatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
+	return &t
+}
 
-		task.Status = application.TaskStatus(status)
-		if imagePath.Valid {
-			task.ImagePath = imagePath.String
-		}
-		task.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
-		task.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+// joinTags stores the tags of a task as a comma-separated list; tags can't contain commas
+func joinTags(tags []string) string {
+	return strings.Join(tags, ",")
+}
 
-		tasks = append(tasks, &task)
+// splitTags parses the tags column written by joinTags
+func splitTags(value string) []string {
+	if value == "" {
+		return nil
 	}
-
-	return tasks, rows.Err()
+	return strings.Split(value, ",")
 }
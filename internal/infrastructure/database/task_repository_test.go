@@ -0,0 +1,290 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteTaskRepository_PriorityAndTags(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := NewSQLiteUserRepository(db).Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	repo := NewSQLiteTaskRepository(db)
+	plain, _ := application.NewTask("t-plain", "Plain", "", application.StatusPending, "u-ana", "")
+	tagged, _ := application.NewTask("t-tagged", "Tagged", "", application.StatusPending, "u-ana", "/uploads/images/a.png")
+	tagged.SetPriority(application.PriorityHigh)
+	tagged.SetTags([]string{"compras", "casa"})
+	tagged.SetColor(application.ColorGreen)
+	for _, task := range []*application.Task{plain, tagged} {
+		if err := repo.Create(ctx, task); err != nil {
+			t.Fatalf("Create() error: %v", err)
+		}
+	}
+
+	found, err := repo.FindByID(ctx, "t-tagged")
+	if err != nil || found.Priority != application.PriorityHigh || strings.Join(found.Tags, ",") != "compras,casa" || found.Color != application.ColorGreen {
+		t.Fatalf("FindByID() = %+v, %v; want high priority, both tags and green", found, err)
+	}
+	found, err = repo.FindByID(ctx, "t-plain")
+	if err != nil || found.Priority != application.PriorityNormal || found.Tags != nil || found.Color != application.ColorNone {
+		t.Fatalf("FindByID() = %+v, %v; want normal priority, no tags and no color", found, err)
+	}
+
+	tagged.SetPriority(application.PriorityLow)
+	tagged.SetTags(nil)
+	tagged.SetColor(application.ColorNone)
+	if err := repo.Update(ctx, tagged); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	found, err = repo.FindByID(ctx, "t-tagged")
+	if err != nil || found.Priority != application.PriorityLow || found.Tags != nil || found.Color != application.ColorNone {
+		t.Fatalf("FindByID() after update = %+v, %v; want low priority, no tags and no color", found, err)
+	}
+
+	withImage, err := repo.FindByImagePath(ctx, "/uploads/images/a.png")
+	if err != nil || len(withImage) != 1 || withImage[0].ID != "t-tagged" {
+		t.Fatalf("FindByImagePath() = %v, %v; want the tagged task", withImage, err)
+	}
+}
+
+func TestSQLiteTaskRepository_FindByIDUnknown(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	task, err := NewSQLiteTaskRepository(db).FindByID(context.Background(), "missing")
+	if !errors.Is(err, application.ErrTaskNotFound) || task != nil {
+		t.Errorf("FindByID() = %v, %v; want ErrTaskNotFound", task, err)
+	}
+}
+
+func TestSQLiteTaskRepository_ImagePath(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := NewSQLiteUserRepository(db).Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	repo := NewSQLiteTaskRepository(db)
+	task, _ := application.NewTask("t-1", "Relatório", "", application.StatusPending, "u-ana", "/uploads/images/a.png")
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	imported, _ := application.NewTask("t-2", "Importada", "", application.StatusPending, "u-ana", "/uploads/images/b.png")
+	if err := repo.CreateMany(ctx, []*application.Task{imported}); err != nil {
+		t.Fatalf("CreateMany() error: %v", err)
+	}
+
+	imageOf := func(id string) string {
+		t.Helper()
+		found, err := repo.FindByID(ctx, id)
+		if err != nil {
+			t.Fatalf("FindByID(%s) error: %v", id, err)
+		}
+		return found.ImagePath
+	}
+	if got := imageOf("t-1"); got != "/uploads/images/a.png" {
+		t.Errorf("ImagePath after Create() = %q, want /uploads/images/a.png", got)
+	}
+	if got := imageOf("t-2"); got != "/uploads/images/b.png" {
+		t.Errorf("ImagePath after CreateMany() = %q, want /uploads/images/b.png", got)
+	}
+	owned, err := repo.FindByOwnerID(ctx, "u-ana")
+	if err != nil || len(owned) != 2 || owned[0].ImagePath == "" || owned[1].ImagePath == "" {
+		t.Errorf("FindByOwnerID() = %v, %v; want both tasks with their images", owned, err)
+	}
+
+	// Replacing and removing the image are kept too
+	if err := task.ReplaceImage("/uploads/images/c.png"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	if got := imageOf("t-1"); got != "/uploads/images/c.png" {
+		t.Errorf("ImagePath after replacing = %q, want /uploads/images/c.png", got)
+	}
+	if err := task.RemoveImage(); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	if got := imageOf("t-1"); got != "" {
+		t.Errorf("ImagePath after removing = %q, want none", got)
+	}
+
+	// Rows written before the column had a value read as no image
+	if _, err := db.ExecContext(ctx, `UPDATE tasks SET image_path = NULL WHERE id = 't-2'`); err != nil {
+		t.Fatal(err)
+	}
+	if got := imageOf("t-2"); got != "" {
+		t.Errorf("ImagePath of a NULL column = %q, want none", got)
+	}
+}
+
+func TestSQLiteTaskRepository_SnoozedUntil(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := NewSQLiteUserRepository(db).Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	repo := NewSQLiteTaskRepository(db)
+	task, _ := application.NewTask("t-1", "Snoozed", "", application.StatusPending, "u-ana", "")
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	until := now.Add(24 * time.Hour)
+	if err := task.Snooze(until, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	found, err := repo.FindByID(ctx, "t-1")
+	if err != nil || found.SnoozedUntil == nil || !found.SnoozedUntil.Equal(until) {
+		t.Fatalf("FindByID() = %+v, %v; want snoozed until %v", found, err, until)
+	}
+
+	task.Wake(now)
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	found, err = repo.FindByID(ctx, "t-1")
+	if err != nil || found.SnoozedUntil != nil {
+		t.Fatalf("FindByID() after waking = %+v, %v; want no snooze", found, err)
+	}
+}
+
+func TestSQLiteTaskRepository_ProgressAndCompletionNote(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := NewSQLiteUserRepository(db).Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	repo := NewSQLiteTaskRepository(db)
+	task, _ := application.NewTask("t-1", "Relatório", "", application.StatusInProgress, "u-ana", "")
+	task.SetProgress(40)
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	found, err := repo.FindByID(ctx, "t-1")
+	if err != nil || found.Progress != 40 || found.CompletionNote != "" {
+		t.Fatalf("FindByID() = %+v, %v; want 40%% progress and no note", found, err)
+	}
+
+	task.CompleteTask()
+	if err := task.SetCompletionNote("Entregue ao cliente"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	found, err = repo.FindByID(ctx, "t-1")
+	if err != nil || found.Progress != 40 || found.CompletionNote != "Entregue ao cliente" {
+		t.Fatalf("FindByID() after completing = %+v, %v; want the progress kept and the note", found, err)
+	}
+
+	if got, want := strings.Count(emptyTaskColumns, ",")+1, len(strings.Split(taskColumns, ",")); got != want {
+		t.Errorf("emptyTaskColumns has %d columns, want %d like taskColumns", got, want)
+	}
+}
+
+// BenchmarkSQLiteTaskRepository_Lists compares the task lists read on every page load with the
+// same queries prepared on every call
+func BenchmarkSQLiteTaskRepository_Lists(b *testing.B) {
+	db, err := NewSQLiteDB(filepath.Join(b.TempDir(), "bench.db"), time.Second)
+	if err != nil {
+		b.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	userRepo := NewSQLiteUserRepository(db)
+	for _, id := range []string{"u-ana", "u-bia"} {
+		if err := userRepo.Create(ctx, &application.User{ID: id, Name: id, Email: id + "@example.com", CreatedAt: time.Now()}); err != nil {
+			b.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	repo := NewSQLiteTaskRepository(db)
+	shareRepo := NewSQLiteShareRepository(db)
+	for i := 0; i < 50; i++ {
+		task, _ := application.NewTask(fmt.Sprintf("t-%02d", i), "Tarefa", "", application.StatusPending, "u-ana", "")
+		if err := repo.Create(ctx, task); err != nil {
+			b.Fatalf("Create() error: %v", err)
+		}
+		if i%2 == 0 {
+			shareRepo.Share(ctx, task.ID, "u-bia")
+			shareRepo.AcceptInvitation(ctx, task.ID, "u-bia")
+		}
+	}
+
+	unprepared := &SQLiteTaskRepository{db: db, stmts: newPreparedStatements(db)}
+	benchmarks := []struct {
+		name string
+		run  func(repo *SQLiteTaskRepository) ([]*application.Task, error)
+		want int
+	}{
+		{"FindByOwnerID", func(r *SQLiteTaskRepository) ([]*application.Task, error) { return r.FindByOwnerID(ctx, "u-ana") }, 50},
+		{"FindSharedWithUser", func(r *SQLiteTaskRepository) ([]*application.Task, error) { return r.FindSharedWithUser(ctx, "u-bia") }, 25},
+	}
+	for _, bm := range benchmarks {
+		for _, variant := range []struct {
+			name  string
+			repo  *SQLiteTaskRepository
+			reset bool
+		}{{"prepared", repo, false}, {"unprepared", unprepared, true}} {
+			b.Run(bm.name+"/"+variant.name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if variant.reset {
+						// Drop the statement so every call parses and plans the query again
+						for _, stmt := range variant.repo.stmts.stmts {
+							stmt.Close()
+						}
+						variant.repo.stmts = newPreparedStatements(db)
+					}
+					tasks, err := bm.run(variant.repo)
+					if err != nil || len(tasks) != bm.want {
+						b.Fatalf("%s = %d tasks, %v; want %d", bm.name, len(tasks), err, bm.want)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteTaskRevisionRepository implements repository.TaskRevisionRepository using SQLite
+type SQLiteTaskRevisionRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteTaskRevisionRepository creates a new SQLiteTaskRevisionRepository
+func NewSQLiteTaskRevisionRepository(db *sql.DB) *SQLiteTaskRevisionRepository {
+	return &SQLiteTaskRevisionRepository{db: db}
+}
+
+// taskRevisionColumns lists the columns scanned by scanTaskRevision
+const taskRevisionColumns = `id, task_id, title, description, status, edited_by, replaced_at`
+
+// Add stores a revision and deletes the oldest ones of its task beyond keep, using prepared
+// statement
+func (r *SQLiteTaskRevisionRepository) Add(ctx context.Context, revision *application.TaskRevision, keep int) error {
+	query := `INSERT INTO task_revisions (task_id, title, description, status, edited_by, replaced_at)
+	          VALUES (?, ?, ?, ?, ?, ?)`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query,
+		revision.TaskID,
+		revision.Title,
+		revision.Description,
+		string(revision.Status),
+		revision.EditedBy,
+		revision.ReplacedAt.UTC().Format(sortableTimeLayout),
+	)
+	if err != nil {
+		return err
+	}
+	if revision.ID, err = result.LastInsertId(); err != nil {
+		return err
+	}
+
+	_, err = conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM task_revisions WHERE task_id = ? AND id NOT IN (
+		     SELECT id FROM task_revisions WHERE task_id = ? ORDER BY id DESC LIMIT ?)`,
+		revision.TaskID, revision.TaskID, keep)
+	return err
+}
+
+// FindByTaskID finds the revisions of a task, newest first, using prepared statement
+func (r *SQLiteTaskRevisionRepository) FindByTaskID(ctx context.Context, taskID string) ([]*application.TaskRevision, error) {
+	query := `SELECT ` + taskRevisionColumns + `
+	          FROM task_revisions WHERE task_id = ?
+	          ORDER BY id DESC`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []*application.TaskRevision
+	for rows.Next() {
+		revision, err := scanTaskRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, rows.Err()
+}
+
+// scanTaskRevision scans a row holding taskRevisionColumns
+func scanTaskRevision(row rowScanner) (*application.TaskRevision, error) {
+	var revision application.TaskRevision
+	var status, replacedAt string
+
+	err := row.Scan(&revision.ID, &revision.TaskID, &revision.Title, &revision.Description, &status, &revision.EditedBy, &replacedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	revision.Status = application.TaskStatus(status)
+	revision.ReplacedAt, _ = time.Parse(sortableTimeLayout, replacedAt)
+
+	return &revision, nil
+}
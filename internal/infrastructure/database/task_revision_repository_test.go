@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteTaskRevisionRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := NewSQLiteUserRepository(db).Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	taskRepo := NewSQLiteTaskRepository(db)
+	for _, id := range []string{"task-1", "task-2"} {
+		task, _ := application.NewTask(id, "Relatório", "", application.StatusPending, "u-ana", "")
+		if err := taskRepo.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	repo := NewSQLiteTaskRevisionRepository(db)
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	for i := 1; i <= 4; i++ {
+		revision := &application.TaskRevision{
+			TaskID:      "task-1",
+			Title:       fmt.Sprintf("v%d", i),
+			Description: "Revisar",
+			Status:      application.StatusPending,
+			EditedBy:    "u-ana",
+			ReplacedAt:  now.Add(time.Duration(i) * time.Minute),
+		}
+		if err := repo.Add(ctx, revision, 3); err != nil {
+			t.Fatalf("Add() error: %v", err)
+		}
+		if revision.ID == 0 {
+			t.Error("Add() should set the ID of the revision")
+		}
+	}
+	other := &application.TaskRevision{TaskID: "task-2", Title: "other", Status: application.StatusPending, EditedBy: "u-ana", ReplacedAt: now}
+	if err := repo.Add(ctx, other, 3); err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+
+	// Only the 3 newest revisions of task-1 are kept, whatever the other tasks have
+	revisions, err := repo.FindByTaskID(ctx, "task-1")
+	if err != nil || len(revisions) != 3 {
+		t.Fatalf("FindByTaskID() = %d revisions, %v; want 3", len(revisions), err)
+	}
+	for i, want := range []string{"v4", "v3", "v2"} {
+		if revisions[i].Title != want {
+			t.Errorf("revision %d = %q, want %q", i, revisions[i].Title, want)
+		}
+	}
+	if got := revisions[0]; got.Description != "Revisar" || got.EditedBy != "u-ana" || !got.ReplacedAt.Equal(now.Add(4*time.Minute)) {
+		t.Errorf("FindByTaskID() newest = %+v", got)
+	}
+	if revisions, err := repo.FindByTaskID(ctx, "task-2"); err != nil || len(revisions) != 1 {
+		t.Errorf("FindByTaskID(task-2) = %d revisions, %v; want 1", len(revisions), err)
+	}
+
+	// The revisions go with their task
+	if err := taskRepo.Delete(ctx, "task-1"); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if revisions, err := repo.FindByTaskID(ctx, "task-1"); err != nil || len(revisions) != 0 {
+		t.Errorf("FindByTaskID() of a deleted task = %d revisions, %v; want none", len(revisions), err)
+	}
+}
@@ -0,0 +1,150 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteTaskUndoRepository implements repository.TaskUndoRepository using SQLite. Trashed
+// tasks stay in the tasks table with deleted_at set, which the task queries filter out.
+type SQLiteTaskUndoRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteTaskUndoRepository creates a new SQLiteTaskUndoRepository
+func NewSQLiteTaskUndoRepository(db *sql.DB) *SQLiteTaskUndoRepository {
+	return &SQLiteTaskUndoRepository{db: db}
+}
+
+// taskUndoColumns lists the columns scanned by scanTaskUndo
+const taskUndoColumns = `task_id, user_id, action, previous_status, expires_at`
+
+// Save records the undo of an action on a task, replacing the previous one, using prepared
+// statement
+func (r *SQLiteTaskUndoRepository) Save(ctx context.Context, undo *application.TaskUndo) error {
+	query := `INSERT OR REPLACE INTO task_undos (` + taskUndoColumns + `)
+	          VALUES (?, ?, ?, ?, ?)`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		undo.TaskID,
+		undo.UserID,
+		string(undo.Action),
+		string(undo.PreviousStatus),
+		undo.ExpiresAt.UTC().Format(sortableTimeLayout),
+	)
+	return err
+}
+
+// Take removes and returns the undo a user can still take on a task. The conditional DELETE
+// decides who takes it, so an undo is taken once, using prepared statement
+func (r *SQLiteTaskUndoRepository) Take(ctx context.Context, taskID, userID string, now time.Time) (*application.TaskUndo, error) {
+	cutoff := now.UTC().Format(sortableTimeLayout)
+	query := `SELECT ` + taskUndoColumns + `
+	          FROM task_undos WHERE task_id = ? AND user_id = ? AND expires_at > ?`
+
+	undo, err := scanTaskUndo(conn(ctx, r.db).QueryRowContext(ctx, query, taskID, userID, cutoff))
+	if err == sql.ErrNoRows {
+		return nil, application.ErrUndoExpired
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM task_undos WHERE task_id = ? AND user_id = ? AND expires_at > ?`,
+		taskID, userID, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	taken, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if taken == 0 {
+		return nil, application.ErrUndoExpired
+	}
+	return undo, nil
+}
+
+// FindExpired finds a batch of expired undos, oldest first, using prepared statement
+func (r *SQLiteTaskUndoRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]*application.TaskUndo, error) {
+	query := `SELECT ` + taskUndoColumns + `
+	          FROM task_undos WHERE expires_at <= ?
+	          ORDER BY expires_at
+	          LIMIT ?`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, now.UTC().Format(sortableTimeLayout), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var undos []*application.TaskUndo
+	for rows.Next() {
+		undo, err := scanTaskUndo(rows)
+		if err != nil {
+			return nil, err
+		}
+		undos = append(undos, undo)
+	}
+
+	return undos, rows.Err()
+}
+
+// DeleteExpired removes the undo of a task if it expired, using prepared statement
+func (r *SQLiteTaskUndoRepository) DeleteExpired(ctx context.Context, taskID string, now time.Time) (bool, error) {
+	query := `DELETE FROM task_undos WHERE task_id = ? AND expires_at <= ?`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, taskID, now.UTC().Format(sortableTimeLayout))
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// Trash hides a task using prepared statement
+func (r *SQLiteTaskUndoRepository) Trash(ctx context.Context, taskID string, deletedAt time.Time) error {
+	query := `UPDATE tasks SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, deletedAt.UTC().Format(sortableTimeLayout), taskID)
+	return err
+}
+
+// Restore shows a trashed task again using prepared statement
+func (r *SQLiteTaskUndoRepository) Restore(ctx context.Context, taskID string) error {
+	query := `UPDATE tasks SET deleted_at = NULL WHERE id = ?`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, taskID)
+	return err
+}
+
+// FindTrashed finds a trashed task by ID using prepared statement
+func (r *SQLiteTaskUndoRepository) FindTrashed(ctx context.Context, taskID string) (*application.Task, error) {
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE id = ? AND deleted_at IS NOT NULL`
+
+	task, err := scanTask(conn(ctx, r.db).QueryRowContext(ctx, query, taskID))
+	if err == sql.ErrNoRows {
+		return nil, application.ErrTaskNotFound
+	}
+	return task, err
+}
+
+// scanTaskUndo scans a row holding taskUndoColumns
+func scanTaskUndo(row rowScanner) (*application.TaskUndo, error) {
+	var undo application.TaskUndo
+	var action, previousStatus, expiresAt string
+
+	if err := row.Scan(&undo.TaskID, &undo.UserID, &action, &previousStatus, &expiresAt); err != nil {
+		return nil, err
+	}
+
+	undo.Action = application.UndoAction(action)
+	undo.PreviousStatus = application.TaskStatus(previousStatus)
+	undo.ExpiresAt, _ = time.Parse(sortableTimeLayout, expiresAt)
+
+	return &undo, nil
+}
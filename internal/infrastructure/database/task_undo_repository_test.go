@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteTaskUndoRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := NewSQLiteUserRepository(db).Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	taskRepo := NewSQLiteTaskRepository(db)
+	for _, id := range []string{"task-1", "task-2"} {
+		task, _ := application.NewTask(id, "Relatório", "", application.StatusPending, "u-ana", "")
+		if err := taskRepo.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	repo := NewSQLiteTaskUndoRepository(db)
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	// Trashed tasks disappear from the task lookups until restored
+	if err := repo.Trash(ctx, "task-1", now); err != nil {
+		t.Fatalf("Trash() error: %v", err)
+	}
+	if task, err := taskRepo.FindByID(ctx, "task-1"); !errors.Is(err, application.ErrTaskNotFound) {
+		t.Errorf("FindByID() of a trashed task = %v, %v; want ErrTaskNotFound", task, err)
+	}
+	if tasks, err := taskRepo.FindByOwnerID(ctx, "u-ana"); err != nil || len(tasks) != 1 || tasks[0].ID != "task-2" {
+		t.Errorf("FindByOwnerID() = %v, %v; want only task-2", tasks, err)
+	}
+	if task, err := repo.FindTrashed(ctx, "task-1"); err != nil || task == nil || task.Title != "Relatório" {
+		t.Errorf("FindTrashed() = %v, %v; want task-1", task, err)
+	}
+	if task, err := repo.FindTrashed(ctx, "task-2"); !errors.Is(err, application.ErrTaskNotFound) || task != nil {
+		t.Errorf("FindTrashed() of a visible task = %v, %v; want nil, ErrTaskNotFound", task, err)
+	}
+	if err := repo.Restore(ctx, "task-1"); err != nil {
+		t.Fatalf("Restore() error: %v", err)
+	}
+	if task, err := taskRepo.FindByID(ctx, "task-1"); err != nil || task == nil {
+		t.Errorf("FindByID() of a restored task = %v, %v; want task-1", task, err)
+	}
+
+	undo, _ := application.NewTaskUndo("task-1", "u-ana", application.UndoComplete, application.StatusInProgress, now, 10*time.Second)
+	if err := repo.Save(ctx, undo); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	// A later action replaces the undo of the task
+	undo, _ = application.NewTaskUndo("task-1", "u-ana", application.UndoDelete, "", now, 10*time.Second)
+	if err := repo.Save(ctx, undo); err != nil {
+		t.Fatalf("second Save() error: %v", err)
+	}
+	expiring, _ := application.NewTaskUndo("task-2", "u-ana", application.UndoComplete, application.StatusPending, now.Add(-time.Minute), 10*time.Second)
+	if err := repo.Save(ctx, expiring); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	if got, err := repo.Take(ctx, "task-1", "u-bia", now); !errors.Is(err, application.ErrUndoExpired) || got != nil {
+		t.Errorf("Take() by another user = %v, %v; want nil, ErrUndoExpired", got, err)
+	}
+	if got, err := repo.Take(ctx, "task-2", "u-ana", now); !errors.Is(err, application.ErrUndoExpired) || got != nil {
+		t.Errorf("Take() of an expired undo = %v, %v; want nil, ErrUndoExpired", got, err)
+	}
+
+	expired, err := repo.FindExpired(ctx, now, 10)
+	if err != nil || len(expired) != 1 || expired[0].TaskID != "task-2" || expired[0].PreviousStatus != application.StatusPending {
+		t.Fatalf("FindExpired() = %v, %v; want the undo of task-2", expired, err)
+	}
+	if deleted, err := repo.DeleteExpired(ctx, "task-1", now); err != nil || deleted {
+		t.Errorf("DeleteExpired() of an undo still running = %v, %v; want false", deleted, err)
+	}
+	if deleted, err := repo.DeleteExpired(ctx, "task-2", now); err != nil || !deleted {
+		t.Errorf("DeleteExpired() = %v, %v; want true", deleted, err)
+	}
+
+	got, err := repo.Take(ctx, "task-1", "u-ana", now.Add(5*time.Second))
+	if err != nil || got == nil || got.Action != application.UndoDelete || !got.ExpiresAt.Equal(now.Add(10*time.Second)) {
+		t.Fatalf("Take() = %+v, %v; want the delete undo", got, err)
+	}
+	if got, err := repo.Take(ctx, "task-1", "u-ana", now.Add(5*time.Second)); !errors.Is(err, application.ErrUndoExpired) || got != nil {
+		t.Errorf("second Take() = %v, %v; want nil, ErrUndoExpired", got, err)
+	}
+}
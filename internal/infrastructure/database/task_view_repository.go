@@ -0,0 +1,226 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteTaskViewRepository implements repository.TaskViewRepository using SQLite
+type SQLiteTaskViewRepository struct {
+	stmts *preparedStatements
+}
+
+// NewSQLiteTaskViewRepository creates a new SQLiteTaskViewRepository
+func NewSQLiteTaskViewRepository(db *sql.DB) *SQLiteTaskViewRepository {
+	return &SQLiteTaskViewRepository{stmts: newPreparedStatements(db)}
+}
+
+// taskViewColumns lists the columns scanned by scanTaskView: the owner name and the accepted
+// sharees as a JSON array, followed by taskColumns. The correlated subqueries run inside the
+// list query, using the task_shares primary key, so a list costs a single round trip.
+const taskViewColumns = `(SELECT name FROM users WHERE users.id = tasks.owner_id),
+	          (SELECT json_group_array(json_object('user_id', id, 'name', name))
+	           FROM (SELECT users.id, users.name FROM task_shares
+	                 INNER JOIN users ON users.id = task_shares.user_id
+	                 WHERE task_shares.task_id = tasks.id AND task_shares.status = 'accepted'
+	                 ORDER BY users.name, users.id)),
+	          ` + taskColumns
+
+// FindByOwnerID finds a page of the views of the tasks owned by a user that aren't snoozed
+// at now, in the workspace of ctx and the order of sort, using prepared statement
+func (r *SQLiteTaskViewRepository) FindByOwnerID(ctx context.Context, ownerID string, now time.Time, sort application.TaskSort, after *application.TaskCursor, limit int) ([]*application.TaskView, error) {
+	query := `SELECT ` + taskViewColumns + `
+	          FROM tasks WHERE owner_id = ? AND deleted_at IS NULL
+	          AND (snoozed_until IS NULL OR julianday(snoozed_until) <= julianday(?))
+	          AND ` + orgScopeCondition
+
+	return r.page(ctx, query, append([]any{ownerID, now}, orgScopeArgs(ctx)...), sort, after, limit)
+}
+
+// FindByProjectID finds a page of the views of the tasks of a project, in the workspace of ctx
+// and the order of sort, using prepared statement
+func (r *SQLiteTaskViewRepository) FindByProjectID(ctx context.Context, projectID string, sort application.TaskSort, after *application.TaskCursor, limit int) ([]*application.TaskView, error) {
+	query := `SELECT ` + taskViewColumns + `
+	          FROM tasks WHERE project_id = ? AND deleted_at IS NULL AND ` + orgScopeCondition
+
+	return r.page(ctx, query, append([]any{projectID}, orgScopeArgs(ctx)...), sort, after, limit)
+}
+
+// page runs a task view listing query for the limit views after the cursor, nil for the
+// first page, in the order of sort. Sorts without cursor pagination fall back to newest first.
+func (r *SQLiteTaskViewRepository) page(ctx context.Context, query string, args []any, sort application.TaskSort, after *application.TaskCursor, limit int) ([]*application.TaskView, error) {
+	if _, ok := taskCursorConditions[sort]; !ok {
+		sort = application.SortNewest
+	}
+	if after != nil {
+		query += ` AND ` + taskCursorConditions[sort]
+		args = append(args, after.CreatedAt, after.CreatedAt, after.ID)
+	}
+	query += ` ORDER BY ` + taskSortOrders[sort] + ` LIMIT ?`
+
+	return r.query(ctx, query, append(args, limit)...)
+}
+
+// sharedTaskConditions selects the tasks shared with a user that match a TaskListQuery, in the
+// workspace of the context. Its
+// arguments come from sharedTaskArgs; empty filters match every task. instr is used instead
+// of LIKE so tags and searches can't smuggle in wildcards.
+const sharedTaskConditions = `(id IN (SELECT task_id FROM task_shares WHERE user_id = ? AND status = 'accepted')
+	             OR project_id IN (SELECT project_id FROM project_shares WHERE user_id = ?)
+	             OR (org_id IN (` + sharedOrgIDs + `) AND owner_id != ?))
+	          AND deleted_at IS NULL
+	          AND ` + orgScopeCondition + `
+	          AND (? = '' OR status = ?)
+	          AND (? = '' OR instr(',' || tags || ',', ',' || ? || ',') > 0)
+	          AND (? = '' OR color = ?)
+	          AND (? = '' OR instr(lower(title), lower(?)) > 0 OR instr(lower(description), lower(?)) > 0)`
+
+// taskSortOrders holds the ORDER BY clause of each TaskSort. The id breaks ties, so pages
+// don't overlap when tasks share a sort key.
+var taskSortOrders = map[application.TaskSort]string{
+	application.SortNewest:  `created_at DESC, id`,
+	application.SortOldest:  `created_at, id`,
+	application.SortDueDate: `due_date IS NULL, due_date, created_at DESC, id`,
+	application.SortTitle:   `title COLLATE NOCASE, created_at DESC, id`,
+}
+
+// taskCursorConditions holds the condition selecting the tasks after a TaskCursor for the
+// sorts allowed in cursor pagination, following their ORDER BY clause. Its arguments are the
+// creation time twice and the ID of the cursor.
+var taskCursorConditions = map[application.TaskSort]string{
+	application.SortNewest: `(created_at < ? OR (created_at = ? AND id > ?))`,
+	application.SortOldest: `(created_at > ? OR (created_at = ? AND id > ?))`,
+}
+
+// FindSharedWithUser finds a page of the views of the tasks shared with a user that match
+// query, and how many match in total, using prepared statements. In cursor pagination the
+// page starts after the cursor and the total isn't counted.
+func (r *SQLiteTaskViewRepository) FindSharedWithUser(ctx context.Context, userID string, query application.TaskListQuery) ([]*application.TaskView, int, error) {
+	order, ok := taskSortOrders[query.Sort]
+	if !ok {
+		return nil, 0, application.ErrInvalidTaskSort
+	}
+	args := sharedTaskArgs(ctx, userID, query)
+
+	if query.Cursor {
+		pageQuery := `SELECT ` + taskViewColumns + `
+	          FROM tasks WHERE ` + sharedTaskConditions
+		if query.After != nil {
+			condition, ok := taskCursorConditions[query.Sort]
+			if !ok {
+				return nil, 0, application.ErrCursorTaskSort
+			}
+			pageQuery += ` AND ` + condition
+			args = append(args, query.After.CreatedAt, query.After.CreatedAt, query.After.ID)
+		}
+		pageQuery += ` ORDER BY ` + order + ` LIMIT ?`
+
+		views, err := r.query(ctx, pageQuery, append(args, query.PerPage)...)
+		return views, 0, err
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM tasks WHERE ` + sharedTaskConditions
+	if err := r.stmts.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	pageQuery := `SELECT ` + taskViewColumns + `
+	          FROM tasks WHERE ` + sharedTaskConditions + `
+	          ORDER BY ` + order + ` LIMIT ? OFFSET ?`
+	views, err := r.query(ctx, pageQuery, append(args, query.PerPage, query.Offset())...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return views, total, nil
+}
+
+// CountForUser counts the open tasks of a user in the workspace of ctx for the navbar counters
+// in a single aggregate query using prepared statement
+func (r *SQLiteTaskViewRepository) CountForUser(ctx context.Context, userID string, now time.Time) (*application.TaskCounters, error) {
+	query := `SELECT
+	              COALESCE(SUM(owner_id = ? AND (snoozed_until IS NULL OR julianday(snoozed_until) <= julianday(?))), 0),
+	              COALESCE(SUM(owner_id = ? AND (snoozed_until IS NULL OR julianday(snoozed_until) <= julianday(?))
+	                           AND due_date IS NOT NULL AND julianday(due_date) < julianday(?)), 0),
+	              COALESCE(SUM(owner_id != ?), 0)
+	          FROM tasks
+	          WHERE deleted_at IS NULL AND status != ? AND ` + orgScopeCondition + `
+	            AND (owner_id = ?
+	                 OR id IN (SELECT task_id FROM task_shares WHERE user_id = ? AND status = 'accepted')
+	                 OR project_id IN (SELECT project_id FROM project_shares WHERE user_id = ?)
+	                 OR org_id IN (` + sharedOrgIDs + `))`
+
+	args := []any{
+		userID, now,
+		userID, now, now,
+		userID,
+		string(application.StatusCompleted),
+	}
+	args = append(args, orgScopeArgs(ctx)...)
+	args = append(args, userID, userID, userID, userID)
+
+	var counters application.TaskCounters
+	err := r.stmts.QueryRowContext(ctx, query, args...).Scan(&counters.Pending, &counters.Overdue, &counters.Shared)
+	if err != nil {
+		return nil, err
+	}
+
+	return &counters, nil
+}
+
+// sharedTaskArgs returns the arguments of sharedTaskConditions
+func sharedTaskArgs(ctx context.Context, userID string, query application.TaskListQuery) []any {
+	status := string(query.Status)
+	orgScope := orgScopeArgs(ctx)
+	return []any{
+		userID, userID, userID, userID,
+		orgScope[0], orgScope[1],
+		status, status,
+		query.Tag, query.Tag,
+		string(query.Color), string(query.Color),
+		query.Search, query.Search, query.Search,
+	}
+}
+
+// query runs a task view listing query using prepared statement
+func (r *SQLiteTaskViewRepository) query(ctx context.Context, query string, args ...any) ([]*application.TaskView, error) {
+	rows, err := r.stmts.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var views []*application.TaskView
+	for rows.Next() {
+		view, err := scanTaskView(rows)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+
+	return views, rows.Err()
+}
+
+// scanTaskView scans a row holding taskViewColumns
+func scanTaskView(row rowScanner) (*application.TaskView, error) {
+	var ownerName sql.NullString
+	var sharees string
+
+	task, err := scanTask(prefixedScanner{row: row, prefix: []any{&ownerName, &sharees}})
+	if err != nil {
+		return nil, err
+	}
+
+	view := &application.TaskView{Task: task, OwnerName: ownerName.String}
+	if err := json.Unmarshal([]byte(sharees), &view.SharedWith); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
@@ -0,0 +1,441 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteTaskViewRepository_FindByOwnerID(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	for _, user := range []*application.User{
+		{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()},
+		{ID: "u-caio", Name: "Caio", Email: "caio@example.com", CreatedAt: time.Now()},
+		{ID: "u-bia", Name: "Bia", Email: "bia@example.com", CreatedAt: time.Now()},
+		{ID: "u-davi", Name: "Davi", Email: "davi@example.com", CreatedAt: time.Now()},
+	} {
+		if err := users.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	tasks := NewSQLiteTaskRepository(db)
+	for i, id := range []string{"t-1", "t-2"} {
+		task, _ := application.NewTask(id, "Relatório "+id, "", application.StatusPending, "u-ana", "")
+		task.CreatedAt = time.Now().Add(time.Duration(i) * time.Minute)
+		if err := tasks.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	// Only accepted shares are listed; Davi's invitation is still pending
+	shares := NewSQLiteShareRepository(db)
+	for _, userID := range []string{"u-caio", "u-bia", "u-davi"} {
+		if err := shares.Share(ctx, "t-1", userID); err != nil {
+			t.Fatalf("Share() error: %v", err)
+		}
+	}
+	for _, userID := range []string{"u-caio", "u-bia"} {
+		if err := shares.AcceptInvitation(ctx, "t-1", userID); err != nil {
+			t.Fatalf("AcceptInvitation() error: %v", err)
+		}
+	}
+
+	views, err := NewSQLiteTaskViewRepository(db).FindByOwnerID(ctx, "u-ana", time.Now(), application.SortNewest, nil, 10)
+	if err != nil {
+		t.Fatalf("FindByOwnerID() error: %v", err)
+	}
+	if len(views) != 2 || views[0].ID != "t-2" || views[1].ID != "t-1" {
+		t.Fatalf("FindByOwnerID() = %v, want t-2 and t-1, newest first", views)
+	}
+
+	if views[0].OwnerName != "Ana" || views[0].ShareCount() != 0 {
+		t.Errorf("t-2 view = %q shared with %v, want Ana's task shared with nobody", views[0].OwnerName, views[0].SharedWith)
+	}
+	want := []application.Sharee{{UserID: "u-bia", Name: "Bia"}, {UserID: "u-caio", Name: "Caio"}}
+	if !reflect.DeepEqual(views[1].SharedWith, want) {
+		t.Errorf("t-1 SharedWith = %v, want %v", views[1].SharedWith, want)
+	}
+	if views[1].Title != "Relatório t-1" || !views[1].IsOwnedBy("u-ana") {
+		t.Errorf("t-1 view task = %+v, want the scanned task", views[1].Task)
+	}
+}
+
+func TestSQLiteTaskViewRepository_FindByOwnerID_Pages(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := NewSQLiteUserRepository(db).Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	// t-2 and t-3 are created at the same time, so the ID breaks the tie; t-4 is snoozed
+	now := time.Now()
+	tomorrow := now.Add(24 * time.Hour)
+	tasks := NewSQLiteTaskRepository(db)
+	for _, spec := range []struct {
+		id      string
+		age     time.Duration
+		snoozed *time.Time
+	}{
+		{"t-1", 3 * time.Hour, nil},
+		{"t-2", 2 * time.Hour, nil},
+		{"t-3", 2 * time.Hour, nil},
+		{"t-4", time.Hour, &tomorrow},
+		{"t-5", 0, nil},
+	} {
+		task, _ := application.NewTask(spec.id, "Relatório "+spec.id, "", application.StatusPending, "u-ana", "")
+		task.CreatedAt = now.Add(-spec.age)
+		task.SnoozedUntil = spec.snoozed
+		if err := tasks.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	repo := NewSQLiteTaskViewRepository(db)
+	tests := []struct {
+		sort application.TaskSort
+		want [][]string
+	}{
+		{application.SortNewest, [][]string{{"t-5", "t-2"}, {"t-3", "t-1"}}},
+		{application.SortOldest, [][]string{{"t-1", "t-2"}, {"t-3", "t-5"}}},
+	}
+
+	for _, tt := range tests {
+		var pages [][]string
+		var after *application.TaskCursor
+		for len(pages) < 4 {
+			views, err := repo.FindByOwnerID(ctx, "u-ana", now, tt.sort, after, 2)
+			if err != nil {
+				t.Fatalf("FindByOwnerID() error: %v", err)
+			}
+			if len(views) == 0 {
+				break
+			}
+			var ids []string
+			for _, view := range views {
+				ids = append(ids, view.ID)
+			}
+			pages = append(pages, ids)
+			after = views[len(views)-1].Cursor()
+		}
+
+		if !reflect.DeepEqual(pages, tt.want) {
+			t.Errorf("FindByOwnerID(%s) pages = %v, want %v", tt.sort, pages, tt.want)
+		}
+	}
+}
+
+func TestSQLiteTaskViewRepository_FindByProjectID(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	if err := users.Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	project, _ := application.NewProject("p-1", "Trabalho", "", "u-ana")
+	if err := NewSQLiteProjectRepository(db).Create(ctx, project); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	tasks := NewSQLiteTaskRepository(db)
+	for _, id := range []string{"t-1", "t-2"} {
+		task, _ := application.NewTask(id, "Relatório "+id, "", application.StatusPending, "u-ana", "")
+		if id == "t-1" {
+			task.SetProject("p-1")
+		}
+		if err := tasks.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	views, err := NewSQLiteTaskViewRepository(db).FindByProjectID(ctx, "p-1", application.SortNewest, nil, 10)
+	if err != nil {
+		t.Fatalf("FindByProjectID() error: %v", err)
+	}
+	if len(views) != 1 || views[0].ID != "t-1" || views[0].OwnerName != "Ana" || views[0].SharedWith == nil {
+		t.Errorf("FindByProjectID() = %v, want t-1 owned by Ana with an empty share list", views)
+	}
+}
+
+func TestSQLiteTaskViewRepository_FindSharedWithUser(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	for _, user := range []*application.User{
+		{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()},
+		{ID: "u-bia", Name: "Bia", Email: "bia@example.com", CreatedAt: time.Now()},
+		{ID: "u-caio", Name: "Caio", Email: "caio@example.com", CreatedAt: time.Now()},
+	} {
+		if err := users.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	projects := NewSQLiteProjectRepository(db)
+	project, _ := application.NewProject("p-1", "Trabalho", "", "u-bia")
+	if err := projects.Create(ctx, project); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if err := projects.Share(ctx, "p-1", "u-ana"); err != nil {
+		t.Fatalf("Failed to share project: %v", err)
+	}
+
+	// t-1 to t-3 are shared with Ana directly, t-4 through Bia's project; t-5 is only
+	// invited and t-6 is Ana's own
+	due := time.Now().Add(48 * time.Hour)
+	tasks := NewSQLiteTaskRepository(db)
+	for i, spec := range []struct {
+		id, title, owner string
+		status           application.TaskStatus
+		tags             []string
+		due              *time.Time
+	}{
+		{"t-1", "Relatório mensal", "u-bia", application.StatusPending, []string{"work"}, nil},
+		{"t-2", "Comprar pão", "u-caio", application.StatusCompleted, nil, &due},
+		{"t-3", "Agenda", "u-caio", application.StatusPending, []string{"work", "urgent"}, nil},
+		{"t-4", "Orçamento", "u-bia", application.StatusPending, []string{"workshop"}, nil},
+		{"t-5", "Convite", "u-caio", application.StatusPending, nil, nil},
+		{"t-6", "Minha tarefa", "u-ana", application.StatusPending, nil, nil},
+	} {
+		task, _ := application.NewTask(spec.id, spec.title, "", spec.status, spec.owner, "")
+		task.CreatedAt = time.Now().Add(time.Duration(i) * time.Minute)
+		task.SetTags(spec.tags)
+		task.SetDueDate(spec.due)
+		if spec.id == "t-4" {
+			task.SetProject("p-1")
+		}
+		if spec.id == "t-1" || spec.id == "t-6" {
+			task.SetColor(application.ColorBlue)
+		}
+		if err := tasks.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+	shares := NewSQLiteShareRepository(db)
+	for _, taskID := range []string{"t-1", "t-2", "t-3", "t-5"} {
+		if err := shares.Share(ctx, taskID, "u-ana"); err != nil {
+			t.Fatalf("Share() error: %v", err)
+		}
+		if taskID == "t-5" {
+			continue
+		}
+		if err := shares.AcceptInvitation(ctx, taskID, "u-ana"); err != nil {
+			t.Fatalf("AcceptInvitation() error: %v", err)
+		}
+	}
+
+	repo := NewSQLiteTaskViewRepository(db)
+	tests := []struct {
+		name      string
+		status    string
+		tag       string
+		color     string
+		search    string
+		sort      string
+		page      int
+		perPage   int
+		wantIDs   []string
+		wantTotal int
+	}{
+		{name: "newest first", wantIDs: []string{"t-4", "t-3", "t-2", "t-1"}, wantTotal: 4},
+		{name: "oldest first", sort: "created_at", wantIDs: []string{"t-1", "t-2", "t-3", "t-4"}, wantTotal: 4},
+		{name: "due date first", sort: "due_date", wantIDs: []string{"t-2", "t-4", "t-3", "t-1"}, wantTotal: 4},
+		{name: "by title", sort: "title", wantIDs: []string{"t-3", "t-2", "t-4", "t-1"}, wantTotal: 4},
+		{name: "status", status: "completed", wantIDs: []string{"t-2"}, wantTotal: 1},
+		{name: "whole tag only", tag: "work", wantIDs: []string{"t-3", "t-1"}, wantTotal: 2},
+		{name: "color", color: "blue", wantIDs: []string{"t-1"}, wantTotal: 1},
+		{name: "search ignores case", search: "MENSAL", wantIDs: []string{"t-1"}, wantTotal: 1},
+		{name: "search wildcards are literal", search: "%", wantIDs: nil, wantTotal: 0},
+		{name: "second page", page: 2, perPage: 3, wantIDs: []string{"t-1"}, wantTotal: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := application.NewTaskListQuery(tt.status, tt.tag, tt.search, tt.sort, tt.page, tt.perPage)
+			if err == nil {
+				query, err = query.WithColor(tt.color)
+			}
+			if err != nil {
+				t.Fatalf("NewTaskListQuery() error: %v", err)
+			}
+
+			views, total, err := repo.FindSharedWithUser(ctx, "u-ana", query)
+			if err != nil {
+				t.Fatalf("FindSharedWithUser() error: %v", err)
+			}
+			var ids []string
+			for _, view := range views {
+				ids = append(ids, view.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) || total != tt.wantTotal {
+				t.Errorf("FindSharedWithUser() = %v of %d, want %v of %d", ids, total, tt.wantIDs, tt.wantTotal)
+			}
+		})
+	}
+
+	for _, sort := range []string{"-created_at", "created_at"} {
+		t.Run("cursor pages "+sort, func(t *testing.T) {
+			var ids []string
+			var after *application.TaskCursor
+			for pages := 0; pages < 3; pages++ {
+				query, _ := application.NewTaskListQuery("", "", "", sort, 0, 0)
+				query, _ = query.WithCursor(after, 2)
+
+				views, _, err := repo.FindSharedWithUser(ctx, "u-ana", query)
+				if err != nil {
+					t.Fatalf("FindSharedWithUser() error: %v", err)
+				}
+				for _, view := range views {
+					ids = append(ids, view.ID)
+				}
+				if len(views) < 2 {
+					break
+				}
+				after = views[len(views)-1].Cursor()
+			}
+
+			want := []string{"t-4", "t-3", "t-2", "t-1"}
+			if sort == "created_at" {
+				want = []string{"t-1", "t-2", "t-3", "t-4"}
+			}
+			if !reflect.DeepEqual(ids, want) {
+				t.Errorf("cursor pages = %v, want %v", ids, want)
+			}
+		})
+	}
+
+	query, _ := application.NewTaskListQuery("", "", "", "", 0, 0)
+	views, _, err := repo.FindSharedWithUser(ctx, "u-ana", query)
+	if err != nil {
+		t.Fatalf("FindSharedWithUser() error: %v", err)
+	}
+	owners := map[string]string{}
+	for _, view := range views {
+		owners[view.ID] = view.OwnerName
+	}
+	want := map[string]string{"t-1": "Bia", "t-2": "Caio", "t-3": "Caio", "t-4": "Bia"}
+	if !reflect.DeepEqual(owners, want) {
+		t.Errorf("owner names = %v, want %v", owners, want)
+	}
+}
+
+func TestSQLiteTaskViewRepository_CountForUser(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	for _, user := range []*application.User{
+		{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()},
+		{ID: "u-bia", Name: "Bia", Email: "bia@example.com", CreatedAt: time.Now()},
+	} {
+		if err := users.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	projects := NewSQLiteProjectRepository(db)
+	project, _ := application.NewProject("p-1", "Trabalho", "", "u-bia")
+	if err := projects.Create(ctx, project); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if err := projects.Share(ctx, "p-1", "u-ana"); err != nil {
+		t.Fatalf("Failed to share project: %v", err)
+	}
+
+	now := time.Now()
+	yesterday := now.Add(-24 * time.Hour)
+	tomorrow := now.Add(24 * time.Hour)
+	tasks := NewSQLiteTaskRepository(db)
+	for _, spec := range []struct {
+		id, owner string
+		status    application.TaskStatus
+		due       *time.Time
+		snoozed   *time.Time
+	}{
+		{"t-1", "u-ana", application.StatusPending, nil, nil},
+		{"t-2", "u-ana", application.StatusInProgress, &yesterday, nil},
+		{"t-3", "u-ana", application.StatusPending, &tomorrow, nil},
+		{"t-4", "u-ana", application.StatusPending, &yesterday, &tomorrow},
+		{"t-5", "u-ana", application.StatusCompleted, &yesterday, nil},
+		{"t-6", "u-ana", application.StatusPending, nil, nil},
+		{"t-7", "u-bia", application.StatusPending, nil, nil},
+		{"t-8", "u-bia", application.StatusCompleted, nil, nil},
+		{"t-9", "u-bia", application.StatusPending, nil, nil},
+		{"t-10", "u-bia", application.StatusPending, nil, nil},
+		{"t-11", "u-bia", application.StatusPending, nil, nil},
+	} {
+		task, _ := application.NewTask(spec.id, spec.id, "", spec.status, spec.owner, "")
+		task.SetDueDate(spec.due)
+		task.SnoozedUntil = spec.snoozed
+		if spec.id == "t-9" {
+			task.SetProject("p-1")
+		}
+		if err := tasks.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+	if _, err := db.Exec(`UPDATE tasks SET deleted_at = ? WHERE id = 't-6'`, now); err != nil {
+		t.Fatalf("Failed to trash task: %v", err)
+	}
+	// t-7 and t-8 are shared with Ana and t-9 through Bia's project; t-10 is only invited and
+	// t-11 isn't shared at all
+	shares := NewSQLiteShareRepository(db)
+	for _, taskID := range []string{"t-7", "t-8", "t-10"} {
+		if err := shares.Share(ctx, taskID, "u-ana"); err != nil {
+			t.Fatalf("Share() error: %v", err)
+		}
+		if taskID == "t-10" {
+			continue
+		}
+		if err := shares.AcceptInvitation(ctx, taskID, "u-ana"); err != nil {
+			t.Fatalf("AcceptInvitation() error: %v", err)
+		}
+	}
+
+	repo := NewSQLiteTaskViewRepository(db)
+	tests := []struct {
+		userID string
+		want   application.TaskCounters
+	}{
+		{"u-ana", application.TaskCounters{Pending: 3, Overdue: 1, Shared: 2}},
+		{"u-bia", application.TaskCounters{Pending: 4, Overdue: 0, Shared: 0}},
+		{"u-none", application.TaskCounters{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.userID, func(t *testing.T) {
+			counters, err := repo.CountForUser(ctx, tt.userID, now)
+			if err != nil {
+				t.Fatalf("CountForUser() error: %v", err)
+			}
+			if *counters != tt.want {
+				t.Errorf("CountForUser() = %+v, want %+v", *counters, tt.want)
+			}
+		})
+	}
+}
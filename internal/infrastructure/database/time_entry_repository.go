@@ -0,0 +1,171 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteTimeEntryRepository implements repository.TimeEntryRepository using SQLite
+type SQLiteTimeEntryRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteTimeEntryRepository creates a new SQLiteTimeEntryRepository
+func NewSQLiteTimeEntryRepository(db *sql.DB) *SQLiteTimeEntryRepository {
+	return &SQLiteTimeEntryRepository{db: db}
+}
+
+// timeEntryColumns lists the columns scanned by scanTimeEntry
+const timeEntryColumns = `id, task_id, user_id, started_at, stopped_at`
+
+// Start creates a running time entry unless the user already runs a timer on the task, using
+// prepared statement
+func (r *SQLiteTimeEntryRepository) Start(ctx context.Context, entry *application.TimeEntry) (bool, error) {
+	query := `INSERT INTO time_entries (` + timeEntryColumns + `)
+	          SELECT ?, ?, ?, ?, NULL
+	          WHERE NOT EXISTS (SELECT 1 FROM time_entries WHERE task_id = ? AND user_id = ? AND stopped_at IS NULL)`
+
+	result, err := r.db.ExecContext(ctx, query,
+		entry.ID,
+		entry.TaskID,
+		entry.UserID,
+		entry.StartedAt.UTC().Format(sortableTimeLayout),
+		entry.TaskID,
+		entry.UserID,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// FindRunning finds the running entry of a user on a task using prepared statement
+func (r *SQLiteTimeEntryRepository) FindRunning(ctx context.Context, taskID, userID string) (*application.TimeEntry, error) {
+	query := `SELECT ` + timeEntryColumns + `
+	          FROM time_entries WHERE task_id = ? AND user_id = ? AND stopped_at IS NULL`
+
+	entry, err := scanTimeEntry(r.db.QueryRowContext(ctx, query, taskID, userID))
+	if err == sql.ErrNoRows {
+		return nil, application.ErrTimerNotRunning
+	}
+	return entry, err
+}
+
+// FindRunningByUserID finds the running entries of a user using prepared statement
+func (r *SQLiteTimeEntryRepository) FindRunningByUserID(ctx context.Context, userID string) ([]*application.TimeEntry, error) {
+	query := `SELECT ` + timeEntryColumns + `
+	          FROM time_entries WHERE user_id = ? AND stopped_at IS NULL
+	          ORDER BY started_at`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*application.TimeEntry
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// Stop records the end of a running entry using prepared statement
+func (r *SQLiteTimeEntryRepository) Stop(ctx context.Context, id string, stoppedAt time.Time) (bool, error) {
+	query := `UPDATE time_entries SET stopped_at = ? WHERE id = ? AND stopped_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, stoppedAt.UTC().Format(sortableTimeLayout), id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// SumByTaskIDs sums the time tracked on several tasks using prepared statement. The sortable
+// UTC times are read by julianday, and the sum is rounded to the millisecond.
+func (r *SQLiteTimeEntryRepository) SumByTaskIDs(ctx context.Context, taskIDs []string, now time.Time) (map[string]time.Duration, error) {
+	totals := make(map[string]time.Duration)
+	if len(taskIDs) == 0 {
+		return totals, nil
+	}
+
+	args := make([]any, 0, len(taskIDs)+1)
+	args = append(args, now.UTC().Format(sortableTimeLayout))
+	for _, id := range taskIDs {
+		args = append(args, id)
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(taskIDs)), ", ")
+	query := `SELECT task_id,
+	                 CAST(ROUND(SUM(MAX(julianday(COALESCE(stopped_at, ?1)) - julianday(started_at), 0)) * 86400000) AS INTEGER)
+	          FROM time_entries
+	          WHERE task_id IN (` + placeholders + `)
+	          GROUP BY task_id`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID string
+		var milliseconds int64
+		if err := rows.Scan(&taskID, &milliseconds); err != nil {
+			return nil, err
+		}
+		totals[taskID] = time.Duration(milliseconds) * time.Millisecond
+	}
+
+	return totals, rows.Err()
+}
+
+// scanTimeEntry scans a row selected with timeEntryColumns
+func scanTimeEntry(row rowScanner) (*application.TimeEntry, error) {
+	var entry application.TimeEntry
+	var startedAt string
+	var stoppedAt sql.NullString
+
+	err := row.Scan(
+		&entry.ID,
+		&entry.TaskID,
+		&entry.UserID,
+		&startedAt,
+		&stoppedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.StartedAt, err = time.Parse(sortableTimeLayout, startedAt); err != nil {
+		return nil, err
+	}
+	if stoppedAt.Valid {
+		stopped, err := time.Parse(sortableTimeLayout, stoppedAt.String)
+		if err != nil {
+			return nil, err
+		}
+		entry.StoppedAt = &stopped
+	}
+
+	return &entry, nil
+}
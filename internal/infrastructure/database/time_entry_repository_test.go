@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteTimeEntryRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	userRepo := NewSQLiteUserRepository(db)
+	for _, id := range []string{"u-ana", "u-bia"} {
+		if err := userRepo.Create(ctx, &application.User{ID: id, Name: id, Email: id + "@example.com", CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	for _, id := range []string{"task-1", "task-2"} {
+		task, err := application.NewTask(id, "Relatório", "", application.StatusPending, "u-ana", "")
+		if err != nil {
+			t.Fatalf("NewTask() error: %v", err)
+		}
+		if err := NewSQLiteTaskRepository(db).Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	repo := NewSQLiteTimeEntryRepository(db)
+	start := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	running, err := repo.FindRunning(ctx, "task-1", "u-ana")
+	if !errors.Is(err, application.ErrTimerNotRunning) || running != nil {
+		t.Fatalf("FindRunning() without timers = %v, %v; want nil, ErrTimerNotRunning", running, err)
+	}
+
+	entries := []struct {
+		id     string
+		userID string
+		start  time.Time
+	}{
+		{"entry-ana", "u-ana", start},
+		{"entry-bia", "u-bia", start.Add(30 * time.Minute)},
+	}
+	for _, e := range entries {
+		entry, _ := application.NewTimeEntry(e.id, "task-1", e.userID, e.start)
+		started, err := repo.Start(ctx, entry)
+		if err != nil || !started {
+			t.Fatalf("Start(%s) = %v, %v; want true", e.id, started, err)
+		}
+	}
+
+	// A second timer of the same user on the task isn't started
+	again, _ := application.NewTimeEntry("entry-ana-2", "task-1", "u-ana", start.Add(time.Minute))
+	if started, err := repo.Start(ctx, again); err != nil || started {
+		t.Fatalf("second Start() = %v, %v; want false", started, err)
+	}
+
+	running, err = repo.FindRunning(ctx, "task-1", "u-ana")
+	if err != nil || running == nil || running.ID != "entry-ana" || !running.StartedAt.Equal(start) {
+		t.Fatalf("FindRunning() = %+v, %v; want entry-ana", running, err)
+	}
+
+	stopped, err := repo.Stop(ctx, "entry-ana", start.Add(90*time.Minute+250*time.Millisecond))
+	if err != nil || !stopped {
+		t.Fatalf("Stop() = %v, %v; want true", stopped, err)
+	}
+	if stopped, err := repo.Stop(ctx, "entry-ana", start.Add(2*time.Hour)); err != nil || stopped {
+		t.Fatalf("second Stop() = %v, %v; want false", stopped, err)
+	}
+
+	runningByUser, err := repo.FindRunningByUserID(ctx, "u-bia")
+	if err != nil || len(runningByUser) != 1 || runningByUser[0].ID != "entry-bia" {
+		t.Fatalf("FindRunningByUserID() = %v, %v; want entry-bia", runningByUser, err)
+	}
+
+	// Ana tracked 1h30m and a quarter of a second; Bia's timer runs since 9:30
+	totals, err := repo.SumByTaskIDs(ctx, []string{"task-1", "task-2"}, start.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("SumByTaskIDs() error: %v", err)
+	}
+	want := 90*time.Minute + 250*time.Millisecond + 90*time.Minute
+	if len(totals) != 1 || totals["task-1"] != want {
+		t.Errorf("SumByTaskIDs() = %v, want task-1: %v", totals, want)
+	}
+
+	if totals, err := repo.SumByTaskIDs(ctx, nil, start); err != nil || len(totals) != 0 {
+		t.Errorf("SumByTaskIDs() of no tasks = %v, %v; want empty", totals, err)
+	}
+}
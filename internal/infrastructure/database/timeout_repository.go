@@ -0,0 +1,1724 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// ErrQueryTimeout is returned when a repository call exceeds its query timeout
+var ErrQueryTimeout = errors.New("database query timed out")
+
+// queryTimeout bounds every call of a decorated repository with a deadline, so a query
+// waiting on a SQLite lock can't hang the request that issued it
+type queryTimeout time.Duration
+
+// start derives the context of a single repository call. A non-positive timeout leaves the
+// context untouched, and an earlier deadline already set by the caller is kept.
+func (t queryTimeout) start(ctx context.Context) (context.Context, context.CancelFunc) {
+	if t <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, time.Duration(t))
+}
+
+// wrap marks errors caused by the query deadline with ErrQueryTimeout
+func (t queryTimeout) wrap(ctx context.Context, err error) error {
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %v", ErrQueryTimeout, err)
+	}
+	return err
+}
+
+// TimeoutTaskRepository decorates a TaskRepository with per-query timeouts
+type TimeoutTaskRepository struct {
+	next    repository.TaskRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutTaskRepository creates a new TimeoutTaskRepository
+func NewTimeoutTaskRepository(next repository.TaskRepository, timeout time.Duration) *TimeoutTaskRepository {
+	return &TimeoutTaskRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Create creates a new task
+func (r *TimeoutTaskRepository) Create(ctx context.Context, task *application.Task) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Create(ctx, task))
+}
+
+// Update updates an existing task
+func (r *TimeoutTaskRepository) Update(ctx context.Context, task *application.Task) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Update(ctx, task))
+}
+
+// Delete deletes a task by ID
+func (r *TimeoutTaskRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Delete(ctx, id))
+}
+
+// FindByID finds a task by ID
+func (r *TimeoutTaskRepository) FindByID(ctx context.Context, id string) (*application.Task, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	task, err := r.next.FindByID(ctx, id)
+	return task, r.timeout.wrap(ctx, err)
+}
+
+// FindByOwnerID finds all tasks owned by a user
+func (r *TimeoutTaskRepository) FindByOwnerID(ctx context.Context, ownerID string) ([]*application.Task, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	tasks, err := r.next.FindByOwnerID(ctx, ownerID)
+	return tasks, r.timeout.wrap(ctx, err)
+}
+
+// FindByProjectID finds all tasks of a project
+func (r *TimeoutTaskRepository) FindByProjectID(ctx context.Context, projectID string) ([]*application.Task, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	tasks, err := r.next.FindByProjectID(ctx, projectID)
+	return tasks, r.timeout.wrap(ctx, err)
+}
+
+// FindByImagePath finds the tasks whose image is stored at imagePath
+func (r *TimeoutTaskRepository) FindByImagePath(ctx context.Context, imagePath string) ([]*application.Task, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	tasks, err := r.next.FindByImagePath(ctx, imagePath)
+	return tasks, r.timeout.wrap(ctx, err)
+}
+
+// FindSharedWithUser finds all tasks shared with a user
+func (r *TimeoutTaskRepository) FindSharedWithUser(ctx context.Context, userID string) ([]*application.Task, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	tasks, err := r.next.FindSharedWithUser(ctx, userID)
+	return tasks, r.timeout.wrap(ctx, err)
+}
+
+// CreateMany creates several tasks atomically
+func (r *TimeoutTaskRepository) CreateMany(ctx context.Context, tasks []*application.Task) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.CreateMany(ctx, tasks))
+}
+
+// TimeoutUserRepository decorates a UserRepository with per-query timeouts
+type TimeoutUserRepository struct {
+	next    repository.UserRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutUserRepository creates a new TimeoutUserRepository
+func NewTimeoutUserRepository(next repository.UserRepository, timeout time.Duration) *TimeoutUserRepository {
+	return &TimeoutUserRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Create creates a new user
+func (r *TimeoutUserRepository) Create(ctx context.Context, user *application.User) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Create(ctx, user))
+}
+
+// FindByID finds a user by ID
+func (r *TimeoutUserRepository) FindByID(ctx context.Context, id string) (*application.User, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	user, err := r.next.FindByID(ctx, id)
+	return user, r.timeout.wrap(ctx, err)
+}
+
+// FindByIDs finds the users with the given IDs
+func (r *TimeoutUserRepository) FindByIDs(ctx context.Context, ids []string) ([]*application.User, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	users, err := r.next.FindByIDs(ctx, ids)
+	return users, r.timeout.wrap(ctx, err)
+}
+
+// FindByEmail finds a user by email
+func (r *TimeoutUserRepository) FindByEmail(ctx context.Context, email string) (*application.User, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	user, err := r.next.FindByEmail(ctx, email)
+	return user, r.timeout.wrap(ctx, err)
+}
+
+// Update updates an existing user
+func (r *TimeoutUserRepository) Update(ctx context.Context, user *application.User) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Update(ctx, user))
+}
+
+// Delete deletes a user by ID
+func (r *TimeoutUserRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Delete(ctx, id))
+}
+
+// RevokeSessions invalidates the tokens issued to a user so far
+func (r *TimeoutUserRepository) RevokeSessions(ctx context.Context, id string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.RevokeSessions(ctx, id))
+}
+
+// Search finds users whose name or email contains the query
+func (r *TimeoutUserRepository) Search(ctx context.Context, query, excludeID string, limit int) ([]*application.User, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	users, err := r.next.Search(ctx, query, excludeID, limit)
+	return users, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutShareRepository decorates a ShareRepository with per-query timeouts
+type TimeoutShareRepository struct {
+	next    repository.ShareRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutShareRepository creates a new TimeoutShareRepository
+func NewTimeoutShareRepository(next repository.ShareRepository, timeout time.Duration) *TimeoutShareRepository {
+	return &TimeoutShareRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Share invites a user to a task
+func (r *TimeoutShareRepository) Share(ctx context.Context, taskID, userID string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Share(ctx, taskID, userID))
+}
+
+// Unshare removes task sharing with a user
+func (r *TimeoutShareRepository) Unshare(ctx context.Context, taskID, userID string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Unshare(ctx, taskID, userID))
+}
+
+// FindSharedUsers finds all users that accepted to share a task, with their names and emails
+func (r *TimeoutShareRepository) FindSharedUsers(ctx context.Context, taskID string) ([]*application.SharedUser, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	users, err := r.next.FindSharedUsers(ctx, taskID)
+	return users, r.timeout.wrap(ctx, err)
+}
+
+// FindInvitedUserIDs finds the IDs of the users a task is shared with, accepted or still pending
+func (r *TimeoutShareRepository) FindInvitedUserIDs(ctx context.Context, taskID string) ([]string, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	userIDs, err := r.next.FindInvitedUserIDs(ctx, taskID)
+	return userIDs, r.timeout.wrap(ctx, err)
+}
+
+// FindSharedUsersByTaskIDs finds the users that accepted to share each of several tasks
+func (r *TimeoutShareRepository) FindSharedUsersByTaskIDs(ctx context.Context, taskIDs []string) (map[string][]string, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	shared, err := r.next.FindSharedUsersByTaskIDs(ctx, taskIDs)
+	return shared, r.timeout.wrap(ctx, err)
+}
+
+// FindPendingInvitations finds the invitations a user hasn't answered
+func (r *TimeoutShareRepository) FindPendingInvitations(ctx context.Context, userID string) ([]*application.ShareInvitation, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	invitations, err := r.next.FindPendingInvitations(ctx, userID)
+	return invitations, r.timeout.wrap(ctx, err)
+}
+
+// AcceptInvitation turns a pending invitation into a share
+func (r *TimeoutShareRepository) AcceptInvitation(ctx context.Context, taskID, userID string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.AcceptInvitation(ctx, taskID, userID))
+}
+
+// DeclineInvitation removes a pending invitation
+func (r *TimeoutShareRepository) DeclineInvitation(ctx context.Context, taskID, userID string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.DeclineInvitation(ctx, taskID, userID))
+}
+
+// IsSharedWith checks if a task is shared with a user
+func (r *TimeoutShareRepository) IsSharedWith(ctx context.Context, taskID, userID string) (bool, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	shared, err := r.next.IsSharedWith(ctx, taskID, userID)
+	return shared, r.timeout.wrap(ctx, err)
+}
+
+// DeleteAllShares removes every share of a task
+func (r *TimeoutShareRepository) DeleteAllShares(ctx context.Context, taskID string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.DeleteAllShares(ctx, taskID))
+}
+
+// TimeoutTransactor decorates a Transactor with a deadline for the whole transaction,
+// so a unit of work can't hold the SQLite write lock indefinitely
+type TimeoutTransactor struct {
+	next    repository.Transactor
+	timeout queryTimeout
+}
+
+// NewTimeoutTransactor creates a new TimeoutTransactor
+func NewTimeoutTransactor(next repository.Transactor, timeout time.Duration) *TimeoutTransactor {
+	return &TimeoutTransactor{next: next, timeout: queryTimeout(timeout)}
+}
+
+// WithinTransaction runs fn in a transaction
+func (t *TimeoutTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, cancel := t.timeout.start(ctx)
+	defer cancel()
+	return t.timeout.wrap(ctx, t.next.WithinTransaction(ctx, fn))
+}
+
+// TimeoutLinkRepository decorates a LinkRepository with per-query timeouts
+type TimeoutLinkRepository struct {
+	next    repository.LinkRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutLinkRepository creates a new TimeoutLinkRepository
+func NewTimeoutLinkRepository(next repository.LinkRepository, timeout time.Duration) *TimeoutLinkRepository {
+	return &TimeoutLinkRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Save creates or updates the check result of a link
+func (r *TimeoutLinkRepository) Save(ctx context.Context, link *application.TaskLink) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Save(ctx, link))
+}
+
+// FindByTaskID finds the checked links of a task
+func (r *TimeoutLinkRepository) FindByTaskID(ctx context.Context, taskID string) ([]*application.TaskLink, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	links, err := r.next.FindByTaskID(ctx, taskID)
+	return links, r.timeout.wrap(ctx, err)
+}
+
+// FindBrokenByOwnerID finds the broken links of a user's tasks
+func (r *TimeoutLinkRepository) FindBrokenByOwnerID(ctx context.Context, ownerID string) ([]*application.TaskLink, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	links, err := r.next.FindBrokenByOwnerID(ctx, ownerID)
+	return links, r.timeout.wrap(ctx, err)
+}
+
+// FindTasksWithLinks finds the tasks whose descriptions contain links
+func (r *TimeoutLinkRepository) FindTasksWithLinks(ctx context.Context) ([]*application.Task, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	tasks, err := r.next.FindTasksWithLinks(ctx)
+	return tasks, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutNotificationRepository decorates a NotificationRepository with per-query timeouts
+type TimeoutNotificationRepository struct {
+	next    repository.NotificationRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutNotificationRepository creates a new TimeoutNotificationRepository
+func NewTimeoutNotificationRepository(next repository.NotificationRepository, timeout time.Duration) *TimeoutNotificationRepository {
+	return &TimeoutNotificationRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Create creates a new notification
+func (r *TimeoutNotificationRepository) Create(ctx context.Context, notification *application.Notification) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Create(ctx, notification))
+}
+
+// FindByUserID finds the latest notifications of a user
+func (r *TimeoutNotificationRepository) FindByUserID(ctx context.Context, userID string, limit int) ([]*application.Notification, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	notifications, err := r.next.FindByUserID(ctx, userID, limit)
+	return notifications, r.timeout.wrap(ctx, err)
+}
+
+// MarkAsRead marks a notification of a user as read
+func (r *TimeoutNotificationRepository) MarkAsRead(ctx context.Context, id, userID string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.MarkAsRead(ctx, id, userID))
+}
+
+// TimeoutLoginEventRepository decorates a LoginEventRepository with per-query timeouts
+type TimeoutLoginEventRepository struct {
+	next    repository.LoginEventRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutLoginEventRepository creates a new TimeoutLoginEventRepository
+func NewTimeoutLoginEventRepository(next repository.LoginEventRepository, timeout time.Duration) *TimeoutLoginEventRepository {
+	return &TimeoutLoginEventRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Create records a login attempt
+func (r *TimeoutLoginEventRepository) Create(ctx context.Context, event *application.LoginEvent) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Create(ctx, event))
+}
+
+// FindByUserID finds the latest login attempts of a user
+func (r *TimeoutLoginEventRepository) FindByUserID(ctx context.Context, userID string, limit int) ([]*application.LoginEvent, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	events, err := r.next.FindByUserID(ctx, userID, limit)
+	return events, r.timeout.wrap(ctx, err)
+}
+
+// FindLastSuccessful finds the latest successful login of a user
+func (r *TimeoutLoginEventRepository) FindLastSuccessful(ctx context.Context, userID string) (*application.LoginEvent, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	event, err := r.next.FindLastSuccessful(ctx, userID)
+	return event, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutReminderRepository decorates a ReminderRepository with per-query timeouts
+type TimeoutReminderRepository struct {
+	next    repository.ReminderRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutReminderRepository creates a new TimeoutReminderRepository
+func NewTimeoutReminderRepository(next repository.ReminderRepository, timeout time.Duration) *TimeoutReminderRepository {
+	return &TimeoutReminderRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Create creates a new reminder
+func (r *TimeoutReminderRepository) Create(ctx context.Context, reminder *application.Reminder) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Create(ctx, reminder))
+}
+
+// FindByID finds a reminder by ID
+func (r *TimeoutReminderRepository) FindByID(ctx context.Context, id string) (*application.Reminder, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	reminder, err := r.next.FindByID(ctx, id)
+	return reminder, r.timeout.wrap(ctx, err)
+}
+
+// FindByTaskID finds the reminders of a user on a task
+func (r *TimeoutReminderRepository) FindByTaskID(ctx context.Context, taskID, userID string) ([]*application.Reminder, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	reminders, err := r.next.FindByTaskID(ctx, taskID, userID)
+	return reminders, r.timeout.wrap(ctx, err)
+}
+
+// CountPending counts the undelivered reminders of a user on a task
+func (r *TimeoutReminderRepository) CountPending(ctx context.Context, taskID, userID string) (int, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	count, err := r.next.CountPending(ctx, taskID, userID)
+	return count, r.timeout.wrap(ctx, err)
+}
+
+// Delete deletes a reminder by ID
+func (r *TimeoutReminderRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Delete(ctx, id))
+}
+
+// FindDue finds the undelivered reminders due at now
+func (r *TimeoutReminderRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*application.Reminder, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	reminders, err := r.next.FindDue(ctx, now, limit)
+	return reminders, r.timeout.wrap(ctx, err)
+}
+
+// MarkSent marks an undelivered reminder as sent
+func (r *TimeoutReminderRepository) MarkSent(ctx context.Context, id string, sentAt time.Time) (bool, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	marked, err := r.next.MarkSent(ctx, id, sentAt)
+	return marked, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutCommentRepository decorates a CommentRepository with per-query timeouts
+type TimeoutCommentRepository struct {
+	next    repository.CommentRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutCommentRepository creates a new TimeoutCommentRepository
+func NewTimeoutCommentRepository(next repository.CommentRepository, timeout time.Duration) *TimeoutCommentRepository {
+	return &TimeoutCommentRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Create creates a new comment and its mentions
+func (r *TimeoutCommentRepository) Create(ctx context.Context, comment *application.Comment) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Create(ctx, comment))
+}
+
+// FindByTaskID finds the comments of a task, oldest first
+func (r *TimeoutCommentRepository) FindByTaskID(ctx context.Context, taskID string) ([]*application.Comment, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	comments, err := r.next.FindByTaskID(ctx, taskID)
+	return comments, r.timeout.wrap(ctx, err)
+}
+
+// FindByTaskIDs finds the comments of several tasks at once
+func (r *TimeoutCommentRepository) FindByTaskIDs(ctx context.Context, taskIDs []string) (map[string][]*application.Comment, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	comments, err := r.next.FindByTaskIDs(ctx, taskIDs)
+	return comments, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutReportRepository decorates a ReportRepository with per-query timeouts
+type TimeoutReportRepository struct {
+	next    repository.ReportRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutReportRepository creates a new TimeoutReportRepository
+func NewTimeoutReportRepository(next repository.ReportRepository, timeout time.Duration) *TimeoutReportRepository {
+	return &TimeoutReportRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// FindOverdueByUnit finds the overdue tasks owned by members of a unit
+func (r *TimeoutReportRepository) FindOverdueByUnit(ctx context.Context, unit string, now time.Time) ([]*application.OverdueTask, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	tasks, err := r.next.FindOverdueByUnit(ctx, unit, now)
+	return tasks, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutTaskViewRepository decorates a TaskViewRepository with per-query timeouts
+type TimeoutTaskViewRepository struct {
+	next    repository.TaskViewRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutTaskViewRepository creates a new TimeoutTaskViewRepository
+func NewTimeoutTaskViewRepository(next repository.TaskViewRepository, timeout time.Duration) *TimeoutTaskViewRepository {
+	return &TimeoutTaskViewRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// FindByOwnerID finds a page of the views of the tasks owned by a user
+func (r *TimeoutTaskViewRepository) FindByOwnerID(ctx context.Context, ownerID string, now time.Time, sort application.TaskSort, after *application.TaskCursor, limit int) ([]*application.TaskView, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	views, err := r.next.FindByOwnerID(ctx, ownerID, now, sort, after, limit)
+	return views, r.timeout.wrap(ctx, err)
+}
+
+// FindByProjectID finds a page of the views of the tasks of a project
+func (r *TimeoutTaskViewRepository) FindByProjectID(ctx context.Context, projectID string, sort application.TaskSort, after *application.TaskCursor, limit int) ([]*application.TaskView, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	views, err := r.next.FindByProjectID(ctx, projectID, sort, after, limit)
+	return views, r.timeout.wrap(ctx, err)
+}
+
+// FindSharedWithUser finds a page of the views of the tasks shared with a user
+func (r *TimeoutTaskViewRepository) FindSharedWithUser(ctx context.Context, userID string, query application.TaskListQuery) ([]*application.TaskView, int, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	views, total, err := r.next.FindSharedWithUser(ctx, userID, query)
+	return views, total, r.timeout.wrap(ctx, err)
+}
+
+// CountForUser counts the open tasks of a user for the navbar counters
+func (r *TimeoutTaskViewRepository) CountForUser(ctx context.Context, userID string, now time.Time) (*application.TaskCounters, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	counters, err := r.next.CountForUser(ctx, userID, now)
+	return counters, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutTaskOverviewQuery decorates a TaskOverviewQuery with per-query timeouts
+type TimeoutTaskOverviewQuery struct {
+	next    repository.TaskOverviewQuery
+	timeout queryTimeout
+}
+
+// NewTimeoutTaskOverviewQuery creates a new TimeoutTaskOverviewQuery
+func NewTimeoutTaskOverviewQuery(next repository.TaskOverviewQuery, timeout time.Duration) *TimeoutTaskOverviewQuery {
+	return &TimeoutTaskOverviewQuery{next: next, timeout: queryTimeout(timeout)}
+}
+
+// FindOverview finds a page of the task list of a user and its counters
+func (r *TimeoutTaskOverviewQuery) FindOverview(ctx context.Context, userID string, now time.Time, sort application.TaskSort, after *application.TaskCursor, limit int) (*application.TaskOverview, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	overview, err := r.next.FindOverview(ctx, userID, now, sort, after, limit)
+	return overview, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutOverdueRepository decorates an OverdueRepository with per-query timeouts
+type TimeoutOverdueRepository struct {
+	next    repository.OverdueRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutOverdueRepository creates a new TimeoutOverdueRepository
+func NewTimeoutOverdueRepository(next repository.OverdueRepository, timeout time.Duration) *TimeoutOverdueRepository {
+	return &TimeoutOverdueRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// FlagOverdue flags a batch of overdue pending tasks
+func (r *TimeoutOverdueRepository) FlagOverdue(ctx context.Context, now time.Time, limit int) ([]*application.Task, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	tasks, err := r.next.FlagOverdue(ctx, now, limit)
+	return tasks, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutExportUsageRepository decorates an ExportUsageRepository with per-query timeouts
+type TimeoutExportUsageRepository struct {
+	next    repository.ExportUsageRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutExportUsageRepository creates a new TimeoutExportUsageRepository
+func NewTimeoutExportUsageRepository(next repository.ExportUsageRepository, timeout time.Duration) *TimeoutExportUsageRepository {
+	return &TimeoutExportUsageRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// CreateIfBelowLimit records an export if the user is below the limit
+func (r *TimeoutExportUsageRepository) CreateIfBelowLimit(ctx context.Context, usage *application.ExportUsage, since time.Time, limit int) (bool, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	created, err := r.next.CreateIfBelowLimit(ctx, usage, since, limit)
+	return created, r.timeout.wrap(ctx, err)
+}
+
+// FindOldestSince finds the oldest export of a user since a time
+func (r *TimeoutExportUsageRepository) FindOldestSince(ctx context.Context, userID string, since time.Time) (time.Time, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	oldest, err := r.next.FindOldestSince(ctx, userID, since)
+	return oldest, r.timeout.wrap(ctx, err)
+}
+
+// Delete deletes an export usage by ID
+func (r *TimeoutExportUsageRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Delete(ctx, id))
+}
+
+// DeleteBefore deletes export usages older than a time
+func (r *TimeoutExportUsageRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.DeleteBefore(ctx, before))
+}
+
+// TimeoutStoredFileRepository decorates a StoredFileRepository with per-query timeouts
+type TimeoutStoredFileRepository struct {
+	next    repository.StoredFileRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutStoredFileRepository creates a new TimeoutStoredFileRepository
+func NewTimeoutStoredFileRepository(next repository.StoredFileRepository, timeout time.Duration) *TimeoutStoredFileRepository {
+	return &TimeoutStoredFileRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// CreateIfWithinQuota records a file if it fits the quotas
+func (r *TimeoutStoredFileRepository) CreateIfWithinQuota(ctx context.Context, file *application.StoredFile, userLimit, globalLimit int64) (bool, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	created, err := r.next.CreateIfWithinQuota(ctx, file, userLimit, globalLimit)
+	return created, r.timeout.wrap(ctx, err)
+}
+
+// FindByName returns the record of a file
+func (r *TimeoutStoredFileRepository) FindByName(ctx context.Context, kind application.StoredFileKind, name string) (*application.StoredFile, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	file, err := r.next.FindByName(ctx, kind, name)
+	return file, r.timeout.wrap(ctx, err)
+}
+
+// Delete deletes the record of a file
+func (r *TimeoutStoredFileRepository) Delete(ctx context.Context, kind application.StoredFileKind, name string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Delete(ctx, kind, name))
+}
+
+// UsageByUser returns the space taken by the files of a user
+func (r *TimeoutStoredFileRepository) UsageByUser(ctx context.Context, userID string) (*application.StorageUsage, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	usage, err := r.next.UsageByUser(ctx, userID)
+	return usage, r.timeout.wrap(ctx, err)
+}
+
+// TotalUsage returns the space taken by the files of all users
+func (r *TimeoutStoredFileRepository) TotalUsage(ctx context.Context) (*application.StorageUsage, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	usage, err := r.next.TotalUsage(ctx)
+	return usage, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutAttachmentRepository decorates an AttachmentRepository with per-query timeouts
+type TimeoutAttachmentRepository struct {
+	next    repository.AttachmentRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutAttachmentRepository creates a new TimeoutAttachmentRepository
+func NewTimeoutAttachmentRepository(next repository.AttachmentRepository, timeout time.Duration) *TimeoutAttachmentRepository {
+	return &TimeoutAttachmentRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Create creates a new attachment
+func (r *TimeoutAttachmentRepository) Create(ctx context.Context, attachment *application.Attachment) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Create(ctx, attachment))
+}
+
+// FindByID finds an attachment by ID
+func (r *TimeoutAttachmentRepository) FindByID(ctx context.Context, id string) (*application.Attachment, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	attachment, err := r.next.FindByID(ctx, id)
+	return attachment, r.timeout.wrap(ctx, err)
+}
+
+// FindByTaskID finds all attachments of a task
+func (r *TimeoutAttachmentRepository) FindByTaskID(ctx context.Context, taskID string) ([]*application.Attachment, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	attachments, err := r.next.FindByTaskID(ctx, taskID)
+	return attachments, r.timeout.wrap(ctx, err)
+}
+
+// FindByOwnerID finds the attachments of all tasks owned by a user
+func (r *TimeoutAttachmentRepository) FindByOwnerID(ctx context.Context, ownerID string) ([]*application.Attachment, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	attachments, err := r.next.FindByOwnerID(ctx, ownerID)
+	return attachments, r.timeout.wrap(ctx, err)
+}
+
+// CountByTaskID counts the attachments of a task
+func (r *TimeoutAttachmentRepository) CountByTaskID(ctx context.Context, taskID string) (int, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	count, err := r.next.CountByTaskID(ctx, taskID)
+	return count, r.timeout.wrap(ctx, err)
+}
+
+// Delete deletes an attachment by ID
+func (r *TimeoutAttachmentRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Delete(ctx, id))
+}
+
+// TimeoutCredentialRepository decorates a CredentialRepository with per-query timeouts
+type TimeoutCredentialRepository struct {
+	next    repository.CredentialRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutCredentialRepository creates a new TimeoutCredentialRepository
+func NewTimeoutCredentialRepository(next repository.CredentialRepository, timeout time.Duration) *TimeoutCredentialRepository {
+	return &TimeoutCredentialRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Create creates a new credential
+func (r *TimeoutCredentialRepository) Create(ctx context.Context, credential *application.Credential) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Create(ctx, credential))
+}
+
+// FindByID finds a credential by ID
+func (r *TimeoutCredentialRepository) FindByID(ctx context.Context, id string) (*application.Credential, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	credential, err := r.next.FindByID(ctx, id)
+	return credential, r.timeout.wrap(ctx, err)
+}
+
+// FindByUserID finds the credentials of a user
+func (r *TimeoutCredentialRepository) FindByUserID(ctx context.Context, userID string) ([]*application.Credential, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	credentials, err := r.next.FindByUserID(ctx, userID)
+	return credentials, r.timeout.wrap(ctx, err)
+}
+
+// UpdateUsage stores the sign count, backup state and last use of a credential
+func (r *TimeoutCredentialRepository) UpdateUsage(ctx context.Context, credential *application.Credential) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.UpdateUsage(ctx, credential))
+}
+
+// Delete deletes a credential by ID
+func (r *TimeoutCredentialRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Delete(ctx, id))
+}
+
+// TimeoutTwoFactorRepository decorates a TwoFactorRepository with per-query timeouts
+type TimeoutTwoFactorRepository struct {
+	next    repository.TwoFactorRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutTwoFactorRepository creates a new TimeoutTwoFactorRepository
+func NewTimeoutTwoFactorRepository(next repository.TwoFactorRepository, timeout time.Duration) *TimeoutTwoFactorRepository {
+	return &TimeoutTwoFactorRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// FindByUserID finds the second factor of a user
+func (r *TimeoutTwoFactorRepository) FindByUserID(ctx context.Context, userID string) (*application.TwoFactor, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	twoFactor, err := r.next.FindByUserID(ctx, userID)
+	return twoFactor, r.timeout.wrap(ctx, err)
+}
+
+// Save creates or replaces the second factor of a user
+func (r *TimeoutTwoFactorRepository) Save(ctx context.Context, twoFactor *application.TwoFactor) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Save(ctx, twoFactor))
+}
+
+// AdvanceLastStep stores a newer last accepted time step
+func (r *TimeoutTwoFactorRepository) AdvanceLastStep(ctx context.Context, userID string, step int64) (bool, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	advanced, err := r.next.AdvanceLastStep(ctx, userID, step)
+	return advanced, r.timeout.wrap(ctx, err)
+}
+
+// Delete deletes the second factor and the recovery codes of a user
+func (r *TimeoutTwoFactorRepository) Delete(ctx context.Context, userID string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Delete(ctx, userID))
+}
+
+// ReplaceRecoveryCodes replaces the recovery codes of a user
+func (r *TimeoutTwoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.ReplaceRecoveryCodes(ctx, userID, codeHashes))
+}
+
+// UseRecoveryCode deletes a recovery code of a user
+func (r *TimeoutTwoFactorRepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	used, err := r.next.UseRecoveryCode(ctx, userID, codeHash)
+	return used, r.timeout.wrap(ctx, err)
+}
+
+// CountRecoveryCodes counts the unused recovery codes of a user
+func (r *TimeoutTwoFactorRepository) CountRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	count, err := r.next.CountRecoveryCodes(ctx, userID)
+	return count, r.timeout.wrap(ctx, err)
+}
+
+// CreateChallenge creates a login challenge
+func (r *TimeoutTwoFactorRepository) CreateChallenge(ctx context.Context, challenge *application.TwoFactorChallenge) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.CreateChallenge(ctx, challenge))
+}
+
+// FindChallenge finds a login challenge by token hash
+func (r *TimeoutTwoFactorRepository) FindChallenge(ctx context.Context, tokenHash string) (*application.TwoFactorChallenge, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	challenge, err := r.next.FindChallenge(ctx, tokenHash)
+	return challenge, r.timeout.wrap(ctx, err)
+}
+
+// RecordChallengeAttempt counts a wrong code against a login challenge
+func (r *TimeoutTwoFactorRepository) RecordChallengeAttempt(ctx context.Context, tokenHash string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.RecordChallengeAttempt(ctx, tokenHash))
+}
+
+// DeleteChallenge deletes a login challenge
+func (r *TimeoutTwoFactorRepository) DeleteChallenge(ctx context.Context, tokenHash string) (bool, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	deleted, err := r.next.DeleteChallenge(ctx, tokenHash)
+	return deleted, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutProjectRepository decorates a ProjectRepository with per-query timeouts
+type TimeoutProjectRepository struct {
+	next    repository.ProjectRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutProjectRepository creates a new TimeoutProjectRepository
+func NewTimeoutProjectRepository(next repository.ProjectRepository, timeout time.Duration) *TimeoutProjectRepository {
+	return &TimeoutProjectRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Create creates a new project
+func (r *TimeoutProjectRepository) Create(ctx context.Context, project *application.Project) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Create(ctx, project))
+}
+
+// Update updates the name and color of a project
+func (r *TimeoutProjectRepository) Update(ctx context.Context, project *application.Project) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Update(ctx, project))
+}
+
+// Delete deletes a project by ID
+func (r *TimeoutProjectRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Delete(ctx, id))
+}
+
+// FindByID finds a project by ID
+func (r *TimeoutProjectRepository) FindByID(ctx context.Context, id string) (*application.Project, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	project, err := r.next.FindByID(ctx, id)
+	return project, r.timeout.wrap(ctx, err)
+}
+
+// FindByOwnerID finds all projects owned by a user
+func (r *TimeoutProjectRepository) FindByOwnerID(ctx context.Context, ownerID string) ([]*application.Project, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	projects, err := r.next.FindByOwnerID(ctx, ownerID)
+	return projects, r.timeout.wrap(ctx, err)
+}
+
+// FindSharedWithUser finds all projects shared with a user
+func (r *TimeoutProjectRepository) FindSharedWithUser(ctx context.Context, userID string) ([]*application.Project, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	projects, err := r.next.FindSharedWithUser(ctx, userID)
+	return projects, r.timeout.wrap(ctx, err)
+}
+
+// Share shares a project with a user
+func (r *TimeoutProjectRepository) Share(ctx context.Context, projectID, userID string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Share(ctx, projectID, userID))
+}
+
+// Unshare removes sharing of a project with a user
+func (r *TimeoutProjectRepository) Unshare(ctx context.Context, projectID, userID string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Unshare(ctx, projectID, userID))
+}
+
+// IsSharedWith checks if a project is shared with a user
+func (r *TimeoutProjectRepository) IsSharedWith(ctx context.Context, projectID, userID string) (bool, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	shared, err := r.next.IsSharedWith(ctx, projectID, userID)
+	return shared, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutDependencyRepository decorates a DependencyRepository with per-query timeouts
+type TimeoutDependencyRepository struct {
+	next    repository.DependencyRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutDependencyRepository creates a new TimeoutDependencyRepository
+func NewTimeoutDependencyRepository(next repository.DependencyRepository, timeout time.Duration) *TimeoutDependencyRepository {
+	return &TimeoutDependencyRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Add records a dependency
+func (r *TimeoutDependencyRepository) Add(ctx context.Context, dependency *application.TaskDependency) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Add(ctx, dependency))
+}
+
+// Remove removes the dependency of a task on a blocker
+func (r *TimeoutDependencyRepository) Remove(ctx context.Context, taskID, blockerID string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Remove(ctx, taskID, blockerID))
+}
+
+// FindBlockers finds the tasks blocking a task
+func (r *TimeoutDependencyRepository) FindBlockers(ctx context.Context, taskID string) ([]*application.Task, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	tasks, err := r.next.FindBlockers(ctx, taskID)
+	return tasks, r.timeout.wrap(ctx, err)
+}
+
+// FindOpenBlockers finds the open blockers of several tasks
+func (r *TimeoutDependencyRepository) FindOpenBlockers(ctx context.Context, taskIDs []string) (map[string][]*application.Task, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	blockers, err := r.next.FindOpenBlockers(ctx, taskIDs)
+	return blockers, r.timeout.wrap(ctx, err)
+}
+
+// IsBlockedBy reports whether a task depends on a blocker, directly or through other tasks
+func (r *TimeoutDependencyRepository) IsBlockedBy(ctx context.Context, taskID, blockerID string) (bool, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	blocked, err := r.next.IsBlockedBy(ctx, taskID, blockerID)
+	return blocked, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutExportJobRepository decorates an ExportJobRepository with per-query timeouts
+type TimeoutExportJobRepository struct {
+	next    repository.ExportJobRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutExportJobRepository creates a new TimeoutExportJobRepository
+func NewTimeoutExportJobRepository(next repository.ExportJobRepository, timeout time.Duration) *TimeoutExportJobRepository {
+	return &TimeoutExportJobRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Create creates a new job
+func (r *TimeoutExportJobRepository) Create(ctx context.Context, job *application.ExportJob) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Create(ctx, job))
+}
+
+// FindByID finds a job by ID without its file
+func (r *TimeoutExportJobRepository) FindByID(ctx context.Context, id string) (*application.ExportJob, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	job, err := r.next.FindByID(ctx, id)
+	return job, r.timeout.wrap(ctx, err)
+}
+
+// FindResult returns the file generated by a job
+func (r *TimeoutExportJobRepository) FindResult(ctx context.Context, id string) ([]byte, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	result, err := r.next.FindResult(ctx, id)
+	return result, r.timeout.wrap(ctx, err)
+}
+
+// ClaimNext marks the oldest pending job as running and returns it
+func (r *TimeoutExportJobRepository) ClaimNext(ctx context.Context, now, staleBefore time.Time) (*application.ExportJob, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	job, err := r.next.ClaimNext(ctx, now, staleBefore)
+	return job, r.timeout.wrap(ctx, err)
+}
+
+// Finish stores the file of a running job and marks it as done
+func (r *TimeoutExportJobRepository) Finish(ctx context.Context, id string, result []byte, finishedAt time.Time) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Finish(ctx, id, result, finishedAt))
+}
+
+// Fail marks a running job as failed
+func (r *TimeoutExportJobRepository) Fail(ctx context.Context, id, message string, finishedAt time.Time) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Fail(ctx, id, message, finishedAt))
+}
+
+// DeleteFinishedBefore deletes the jobs that finished before a time
+func (r *TimeoutExportJobRepository) DeleteFinishedBefore(ctx context.Context, before time.Time) (int, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	deleted, err := r.next.DeleteFinishedBefore(ctx, before)
+	return deleted, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutJobRepository decorates a JobRepository with per-query timeouts
+type TimeoutJobRepository struct {
+	next    repository.JobRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutJobRepository creates a new TimeoutJobRepository
+func NewTimeoutJobRepository(next repository.JobRepository, timeout time.Duration) *TimeoutJobRepository {
+	return &TimeoutJobRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Create queues a new job
+func (r *TimeoutJobRepository) Create(ctx context.Context, job *application.Job) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Create(ctx, job))
+}
+
+// ClaimNext marks the oldest due job as running and returns it
+func (r *TimeoutJobRepository) ClaimNext(ctx context.Context, now, staleBefore time.Time) (*application.Job, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	job, err := r.next.ClaimNext(ctx, now, staleBefore)
+	return job, r.timeout.wrap(ctx, err)
+}
+
+// Finish marks a running job as done
+func (r *TimeoutJobRepository) Finish(ctx context.Context, id string, finishedAt time.Time) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Finish(ctx, id, finishedAt))
+}
+
+// Retry puts a running job back in the queue
+func (r *TimeoutJobRepository) Retry(ctx context.Context, id, message string, runAt time.Time) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Retry(ctx, id, message, runAt))
+}
+
+// Fail marks a running job as failed for good
+func (r *TimeoutJobRepository) Fail(ctx context.Context, id, message string, finishedAt time.Time) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Fail(ctx, id, message, finishedAt))
+}
+
+// FindFailed finds the failed jobs, most recently failed first
+func (r *TimeoutJobRepository) FindFailed(ctx context.Context, limit int) ([]*application.Job, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	jobs, err := r.next.FindFailed(ctx, limit)
+	return jobs, r.timeout.wrap(ctx, err)
+}
+
+// Requeue puts a failed job back in the queue
+func (r *TimeoutJobRepository) Requeue(ctx context.Context, id string, runAt time.Time) (*application.Job, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	job, err := r.next.Requeue(ctx, id, runAt)
+	return job, r.timeout.wrap(ctx, err)
+}
+
+// DeleteDoneBefore deletes the jobs done before a time
+func (r *TimeoutJobRepository) DeleteDoneBefore(ctx context.Context, before time.Time) (int, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	deleted, err := r.next.DeleteDoneBefore(ctx, before)
+	return deleted, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutOrgRepository decorates an OrgRepository with per-query timeouts
+type TimeoutOrgRepository struct {
+	next    repository.OrgRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutOrgRepository creates a new TimeoutOrgRepository
+func NewTimeoutOrgRepository(next repository.OrgRepository, timeout time.Duration) *TimeoutOrgRepository {
+	return &TimeoutOrgRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Create creates an org
+func (r *TimeoutOrgRepository) Create(ctx context.Context, org *application.Org) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Create(ctx, org))
+}
+
+// Update updates an org
+func (r *TimeoutOrgRepository) Update(ctx context.Context, org *application.Org) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Update(ctx, org))
+}
+
+// FindByID finds an org by ID
+func (r *TimeoutOrgRepository) FindByID(ctx context.Context, id string) (*application.Org, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	org, err := r.next.FindByID(ctx, id)
+	return org, r.timeout.wrap(ctx, err)
+}
+
+// FindByUserID finds the orgs a user belongs to
+func (r *TimeoutOrgRepository) FindByUserID(ctx context.Context, userID string) ([]*application.OrgMembership, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	memberships, err := r.next.FindByUserID(ctx, userID)
+	return memberships, r.timeout.wrap(ctx, err)
+}
+
+// FindMember finds the membership of a user in an org
+func (r *TimeoutOrgRepository) FindMember(ctx context.Context, orgID, userID string) (*application.OrgMember, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	member, err := r.next.FindMember(ctx, orgID, userID)
+	return member, r.timeout.wrap(ctx, err)
+}
+
+// FindMembers finds the members of an org
+func (r *TimeoutOrgRepository) FindMembers(ctx context.Context, orgID string) ([]*application.OrgMember, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	members, err := r.next.FindMembers(ctx, orgID)
+	return members, r.timeout.wrap(ctx, err)
+}
+
+// SaveMember adds a user to an org or changes their role
+func (r *TimeoutOrgRepository) SaveMember(ctx context.Context, member *application.OrgMember) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.SaveMember(ctx, member))
+}
+
+// RemoveMember removes a user from an org
+func (r *TimeoutOrgRepository) RemoveMember(ctx context.Context, orgID, userID string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.RemoveMember(ctx, orgID, userID))
+}
+
+// CountOwners counts the owners of an org
+func (r *TimeoutOrgRepository) CountOwners(ctx context.Context, orgID string) (int, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	owners, err := r.next.CountOwners(ctx, orgID)
+	return owners, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutUploadReferenceRepository decorates an UploadReferenceRepository with per-query timeouts
+type TimeoutUploadReferenceRepository struct {
+	next    repository.UploadReferenceRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutUploadReferenceRepository creates a new TimeoutUploadReferenceRepository
+func NewTimeoutUploadReferenceRepository(next repository.UploadReferenceRepository, timeout time.Duration) *TimeoutUploadReferenceRepository {
+	return &TimeoutUploadReferenceRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// ImagePaths returns the image paths of all tasks and the avatar paths of all users
+func (r *TimeoutUploadReferenceRepository) ImagePaths(ctx context.Context) ([]string, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	paths, err := r.next.ImagePaths(ctx)
+	return paths, r.timeout.wrap(ctx, err)
+}
+
+// AttachmentPaths returns the file paths of all task attachments
+func (r *TimeoutUploadReferenceRepository) AttachmentPaths(ctx context.Context) ([]string, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	paths, err := r.next.AttachmentPaths(ctx)
+	return paths, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutAdminRepository decorates an AdminRepository with per-query timeouts
+type TimeoutAdminRepository struct {
+	next    repository.AdminRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutAdminRepository creates a new TimeoutAdminRepository
+func NewTimeoutAdminRepository(next repository.AdminRepository, timeout time.Duration) *TimeoutAdminRepository {
+	return &TimeoutAdminRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// CountUsers returns the number of registered users
+func (r *TimeoutAdminRepository) CountUsers(ctx context.Context) (int, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	count, err := r.next.CountUsers(ctx)
+	return count, r.timeout.wrap(ctx, err)
+}
+
+// ListUsers returns a page of users ordered by creation
+func (r *TimeoutAdminRepository) ListUsers(ctx context.Context, limit, offset int) ([]*application.User, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	users, err := r.next.ListUsers(ctx, limit, offset)
+	return users, r.timeout.wrap(ctx, err)
+}
+
+// CountTasksCreatedSince counts the tasks created on each day since a time
+func (r *TimeoutAdminRepository) CountTasksCreatedSince(ctx context.Context, since time.Time) ([]application.DailyCount, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	counts, err := r.next.CountTasksCreatedSince(ctx, since)
+	return counts, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutShareLinkRepository decorates a ShareLinkRepository with per-query timeouts
+type TimeoutShareLinkRepository struct {
+	next    repository.ShareLinkRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutShareLinkRepository creates a new TimeoutShareLinkRepository
+func NewTimeoutShareLinkRepository(next repository.ShareLinkRepository, timeout time.Duration) *TimeoutShareLinkRepository {
+	return &TimeoutShareLinkRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Create creates a new share link
+func (r *TimeoutShareLinkRepository) Create(ctx context.Context, link *application.ShareLink) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Create(ctx, link))
+}
+
+// FindByID finds a share link by ID
+func (r *TimeoutShareLinkRepository) FindByID(ctx context.Context, id string) (*application.ShareLink, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	link, err := r.next.FindByID(ctx, id)
+	return link, r.timeout.wrap(ctx, err)
+}
+
+// FindByTokenHash finds a share link by the hash of its token
+func (r *TimeoutShareLinkRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*application.ShareLink, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	link, err := r.next.FindByTokenHash(ctx, tokenHash)
+	return link, r.timeout.wrap(ctx, err)
+}
+
+// FindActiveByTaskID finds the active links of a task
+func (r *TimeoutShareLinkRepository) FindActiveByTaskID(ctx context.Context, taskID string, now time.Time) ([]*application.ShareLink, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	links, err := r.next.FindActiveByTaskID(ctx, taskID, now)
+	return links, r.timeout.wrap(ctx, err)
+}
+
+// Revoke records the revocation of a share link
+func (r *TimeoutShareLinkRepository) Revoke(ctx context.Context, id string, revokedAt time.Time) (bool, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	revoked, err := r.next.Revoke(ctx, id, revokedAt)
+	return revoked, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutTimeEntryRepository decorates a TimeEntryRepository with per-query timeouts
+type TimeoutTimeEntryRepository struct {
+	next    repository.TimeEntryRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutTimeEntryRepository creates a new TimeoutTimeEntryRepository
+func NewTimeoutTimeEntryRepository(next repository.TimeEntryRepository, timeout time.Duration) *TimeoutTimeEntryRepository {
+	return &TimeoutTimeEntryRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Start creates a running time entry
+func (r *TimeoutTimeEntryRepository) Start(ctx context.Context, entry *application.TimeEntry) (bool, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	started, err := r.next.Start(ctx, entry)
+	return started, r.timeout.wrap(ctx, err)
+}
+
+// FindRunning finds the running entry of a user on a task
+func (r *TimeoutTimeEntryRepository) FindRunning(ctx context.Context, taskID, userID string) (*application.TimeEntry, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	entry, err := r.next.FindRunning(ctx, taskID, userID)
+	return entry, r.timeout.wrap(ctx, err)
+}
+
+// FindRunningByUserID finds the running entries of a user
+func (r *TimeoutTimeEntryRepository) FindRunningByUserID(ctx context.Context, userID string) ([]*application.TimeEntry, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	entries, err := r.next.FindRunningByUserID(ctx, userID)
+	return entries, r.timeout.wrap(ctx, err)
+}
+
+// Stop records the end of a running entry
+func (r *TimeoutTimeEntryRepository) Stop(ctx context.Context, id string, stoppedAt time.Time) (bool, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	stopped, err := r.next.Stop(ctx, id, stoppedAt)
+	return stopped, r.timeout.wrap(ctx, err)
+}
+
+// SumByTaskIDs sums the time tracked on several tasks
+func (r *TimeoutTimeEntryRepository) SumByTaskIDs(ctx context.Context, taskIDs []string, now time.Time) (map[string]time.Duration, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	totals, err := r.next.SumByTaskIDs(ctx, taskIDs, now)
+	return totals, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutTaskUndoRepository decorates a TaskUndoRepository with per-query timeouts
+type TimeoutTaskUndoRepository struct {
+	next    repository.TaskUndoRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutTaskUndoRepository creates a new TimeoutTaskUndoRepository
+func NewTimeoutTaskUndoRepository(next repository.TaskUndoRepository, timeout time.Duration) *TimeoutTaskUndoRepository {
+	return &TimeoutTaskUndoRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Save records the undo of an action on a task
+func (r *TimeoutTaskUndoRepository) Save(ctx context.Context, undo *application.TaskUndo) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Save(ctx, undo))
+}
+
+// Take removes and returns the undo a user can still take on a task
+func (r *TimeoutTaskUndoRepository) Take(ctx context.Context, taskID, userID string, now time.Time) (*application.TaskUndo, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	undo, err := r.next.Take(ctx, taskID, userID, now)
+	return undo, r.timeout.wrap(ctx, err)
+}
+
+// FindExpired finds a batch of expired undos
+func (r *TimeoutTaskUndoRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]*application.TaskUndo, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	undos, err := r.next.FindExpired(ctx, now, limit)
+	return undos, r.timeout.wrap(ctx, err)
+}
+
+// DeleteExpired removes the undo of a task if it expired
+func (r *TimeoutTaskUndoRepository) DeleteExpired(ctx context.Context, taskID string, now time.Time) (bool, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	deleted, err := r.next.DeleteExpired(ctx, taskID, now)
+	return deleted, r.timeout.wrap(ctx, err)
+}
+
+// Trash hides a task
+func (r *TimeoutTaskUndoRepository) Trash(ctx context.Context, taskID string, deletedAt time.Time) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Trash(ctx, taskID, deletedAt))
+}
+
+// Restore shows a trashed task again
+func (r *TimeoutTaskUndoRepository) Restore(ctx context.Context, taskID string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Restore(ctx, taskID))
+}
+
+// FindTrashed finds a trashed task by ID
+func (r *TimeoutTaskUndoRepository) FindTrashed(ctx context.Context, taskID string) (*application.Task, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	task, err := r.next.FindTrashed(ctx, taskID)
+	return task, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutFeedTokenRepository decorates a FeedTokenRepository with per-query timeouts
+type TimeoutFeedTokenRepository struct {
+	next    repository.FeedTokenRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutFeedTokenRepository creates a new TimeoutFeedTokenRepository
+func NewTimeoutFeedTokenRepository(next repository.FeedTokenRepository, timeout time.Duration) *TimeoutFeedTokenRepository {
+	return &TimeoutFeedTokenRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Save stores the feed token of a user
+func (r *TimeoutFeedTokenRepository) Save(ctx context.Context, token *application.FeedToken) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Save(ctx, token))
+}
+
+// FindByTokenHash finds a feed token by its hash
+func (r *TimeoutFeedTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*application.FeedToken, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	token, err := r.next.FindByTokenHash(ctx, tokenHash)
+	return token, r.timeout.wrap(ctx, err)
+}
+
+// Delete removes the feed token of a user
+func (r *TimeoutFeedTokenRepository) Delete(ctx context.Context, userID string) (bool, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	deleted, err := r.next.Delete(ctx, userID)
+	return deleted, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutAccountDeletionRepository decorates an AccountDeletionRepository with per-query timeouts
+type TimeoutAccountDeletionRepository struct {
+	next    repository.AccountDeletionRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutAccountDeletionRepository creates a new TimeoutAccountDeletionRepository
+func NewTimeoutAccountDeletionRepository(next repository.AccountDeletionRepository, timeout time.Duration) *TimeoutAccountDeletionRepository {
+	return &TimeoutAccountDeletionRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Create schedules the deletion of an account unless it already is
+func (r *TimeoutAccountDeletionRepository) Create(ctx context.Context, deletion *application.AccountDeletion) (bool, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	created, err := r.next.Create(ctx, deletion)
+	return created, r.timeout.wrap(ctx, err)
+}
+
+// FindByUserID finds the scheduled deletion of an account
+func (r *TimeoutAccountDeletionRepository) FindByUserID(ctx context.Context, userID string) (*application.AccountDeletion, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	deletion, err := r.next.FindByUserID(ctx, userID)
+	return deletion, r.timeout.wrap(ctx, err)
+}
+
+// FindDue finds a batch of deletions whose grace period is over
+func (r *TimeoutAccountDeletionRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*application.AccountDeletion, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	deletions, err := r.next.FindDue(ctx, now, limit)
+	return deletions, r.timeout.wrap(ctx, err)
+}
+
+// Delete cancels the deletion of an account
+func (r *TimeoutAccountDeletionRepository) Delete(ctx context.Context, userID string) (bool, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	deleted, err := r.next.Delete(ctx, userID)
+	return deleted, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutCreationUsageRepository decorates a CreationUsageRepository with per-query timeouts
+type TimeoutCreationUsageRepository struct {
+	next    repository.CreationUsageRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutCreationUsageRepository creates a new TimeoutCreationUsageRepository
+func NewTimeoutCreationUsageRepository(next repository.CreationUsageRepository, timeout time.Duration) *TimeoutCreationUsageRepository {
+	return &TimeoutCreationUsageRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// CreateIfBelowLimit records a usage if the user stays within the limit
+func (r *TimeoutCreationUsageRepository) CreateIfBelowLimit(ctx context.Context, usage *application.CreationUsage, since time.Time, limit int) (bool, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	created, err := r.next.CreateIfBelowLimit(ctx, usage, since, limit)
+	return created, r.timeout.wrap(ctx, err)
+}
+
+// FindOldestSince finds the oldest usage of a kind by a user since a time
+func (r *TimeoutCreationUsageRepository) FindOldestSince(ctx context.Context, userID string, kind application.CreationKind, since time.Time) (time.Time, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	oldest, err := r.next.FindOldestSince(ctx, userID, kind, since)
+	return oldest, r.timeout.wrap(ctx, err)
+}
+
+// Delete deletes a creation usage by ID
+func (r *TimeoutCreationUsageRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Delete(ctx, id))
+}
+
+// DeleteBefore deletes expired creation usage
+func (r *TimeoutCreationUsageRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.DeleteBefore(ctx, before))
+}
+
+// TimeoutDigestRepository decorates a DigestRepository with per-query timeouts
+type TimeoutDigestRepository struct {
+	next    repository.DigestRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutDigestRepository creates a new TimeoutDigestRepository
+func NewTimeoutDigestRepository(next repository.DigestRepository, timeout time.Duration) *TimeoutDigestRepository {
+	return &TimeoutDigestRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Save creates or replaces the digest subscription of a user
+func (r *TimeoutDigestRepository) Save(ctx context.Context, subscription *application.DigestSubscription) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Save(ctx, subscription))
+}
+
+// FindByUserID finds the digest subscription of a user
+func (r *TimeoutDigestRepository) FindByUserID(ctx context.Context, userID string) (*application.DigestSubscription, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	subscription, err := r.next.FindByUserID(ctx, userID)
+	return subscription, r.timeout.wrap(ctx, err)
+}
+
+// Delete deletes the digest subscription of a user
+func (r *TimeoutDigestRepository) Delete(ctx context.Context, userID string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Delete(ctx, userID))
+}
+
+// FindDue finds the digest subscriptions due at now
+func (r *TimeoutDigestRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*application.DigestSubscription, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	subscriptions, err := r.next.FindDue(ctx, now, limit)
+	return subscriptions, r.timeout.wrap(ctx, err)
+}
+
+// Reschedule moves the next digest of a subscription
+func (r *TimeoutDigestRepository) Reschedule(ctx context.Context, userID string, previous, next, sentAt time.Time) (bool, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	moved, err := r.next.Reschedule(ctx, userID, previous, next, sentAt)
+	return moved, r.timeout.wrap(ctx, err)
+}
+
+// Summarize counts the tasks of a user for the weekly digest
+func (r *TimeoutDigestRepository) Summarize(ctx context.Context, userID string, since, now time.Time, limit int) (*application.WeeklyDigest, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	digest, err := r.next.Summarize(ctx, userID, since, now, limit)
+	return digest, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutUserSettingsRepository decorates a UserSettingsRepository with per-query timeouts
+type TimeoutUserSettingsRepository struct {
+	next    repository.UserSettingsRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutUserSettingsRepository creates a new TimeoutUserSettingsRepository
+func NewTimeoutUserSettingsRepository(next repository.UserSettingsRepository, timeout time.Duration) *TimeoutUserSettingsRepository {
+	return &TimeoutUserSettingsRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Save stores the settings of a user
+func (r *TimeoutUserSettingsRepository) Save(ctx context.Context, settings *application.UserSettings) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Save(ctx, settings))
+}
+
+// FindByUserID finds the settings of a user
+func (r *TimeoutUserSettingsRepository) FindByUserID(ctx context.Context, userID string) (*application.UserSettings, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	settings, err := r.next.FindByUserID(ctx, userID)
+	return settings, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutTaskRevisionRepository decorates a TaskRevisionRepository with per-query timeouts
+type TimeoutTaskRevisionRepository struct {
+	next    repository.TaskRevisionRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutTaskRevisionRepository creates a new TimeoutTaskRevisionRepository
+func NewTimeoutTaskRevisionRepository(next repository.TaskRevisionRepository, timeout time.Duration) *TimeoutTaskRevisionRepository {
+	return &TimeoutTaskRevisionRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Add stores a revision and deletes the oldest ones of its task beyond keep
+func (r *TimeoutTaskRevisionRepository) Add(ctx context.Context, revision *application.TaskRevision, keep int) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Add(ctx, revision, keep))
+}
+
+// FindByTaskID finds the revisions of a task, newest first
+func (r *TimeoutTaskRevisionRepository) FindByTaskID(ctx context.Context, taskID string) ([]*application.TaskRevision, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	revisions, err := r.next.FindByTaskID(ctx, taskID)
+	return revisions, r.timeout.wrap(ctx, err)
+}
+
+// TimeoutIdempotencyRepository decorates an IdempotencyRepository with per-query timeouts
+type TimeoutIdempotencyRepository struct {
+	next    repository.IdempotencyRepository
+	timeout queryTimeout
+}
+
+// NewTimeoutIdempotencyRepository creates a new TimeoutIdempotencyRepository
+func NewTimeoutIdempotencyRepository(next repository.IdempotencyRepository, timeout time.Duration) *TimeoutIdempotencyRepository {
+	return &TimeoutIdempotencyRepository{next: next, timeout: queryTimeout(timeout)}
+}
+
+// Reserve stores the record of a request about to run, unless its key is taken
+func (r *TimeoutIdempotencyRepository) Reserve(ctx context.Context, record *application.IdempotencyRecord, staleBefore time.Time) (*application.IdempotencyRecord, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	existing, err := r.next.Reserve(ctx, record, staleBefore)
+	return existing, r.timeout.wrap(ctx, err)
+}
+
+// Complete stores the response of a reserved request
+func (r *TimeoutIdempotencyRepository) Complete(ctx context.Context, record *application.IdempotencyRecord) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Complete(ctx, record))
+}
+
+// Delete deletes the record of a key
+func (r *TimeoutIdempotencyRepository) Delete(ctx context.Context, userID, key string) error {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	return r.timeout.wrap(ctx, r.next.Delete(ctx, userID, key))
+}
+
+// DeleteExpired deletes the records expired by a time
+func (r *TimeoutIdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	ctx, cancel := r.timeout.start(ctx)
+	defer cancel()
+	deleted, err := r.next.DeleteExpired(ctx, now)
+	return deleted, r.timeout.wrap(ctx, err)
+}
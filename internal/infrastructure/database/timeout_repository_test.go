@@ -0,0 +1,146 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// blockingTaskRepository waits for the context of FindByID to be done, like a query stuck on a lock
+type blockingTaskRepository struct {
+	repository.TaskRepository
+	deadline time.Time
+}
+
+func (r *blockingTaskRepository) FindByID(ctx context.Context, id string) (*application.Task, error) {
+	r.deadline, _ = ctx.Deadline()
+	<-ctx.Done()
+	return nil, errors.New("interrupted")
+}
+
+func TestTimeoutTaskRepository_CancelsSlowQuery(t *testing.T) {
+	next := &blockingTaskRepository{}
+	repo := NewTimeoutTaskRepository(next, 20*time.Millisecond)
+
+	start := time.Now()
+	_, err := repo.FindByID(context.Background(), "task-1")
+
+	if !errors.Is(err, ErrQueryTimeout) {
+		t.Fatalf("Expected ErrQueryTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the query to be cut at the timeout, took %v", elapsed)
+	}
+}
+
+func TestTimeoutTaskRepository_KeepsEarlierCallerDeadline(t *testing.T) {
+	next := &blockingTaskRepository{}
+	repo := NewTimeoutTaskRepository(next, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	want, _ := ctx.Deadline()
+
+	repo.FindByID(ctx, "task-1")
+
+	if !next.deadline.Equal(want) {
+		t.Errorf("Expected caller deadline %v to be kept, got %v", want, next.deadline)
+	}
+}
+
+func TestTimeoutTaskRepository_CallerCancellationIsNotATimeout(t *testing.T) {
+	repo := NewTimeoutTaskRepository(&blockingTaskRepository{}, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := repo.FindByID(ctx, "task-1")
+	if err == nil || errors.Is(err, ErrQueryTimeout) {
+		t.Errorf("Expected the original error for a canceled request, got %v", err)
+	}
+}
+
+func TestNewSQLiteDB_ConnectionPragmas(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), 1500*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	// Hold several connections at once so pragmas are checked on more than the first one
+	db.SetMaxIdleConns(0)
+	for i := 0; i < 3; i++ {
+		var journalMode string
+		var busyTimeout, foreignKeys, synchronous int
+		if err := db.QueryRow("PRAGMA journal_mode").Scan(&journalMode); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.QueryRow("PRAGMA foreign_keys").Scan(&foreignKeys); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.QueryRow("PRAGMA synchronous").Scan(&synchronous); err != nil {
+			t.Fatal(err)
+		}
+
+		if journalMode != "wal" {
+			t.Errorf("Expected journal_mode wal, got %s", journalMode)
+		}
+		if busyTimeout != 1500 {
+			t.Errorf("Expected busy_timeout 1500, got %d", busyTimeout)
+		}
+		if foreignKeys != 1 {
+			t.Errorf("Expected foreign_keys on, got %d", foreignKeys)
+		}
+		if synchronous != 1 {
+			t.Errorf("Expected synchronous NORMAL (1), got %d", synchronous)
+		}
+	}
+}
+
+func TestNewSQLiteDB_Pool(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         func(t *testing.T) string
+		wantDefault  int
+		maxOpenConns int
+		wantOpen     int
+	}{
+		{"file", func(t *testing.T) string { return filepath.Join(t.TempDir(), "test.db") }, DefaultMaxOpenConns, 4, 4},
+		{"in memory", func(t *testing.T) string { return ":memory:" }, 1, 4, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := tt.path(t)
+			db, err := NewSQLiteDB(path, time.Second)
+			if err != nil {
+				t.Fatalf("Failed to open database: %v", err)
+			}
+			defer db.Close()
+
+			if got := db.Stats().MaxOpenConnections; got != tt.wantDefault {
+				t.Errorf("MaxOpenConnections = %d, want %d", got, tt.wantDefault)
+			}
+			ConfigurePool(db, path, tt.maxOpenConns)
+			if got := db.Stats().MaxOpenConnections; got != tt.wantOpen {
+				t.Errorf("MaxOpenConnections after ConfigurePool = %d, want %d", got, tt.wantOpen)
+			}
+		})
+	}
+}
+
+func TestNewSQLiteDB_UnreachablePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "test.db")
+	if db, err := NewSQLiteDB(path, time.Second); err == nil {
+		db.Close()
+		t.Fatal("Expected an error opening a database in a missing directory")
+	}
+}
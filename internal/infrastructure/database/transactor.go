@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// txKey is the context key of the transaction started by SQLiteTransactor
+type txKey struct{}
+
+// executor is implemented by both *sql.DB and *sql.Tx
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// conn returns the transaction carried by ctx, or db when the call is not part of one
+func conn(ctx context.Context, db *sql.DB) executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// SQLiteTransactor implements repository.Transactor using SQLite transactions
+type SQLiteTransactor struct {
+	db *sql.DB
+}
+
+// NewSQLiteTransactor creates a new SQLiteTransactor
+func NewSQLiteTransactor(db *sql.DB) *SQLiteTransactor {
+	return &SQLiteTransactor{db: db}
+}
+
+// WithinTransaction runs fn in a transaction. A call nested in another transaction joins it.
+// A transaction that finds the database locked is rolled back and run again from the start,
+// with defaultBusyRetry.
+func (t *SQLiteTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	return defaultBusyRetry.do(ctx, func() error {
+		return t.run(ctx, fn)
+	})
+}
+
+// run runs fn in a new transaction, committed when fn returns nil
+func (t *SQLiteTransactor) run(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteTransactor_DeleteTaskWithShares(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	tasks := NewSQLiteTaskRepository(db)
+	shares := NewSQLiteShareRepository(db)
+	transactor := NewTimeoutTransactor(NewSQLiteTransactor(db), time.Second)
+
+	for _, id := range []string{"owner", "viewer"} {
+		if err := users.Create(ctx, &application.User{ID: id, Name: id, Email: id + "@example.com", CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	task, _ := application.NewTask("task-1", "Relatório", "", application.StatusPending, "owner", "")
+	if err := tasks.Create(ctx, task); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	if err := shares.Share(ctx, "task-1", "viewer"); err != nil {
+		t.Fatalf("Failed to share task: %v", err)
+	}
+	if err := shares.AcceptInvitation(ctx, "task-1", "viewer"); err != nil {
+		t.Fatalf("Failed to accept the invitation: %v", err)
+	}
+
+	deleteAll := func(ctx context.Context) error {
+		if err := shares.DeleteAllShares(ctx, "task-1"); err != nil {
+			return err
+		}
+		return tasks.Delete(ctx, "task-1")
+	}
+
+	// A failure after the writes rolls all of them back
+	failure := errors.New("disk full")
+	err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := deleteAll(ctx); err != nil {
+			return err
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Expected the function error, got %v", err)
+	}
+	if found, _ := tasks.FindByID(ctx, "task-1"); found == nil {
+		t.Fatal("Expected the task to survive a rolled back transaction")
+	}
+	if shared, _ := shares.IsSharedWith(ctx, "task-1", "viewer"); !shared {
+		t.Fatal("Expected the share to survive a rolled back transaction")
+	}
+
+	// Nested calls join the outer transaction
+	err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return transactor.WithinTransaction(ctx, deleteAll)
+	})
+	if err != nil {
+		t.Fatalf("WithinTransaction() error: %v", err)
+	}
+	if found, _ := tasks.FindByID(ctx, "task-1"); found != nil {
+		t.Error("Expected the task to be deleted")
+	}
+	if users, _ := shares.FindSharedUsers(ctx, "task-1"); len(users) != 0 {
+		t.Errorf("Expected no shares left, got %v", users)
+	}
+}
@@ -0,0 +1,201 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteTwoFactorRepository implements repository.TwoFactorRepository using SQLite
+type SQLiteTwoFactorRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteTwoFactorRepository creates a new SQLiteTwoFactorRepository
+func NewSQLiteTwoFactorRepository(db *sql.DB) *SQLiteTwoFactorRepository {
+	return &SQLiteTwoFactorRepository{db: db}
+}
+
+// FindByUserID finds the second factor of a user using prepared statement
+func (r *SQLiteTwoFactorRepository) FindByUserID(ctx context.Context, userID string) (*application.TwoFactor, error) {
+	query := `SELECT user_id, secret, enabled, last_step, created_at FROM two_factor WHERE user_id = ?`
+
+	var twoFactor application.TwoFactor
+	var createdAt string
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&twoFactor.UserID,
+		&twoFactor.Secret,
+		&twoFactor.Enabled,
+		&twoFactor.LastStep,
+		&createdAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, application.ErrTwoFactorNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if twoFactor.CreatedAt, err = time.Parse(sortableTimeLayout, createdAt); err != nil {
+		return nil, err
+	}
+	return &twoFactor, nil
+}
+
+// Save creates or replaces the second factor of a user using prepared statement
+func (r *SQLiteTwoFactorRepository) Save(ctx context.Context, twoFactor *application.TwoFactor) error {
+	query := `INSERT INTO two_factor (user_id, secret, enabled, last_step, created_at)
+	          VALUES (?, ?, ?, ?, ?)
+	          ON CONFLICT(user_id) DO UPDATE SET
+	              secret = excluded.secret,
+	              enabled = excluded.enabled,
+	              last_step = excluded.last_step,
+	              created_at = excluded.created_at`
+
+	_, err := r.db.ExecContext(ctx, query,
+		twoFactor.UserID,
+		twoFactor.Secret,
+		twoFactor.Enabled,
+		twoFactor.LastStep,
+		twoFactor.CreatedAt.UTC().Format(sortableTimeLayout),
+	)
+	return err
+}
+
+// AdvanceLastStep stores a newer last accepted time step using prepared statement
+func (r *SQLiteTwoFactorRepository) AdvanceLastStep(ctx context.Context, userID string, step int64) (bool, error) {
+	query := `UPDATE two_factor SET last_step = ? WHERE user_id = ? AND last_step < ?`
+
+	result, err := r.db.ExecContext(ctx, query, step, userID, step)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// Delete deletes the second factor and the recovery codes of a user in a transaction
+func (r *SQLiteTwoFactorRepository) Delete(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM two_factor WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ReplaceRecoveryCodes replaces the recovery codes of a user in a transaction
+func (r *SQLiteTwoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO recovery_codes (user_id, code_hash) VALUES (?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, hash := range codeHashes {
+		if _, err := stmt.ExecContext(ctx, userID, hash); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// UseRecoveryCode deletes a recovery code of a user using prepared statement
+func (r *SQLiteTwoFactorRepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = ? AND code_hash = ?`, userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// CountRecoveryCodes counts the recovery codes of a user using prepared statement
+func (r *SQLiteTwoFactorRepository) CountRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM recovery_codes WHERE user_id = ?`, userID).Scan(&count)
+	return count, err
+}
+
+// CreateChallenge creates a login challenge using prepared statement. Expired challenges
+// are dropped on the way, so abandoned logins don't pile up.
+func (r *SQLiteTwoFactorRepository) CreateChallenge(ctx context.Context, challenge *application.TwoFactorChallenge) error {
+	now := time.Now().UTC().Format(sortableTimeLayout)
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM two_factor_challenges WHERE expires_at < ?`, now); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO two_factor_challenges (token_hash, user_id, remember_me, attempts, expires_at)
+	          VALUES (?, ?, ?, ?, ?)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		challenge.TokenHash,
+		challenge.UserID,
+		challenge.RememberMe,
+		challenge.Attempts,
+		challenge.ExpiresAt.UTC().Format(sortableTimeLayout),
+	)
+	return err
+}
+
+// FindChallenge finds a login challenge by token hash using prepared statement
+func (r *SQLiteTwoFactorRepository) FindChallenge(ctx context.Context, tokenHash string) (*application.TwoFactorChallenge, error) {
+	query := `SELECT token_hash, user_id, remember_me, attempts, expires_at
+	          FROM two_factor_challenges WHERE token_hash = ?`
+
+	var challenge application.TwoFactorChallenge
+	var expiresAt string
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&challenge.TokenHash,
+		&challenge.UserID,
+		&challenge.RememberMe,
+		&challenge.Attempts,
+		&expiresAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, application.ErrTwoFactorChallengeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if challenge.ExpiresAt, err = time.Parse(sortableTimeLayout, expiresAt); err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// RecordChallengeAttempt counts a wrong code against a login challenge using prepared statement
+func (r *SQLiteTwoFactorRepository) RecordChallengeAttempt(ctx context.Context, tokenHash string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE two_factor_challenges SET attempts = attempts + 1 WHERE token_hash = ?`, tokenHash)
+	return err
+}
+
+// DeleteChallenge deletes a login challenge using prepared statement
+func (r *SQLiteTwoFactorRepository) DeleteChallenge(ctx context.Context, tokenHash string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM two_factor_challenges WHERE token_hash = ?`, tokenHash)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteTwoFactorRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	if err := users.Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	repo := NewSQLiteTwoFactorRepository(db)
+
+	missing, err := repo.FindByUserID(ctx, "u-ana")
+	if !errors.Is(err, application.ErrTwoFactorNotFound) || missing != nil {
+		t.Fatalf("FindByUserID() without second factor = %v, %v; want nil, ErrTwoFactorNotFound", missing, err)
+	}
+
+	twoFactor, _ := application.NewTwoFactor("u-ana", "SECRET1")
+	if err := repo.Save(ctx, twoFactor); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	// Saving again replaces the pending enrollment
+	twoFactor.Secret = "SECRET2"
+	twoFactor.Enabled = true
+	if err := repo.Save(ctx, twoFactor); err != nil {
+		t.Fatalf("Save() replacing error: %v", err)
+	}
+
+	found, err := repo.FindByUserID(ctx, "u-ana")
+	if err != nil || found == nil || found.Secret != "SECRET2" || !found.Enabled || found.LastStep != 0 {
+		t.Fatalf("FindByUserID() = %+v, %v; want the replaced, enabled second factor", found, err)
+	}
+
+	if advanced, err := repo.AdvanceLastStep(ctx, "u-ana", 100); err != nil || !advanced {
+		t.Errorf("AdvanceLastStep(100) = %v, %v; want true", advanced, err)
+	}
+	for _, step := range []int64{100, 99} {
+		if advanced, err := repo.AdvanceLastStep(ctx, "u-ana", step); err != nil || advanced {
+			t.Errorf("AdvanceLastStep(%d) after 100 = %v, %v; want false", step, advanced, err)
+		}
+	}
+
+	if err := repo.ReplaceRecoveryCodes(ctx, "u-ana", []string{"h1", "h2", "h3"}); err != nil {
+		t.Fatalf("ReplaceRecoveryCodes() error: %v", err)
+	}
+	if err := repo.ReplaceRecoveryCodes(ctx, "u-ana", []string{"h4", "h5"}); err != nil {
+		t.Fatalf("ReplaceRecoveryCodes() error: %v", err)
+	}
+	if count, err := repo.CountRecoveryCodes(ctx, "u-ana"); err != nil || count != 2 {
+		t.Errorf("CountRecoveryCodes() = %d, %v; want 2", count, err)
+	}
+
+	if used, err := repo.UseRecoveryCode(ctx, "u-ana", "h1"); err != nil || used {
+		t.Errorf("UseRecoveryCode() of a replaced code = %v, %v; want false", used, err)
+	}
+	if used, err := repo.UseRecoveryCode(ctx, "u-ana", "h4"); err != nil || !used {
+		t.Errorf("UseRecoveryCode() = %v, %v; want true", used, err)
+	}
+	if used, err := repo.UseRecoveryCode(ctx, "u-ana", "h4"); err != nil || used {
+		t.Errorf("UseRecoveryCode() twice = %v, %v; want false", used, err)
+	}
+
+	if err := repo.Delete(ctx, "u-ana"); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if found, err := repo.FindByUserID(ctx, "u-ana"); !errors.Is(err, application.ErrTwoFactorNotFound) || found != nil {
+		t.Errorf("FindByUserID() after Delete() = %v, %v; want nil, ErrTwoFactorNotFound", found, err)
+	}
+	if count, err := repo.CountRecoveryCodes(ctx, "u-ana"); err != nil || count != 0 {
+		t.Errorf("CountRecoveryCodes() after Delete() = %d, %v; want 0", count, err)
+	}
+}
+
+func TestSQLiteTwoFactorRepository_Challenges(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	if err := users.Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	repo := NewSQLiteTwoFactorRepository(db)
+
+	expired := &application.TwoFactorChallenge{TokenHash: "expired", UserID: "u-ana", ExpiresAt: time.Now().Add(-time.Minute)}
+	if err := repo.CreateChallenge(ctx, expired); err != nil {
+		t.Fatalf("CreateChallenge() error: %v", err)
+	}
+
+	challenge, _ := application.NewTwoFactorChallenge("pending", "u-ana", true, 5*time.Minute)
+	if err := repo.CreateChallenge(ctx, challenge); err != nil {
+		t.Fatalf("CreateChallenge() error: %v", err)
+	}
+
+	if found, err := repo.FindChallenge(ctx, "expired"); !errors.Is(err, application.ErrTwoFactorChallengeNotFound) || found != nil {
+		t.Errorf("FindChallenge() of an expired challenge = %v, %v; want it dropped by CreateChallenge()", found, err)
+	}
+
+	if err := repo.RecordChallengeAttempt(ctx, "pending"); err != nil {
+		t.Fatalf("RecordChallengeAttempt() error: %v", err)
+	}
+
+	found, err := repo.FindChallenge(ctx, "pending")
+	if err != nil || found == nil {
+		t.Fatalf("FindChallenge() = %v, %v", found, err)
+	}
+	if found.UserID != "u-ana" || !found.RememberMe || found.Attempts != 1 || !found.ExpiresAt.Equal(challenge.ExpiresAt) {
+		t.Errorf("FindChallenge() = %+v", found)
+	}
+
+	if deleted, err := repo.DeleteChallenge(ctx, "pending"); err != nil || !deleted {
+		t.Errorf("DeleteChallenge() = %v, %v; want true", deleted, err)
+	}
+	if deleted, err := repo.DeleteChallenge(ctx, "pending"); err != nil || deleted {
+		t.Errorf("DeleteChallenge() twice = %v, %v; want false", deleted, err)
+	}
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+)
+
+// SQLiteUploadReferenceRepository implements repository.UploadReferenceRepository using SQLite
+type SQLiteUploadReferenceRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteUploadReferenceRepository creates a new SQLiteUploadReferenceRepository
+func NewSQLiteUploadReferenceRepository(db *sql.DB) *SQLiteUploadReferenceRepository {
+	return &SQLiteUploadReferenceRepository{db: db}
+}
+
+// ImagePaths returns the image paths of all tasks and the avatar paths of all users, which
+// share the uploads directory
+func (r *SQLiteUploadReferenceRepository) ImagePaths(ctx context.Context) ([]string, error) {
+	query := `SELECT image_path FROM tasks WHERE image_path <> ''
+	          UNION
+	          SELECT avatar_path FROM users WHERE avatar_path <> ''`
+
+	return r.queryPaths(ctx, query)
+}
+
+// AttachmentPaths returns the file paths of all task attachments
+func (r *SQLiteUploadReferenceRepository) AttachmentPaths(ctx context.Context) ([]string, error) {
+	query := `SELECT DISTINCT path FROM attachments`
+
+	return r.queryPaths(ctx, query)
+}
+
+func (r *SQLiteUploadReferenceRepository) queryPaths(ctx context.Context, query string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var paths []string
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, rows.Err()
+}
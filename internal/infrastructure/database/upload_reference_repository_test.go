@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteUploadReferenceRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	for _, user := range []*application.User{
+		{ID: "u-ana", Name: "Ana", Email: "ana@example.com", AvatarPath: "/uploads/images/3_avatar.png", CreatedAt: time.Now()},
+		{ID: "u-bia", Name: "Bia", Email: "bia@example.com", CreatedAt: time.Now()},
+	} {
+		if err := users.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	tasks := NewSQLiteTaskRepository(db)
+	for _, tc := range []struct{ id, imagePath string }{
+		{"t-image", "/uploads/images/1_task.png"},
+		{"t-same-image", "/uploads/images/1_task.png"},
+		{"t-other-image", "/uploads/images/2_task.png"},
+		{"t-no-image", ""},
+	} {
+		task, _ := application.NewTask(tc.id, tc.id, "", application.StatusPending, "u-ana", tc.imagePath)
+		if err := tasks.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	attachments := NewSQLiteAttachmentRepository(db)
+	for _, id := range []string{"a1", "a2"} {
+		attachment, _ := application.NewAttachment(id, "t-image", id+".pdf", "application/pdf", 10, id+"_stored.pdf")
+		if err := attachments.Create(ctx, attachment); err != nil {
+			t.Fatalf("Failed to create attachment: %v", err)
+		}
+	}
+
+	repo := NewSQLiteUploadReferenceRepository(db)
+
+	tests := []struct {
+		name  string
+		paths func(ctx context.Context) ([]string, error)
+		want  []string
+	}{
+		{name: "task images and avatars", paths: repo.ImagePaths, want: []string{"/uploads/images/1_task.png", "/uploads/images/2_task.png", "/uploads/images/3_avatar.png"}},
+		{name: "attachment files", paths: repo.AttachmentPaths, want: []string{"a1_stored.pdf", "a2_stored.pdf"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.paths(ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			sort.Strings(got)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Expected %v, got %v", tt.want, got)
+					break
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
@@ -10,24 +11,32 @@ import (
 
 // SQLiteUserRepository implements repository.UserRepository using SQLite
 type SQLiteUserRepository struct {
-	db *sql.DB
+	db    *sql.DB
+	stmts *preparedStatements
 }
 
 // NewSQLiteUserRepository creates a new SQLiteUserRepository
 func NewSQLiteUserRepository(db *sql.DB) *SQLiteUserRepository {
-	return &SQLiteUserRepository{db: db}
+	return &SQLiteUserRepository{db: db, stmts: newPreparedStatements(db)}
 }
 
 // Create creates a new user using prepared statement
 func (r *SQLiteUserRepository) Create(ctx context.Context, user *application.User) error {
-	query := `INSERT INTO users (id, name, email, password_hash, created_at)
-	          VALUES (?, ?, ?, ?, ?)`
+	query := `INSERT INTO users (id, name, email, password_hash, role, unit, theme, locale, overdue_opt_out, avatar_path, disabled, created_at)
+	          VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.stmts.ExecContext(ctx, query,
 		user.ID,
 		user.Name,
 		user.Email,
 		user.PasswordHash,
+		userRole(user),
+		user.Unit,
+		userTheme(user),
+		string(user.Locale),
+		user.OverdueOptOut,
+		user.AvatarPath,
+		user.Disabled,
 		user.CreatedAt,
 	)
 	return err
@@ -35,65 +44,94 @@ func (r *SQLiteUserRepository) Create(ctx context.Context, user *application.Use
 
 // FindByID finds a user by ID using prepared statement
 func (r *SQLiteUserRepository) FindByID(ctx context.Context, id string) (*application.User, error) {
-	query := `SELECT id, name, email, password_hash, created_at
+	query := `SELECT id, name, email, password_hash, role, unit, theme, locale, overdue_opt_out, avatar_path, disabled, token_version, created_at
 	          FROM users WHERE id = ?`
 
 	var user application.User
-	var createdAt string
+	var role, theme, locale, createdAt string
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.stmts.QueryRowContext(ctx, query, id).Scan(
 		&user.ID,
 		&user.Name,
 		&user.Email,
 		&user.PasswordHash,
+		&role,
+		&user.Unit,
+		&theme,
+		&locale,
+		&user.OverdueOptOut,
+		&user.AvatarPath,
+		&user.Disabled,
+		&user.TokenVersion,
 		&createdAt,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, nil
+			return nil, application.ErrUserNotFound
 		}
 		return nil, err
 	}
 
+	user.Role = application.UserRole(role)
+	user.Theme = application.Theme(theme)
+	user.Locale = application.Locale(locale)
 	user.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
 	return &user, nil
 }
 
 // FindByEmail finds a user by email using prepared statement
 func (r *SQLiteUserRepository) FindByEmail(ctx context.Context, email string) (*application.User, error) {
-	query := `SELECT id, name, email, password_hash, created_at
+	query := `SELECT id, name, email, password_hash, role, unit, theme, locale, overdue_opt_out, avatar_path, disabled, token_version, created_at
 	          FROM users WHERE email = ?`
 
 	var user application.User
-	var createdAt string
+	var role, theme, locale, createdAt string
 
-	err := r.db.QueryRowContext(ctx, query, email).Scan(
+	err := r.stmts.QueryRowContext(ctx, query, email).Scan(
 		&user.ID,
 		&user.Name,
 		&user.Email,
 		&user.PasswordHash,
+		&role,
+		&user.Unit,
+		&theme,
+		&locale,
+		&user.OverdueOptOut,
+		&user.AvatarPath,
+		&user.Disabled,
+		&user.TokenVersion,
 		&createdAt,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, nil
+			return nil, application.ErrUserNotFound
 		}
 		return nil, err
 	}
 
+	user.Role = application.UserRole(role)
+	user.Theme = application.Theme(theme)
+	user.Locale = application.Locale(locale)
 	user.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
 	return &user, nil
 }
 
 // Update updates an existing user using prepared statement
 func (r *SQLiteUserRepository) Update(ctx context.Context, user *application.User) error {
-	query := `UPDATE users SET name = ?, email = ?, password_hash = ?
+	query := `UPDATE users SET name = ?, email = ?, password_hash = ?, role = ?, unit = ?, theme = ?, locale = ?, overdue_opt_out = ?, avatar_path = ?, disabled = ?
 	          WHERE id = ?`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.stmts.ExecContext(ctx, query,
 		user.Name,
 		user.Email,
 		user.PasswordHash,
+		userRole(user),
+		user.Unit,
+		userTheme(user),
+		string(user.Locale),
+		user.OverdueOptOut,
+		user.AvatarPath,
+		user.Disabled,
 		user.ID,
 	)
 	return err
@@ -102,6 +140,109 @@ func (r *SQLiteUserRepository) Update(ctx context.Context, user *application.Use
 // Delete deletes a user using prepared statement
 func (r *SQLiteUserRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM users WHERE id = ?`
-	_, err := r.db.ExecContext(ctx, query, id)
+	_, err := r.stmts.ExecContext(ctx, query, id)
 	return err
 }
+
+// RevokeSessions increments the token version of a user using prepared statement, in the
+// database so concurrent updates of the user can't undo it
+func (r *SQLiteUserRepository) RevokeSessions(ctx context.Context, id string) error {
+	query := `UPDATE users SET token_version = token_version + 1 WHERE id = ?`
+	_, err := r.stmts.ExecContext(ctx, query, id)
+	return err
+}
+
+// Search finds users whose name or email contains the query using prepared statement.
+// LIKE wildcards typed by the user are escaped so they match literally.
+func (r *SQLiteUserRepository) Search(ctx context.Context, query, excludeID string, limit int) ([]*application.User, error) {
+	sqlQuery := `SELECT id, name, email, password_hash, role, unit, theme, locale, overdue_opt_out, avatar_path, disabled, token_version, created_at
+	             FROM users
+	             WHERE id != ? AND (name LIKE ? ESCAPE '\' OR email LIKE ? ESCAPE '\')
+	             ORDER BY name, email
+	             LIMIT ?`
+
+	pattern := "%" + likeEscaper.Replace(query) + "%"
+	rows, err := r.stmts.QueryContext(ctx, sqlQuery, excludeID, pattern, pattern, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanUsers(rows)
+}
+
+// FindByIDs finds the users with the given IDs in a single query
+func (r *SQLiteUserRepository) FindByIDs(ctx context.Context, ids []string) ([]*application.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	query := `SELECT id, name, email, password_hash, role, unit, theme, locale, overdue_opt_out, avatar_path, disabled, token_version, created_at
+	          FROM users WHERE id IN (` + placeholders + `)`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanUsers(rows)
+}
+
+// scanUsers reads the users of rows and closes them
+func scanUsers(rows *sql.Rows) ([]*application.User, error) {
+	defer rows.Close()
+
+	var users []*application.User
+	for rows.Next() {
+		var user application.User
+		var role, theme, locale, createdAt string
+
+		if err := rows.Scan(
+			&user.ID,
+			&user.Name,
+			&user.Email,
+			&user.PasswordHash,
+			&role,
+			&user.Unit,
+			&theme,
+			&locale,
+			&user.OverdueOptOut,
+			&user.AvatarPath,
+			&user.Disabled,
+			&user.TokenVersion,
+			&createdAt,
+		); err != nil {
+			return nil, err
+		}
+
+		user.Role = application.UserRole(role)
+		user.Theme = application.Theme(theme)
+		user.Locale = application.Locale(locale)
+		user.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		users = append(users, &user)
+	}
+
+	return users, rows.Err()
+}
+
+// likeEscaper escapes the LIKE wildcards and the escape character itself
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// userRole returns the role to persist, defaulting to member
+func userRole(user *application.User) string {
+	if user.Role == "" {
+		return string(application.RoleMember)
+	}
+	return string(user.Role)
+}
+
+// userTheme returns the theme to persist, defaulting to light
+func userTheme(user *application.User) string {
+	if user.Theme == "" {
+		return string(application.ThemeLight)
+	}
+	return string(user.Theme)
+}
@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteUserRepository_Search(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewSQLiteUserRepository(db)
+	ctx := context.Background()
+	for _, user := range []*application.User{
+		{ID: "u-ana", Name: "Ana Souza", Email: "ana@receita.gov.br", CreatedAt: time.Now()},
+		{ID: "u-bruno", Name: "Bruno", Email: "bruno_lima@receita.gov.br", CreatedAt: time.Now()},
+		{ID: "u-carla", Name: "Carla 100%", Email: "carla@example.com", CreatedAt: time.Now()},
+	} {
+		if err := repo.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		excludeID string
+		limit     int
+		wantIDs   []string
+	}{
+		{"matches name case-insensitively", "souza", "", 10, []string{"u-ana"}},
+		{"matches email", "receita.gov", "", 10, []string{"u-ana", "u-bruno"}},
+		{"excludes the requester", "receita.gov", "u-ana", 10, []string{"u-bruno"}},
+		{"respects the limit", "receita.gov", "", 1, []string{"u-ana"}},
+		{"underscore is literal", "o_l", "", 10, []string{"u-bruno"}},
+		{"percent is literal", "0%", "", 10, []string{"u-carla"}},
+		{"lone percent does not match everything", "%", "", 10, []string{"u-carla"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, err := repo.Search(ctx, tt.query, tt.excludeID, tt.limit)
+			if err != nil {
+				t.Fatalf("Search() error: %v", err)
+			}
+
+			var ids []string
+			for _, user := range users {
+				ids = append(ids, user.ID)
+			}
+			if len(ids) != len(tt.wantIDs) {
+				t.Fatalf("Search(%q) = %v, want %v", tt.query, ids, tt.wantIDs)
+			}
+			for i := range ids {
+				if ids[i] != tt.wantIDs[i] {
+					t.Errorf("Search(%q) = %v, want %v", tt.query, ids, tt.wantIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestSQLiteUserRepository_FindByIDs(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewSQLiteUserRepository(db)
+	ctx := context.Background()
+	for _, user := range []*application.User{
+		{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()},
+		{ID: "u-bia", Name: "Bia", Email: "bia@example.com", CreatedAt: time.Now()},
+		{ID: "u-caio", Name: "Caio", Email: "caio@example.com", CreatedAt: time.Now()},
+	} {
+		if err := repo.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	users, err := repo.FindByIDs(ctx, []string{"u-ana", "u-caio", "u-missing"})
+	if err != nil {
+		t.Fatalf("FindByIDs() error: %v", err)
+	}
+	names := make(map[string]string)
+	for _, user := range users {
+		names[user.ID] = user.Name
+	}
+	if len(names) != 2 || names["u-ana"] != "Ana" || names["u-caio"] != "Caio" {
+		t.Errorf("FindByIDs() = %v, want Ana and Caio only", names)
+	}
+
+	if users, err := repo.FindByIDs(ctx, nil); err != nil || len(users) != 0 {
+		t.Errorf("FindByIDs(nil) = %v, %v; want none", users, err)
+	}
+}
+
+func TestSQLiteUserRepository_NotFound(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewSQLiteUserRepository(db)
+	ctx := context.Background()
+
+	if user, err := repo.FindByID(ctx, "missing"); !errors.Is(err, application.ErrUserNotFound) || user != nil {
+		t.Errorf("FindByID() of missing user = %v, %v; want nil, ErrUserNotFound", user, err)
+	}
+	if user, err := repo.FindByEmail(ctx, "nobody@example.com"); !errors.Is(err, application.ErrUserNotFound) || user != nil {
+		t.Errorf("FindByEmail() of missing user = %v, %v; want nil, ErrUserNotFound", user, err)
+	}
+}
+
+func TestSQLiteUserRepository_Locale(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewSQLiteUserRepository(db)
+	ctx := context.Background()
+	if err := repo.Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	user, err := repo.FindByID(ctx, "u-ana")
+	if err != nil || user.Locale != "" {
+		t.Fatalf("FindByID() locale = %q, %v; want none chosen", user.Locale, err)
+	}
+
+	user.Locale = application.LocaleEN
+	if err := repo.Update(ctx, user); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+
+	user, err = repo.FindByEmail(ctx, "ana@example.com")
+	if err != nil || user.Locale != application.LocaleEN {
+		t.Errorf("FindByEmail() locale = %q, %v; want %q", user.Locale, err, application.LocaleEN)
+	}
+}
+
+func TestSQLiteUserRepository_AvatarPath(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewSQLiteUserRepository(db)
+	ctx := context.Background()
+	if err := repo.Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	user, err := repo.FindByID(ctx, "u-ana")
+	if err != nil || user.AvatarPath != "" {
+		t.Fatalf("FindByID() avatar = %q, %v; want none uploaded", user.AvatarPath, err)
+	}
+
+	user.AvatarPath = "/uploads/images/1_ana.png"
+	if err := repo.Update(ctx, user); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+
+	users, err := repo.Search(ctx, "ana", "", 10)
+	if err != nil || len(users) != 1 || users[0].AvatarPath != "/uploads/images/1_ana.png" {
+		t.Errorf("Search() = %v, %v; want Ana with her avatar", users, err)
+	}
+}
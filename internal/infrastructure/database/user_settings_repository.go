@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// SQLiteUserSettingsRepository implements repository.UserSettingsRepository using SQLite
+type SQLiteUserSettingsRepository struct {
+	stmts *preparedStatements
+}
+
+// NewSQLiteUserSettingsRepository creates a new SQLiteUserSettingsRepository
+func NewSQLiteUserSettingsRepository(db *sql.DB) *SQLiteUserSettingsRepository {
+	return &SQLiteUserSettingsRepository{stmts: newPreparedStatements(db)}
+}
+
+// Save stores the settings of a user, replacing the ones they had, using prepared statement
+func (r *SQLiteUserSettingsRepository) Save(ctx context.Context, settings *application.UserSettings) error {
+	query := `INSERT INTO user_settings (user_id, default_sort, notify_task_shared, notify_task_completed, notify_task_overdue, notify_comment_mention, updated_at)
+	          VALUES (?, ?, ?, ?, ?, ?, ?)
+	          ON CONFLICT(user_id) DO UPDATE SET
+	              default_sort = excluded.default_sort,
+	              notify_task_shared = excluded.notify_task_shared,
+	              notify_task_completed = excluded.notify_task_completed,
+	              notify_task_overdue = excluded.notify_task_overdue,
+	              notify_comment_mention = excluded.notify_comment_mention,
+	              updated_at = excluded.updated_at`
+
+	_, err := r.stmts.ExecContext(ctx, query,
+		settings.UserID,
+		string(settings.DefaultSort),
+		settings.Notifications.TaskShared,
+		settings.Notifications.TaskCompleted,
+		settings.Notifications.TaskOverdue,
+		settings.Notifications.CommentMention,
+		settings.UpdatedAt.UTC().Format(sortableTimeLayout),
+	)
+	return err
+}
+
+// FindByUserID finds the settings of a user using prepared statement
+func (r *SQLiteUserSettingsRepository) FindByUserID(ctx context.Context, userID string) (*application.UserSettings, error) {
+	query := `SELECT user_id, default_sort, notify_task_shared, notify_task_completed, notify_task_overdue, notify_comment_mention, updated_at
+	          FROM user_settings WHERE user_id = ?`
+
+	var settings application.UserSettings
+	var defaultSort, updatedAt string
+	err := r.stmts.QueryRowContext(ctx, query, userID).Scan(
+		&settings.UserID,
+		&defaultSort,
+		&settings.Notifications.TaskShared,
+		&settings.Notifications.TaskCompleted,
+		&settings.Notifications.TaskOverdue,
+		&settings.Notifications.CommentMention,
+		&updatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, application.ErrUserSettingsNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	settings.DefaultSort = application.TaskSort(defaultSort)
+	if settings.UpdatedAt, err = time.Parse(sortableTimeLayout, updatedAt); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSQLiteUserSettingsRepository(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	users := NewSQLiteUserRepository(db)
+	if err := users.Create(ctx, &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	repo := NewSQLiteUserSettingsRepository(db)
+	if settings, err := repo.FindByUserID(ctx, "u-ana"); !errors.Is(err, application.ErrUserSettingsNotFound) || settings != nil {
+		t.Fatalf("FindByUserID() before Save = %+v, %v; want nil, ErrUserSettingsNotFound", settings, err)
+	}
+
+	updated := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	first := application.DefaultUserSettings("u-ana")
+	first.UpdatedAt = updated
+	if err := repo.Save(ctx, first); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	second := &application.UserSettings{
+		UserID:        "u-ana",
+		DefaultSort:   application.SortOldest,
+		Notifications: application.NotificationSettings{TaskShared: true, CommentMention: true},
+		UpdatedAt:     updated.Add(time.Hour),
+	}
+	if err := repo.Save(ctx, second); err != nil {
+		t.Fatalf("Save() replacing the settings error: %v", err)
+	}
+
+	settings, err := repo.FindByUserID(ctx, "u-ana")
+	if err != nil || settings == nil {
+		t.Fatalf("FindByUserID() = %+v, %v; want the settings of u-ana", settings, err)
+	}
+	if settings.DefaultSort != application.SortOldest || settings.Notifications != second.Notifications || !settings.UpdatedAt.Equal(second.UpdatedAt) {
+		t.Errorf("FindByUserID() = %+v, want %+v", settings, second)
+	}
+
+	// The settings go with the account
+	if err := users.Delete(ctx, "u-ana"); err != nil {
+		t.Fatalf("Failed to delete user: %v", err)
+	}
+	if settings, err := repo.FindByUserID(ctx, "u-ana"); !errors.Is(err, application.ErrUserSettingsNotFound) || settings != nil {
+		t.Errorf("FindByUserID() after deleting the user = %+v, %v; want nil, ErrUserSettingsNotFound", settings, err)
+	}
+}
@@ -0,0 +1,64 @@
+// Package filestore reads and removes the files of the upload directories
+package filestore
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// Dir is an upload directory; it implements usecases.FileStore
+type Dir struct {
+	path string
+}
+
+// NewDir creates a Dir for the directory at path, which may not exist yet
+func NewDir(path string) *Dir {
+	return &Dir{path: path}
+}
+
+// List returns the regular files of the directory. Subdirectories and hidden files, such as
+// a .gitkeep, are left out; a missing directory has no files.
+func (d *Dir) List(ctx context.Context) ([]usecases.UploadedFile, error) {
+	entries, err := os.ReadDir(d.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]usecases.UploadedFile, 0, len(entries))
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		info, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			continue // Removed since the directory was read
+		}
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, usecases.UploadedFile{Name: entry.Name(), Size: info.Size(), ModTime: info.ModTime()})
+	}
+	return files, nil
+}
+
+// Remove deletes a file of the directory; a file that is already gone is not an error
+func (d *Dir) Remove(name string) error {
+	err := os.Remove(filepath.Join(d.path, filepath.Base(name)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,89 @@
+package filestore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestDir_List(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"1_abc.png": "png",
+		"2_def.jpg": "jpeg!",
+		".gitkeep":  "",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "nested"), 0755); err != nil {
+		t.Fatalf("Failed to create subdirectory: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		wantNames []string
+		wantSizes []int64
+	}{
+		{name: "regular files only", path: dir, wantNames: []string{"1_abc.png", "2_def.jpg"}, wantSizes: []int64{3, 5}},
+		{name: "missing directory is empty", path: filepath.Join(dir, "missing")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := NewDir(tt.path).List(context.Background())
+			if err != nil {
+				t.Fatalf("List() error: %v", err)
+			}
+			sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+
+			if len(files) != len(tt.wantNames) {
+				t.Fatalf("Expected %d files, got %+v", len(tt.wantNames), files)
+			}
+			for i, file := range files {
+				if file.Name != tt.wantNames[i] || file.Size != tt.wantSizes[i] {
+					t.Errorf("Expected %s (%d bytes), got %s (%d bytes)", tt.wantNames[i], tt.wantSizes[i], file.Name, file.Size)
+				}
+				if file.ModTime.IsZero() {
+					t.Errorf("Expected a modification time for %s", file.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestDir_Remove(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "images")
+	outside := filepath.Join(root, "outside.png")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	for _, path := range []string{filepath.Join(dir, "1_abc.png"), outside} {
+		if err := os.WriteFile(path, []byte("png"), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+	store := NewDir(dir)
+
+	if err := store.Remove("1_abc.png"); err != nil {
+		t.Fatalf("Remove() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "1_abc.png")); !os.IsNotExist(err) {
+		t.Errorf("Expected the file to be removed, stat error: %v", err)
+	}
+
+	// Already removed, and names can't leave the directory
+	for _, name := range []string{"1_abc.png", "../outside.png"} {
+		if err := store.Remove(name); err != nil {
+			t.Errorf("Remove(%q) error: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(outside); err != nil {
+		t.Errorf("Expected the file outside the directory to be kept, stat error: %v", err)
+	}
+}
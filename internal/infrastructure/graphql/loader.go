@@ -0,0 +1,110 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchFunc loads the values of several keys at once. Keys without a value are left out of
+// the map and load as the zero value, like a nil pointer.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader is a dataloader: the keys passed to Load are queued and fetched together, in a single
+// call of its batch function, when the value of any of them is first needed. Resolvers of a
+// list queue the keys of every item up front, so the items resolving in parallel share a
+// batch. Values are cached for the lifetime of the loader, which LoaderFor scopes to a single
+// query.
+type Loader[K comparable, V any] struct {
+	batch   BatchFunc[K, V]
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]bool
+	values  map[K]V
+	errs    map[K]error
+	loaded  map[K]bool
+}
+
+// NewLoader creates a new Loader over a batch function
+func NewLoader[K comparable, V any](batch BatchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		batch:  batch,
+		queued: make(map[K]bool),
+		values: make(map[K]V),
+		errs:   make(map[K]error),
+		loaded: make(map[K]bool),
+	}
+}
+
+// Load queues keys to be fetched with the next batch
+func (l *Loader[K, V]) Load(keys ...K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		if !l.loaded[key] && !l.queued[key] {
+			l.queued[key] = true
+			l.pending = append(l.pending, key)
+		}
+	}
+}
+
+// Get returns the value of a key, fetching it right away together with the keys queued so far
+// when it isn't loaded yet
+func (l *Loader[K, V]) Get(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.loaded[key] {
+		keys := l.pending
+		if !l.queued[key] {
+			keys = append(keys, key)
+		}
+		l.pending = nil
+		l.queued = make(map[K]bool)
+
+		values, err := l.batch(ctx, keys)
+		for _, k := range keys {
+			l.loaded[k] = true
+			if err != nil {
+				l.errs[k] = err
+				continue
+			}
+			if value, ok := values[k]; ok {
+				l.values[k] = value
+			}
+		}
+	}
+	return l.values[key], l.errs[key]
+}
+
+// loadersKey is the context key of the loaders of the query being executed
+type loadersKey struct{}
+
+// loaderRegistry holds the loaders of a query by name
+type loaderRegistry struct {
+	mu      sync.Mutex
+	loaders map[string]any
+}
+
+// withLoaders returns a context holding a new, empty set of loaders for a query
+func withLoaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaderRegistry{loaders: make(map[string]any)})
+}
+
+// LoaderFor returns the loader registered under name for the query being executed, creating
+// it with batch on first use, so every resolver of the query shares its batches and cache.
+// Outside of a query it returns a new loader.
+func LoaderFor[K comparable, V any](ctx context.Context, name string, batch BatchFunc[K, V]) *Loader[K, V] {
+	registry, ok := ctx.Value(loadersKey{}).(*loaderRegistry)
+	if !ok {
+		return NewLoader(batch)
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if loader, ok := registry.loaders[name].(*Loader[K, V]); ok {
+		return loader
+	}
+	loader := NewLoader(batch)
+	registry.loaders[name] = loader
+	return loader
+}
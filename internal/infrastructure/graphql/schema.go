@@ -0,0 +1,79 @@
+// Package graphql serves read-only GraphQL queries with graph-gophers/graphql-go: a schema in
+// the GraphQL schema definition language and the resolvers of its types. Mutations aren't
+// offered; writes stay in the REST API.
+//
+// Resolvers load related entities through a Loader, shared by every resolver of a query, so
+// the owners, sharees and comments of the tasks of a list are fetched in a few batches
+// instead of once per task.
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+// errInternal replaces errors that must not reach clients, like those of a repository, which
+// are logged instead
+var errInternal = errors.New("internal error")
+
+// DefaultMaxDepth limits how deeply fields of a query can nest, generous for the queries of a
+// client but small enough to keep a single request from loading the whole database
+const DefaultMaxDepth = 10
+
+// Request is a GraphQL request, as sent in the JSON body of a POST
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+	Extensions    map[string]any `json:"extensions,omitempty"` // accepted from clients that send it, and ignored
+}
+
+// Response is the result of a request. Data is absent when the request failed before
+// executing, because it was malformed or invalid, and null when a non-null root field failed.
+type Response struct {
+	Data   json.RawMessage         `json:"data,omitempty"`
+	Errors []*gqlerrors.QueryError `json:"errors,omitempty"`
+}
+
+// Schema is a GraphQL schema with the resolvers of its types
+type Schema struct {
+	schema *graphqlgo.Schema
+	sdl    string
+}
+
+// NewSchema parses a schema in the GraphQL schema definition language, whose descriptions are
+// strings, and checks that resolver, the resolver of its Query type, resolves every field
+func NewSchema(sdl string, resolver any) (*Schema, error) {
+	schema, err := graphqlgo.ParseSchema(sdl, resolver, graphqlgo.UseStringDescriptions(), graphqlgo.MaxDepth(DefaultMaxDepth))
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{schema: schema, sdl: sdl}, nil
+}
+
+// MustSchema is like NewSchema but panics on an invalid schema, for schemas defined in code
+func MustSchema(sdl string, resolver any) *Schema {
+	s, err := NewSchema(sdl, resolver)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Execute runs the query of a request. Errors of the request itself, like a syntax error or
+// an unknown field, are returned without data; errors of resolvers null their field and are
+// returned along the data of the rest of the query.
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	response := s.schema.Exec(withLoaders(ctx), req.Query, req.OperationName, req.Variables)
+	return &Response{Data: response.Data, Errors: response.Errors}
+}
+
+// SDL returns the schema in the GraphQL schema definition language, for clients to generate
+// code from
+func (s *Schema) SDL() string {
+	return s.sdl
+}
@@ -0,0 +1,338 @@
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"log"
+	"slices"
+	"time"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+//go:embed schema.graphql
+var todoSDL string
+
+// errInvalidFirst is returned for a page size out of the range of the REST API
+var errInvalidFirst = errors.New("first must be between 1 and 100")
+
+// Names of the loaders of the todo schema
+const (
+	userLoader    = "users"
+	shareeLoader  = "sharees"
+	commentLoader = "comments"
+)
+
+// todoResolvers resolves the fields of the todo schema over the repositories. The user making
+// the request is read from the context, where the auth middleware put it.
+type todoResolvers struct {
+	taskRepo    repository.TaskRepository
+	userRepo    repository.UserRepository
+	shareRepo   repository.ShareRepository
+	commentRepo repository.CommentRepository
+}
+
+// NewTodoSchema creates the schema of the GraphQL API, in schema.graphql: the tasks a user
+// owns or that are shared with them, their owners, the users they are shared with and their
+// comments
+func NewTodoSchema(taskRepo repository.TaskRepository, userRepo repository.UserRepository, shareRepo repository.ShareRepository, commentRepo repository.CommentRepository) *Schema {
+	return MustSchema(todoSDL, &todoResolvers{taskRepo: taskRepo, userRepo: userRepo, shareRepo: shareRepo, commentRepo: commentRepo})
+}
+
+// requestUserID returns the ID of the user making the request
+func requestUserID(ctx context.Context) string {
+	userID, _ := ctx.Value("userID").(string)
+	return userID
+}
+
+// internalError logs an error of a repository and hides it from the response
+func internalError(err error) error {
+	log.Printf("graphql: %v", err)
+	return errInternal
+}
+
+// formatTime formats an optional time in RFC 3339, in UTC
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.UTC().Format(time.RFC3339)
+	return &formatted
+}
+
+// users returns the loader of users by ID of a query
+func (r *todoResolvers) users(ctx context.Context) *Loader[string, *application.User] {
+	return LoaderFor(ctx, userLoader, func(ctx context.Context, ids []string) (map[string]*application.User, error) {
+		users, err := r.userRepo.FindByIDs(ctx, ids)
+		if err != nil {
+			return nil, internalError(err)
+		}
+		byID := make(map[string]*application.User, len(users))
+		for _, user := range users {
+			byID[user.ID] = user
+		}
+		return byID, nil
+	})
+}
+
+// sharees returns the loader of the IDs of the users each task is shared with of a query. The
+// users of a batch are queued together in the user loader.
+func (r *todoResolvers) sharees(ctx context.Context) *Loader[string, []string] {
+	return LoaderFor(ctx, shareeLoader, func(ctx context.Context, taskIDs []string) (map[string][]string, error) {
+		shared, err := r.shareRepo.FindSharedUsersByTaskIDs(ctx, taskIDs)
+		if err != nil {
+			return nil, internalError(err)
+		}
+		users := r.users(ctx)
+		for _, userIDs := range shared {
+			users.Load(userIDs...)
+		}
+		return shared, nil
+	})
+}
+
+// commentsOf returns the loader of the comments of each task of a query. The authors and the
+// mentioned users of a batch are queued together in the user loader.
+func (r *todoResolvers) commentsOf(ctx context.Context) *Loader[string, []*application.Comment] {
+	return LoaderFor(ctx, commentLoader, func(ctx context.Context, taskIDs []string) (map[string][]*application.Comment, error) {
+		comments, err := r.commentRepo.FindByTaskIDs(ctx, taskIDs)
+		if err != nil {
+			return nil, internalError(err)
+		}
+		users := r.users(ctx)
+		for _, taskComments := range comments {
+			for _, comment := range taskComments {
+				users.Load(comment.UserID)
+				users.Load(comment.Mentions...)
+			}
+		}
+		return comments, nil
+	})
+}
+
+// taskList returns the resolvers of a list of tasks, queueing their owners, sharees and
+// comments so each loads in one batch for the whole list
+func (r *todoResolvers) taskList(ctx context.Context, tasks []*application.Task) []*taskResolver {
+	users, sharees, comments := r.users(ctx), r.sharees(ctx), r.commentsOf(ctx)
+	resolvers := make([]*taskResolver, 0, len(tasks))
+	for _, task := range tasks {
+		users.Load(task.OwnerID)
+		sharees.Load(task.ID)
+		comments.Load(task.ID)
+		resolvers = append(resolvers, &taskResolver{r: r, task: task})
+	}
+	return resolvers
+}
+
+// userList returns the resolvers of the users with the given IDs. Users deleted in the
+// meantime are left out.
+func (r *todoResolvers) userList(ctx context.Context, userIDs []string) ([]*userResolver, error) {
+	users := r.users(ctx)
+	users.Load(userIDs...)
+	resolvers := []*userResolver{}
+	for _, userID := range userIDs {
+		user, err := users.Get(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if user != nil {
+			resolvers = append(resolvers, &userResolver{user: user})
+		}
+	}
+	return resolvers, nil
+}
+
+// user returns the resolver of the user with the given ID, nil when there's no such user
+func (r *todoResolvers) user(ctx context.Context, userID string) (*userResolver, error) {
+	user, err := r.users(ctx).Get(ctx, userID)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	return &userResolver{user: user}, nil
+}
+
+// Me resolves Query.me
+func (r *todoResolvers) Me(ctx context.Context) (*userResolver, error) {
+	user, err := r.user(ctx, requestUserID(ctx))
+	if err == nil && user == nil {
+		return nil, internalError(application.ErrUserNotFound)
+	}
+	return user, err
+}
+
+// Task resolves Query.task
+func (r *todoResolvers) Task(ctx context.Context, args struct{ ID graphqlgo.ID }) (*taskResolver, error) {
+	userID := requestUserID(ctx)
+	task, err := r.taskRepo.FindByID(ctx, string(args.ID))
+	if errors.Is(err, application.ErrTaskNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, internalError(err)
+	}
+	if task.OwnerID != userID {
+		shared, err := r.shareRepo.IsSharedWith(ctx, task.ID, userID)
+		if err != nil {
+			return nil, internalError(err)
+		}
+		if !shared {
+			// Tasks of other users look the same as missing ones
+			return nil, nil
+		}
+	}
+	return r.taskList(ctx, []*application.Task{task})[0], nil
+}
+
+// Tasks resolves Query.tasks
+func (r *todoResolvers) Tasks(ctx context.Context, args struct {
+	Status *string
+	Tag    *string
+	First  int32
+}) ([]*taskResolver, error) {
+	first, err := firstArg(args.First)
+	if err != nil {
+		return nil, err
+	}
+	var status, tag string
+	if args.Status != nil {
+		status = *args.Status
+	}
+	if args.Tag != nil {
+		tag = *args.Tag
+	}
+	query, err := application.NewTaskListQuery(status, tag, "", "", 1, first)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := r.taskRepo.FindByOwnerID(ctx, requestUserID(ctx))
+	if err != nil {
+		return nil, internalError(err)
+	}
+	filtered := []*application.Task{}
+	for _, task := range tasks {
+		if len(filtered) == first {
+			break
+		}
+		if query.Status != "" && task.Status != query.Status || query.Tag != "" && !slices.Contains(task.Tags, query.Tag) {
+			continue
+		}
+		filtered = append(filtered, task)
+	}
+	return r.taskList(ctx, filtered), nil
+}
+
+// SharedTasks resolves Query.sharedTasks
+func (r *todoResolvers) SharedTasks(ctx context.Context, args struct{ First int32 }) ([]*taskResolver, error) {
+	first, err := firstArg(args.First)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := r.taskRepo.FindSharedWithUser(ctx, requestUserID(ctx))
+	if err != nil {
+		return nil, internalError(err)
+	}
+	if len(tasks) > first {
+		tasks = tasks[:first]
+	}
+	return r.taskList(ctx, tasks), nil
+}
+
+// firstArg returns the number of items a list field may return
+func firstArg(first int32) (int, error) {
+	if first < 1 || first > application.MaxTasksPerPage {
+		return 0, errInvalidFirst
+	}
+	return int(first), nil
+}
+
+// taskResolver resolves the fields of a Task
+type taskResolver struct {
+	r    *todoResolvers
+	task *application.Task
+}
+
+func (t *taskResolver) ID() graphqlgo.ID       { return graphqlgo.ID(t.task.ID) }
+func (t *taskResolver) Title() string          { return t.task.Title }
+func (t *taskResolver) Description() string    { return t.task.Description }
+func (t *taskResolver) Status() string         { return string(t.task.Status) }
+func (t *taskResolver) Priority() string       { return string(t.task.Priority) }
+func (t *taskResolver) Tags() []string         { return t.task.Tags }
+func (t *taskResolver) Color() string          { return string(t.task.Color) }
+func (t *taskResolver) DueDate() *string       { return formatTime(t.task.DueDate) }
+func (t *taskResolver) Progress() int32        { return int32(t.task.PercentComplete()) }
+func (t *taskResolver) CompletionNote() string { return t.task.CompletionNote }
+func (t *taskResolver) SnoozedUntil() *string  { return formatTime(t.task.SnoozedUntil) }
+func (t *taskResolver) CreatedAt() string      { return *formatTime(&t.task.CreatedAt) }
+func (t *taskResolver) UpdatedAt() string      { return *formatTime(&t.task.UpdatedAt) }
+func (t *taskResolver) IsOwner(ctx context.Context) bool {
+	return t.task.OwnerID == requestUserID(ctx)
+}
+
+// Owner resolves Task.owner, null once the owner's account is deleted
+func (t *taskResolver) Owner(ctx context.Context) (*userResolver, error) {
+	return t.r.user(ctx, t.task.OwnerID)
+}
+
+// SharedWith resolves Task.sharedWith: the sharees of every task of the list load in one
+// batch, then their users in another one
+func (t *taskResolver) SharedWith(ctx context.Context) ([]*userResolver, error) {
+	userIDs, err := t.r.sharees(ctx).Get(ctx, t.task.ID)
+	if err != nil {
+		return nil, err
+	}
+	return t.r.userList(ctx, userIDs)
+}
+
+// Comments resolves Task.comments: the comments of every task of the list load in one batch
+func (t *taskResolver) Comments(ctx context.Context) ([]*commentResolver, error) {
+	comments, err := t.r.commentsOf(ctx).Get(ctx, t.task.ID)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*commentResolver, 0, len(comments))
+	for _, comment := range comments {
+		resolvers = append(resolvers, &commentResolver{r: t.r, comment: comment})
+	}
+	return resolvers, nil
+}
+
+// userResolver resolves the fields of a User
+type userResolver struct {
+	user *application.User
+}
+
+func (u *userResolver) ID() graphqlgo.ID { return graphqlgo.ID(u.user.ID) }
+func (u *userResolver) Name() string     { return u.user.Name }
+
+// Email resolves User.email, only for the user making the request
+func (u *userResolver) Email(ctx context.Context) *string {
+	if u.user.ID != requestUserID(ctx) {
+		return nil
+	}
+	return &u.user.Email
+}
+
+// commentResolver resolves the fields of a Comment
+type commentResolver struct {
+	r       *todoResolvers
+	comment *application.Comment
+}
+
+func (c *commentResolver) ID() graphqlgo.ID  { return graphqlgo.ID(c.comment.ID) }
+func (c *commentResolver) Body() string      { return c.comment.Body }
+func (c *commentResolver) CreatedAt() string { return *formatTime(&c.comment.CreatedAt) }
+
+// Author resolves Comment.author, null once the author's account is deleted
+func (c *commentResolver) Author(ctx context.Context) (*userResolver, error) {
+	return c.r.user(ctx, c.comment.UserID)
+}
+
+// Mentions resolves Comment.mentions, loaded with the authors
+func (c *commentResolver) Mentions(ctx context.Context) ([]*userResolver, error) {
+	return c.r.userList(ctx, c.comment.Mentions)
+}
@@ -0,0 +1,243 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+type mockTaskRepositoryForGraphQL struct {
+	repository.TaskRepository
+	tasks []*application.Task
+}
+
+func (m *mockTaskRepositoryForGraphQL) FindByID(ctx context.Context, id string) (*application.Task, error) {
+	for _, task := range m.tasks {
+		if task.ID == id {
+			return task, nil
+		}
+	}
+	return nil, application.ErrTaskNotFound
+}
+
+func (m *mockTaskRepositoryForGraphQL) FindByOwnerID(ctx context.Context, ownerID string) ([]*application.Task, error) {
+	var tasks []*application.Task
+	for _, task := range m.tasks {
+		if task.OwnerID == ownerID {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+func (m *mockTaskRepositoryForGraphQL) FindSharedWithUser(ctx context.Context, userID string) ([]*application.Task, error) {
+	return []*application.Task{m.tasks[2]}, nil
+}
+
+type mockUserRepositoryForGraphQL struct {
+	repository.UserRepository
+	users map[string]*application.User
+	calls [][]string
+}
+
+func (m *mockUserRepositoryForGraphQL) FindByIDs(ctx context.Context, ids []string) ([]*application.User, error) {
+	m.calls = append(m.calls, ids)
+	var users []*application.User
+	for _, id := range ids {
+		if user, ok := m.users[id]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+type mockShareRepositoryForGraphQL struct {
+	repository.ShareRepository
+	shares map[string][]string
+	calls  int
+}
+
+func (m *mockShareRepositoryForGraphQL) FindSharedUsersByTaskIDs(ctx context.Context, taskIDs []string) (map[string][]string, error) {
+	m.calls++
+	shared := make(map[string][]string)
+	for _, taskID := range taskIDs {
+		if users, ok := m.shares[taskID]; ok {
+			shared[taskID] = users
+		}
+	}
+	return shared, nil
+}
+
+func (m *mockShareRepositoryForGraphQL) IsSharedWith(ctx context.Context, taskID, userID string) (bool, error) {
+	for _, id := range m.shares[taskID] {
+		if id == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type mockCommentRepositoryForGraphQL struct {
+	repository.CommentRepository
+	comments map[string][]*application.Comment
+	calls    [][]string
+}
+
+func (m *mockCommentRepositoryForGraphQL) FindByTaskIDs(ctx context.Context, taskIDs []string) (map[string][]*application.Comment, error) {
+	m.calls = append(m.calls, taskIDs)
+	comments := make(map[string][]*application.Comment)
+	for _, taskID := range taskIDs {
+		if taskComments, ok := m.comments[taskID]; ok {
+			comments[taskID] = taskComments
+		}
+	}
+	return comments, nil
+}
+
+// The tasks of the schema tests: ana owns task-1, shared with bia and caio, and task-2, shared
+// with bia and a deleted user; bia owns task-3, shared with ana
+var (
+	todoCreated = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	todoDue     = time.Date(2026, 10, 20, 18, 0, 0, 0, time.UTC)
+	todoTasks   = []*application.Task{
+		{ID: "task-1", Title: "Relatório", Status: application.StatusPending, Priority: application.PriorityHigh, OwnerID: "ana", Tags: []string{"receita"}, DueDate: &todoDue, CreatedAt: todoCreated, UpdatedAt: todoCreated},
+		{ID: "task-2", Title: "Ofício", Status: application.StatusCompleted, Priority: application.PriorityNormal, OwnerID: "ana", CreatedAt: todoCreated, UpdatedAt: todoCreated},
+		{ID: "task-3", Title: "Parecer", Status: application.StatusPending, Priority: application.PriorityLow, OwnerID: "bia", CreatedAt: todoCreated, UpdatedAt: todoCreated},
+		{ID: "task-4", Title: "Privada", Status: application.StatusPending, Priority: application.PriorityLow, OwnerID: "caio", CreatedAt: todoCreated, UpdatedAt: todoCreated},
+	}
+	todoUsers = map[string]*application.User{
+		"ana":  {ID: "ana", Name: "Ana", Email: "ana@example.com"},
+		"bia":  {ID: "bia", Name: "Bia", Email: "bia@example.com"},
+		"caio": {ID: "caio", Name: "Caio", Email: "caio@example.com"},
+	}
+	todoShares = map[string][]string{
+		"task-1": {"bia", "caio"},
+		"task-2": {"bia", "deleted"},
+		"task-3": {"ana"},
+	}
+	todoComments = map[string][]*application.Comment{
+		"task-1": {
+			{ID: "comment-1", TaskID: "task-1", UserID: "bia", Body: "@ana.souza veja", Mentions: []string{"ana"}, CreatedAt: todoCreated},
+			{ID: "comment-2", TaskID: "task-1", UserID: "deleted", Body: "Feito", CreatedAt: todoCreated},
+		},
+	}
+)
+
+func executeAs(schema *Schema, userID, query string, variables map[string]any) string {
+	ctx := context.WithValue(context.Background(), "userID", userID)
+	response, _ := json.Marshal(schema.Execute(ctx, Request{Query: query, Variables: variables}))
+	return string(response)
+}
+
+func TestTodoSchema_LoadsInBatches(t *testing.T) {
+	users := &mockUserRepositoryForGraphQL{users: todoUsers}
+	shares := &mockShareRepositoryForGraphQL{shares: todoShares}
+	schema := NewTodoSchema(&mockTaskRepositoryForGraphQL{tasks: todoTasks}, users, shares, &mockCommentRepositoryForGraphQL{comments: todoComments})
+
+	got := executeAs(schema, "ana", `{
+		me { name email }
+		tasks { id status tags dueDate isOwner owner { name } sharedWith { id name email } }
+	}`, nil)
+
+	want := `{"data":{"me":{"name":"Ana","email":"ana@example.com"},"tasks":[` +
+		`{"id":"task-1","status":"pending","tags":["receita"],"dueDate":"2026-10-20T18:00:00Z","isOwner":true,"owner":{"name":"Ana"},"sharedWith":[{"id":"bia","name":"Bia","email":null},{"id":"caio","name":"Caio","email":null}]},` +
+		`{"id":"task-2","status":"completed","tags":[],"dueDate":null,"isOwner":true,"owner":{"name":"Ana"},"sharedWith":[{"id":"bia","name":"Bia","email":null}]}]}}`
+	if got != want {
+		t.Errorf("response =\n%s\nwant\n%s", got, want)
+	}
+
+	// The sharees of both tasks load together, and every user once: me and the owners, which
+	// may resolve first, then the sharees
+	if shares.calls != 1 {
+		t.Errorf("FindSharedUsersByTaskIDs() called %d times, want 1", shares.calls)
+	}
+	loaded := slices.Concat(users.calls...)
+	slices.Sort(loaded)
+	if len(users.calls) > 2 || fmt.Sprint(loaded) != "[ana bia caio deleted]" {
+		t.Errorf("FindByIDs() calls = %v, want every user loaded once, in at most 2 calls", users.calls)
+	}
+}
+
+func TestTodoSchema_Comments(t *testing.T) {
+	users := &mockUserRepositoryForGraphQL{users: todoUsers}
+	schema := NewTodoSchema(&mockTaskRepositoryForGraphQL{tasks: todoTasks}, users, &mockShareRepositoryForGraphQL{shares: todoShares}, &mockCommentRepositoryForGraphQL{comments: todoComments})
+
+	got := executeAs(schema, "ana", `{ tasks { id comments { id body createdAt author { name } mentions { name } } } }`, nil)
+
+	want := `{"data":{"tasks":[` +
+		`{"id":"task-1","comments":[{"id":"comment-1","body":"@ana.souza veja","createdAt":"2026-10-01T09:00:00Z","author":{"name":"Bia"},"mentions":[{"name":"Ana"}]},` +
+		`{"id":"comment-2","body":"Feito","createdAt":"2026-10-01T09:00:00Z","author":null,"mentions":[]}]},` +
+		`{"id":"task-2","comments":[]}]}}`
+	if got != want {
+		t.Errorf("response =\n%s\nwant\n%s", got, want)
+	}
+
+	// The authors and the mentioned users of both comments load together
+	if len(users.calls) != 1 || len(users.calls[0]) != 3 {
+		t.Errorf("FindByIDs() calls = %v, want [[bia ana deleted]]", users.calls)
+	}
+}
+
+func TestTodoSchema_Task(t *testing.T) {
+	schema := NewTodoSchema(&mockTaskRepositoryForGraphQL{tasks: todoTasks}, &mockUserRepositoryForGraphQL{users: todoUsers}, &mockShareRepositoryForGraphQL{shares: todoShares}, &mockCommentRepositoryForGraphQL{comments: todoComments})
+	query := `query ($id: ID!) { task(id: $id) { title isOwner owner { name } } }`
+
+	tests := []struct {
+		name   string
+		userID string
+		taskID string
+		want   string
+	}{
+		{name: "owned", userID: "ana", taskID: "task-1", want: `{"data":{"task":{"title":"Relatório","isOwner":true,"owner":{"name":"Ana"}}}}`},
+		{name: "shared", userID: "bia", taskID: "task-1", want: `{"data":{"task":{"title":"Relatório","isOwner":false,"owner":{"name":"Ana"}}}}`},
+		{name: "not shared", userID: "bia", taskID: "task-4", want: `{"data":{"task":null}}`},
+		{name: "missing", userID: "ana", taskID: "nope", want: `{"data":{"task":null}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := executeAs(schema, tt.userID, query, map[string]any{"id": tt.taskID}); got != tt.want {
+				t.Errorf("response = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTodoSchema_TaskFilters(t *testing.T) {
+	schema := NewTodoSchema(&mockTaskRepositoryForGraphQL{tasks: todoTasks}, &mockUserRepositoryForGraphQL{users: todoUsers}, &mockShareRepositoryForGraphQL{shares: todoShares}, &mockCommentRepositoryForGraphQL{comments: todoComments})
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "status", query: `{ tasks(status: "completed") { id } }`, want: `{"data":{"tasks":[{"id":"task-2"}]}}`},
+		{name: "tag with #", query: `{ tasks(tag: "#Receita") { id } }`, want: `{"data":{"tasks":[{"id":"task-1"}]}}`},
+		{name: "first", query: `{ tasks(first: 1) { id } }`, want: `{"data":{"tasks":[{"id":"task-1"}]}}`},
+		{name: "shared", query: `{ sharedTasks { id isOwner } }`, want: `{"data":{"sharedTasks":[{"id":"task-3","isOwner":false}]}}`},
+		{
+			name:  "invalid status",
+			query: `{ tasks(status: "done") { id } }`,
+			want:  `{"data":null,"errors":[{"message":"status must be pending, in_progress or completed","path":["tasks"]}]}`,
+		},
+		{
+			name:  "first out of range",
+			query: `{ sharedTasks(first: 500) { id } }`,
+			want:  `{"data":null,"errors":[{"message":"first must be between 1 and 100","path":["sharedTasks"]}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := executeAs(schema, "ana", tt.query, nil); got != tt.want {
+				t.Errorf("response =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// AuthInterceptor authenticates calls with the same JWTs as the REST API, sent in the
+// "authorization: Bearer <token>" metadata, and puts the user they identify in the context
+// like the HTTP auth middleware does. Tokens are checked against the current keys of jwtKeys
+// and against their user in users, so revoked sessions are refused.
+func AuthInterceptor(jwtKeys *service.JWTKeySet, users service.SessionUsers) grpcgo.UnaryServerInterceptor {
+	authService := service.NewAuthServiceWithKeys(jwtKeys, nil)
+
+	return func(ctx context.Context, req any, info *grpcgo.UnaryServerInfo, next grpcgo.UnaryHandler) (any, error) {
+		token, ok := strings.CutPrefix(metadataValue(ctx, "authorization"), "Bearer ")
+		if !ok || token == "" {
+			return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+		}
+		claims, err := authService.ValidateToken(token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		if err := service.CheckSession(ctx, users, claims); err != nil {
+			if errors.Is(err, service.ErrSessionRevoked) {
+				return nil, status.Error(codes.Unauthenticated, "invalid token")
+			}
+			return nil, fmt.Errorf("failed to check session: %w", err)
+		}
+
+		ctx = context.WithValue(ctx, "userID", claims.UserID)
+		ctx = context.WithValue(ctx, "email", claims.Email)
+		return next(ctx, req)
+	}
+}
+
+// metadataValue returns the first value of the metadata key the client sent, empty when
+// there is none
+func metadataValue(ctx context.Context, key string) string {
+	values := metadata.ValueFromIncomingContext(ctx, key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// OrgHeader is the metadata key clients use to pick the workspace of a call, the same header as
+// in the REST API
+const OrgHeader = "X-Org-ID"
+
+// OrgMembers finds the membership of a user in an org
+type OrgMembers interface {
+	FindMember(ctx context.Context, orgID, userID string) (*application.OrgMember, error)
+}
+
+// OrgScopeInterceptor scopes the task lists of each call to a workspace, like the HTTP org scope
+// middleware: the org named by the x-org-id metadata, or the user's personal tasks without it.
+// An org the user doesn't belong to is refused with PermissionDenied. It must run after
+// AuthInterceptor.
+func OrgScopeInterceptor(members OrgMembers) grpcgo.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpcgo.UnaryServerInfo, next grpcgo.UnaryHandler) (any, error) {
+		orgID := metadataValue(ctx, OrgHeader)
+		if orgID != "" {
+			_, err := members.FindMember(ctx, orgID, requestUserID(ctx))
+			switch {
+			case errors.Is(err, application.ErrOrgMemberNotFound):
+				return nil, status.Error(codes.PermissionDenied, "you are not a member of this org")
+			case err != nil:
+				return nil, fmt.Errorf("failed to check org membership: %w", err)
+			}
+		}
+		return next(repository.WithOrgScope(ctx, orgID), req)
+	}
+}
+
+// InvalidateOnWriteInterceptor calls invalidate with the authenticated user ID after every
+// successful call that may change tasks, every method but the Get and List ones, so per-user
+// caches never outlive the user's own writes. It must run after AuthInterceptor.
+func InvalidateOnWriteInterceptor(invalidate func(userID string)) grpcgo.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpcgo.UnaryServerInfo, next grpcgo.UnaryHandler) (any, error) {
+		resp, err := next(ctx, req)
+		name := info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]
+		if err == nil && !strings.HasPrefix(name, "Get") && !strings.HasPrefix(name, "List") {
+			if userID := requestUserID(ctx); userID != "" {
+				invalidate(userID)
+			}
+		}
+		return resp, err
+	}
+}
+
+// RecoveryInterceptor turns a panic of a call into an internal error, logged with the method
+func RecoveryInterceptor(ctx context.Context, req any, info *grpcgo.UnaryServerInfo, next grpcgo.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("grpc: panic in %s: %v", info.FullMethod, r)
+			resp, err = nil, fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return next(ctx, req)
+}
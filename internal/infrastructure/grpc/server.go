@@ -0,0 +1,64 @@
+// Package grpc serves the task use cases over gRPC, for the internal services that integrate
+// with the app. The messages and the service stubs are generated from
+// proto/todo/v1/tasks.proto into package todov1, and served by grpc-go; this package holds
+// the implementation of the service and the interceptors that authenticate and scope calls.
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewServer creates a gRPC server whose calls run through interceptors, in order. Calls get
+// at most timeout to finish, less when the client sets a shorter deadline, and errors without
+// a status answer with an internal error.
+func NewServer(timeout time.Duration, interceptors ...grpcgo.UnaryServerInterceptor) *grpcgo.Server {
+	chain := append([]grpcgo.UnaryServerInterceptor{timeoutInterceptor(timeout), statusInterceptor}, interceptors...)
+	return grpcgo.NewServer(grpcgo.ChainUnaryInterceptor(chain...))
+}
+
+// timeoutInterceptor bounds every call by timeout; the deadline of the client, already in
+// the context, still applies when it's sooner
+func timeoutInterceptor(timeout time.Duration) grpcgo.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpcgo.UnaryServerInfo, next grpcgo.UnaryHandler) (any, error) {
+		if timeout <= 0 {
+			return next(ctx, req)
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return next(ctx, req)
+	}
+}
+
+// statusInterceptor gives every failed call a status, see callStatus. It runs inside
+// timeoutInterceptor, to see the deadline of the call.
+func statusInterceptor(ctx context.Context, req any, info *grpcgo.UnaryServerInfo, next grpcgo.UnaryHandler) (any, error) {
+	resp, err := next(ctx, req)
+	if err != nil {
+		return nil, callStatus(ctx, err)
+	}
+	return resp, nil
+}
+
+// callStatus returns the status error a failed call answers with; errors without one are
+// internal
+func callStatus(ctx context.Context, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "deadline exceeded")
+	case errors.Is(ctx.Err(), context.Canceled):
+		return status.Error(codes.Canceled, "call canceled")
+	}
+	// Other errors may hold details of the database, so they're only logged
+	log.Printf("grpc: %v", err)
+	return status.Error(codes.Internal, "internal error")
+}
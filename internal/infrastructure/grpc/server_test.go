@@ -0,0 +1,447 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+	todov1 "github.com/ia-edev-sindireceita/todo/proto/todo/v1"
+)
+
+const testJWTSecret = "grpc-test-secret"
+
+type mockTaskUseCasesForGRPC struct {
+	tasks   map[string]*application.Task
+	shared  []string
+	removed []*usecases.DeletedTaskFiles
+	listErr error
+	slow    bool // Lists wait for the call to be canceled
+
+	invalidated []string // Users whose caches the server invalidated
+}
+
+func (m *mockTaskUseCasesForGRPC) Create(ctx context.Context, title, description, ownerID, imagePath string, dueDate *time.Time, projectID string, color application.TaskColor) (*application.Task, error) {
+	task, err := application.NewTask("new-task", title, description, application.StatusPending, ownerID, imagePath)
+	if err != nil {
+		return nil, err
+	}
+	task.DueDate = dueDate
+	task.Color = color
+	m.tasks[task.ID] = task
+	return task, nil
+}
+
+func (m *mockTaskUseCasesForGRPC) Get(ctx context.Context, taskID, userID string) (*application.Task, error) {
+	if taskID == "panic" {
+		panic("boom")
+	}
+	task, ok := m.tasks[taskID]
+	if !ok {
+		return nil, application.ErrTaskNotFound
+	}
+	if task.OwnerID != userID {
+		return nil, errors.New("user does not have permission to access this task")
+	}
+	return task, nil
+}
+
+func (m *mockTaskUseCasesForGRPC) Update(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string, dueDate *time.Time, projectID string, color application.TaskColor, progress int, completionNote string) error {
+	task := m.tasks[taskID]
+	if status == application.StatusCompleted && taskID == "blocked" {
+		return application.ErrTaskBlocked
+	}
+	if err := task.Update(title, description, status, imagePath); err != nil {
+		return err
+	}
+	task.DueDate = dueDate
+	task.Color = color
+	if err := task.SetProgress(progress); err != nil {
+		return err
+	}
+	return task.SetCompletionNote(completionNote)
+}
+
+func (m *mockTaskUseCasesForGRPC) Delete(ctx context.Context, taskID, userID string) (*usecases.DeletedTaskFiles, error) {
+	if _, err := m.Get(ctx, taskID, userID); err != nil {
+		if errors.Is(err, application.ErrTaskNotFound) {
+			return nil, err
+		}
+		return nil, errors.New("user does not have permission to delete this task")
+	}
+	delete(m.tasks, taskID)
+	return &usecases.DeletedTaskFiles{ImagePath: taskID + ".png"}, nil
+}
+
+func (m *mockTaskUseCasesForGRPC) List(ctx context.Context, userID string) ([]*application.Task, error) {
+	if m.slow {
+		<-ctx.Done()
+		return nil, fmt.Errorf("failed to list tasks: %w", ctx.Err())
+	}
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	var tasks []*application.Task
+	for _, task := range m.tasks {
+		if task.OwnerID == userID {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+func (m *mockTaskUseCasesForGRPC) ListShared(ctx context.Context, userID string, query application.TaskListQuery) (*application.TaskPage, error) {
+	return &application.TaskPage{Views: []*application.TaskView{{Task: m.tasks["other"]}}, Page: 1, PerPage: query.PerPage}, nil
+}
+
+func (m *mockTaskUseCasesForGRPC) Share(ctx context.Context, taskID, ownerID, shareWithUserID string) error {
+	if _, err := m.Get(ctx, taskID, ownerID); err != nil {
+		return errors.New("only the task owner can share the task")
+	}
+	m.shared = append(m.shared, shareWithUserID)
+	return nil
+}
+
+func (m *mockTaskUseCasesForGRPC) RemoveDeleted(ctx context.Context, files *usecases.DeletedTaskFiles) {
+	m.removed = append(m.removed, files)
+}
+
+// Adapters of the mock to each use case interface
+type (
+	createFunc     func(ctx context.Context, title, description, ownerID, imagePath string, dueDate *time.Time, projectID string, color application.TaskColor) (*application.Task, error)
+	getFunc        func(ctx context.Context, taskID, userID string) (*application.Task, error)
+	updateFunc     func(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string, dueDate *time.Time, projectID string, color application.TaskColor, progress int, completionNote string) error
+	deleteFunc     func(ctx context.Context, taskID, userID string) (*usecases.DeletedTaskFiles, error)
+	listFunc       func(ctx context.Context, userID string) ([]*application.Task, error)
+	listSharedFunc func(ctx context.Context, userID string, query application.TaskListQuery) (*application.TaskPage, error)
+	shareFunc      func(ctx context.Context, taskID, ownerID, shareWithUserID string) error
+)
+
+func (f createFunc) Execute(ctx context.Context, title, description, ownerID, imagePath string, dueDate *time.Time, projectID string, color application.TaskColor) (*application.Task, error) {
+	return f(ctx, title, description, ownerID, imagePath, dueDate, projectID, color)
+}
+
+func (f getFunc) Execute(ctx context.Context, taskID, userID string) (*application.Task, error) {
+	return f(ctx, taskID, userID)
+}
+
+func (f updateFunc) Execute(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string, dueDate *time.Time, projectID string, color application.TaskColor, progress int, completionNote string) error {
+	return f(ctx, taskID, title, description, status, imagePath, userID, dueDate, projectID, color, progress, completionNote)
+}
+
+func (f deleteFunc) Execute(ctx context.Context, taskID, userID string) (*usecases.DeletedTaskFiles, error) {
+	return f(ctx, taskID, userID)
+}
+
+func (f listFunc) Execute(ctx context.Context, userID string) ([]*application.Task, error) {
+	return f(ctx, userID)
+}
+
+func (f listSharedFunc) Execute(ctx context.Context, userID string, query application.TaskListQuery) (*application.TaskPage, error) {
+	return f(ctx, userID, query)
+}
+
+func (f shareFunc) Execute(ctx context.Context, taskID, ownerID, shareWithUserID string) error {
+	return f(ctx, taskID, ownerID, shareWithUserID)
+}
+
+// newTestServer serves the task service over an in-memory connection and returns a client of it
+func newTestServer(t *testing.T) (todov1.TaskServiceClient, *mockTaskUseCasesForGRPC) {
+	t.Helper()
+	base := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	m := &mockTaskUseCasesForGRPC{tasks: map[string]*application.Task{}}
+	for i, id := range []string{"t1", "t2", "t3", "blocked"} {
+		m.tasks[id] = &application.Task{ID: id, Title: "Task " + id, Status: application.StatusPending, Priority: application.PriorityNormal, OwnerID: "ana", CreatedAt: base.Add(time.Duration(i) * time.Hour)}
+	}
+	m.tasks["t3"].Tags = []string{"receita"}
+	m.tasks["other"] = &application.Task{ID: "other", Title: "Parecer", Status: application.StatusPending, OwnerID: "bia", CreatedAt: base}
+
+	keys := service.NewJWTKeySet(testJWTSecret)
+	server := NewServer(250*time.Millisecond, RecoveryInterceptor, AuthInterceptor(keys, deletedUsers{"gone": true}), InvalidateOnWriteInterceptor(func(userID string) {
+		m.invalidated = append(m.invalidated, userID)
+	}))
+	NewTaskService(createFunc(m.Create), getFunc(m.Get), updateFunc(m.Update), deleteFunc(m.Delete), listFunc(m.List), listSharedFunc(m.ListShared), shareFunc(m.Share), m).Register(server)
+
+	ln := bufconn.Listen(1 << 20)
+	go server.Serve(ln)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpcgo.NewClient("passthrough:///bufnet",
+		grpcgo.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return ln.DialContext(ctx)
+		}),
+		grpcgo.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return todov1.NewTaskServiceClient(conn), m
+}
+
+// deletedUsers finds every user but the deleted ones, active and at token version 0
+type deletedUsers map[string]bool
+
+func (d deletedUsers) FindByID(ctx context.Context, id string) (*application.User, error) {
+	if d[id] {
+		return nil, application.ErrUserNotFound
+	}
+	return &application.User{ID: id}, nil
+}
+
+func tokenFor(t *testing.T, userID string) string {
+	t.Helper()
+	token, err := service.NewAuthService(testJWTSecret).GenerateToken(userID, userID+"@example.com", time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateToken() error: %v", err)
+	}
+	return token
+}
+
+// withToken returns a context whose calls send token as a bearer token, none when it's empty
+func withToken(token string) context.Context {
+	if token == "" {
+		return context.Background()
+	}
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestServer_Calls(t *testing.T) {
+	client, m := newTestServer(t)
+	ana := tokenFor(t, "ana")
+
+	tests := []struct {
+		name     string
+		call     func(ctx context.Context) error
+		token    string
+		wantCode codes.Code
+		wantMsg  string
+	}{
+		{name: "get", token: ana, call: getTask(client, "t1"), wantCode: codes.OK},
+		{name: "no token", call: getTask(client, "t1"), wantCode: codes.Unauthenticated, wantMsg: "missing bearer token"},
+		{name: "bad token", token: "nope", call: getTask(client, "t1"), wantCode: codes.Unauthenticated, wantMsg: "invalid token"},
+		{name: "deleted user's token", token: tokenFor(t, "gone"), call: getTask(client, "t1"), wantCode: codes.Unauthenticated, wantMsg: "invalid token"},
+		{name: "other user's task", token: ana, call: getTask(client, "other"), wantCode: codes.PermissionDenied, wantMsg: "user does not have permission to access this task"},
+		{name: "unknown task", token: ana, call: getTask(client, "missing"), wantCode: codes.NotFound, wantMsg: "task not found"},
+		{name: "invalid create", token: ana, call: func(ctx context.Context) error {
+			_, err := client.CreateTask(ctx, &todov1.CreateTaskRequest{})
+			return err
+		}, wantCode: codes.InvalidArgument, wantMsg: "task title cannot be empty"},
+		{name: "blocked", token: ana, call: func(ctx context.Context) error {
+			_, err := client.UpdateTask(ctx, &todov1.UpdateTaskRequest{Id: "blocked", Title: "x", Status: "completed"})
+			return err
+		}, wantCode: codes.FailedPrecondition, wantMsg: "task is blocked by open tasks"},
+		{name: "invalid page size", token: ana, call: func(ctx context.Context) error {
+			_, err := client.ListTasks(ctx, &todov1.ListTasksRequest{PageSize: 500})
+			return err
+		}, wantCode: codes.InvalidArgument, wantMsg: "limit must be between 1 and 100"},
+		{name: "invalid page token", token: ana, call: func(ctx context.Context) error {
+			_, err := client.ListTasks(ctx, &todov1.ListTasksRequest{PageToken: "%%%"})
+			return err
+		}, wantCode: codes.InvalidArgument, wantMsg: "invalid cursor"},
+		{name: "share without user", token: ana, call: func(ctx context.Context) error {
+			_, err := client.ShareTask(ctx, &todov1.ShareTaskRequest{TaskId: "t1"})
+			return err
+		}, wantCode: codes.InvalidArgument, wantMsg: "user_id is required"},
+		{name: "panic", token: ana, call: getTask(client, "panic"), wantCode: codes.Internal, wantMsg: "internal error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := status.Convert(tt.call(withToken(tt.token)))
+			if got.Code() != tt.wantCode || got.Message() != tt.wantMsg {
+				t.Errorf("status = %v %q, want %v %q", got.Code(), got.Message(), tt.wantCode, tt.wantMsg)
+			}
+		})
+	}
+
+	// Repository errors are logged, not sent
+	m.listErr = errors.New("database is locked: /var/lib/todo.db")
+	_, err := client.ListTasks(withToken(ana), &todov1.ListTasksRequest{})
+	if got := status.Convert(err); got.Code() != codes.Internal || got.Message() != "internal error" {
+		t.Errorf("failing ListTasks status = %v %q, want Internal internal error", got.Code(), got.Message())
+	}
+}
+
+// getTask returns a call of GetTask for a task
+func getTask(client todov1.TaskServiceClient, id string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := client.GetTask(ctx, &todov1.GetTaskRequest{Id: id})
+		return err
+	}
+}
+
+func TestServer_Timeout(t *testing.T) {
+	client, m := newTestServer(t)
+	m.slow = true
+
+	_, err := client.ListTasks(withToken(tokenFor(t, "ana")), &todov1.ListTasksRequest{})
+	if got := status.Convert(err); got.Code() != codes.DeadlineExceeded || got.Message() != "deadline exceeded" {
+		t.Errorf("ListTasks outliving the server timeout status = %v %q, want DeadlineExceeded", got.Code(), got.Message())
+	}
+}
+
+func TestServer_TaskLifecycle(t *testing.T) {
+	client, m := newTestServer(t)
+	ctx := withToken(tokenFor(t, "ana"))
+	due := time.Date(2026, 11, 5, 12, 0, 0, 0, time.UTC)
+
+	created, err := client.CreateTask(ctx, &todov1.CreateTaskRequest{Title: "Relatório", DueDate: timestamppb.New(due)})
+	if err != nil {
+		t.Fatalf("CreateTask() error: %v", err)
+	}
+	if created.Id != "new-task" || created.OwnerId != "ana" || created.Status != "pending" || !created.DueDate.AsTime().Equal(due) {
+		t.Errorf("CreateTask = %v", created)
+	}
+
+	updated, err := client.UpdateTask(ctx, &todov1.UpdateTaskRequest{Id: "new-task", Title: "Relatório final", Status: "in_progress"})
+	if err != nil {
+		t.Fatalf("UpdateTask() error: %v", err)
+	}
+	if updated.Title != "Relatório final" || updated.Status != "in_progress" || updated.DueDate != nil {
+		t.Errorf("UpdateTask = %v, want the new title and status and no due date", updated)
+	}
+
+	if _, err := client.ShareTask(ctx, &todov1.ShareTaskRequest{TaskId: "new-task", UserId: "bia"}); err != nil {
+		t.Fatalf("ShareTask() error: %v", err)
+	}
+	_, err = client.ShareTask(withToken(tokenFor(t, "bia")), &todov1.ShareTaskRequest{TaskId: "new-task", UserId: "caio"})
+	if code := status.Code(err); code != codes.PermissionDenied {
+		t.Errorf("ShareTask by a non-owner status = %v, want PermissionDenied", code)
+	}
+	if fmt.Sprint(m.shared) != "[bia]" {
+		t.Errorf("shared with %v, want [bia]", m.shared)
+	}
+
+	if _, err := client.DeleteTask(ctx, &todov1.DeleteTaskRequest{Id: "new-task"}); err != nil {
+		t.Fatalf("DeleteTask() error: %v", err)
+	}
+	if len(m.removed) != 1 || m.removed[0].ImagePath != "new-task.png" {
+		t.Errorf("removed files = %v, want the deleted task's", m.removed)
+	}
+	if err := getTask(client, "new-task")(ctx); status.Code(err) != codes.NotFound {
+		t.Errorf("GetTask after delete status = %v, want NotFound", status.Code(err))
+	}
+}
+
+func TestServer_ListPagination(t *testing.T) {
+	client, _ := newTestServer(t)
+	ctx := withToken(tokenFor(t, "ana"))
+
+	var ids []string
+	token := ""
+	for page := 0; page < 3; page++ {
+		resp, err := client.ListTasks(ctx, &todov1.ListTasksRequest{PageSize: 3, PageToken: token})
+		if err != nil {
+			t.Fatalf("ListTasks() error: %v", err)
+		}
+		for _, task := range resp.Tasks {
+			ids = append(ids, task.Id)
+		}
+		if token = resp.NextPageToken; token == "" {
+			break
+		}
+	}
+	if fmt.Sprint(ids) != "[blocked t3 t2 t1]" {
+		t.Errorf("listed %v, want every task newest first", ids)
+	}
+
+	filtered, err := client.ListTasks(ctx, &todov1.ListTasksRequest{Tag: "#Receita"})
+	if err != nil || len(filtered.Tasks) != 1 || filtered.Tasks[0].Id != "t3" || filtered.NextPageToken != "" {
+		t.Errorf("ListTasks by tag = %v, %v, want only t3", filtered, err)
+	}
+
+	shared, err := client.ListSharedTasks(ctx, &todov1.ListTasksRequest{})
+	if err != nil || len(shared.Tasks) != 1 || shared.Tasks[0].OwnerId != "bia" {
+		t.Errorf("ListSharedTasks = %v, %v, want bia's task", shared, err)
+	}
+}
+
+func TestServer_InvalidatesOnWrites(t *testing.T) {
+	client, m := newTestServer(t)
+	ctx := withToken(tokenFor(t, "ana"))
+
+	client.GetTask(ctx, &todov1.GetTaskRequest{Id: "t1"})
+	client.ListTasks(ctx, &todov1.ListTasksRequest{})
+	client.CreateTask(ctx, &todov1.CreateTaskRequest{})
+	if len(m.invalidated) != 0 {
+		t.Errorf("invalidated %v after reads and a failed write, want nothing", m.invalidated)
+	}
+	client.CreateTask(ctx, &todov1.CreateTaskRequest{Title: "Nova"})
+	if fmt.Sprint(m.invalidated) != "[ana]" {
+		t.Errorf("invalidated %v after a write, want [ana]", m.invalidated)
+	}
+}
+
+// mockOrgMembers holds the memberships of users in orgs, as "org|user" keys
+type mockOrgMembers map[string]bool
+
+func (m mockOrgMembers) FindMember(ctx context.Context, orgID, userID string) (*application.OrgMember, error) {
+	if orgID == "broken" {
+		return nil, errors.New("database is locked")
+	}
+	if !m[orgID+"|"+userID] {
+		return nil, application.ErrOrgMemberNotFound
+	}
+	return &application.OrgMember{OrgID: orgID, UserID: userID}, nil
+}
+
+func TestOrgScopeInterceptor(t *testing.T) {
+	interceptor := OrgScopeInterceptor(mockOrgMembers{"acme|ana": true})
+
+	tests := []struct {
+		name      string
+		orgID     string
+		wantScope string
+		wantCode  codes.Code
+	}{
+		{name: "personal tasks without the metadata", wantScope: "", wantCode: codes.OK},
+		{name: "member of the org", orgID: "acme", wantScope: "acme", wantCode: codes.OK},
+		{name: "not a member", orgID: "globex", wantCode: codes.PermissionDenied},
+		{name: "membership check failing", orgID: "broken", wantCode: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := metadata.MD{}
+			if tt.orgID != "" {
+				md.Set(OrgHeader, tt.orgID)
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			ctx = context.WithValue(ctx, "userID", "ana")
+			info := &grpcgo.UnaryServerInfo{FullMethod: todov1.TaskService_ListTasks_FullMethodName}
+
+			var scope string
+			var scoped bool
+			_, err := interceptor(ctx, &todov1.ListTasksRequest{}, info, func(ctx context.Context, req any) (any, error) {
+				scope, scoped = repository.OrgScope(ctx)
+				return &todov1.ListTasksResponse{}, nil
+			})
+
+			code := codes.OK
+			if err != nil {
+				code = status.Code(callStatus(ctx, err))
+			}
+			if code != tt.wantCode {
+				t.Fatalf("status = %v, want %v (error %v)", code, tt.wantCode, err)
+			}
+			if tt.wantCode == codes.OK && (!scoped || scope != tt.wantScope) {
+				t.Errorf("org scope = %q (scoped %v), want %q", scope, scoped, tt.wantScope)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package grpc
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+	todov1 "github.com/ia-edev-sindireceita/todo/proto/todo/v1"
+)
+
+// DeletedFileRemover removes the files of deleted tasks once the deletion is committed
+type DeletedFileRemover interface {
+	RemoveDeleted(ctx context.Context, files *usecases.DeletedTaskFiles)
+}
+
+// TaskService implements todo.v1.TaskService over the task use cases, on behalf of the user
+// the auth interceptor authenticated. Errors map to status codes like the REST API maps them
+// to HTTP statuses.
+type TaskService struct {
+	todov1.UnimplementedTaskServiceServer
+
+	createTask      usecases.CreateTaskUseCaseInterface
+	getTask         usecases.GetTaskUseCaseInterface
+	updateTask      usecases.UpdateTaskUseCaseInterface
+	deleteTask      usecases.DeleteTaskUseCaseInterface
+	listTasks       usecases.ListTasksUseCaseInterface
+	listSharedTasks usecases.ListSharedTasksUseCaseInterface
+	shareTask       usecases.ShareTaskUseCaseInterface
+	files           DeletedFileRemover
+}
+
+// NewTaskService creates a new TaskService
+func NewTaskService(
+	createTask usecases.CreateTaskUseCaseInterface,
+	getTask usecases.GetTaskUseCaseInterface,
+	updateTask usecases.UpdateTaskUseCaseInterface,
+	deleteTask usecases.DeleteTaskUseCaseInterface,
+	listTasks usecases.ListTasksUseCaseInterface,
+	listSharedTasks usecases.ListSharedTasksUseCaseInterface,
+	shareTask usecases.ShareTaskUseCaseInterface,
+	files DeletedFileRemover,
+) *TaskService {
+	return &TaskService{
+		createTask:      createTask,
+		getTask:         getTask,
+		updateTask:      updateTask,
+		deleteTask:      deleteTask,
+		listTasks:       listTasks,
+		listSharedTasks: listSharedTasks,
+		shareTask:       shareTask,
+		files:           files,
+	}
+}
+
+// Register adds the service to a server
+func (s *TaskService) Register(server grpcgo.ServiceRegistrar) {
+	todov1.RegisterTaskServiceServer(server, s)
+}
+
+// requestUserID returns the ID of the user making the call
+func requestUserID(ctx context.Context) string {
+	userID, _ := ctx.Value("userID").(string)
+	return userID
+}
+
+// CreateTask creates a task owned by the caller
+func (s *TaskService) CreateTask(ctx context.Context, req *todov1.CreateTaskRequest) (*todov1.Task, error) {
+	task, err := s.createTask.Execute(ctx, req.Title, req.Description, requestUserID(ctx), "", optionalTime(req.DueDate), req.ProjectId, application.TaskColor(req.Color))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return newTask(task), nil
+}
+
+// GetTask returns a task the caller owns or that is shared with them
+func (s *TaskService) GetTask(ctx context.Context, req *todov1.GetTaskRequest) (*todov1.Task, error) {
+	task, err := s.getTask.Execute(ctx, req.Id, requestUserID(ctx))
+	if err != nil {
+		return nil, taskError(err, codes.PermissionDenied)
+	}
+	return newTask(task), nil
+}
+
+// UpdateTask replaces the fields of a task the caller owns and returns it. The image of the
+// task, which is uploaded through the REST API, is kept.
+func (s *TaskService) UpdateTask(ctx context.Context, req *todov1.UpdateTaskRequest) (*todov1.Task, error) {
+	userID := requestUserID(ctx)
+	task, err := s.getTask.Execute(ctx, req.Id, userID)
+	if errors.Is(err, application.ErrTaskNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, "user does not have permission to modify this task")
+	}
+
+	err = s.updateTask.Execute(ctx, req.Id, req.Title, req.Description, application.TaskStatus(req.Status), task.ImagePath, userID, optionalTime(req.DueDate), req.ProjectId, application.TaskColor(req.Color), int(req.Progress), req.CompletionNote)
+	if errors.Is(err, application.ErrTaskBlocked) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return s.GetTask(ctx, &todov1.GetTaskRequest{Id: req.Id})
+}
+
+// DeleteTask deletes a task the caller owns, with its image and attachments
+func (s *TaskService) DeleteTask(ctx context.Context, req *todov1.DeleteTaskRequest) (*emptypb.Empty, error) {
+	files, err := s.deleteTask.Execute(ctx, req.Id, requestUserID(ctx))
+	if err != nil {
+		return nil, taskError(err, codes.PermissionDenied)
+	}
+	s.files.RemoveDeleted(ctx, files)
+	return &emptypb.Empty{}, nil
+}
+
+// taskError returns NotFound for a task that doesn't exist and code for the other errors of a
+// task use case
+func taskError(err error, code codes.Code) error {
+	if errors.Is(err, application.ErrTaskNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(code, err.Error())
+}
+
+// ListTasks lists a page of the tasks the caller owns, newest first
+func (s *TaskService) ListTasks(ctx context.Context, req *todov1.ListTasksRequest) (*todov1.ListTasksResponse, error) {
+	query, err := listQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.listTasks.Execute(ctx, requestUserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	matching := make([]*application.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if query.Status != "" && task.Status != query.Status || query.Tag != "" && !slices.Contains(task.Tags, query.Tag) {
+			continue
+		}
+		matching = append(matching, task)
+	}
+	page, next := application.CursorPage(matching, query)
+	return newListTasksResponse(page, next), nil
+}
+
+// ListSharedTasks lists a page of the tasks shared with the caller, newest first
+func (s *TaskService) ListSharedTasks(ctx context.Context, req *todov1.ListTasksRequest) (*todov1.ListTasksResponse, error) {
+	query, err := listQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	page, err := s.listSharedTasks.Execute(ctx, requestUserID(ctx), query)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*application.Task, 0, len(page.Views))
+	for _, view := range page.Views {
+		tasks = append(tasks, view.Task)
+	}
+	return newListTasksResponse(tasks, page.NextCursor), nil
+}
+
+// ShareTask invites a user to share a task the caller owns
+func (s *TaskService) ShareTask(ctx context.Context, req *todov1.ShareTaskRequest) (*emptypb.Empty, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if err := s.shareTask.Execute(ctx, req.TaskId, requestUserID(ctx), req.UserId); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// listQuery returns the query of a page of a task list in cursor pagination
+func listQuery(req *todov1.ListTasksRequest) (application.TaskListQuery, error) {
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return application.TaskListQuery{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	query, err := application.NewTaskListQuery(req.Status, req.Tag, "", "", 0, 0)
+	if err == nil {
+		query, err = query.WithCursor(after, int(req.PageSize))
+	}
+	if err != nil {
+		return application.TaskListQuery{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return query, nil
+}
+
+func newListTasksResponse(tasks []*application.Task, next *application.TaskCursor) *todov1.ListTasksResponse {
+	resp := &todov1.ListTasksResponse{Tasks: make([]*todov1.Task, 0, len(tasks))}
+	for _, task := range tasks {
+		resp.Tasks = append(resp.Tasks, newTask(task))
+	}
+	if next != nil {
+		resp.NextPageToken = encodePageToken(next)
+	}
+	return resp
+}
+
+// encodePageToken returns the opaque form of a cursor sent to clients, the same as the
+// cursors of the REST API
+func encodePageToken(cursor *application.TaskCursor) string {
+	raw := cursor.CreatedAt.Format(time.RFC3339Nano) + " " + cursor.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodePageToken parses a token from encodePageToken; empty is the start of the list
+func decodePageToken(token string) (*application.TaskCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, application.ErrInvalidTaskCursor
+	}
+	createdAt, id, ok := strings.Cut(string(raw), " ")
+	if !ok || id == "" {
+		return nil, application.ErrInvalidTaskCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, application.ErrInvalidTaskCursor
+	}
+	return &application.TaskCursor{CreatedAt: t, ID: id}, nil
+}
+
+// newTask converts a task entity to its message
+func newTask(task *application.Task) *todov1.Task {
+	return &todov1.Task{
+		Id:             task.ID,
+		Title:          task.Title,
+		Description:    task.Description,
+		Status:         string(task.Status),
+		Priority:       string(task.Priority),
+		Tags:           task.Tags,
+		DueDate:        timestamp(task.DueDate),
+		OwnerId:        task.OwnerID,
+		ProjectId:      task.ProjectID,
+		CreatedAt:      timestamppb.New(task.CreatedAt),
+		UpdatedAt:      timestamppb.New(task.UpdatedAt),
+		Color:          string(task.Color),
+		SnoozedUntil:   timestamp(task.SnoozedUntil),
+		Progress:       int32(task.PercentComplete()),
+		CompletionNote: task.CompletionNote,
+	}
+}
+
+// timestamp converts an optional time to a message, unset when it's nil
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// optionalTime converts an optional timestamp of a message, nil when it's unset
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// AccountHandler handles users downloading their data and deleting their account
+type AccountHandler struct {
+	requestDeletion usecases.RequestAccountDeletionUseCaseInterface
+	getDeletion     usecases.GetAccountDeletionUseCaseInterface
+	cancelDeletion  usecases.CancelAccountDeletionUseCaseInterface
+	exportData      usecases.ExportAccountDataUseCaseInterface
+	imagesDir       string
+}
+
+// NewAccountHandler creates a new AccountHandler adding the images kept in imagesDir to the
+// data exports
+func NewAccountHandler(
+	requestDeletion usecases.RequestAccountDeletionUseCaseInterface,
+	getDeletion usecases.GetAccountDeletionUseCaseInterface,
+	cancelDeletion usecases.CancelAccountDeletionUseCaseInterface,
+	exportData usecases.ExportAccountDataUseCaseInterface,
+	imagesDir string,
+) *AccountHandler {
+	return &AccountHandler{
+		requestDeletion: requestDeletion,
+		getDeletion:     getDeletion,
+		cancelDeletion:  cancelDeletion,
+		exportData:      exportData,
+		imagesDir:       imagesDir,
+	}
+}
+
+// accountExportURL is where users download their data, until their account is purged
+const accountExportURL = "/api/me/export"
+
+// DeleteAccountRequest confirms the deletion of the account of the current user
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+}
+
+// AccountDeletionResponse is the JSON representation of a scheduled account deletion
+type AccountDeletionResponse struct {
+	RequestedAt time.Time `json:"requested_at"`
+	PurgeAt     time.Time `json:"purge_at"`
+	ExportURL   string    `json:"export_url"`
+}
+
+// newAccountDeletionResponse converts a scheduled account deletion to its JSON representation
+func newAccountDeletionResponse(deletion *application.AccountDeletion) AccountDeletionResponse {
+	return AccountDeletionResponse{
+		RequestedAt: deletion.RequestedAt,
+		PurgeAt:     deletion.PurgeAt,
+		ExportURL:   accountExportURL,
+	}
+}
+
+// DeleteAccount handles DELETE /api/me. The password confirms the request; the account is
+// purged once the grace period is over, so it answers 202 with the time of the purge and
+// where to download the data until then.
+func (h *AccountHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req DeleteAccountRequest
+	if !decodeJSON(w, r, &req, maxJSONBodySize) {
+		return
+	}
+
+	deletion, err := h.requestDeletion.Execute(r.Context(), userID, req.Password)
+	if err != nil {
+		status, message := accountErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(newAccountDeletionResponse(deletion))
+}
+
+// GetDeletion handles GET /api/me/deletion, answering 404 when the account isn't scheduled
+// for deletion
+func (h *AccountHandler) GetDeletion(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	deletion, err := h.getDeletion.Execute(r.Context(), userID)
+	if err != nil {
+		status, message := accountErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newAccountDeletionResponse(deletion))
+}
+
+// CancelDeletion handles DELETE /api/me/deletion, keeping the account
+func (h *AccountHandler) CancelDeletion(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := h.cancelDeletion.Execute(r.Context(), userID); err != nil {
+		status, message := accountErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// accountExport is the content of tasks.json in a data export
+type accountExport struct {
+	ExportedAt time.Time         `json:"exported_at"`
+	User       accountExportUser `json:"user"`
+	Tasks      []TaskResponse    `json:"tasks"`
+}
+
+// accountExportUser is the profile of the user in a data export, without the password hash
+type accountExportUser struct {
+	ID        string               `json:"id"`
+	Name      string               `json:"name"`
+	Email     string               `json:"email"`
+	Role      application.UserRole `json:"role"`
+	Unit      string               `json:"unit,omitempty"`
+	Locale    application.Locale   `json:"locale,omitempty"`
+	Avatar    string               `json:"avatar,omitempty"` // Path of the avatar inside the archive
+	CreatedAt time.Time            `json:"created_at"`
+}
+
+// ExportData handles GET /api/me/export, a zip archive with the account and the tasks the user
+// owns in tasks.json and their images in images/. Images missing on disk are left out.
+func (h *AccountHandler) ExportData(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	data, err := h.exportData.Execute(r.Context(), userID)
+	if err != nil {
+		status, message := accountErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	export := accountExport{
+		ExportedAt: time.Now().UTC(),
+		User: accountExportUser{
+			ID:        data.User.ID,
+			Name:      data.User.Name,
+			Email:     data.User.Email,
+			Role:      data.User.Role,
+			Unit:      data.User.Unit,
+			Locale:    data.User.Locale,
+			CreatedAt: data.User.CreatedAt,
+		},
+		Tasks: make([]TaskResponse, 0, len(data.Tasks)),
+	}
+	images := make([]string, 0, len(data.Tasks)+1)
+	if data.User.AvatarPath != "" {
+		images = append(images, data.User.AvatarPath)
+		export.User.Avatar = "images/" + filepath.Base(data.User.AvatarPath)
+	}
+	for _, task := range data.Tasks {
+		export.Tasks = append(export.Tasks, toTaskResponse(task, data.User.Name))
+		if task.ImagePath != "" {
+			images = append(images, task.ImagePath)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="todo-export-`+export.ExportedAt.Format("2006-01-02")+`.zip"`)
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	// The status is sent, so failures from here on can only be logged
+	archive := zip.NewWriter(w)
+	entry, err := archive.Create("tasks.json")
+	if err == nil {
+		encoder := json.NewEncoder(entry)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(export)
+	}
+	added := make(map[string]bool, len(images))
+	for _, image := range images {
+		if err != nil {
+			break
+		}
+		if !added[filepath.Base(image)] {
+			added[filepath.Base(image)] = true
+			err = h.addImage(archive, image)
+		}
+	}
+	if err == nil {
+		err = archive.Close()
+	}
+	if err != nil {
+		log.Printf("failed to write the data export of %s: %v", userID, err)
+	}
+}
+
+// addImage copies an uploaded image into the images/ directory of an archive. A missing image
+// is logged and skipped; only failures to write the archive are returned.
+func (h *AccountHandler) addImage(archive *zip.Writer, imagePath string) error {
+	name := filepath.Base(imagePath)
+	file, err := os.Open(filepath.Join(h.imagesDir, name))
+	if err != nil {
+		log.Printf("data export: skipping image %s: %v", imagePath, err)
+		return nil
+	}
+	defer file.Close()
+
+	entry, err := archive.Create("images/" + name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entry, file)
+	return err
+}
+
+// accountErrorStatus maps an account use case error to an HTTP status and client message
+func accountErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecases.ErrCurrentPasswordMismatch):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, application.ErrUserNotFound), errors.Is(err, application.ErrAccountDeletionNotFound):
+		return http.StatusNotFound, err.Error()
+	default:
+		log.Printf("account operation failed: %v", err)
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockRequestAccountDeletionUseCase struct {
+	deletion *application.AccountDeletion
+	err      error
+}
+
+func (m *mockRequestAccountDeletionUseCase) Execute(ctx context.Context, userID, password string) (*application.AccountDeletion, error) {
+	return m.deletion, m.err
+}
+
+type mockExportAccountDataUseCase struct {
+	data *usecases.AccountData
+}
+
+func (m *mockExportAccountDataUseCase) Execute(ctx context.Context, userID string) (*usecases.AccountData, error) {
+	return m.data, nil
+}
+
+func TestAccountHandler_DeleteAccount(t *testing.T) {
+	deletion, _ := application.NewAccountDeletion("user-123", time.Now(), 7*24*time.Hour)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"scheduled", nil, http.StatusAccepted},
+		{"wrong password", usecases.ErrCurrentPasswordMismatch, http.StatusForbidden},
+		{"unknown user", application.ErrUserNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewAccountHandler(&mockRequestAccountDeletionUseCase{deletion: deletion, err: tt.err}, nil, nil, nil, t.TempDir())
+
+			req := httptest.NewRequest("DELETE", "/api/me", strings.NewReader(`{"password":"secret"}`))
+			w := httptest.NewRecorder()
+			h.DeleteAccount(w, withUser(req))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.err == nil && !strings.Contains(w.Body.String(), `"export_url":"/api/me/export"`) {
+				t.Errorf("expected the export URL in the response: %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAccountHandler_ExportData(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "task.png"), []byte("png data"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	data := &usecases.AccountData{
+		User: &application.User{ID: "user-123", Name: "Ana", Email: "ana@example.com", PasswordHash: "{bcrypt}hash", AvatarPath: "/uploads/images/missing.png"},
+		Tasks: []*application.Task{
+			{ID: "task-1", Title: "Com imagem", ImagePath: "/uploads/images/task.png"},
+			{ID: "task-2", Title: "Mesma imagem", ImagePath: "/uploads/images/task.png"},
+		},
+	}
+	h := NewAccountHandler(nil, nil, nil, &mockExportAccountDataUseCase{data: data}, dir)
+
+	w := httptest.NewRecorder()
+	h.ExportData(w, withUser(httptest.NewRequest("GET", "/api/me/export", nil)))
+
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/zip" {
+		t.Fatalf("status = %d %s, want a zip", w.Code, w.Header().Get("Content-Type"))
+	}
+	archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The missing avatar is skipped and the shared image is added once
+	contents := map[string]string{}
+	for _, file := range archive.File {
+		reader, _ := file.Open()
+		content, _ := io.ReadAll(reader)
+		contents[file.Name] = string(content)
+	}
+	if len(contents) != 2 || contents["images/task.png"] != "png data" {
+		t.Fatalf("archive = %v, want tasks.json and images/task.png", contents)
+	}
+	if tasks := contents["tasks.json"]; !strings.Contains(tasks, "Com imagem") || strings.Contains(tasks, "{bcrypt}hash") {
+		t.Errorf("tasks.json = %s, want the tasks without the password hash", tasks)
+	}
+}
@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// AdminHandler handles the admin dashboard and its user management actions
+type AdminHandler struct {
+	dashboard     usecases.GetAdminDashboardUseCaseInterface
+	listUsers     usecases.ListUsersForAdminUseCaseInterface
+	setDisabled   usecases.SetUserDisabledUseCaseInterface
+	resetPassword usecases.ResetUserPasswordUseCaseInterface
+	deleteUser    usecases.DeleteUserUseCaseInterface
+	pages         *PageTemplates
+	location      *time.Location
+}
+
+// NewAdminHandler creates a new AdminHandler
+func NewAdminHandler(
+	dashboard usecases.GetAdminDashboardUseCaseInterface,
+	listUsers usecases.ListUsersForAdminUseCaseInterface,
+	setDisabled usecases.SetUserDisabledUseCaseInterface,
+	resetPassword usecases.ResetUserPasswordUseCaseInterface,
+	deleteUser usecases.DeleteUserUseCaseInterface,
+	pages *PageTemplates,
+	location *time.Location,
+) *AdminHandler {
+	return &AdminHandler{
+		dashboard:     dashboard,
+		listUsers:     listUsers,
+		setDisabled:   setDisabled,
+		resetPassword: resetPassword,
+		deleteUser:    deleteUser,
+		pages:         pages,
+		location:      location,
+	}
+}
+
+// DashboardPage handles GET /admin?offset=N, showing the user count, the tasks created per day,
+// the storage usage, the rate-limit rejections, the recent errors and the users from offset on
+func (h *AdminHandler) DashboardPage(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok || userID == "" {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	locale := RequestLocale(r)
+
+	dashboard, err := h.dashboard.Execute(r.Context(), userID)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	page, err := h.listUsers.Execute(r.Context(), userID, adminOffset(r))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	rows, err := renderAdminUserRows(adminUserRows{Users: page.Users, CurrentUserID: userID, NextOffset: page.NextOffset}, locale)
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, i18n.Error(locale, "Internal server error"))
+		return
+	}
+
+	loc := requestLocation(r, h.location)
+	maxTasks := dashboard.MaxTasksPerDay()
+	tmpl, err := h.pages.Page(locale, template.FuncMap{
+		"formatTime": func(t time.Time) string {
+			return formatDateTime(locale, t.In(loc))
+		},
+		"formatDay": func(t time.Time) string {
+			return t.Format(i18n.T(locale, "format.day"))
+		},
+		"formatSize": formatFileSize,
+		// barPercent is the width of the bar of a day in the tasks chart
+		"barPercent": func(count int) int {
+			if maxTasks == 0 {
+				return 0
+			}
+			return count * 100 / maxTasks
+		},
+	},
+		"base.html", "admin.html",
+	)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	data := PageData(locale, map[string]interface{}{
+		"Title":     i18n.T(locale, "title.admin"),
+		"UserID":    userID,
+		"Theme":     string(ThemeFromRequest(r)),
+		"Dashboard": dashboard,
+		"Days":      usecases.AdminDashboardDays,
+		"UserRows":  template.HTML(rows),
+	})
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(buf.Bytes())
+}
+
+// WebListUsers handles GET /web/admin/users?offset=N, rendering the next rows of the users
+// table. Without HTMX, the dashboard shows them.
+func (h *AdminHandler) WebListUsers(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	offset := adminOffset(r)
+	if !IsHTMX(r) {
+		http.Redirect(w, r, fmt.Sprintf("/admin?offset=%d", offset), http.StatusSeeOther)
+		return
+	}
+
+	page, err := h.listUsers.Execute(r.Context(), userID, offset)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.writeRows(w, r, adminUserRows{Users: page.Users, CurrentUserID: userID, NextOffset: page.NextOffset})
+}
+
+// WebDisableUser handles POST /web/admin/users/{id}/disable, re-rendering the row of the user
+func (h *AdminHandler) WebDisableUser(w http.ResponseWriter, r *http.Request) {
+	h.writeSetDisabled(w, r, true)
+}
+
+// WebEnableUser handles POST /web/admin/users/{id}/enable, re-rendering the row of the user
+func (h *AdminHandler) WebEnableUser(w http.ResponseWriter, r *http.Request) {
+	h.writeSetDisabled(w, r, false)
+}
+
+// writeSetDisabled disables or enables the user of the request and re-renders their row
+func (h *AdminHandler) writeSetDisabled(w http.ResponseWriter, r *http.Request, disabled bool) {
+	userID := r.Context().Value("userID").(string)
+
+	user, err := h.setDisabled.Execute(r.Context(), userID, r.PathValue("id"), disabled)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.writeRows(w, r, adminUserRows{Users: []*application.User{user}, CurrentUserID: userID})
+}
+
+// WebResetPassword handles POST /web/admin/users/{id}/password, re-rendering the row of the
+// user followed by their temporary password. Without HTMX, a page shows the password, which
+// a redirect would lose.
+func (h *AdminHandler) WebResetPassword(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	locale := RequestLocale(r)
+
+	user, password, err := h.resetPassword.Execute(r.Context(), userID, r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if !IsHTMX(r) {
+		message := i18n.T(locale, "admin.temporary_password", user.Email, password)
+		h.pages.writeWebPage(w, r, http.StatusOK, template.HTML(`<p role="status">`+template.HTMLEscapeString(message)+`</p>`))
+		return
+	}
+	h.writeRows(w, r, adminUserRows{
+		Users:                 []*application.User{user},
+		CurrentUserID:         userID,
+		TemporaryPassword:     password,
+		TemporaryPasswordUser: user,
+	})
+}
+
+// WebDeleteUser handles DELETE /web/admin/users/{id}, removing the row of the user
+func (h *AdminHandler) WebDeleteUser(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := h.deleteUser.Execute(r.Context(), userID, r.PathValue("id")); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.pages.writeWebFragment(w, r, http.StatusOK, "")
+}
+
+// writeRows writes rows of the users table
+func (h *AdminHandler) writeRows(w http.ResponseWriter, r *http.Request, rows adminUserRows) {
+	html, err := renderAdminUserRows(rows, RequestLocale(r))
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, i18n.Error(RequestLocale(r), "Internal server error"))
+		return
+	}
+	h.pages.writeWebFragment(w, r, http.StatusOK, html)
+}
+
+// writeError writes the error of an admin use case, localized
+func (h *AdminHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	status, message := adminErrorStatus(err)
+	h.pages.writeWebError(w, r, status, i18n.Error(RequestLocale(r), message))
+}
+
+// adminErrorStatus maps an admin use case error to an HTTP status and message
+func adminErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecases.ErrAdminRequired):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, application.ErrUserNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, usecases.ErrCannotManageSelf):
+		return http.StatusConflict, err.Error()
+	default:
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
+
+// adminOffset returns the offset query parameter of the users table, zero when missing or invalid
+func adminOffset(r *http.Request) int {
+	offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+	if err != nil || offset < 0 {
+		return 0
+	}
+	return offset
+}
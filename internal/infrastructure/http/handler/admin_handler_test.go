@@ -0,0 +1,371 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// mockAdminUseCases implements the admin use cases, with user-123 as the admin
+type mockAdminUseCases struct {
+	users    map[string]*application.User
+	deleted  []string
+	password string
+}
+
+func newMockAdminUseCases() *mockAdminUseCases {
+	return &mockAdminUseCases{
+		users: map[string]*application.User{
+			"user-123": {ID: "user-123", Name: "Ana", Email: "ana@example.com", Role: application.RoleAdmin},
+			"user-456": {ID: "user-456", Name: "Bia", Email: "bia@example.com", Role: application.RoleMember},
+		},
+		password: "abcde-fghij-klmno-pqrst",
+	}
+}
+
+func (m *mockAdminUseCases) check(adminID, userID string) (*application.User, error) {
+	if adminID != "user-123" {
+		return nil, usecases.ErrAdminRequired
+	}
+	user, ok := m.users[userID]
+	if !ok {
+		return nil, application.ErrUserNotFound
+	}
+	return user, nil
+}
+
+type mockAdminDashboard struct{ *mockAdminUseCases }
+
+func (m mockAdminDashboard) Execute(ctx context.Context, adminID string) (*application.AdminDashboard, error) {
+	if adminID != "user-123" {
+		return nil, usecases.ErrAdminRequired
+	}
+	return &application.AdminDashboard{
+		Users:               len(m.users),
+		TasksPerDay:         []application.DailyCount{{Day: time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), Count: 2}, {Day: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), Count: 4}},
+		Storage:             application.StorageUsage{Bytes: 2048, Files: 3},
+		RateLimitRejections: 17,
+		RateLimitEvictions:  42,
+		RecentErrors:        []application.RequestError{{At: time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC), Method: "POST", Path: "/web/tasks", Status: 500}},
+	}, nil
+}
+
+type mockAdminListUsers struct{ *mockAdminUseCases }
+
+func (m mockAdminListUsers) Execute(ctx context.Context, adminID string, offset int) (*usecases.AdminUserPage, error) {
+	if adminID != "user-123" {
+		return nil, usecases.ErrAdminRequired
+	}
+	if offset > 0 {
+		return &usecases.AdminUserPage{Users: []*application.User{m.users["user-456"]}}, nil
+	}
+	return &usecases.AdminUserPage{Users: []*application.User{m.users["user-123"], m.users["user-456"]}, NextOffset: 2}, nil
+}
+
+type mockAdminSetDisabled struct{ *mockAdminUseCases }
+
+func (m mockAdminSetDisabled) Execute(ctx context.Context, adminID, userID string, disabled bool) (*application.User, error) {
+	if userID == adminID {
+		return nil, usecases.ErrCannotManageSelf
+	}
+	user, err := m.check(adminID, userID)
+	if err != nil {
+		return nil, err
+	}
+	user.Disabled = disabled
+	return user, nil
+}
+
+type mockAdminResetPassword struct{ *mockAdminUseCases }
+
+func (m mockAdminResetPassword) Execute(ctx context.Context, adminID, userID string) (*application.User, string, error) {
+	user, err := m.check(adminID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	return user, m.password, nil
+}
+
+type mockAdminDeleteUser struct{ *mockAdminUseCases }
+
+func (m mockAdminDeleteUser) Execute(ctx context.Context, adminID, userID string) error {
+	if userID == adminID {
+		return usecases.ErrCannotManageSelf
+	}
+	if _, err := m.check(adminID, userID); err != nil {
+		return err
+	}
+	m.deleted = append(m.deleted, userID)
+	return nil
+}
+
+func newTestAdminHandler() (*AdminHandler, *mockAdminUseCases) {
+	m := newMockAdminUseCases()
+	h := NewAdminHandler(mockAdminDashboard{m}, mockAdminListUsers{m}, mockAdminSetDisabled{m}, mockAdminResetPassword{m}, mockAdminDeleteUser{m}, NewPageTemplates(""), time.Local)
+	return h, m
+}
+
+// asUser puts userID in the context of req like the auth middleware
+func asUser(req *http.Request, userID string) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), "userID", userID))
+}
+
+func TestAdminHandler_DashboardPage(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		wantStatus int
+		wantBody   []string
+	}{
+		{
+			name:       "admin",
+			userID:     "user-123",
+			wantStatus: http.StatusOK,
+			wantBody: []string{
+				"<!DOCTYPE html>",
+				"17",
+				"42 clientes descartados por limitadores cheios",
+				"2 KB",
+				"POST /web/tasks",
+				"width: 50%",
+				"width: 100%",
+				`id="admin-user-user-456"`,
+				`hx-post="/web/admin/users/user-456/disable"`,
+				`action="/web/admin/users/user-456?_method=DELETE"`,
+				`hx-get="/web/admin/users?offset=2"`,
+			},
+		},
+		{
+			name:       "member",
+			userID:     "user-456",
+			wantStatus: http.StatusForbidden,
+			wantBody:   []string{"acesso restrito a administradores"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newTestAdminHandler()
+			req := asUser(httptest.NewRequest("GET", "/admin", nil), tt.userID)
+			w := httptest.NewRecorder()
+
+			h.DashboardPage(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(w.Body.String(), want) {
+					t.Errorf("Expected %q in the page", want)
+				}
+			}
+		})
+	}
+}
+
+func TestAdminHandler_DashboardPage_OwnRowHasNoActions(t *testing.T) {
+	h, _ := newTestAdminHandler()
+	req := asUser(httptest.NewRequest("GET", "/admin", nil), "user-123")
+	w := httptest.NewRecorder()
+
+	h.DashboardPage(w, req)
+
+	if strings.Contains(w.Body.String(), "/web/admin/users/user-123/") {
+		t.Error("Expected no actions on the row of the admin")
+	}
+}
+
+func TestAdminHandler_Actions(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		target       string
+		userID       string
+		htmx         bool
+		handle       func(h *AdminHandler) http.HandlerFunc
+		wantStatus   int
+		wantLocation string
+		wantBody     []string
+		notBody      []string
+	}{
+		{
+			name:       "disable",
+			method:     "POST",
+			target:     "user-456",
+			userID:     "user-123",
+			htmx:       true,
+			handle:     func(h *AdminHandler) http.HandlerFunc { return h.WebDisableUser },
+			wantStatus: http.StatusOK,
+			wantBody:   []string{`id="admin-user-user-456"`, `hx-post="/web/admin/users/user-456/enable"`},
+			notBody:    []string{"<!DOCTYPE html>"},
+		},
+		{
+			name:         "disable without HTMX goes back to the dashboard",
+			method:       "POST",
+			target:       "user-456",
+			userID:       "user-123",
+			handle:       func(h *AdminHandler) http.HandlerFunc { return h.WebDisableUser },
+			wantStatus:   http.StatusSeeOther,
+			wantLocation: "/admin",
+		},
+		{
+			name:       "enable",
+			method:     "POST",
+			target:     "user-456",
+			userID:     "user-123",
+			htmx:       true,
+			handle:     func(h *AdminHandler) http.HandlerFunc { return h.WebEnableUser },
+			wantStatus: http.StatusOK,
+			wantBody:   []string{`hx-post="/web/admin/users/user-456/disable"`},
+		},
+		{
+			name:       "disable self",
+			method:     "POST",
+			target:     "user-123",
+			userID:     "user-123",
+			htmx:       true,
+			handle:     func(h *AdminHandler) http.HandlerFunc { return h.WebDisableUser },
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "member",
+			method:     "POST",
+			target:     "user-123",
+			userID:     "user-456",
+			htmx:       true,
+			handle:     func(h *AdminHandler) http.HandlerFunc { return h.WebDisableUser },
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "reset password",
+			method:     "POST",
+			target:     "user-456",
+			userID:     "user-123",
+			htmx:       true,
+			handle:     func(h *AdminHandler) http.HandlerFunc { return h.WebResetPassword },
+			wantStatus: http.StatusOK,
+			wantBody:   []string{`id="admin-user-user-456"`, `id="admin-user-user-456-password"`, "abcde-fghij-klmno-pqrst"},
+		},
+		{
+			name:       "reset password without HTMX shows it in a page",
+			method:     "POST",
+			target:     "user-456",
+			userID:     "user-123",
+			handle:     func(h *AdminHandler) http.HandlerFunc { return h.WebResetPassword },
+			wantStatus: http.StatusOK,
+			wantBody:   []string{"<!DOCTYPE html>", "abcde-fghij-klmno-pqrst"},
+		},
+		{
+			name:       "reset password of unknown user",
+			method:     "POST",
+			target:     "nobody",
+			userID:     "user-123",
+			htmx:       true,
+			handle:     func(h *AdminHandler) http.HandlerFunc { return h.WebResetPassword },
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "delete",
+			method:     "DELETE",
+			target:     "user-456",
+			userID:     "user-123",
+			htmx:       true,
+			handle:     func(h *AdminHandler) http.HandlerFunc { return h.WebDeleteUser },
+			wantStatus: http.StatusOK,
+			notBody:    []string{"admin-user"},
+		},
+		{
+			name:       "delete self",
+			method:     "DELETE",
+			target:     "user-123",
+			userID:     "user-123",
+			htmx:       true,
+			handle:     func(h *AdminHandler) http.HandlerFunc { return h.WebDeleteUser },
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, m := newTestAdminHandler()
+			req := httptest.NewRequest(tt.method, "/web/admin/users/"+tt.target, nil)
+			req.SetPathValue("id", tt.target)
+			req.Header.Set("Referer", "http://example.com/admin")
+			if tt.htmx {
+				req = htmx(req)
+			}
+			req = asUser(req, tt.userID)
+			w := httptest.NewRecorder()
+
+			tt.handle(h)(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantLocation != "" && w.Header().Get("Location") != tt.wantLocation {
+				t.Errorf("Expected Location %q, got %q", tt.wantLocation, w.Header().Get("Location"))
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(w.Body.String(), want) {
+					t.Errorf("Expected %q in:\n%s", want, w.Body.String())
+				}
+			}
+			for _, unwanted := range tt.notBody {
+				if strings.Contains(w.Body.String(), unwanted) {
+					t.Errorf("Expected no %q in:\n%s", unwanted, w.Body.String())
+				}
+			}
+			if tt.name == "delete" && (len(m.deleted) != 1 || m.deleted[0] != "user-456") {
+				t.Errorf("Expected user-456 deleted, got %v", m.deleted)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_WebListUsers(t *testing.T) {
+	h, _ := newTestAdminHandler()
+
+	req := asUser(htmx(httptest.NewRequest("GET", "/web/admin/users?offset=2", nil)), "user-123")
+	w := httptest.NewRecorder()
+	h.WebListUsers(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `id="admin-user-user-456"`) || strings.Contains(w.Body.String(), "admin-users-more") {
+		t.Errorf("Expected the last page without a load more row, got:\n%s", w.Body.String())
+	}
+
+	req = asUser(httptest.NewRequest("GET", "/web/admin/users?offset=2", nil), "user-123")
+	w = httptest.NewRecorder()
+	h.WebListUsers(w, req)
+
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/admin?offset=2" {
+		t.Errorf("Expected a redirect to the dashboard without HTMX, got %d %q", w.Code, w.Header().Get("Location"))
+	}
+}
+
+func TestAdminErrorStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{usecases.ErrAdminRequired, http.StatusForbidden},
+		{application.ErrUserNotFound, http.StatusNotFound},
+		{usecases.ErrCannotManageSelf, http.StatusConflict},
+		{errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		if got, _ := adminErrorStatus(tt.err); got != tt.want {
+			t.Errorf("adminErrorStatus(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,480 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+const (
+	// MaxAttachmentSize is the largest attachment accepted for any file type
+	MaxAttachmentSize = 20 << 20 // 20MB
+
+	// maxAttachmentFormMemory is the part of an upload kept in memory; the rest goes to a temp file
+	maxAttachmentFormMemory = 1 << 20 // 1MB
+)
+
+// attachmentType describes a file type accepted as attachment
+type attachmentType struct {
+	mimeType string // sent on download
+	sniffed  string // media type http.DetectContentType must report for the content
+	maxSize  int64
+	label    string
+}
+
+// attachmentTypes lists the accepted attachments by extension. The content is sniffed as well,
+// so a renamed executable or HTML page is rejected. Office documents are ZIP containers.
+var attachmentTypes = map[string]attachmentType{
+	".pdf":  {"application/pdf", "application/pdf", 20 << 20, "PDF"},
+	".txt":  {"text/plain; charset=utf-8", "text/plain", 2 << 20, "TXT"},
+	".csv":  {"text/csv; charset=utf-8", "text/plain", 5 << 20, "CSV"},
+	".jpg":  {"image/jpeg", "image/jpeg", 10 << 20, "JPG"},
+	".jpeg": {"image/jpeg", "image/jpeg", 10 << 20, "JPG"},
+	".png":  {"image/png", "image/png", 10 << 20, "PNG"},
+	".gif":  {"image/gif", "image/gif", 10 << 20, "GIF"},
+	".webp": {"image/webp", "image/webp", 10 << 20, "WebP"},
+	".docx": {"application/vnd.openxmlformats-officedocument.wordprocessingml.document", "application/zip", 20 << 20, "DOCX"},
+	".xlsx": {"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "application/zip", 20 << 20, "XLSX"},
+	".odt":  {"application/vnd.oasis.opendocument.text", "application/zip", 20 << 20, "ODT"},
+	".ods":  {"application/vnd.oasis.opendocument.spreadsheet", "application/zip", 20 << 20, "ODS"},
+	".zip":  {"application/zip", "application/zip", 20 << 20, "ZIP"},
+}
+
+// allowedAttachmentLabels lists the accepted types for error messages
+const allowedAttachmentLabels = "PDF, TXT, CSV, JPG, PNG, GIF, WebP, DOCX, XLSX, ODT, ODS and ZIP"
+
+// invalidAttachmentError is a validation error whose message can be shown to the user
+type invalidAttachmentError string
+
+func (e invalidAttachmentError) Error() string {
+	return string(e)
+}
+
+// storedAttachment is a validated upload saved in the attachments directory
+type storedAttachment struct {
+	filename string
+	mimeType string
+	size     int64
+	path     string
+}
+
+// AttachmentResponse is the JSON representation of an attachment
+type AttachmentResponse struct {
+	ID        string    `json:"id"`
+	TaskID    string    `json:"task_id"`
+	Filename  string    `json:"filename"`
+	MimeType  string    `json:"mime"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AttachmentHandler handles HTTP requests for task attachments. Files are stored outside
+// the public /uploads/ directory and only served through the permission-checked download.
+type AttachmentHandler struct {
+	addAttachment    usecases.AddAttachmentUseCaseInterface
+	listAttachments  usecases.ListAttachmentsUseCaseInterface
+	getAttachment    usecases.GetAttachmentUseCaseInterface
+	deleteAttachment usecases.DeleteAttachmentUseCaseInterface
+	dir              string
+	quota            usecases.StorageQuotaUseCaseInterface
+	pages            *PageTemplates
+}
+
+// NewAttachmentHandler creates a new AttachmentHandler storing files in dir. Files are charged
+// to the storage quota of the user who uploads them; a nil quota stores them without tracking.
+func NewAttachmentHandler(
+	addAttachment usecases.AddAttachmentUseCaseInterface,
+	listAttachments usecases.ListAttachmentsUseCaseInterface,
+	getAttachment usecases.GetAttachmentUseCaseInterface,
+	deleteAttachment usecases.DeleteAttachmentUseCaseInterface,
+	dir string,
+	quota usecases.StorageQuotaUseCaseInterface,
+	pages *PageTemplates,
+) *AttachmentHandler {
+	return &AttachmentHandler{
+		addAttachment:    addAttachment,
+		listAttachments:  listAttachments,
+		getAttachment:    getAttachment,
+		deleteAttachment: deleteAttachment,
+		dir:              dir,
+		quota:            quota,
+		pages:            pages,
+	}
+}
+
+// UploadAttachment handles POST /api/tasks/{id}/attachments
+func (h *AttachmentHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	attachment, status, err := h.upload(w, r, userID)
+	if err != nil {
+		var exceeded *application.StorageQuotaExceededError
+		if errors.As(err, &exceeded) {
+			WriteJSONError(w, r, status, CodeStorageQuotaExceeded, storageQuotaMessage(RequestLocale(r), exceeded))
+			return
+		}
+		writeAPIError(w, r, status, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toAttachmentResponse(attachment))
+}
+
+// ListAttachments handles GET /api/tasks/{id}/attachments
+func (h *AttachmentHandler) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	attachments, err := h.listAttachments.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		status, message := attachmentErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	response := make([]AttachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		response = append(response, toAttachmentResponse(attachment))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// DownloadAttachment handles GET /api/tasks/{id}/attachments/{attachmentID}
+func (h *AttachmentHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	attachment, err := h.getAttachment.Execute(r.Context(), r.PathValue("id"), r.PathValue("attachmentID"), userID)
+	if err != nil {
+		status, message := attachmentErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	if !h.serveFile(w, r, attachment) {
+		writeAPIError(w, r, http.StatusNotFound, application.ErrAttachmentNotFound.Error())
+	}
+}
+
+// DeleteAttachment handles DELETE /api/tasks/{id}/attachments/{attachmentID}
+func (h *AttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	attachment, err := h.deleteAttachment.Execute(r.Context(), r.PathValue("id"), r.PathValue("attachmentID"), userID)
+	if err != nil {
+		status, message := attachmentErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	h.removeFile(r.Context(), attachment.Path)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// WebUploadAttachment handles POST /web/tasks/{id}/attachments, returning the updated attachment list
+func (h *AttachmentHandler) WebUploadAttachment(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok || userID == "" {
+		h.pages.writeWebError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	_, _, err := h.upload(w, r, userID)
+	h.renderList(w, r, userID, err)
+}
+
+// WebDownloadAttachment handles GET /web/tasks/{id}/attachments/{attachmentID}
+func (h *AttachmentHandler) WebDownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok || userID == "" {
+		h.pages.writeWebError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	attachment, err := h.getAttachment.Execute(r.Context(), r.PathValue("id"), r.PathValue("attachmentID"), userID)
+	if err != nil {
+		status, message := attachmentErrorStatus(err)
+		h.pages.writeWebError(w, r, status, message)
+		return
+	}
+
+	if !h.serveFile(w, r, attachment) {
+		h.pages.writeWebError(w, r, http.StatusNotFound, i18n.T(RequestLocale(r), "attachments.file_not_found"))
+	}
+}
+
+// WebDeleteAttachment handles DELETE /web/tasks/{id}/attachments/{attachmentID}, returning the updated list
+func (h *AttachmentHandler) WebDeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok || userID == "" {
+		h.pages.writeWebError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	attachment, err := h.deleteAttachment.Execute(r.Context(), r.PathValue("id"), r.PathValue("attachmentID"), userID)
+	if err == nil {
+		h.removeFile(r.Context(), attachment.Path)
+	}
+	h.renderList(w, r, userID, err)
+}
+
+// upload validates and stores the uploaded file, charges it to the user's storage quota and
+// attaches it to the task. The stored file is removed again when the quota or the use case rejects it.
+func (h *AttachmentHandler) upload(w http.ResponseWriter, r *http.Request, userID string) (*application.Attachment, int, error) {
+	// Leave room for the multipart headers around the file
+	r.Body = http.MaxBytesReader(w, r.Body, MaxAttachmentSize+maxAttachmentFormMemory)
+
+	if err := r.ParseMultipartForm(maxAttachmentFormMemory); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, http.StatusRequestEntityTooLarge, fmt.Errorf("file exceeds the %dMB limit", MaxAttachmentSize>>20)
+		}
+		return nil, http.StatusBadRequest, errors.New("invalid form data")
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("no file uploaded")
+	}
+	defer file.Close()
+
+	stored, err := h.saveFile(file, header)
+	if err != nil {
+		var invalid invalidAttachmentError
+		if errors.As(err, &invalid) {
+			return nil, http.StatusBadRequest, err
+		}
+		log.Printf("failed to store attachment: %v", err)
+		return nil, http.StatusInternalServerError, errors.New("failed to store file")
+	}
+
+	if h.quota != nil {
+		if err := h.quota.Reserve(r.Context(), userID, application.StoredFileAttachment, stored.path, stored.size); err != nil {
+			removeAttachmentFile(h.dir, stored.path)
+			var exceeded *application.StorageQuotaExceededError
+			if errors.As(err, &exceeded) {
+				return nil, http.StatusRequestEntityTooLarge, err
+			}
+			log.Printf("failed to reserve storage for attachment: %v", err)
+			return nil, http.StatusInternalServerError, errors.New("failed to store file")
+		}
+	}
+
+	attachment, err := h.addAttachment.Execute(r.Context(), r.PathValue("id"), userID, stored.filename, stored.mimeType, stored.size, stored.path)
+	if err != nil {
+		h.removeFile(r.Context(), stored.path)
+		status, message := attachmentErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			return nil, status, errors.New(message)
+		}
+		return nil, status, err
+	}
+
+	return attachment, http.StatusCreated, nil
+}
+
+// saveFile validates the type and size of an upload and writes it under a random name
+func (h *AttachmentHandler) saveFile(file multipart.File, header *multipart.FileHeader) (*storedAttachment, error) {
+	filename := sanitizeFilename(header.Filename)
+	if filename == "" {
+		return nil, invalidAttachmentError("file name is required")
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	kind, ok := attachmentTypes[ext]
+	if !ok {
+		return nil, invalidAttachmentError("file type not allowed. Allowed types: " + allowedAttachmentLabels)
+	}
+
+	if header.Size == 0 {
+		return nil, invalidAttachmentError("file is empty")
+	}
+	if header.Size > kind.maxSize {
+		return nil, invalidAttachmentError(fmt.Sprintf("%s files cannot exceed %dMB", kind.label, kind.maxSize>>20))
+	}
+
+	// Read the first 512 bytes to detect the real content type
+	buffer := make([]byte, 512)
+	n, err := io.ReadFull(file, buffer)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("read upload: %w", err)
+	}
+	sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(buffer[:n]))
+	if sniffed != kind.sniffed {
+		return nil, invalidAttachmentError(fmt.Sprintf("file content does not match a %s file", kind.label))
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("rewind upload: %w", err)
+	}
+
+	name, err := randomFileName(ext)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(h.dir, 0755); err != nil {
+		return nil, fmt.Errorf("create attachments directory: %w", err)
+	}
+
+	dst, err := os.Create(filepath.Join(h.dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("create attachment file: %w", err)
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, file); err != nil {
+		removeAttachmentFile(h.dir, name)
+		return nil, fmt.Errorf("write attachment file: %w", err)
+	}
+
+	return &storedAttachment{
+		filename: filename,
+		mimeType: kind.mimeType,
+		size:     header.Size,
+		path:     name,
+	}, nil
+}
+
+// serveFile sends a stored attachment as a download, reporting false when the file is missing
+func (h *AttachmentHandler) serveFile(w http.ResponseWriter, r *http.Request, attachment *application.Attachment) bool {
+	f, err := os.Open(filepath.Join(h.dir, filepath.Base(attachment.Path)))
+	if err != nil {
+		log.Printf("attachment %s file unavailable: %v", attachment.ID, err)
+		return false
+	}
+	defer f.Close()
+
+	// Always download, never render inline, so uploaded content can't run in the app's origin
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+	w.Header().Set("Content-Type", attachment.MimeType)
+	w.Header().Set("Content-Disposition", disposition)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, no-cache")
+
+	http.ServeContent(w, r, "", attachment.CreatedAt, f)
+	return true
+}
+
+// removeFile deletes a stored attachment file, ignoring files that are already gone, and gives
+// its space back to the storage quota
+func (h *AttachmentHandler) removeFile(ctx context.Context, name string) {
+	removeAttachmentFile(h.dir, name)
+	releaseStoredFile(ctx, h.quota, application.StoredFileAttachment, name)
+}
+
+// renderList renders the attachment list of the task in the request path after a change.
+// HTMX only swaps successful responses, so a failed change is reported inside the fragment.
+func (h *AttachmentHandler) renderList(w http.ResponseWriter, r *http.Request, userID string, changeErr error) {
+	taskID := r.PathValue("id")
+
+	attachments, err := h.listAttachments.Execute(r.Context(), taskID, userID)
+	if err != nil {
+		status, message := attachmentErrorStatus(err)
+		h.pages.writeWebError(w, r, status, message)
+		return
+	}
+
+	errMessage := ""
+	if changeErr != nil {
+		errMessage = webAttachmentError(changeErr, RequestLocale(r))
+	}
+	canEdit := !errors.Is(changeErr, usecases.ErrAttachmentPermissionDenied)
+
+	h.pages.writeWebFragment(w, r, http.StatusOK, string(RenderAttachmentList(taskID, attachments, canEdit, errMessage, RequestLocale(r))))
+}
+
+// attachmentErrorStatus maps attachment use case errors to an HTTP status and message
+func attachmentErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecases.ErrTaskUnavailable), errors.Is(err, application.ErrAttachmentNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, usecases.ErrAttachmentPermissionDenied):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, application.ErrTooManyAttachments):
+		return http.StatusConflict, err.Error()
+	default:
+		log.Printf("attachment operation failed: %v", err)
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
+
+// webAttachmentError translates an attachment error into the message shown in the web interface
+func webAttachmentError(err error, locale application.Locale) string {
+	var invalid invalidAttachmentError
+	var exceeded *application.StorageQuotaExceededError
+	switch {
+	case errors.As(err, &invalid):
+		return i18n.T(locale, "attachments.invalid", i18n.Error(locale, err.Error()))
+	case errors.As(err, &exceeded):
+		return storageQuotaMessage(locale, exceeded)
+	case errors.Is(err, application.ErrTooManyAttachments):
+		return i18n.T(locale, "attachments.limit", application.MaxAttachmentsPerTask)
+	case errors.Is(err, usecases.ErrAttachmentPermissionDenied):
+		return i18n.T(locale, "attachments.owner_only")
+	case errors.Is(err, application.ErrAttachmentNotFound):
+		return i18n.T(locale, "attachments.not_found")
+	default:
+		return i18n.T(locale, "attachments.failed", i18n.Error(locale, err.Error()))
+	}
+}
+
+// toAttachmentResponse converts an attachment to its JSON representation
+func toAttachmentResponse(attachment *application.Attachment) AttachmentResponse {
+	return AttachmentResponse{
+		ID:        attachment.ID,
+		TaskID:    attachment.TaskID,
+		Filename:  attachment.Filename,
+		MimeType:  attachment.MimeType,
+		Size:      attachment.Size,
+		CreatedAt: attachment.CreatedAt,
+	}
+}
+
+// sanitizeFilename keeps only the base name of an uploaded file, without control characters
+func sanitizeFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "." || name == "/" {
+		return ""
+	}
+	return name
+}
+
+// randomFileName generates an unguessable name for a stored file
+func randomFileName(ext string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate file name: %w", err)
+	}
+	return hex.EncodeToString(b) + ext, nil
+}
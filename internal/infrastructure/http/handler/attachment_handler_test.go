@@ -0,0 +1,411 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// =============================================================================
+// Mocks
+// =============================================================================
+
+type mockAddAttachmentUseCase struct {
+	executeFunc func(ctx context.Context, taskID, userID, filename, mimeType string, size int64, path string) (*application.Attachment, error)
+}
+
+func (m *mockAddAttachmentUseCase) Execute(ctx context.Context, taskID, userID, filename, mimeType string, size int64, path string) (*application.Attachment, error) {
+	return m.executeFunc(ctx, taskID, userID, filename, mimeType, size, path)
+}
+
+type mockListAttachmentsUseCase struct {
+	attachments []*application.Attachment
+}
+
+func (m *mockListAttachmentsUseCase) Execute(ctx context.Context, taskID, userID string) ([]*application.Attachment, error) {
+	return m.attachments, nil
+}
+
+type mockGetAttachmentUseCase struct {
+	attachment *application.Attachment
+	err        error
+}
+
+func (m *mockGetAttachmentUseCase) Execute(ctx context.Context, taskID, attachmentID, userID string) (*application.Attachment, error) {
+	return m.attachment, m.err
+}
+
+type mockDeleteAttachmentUseCase struct {
+	attachment *application.Attachment
+	err        error
+}
+
+func (m *mockDeleteAttachmentUseCase) Execute(ctx context.Context, taskID, attachmentID, userID string) (*application.Attachment, error) {
+	return m.attachment, m.err
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// acceptAttachment is an add use case that echoes the stored file as a new attachment
+func acceptAttachment() *mockAddAttachmentUseCase {
+	return &mockAddAttachmentUseCase{
+		executeFunc: func(ctx context.Context, taskID, userID, filename, mimeType string, size int64, path string) (*application.Attachment, error) {
+			return &application.Attachment{ID: "att-1", TaskID: taskID, Filename: filename, MimeType: mimeType, Size: size, Path: path}, nil
+		},
+	}
+}
+
+func newTestAttachmentHandler(t *testing.T, add *mockAddAttachmentUseCase) (*AttachmentHandler, string) {
+	dir := t.TempDir()
+	h := NewAttachmentHandler(add, &mockListAttachmentsUseCase{}, &mockGetAttachmentUseCase{}, &mockDeleteAttachmentUseCase{}, dir, nil, NewPageTemplates(""))
+	return h, dir
+}
+
+func newAttachmentUploadRequest(t *testing.T, target, filename string, content []byte) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(content)
+	writer.Close()
+
+	req := htmx(httptest.NewRequest("POST", target, &body))
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.SetPathValue("id", "task-1")
+	return req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+}
+
+func storedFiles(t *testing.T, dir string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+var pdfContent = []byte("%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\n%%EOF\n")
+
+// =============================================================================
+// Tests
+// =============================================================================
+
+func TestUploadAttachment_Success(t *testing.T) {
+	h, dir := newTestAttachmentHandler(t, acceptAttachment())
+
+	w := httptest.NewRecorder()
+	h.UploadAttachment(w, newAttachmentUploadRequest(t, "/tasks/task-1/attachments", `..\..\relatório final.pdf`, pdfContent))
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response AttachmentResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Filename != "relatório final.pdf" || response.MimeType != "application/pdf" || response.Size != int64(len(pdfContent)) {
+		t.Errorf("Unexpected attachment %+v", response)
+	}
+
+	files := storedFiles(t, dir)
+	if len(files) != 1 || !strings.HasSuffix(files[0], ".pdf") || strings.Contains(files[0], "relat") {
+		t.Fatalf("Expected one file stored under a random name, got %v", files)
+	}
+	stored, _ := os.ReadFile(filepath.Join(dir, files[0]))
+	if !bytes.Equal(stored, pdfContent) {
+		t.Error("Stored file content differs from the upload")
+	}
+}
+
+func TestUploadAttachment_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		content  []byte
+		wantMsg  string
+	}{
+		{"extension not allowed", "script.exe", []byte("MZ\x90\x00"), "file type not allowed"},
+		{"html renamed to pdf", "page.pdf", []byte("<html><script>alert(1)</script></html>"), "does not match a PDF file"},
+		{"html renamed to txt", "notes.txt", []byte("<!DOCTYPE html><html></html>"), "does not match a TXT file"},
+		{"empty file", "empty.txt", nil, "file is empty"},
+		{"image over its limit", "big.png", append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 10<<20)...), "PNG files cannot exceed 10MB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, dir := newTestAttachmentHandler(t, acceptAttachment())
+
+			w := httptest.NewRecorder()
+			h.UploadAttachment(w, newAttachmentUploadRequest(t, "/tasks/task-1/attachments", tt.filename, tt.content))
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("Expected status 400, got %d", w.Code)
+			}
+			if body := decodeErrorResponse(t, w); !strings.Contains(body.Message, tt.wantMsg) {
+				t.Errorf("Expected message containing %q, got %q", tt.wantMsg, body.Message)
+			}
+			if files := storedFiles(t, dir); len(files) != 0 {
+				t.Errorf("Expected no stored file, got %v", files)
+			}
+		})
+	}
+}
+
+func TestUploadAttachment_UseCaseErrorRemovesFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"not owner", usecases.ErrAttachmentPermissionDenied, http.StatusForbidden},
+		{"task not found", usecases.ErrTaskUnavailable, http.StatusNotFound},
+		{"limit reached", application.ErrTooManyAttachments, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, dir := newTestAttachmentHandler(t, &mockAddAttachmentUseCase{
+				executeFunc: func(ctx context.Context, taskID, userID, filename, mimeType string, size int64, path string) (*application.Attachment, error) {
+					return nil, tt.err
+				},
+			})
+
+			w := httptest.NewRecorder()
+			h.UploadAttachment(w, newAttachmentUploadRequest(t, "/tasks/task-1/attachments", "a.pdf", pdfContent))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if files := storedFiles(t, dir); len(files) != 0 {
+				t.Errorf("Expected rejected upload to be removed, got %v", files)
+			}
+		})
+	}
+}
+
+func TestDownloadAttachment(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "stored.pdf"), pdfContent, 0644); err != nil {
+		t.Fatal(err)
+	}
+	attachment := &application.Attachment{ID: "att-1", TaskID: "task-1", Filename: "relatório.pdf", MimeType: "application/pdf", Path: "stored.pdf", CreatedAt: time.Now()}
+	h := NewAttachmentHandler(acceptAttachment(), &mockListAttachmentsUseCase{}, &mockGetAttachmentUseCase{attachment: attachment}, &mockDeleteAttachmentUseCase{}, dir, nil, NewPageTemplates(""))
+
+	req := httptest.NewRequest("GET", "/tasks/task-1/attachments/att-1", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+	w := httptest.NewRecorder()
+	h.DownloadAttachment(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if !bytes.Equal(w.Body.Bytes(), pdfContent) {
+		t.Error("Expected the stored file content")
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/pdf" {
+		t.Errorf("Expected Content-Type application/pdf, got %q", ct)
+	}
+	if cd := w.Header().Get("Content-Disposition"); !strings.HasPrefix(cd, "attachment;") || !strings.Contains(cd, "relat%C3%B3rio.pdf") {
+		t.Errorf("Expected attachment disposition with the original name, got %q", cd)
+	}
+	if w.Header().Get("X-Content-Type-Options") != "nosniff" {
+		t.Error("Expected nosniff header")
+	}
+}
+
+func TestDownloadAttachment_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		attachment *application.Attachment
+		err        error
+		wantStatus int
+	}{
+		{"not visible", nil, usecases.ErrTaskUnavailable, http.StatusNotFound},
+		{"missing attachment", nil, application.ErrAttachmentNotFound, http.StatusNotFound},
+		{"file missing on disk", &application.Attachment{ID: "att-1", Path: "gone.pdf"}, nil, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewAttachmentHandler(acceptAttachment(), &mockListAttachmentsUseCase{}, &mockGetAttachmentUseCase{attachment: tt.attachment, err: tt.err}, &mockDeleteAttachmentUseCase{}, t.TempDir(), nil, NewPageTemplates(""))
+
+			req := httptest.NewRequest("GET", "/tasks/task-1/attachments/att-1", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+			w := httptest.NewRecorder()
+			h.DownloadAttachment(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			decodeErrorResponse(t, w)
+		})
+	}
+}
+
+func TestDeleteAttachment_RemovesFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "stored.pdf"), pdfContent, 0644); err != nil {
+		t.Fatal(err)
+	}
+	deleted := &application.Attachment{ID: "att-1", TaskID: "task-1", Path: "stored.pdf"}
+	quota := &mockStorageQuota{}
+	h := NewAttachmentHandler(acceptAttachment(), &mockListAttachmentsUseCase{}, &mockGetAttachmentUseCase{}, &mockDeleteAttachmentUseCase{attachment: deleted}, dir, quota, NewPageTemplates(""))
+
+	req := httptest.NewRequest("DELETE", "/tasks/task-1/attachments/att-1", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+	w := httptest.NewRecorder()
+	h.DeleteAttachment(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d", w.Code)
+	}
+	if files := storedFiles(t, dir); len(files) != 0 {
+		t.Errorf("Expected the file to be removed, got %v", files)
+	}
+	if len(quota.released) != 1 || quota.released[0] != "attachment/stored.pdf" {
+		t.Errorf("Expected the file to be released from the quota, got %v", quota.released)
+	}
+}
+
+func TestUploadAttachment_StorageQuota(t *testing.T) {
+	tests := []struct {
+		name         string
+		quotaErr     error
+		wantStatus   int
+		wantFiles    int
+		wantReserved int
+	}{
+		{"within quota", nil, http.StatusCreated, 1, 1},
+		{"quota exceeded", &application.StorageQuotaExceededError{Limit: 100, Used: 90, Size: 20}, http.StatusRequestEntityTooLarge, 0, 0},
+		{"quota unavailable", errors.New("database is locked"), http.StatusInternalServerError, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, dir := newTestAttachmentHandler(t, acceptAttachment())
+			quota := &mockStorageQuota{err: tt.quotaErr}
+			h.quota = quota
+
+			w := httptest.NewRecorder()
+			h.UploadAttachment(w, newAttachmentUploadRequest(t, "/tasks/task-1/attachments", "a.pdf", pdfContent))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if files := storedFiles(t, dir); len(files) != tt.wantFiles {
+				t.Errorf("Expected %d stored files, got %v", tt.wantFiles, files)
+			}
+			if len(quota.reserved) != tt.wantReserved {
+				t.Errorf("Expected %d reserved files, got %v", tt.wantReserved, quota.reserved)
+			}
+			if tt.wantStatus == http.StatusRequestEntityTooLarge && !strings.Contains(w.Body.String(), `"code":"storage_quota_exceeded"`) {
+				t.Errorf("Expected the storage quota error code, got %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestWebUploadAttachment_RendersList(t *testing.T) {
+	tests := []struct {
+		name      string
+		filename  string
+		content   []byte
+		wantParts []string
+	}{
+		{
+			name:      "success",
+			filename:  "a.pdf",
+			content:   pdfContent,
+			wantParts: []string{`id="task-task-1-attachments"`, "existente.txt", `hx-post="/web/tasks/task-1/attachments"`},
+		},
+		{
+			name:      "validation error is shown in the fragment",
+			filename:  "a.exe",
+			content:   []byte("MZ"),
+			wantParts: []string{"Arquivo inválido", "existente.txt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := &mockListAttachmentsUseCase{attachments: []*application.Attachment{
+				{ID: "att-0", TaskID: "task-1", Filename: "existente.txt", Size: 10},
+			}}
+			h := NewAttachmentHandler(acceptAttachment(), list, &mockGetAttachmentUseCase{}, &mockDeleteAttachmentUseCase{}, t.TempDir(), nil, NewPageTemplates(""))
+
+			w := httptest.NewRecorder()
+			h.WebUploadAttachment(w, newAttachmentUploadRequest(t, "/tasks/task-1/attachments", tt.filename, tt.content))
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d", w.Code)
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(w.Body.String(), part) {
+					t.Errorf("Expected fragment to contain %q, got %s", part, w.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRenderAttachmentList_EscapesAndHidesControls(t *testing.T) {
+	html := string(RenderAttachmentList("task-1", []*application.Attachment{
+		{ID: "att-1", TaskID: "task-1", Filename: "<img src=x onerror=alert(1)>.txt", Size: 3 << 20},
+	}, false, "", i18n.Default))
+
+	if strings.Contains(html, "<img") {
+		t.Error("Expected the file name to be escaped")
+	}
+	if !strings.Contains(html, "3,0 MB") {
+		t.Errorf("Expected formatted size, got %s", html)
+	}
+	if strings.Contains(html, "hx-delete") || strings.Contains(html, "hx-post") {
+		t.Error("Expected no edit controls for read-only lists")
+	}
+}
+
+func TestSanitizeFilename(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"report.pdf", "report.pdf"},
+		{"../../etc/passwd", "passwd"},
+		{`C:\Users\ana\nota.txt`, "nota.txt"},
+		{"bad\x00name\r\n.txt", "badname.txt"},
+		{"  spaced.txt  ", "spaced.txt"},
+		{"/", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := sanitizeFilename(tt.input); got != tt.want {
+			t.Errorf("sanitizeFilename(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -2,9 +2,17 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"html/template"
+	"log"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
 	"github.com/ia-edev-sindireceita/todo/internal/usecases"
 )
 
@@ -12,28 +20,46 @@ import (
 type AuthHandler struct {
 	loginUseCase    usecases.LoginUseCaseInterface
 	registerUseCase usecases.RegisterUseCaseInterface
+	taskListWarmer  TaskListWarmer
+	recordLogin     usecases.RecordLoginEventUseCaseInterface
+	pages           *PageTemplates
+	cookies         CookieConfig
 }
 
-// NewAuthHandler creates a new AuthHandler
+// NewAuthHandler creates a new AuthHandler. taskListWarmer and recordLogin are optional.
 func NewAuthHandler(
 	loginUseCase usecases.LoginUseCaseInterface,
 	registerUseCase usecases.RegisterUseCaseInterface,
+	taskListWarmer TaskListWarmer,
+	recordLogin usecases.RecordLoginEventUseCaseInterface,
+	pages *PageTemplates,
+	cookies CookieConfig,
 ) *AuthHandler {
 	return &AuthHandler{
 		loginUseCase:    loginUseCase,
 		registerUseCase: registerUseCase,
+		taskListWarmer:  taskListWarmer,
+		recordLogin:     recordLogin,
+		pages:           pages,
+		cookies:         cookies,
 	}
 }
 
 // LoginRequest represents a login request
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	RememberMe bool   `json:"remember_me"`
 }
 
-// LoginResponse represents a login response
+// LoginResponse represents a login response. Users with two-factor authentication get
+// two_factor_token instead of token, to be sent with a code to POST /api/auth/2fa/verify
+// before expires_at.
 type LoginResponse struct {
-	Token string `json:"token"`
+	Token             string    `json:"token,omitempty"`
+	ExpiresAt         time.Time `json:"expires_at"`
+	TwoFactorRequired bool      `json:"two_factor_required,omitempty"`
+	TwoFactorToken    string    `json:"two_factor_token,omitempty"`
 }
 
 // RegisterRequest represents a registration request
@@ -43,88 +69,149 @@ type RegisterRequest struct {
 	Password string `json:"password"`
 }
 
-// RegisterResponse represents a registration response
-type RegisterResponse struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"created_at"`
-}
-
 // Login handles user login (API)
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req LoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if !decodeJSON(w, r, &req, maxJSONBodySize) {
 		return
 	}
 
-	token, err := h.loginUseCase.Execute(r.Context(), req.Email, req.Password)
+	session, err := h.loginUseCase.Execute(r.Context(), req.Email, req.Password, req.RememberMe)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
+		h.auditLogin(r, req.Email, false)
+		status := http.StatusUnauthorized
+		if errors.Is(err, application.ErrUserDisabled) {
+			status = http.StatusForbidden
+		}
+		writeAPIError(w, r, status, err.Error())
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(LoginResponse{Token: token})
+	w.Header().Set("Cache-Control", "no-store")
+
+	// The login is only recorded once the second factor is verified
+	if session.TwoFactorRequired() {
+		json.NewEncoder(w).Encode(LoginResponse{
+			ExpiresAt:         session.ExpiresAt,
+			TwoFactorRequired: true,
+			TwoFactorToken:    session.TwoFactorToken,
+		})
+		return
+	}
+
+	h.auditLogin(r, req.Email, true)
+	json.NewEncoder(w).Encode(LoginResponse{Token: session.Token, ExpiresAt: session.ExpiresAt})
 }
 
 // Register handles user registration (API)
 func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var req RegisterRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if !decodeJSON(w, r, &req, maxJSONBodySize) {
 		return
 	}
 
 	user, err := h.registerUseCase.Execute(r.Context(), req.Name, req.Email, req.Password)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		var policyErr *service.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			writePasswordPolicyError(w, r, "password", policyErr)
+			return
+		}
+		if writeValidationError(w, r, err) {
+			return
+		}
+		writeAPIError(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(RegisterResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-	})
+	json.NewEncoder(w).Encode(toUserResponse(user))
 }
 
-// WebLogin handles web login (form submission)
+// WebLogin handles web login (form submission). Validation errors are shown inline in the
+// form; failed logins get the same message whatever failed.
 func (h *AuthHandler) WebLogin(w http.ResponseWriter, r *http.Request) {
 	if err := r.ParseForm(); err != nil {
-		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		h.pages.writeFormErrors(w, r, http.StatusBadRequest, loginForm, formError("", "Invalid form data"))
 		return
 	}
 
 	email := r.FormValue("email")
 	password := r.FormValue("password")
+	rememberMe := r.FormValue("remember_me") != ""
 
-	token, err := h.loginUseCase.Execute(r.Context(), email, password)
+	// Missing fields are rejected before any account is looked up
+	missing := FormErrors{Fields: map[string]string{}}
+	if strings.TrimSpace(email) == "" {
+		missing.Fields["email"] = "email is required"
+	}
+	if password == "" {
+		missing.Fields["password"] = "password is required"
+	}
+	if len(missing.Fields) > 0 {
+		h.pages.writeFormErrors(w, r, http.StatusBadRequest, loginForm, missing)
+		return
+	}
+
+	session, err := h.loginUseCase.Execute(r.Context(), email, password, rememberMe)
+	if errors.Is(err, application.ErrUserDisabled) {
+		h.auditLogin(r, email, false)
+		h.pages.writeFormErrors(w, r, http.StatusForbidden, loginForm, formError("", err.Error()))
+		return
+	}
 	if err != nil {
-		// Return error HTML fragment for HTMX
-		w.WriteHeader(http.StatusUnauthorized)
-		w.Write([]byte(`<div class="bg-red-100 border border-red-400 text-red-700 px-4 py-3 rounded">
-			Credenciais inválidas. Tente novamente.
-		</div>`))
+		h.auditLogin(r, email, false)
+		h.pages.writeFormErrors(w, r, http.StatusUnauthorized, loginForm, formError("", i18n.T(RequestLocale(r), "login.invalid_credentials")))
 		return
 	}
 
+	// The cookie is only issued after the second factor: swap the login form for the code form
+	if session.TwoFactorRequired() {
+		fragment, err := renderTwoFactorForm(session.TwoFactorToken, RequestLocale(r))
+		if err != nil {
+			h.pages.writeWebError(w, r, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("HX-Retarget", "#login-form")
+		w.Header().Set("HX-Reswap", "outerHTML")
+		h.pages.writeWebPage(w, r, http.StatusOK, template.HTML(fragment))
+		return
+	}
+
+	h.auditLogin(r, email, true)
+	startWebSession(w, r, h.cookies, session, h.taskListWarmer)
+}
+
+// startWebSession sets the auth cookie of a session issued to the browser and sends it to the
+// task list
+func startWebSession(w http.ResponseWriter, r *http.Request, cookies CookieConfig, session *usecases.LoginResult, taskListWarmer TaskListWarmer) {
 	// Set JWT token in HttpOnly cookie
-	http.SetCookie(w, createAuthCookie(token))
+	http.SetCookie(w, cookies.createAuthCookie(session.Token, session.Duration))
+
+	// The language chosen in a previous session follows the user to this browser
+	locale := RequestLocale(r)
+	if session.Locale != "" {
+		locale = session.Locale
+		http.SetCookie(w, cookies.createLocaleCookie(locale))
+	}
+
+	// Pre-render the task list while the browser follows the redirect
+	if taskListWarmer != nil {
+		taskListWarmer.WarmUp(session.Token, locale)
+	}
 
 	// Redirect to tasks page
-	w.Header().Set("HX-Redirect", "/tasks")
-	w.WriteHeader(http.StatusOK)
+	writeWebRedirect(w, r, "/tasks")
 }
 
-// WebRegister handles web registration (form submission)
+// WebRegister handles web registration (form submission). Validation errors are shown inline
+// in the form.
 func (h *AuthHandler) WebRegister(w http.ResponseWriter, r *http.Request) {
 	if err := r.ParseForm(); err != nil {
-		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		h.pages.writeFormErrors(w, r, http.StatusBadRequest, registerForm, formError("", "Invalid form data"))
 		return
 	}
 
@@ -134,37 +221,65 @@ func (h *AuthHandler) WebRegister(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.registerUseCase.Execute(r.Context(), name, email, password)
 	if err != nil {
-		// Return error HTML fragment for HTMX
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte(`<div class="bg-red-100 border border-red-400 text-red-700 px-4 py-3 rounded">
-			` + err.Error() + `
-		</div>`))
+		var policyErr *service.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			h.pages.writeFormErrors(w, r, http.StatusBadRequest, registerForm, formError("password", passwordPolicyMessage(r, policyErr)))
+			return
+		}
+		h.pages.writeFormErrors(w, r, http.StatusBadRequest, registerForm, useCaseFormError(registerForm, err))
 		return
 	}
 
 	// Auto-login after registration using the same password
-	token, err := h.loginUseCase.Execute(r.Context(), user.Email, password)
-	if err != nil {
+	session, err := h.loginUseCase.Execute(r.Context(), user.Email, password, false)
+	h.auditLogin(r, user.Email, err == nil)
+	if err != nil || session.TwoFactorRequired() {
 		// Redirect to login page if auto-login fails
-		w.Header().Set("HX-Redirect", "/login")
-		w.WriteHeader(http.StatusOK)
+		writeWebRedirect(w, r, "/login")
 		return
 	}
 
 	// Set JWT token in HttpOnly cookie
-	http.SetCookie(w, createAuthCookie(token))
+	http.SetCookie(w, h.cookies.createAuthCookie(session.Token, session.Duration))
 
 	// Redirect to tasks page
-	w.Header().Set("HX-Redirect", "/tasks")
-	w.WriteHeader(http.StatusOK)
+	writeWebRedirect(w, r, "/tasks")
 }
 
 // Logout handles user logout
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	// Clear the auth cookie
-	http.SetCookie(w, deleteAuthCookie())
+	http.SetCookie(w, h.cookies.deleteAuthCookie())
 
 	// Redirect to login page
-	w.Header().Set("HX-Redirect", "/login")
-	w.WriteHeader(http.StatusOK)
+	writeWebRedirect(w, r, "/login")
+}
+
+// auditLogin records a login attempt
+func (h *AuthHandler) auditLogin(r *http.Request, email string, success bool) {
+	recordLoginAttempt(r, h.recordLogin, email, success)
+}
+
+// recordLoginAttempt records a login attempt when recordLogin is set. Failing to record it never
+// blocks the login itself.
+func recordLoginAttempt(r *http.Request, recordLogin usecases.RecordLoginEventUseCaseInterface, email string, success bool) {
+	if recordLogin == nil {
+		return
+	}
+
+	if err := recordLogin.Execute(r.Context(), email, clientIP(r), r.UserAgent(), success); err != nil {
+		log.Printf("failed to record login attempt: %v", err)
+	}
+}
+
+// clientIP returns the client IP resolved by the client IP middleware, falling back to the
+// connection address
+func clientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value("clientIP").(string); ok && ip != "" {
+		return ip
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
 }
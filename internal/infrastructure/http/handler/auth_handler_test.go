@@ -13,18 +13,39 @@ import (
 	"time"
 
 	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
 )
 
 // Mock for LoginUseCase
 type mockLoginUseCase struct {
 	executeFunc func(ctx context.Context, email, password string) (string, error)
+	rememberMe  bool
+	locale      application.Locale
+
+	// twoFactorToken makes successful logins return a two-factor challenge
+	twoFactorToken string
 }
 
-func (m *mockLoginUseCase) Execute(ctx context.Context, email, password string) (string, error) {
+func (m *mockLoginUseCase) Execute(ctx context.Context, email, password string, rememberMe bool) (*usecases.LoginResult, error) {
+	m.rememberMe = rememberMe
+
+	token := "mock-jwt-token"
 	if m.executeFunc != nil {
-		return m.executeFunc(ctx, email, password)
+		var err error
+		if token, err = m.executeFunc(ctx, email, password); err != nil {
+			return nil, err
+		}
+	}
+
+	if m.twoFactorToken != "" {
+		return &usecases.LoginResult{ExpiresAt: time.Now().Add(5 * time.Minute), Email: email, TwoFactorToken: m.twoFactorToken}, nil
+	}
+
+	duration := 24 * time.Hour
+	if rememberMe {
+		duration = 30 * 24 * time.Hour
 	}
-	return "mock-jwt-token", nil
+	return &usecases.LoginResult{Token: token, Duration: duration, ExpiresAt: time.Now().Add(duration), Locale: m.locale}, nil
 }
 
 // Mock for RegisterUseCase
@@ -230,7 +251,7 @@ func TestRegister_Success(t *testing.T) {
 		t.Errorf("Expected Content-Type application/json, got %s", w.Header().Get("Content-Type"))
 	}
 
-	var response RegisterResponse
+	var response UserResponse
 	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
 		t.Fatalf("Failed to decode response: %v", err)
 	}
@@ -425,13 +446,13 @@ func TestWebLogin_Success(t *testing.T) {
 		},
 	}
 
-	handler := &AuthHandler{loginUseCase: mockLogin}
+	handler := &AuthHandler{loginUseCase: mockLogin, cookies: DefaultCookieConfig()}
 
 	formData := url.Values{}
 	formData.Set("email", "test@example.com")
 	formData.Set("password", "password123")
 
-	req := httptest.NewRequest("POST", "/web/auth/login", strings.NewReader(formData.Encode()))
+	req := htmx(httptest.NewRequest("POST", "/web/auth/login", strings.NewReader(formData.Encode())))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	w := httptest.NewRecorder()
 
@@ -481,7 +502,7 @@ func TestWebLogin_InvalidCredentials(t *testing.T) {
 	formData.Set("email", "wrong@example.com")
 	formData.Set("password", "wrongpass")
 
-	req := httptest.NewRequest("POST", "/web/auth/login", strings.NewReader(formData.Encode()))
+	req := htmx(httptest.NewRequest("POST", "/web/auth/login", strings.NewReader(formData.Encode())))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	w := httptest.NewRecorder()
 
@@ -507,7 +528,7 @@ func TestWebLogin_InvalidForm(t *testing.T) {
 	handler := &AuthHandler{loginUseCase: &mockLoginUseCase{}}
 
 	// Create request with invalid form encoding
-	req := httptest.NewRequest("POST", "/web/auth/login", strings.NewReader("%invalid%form%"))
+	req := htmx(httptest.NewRequest("POST", "/web/auth/login", strings.NewReader("%invalid%form%")))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	w := httptest.NewRecorder()
 
@@ -518,6 +539,81 @@ func TestWebLogin_InvalidForm(t *testing.T) {
 	}
 }
 
+func TestWebLogin_RememberMe(t *testing.T) {
+	tests := []struct {
+		name           string
+		rememberMe     string
+		wantRememberMe bool
+		wantMaxAge     int
+	}{
+		{name: "unchecked issues the default session", rememberMe: "", wantRememberMe: false, wantMaxAge: 24 * 3600},
+		{name: "checked issues a long session", rememberMe: "on", wantRememberMe: true, wantMaxAge: 30 * 24 * 3600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogin := &mockLoginUseCase{}
+			handler := &AuthHandler{loginUseCase: mockLogin, cookies: DefaultCookieConfig()}
+
+			formData := url.Values{}
+			formData.Set("email", "test@example.com")
+			formData.Set("password", "password123")
+			if tt.rememberMe != "" {
+				formData.Set("remember_me", tt.rememberMe)
+			}
+
+			req := htmx(httptest.NewRequest("POST", "/web/auth/login", strings.NewReader(formData.Encode())))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+
+			handler.WebLogin(w, req)
+
+			if mockLogin.rememberMe != tt.wantRememberMe {
+				t.Errorf("rememberMe = %v, want %v", mockLogin.rememberMe, tt.wantRememberMe)
+			}
+
+			var cookie *http.Cookie
+			for _, c := range w.Result().Cookies() {
+				if c.Name == AuthCookieName {
+					cookie = c
+				}
+			}
+			if cookie == nil {
+				t.Fatal("Expected auth cookie to be set")
+			}
+			if cookie.MaxAge != tt.wantMaxAge {
+				t.Errorf("MaxAge = %d, want %d", cookie.MaxAge, tt.wantMaxAge)
+			}
+		})
+	}
+}
+
+func TestLogin_RememberMe(t *testing.T) {
+	mockLogin := &mockLoginUseCase{}
+	handler := &AuthHandler{loginUseCase: mockLogin}
+
+	body, _ := json.Marshal(LoginRequest{Email: "test@example.com", Password: "password123", RememberMe: true})
+	req := httptest.NewRequest("POST", "/api/auth/login", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.Login(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if !mockLogin.rememberMe {
+		t.Error("Expected remember_me to be passed to the use case")
+	}
+
+	var response LoginResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if time.Until(response.ExpiresAt) < 29*24*time.Hour {
+		t.Errorf("Expected expires_at about 30 days ahead, got %v", response.ExpiresAt)
+	}
+}
+
 // =============================================================================
 // WebRegister Tests (HTMX)
 // =============================================================================
@@ -543,6 +639,7 @@ func TestWebRegister_Success(t *testing.T) {
 	handler := &AuthHandler{
 		registerUseCase: mockRegister,
 		loginUseCase:    mockLogin,
+		cookies:         DefaultCookieConfig(),
 	}
 
 	formData := url.Values{}
@@ -550,7 +647,7 @@ func TestWebRegister_Success(t *testing.T) {
 	formData.Set("email", "new@example.com")
 	formData.Set("password", "password123")
 
-	req := httptest.NewRequest("POST", "/web/auth/register", strings.NewReader(formData.Encode()))
+	req := htmx(httptest.NewRequest("POST", "/web/auth/register", strings.NewReader(formData.Encode())))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	w := httptest.NewRecorder()
 
@@ -595,7 +692,7 @@ func TestWebRegister_ValidationError(t *testing.T) {
 	formData.Set("email", "test@example.com")
 	formData.Set("password", "short")
 
-	req := httptest.NewRequest("POST", "/web/auth/register", strings.NewReader(formData.Encode()))
+	req := htmx(httptest.NewRequest("POST", "/web/auth/register", strings.NewReader(formData.Encode())))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	w := httptest.NewRecorder()
 
@@ -644,7 +741,7 @@ func TestWebRegister_AutoLoginFails(t *testing.T) {
 	formData.Set("email", "test@example.com")
 	formData.Set("password", "password123")
 
-	req := httptest.NewRequest("POST", "/web/auth/register", strings.NewReader(formData.Encode()))
+	req := htmx(httptest.NewRequest("POST", "/web/auth/register", strings.NewReader(formData.Encode())))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	w := httptest.NewRecorder()
 
@@ -663,7 +760,7 @@ func TestWebRegister_AutoLoginFails(t *testing.T) {
 func TestWebRegister_InvalidForm(t *testing.T) {
 	handler := &AuthHandler{registerUseCase: &mockRegisterUseCase{}}
 
-	req := httptest.NewRequest("POST", "/web/auth/register", strings.NewReader("%invalid%form%"))
+	req := htmx(httptest.NewRequest("POST", "/web/auth/register", strings.NewReader("%invalid%form%")))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	w := httptest.NewRecorder()
 
@@ -679,9 +776,9 @@ func TestWebRegister_InvalidForm(t *testing.T) {
 // =============================================================================
 
 func TestLogout_Success(t *testing.T) {
-	handler := &AuthHandler{}
+	handler := &AuthHandler{cookies: DefaultCookieConfig()}
 
-	req := httptest.NewRequest("POST", "/web/auth/logout", nil)
+	req := htmx(httptest.NewRequest("POST", "/web/auth/logout", nil))
 	w := httptest.NewRecorder()
 
 	handler.Logout(w, req)
@@ -713,3 +810,157 @@ func TestLogout_Success(t *testing.T) {
 		t.Error("Expected auth cookie to be set for deletion")
 	}
 }
+
+// =============================================================================
+// Login Audit Tests
+// =============================================================================
+
+type recordedLogin struct {
+	email, ip, userAgent string
+	success              bool
+}
+
+type mockRecordLoginEventUseCase struct {
+	recorded []recordedLogin
+	err      error
+}
+
+func (m *mockRecordLoginEventUseCase) Execute(ctx context.Context, email, ip, userAgent string, success bool) error {
+	m.recorded = append(m.recorded, recordedLogin{email, ip, userAgent, success})
+	return m.err
+}
+
+func TestLogin_RecordsAttempts(t *testing.T) {
+	tests := []struct {
+		name        string
+		password    string
+		wantStatus  int
+		wantSuccess bool
+	}{
+		{name: "successful login", password: "password123", wantStatus: http.StatusOK, wantSuccess: true},
+		{name: "failed login", password: "wrongpass", wantStatus: http.StatusUnauthorized, wantSuccess: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audit := &mockRecordLoginEventUseCase{}
+			handler := NewAuthHandler(&mockLoginUseCase{
+				executeFunc: func(ctx context.Context, email, password string) (string, error) {
+					if password != "password123" {
+						return "", errors.New("invalid credentials")
+					}
+					return "token", nil
+				},
+			}, nil, nil, audit, NewPageTemplates(""), DefaultCookieConfig())
+
+			body, _ := json.Marshal(LoginRequest{Email: "test@example.com", Password: tt.password})
+			req := httptest.NewRequest("POST", "/api/auth/login", bytes.NewReader(body))
+			req.RemoteAddr = "203.0.113.7:5000"
+			req.Header.Set("User-Agent", "curl/8.0")
+			w := httptest.NewRecorder()
+
+			handler.Login(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			want := recordedLogin{"test@example.com", "203.0.113.7", "curl/8.0", tt.wantSuccess}
+			if len(audit.recorded) != 1 || audit.recorded[0] != want {
+				t.Errorf("recorded = %+v, want [%+v]", audit.recorded, want)
+			}
+		})
+	}
+}
+
+func TestWebLogin_UsesResolvedClientIP(t *testing.T) {
+	audit := &mockRecordLoginEventUseCase{}
+	handler := NewAuthHandler(&mockLoginUseCase{}, nil, nil, audit, NewPageTemplates(""), DefaultCookieConfig())
+
+	formData := url.Values{}
+	formData.Set("email", "test@example.com")
+	formData.Set("password", "password123")
+
+	req := htmx(httptest.NewRequest("POST", "/web/auth/login", strings.NewReader(formData.Encode())))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = req.WithContext(context.WithValue(req.Context(), "clientIP", "198.51.100.1"))
+	w := httptest.NewRecorder()
+
+	handler.WebLogin(w, req)
+
+	if len(audit.recorded) != 1 || audit.recorded[0].ip != "198.51.100.1" || !audit.recorded[0].success {
+		t.Errorf("recorded = %+v, want a successful attempt from 198.51.100.1", audit.recorded)
+	}
+}
+
+func TestLogin_AuditFailureDoesNotBlockLogin(t *testing.T) {
+	handler := NewAuthHandler(&mockLoginUseCase{}, nil, nil, &mockRecordLoginEventUseCase{err: errors.New("database is locked")}, NewPageTemplates(""), DefaultCookieConfig())
+
+	body, _ := json.Marshal(LoginRequest{Email: "test@example.com", Password: "password123"})
+	req := httptest.NewRequest("POST", "/api/auth/login", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.Login(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 when the audit fails, got %d", w.Code)
+	}
+}
+
+func TestLogin_TwoFactorRequired(t *testing.T) {
+	audit := &mockRecordLoginEventUseCase{}
+	handler := NewAuthHandler(&mockLoginUseCase{twoFactorToken: "challenge-1"}, nil, nil, audit, NewPageTemplates(""), DefaultCookieConfig())
+
+	body, _ := json.Marshal(LoginRequest{Email: "test@example.com", Password: "password123"})
+	req := httptest.NewRequest("POST", "/api/auth/login", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.Login(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var response map[string]interface{}
+	json.NewDecoder(w.Body).Decode(&response)
+	if response["two_factor_required"] != true || response["two_factor_token"] != "challenge-1" {
+		t.Errorf("response = %v, want the two-factor challenge", response)
+	}
+	if _, ok := response["token"]; ok {
+		t.Error("no session token should be issued before the second factor")
+	}
+	if len(audit.recorded) != 0 {
+		t.Errorf("recorded = %+v, want the login recorded only after the second factor", audit.recorded)
+	}
+}
+
+func TestWebLogin_TwoFactorRequired(t *testing.T) {
+	handler := NewAuthHandler(&mockLoginUseCase{twoFactorToken: "challenge-1"}, nil, nil, nil, NewPageTemplates(""), DefaultCookieConfig())
+
+	formData := url.Values{}
+	formData.Set("email", "test@example.com")
+	formData.Set("password", "password123")
+
+	req := htmx(httptest.NewRequest("POST", "/web/auth/login", strings.NewReader(formData.Encode())))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	handler.WebLogin(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if len(w.Result().Cookies()) != 0 {
+		t.Errorf("cookies = %v, want none before the second factor", w.Result().Cookies())
+	}
+	if w.Header().Get("HX-Redirect") != "" {
+		t.Error("the login should stay on the page for the second factor")
+	}
+	if w.Header().Get("HX-Retarget") != "#login-form" || w.Header().Get("HX-Reswap") != "outerHTML" {
+		t.Errorf("HX-Retarget = %q, HX-Reswap = %q; want the login form replaced", w.Header().Get("HX-Retarget"), w.Header().Get("HX-Reswap"))
+	}
+
+	body := w.Body.String()
+	if !strings.Contains(body, `hx-post="/web/auth/2fa"`) || !strings.Contains(body, `value="challenge-1"`) || !strings.Contains(body, `autocomplete="one-time-code"`) {
+		t.Errorf("body = %s, want the code form carrying the challenge", body)
+	}
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/identicon"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// avatarURLPrefix is the URL path of the avatars, followed by the user ID
+const avatarURLPrefix = "/uploads/avatars/"
+
+// AvatarURL returns the URL of the avatar of a user, which falls back to an identicon
+func AvatarURL(userID string) string {
+	return avatarURLPrefix + url.PathEscape(userID)
+}
+
+// AvatarHandler handles the avatars shown next to user names. Uploaded avatars go through
+// the validation and storage quota of task images and live in the same directory.
+type AvatarHandler struct {
+	images       *UploadHandler
+	getAvatar    usecases.GetUserAvatarUseCaseInterface
+	updateAvatar usecases.UpdateUserAvatarUseCaseInterface
+}
+
+// NewAvatarHandler creates a new AvatarHandler storing avatars with images
+func NewAvatarHandler(images *UploadHandler, getAvatar usecases.GetUserAvatarUseCaseInterface, updateAvatar usecases.UpdateUserAvatarUseCaseInterface) *AvatarHandler {
+	return &AvatarHandler{
+		images:       images,
+		getAvatar:    getAvatar,
+		updateAvatar: updateAvatar,
+	}
+}
+
+// UpdateAvatar handles PUT /api/me/avatar, replacing the avatar of the user with the image
+// sent in the avatar field of a multipart form
+func (h *AvatarHandler) UpdateAvatar(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxFileSize)
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		writeAPIError(w, r, http.StatusBadRequest, "File too large or invalid form data")
+		return
+	}
+
+	file, header, err := r.FormFile("avatar")
+	if err != nil {
+		writeAPIError(w, r, http.StatusBadRequest, "No file uploaded")
+		return
+	}
+	defer file.Close()
+
+	path, err := h.images.SaveImage(r.Context(), userID, file, header)
+	if err != nil {
+		if writeCreationQuotaError(w, r, err) {
+			return
+		}
+		var exceeded *application.StorageQuotaExceededError
+		if errors.As(err, &exceeded) {
+			WriteJSONError(w, r, http.StatusRequestEntityTooLarge, CodeStorageQuotaExceeded, storageQuotaMessage(RequestLocale(r), exceeded))
+			return
+		}
+		writeAPIError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	previous, err := h.updateAvatar.Execute(r.Context(), userID, path)
+	if err != nil {
+		log.Printf("failed to update avatar: %v", err)
+		h.deleteImage(r, path)
+		writeAPIError(w, r, http.StatusInternalServerError, "Failed to update avatar")
+		return
+	}
+	if previous != path {
+		h.deleteImage(r, previous)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"avatar_path": path,
+		"avatar_url":  AvatarURL(userID),
+	})
+}
+
+// ServeAvatar handles GET /uploads/avatars/{id}, serving the avatar of any user to signed-in
+// users, or an identicon drawn from the user ID when none was uploaded. Avatars change under
+// the same URL, so browsers revalidate them on every use.
+func (h *AvatarHandler) ServeAvatar(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("id")
+
+	path, err := h.getAvatar.Execute(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, application.ErrUserNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		log.Printf("failed to look up avatar of user %s: %v", userID, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if path != "" && h.serveImage(w, r, filepath.Base(path)) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Write(identicon.SVG(userID))
+}
+
+// serveImage serves an uploaded avatar, reporting false when its file is gone
+func (h *AvatarHandler) serveImage(w http.ResponseWriter, r *http.Request, name string) bool {
+	fullPath, ok := resolveImage(h.images.uploadDir, name)
+	if !ok {
+		return false
+	}
+
+	f, err := os.Open(fullPath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		return false
+	}
+
+	http.ServeContent(w, r, name, info.ModTime(), f)
+	return true
+}
+
+// deleteImage removes a replaced or unused avatar, logging failures
+func (h *AvatarHandler) deleteImage(r *http.Request, path string) {
+	if err := h.images.DeleteImage(r.Context(), path); err != nil {
+		log.Printf("failed to delete avatar %s: %v", path, err)
+	}
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// mockAvatarUseCases keeps the avatar paths of users in memory
+type mockAvatarUseCases struct {
+	avatars   map[string]string
+	updateErr error
+}
+
+func (m *mockAvatarUseCases) get() *mockGetUserAvatar { return &mockGetUserAvatar{m} }
+
+func (m *mockAvatarUseCases) Execute(ctx context.Context, userID, avatarPath string) (string, error) {
+	if m.updateErr != nil {
+		return "", m.updateErr
+	}
+	previous := m.avatars[userID]
+	m.avatars[userID] = avatarPath
+	return previous, nil
+}
+
+type mockGetUserAvatar struct{ m *mockAvatarUseCases }
+
+func (g *mockGetUserAvatar) Execute(ctx context.Context, userID string) (string, error) {
+	path, ok := g.m.avatars[userID]
+	if !ok {
+		return "", application.ErrUserNotFound
+	}
+	return path, nil
+}
+
+// avatarRequest builds a PUT /api/me/avatar request uploading content as filename
+func avatarRequest(filename string, content []byte) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("avatar", filename)
+	part.Write(content)
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPut, "/api/me/avatar", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+}
+
+var testPNG = append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 100)...)
+
+func TestAvatarHandler_UpdateAvatar(t *testing.T) {
+	dir := t.TempDir()
+	m := &mockAvatarUseCases{avatars: map[string]string{"user-123": "/uploads/images/1_old.png"}}
+	os.WriteFile(filepath.Join(dir, "1_old.png"), testPNG, 0644)
+	h := NewAvatarHandler(NewUploadHandler(dir, nil), m.get(), m)
+
+	w := httptest.NewRecorder()
+	h.UpdateAvatar(w, avatarRequest("me.png", testPNG))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response map[string]string
+	json.NewDecoder(w.Body).Decode(&response)
+	if response["avatar_url"] != "/uploads/avatars/user-123" || response["avatar_path"] != m.avatars["user-123"] {
+		t.Errorf("Unexpected response %v for stored avatar %q", response, m.avatars["user-123"])
+	}
+	if _, err := os.Stat(filepath.Join(dir, filepath.Base(m.avatars["user-123"]))); err != nil {
+		t.Errorf("Expected the new avatar on disk: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "1_old.png")); !os.IsNotExist(err) {
+		t.Error("Expected the replaced avatar to be removed")
+	}
+}
+
+func TestAvatarHandler_UpdateAvatarErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        *http.Request
+		updateErr  error
+		wantStatus int
+	}{
+		{"not an image", avatarRequest("me.png", []byte("not an image")), nil, http.StatusBadRequest},
+		{"not an image extension", avatarRequest("me.svg", testPNG), nil, http.StatusBadRequest},
+		{"no file", avatarRequest("", nil), nil, http.StatusBadRequest},
+		{"update fails", avatarRequest("me.png", testPNG), errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			m := &mockAvatarUseCases{avatars: map[string]string{"user-123": ""}, updateErr: tt.updateErr}
+			h := NewAvatarHandler(NewUploadHandler(dir, nil), m.get(), m)
+
+			w := httptest.NewRecorder()
+			h.UpdateAvatar(w, tt.req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if files, _ := os.ReadDir(dir); len(files) != 0 {
+				t.Errorf("Expected no file left behind, got %d", len(files))
+			}
+		})
+	}
+}
+
+func TestAvatarHandler_ServeAvatar(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "1_ana.png"), testPNG, 0644)
+	m := &mockAvatarUseCases{avatars: map[string]string{
+		"user-ana":  "/uploads/images/1_ana.png",
+		"user-bia":  "",
+		"user-gone": "/uploads/images/2_missing.png",
+	}}
+	h := NewAvatarHandler(NewUploadHandler(dir, nil), m.get(), m)
+
+	tests := []struct {
+		name            string
+		userID          string
+		wantStatus      int
+		wantContentType string
+	}{
+		{"uploaded avatar", "user-ana", http.StatusOK, "image/png"},
+		{"identicon without an avatar", "user-bia", http.StatusOK, "image/svg+xml"},
+		{"identicon when the file is gone", "user-gone", http.StatusOK, "image/svg+xml"},
+		{"unknown user", "user-missing", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/uploads/avatars/"+tt.userID, nil)
+			req.SetPathValue("id", tt.userID)
+
+			w := httptest.NewRecorder()
+			h.ServeAvatar(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantContentType == "" {
+				return
+			}
+			if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, tt.wantContentType) {
+				t.Errorf("Expected Content-Type %s, got %s", tt.wantContentType, got)
+			}
+			if got := w.Header().Get("Cache-Control"); got != "private, no-cache" {
+				t.Errorf("Expected avatars to be revalidated, got Cache-Control %q", got)
+			}
+		})
+	}
+}
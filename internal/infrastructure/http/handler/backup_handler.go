@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// BackupHandler handles the backups requested by admins
+type BackupHandler struct {
+	createBackup usecases.CreateBackupUseCaseInterface
+}
+
+// NewBackupHandler creates a new BackupHandler
+func NewBackupHandler(createBackup usecases.CreateBackupUseCaseInterface) *BackupHandler {
+	return &BackupHandler{
+		createBackup: createBackup,
+	}
+}
+
+// BackupResponse is the JSON representation of a backup, with the names of its files in the
+// backup directory of the server
+type BackupResponse struct {
+	Database  string    `json:"database"`
+	Files     string    `json:"files"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateBackup handles POST /api/admin/backups, backing up the database and the uploaded files
+// while the server runs
+func (h *BackupHandler) CreateBackup(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	backup, err := h.createBackup.Execute(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, usecases.ErrAdminRequired) {
+			writeAPIError(w, r, http.StatusForbidden, err.Error())
+			return
+		}
+		log.Printf("backup failed: %v", err)
+		writeAPIError(w, r, http.StatusInternalServerError, "Failed to create the backup")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(BackupResponse{
+		Database:  filepath.Base(backup.Database),
+		Files:     filepath.Base(backup.Files),
+		CreatedAt: backup.CreatedAt,
+	})
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockCreateBackupUseCase struct {
+	err error
+}
+
+func (m *mockCreateBackupUseCase) Execute(ctx context.Context, adminID string) (*application.Backup, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &application.Backup{
+		Database:  "/var/lib/todo/backups/todo-20261016-150405.db",
+		Files:     "/var/lib/todo/backups/todo-20261016-150405-files.tar.gz",
+		CreatedAt: time.Date(2026, 10, 16, 15, 4, 5, 0, time.UTC),
+	}, nil
+}
+
+func TestBackupHandler_CreateBackup(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "created", wantStatus: http.StatusCreated},
+		{name: "not an admin", err: usecases.ErrAdminRequired, wantStatus: http.StatusForbidden},
+		{name: "failure", err: errors.New("disk full"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewBackupHandler(&mockCreateBackupUseCase{err: tt.err})
+			req := withUser(httptest.NewRequest("POST", "/api/admin/backups", nil))
+			w := httptest.NewRecorder()
+
+			h.CreateBackup(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+			var response BackupResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			// Only the names are sent, not where the server keeps them
+			if response.Database != "todo-20261016-150405.db" || response.Files != "todo-20261016-150405-files.tar.gz" {
+				t.Errorf("Unexpected response %+v", response)
+			}
+		})
+	}
+}
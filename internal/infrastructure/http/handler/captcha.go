@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+)
+
+// CaptchaTokenHeader carries the answer to a CAPTCHA challenge sent by API clients. Web forms
+// post it in the field of the provider's widget instead.
+const CaptchaTokenHeader = "X-Captcha-Token"
+
+// CaptchaWidget holds what a client needs to render the widget of a CAPTCHA provider
+type CaptchaWidget struct {
+	Provider  string // Name of the provider, told to API clients
+	SiteKey   string
+	ScriptURL string // Widget script for explicit rendering, calling window.onCaptchaLoad once loaded
+	Global    string // Object the script defines, with render(element, {sitekey}) and reset(id)
+}
+
+// captchaChallengeTemplates are the templates for the challenge filling the #captcha-challenge
+// slot of the login form, with its message swapped into the error container. The widget is
+// rendered explicitly, as an earlier challenge may have loaded the script already; a token
+// answers a single attempt, so the widget is reset once the form is sent.
+var captchaChallengeTemplates = localizedTemplates("captchaChallenge", `<div id="captcha-challenge" class="flex justify-center">
+		<div data-captcha-widget></div>
+		<script>
+			(function () {
+				var container = document.querySelector("#captcha-challenge [data-captcha-widget]");
+				window.onCaptchaLoad = function () {
+					var captcha = window[{{.Global}}];
+					var widget = captcha.render(container, {sitekey: {{.SiteKey}}});
+					container.closest("form").addEventListener("htmx:afterRequest", function () {
+						if (document.body.contains(container)) {
+							captcha.reset(widget);
+						}
+					});
+				};
+				if (window[{{.Global}}]) {
+					window.onCaptchaLoad();
+					return;
+				}
+				var script = document.createElement("script");
+				script.src = {{.ScriptURL}};
+				script.async = true;
+				document.head.appendChild(script);
+			})();
+		</script>
+	</div>
+	<div id="error-message" hx-swap-oob="innerHTML"><div class="bg-red-100 border border-red-400 text-red-700 px-4 py-3 rounded" role="alert">{{translateError .Message}}</div></div>`)
+
+// WriteCaptchaChallenge answers a web form sent without the solved CAPTCHA it needs, message
+// telling why. HTMX forms get the widget in their #captcha-challenge slot; without HTMX the
+// widget can't post its answer, so only the message is shown.
+func (p *PageTemplates) WriteCaptchaChallenge(w http.ResponseWriter, r *http.Request, status int, widget CaptchaWidget, message string) {
+	w.Header().Set("Cache-Control", "no-store")
+
+	locale := RequestLocale(r)
+	if !IsHTMX(r) {
+		p.writeWebError(w, r, status, i18n.Error(locale, message))
+		return
+	}
+
+	var buf bytes.Buffer
+	err := localizedTemplate(captchaChallengeTemplates, locale).Execute(&buf, map[string]interface{}{
+		"Global":    widget.Global,
+		"SiteKey":   widget.SiteKey,
+		"ScriptURL": widget.ScriptURL,
+		"Message":   message,
+	})
+	if err != nil {
+		log.Printf("failed to render the CAPTCHA challenge: %v", err)
+		p.writeWebError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.Header().Set("HX-Retarget", "#captcha-challenge")
+	w.Header().Set("HX-Reswap", "outerHTML")
+	p.writeWebFragment(w, r, status, buf.String())
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWriteCaptchaChallenge(t *testing.T) {
+	widget := CaptchaWidget{Provider: "turnstile", SiteKey: "site-key", ScriptURL: "https://challenges.cloudflare.com/turnstile/v0/api.js", Global: "turnstile"}
+
+	tests := []struct {
+		name         string
+		htmx         bool
+		wantRetarget string
+		wantBody     []string
+		notWantBody  []string
+	}{
+		{
+			name:         "HTMX form gets the widget",
+			htmx:         true,
+			wantRetarget: "#captcha-challenge",
+			wantBody:     []string{`id="captcha-challenge"`, `window["turnstile"]`, `sitekey: "site-key"`, "Muitas tentativas sem sucesso"},
+		},
+		{
+			name:        "without HTMX only the message is shown",
+			wantBody:    []string{"<!DOCTYPE html>", "Muitas tentativas sem sucesso"},
+			notWantBody: []string{"data-captcha-widget"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/web/auth/login", nil)
+			if tt.htmx {
+				req.Header.Set("HX-Request", "true")
+			}
+			w := httptest.NewRecorder()
+
+			NewPageTemplates("").WriteCaptchaChallenge(w, req, http.StatusPreconditionRequired, widget, "Too many failed attempts: solve the CAPTCHA to try again")
+
+			if w.Code != http.StatusPreconditionRequired {
+				t.Errorf("Expected status 428, got %d", w.Code)
+			}
+			if got := w.Header().Get("HX-Retarget"); got != tt.wantRetarget {
+				t.Errorf("Expected HX-Retarget %q, got %q", tt.wantRetarget, got)
+			}
+			body := w.Body.String()
+			for _, want := range tt.wantBody {
+				if !strings.Contains(body, want) {
+					t.Errorf("Expected %q in the body, got %s", want, body)
+				}
+			}
+			for _, notWant := range tt.notWantBody {
+				if strings.Contains(body, notWant) {
+					t.Errorf("Expected no %q in the body", notWant)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// maxCommentBodySize limits the body of a comment request, room for the longest comment
+const maxCommentBodySize = 16 << 10 // 16KB
+
+// CommentHandler handles HTTP requests for task comments
+type CommentHandler struct {
+	addComment   usecases.AddCommentUseCaseInterface
+	listComments usecases.ListCommentsUseCaseInterface
+}
+
+// NewCommentHandler creates a new CommentHandler
+func NewCommentHandler(
+	addComment usecases.AddCommentUseCaseInterface,
+	listComments usecases.ListCommentsUseCaseInterface,
+) *CommentHandler {
+	return &CommentHandler{
+		addComment:   addComment,
+		listComments: listComments,
+	}
+}
+
+// AddCommentRequest is the body of POST /api/tasks/{id}/comments
+type AddCommentRequest struct {
+	Body string `json:"body"`
+}
+
+// CommentResponse is the JSON representation of a comment. Mentions holds the IDs of the
+// users it mentioned and notified.
+type CommentResponse struct {
+	ID        string    `json:"id"`
+	TaskID    string    `json:"task_id"`
+	UserID    string    `json:"user_id"`
+	Body      string    `json:"body"`
+	Mentions  []string  `json:"mentions"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toCommentResponse(comment *application.Comment) CommentResponse {
+	mentions := comment.Mentions
+	if mentions == nil {
+		mentions = []string{}
+	}
+	return CommentResponse{
+		ID:        comment.ID,
+		TaskID:    comment.TaskID,
+		UserID:    comment.UserID,
+		Body:      comment.Body,
+		Mentions:  mentions,
+		CreatedAt: comment.CreatedAt,
+	}
+}
+
+// AddComment handles POST /api/tasks/{id}/comments
+func (h *CommentHandler) AddComment(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req AddCommentRequest
+	if !decodeJSON(w, r, &req, maxCommentBodySize) {
+		return
+	}
+
+	comment, err := h.addComment.Execute(r.Context(), r.PathValue("id"), userID, req.Body)
+	if err != nil {
+		status, message := commentErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toCommentResponse(comment))
+}
+
+// ListComments handles GET /api/tasks/{id}/comments
+func (h *CommentHandler) ListComments(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	comments, err := h.listComments.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		status, message := commentErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	response := make([]CommentResponse, 0, len(comments))
+	for _, comment := range comments {
+		response = append(response, toCommentResponse(comment))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// commentErrorStatus maps a comment use case error to an HTTP status and client message
+func commentErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecases.ErrTaskUnavailable):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, application.ErrCommentEmpty), errors.Is(err, application.ErrCommentTooLong):
+		return http.StatusBadRequest, err.Error()
+	default:
+		log.Printf("comment operation failed: %v", err)
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockAddCommentUseCase struct {
+	body string
+	err  error
+}
+
+func (m *mockAddCommentUseCase) Execute(ctx context.Context, taskID, userID, body string) (*application.Comment, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.body = body
+	return &application.Comment{ID: "comment-1", TaskID: taskID, UserID: userID, Body: body, Mentions: []string{"user-2"}}, nil
+}
+
+type mockListCommentsUseCase struct {
+	comments []*application.Comment
+	err      error
+}
+
+func (m *mockListCommentsUseCase) Execute(ctx context.Context, taskID, userID string) ([]*application.Comment, error) {
+	return m.comments, m.err
+}
+
+func newCommentRequest(method, body string) *http.Request {
+	req := httptest.NewRequest(method, "/api/tasks/task-1/comments", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", "task-1")
+	return withUser(req)
+}
+
+func TestAddComment(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"valid comment", `{"body": "@bia pode ver?"}`, nil, http.StatusCreated, ""},
+		{"invalid body", `{`, nil, http.StatusBadRequest, CodeInvalidBody},
+		{"blank comment", `{"body": " "}`, application.ErrCommentEmpty, http.StatusBadRequest, "bad_request"},
+		{"comment too long", `{"body": "..."}`, application.ErrCommentTooLong, http.StatusBadRequest, "bad_request"},
+		{"task not visible", `{"body": "oi"}`, usecases.ErrTaskUnavailable, http.StatusNotFound, "not_found"},
+		{"failure", `{"body": "oi"}`, errors.New("db down"), http.StatusInternalServerError, "internal_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			add := &mockAddCommentUseCase{err: tt.err}
+			h := NewCommentHandler(add, &mockListCommentsUseCase{})
+
+			w := httptest.NewRecorder()
+			h.AddComment(w, newCommentRequest("POST", tt.body))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantCode != "" {
+				var resp ErrorResponse
+				if err := json.NewDecoder(w.Body).Decode(&resp); err != nil || resp.Error.Code != tt.wantCode {
+					t.Errorf("error code = %q (%v), want %q", resp.Error.Code, err, tt.wantCode)
+				}
+				return
+			}
+
+			var resp CommentResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("invalid response: %v", err)
+			}
+			if resp.ID != "comment-1" || resp.TaskID != "task-1" || resp.Body != "@bia pode ver?" || add.body != "@bia pode ver?" || len(resp.Mentions) != 1 {
+				t.Errorf("response = %+v", resp)
+			}
+		})
+	}
+}
+
+func TestListComments(t *testing.T) {
+	list := &mockListCommentsUseCase{comments: []*application.Comment{
+		{ID: "comment-1", TaskID: "task-1", Body: "oi @bia", Mentions: []string{"user-2"}},
+		{ID: "comment-2", TaskID: "task-1", Body: "oi"},
+	}}
+	h := NewCommentHandler(&mockAddCommentUseCase{}, list)
+
+	w := httptest.NewRecorder()
+	h.ListComments(w, newCommentRequest("GET", ""))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	var raw []map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&raw); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if len(raw) != 2 || len(raw[0]["mentions"].([]interface{})) != 1 || len(raw[1]["mentions"].([]interface{})) != 0 {
+		t.Errorf("response = %v", raw)
+	}
+
+	list.err = usecases.ErrTaskUnavailable
+	w = httptest.NewRecorder()
+	h.ListComments(w, newCommentRequest("GET", ""))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404 for a task the user can't see", w.Code)
+	}
+}
@@ -1,46 +1,216 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
-	"os"
+	"strings"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
 )
 
 const (
-	// AuthCookieName is the name of the authentication cookie
+	// AuthCookieName is the default name of the authentication cookie
 	AuthCookieName = "auth_token"
 
-	// AuthCookieMaxAge is the max age of the auth cookie in seconds (24 hours)
-	AuthCookieMaxAge = 86400
+	// ThemeCookieName is the name of the cookie remembering the theme on pages without a session
+	ThemeCookieName = "theme"
+
+	// ThemeCookieMaxAge is the max age of the theme cookie in seconds (1 year)
+	ThemeCookieMaxAge = 365 * 86400
+
+	// LocaleCookieName is the name of the cookie remembering the language chosen in the navbar
+	LocaleCookieName = "lang"
+
+	// OrgCookieName is the name of the cookie remembering the workspace picked in the navbar
+	OrgCookieName = "org"
+
+	// PasskeySessionCookieName is the name of the cookie linking the two steps of a passkey ceremony
+	PasskeySessionCookieName = "webauthn_session"
+
+	// passkeySessionCookiePath limits the passkey session cookie to the ceremony endpoints
+	passkeySessionCookiePath = "/api/auth/webauthn/"
+
+	// passkeySessionCookieMaxAge is the max age of the passkey session cookie in seconds; the
+	// ceremony session itself expires on the server
+	passkeySessionCookieMaxAge = 10 * 60
 )
 
-// isProduction checks if the application is running in production mode
-func isProduction() bool {
-	env := os.Getenv("ENV")
-	return env == "production" || env == "prod"
+// hostCookiePrefix makes browsers accept a cookie only when it's Secure, on path / and without
+// a Domain, so a subdomain can't set or shadow it
+const hostCookiePrefix = "__Host-"
+
+// CookieConfig sets the attributes of the cookies the app sends
+type CookieConfig struct {
+	Name       string        // name of the auth cookie, before the __Host- prefix
+	Secure     bool          // send the cookies only over HTTPS
+	SameSite   http.SameSite // of the auth, theme and language cookies; the passkey session cookie is always Strict
+	Domain     string        // shares the cookies with the subdomains of Domain; empty keeps them on the host
+	HostPrefix bool          // prefixes the auth cookie name with __Host-
+}
+
+// DefaultCookieConfig returns the cookie configuration suited to local development over plain HTTP
+func DefaultCookieConfig() CookieConfig {
+	return CookieConfig{Name: AuthCookieName, SameSite: http.SameSiteLaxMode}
+}
+
+// Validate checks the combinations browsers would reject or that would weaken the cookies
+func (c CookieConfig) Validate() error {
+	if c.Name == "" || strings.ContainsAny(c.Name, " \t\r\n;,=\"") {
+		return fmt.Errorf("invalid cookie name %q", c.Name)
+	}
+	if c.SameSite == http.SameSiteNoneMode && !c.Secure {
+		return errors.New("SameSite=None cookies must be Secure")
+	}
+	if c.HostPrefix && (!c.Secure || c.Domain != "") {
+		return errors.New("__Host- cookies must be Secure and have no Domain")
+	}
+	return nil
+}
+
+// AuthCookieName returns the name of the auth cookie, with the __Host- prefix when enabled
+func (c CookieConfig) AuthCookieName() string {
+	if c.HostPrefix {
+		return hostCookiePrefix + c.Name
+	}
+	return c.Name
+}
+
+// ParseSameSite parses a SameSite mode: lax, strict or none
+func ParseSameSite(mode string) (http.SameSite, error) {
+	switch strings.ToLower(mode) {
+	case "lax":
+		return http.SameSiteLaxMode, nil
+	case "strict":
+		return http.SameSiteStrictMode, nil
+	case "none":
+		return http.SameSiteNoneMode, nil
+	}
+	return 0, fmt.Errorf("invalid SameSite mode %q: must be lax, strict or none", mode)
 }
 
-// createAuthCookie creates a secure authentication cookie
-func createAuthCookie(token string) *http.Cookie {
+// createAuthCookie creates a secure authentication cookie that lives as long as its token
+func (c CookieConfig) createAuthCookie(token string, maxAge time.Duration) *http.Cookie {
 	return &http.Cookie{
-		Name:     AuthCookieName,
+		Name:     c.AuthCookieName(),
 		Value:    token,
 		Path:     "/",
+		Domain:   c.Domain,
 		HttpOnly: true,
-		Secure:   isProduction(), // Only send over HTTPS in production
-		SameSite: http.SameSiteLaxMode,
-		MaxAge:   AuthCookieMaxAge,
+		Secure:   c.Secure,
+		SameSite: c.SameSite,
+		MaxAge:   int(maxAge.Seconds()),
 	}
 }
 
 // deleteAuthCookie creates a cookie that deletes the auth cookie
-func deleteAuthCookie() *http.Cookie {
+func (c CookieConfig) deleteAuthCookie() *http.Cookie {
 	return &http.Cookie{
-		Name:     AuthCookieName,
+		Name:     c.AuthCookieName(),
 		Value:    "",
 		Path:     "/",
+		Domain:   c.Domain,
 		HttpOnly: true,
-		Secure:   isProduction(),
-		SameSite: http.SameSiteLaxMode,
+		Secure:   c.Secure,
+		SameSite: c.SameSite,
 		MaxAge:   -1, // Delete cookie
 	}
 }
+
+// createThemeCookie creates the theme cookie. It isn't HttpOnly because the navbar toggle
+// also updates it on pages without a session.
+func (c CookieConfig) createThemeCookie(theme application.Theme) *http.Cookie {
+	return &http.Cookie{
+		Name:     ThemeCookieName,
+		Value:    string(theme),
+		Path:     "/",
+		Domain:   c.Domain,
+		Secure:   c.Secure,
+		SameSite: c.SameSite,
+		MaxAge:   ThemeCookieMaxAge,
+	}
+}
+
+// ThemeFromRequest returns the theme stored in the theme cookie, defaulting to light
+func ThemeFromRequest(r *http.Request) application.Theme {
+	cookie, err := r.Cookie(ThemeCookieName)
+	if err != nil {
+		return application.ThemeLight
+	}
+
+	theme, err := application.ParseTheme(cookie.Value)
+	if err != nil {
+		return application.ThemeLight
+	}
+	return theme
+}
+
+// createLocaleCookie creates the language cookie. Like the theme cookie, it isn't HttpOnly
+// because the navbar selector also sets it on pages without a session.
+func (c CookieConfig) createLocaleCookie(locale application.Locale) *http.Cookie {
+	return &http.Cookie{
+		Name:     LocaleCookieName,
+		Value:    string(locale),
+		Path:     "/",
+		Domain:   c.Domain,
+		Secure:   c.Secure,
+		SameSite: c.SameSite,
+		MaxAge:   ThemeCookieMaxAge,
+	}
+}
+
+// createOrgCookie creates the workspace cookie; an empty orgID selects the personal tasks
+func (c CookieConfig) createOrgCookie(orgID string) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     OrgCookieName,
+		Value:    orgID,
+		Path:     "/",
+		Domain:   c.Domain,
+		HttpOnly: true,
+		Secure:   c.Secure,
+		SameSite: c.SameSite,
+		MaxAge:   ThemeCookieMaxAge,
+	}
+	if orgID == "" {
+		cookie.MaxAge = -1 // Delete cookie
+	}
+	return cookie
+}
+
+// OrgFromRequest returns the org stored in the workspace cookie, or "" for the personal tasks
+func OrgFromRequest(r *http.Request) string {
+	cookie, err := r.Cookie(OrgCookieName)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// createPasskeySessionCookie creates the cookie holding the session ID of a passkey ceremony
+func (c CookieConfig) createPasskeySessionCookie(sessionID string) *http.Cookie {
+	return &http.Cookie{
+		Name:     PasskeySessionCookieName,
+		Value:    sessionID,
+		Path:     passkeySessionCookiePath,
+		Domain:   c.Domain,
+		HttpOnly: true,
+		Secure:   c.Secure,
+		SameSite: http.SameSiteStrictMode,
+		MaxAge:   passkeySessionCookieMaxAge,
+	}
+}
+
+// deletePasskeySessionCookie creates a cookie that deletes the passkey session cookie
+func (c CookieConfig) deletePasskeySessionCookie() *http.Cookie {
+	return &http.Cookie{
+		Name:     PasskeySessionCookieName,
+		Value:    "",
+		Path:     passkeySessionCookiePath,
+		Domain:   c.Domain,
+		HttpOnly: true,
+		Secure:   c.Secure,
+		SameSite: http.SameSiteStrictMode,
+		MaxAge:   -1,
+	}
+}
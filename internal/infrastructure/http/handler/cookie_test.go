@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCookieConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  CookieConfig
+		wantErr bool
+	}{
+		{"default", CookieConfig{Name: AuthCookieName, SameSite: http.SameSiteLaxMode}, false},
+		{"host prefix", CookieConfig{Name: "session", Secure: true, SameSite: http.SameSiteStrictMode, HostPrefix: true}, false},
+		{"domain", CookieConfig{Name: "session", Secure: true, SameSite: http.SameSiteLaxMode, Domain: "example.com"}, false},
+		{"no name", CookieConfig{SameSite: http.SameSiteLaxMode}, true},
+		{"name with a separator", CookieConfig{Name: "auth;token", SameSite: http.SameSiteLaxMode}, true},
+		{"SameSite=None over HTTP", CookieConfig{Name: "session", SameSite: http.SameSiteNoneMode}, true},
+		{"host prefix over HTTP", CookieConfig{Name: "session", SameSite: http.SameSiteLaxMode, HostPrefix: true}, true},
+		{"host prefix with a domain", CookieConfig{Name: "session", Secure: true, Domain: "example.com", HostPrefix: true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateAuthCookie_UsesCookieConfig(t *testing.T) {
+	c := CookieConfig{Name: "session", Secure: true, SameSite: http.SameSiteStrictMode, HostPrefix: true}
+
+	cookie := c.createAuthCookie("token", time.Hour)
+	if cookie.Name != "__Host-session" || !cookie.Secure || cookie.SameSite != http.SameSiteStrictMode || cookie.Path != "/" || cookie.Domain != "" || !cookie.HttpOnly {
+		t.Errorf("auth cookie = %+v, want a Secure, Strict, host-only __Host-session cookie", cookie)
+	}
+	if deleted := c.deleteAuthCookie(); deleted.Name != cookie.Name || deleted.MaxAge != -1 {
+		t.Errorf("deleting cookie = %+v, want %s expired", deleted, cookie.Name)
+	}
+
+	c = CookieConfig{Name: "session", Secure: true, SameSite: http.SameSiteLaxMode, Domain: "example.com"}
+	for _, cookie := range []*http.Cookie{c.createAuthCookie("token", time.Hour), c.createThemeCookie("dark"), c.createLocaleCookie("en"), c.createPasskeySessionCookie("s")} {
+		if cookie.Domain != "example.com" || !cookie.Secure {
+			t.Errorf("cookie %s = %+v, want Secure on example.com", cookie.Name, cookie)
+		}
+	}
+}
+
+func TestParseSameSite(t *testing.T) {
+	tests := []struct {
+		mode    string
+		want    http.SameSite
+		wantErr bool
+	}{
+		{"lax", http.SameSiteLaxMode, false},
+		{"Strict", http.SameSiteStrictMode, false},
+		{"none", http.SameSiteNoneMode, false},
+		{"always", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			got, err := ParseSameSite(tt.mode)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseSameSite(%q) = %v, %v; want %v", tt.mode, got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// CountersHandler handles the task counters shown in the navbar
+type CountersHandler struct {
+	getCounters usecases.GetTaskCountersUseCaseInterface
+	pages       *PageTemplates
+}
+
+// NewCountersHandler creates a new CountersHandler
+func NewCountersHandler(getCounters usecases.GetTaskCountersUseCaseInterface, pages *PageTemplates) *CountersHandler {
+	return &CountersHandler{
+		getCounters: getCounters,
+		pages:       pages,
+	}
+}
+
+// WebCounters handles GET /web/fragments/counters, rendering the pending, overdue and shared
+// task counts the navbar polls for
+func (h *CountersHandler) WebCounters(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	counters, err := h.getCounters.Execute(r.Context(), userID)
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Failed to count tasks")
+		return
+	}
+
+	fragment, err := renderTaskCounters(counters, RequestLocale(r))
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	h.pages.writeWebFragment(w, r, http.StatusOK, fragment)
+}
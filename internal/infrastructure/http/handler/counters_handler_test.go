@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+type mockGetTaskCountersUseCase struct {
+	counters *application.TaskCounters
+	err      error
+	userID   string
+}
+
+func (m *mockGetTaskCountersUseCase) Execute(ctx context.Context, userID string) (*application.TaskCounters, error) {
+	m.userID = userID
+	return m.counters, m.err
+}
+
+func TestCountersHandler_WebCounters(t *testing.T) {
+	tests := []struct {
+		name       string
+		counters   *application.TaskCounters
+		err        error
+		wantStatus int
+		want       []string
+		notWant    []string
+	}{
+		{
+			name:       "renders the counts",
+			counters:   &application.TaskCounters{Pending: 5, Overdue: 2, Shared: 3},
+			wantStatus: http.StatusOK,
+			want:       []string{`aria-label="Contadores de tarefas: 5 pendentes, 2 atrasadas, 3 compartilhadas com você"`, `>5</span>`, `>2</span>`, `>3</span>`, "bg-red-100"},
+		},
+		{
+			name:       "overdue stands out only when there are overdue tasks",
+			counters:   &application.TaskCounters{Pending: 1},
+			wantStatus: http.StatusOK,
+			want:       []string{`>1</span>`, `>0</span>`},
+			notWant:    []string{"bg-red-100"},
+		},
+		{
+			name:       "counting fails",
+			err:        errors.New("database error"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getCounters := &mockGetTaskCountersUseCase{counters: tt.counters, err: tt.err}
+			h := NewCountersHandler(getCounters, NewPageTemplates(""))
+
+			w := httptest.NewRecorder()
+			h.WebCounters(w, withUser(htmx(httptest.NewRequest("GET", "/web/fragments/counters", nil))))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if getCounters.userID != "user-123" {
+				t.Errorf("Expected counters of user-123, got %q", getCounters.userID)
+			}
+			body := w.Body.String()
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("body does not contain %q:\n%s", want, body)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(body, notWant) {
+					t.Errorf("body contains %q:\n%s", notWant, body)
+				}
+			}
+		})
+	}
+}
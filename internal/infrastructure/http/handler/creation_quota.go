@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+)
+
+// creationQuotaExceeded returns the creation quota err is about, setting Retry-After to when
+// creating will be allowed again. It returns nil when err is about something else.
+func creationQuotaExceeded(w http.ResponseWriter, err error) *application.CreationQuotaExceededError {
+	var exceeded *application.CreationQuotaExceededError
+	if !errors.As(err, &exceeded) {
+		return nil
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(int(exceeded.RetryAfter(time.Now()).Seconds())))
+	return exceeded
+}
+
+// writeCreationQuotaError answers 429 to an API request that would take the user over a
+// creation quota, returning false when err is about something else
+func writeCreationQuotaError(w http.ResponseWriter, r *http.Request, err error) bool {
+	exceeded := creationQuotaExceeded(w, err)
+	if exceeded == nil {
+		return false
+	}
+	WriteJSONError(w, r, http.StatusTooManyRequests, CodeCreationQuotaExceeded, creationQuotaMessage(RequestLocale(r), exceeded))
+	return true
+}
+
+// creationQuotaMessage explains the creation quota a user reached and when it frees up
+func creationQuotaMessage(locale application.Locale, exceeded *application.CreationQuotaExceededError) string {
+	wait := exceeded.RetryAfter(time.Now())
+	var when string
+	switch {
+	case wait > time.Hour:
+		when = i18n.T(locale, "duration.hours", int(math.Ceil(wait.Hours())))
+	default:
+		when = i18n.T(locale, "duration.minutes", max(int(math.Ceil(wait.Minutes())), 1))
+	}
+
+	if exceeded.Kind == application.CreationImage {
+		return i18n.T(locale, "quota.images_exceeded", exceeded.Limit, when)
+	}
+	return i18n.T(locale, "quota.tasks_exceeded", exceeded.Limit, when)
+}
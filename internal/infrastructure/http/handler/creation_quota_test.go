@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// taskQuotaReached creates no task, the daily task quota of the user being used up
+var taskQuotaReached = &mockCreateTaskUseCase{
+	executeFunc: func(ctx context.Context, title, description, ownerID, imagePath string, dueDate *time.Time) (*application.Task, error) {
+		return nil, &application.CreationQuotaExceededError{
+			Kind:    application.CreationTask,
+			Limit:   1000,
+			Window:  24 * time.Hour,
+			RetryAt: time.Now().Add(3*time.Hour - time.Minute),
+		}
+	},
+}
+
+func TestCreateTask_CreationQuotaExceeded(t *testing.T) {
+	handler := NewTaskHandler(taskQuotaReached, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+
+	req := httptest.NewRequest("POST", "/api/tasks", strings.NewReader(`{"title":"Mais uma"}`))
+	req.Header.Set("Accept-Language", "pt-BR")
+	w := httptest.NewRecorder()
+	handler.CreateTask(w, withUser(req))
+
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") == "" {
+		t.Fatalf("status = %d, Retry-After = %q; want 429 with Retry-After", w.Code, w.Header().Get("Retry-After"))
+	}
+	var resp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Limite de 1000 novas tarefas por dia atingido. Novas tarefas serão liberadas em 3 horas."; resp.Error.Code != CodeCreationQuotaExceeded || resp.Error.Message != want {
+		t.Errorf("error = %+v, want %s: %q", resp.Error, CodeCreationQuotaExceeded, want)
+	}
+}
+
+func TestWebCreateTask_CreationQuotaExceeded(t *testing.T) {
+	handler := NewWebTaskHandler(taskQuotaReached, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
+
+	req := httptest.NewRequest("POST", "/web/tasks", strings.NewReader("title=Mais+uma"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	handler.CreateTask(w, htmx(withUser(req)))
+
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("HX-Retarget") != "#create-task-errors" {
+		t.Fatalf("status = %d, HX-Retarget = %q; want 429 in the form errors", w.Code, w.Header().Get("HX-Retarget"))
+	}
+	if !strings.Contains(w.Body.String(), "novas tarefas por dia") {
+		t.Errorf("expected the quota message: %s", w.Body.String())
+	}
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxJSONBodySize caps the API request bodies of the task and auth routes
+const maxJSONBodySize = 64 << 10 // 64KB
+
+// errTrailingData rejects a body with more than one JSON value
+var errTrailingData = errors.New("trailing data after the JSON value")
+
+// decodeJSON decodes a request body of at most limit bytes into v, writing the error
+// response when it can't: 413 past the limit, 400 for malformed JSON, fields v doesn't
+// have and anything after the first JSON value
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any, limit int64) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, limit)
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(v)
+	if err == nil {
+		// A second value, or garbage after the first one, means the client sent something else
+		if _, err = decoder.Token(); err == io.EOF {
+			err = nil
+		} else if !isTooLarge(err) {
+			err = errTrailingData
+		}
+	}
+
+	switch {
+	case err == nil:
+		return true
+	case isTooLarge(err):
+		writeAPIError(w, r, http.StatusRequestEntityTooLarge, "Request body too large")
+	case errors.Is(err, errTrailingData):
+		WriteJSONError(w, r, http.StatusBadRequest, CodeTrailingData, "Request body must contain a single JSON value")
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json only signals a field missing from v through the message
+		WriteJSONError(w, r, http.StatusBadRequest, CodeUnknownField, "Request body has unknown fields")
+	default:
+		WriteJSONError(w, r, http.StatusBadRequest, CodeInvalidBody, "Invalid request body")
+	}
+	return false
+}
+
+// isTooLarge reports whether err comes from reading past the body limit
+func isTooLarge(err error) bool {
+	var tooLarge *http.MaxBytesError
+	return errors.As(err, &tooLarge)
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantOK     bool
+		wantStatus int
+		wantCode   string
+	}{
+		{"valid body", `{"title": "Comprar pão", "description": "integral"}`, true, http.StatusOK, ""},
+		{"trailing whitespace", "{\"title\": \"Comprar pão\"}\n", true, http.StatusOK, ""},
+		{"unknown field", `{"title": "Comprar pão", "owner_id": "user-2"}`, false, http.StatusBadRequest, CodeUnknownField},
+		{"second value", `{"title": "a"}{"title": "b"}`, false, http.StatusBadRequest, CodeTrailingData},
+		{"trailing garbage", `{"title": "a"} x`, false, http.StatusBadRequest, CodeTrailingData},
+		{"malformed", `{"title": `, false, http.StatusBadRequest, CodeInvalidBody},
+		{"wrong type", `{"title": 1}`, false, http.StatusBadRequest, CodeInvalidBody},
+		{"empty body", ``, false, http.StatusBadRequest, CodeInvalidBody},
+		{"too large", `{"title": "` + strings.Repeat("a", 80) + `"}`, false, http.StatusRequestEntityTooLarge, "payload_too_large"},
+		{"too large after the value", `{"title": "a"}` + strings.Repeat(" ", 80) + `{}`, false, http.StatusRequestEntityTooLarge, "payload_too_large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/tasks", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			var v struct {
+				Title       string `json:"title"`
+				Description string `json:"description"`
+			}
+			if ok := decodeJSON(w, req, &v, 64); ok != tt.wantOK {
+				t.Fatalf("decodeJSON() = %v, want %v (%s)", ok, tt.wantOK, w.Body.String())
+			}
+			if tt.wantOK {
+				if v.Title != "Comprar pão" {
+					t.Errorf("decoded title = %q", v.Title)
+				}
+				return
+			}
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			var resp ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("error body %q: %v", w.Body.String(), err)
+			}
+			if resp.Error.Code != tt.wantCode {
+				t.Errorf("error code = %q, want %q", resp.Error.Code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// maxDependencyBodySize limits the body of a dependency request, which only carries a task ID
+const maxDependencyBodySize = 4 << 10 // 4KB
+
+// DependencyHandler handles HTTP requests for task dependencies
+type DependencyHandler struct {
+	addDependency    usecases.AddDependencyUseCaseInterface
+	listDependencies usecases.ListDependenciesUseCaseInterface
+	removeDependency usecases.RemoveDependencyUseCaseInterface
+}
+
+// NewDependencyHandler creates a new DependencyHandler
+func NewDependencyHandler(
+	addDependency usecases.AddDependencyUseCaseInterface,
+	listDependencies usecases.ListDependenciesUseCaseInterface,
+	removeDependency usecases.RemoveDependencyUseCaseInterface,
+) *DependencyHandler {
+	return &DependencyHandler{
+		addDependency:    addDependency,
+		listDependencies: listDependencies,
+		removeDependency: removeDependency,
+	}
+}
+
+// AddDependencyRequest is the body of POST /api/tasks/{id}/dependencies
+type AddDependencyRequest struct {
+	BlockerID string `json:"blocker_id"`
+}
+
+// BlockerResponse is the JSON representation of a task blocking another
+type BlockerResponse struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+}
+
+func toBlockerResponses(blockers []*application.Task) []BlockerResponse {
+	response := make([]BlockerResponse, 0, len(blockers))
+	for _, blocker := range blockers {
+		response = append(response, BlockerResponse{
+			ID:     blocker.ID,
+			Title:  blocker.Title,
+			Status: string(blocker.Status),
+		})
+	}
+	return response
+}
+
+// AddDependency handles POST /api/tasks/{id}/dependencies
+func (h *DependencyHandler) AddDependency(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req AddDependencyRequest
+	if !decodeJSON(w, r, &req, maxDependencyBodySize) {
+		return
+	}
+	if req.BlockerID == "" {
+		writeAPIError(w, r, http.StatusBadRequest, "blocker_id is required")
+		return
+	}
+
+	blockers, err := h.addDependency.Execute(r.Context(), r.PathValue("id"), req.BlockerID, userID)
+	if err != nil {
+		status, message := dependencyErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toBlockerResponses(blockers))
+}
+
+// ListDependencies handles GET /api/tasks/{id}/dependencies
+func (h *DependencyHandler) ListDependencies(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	blockers, err := h.listDependencies.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		status, message := dependencyErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toBlockerResponses(blockers))
+}
+
+// RemoveDependency handles DELETE /api/tasks/{id}/dependencies/{blockerID}
+func (h *DependencyHandler) RemoveDependency(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := h.removeDependency.Execute(r.Context(), r.PathValue("id"), r.PathValue("blockerID"), userID); err != nil {
+		status, message := dependencyErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// dependencyErrorStatus maps a dependency use case error to an HTTP status and client message
+func dependencyErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecases.ErrTaskUnavailable), errors.Is(err, application.ErrDependencyNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, usecases.ErrDependencyPermissionDenied):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, application.ErrDependencyCycle), errors.Is(err, application.ErrTooManyBlockers):
+		return http.StatusConflict, err.Error()
+	default:
+		log.Printf("dependency operation failed: %v", err)
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockAddDependencyUseCase struct {
+	blockerID string
+	err       error
+}
+
+func (m *mockAddDependencyUseCase) Execute(ctx context.Context, taskID, blockerID, userID string) ([]*application.Task, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.blockerID = blockerID
+	return []*application.Task{{ID: blockerID, Title: "Aprovar orçamento", Status: application.StatusPending}}, nil
+}
+
+type mockListDependenciesUseCase struct {
+	blockers []*application.Task
+}
+
+func (m *mockListDependenciesUseCase) Execute(ctx context.Context, taskID, userID string) ([]*application.Task, error) {
+	return m.blockers, nil
+}
+
+type mockRemoveDependencyUseCase struct {
+	err error
+}
+
+func (m *mockRemoveDependencyUseCase) Execute(ctx context.Context, taskID, blockerID, userID string) error {
+	return m.err
+}
+
+func newDependencyRequest(method, body string) *http.Request {
+	req := httptest.NewRequest(method, "/api/tasks/task-1/dependencies", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", "task-1")
+	req.SetPathValue("blockerID", "task-2")
+	return withUser(req)
+}
+
+func TestAddDependency(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"valid blocker", `{"blocker_id": "task-2"}`, nil, http.StatusCreated, ""},
+		{"invalid body", `{`, nil, http.StatusBadRequest, CodeInvalidBody},
+		{"missing blocker", `{}`, nil, http.StatusBadRequest, "bad_request"},
+		{"task not visible", `{"blocker_id": "task-2"}`, usecases.ErrTaskUnavailable, http.StatusNotFound, "not_found"},
+		{"not the owner", `{"blocker_id": "task-2"}`, usecases.ErrDependencyPermissionDenied, http.StatusForbidden, "forbidden"},
+		{"cycle", `{"blocker_id": "task-2"}`, application.ErrDependencyCycle, http.StatusConflict, "conflict"},
+		{"too many blockers", `{"blocker_id": "task-2"}`, application.ErrTooManyBlockers, http.StatusConflict, "conflict"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			add := &mockAddDependencyUseCase{err: tt.err}
+			h := NewDependencyHandler(add, &mockListDependenciesUseCase{}, &mockRemoveDependencyUseCase{})
+
+			w := httptest.NewRecorder()
+			h.AddDependency(w, newDependencyRequest("POST", tt.body))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantCode != "" {
+				var resp ErrorResponse
+				json.NewDecoder(w.Body).Decode(&resp)
+				if resp.Error.Code != tt.wantCode {
+					t.Errorf("code = %q, want %q", resp.Error.Code, tt.wantCode)
+				}
+				return
+			}
+
+			var blockers []BlockerResponse
+			if err := json.NewDecoder(w.Body).Decode(&blockers); err != nil {
+				t.Fatalf("invalid response: %v", err)
+			}
+			if add.blockerID != "task-2" || len(blockers) != 1 || blockers[0].ID != "task-2" || blockers[0].Status != "pending" {
+				t.Errorf("blockers = %+v, blocker passed = %q", blockers, add.blockerID)
+			}
+		})
+	}
+}
+
+func TestListDependencies_EmptyListIsArray(t *testing.T) {
+	h := NewDependencyHandler(&mockAddDependencyUseCase{}, &mockListDependenciesUseCase{}, &mockRemoveDependencyUseCase{})
+
+	w := httptest.NewRecorder()
+	h.ListDependencies(w, newDependencyRequest("GET", ""))
+
+	if w.Code != http.StatusOK || strings.TrimSpace(w.Body.String()) != "[]" {
+		t.Errorf("status = %d, body = %q; want 200 and []", w.Code, w.Body.String())
+	}
+}
+
+func TestRemoveDependency(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"removed", nil, http.StatusNoContent},
+		{"unknown dependency", application.ErrDependencyNotFound, http.StatusNotFound},
+		{"not the owner", usecases.ErrDependencyPermissionDenied, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewDependencyHandler(&mockAddDependencyUseCase{}, &mockListDependenciesUseCase{}, &mockRemoveDependencyUseCase{err: tt.err})
+
+			w := httptest.NewRecorder()
+			h.RemoveDependency(w, newDependencyRequest("DELETE", ""))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+const (
+	// defaultDigestWeekday and defaultDigestHour schedule the digests of users opting in
+	// without picking when
+	defaultDigestWeekday = time.Monday
+	defaultDigestHour    = 8
+)
+
+// DigestHandler handles the opt-in to the weekly summary email
+type DigestHandler struct {
+	getSubscription usecases.GetDigestSubscriptionUseCaseInterface
+	subscribe       usecases.SubscribeDigestUseCaseInterface
+	unsubscribe     usecases.UnsubscribeDigestUseCaseInterface
+	pages           *PageTemplates
+}
+
+// NewDigestHandler creates a new DigestHandler
+func NewDigestHandler(
+	getSubscription usecases.GetDigestSubscriptionUseCaseInterface,
+	subscribe usecases.SubscribeDigestUseCaseInterface,
+	unsubscribe usecases.UnsubscribeDigestUseCaseInterface,
+	pages *PageTemplates,
+) *DigestHandler {
+	return &DigestHandler{
+		getSubscription: getSubscription,
+		subscribe:       subscribe,
+		unsubscribe:     unsubscribe,
+		pages:           pages,
+	}
+}
+
+// DigestPreference is the body of GET and PUT /api/me/preferences/digest. Weekday is 0
+// (Sunday) to 6 and hour 0 to 23, in the application timezone; they default to Monday at 8.
+type DigestPreference struct {
+	Enabled    *bool      `json:"enabled"`
+	Weekday    *int       `json:"weekday,omitempty"`
+	Hour       *int       `json:"hour,omitempty"`
+	NextSendAt *time.Time `json:"next_send_at,omitempty"`
+}
+
+// newDigestPreference converts a subscription, nil when the user didn't opt in, to its response
+func newDigestPreference(subscription *application.DigestSubscription) DigestPreference {
+	enabled := subscription != nil
+	if !enabled {
+		return DigestPreference{Enabled: &enabled}
+	}
+	weekday := int(subscription.Weekday)
+	return DigestPreference{
+		Enabled:    &enabled,
+		Weekday:    &weekday,
+		Hour:       &subscription.Hour,
+		NextSendAt: &subscription.NextSendAt,
+	}
+}
+
+// GetPreference handles GET /api/me/preferences/digest
+func (h *DigestHandler) GetPreference(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	subscription, err := h.getSubscription.Execute(r.Context(), userID)
+	if err != nil {
+		status, message := digestErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newDigestPreference(subscription))
+}
+
+// UpdatePreference handles PUT /api/me/preferences/digest, subscribing or unsubscribing the
+// current user
+func (h *DigestHandler) UpdatePreference(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req DigestPreference
+	if !decodeProjectBody(w, r, &req) {
+		return
+	}
+	if req.Enabled == nil {
+		writeAPIError(w, r, http.StatusBadRequest, "enabled is required")
+		return
+	}
+
+	if !*req.Enabled {
+		if err := h.unsubscribe.Execute(r.Context(), userID); err != nil {
+			status, message := digestErrorStatus(err)
+			writeAPIError(w, r, status, message)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(newDigestPreference(nil))
+		return
+	}
+
+	weekday, hour := int(defaultDigestWeekday), defaultDigestHour
+	if req.Weekday != nil {
+		weekday = *req.Weekday
+	}
+	if req.Hour != nil {
+		hour = *req.Hour
+	}
+
+	subscription, err := h.subscribe.Execute(r.Context(), userID, time.Weekday(weekday), hour)
+	if err != nil {
+		status, message := digestErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newDigestPreference(subscription))
+}
+
+// WebUpdatePreference handles POST /web/preferences/digest, sent by the profile page form,
+// which only carries "enabled" when its checkbox is checked
+func (h *DigestHandler) WebUpdatePreference(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok || userID == "" {
+		h.pages.writeWebError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var err error
+	if r.FormValue("enabled") != "true" {
+		err = h.unsubscribe.Execute(r.Context(), userID)
+	} else {
+		weekday, weekdayErr := strconv.Atoi(r.FormValue("weekday"))
+		hour, hourErr := strconv.Atoi(r.FormValue("hour"))
+		if weekdayErr != nil || hourErr != nil {
+			h.pages.writeWebError(w, r, http.StatusBadRequest, i18n.Error(RequestLocale(r), "weekday and hour must be numbers"))
+			return
+		}
+		_, err = h.subscribe.Execute(r.Context(), userID, time.Weekday(weekday), hour)
+	}
+	if err != nil {
+		status, message := digestErrorStatus(err)
+		h.pages.writeWebError(w, r, status, i18n.Error(RequestLocale(r), message))
+		return
+	}
+
+	h.pages.writeWebFragment(w, r, http.StatusNoContent, "")
+}
+
+// digestErrorStatus maps a digest use case error to an HTTP status and client message
+func digestErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, application.ErrUserNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, application.ErrInvalidDigestDay), errors.Is(err, application.ErrInvalidDigestHour):
+		return http.StatusBadRequest, err.Error()
+	}
+	log.Printf("digest operation failed: %v", err)
+	return http.StatusInternalServerError, "Internal server error"
+}
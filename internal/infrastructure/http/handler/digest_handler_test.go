@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+type mockGetDigestSubscriptionUseCase struct {
+	subscription *application.DigestSubscription
+	err          error
+}
+
+func (m *mockGetDigestSubscriptionUseCase) Execute(ctx context.Context, userID string) (*application.DigestSubscription, error) {
+	return m.subscription, m.err
+}
+
+type mockSubscribeDigestUseCase struct {
+	called  bool
+	weekday time.Weekday
+	hour    int
+}
+
+func (m *mockSubscribeDigestUseCase) Execute(ctx context.Context, userID string, weekday time.Weekday, hour int) (*application.DigestSubscription, error) {
+	m.called, m.weekday, m.hour = true, weekday, hour
+	return application.NewDigestSubscription(userID, weekday, hour, time.UTC, time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC))
+}
+
+type mockUnsubscribeDigestUseCase struct {
+	called bool
+}
+
+func (m *mockUnsubscribeDigestUseCase) Execute(ctx context.Context, userID string) error {
+	m.called = true
+	return nil
+}
+
+func TestGetDigestPreference(t *testing.T) {
+	subscription := &application.DigestSubscription{
+		UserID:     "user-123",
+		Weekday:    time.Friday,
+		Hour:       18,
+		NextSendAt: time.Date(2026, 3, 13, 18, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name         string
+		subscription *application.DigestSubscription
+		err          error
+		wantStatus   int
+		wantBody     string
+	}{
+		{"subscribed", subscription, nil, http.StatusOK, `{"enabled":true,"weekday":5,"hour":18,"next_send_at":"2026-03-13T18:00:00Z"}`},
+		{"not subscribed", nil, nil, http.StatusOK, `{"enabled":false}`},
+		{"repository failure", nil, errors.New("db down"), http.StatusInternalServerError, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewDigestHandler(&mockGetDigestSubscriptionUseCase{subscription: tt.subscription, err: tt.err}, &mockSubscribeDigestUseCase{}, &mockUnsubscribeDigestUseCase{}, NewPageTemplates(""))
+
+			w := httptest.NewRecorder()
+			h.GetPreference(w, withUser(httptest.NewRequest("GET", "/api/me/preferences/digest", nil)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" && strings.TrimSpace(w.Body.String()) != tt.wantBody {
+				t.Errorf("body = %s, want %s", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestUpdateDigestPreference(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		wantStatus      int
+		wantSubscribe   bool
+		wantWeekday     time.Weekday
+		wantHour        int
+		wantUnsubscribe bool
+	}{
+		{"subscribe with schedule", `{"enabled": true, "weekday": 5, "hour": 18}`, http.StatusOK, true, time.Friday, 18, false},
+		{"subscribe with defaults", `{"enabled": true}`, http.StatusOK, true, time.Monday, 8, false},
+		{"invalid hour", `{"enabled": true, "hour": 24}`, http.StatusBadRequest, true, time.Monday, 24, false},
+		{"unsubscribe", `{"enabled": false}`, http.StatusOK, false, 0, 0, true},
+		{"missing field", `{}`, http.StatusBadRequest, false, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscribe, unsubscribe := &mockSubscribeDigestUseCase{}, &mockUnsubscribeDigestUseCase{}
+			h := NewDigestHandler(&mockGetDigestSubscriptionUseCase{}, subscribe, unsubscribe, NewPageTemplates(""))
+
+			req := httptest.NewRequest("PUT", "/api/me/preferences/digest", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			h.UpdatePreference(w, withUser(req))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if subscribe.called != tt.wantSubscribe || unsubscribe.called != tt.wantUnsubscribe {
+				t.Fatalf("subscribe called = %v, unsubscribe called = %v", subscribe.called, unsubscribe.called)
+			}
+			if tt.wantSubscribe && (subscribe.weekday != tt.wantWeekday || subscribe.hour != tt.wantHour) {
+				t.Errorf("subscribed on %v at %d, want %v at %d", subscribe.weekday, subscribe.hour, tt.wantWeekday, tt.wantHour)
+			}
+		})
+	}
+}
+
+func TestWebUpdateDigestPreference(t *testing.T) {
+	tests := []struct {
+		name            string
+		form            string
+		wantStatus      int
+		wantSubscribe   bool
+		wantUnsubscribe bool
+	}{
+		{"checked", "enabled=true&weekday=3&hour=7", http.StatusNoContent, true, false},
+		{"unchecked", "weekday=3&hour=7", http.StatusNoContent, false, true},
+		{"invalid hour", "enabled=true&weekday=3&hour=seven", http.StatusBadRequest, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscribe, unsubscribe := &mockSubscribeDigestUseCase{}, &mockUnsubscribeDigestUseCase{}
+			h := NewDigestHandler(&mockGetDigestSubscriptionUseCase{}, subscribe, unsubscribe, NewPageTemplates(""))
+
+			req := htmx(httptest.NewRequest("POST", "/web/preferences/digest", strings.NewReader(tt.form)))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			h.WebUpdatePreference(w, withUser(req))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if subscribe.called != tt.wantSubscribe || unsubscribe.called != tt.wantUnsubscribe {
+				t.Errorf("subscribe called = %v, unsubscribe called = %v", subscribe.called, unsubscribe.called)
+			}
+			if tt.wantSubscribe && (subscribe.weekday != time.Wednesday || subscribe.hour != 7) {
+				t.Errorf("subscribed on %v at %d, want Wednesday at 7", subscribe.weekday, subscribe.hour)
+			}
+		})
+	}
+}
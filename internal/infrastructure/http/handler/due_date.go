@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// requestLocation resolves the user's timezone from the X-Timezone header, the
+// "timezone" form field or the "tz" cookie, falling back to fallback (the local
+// timezone when nil)
+func requestLocation(r *http.Request, fallback *time.Location) *time.Location {
+	candidates := []string{r.Header.Get("X-Timezone")}
+	if r.Form != nil || r.MultipartForm != nil {
+		candidates = append(candidates, r.FormValue("timezone"))
+	}
+	if cookie, err := r.Cookie("tz"); err == nil {
+		candidates = append(candidates, cookie.Value)
+	}
+
+	for _, name := range candidates {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+
+	if fallback == nil {
+		return time.Local
+	}
+	return fallback
+}
+
+// parseDueDateInput parses an optional due date in the user's timezone, or else fallback; empty
+// input means no due date
+func parseDueDateInput(r *http.Request, input string, fallback *time.Location) (*time.Time, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, nil
+	}
+
+	dueDate, err := service.ParseDueDate(input, time.Now(), requestLocation(r, fallback))
+	if err != nil {
+		return nil, err
+	}
+
+	return &dueDate, nil
+}
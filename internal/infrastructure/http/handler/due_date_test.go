@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseDueDateInput_UsesFallbackLocation(t *testing.T) {
+	fallback := time.FixedZone("UTC-3", -3*60*60)
+
+	req := httptest.NewRequest("POST", "/api/tasks", nil)
+	dueDate, err := parseDueDateInput(req, "2030-01-02", fallback)
+	if err != nil {
+		t.Fatalf("parseDueDateInput() error = %v", err)
+	}
+	if dueDate.Location() != fallback {
+		t.Errorf("due date location = %v, want the fallback %v", dueDate.Location(), fallback)
+	}
+
+	req.Header.Set("X-Timezone", "Asia/Tokyo")
+	if loc := requestLocation(req, fallback); loc.String() != "Asia/Tokyo" {
+		t.Errorf("requestLocation() = %v, want the X-Timezone header to win", loc)
+	}
+	if loc := requestLocation(httptest.NewRequest("GET", "/", nil), nil); loc != time.Local {
+		t.Errorf("requestLocation() without a fallback = %v, want the local timezone", loc)
+	}
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+)
+
+// maxEmailActionFormSize limits the confirmation form, which only carries the token
+const maxEmailActionFormSize = 4 << 10 // 4KB
+
+// EmailAction is a destructive action triggered by a link sent by email (delete account,
+// revoke sessions...). The link token is the only authorization, so it must be single-use
+// and short-lived. Implementations return application.ErrInvalidLinkToken for bad tokens.
+type EmailAction interface {
+	// Check validates the token without side effects
+	Check(ctx context.Context, token string) error
+
+	// Execute validates the token and performs the action
+	Execute(ctx context.Context, token string) error
+}
+
+// EmailActionPage holds the texts of the confirmation page of an EmailAction
+type EmailActionPage struct {
+	Title        string
+	Message      string
+	ConfirmLabel string
+	DoneMessage  string
+}
+
+// EmailActionHandler serves the link of an EmailAction. GET only renders a confirmation
+// page and the action runs on the explicit POST of that page, so mail scanners and link
+// prefetchers that follow the link can never trigger it.
+type EmailActionHandler struct {
+	action EmailAction
+	page   EmailActionPage
+	pages  *PageTemplates
+}
+
+// NewEmailActionHandler creates a new EmailActionHandler
+func NewEmailActionHandler(action EmailAction, page EmailActionPage, pages *PageTemplates) *EmailActionHandler {
+	return &EmailActionHandler{
+		action: action,
+		page:   page,
+		pages:  pages,
+	}
+}
+
+// EmailActionURL builds the link to an EmailActionHandler route to be sent by email
+func EmailActionURL(baseURL, path, token string) string {
+	return strings.TrimSuffix(baseURL, "/") + path + "?" + url.Values{"token": {token}}.Encode()
+}
+
+// ServeHTTP renders the confirmation page on GET and HEAD and performs the action on POST
+func (h *EmailActionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The token travels in the URL: keep it out of caches, search engines and Referer headers
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("X-Robots-Tag", "noindex")
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		h.confirm(w, r)
+	case http.MethodPost:
+		h.execute(w, r)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, POST")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// confirm renders the confirmation form for a valid token
+func (h *EmailActionHandler) confirm(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+
+	if err := h.check(r.Context(), token); err != nil {
+		h.renderError(w, r, err)
+		return
+	}
+
+	h.render(w, r, http.StatusOK, map[string]interface{}{
+		"Token":  token,
+		"Action": r.URL.Path,
+	})
+}
+
+// execute performs the action with the token posted by the confirmation form
+func (h *EmailActionHandler) execute(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxEmailActionFormSize)
+	token := r.PostFormValue("token")
+
+	if token == "" {
+		h.renderError(w, r, application.ErrInvalidLinkToken)
+		return
+	}
+
+	if err := h.action.Execute(r.Context(), token); err != nil {
+		h.renderError(w, r, err)
+		return
+	}
+
+	h.render(w, r, http.StatusOK, map[string]interface{}{
+		"Done": true,
+	})
+}
+
+// check validates a token, rejecting empty ones without calling the action
+func (h *EmailActionHandler) check(ctx context.Context, token string) error {
+	if token == "" {
+		return application.ErrInvalidLinkToken
+	}
+	return h.action.Check(ctx, token)
+}
+
+// renderError renders the page with a user-facing error, keeping internal errors out of it
+func (h *EmailActionHandler) renderError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, application.ErrInvalidLinkToken) {
+		h.render(w, r, http.StatusBadRequest, map[string]interface{}{
+			"Error": i18n.T(RequestLocale(r), "email_action.invalid_link"),
+		})
+		return
+	}
+
+	log.Printf("email action %q failed: %v", r.URL.Path, err)
+	h.render(w, r, http.StatusInternalServerError, map[string]interface{}{
+		"Error": i18n.T(RequestLocale(r), "email_action.failed"),
+	})
+}
+
+// render renders the confirmation page with the given data
+func (h *EmailActionHandler) render(w http.ResponseWriter, r *http.Request, status int, data map[string]interface{}) {
+	locale := RequestLocale(r)
+	tmpl, err := h.pages.Page(locale, nil, "base.html", "confirm.html")
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	data["Title"] = h.page.Title
+	data["Page"] = h.page
+	data["Theme"] = string(ThemeFromRequest(r))
+	PageData(locale, data)
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+type mockEmailAction struct {
+	validToken string
+	executed   int
+	executeErr error
+}
+
+func (m *mockEmailAction) Check(ctx context.Context, token string) error {
+	if token != m.validToken {
+		return application.ErrInvalidLinkToken
+	}
+	return nil
+}
+
+func (m *mockEmailAction) Execute(ctx context.Context, token string) error {
+	if err := m.Check(ctx, token); err != nil {
+		return err
+	}
+	if m.executeErr != nil {
+		return m.executeErr
+	}
+	m.executed++
+	return nil
+}
+
+func newTestEmailActionHandler(action *mockEmailAction) *EmailActionHandler {
+	return NewEmailActionHandler(action, EmailActionPage{
+		Title:        "Excluir conta",
+		Message:      "Sua conta e todas as suas tarefas serão excluídas.",
+		ConfirmLabel: "Excluir minha conta",
+		DoneMessage:  "Conta excluída.",
+	}, NewPageTemplates(""))
+}
+
+func postEmailAction(h *EmailActionHandler, token string) *httptest.ResponseRecorder {
+	form := url.Values{"token": {token}}
+	req := httptest.NewRequest("POST", "/account/delete", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestEmailActionHandler_GetRendersConfirmationWithoutExecuting(t *testing.T) {
+	action := &mockEmailAction{validToken: "tok-123"}
+	h := newTestEmailActionHandler(action)
+
+	for _, method := range []string{"GET", "HEAD"} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, "/account/delete?token=tok-123", nil))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d", method, w.Code)
+		}
+		if w.Header().Get("Referrer-Policy") != "no-referrer" || w.Header().Get("Cache-Control") != "no-store" {
+			t.Errorf("%s: expected no-referrer and no-store headers", method)
+		}
+	}
+
+	if action.executed != 0 {
+		t.Fatalf("GET must never execute the action, executed %d time(s)", action.executed)
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/account/delete?token=tok-123", nil))
+	body := w.Body.String()
+	if !strings.Contains(body, `<form method="POST" action="/account/delete"`) {
+		t.Error("Expected a POST form to the same path")
+	}
+	if !strings.Contains(body, `name="token" value="tok-123"`) {
+		t.Error("Expected the token in a hidden field")
+	}
+	if !strings.Contains(body, "Excluir minha conta") {
+		t.Error("Expected the confirm label")
+	}
+}
+
+func TestEmailActionHandler_PostExecutes(t *testing.T) {
+	action := &mockEmailAction{validToken: "tok-123"}
+	h := newTestEmailActionHandler(action)
+
+	w := postEmailAction(h, "tok-123")
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if action.executed != 1 {
+		t.Errorf("Expected action executed once, got %d", action.executed)
+	}
+	if !strings.Contains(w.Body.String(), "Conta excluída.") {
+		t.Error("Expected done message")
+	}
+}
+
+func TestEmailActionHandler_InvalidToken(t *testing.T) {
+	action := &mockEmailAction{validToken: "tok-123"}
+	h := newTestEmailActionHandler(action)
+
+	tests := []struct {
+		name string
+		do   func() *httptest.ResponseRecorder
+	}{
+		{"get without token", func() *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest("GET", "/account/delete", nil))
+			return w
+		}},
+		{"get with expired token", func() *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest("GET", "/account/delete?token=old", nil))
+			return w
+		}},
+		{"post with expired token", func() *httptest.ResponseRecorder { return postEmailAction(h, "old") }},
+		{"post without token", func() *httptest.ResponseRecorder { return postEmailAction(h, "") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := tt.do()
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status 400, got %d", w.Code)
+			}
+			body := w.Body.String()
+			if !strings.Contains(body, "inválido ou expirou") {
+				t.Error("Expected invalid link message")
+			}
+			if strings.Contains(body, "<form") {
+				t.Error("Expected no confirmation form for an invalid token")
+			}
+		})
+	}
+
+	if action.executed != 0 {
+		t.Errorf("Expected no executions, got %d", action.executed)
+	}
+}
+
+func TestEmailActionHandler_InternalErrorIsHidden(t *testing.T) {
+	action := &mockEmailAction{validToken: "tok-123", executeErr: errors.New("database is locked")}
+	h := newTestEmailActionHandler(action)
+
+	w := postEmailAction(h, "tok-123")
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", w.Code)
+	}
+	if strings.Contains(w.Body.String(), "database is locked") {
+		t.Error("Internal errors must not be shown to the user")
+	}
+}
+
+func TestEmailActionHandler_MethodNotAllowed(t *testing.T) {
+	h := newTestEmailActionHandler(&mockEmailAction{validToken: "tok-123"})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("DELETE", "/account/delete?token=tok-123", nil))
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", w.Code)
+	}
+	if w.Header().Get("Allow") != "GET, HEAD, POST" {
+		t.Errorf("Expected Allow header, got %q", w.Header().Get("Allow"))
+	}
+}
+
+func TestEmailActionURL(t *testing.T) {
+	got := EmailActionURL("https://todo.example.com/", "/account/delete", "a+b/c")
+	want := "https://todo.example.com/account/delete?token=a%2Bb%2Fc"
+	if got != want {
+		t.Errorf("EmailActionURL() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// ErrorBody describes an API error
+type ErrorBody struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"` // Code of the error of each invalid field
+	Details []ErrorDetail     `json:"details,omitempty"`
+}
+
+// ErrorDetail describes one of the problems of a rejected request, such as a rule a password
+// doesn't follow
+type ErrorDetail struct {
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse is the body of every API error response: {"error": {"code": "...", "message": "..."}}
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// Error codes more specific than the default code of their status
+const (
+	CodeCreationQuotaExceeded = "creation_quota_exceeded"
+	CodeInvalidBody           = "invalid_body"
+	CodeInvalidDueDate        = "invalid_due_date"
+	CodeInvalidRemindAt       = "invalid_remind_at"
+	CodeStorageQuotaExceeded  = "storage_quota_exceeded"
+	CodeTimeout               = "timeout"
+	CodeTrailingData          = "trailing_data"
+	CodeUnknownField          = "unknown_field"
+	CodeValidationFailed      = "validation_failed"
+	CodeWeakPassword          = "weak_password"
+)
+
+// WriteJSONError writes an API error response. Web routes keep using http.Error or HTML fragments.
+// The message is translated when the request asks for a language (see NegotiatedLocale).
+func WriteJSONError(w http.ResponseWriter, r *http.Request, status int, code, message string) {
+	message = LocalizeError(r, message)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ErrorResponse{Error: ErrorBody{Code: code, Message: message}})
+}
+
+// ErrorCode returns the default API error code of an HTTP status
+func ErrorCode(status int) string {
+	switch status {
+	case http.StatusInternalServerError:
+		return "internal_error"
+	case http.StatusRequestEntityTooLarge:
+		return "payload_too_large"
+	case http.StatusUnprocessableEntity:
+		return "unprocessable_entity"
+	default:
+		text := http.StatusText(status)
+		if text == "" {
+			return "error"
+		}
+		return strings.ReplaceAll(strings.ToLower(text), " ", "_")
+	}
+}
+
+// writeAPIError writes an API error response with the default code of its status
+func writeAPIError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	WriteJSONError(w, r, status, ErrorCode(status), message)
+}
@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// decodeErrorResponse asserts that a response is a JSON error envelope and returns it
+func decodeErrorResponse(t *testing.T, w *httptest.ResponseRecorder) ErrorBody {
+	t.Helper()
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("Expected Content-Type application/json, got %q", ct)
+	}
+
+	var raw map[string]map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &raw); err != nil {
+		t.Fatalf("Expected a JSON error envelope, got %q: %v", w.Body.String(), err)
+	}
+	if len(raw) != 1 || len(raw["error"]) != 2 {
+		t.Fatalf("Expected exactly {\"error\": {\"code\", \"message\"}}, got %s", w.Body.String())
+	}
+
+	code, _ := raw["error"]["code"].(string)
+	message, _ := raw["error"]["message"].(string)
+	if code == "" || message == "" {
+		t.Fatalf("Expected non-empty code and message, got %s", w.Body.String())
+	}
+	return ErrorBody{Code: code, Message: message}
+}
+
+func TestWriteJSONError(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/api/tasks/1/share", nil)
+	WriteJSONError(w, r, http.StatusConflict, "already_shared", "task already shared")
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status 409, got %d", w.Code)
+	}
+	if w.Header().Get("X-Content-Type-Options") != "nosniff" {
+		t.Error("Expected nosniff header")
+	}
+
+	body := decodeErrorResponse(t, w)
+	if body.Code != "already_shared" || body.Message != "task already shared" {
+		t.Errorf("Unexpected error body %+v", body)
+	}
+}
+
+func TestWriteJSONError_Localized(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		cookie string
+		want   string
+	}{
+		{"no language keeps the original message", "", "", "task already shared"},
+		{"english", "en-US,en;q=0.9", "", "task already shared"},
+		{"portuguese", "pt-BR,pt;q=0.9", "", "tarefa já compartilhada"},
+		{"language cookie wins over the header", "en", "pt-BR", "tarefa já compartilhada"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/api/tasks/1/share", nil)
+			if tt.header != "" {
+				r.Header.Set("Accept-Language", tt.header)
+			}
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: LocaleCookieName, Value: tt.cookie})
+			}
+
+			WriteJSONError(w, r, http.StatusConflict, "already_shared", "task already shared")
+
+			body := decodeErrorResponse(t, w)
+			if body.Message != tt.want {
+				t.Errorf("Expected message %q, got %q", tt.want, body.Message)
+			}
+		})
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		status int
+		want   string
+	}{
+		{http.StatusBadRequest, "bad_request"},
+		{http.StatusUnauthorized, "unauthorized"},
+		{http.StatusForbidden, "forbidden"},
+		{http.StatusNotFound, "not_found"},
+		{http.StatusRequestEntityTooLarge, "payload_too_large"},
+		{http.StatusUnsupportedMediaType, "unsupported_media_type"},
+		{http.StatusUnprocessableEntity, "unprocessable_entity"},
+		{http.StatusTooManyRequests, "too_many_requests"},
+		{http.StatusInternalServerError, "internal_error"},
+		{http.StatusServiceUnavailable, "service_unavailable"},
+		{599, "error"},
+	}
+
+	for _, tt := range tests {
+		if got := ErrorCode(tt.status); got != tt.want {
+			t.Errorf("ErrorCode(%d) = %q, want %q", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestAPIHandlers_ErrorEnvelope(t *testing.T) {
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+	}
+
+	tests := []struct {
+		name       string
+		serve      func(w http.ResponseWriter)
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name: "create task with invalid body",
+			serve: func(w http.ResponseWriter) {
+				h := NewTaskHandler(&mockCreateTaskUseCase{}, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+				h.CreateTask(w, withUser(httptest.NewRequest("POST", "/api/tasks", strings.NewReader("{"))))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeInvalidBody,
+		},
+		{
+			name: "create task with invalid due date",
+			serve: func(w http.ResponseWriter) {
+				h := NewTaskHandler(&mockCreateTaskUseCase{}, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+				body, _ := json.Marshal(CreateTaskRequest{Title: "x", DueDate: "nunca"})
+				h.CreateTask(w, withUser(httptest.NewRequest("POST", "/api/tasks", bytes.NewReader(body))))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeInvalidDueDate,
+		},
+		{
+			name: "get task without permission",
+			serve: func(w http.ResponseWriter) {
+				h := NewTaskHandler(nil, nil, nil, &mockGetTaskUseCase{
+					executeFunc: func(ctx context.Context, taskID, userID string) (*application.Task, error) {
+						return nil, errors.New("user does not have permission to access this task")
+					},
+				}, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+				h.GetTask(w, withUser(httptest.NewRequest("GET", "/api/tasks/task-1", nil)))
+			},
+			wantStatus: http.StatusForbidden,
+			wantCode:   "forbidden",
+		},
+		{
+			name: "list tasks failure",
+			serve: func(w http.ResponseWriter) {
+				h := NewTaskHandler(nil, nil, nil, nil, &mockListTasksUseCase{
+					executeFunc: func(ctx context.Context, userID string) ([]*application.Task, error) {
+						return nil, errors.New("database error")
+					},
+				}, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+				h.ListTasks(w, withUser(httptest.NewRequest("GET", "/api/tasks", nil)))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "internal_error",
+		},
+		{
+			name: "login with wrong credentials",
+			serve: func(w http.ResponseWriter) {
+				h := NewAuthHandler(&mockLoginUseCase{
+					executeFunc: func(ctx context.Context, email, password string) (string, error) {
+						return "", errors.New("invalid credentials")
+					},
+				}, &mockRegisterUseCase{}, nil, nil, NewPageTemplates(""), DefaultCookieConfig())
+				h.Login(w, httptest.NewRequest("POST", "/api/auth/login", strings.NewReader(`{"email":"a@a.com","password":"x"}`)))
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   "unauthorized",
+		},
+		{
+			name: "report with invalid format",
+			serve: func(w http.ResponseWriter) {
+				h := NewReportHandler(&mockOverdueReportUseCase{})
+				h.OverdueReport(w, withUser(httptest.NewRequest("GET", "/api/admin/reports/overdue?format=xls", nil)))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "bad_request",
+		},
+		{
+			name: "report forbidden",
+			serve: func(w http.ResponseWriter) {
+				h := NewReportHandler(&mockOverdueReportUseCase{
+					executeFunc: func(ctx context.Context, requesterID, unit string) (*application.OverdueReport, error) {
+						return nil, usecases.ErrReportForbidden
+					},
+				})
+				h.OverdueReport(w, withUser(httptest.NewRequest("GET", "/api/admin/reports/overdue", nil)))
+			},
+			wantStatus: http.StatusForbidden,
+			wantCode:   "forbidden",
+		},
+		{
+			name: "import with unsupported format",
+			serve: func(w http.ResponseWriter) {
+				h := NewImportHandler(&mockImportTasksUseCase{}, NewPageTemplates(""), time.Local)
+				req := httptest.NewRequest("POST", "/api/tasks/import", strings.NewReader("x"))
+				req.Header.Set("Content-Type", "text/plain")
+				h.ImportTasks(w, withUser(req))
+			},
+			wantStatus: http.StatusUnsupportedMediaType,
+			wantCode:   "unsupported_media_type",
+		},
+		{
+			name: "upload without file",
+			serve: func(w http.ResponseWriter) {
+				h := NewUploadHandler(t.TempDir(), nil)
+				req := httptest.NewRequest("POST", "/upload/image", strings.NewReader(""))
+				req.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+				h.UploadImage(w, withUser(req))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "bad_request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.serve(w)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if body := decodeErrorResponse(t, w); body.Code != tt.wantCode {
+				t.Errorf("Expected code %q, got %q", tt.wantCode, body.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// taskListETag computes a weak ETag for a user's task list. Every change to a task bumps
+// its UpdatedAt, a new task is the newest one and a deletion changes the count, so the
+// newest UpdatedAt and the count identify the list contents.
+func taskListETag(userID string, tasks []*application.Task) string {
+	var newest int64
+	for _, task := range tasks {
+		if updated := task.UpdatedAt.UnixNano(); updated > newest {
+			newest = updated
+		}
+	}
+	return weakETag(fmt.Sprintf("list|%s|%d|%d", userID, len(tasks), newest))
+}
+
+// taskETag computes a weak ETag for a single task
+func taskETag(task *application.Task) string {
+	return weakETag(fmt.Sprintf("task|%s|%d", task.ID, task.UpdatedAt.UnixNano()))
+}
+
+// weakETag hashes a version string into a weak ETag, keeping IDs out of the header
+func weakETag(version string) string {
+	sum := sha256.Sum256([]byte(version))
+	return `W/"` + hex.EncodeToString(sum[:12]) + `"`
+}
+
+// writeNotModified sets the validator headers of a cacheable GET and answers 304 when the
+// client's If-None-Match matches etag. Clients must revalidate on every use (no-cache), and
+// shared caches must not store the response (private). It reports whether 304 was written.
+func writeNotModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+
+	if !etagMatches(r.Header.Get("If-None-Match"), etag) {
+		return false
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// etagMatches applies the weak comparison of If-None-Match (RFC 9110 section 13.1.2)
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/database"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// getWithETag performs a GET on handle as userID, sending ifNoneMatch when not empty
+func getWithETag(handle http.HandlerFunc, target, userID, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", target, nil)
+	req.SetPathValue("id", "task-1")
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+
+	w := httptest.NewRecorder()
+	handle(w, req)
+	return w
+}
+
+func TestListTasks_ETag(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tasks := []*application.Task{
+		{ID: "task-1", Title: "A", Status: application.StatusPending, OwnerID: "user-123", UpdatedAt: base},
+		{ID: "task-2", Title: "B", Status: application.StatusPending, OwnerID: "user-123", UpdatedAt: base.Add(time.Minute)},
+	}
+	handler := NewTaskHandler(nil, nil, nil, nil, &mockListTasksUseCase{
+		executeFunc: func(ctx context.Context, userID string) ([]*application.Task, error) {
+			return tasks, nil
+		},
+	}, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+
+	first := getWithETag(handler.ListTasks, "/api/tasks", "user-123", "")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("Expected 200 with an ETag, got %d %q", first.Code, etag)
+	}
+	if got := first.Header().Get("Cache-Control"); got != "private, no-cache" {
+		t.Errorf("Expected Cache-Control 'private, no-cache', got %q", got)
+	}
+
+	unchanged := getWithETag(handler.ListTasks, "/api/tasks", "user-123", etag)
+	if unchanged.Code != http.StatusNotModified || unchanged.Body.Len() != 0 {
+		t.Fatalf("Expected empty 304 for an unchanged list, got %d with %d bytes", unchanged.Code, unchanged.Body.Len())
+	}
+	if unchanged.Header().Get("ETag") != etag {
+		t.Error("Expected 304 to repeat the ETag")
+	}
+
+	if w := getWithETag(handler.ListTasks, "/api/tasks", "other-user", etag); w.Code != http.StatusOK {
+		t.Errorf("Expected another user's ETag not to match, got %d", w.Code)
+	}
+
+	tasks[0].UpdatedAt = base.Add(2 * time.Minute)
+	updated := getWithETag(handler.ListTasks, "/api/tasks", "user-123", etag)
+	if updated.Code != http.StatusOK || updated.Header().Get("ETag") == etag {
+		t.Fatalf("Expected a new ETag after an update, got %d", updated.Code)
+	}
+
+	tasks = tasks[:1]
+	if w := getWithETag(handler.ListTasks, "/api/tasks", "user-123", updated.Header().Get("ETag")); w.Code != http.StatusOK {
+		t.Errorf("Expected a new ETag after a deletion, got %d", w.Code)
+	}
+}
+
+func TestGetTask_ETag(t *testing.T) {
+	task := &application.Task{ID: "task-1", Title: "A", Status: application.StatusPending, OwnerID: "user-123", UpdatedAt: time.Now()}
+	handler := NewTaskHandler(nil, nil, nil, &mockGetTaskUseCase{
+		executeFunc: func(ctx context.Context, taskID, userID string) (*application.Task, error) {
+			return task, nil
+		},
+	}, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+
+	first := getWithETag(handler.GetTask, "/api/tasks/task-1", "user-123", "")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("Expected 200 with an ETag, got %d %q", first.Code, etag)
+	}
+
+	if w := getWithETag(handler.GetTask, "/api/tasks/task-1", "user-123", `"other", `+etag); w.Code != http.StatusNotModified {
+		t.Errorf("Expected 304 when one of the listed ETags matches, got %d", w.Code)
+	}
+
+	task.UpdatedAt = task.UpdatedAt.Add(time.Nanosecond)
+	if w := getWithETag(handler.GetTask, "/api/tasks/task-1", "user-123", etag); w.Code != http.StatusOK {
+		t.Errorf("Expected 200 after the task changed, got %d", w.Code)
+	}
+}
+
+func TestTaskETags_OverdueFlag(t *testing.T) {
+	db, err := database.NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := database.NewSQLiteUserRepository(db).Create(ctx, &application.User{ID: "user-123", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	taskRepo := database.NewSQLiteTaskRepository(db)
+	task, _ := application.NewTask("task-1", "Pagar contas", "", application.StatusPending, "user-123", "")
+	due := time.Now().Add(-time.Hour)
+	task.SetDueDate(&due)
+	task.UpdatedAt = task.UpdatedAt.Add(-time.Minute)
+	if err := taskRepo.Create(ctx, task); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	taskService := service.NewTaskService(taskRepo, database.NewSQLiteShareRepository(db))
+	handler := NewTaskHandler(nil, nil, nil, usecases.NewGetTaskUseCase(taskRepo, taskService), usecases.NewListTasksUseCase(taskRepo), nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+	listETag := getWithETag(handler.ListTasks, "/api/tasks", "user-123", "").Header().Get("ETag")
+	taskETag := getWithETag(handler.GetTask, "/api/tasks/task-1", "user-123", "").Header().Get("ETag")
+
+	// The overdue job flags the task without going through the task use cases
+	if flagged, err := database.NewSQLiteOverdueRepository(db).FlagOverdue(ctx, time.Now(), 10); err != nil || len(flagged) != 1 {
+		t.Fatalf("FlagOverdue() = %d tasks, %v; want task-1", len(flagged), err)
+	}
+
+	if w := getWithETag(handler.ListTasks, "/api/tasks", "user-123", listETag); w.Code != http.StatusOK {
+		t.Errorf("Expected 200 for the list after the task was flagged overdue, got %d", w.Code)
+	}
+	if w := getWithETag(handler.GetTask, "/api/tasks/task-1", "user-123", taskETag); w.Code != http.StatusOK {
+		t.Errorf("Expected 200 for the task after it was flagged overdue, got %d", w.Code)
+	}
+}
+
+func TestEtagMatches(t *testing.T) {
+	etag := `W/"abc"`
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        bool
+	}{
+		{"empty", "", false},
+		{"exact", `W/"abc"`, true},
+		{"strong form matches weakly", `"abc"`, true},
+		{"list", `"x", W/"abc"`, true},
+		{"wildcard", "*", true},
+		{"different", `W/"abd"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := etagMatches(tt.ifNoneMatch, etag); got != tt.want {
+				t.Errorf("etagMatches(%q) = %v, want %v", tt.ifNoneMatch, got, tt.want)
+			}
+		})
+	}
+}
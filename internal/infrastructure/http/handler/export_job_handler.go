@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// ExportJobHandler handles HTTP requests for PDF exports generated in the background
+type ExportJobHandler struct {
+	requestExport  usecases.RequestTaskExportUseCaseInterface
+	getExportJob   usecases.GetExportJobUseCaseInterface
+	downloadExport usecases.DownloadExportUseCaseInterface
+	pages          *PageTemplates
+}
+
+// NewExportJobHandler creates a new ExportJobHandler
+func NewExportJobHandler(
+	requestExport usecases.RequestTaskExportUseCaseInterface,
+	getExportJob usecases.GetExportJobUseCaseInterface,
+	downloadExport usecases.DownloadExportUseCaseInterface,
+	pages *PageTemplates,
+) *ExportJobHandler {
+	return &ExportJobHandler{
+		requestExport:  requestExport,
+		getExportJob:   getExportJob,
+		downloadExport: downloadExport,
+		pages:          pages,
+	}
+}
+
+// ExportJobResponse is the JSON representation of an export job
+type ExportJobResponse struct {
+	ID          string     `json:"id"`
+	Kind        string     `json:"kind"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	Size        int        `json:"size,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+	DownloadURL string     `json:"download_url,omitempty"`
+}
+
+// newExportJobResponse converts an export job to its JSON representation
+func newExportJobResponse(job *application.ExportJob) ExportJobResponse {
+	response := ExportJobResponse{
+		ID:         job.ID,
+		Kind:       job.Kind,
+		Status:     string(job.Status),
+		Error:      job.Error,
+		Size:       job.Size,
+		CreatedAt:  job.CreatedAt,
+		FinishedAt: job.FinishedAt,
+	}
+	if job.Status == application.ExportJobDone {
+		response.DownloadURL = "/api/exports/" + job.ID + "/download"
+	}
+	return response
+}
+
+// RequestExport handles POST /api/exports, returning 202 with the job to poll. Small task lists
+// are exported right away, so the job may already be done.
+func (h *ExportJobHandler) RequestExport(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	job, err := h.requestExport.Execute(r.Context(), userID)
+	if err != nil {
+		status, message := exportJobErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/api/exports/"+job.ID)
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(newExportJobResponse(job))
+}
+
+// GetExport handles GET /api/exports/{id}
+func (h *ExportJobHandler) GetExport(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	job, err := h.getExportJob.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		status, message := exportJobErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newExportJobResponse(job))
+}
+
+// DownloadExport handles GET /api/exports/{id}/download, answering 409 until the file is ready
+func (h *ExportJobHandler) DownloadExport(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	job, pdf, err := h.downloadExport.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		status, message := exportJobErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	writeExportFile(w, job, pdf)
+}
+
+// WebRequestExport handles POST /web/exports, rendering the progress of the new export
+func (h *ExportJobHandler) WebRequestExport(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	job, err := h.requestExport.Execute(r.Context(), userID)
+	if err != nil {
+		h.writeWebError(w, r, err)
+		return
+	}
+	// Browsers without JavaScript go to the progress page instead
+	if !IsHTMX(r) {
+		writeWebRedirect(w, r, "/web/exports/"+url.PathEscape(job.ID))
+		return
+	}
+	h.writeExportStatus(w, r, job)
+}
+
+// WebGetExport handles GET /web/exports/{id}, polled by the progress of an export
+func (h *ExportJobHandler) WebGetExport(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	job, err := h.getExportJob.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		h.writeWebError(w, r, err)
+		return
+	}
+	h.writeExportStatus(w, r, job)
+}
+
+// WebDownloadExport handles GET /web/exports/{id}/download
+func (h *ExportJobHandler) WebDownloadExport(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	job, pdf, err := h.downloadExport.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		h.writeWebError(w, r, err)
+		return
+	}
+
+	writeExportFile(w, job, pdf)
+}
+
+// writeExportStatus writes the progress fragment of an export
+func (h *ExportJobHandler) writeExportStatus(w http.ResponseWriter, r *http.Request, job *application.ExportJob) {
+	fragment, err := renderExportStatus(job, RequestLocale(r))
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.pages.writeWebFragment(w, r, http.StatusOK, fragment)
+}
+
+// writeWebError writes the error of an export from the web interface
+func (h *ExportJobHandler) writeWebError(w http.ResponseWriter, r *http.Request, err error) {
+	status, message := exportJobErrorStatus(err)
+	h.pages.writeWebError(w, r, status, i18n.Error(RequestLocale(r), message))
+}
+
+// writeExportFile writes the file of a finished export as a download
+func writeExportFile(w http.ResponseWriter, job *application.ExportJob, pdf []byte) {
+	finishedAt := job.CreatedAt
+	if job.FinishedAt != nil {
+		finishedAt = *job.FinishedAt
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=tarefas_%s.pdf", finishedAt.Local().Format("20060102_150405")))
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(pdf)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(pdf)
+}
+
+// exportJobErrorStatus maps an export job use case error to an HTTP status and message
+func exportJobErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, application.ErrExportJobNotFound):
+		return http.StatusNotFound, "Export not found"
+	case errors.Is(err, application.ErrExportNotReady):
+		return http.StatusConflict, "Export is not ready yet"
+	default:
+		log.Printf("export job error: %v", err)
+		return http.StatusInternalServerError, "Failed to generate PDF"
+	}
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// mockExportJobs keeps the export jobs of user-123 in memory
+type mockExportJobs struct {
+	jobs      map[string]*application.ExportJob
+	newStatus application.ExportJobStatus
+}
+
+func newMockExportJobs() *mockExportJobs {
+	finishedAt := time.Date(2026, 3, 10, 12, 0, 5, 0, time.UTC)
+	return &mockExportJobs{
+		newStatus: application.ExportJobPending,
+		jobs: map[string]*application.ExportJob{
+			"job-pending": {ID: "job-pending", UserID: "user-123", Kind: application.ExportKindTasksPDF, Status: application.ExportJobPending},
+			"job-done":    {ID: "job-done", UserID: "user-123", Kind: application.ExportKindTasksPDF, Status: application.ExportJobDone, Size: 8, FinishedAt: &finishedAt},
+			"job-failed":  {ID: "job-failed", UserID: "user-123", Kind: application.ExportKindTasksPDF, Status: application.ExportJobFailed, Error: "Failed to generate PDF"},
+		},
+	}
+}
+
+// Execute requests an export
+func (m *mockExportJobs) Execute(ctx context.Context, userID string) (*application.ExportJob, error) {
+	job, _ := application.NewExportJob("job-new", userID, application.ExportKindTasksPDF, time.Now())
+	job.Status = m.newStatus
+	m.jobs[job.ID] = job
+	return job, nil
+}
+
+func (m *mockExportJobs) find(jobID, userID string) (*application.ExportJob, error) {
+	job, ok := m.jobs[jobID]
+	if !ok || job.UserID != userID {
+		return nil, application.ErrExportJobNotFound
+	}
+	return job, nil
+}
+
+type mockGetExportJob struct{ m *mockExportJobs }
+
+func (g *mockGetExportJob) Execute(ctx context.Context, jobID, userID string) (*application.ExportJob, error) {
+	return g.m.find(jobID, userID)
+}
+
+type mockDownloadExport struct{ m *mockExportJobs }
+
+func (d *mockDownloadExport) Execute(ctx context.Context, jobID, userID string) (*application.ExportJob, []byte, error) {
+	job, err := d.m.find(jobID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if job.Status != application.ExportJobDone {
+		return nil, nil, application.ErrExportNotReady
+	}
+	return job, []byte("%PDF-1.4"), nil
+}
+
+func newExportJobHandlerForTest() (*ExportJobHandler, *mockExportJobs) {
+	m := newMockExportJobs()
+	return NewExportJobHandler(m, &mockGetExportJob{m}, &mockDownloadExport{m}, NewPageTemplates("")), m
+}
+
+func TestExportJobHandler_RequestExport(t *testing.T) {
+	h, _ := newExportJobHandlerForTest()
+	w := httptest.NewRecorder()
+	h.RequestExport(w, withUser(httptest.NewRequest("POST", "/api/exports", nil)))
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want 202; body = %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Location"); got != "/api/exports/job-new" {
+		t.Errorf("Location = %q", got)
+	}
+	var response ExportJobResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if response.ID != "job-new" || response.Status != "pending" || response.DownloadURL != "" {
+		t.Errorf("response = %+v", response)
+	}
+}
+
+func TestExportJobHandler_API(t *testing.T) {
+	tests := []struct {
+		name       string
+		download   bool
+		jobID      string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "pending job", jobID: "job-pending", wantStatus: http.StatusOK, wantBody: `"status":"pending"`},
+		{name: "done job links its file", jobID: "job-done", wantStatus: http.StatusOK, wantBody: `"size":8,"created_at":"0001-01-01T00:00:00Z","finished_at":"2026-03-10T12:00:05Z","download_url":"/api/exports/job-done/download"`},
+		{name: "failed job", jobID: "job-failed", wantStatus: http.StatusOK, wantBody: `"status":"failed","error":"Failed to generate PDF"`},
+		{name: "unknown job", jobID: "job-9", wantStatus: http.StatusNotFound, wantBody: `"code":"not_found"`},
+		{name: "download before it's ready", download: true, jobID: "job-pending", wantStatus: http.StatusConflict, wantBody: `"code":"conflict"`},
+		{name: "download unknown job", download: true, jobID: "job-9", wantStatus: http.StatusNotFound, wantBody: `"code":"not_found"`},
+		{name: "download", download: true, jobID: "job-done", wantStatus: http.StatusOK, wantBody: "%PDF-1.4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newExportJobHandlerForTest()
+			req := withUser(httptest.NewRequest("GET", "/api/exports/"+tt.jobID, nil))
+			req.SetPathValue("id", tt.jobID)
+			w := httptest.NewRecorder()
+			if tt.download {
+				h.DownloadExport(w, req)
+			} else {
+				h.GetExport(w, req)
+			}
+
+			if w.Code != tt.wantStatus || !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("status = %d, body = %s; want %d with %s", w.Code, w.Body.String(), tt.wantStatus, tt.wantBody)
+			}
+		})
+	}
+
+	t.Run("download headers", func(t *testing.T) {
+		h, _ := newExportJobHandlerForTest()
+		req := withUser(httptest.NewRequest("GET", "/api/exports/job-done/download", nil))
+		req.SetPathValue("id", "job-done")
+		w := httptest.NewRecorder()
+		h.DownloadExport(w, req)
+
+		if got := w.Header().Get("Content-Type"); got != "application/pdf" {
+			t.Errorf("Content-Type = %q", got)
+		}
+		if got := w.Header().Get("Content-Disposition"); !strings.HasPrefix(got, "attachment; filename=tarefas_") {
+			t.Errorf("Content-Disposition = %q", got)
+		}
+	})
+}
+
+func TestExportJobHandler_Web(t *testing.T) {
+	t.Run("request renders a polling status", func(t *testing.T) {
+		h, _ := newExportJobHandlerForTest()
+		w := httptest.NewRecorder()
+		h.WebRequestExport(w, withUser(htmx(httptest.NewRequest("POST", "/web/exports", nil))))
+
+		body := w.Body.String()
+		if w.Code != http.StatusOK || !strings.Contains(body, `hx-get="/web/exports/job-new" hx-trigger="every 2s"`) || !strings.Contains(body, "Gerando o PDF") {
+			t.Errorf("status = %d, body = %s", w.Code, body)
+		}
+	})
+
+	t.Run("small export links the download at once", func(t *testing.T) {
+		h, m := newExportJobHandlerForTest()
+		m.newStatus = application.ExportJobDone
+		w := httptest.NewRecorder()
+		h.WebRequestExport(w, withUser(htmx(httptest.NewRequest("POST", "/web/exports", nil))))
+
+		body := w.Body.String()
+		if !strings.Contains(body, `href="/web/exports/job-new/download"`) || strings.Contains(body, "hx-trigger") {
+			t.Errorf("body = %s", body)
+		}
+	})
+
+	tests := []struct {
+		name       string
+		jobID      string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "pending keeps polling", jobID: "job-pending", wantStatus: http.StatusOK, wantBody: `hx-trigger="every 2s"`},
+		{name: "done links the download", jobID: "job-done", wantStatus: http.StatusOK, wantBody: `href="/web/exports/job-done/download"`},
+		{name: "failed shows an alert", jobID: "job-failed", wantStatus: http.StatusOK, wantBody: `role="alert"`},
+		{name: "unknown job", jobID: "job-9", wantStatus: http.StatusNotFound, wantBody: "Exportação não encontrada"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newExportJobHandlerForTest()
+			req := withUser(htmx(httptest.NewRequest("GET", "/web/exports/"+tt.jobID, nil)))
+			req.SetPathValue("id", tt.jobID)
+			w := httptest.NewRecorder()
+			h.WebGetExport(w, req)
+
+			if w.Code != tt.wantStatus || !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("status = %d, body = %s; want %d with %s", w.Code, w.Body.String(), tt.wantStatus, tt.wantBody)
+			}
+		})
+	}
+
+	t.Run("download", func(t *testing.T) {
+		h, _ := newExportJobHandlerForTest()
+		req := withUser(htmx(httptest.NewRequest("GET", "/web/exports/job-done/download", nil)))
+		req.SetPathValue("id", "job-done")
+		w := httptest.NewRecorder()
+		h.WebDownloadExport(w, req)
+
+		if w.Code != http.StatusOK || w.Body.String() != "%PDF-1.4" || w.Header().Get("Content-Type") != "application/pdf" {
+			t.Errorf("status = %d, body = %q", w.Code, w.Body.String())
+		}
+	})
+}
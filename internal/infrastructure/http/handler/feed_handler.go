@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/markdown"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// FeedHandler handles the Atom feed of the tasks of a user, read by feed readers with a feed
+// token instead of a JWT, and the management of that token
+type FeedHandler struct {
+	createToken usecases.CreateFeedTokenUseCaseInterface
+	revokeToken usecases.RevokeFeedTokenUseCaseInterface
+	getFeed     usecases.GetTaskFeedUseCaseInterface
+	ownerNames  usecases.GetOwnerNamesUseCaseInterface
+	baseURL     string
+}
+
+// NewFeedHandler creates a new FeedHandler building the URLs of the feed on baseURL, the
+// public URL of the app
+func NewFeedHandler(
+	createToken usecases.CreateFeedTokenUseCaseInterface,
+	revokeToken usecases.RevokeFeedTokenUseCaseInterface,
+	getFeed usecases.GetTaskFeedUseCaseInterface,
+	ownerNames usecases.GetOwnerNamesUseCaseInterface,
+	baseURL string,
+) *FeedHandler {
+	return &FeedHandler{
+		createToken: createToken,
+		revokeToken: revokeToken,
+		getFeed:     getFeed,
+		ownerNames:  ownerNames,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// FeedTokenResponse is the JSON representation of a new feed token, with the URL of the feed
+// it opens. It is only returned when the token is created.
+type FeedTokenResponse struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+// feedURL returns the URL of the feed, with the token when one is given
+func (h *FeedHandler) feedURL(token string) string {
+	if token == "" {
+		return h.baseURL + "/api/tasks.atom"
+	}
+	return h.baseURL + "/api/tasks.atom?token=" + url.QueryEscape(token)
+}
+
+// CreateFeedToken handles POST /api/me/feed-token, replacing the feed token of the user
+func (h *FeedHandler) CreateFeedToken(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	token, err := h.createToken.Execute(r.Context(), userID)
+	if err != nil {
+		status, message := feedErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(FeedTokenResponse{Token: token, URL: h.feedURL(token)})
+}
+
+// RevokeFeedToken handles DELETE /api/me/feed-token
+func (h *FeedHandler) RevokeFeedToken(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := h.revokeToken.Execute(r.Context(), userID); err != nil {
+		status, message := feedErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// The elements of an Atom feed (RFC 4287) this handler writes
+type (
+	atomFeed struct {
+		XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+		ID      string      `xml:"id"`
+		Title   string      `xml:"title"`
+		Updated string      `xml:"updated"`
+		Author  atomPerson  `xml:"author"`
+		Links   []atomLink  `xml:"link"`
+		Entries []atomEntry `xml:"entry"`
+	}
+
+	atomEntry struct {
+		ID         string         `xml:"id"`
+		Title      string         `xml:"title"`
+		Published  string         `xml:"published"`
+		Updated    string         `xml:"updated"`
+		Author     atomPerson     `xml:"author"`
+		Link       atomLink       `xml:"link"`
+		Categories []atomCategory `xml:"category"`
+		Content    *atomContent   `xml:"content,omitempty"`
+	}
+
+	atomPerson struct {
+		Name string `xml:"name"`
+	}
+
+	atomLink struct {
+		Rel  string `xml:"rel,attr,omitempty"`
+		Type string `xml:"type,attr,omitempty"`
+		Href string `xml:"href,attr"`
+	}
+
+	atomCategory struct {
+		Term  string `xml:"term,attr"`
+		Label string `xml:"label,attr,omitempty"`
+	}
+
+	atomContent struct {
+		Type string `xml:"type,attr"`
+		Body string `xml:",chardata"`
+	}
+)
+
+// TaskFeed handles GET /api/tasks.atom?token=..., the Atom feed of the most recently created
+// or updated tasks of the user the token belongs to. The token is the only authorization;
+// unknown and revoked tokens get a 404.
+func (h *FeedHandler) TaskFeed(w http.ResponseWriter, r *http.Request) {
+	user, tasks, err := h.getFeed.Execute(r.Context(), r.URL.Query().Get("token"))
+	if err != nil {
+		status, message := feedErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	locale := user.Locale
+	if locale == "" {
+		locale = i18n.Default
+	}
+	names, err := h.ownerNames.Execute(r.Context(), tasks)
+	if err != nil {
+		// The feed still works without the names of the owners of shared tasks
+		log.Printf("feed: failed to load owner names: %v", err)
+	}
+
+	updated := user.CreatedAt
+	if len(tasks) > 0 {
+		updated = tasks[0].UpdatedAt
+	}
+	feed := atomFeed{
+		ID:      "urn:todo:feed:" + user.ID,
+		Title:   i18n.T(locale, "feed.title", user.Name),
+		Updated: atomTime(updated),
+		Author:  atomPerson{Name: user.Name},
+		Links: []atomLink{
+			{Rel: "self", Type: "application/atom+xml", Href: h.feedURL("")},
+			{Rel: "alternate", Type: "text/html", Href: h.baseURL + "/tasks"},
+		},
+	}
+	for _, task := range tasks {
+		author := user.Name
+		if task.OwnerID != user.ID {
+			author = names[task.OwnerID]
+		}
+		entry := atomEntry{
+			ID:         "urn:todo:task:" + task.ID,
+			Title:      task.Title,
+			Published:  atomTime(task.CreatedAt),
+			Updated:    atomTime(task.UpdatedAt),
+			Author:     atomPerson{Name: author},
+			Link:       atomLink{Rel: "alternate", Type: "text/html", Href: h.baseURL + "/tasks#task-" + task.ID},
+			Categories: []atomCategory{{Term: string(task.Status), Label: i18n.T(locale, "task.status."+string(task.Status))}},
+		}
+		for _, tag := range task.Tags {
+			entry.Categories = append(entry.Categories, atomCategory{Term: "#" + tag})
+		}
+		if task.Description != "" {
+			entry.Content = &atomContent{Type: "html", Body: string(markdown.Render(task.Description))}
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Set("Last-Modified", updated.UTC().Format(http.TimeFormat))
+	w.Header().Set("X-Robots-Tag", "noindex")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(xml.Header))
+	if err := xml.NewEncoder(w).Encode(feed); err != nil {
+		log.Printf("feed: failed to write the feed: %v", err)
+	}
+}
+
+// atomTime formats a time as an Atom date
+func atomTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// feedErrorStatus maps a feed use case error to an HTTP status and client message
+func feedErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, application.ErrFeedTokenNotFound):
+		return http.StatusNotFound, err.Error()
+	default:
+		log.Printf("feed operation failed: %v", err)
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
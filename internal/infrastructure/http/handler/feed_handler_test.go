@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+type mockGetTaskFeedUseCase struct {
+	user  *application.User
+	tasks []*application.Task
+	err   error
+}
+
+func (m *mockGetTaskFeedUseCase) Execute(ctx context.Context, token string) (*application.User, []*application.Task, error) {
+	return m.user, m.tasks, m.err
+}
+
+func TestTaskFeed(t *testing.T) {
+	updated := time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+	getFeed := &mockGetTaskFeedUseCase{
+		user: &application.User{ID: "ana", Name: "Ana", Locale: application.LocaleEN},
+		tasks: []*application.Task{
+			{ID: "t-2", Title: "Parecer & ofício", Status: application.StatusInProgress, OwnerID: "bia", Tags: []string{"receita"}, CreatedAt: updated, UpdatedAt: updated},
+			{ID: "t-1", Title: "Relatório", Status: application.StatusPending, OwnerID: "ana", CreatedAt: updated.Add(-time.Hour), UpdatedAt: updated.Add(-time.Hour)},
+		},
+	}
+	ownerNames := &mockGetOwnerNamesUseCase{names: map[string]string{"bia": "Bia"}}
+	h := NewFeedHandler(nil, nil, getFeed, ownerNames, "https://todo.example.com/")
+
+	w := httptest.NewRecorder()
+	h.TaskFeed(w, httptest.NewRequest("GET", "/api/tasks.atom?token=secret", nil))
+
+	if w.Code != http.StatusOK || w.Header().Get("Last-Modified") != "Tue, 10 Mar 2026 09:30:00 GMT" {
+		t.Fatalf("status = %d, Last-Modified = %q: %s", w.Code, w.Header().Get("Last-Modified"), w.Body.String())
+	}
+	var feed atomFeed
+	if err := xml.Unmarshal(w.Body.Bytes(), &feed); err != nil {
+		t.Fatalf("invalid feed: %v\n%s", err, w.Body.String())
+	}
+	if feed.Title != "Tasks of Ana" || feed.Updated != "2026-03-10T09:30:00Z" || feed.Links[0].Href != "https://todo.example.com/api/tasks.atom" {
+		t.Errorf("feed = %+v", feed)
+	}
+	if len(feed.Entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(feed.Entries))
+	}
+	shared := feed.Entries[0]
+	if shared.ID != "urn:todo:task:t-2" || shared.Title != "Parecer & ofício" || shared.Author.Name != "Bia" ||
+		shared.Link.Href != "https://todo.example.com/tasks#task-t-2" || len(shared.Categories) != 2 ||
+		shared.Categories[0].Label != "In Progress" || shared.Categories[1].Term != "#receita" || shared.Content != nil {
+		t.Errorf("shared task entry = %+v", shared)
+	}
+	if feed.Entries[1].Author.Name != "Ana" {
+		t.Errorf("own task author = %q, want Ana", feed.Entries[1].Author.Name)
+	}
+}
+
+func TestTaskFeed_UnknownToken(t *testing.T) {
+	h := NewFeedHandler(nil, nil, &mockGetTaskFeedUseCase{err: application.ErrFeedTokenNotFound}, &mockGetOwnerNamesUseCase{}, "")
+
+	w := httptest.NewRecorder()
+	h.TaskFeed(w, httptest.NewRequest("GET", "/api/tasks.atom?token=nope", nil))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", w.Code)
+	}
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+)
+
+// webForm describes a web form whose validation errors are rendered inline. Its messages go
+// to the error container; each field has an error slot below its input, with the id of the
+// input followed by "-error", filled or cleared by out-of-band swaps.
+type webForm struct {
+	errorsID string   // id of the error container
+	fields   []string // ids of the inputs with an error slot
+}
+
+var (
+	loginForm      = webForm{errorsID: "error-message", fields: []string{"email", "password"}}
+	registerForm   = webForm{errorsID: "error-message", fields: []string{"name", "email", "password"}}
+	twoFactorForm  = webForm{errorsID: "error-message"} // Replaces the login form once the password is verified
+	createTaskForm = webForm{errorsID: "create-task-errors", fields: []string{"title", "description", "due_date", "color", "image"}}
+)
+
+// shareForm returns the share form of a task card
+func shareForm(taskID string) webForm {
+	return webForm{errorsID: "share-" + taskID + "-errors", fields: []string{"share-search-" + taskID}}
+}
+
+// FormErrors are the validation errors of a web form submission
+type FormErrors struct {
+	Message string            // About the whole form; empty shows a generic message when a field has an error
+	Fields  map[string]string // By input id
+}
+
+// formError returns the errors of a submission whose only error is message. It goes below the
+// input named by field, or to the error container when field is empty.
+func formError(field, message string) FormErrors {
+	if field == "" {
+		return FormErrors{Message: message}
+	}
+	return FormErrors{Fields: map[string]string{field: message}}
+}
+
+// fieldErrors maps the validation messages of the use cases to the form field they are about
+var fieldErrors = map[string]string{
+	"user name cannot be empty":                      "name",
+	"user name cannot exceed 100 characters":         "name",
+	"user email cannot be empty":                     "email",
+	"invalid email format":                           "email",
+	"email already registered":                       "email",
+	"password must be at least 8 characters":         "password",
+	"task title cannot be empty":                     "title",
+	"task title cannot exceed 200 characters":        "title",
+	"task description cannot exceed 1000 characters": "description",
+	"due date must be between 2000 and 2099":         "due_date",
+}
+
+// useCaseFormError returns the errors of a submission rejected by a use case, below the field
+// the message is about when it is one of form. Every field of an *application.ValidationError
+// gets its own error; the errors of fields the form doesn't have go to the error container.
+func useCaseFormError(form webForm, err error) FormErrors {
+	var invalid *application.ValidationError
+	if errors.As(err, &invalid) {
+		return validationFormErrors(form, invalid)
+	}
+
+	field := fieldErrors[err.Error()]
+	for _, id := range form.fields {
+		if id == field {
+			return formError(field, err.Error())
+		}
+	}
+	return formError("", err.Error())
+}
+
+// validationFormErrors places the field errors of a rejected entity below the inputs of form,
+// keeping the first error of each field
+func validationFormErrors(form webForm, invalid *application.ValidationError) FormErrors {
+	errs := FormErrors{Fields: make(map[string]string)}
+	var others []string
+	for _, f := range invalid.Fields {
+		if !slices.Contains(form.fields, f.Field) {
+			others = append(others, f.Message)
+			continue
+		}
+		if _, ok := errs.Fields[f.Field]; !ok {
+			errs.Fields[f.Field] = f.Message
+		}
+	}
+	errs.Message = strings.Join(others, "; ")
+	return errs
+}
+
+// formErrorsTemplate is the template for the errors of a web form: the message in the error
+// container, which the response is retargeted to, and the field slots swapped out of band
+var formErrorsTemplate = template.Must(template.New("formErrors").Parse(`{{if .Message}}<div class="bg-red-100 border border-red-400 text-red-700 px-4 py-3 rounded" role="alert">{{.Message}}</div>{{end}}
+{{range .Slots}}<p id="{{.ID}}-error" data-field-error="{{.ID}}" class="mt-1 text-sm text-red-600 dark:text-red-400" aria-live="polite" hx-swap-oob="true">{{.Message}}</p>
+{{end}}`))
+
+// formErrorSlot is the error slot of a form field
+type formErrorSlot struct {
+	ID      string
+	Message string
+}
+
+// renderFormErrors renders the localized errors of form. Every field slot is included, so the
+// fields fixed since the last submission are cleared; a zero FormErrors clears the whole form.
+func renderFormErrors(form webForm, errs FormErrors, locale application.Locale) (string, error) {
+	slots := make([]formErrorSlot, len(form.fields))
+	for i, id := range form.fields {
+		slots[i] = formErrorSlot{ID: id, Message: errs.Fields[id]}
+	}
+
+	message := errs.Message
+	if message == "" && len(errs.Fields) > 0 {
+		message = i18n.T(locale, "form.invalid")
+	}
+
+	var buf bytes.Buffer
+	err := formErrorsTemplate.Execute(&buf, map[string]interface{}{
+		"Message": message,
+		"Slots":   slots,
+	})
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// writeFormErrors answers a web form submitted with HTMX with its validation errors, translated
+// like the API errors (see LocalizeError). The response is retargeted to the error container
+// of the form, whatever the form swaps on success; base.html lets HTMX swap it despite the
+// error status.
+func (p *PageTemplates) writeFormErrors(w http.ResponseWriter, r *http.Request, status int, form webForm, errs FormErrors) {
+	localized := FormErrors{Message: LocalizeError(r, errs.Message), Fields: make(map[string]string, len(errs.Fields))}
+	for id, message := range errs.Fields {
+		localized.Fields[id] = LocalizeError(r, message)
+	}
+
+	fragment, err := renderFormErrors(form, localized, RequestLocale(r))
+	if err != nil {
+		log.Printf("failed to render form errors: %v", err)
+		p.writeWebError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.Header().Set("HX-Retarget", "#"+form.errorsID)
+	w.Header().Set("HX-Reswap", "innerHTML")
+	p.writeWebFragment(w, r, status, fragment)
+}
+
+// clearFormErrors returns the out-of-band swaps clearing the errors of form, sent along with
+// a successful response
+func clearFormErrors(form webForm, locale application.Locale) string {
+	fragment, err := renderFormErrors(form, FormErrors{}, locale)
+	if err != nil {
+		log.Printf("failed to render form errors: %v", err)
+		return ""
+	}
+	return `<div id="` + form.errorsID + `" hx-swap-oob="innerHTML"></div>` + fragment
+}
@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockShareTaskUseCase struct {
+	results []*application.ShareResult
+	err     error
+}
+
+func (m *mockShareTaskUseCase) Execute(ctx context.Context, taskID, ownerID string, recipients []string) ([]*application.ShareResult, error) {
+	return m.results, m.err
+}
+
+func TestRenderFormErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		errs    FormErrors
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "field error fills its slot and clears the others",
+			errs: formError("due_date", "invalid due date"),
+			want: []string{
+				"Corrija os campos destacados.",
+				`<p id="due_date-error" data-field-error="due_date" class="mt-1 text-sm text-red-600 dark:text-red-400" aria-live="polite" hx-swap-oob="true">invalid due date</p>`,
+				`<p id="title-error" data-field-error="title" class="mt-1 text-sm text-red-600 dark:text-red-400" aria-live="polite" hx-swap-oob="true"></p>`,
+			},
+		},
+		{
+			name:    "form error goes to the container",
+			errs:    formError("", "<b>boom</b>"),
+			want:    []string{`role="alert">&lt;b&gt;boom&lt;/b&gt;</div>`, `id="title-error"`},
+			notWant: []string{"Corrija os campos destacados.", "<b>"},
+		},
+		{
+			name:    "no errors clears every slot",
+			want:    []string{`id="title-error"`, `id="image-error"`},
+			notWant: []string{`role="alert"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderFormErrors(createTaskForm, tt.errs, application.LocalePtBR)
+			if err != nil {
+				t.Fatalf("renderFormErrors() error: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("Expected %q in:\n%s", want, got)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("Did not expect %q in:\n%s", notWant, got)
+				}
+			}
+		})
+	}
+}
+
+func TestWebForms_InlineErrors(t *testing.T) {
+	failingLogin := &AuthHandler{loginUseCase: &mockLoginUseCase{
+		executeFunc: func(ctx context.Context, email, password string) (string, error) {
+			return "", application.ErrInvalidCredentials
+		},
+	}}
+	takenEmail := &AuthHandler{registerUseCase: &mockRegisterUseCase{
+		executeFunc: func(ctx context.Context, name, email, password string) (*application.User, error) {
+			return nil, errors.New("email already registered")
+		},
+	}}
+	emptyTitle := NewWebTaskHandler(&mockCreateTaskUseCase{
+		executeFunc: func(ctx context.Context, title, description, ownerID, imagePath string, dueDate *time.Time) (*application.Task, error) {
+			return nil, errors.New("task title cannot be empty")
+		},
+	}, nil, nil, &mockShareTaskUseCase{results: []*application.ShareResult{{Recipient: "user-123", Err: application.ErrCannotShareTaskToSelf}}}, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
+	notOwner := NewWebTaskHandler(nil, nil, nil, &mockShareTaskUseCase{err: usecases.ErrShareTaskPermissionDenied}, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
+
+	tests := []struct {
+		name         string
+		handler      http.HandlerFunc
+		path         string
+		form         url.Values
+		wantStatus   int
+		wantRetarget string
+		want         []string
+	}{
+		{
+			name:         "login without fields",
+			handler:      failingLogin.WebLogin,
+			path:         "/web/auth/login",
+			form:         url.Values{"email": {" "}},
+			wantStatus:   http.StatusBadRequest,
+			wantRetarget: "#error-message",
+			want:         []string{`id="email-error"`, "email is required", "password is required"},
+		},
+		{
+			name:         "login with wrong credentials",
+			handler:      failingLogin.WebLogin,
+			path:         "/web/auth/login",
+			form:         url.Values{"email": {"ana@example.com"}, "password": {"wrong"}},
+			wantStatus:   http.StatusUnauthorized,
+			wantRetarget: "#error-message",
+			want:         []string{"Credenciais inválidas", `id="password-error"`},
+		},
+		{
+			name:         "register with a taken email",
+			handler:      takenEmail.WebRegister,
+			path:         "/web/auth/register",
+			form:         url.Values{"name": {"Ana"}, "email": {"ana@example.com"}, "password": {"Senha123!"}},
+			wantStatus:   http.StatusBadRequest,
+			wantRetarget: "#error-message",
+			want:         []string{`hx-swap-oob="true">email already registered</p>`},
+		},
+		{
+			name:         "create task without a title",
+			handler:      emptyTitle.CreateTask,
+			path:         "/web/tasks",
+			form:         url.Values{"title": {""}},
+			wantStatus:   http.StatusBadRequest,
+			wantRetarget: "#create-task-errors",
+			want:         []string{`hx-swap-oob="true">task title cannot be empty</p>`},
+		},
+		{
+			name:         "create task with a bad due date",
+			handler:      emptyTitle.CreateTask,
+			path:         "/web/tasks",
+			form:         url.Values{"title": {"Relatório"}, "due_date": {"someday"}},
+			wantStatus:   http.StatusBadRequest,
+			wantRetarget: "#create-task-errors",
+			want:         []string{`<p id="due_date-error"`, `<p id="title-error" data-field-error="title" class="mt-1 text-sm text-red-600 dark:text-red-400" aria-live="polite" hx-swap-oob="true"></p>`},
+		},
+		{
+			name:         "share without a user",
+			handler:      emptyTitle.ShareTask,
+			path:         "/web/tasks/task-1/share",
+			form:         url.Values{},
+			wantStatus:   http.StatusBadRequest,
+			wantRetarget: "#share-task-1-errors",
+			want:         []string{`<p id="share-search-task-1-error"`, "share_with_user_id is required"},
+		},
+		{
+			name:         "share with yourself",
+			handler:      emptyTitle.ShareTask,
+			path:         "/web/tasks/task-1/share",
+			form:         url.Values{"share_with_user_id": {"user-123"}},
+			wantStatus:   http.StatusBadRequest,
+			wantRetarget: "#share-task-1-errors",
+			want:         []string{`hx-swap-oob="true">cannot share task with yourself</p>`},
+		},
+		{
+			name:         "share a task of someone else",
+			handler:      notOwner.ShareTask,
+			path:         "/web/tasks/task-1/share",
+			form:         url.Values{"share_with_user_id": {"user-456"}},
+			wantStatus:   http.StatusForbidden,
+			wantRetarget: "#share-task-1-errors",
+			want:         []string{`role="alert">only the task owner can share the task</div>`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := htmx(httptest.NewRequest("POST", tt.path, strings.NewReader(tt.form.Encode())))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.SetPathValue("id", "task-1")
+			w := httptest.NewRecorder()
+
+			tt.handler(w, withUser(req))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("HX-Retarget"); got != tt.wantRetarget {
+				t.Errorf("Expected HX-Retarget %q, got %q", tt.wantRetarget, got)
+			}
+			if got := w.Header().Get("HX-Reswap"); got != "innerHTML" {
+				t.Errorf("Expected HX-Reswap innerHTML, got %q", got)
+			}
+			body := w.Body.String()
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("Expected %q in:\n%s", want, body)
+				}
+			}
+		})
+	}
+}
+
+func TestWebCreateTask_ClearsFormErrors(t *testing.T) {
+	handler := NewWebTaskHandler(&mockCreateTaskUseCase{}, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
+
+	req := htmx(httptest.NewRequest("POST", "/web/tasks", strings.NewReader("title=Relat%C3%B3rio")))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	handler.CreateTask(w, withUser(req))
+
+	if w.Code != http.StatusOK || w.Header().Get("HX-Retarget") != "" {
+		t.Fatalf("status = %d, HX-Retarget = %q", w.Code, w.Header().Get("HX-Retarget"))
+	}
+	body := w.Body.String()
+	for _, want := range []string{`<div id="create-task-errors" hx-swap-oob="innerHTML"></div>`, `<p id="title-error"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %q in:\n%s", want, body)
+		}
+	}
+}
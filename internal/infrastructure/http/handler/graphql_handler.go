@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/graphql"
+)
+
+// maxGraphQLBodySize limits the body of a GraphQL request: the query text and its variables
+const maxGraphQLBodySize = 64 << 10 // 64KB
+
+// GraphQLHandler handles the GraphQL API, which reads tasks together with their owners and
+// sharees in one round trip. Writes stay in the REST API.
+type GraphQLHandler struct {
+	schema *graphql.Schema
+}
+
+// NewGraphQLHandler creates a new GraphQLHandler
+func NewGraphQLHandler(schema *graphql.Schema) *GraphQLHandler {
+	return &GraphQLHandler{schema: schema}
+}
+
+// Query handles POST /api/graphql. Requests that can't be executed, like one with a syntax
+// error or an unknown field, answer 400; errors while executing are reported next to the data
+// with 200, as GraphQL clients expect.
+func (h *GraphQLHandler) Query(w http.ResponseWriter, r *http.Request) {
+	var req graphql.Request
+	if !decodeJSON(w, r, &req, maxGraphQLBodySize) {
+		return
+	}
+
+	response := h.schema.Execute(r.Context(), req)
+	status := http.StatusOK
+	if response.Data == nil {
+		status = http.StatusBadRequest
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
+
+// Schema handles GET /api/graphql/schema, the schema in the GraphQL schema definition
+// language for clients to generate code from
+func (h *GraphQLHandler) Schema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte(h.schema.SDL()))
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/graphql"
+)
+
+// viewerResolver resolves a schema with the ID of the user making the request
+type viewerResolver struct{}
+
+func (*viewerResolver) Viewer(ctx context.Context) graphqlgo.ID {
+	return graphqlgo.ID(ctx.Value("userID").(string))
+}
+
+func newTestGraphQLHandler() *GraphQLHandler {
+	return NewGraphQLHandler(graphql.MustSchema("type Query {\n  viewer: ID!\n}\n", &viewerResolver{}))
+}
+
+func TestGraphQLHandler_Query(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "query",
+			body:       `{"query": "{ viewer }", "extensions": {"persistedQuery": null}}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":{"viewer":"user-123"}}`,
+		},
+		{
+			name:       "invalid query",
+			body:       `{"query": "{ nope }"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"errors":[{"message":"Cannot query field \"nope\" on type \"Query\".","locations":[{"line":1,"column":3}]}]}`,
+		},
+		{
+			name:       "unknown member",
+			body:       `{"query": "{ viewer }", "other": 1}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	h := newTestGraphQLHandler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := withUser(httptest.NewRequest(http.MethodPost, "/api/graphql", strings.NewReader(tt.body)))
+			rec := httptest.NewRecorder()
+			h.Query(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" && strings.TrimSpace(rec.Body.String()) != tt.wantBody {
+				t.Errorf("body = %s, want %s", rec.Body.String(), tt.wantBody)
+			}
+			if !json.Valid(rec.Body.Bytes()) {
+				t.Errorf("body isn't JSON: %s", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestGraphQLHandler_Schema(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestGraphQLHandler().Schema(rec, withUser(httptest.NewRequest(http.MethodGet, "/api/graphql/schema", nil)))
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Content-Type = %q, want text/plain", ct)
+	}
+	if !strings.Contains(rec.Body.String(), "type Query {\n  viewer: ID!\n}") {
+		t.Errorf("body = %s, want the schema", rec.Body.String())
+	}
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+)
+
+// healthPingTimeout bounds the database ping of a health check, so a locked database
+// answers unhealthy instead of hanging the probe
+const healthPingTimeout = 2 * time.Second
+
+// HealthHandler answers the health checks of load balancers and orchestrators
+type HealthHandler struct {
+	ping func(ctx context.Context) error
+}
+
+// NewHealthHandler creates a new HealthHandler checking the database with ping, usually
+// (*sql.DB).PingContext
+func NewHealthHandler(ping func(ctx context.Context) error) *HealthHandler {
+	return &HealthHandler{ping: ping}
+}
+
+// HealthResponse is the body of GET /health
+type HealthResponse struct {
+	Status   string `json:"status"`
+	Database string `json:"database"`
+}
+
+// Health handles GET /health: 200 when the database answers, 503 otherwise
+func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), healthPingTimeout)
+	defer cancel()
+
+	status, response := http.StatusOK, HealthResponse{Status: "ok", Database: "ok"}
+	if err := h.ping(ctx); err != nil {
+		log.Printf("health check failed: %v", err)
+		status, response = http.StatusServiceUnavailable, HealthResponse{Status: "unavailable", Database: "unreachable"}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHealth(t *testing.T) {
+	tests := []struct {
+		name       string
+		pingErr    error
+		wantStatus int
+		wantBody   string
+	}{
+		{"database up", nil, http.StatusOK, `{"status":"ok","database":"ok"}`},
+		{"database down", errors.New("database is locked"), http.StatusServiceUnavailable, `{"status":"unavailable","database":"unreachable"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deadline bool
+			h := NewHealthHandler(func(ctx context.Context) error {
+				_, deadline = ctx.Deadline()
+				return tt.pingErr
+			})
+
+			w := httptest.NewRecorder()
+			h.Health(w, httptest.NewRequest("GET", "/health", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if strings.TrimSpace(w.Body.String()) != tt.wantBody {
+				t.Errorf("body = %s, want %s", w.Body.String(), tt.wantBody)
+			}
+			if !deadline {
+				t.Error("the ping should have a deadline")
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// ImageHandler serves uploaded task images to the users who can access their task
+type ImageHandler struct {
+	dir     string
+	canView usecases.CanViewTaskImageUseCaseInterface
+}
+
+// NewImageHandler creates a new ImageHandler serving the images stored in dir
+func NewImageHandler(dir string, canView usecases.CanViewTaskImageUseCaseInterface) *ImageHandler {
+	return &ImageHandler{
+		dir:     dir,
+		canView: canView,
+	}
+}
+
+// ServeImage handles GET /uploads/images/{name}. Images that don't exist and images of tasks
+// the user can't access both answer 404, so image names can't be probed.
+func (h *ImageHandler) ServeImage(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	name := r.PathValue("name")
+
+	fullPath, ok := resolveImage(h.dir, name)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	canView, err := h.canView.Execute(r.Context(), imageURLPrefix+name, userID)
+	if err != nil {
+		log.Printf("failed to check access to image %s: %v", name, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if !canView {
+		http.NotFound(w, r)
+		return
+	}
+
+	f, err := os.Open(fullPath)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+
+	// Image names hold a hash of their content, so a name never points to different bytes
+	w.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+	http.ServeContent(w, r, name, info.ModTime(), f)
+}
+
+// resolveImage returns the path of an image file in dir, rejecting names that aren't a plain
+// image file name or that would point outside dir
+func resolveImage(dir, name string) (string, bool) {
+	if name == "" || strings.ContainsAny(name, `/\`) || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "", false
+	}
+	if !allowedExtensions[strings.ToLower(filepath.Ext(name))] {
+		return "", false
+	}
+
+	fullPath := filepath.Join(dir, name)
+	rel, err := filepath.Rel(dir, fullPath)
+	if err != nil || rel != name {
+		return "", false
+	}
+	return fullPath, true
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type mockCanViewTaskImageUseCase struct {
+	allowed bool
+	err     error
+	checked string // image path of the last check
+}
+
+func (m *mockCanViewTaskImageUseCase) Execute(ctx context.Context, imagePath, userID string) (bool, error) {
+	m.checked = imagePath
+	return m.allowed, m.err
+}
+
+func TestServeImage(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "1700000000_abc.png"), []byte("png bytes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(filepath.Dir(dir), "secret.png"), []byte("secret"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		file        string
+		allowed     bool
+		err         error
+		wantStatus  int
+		wantChecked string
+	}{
+		{"task image", "1700000000_abc.png", true, nil, http.StatusOK, "/uploads/images/1700000000_abc.png"},
+		{"image of an inaccessible task", "1700000000_abc.png", false, nil, http.StatusNotFound, "/uploads/images/1700000000_abc.png"},
+		{"missing file", "1700000000_def.png", true, nil, http.StatusNotFound, "/uploads/images/1700000000_def.png"},
+		{"path traversal", "../secret.png", true, nil, http.StatusNotFound, ""},
+		{"not an image", "notes.txt", true, nil, http.StatusNotFound, ""},
+		{"hidden file", ".png", true, nil, http.StatusNotFound, ""},
+		{"access check failure", "1700000000_abc.png", false, errors.New("db down"), http.StatusInternalServerError, "/uploads/images/1700000000_abc.png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			canView := &mockCanViewTaskImageUseCase{allowed: tt.allowed, err: tt.err}
+			h := NewImageHandler(dir, canView)
+
+			req := httptest.NewRequest("GET", "/uploads/images/x", nil)
+			req.SetPathValue("name", tt.file)
+			w := httptest.NewRecorder()
+			h.ServeImage(w, withUser(req))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if canView.checked != tt.wantChecked {
+				t.Errorf("checked access to %q, want %q", canView.checked, tt.wantChecked)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if w.Body.String() != "png bytes" {
+				t.Errorf("body = %q, want the image", w.Body.String())
+			}
+			if got := w.Header().Get("Cache-Control"); got != "private, max-age=31536000, immutable" {
+				t.Errorf("Cache-Control = %q, want immutable", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// maxImportSize limits the size of an uploaded import file
+const maxImportSize = 5 << 20 // 5MB
+
+// errUnsupportedImportFormat is returned when the import file isn't CSV or JSON
+var errUnsupportedImportFormat = errors.New("import file must be CSV or JSON")
+
+// ImportHandler handles task import requests
+type ImportHandler struct {
+	importTasks usecases.ImportTasksUseCaseInterface
+	pages       *PageTemplates
+	location    *time.Location
+}
+
+// NewImportHandler creates a new ImportHandler
+func NewImportHandler(importTasks usecases.ImportTasksUseCaseInterface, pages *PageTemplates, location *time.Location) *ImportHandler {
+	return &ImportHandler{
+		importTasks: importTasks,
+		pages:       pages,
+		location:    location,
+	}
+}
+
+// ImportTasks handles POST /api/tasks/import with a CSV/JSON body or a multipart "file" field
+func (h *ImportHandler) ImportTasks(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	report, status, err := h.runImport(w, r, userID)
+	if err != nil {
+		if status == http.StatusTooManyRequests {
+			WriteJSONError(w, r, status, CodeCreationQuotaExceeded, err.Error())
+			return
+		}
+		writeAPIError(w, r, status, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if report.Created > 0 {
+		w.WriteHeader(http.StatusCreated)
+	} else {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+	json.NewEncoder(w).Encode(report)
+}
+
+// WebImportTasks handles the import form of the tasks page, returning an HTML fragment for HTMX
+func (h *ImportHandler) WebImportTasks(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok || userID == "" {
+		h.pages.writeWebError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	report, status, err := h.runImport(w, r, userID)
+	if err != nil {
+		h.pages.writeWebError(w, r, status, err.Error())
+		return
+	}
+
+	html, err := renderImportResult(report, userID, RequestLocale(r))
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.pages.writeWebFragment(w, r, http.StatusOK, html)
+}
+
+// runImport reads the import rows from the request and runs the import use case
+func (h *ImportHandler) runImport(w http.ResponseWriter, r *http.Request, userID string) (*usecases.ImportReport, int, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	rows, err := readImportRows(r)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesErr):
+			return nil, http.StatusRequestEntityTooLarge, errors.New("import file cannot exceed 5MB")
+		case errors.Is(err, errUnsupportedImportFormat):
+			return nil, http.StatusUnsupportedMediaType, err
+		default:
+			return nil, http.StatusBadRequest, err
+		}
+	}
+
+	strict := r.URL.Query().Get("strict") == "true" || r.FormValue("strict") == "on"
+
+	report, err := h.importTasks.Execute(r.Context(), userID, rows, requestLocation(r, h.location), strict)
+	if err != nil {
+		if errors.Is(err, usecases.ErrImportEmpty) || errors.Is(err, usecases.ErrImportTooLarge) {
+			return nil, http.StatusBadRequest, err
+		}
+		if exceeded := creationQuotaExceeded(w, err); exceeded != nil {
+			return nil, http.StatusTooManyRequests, errors.New(creationQuotaMessage(RequestLocale(r), exceeded))
+		}
+		return nil, http.StatusInternalServerError, errors.New("Failed to import tasks")
+	}
+
+	return report, http.StatusOK, nil
+}
+
+// readImportRows parses the import file from a multipart form or from the raw request body
+func readImportRows(r *http.Request) ([]usecases.ImportRow, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	switch mediaType {
+	case "multipart/form-data":
+		if err := r.ParseMultipartForm(maxImportSize); err != nil {
+			return nil, err
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			return nil, errors.New("import file is required")
+		}
+		defer file.Close()
+
+		switch strings.ToLower(filepath.Ext(header.Filename)) {
+		case ".csv":
+			return usecases.ParseImportCSV(file)
+		case ".json":
+			return usecases.ParseImportJSON(file)
+		default:
+			return nil, errUnsupportedImportFormat
+		}
+	case "application/json":
+		return usecases.ParseImportJSON(r.Body)
+	case "text/csv":
+		return usecases.ParseImportCSV(r.Body)
+	default:
+		return nil, errUnsupportedImportFormat
+	}
+}
+
+// importResultTemplates render the import summary; created task cards are swapped into the task list out of band
+var importResultTemplates = localizedTemplates("importResult", `<div id="import-result" class="mt-4 rounded-lg p-4 {{if .Report.Failed}}bg-yellow-50 text-yellow-800 dark:bg-yellow-900/30 dark:text-yellow-200{{else}}bg-green-50 text-green-800 dark:bg-green-900/30 dark:text-green-200{{end}}">
+	<p class="font-medium">{{t "import.summary" .Report.Created .Report.Total}}</p>
+	{{if .Report.Failed}}
+	<ul class="mt-2 text-sm list-disc list-inside">
+		{{range .Report.Rows}}{{if not .Success}}<li>{{t "import.line" .Line}}{{if .Title}} ({{.Title}}){{end}}: {{translateError .Error}}</li>{{end}}{{end}}
+	</ul>
+	{{end}}
+</div>
+{{if .Cards}}<div hx-swap-oob="afterbegin:#task-list">{{range .Cards}}{{.}}{{end}}</div>{{end}}`)
+
+// renderImportResult renders the import summary HTML fragment
+func renderImportResult(report *usecases.ImportReport, userID string, locale application.Locale) (string, error) {
+	var cards []template.HTML
+	for _, task := range report.Tasks {
+		card, err := renderTaskCard(&application.TaskView{Task: task}, userID, locale)
+		if err != nil {
+			return "", err
+		}
+		// renderTaskCard output is produced by html/template and already escaped
+		cards = append(cards, template.HTML(card))
+	}
+
+	var buf strings.Builder
+	err := localizedTemplate(importResultTemplates, locale).Execute(&buf, map[string]interface{}{
+		"Report": report,
+		"Cards":  cards,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// mockImportTasksUseCase is a mock implementation of ImportTasksUseCaseInterface
+type mockImportTasksUseCase struct {
+	executeFunc func(ctx context.Context, ownerID string, rows []usecases.ImportRow, loc *time.Location, strict bool) (*usecases.ImportReport, error)
+}
+
+func (m *mockImportTasksUseCase) Execute(ctx context.Context, ownerID string, rows []usecases.ImportRow, loc *time.Location, strict bool) (*usecases.ImportReport, error) {
+	return m.executeFunc(ctx, ownerID, rows, loc, strict)
+}
+
+// importAll reports every row as created
+func importAll(t *testing.T, wantRows int) *mockImportTasksUseCase {
+	return &mockImportTasksUseCase{
+		executeFunc: func(ctx context.Context, ownerID string, rows []usecases.ImportRow, loc *time.Location, strict bool) (*usecases.ImportReport, error) {
+			if ownerID != "user-123" {
+				t.Errorf("Expected ownerID 'user-123', got %s", ownerID)
+			}
+			if len(rows) != wantRows {
+				t.Errorf("Expected %d rows, got %d", wantRows, len(rows))
+			}
+			report := &usecases.ImportReport{Total: len(rows)}
+			for _, row := range rows {
+				task := &application.Task{ID: "task-" + row.Title, Title: row.Title, OwnerID: ownerID, Status: application.StatusPending}
+				report.Tasks = append(report.Tasks, task)
+				report.Rows = append(report.Rows, usecases.ImportRowResult{Line: row.Line, Title: row.Title, TaskID: task.ID, Success: true})
+			}
+			report.Created = len(report.Tasks)
+			return report, nil
+		},
+	}
+}
+
+func TestImportTasks_JSONBody(t *testing.T) {
+	handler := NewImportHandler(importAll(t, 2), NewPageTemplates(""), time.Local)
+
+	req := httptest.NewRequest("POST", "/api/tasks/import", strings.NewReader(`[{"title":"A"},{"title":"B"}]`))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+
+	w := httptest.NewRecorder()
+	handler.ImportTasks(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var report usecases.ImportReport
+	if err := json.NewDecoder(w.Body).Decode(&report); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if report.Created != 2 || len(report.Rows) != 2 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+}
+
+func TestImportTasks_MultipartCSV(t *testing.T) {
+	handler := NewImportHandler(importAll(t, 1), NewPageTemplates(""), time.Local)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("file", "tarefas.csv")
+	part.Write([]byte("title,due_date\nComprar pão,amanhã\n"))
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/api/tasks/import", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+
+	w := httptest.NewRecorder()
+	handler.ImportTasks(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestImportTasks_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+	}{
+		{name: "unsupported content type", contentType: "application/xml", body: "<tasks/>", wantStatus: http.StatusUnsupportedMediaType},
+		{name: "malformed json", contentType: "application/json", body: "{", wantStatus: http.StatusBadRequest},
+		{name: "csv without title column", contentType: "text/csv", body: "description\nx\n", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewImportHandler(&mockImportTasksUseCase{
+				executeFunc: func(ctx context.Context, ownerID string, rows []usecases.ImportRow, loc *time.Location, strict bool) (*usecases.ImportReport, error) {
+					t.Error("use case should not be called for invalid input")
+					return nil, nil
+				},
+			}, NewPageTemplates(""), time.Local)
+
+			req := httptest.NewRequest("POST", "/api/tasks/import", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+
+			w := httptest.NewRecorder()
+			handler.ImportTasks(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestImportTasks_NothingCreated(t *testing.T) {
+	handler := NewImportHandler(&mockImportTasksUseCase{
+		executeFunc: func(ctx context.Context, ownerID string, rows []usecases.ImportRow, loc *time.Location, strict bool) (*usecases.ImportReport, error) {
+			if !strict {
+				t.Error("Expected strict mode from query string")
+			}
+			return &usecases.ImportReport{Total: 1, Failed: 1, Rows: []usecases.ImportRowResult{{Line: 1, Error: "task title cannot be empty"}}}, nil
+		},
+	}, NewPageTemplates(""), time.Local)
+
+	req := httptest.NewRequest("POST", "/api/tasks/import?strict=true", strings.NewReader(`[{"title":""}]`))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+
+	w := httptest.NewRecorder()
+	handler.ImportTasks(w, req)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status 422, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "task title cannot be empty") {
+		t.Errorf("Expected row error in report, got %s", w.Body.String())
+	}
+}
+
+func TestWebImportTasks(t *testing.T) {
+	handler := NewImportHandler(importAll(t, 1), NewPageTemplates(""), time.Local)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("file", "tarefas.json")
+	part.Write([]byte(`[{"title":"<script>alert(1)</script>"}]`))
+	writer.Close()
+
+	req := htmx(httptest.NewRequest("POST", "/web/tasks/import", &body))
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+
+	w := httptest.NewRecorder()
+	handler.WebImportTasks(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	html := w.Body.String()
+	if !strings.Contains(html, "1 de 1 tarefa(s) importada(s)") {
+		t.Errorf("Expected import summary, got %s", html)
+	}
+	if !strings.Contains(html, `hx-swap-oob="afterbegin:#task-list"`) {
+		t.Error("Expected created task cards to be swapped into the task list")
+	}
+	if strings.Contains(html, "<script>alert(1)</script>") {
+		t.Error("Task title must be escaped")
+	}
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// JobAdminHandler handles the admins inspecting and retrying the failed background jobs
+type JobAdminHandler struct {
+	listFailedJobs usecases.ListFailedJobsUseCaseInterface
+	retryJob       usecases.RetryJobUseCaseInterface
+}
+
+// NewJobAdminHandler creates a new JobAdminHandler
+func NewJobAdminHandler(listFailedJobs usecases.ListFailedJobsUseCaseInterface, retryJob usecases.RetryJobUseCaseInterface) *JobAdminHandler {
+	return &JobAdminHandler{
+		listFailedJobs: listFailedJobs,
+		retryJob:       retryJob,
+	}
+}
+
+// JobResponse is the JSON representation of a background job
+type JobResponse struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload,omitempty"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	Error       string          `json:"error,omitempty"`
+	RunAt       time.Time       `json:"run_at"`
+	CreatedAt   time.Time       `json:"created_at"`
+	StartedAt   *time.Time      `json:"started_at,omitempty"`
+	FinishedAt  *time.Time      `json:"finished_at,omitempty"`
+}
+
+// newJobResponse converts a job to its JSON representation
+func newJobResponse(job *application.Job) JobResponse {
+	response := JobResponse{
+		ID:          job.ID,
+		Type:        job.Type,
+		Status:      string(job.Status),
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		Error:       job.Error,
+		RunAt:       job.RunAt,
+		CreatedAt:   job.CreatedAt,
+		StartedAt:   job.StartedAt,
+		FinishedAt:  job.FinishedAt,
+	}
+	if json.Valid(job.Payload) {
+		response.Payload = job.Payload
+	}
+	return response
+}
+
+// ListFailedJobs handles GET /api/admin/jobs/failed, most recently failed first
+func (h *JobAdminHandler) ListFailedJobs(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	jobs, err := h.listFailedJobs.Execute(r.Context(), userID)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	response := make([]JobResponse, 0, len(jobs))
+	for _, job := range jobs {
+		response = append(response, newJobResponse(job))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// RetryJob handles POST /api/admin/jobs/{id}/retry, queueing a failed job to run again right away
+func (h *JobAdminHandler) RetryJob(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	job, err := h.retryJob.Execute(r.Context(), userID, r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newJobResponse(job))
+}
+
+// writeError writes the error of a job use case
+func (h *JobAdminHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrAdminRequired):
+		writeAPIError(w, r, http.StatusForbidden, err.Error())
+	case errors.Is(err, application.ErrJobNotFound):
+		writeAPIError(w, r, http.StatusNotFound, err.Error())
+	default:
+		log.Printf("job admin request failed: %v", err)
+		writeAPIError(w, r, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockListFailedJobsUseCase struct {
+	err error
+}
+
+func (m *mockListFailedJobsUseCase) Execute(ctx context.Context, adminID string) ([]*application.Job, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	finishedAt := time.Date(2026, 10, 16, 15, 4, 5, 0, time.UTC)
+	return []*application.Job{
+		{ID: "job-1", Type: "send_email", Payload: []byte(`{"to":"ana@example.com"}`), Status: application.JobFailed, Attempts: 5, MaxAttempts: 5, Error: "smtp unavailable", FinishedAt: &finishedAt},
+	}, nil
+}
+
+type mockRetryJobUseCase struct {
+	err error
+}
+
+func (m *mockRetryJobUseCase) Execute(ctx context.Context, adminID, jobID string) (*application.Job, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &application.Job{ID: jobID, Type: "send_email", Status: application.JobPending, MaxAttempts: 5}, nil
+}
+
+func TestJobAdminHandler_ListFailedJobs(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "listed", wantStatus: http.StatusOK},
+		{name: "not an admin", err: usecases.ErrAdminRequired, wantStatus: http.StatusForbidden},
+		{name: "failure", err: errors.New("database is locked"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewJobAdminHandler(&mockListFailedJobsUseCase{err: tt.err}, &mockRetryJobUseCase{})
+			req := withUser(httptest.NewRequest("GET", "/api/admin/jobs/failed", nil))
+			w := httptest.NewRecorder()
+
+			h.ListFailedJobs(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var response []JobResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(response) != 1 || response[0].Error != "smtp unavailable" || string(response[0].Payload) != `{"to":"ana@example.com"}` {
+				t.Errorf("Unexpected response %+v", response)
+			}
+		})
+	}
+}
+
+func TestJobAdminHandler_RetryJob(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "requeued", wantStatus: http.StatusOK},
+		{name: "not an admin", err: usecases.ErrAdminRequired, wantStatus: http.StatusForbidden},
+		{name: "not failed", err: application.ErrJobNotFound, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewJobAdminHandler(&mockListFailedJobsUseCase{}, &mockRetryJobUseCase{err: tt.err})
+			req := withUser(httptest.NewRequest("POST", "/api/admin/jobs/job-1/retry", nil))
+			req.SetPathValue("id", "job-1")
+			w := httptest.NewRecorder()
+
+			h.RetryJob(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var response JobResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response.ID != "job-1" || response.Status != "pending" {
+				t.Errorf("Unexpected response %+v", response)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// JSONAPIMediaType is the media type of JSON:API documents (https://jsonapi.org). The task
+// routes answer one instead of plain JSON when the client prefers it in Accept.
+const JSONAPIMediaType = "application/vnd.api+json"
+
+// JSONAPIDocument is the top level of a JSON:API response
+type JSONAPIDocument struct {
+	Data  any               `json:"data"` // a JSONAPIResource or a slice of them
+	Links map[string]string `json:"links,omitempty"`
+	Meta  map[string]any    `json:"meta,omitempty"`
+}
+
+// JSONAPIResource is a resource object: the attributes of a DTO, with the IDs of the
+// resources it refers to moved to its relationships
+type JSONAPIResource struct {
+	Type          string                         `json:"type"`
+	ID            string                         `json:"id"`
+	Attributes    map[string]json.RawMessage     `json:"attributes"`
+	Relationships map[string]JSONAPIRelationship `json:"relationships,omitempty"`
+	Links         map[string]string              `json:"links,omitempty"`
+}
+
+// JSONAPIRelationship links a resource to others. A nil Data leaves it out, for relationships
+// given only by their links; an empty to-one relationship has jsonAPINull.
+type JSONAPIRelationship struct {
+	Data  any               `json:"data,omitempty"` // a JSONAPIIdentifier, a slice of them or jsonAPINull
+	Links map[string]string `json:"links,omitempty"`
+}
+
+// jsonAPINull is the data of an empty to-one relationship
+var jsonAPINull = json.RawMessage("null")
+
+// JSONAPIIdentifier identifies a resource in a relationship
+type JSONAPIIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// jsonAPIRequested reports whether the client prefers a JSON:API document to plain JSON: Accept
+// must name the JSON:API media type with a quality no lower than application/json's. Both
+// answers depend on Accept, so Vary says so to caches.
+func jsonAPIRequested(w http.ResponseWriter, r *http.Request) bool {
+	w.Header().Add("Vary", "Accept")
+
+	accept := r.Header.Get("Accept")
+	quality := acceptQuality(accept, JSONAPIMediaType)
+	return quality > 0 && quality >= acceptQuality(accept, "application/json")
+}
+
+// acceptQuality returns the quality an Accept header gives to a media type it names; 0 when it
+// doesn't name it, as the wildcards never choose JSON:API
+func acceptQuality(accept, mediaType string) float64 {
+	for _, part := range strings.Split(accept, ",") {
+		mediaRange, params, _ := strings.Cut(part, ";")
+		if !strings.EqualFold(strings.TrimSpace(mediaRange), mediaType) {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			if key, raw, ok := strings.Cut(strings.TrimSpace(param), "="); ok && strings.TrimSpace(key) == "q" {
+				if parsed, err := strconv.ParseFloat(strings.TrimSpace(raw), 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+		return quality
+	}
+	return 0
+}
+
+// writeJSONAPI writes a JSON:API document with the given status
+func writeJSONAPI(w http.ResponseWriter, status int, document JSONAPIDocument) {
+	w.Header().Set("Content-Type", JSONAPIMediaType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(document)
+}
+
+// jsonAPIAttributes returns the JSON fields of a DTO, but those in omit, as the attributes of a
+// resource. DTOs are plain structs, which always encode.
+func jsonAPIAttributes(dto any, omit ...string) map[string]json.RawMessage {
+	data, _ := json.Marshal(dto)
+
+	var attributes map[string]json.RawMessage
+	json.Unmarshal(data, &attributes)
+	for _, key := range omit {
+		delete(attributes, key)
+	}
+	return attributes
+}
+
+// taskResourceURL is the URL of a task in the API
+func taskResourceURL(taskID string) string {
+	return "/api/tasks/" + taskID
+}
+
+// newTaskResources converts tasks to JSON:API resources, relating each one to its owner, its
+// project, its comments (by link only, as they are listed apart) and, for the tasks userID owns,
+// the users it is shared with. sharees has those users by
+// task ID; when it is nil the shares weren't looked up and are left out.
+func newTaskResources(userID string, tasks []TaskResponse, sharees map[string][]string) []JSONAPIResource {
+	resources := make([]JSONAPIResource, 0, len(tasks))
+	for _, task := range tasks {
+		self := taskResourceURL(task.ID)
+		resource := JSONAPIResource{
+			Type:       "tasks",
+			ID:         task.ID,
+			Attributes: jsonAPIAttributes(task, "id", "owner_id", "project_id"),
+			Relationships: map[string]JSONAPIRelationship{
+				"owner":    {Data: JSONAPIIdentifier{Type: "users", ID: task.OwnerID}},
+				"project":  {Data: jsonAPINull},
+				"comments": {Links: map[string]string{"related": self + "/comments"}},
+			},
+			Links: map[string]string{"self": self},
+		}
+
+		if task.ProjectID != "" {
+			resource.Relationships["project"] = JSONAPIRelationship{
+				Data:  JSONAPIIdentifier{Type: "projects", ID: task.ProjectID},
+				Links: map[string]string{"related": "/api/projects/" + task.ProjectID},
+			}
+		}
+
+		if sharees != nil && task.OwnerID == userID {
+			shares := make([]JSONAPIIdentifier, 0, len(sharees[task.ID]))
+			for _, shareeID := range sharees[task.ID] {
+				shares = append(shares, JSONAPIIdentifier{Type: "users", ID: shareeID})
+			}
+			resource.Relationships["shares"] = JSONAPIRelationship{
+				Data:  shares,
+				Links: map[string]string{"related": self + "/shares"},
+			}
+		}
+
+		resources = append(resources, resource)
+	}
+	return resources
+}
+
+// jsonAPIETag returns the ETag of the JSON:API form of a response from the ETag of its plain
+// form. Sharing a task doesn't change its UpdatedAt, so the shares are part of the version.
+func jsonAPIETag(etag string, sharees map[string][]string) string {
+	taskIDs := make([]string, 0, len(sharees))
+	for taskID := range sharees {
+		taskIDs = append(taskIDs, taskID)
+	}
+	slices.Sort(taskIDs)
+
+	version := "jsonapi|" + etag
+	for _, taskID := range taskIDs {
+		version += "|" + taskID + ":" + strings.Join(sharees[taskID], ",")
+	}
+	return weakETag(version)
+}
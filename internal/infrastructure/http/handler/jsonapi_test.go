@@ -0,0 +1,381 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+type mockGetShareeIDsUseCase struct {
+	sharees map[string][]string
+	err     error
+}
+
+func (m *mockGetShareeIDsUseCase) Execute(ctx context.Context, userID string, tasks []*application.Task) (map[string][]string, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	sharees := make(map[string][]string)
+	for _, task := range tasks {
+		if users, ok := m.sharees[task.ID]; ok && task.OwnerID == userID {
+			sharees[task.ID] = users
+		}
+	}
+	return sharees, nil
+}
+
+// jsonAPITestDocument decodes the parts of a JSON:API document the tests look at
+type jsonAPITestDocument struct {
+	Data  json.RawMessage   `json:"data"`
+	Links map[string]string `json:"links"`
+	Meta  map[string]int    `json:"meta"`
+}
+
+func TestJSONAPIRequested(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{accept: "", want: false},
+		{accept: "application/json", want: false},
+		{accept: "*/*", want: false},
+		{accept: "application/vnd.api+json", want: true},
+		{accept: "Application/Vnd.Api+JSON", want: true},
+		{accept: "application/json, application/vnd.api+json", want: true},
+		{accept: "application/json;q=0.5, application/vnd.api+json", want: true},
+		{accept: "application/json, application/vnd.api+json;q=0.5", want: false},
+		{accept: "application/vnd.api+json;q=0", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/tasks", nil)
+			req.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+
+			if got := jsonAPIRequested(w, req); got != tt.want {
+				t.Errorf("jsonAPIRequested(%q) = %v, want %v", tt.accept, got, tt.want)
+			}
+			if vary := w.Header().Get("Vary"); vary != "Accept" {
+				t.Errorf("Expected Vary: Accept, got %q", vary)
+			}
+		})
+	}
+}
+
+func TestGetTask_JSONAPI(t *testing.T) {
+	tests := []struct {
+		name              string
+		task              *application.Task
+		sharees           *mockGetShareeIDsUseCase
+		wantRelationships map[string]string
+	}{
+		{
+			name:    "owned task in a project",
+			task:    &application.Task{ID: "task-1", Title: "Report", OwnerID: "user-123", ProjectID: "project-1"},
+			sharees: &mockGetShareeIDsUseCase{sharees: map[string][]string{"task-1": {"user-2", "user-3"}}},
+			wantRelationships: map[string]string{
+				"owner":    `{"data":{"type":"users","id":"user-123"}}`,
+				"project":  `{"data":{"type":"projects","id":"project-1"},"links":{"related":"/api/projects/project-1"}}`,
+				"comments": `{"links":{"related":"/api/tasks/task-1/comments"}}`,
+				"shares":   `{"data":[{"type":"users","id":"user-2"},{"type":"users","id":"user-3"}],"links":{"related":"/api/tasks/task-1/shares"}}`,
+			},
+		},
+		{
+			name:    "owned task without shares",
+			task:    &application.Task{ID: "task-1", Title: "Report", OwnerID: "user-123"},
+			sharees: &mockGetShareeIDsUseCase{},
+			wantRelationships: map[string]string{
+				"owner":    `{"data":{"type":"users","id":"user-123"}}`,
+				"project":  `{"data":null}`,
+				"comments": `{"links":{"related":"/api/tasks/task-1/comments"}}`,
+				"shares":   `{"data":[],"links":{"related":"/api/tasks/task-1/shares"}}`,
+			},
+		},
+		{
+			name:    "task shared with the user",
+			task:    &application.Task{ID: "task-1", Title: "Report", OwnerID: "user-2"},
+			sharees: &mockGetShareeIDsUseCase{sharees: map[string][]string{"task-1": {"user-123"}}},
+			wantRelationships: map[string]string{
+				"owner":    `{"data":{"type":"users","id":"user-2"}}`,
+				"project":  `{"data":null}`,
+				"comments": `{"links":{"related":"/api/tasks/task-1/comments"}}`,
+			},
+		},
+		{
+			name:    "shares that can't be looked up are left out",
+			task:    &application.Task{ID: "task-1", Title: "Report", OwnerID: "user-123"},
+			sharees: &mockGetShareeIDsUseCase{err: errors.New("database is locked")},
+			wantRelationships: map[string]string{
+				"owner":    `{"data":{"type":"users","id":"user-123"}}`,
+				"project":  `{"data":null}`,
+				"comments": `{"links":{"related":"/api/tasks/task-1/comments"}}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getTask := &mockGetTaskUseCase{executeFunc: func(ctx context.Context, taskID, userID string) (*application.Task, error) {
+				return tt.task, nil
+			}}
+			handler := NewTaskHandler(nil, nil, nil, getTask, nil, nil, nil, &mockGetOwnerNamesUseCase{}, tt.sharees, time.Local)
+
+			req := withUser(httptest.NewRequest(http.MethodGet, "/api/tasks/task-1", nil))
+			req.SetPathValue("id", "task-1")
+			req.Header.Set("Accept", JSONAPIMediaType)
+			w := httptest.NewRecorder()
+			handler.GetTask(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			if contentType := w.Header().Get("Content-Type"); contentType != JSONAPIMediaType {
+				t.Errorf("Expected Content-Type %s, got %q", JSONAPIMediaType, contentType)
+			}
+
+			var document struct {
+				Data struct {
+					Type          string                     `json:"type"`
+					ID            string                     `json:"id"`
+					Attributes    map[string]json.RawMessage `json:"attributes"`
+					Relationships map[string]json.RawMessage `json:"relationships"`
+					Links         map[string]string          `json:"links"`
+				} `json:"data"`
+				Links map[string]string `json:"links"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&document); err != nil {
+				t.Fatalf("Failed to decode document: %v", err)
+			}
+
+			if document.Data.Type != "tasks" || document.Data.ID != "task-1" {
+				t.Errorf("Expected tasks resource task-1, got %s %s", document.Data.Type, document.Data.ID)
+			}
+			if string(document.Data.Attributes["title"]) != `"Report"` {
+				t.Errorf("Expected title attribute, got %s", document.Data.Attributes["title"])
+			}
+			for _, moved := range []string{"id", "owner_id", "project_id"} {
+				if _, ok := document.Data.Attributes[moved]; ok {
+					t.Errorf("Expected %s out of the attributes", moved)
+				}
+			}
+			if document.Data.Links["self"] != "/api/tasks/task-1" || document.Links["self"] != "/api/tasks/task-1" {
+				t.Errorf("Expected self links to /api/tasks/task-1, got %v and %v", document.Data.Links, document.Links)
+			}
+
+			relationships := make(map[string]string)
+			for name, raw := range document.Data.Relationships {
+				relationships[name] = string(raw)
+			}
+			if !reflect.DeepEqual(relationships, tt.wantRelationships) {
+				t.Errorf("relationships = %v, want %v", relationships, tt.wantRelationships)
+			}
+		})
+	}
+}
+
+func TestGetTask_JSONAPIETag(t *testing.T) {
+	task := &application.Task{ID: "task-1", OwnerID: "user-123", UpdatedAt: time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)}
+	getTask := &mockGetTaskUseCase{executeFunc: func(ctx context.Context, taskID, userID string) (*application.Task, error) {
+		return task, nil
+	}}
+	sharees := &mockGetShareeIDsUseCase{}
+	handler := NewTaskHandler(nil, nil, nil, getTask, nil, nil, nil, &mockGetOwnerNamesUseCase{}, sharees, time.Local)
+
+	get := func(accept, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := withUser(httptest.NewRequest(http.MethodGet, "/api/tasks/task-1", nil))
+		req.SetPathValue("id", "task-1")
+		req.Header.Set("Accept", accept)
+		req.Header.Set("If-None-Match", ifNoneMatch)
+		w := httptest.NewRecorder()
+		handler.GetTask(w, req)
+		return w
+	}
+
+	plainETag := get("application/json", "").Header().Get("ETag")
+	jsonAPIETag := get(JSONAPIMediaType, "").Header().Get("ETag")
+	if jsonAPIETag == "" || jsonAPIETag == plainETag {
+		t.Fatalf("Expected the JSON:API form to have its own ETag, got %q and %q", jsonAPIETag, plainETag)
+	}
+	if w := get(JSONAPIMediaType, jsonAPIETag); w.Code != http.StatusNotModified {
+		t.Errorf("Expected 304 for an unchanged document, got %d", w.Code)
+	}
+
+	// Sharing doesn't touch the task, but changes its shares relationship
+	sharees.sharees = map[string][]string{"task-1": {"user-2"}}
+	if w := get(JSONAPIMediaType, jsonAPIETag); w.Code != http.StatusOK {
+		t.Errorf("Expected 200 once the task is shared, got %d", w.Code)
+	}
+}
+
+func TestListSharedTasks_JSONAPI(t *testing.T) {
+	tasks := func(n int) []*application.TaskView {
+		views := make([]*application.TaskView, n)
+		for i := range views {
+			views[i] = &application.TaskView{Task: &application.Task{ID: "task-" + string(rune('a'+i)), OwnerID: "user-2"}, OwnerName: "Bia"}
+		}
+		return views
+	}
+	cursor := &application.TaskCursor{CreatedAt: time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC), ID: "task-b"}
+
+	tests := []struct {
+		name      string
+		target    string
+		page      *application.TaskPage
+		wantLinks map[string]string
+		wantMeta  map[string]int
+	}{
+		{
+			name:   "middle page",
+			target: "/api/tasks/shared?per_page=2&page=2&status=pending",
+			page:   &application.TaskPage{Views: tasks(2), Total: 5, Page: 2, PerPage: 2},
+			wantLinks: map[string]string{
+				"self": "/api/tasks/shared?per_page=2&page=2&status=pending",
+				"next": "/api/tasks/shared?page=3&per_page=2&status=pending",
+				"prev": "/api/tasks/shared?page=1&per_page=2&status=pending",
+			},
+			wantMeta: map[string]int{"total": 5},
+		},
+		{
+			name:      "only page",
+			target:    "/api/tasks/shared",
+			page:      &application.TaskPage{Views: tasks(1), Total: 1, Page: 1, PerPage: 20},
+			wantLinks: map[string]string{"self": "/api/tasks/shared"},
+			wantMeta:  map[string]int{"total": 1},
+		},
+		{
+			name:   "cursor page",
+			target: "/api/tasks/shared?limit=2",
+			page:   &application.TaskPage{Views: tasks(2), NextCursor: cursor},
+			wantLinks: map[string]string{
+				"self": "/api/tasks/shared?limit=2",
+				"next": "/api/tasks/shared?cursor=" + encodeTaskCursor(cursor) + "&limit=2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listShared := &mockListSharedTasksUseCase{executeFunc: func(ctx context.Context, userID string, query application.TaskListQuery) (*application.TaskPage, error) {
+				return tt.page, nil
+			}}
+			handler := NewTaskHandler(nil, nil, nil, nil, nil, listShared, nil, &mockGetOwnerNamesUseCase{}, &mockGetShareeIDsUseCase{}, time.Local)
+
+			req := withUser(httptest.NewRequest(http.MethodGet, tt.target, nil))
+			req.Header.Set("Accept", JSONAPIMediaType)
+			w := httptest.NewRecorder()
+			handler.ListSharedTasks(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			if w.Header().Get("Link") != "" {
+				t.Errorf("Expected the page links in the document, not in Link: %s", w.Header().Get("Link"))
+			}
+
+			var document jsonAPITestDocument
+			if err := json.NewDecoder(w.Body).Decode(&document); err != nil {
+				t.Fatalf("Failed to decode document: %v", err)
+			}
+			var resources []JSONAPIResource
+			if err := json.Unmarshal(document.Data, &resources); err != nil {
+				t.Fatalf("Failed to decode data: %v", err)
+			}
+			if len(resources) != len(tt.page.Views) {
+				t.Errorf("Expected %d resources, got %d", len(tt.page.Views), len(resources))
+			}
+			if !reflect.DeepEqual(document.Links, tt.wantLinks) {
+				t.Errorf("links = %v, want %v", document.Links, tt.wantLinks)
+			}
+			if !reflect.DeepEqual(document.Meta, tt.wantMeta) {
+				t.Errorf("meta = %v, want %v", document.Meta, tt.wantMeta)
+			}
+		})
+	}
+}
+
+func TestListTasks_JSONAPICursor(t *testing.T) {
+	created := time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+	listTasks := &mockListTasksUseCase{executeFunc: func(ctx context.Context, userID string) ([]*application.Task, error) {
+		return []*application.Task{
+			{ID: "task-1", OwnerID: userID, CreatedAt: created},
+			{ID: "task-2", OwnerID: userID, CreatedAt: created.Add(-time.Hour)},
+			{ID: "task-3", OwnerID: userID, CreatedAt: created.Add(-2 * time.Hour)},
+		}, nil
+	}}
+	sharees := &mockGetShareeIDsUseCase{sharees: map[string][]string{"task-1": {"user-2"}}}
+	handler := NewTaskHandler(nil, nil, nil, nil, listTasks, nil, nil, &mockGetOwnerNamesUseCase{}, sharees, time.Local)
+
+	req := withUser(httptest.NewRequest(http.MethodGet, "/api/tasks?limit=2", nil))
+	req.Header.Set("Accept", JSONAPIMediaType)
+	w := httptest.NewRecorder()
+	handler.ListTasks(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var document jsonAPITestDocument
+	if err := json.NewDecoder(w.Body).Decode(&document); err != nil {
+		t.Fatalf("Failed to decode document: %v", err)
+	}
+	var resources []struct {
+		ID            string                        `json:"id"`
+		Relationships map[string]struct{ Data any } `json:"relationships"`
+		Links         map[string]string             `json:"links"`
+	}
+	if err := json.Unmarshal(document.Data, &resources); err != nil {
+		t.Fatalf("Failed to decode data: %v", err)
+	}
+
+	if len(resources) != 2 || resources[0].ID != "task-1" || resources[1].ID != "task-2" {
+		t.Fatalf("Expected task-1 and task-2, got %+v", resources)
+	}
+	if shares := resources[0].Relationships["shares"].Data.([]any); len(shares) != 1 {
+		t.Errorf("Expected task-1 shared with one user, got %v", shares)
+	}
+	next := "/api/tasks?cursor=" + encodeTaskCursor(&application.TaskCursor{CreatedAt: created.Add(-time.Hour), ID: "task-2"}) + "&limit=2"
+	if document.Links["next"] != next {
+		t.Errorf("Expected next link %s, got %s", next, document.Links["next"])
+	}
+}
+
+func TestCreateTask_JSONAPI(t *testing.T) {
+	handler := NewTaskHandler(&mockCreateTaskUseCase{}, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, &mockGetShareeIDsUseCase{}, time.Local)
+
+	req := withUser(httptest.NewRequest(http.MethodPost, "/api/tasks", strings.NewReader(`{"title":"Report"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", JSONAPIMediaType)
+	w := httptest.NewRecorder()
+	handler.CreateTask(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != JSONAPIMediaType {
+		t.Errorf("Expected Content-Type %s, got %q", JSONAPIMediaType, contentType)
+	}
+
+	var document jsonAPITestDocument
+	if err := json.NewDecoder(w.Body).Decode(&document); err != nil {
+		t.Fatalf("Failed to decode document: %v", err)
+	}
+	var resource JSONAPIResource
+	if err := json.Unmarshal(document.Data, &resource); err != nil {
+		t.Fatalf("Failed to decode data: %v", err)
+	}
+	if resource.ID != "task-123" || resource.Attributes["title"] == nil {
+		t.Errorf("Expected the new task, got %+v", resource)
+	}
+	if location := w.Header().Get("Location"); location != "/api/tasks/task-123" {
+		t.Errorf("Expected Location of the new task, got %q", location)
+	}
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+)
+
+// NegotiatedLocale returns the locale asked by the client: the language cookie set by the
+// navbar selector or, without it, the Accept-Language header. It returns false when the
+// client asks for no supported language.
+func NegotiatedLocale(r *http.Request) (application.Locale, bool) {
+	if cookie, err := r.Cookie(LocaleCookieName); err == nil {
+		if locale, err := application.ParseLocale(cookie.Value); err == nil {
+			return locale, true
+		}
+	}
+	return i18n.Negotiate(r.Header.Get("Accept-Language"))
+}
+
+// RequestLocale returns the locale to render pages and fragments in, defaulting to pt-BR
+func RequestLocale(r *http.Request) application.Locale {
+	if locale, ok := NegotiatedLocale(r); ok {
+		return locale
+	}
+	return i18n.Default
+}
+
+// LocalizeError translates an error message to the locale asked by the client. Clients that
+// ask for no language keep receiving the original messages.
+func LocalizeError(r *http.Request, message string) string {
+	if locale, ok := NegotiatedLocale(r); ok {
+		return i18n.Error(locale, message)
+	}
+	return message
+}
+
+// localeFuncs returns the template functions that localize a template: t looks up a catalog
+// message and translateError translates an error message
+func localeFuncs(locale application.Locale) template.FuncMap {
+	return template.FuncMap{
+		"t": func(key string, args ...any) string {
+			return i18n.T(locale, key, args...)
+		},
+		"translateError": func(message string) string {
+			return i18n.Error(locale, message)
+		},
+	}
+}
+
+// userFuncs are the template functions that show users: avatarURL links to their avatar
+var userFuncs = template.FuncMap{
+	"avatarURL": AvatarURL,
+}
+
+// PageData adds the locale fields used by base.html (the lang attribute and the language selector) to page data
+func PageData(locale application.Locale, data map[string]interface{}) map[string]interface{} {
+	locales := make([]string, 0, len(i18n.Supported()))
+	for _, supported := range i18n.Supported() {
+		locales = append(locales, string(supported))
+	}
+
+	data["Locale"] = string(locale)
+	data["Locales"] = locales
+	return data
+}
+
+// formatDateTime formats a time with the date layout of a locale
+func formatDateTime(locale application.Locale, t time.Time) string {
+	return t.Format(i18n.T(locale, "format.datetime"))
+}
+
+// localizedTemplates parses a fragment template once per supported locale, binding t to the
+// catalog of the locale; userFuncs are available too
+func localizedTemplates(name, text string) map[application.Locale]*template.Template {
+	templates := make(map[application.Locale]*template.Template, len(i18n.Supported()))
+	for _, locale := range i18n.Supported() {
+		templates[locale] = template.Must(template.New(name).Funcs(localeFuncs(locale)).Funcs(userFuncs).Parse(text))
+	}
+	return templates
+}
+
+// localizedTemplate returns the template of a locale, falling back to the default locale
+func localizedTemplate(templates map[application.Locale]*template.Template, locale application.Locale) *template.Template {
+	if tmpl, ok := templates[locale]; ok {
+		return tmpl
+	}
+	return templates[i18n.Default]
+}
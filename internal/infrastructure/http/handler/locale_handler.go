@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// LocaleHandler handles the language preference of the web interface
+type LocaleHandler struct {
+	updateLocale usecases.UpdateUserLocaleUseCaseInterface
+	pages        *PageTemplates
+	cookies      CookieConfig
+}
+
+// NewLocaleHandler creates a new LocaleHandler
+func NewLocaleHandler(updateLocale usecases.UpdateUserLocaleUseCaseInterface, pages *PageTemplates, cookies CookieConfig) *LocaleHandler {
+	return &LocaleHandler{
+		updateLocale: updateLocale,
+		pages:        pages,
+		cookies:      cookies,
+	}
+}
+
+// UpdateLocale handles POST /web/preferences/locale, persisting the language chosen in the navbar
+// selector. The page is reloaded because every text on it changes.
+func (h *LocaleHandler) UpdateLocale(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok || userID == "" {
+		h.pages.writeWebError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	locale := r.FormValue("locale")
+	if err := h.updateLocale.Execute(r.Context(), userID, locale); err != nil {
+		if errors.Is(err, application.ErrInvalidLocale) {
+			h.pages.writeWebError(w, r, http.StatusBadRequest, LocalizeError(r, err.Error()))
+			return
+		}
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, LocalizeError(r, "Failed to update language"))
+		return
+	}
+
+	// The cookie selects the language of every page and fragment from now on
+	http.SetCookie(w, h.cookies.createLocaleCookie(application.Locale(locale)))
+	w.Header().Set("HX-Refresh", "true")
+	h.pages.writeWebFragment(w, r, http.StatusNoContent, "")
+}
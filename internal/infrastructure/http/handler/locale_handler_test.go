@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+type mockUpdateUserLocaleUseCase struct {
+	executeFunc func(ctx context.Context, userID, locale string) error
+}
+
+func (m *mockUpdateUserLocaleUseCase) Execute(ctx context.Context, userID, locale string) error {
+	return m.executeFunc(ctx, userID, locale)
+}
+
+func postLocale(h *LocaleHandler, locale string, withUser bool) *httptest.ResponseRecorder {
+	req := htmx(httptest.NewRequest("POST", "/web/preferences/locale", strings.NewReader("locale="+locale)))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if withUser {
+		req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+	}
+	w := httptest.NewRecorder()
+	h.UpdateLocale(w, req)
+	return w
+}
+
+func TestUpdateLocale_Success(t *testing.T) {
+	h := NewLocaleHandler(&mockUpdateUserLocaleUseCase{
+		executeFunc: func(ctx context.Context, userID, locale string) error {
+			if userID != "user-123" || locale != "en" {
+				t.Errorf("Unexpected arguments %q, %q", userID, locale)
+			}
+			return nil
+		},
+	}, NewPageTemplates(""), DefaultCookieConfig())
+
+	w := postLocale(h, "en", true)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d", w.Code)
+	}
+	if w.Header().Get("HX-Refresh") != "true" {
+		t.Error("Expected HX-Refresh header to reload the page")
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != LocaleCookieName || cookies[0].Value != "en" {
+		t.Errorf("Expected lang cookie set to en, got %v", cookies)
+	}
+}
+
+func TestUpdateLocale_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		withUser   bool
+		wantStatus int
+	}{
+		{"unauthorized", nil, false, http.StatusUnauthorized},
+		{"invalid locale", application.ErrInvalidLocale, true, http.StatusBadRequest},
+		{"repository failure", errors.New("db down"), true, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewLocaleHandler(&mockUpdateUserLocaleUseCase{
+				executeFunc: func(ctx context.Context, userID, locale string) error {
+					return tt.err
+				},
+			}, NewPageTemplates(""), DefaultCookieConfig())
+
+			w := postLocale(h, "fr", tt.withUser)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if len(w.Result().Cookies()) != 0 {
+				t.Error("Expected no cookie on failure")
+			}
+		})
+	}
+}
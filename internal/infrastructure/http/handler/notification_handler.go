@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// NotificationHandler handles HTTP requests for notifications
+type NotificationHandler struct {
+	listNotifications usecases.ListNotificationsUseCaseInterface
+}
+
+// NewNotificationHandler creates a new NotificationHandler
+func NewNotificationHandler(listNotifications usecases.ListNotificationsUseCaseInterface) *NotificationHandler {
+	return &NotificationHandler{
+		listNotifications: listNotifications,
+	}
+}
+
+// ListNotifications handles GET /api/notifications
+func (h *NotificationHandler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	notifications, err := h.listNotifications.Execute(r.Context(), userID)
+	if err != nil {
+		writeAPIError(w, r, http.StatusInternalServerError, "Failed to list notifications")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toNotificationResponses(notifications))
+}
+
+// NotificationResponse is the JSON representation of a notification
+type NotificationResponse struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	TaskID    string    `json:"task_id"`
+	Message   string    `json:"message"`
+	Read      bool      `json:"read"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toNotificationResponses(notifications []*application.Notification) []NotificationResponse {
+	response := make([]NotificationResponse, 0, len(notifications))
+	for _, notification := range notifications {
+		response = append(response, NotificationResponse{
+			ID:        notification.ID,
+			Type:      string(notification.Type),
+			TaskID:    notification.TaskID,
+			Message:   notification.Message,
+			Read:      notification.Read,
+			CreatedAt: notification.CreatedAt,
+		})
+	}
+	return response
+}
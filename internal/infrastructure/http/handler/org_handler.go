@@ -0,0 +1,302 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// maxOrgBodySize limits the body of an org request, which only carries a name or a member
+const maxOrgBodySize = 4 << 10 // 4KB
+
+// OrgHandler handles HTTP requests for orgs, their members and the workspace switcher
+type OrgHandler struct {
+	createOrg    usecases.CreateOrgUseCaseInterface
+	listOrgs     usecases.ListOrgsUseCaseInterface
+	updateOrg    usecases.UpdateOrgUseCaseInterface
+	listMembers  usecases.ListOrgMembersUseCaseInterface
+	saveMember   usecases.SaveOrgMemberUseCaseInterface
+	removeMember usecases.RemoveOrgMemberUseCaseInterface
+	pages        *PageTemplates
+	cookies      CookieConfig
+}
+
+// NewOrgHandler creates a new OrgHandler
+func NewOrgHandler(
+	createOrg usecases.CreateOrgUseCaseInterface,
+	listOrgs usecases.ListOrgsUseCaseInterface,
+	updateOrg usecases.UpdateOrgUseCaseInterface,
+	listMembers usecases.ListOrgMembersUseCaseInterface,
+	saveMember usecases.SaveOrgMemberUseCaseInterface,
+	removeMember usecases.RemoveOrgMemberUseCaseInterface,
+	pages *PageTemplates,
+	cookies CookieConfig,
+) *OrgHandler {
+	return &OrgHandler{
+		createOrg:    createOrg,
+		listOrgs:     listOrgs,
+		updateOrg:    updateOrg,
+		listMembers:  listMembers,
+		saveMember:   saveMember,
+		removeMember: removeMember,
+		pages:        pages,
+		cookies:      cookies,
+	}
+}
+
+// OrgRequest is the body of POST /api/orgs and PUT /api/orgs/{id}. New orgs share their
+// tasks with all their members, so ShareTasks is only read by PUT.
+type OrgRequest struct {
+	Name       string `json:"name"`
+	ShareTasks bool   `json:"share_tasks"`
+}
+
+// OrgMemberRequest is the body of POST /api/orgs/{id}/members
+type OrgMemberRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// OrgResponse is the JSON representation of an org, with the role of the user in it
+type OrgResponse struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	ShareTasks bool      `json:"share_tasks"`
+	Role       string    `json:"role,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// OrgMemberResponse is the JSON representation of an org member
+type OrgMemberResponse struct {
+	UserID   string    `json:"user_id"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+func toOrgResponse(org *application.Org, role application.OrgRole) OrgResponse {
+	return OrgResponse{
+		ID:         org.ID,
+		Name:       org.Name,
+		ShareTasks: org.ShareTasks,
+		Role:       string(role),
+		CreatedAt:  org.CreatedAt,
+	}
+}
+
+func toOrgMemberResponse(member *application.OrgMember) OrgMemberResponse {
+	return OrgMemberResponse{
+		UserID:   member.UserID,
+		Name:     member.Name,
+		Email:    member.Email,
+		Role:     string(member.Role),
+		JoinedAt: member.JoinedAt,
+	}
+}
+
+// CreateOrg handles POST /api/orgs; the user becomes its owner
+func (h *OrgHandler) CreateOrg(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req OrgRequest
+	if !decodeJSON(w, r, &req, maxOrgBodySize) {
+		return
+	}
+
+	org, err := h.createOrg.Execute(r.Context(), userID, req.Name)
+	if err != nil {
+		status, message := orgErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toOrgResponse(org, application.OrgRoleOwner))
+}
+
+// ListOrgs handles GET /api/orgs, the orgs of the user with their role in each
+func (h *OrgHandler) ListOrgs(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	memberships, err := h.listOrgs.Execute(r.Context(), userID)
+	if err != nil {
+		status, message := orgErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	response := make([]OrgResponse, 0, len(memberships))
+	for _, membership := range memberships {
+		response = append(response, toOrgResponse(membership.Org, membership.Role))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// UpdateOrg handles PUT /api/orgs/{id}
+func (h *OrgHandler) UpdateOrg(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req OrgRequest
+	if !decodeJSON(w, r, &req, maxOrgBodySize) {
+		return
+	}
+
+	org, err := h.updateOrg.Execute(r.Context(), r.PathValue("id"), userID, req.Name, req.ShareTasks)
+	if err != nil {
+		status, message := orgErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toOrgResponse(org, ""))
+}
+
+// ListOrgMembers handles GET /api/orgs/{id}/members
+func (h *OrgHandler) ListOrgMembers(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	members, err := h.listMembers.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		status, message := orgErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	response := make([]OrgMemberResponse, 0, len(members))
+	for _, member := range members {
+		response = append(response, toOrgMemberResponse(member))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// SaveOrgMember handles POST /api/orgs/{id}/members, adding the user with the email to the
+// org or changing their role
+func (h *OrgHandler) SaveOrgMember(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req OrgMemberRequest
+	if !decodeJSON(w, r, &req, maxOrgBodySize) {
+		return
+	}
+	if req.Email == "" {
+		writeAPIError(w, r, http.StatusBadRequest, "email is required")
+		return
+	}
+
+	member, err := h.saveMember.Execute(r.Context(), r.PathValue("id"), userID, req.Email, application.OrgRole(req.Role))
+	if err != nil {
+		status, message := orgErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toOrgMemberResponse(member))
+}
+
+// RemoveOrgMember handles DELETE /api/orgs/{id}/members/{userID}
+func (h *OrgHandler) RemoveOrgMember(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := h.removeMember.Execute(r.Context(), r.PathValue("id"), userID, r.PathValue("userID")); err != nil {
+		status, message := orgErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// WebOrgSwitcher handles GET /web/fragments/orgs, rendering the workspace selector of the
+// navbar. Users who belong to no org get nothing.
+func (h *OrgHandler) WebOrgSwitcher(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	memberships, err := h.listOrgs.Execute(r.Context(), userID)
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Failed to list orgs")
+		return
+	}
+
+	fragment := ""
+	if len(memberships) > 0 {
+		fragment, err = renderOrgSwitcher(memberships, OrgFromRequest(r), RequestLocale(r))
+		if err != nil {
+			h.pages.writeWebError(w, r, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	h.pages.writeWebFragment(w, r, http.StatusOK, fragment)
+}
+
+// WebSwitchOrg handles POST /web/preferences/org, switching the workspace of the web interface
+// to the org picked in the navbar, or to the personal tasks when none is, and sends the browser
+// to its tasks
+func (h *OrgHandler) WebSwitchOrg(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok || userID == "" {
+		h.pages.writeWebError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	orgID := r.FormValue("org")
+	if orgID != "" {
+		memberships, err := h.listOrgs.Execute(r.Context(), userID)
+		if err != nil {
+			h.pages.writeWebError(w, r, http.StatusInternalServerError, "Failed to list orgs")
+			return
+		}
+		if !isOrgMember(memberships, orgID) {
+			h.pages.writeWebError(w, r, http.StatusNotFound, LocalizeError(r, application.ErrOrgNotFound.Error()))
+			return
+		}
+	}
+
+	http.SetCookie(w, h.cookies.createOrgCookie(orgID))
+	writeWebRedirect(w, r, "/tasks")
+}
+
+// isOrgMember reports whether the memberships include the org
+func isOrgMember(memberships []*application.OrgMembership, orgID string) bool {
+	for _, membership := range memberships {
+		if membership.Org.ID == orgID {
+			return true
+		}
+	}
+	return false
+}
+
+// orgErrorStatus maps an org use case error to an HTTP status and client message
+func orgErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, application.ErrOrgNotFound),
+		errors.Is(err, application.ErrOrgMemberNotFound),
+		errors.Is(err, application.ErrUserNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, application.ErrOrgAdminRequired), errors.Is(err, application.ErrOrgOwnerRequired):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, application.ErrLastOrgOwner):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, application.ErrOrgNameRequired),
+		errors.Is(err, application.ErrOrgNameTooLong),
+		errors.Is(err, application.ErrInvalidOrgRole):
+		return http.StatusBadRequest, err.Error()
+	default:
+		log.Printf("org operation failed: %v", err)
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
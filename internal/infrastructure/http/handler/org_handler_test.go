@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// mockOrgUseCases answers every org use case with a fixed org, or fails with err
+type mockOrgUseCases struct {
+	err     error
+	removed string
+}
+
+func (m *mockOrgUseCases) org() *application.Org {
+	return &application.Org{ID: "org-1", Name: "Receita", ShareTasks: true, CreatedAt: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+}
+
+type mockCreateOrgUseCase struct{ *mockOrgUseCases }
+
+func (m mockCreateOrgUseCase) Execute(ctx context.Context, userID, name string) (*application.Org, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	org := m.org()
+	org.Name = name
+	return org, nil
+}
+
+type mockListOrgsUseCase struct{ *mockOrgUseCases }
+
+func (m mockListOrgsUseCase) Execute(ctx context.Context, userID string) ([]*application.OrgMembership, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []*application.OrgMembership{{Org: m.org(), Role: application.OrgRoleAdmin}}, nil
+}
+
+type mockUpdateOrgUseCase struct{ *mockOrgUseCases }
+
+func (m mockUpdateOrgUseCase) Execute(ctx context.Context, orgID, userID, name string, shareTasks bool) (*application.Org, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &application.Org{ID: orgID, Name: name, ShareTasks: shareTasks}, nil
+}
+
+type mockListOrgMembersUseCase struct{ *mockOrgUseCases }
+
+func (m mockListOrgMembersUseCase) Execute(ctx context.Context, orgID, userID string) ([]*application.OrgMember, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []*application.OrgMember{{OrgID: orgID, UserID: userID, Name: "Ana", Email: "ana@example.com", Role: application.OrgRoleOwner}}, nil
+}
+
+type mockSaveOrgMemberUseCase struct{ *mockOrgUseCases }
+
+func (m mockSaveOrgMemberUseCase) Execute(ctx context.Context, orgID, actorID, email string, role application.OrgRole) (*application.OrgMember, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &application.OrgMember{OrgID: orgID, UserID: "user-2", Email: email, Role: role}, nil
+}
+
+type mockRemoveOrgMemberUseCase struct{ *mockOrgUseCases }
+
+func (m mockRemoveOrgMemberUseCase) Execute(ctx context.Context, orgID, actorID, userID string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.removed = userID
+	return nil
+}
+
+func newTestOrgHandler(m *mockOrgUseCases) *OrgHandler {
+	return NewOrgHandler(mockCreateOrgUseCase{m}, mockListOrgsUseCase{m}, mockUpdateOrgUseCase{m},
+		mockListOrgMembersUseCase{m}, mockSaveOrgMemberUseCase{m}, mockRemoveOrgMemberUseCase{m}, NewPageTemplates(""), DefaultCookieConfig())
+}
+
+func TestOrgHandler_ErrorStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"unknown org", application.ErrOrgNotFound, http.StatusNotFound},
+		{"unknown user", application.ErrUserNotFound, http.StatusNotFound},
+		{"not an admin", application.ErrOrgAdminRequired, http.StatusForbidden},
+		{"not an owner", application.ErrOrgOwnerRequired, http.StatusForbidden},
+		{"last owner", application.ErrLastOrgOwner, http.StatusConflict},
+		{"invalid role", application.ErrInvalidOrgRole, http.StatusBadRequest},
+		{"failure", errors.New("database is locked"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestOrgHandler(&mockOrgUseCases{err: tt.err})
+			req := withUser(httptest.NewRequest("POST", "/api/orgs/org-1/members", strings.NewReader(`{"email":"bia@example.com","role":"owner"}`)))
+			req.Header.Set("Content-Type", "application/json")
+			req.SetPathValue("id", "org-1")
+			w := httptest.NewRecorder()
+
+			h.SaveOrgMember(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestOrgHandler_API(t *testing.T) {
+	m := &mockOrgUseCases{}
+	h := newTestOrgHandler(m)
+
+	req := withUser(httptest.NewRequest("POST", "/api/orgs", strings.NewReader(`{"name":"Diretoria"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.CreateOrg(w, req)
+	var created OrgResponse
+	json.NewDecoder(w.Body).Decode(&created)
+	if w.Code != http.StatusCreated || created.Name != "Diretoria" || created.Role != "owner" {
+		t.Errorf("CreateOrg() = %d %+v, want 201 with the user as owner", w.Code, created)
+	}
+
+	w = httptest.NewRecorder()
+	h.ListOrgs(w, withUser(httptest.NewRequest("GET", "/api/orgs", nil)))
+	var orgs []OrgResponse
+	json.NewDecoder(w.Body).Decode(&orgs)
+	if w.Code != http.StatusOK || len(orgs) != 1 || orgs[0].Role != "admin" || !orgs[0].ShareTasks {
+		t.Errorf("ListOrgs() = %d %+v", w.Code, orgs)
+	}
+
+	req = withUser(httptest.NewRequest("PUT", "/api/orgs/org-1", strings.NewReader(`{"name":"Receita","share_tasks":false}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", "org-1")
+	w = httptest.NewRecorder()
+	h.UpdateOrg(w, req)
+	var updated OrgResponse
+	json.NewDecoder(w.Body).Decode(&updated)
+	if w.Code != http.StatusOK || updated.ShareTasks {
+		t.Errorf("UpdateOrg() = %d %+v, want the tasks no longer shared", w.Code, updated)
+	}
+
+	req = withUser(httptest.NewRequest("POST", "/api/orgs/org-1/members", strings.NewReader(`{"role":"member"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", "org-1")
+	w = httptest.NewRecorder()
+	h.SaveOrgMember(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("SaveOrgMember() without an email = %d, want 400", w.Code)
+	}
+
+	req = withUser(httptest.NewRequest("DELETE", "/api/orgs/org-1/members/user-2", nil))
+	req.SetPathValue("id", "org-1")
+	req.SetPathValue("userID", "user-2")
+	w = httptest.NewRecorder()
+	h.RemoveOrgMember(w, req)
+	if w.Code != http.StatusNoContent || m.removed != "user-2" {
+		t.Errorf("RemoveOrgMember() = %d, removed %q", w.Code, m.removed)
+	}
+}
+
+func TestOrgHandler_WebOrgSwitcher(t *testing.T) {
+	h := newTestOrgHandler(&mockOrgUseCases{})
+	req := withUser(httptest.NewRequest("GET", "/web/fragments/orgs", nil))
+	req.AddCookie(&http.Cookie{Name: OrgCookieName, Value: "org-1"})
+	w := httptest.NewRecorder()
+
+	h.WebOrgSwitcher(w, req)
+
+	body := w.Body.String()
+	if w.Code != http.StatusOK || !strings.Contains(body, `<option value="org-1" selected>Receita</option>`) || !strings.Contains(body, `<option value="">Pessoal</option>`) {
+		t.Errorf("WebOrgSwitcher() = %d %q, want the personal workspace and the current org selected", w.Code, body)
+	}
+}
+
+func TestOrgHandler_WebSwitchOrg(t *testing.T) {
+	tests := []struct {
+		name       string
+		org        string
+		wantStatus int
+		wantCookie string
+		wantMaxAge int
+	}{
+		{"switch to an org", "org-1", http.StatusSeeOther, "org-1", ThemeCookieMaxAge},
+		{"back to the personal tasks", "", http.StatusSeeOther, "", -1},
+		{"foreign org", "org-2", http.StatusNotFound, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestOrgHandler(&mockOrgUseCases{})
+			form := url.Values{"org": {tt.org}}
+			req := withUser(httptest.NewRequest("POST", "/web/preferences/org", strings.NewReader(form.Encode())))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+
+			h.WebSwitchOrg(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			cookies := w.Result().Cookies()
+			if tt.wantStatus != http.StatusSeeOther {
+				if len(cookies) != 0 {
+					t.Errorf("Expected no cookie, got %+v", cookies)
+				}
+				return
+			}
+			if len(cookies) != 1 || cookies[0].Name != OrgCookieName || cookies[0].Value != tt.wantCookie || cookies[0].MaxAge != tt.wantMaxAge {
+				t.Errorf("Unexpected cookies %+v", cookies)
+			}
+			if location := w.Header().Get("Location"); location != "/tasks" {
+				t.Errorf("Location = %q, want /tasks", location)
+			}
+		})
+	}
+}
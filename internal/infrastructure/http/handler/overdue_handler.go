@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// OverduePreferenceHandler handles whether the overdue job flags the late tasks of a user
+type OverduePreferenceHandler struct {
+	getPreference    usecases.GetOverduePreferenceUseCaseInterface
+	updatePreference usecases.UpdateOverduePreferenceUseCaseInterface
+	pages            *PageTemplates
+}
+
+// NewOverduePreferenceHandler creates a new OverduePreferenceHandler
+func NewOverduePreferenceHandler(
+	getPreference usecases.GetOverduePreferenceUseCaseInterface,
+	updatePreference usecases.UpdateOverduePreferenceUseCaseInterface,
+	pages *PageTemplates,
+) *OverduePreferenceHandler {
+	return &OverduePreferenceHandler{
+		getPreference:    getPreference,
+		updatePreference: updatePreference,
+		pages:            pages,
+	}
+}
+
+// OverduePreference is the body of GET and PUT /api/me/preferences/overdue
+type OverduePreference struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// GetPreference handles GET /api/me/preferences/overdue
+func (h *OverduePreferenceHandler) GetPreference(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	enabled, err := h.getPreference.Execute(r.Context(), userID)
+	if err != nil {
+		status, message := overduePreferenceErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(OverduePreference{Enabled: &enabled})
+}
+
+// UpdatePreference handles PUT /api/me/preferences/overdue
+func (h *OverduePreferenceHandler) UpdatePreference(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req OverduePreference
+	if !decodeProjectBody(w, r, &req) {
+		return
+	}
+	if req.Enabled == nil {
+		writeAPIError(w, r, http.StatusBadRequest, "enabled is required")
+		return
+	}
+
+	if err := h.updatePreference.Execute(r.Context(), userID, *req.Enabled); err != nil {
+		status, message := overduePreferenceErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(req)
+}
+
+// WebUpdatePreference handles POST /web/preferences/overdue, sent by the profile page checkbox,
+// which only carries "enabled" when it is checked
+func (h *OverduePreferenceHandler) WebUpdatePreference(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok || userID == "" {
+		h.pages.writeWebError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.updatePreference.Execute(r.Context(), userID, r.FormValue("enabled") == "true"); err != nil {
+		status, message := overduePreferenceErrorStatus(err)
+		h.pages.writeWebError(w, r, status, message)
+		return
+	}
+
+	h.pages.writeWebFragment(w, r, http.StatusNoContent, "")
+}
+
+// overduePreferenceErrorStatus maps an overdue preference use case error to an HTTP status and client message
+func overduePreferenceErrorStatus(err error) (int, string) {
+	if errors.Is(err, application.ErrUserNotFound) {
+		return http.StatusNotFound, err.Error()
+	}
+	log.Printf("overdue preference operation failed: %v", err)
+	return http.StatusInternalServerError, "Internal server error"
+}
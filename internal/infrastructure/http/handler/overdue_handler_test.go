@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+type mockGetOverduePreferenceUseCase struct {
+	enabled bool
+	err     error
+}
+
+func (m *mockGetOverduePreferenceUseCase) Execute(ctx context.Context, userID string) (bool, error) {
+	return m.enabled, m.err
+}
+
+type mockUpdateOverduePreferenceUseCase struct {
+	enabled *bool
+	err     error
+}
+
+func (m *mockUpdateOverduePreferenceUseCase) Execute(ctx context.Context, userID string, enabled bool) error {
+	m.enabled = &enabled
+	return m.err
+}
+
+func TestGetOverduePreference(t *testing.T) {
+	tests := []struct {
+		name       string
+		enabled    bool
+		err        error
+		wantStatus int
+		wantBody   string
+	}{
+		{"enabled", true, nil, http.StatusOK, `{"enabled":true}`},
+		{"opted out", false, nil, http.StatusOK, `{"enabled":false}`},
+		{"unknown user", false, application.ErrUserNotFound, http.StatusNotFound, ""},
+		{"repository failure", false, errors.New("db down"), http.StatusInternalServerError, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewOverduePreferenceHandler(&mockGetOverduePreferenceUseCase{enabled: tt.enabled, err: tt.err}, &mockUpdateOverduePreferenceUseCase{}, NewPageTemplates(""))
+
+			w := httptest.NewRecorder()
+			h.GetPreference(w, withUser(httptest.NewRequest("GET", "/api/me/preferences/overdue", nil)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" && strings.TrimSpace(w.Body.String()) != tt.wantBody {
+				t.Errorf("body = %s, want %s", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestUpdateOverduePreference(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantEnabled *bool
+	}{
+		{"turn off", `{"enabled": false}`, http.StatusOK, new(bool)},
+		{"missing field", `{}`, http.StatusBadRequest, nil},
+		{"invalid body", `{"enabled": "no"}`, http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := &mockUpdateOverduePreferenceUseCase{}
+			h := NewOverduePreferenceHandler(&mockGetOverduePreferenceUseCase{}, update, NewPageTemplates(""))
+
+			req := httptest.NewRequest("PUT", "/api/me/preferences/overdue", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			h.UpdatePreference(w, withUser(req))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if (update.enabled == nil) != (tt.wantEnabled == nil) || (update.enabled != nil && *update.enabled != *tt.wantEnabled) {
+				t.Errorf("use case called with %v, want %v", update.enabled, tt.wantEnabled)
+			}
+		})
+	}
+}
+
+func TestWebUpdateOverduePreference(t *testing.T) {
+	tests := []struct {
+		name        string
+		form        string
+		wantEnabled bool
+	}{
+		{"checked", "enabled=true", true},
+		{"unchecked", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := &mockUpdateOverduePreferenceUseCase{}
+			h := NewOverduePreferenceHandler(&mockGetOverduePreferenceUseCase{}, update, NewPageTemplates(""))
+
+			req := htmx(httptest.NewRequest("POST", "/web/preferences/overdue", strings.NewReader(tt.form)))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			h.WebUpdatePreference(w, withUser(req))
+
+			if w.Code != http.StatusNoContent {
+				t.Fatalf("status = %d, want 204", w.Code)
+			}
+			if update.enabled == nil || *update.enabled != tt.wantEnabled {
+				t.Errorf("use case called with %v, want %v", update.enabled, tt.wantEnabled)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"html/template"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/templates"
+)
+
+// PageTemplates parses the page templates. The templates embedded in the binary, used in
+// production, are parsed once per page and locale. Templates read from a directory, used in
+// development, are parsed again when one of their files changes, so edits show up on the
+// next request without restarting the server.
+type PageTemplates struct {
+	fsys   fs.FS
+	reload bool
+
+	mu    sync.Mutex
+	pages map[pageKey]*parsedPage
+}
+
+// pageKey identifies a parsed page: its files, joined by commas, in a locale
+type pageKey struct {
+	locale application.Locale
+	files  string
+}
+
+// parsedPage is a page ready to be cloned for a request
+type parsedPage struct {
+	tmpl     *template.Template
+	modTimes []time.Time // Of the files when the page was parsed; nil without reload
+}
+
+// NewPageTemplates returns the page templates read from dir, reloaded when they change, or
+// the ones embedded in the binary when dir is empty
+func NewPageTemplates(dir string) *PageTemplates {
+	p := &PageTemplates{fsys: templates.FS, pages: make(map[pageKey]*parsedPage)}
+	if dir != "" {
+		p.fsys, p.reload = os.DirFS(dir), true
+	}
+	return p
+}
+
+// Page returns the template of a page made of files in a locale, ready to execute with the
+// localization functions, userFuncs and funcs, the page-specific functions. The first file
+// names the template, as in template.ParseFiles. funcs may close over the request: the page
+// is parsed once and each call gets a copy bound to its own funcs.
+func (p *PageTemplates) Page(locale application.Locale, funcs template.FuncMap, files ...string) (*template.Template, error) {
+	var modTimes []time.Time
+	if p.reload {
+		var err error
+		if modTimes, err = p.modTimes(files); err != nil {
+			return nil, err
+		}
+	}
+
+	key := pageKey{locale: locale, files: strings.Join(files, ",")}
+	p.mu.Lock()
+	page, ok := p.pages[key]
+	if !ok || !slices.EqualFunc(page.modTimes, modTimes, time.Time.Equal) {
+		tmpl, err := parsePage(p.fsys, locale, funcs, files...)
+		if err != nil {
+			p.mu.Unlock()
+			return nil, err
+		}
+		page = &parsedPage{tmpl: tmpl, modTimes: modTimes}
+		p.pages[key] = page
+	}
+	p.mu.Unlock()
+
+	// A template can't be cloned once executed, so the parsed one is only ever cloned
+	tmpl, err := page.tmpl.Clone()
+	if err != nil {
+		return nil, err
+	}
+	return tmpl.Funcs(funcs), nil
+}
+
+// modTimes returns the modification times of files
+func (p *PageTemplates) modTimes(files []string) ([]time.Time, error) {
+	modTimes := make([]time.Time, len(files))
+	for i, name := range files {
+		info, err := fs.Stat(p.fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		modTimes[i] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+// parsePage parses page templates from fsys with the localization functions of a locale and
+// userFuncs, plus the page-specific funcs
+func parsePage(fsys fs.FS, locale application.Locale, funcs template.FuncMap, files ...string) (*template.Template, error) {
+	return template.New(files[0]).
+		Funcs(localeFuncs(locale)).
+		Funcs(userFuncs).
+		Funcs(funcs).
+		ParseFS(fsys, files...)
+}
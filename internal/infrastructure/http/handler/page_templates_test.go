@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"bytes"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestPageTemplates_Embedded(t *testing.T) {
+	pages := NewPageTemplates("")
+
+	tmpl, err := pages.Page(application.Locale("pt-BR"), nil, "base.html", "login.html", "passkey.html")
+	if err != nil {
+		t.Fatalf("Page() error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, PageData("pt-BR", map[string]interface{}{"Title": "Entrar"})); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "<title>Entrar") {
+		t.Errorf("expected the login page, got %s", buf.String())
+	}
+}
+
+func TestPageTemplates_Funcs(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "page.html", `{{greet}}`, time.Now())
+	pages := NewPageTemplates(dir)
+
+	// Each call binds its own funcs to the page, parsed once
+	for _, name := range []string{"Ana", "Bia"} {
+		tmpl, err := pages.Page("en", template.FuncMap{"greet": func() string { return "Hi " + name }}, "page.html")
+		if err != nil {
+			t.Fatalf("Page() error: %v", err)
+		}
+		if got := execute(t, tmpl); got != "Hi "+name {
+			t.Errorf("page = %q, want %q", got, "Hi "+name)
+		}
+	}
+}
+
+func TestPageTemplates_Reload(t *testing.T) {
+	dir := t.TempDir()
+	modified := time.Now().Add(-time.Hour)
+	writeTemplate(t, dir, "page.html", `{{t "task.complete"}}`, modified)
+	pages := NewPageTemplates(dir)
+
+	page := func() string {
+		tmpl, err := pages.Page("en", nil, "page.html")
+		if err != nil {
+			t.Fatalf("Page() error: %v", err)
+		}
+		return execute(t, tmpl)
+	}
+	if got := page(); got != "Complete" {
+		t.Fatalf("page = %q, want %q", got, "Complete")
+	}
+
+	writeTemplate(t, dir, "page.html", `Edited: {{t "task.complete"}}`, modified.Add(time.Minute))
+	if got := page(); got != "Edited: Complete" {
+		t.Errorf("page after editing = %q, want the edit", got)
+	}
+
+	if err := os.Remove(filepath.Join(dir, "page.html")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pages.Page("en", nil, "page.html"); err == nil {
+		t.Error("expected an error once the file is gone")
+	}
+}
+
+// writeTemplate writes a template file modified at modTime
+func writeTemplate(t *testing.T, dir, name, text string, modTime time.Time) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// execute executes a template without data and returns its output
+func execute(t *testing.T, tmpl *template.Template) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	return buf.String()
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// errMixedTaskPagination is returned for a task list asking for both an offset and a cursor
+var errMixedTaskPagination = errors.New("use either page and per_page or cursor and limit")
+
+// parseTaskListQuery reads the filters, sort and page of a task list from the query string:
+// status, tag, color, search, sort, and either page and per_page or, for cursor pagination, cursor
+// and limit
+func parseTaskListQuery(r *http.Request) (application.TaskListQuery, error) {
+	values := r.URL.Query()
+
+	if values.Has("cursor") || values.Has("limit") {
+		if values.Has("page") || values.Has("per_page") {
+			return application.TaskListQuery{}, errMixedTaskPagination
+		}
+		limit, err := queryInt(values, "limit")
+		if err != nil {
+			return application.TaskListQuery{}, application.ErrInvalidTaskLimit
+		}
+		after, err := decodeTaskCursor(values.Get("cursor"))
+		if err != nil {
+			return application.TaskListQuery{}, err
+		}
+
+		query, err := application.NewTaskListQuery(values.Get("status"), values.Get("tag"), values.Get("search"), values.Get("sort"), 0, 0)
+		if err == nil {
+			query, err = query.WithColor(values.Get("color"))
+		}
+		if err != nil {
+			return application.TaskListQuery{}, err
+		}
+		return query.WithCursor(after, limit)
+	}
+
+	page, err := queryInt(values, "page")
+	if err != nil {
+		return application.TaskListQuery{}, application.ErrInvalidTaskPage
+	}
+	perPage, err := queryInt(values, "per_page")
+	if err != nil {
+		return application.TaskListQuery{}, application.ErrInvalidTaskPage
+	}
+
+	query, err := application.NewTaskListQuery(values.Get("status"), values.Get("tag"), values.Get("search"), values.Get("sort"), page, perPage)
+	if err != nil {
+		return application.TaskListQuery{}, err
+	}
+	return query.WithColor(values.Get("color"))
+}
+
+// queryInt parses an optional integer query parameter; 0 when it's missing
+func queryInt(values url.Values, key string) (int, error) {
+	raw := values.Get(key)
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(raw)
+}
+
+// writePageHeaders describes a page of a list: X-Total-Count has the size of the whole list
+// and Link the URLs of the next and previous pages, keeping the other query parameters
+func writePageHeaders(w http.ResponseWriter, r *http.Request, page *application.TaskPage) {
+	w.Header().Set("X-Total-Count", strconv.Itoa(page.Total))
+
+	var links []string
+	if page.HasNext() {
+		links = append(links, pageLink(r, page.Page+1, page.PerPage, "next"))
+	}
+	if page.HasPrevious() {
+		links = append(links, pageLink(r, page.Page-1, page.PerPage, "prev"))
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+}
+
+// pageLink returns a Link header entry for another page of the list requested by r
+func pageLink(r *http.Request, page, perPage int, rel string) string {
+	return "<" + pageURL(r, page, perPage) + `>; rel="` + rel + `"`
+}
+
+// pageURL returns the URL of another page of the list requested by r
+func pageURL(r *http.Request, page, perPage int) string {
+	u := requestURL(r)
+	values := u.Query()
+	values.Set("page", strconv.Itoa(page))
+	values.Set("per_page", strconv.Itoa(perPage))
+	u.RawQuery = values.Encode()
+	return u.String()
+}
+
+// cursorURL returns the URL of the page after cursor of the list requested by r
+func cursorURL(r *http.Request, cursor string) string {
+	u := requestURL(r)
+	values := u.Query()
+	values.Set("cursor", cursor)
+	u.RawQuery = values.Encode()
+	return u.String()
+}
+
+// requestURL returns the path and query of r as the client sent them. The path comes from
+// RequestURI, since URL.Path has lost the prefixes stripped by the router.
+func requestURL(r *http.Request) *url.URL {
+	u, err := url.ParseRequestURI(r.RequestURI)
+	if err != nil || r.RequestURI == "" {
+		u = &url.URL{Path: r.URL.Path, RawQuery: r.URL.RawQuery}
+	}
+	return &url.URL{Path: u.Path, RawQuery: u.RawQuery}
+}
+
+// encodeTaskCursor returns the opaque form of a cursor sent to clients
+func encodeTaskCursor(cursor *application.TaskCursor) string {
+	raw := cursor.CreatedAt.Format(time.RFC3339Nano) + " " + cursor.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeTaskCursor parses a cursor from encodeTaskCursor; empty is the start of the list. The
+// time keeps its offset, so it matches the creation time stored with the task.
+func decodeTaskCursor(encoded string) (*application.TaskCursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, application.ErrInvalidTaskCursor
+	}
+	createdAt, id, ok := strings.Cut(string(raw), " ")
+	if !ok || id == "" {
+		return nil, application.ErrInvalidTaskCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, application.ErrInvalidTaskCursor
+	}
+
+	return &application.TaskCursor{CreatedAt: t, ID: id}, nil
+}
+
+// TaskCursorPage is a page of a task list in cursor pagination
+type TaskCursorPage struct {
+	Tasks      []TaskResponse `json:"tasks"`
+	NextCursor *string        `json:"next_cursor"` // null on the last page
+}
+
+// newTaskCursorPage returns the payload of a page in cursor pagination
+func newTaskCursorPage(tasks []TaskResponse, next *application.TaskCursor) TaskCursorPage {
+	page := TaskCursorPage{Tasks: tasks}
+	if next != nil {
+		encoded := encodeTaskCursor(next)
+		page.NextCursor = &encoded
+	}
+	return page
+}
@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// maxPasskeyResponseSize limits the authenticator response of a ceremony, which may carry
+// an attestation certificate chain
+const maxPasskeyResponseSize = 64 << 10 // 64KB
+
+// PasskeyCeremonies runs the two-step WebAuthn ceremonies of passkeys. Begin returns the
+// options for navigator.credentials and the ID of the server-side ceremony session; Finish
+// verifies the authenticator response against it. Implemented by the passkey package.
+type PasskeyCeremonies interface {
+	BeginRegistration(ctx context.Context, userID string) (any, string, error)
+	FinishRegistration(ctx context.Context, userID, sessionID, name string, response io.Reader) (*application.Credential, error)
+	BeginLogin(ctx context.Context) (any, string, error)
+	FinishLogin(ctx context.Context, sessionID string, response io.Reader) (*application.User, error)
+}
+
+// PasskeyHandler handles passkey (WebAuthn) registration, login and management. Passwords
+// keep working next to passkeys.
+type PasskeyHandler struct {
+	ceremonies       PasskeyCeremonies
+	passkeyLogin     usecases.PasskeyLoginUseCaseInterface
+	listCredentials  usecases.ListCredentialsUseCaseInterface
+	deleteCredential usecases.DeleteCredentialUseCaseInterface
+	taskListWarmer   TaskListWarmer
+	recordLogin      usecases.RecordLoginEventUseCaseInterface
+	cookies          CookieConfig
+}
+
+// NewPasskeyHandler creates a new PasskeyHandler. taskListWarmer and recordLogin are optional.
+func NewPasskeyHandler(
+	ceremonies PasskeyCeremonies,
+	passkeyLogin usecases.PasskeyLoginUseCaseInterface,
+	listCredentials usecases.ListCredentialsUseCaseInterface,
+	deleteCredential usecases.DeleteCredentialUseCaseInterface,
+	taskListWarmer TaskListWarmer,
+	recordLogin usecases.RecordLoginEventUseCaseInterface,
+	cookies CookieConfig,
+) *PasskeyHandler {
+	return &PasskeyHandler{
+		ceremonies:       ceremonies,
+		passkeyLogin:     passkeyLogin,
+		listCredentials:  listCredentials,
+		deleteCredential: deleteCredential,
+		taskListWarmer:   taskListWarmer,
+		recordLogin:      recordLogin,
+		cookies:          cookies,
+	}
+}
+
+// CredentialResponse is the JSON representation of a passkey
+type CredentialResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+func toCredentialResponse(credential *application.Credential) CredentialResponse {
+	return CredentialResponse{
+		ID:         credential.ID,
+		Name:       credential.Name,
+		CreatedAt:  credential.CreatedAt,
+		LastUsedAt: credential.LastUsedAt,
+	}
+}
+
+// BeginRegistration handles POST /api/auth/webauthn/register/begin, returning the options for
+// navigator.credentials.create
+func (h *PasskeyHandler) BeginRegistration(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	options, sessionID, err := h.ceremonies.BeginRegistration(r.Context(), userID)
+	if err != nil {
+		log.Printf("failed to begin passkey registration: %v", err)
+		writeAPIError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.writeCeremonyOptions(w, sessionID, options)
+}
+
+// FinishRegistration handles POST /api/auth/webauthn/register/finish?name=..., storing the
+// passkey created by navigator.credentials.create
+func (h *PasskeyHandler) FinishRegistration(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	http.SetCookie(w, h.cookies.deletePasskeySessionCookie())
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxPasskeyResponseSize)
+	credential, err := h.ceremonies.FinishRegistration(r.Context(), userID, passkeySessionID(r), r.URL.Query().Get("name"), r.Body)
+	if err != nil {
+		status, message := passkeyErrorStatus(err, http.StatusBadRequest)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toCredentialResponse(credential))
+}
+
+// BeginLogin handles POST /api/auth/webauthn/login/begin, returning the options for
+// navigator.credentials.get
+func (h *PasskeyHandler) BeginLogin(w http.ResponseWriter, r *http.Request) {
+	options, sessionID, err := h.ceremonies.BeginLogin(r.Context())
+	if err != nil {
+		log.Printf("failed to begin passkey login: %v", err)
+		writeAPIError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.writeCeremonyOptions(w, sessionID, options)
+}
+
+// FinishLogin handles POST /api/auth/webauthn/login/finish?remember_me=true, verifying the
+// assertion of navigator.credentials.get. Passkeys are only usable from a browser, so besides
+// returning the token like the password login API, it also sets the auth cookie like the web login.
+func (h *PasskeyHandler) FinishLogin(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, h.cookies.deletePasskeySessionCookie())
+	rememberMe, _ := strconv.ParseBool(r.URL.Query().Get("remember_me"))
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxPasskeyResponseSize)
+	user, err := h.ceremonies.FinishLogin(r.Context(), passkeySessionID(r), r.Body)
+	if err != nil {
+		// The user is unknown until the assertion verifies, so failures are recorded without an email
+		recordLoginAttempt(r, h.recordLogin, "", false)
+		status, message := passkeyErrorStatus(err, http.StatusUnauthorized)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	session, err := h.passkeyLogin.Execute(r.Context(), user.ID, rememberMe)
+	recordLoginAttempt(r, h.recordLogin, user.Email, err == nil)
+	if errors.Is(err, application.ErrUserDisabled) {
+		writeAPIError(w, r, http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("failed to issue passkey session: %v", err)
+		writeAPIError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	http.SetCookie(w, h.cookies.createAuthCookie(session.Token, session.Duration))
+	locale := RequestLocale(r)
+	if session.Locale != "" {
+		locale = session.Locale
+		http.SetCookie(w, h.cookies.createLocaleCookie(locale))
+	}
+	if h.taskListWarmer != nil {
+		h.taskListWarmer.WarmUp(session.Token, locale)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(LoginResponse{Token: session.Token, ExpiresAt: session.ExpiresAt})
+}
+
+// ListCredentials handles GET /api/auth/webauthn/credentials, listing the passkeys of the user
+func (h *PasskeyHandler) ListCredentials(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	credentials, err := h.listCredentials.Execute(r.Context(), userID)
+	if err != nil {
+		status, message := passkeyErrorStatus(err, http.StatusBadRequest)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	response := make([]CredentialResponse, 0, len(credentials))
+	for _, credential := range credentials {
+		response = append(response, toCredentialResponse(credential))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// DeleteCredential handles DELETE /api/auth/webauthn/credentials/{id}
+func (h *PasskeyHandler) DeleteCredential(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := h.deleteCredential.Execute(r.Context(), r.PathValue("id"), userID); err != nil {
+		status, message := passkeyErrorStatus(err, http.StatusBadRequest)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeCeremonyOptions sends the options of a ceremony and keeps its session ID in a cookie
+func (h *PasskeyHandler) writeCeremonyOptions(w http.ResponseWriter, sessionID string, options any) {
+	http.SetCookie(w, h.cookies.createPasskeySessionCookie(sessionID))
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(options)
+}
+
+// passkeySessionID returns the ceremony session ID kept in the passkey session cookie
+func passkeySessionID(r *http.Request) string {
+	cookie, err := r.Cookie(PasskeySessionCookieName)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// passkeyErrorStatus maps a passkey error to an HTTP status and client message. Failed
+// verifications use failureStatus; their details are logged but never sent to the client.
+func passkeyErrorStatus(err error, failureStatus int) (int, string) {
+	switch {
+	case errors.Is(err, application.ErrPasskeySessionExpired):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, application.ErrPasskeyVerificationFailed):
+		log.Printf("passkey ceremony rejected: %v", err)
+		return failureStatus, application.ErrPasskeyVerificationFailed.Error()
+	case errors.Is(err, application.ErrCredentialNotFound):
+		return http.StatusNotFound, err.Error()
+	default:
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return http.StatusRequestEntityTooLarge, "Request body too large"
+		}
+		log.Printf("passkey operation failed: %v", err)
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockPasskeyCeremonies struct {
+	sessionID string // session ID received by the last Finish call
+	name      string
+	err       error
+}
+
+func (m *mockPasskeyCeremonies) BeginRegistration(ctx context.Context, userID string) (any, string, error) {
+	if m.err != nil {
+		return nil, "", m.err
+	}
+	return map[string]any{"publicKey": map[string]string{"challenge": "register-" + userID}}, "session-1", nil
+}
+
+func (m *mockPasskeyCeremonies) FinishRegistration(ctx context.Context, userID, sessionID, name string, response io.Reader) (*application.Credential, error) {
+	m.sessionID, m.name = sessionID, name
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &application.Credential{ID: "cred-1", UserID: userID, Name: name, CreatedAt: time.Now()}, nil
+}
+
+func (m *mockPasskeyCeremonies) BeginLogin(ctx context.Context) (any, string, error) {
+	if m.err != nil {
+		return nil, "", m.err
+	}
+	return map[string]any{"publicKey": map[string]string{"challenge": "login"}}, "session-2", nil
+}
+
+func (m *mockPasskeyCeremonies) FinishLogin(ctx context.Context, sessionID string, response io.Reader) (*application.User, error) {
+	m.sessionID = sessionID
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &application.User{ID: "user-123", Email: "ana@example.com"}, nil
+}
+
+type mockPasskeyLoginUseCase struct {
+	rememberMe bool
+}
+
+func (m *mockPasskeyLoginUseCase) Execute(ctx context.Context, userID string, rememberMe bool) (*usecases.LoginResult, error) {
+	m.rememberMe = rememberMe
+	return &usecases.LoginResult{Token: "token-" + userID, Duration: time.Hour, Locale: application.LocaleEN}, nil
+}
+
+type mockListCredentialsUseCase struct {
+	credentials []*application.Credential
+}
+
+func (m *mockListCredentialsUseCase) Execute(ctx context.Context, userID string) ([]*application.Credential, error) {
+	return m.credentials, nil
+}
+
+type mockDeleteCredentialUseCase struct {
+	err error
+}
+
+func (m *mockDeleteCredentialUseCase) Execute(ctx context.Context, credentialID, userID string) error {
+	return m.err
+}
+
+func newTestPasskeyHandler(ceremonies *mockPasskeyCeremonies, audit *mockRecordLoginEventUseCase) (*PasskeyHandler, *mockPasskeyLoginUseCase) {
+	login := &mockPasskeyLoginUseCase{}
+	return NewPasskeyHandler(ceremonies, login, &mockListCredentialsUseCase{}, &mockDeleteCredentialUseCase{}, nil, audit, DefaultCookieConfig()), login
+}
+
+func findCookie(w *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestPasskeyBeginRegistration_SetsSessionCookie(t *testing.T) {
+	h, _ := newTestPasskeyHandler(&mockPasskeyCeremonies{}, nil)
+
+	req := withUser(httptest.NewRequest("POST", "/api/auth/webauthn/register/begin", nil))
+	w := httptest.NewRecorder()
+	h.BeginRegistration(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"challenge":"register-user-123"`) {
+		t.Errorf("body = %s, want the registration options", w.Body.String())
+	}
+
+	cookie := findCookie(w, PasskeySessionCookieName)
+	if cookie == nil || cookie.Value != "session-1" || !cookie.HttpOnly || cookie.Path != passkeySessionCookiePath {
+		t.Errorf("session cookie = %+v, want HttpOnly session-1 scoped to the ceremony endpoints", cookie)
+	}
+}
+
+func TestPasskeyFinishRegistration(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "passkey stored", wantStatus: http.StatusCreated},
+		{name: "expired session", err: application.ErrPasskeySessionExpired, wantStatus: http.StatusBadRequest},
+		{name: "invalid attestation", err: fmt.Errorf("%w: bad origin", application.ErrPasskeyVerificationFailed), wantStatus: http.StatusBadRequest},
+		{name: "storage failure", err: errors.New("disk full"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ceremonies := &mockPasskeyCeremonies{err: tt.err}
+			h, _ := newTestPasskeyHandler(ceremonies, nil)
+
+			req := withUser(httptest.NewRequest("POST", "/api/auth/webauthn/register/finish?name=Laptop", strings.NewReader(`{}`)))
+			req.AddCookie(&http.Cookie{Name: PasskeySessionCookieName, Value: "session-1"})
+			w := httptest.NewRecorder()
+			h.FinishRegistration(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if ceremonies.sessionID != "session-1" || ceremonies.name != "Laptop" {
+				t.Errorf("FinishRegistration() got session %q and name %q", ceremonies.sessionID, ceremonies.name)
+			}
+			if strings.Contains(w.Body.String(), "bad origin") || strings.Contains(w.Body.String(), "disk full") {
+				t.Errorf("body = %s, want internal details kept out", w.Body.String())
+			}
+			if cookie := findCookie(w, PasskeySessionCookieName); cookie == nil || cookie.MaxAge >= 0 {
+				t.Errorf("session cookie = %+v, want it deleted", cookie)
+			}
+		})
+	}
+}
+
+func TestPasskeyFinishLogin_Success(t *testing.T) {
+	audit := &mockRecordLoginEventUseCase{}
+	ceremonies := &mockPasskeyCeremonies{}
+	h, login := newTestPasskeyHandler(ceremonies, audit)
+
+	req := httptest.NewRequest("POST", "/api/auth/webauthn/login/finish?remember_me=true", strings.NewReader(`{}`))
+	req.AddCookie(&http.Cookie{Name: PasskeySessionCookieName, Value: "session-2"})
+	req.RemoteAddr = "203.0.113.7:5000"
+	w := httptest.NewRecorder()
+	h.FinishLogin(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	if ceremonies.sessionID != "session-2" || !login.rememberMe {
+		t.Errorf("session = %q, rememberMe = %v", ceremonies.sessionID, login.rememberMe)
+	}
+
+	var response LoginResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil || response.Token != "token-user-123" {
+		t.Errorf("response = %+v, %v; want the issued token", response, err)
+	}
+	if cookie := findCookie(w, AuthCookieName); cookie == nil || cookie.Value != "token-user-123" {
+		t.Errorf("auth cookie = %+v, want the issued token", cookie)
+	}
+	if cookie := findCookie(w, LocaleCookieName); cookie == nil || cookie.Value != "en" {
+		t.Errorf("locale cookie = %+v, want the user's language", cookie)
+	}
+	if len(audit.recorded) != 1 || audit.recorded[0].email != "ana@example.com" || !audit.recorded[0].success {
+		t.Errorf("recorded = %+v, want a successful login of ana@example.com", audit.recorded)
+	}
+}
+
+func TestPasskeyFinishLogin_Failure(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "expired session", err: application.ErrPasskeySessionExpired, wantStatus: http.StatusBadRequest},
+		{name: "forged assertion", err: fmt.Errorf("%w: invalid signature", application.ErrPasskeyVerificationFailed), wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audit := &mockRecordLoginEventUseCase{}
+			h, _ := newTestPasskeyHandler(&mockPasskeyCeremonies{err: tt.err}, audit)
+
+			w := httptest.NewRecorder()
+			h.FinishLogin(w, httptest.NewRequest("POST", "/api/auth/webauthn/login/finish", strings.NewReader(`{}`)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if findCookie(w, AuthCookieName) != nil {
+				t.Error("failed login set the auth cookie")
+			}
+			if len(audit.recorded) != 1 || audit.recorded[0].success {
+				t.Errorf("recorded = %+v, want one failed attempt", audit.recorded)
+			}
+		})
+	}
+}
+
+func TestPasskeyDeleteCredential(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "removed", wantStatus: http.StatusNoContent},
+		{name: "not found", err: application.ErrCredentialNotFound, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewPasskeyHandler(&mockPasskeyCeremonies{}, &mockPasskeyLoginUseCase{}, &mockListCredentialsUseCase{}, &mockDeleteCredentialUseCase{err: tt.err}, nil, nil, DefaultCookieConfig())
+
+			req := withUser(httptest.NewRequest("DELETE", "/api/auth/webauthn/credentials/cred-1", nil))
+			req.SetPathValue("id", "cred-1")
+			w := httptest.NewRecorder()
+			h.DeleteCredential(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// PasswordHandler handles users changing their own password
+type PasswordHandler struct {
+	changePassword usecases.ChangePasswordUseCaseInterface
+}
+
+// NewPasswordHandler creates a new PasswordHandler
+func NewPasswordHandler(changePassword usecases.ChangePasswordUseCaseInterface) *PasswordHandler {
+	return &PasswordHandler{
+		changePassword: changePassword,
+	}
+}
+
+// ChangePasswordRequest represents a request to change the password of the current user
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// ChangePassword handles PUT /api/me/password. A new password breaking the password policy
+// gets a 400 listing every rule it breaks.
+func (h *PasswordHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req ChangePasswordRequest
+	if !decodeJSON(w, r, &req, maxJSONBodySize) {
+		return
+	}
+
+	if err := h.changePassword.Execute(r.Context(), userID, req.CurrentPassword, req.NewPassword); err != nil {
+		var policyErr *service.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			writePasswordPolicyError(w, r, "new_password", policyErr)
+			return
+		}
+		status, message := passwordErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writePasswordPolicyError writes the API error of a password breaking the password policy,
+// with a detail per broken rule about field
+func writePasswordPolicyError(w http.ResponseWriter, r *http.Request, field string, err *service.PasswordPolicyError) {
+	details := make([]ErrorDetail, len(err.Violations))
+	for i, v := range err.Violations {
+		details[i] = ErrorDetail{Field: field, Code: string(v.Rule), Message: LocalizeError(r, v.Message)}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(ErrorResponse{Error: ErrorBody{
+		Code:    CodeWeakPassword,
+		Message: passwordPolicyMessage(r, err),
+		Details: details,
+	}})
+}
+
+// passwordPolicyMessage joins the localized messages of the rules a password breaks
+func passwordPolicyMessage(r *http.Request, err *service.PasswordPolicyError) string {
+	messages := make([]string, len(err.Violations))
+	for i, v := range err.Violations {
+		messages[i] = LocalizeError(r, v.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// passwordErrorStatus maps a password change error to an HTTP status and client message
+func passwordErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecases.ErrCurrentPasswordMismatch):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, application.ErrUserNotFound):
+		return http.StatusNotFound, err.Error()
+	default:
+		log.Printf("password change failed: %v", err)
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
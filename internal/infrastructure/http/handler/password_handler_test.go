@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockChangePasswordUseCase struct {
+	err error
+}
+
+func (m *mockChangePasswordUseCase) Execute(ctx context.Context, userID, currentPassword, newPassword string) error {
+	return m.err
+}
+
+// weakPasswordError is a policy error breaking two rules
+var weakPasswordError = &service.PasswordPolicyError{Violations: []service.PasswordViolation{
+	{Rule: service.RuleMinLength, Message: "password must be at least 8 characters"},
+	{Rule: service.RuleNotCommon, Message: "password is too common"},
+}}
+
+func TestPasswordHandler_ChangePassword(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"changed", nil, http.StatusNoContent, ""},
+		{"wrong current password", usecases.ErrCurrentPasswordMismatch, http.StatusForbidden, "forbidden"},
+		{"unknown user", application.ErrUserNotFound, http.StatusNotFound, "not_found"},
+		{"weak password", weakPasswordError, http.StatusBadRequest, CodeWeakPassword},
+		{"storage failure", errors.New("disk full"), http.StatusInternalServerError, "internal_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewPasswordHandler(&mockChangePasswordUseCase{err: tt.err})
+
+			req := httptest.NewRequest("PUT", "/api/me/password", strings.NewReader(`{"current_password":"old","new_password":"new"}`))
+			w := httptest.NewRecorder()
+			h.ChangePassword(w, withUser(req))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantCode == "" {
+				return
+			}
+			var resp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Error.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", resp.Error.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestWritePasswordPolicyError(t *testing.T) {
+	req := httptest.NewRequest("POST", "/api/auth/register", nil)
+	req.Header.Set("Accept-Language", "pt-BR")
+	w := httptest.NewRecorder()
+
+	writePasswordPolicyError(w, req, "password", weakPasswordError)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+	var resp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if want := "a senha deve ter no mínimo 8 caracteres; a senha é comum demais"; resp.Error.Message != want {
+		t.Errorf("message = %q, want %q", resp.Error.Message, want)
+	}
+	want := []ErrorDetail{
+		{Field: "password", Code: "min_length", Message: "a senha deve ter no mínimo 8 caracteres"},
+		{Field: "password", Code: "not_common", Message: "a senha é comum demais"},
+	}
+	if len(resp.Error.Details) != len(want) {
+		t.Fatalf("details = %+v, want %+v", resp.Error.Details, want)
+	}
+	for i := range want {
+		if resp.Error.Details[i] != want[i] {
+			t.Errorf("details[%d] = %+v, want %+v", i, resp.Error.Details[i], want[i])
+		}
+	}
+}
+
+func TestRegister_PasswordPolicy(t *testing.T) {
+	handler := &AuthHandler{registerUseCase: &mockRegisterUseCase{
+		executeFunc: func(ctx context.Context, name, email, password string) (*application.User, error) {
+			return nil, weakPasswordError
+		},
+	}}
+
+	req := httptest.NewRequest("POST", "/register", strings.NewReader("name=Ana&email=ana@example.com&password=abc"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	handler.WebRegister(w, htmx(req))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, `id="password-error"`) || !strings.Contains(body, "password is too common") {
+		t.Errorf("expected every broken rule below the password field: %s", body)
+	}
+}
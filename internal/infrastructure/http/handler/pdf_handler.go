@@ -1,34 +1,54 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
 	"github.com/ia-edev-sindireceita/todo/internal/usecases"
 )
 
 // PDFHandler handles HTTP requests for PDF export
 type PDFHandler struct {
 	exportTasksPDF usecases.ExportTasksPDFUseCaseInterface
+	exportTaskPDF  usecases.ExportTaskPDFUseCaseInterface
+	pages          *PageTemplates
 }
 
 // NewPDFHandler creates a new PDFHandler
-func NewPDFHandler(exportTasksPDF usecases.ExportTasksPDFUseCaseInterface) *PDFHandler {
+func NewPDFHandler(exportTasksPDF usecases.ExportTasksPDFUseCaseInterface, exportTaskPDF usecases.ExportTaskPDFUseCaseInterface, pages *PageTemplates) *PDFHandler {
 	return &PDFHandler{
 		exportTasksPDF: exportTasksPDF,
+		exportTaskPDF:  exportTaskPDF,
+		pages:          pages,
 	}
 }
 
-// ExportTasks handles GET /api/tasks/export/pdf
+// ExportTasks handles GET /api/tasks/export/pdf?project=&tag=&status=, exporting the tasks
+// of the user in the scope of the optional filters. Filtering by project exports a project
+// the user owns or that is shared with them.
 func (h *PDFHandler) ExportTasks(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID := r.Context().Value("userID").(string)
 
+	values := r.URL.Query()
+	filter, err := application.NewTaskExportFilter(values.Get("project"), values.Get("tag"), values.Get("status"))
+	if err != nil {
+		writeAPIError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Generate PDF
-	pdfBytes, err := h.exportTasksPDF.Execute(r.Context(), userID)
+	pdfBytes, err := h.exportTasksPDF.Execute(r.Context(), userID, filter)
+	if errors.Is(err, application.ErrProjectNotFound) {
+		writeAPIError(w, r, http.StatusNotFound, err.Error())
+		return
+	}
 	if err != nil {
-		http.Error(w, "Failed to generate PDF", http.StatusInternalServerError)
+		writeAPIError(w, r, http.StatusInternalServerError, "Failed to generate PDF")
 		return
 	}
 
@@ -41,3 +61,48 @@ func (h *PDFHandler) ExportTasks(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	w.Write(pdfBytes)
 }
+
+// ExportTask handles GET /api/tasks/{id}/export/pdf
+func (h *PDFHandler) ExportTask(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	pdfBytes, err := h.exportTaskPDF.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		status, message := exportTaskErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	writeTaskPDF(w, r.PathValue("id"), pdfBytes)
+}
+
+// WebExportTask handles GET /web/tasks/{id}/export/pdf, linked from the task cards
+func (h *PDFHandler) WebExportTask(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	pdfBytes, err := h.exportTaskPDF.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		status, message := exportTaskErrorStatus(err)
+		h.pages.writeWebError(w, r, status, i18n.Error(RequestLocale(r), message))
+		return
+	}
+
+	writeTaskPDF(w, r.PathValue("id"), pdfBytes)
+}
+
+// exportTaskErrorStatus maps the errors of a task export to a status and a message
+func exportTaskErrorStatus(err error) (int, string) {
+	if errors.Is(err, usecases.ErrTaskUnavailable) {
+		return http.StatusNotFound, err.Error()
+	}
+	return http.StatusInternalServerError, "Failed to generate PDF"
+}
+
+// writeTaskPDF writes the PDF of a task as a download
+func writeTaskPDF(w http.ResponseWriter, taskID string, pdfBytes []byte) {
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=tarefa_%s.pdf", taskID))
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(pdfBytes)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(pdfBytes)
+}
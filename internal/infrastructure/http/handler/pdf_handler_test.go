@@ -7,14 +7,19 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
 )
 
 type MockExportPDFUseCase struct {
 	pdfBytes []byte
 	err      error
+	filter   application.TaskExportFilter
 }
 
-func (m *MockExportPDFUseCase) Execute(ctx context.Context, ownerID string) ([]byte, error) {
+func (m *MockExportPDFUseCase) Execute(ctx context.Context, ownerID string, filter application.TaskExportFilter) ([]byte, error) {
+	m.filter = filter
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -55,7 +60,7 @@ func TestPDFHandler_ExportTasks(t *testing.T) {
 				err:      tt.mockError,
 			}
 
-			handler := NewPDFHandler(mockUseCase)
+			handler := NewPDFHandler(mockUseCase, nil, NewPageTemplates(""))
 
 			req := httptest.NewRequest(http.MethodGet, "/api/tasks/export/pdf", nil)
 			ctx := context.WithValue(req.Context(), "userID", tt.userID)
@@ -86,3 +91,89 @@ func TestPDFHandler_ExportTasks(t *testing.T) {
 		})
 	}
 }
+
+func TestPDFHandler_ExportTasksScope(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		err        error
+		wantStatus int
+		wantFilter application.TaskExportFilter
+	}{
+		{
+			name:       "project, tag and status",
+			query:      "?project=project-1&tag=%23Receita&status=pending",
+			wantStatus: http.StatusOK,
+			wantFilter: application.TaskExportFilter{ProjectID: "project-1", Tag: "receita", Status: application.StatusPending},
+		},
+		{name: "invalid status", query: "?status=done", wantStatus: http.StatusBadRequest},
+		{name: "project not visible", query: "?project=project-2", err: application.ErrProjectNotFound, wantStatus: http.StatusNotFound, wantFilter: application.TaskExportFilter{ProjectID: "project-2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUseCase := &MockExportPDFUseCase{pdfBytes: []byte("%PDF-1.4"), err: tt.err}
+			w := httptest.NewRecorder()
+			NewPDFHandler(mockUseCase, nil, NewPageTemplates("")).ExportTasks(w, withUser(httptest.NewRequest(http.MethodGet, "/api/tasks/export/pdf"+tt.query, nil)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if mockUseCase.filter != tt.wantFilter {
+				t.Errorf("Expected filter %+v, got %+v", tt.wantFilter, mockUseCase.filter)
+			}
+		})
+	}
+}
+
+type mockExportTaskPDFUseCase struct {
+	err error
+}
+
+func (m *mockExportTaskPDFUseCase) Execute(ctx context.Context, taskID, userID string) ([]byte, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []byte("%PDF-1.4 " + taskID), nil
+}
+
+func TestPDFHandler_ExportTask(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		web        bool
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "api download", wantStatus: http.StatusOK, wantBody: "%PDF-1.4 task-1"},
+		{name: "web download", web: true, wantStatus: http.StatusOK, wantBody: "%PDF-1.4 task-1"},
+		{name: "api task not visible", err: usecases.ErrTaskUnavailable, wantStatus: http.StatusNotFound, wantBody: `"code":"not_found"`},
+		{name: "web task not visible", err: usecases.ErrTaskUnavailable, web: true, wantStatus: http.StatusNotFound, wantBody: "tarefa não encontrada"},
+		{name: "api failure", err: errors.New("disk full"), wantStatus: http.StatusInternalServerError, wantBody: "Failed to generate PDF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewPDFHandler(nil, &mockExportTaskPDFUseCase{err: tt.err}, NewPageTemplates(""))
+
+			target, export := "/api/tasks/task-1/export/pdf", handler.ExportTask
+			if tt.web {
+				target, export = "/web/tasks/task-1/export/pdf", handler.WebExportTask
+			}
+			req := htmx(httptest.NewRequest(http.MethodGet, target, nil))
+			req.SetPathValue("id", "task-1")
+			w := httptest.NewRecorder()
+			export(w, withUser(req))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if !bytes.Contains(w.Body.Bytes(), []byte(tt.wantBody)) {
+				t.Errorf("Expected %q in %q", tt.wantBody, w.Body.String())
+			}
+			if tt.err == nil && w.Header().Get("Content-Disposition") != "attachment; filename=tarefa_task-1.pdf" {
+				t.Errorf("Unexpected Content-Disposition %q", w.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
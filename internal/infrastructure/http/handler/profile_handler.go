@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// profileRecentLogins is how many login attempts the profile page lists
+const profileRecentLogins = 10
+
+// ProfileHandler handles the profile page and the account security endpoints
+type ProfileHandler struct {
+	listLogins      usecases.ListLoginEventsUseCaseInterface
+	lastLogin       usecases.GetLastLoginUseCaseInterface
+	getTheme        usecases.GetUserThemeUseCaseInterface
+	listCredentials usecases.ListCredentialsUseCaseInterface
+	twoFactorStatus usecases.GetTwoFactorStatusUseCaseInterface
+	overdue         usecases.GetOverduePreferenceUseCaseInterface
+	digest          usecases.GetDigestSubscriptionUseCaseInterface
+	pages           *PageTemplates
+	location        *time.Location
+}
+
+// NewProfileHandler creates a new ProfileHandler
+func NewProfileHandler(
+	listLogins usecases.ListLoginEventsUseCaseInterface,
+	lastLogin usecases.GetLastLoginUseCaseInterface,
+	getTheme usecases.GetUserThemeUseCaseInterface,
+	listCredentials usecases.ListCredentialsUseCaseInterface,
+	twoFactorStatus usecases.GetTwoFactorStatusUseCaseInterface,
+	overdue usecases.GetOverduePreferenceUseCaseInterface,
+	digest usecases.GetDigestSubscriptionUseCaseInterface,
+	pages *PageTemplates,
+	location *time.Location,
+) *ProfileHandler {
+	return &ProfileHandler{
+		listLogins:      listLogins,
+		lastLogin:       lastLogin,
+		getTheme:        getTheme,
+		listCredentials: listCredentials,
+		twoFactorStatus: twoFactorStatus,
+		overdue:         overdue,
+		digest:          digest,
+		pages:           pages,
+		location:        location,
+	}
+}
+
+// LoginEventResponse represents a login attempt in API responses
+type LoginEventResponse struct {
+	Email     string    `json:"email"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	Success   bool      `json:"success"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ListLogins handles GET /api/me/security/logins?limit=N, listing the latest login attempts
+// of the current user, successful or not
+func (h *ProfileHandler) ListLogins(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			writeAPIError(w, r, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = parsed
+	}
+
+	events, err := h.listLogins.Execute(r.Context(), userID, limit)
+	if err != nil {
+		writeAPIError(w, r, http.StatusInternalServerError, "Failed to list logins")
+		return
+	}
+
+	response := make([]LoginEventResponse, 0, len(events))
+	for _, event := range events {
+		response = append(response, LoginEventResponse{
+			Email:     event.Email,
+			IP:        event.IP,
+			UserAgent: event.UserAgent,
+			Success:   event.Success,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(response)
+}
+
+// digestWeekdays and digestHours are the choices of the weekly digest schedule, from Sunday
+// and midnight
+var (
+	digestWeekdays = []int{0, 1, 2, 3, 4, 5, 6}
+	digestHours    = []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23}
+)
+
+// ProfilePage handles GET /profile, showing the last login, the latest login attempts, the passkeys,
+// the two-factor status, the overdue preference and the weekly digest subscription
+func (h *ProfileHandler) ProfilePage(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok || userID == "" {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	email, _ := r.Context().Value("email").(string)
+
+	lastLogin, err := h.lastLogin.Execute(r.Context(), userID)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	logins, err := h.listLogins.Execute(r.Context(), userID, profileRecentLogins)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	theme, err := h.getTheme.Execute(r.Context(), userID)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	passkeys, err := h.listCredentials.Execute(r.Context(), userID)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	twoFactor, err := h.twoFactorStatus.Execute(r.Context(), userID)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	overdueEnabled, err := h.overdue.Execute(r.Context(), userID)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	digest, err := h.digest.Execute(r.Context(), userID)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	digestWeekday, digestHour := int(defaultDigestWeekday), defaultDigestHour
+	if digest != nil {
+		digestWeekday, digestHour = int(digest.Weekday), digest.Hour
+	}
+
+	loc := requestLocation(r, h.location)
+	locale := RequestLocale(r)
+	tmpl, err := h.pages.Page(locale, template.FuncMap{
+		"formatTime": func(t time.Time) string {
+			return formatDateTime(locale, t.In(loc))
+		},
+	},
+		"base.html", "profile.html", "passkey.html",
+	)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	data := PageData(locale, map[string]interface{}{
+		"Title":     i18n.T(locale, "title.profile"),
+		"UserID":    userID,
+		"Email":     email,
+		"LastLogin": lastLogin,
+		"Logins":    logins,
+		"Passkeys":  passkeys,
+		"TwoFactor": twoFactor,
+		"Overdue":   overdueEnabled,
+		"Theme":     string(theme),
+		"Digest": map[string]interface{}{
+			"Enabled":  digest != nil,
+			"Weekday":  digestWeekday,
+			"Hour":     digestHour,
+			"Weekdays": digestWeekdays,
+			"Hours":    digestHours,
+		},
+	})
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(buf.Bytes())
+}
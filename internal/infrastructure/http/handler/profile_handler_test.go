@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockListLoginEventsUseCase struct {
+	events    []*application.LoginEvent
+	err       error
+	lastLimit int
+}
+
+func (m *mockListLoginEventsUseCase) Execute(ctx context.Context, userID string, limit int) ([]*application.LoginEvent, error) {
+	m.lastLimit = limit
+	return m.events, m.err
+}
+
+type mockGetLastLoginUseCase struct {
+	event *application.LoginEvent
+}
+
+func (m *mockGetLastLoginUseCase) Execute(ctx context.Context, userID string) (*application.LoginEvent, error) {
+	return m.event, nil
+}
+
+func newTestProfileHandler(events []*application.LoginEvent, last *application.LoginEvent) (*ProfileHandler, *mockListLoginEventsUseCase) {
+	list := &mockListLoginEventsUseCase{events: events}
+	h := NewProfileHandler(list, &mockGetLastLoginUseCase{event: last}, &mockGetUserThemeUseCase{theme: application.ThemeLight}, &mockListCredentialsUseCase{}, &mockGetTwoFactorStatusUseCase{}, &mockGetOverduePreferenceUseCase{enabled: true}, &mockGetDigestSubscriptionUseCase{}, NewPageTemplates(""), time.Local)
+	return h, list
+}
+
+func withUser(req *http.Request) *http.Request {
+	ctx := context.WithValue(req.Context(), "userID", "user-123")
+	ctx = context.WithValue(ctx, "email", "ana@example.com")
+	return req.WithContext(ctx)
+}
+
+func TestListLogins(t *testing.T) {
+	createdAt := time.Date(2026, 3, 10, 14, 30, 0, 0, time.UTC)
+	h, list := newTestProfileHandler([]*application.LoginEvent{
+		{ID: "login-2", UserID: "user-123", Email: "ana@example.com", IP: "198.51.100.1", UserAgent: "curl/8.0", Success: false, CreatedAt: createdAt},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	h.ListLogins(w, withUser(httptest.NewRequest("GET", "/api/me/security/logins?limit=5", nil)))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if list.lastLimit != 5 {
+		t.Errorf("limit = %d, want 5", list.lastLimit)
+	}
+	if cc := w.Header().Get("Cache-Control"); cc != "no-store" {
+		t.Errorf("Cache-Control = %q, want no-store", cc)
+	}
+
+	var response []LoginEventResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	want := LoginEventResponse{Email: "ana@example.com", IP: "198.51.100.1", UserAgent: "curl/8.0", Success: false, CreatedAt: createdAt}
+	if len(response) != 1 || response[0] != want {
+		t.Errorf("response = %+v, want [%+v]", response, want)
+	}
+}
+
+func TestListLogins_EmptyListIsArray(t *testing.T) {
+	h, list := newTestProfileHandler(nil, nil)
+
+	w := httptest.NewRecorder()
+	h.ListLogins(w, withUser(httptest.NewRequest("GET", "/api/me/security/logins", nil)))
+
+	if strings.TrimSpace(w.Body.String()) != "[]" {
+		t.Errorf("Expected empty JSON array, got %s", w.Body.String())
+	}
+	if list.lastLimit != 0 {
+		t.Errorf("limit = %d, want 0 so the use case applies its default", list.lastLimit)
+	}
+}
+
+func TestListLogins_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		err        error
+		wantStatus int
+	}{
+		{name: "non-numeric limit", query: "?limit=abc", wantStatus: http.StatusBadRequest},
+		{name: "zero limit", query: "?limit=0", wantStatus: http.StatusBadRequest},
+		{name: "repository failure", err: errors.New("database is locked"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, list := newTestProfileHandler(nil, nil)
+			list.err = tt.err
+
+			w := httptest.NewRecorder()
+			h.ListLogins(w, withUser(httptest.NewRequest("GET", "/api/me/security/logins"+tt.query, nil)))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestProfilePage_ShowsLastLoginAndAttempts(t *testing.T) {
+	last := &application.LoginEvent{ID: "login-1", UserID: "user-123", IP: "203.0.113.7", UserAgent: "Firefox", Success: true, CreatedAt: time.Date(2026, 3, 10, 14, 30, 0, 0, time.UTC)}
+	failed := &application.LoginEvent{ID: "login-2", UserID: "user-123", IP: "198.51.100.1", UserAgent: "<script>alert(1)</script>", CreatedAt: time.Date(2026, 3, 11, 9, 0, 0, 0, time.UTC)}
+	h, list := newTestProfileHandler([]*application.LoginEvent{failed, last}, last)
+
+	req := withUser(httptest.NewRequest("GET", "/profile", nil))
+	req.Header.Set("X-Timezone", "UTC")
+	w := httptest.NewRecorder()
+	h.ProfilePage(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if list.lastLimit != profileRecentLogins {
+		t.Errorf("limit = %d, want %d", list.lastLimit, profileRecentLogins)
+	}
+
+	body := w.Body.String()
+	for _, want := range []string{"ana@example.com", "10/03/2026 14:30 de 203.0.113.7", "Falha", "Sucesso", "&lt;script&gt;"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected page to contain %q", want)
+		}
+	}
+	if strings.Contains(body, "<script>alert(1)</script>") {
+		t.Error("User agent must be escaped")
+	}
+}
+
+func TestProfilePage_WithoutLogins(t *testing.T) {
+	h, _ := newTestProfileHandler(nil, nil)
+
+	w := httptest.NewRecorder()
+	h.ProfilePage(w, withUser(httptest.NewRequest("GET", "/profile", nil)))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "Nenhum login registrado") {
+		t.Error("Expected empty last login message")
+	}
+}
+
+func TestProfilePage_RequiresUser(t *testing.T) {
+	h, _ := newTestProfileHandler(nil, nil)
+
+	w := httptest.NewRecorder()
+	h.ProfilePage(w, httptest.NewRequest("GET", "/profile", nil))
+
+	if w.Code != http.StatusFound || w.Header().Get("Location") != "/login" {
+		t.Errorf("Expected redirect to /login, got %d %q", w.Code, w.Header().Get("Location"))
+	}
+}
+
+func TestProfilePage_ListsPasskeys(t *testing.T) {
+	h, _ := newTestProfileHandler(nil, nil)
+	usedAt := time.Date(2026, 3, 12, 8, 15, 0, 0, time.UTC)
+	h.listCredentials = &mockListCredentialsUseCase{credentials: []*application.Credential{
+		{ID: "cred-1", Name: "<b>Notebook</b>", CreatedAt: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), LastUsedAt: &usedAt},
+		{ID: "cred-2", Name: "Celular", CreatedAt: time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)},
+	}}
+
+	req := withUser(httptest.NewRequest("GET", "/profile", nil))
+	req.Header.Set("X-Timezone", "UTC")
+	w := httptest.NewRecorder()
+	h.ProfilePage(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	body := w.Body.String()
+	for _, want := range []string{"&lt;b&gt;Notebook&lt;/b&gt;", "último uso em 12/03/2026 08:15", "Celular", "nunca usada", `data-id="cred-2"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected page to contain %q", want)
+		}
+	}
+}
+
+func TestProfilePage_ShowsTwoFactorStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   *usecases.TwoFactorStatus
+		want     []string
+		dontWant string
+	}{
+		{
+			name:     "disabled offers enrollment",
+			status:   &usecases.TwoFactorStatus{},
+			want:     []string{"Desativada.", `onclick="setupTwoFactor()"`},
+			dontWant: `onsubmit="disableTwoFactor(event)"`,
+		},
+		{
+			name:     "enabled offers disabling",
+			status:   &usecases.TwoFactorStatus{Enabled: true, RecoveryCodesLeft: 8},
+			want:     []string{"Códigos de recuperação restantes: 8.", `onsubmit="disableTwoFactor(event)"`},
+			dontWant: `onclick="setupTwoFactor()"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newTestProfileHandler(nil, nil)
+			h.twoFactorStatus = &mockGetTwoFactorStatusUseCase{status: tt.status}
+
+			w := httptest.NewRecorder()
+			h.ProfilePage(w, withUser(httptest.NewRequest("GET", "/profile", nil)))
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			body := w.Body.String()
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("Expected page to contain %q", want)
+				}
+			}
+			if strings.Contains(body, tt.dontWant) {
+				t.Errorf("Expected page not to contain %q", tt.dontWant)
+			}
+		})
+	}
+}
+
+func TestProfilePage_ShowsOverduePreference(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		h, _ := newTestProfileHandler(nil, nil)
+		h.overdue = &mockGetOverduePreferenceUseCase{enabled: enabled}
+
+		w := httptest.NewRecorder()
+		h.ProfilePage(w, withUser(httptest.NewRequest("GET", "/profile", nil)))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		body := w.Body.String()
+		if !strings.Contains(body, `hx-post="/web/preferences/overdue"`) {
+			t.Fatal("Expected the overdue preference checkbox")
+		}
+		if got := strings.Contains(body, `value="true" checked`); got != enabled {
+			t.Errorf("checked = %v, want %v", got, enabled)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// maxProjectBodySize limits the body of a project request, which only carries a name and a color
+const maxProjectBodySize = 4 << 10 // 4KB
+
+// ProjectHandler handles HTTP requests for projects
+type ProjectHandler struct {
+	createProject    usecases.CreateProjectUseCaseInterface
+	listProjects     usecases.ListProjectsUseCaseInterface
+	getProject       usecases.GetProjectUseCaseInterface
+	updateProject    usecases.UpdateProjectUseCaseInterface
+	deleteProject    usecases.DeleteProjectUseCaseInterface
+	listProjectTasks usecases.ListProjectTasksUseCaseInterface
+	shareProject     usecases.ShareProjectUseCaseInterface
+	unshareProject   usecases.UnshareProjectUseCaseInterface
+	pages            *PageTemplates
+}
+
+// NewProjectHandler creates a new ProjectHandler
+func NewProjectHandler(
+	createProject usecases.CreateProjectUseCaseInterface,
+	listProjects usecases.ListProjectsUseCaseInterface,
+	getProject usecases.GetProjectUseCaseInterface,
+	updateProject usecases.UpdateProjectUseCaseInterface,
+	deleteProject usecases.DeleteProjectUseCaseInterface,
+	listProjectTasks usecases.ListProjectTasksUseCaseInterface,
+	shareProject usecases.ShareProjectUseCaseInterface,
+	unshareProject usecases.UnshareProjectUseCaseInterface,
+	pages *PageTemplates,
+) *ProjectHandler {
+	return &ProjectHandler{
+		createProject:    createProject,
+		listProjects:     listProjects,
+		getProject:       getProject,
+		updateProject:    updateProject,
+		deleteProject:    deleteProject,
+		listProjectTasks: listProjectTasks,
+		shareProject:     shareProject,
+		unshareProject:   unshareProject,
+		pages:            pages,
+	}
+}
+
+// ProjectRequest is the body of POST /api/projects and PUT /api/projects/{id}
+type ProjectRequest struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// ShareProjectRequest is the body of POST /api/projects/{id}/shares
+type ShareProjectRequest struct {
+	UserID string `json:"user_id"`
+}
+
+// ProjectResponse is the JSON representation of a project
+type ProjectResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	OwnerID   string    `json:"owner_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProjectListResponse is the body of GET /api/projects
+type ProjectListResponse struct {
+	Owned  []ProjectResponse `json:"owned"`
+	Shared []ProjectResponse `json:"shared"`
+}
+
+func toProjectResponse(project *application.Project) ProjectResponse {
+	return ProjectResponse{
+		ID:        project.ID,
+		Name:      project.Name,
+		Color:     project.Color,
+		OwnerID:   project.OwnerID,
+		CreatedAt: project.CreatedAt,
+		UpdatedAt: project.UpdatedAt,
+	}
+}
+
+func toProjectResponses(projects []*application.Project) []ProjectResponse {
+	response := make([]ProjectResponse, 0, len(projects))
+	for _, project := range projects {
+		response = append(response, toProjectResponse(project))
+	}
+	return response
+}
+
+// CreateProject handles POST /api/projects
+func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req ProjectRequest
+	if !decodeProjectBody(w, r, &req) {
+		return
+	}
+
+	project, err := h.createProject.Execute(r.Context(), userID, req.Name, req.Color)
+	if err != nil {
+		status, message := projectErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toProjectResponse(project))
+}
+
+// ListProjects handles GET /api/projects
+func (h *ProjectHandler) ListProjects(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	list, err := h.listProjects.Execute(r.Context(), userID)
+	if err != nil {
+		status, message := projectErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ProjectListResponse{
+		Owned:  toProjectResponses(list.Owned),
+		Shared: toProjectResponses(list.Shared),
+	})
+}
+
+// GetProject handles GET /api/projects/{id}
+func (h *ProjectHandler) GetProject(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	project, err := h.getProject.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		status, message := projectErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toProjectResponse(project))
+}
+
+// UpdateProject handles PUT /api/projects/{id}
+func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req ProjectRequest
+	if !decodeProjectBody(w, r, &req) {
+		return
+	}
+
+	project, err := h.updateProject.Execute(r.Context(), r.PathValue("id"), userID, req.Name, req.Color)
+	if err != nil {
+		status, message := projectErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toProjectResponse(project))
+}
+
+// DeleteProject handles DELETE /api/projects/{id}
+func (h *ProjectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := h.deleteProject.Execute(r.Context(), r.PathValue("id"), userID); err != nil {
+		status, message := projectErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListProjectTasks handles GET /api/projects/{id}/tasks
+func (h *ProjectHandler) ListProjectTasks(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	tasks, err := h.listProjectTasks.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		status, message := projectErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toTaskResponses(tasks))
+}
+
+// ShareProject handles POST /api/projects/{id}/shares
+func (h *ProjectHandler) ShareProject(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req ShareProjectRequest
+	if !decodeProjectBody(w, r, &req) {
+		return
+	}
+	if req.UserID == "" {
+		writeAPIError(w, r, http.StatusBadRequest, "user_id is required")
+		return
+	}
+
+	if err := h.shareProject.Execute(r.Context(), r.PathValue("id"), userID, req.UserID); err != nil {
+		status, message := projectErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnshareProject handles DELETE /api/projects/{id}/shares/{userID}
+func (h *ProjectHandler) UnshareProject(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := h.unshareProject.Execute(r.Context(), r.PathValue("id"), userID, r.PathValue("userID")); err != nil {
+		status, message := projectErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// WebCreateProject handles POST /web/projects, the create form of the tasks page sidebar, and
+// sends the browser to the new project
+func (h *ProjectHandler) WebCreateProject(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok || userID == "" {
+		h.pages.writeWebError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	project, err := h.createProject.Execute(r.Context(), userID, r.FormValue("name"), r.FormValue("color"))
+	if err != nil {
+		status, message := projectErrorStatus(err)
+		h.pages.writeWebError(w, r, status, message)
+		return
+	}
+
+	writeWebRedirect(w, r, "/tasks?project="+url.QueryEscape(project.ID))
+}
+
+// decodeProjectBody decodes a size-limited JSON body, writing the error response when it's invalid
+func decodeProjectBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	return decodeJSON(w, r, v, maxProjectBodySize)
+}
+
+// projectErrorStatus maps a project use case error to an HTTP status and client message
+func projectErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, application.ErrProjectNotFound), errors.Is(err, application.ErrUserNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, application.ErrProjectPermissionDenied):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, application.ErrProjectNameRequired),
+		errors.Is(err, application.ErrProjectNameTooLong),
+		errors.Is(err, application.ErrInvalidProjectColor),
+		errors.Is(err, application.ErrCannotShareProjectToSelf):
+		return http.StatusBadRequest, err.Error()
+	default:
+		log.Printf("project operation failed: %v", err)
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
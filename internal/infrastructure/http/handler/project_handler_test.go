@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockCreateProjectUseCase struct {
+	err error
+}
+
+func (m *mockCreateProjectUseCase) Execute(ctx context.Context, ownerID, name, color string) (*application.Project, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if color == "" {
+		color = application.DefaultProjectColor
+	}
+	return &application.Project{ID: "proj-1", Name: name, Color: color, OwnerID: ownerID}, nil
+}
+
+type mockListProjectsUseCase struct {
+	list *usecases.ProjectList
+}
+
+func (m *mockListProjectsUseCase) Execute(ctx context.Context, userID string) (*usecases.ProjectList, error) {
+	if m.list == nil {
+		return &usecases.ProjectList{}, nil
+	}
+	return m.list, nil
+}
+
+type mockGetProjectUseCase struct {
+	err error
+}
+
+func (m *mockGetProjectUseCase) Execute(ctx context.Context, projectID, userID string) (*application.Project, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &application.Project{ID: projectID, Name: "Trabalho", Color: "#ef4444", OwnerID: userID}, nil
+}
+
+type mockUpdateProjectUseCase struct {
+	err error
+}
+
+func (m *mockUpdateProjectUseCase) Execute(ctx context.Context, projectID, userID, name, color string) (*application.Project, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &application.Project{ID: projectID, Name: name, Color: color, OwnerID: userID}, nil
+}
+
+type mockDeleteProjectUseCase struct {
+	err error
+}
+
+func (m *mockDeleteProjectUseCase) Execute(ctx context.Context, projectID, userID string) error {
+	return m.err
+}
+
+type mockListProjectTasksUseCase struct {
+	tasks []*application.Task
+	err   error
+}
+
+func (m *mockListProjectTasksUseCase) Execute(ctx context.Context, projectID, userID string) ([]*application.Task, error) {
+	return m.tasks, m.err
+}
+
+type mockShareProjectUseCase struct {
+	sharedWith string
+	err        error
+}
+
+func (m *mockShareProjectUseCase) Execute(ctx context.Context, projectID, ownerID, shareWithUserID string) error {
+	m.sharedWith = shareWithUserID
+	return m.err
+}
+
+type mockUnshareProjectUseCase struct {
+	err error
+}
+
+func (m *mockUnshareProjectUseCase) Execute(ctx context.Context, projectID, ownerID, userID string) error {
+	return m.err
+}
+
+// projectHandlerMocks holds the use cases behind a ProjectHandler built by newTestProjectHandler
+type projectHandlerMocks struct {
+	create  *mockCreateProjectUseCase
+	list    *mockListProjectsUseCase
+	get     *mockGetProjectUseCase
+	update  *mockUpdateProjectUseCase
+	delete  *mockDeleteProjectUseCase
+	tasks   *mockListProjectTasksUseCase
+	share   *mockShareProjectUseCase
+	unshare *mockUnshareProjectUseCase
+}
+
+func newTestProjectHandler() (*ProjectHandler, *projectHandlerMocks) {
+	m := &projectHandlerMocks{
+		create:  &mockCreateProjectUseCase{},
+		list:    &mockListProjectsUseCase{},
+		get:     &mockGetProjectUseCase{},
+		update:  &mockUpdateProjectUseCase{},
+		delete:  &mockDeleteProjectUseCase{},
+		tasks:   &mockListProjectTasksUseCase{},
+		share:   &mockShareProjectUseCase{},
+		unshare: &mockUnshareProjectUseCase{},
+	}
+	return NewProjectHandler(m.create, m.list, m.get, m.update, m.delete, m.tasks, m.share, m.unshare, NewPageTemplates("")), m
+}
+
+func newProjectRequest(method, body string) *http.Request {
+	req := httptest.NewRequest(method, "/api/projects/proj-1", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", "proj-1")
+	req.SetPathValue("userID", "user-456")
+	return withUser(req)
+}
+
+func TestCreateProject(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{"created", `{"name": "Trabalho", "color": "#ef4444"}`, nil, http.StatusCreated},
+		{"invalid body", `{`, nil, http.StatusBadRequest},
+		{"empty name", `{"name": ""}`, application.ErrProjectNameRequired, http.StatusBadRequest},
+		{"invalid color", `{"name": "Trabalho", "color": "red"}`, application.ErrInvalidProjectColor, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, m := newTestProjectHandler()
+			m.create.err = tt.err
+
+			w := httptest.NewRecorder()
+			h.CreateProject(w, newProjectRequest("POST", tt.body))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+
+			var resp ProjectResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("invalid response: %v", err)
+			}
+			if resp.ID != "proj-1" || resp.Name != "Trabalho" || resp.Color != "#ef4444" || resp.OwnerID != "user-123" {
+				t.Errorf("response = %+v", resp)
+			}
+		})
+	}
+}
+
+func TestListProjects(t *testing.T) {
+	h, m := newTestProjectHandler()
+	m.list.list = &usecases.ProjectList{Owned: []*application.Project{{ID: "proj-1", Name: "Trabalho"}}}
+
+	w := httptest.NewRecorder()
+	h.ListProjects(w, newProjectRequest("GET", ""))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	var raw map[string][]map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&raw); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	// Empty lists are encoded as [] rather than null
+	if len(raw["owned"]) != 1 || raw["owned"][0]["id"] != "proj-1" || raw["shared"] == nil {
+		t.Errorf("response = %v", raw)
+	}
+}
+
+func TestProjectHandler_ErrorStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"not found", application.ErrProjectNotFound, http.StatusNotFound},
+		{"shared user", application.ErrProjectPermissionDenied, http.StatusForbidden},
+		{"invalid name", application.ErrProjectNameTooLong, http.StatusBadRequest},
+		{"unexpected", context.DeadlineExceeded, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, m := newTestProjectHandler()
+			m.get.err = tt.err
+			m.update.err = tt.err
+			m.delete.err = tt.err
+			m.tasks.err = tt.err
+
+			for name, serve := range map[string]func(http.ResponseWriter, *http.Request){
+				"GetProject":       h.GetProject,
+				"UpdateProject":    h.UpdateProject,
+				"DeleteProject":    h.DeleteProject,
+				"ListProjectTasks": h.ListProjectTasks,
+			} {
+				w := httptest.NewRecorder()
+				serve(w, newProjectRequest("PUT", `{"name": "Pessoal"}`))
+				if w.Code != tt.wantStatus {
+					t.Errorf("%s status = %d, want %d", name, w.Code, tt.wantStatus)
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteProject(t *testing.T) {
+	h, _ := newTestProjectHandler()
+
+	w := httptest.NewRecorder()
+	h.DeleteProject(w, newProjectRequest("DELETE", ""))
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want 204", w.Code)
+	}
+}
+
+func TestShareProject(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{"shared", `{"user_id": "user-456"}`, nil, http.StatusNoContent},
+		{"missing user", `{}`, nil, http.StatusBadRequest},
+		{"unknown user", `{"user_id": "user-999"}`, application.ErrUserNotFound, http.StatusNotFound},
+		{"with self", `{"user_id": "user-123"}`, application.ErrCannotShareProjectToSelf, http.StatusBadRequest},
+		{"not the owner", `{"user_id": "user-456"}`, application.ErrProjectPermissionDenied, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, m := newTestProjectHandler()
+			m.share.err = tt.err
+
+			w := httptest.NewRecorder()
+			h.ShareProject(w, newProjectRequest("POST", tt.body))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusNoContent && m.share.sharedWith != "user-456" {
+				t.Errorf("shared with %q, want user-456", m.share.sharedWith)
+			}
+		})
+	}
+}
+
+func TestUnshareProject(t *testing.T) {
+	h, _ := newTestProjectHandler()
+
+	w := httptest.NewRecorder()
+	h.UnshareProject(w, newProjectRequest("DELETE", ""))
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want 204", w.Code)
+	}
+}
+
+func TestWebCreateProject(t *testing.T) {
+	h, _ := newTestProjectHandler()
+
+	form := url.Values{"name": {"Trabalho"}, "color": {"#ef4444"}}
+	req := htmx(httptest.NewRequest("POST", "/web/projects", strings.NewReader(form.Encode())))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.WebCreateProject(w, withUser(req))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("HX-Redirect"); got != "/tasks?project=proj-1" {
+		t.Errorf("HX-Redirect = %q, want the new project page", got)
+	}
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"html"
+	"net/http"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/quickadd"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// QuickAddHandler handles creating tasks from a single line of text
+type QuickAddHandler struct {
+	quickAdd usecases.QuickAddTaskUseCaseInterface
+	pages    *PageTemplates
+	location *time.Location
+}
+
+// NewQuickAddHandler creates a new QuickAddHandler
+func NewQuickAddHandler(quickAdd usecases.QuickAddTaskUseCaseInterface, pages *PageTemplates, location *time.Location) *QuickAddHandler {
+	return &QuickAddHandler{quickAdd: quickAdd, pages: pages, location: location}
+}
+
+type QuickAddRequest struct {
+	Text      string `json:"text"`
+	ProjectID string `json:"project_id"`
+}
+
+// QuickAdd handles POST /api/tasks/quick
+func (h *QuickAddHandler) QuickAdd(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req QuickAddRequest
+	if !decodeJSON(w, r, &req, maxJSONBodySize) {
+		return
+	}
+
+	task, err := h.quickAdd.Execute(r.Context(), userID, req.Text, req.ProjectID, requestLocation(r, h.location))
+	if err != nil {
+		if writeCreationQuotaError(w, r, err) {
+			return
+		}
+		writeAPIError(w, r, http.StatusBadRequest, quickAddErrorMessage(err, RequestLocale(r)))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toTaskResponse(task, ""))
+}
+
+// WebQuickAdd handles POST /web/tasks/quick, returning the new task card. Errors are shown
+// below the quick-add box, which keeps the typed text.
+func (h *QuickAddHandler) WebQuickAdd(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := r.ParseForm(); err != nil {
+		h.pages.writeWebError(w, r, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+
+	locale := RequestLocale(r)
+	task, err := h.quickAdd.Execute(r.Context(), userID, r.FormValue("text"), r.FormValue("project_id"), requestLocation(r, h.location))
+	if err != nil {
+		status := http.StatusBadRequest
+		if creationQuotaExceeded(w, err) != nil {
+			status = http.StatusTooManyRequests
+		}
+		// Without HTMX there's no error box to fill in, and a redirect would lose the error
+		if !IsHTMX(r) {
+			h.pages.writeWebError(w, r, status, quickAddErrorMessage(err, locale))
+			return
+		}
+		if status != http.StatusTooManyRequests {
+			status = http.StatusOK
+		}
+		w.Header().Set("HX-Retarget", "#quick-add-error")
+		w.Header().Set("HX-Reswap", "innerHTML")
+		h.pages.writeWebFragment(w, r, status, html.EscapeString(quickAddErrorMessage(err, locale)))
+		return
+	}
+
+	card, err := renderTaskCard(&application.TaskView{Task: task}, userID, locale)
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Clear the error of a previous attempt along with adding the card
+	h.pages.writeWebFragment(w, r, http.StatusOK, card+`<div id="quick-add-error" hx-swap-oob="innerHTML"></div>`)
+}
+
+// quickAddErrorMessage returns the message shown for a failed quick add
+func quickAddErrorMessage(err error, locale application.Locale) string {
+	var unknownPriority *quickadd.UnknownPriorityError
+	var exceeded *application.CreationQuotaExceededError
+	switch {
+	case errors.Is(err, quickadd.ErrEmptyTitle):
+		return i18n.T(locale, "quickadd.empty_title")
+	case errors.As(err, &unknownPriority):
+		return i18n.T(locale, "quickadd.unknown_priority", unknownPriority.Priority)
+	case errors.As(err, &exceeded):
+		return creationQuotaMessage(locale, exceeded)
+	default:
+		return err.Error()
+	}
+}
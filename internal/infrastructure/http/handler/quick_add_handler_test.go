@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/quickadd"
+)
+
+type mockQuickAddTaskUseCase struct {
+	err       error
+	text      string
+	projectID string
+	loc       *time.Location
+}
+
+func (m *mockQuickAddTaskUseCase) Execute(ctx context.Context, ownerID, text, projectID string, loc *time.Location) (*application.Task, error) {
+	m.text, m.projectID, m.loc = text, projectID, loc
+	if m.err != nil {
+		return nil, m.err
+	}
+	task, _ := application.NewTask("task-1", "Comprar pão", "", application.StatusPending, ownerID, "")
+	task.SetPriority(application.PriorityHigh)
+	task.SetTags([]string{"compras"})
+	return task, nil
+}
+
+func TestQuickAdd(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+		wantBody   string
+	}{
+		{"creates task", `{"text": "Comprar pão amanhã !alta #compras", "project_id": "p-1"}`, nil, http.StatusCreated, `"priority":"high","tags":["compras"]`},
+		{"invalid body", `{"text": 1}`, nil, http.StatusBadRequest, "invalid_body"},
+		{"no title", `{"text": "#compras"}`, quickadd.ErrEmptyTitle, http.StatusBadRequest, "Informe um título"},
+		{"unknown priority", `{"text": "Pagar !já"}`, &quickadd.UnknownPriorityError{Priority: "!já"}, http.StatusBadRequest, "Prioridade desconhecida !já"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quickAdd := &mockQuickAddTaskUseCase{err: tt.err}
+			h := NewQuickAddHandler(quickAdd, NewPageTemplates(""), time.Local)
+
+			req := httptest.NewRequest("POST", "/api/tasks/quick", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Timezone", "America/Sao_Paulo")
+			w := httptest.NewRecorder()
+			h.QuickAdd(w, withUser(req))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("body = %s, want it to contain %s", w.Body.String(), tt.wantBody)
+			}
+			if tt.wantStatus == http.StatusCreated && (quickAdd.projectID != "p-1" || quickAdd.loc.String() != "America/Sao_Paulo") {
+				t.Errorf("use case called with project %q and location %v", quickAdd.projectID, quickAdd.loc)
+			}
+		})
+	}
+}
+
+func TestWebQuickAdd(t *testing.T) {
+	t.Run("renders the new task card", func(t *testing.T) {
+		h := NewQuickAddHandler(&mockQuickAddTaskUseCase{}, NewPageTemplates(""), time.Local)
+
+		req := htmx(httptest.NewRequest("POST", "/web/tasks/quick", strings.NewReader("text=Comprar+p%C3%A3o+%21alta+%23compras")))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept-Language", "en")
+		w := httptest.NewRecorder()
+		h.WebQuickAdd(w, withUser(req))
+
+		body := w.Body.String()
+		if w.Code != http.StatusOK || w.Header().Get("HX-Retarget") != "" {
+			t.Fatalf("status = %d, HX-Retarget = %q", w.Code, w.Header().Get("HX-Retarget"))
+		}
+		for _, want := range []string{`id="task-task-1"`, "Comprar pão", "High priority", "#compras", `id="quick-add-error" hx-swap-oob`} {
+			if !strings.Contains(body, want) {
+				t.Errorf("body does not contain %q:\n%s", want, body)
+			}
+		}
+	})
+
+	t.Run("shows errors below the box", func(t *testing.T) {
+		h := NewQuickAddHandler(&mockQuickAddTaskUseCase{err: &quickadd.UnknownPriorityError{Priority: "!<b>"}}, NewPageTemplates(""), time.Local)
+
+		req := htmx(httptest.NewRequest("POST", "/web/tasks/quick", strings.NewReader("text=x")))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept-Language", "en")
+		w := httptest.NewRecorder()
+		h.WebQuickAdd(w, withUser(req))
+
+		if w.Header().Get("HX-Retarget") != "#quick-add-error" || w.Header().Get("HX-Reswap") != "innerHTML" {
+			t.Errorf("HX-Retarget = %q, HX-Reswap = %q; want the error element", w.Header().Get("HX-Retarget"), w.Header().Get("HX-Reswap"))
+		}
+		if body := w.Body.String(); body != "Unknown priority !&lt;b&gt;. Use !alta, !media or !baixa." {
+			t.Errorf("body = %q, want the escaped message", body)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// maxReminderBodySize limits the body of a reminder request, which only carries a time
+const maxReminderBodySize = 4 << 10 // 4KB
+
+// ReminderHandler handles HTTP requests for task reminders
+type ReminderHandler struct {
+	createReminder usecases.CreateReminderUseCaseInterface
+	listReminders  usecases.ListRemindersUseCaseInterface
+	deleteReminder usecases.DeleteReminderUseCaseInterface
+	location       *time.Location
+}
+
+// NewReminderHandler creates a new ReminderHandler
+func NewReminderHandler(
+	createReminder usecases.CreateReminderUseCaseInterface,
+	listReminders usecases.ListRemindersUseCaseInterface,
+	deleteReminder usecases.DeleteReminderUseCaseInterface,
+	location *time.Location,
+) *ReminderHandler {
+	return &ReminderHandler{
+		createReminder: createReminder,
+		listReminders:  listReminders,
+		deleteReminder: deleteReminder,
+		location:       location,
+	}
+}
+
+// CreateReminderRequest is the body of POST /api/tasks/{id}/reminders
+type CreateReminderRequest struct {
+	RemindAt string `json:"remind_at"`
+}
+
+// ReminderResponse is the JSON representation of a reminder
+type ReminderResponse struct {
+	ID        string     `json:"id"`
+	TaskID    string     `json:"task_id"`
+	RemindAt  time.Time  `json:"remind_at"`
+	SentAt    *time.Time `json:"sent_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func toReminderResponse(reminder *application.Reminder) ReminderResponse {
+	return ReminderResponse{
+		ID:        reminder.ID,
+		TaskID:    reminder.TaskID,
+		RemindAt:  reminder.RemindAt,
+		SentAt:    reminder.SentAt,
+		CreatedAt: reminder.CreatedAt,
+	}
+}
+
+// CreateReminder handles POST /api/tasks/{id}/reminders
+func (h *ReminderHandler) CreateReminder(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req CreateReminderRequest
+	if !decodeJSON(w, r, &req, maxReminderBodySize) {
+		return
+	}
+
+	// remind_at accepts the same formats as due dates, including expressions like "amanhã 9h"
+	remindAt, err := parseDueDateInput(r, req.RemindAt, h.location)
+	if err != nil {
+		WriteJSONError(w, r, http.StatusBadRequest, CodeInvalidRemindAt, err.Error())
+		return
+	}
+	if remindAt == nil {
+		WriteJSONError(w, r, http.StatusBadRequest, CodeInvalidRemindAt, "remind_at is required")
+		return
+	}
+
+	reminder, err := h.createReminder.Execute(r.Context(), r.PathValue("id"), userID, *remindAt)
+	if err != nil {
+		if errors.Is(err, application.ErrReminderInPast) {
+			WriteJSONError(w, r, http.StatusBadRequest, CodeInvalidRemindAt, err.Error())
+			return
+		}
+		status, message := reminderErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toReminderResponse(reminder))
+}
+
+// ListReminders handles GET /api/tasks/{id}/reminders
+func (h *ReminderHandler) ListReminders(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	reminders, err := h.listReminders.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		status, message := reminderErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	response := make([]ReminderResponse, 0, len(reminders))
+	for _, reminder := range reminders {
+		response = append(response, toReminderResponse(reminder))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// DeleteReminder handles DELETE /api/tasks/{id}/reminders/{reminderID}
+func (h *ReminderHandler) DeleteReminder(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := h.deleteReminder.Execute(r.Context(), r.PathValue("id"), r.PathValue("reminderID"), userID); err != nil {
+		status, message := reminderErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// reminderErrorStatus maps a reminder use case error to an HTTP status and client message
+func reminderErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecases.ErrTaskUnavailable), errors.Is(err, application.ErrReminderNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, application.ErrTooManyReminders):
+		return http.StatusConflict, err.Error()
+	default:
+		log.Printf("reminder operation failed: %v", err)
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
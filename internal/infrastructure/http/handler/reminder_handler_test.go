@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockCreateReminderUseCase struct {
+	remindAt time.Time
+	err      error
+}
+
+func (m *mockCreateReminderUseCase) Execute(ctx context.Context, taskID, userID string, remindAt time.Time) (*application.Reminder, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.remindAt = remindAt
+	return &application.Reminder{ID: "rem-1", TaskID: taskID, UserID: userID, RemindAt: remindAt}, nil
+}
+
+type mockListRemindersUseCase struct {
+	reminders []*application.Reminder
+	err       error
+}
+
+func (m *mockListRemindersUseCase) Execute(ctx context.Context, taskID, userID string) ([]*application.Reminder, error) {
+	return m.reminders, m.err
+}
+
+type mockDeleteReminderUseCase struct {
+	err error
+}
+
+func (m *mockDeleteReminderUseCase) Execute(ctx context.Context, taskID, reminderID, userID string) error {
+	return m.err
+}
+
+func newReminderRequest(method, body string) *http.Request {
+	req := httptest.NewRequest(method, "/api/tasks/task-1/reminders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", "task-1")
+	req.SetPathValue("reminderID", "rem-1")
+	return withUser(req)
+}
+
+func TestCreateReminder(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"valid RFC 3339 time", `{"remind_at": "2099-01-02T09:00:00Z"}`, nil, http.StatusCreated, ""},
+		{"invalid body", `{`, nil, http.StatusBadRequest, CodeInvalidBody},
+		{"missing time", `{}`, nil, http.StatusBadRequest, CodeInvalidRemindAt},
+		{"unparseable time", `{"remind_at": "quando der"}`, nil, http.StatusBadRequest, CodeInvalidRemindAt},
+		{"time in the past", `{"remind_at": "2099-01-02T09:00:00Z"}`, application.ErrReminderInPast, http.StatusBadRequest, CodeInvalidRemindAt},
+		{"task not visible", `{"remind_at": "2099-01-02T09:00:00Z"}`, usecases.ErrTaskUnavailable, http.StatusNotFound, "not_found"},
+		{"too many reminders", `{"remind_at": "2099-01-02T09:00:00Z"}`, application.ErrTooManyReminders, http.StatusConflict, "conflict"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			create := &mockCreateReminderUseCase{err: tt.err}
+			h := NewReminderHandler(create, &mockListRemindersUseCase{}, &mockDeleteReminderUseCase{}, time.Local)
+
+			w := httptest.NewRecorder()
+			h.CreateReminder(w, newReminderRequest("POST", tt.body))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantCode != "" {
+				var resp ErrorResponse
+				if err := json.NewDecoder(w.Body).Decode(&resp); err != nil || resp.Error.Code != tt.wantCode {
+					t.Errorf("error code = %q (%v), want %q", resp.Error.Code, err, tt.wantCode)
+				}
+				return
+			}
+
+			var resp ReminderResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("invalid response: %v", err)
+			}
+			want := time.Date(2099, 1, 2, 9, 0, 0, 0, time.UTC)
+			if resp.ID != "rem-1" || resp.TaskID != "task-1" || !resp.RemindAt.Equal(want) || !create.remindAt.Equal(want) {
+				t.Errorf("response = %+v", resp)
+			}
+		})
+	}
+}
+
+func TestListReminders(t *testing.T) {
+	sentAt := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	list := &mockListRemindersUseCase{reminders: []*application.Reminder{
+		{ID: "rem-1", TaskID: "task-1", RemindAt: sentAt, SentAt: &sentAt},
+		{ID: "rem-2", TaskID: "task-1", RemindAt: sentAt.Add(time.Hour)},
+	}}
+	h := NewReminderHandler(&mockCreateReminderUseCase{}, list, &mockDeleteReminderUseCase{}, time.Local)
+
+	w := httptest.NewRecorder()
+	h.ListReminders(w, newReminderRequest("GET", ""))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	var raw []map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&raw); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if len(raw) != 2 || raw[0]["sent_at"] == nil || raw[1]["sent_at"] != nil {
+		t.Errorf("response = %v", raw)
+	}
+}
+
+func TestDeleteReminder(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"deleted", nil, http.StatusNoContent},
+		{"reminder of another user", application.ErrReminderNotFound, http.StatusNotFound},
+		{"task not visible", usecases.ErrTaskUnavailable, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewReminderHandler(&mockCreateReminderUseCase{}, &mockListRemindersUseCase{}, &mockDeleteReminderUseCase{err: tt.err}, time.Local)
+
+			w := httptest.NewRecorder()
+			h.DeleteReminder(w, newReminderRequest("DELETE", ""))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// ReportHandler handles HTTP requests for team reports
+type ReportHandler struct {
+	overdueReport usecases.OverdueReportUseCaseInterface
+}
+
+// NewReportHandler creates a new ReportHandler
+func NewReportHandler(overdueReport usecases.OverdueReportUseCaseInterface) *ReportHandler {
+	return &ReportHandler{
+		overdueReport: overdueReport,
+	}
+}
+
+// OverdueReport handles GET /api/admin/reports/overdue?unit=&format=json|csv|pdf
+func (h *ReportHandler) OverdueReport(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	unit := r.URL.Query().Get("unit")
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" && format != "pdf" {
+		writeAPIError(w, r, http.StatusBadRequest, "format must be json, csv or pdf")
+		return
+	}
+
+	report, err := h.overdueReport.Execute(r.Context(), userID, unit)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrUnitRequired):
+			writeAPIError(w, r, http.StatusBadRequest, err.Error())
+		case errors.Is(err, usecases.ErrReportForbidden), errors.Is(err, application.ErrUserNotFound):
+			writeAPIError(w, r, http.StatusForbidden, usecases.ErrReportForbidden.Error())
+		default:
+			writeAPIError(w, r, http.StatusInternalServerError, "Failed to generate report")
+		}
+		return
+	}
+
+	filename := fmt.Sprintf("atrasadas_%s", report.GeneratedAt.Format("20060102_150405"))
+
+	switch format {
+	case "csv":
+		data, err := usecases.OverdueReportCSV(report)
+		if err != nil {
+			writeAPIError(w, r, http.StatusInternalServerError, "Failed to generate report")
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.csv", filename))
+		w.Write(data)
+	case "pdf":
+		data, err := usecases.OverdueReportPDF(report)
+		if err != nil {
+			writeAPIError(w, r, http.StatusInternalServerError, "Failed to generate report")
+			return
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.pdf", filename))
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(data)))
+		w.Write(data)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(toOverdueReportResponse(report))
+	}
+}
+
+// OverdueReportResponse is the JSON representation of the overdue tasks report of a unit
+type OverdueReportResponse struct {
+	Unit        string                       `json:"unit"`
+	GeneratedAt time.Time                    `json:"generated_at"`
+	Users       []UserOverdueSummaryResponse `json:"users"`
+}
+
+// UserOverdueSummaryResponse is the overdue tasks of one user in an OverdueReportResponse
+type UserOverdueSummaryResponse struct {
+	UserID        string                `json:"user_id"`
+	Name          string                `json:"name"`
+	Email         string                `json:"email"`
+	OverdueCount  int                   `json:"overdue_count"`
+	OldestDueDate time.Time             `json:"oldest_due_date"`
+	Tasks         []OverdueTaskResponse `json:"tasks"`
+}
+
+// OverdueTaskResponse is an overdue task in a report, without its description
+type OverdueTaskResponse struct {
+	TaskID  string    `json:"task_id"`
+	Title   string    `json:"title"`
+	Status  string    `json:"status"`
+	DueDate time.Time `json:"due_date"`
+}
+
+func toOverdueReportResponse(report *application.OverdueReport) OverdueReportResponse {
+	response := OverdueReportResponse{
+		Unit:        report.Unit,
+		GeneratedAt: report.GeneratedAt,
+		Users:       make([]UserOverdueSummaryResponse, 0, len(report.Users)),
+	}
+	for _, user := range report.Users {
+		summary := UserOverdueSummaryResponse{
+			UserID:        user.UserID,
+			Name:          user.Name,
+			Email:         user.Email,
+			OverdueCount:  user.OverdueCount,
+			OldestDueDate: user.OldestDueDate,
+			Tasks:         make([]OverdueTaskResponse, 0, len(user.Tasks)),
+		}
+		for _, task := range user.Tasks {
+			summary.Tasks = append(summary.Tasks, OverdueTaskResponse{
+				TaskID:  task.TaskID,
+				Title:   task.Title,
+				Status:  string(task.Status),
+				DueDate: task.DueDate,
+			})
+		}
+		response.Users = append(response.Users, summary)
+	}
+	return response
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// mockOverdueReportUseCase is a mock implementation of OverdueReportUseCaseInterface
+type mockOverdueReportUseCase struct {
+	executeFunc func(ctx context.Context, requesterID, unit string) (*application.OverdueReport, error)
+}
+
+func (m *mockOverdueReportUseCase) Execute(ctx context.Context, requesterID, unit string) (*application.OverdueReport, error) {
+	return m.executeFunc(ctx, requesterID, unit)
+}
+
+func TestOverdueReport(t *testing.T) {
+	now := time.Now()
+	report := application.NewOverdueReport("fiscal", []*application.OverdueTask{
+		{TaskID: "t1", OwnerID: "u1", OwnerName: "Ana", OwnerEmail: "ana@example.com", DueDate: now.Add(-48 * time.Hour)},
+	}, now)
+
+	tests := []struct {
+		name            string
+		query           string
+		err             error
+		wantStatus      int
+		wantContentType string
+	}{
+		{name: "json by default", query: "?unit=fiscal", wantStatus: http.StatusOK, wantContentType: "application/json"},
+		{name: "csv", query: "?unit=fiscal&format=csv", wantStatus: http.StatusOK, wantContentType: "text/csv; charset=utf-8"},
+		{name: "pdf", query: "?unit=fiscal&format=pdf", wantStatus: http.StatusOK, wantContentType: "application/pdf"},
+		{name: "invalid format", query: "?format=xml", wantStatus: http.StatusBadRequest},
+		{name: "forbidden", query: "?unit=juridico", err: usecases.ErrReportForbidden, wantStatus: http.StatusForbidden},
+		{name: "unit required", err: usecases.ErrUnitRequired, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockReport := &mockOverdueReportUseCase{
+				executeFunc: func(ctx context.Context, requesterID, unit string) (*application.OverdueReport, error) {
+					if requesterID != "manager-1" {
+						t.Errorf("Expected requester 'manager-1', got %s", requesterID)
+					}
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return report, nil
+				},
+			}
+			handler := NewReportHandler(mockReport)
+
+			req := httptest.NewRequest("GET", "/api/admin/reports/overdue"+tt.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", "manager-1"))
+			w := httptest.NewRecorder()
+
+			handler.OverdueReport(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantContentType != "" && w.Header().Get("Content-Type") != tt.wantContentType {
+				t.Errorf("Expected Content-Type %s, got %s", tt.wantContentType, w.Header().Get("Content-Type"))
+			}
+			if tt.wantStatus == http.StatusOK && tt.wantContentType != "application/pdf" && !strings.Contains(w.Body.String(), "ana@example.com") {
+				t.Errorf("Expected report body to contain user email, got %s", w.Body.String())
+			}
+		})
+	}
+}
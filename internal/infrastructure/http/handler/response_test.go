@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// domainPackage holds the entities that must not be serialized straight into responses
+const domainPackage = "github.com/ia-edev-sindireceita/todo/internal/domain/application"
+
+// TestResponsesDoNotSerializeDomainEntities type-checks the handlers and fails when a value
+// given to json.Marshal or a json.Encoder holds a domain struct, such as *application.Task or
+// *application.User, instead of a response type with explicit JSON tags
+func TestResponsesDoNotSerializeDomainEntities(t *testing.T) {
+	if testing.Short() {
+		t.Skip("type-checks the package from source")
+	}
+
+	fset := token.NewFileSet()
+	names, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var files []*ast.File
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, file)
+	}
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("handler", fset, files, info); err != nil {
+		t.Fatalf("type-checking the handlers: %v", err)
+	}
+
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 || !isJSONEncode(info, call) {
+				return true
+			}
+			if entity := domainStruct(info.Types[call.Args[0]].Type, map[types.Type]bool{}); entity != "" {
+				t.Errorf("%s: %s is serialized into a response; map it to a response type", fset.Position(call.Pos()), entity)
+			}
+			return true
+		})
+	}
+}
+
+// isJSONEncode reports whether call is json.Marshal, json.MarshalIndent or (*json.Encoder).Encode
+func isJSONEncode(info *types.Info, call *ast.CallExpr) bool {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	fn, ok := info.Uses[selector.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "encoding/json" {
+		return false
+	}
+	return fn.Name() == "Marshal" || fn.Name() == "MarshalIndent" || fn.Name() == "Encode"
+}
+
+// domainStruct returns the name of a domain struct reached through the JSON fields of t, or ""
+func domainStruct(t types.Type, seen map[types.Type]bool) string {
+	if t == nil || seen[t] {
+		return ""
+	}
+	seen[t] = true
+
+	if named, ok := t.(*types.Named); ok {
+		if obj := named.Obj(); obj.Pkg() != nil && obj.Pkg().Path() == domainPackage {
+			if _, isStruct := named.Underlying().(*types.Struct); isStruct {
+				return "application." + obj.Name()
+			}
+		}
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		return domainStruct(u.Elem(), seen)
+	case *types.Slice:
+		return domainStruct(u.Elem(), seen)
+	case *types.Array:
+		return domainStruct(u.Elem(), seen)
+	case *types.Map:
+		return domainStruct(u.Elem(), seen)
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			field := u.Field(i)
+			if !field.Exported() && !field.Embedded() || reflect.StructTag(u.Tag(i)).Get("json") == "-" {
+				continue
+			}
+			if entity := domainStruct(field.Type(), seen); entity != "" {
+				return entity
+			}
+		}
+	}
+	return ""
+}
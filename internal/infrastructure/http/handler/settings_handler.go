@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// SettingsHandler handles the preferences of a user as a single document, which can be read
+// and put back to move them between accounts or instances
+type SettingsHandler struct {
+	getPreferences    usecases.GetUserPreferencesUseCaseInterface
+	updatePreferences usecases.UpdateUserPreferencesUseCaseInterface
+	cookies           CookieConfig
+}
+
+// NewSettingsHandler creates a new SettingsHandler
+func NewSettingsHandler(
+	getPreferences usecases.GetUserPreferencesUseCaseInterface,
+	updatePreferences usecases.UpdateUserPreferencesUseCaseInterface,
+	cookies CookieConfig,
+) *SettingsHandler {
+	return &SettingsHandler{
+		getPreferences:    getPreferences,
+		updatePreferences: updatePreferences,
+		cookies:           cookies,
+	}
+}
+
+// SettingsResponse is the body of GET and PUT /api/me/settings. The locale is left out until
+// the user picks a language.
+type SettingsResponse struct {
+	Theme         string          `json:"theme"`
+	Locale        string          `json:"locale,omitempty"`
+	DefaultSort   string          `json:"default_sort"`
+	Notifications map[string]bool `json:"notifications"`
+	UpdatedAt     *time.Time      `json:"updated_at,omitempty"`
+}
+
+// UpdateSettingsRequest is the request body of PUT /api/me/settings. Fields and notification
+// types left out keep their value; updated_at, as sent back by GET, is ignored.
+type UpdateSettingsRequest struct {
+	Theme         *string         `json:"theme"`
+	Locale        *string         `json:"locale"`
+	DefaultSort   *string         `json:"default_sort"`
+	Notifications map[string]bool `json:"notifications"`
+	UpdatedAt     *time.Time      `json:"updated_at"`
+}
+
+// GetSettings handles GET /api/me/settings
+func (h *SettingsHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	preferences, err := h.getPreferences.Execute(r.Context(), userID)
+	if err != nil {
+		status, message := settingsErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(toSettingsResponse(preferences))
+}
+
+// UpdateSettings handles PUT /api/me/settings, changing every preference in the body at once
+func (h *SettingsHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req UpdateSettingsRequest
+	if !decodeProjectBody(w, r, &req) {
+		return
+	}
+
+	preferences, err := h.updatePreferences.Execute(r.Context(), userID, usecases.UserPreferencesChanges{
+		Theme:         req.Theme,
+		Locale:        req.Locale,
+		DefaultSort:   req.DefaultSort,
+		Notifications: req.Notifications,
+	})
+	if err != nil {
+		status, message := settingsErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	// Keep the cookies in sync, like the navbar toggles do, so the pages follow right away
+	http.SetCookie(w, h.cookies.createThemeCookie(preferences.Theme))
+	if preferences.Locale != "" {
+		http.SetCookie(w, h.cookies.createLocaleCookie(preferences.Locale))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toSettingsResponse(preferences))
+}
+
+// toSettingsResponse maps the preferences of a user to the settings document
+func toSettingsResponse(preferences *usecases.UserPreferences) SettingsResponse {
+	settings := preferences.Settings
+	response := SettingsResponse{
+		Theme:       string(preferences.Theme),
+		Locale:      string(preferences.Locale),
+		DefaultSort: string(settings.DefaultSort),
+		Notifications: map[string]bool{
+			string(application.NotificationTaskShared):     settings.Notifications.TaskShared,
+			string(application.NotificationTaskCompleted):  settings.Notifications.TaskCompleted,
+			string(application.NotificationTaskOverdue):    settings.Notifications.TaskOverdue,
+			string(application.NotificationCommentMention): settings.Notifications.CommentMention,
+		},
+	}
+	if !settings.UpdatedAt.IsZero() {
+		response.UpdatedAt = &settings.UpdatedAt
+	}
+	return response
+}
+
+// settingsErrorStatus maps a settings use case error to an HTTP status and client message
+func settingsErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, application.ErrUserNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, application.ErrInvalidTheme),
+		errors.Is(err, application.ErrInvalidLocale),
+		errors.Is(err, application.ErrInvalidDefaultSort),
+		errors.Is(err, application.ErrUnknownNotificationSetting):
+		return http.StatusBadRequest, err.Error()
+	default:
+		log.Printf("settings operation failed: %v", err)
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockGetUserPreferencesUseCase struct {
+	preferences *usecases.UserPreferences
+	err         error
+}
+
+func (m *mockGetUserPreferencesUseCase) Execute(ctx context.Context, userID string) (*usecases.UserPreferences, error) {
+	return m.preferences, m.err
+}
+
+type mockUpdateUserPreferencesUseCase struct {
+	changes *usecases.UserPreferencesChanges
+	err     error
+}
+
+func (m *mockUpdateUserPreferencesUseCase) Execute(ctx context.Context, userID string, changes usecases.UserPreferencesChanges) (*usecases.UserPreferences, error) {
+	m.changes = &changes
+	if m.err != nil {
+		return nil, m.err
+	}
+	preferences := &usecases.UserPreferences{Theme: application.ThemeLight, Settings: application.DefaultUserSettings(userID)}
+	if changes.Theme != nil {
+		preferences.Theme = application.Theme(*changes.Theme)
+	}
+	return preferences, nil
+}
+
+func TestGetSettings(t *testing.T) {
+	settings := application.DefaultUserSettings("user-123")
+	settings.Notifications.TaskCompleted = false
+	preferences := &usecases.UserPreferences{Theme: application.ThemeDark, Locale: application.LocaleEN, Settings: settings}
+
+	tests := []struct {
+		name        string
+		preferences *usecases.UserPreferences
+		err         error
+		wantStatus  int
+		wantBody    string
+	}{
+		{
+			name:        "saved preferences",
+			preferences: preferences,
+			wantStatus:  http.StatusOK,
+			wantBody:    `{"theme":"dark","locale":"en","default_sort":"-created_at","notifications":{"comment_mention":true,"task_completed":false,"task_overdue":true,"task_shared":true}}`,
+		},
+		{
+			name:        "no language picked yet",
+			preferences: &usecases.UserPreferences{Theme: application.ThemeLight, Settings: application.DefaultUserSettings("user-123")},
+			wantStatus:  http.StatusOK,
+			wantBody:    `{"theme":"light","default_sort":"-created_at","notifications":{"comment_mention":true,"task_completed":true,"task_overdue":true,"task_shared":true}}`,
+		},
+		{name: "unknown user", err: application.ErrUserNotFound, wantStatus: http.StatusNotFound},
+		{name: "repository failure", err: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewSettingsHandler(&mockGetUserPreferencesUseCase{preferences: tt.preferences, err: tt.err}, &mockUpdateUserPreferencesUseCase{}, DefaultCookieConfig())
+
+			w := httptest.NewRecorder()
+			h.GetSettings(w, withUser(httptest.NewRequest("GET", "/api/me/settings", nil)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" && strings.TrimSpace(w.Body.String()) != tt.wantBody {
+				t.Errorf("body = %s, want %s", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestUpdateSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{"whole document", `{"theme":"dark","locale":"en","default_sort":"created_at","notifications":{"task_shared":false},"updated_at":"2026-03-10T09:00:00Z"}`, nil, http.StatusOK},
+		{"some fields", `{"default_sort":"created_at"}`, nil, http.StatusOK},
+		{"invalid value", `{"default_sort":"due_date"}`, application.ErrInvalidDefaultSort, http.StatusBadRequest},
+		{"unknown notification", `{"notifications":{"task_reminder":false}}`, application.ErrUnknownNotificationSetting, http.StatusBadRequest},
+		{"unknown field", `{"font":"serif"}`, nil, http.StatusBadRequest},
+		{"invalid body", `{"theme": 1}`, nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := &mockUpdateUserPreferencesUseCase{err: tt.err}
+			h := NewSettingsHandler(&mockGetUserPreferencesUseCase{}, update, DefaultCookieConfig())
+
+			w := httptest.NewRecorder()
+			h.UpdateSettings(w, withUser(httptest.NewRequest("PUT", "/api/me/settings", strings.NewReader(tt.body))))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response SettingsResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil || response.DefaultSort == "" {
+				t.Errorf("response = %+v, %v; want the settings document", response, err)
+			}
+			if update.changes.DefaultSort == nil || *update.changes.DefaultSort != "created_at" {
+				t.Errorf("changes = %+v, want the default sort", update.changes)
+			}
+		})
+	}
+}
+
+func TestUpdateSettings_SyncsCookies(t *testing.T) {
+	h := NewSettingsHandler(&mockGetUserPreferencesUseCase{}, &mockUpdateUserPreferencesUseCase{}, DefaultCookieConfig())
+
+	w := httptest.NewRecorder()
+	h.UpdateSettings(w, withUser(httptest.NewRequest("PUT", "/api/me/settings", strings.NewReader(`{"theme":"dark"}`))))
+
+	var theme *http.Cookie
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == ThemeCookieName {
+			theme = cookie
+		}
+	}
+	if theme == nil || theme.Value != "dark" {
+		t.Errorf("theme cookie = %+v, want dark", theme)
+	}
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// ShareInvitationHandler handles HTTP requests for the invitations to collaborate on tasks
+type ShareInvitationHandler struct {
+	listInvitations   usecases.ListShareInvitationsUseCaseInterface
+	acceptInvitation  usecases.AcceptShareInvitationUseCaseInterface
+	declineInvitation usecases.DeclineShareInvitationUseCaseInterface
+	ownerNames        usecases.GetOwnerNamesUseCaseInterface
+	pages             *PageTemplates
+}
+
+// NewShareInvitationHandler creates a new ShareInvitationHandler
+func NewShareInvitationHandler(
+	listInvitations usecases.ListShareInvitationsUseCaseInterface,
+	acceptInvitation usecases.AcceptShareInvitationUseCaseInterface,
+	declineInvitation usecases.DeclineShareInvitationUseCaseInterface,
+	ownerNames usecases.GetOwnerNamesUseCaseInterface,
+	pages *PageTemplates,
+) *ShareInvitationHandler {
+	return &ShareInvitationHandler{
+		listInvitations:   listInvitations,
+		acceptInvitation:  acceptInvitation,
+		declineInvitation: declineInvitation,
+		ownerNames:        ownerNames,
+		pages:             pages,
+	}
+}
+
+// InvitationResponse is the JSON representation of a pending share invitation
+type InvitationResponse struct {
+	TaskID    string    `json:"task_id"`
+	TaskTitle string    `json:"task_title"`
+	OwnerID   string    `json:"owner_id"`
+	OwnerName string    `json:"owner_name"`
+	InvitedAt time.Time `json:"invited_at"`
+}
+
+// ListInvitations handles GET /api/invitations
+func (h *ShareInvitationHandler) ListInvitations(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	invitations, err := h.listInvitations.Execute(r.Context(), userID)
+	if err != nil {
+		writeAPIError(w, r, http.StatusInternalServerError, "Failed to list invitations")
+		return
+	}
+
+	response := make([]InvitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		response = append(response, InvitationResponse{
+			TaskID:    invitation.TaskID,
+			TaskTitle: invitation.TaskTitle,
+			OwnerID:   invitation.OwnerID,
+			OwnerName: invitation.OwnerName,
+			InvitedAt: invitation.InvitedAt,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// AcceptInvitation handles POST /api/invitations/{id}/accept, returning the task now shared
+// with the user
+func (h *ShareInvitationHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	task, err := h.acceptInvitation.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		status, message := invitationErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	view := withOwnerName(r.Context(), h.ownerNames, task)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toTaskResponse(view.Task, view.OwnerName))
+}
+
+// DeclineInvitation handles POST /api/invitations/{id}/decline
+func (h *ShareInvitationHandler) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := h.declineInvitation.Execute(r.Context(), r.PathValue("id"), userID); err != nil {
+		status, message := invitationErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// WebListInvitations handles GET /web/invitations, rendering the pending invitations shown
+// above the task list
+func (h *ShareInvitationHandler) WebListInvitations(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	h.writeInvitationList(w, r, userID, "")
+}
+
+// WebAcceptInvitation handles POST /web/invitations/{id}/accept. The response re-renders the
+// invitations and adds the card of the accepted task to the top of the task list.
+func (h *ShareInvitationHandler) WebAcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	locale := RequestLocale(r)
+
+	task, err := h.acceptInvitation.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		h.writeInvitationError(w, r, err)
+		return
+	}
+
+	card, err := renderTaskCard(withOwnerName(r.Context(), h.ownerNames, task), userID, locale)
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	h.writeInvitationList(w, r, userID, `<div hx-swap-oob="afterbegin:#task-list">`+card+`</div>`)
+}
+
+// WebDeclineInvitation handles POST /web/invitations/{id}/decline, re-rendering the invitations
+func (h *ShareInvitationHandler) WebDeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := h.declineInvitation.Execute(r.Context(), r.PathValue("id"), userID); err != nil {
+		h.writeInvitationError(w, r, err)
+		return
+	}
+	h.writeInvitationList(w, r, userID, "")
+}
+
+// writeInvitationList writes the pending invitations of a user followed by extra HTML
+func (h *ShareInvitationHandler) writeInvitationList(w http.ResponseWriter, r *http.Request, userID, extra string) {
+	invitations, err := h.listInvitations.Execute(r.Context(), userID)
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Failed to list invitations")
+		return
+	}
+
+	list, err := renderInvitationList(invitations, RequestLocale(r))
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.pages.writeWebFragment(w, r, http.StatusOK, list+extra)
+}
+
+// writeInvitationError writes the error of answering an invitation from the web interface
+func (h *ShareInvitationHandler) writeInvitationError(w http.ResponseWriter, r *http.Request, err error) {
+	status, message := invitationErrorStatus(err)
+	if status == http.StatusNotFound {
+		message = i18n.T(RequestLocale(r), "invitations.not_found")
+	}
+	h.pages.writeWebError(w, r, status, message)
+}
+
+// invitationErrorStatus maps an invitation use case error to an HTTP status and message
+func invitationErrorStatus(err error) (int, string) {
+	if errors.Is(err, application.ErrInvitationNotFound) {
+		return http.StatusNotFound, "Invitation not found"
+	}
+	return http.StatusInternalServerError, "Failed to answer the invitation"
+}
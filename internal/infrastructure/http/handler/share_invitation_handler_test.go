@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+type mockShareInvitationUseCases struct {
+	invitations []*application.ShareInvitation
+	answered    string
+}
+
+func (m *mockShareInvitationUseCases) list() *mockListShareInvitations {
+	return &mockListShareInvitations{m}
+}
+
+type mockListShareInvitations struct{ m *mockShareInvitationUseCases }
+
+func (l *mockListShareInvitations) Execute(ctx context.Context, userID string) ([]*application.ShareInvitation, error) {
+	return l.m.invitations, nil
+}
+
+// Execute accepts an invitation
+func (m *mockShareInvitationUseCases) Execute(ctx context.Context, taskID, userID string) (*application.Task, error) {
+	if err := m.answer(taskID); err != nil {
+		return nil, err
+	}
+	return application.NewTask(taskID, "Relatório <b>anual</b>", "", application.StatusPending, "user-ana", "")
+}
+
+func (m *mockShareInvitationUseCases) answer(taskID string) error {
+	for i, invitation := range m.invitations {
+		if invitation.TaskID == taskID {
+			m.invitations = append(m.invitations[:i], m.invitations[i+1:]...)
+			m.answered = taskID
+			return nil
+		}
+	}
+	return application.ErrInvitationNotFound
+}
+
+type mockDeclineShareInvitation struct{ m *mockShareInvitationUseCases }
+
+func (d *mockDeclineShareInvitation) Execute(ctx context.Context, taskID, userID string) error {
+	return d.m.answer(taskID)
+}
+
+func newInvitationHandlerForTest() (*ShareInvitationHandler, *mockShareInvitationUseCases) {
+	m := &mockShareInvitationUseCases{invitations: []*application.ShareInvitation{
+		{TaskID: "task-1", TaskTitle: "Relatório <b>anual</b>", OwnerID: "user-ana", OwnerName: "Ana", UserID: "user-123", InvitedAt: time.Date(2025, 6, 11, 10, 0, 0, 0, time.UTC)},
+		{TaskID: "task-2", TaskTitle: "Orçamento", OwnerID: "user-ana", OwnerName: "Ana", UserID: "user-123"},
+	}}
+	return NewShareInvitationHandler(m.list(), m, &mockDeclineShareInvitation{m}, &mockGetOwnerNamesUseCase{}, NewPageTemplates("")), m
+}
+
+func TestShareInvitationHandler_API(t *testing.T) {
+	t.Run("lists pending invitations", func(t *testing.T) {
+		h, _ := newInvitationHandlerForTest()
+		w := httptest.NewRecorder()
+		h.ListInvitations(w, withUser(httptest.NewRequest("GET", "/api/invitations", nil)))
+
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"task_id":"task-1","task_title":"Relatório \u003cb\u003eanual\u003c/b\u003e","owner_id":"user-ana","owner_name":"Ana","invited_at":"2025-06-11T10:00:00Z"`) {
+			t.Errorf("status = %d, body = %s", w.Code, w.Body.String())
+		}
+	})
+
+	tests := []struct {
+		name       string
+		handle     func(h *ShareInvitationHandler) http.HandlerFunc
+		taskID     string
+		wantStatus int
+	}{
+		{"accept", func(h *ShareInvitationHandler) http.HandlerFunc { return h.AcceptInvitation }, "task-1", http.StatusOK},
+		{"accept unknown invitation", func(h *ShareInvitationHandler) http.HandlerFunc { return h.AcceptInvitation }, "task-9", http.StatusNotFound},
+		{"decline", func(h *ShareInvitationHandler) http.HandlerFunc { return h.DeclineInvitation }, "task-2", http.StatusNoContent},
+		{"decline unknown invitation", func(h *ShareInvitationHandler) http.HandlerFunc { return h.DeclineInvitation }, "task-9", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, m := newInvitationHandlerForTest()
+			req := httptest.NewRequest("POST", "/api/invitations/"+tt.taskID+"/x", nil)
+			req.SetPathValue("id", tt.taskID)
+			w := httptest.NewRecorder()
+			tt.handle(h)(w, withUser(req))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && !strings.Contains(w.Body.String(), `"id":"task-1"`) {
+				t.Errorf("body = %s, want the accepted task", w.Body.String())
+			}
+			if tt.wantStatus != http.StatusNotFound && m.answered != tt.taskID {
+				t.Errorf("answered = %q, want %q", m.answered, tt.taskID)
+			}
+		})
+	}
+}
+
+func TestShareInvitationHandler_Web(t *testing.T) {
+	t.Run("lists pending invitations", func(t *testing.T) {
+		h, _ := newInvitationHandlerForTest()
+		w := httptest.NewRecorder()
+		h.WebListInvitations(w, withUser(htmx(httptest.NewRequest("GET", "/web/invitations", nil))))
+
+		body := w.Body.String()
+		for _, want := range []string{`id="invitation-task-1"`, "Relatório &lt;b&gt;anual&lt;/b&gt;", "Ana convidou você", `hx-post="/web/invitations/task-2/decline"`} {
+			if !strings.Contains(body, want) {
+				t.Errorf("body does not contain %q:\n%s", want, body)
+			}
+		}
+	})
+
+	t.Run("accepting re-renders the list and adds the task card", func(t *testing.T) {
+		h, _ := newInvitationHandlerForTest()
+		req := htmx(httptest.NewRequest("POST", "/web/invitations/task-1/accept", nil))
+		req.SetPathValue("id", "task-1")
+		w := httptest.NewRecorder()
+		h.WebAcceptInvitation(w, withUser(req))
+
+		body := w.Body.String()
+		if strings.Contains(body, `id="invitation-task-1"`) || !strings.Contains(body, `id="invitation-task-2"`) {
+			t.Errorf("body should list only the other invitation:\n%s", body)
+		}
+		if !strings.Contains(body, `<div hx-swap-oob="afterbegin:#task-list"><div class="bg-white dark:bg-gray-800 shadow rounded-lg p-6" id="task-task-1" role="article" aria-labelledby="task-task-1-title">`) {
+			t.Errorf("body does not add the task card to the list:\n%s", body)
+		}
+	})
+
+	t.Run("declining the last invitation empties the list", func(t *testing.T) {
+		h, m := newInvitationHandlerForTest()
+		m.invitations = m.invitations[1:]
+		req := htmx(httptest.NewRequest("POST", "/web/invitations/task-2/decline", nil))
+		req.SetPathValue("id", "task-2")
+		w := httptest.NewRecorder()
+		h.WebDeclineInvitation(w, withUser(req))
+
+		if w.Code != http.StatusOK || w.Body.String() != "" {
+			t.Errorf("status = %d, body = %q; want an empty list", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("answering a missing invitation", func(t *testing.T) {
+		h, _ := newInvitationHandlerForTest()
+		req := htmx(httptest.NewRequest("POST", "/web/invitations/task-9/accept", nil))
+		req.SetPathValue("id", "task-9")
+		req.Header.Set("Accept-Language", "en")
+		w := httptest.NewRecorder()
+		h.WebAcceptInvitation(w, withUser(req))
+
+		if w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), "This invitation no longer exists") {
+			t.Errorf("status = %d, body = %q", w.Code, w.Body.String())
+		}
+	})
+}
@@ -0,0 +1,297 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/markdown"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// maxShareLinkBodySize limits the body of a share link request, which only carries its expiry
+const maxShareLinkBodySize = 4 << 10 // 4KB
+
+// defaultShareLinkExpiryDays is the lifetime of a link created without one
+const defaultShareLinkExpiryDays = 7
+
+// ShareLinkHandler handles the public, read-only links to tasks: their management by the
+// owner, and the page they open without signing in
+type ShareLinkHandler struct {
+	createLink usecases.CreateShareLinkUseCaseInterface
+	listLinks  usecases.ListShareLinksUseCaseInterface
+	revokeLink usecases.RevokeShareLinkUseCaseInterface
+	viewTask   usecases.ViewSharedTaskUseCaseInterface
+	baseURL    string
+	pages      *PageTemplates
+	location   *time.Location
+}
+
+// NewShareLinkHandler creates a new ShareLinkHandler building the URLs of the links on
+// baseURL, the public URL of the app
+func NewShareLinkHandler(
+	createLink usecases.CreateShareLinkUseCaseInterface,
+	listLinks usecases.ListShareLinksUseCaseInterface,
+	revokeLink usecases.RevokeShareLinkUseCaseInterface,
+	viewTask usecases.ViewSharedTaskUseCaseInterface,
+	baseURL string,
+	pages *PageTemplates,
+	location *time.Location,
+) *ShareLinkHandler {
+	return &ShareLinkHandler{
+		createLink: createLink,
+		listLinks:  listLinks,
+		revokeLink: revokeLink,
+		viewTask:   viewTask,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		pages:      pages,
+		location:   location,
+	}
+}
+
+// CreateShareLinkRequest is the body of POST /api/tasks/{id}/share-links
+type CreateShareLinkRequest struct {
+	ExpiresInDays int `json:"expires_in_days"` // 1, 7 or 30; 0 means 7
+}
+
+// ShareLinkResponse is the JSON representation of a share link. URL is only returned when
+// the link is created.
+type ShareLinkResponse struct {
+	ID        string    `json:"id"`
+	TaskID    string    `json:"task_id"`
+	URL       string    `json:"url,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toShareLinkResponse(link *application.ShareLink, url string) ShareLinkResponse {
+	return ShareLinkResponse{
+		ID:        link.ID,
+		TaskID:    link.TaskID,
+		URL:       url,
+		ExpiresAt: link.ExpiresAt,
+		CreatedAt: link.CreatedAt,
+	}
+}
+
+// linkURL returns the public URL opened by a link token
+func (h *ShareLinkHandler) linkURL(token string) string {
+	return h.baseURL + "/share/" + token
+}
+
+// CreateShareLink handles POST /api/tasks/{id}/share-links
+func (h *ShareLinkHandler) CreateShareLink(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req CreateShareLinkRequest
+	if !decodeJSON(w, r, &req, maxShareLinkBodySize) {
+		return
+	}
+	if req.ExpiresInDays == 0 {
+		req.ExpiresInDays = defaultShareLinkExpiryDays
+	}
+
+	link, token, err := h.createLink.Execute(r.Context(), r.PathValue("id"), userID, req.ExpiresInDays)
+	if err != nil {
+		status, message := shareLinkErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toShareLinkResponse(link, h.linkURL(token)))
+}
+
+// ListShareLinks handles GET /api/tasks/{id}/share-links, listing the active links of a task
+func (h *ShareLinkHandler) ListShareLinks(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	links, err := h.listLinks.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		status, message := shareLinkErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	response := make([]ShareLinkResponse, 0, len(links))
+	for _, link := range links {
+		response = append(response, toShareLinkResponse(link, ""))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// RevokeShareLink handles DELETE /api/share-links/{id}
+func (h *ShareLinkHandler) RevokeShareLink(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := h.revokeLink.Execute(r.Context(), r.PathValue("id"), userID); err != nil {
+		status, message := shareLinkErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// WebCreateShareLink handles POST /web/tasks/{id}/share-links, rendering the new link with
+// its URL for the share menu. Without HTMX, a page shows the URL, which a redirect would lose.
+func (h *ShareLinkHandler) WebCreateShareLink(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	r.Body = http.MaxBytesReader(w, r.Body, maxShareLinkBodySize)
+
+	expiryDays, err := strconv.Atoi(r.PostFormValue("expires_in_days"))
+	if err != nil {
+		expiryDays = defaultShareLinkExpiryDays
+	}
+
+	link, token, err := h.createLink.Execute(r.Context(), r.PathValue("id"), userID, expiryDays)
+	if err != nil {
+		h.writeWebError(w, r, err)
+		return
+	}
+
+	html, err := renderShareLinks(shareLinkList{Links: []shareLinkItem{h.item(r, link, h.linkURL(token))}}, RequestLocale(r))
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, i18n.Error(RequestLocale(r), "Internal server error"))
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	h.pages.writeWebPage(w, r, http.StatusOK, template.HTML(html))
+}
+
+// WebListShareLinks handles GET /web/tasks/{id}/share-links, rendering the active links of a
+// task for its share menu
+func (h *ShareLinkHandler) WebListShareLinks(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	links, err := h.listLinks.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		h.writeWebError(w, r, err)
+		return
+	}
+
+	list := shareLinkList{Listing: true}
+	for _, link := range links {
+		list.Links = append(list.Links, h.item(r, link, ""))
+	}
+	html, err := renderShareLinks(list, RequestLocale(r))
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, i18n.Error(RequestLocale(r), "Internal server error"))
+		return
+	}
+	h.pages.writeWebFragment(w, r, http.StatusOK, html)
+}
+
+// WebRevokeShareLink handles DELETE /web/share-links/{id}, replacing the link in the share
+// menu with a confirmation
+func (h *ShareLinkHandler) WebRevokeShareLink(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := h.revokeLink.Execute(r.Context(), r.PathValue("id"), userID); err != nil {
+		h.writeWebError(w, r, err)
+		return
+	}
+
+	message := i18n.T(RequestLocale(r), "share_link.revoked")
+	h.pages.writeWebFragment(w, r, http.StatusOK, `<p role="status" class="text-sm text-gray-500 dark:text-gray-400">`+template.HTMLEscapeString(message)+`</p>`)
+}
+
+// SharedTaskPage handles GET /share/{token}, the read-only page of the task a link opens.
+// It needs no session: the token is the only authorization. Unknown, expired and revoked
+// links get the same 404 page.
+func (h *ShareLinkHandler) SharedTaskPage(w http.ResponseWriter, r *http.Request) {
+	// The token travels in the URL: keep it out of caches, search engines and Referer headers
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("X-Robots-Tag", "noindex")
+
+	status := http.StatusOK
+	task, _, err := h.viewTask.Execute(r.Context(), r.PathValue("token"))
+	if errors.Is(err, application.ErrShareLinkNotFound) {
+		status = http.StatusNotFound
+	} else if err != nil {
+		log.Printf("failed to open shared task: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	locale := RequestLocale(r)
+	loc := requestLocation(r, h.location)
+	tmpl, err := h.pages.Page(locale, template.FuncMap{
+		"markdown": markdown.Render,
+		"formatTime": func(t time.Time) string {
+			return formatDateTime(locale, t.In(loc))
+		},
+	},
+		"base.html", "share.html",
+	)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	title := i18n.T(locale, "title.shared_task")
+	if task != nil {
+		title = task.Title
+	}
+	data := PageData(locale, map[string]interface{}{
+		"Title": title,
+		"Theme": string(ThemeFromRequest(r)),
+		"Task":  task,
+	})
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
+
+// item returns a link as the share menu shows it, with its dates in the request's locale and time zone
+func (h *ShareLinkHandler) item(r *http.Request, link *application.ShareLink, url string) shareLinkItem {
+	locale := RequestLocale(r)
+	loc := requestLocation(r, h.location)
+	return shareLinkItem{
+		ID:        link.ID,
+		URL:       url,
+		CreatedAt: formatDateTime(locale, link.CreatedAt.In(loc)),
+		ExpiresAt: formatDateTime(locale, link.ExpiresAt.In(loc)),
+	}
+}
+
+// writeWebError writes the error of a share link use case, localized
+func (h *ShareLinkHandler) writeWebError(w http.ResponseWriter, r *http.Request, err error) {
+	status, message := shareLinkErrorStatus(err)
+	h.pages.writeWebError(w, r, status, i18n.Error(RequestLocale(r), message))
+}
+
+// shareLinkErrorStatus maps a share link use case error to an HTTP status and client message
+func shareLinkErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecases.ErrTaskUnavailable), errors.Is(err, application.ErrShareLinkNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, usecases.ErrShareLinkPermissionDenied):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, application.ErrInvalidShareLinkExpiry):
+		return http.StatusBadRequest, err.Error()
+	default:
+		log.Printf("share link operation failed: %v", err)
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockCreateShareLinkUseCase struct {
+	expiryDays int
+	err        error
+}
+
+func (m *mockCreateShareLinkUseCase) Execute(ctx context.Context, taskID, userID string, expiryDays int) (*application.ShareLink, string, error) {
+	if m.err != nil {
+		return nil, "", m.err
+	}
+	m.expiryDays = expiryDays
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	return &application.ShareLink{ID: "link-1", TaskID: taskID, CreatedAt: now, ExpiresAt: now.AddDate(0, 0, expiryDays)}, "secret-token", nil
+}
+
+type mockListShareLinksUseCase struct {
+	links []*application.ShareLink
+	err   error
+}
+
+func (m *mockListShareLinksUseCase) Execute(ctx context.Context, taskID, userID string) ([]*application.ShareLink, error) {
+	return m.links, m.err
+}
+
+type mockRevokeShareLinkUseCase struct {
+	err error
+}
+
+func (m *mockRevokeShareLinkUseCase) Execute(ctx context.Context, linkID, userID string) error {
+	return m.err
+}
+
+type mockViewSharedTaskUseCase struct {
+	task *application.Task
+	err  error
+}
+
+func (m *mockViewSharedTaskUseCase) Execute(ctx context.Context, token string) (*application.Task, *application.ShareLink, error) {
+	if m.err != nil {
+		return nil, nil, m.err
+	}
+	return m.task, &application.ShareLink{ID: "link-1", TaskID: m.task.ID}, nil
+}
+
+func newTestShareLinkHandler(create *mockCreateShareLinkUseCase, view *mockViewSharedTaskUseCase) *ShareLinkHandler {
+	h := NewShareLinkHandler(create, &mockListShareLinksUseCase{}, &mockRevokeShareLinkUseCase{}, view, "https://todo.example.com/", NewPageTemplates(""), time.Local)
+	return h
+}
+
+func TestCreateShareLink(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+		wantDays   int
+	}{
+		{"explicit expiry", `{"expires_in_days": 30}`, nil, http.StatusCreated, 30},
+		{"default expiry", `{}`, nil, http.StatusCreated, 7},
+		{"invalid body", `{`, nil, http.StatusBadRequest, 0},
+		{"unsupported expiry", `{"expires_in_days": 2}`, application.ErrInvalidShareLinkExpiry, http.StatusBadRequest, 0},
+		{"task not visible", `{}`, usecases.ErrTaskUnavailable, http.StatusNotFound, 0},
+		{"not the owner", `{}`, usecases.ErrShareLinkPermissionDenied, http.StatusForbidden, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			create := &mockCreateShareLinkUseCase{err: tt.err}
+			h := newTestShareLinkHandler(create, &mockViewSharedTaskUseCase{})
+
+			req := httptest.NewRequest("POST", "/api/tasks/task-1/share-links", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.SetPathValue("id", "task-1")
+			w := httptest.NewRecorder()
+			h.CreateShareLink(w, withUser(req))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+			var response ShareLinkResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("invalid response: %v", err)
+			}
+			if response.URL != "https://todo.example.com/share/secret-token" {
+				t.Errorf("url = %q", response.URL)
+			}
+			if create.expiryDays != tt.wantDays {
+				t.Errorf("expiry = %d days, want %d", create.expiryDays, tt.wantDays)
+			}
+		})
+	}
+}
+
+func TestWebCreateShareLink(t *testing.T) {
+	h := newTestShareLinkHandler(&mockCreateShareLinkUseCase{}, &mockViewSharedTaskUseCase{})
+
+	req := httptest.NewRequest("POST", "/web/tasks/task-1/share-links", strings.NewReader("expires_in_days=1"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetPathValue("id", "task-1")
+	w := httptest.NewRecorder()
+	h.WebCreateShareLink(w, htmx(withUser(req)))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	for _, want := range []string{`value="https://todo.example.com/share/secret-token"`, "Copiar link", `hx-delete="/web/share-links/link-1"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("fragment is missing %q: %s", want, body)
+		}
+	}
+}
+
+func TestSharedTaskPage(t *testing.T) {
+	task := &application.Task{ID: "task-1", Title: "Pagar contas", Description: "**boleto**", Status: application.StatusPending, OwnerID: "user-1"}
+	tests := []struct {
+		name       string
+		view       *mockViewSharedTaskUseCase
+		wantStatus int
+		wantBody   string
+	}{
+		{"active link", &mockViewSharedTaskUseCase{task: task}, http.StatusOK, "Pagar contas"},
+		{"invalid link", &mockViewSharedTaskUseCase{err: application.ErrShareLinkNotFound}, http.StatusNotFound, "Este link é inválido"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestShareLinkHandler(&mockCreateShareLinkUseCase{}, tt.view)
+
+			req := httptest.NewRequest("GET", "/share/token", nil)
+			req.SetPathValue("token", "token")
+			w := httptest.NewRecorder()
+			h.SharedTaskPage(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("page is missing %q", tt.wantBody)
+			}
+			if got := w.Header().Get("X-Robots-Tag"); got != "noindex" {
+				t.Errorf("X-Robots-Tag = %q, want noindex", got)
+			}
+			if strings.Contains(w.Body.String(), "/web/tasks/") {
+				t.Error("read-only page links to task actions")
+			}
+		})
+	}
+}
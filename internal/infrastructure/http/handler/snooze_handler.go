@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+var (
+	errSnoozeMissing = errors.New("snooze needs a preset or an until date")
+	errSnoozeMixed   = errors.New("use either preset or until")
+)
+
+// SnoozeHandler handles owners snoozing their tasks
+type SnoozeHandler struct {
+	snoozeTask usecases.SnoozeTaskUseCaseInterface
+	ownerNames usecases.GetOwnerNamesUseCaseInterface
+	pages      *PageTemplates
+	location   *time.Location
+}
+
+// NewSnoozeHandler creates a new SnoozeHandler
+func NewSnoozeHandler(snoozeTask usecases.SnoozeTaskUseCaseInterface, ownerNames usecases.GetOwnerNamesUseCaseInterface, pages *PageTemplates, location *time.Location) *SnoozeHandler {
+	return &SnoozeHandler{
+		snoozeTask: snoozeTask,
+		ownerNames: ownerNames,
+		pages:      pages,
+		location:   location,
+	}
+}
+
+// SnoozeTaskRequest is the body of POST /api/tasks/{id}/snooze: either a preset (tomorrow or
+// next_week) or an until date in the formats accepted for due dates
+type SnoozeTaskRequest struct {
+	Preset string `json:"preset"`
+	Until  string `json:"until"`
+}
+
+// Snooze handles POST /api/tasks/{id}/snooze, hiding the task from the owner's task list until
+// the snooze ends. Presets end at 8:00 in the timezone of the user.
+func (h *SnoozeHandler) Snooze(w http.ResponseWriter, r *http.Request) {
+	var req SnoozeTaskRequest
+	if !decodeJSON(w, r, &req, maxJSONBodySize) {
+		return
+	}
+
+	until, err := snoozeUntil(r, req.Preset, req.Until, h.location)
+	if err != nil {
+		writeAPIError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.writeTask(w, r, until)
+}
+
+// Wake handles DELETE /api/tasks/{id}/snooze, bringing the task back to the task list
+func (h *SnoozeHandler) Wake(w http.ResponseWriter, r *http.Request) {
+	h.writeTask(w, r, nil)
+}
+
+// writeTask snoozes the task of the request until until, or wakes it when nil, and writes it
+func (h *SnoozeHandler) writeTask(w http.ResponseWriter, r *http.Request, until *time.Time) {
+	userID := r.Context().Value("userID").(string)
+
+	task, err := h.snoozeTask.Execute(r.Context(), r.PathValue("id"), userID, until)
+	if err != nil {
+		status, message := snoozeErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	view := withOwnerName(r.Context(), h.ownerNames, task)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toTaskResponse(view.Task, view.OwnerName))
+}
+
+// WebSnooze handles POST /web/tasks/{id}/snooze from the snooze menu of a task card, removing
+// the card from the list
+func (h *SnoozeHandler) WebSnooze(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	locale := RequestLocale(r)
+
+	until, err := snoozeUntil(r, r.FormValue("preset"), r.FormValue("until"), h.location)
+	if err == nil {
+		_, err = h.snoozeTask.Execute(r.Context(), r.PathValue("id"), userID, until)
+	}
+	if err != nil {
+		status, message := snoozeErrorStatus(err)
+		h.pages.writeWebError(w, r, status, i18n.Error(locale, message))
+		return
+	}
+
+	h.pages.writeWebFragment(w, r, http.StatusOK, "")
+}
+
+// snoozeUntil resolves when a snooze ends from a preset or an until date, in the timezone of
+// the user or else fallback
+func snoozeUntil(r *http.Request, preset, until string, fallback *time.Location) (*time.Time, error) {
+	switch {
+	case preset != "" && until != "":
+		return nil, errSnoozeMixed
+	case preset != "":
+		end, err := application.SnoozePreset(preset).Until(time.Now().In(requestLocation(r, fallback)))
+		if err != nil {
+			return nil, err
+		}
+		return &end, nil
+	case until != "":
+		return parseDueDateInput(r, until, fallback)
+	default:
+		return nil, errSnoozeMissing
+	}
+}
+
+// snoozeErrorStatus maps a snooze error to an HTTP status and client message
+func snoozeErrorStatus(err error) (int, string) {
+	var parseErr *service.DateParseError
+	switch {
+	case errors.Is(err, usecases.ErrTaskUnavailable):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, usecases.ErrSnoozePermissionDenied):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, application.ErrInvalidSnooze), errors.Is(err, application.ErrUnknownSnoozePreset),
+		errors.Is(err, errSnoozeMissing), errors.Is(err, errSnoozeMixed), errors.As(err, &parseErr):
+		return http.StatusBadRequest, err.Error()
+	default:
+		log.Printf("snooze failed: %v", err)
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
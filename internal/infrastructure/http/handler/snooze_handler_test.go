@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockSnoozeTaskUseCase struct {
+	until *time.Time
+	err   error
+}
+
+func (m *mockSnoozeTaskUseCase) Execute(ctx context.Context, taskID, userID string, until *time.Time) (*application.Task, error) {
+	m.until = until
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &application.Task{ID: taskID, OwnerID: userID, SnoozedUntil: until}, nil
+}
+
+func TestSnoozeHandler_Snooze(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+		wantHour   int // hour of the snooze in São Paulo, checked when not zero
+	}{
+		{"tomorrow", `{"preset":"tomorrow"}`, nil, http.StatusOK, 8},
+		{"next week", `{"preset":"next_week"}`, nil, http.StatusOK, 8},
+		{"until", `{"until":"02/01/2099 às 15h"}`, nil, http.StatusOK, 15},
+		{"unknown preset", `{"preset":"forever"}`, nil, http.StatusBadRequest, 0},
+		{"invalid until", `{"until":"someday"}`, nil, http.StatusBadRequest, 0},
+		{"neither", `{}`, nil, http.StatusBadRequest, 0},
+		{"both", `{"preset":"tomorrow","until":"2099-01-02"}`, nil, http.StatusBadRequest, 0},
+		{"in the past", `{"until":"2000-01-02"}`, application.ErrInvalidSnooze, http.StatusBadRequest, 0},
+		{"shared user", `{"preset":"tomorrow"}`, usecases.ErrSnoozePermissionDenied, http.StatusForbidden, 0},
+		{"unknown task", `{"preset":"tomorrow"}`, usecases.ErrTaskUnavailable, http.StatusNotFound, 0},
+		{"storage failure", `{"preset":"tomorrow"}`, errors.New("disk full"), http.StatusInternalServerError, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snoozeTask := &mockSnoozeTaskUseCase{err: tt.err}
+			h := NewSnoozeHandler(snoozeTask, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
+
+			req := httptest.NewRequest("POST", "/api/tasks/task-1/snooze", strings.NewReader(tt.body))
+			req.SetPathValue("id", "task-1")
+			req.Header.Set("X-Timezone", "America/Sao_Paulo")
+			w := httptest.NewRecorder()
+			h.Snooze(w, withUser(req))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantHour == 0 {
+				return
+			}
+			var resp TaskResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			loc, _ := time.LoadLocation("America/Sao_Paulo")
+			if resp.SnoozedUntil == nil || resp.SnoozedUntil.In(loc).Hour() != tt.wantHour {
+				t.Errorf("snoozed_until = %v, want at %d:00 in São Paulo", resp.SnoozedUntil, tt.wantHour)
+			}
+		})
+	}
+}
+
+func TestSnoozeHandler_Wake(t *testing.T) {
+	snoozeTask := &mockSnoozeTaskUseCase{}
+	h := NewSnoozeHandler(snoozeTask, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
+
+	req := httptest.NewRequest("DELETE", "/api/tasks/task-1/snooze", nil)
+	req.SetPathValue("id", "task-1")
+	w := httptest.NewRecorder()
+	h.Wake(w, withUser(req))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	if snoozeTask.until != nil {
+		t.Errorf("until = %v, want nil to wake the task", snoozeTask.until)
+	}
+}
+
+func TestSnoozeHandler_WebSnooze(t *testing.T) {
+	tests := []struct {
+		name       string
+		preset     string
+		err        error
+		wantStatus int
+	}{
+		{"removes the card", "tomorrow", nil, http.StatusOK},
+		{"unknown preset", "forever", nil, http.StatusBadRequest},
+		{"shared user", "tomorrow", usecases.ErrSnoozePermissionDenied, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewSnoozeHandler(&mockSnoozeTaskUseCase{err: tt.err}, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
+
+			req := httptest.NewRequest("POST", "/web/tasks/task-1/snooze", strings.NewReader("preset="+tt.preset))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.SetPathValue("id", "task-1")
+			w := httptest.NewRecorder()
+			h.WebSnooze(w, htmx(withUser(req)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && strings.TrimSpace(w.Body.String()) != "" {
+				t.Errorf("body = %q, want empty to remove the card", w.Body.String())
+			}
+		})
+	}
+}
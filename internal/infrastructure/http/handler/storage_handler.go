@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// StorageHandler handles the storage usage of the current user
+type StorageHandler struct {
+	getUsage usecases.GetStorageUsageUseCaseInterface
+}
+
+// NewStorageHandler creates a new StorageHandler
+func NewStorageHandler(getUsage usecases.GetStorageUsageUseCaseInterface) *StorageHandler {
+	return &StorageHandler{
+		getUsage: getUsage,
+	}
+}
+
+// StorageResponse is the body of GET /api/me/storage. Quota and available space are left out
+// when there is no quota.
+type StorageResponse struct {
+	UsedBytes      int64  `json:"used_bytes"`
+	Files          int    `json:"files"`
+	QuotaBytes     *int64 `json:"quota_bytes,omitempty"`
+	AvailableBytes *int64 `json:"available_bytes,omitempty"`
+}
+
+// GetStorage handles GET /api/me/storage, reporting the space taken by the images and
+// attachments uploaded by the current user
+func (h *StorageHandler) GetStorage(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	status, err := h.getUsage.Execute(r.Context(), userID)
+	if err != nil {
+		log.Printf("failed to get storage usage: %v", err)
+		writeAPIError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	response := StorageResponse{UsedBytes: status.UsedBytes, Files: status.Files}
+	if status.QuotaBytes > 0 {
+		available := max(status.QuotaBytes-status.UsedBytes, 0)
+		response.QuotaBytes = &status.QuotaBytes
+		response.AvailableBytes = &available
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(response)
+}
+
+// storageQuotaMessage explains why an upload didn't fit the storage quota
+func storageQuotaMessage(locale application.Locale, exceeded *application.StorageQuotaExceededError) string {
+	if exceeded.Global {
+		return i18n.T(locale, "storage.server_full")
+	}
+	return i18n.T(locale, "storage.quota_exceeded", formatFileSize(exceeded.Limit), formatFileSize(exceeded.Used), formatFileSize(exceeded.Size))
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// mockStorageQuota records the files charged to and released from the storage quota, and who
+// uploaded them
+type mockStorageQuota struct {
+	err      error
+	reserved []string
+	released []string
+	owners   map[string]string
+}
+
+func (m *mockStorageQuota) Reserve(ctx context.Context, userID string, kind application.StoredFileKind, name string, size int64) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.reserved = append(m.reserved, string(kind)+"/"+name)
+	if m.owners == nil {
+		m.owners = map[string]string{}
+	}
+	m.owners[string(kind)+"/"+name] = userID
+	return nil
+}
+
+func (m *mockStorageQuota) Release(ctx context.Context, kind application.StoredFileKind, name string) error {
+	m.released = append(m.released, string(kind)+"/"+name)
+	return nil
+}
+
+func (m *mockStorageQuota) Owns(ctx context.Context, userID string, kind application.StoredFileKind, name string) (bool, error) {
+	return m.owners[string(kind)+"/"+name] == userID, nil
+}
+
+type mockGetStorageUsageUseCase struct {
+	status *usecases.StorageStatus
+	err    error
+}
+
+func (m *mockGetStorageUsageUseCase) Execute(ctx context.Context, userID string) (*usecases.StorageStatus, error) {
+	return m.status, m.err
+}
+
+func TestGetStorage(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     *usecases.StorageStatus
+		err        error
+		wantStatus int
+		want       map[string]float64
+	}{
+		{
+			name:       "with quota",
+			status:     &usecases.StorageStatus{UsedBytes: 300, Files: 2, QuotaBytes: 1000},
+			wantStatus: http.StatusOK,
+			want:       map[string]float64{"used_bytes": 300, "files": 2, "quota_bytes": 1000, "available_bytes": 700},
+		},
+		{
+			name:       "over a lowered quota",
+			status:     &usecases.StorageStatus{UsedBytes: 1200, Files: 3, QuotaBytes: 1000},
+			wantStatus: http.StatusOK,
+			want:       map[string]float64{"used_bytes": 1200, "files": 3, "quota_bytes": 1000, "available_bytes": 0},
+		},
+		{
+			name:       "without quota",
+			status:     &usecases.StorageStatus{UsedBytes: 300, Files: 2},
+			wantStatus: http.StatusOK,
+			want:       map[string]float64{"used_bytes": 300, "files": 2},
+		},
+		{
+			name:       "repository failure",
+			err:        errors.New("database is locked"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewStorageHandler(&mockGetStorageUsageUseCase{status: tt.status, err: tt.err})
+
+			w := httptest.NewRecorder()
+			h.GetStorage(w, withUser(httptest.NewRequest("GET", "/api/me/storage", nil)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.want == nil {
+				return
+			}
+			var got map[string]float64
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("invalid response: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("response = %v, want %v", got, tt.want)
+			}
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Errorf("%s = %v, want %v", key, got[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestStorageQuotaMessage(t *testing.T) {
+	exceeded := &application.StorageQuotaExceededError{Limit: 100 << 20, Used: 95 << 20, Size: 10 << 20}
+	if got, want := storageQuotaMessage("en", exceeded), "Storage limit of 100,0 MB reached: 95,0 MB in use and the file has 10,0 MB. Delete images or attachments to free up space."; got != want {
+		t.Errorf("storageQuotaMessage() = %q, want %q", got, want)
+	}
+
+	exceeded.Global = true
+	if got := storageQuotaMessage("pt-BR", exceeded); got != "O armazenamento do servidor está cheio. Tente novamente mais tarde." {
+		t.Errorf("storageQuotaMessage() = %q", got)
+	}
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// TaskFileStorage removes the files of deleted tasks: the image, kept by an UploadHandler,
+// and the attachments, kept in their own directory
+type TaskFileStorage struct {
+	images         *UploadHandler
+	attachmentsDir string
+	quota          usecases.StorageQuotaUseCaseInterface
+}
+
+// NewTaskFileStorage creates a new TaskFileStorage. Removed attachments are given back to
+// the storage quota; a nil quota doesn't track them.
+func NewTaskFileStorage(images *UploadHandler, attachmentsDir string, quota usecases.StorageQuotaUseCaseInterface) *TaskFileStorage {
+	return &TaskFileStorage{
+		images:         images,
+		attachmentsDir: attachmentsDir,
+		quota:          quota,
+	}
+}
+
+// RemoveDeleted removes the files of a deleted task. The deletion is already committed,
+// so failures are only logged: a leftover file is an orphan, not an inconsistency.
+func (s *TaskFileStorage) RemoveDeleted(ctx context.Context, files *usecases.DeletedTaskFiles) {
+	if files == nil {
+		return
+	}
+
+	if err := s.images.DeleteImage(ctx, files.ImagePath); err != nil {
+		log.Printf("failed to remove task image %s: %v", files.ImagePath, err)
+	}
+	for _, path := range files.AttachmentPaths {
+		removeAttachmentFile(s.attachmentsDir, path)
+		releaseStoredFile(ctx, s.quota, application.StoredFileAttachment, path)
+	}
+}
+
+// removeAttachmentFile removes a stored attachment file; a missing file is not an error
+func removeAttachmentFile(dir, name string) {
+	if err := os.Remove(filepath.Join(dir, filepath.Base(name))); err != nil && !os.IsNotExist(err) {
+		log.Printf("failed to remove attachment file %s: %v", name, err)
+	}
+}
+
+// releaseStoredFile gives the space of a removed file back to the storage quota. Failures are
+// only logged: the file is already gone, and the user is charged for it a while longer.
+func releaseStoredFile(ctx context.Context, quota usecases.StorageQuotaUseCaseInterface, kind application.StoredFileKind, name string) {
+	if quota == nil {
+		return
+	}
+	if err := quota.Release(context.WithoutCancel(ctx), kind, filepath.Base(name)); err != nil {
+		log.Printf("failed to release storage of %s %s: %v", kind, name, err)
+	}
+}
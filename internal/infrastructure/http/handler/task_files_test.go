@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+func TestTaskHandler_DeleteTask_RemovesTaskFiles(t *testing.T) {
+	imagesDir, attachmentsDir := t.TempDir(), t.TempDir()
+	paths := []string{
+		filepath.Join(imagesDir, "abc.png"),
+		filepath.Join(attachmentsDir, "one.pdf"),
+		filepath.Join(attachmentsDir, "two.csv"),
+	}
+	for _, path := range paths {
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	quota := &mockStorageQuota{}
+	storage := NewTaskFileStorage(NewUploadHandler(imagesDir, quota), attachmentsDir, quota)
+	deleteTask := &mockDeleteTaskUseCase{files: &usecases.DeletedTaskFiles{
+		ImagePath:       "/uploads/images/abc.png",
+		AttachmentPaths: []string{"one.pdf", "two.csv", "already-gone.txt"},
+	}}
+	handler := NewTaskHandler(nil, nil, deleteTask, nil, nil, nil, storage, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+
+	req := httptest.NewRequest("DELETE", "/api/tasks/task-1", nil)
+	req.SetPathValue("id", "task-1")
+	req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+	w := httptest.NewRecorder()
+	handler.DeleteTask(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+	for _, path := range paths {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed", filepath.Base(path))
+		}
+	}
+	wantReleased := []string{"image/abc.png", "attachment/one.pdf", "attachment/two.csv", "attachment/already-gone.txt"}
+	if !reflect.DeepEqual(quota.released, wantReleased) {
+		t.Errorf("Expected %v released from the quota, got %v", wantReleased, quota.released)
+	}
+}
+
+func TestWebTaskHandler_DeleteTask_RemovesTaskFiles(t *testing.T) {
+	imagesDir, attachmentsDir := t.TempDir(), t.TempDir()
+	paths := []string{
+		filepath.Join(imagesDir, "abc.png"),
+		filepath.Join(attachmentsDir, "one.pdf"),
+	}
+	for _, path := range paths {
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	quota := &mockStorageQuota{}
+	storage := NewTaskFileStorage(NewUploadHandler(imagesDir, quota), attachmentsDir, quota)
+	deleteTask := &mockDeleteTaskUseCase{files: &usecases.DeletedTaskFiles{
+		ImagePath:       "/uploads/images/abc.png",
+		AttachmentPaths: []string{"one.pdf"},
+	}}
+	handler := NewWebTaskHandler(nil, deleteTask, nil, nil, nil, nil, storage, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
+
+	req := htmx(httptest.NewRequest("DELETE", "/tasks/task-1", nil))
+	req.SetPathValue("id", "task-1")
+	req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+	w := httptest.NewRecorder()
+	handler.DeleteTask(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	for _, path := range paths {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed", filepath.Base(path))
+		}
+	}
+	wantReleased := []string{"image/abc.png", "attachment/one.pdf"}
+	if !reflect.DeepEqual(quota.released, wantReleased) {
+		t.Errorf("Expected %v released from the quota, got %v", wantReleased, quota.released)
+	}
+}
+
+func TestDeleteTask_KeepsFilesWhenDeletionFails(t *testing.T) {
+	imagesDir := t.TempDir()
+	imagePath := filepath.Join(imagesDir, "abc.png")
+	if err := os.WriteFile(imagePath, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	storage := NewTaskFileStorage(NewUploadHandler(imagesDir, nil), t.TempDir(), nil)
+	deleteTask := &mockDeleteTaskUseCase{
+		executeFunc: func(ctx context.Context, taskID, userID string) error {
+			return errors.New("database is locked")
+		},
+		files: &usecases.DeletedTaskFiles{ImagePath: "/uploads/images/abc.png"},
+	}
+
+	req := httptest.NewRequest("DELETE", "/api/tasks/task-1", nil)
+	req.SetPathValue("id", "task-1")
+	req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+	w := httptest.NewRecorder()
+	NewTaskHandler(nil, nil, deleteTask, nil, nil, nil, storage, &mockGetOwnerNamesUseCase{}, nil, time.Local).DeleteTask(w, req)
+
+	if w.Code == http.StatusNoContent {
+		t.Fatal("Expected the deletion to fail")
+	}
+	if _, err := os.Stat(imagePath); err != nil {
+		t.Errorf("Expected the image to be kept, got %v", err)
+	}
+}
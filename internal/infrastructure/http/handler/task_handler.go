@@ -1,8 +1,15 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"log"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
 	"github.com/ia-edev-sindireceita/todo/internal/usecases"
@@ -16,6 +23,10 @@ type TaskHandler struct {
 	getTask         usecases.GetTaskUseCaseInterface
 	listTasks       usecases.ListTasksUseCaseInterface
 	listSharedTasks usecases.ListSharedTasksUseCaseInterface
+	files           *TaskFileStorage
+	ownerNames      usecases.GetOwnerNamesUseCaseInterface
+	shareeIDs       usecases.GetShareeIDsUseCaseInterface
+	location        *time.Location
 }
 
 // NewTaskHandler creates a new TaskHandler
@@ -26,6 +37,10 @@ func NewTaskHandler(
 	getTask usecases.GetTaskUseCaseInterface,
 	listTasks usecases.ListTasksUseCaseInterface,
 	listSharedTasks usecases.ListSharedTasksUseCaseInterface,
+	files *TaskFileStorage,
+	ownerNames usecases.GetOwnerNamesUseCaseInterface,
+	shareeIDs usecases.GetShareeIDsUseCaseInterface,
+	location *time.Location,
 ) *TaskHandler {
 	return &TaskHandler{
 		createTask:      createTask,
@@ -34,6 +49,10 @@ func NewTaskHandler(
 		getTask:         getTask,
 		listTasks:       listTasks,
 		listSharedTasks: listSharedTasks,
+		files:           files,
+		ownerNames:      ownerNames,
+		shareeIDs:       shareeIDs,
+		location:        location,
 	}
 }
 
@@ -41,13 +60,76 @@ type CreateTaskRequest struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	ImagePath   string `json:"image_path"`
+	DueDate     string `json:"due_date"`
+	ProjectID   string `json:"project_id"`
+	Color       string `json:"color"`
+}
+
+// TaskResponse is the JSON representation of a task, with the display name of its owner, empty
+// when it isn't known. Fields are copied one by one from the entity, so a field added to a task only
+// reaches clients once it's added here.
+type TaskResponse struct {
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	Status         string     `json:"status"`
+	OwnerID        string     `json:"owner_id"`
+	OwnerName      string     `json:"owner_name"`
+	ImagePath      string     `json:"image_path"`
+	ProjectID      string     `json:"project_id"`
+	Priority       string     `json:"priority"`
+	Tags           []string   `json:"tags"`
+	Color          string     `json:"color"`
+	DueDate        *time.Time `json:"due_date"`
+	OverdueAt      *time.Time `json:"overdue_at"`
+	SnoozedUntil   *time.Time `json:"snoozed_until"`
+	Progress       int        `json:"progress"`
+	CompletionNote string     `json:"completion_note"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func toTaskResponse(task *application.Task, ownerName string) TaskResponse {
+	return TaskResponse{
+		ID:             task.ID,
+		Title:          task.Title,
+		Description:    task.Description,
+		Status:         string(task.Status),
+		OwnerID:        task.OwnerID,
+		OwnerName:      ownerName,
+		ImagePath:      task.ImagePath,
+		ProjectID:      task.ProjectID,
+		Priority:       string(task.Priority),
+		Tags:           append([]string{}, task.Tags...),
+		Color:          string(task.Color),
+		DueDate:        task.DueDate,
+		OverdueAt:      task.OverdueAt,
+		SnoozedUntil:   task.SnoozedUntil,
+		Progress:       task.PercentComplete(),
+		CompletionNote: task.CompletionNote,
+		CreatedAt:      task.CreatedAt,
+		UpdatedAt:      task.UpdatedAt,
+	}
+}
+
+func toTaskResponses(tasks []*application.Task) []TaskResponse {
+	response := make([]TaskResponse, 0, len(tasks))
+	for _, task := range tasks {
+		response = append(response, toTaskResponse(task, ""))
+	}
+	return response
 }
 
 type UpdateTaskRequest struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
-	ImagePath   string `json:"image_path"`
+	Title          string `json:"title"`
+	Description    string `json:"description"`
+	Status         string `json:"status"`
+	ImagePath      string `json:"image_path"`
+	DueDate        string `json:"due_date"`
+	ProjectID      string `json:"project_id"`
+	Color          string `json:"color"`
+	Progress       int    `json:"progress"`
+	CompletionNote string `json:"completion_note"`
 }
 
 // CreateTask handles POST /api/tasks
@@ -56,48 +138,137 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("userID").(string)
 
 	var req CreateTaskRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if !decodeJSON(w, r, &req, maxJSONBodySize) {
+		return
+	}
+
+	dueDate, err := parseDueDateInput(r, req.DueDate, h.location)
+	if err != nil {
+		WriteJSONError(w, r, http.StatusBadRequest, CodeInvalidDueDate, err.Error())
+		return
+	}
+	if !h.checkImagePath(w, r, userID, "", req.ImagePath) {
 		return
 	}
 
-	task, err := h.createTask.Execute(r.Context(), req.Title, req.Description, userID, req.ImagePath)
+	task, err := h.createTask.Execute(r.Context(), req.Title, req.Description, userID, req.ImagePath, dueDate, req.ProjectID, application.TaskColor(req.Color))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		if writeCreationQuotaError(w, r, err) || writeValidationError(w, r, err) {
+			return
+		}
+		writeAPIError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if jsonAPIRequested(w, r) {
+		sharees := h.shareesOf(r.Context(), userID, task)
+		resource := newTaskResources(userID, h.taskResponses(r.Context(), task), sharees)[0]
+		w.Header().Set("Location", taskResourceURL(task.ID))
+		writeJSONAPI(w, http.StatusCreated, JSONAPIDocument{Data: resource, Links: map[string]string{"self": taskResourceURL(task.ID)}})
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(task)
+	json.NewEncoder(w).Encode(h.taskResponses(r.Context(), task)[0])
 }
 
-// ListTasks handles GET /api/tasks
+// ListTasks handles GET /api/tasks?color=, listing every task of the user, or only those of a
+// color. With cursor or limit it lists a page of them instead, sorted by sort (-created_at or
+// created_at).
 func (h *TaskHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("userID").(string)
 
+	var query application.TaskListQuery
+	var err error
+	if values := r.URL.Query(); values.Has("cursor") || values.Has("limit") {
+		query, err = parseTaskListQuery(r)
+	} else {
+		query, err = query.WithColor(values.Get("color"))
+	}
+	if err != nil {
+		writeAPIError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	tasks, err := h.listTasks.Execute(r.Context(), userID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeAPIError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	tasks = tasksWithColor(tasks, query.Color)
+
+	page, next := tasks, (*application.TaskCursor)(nil)
+	if query.Cursor {
+		page, next = application.CursorPage(tasks, query)
+	}
+
+	etag := taskListETag(userID, tasks)
+	if jsonAPIRequested(w, r) {
+		sharees := h.shareesOf(r.Context(), userID, page...)
+		if writeNotModified(w, r, jsonAPIETag(etag, sharees)) {
+			return
+		}
+
+		links := map[string]string{"self": requestURL(r).String()}
+		if next != nil {
+			links["next"] = cursorURL(r, encodeTaskCursor(next))
+		}
+		writeJSONAPI(w, http.StatusOK, JSONAPIDocument{
+			Data:  newTaskResources(userID, h.taskResponses(r.Context(), page...), sharees),
+			Links: links,
+		})
+		return
+	}
+
+	if writeNotModified(w, r, etag) {
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tasks)
+	if query.Cursor {
+		json.NewEncoder(w).Encode(newTaskCursorPage(h.taskResponses(r.Context(), page...), next))
+		return
+	}
+	json.NewEncoder(w).Encode(h.taskResponses(r.Context(), tasks...))
 }
 
-// ListSharedTasks handles GET /api/tasks/shared
+// ListSharedTasks handles GET /api/tasks/shared?status=&tag=&search=&sort=&page=&per_page=,
+// listing a page of the tasks shared with the user. The size of the whole list is sent in
+// X-Total-Count and the URLs of the neighbouring pages in Link. With cursor and limit instead
+// of page and per_page, the page comes in a TaskCursorPage.
 func (h *TaskHandler) ListSharedTasks(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("userID").(string)
 
-	tasks, err := h.listSharedTasks.Execute(r.Context(), userID)
+	query, err := parseTaskListQuery(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeAPIError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.listSharedTasks.Execute(r.Context(), userID, query)
+	if err != nil {
+		writeAPIError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response := make([]TaskResponse, 0, len(page.Views))
+	for _, view := range page.Views {
+		response = append(response, toTaskResponse(view.Task, view.OwnerName))
+	}
+
+	if jsonAPIRequested(w, r) {
+		h.writeSharedTasksDocument(w, r, userID, query, page, response)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tasks)
+	if query.Cursor {
+		json.NewEncoder(w).Encode(newTaskCursorPage(response, page.NextCursor))
+		return
+	}
+	writePageHeaders(w, r, page)
+	json.NewEncoder(w).Encode(response)
 }
 
 // GetTask handles GET /api/tasks/{id}
@@ -107,45 +278,262 @@ func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 
 	task, err := h.getTask.Execute(r.Context(), taskID, userID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusForbidden)
+		writeAPIError(w, r, taskErrorStatus(err, http.StatusForbidden), err.Error())
+		return
+	}
+
+	if jsonAPIRequested(w, r) {
+		sharees := h.shareesOf(r.Context(), userID, task)
+		if writeNotModified(w, r, jsonAPIETag(taskETag(task), sharees)) {
+			return
+		}
+
+		resource := newTaskResources(userID, h.taskResponses(r.Context(), task), sharees)[0]
+		writeJSONAPI(w, http.StatusOK, JSONAPIDocument{Data: resource, Links: map[string]string{"self": taskResourceURL(task.ID)}})
+		return
+	}
+
+	if writeNotModified(w, r, taskETag(task)) {
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(task)
+	json.NewEncoder(w).Encode(h.taskResponses(r.Context(), task)[0])
 }
 
-// UpdateTask handles PUT /api/tasks/{id}
+// errUnknownImagePath rejects an image_path that isn't an image uploaded through /upload/image
+var errUnknownImagePath = &application.ValidationError{Fields: []application.FieldError{
+	{Field: "image_path", Code: application.CodeInvalid, Message: "image path must be an image uploaded through /upload/image"},
+}}
+
+// checkImagePath writes an error and returns false unless imagePath is empty, the image the task
+// already has, or an image the user uploaded. Pointing a task at the image of another user would
+// delete their image, and release their storage, when the task is deleted. taskID is empty for a
+// new task.
+func (h *TaskHandler) checkImagePath(w http.ResponseWriter, r *http.Request, userID, taskID, imagePath string) bool {
+	if imagePath == "" {
+		return true
+	}
+	if !h.files.images.IsUploadedImage(imagePath) {
+		writeValidationError(w, r, errUnknownImagePath)
+		return false
+	}
+
+	owned, err := h.files.images.IsOwnImage(r.Context(), userID, imagePath)
+	if err != nil {
+		writeAPIError(w, r, http.StatusInternalServerError, "Failed to check image")
+		return false
+	}
+	if owned {
+		return true
+	}
+	// A sharee, or the new owner of a transferred task, keeps the image someone else uploaded
+	if taskID != "" {
+		if task, err := h.getTask.Execute(r.Context(), taskID, userID); err == nil && task.ImagePath == imagePath {
+			return true
+		}
+	}
+	writeAPIError(w, r, http.StatusUnprocessableEntity, "image path must be an image you uploaded")
+	return false
+}
+
+// UpdateTask handles PUT /api/tasks/{id}. The body is JSON, whose image_path must be a path
+// POST /upload/image returned to the same user, or a multipart form with the same fields and the new image
+// in "image". As with the other fields, an empty image_path removes the image.
 func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("userID").(string)
 	taskID := r.PathValue("id")
 
 	var req UpdateTaskRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var image *multipart.FileHeader
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		var ok bool
+		if image, ok = decodeUpdateTaskForm(w, r, &req); !ok {
+			return
+		}
+	} else if !decodeJSON(w, r, &req, maxJSONBodySize) {
+		return
+	}
+
+	dueDate, err := parseDueDateInput(r, req.DueDate, h.location)
+	if err != nil {
+		WriteJSONError(w, r, http.StatusBadRequest, CodeInvalidDueDate, err.Error())
+		return
+	}
+
+	var uploaded string
+	if image != nil {
+		if uploaded, err = h.saveImage(r.Context(), userID, image); err != nil {
+			writeImageError(w, r, err)
+			return
+		}
+		req.ImagePath = uploaded
+	} else if !h.checkImagePath(w, r, userID, taskID, req.ImagePath) {
 		return
 	}
 
 	status := application.TaskStatus(req.Status)
-	err := h.updateTask.Execute(r.Context(), taskID, req.Title, req.Description, status, req.ImagePath, userID)
+	err = h.updateTask.Execute(r.Context(), taskID, req.Title, req.Description, status, req.ImagePath, userID, dueDate, req.ProjectID, application.TaskColor(req.Color), req.Progress, req.CompletionNote)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		// The task doesn't point to the image it came with, so it goes away with the request
+		if uploaded != "" {
+			h.files.images.DeleteImage(r.Context(), uploaded)
+		}
+		if errors.Is(err, application.ErrTaskBlocked) {
+			writeAPIError(w, r, http.StatusConflict, err.Error())
+			return
+		}
+		if writeValidationError(w, r, err) {
+			return
+		}
+		writeAPIError(w, r, taskErrorStatus(err, http.StatusBadRequest), err.Error())
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// decodeUpdateTaskForm reads the fields of a multipart task update into req and returns its
+// image, nil when the form has none, writing the error response when the form is invalid
+func decodeUpdateTaskForm(w http.ResponseWriter, r *http.Request, req *UpdateTaskRequest) (*multipart.FileHeader, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxFileSize+maxJSONBodySize)
+	if err := r.ParseMultipartForm(10 << 20); err != nil { // 10MB in memory
+		if isTooLarge(err) {
+			writeAPIError(w, r, http.StatusRequestEntityTooLarge, "Request body too large")
+			return nil, false
+		}
+		writeAPIError(w, r, http.StatusBadRequest, "Invalid form data")
+		return nil, false
+	}
+
+	*req = UpdateTaskRequest{
+		Title:          r.FormValue("title"),
+		Description:    r.FormValue("description"),
+		Status:         r.FormValue("status"),
+		ImagePath:      r.FormValue("image_path"),
+		DueDate:        r.FormValue("due_date"),
+		ProjectID:      r.FormValue("project_id"),
+		Color:          r.FormValue("color"),
+		CompletionNote: r.FormValue("completion_note"),
+	}
+	if progress := r.FormValue("progress"); progress != "" {
+		var err error
+		if req.Progress, err = strconv.Atoi(progress); err != nil {
+			writeAPIError(w, r, http.StatusBadRequest, "Invalid progress")
+			return nil, false
+		}
+	}
+
+	_, image, err := r.FormFile("image")
+	if errors.Is(err, http.ErrMissingFile) {
+		return nil, true
+	}
+	if err != nil {
+		writeAPIError(w, r, http.StatusBadRequest, "Invalid form data")
+		return nil, false
+	}
+	return image, true
+}
+
+// saveImage stores the image of a multipart task update and returns its path
+func (h *TaskHandler) saveImage(ctx context.Context, userID string, image *multipart.FileHeader) (string, error) {
+	file, err := image.Open()
+	if err != nil {
+		return "", errors.New("error reading file")
+	}
+	defer file.Close()
+	return h.files.images.SaveImage(ctx, userID, file, image)
+}
+
 // DeleteTask handles DELETE /api/tasks/{id}
 func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("userID").(string)
 	taskID := r.PathValue("id")
 
-	err := h.deleteTask.Execute(r.Context(), taskID, userID)
+	files, err := h.deleteTask.Execute(r.Context(), taskID, userID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusForbidden)
+		writeAPIError(w, r, taskErrorStatus(err, http.StatusForbidden), err.Error())
 		return
 	}
 
+	h.files.RemoveDeleted(r.Context(), files)
+
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// taskErrorStatus returns 404 for a task that doesn't exist and status for the other errors of
+// a task use case
+func taskErrorStatus(err error, status int) int {
+	if errors.Is(err, application.ErrTaskNotFound) {
+		return http.StatusNotFound
+	}
+	return status
+}
+
+// tasksWithColor keeps the tasks of a color; ColorNone keeps every task
+func tasksWithColor(tasks []*application.Task, color application.TaskColor) []*application.Task {
+	if color == application.ColorNone {
+		return tasks
+	}
+	colored := make([]*application.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task.Color == color {
+			colored = append(colored, task)
+		}
+	}
+	return colored
+}
+
+// taskResponses pairs tasks with the names of their owners. The names are only shown to the
+// users the tasks are shared with, so when they can't be looked up the tasks are answered
+// without them rather than failing the request.
+func (h *TaskHandler) taskResponses(ctx context.Context, tasks ...*application.Task) []TaskResponse {
+	names, err := h.ownerNames.Execute(ctx, tasks)
+	if err != nil {
+		log.Printf("failed to look up task owner names: %v", err)
+	}
+
+	responses := make([]TaskResponse, len(tasks))
+	for i, task := range tasks {
+		responses[i] = toTaskResponse(task, names[task.OwnerID])
+	}
+	return responses
+}
+
+// shareesOf looks up the users the tasks userID owns are shared with, for the shares
+// relationship of JSON:API resources. As with owner names, when they can't be looked up the
+// tasks are answered without the relationship: it returns nil.
+func (h *TaskHandler) shareesOf(ctx context.Context, userID string, tasks ...*application.Task) map[string][]string {
+	sharees, err := h.shareeIDs.Execute(ctx, userID, tasks)
+	if err != nil {
+		log.Printf("failed to look up task sharees: %v", err)
+		return nil
+	}
+	return sharees
+}
+
+// writeSharedTasksDocument writes a page of the tasks shared with the user as a JSON:API
+// document. Its links point to the neighbouring pages, and with page and per_page its meta has
+// the size of the whole list. The user doesn't own these tasks, so they have no shares.
+func (h *TaskHandler) writeSharedTasksDocument(w http.ResponseWriter, r *http.Request, userID string, query application.TaskListQuery, page *application.TaskPage, tasks []TaskResponse) {
+	document := JSONAPIDocument{
+		Data:  newTaskResources(userID, tasks, nil),
+		Links: map[string]string{"self": requestURL(r).String()},
+	}
+
+	if query.Cursor {
+		if page.NextCursor != nil {
+			document.Links["next"] = cursorURL(r, encodeTaskCursor(page.NextCursor))
+		}
+	} else {
+		document.Meta = map[string]any{"total": page.Total}
+		if page.HasNext() {
+			document.Links["next"] = pageURL(r, page.Page+1, page.PerPage)
+		}
+		if page.HasPrevious() {
+			document.Links["prev"] = pageURL(r, page.Page-1, page.PerPage)
+		}
+	}
+
+	writeJSONAPI(w, http.StatusOK, document)
+}
@@ -5,13 +5,18 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
 )
 
 // =============================================================================
@@ -19,12 +24,16 @@ import (
 // =============================================================================
 
 type mockCreateTaskUseCase struct {
-	executeFunc func(ctx context.Context, title, description, ownerID, imagePath string) (*application.Task, error)
+	executeFunc func(ctx context.Context, title, description, ownerID, imagePath string, dueDate *time.Time) (*application.Task, error)
+	projectID   string
+	color       application.TaskColor
 }
 
-func (m *mockCreateTaskUseCase) Execute(ctx context.Context, title, description, ownerID, imagePath string) (*application.Task, error) {
+func (m *mockCreateTaskUseCase) Execute(ctx context.Context, title, description, ownerID, imagePath string, dueDate *time.Time, projectID string, color application.TaskColor) (*application.Task, error) {
+	m.projectID = projectID
+	m.color = color
 	if m.executeFunc != nil {
-		return m.executeFunc(ctx, title, description, ownerID, imagePath)
+		return m.executeFunc(ctx, title, description, ownerID, imagePath, dueDate)
 	}
 	return &application.Task{
 		ID:          "task-123",
@@ -57,25 +66,36 @@ func (m *mockGetTaskUseCase) Execute(ctx context.Context, taskID, userID string)
 }
 
 type mockUpdateTaskUseCase struct {
-	executeFunc func(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string) error
+	executeFunc    func(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string, dueDate *time.Time) error
+	projectID      string
+	color          application.TaskColor
+	progress       int
+	completionNote string
 }
 
-func (m *mockUpdateTaskUseCase) Execute(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string) error {
+func (m *mockUpdateTaskUseCase) Execute(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string, dueDate *time.Time, projectID string, color application.TaskColor, progress int, completionNote string) error {
+	m.projectID = projectID
+	m.color = color
+	m.progress = progress
+	m.completionNote = completionNote
 	if m.executeFunc != nil {
-		return m.executeFunc(ctx, taskID, title, description, status, imagePath, userID)
+		return m.executeFunc(ctx, taskID, title, description, status, imagePath, userID, dueDate)
 	}
 	return nil
 }
 
 type mockDeleteTaskUseCase struct {
 	executeFunc func(ctx context.Context, taskID, userID string) error
+	files       *usecases.DeletedTaskFiles
 }
 
-func (m *mockDeleteTaskUseCase) Execute(ctx context.Context, taskID, userID string) error {
+func (m *mockDeleteTaskUseCase) Execute(ctx context.Context, taskID, userID string) (*usecases.DeletedTaskFiles, error) {
 	if m.executeFunc != nil {
-		return m.executeFunc(ctx, taskID, userID)
+		if err := m.executeFunc(ctx, taskID, userID); err != nil {
+			return nil, err
+		}
 	}
-	return nil
+	return m.files, nil
 }
 
 type mockListTasksUseCase struct {
@@ -100,33 +120,58 @@ func (m *mockListTasksUseCase) Execute(ctx context.Context, userID string) ([]*a
 }
 
 type mockListSharedTasksUseCase struct {
-	executeFunc func(ctx context.Context, userID string) ([]*application.Task, error)
+	executeFunc func(ctx context.Context, userID string, query application.TaskListQuery) (*application.TaskPage, error)
 }
 
-func (m *mockListSharedTasksUseCase) Execute(ctx context.Context, userID string) ([]*application.Task, error) {
+func (m *mockListSharedTasksUseCase) Execute(ctx context.Context, userID string, query application.TaskListQuery) (*application.TaskPage, error) {
 	if m.executeFunc != nil {
-		return m.executeFunc(ctx, userID)
+		return m.executeFunc(ctx, userID, query)
 	}
-	return []*application.Task{
-		{
-			ID:          "shared-task-1",
-			Title:       "Shared Task 1",
-			Description: "Shared Description 1",
-			Status:      application.StatusPending,
-			OwnerID:     "other-user",
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
+	return &application.TaskPage{
+		Views: []*application.TaskView{
+			{
+				Task: &application.Task{
+					ID:          "shared-task-1",
+					Title:       "Shared Task 1",
+					Description: "Shared Description 1",
+					Status:      application.StatusPending,
+					OwnerID:     "other-user",
+					CreatedAt:   time.Now(),
+					UpdatedAt:   time.Now(),
+				},
+				OwnerName: "Other User",
+			},
 		},
+		Total:   1,
+		Page:    query.Page,
+		PerPage: query.PerPage,
 	}, nil
 }
 
+// mockGetOwnerNamesUseCase knows the names in names, or "Other User" for other-user when
+// names is nil
+type mockGetOwnerNamesUseCase struct {
+	names map[string]string
+	err   error
+}
+
+func (m *mockGetOwnerNamesUseCase) Execute(ctx context.Context, tasks []*application.Task) (map[string]string, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.names == nil {
+		return map[string]string{"other-user": "Other User"}, nil
+	}
+	return m.names, nil
+}
+
 // =============================================================================
 // CreateTask Tests
 // =============================================================================
 
 func TestCreateTask_Success(t *testing.T) {
 	mockCreate := &mockCreateTaskUseCase{
-		executeFunc: func(ctx context.Context, title, description, ownerID, imagePath string) (*application.Task, error) {
+		executeFunc: func(ctx context.Context, title, description, ownerID, imagePath string, dueDate *time.Time) (*application.Task, error) {
 			if title != "New Task" {
 				t.Errorf("Expected title 'New Task', got %s", title)
 			}
@@ -148,7 +193,7 @@ func TestCreateTask_Success(t *testing.T) {
 		},
 	}
 
-	handler := NewTaskHandler(mockCreate, nil, nil, nil, nil, nil)
+	handler := NewTaskHandler(mockCreate, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	reqBody := CreateTaskRequest{
 		Title:       "New Task",
@@ -187,7 +232,7 @@ func TestCreateTask_Success(t *testing.T) {
 }
 
 func TestCreateTask_InvalidJSON(t *testing.T) {
-	handler := NewTaskHandler(&mockCreateTaskUseCase{}, nil, nil, nil, nil, nil)
+	handler := NewTaskHandler(&mockCreateTaskUseCase{}, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	req := httptest.NewRequest("POST", "/api/tasks", strings.NewReader("invalid-json"))
 	ctx := context.WithValue(req.Context(), "userID", "user-123")
@@ -206,14 +251,97 @@ func TestCreateTask_InvalidJSON(t *testing.T) {
 	}
 }
 
+func TestCreateTask_RejectsUnknownFields(t *testing.T) {
+	handler := NewTaskHandler(&mockCreateTaskUseCase{
+		executeFunc: func(ctx context.Context, title, description, ownerID, imagePath string, dueDate *time.Time) (*application.Task, error) {
+			t.Error("task created from a body with unknown fields")
+			return nil, nil
+		},
+	}, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+
+	// A misspelled field would otherwise be silently dropped
+	req := httptest.NewRequest("POST", "/api/tasks", strings.NewReader(`{"title": "New Task", "descripton": "typo"}`))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+	w := httptest.NewRecorder()
+	handler.CreateTask(w, req)
+
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), CodeUnknownField) {
+		t.Errorf("CreateTask() = %d %s, want 400 unknown_field", w.Code, w.Body.String())
+	}
+}
+
+func TestCreateTask_DueDate(t *testing.T) {
+	var received *time.Time
+	mockCreate := &mockCreateTaskUseCase{
+		executeFunc: func(ctx context.Context, title, description, ownerID, imagePath string, dueDate *time.Time) (*application.Task, error) {
+			received = dueDate
+			return &application.Task{ID: "task-456", Title: title, OwnerID: ownerID, DueDate: dueDate}, nil
+		},
+	}
+
+	handler := NewTaskHandler(mockCreate, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+
+	body, _ := json.Marshal(CreateTaskRequest{Title: "New Task", DueDate: "25/12/2030 14h"})
+	req := httptest.NewRequest("POST", "/api/tasks", bytes.NewReader(body))
+	req.Header.Set("X-Timezone", "America/Sao_Paulo")
+	req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+
+	w := httptest.NewRecorder()
+	handler.CreateTask(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if received == nil {
+		t.Fatal("Expected due date to be passed to use case")
+	}
+	if received.Hour() != 14 || received.Day() != 25 || received.Location().String() != "America/Sao_Paulo" {
+		t.Errorf("Unexpected due date %v", received)
+	}
+}
+
+func TestCreateTask_Color(t *testing.T) {
+	mockCreate := &mockCreateTaskUseCase{}
+	handler := NewTaskHandler(mockCreate, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+
+	req := httptest.NewRequest("POST", "/api/tasks", strings.NewReader(`{"title":"New Task","color":"orange"}`))
+	w := httptest.NewRecorder()
+	handler.CreateTask(w, withUser(req))
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if mockCreate.color != application.ColorOrange {
+		t.Errorf("Expected color orange to be passed to use case, got %q", mockCreate.color)
+	}
+}
+
+func TestCreateTask_InvalidDueDate(t *testing.T) {
+	handler := NewTaskHandler(&mockCreateTaskUseCase{}, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+
+	body, _ := json.Marshal(CreateTaskRequest{Title: "New Task", DueDate: "semana que vem"})
+	req := httptest.NewRequest("POST", "/api/tasks", bytes.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+
+	w := httptest.NewRecorder()
+	handler.CreateTask(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "amanhã") {
+		t.Errorf("Expected error with examples, got: %s", w.Body.String())
+	}
+}
+
 func TestCreateTask_EmptyTitle(t *testing.T) {
 	mockCreate := &mockCreateTaskUseCase{
-		executeFunc: func(ctx context.Context, title, description, ownerID, imagePath string) (*application.Task, error) {
+		executeFunc: func(ctx context.Context, title, description, ownerID, imagePath string, dueDate *time.Time) (*application.Task, error) {
 			return nil, errors.New("task title cannot be empty")
 		},
 	}
 
-	handler := NewTaskHandler(mockCreate, nil, nil, nil, nil, nil)
+	handler := NewTaskHandler(mockCreate, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	reqBody := CreateTaskRequest{
 		Title:       "",
@@ -240,7 +368,7 @@ func TestCreateTask_EmptyTitle(t *testing.T) {
 
 func TestCreateTask_TitleTooLong(t *testing.T) {
 	mockCreate := &mockCreateTaskUseCase{
-		executeFunc: func(ctx context.Context, title, description, ownerID, imagePath string) (*application.Task, error) {
+		executeFunc: func(ctx context.Context, title, description, ownerID, imagePath string, dueDate *time.Time) (*application.Task, error) {
 			if len(title) > 200 {
 				return nil, errors.New("task title cannot exceed 200 characters")
 			}
@@ -248,7 +376,7 @@ func TestCreateTask_TitleTooLong(t *testing.T) {
 		},
 	}
 
-	handler := NewTaskHandler(mockCreate, nil, nil, nil, nil, nil)
+	handler := NewTaskHandler(mockCreate, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	longTitle := strings.Repeat("a", 201)
 	reqBody := CreateTaskRequest{
@@ -294,7 +422,7 @@ func TestGetTask_Success(t *testing.T) {
 		},
 	}
 
-	handler := NewTaskHandler(nil, nil, nil, mockGet, nil, nil)
+	handler := NewTaskHandler(nil, nil, nil, mockGet, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	req := httptest.NewRequest("GET", "/api/tasks/task-123", nil)
 	req.SetPathValue("id", "task-123")
@@ -322,14 +450,55 @@ func TestGetTask_Success(t *testing.T) {
 	}
 }
 
+func TestGetTask_OwnerName(t *testing.T) {
+	mockGet := &mockGetTaskUseCase{
+		executeFunc: func(ctx context.Context, taskID, userID string) (*application.Task, error) {
+			return &application.Task{ID: taskID, Title: "Shared Task", Status: application.StatusPending, OwnerID: "other-user"}, nil
+		},
+	}
+
+	tests := []struct {
+		name       string
+		ownerNames *mockGetOwnerNamesUseCase
+		want       string
+	}{
+		{"owner found", &mockGetOwnerNamesUseCase{}, "Other User"},
+		{"lookup failure still answers the task", &mockGetOwnerNamesUseCase{err: errors.New("database error")}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewTaskHandler(nil, nil, nil, mockGet, nil, nil, nil, tt.ownerNames, nil, time.Local)
+
+			req := httptest.NewRequest("GET", "/api/tasks/task-123", nil)
+			req.SetPathValue("id", "task-123")
+			req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+
+			w := httptest.NewRecorder()
+			handler.GetTask(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d", w.Code)
+			}
+			var response map[string]any
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response["owner_name"] != tt.want || response["id"] != "task-123" {
+				t.Errorf("Expected task-123 owned by %q, got %v", tt.want, response)
+			}
+		})
+	}
+}
+
 func TestGetTask_NotFound(t *testing.T) {
 	mockGet := &mockGetTaskUseCase{
 		executeFunc: func(ctx context.Context, taskID, userID string) (*application.Task, error) {
-			return nil, errors.New("task not found")
+			return nil, application.ErrTaskNotFound
 		},
 	}
 
-	handler := NewTaskHandler(nil, nil, nil, mockGet, nil, nil)
+	handler := NewTaskHandler(nil, nil, nil, mockGet, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	req := httptest.NewRequest("GET", "/api/tasks/nonexistent", nil)
 	req.SetPathValue("id", "nonexistent")
@@ -339,8 +508,8 @@ func TestGetTask_NotFound(t *testing.T) {
 	w := httptest.NewRecorder()
 	handler.GetTask(w, req)
 
-	if w.Code != http.StatusForbidden {
-		t.Errorf("Expected status 403, got %d", w.Code)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
 	}
 }
 
@@ -351,7 +520,7 @@ func TestGetTask_NoPermission(t *testing.T) {
 		},
 	}
 
-	handler := NewTaskHandler(nil, nil, nil, mockGet, nil, nil)
+	handler := NewTaskHandler(nil, nil, nil, mockGet, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	req := httptest.NewRequest("GET", "/api/tasks/task-123", nil)
 	req.SetPathValue("id", "task-123")
@@ -372,7 +541,7 @@ func TestGetTask_NoPermission(t *testing.T) {
 
 func TestUpdateTask_Success(t *testing.T) {
 	mockUpdate := &mockUpdateTaskUseCase{
-		executeFunc: func(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string) error {
+		executeFunc: func(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string, dueDate *time.Time) error {
 			if taskID != "task-123" {
 				t.Errorf("Expected taskID 'task-123', got %s", taskID)
 			}
@@ -386,12 +555,13 @@ func TestUpdateTask_Success(t *testing.T) {
 		},
 	}
 
-	handler := NewTaskHandler(nil, mockUpdate, nil, nil, nil, nil)
+	handler := NewTaskHandler(nil, mockUpdate, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	reqBody := UpdateTaskRequest{
 		Title:       "Updated Task",
 		Description: "Updated Description",
 		Status:      "in_progress",
+		Progress:    60,
 	}
 	body, _ := json.Marshal(reqBody)
 
@@ -406,10 +576,33 @@ func TestUpdateTask_Success(t *testing.T) {
 	if w.Code != http.StatusNoContent {
 		t.Errorf("Expected status 204, got %d", w.Code)
 	}
+	if mockUpdate.progress != 60 || mockUpdate.completionNote != "" {
+		t.Errorf("Expected progress 60 without a note, got %d and %q", mockUpdate.progress, mockUpdate.completionNote)
+	}
+}
+
+func TestUpdateTask_InvalidProgress(t *testing.T) {
+	mockUpdate := &mockUpdateTaskUseCase{
+		executeFunc: func(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string, dueDate *time.Time) error {
+			return (&application.Task{}).SetProgress(150)
+		},
+	}
+	handler := NewTaskHandler(nil, mockUpdate, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+
+	req := httptest.NewRequest("PUT", "/api/tasks/task-123", strings.NewReader(`{"title":"Updated Task","status":"in_progress","progress":150}`))
+	req.SetPathValue("id", "task-123")
+	req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+
+	w := httptest.NewRecorder()
+	handler.UpdateTask(w, req)
+
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `"progress"`) {
+		t.Errorf("Expected 400 with an error on progress, got %d: %s", w.Code, w.Body.String())
+	}
 }
 
 func TestUpdateTask_InvalidJSON(t *testing.T) {
-	handler := NewTaskHandler(nil, &mockUpdateTaskUseCase{}, nil, nil, nil, nil)
+	handler := NewTaskHandler(nil, &mockUpdateTaskUseCase{}, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	req := httptest.NewRequest("PUT", "/api/tasks/task-123", strings.NewReader("invalid-json"))
 	req.SetPathValue("id", "task-123")
@@ -426,12 +619,12 @@ func TestUpdateTask_InvalidJSON(t *testing.T) {
 
 func TestUpdateTask_InvalidStatus(t *testing.T) {
 	mockUpdate := &mockUpdateTaskUseCase{
-		executeFunc: func(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string) error {
+		executeFunc: func(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string, dueDate *time.Time) error {
 			return errors.New("invalid task status")
 		},
 	}
 
-	handler := NewTaskHandler(nil, mockUpdate, nil, nil, nil, nil)
+	handler := NewTaskHandler(nil, mockUpdate, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	reqBody := UpdateTaskRequest{
 		Title:       "Task",
@@ -455,12 +648,12 @@ func TestUpdateTask_InvalidStatus(t *testing.T) {
 
 func TestUpdateTask_NoPermission(t *testing.T) {
 	mockUpdate := &mockUpdateTaskUseCase{
-		executeFunc: func(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string) error {
+		executeFunc: func(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string, dueDate *time.Time) error {
 			return errors.New("user does not have permission to modify this task")
 		},
 	}
 
-	handler := NewTaskHandler(nil, mockUpdate, nil, nil, nil, nil)
+	handler := NewTaskHandler(nil, mockUpdate, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	reqBody := UpdateTaskRequest{
 		Title:       "Task",
@@ -482,6 +675,210 @@ func TestUpdateTask_NoPermission(t *testing.T) {
 	}
 }
 
+func TestUpdateTask_NotFound(t *testing.T) {
+	mockUpdate := &mockUpdateTaskUseCase{
+		executeFunc: func(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string, dueDate *time.Time) error {
+			return application.ErrTaskNotFound
+		},
+	}
+
+	handler := NewTaskHandler(nil, mockUpdate, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+
+	body, _ := json.Marshal(UpdateTaskRequest{Title: "Task", Status: "pending"})
+	req := httptest.NewRequest("PUT", "/api/tasks/nonexistent", bytes.NewReader(body))
+	req.SetPathValue("id", "nonexistent")
+	ctx := context.WithValue(req.Context(), "userID", "user-123")
+	req = req.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	handler.UpdateTask(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
+
+func TestUpdateTask_ImagePath(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "1700000000_abc.png"), testPNG, 0644)
+	files := NewTaskFileStorage(NewUploadHandler(dir, nil), t.TempDir(), nil)
+
+	tests := []struct {
+		name       string
+		imagePath  string
+		wantStatus int
+	}{
+		{"uploaded image", "/uploads/images/1700000000_abc.png", http.StatusNoContent},
+		{"no image", "", http.StatusNoContent},
+		{"image never uploaded", "/uploads/images/1700000000_def.png", http.StatusBadRequest},
+		{"outside the uploads", "/etc/passwd", http.StatusBadRequest},
+		{"path traversal", "/uploads/images/../1700000000_abc.png", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotImage *string
+			mockUpdate := &mockUpdateTaskUseCase{
+				executeFunc: func(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string, dueDate *time.Time) error {
+					gotImage = &imagePath
+					return nil
+				},
+			}
+			h := NewTaskHandler(nil, mockUpdate, nil, nil, nil, nil, files, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+
+			body, _ := json.Marshal(UpdateTaskRequest{Title: "Task", Status: "pending", ImagePath: tt.imagePath})
+			req := httptest.NewRequest("PUT", "/api/tasks/task-123", bytes.NewReader(body))
+			req.SetPathValue("id", "task-123")
+			req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+
+			w := httptest.NewRecorder()
+			h.UpdateTask(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusNoContent && (gotImage == nil || *gotImage != tt.imagePath) {
+				t.Errorf("image path = %v, want %q", gotImage, tt.imagePath)
+			}
+			if tt.wantStatus == http.StatusBadRequest && (gotImage != nil || !strings.Contains(w.Body.String(), `"image_path":"invalid"`)) {
+				t.Errorf("unknown image should be a field error before the update: %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestUpdateTask_ImageOfAnotherUser(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "1700000000_abc.png"), testPNG, 0644)
+	quota := &mockStorageQuota{owners: map[string]string{"image/1700000000_abc.png": "user-a"}}
+	files := NewTaskFileStorage(NewUploadHandler(dir, quota), t.TempDir(), nil)
+
+	tests := []struct {
+		name         string
+		userID       string
+		currentImage string
+		wantStatus   int
+	}{
+		{"image of the user", "user-a", "", http.StatusNoContent},
+		{"image of another user", "user-b", "", http.StatusUnprocessableEntity},
+		{"image the task already has", "user-b", "/uploads/images/1700000000_abc.png", http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := false
+			mockUpdate := &mockUpdateTaskUseCase{
+				executeFunc: func(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string, dueDate *time.Time) error {
+					updated = true
+					return nil
+				},
+			}
+			mockGet := &mockGetTaskUseCase{
+				executeFunc: func(ctx context.Context, taskID, userID string) (*application.Task, error) {
+					return &application.Task{ID: taskID, Title: "Task", Status: application.StatusPending, OwnerID: userID, ImagePath: tt.currentImage}, nil
+				},
+			}
+			h := NewTaskHandler(nil, mockUpdate, nil, mockGet, nil, nil, files, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+
+			body, _ := json.Marshal(UpdateTaskRequest{Title: "Task", Status: "pending", ImagePath: "/uploads/images/1700000000_abc.png"})
+			req := httptest.NewRequest("PUT", "/api/tasks/task-b", bytes.NewReader(body))
+			req.SetPathValue("id", "task-b")
+			req = req.WithContext(context.WithValue(req.Context(), "userID", tt.userID))
+
+			w := httptest.NewRecorder()
+			h.UpdateTask(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if updated != (tt.wantStatus == http.StatusNoContent) {
+				t.Errorf("updated = %v, want the task updated only when the image is allowed", updated)
+			}
+		})
+	}
+}
+
+func TestUpdateTask_MultipartImage(t *testing.T) {
+	tests := []struct {
+		name       string
+		updateErr  error
+		wantStatus int
+		wantFiles  int
+	}{
+		{"image saved with the task", nil, http.StatusNoContent, 1},
+		{"image removed when the update fails", errors.New("task title cannot be empty"), http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			var gotTitle, gotImage string
+			mockUpdate := &mockUpdateTaskUseCase{
+				executeFunc: func(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string, dueDate *time.Time) error {
+					gotTitle, gotImage = title, imagePath
+					return tt.updateErr
+				},
+			}
+			h := NewTaskHandler(nil, mockUpdate, nil, nil, nil, nil, NewTaskFileStorage(NewUploadHandler(dir, nil), t.TempDir(), nil), &mockGetOwnerNamesUseCase{}, nil, time.Local)
+
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			writer.WriteField("title", "Com imagem")
+			writer.WriteField("status", "pending")
+			part, _ := writer.CreateFormFile("image", "photo.png")
+			part.Write(testPNG)
+			writer.Close()
+
+			req := httptest.NewRequest("PUT", "/api/tasks/task-123", body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			req.SetPathValue("id", "task-123")
+			req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+
+			w := httptest.NewRecorder()
+			h.UpdateTask(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if gotTitle != "Com imagem" || !strings.HasPrefix(gotImage, "/uploads/images/") {
+				t.Errorf("update got title %q and image %q", gotTitle, gotImage)
+			}
+			if entries, _ := os.ReadDir(dir); len(entries) != tt.wantFiles {
+				t.Errorf("%d images on disk, want %d", len(entries), tt.wantFiles)
+			}
+		})
+	}
+}
+
+func TestUpdateTask_MultipartInvalidImage(t *testing.T) {
+	mockUpdate := &mockUpdateTaskUseCase{
+		executeFunc: func(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string, dueDate *time.Time) error {
+			t.Error("a task should not be updated with a rejected image")
+			return nil
+		},
+	}
+	h := NewTaskHandler(nil, mockUpdate, nil, nil, nil, nil, NewTaskFileStorage(NewUploadHandler(t.TempDir(), nil), t.TempDir(), nil), &mockGetOwnerNamesUseCase{}, nil, time.Local)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("title", "Task")
+	part, _ := writer.CreateFormFile("image", "script.png")
+	part.Write([]byte("#!/bin/sh\necho hi\n"))
+	writer.Close()
+
+	req := httptest.NewRequest("PUT", "/api/tasks/task-123", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.SetPathValue("id", "task-123")
+	req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+
+	w := httptest.NewRecorder()
+	h.UpdateTask(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", w.Code)
+	}
+}
+
 // =============================================================================
 // DeleteTask Tests
 // =============================================================================
@@ -499,7 +896,7 @@ func TestDeleteTask_Success(t *testing.T) {
 		},
 	}
 
-	handler := NewTaskHandler(nil, nil, mockDelete, nil, nil, nil)
+	handler := NewTaskHandler(nil, nil, mockDelete, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	req := httptest.NewRequest("DELETE", "/api/tasks/task-123", nil)
 	req.SetPathValue("id", "task-123")
@@ -517,11 +914,11 @@ func TestDeleteTask_Success(t *testing.T) {
 func TestDeleteTask_NotFound(t *testing.T) {
 	mockDelete := &mockDeleteTaskUseCase{
 		executeFunc: func(ctx context.Context, taskID, userID string) error {
-			return errors.New("task not found")
+			return application.ErrTaskNotFound
 		},
 	}
 
-	handler := NewTaskHandler(nil, nil, mockDelete, nil, nil, nil)
+	handler := NewTaskHandler(nil, nil, mockDelete, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	req := httptest.NewRequest("DELETE", "/api/tasks/nonexistent", nil)
 	req.SetPathValue("id", "nonexistent")
@@ -531,8 +928,8 @@ func TestDeleteTask_NotFound(t *testing.T) {
 	w := httptest.NewRecorder()
 	handler.DeleteTask(w, req)
 
-	if w.Code != http.StatusForbidden {
-		t.Errorf("Expected status 403, got %d", w.Code)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
 	}
 }
 
@@ -543,7 +940,7 @@ func TestDeleteTask_NoPermission(t *testing.T) {
 		},
 	}
 
-	handler := NewTaskHandler(nil, nil, mockDelete, nil, nil, nil)
+	handler := NewTaskHandler(nil, nil, mockDelete, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	req := httptest.NewRequest("DELETE", "/api/tasks/task-123", nil)
 	req.SetPathValue("id", "task-123")
@@ -591,7 +988,7 @@ func TestListTasks_Success(t *testing.T) {
 		},
 	}
 
-	handler := NewTaskHandler(nil, nil, nil, nil, mockList, nil)
+	handler := NewTaskHandler(nil, nil, nil, nil, mockList, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	req := httptest.NewRequest("GET", "/api/tasks", nil)
 	ctx := context.WithValue(req.Context(), "userID", "user-123")
@@ -622,6 +1019,109 @@ func TestListTasks_Success(t *testing.T) {
 	}
 }
 
+func TestListTasks_Cursor(t *testing.T) {
+	base := time.Now()
+	mockList := &mockListTasksUseCase{
+		executeFunc: func(ctx context.Context, userID string) ([]*application.Task, error) {
+			var tasks []*application.Task
+			for i := 1; i <= 5; i++ {
+				tasks = append(tasks, &application.Task{ID: fmt.Sprintf("task-%d", i), OwnerID: userID, CreatedAt: base.Add(time.Duration(i) * time.Minute)})
+			}
+			return tasks, nil
+		},
+	}
+	handler := NewTaskHandler(nil, nil, nil, nil, mockList, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+
+	var ids []string
+	target := "/api/tasks?limit=2"
+	for pages := 0; pages < 5; pages++ {
+		w := httptest.NewRecorder()
+		handler.ListTasks(w, withUser(httptest.NewRequest("GET", target, nil)))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+
+		var response TaskCursorPage
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		for _, task := range response.Tasks {
+			ids = append(ids, task.ID)
+		}
+		if response.NextCursor == nil {
+			break
+		}
+		target = "/api/tasks?limit=2&cursor=" + *response.NextCursor
+	}
+
+	if got := strings.Join(ids, ","); got != "task-5,task-4,task-3,task-2,task-1" {
+		t.Errorf("Expected every task once, newest first, got %s", got)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ListTasks(w, withUser(httptest.NewRequest("GET", "/api/tasks?limit=0x10", nil)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid limit, got %d", w.Code)
+	}
+}
+
+func TestListTasks_Color(t *testing.T) {
+	mockList := &mockListTasksUseCase{
+		executeFunc: func(ctx context.Context, userID string) ([]*application.Task, error) {
+			return []*application.Task{
+				{ID: "task-1", OwnerID: userID, Color: application.ColorRed},
+				{ID: "task-2", OwnerID: userID},
+				{ID: "task-3", OwnerID: userID, Color: application.ColorBlue},
+				{ID: "task-4", OwnerID: userID, Color: application.ColorRed},
+			}, nil
+		},
+	}
+	handler := NewTaskHandler(nil, nil, nil, nil, mockList, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantIDs    string
+	}{
+		{"every color", "/api/tasks", http.StatusOK, "task-1,task-2,task-3,task-4"},
+		{"one color", "/api/tasks?color=red", http.StatusOK, "task-1,task-4"},
+		{"one color with a cursor", "/api/tasks?color=blue&limit=10", http.StatusOK, "task-3"},
+		{"unknown color", "/api/tasks?color=teal", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ListTasks(w, withUser(httptest.NewRequest("GET", tt.target, nil)))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var tasks []TaskResponse
+			if strings.Contains(tt.target, "limit") {
+				var page TaskCursorPage
+				if err := json.NewDecoder(w.Body).Decode(&page); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tasks = page.Tasks
+			} else if err := json.NewDecoder(w.Body).Decode(&tasks); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			var ids []string
+			for _, task := range tasks {
+				ids = append(ids, task.ID)
+			}
+			if got := strings.Join(ids, ","); got != tt.wantIDs {
+				t.Errorf("Expected %s, got %s", tt.wantIDs, got)
+			}
+		})
+	}
+}
+
 func TestListTasks_Empty(t *testing.T) {
 	mockList := &mockListTasksUseCase{
 		executeFunc: func(ctx context.Context, userID string) ([]*application.Task, error) {
@@ -629,7 +1129,7 @@ func TestListTasks_Empty(t *testing.T) {
 		},
 	}
 
-	handler := NewTaskHandler(nil, nil, nil, nil, mockList, nil)
+	handler := NewTaskHandler(nil, nil, nil, nil, mockList, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	req := httptest.NewRequest("GET", "/api/tasks", nil)
 	ctx := context.WithValue(req.Context(), "userID", "user-123")
@@ -659,7 +1159,7 @@ func TestListTasks_Error(t *testing.T) {
 		},
 	}
 
-	handler := NewTaskHandler(nil, nil, nil, nil, mockList, nil)
+	handler := NewTaskHandler(nil, nil, nil, nil, mockList, nil, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	req := httptest.NewRequest("GET", "/api/tasks", nil)
 	ctx := context.WithValue(req.Context(), "userID", "user-123")
@@ -679,25 +1179,33 @@ func TestListTasks_Error(t *testing.T) {
 
 func TestListSharedTasks_Success(t *testing.T) {
 	mockListShared := &mockListSharedTasksUseCase{
-		executeFunc: func(ctx context.Context, userID string) ([]*application.Task, error) {
+		executeFunc: func(ctx context.Context, userID string, query application.TaskListQuery) (*application.TaskPage, error) {
 			if userID != "user-123" {
 				t.Errorf("Expected userID 'user-123', got %s", userID)
 			}
-			return []*application.Task{
-				{
-					ID:          "shared-task-1",
-					Title:       "Shared Task 1",
-					Description: "Shared Description 1",
-					Status:      application.StatusPending,
-					OwnerID:     "other-user",
-					CreatedAt:   time.Now(),
-					UpdatedAt:   time.Now(),
+			return &application.TaskPage{
+				Views: []*application.TaskView{
+					{
+						Task: &application.Task{
+							ID:          "shared-task-1",
+							Title:       "Shared Task 1",
+							Description: "Shared Description 1",
+							Status:      application.StatusPending,
+							OwnerID:     "other-user",
+							CreatedAt:   time.Now(),
+							UpdatedAt:   time.Now(),
+						},
+						OwnerName: "Other User",
+					},
 				},
+				Total:   1,
+				Page:    query.Page,
+				PerPage: query.PerPage,
 			}, nil
 		},
 	}
 
-	handler := NewTaskHandler(nil, nil, nil, nil, nil, mockListShared)
+	handler := NewTaskHandler(nil, nil, nil, nil, nil, mockListShared, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	req := httptest.NewRequest("GET", "/api/tasks/shared", nil)
 	ctx := context.WithValue(req.Context(), "userID", "user-123")
@@ -714,28 +1222,36 @@ func TestListSharedTasks_Success(t *testing.T) {
 		t.Errorf("Expected Content-Type application/json, got %s", w.Header().Get("Content-Type"))
 	}
 
-	var response []*application.Task
+	var response []TaskResponse
 	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
 		t.Fatalf("Failed to decode response: %v", err)
 	}
 
 	if len(response) != 1 {
-		t.Errorf("Expected 1 task, got %d", len(response))
+		t.Fatalf("Expected 1 task, got %d", len(response))
 	}
 
 	if response[0].OwnerID == "user-123" {
 		t.Error("Expected shared task from different owner")
 	}
+
+	if response[0].OwnerName != "Other User" {
+		t.Errorf("Expected owner name 'Other User', got %q", response[0].OwnerName)
+	}
+
+	if got := w.Header().Get("X-Total-Count"); got != "1" {
+		t.Errorf("Expected X-Total-Count 1, got %q", got)
+	}
 }
 
 func TestListSharedTasks_Empty(t *testing.T) {
 	mockListShared := &mockListSharedTasksUseCase{
-		executeFunc: func(ctx context.Context, userID string) ([]*application.Task, error) {
-			return []*application.Task{}, nil
+		executeFunc: func(ctx context.Context, userID string, query application.TaskListQuery) (*application.TaskPage, error) {
+			return &application.TaskPage{Views: []*application.TaskView{}, Page: 1, PerPage: 50}, nil
 		},
 	}
 
-	handler := NewTaskHandler(nil, nil, nil, nil, nil, mockListShared)
+	handler := NewTaskHandler(nil, nil, nil, nil, nil, mockListShared, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	req := httptest.NewRequest("GET", "/api/tasks/shared", nil)
 	ctx := context.WithValue(req.Context(), "userID", "user-123")
@@ -748,24 +1264,138 @@ func TestListSharedTasks_Empty(t *testing.T) {
 		t.Errorf("Expected status 200, got %d", w.Code)
 	}
 
-	var response []*application.Task
+	if body := strings.TrimSpace(w.Body.String()); body != "[]" {
+		t.Errorf("Expected an empty JSON array, got %s", body)
+	}
+}
+
+func TestListSharedTasks_Query(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		total      int
+		wantStatus int
+		wantQuery  application.TaskListQuery
+		wantLink   string
+	}{
+		{
+			name:       "defaults",
+			target:     "/api/tasks/shared",
+			total:      10,
+			wantStatus: http.StatusOK,
+			wantQuery:  application.TaskListQuery{Sort: application.SortNewest, Page: 1, PerPage: 50},
+		},
+		{
+			name:       "filters and sort",
+			target:     "/api/tasks/shared?status=pending&tag=%23Work&search=report&sort=due_date",
+			total:      10,
+			wantStatus: http.StatusOK,
+			wantQuery:  application.TaskListQuery{Status: application.StatusPending, Tag: "work", Search: "report", Sort: application.SortDueDate, Page: 1, PerPage: 50},
+		},
+		{
+			name:       "middle page links both ways keeping the filters",
+			target:     "/api/tasks/shared?status=pending&page=2&per_page=5",
+			total:      12,
+			wantStatus: http.StatusOK,
+			wantQuery:  application.TaskListQuery{Status: application.StatusPending, Sort: application.SortNewest, Page: 2, PerPage: 5},
+			wantLink:   `</api/tasks/shared?page=3&per_page=5&status=pending>; rel="next", </api/tasks/shared?page=1&per_page=5&status=pending>; rel="prev"`,
+		},
+		{name: "invalid status", target: "/api/tasks/shared?status=done", wantStatus: http.StatusBadRequest},
+		{name: "invalid sort", target: "/api/tasks/shared?sort=owner", wantStatus: http.StatusBadRequest},
+		{name: "page is not a number", target: "/api/tasks/shared?page=two", wantStatus: http.StatusBadRequest},
+		{name: "per_page too large", target: "/api/tasks/shared?per_page=500", wantStatus: http.StatusBadRequest},
+		{
+			name:       "first cursor page",
+			target:     "/api/tasks/shared?limit=10&status=pending",
+			wantStatus: http.StatusOK,
+			wantQuery:  application.TaskListQuery{Status: application.StatusPending, Sort: application.SortNewest, Page: 1, PerPage: 10, Cursor: true},
+		},
+		{name: "cursor and page", target: "/api/tasks/shared?limit=10&page=2", wantStatus: http.StatusBadRequest},
+		{name: "invalid cursor", target: "/api/tasks/shared?cursor=not-a-cursor", wantStatus: http.StatusBadRequest},
+		{name: "limit too large", target: "/api/tasks/shared?limit=500", wantStatus: http.StatusBadRequest},
+		{name: "cursor sorted by title", target: "/api/tasks/shared?limit=10&sort=title", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotQuery application.TaskListQuery
+			mockListShared := &mockListSharedTasksUseCase{
+				executeFunc: func(ctx context.Context, userID string, query application.TaskListQuery) (*application.TaskPage, error) {
+					gotQuery = query
+					return &application.TaskPage{Views: []*application.TaskView{}, Total: tt.total, Page: query.Page, PerPage: query.PerPage}, nil
+				},
+			}
+			handler := NewTaskHandler(nil, nil, nil, nil, nil, mockListShared, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+
+			req := httptest.NewRequest("GET", tt.target, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+
+			w := httptest.NewRecorder()
+			handler.ListSharedTasks(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if gotQuery != tt.wantQuery {
+				t.Errorf("Expected query %+v, got %+v", tt.wantQuery, gotQuery)
+			}
+			if got := w.Header().Get("Link"); got != tt.wantLink {
+				t.Errorf("Expected Link %q, got %q", tt.wantLink, got)
+			}
+		})
+	}
+}
+
+func TestListSharedTasks_Cursor(t *testing.T) {
+	after := &application.TaskCursor{CreatedAt: time.Date(2026, 3, 2, 9, 0, 0, 0, time.FixedZone("", -3*3600)), ID: "shared-task-1"}
+
+	var gotQuery application.TaskListQuery
+	mockListShared := &mockListSharedTasksUseCase{
+		executeFunc: func(ctx context.Context, userID string, query application.TaskListQuery) (*application.TaskPage, error) {
+			gotQuery = query
+			view := &application.TaskView{Task: &application.Task{ID: "shared-task-2", CreatedAt: after.CreatedAt.Add(-time.Minute)}}
+			return &application.TaskPage{Views: []*application.TaskView{view}, Page: 1, PerPage: query.PerPage, NextCursor: view.Cursor()}, nil
+		},
+	}
+	handler := NewTaskHandler(nil, nil, nil, nil, nil, mockListShared, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
+
+	req := httptest.NewRequest("GET", "/api/tasks/shared?limit=1&cursor="+encodeTaskCursor(after), nil)
+	w := httptest.NewRecorder()
+	handler.ListSharedTasks(w, withUser(req))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if gotQuery.After == nil || gotQuery.After.ID != after.ID || gotQuery.After.CreatedAt.String() != after.CreatedAt.String() {
+		t.Errorf("Expected the cursor to keep the time and its offset, got %+v", gotQuery.After)
+	}
+	if w.Header().Get("X-Total-Count") != "" || w.Header().Get("Link") != "" {
+		t.Error("Expected no offset pagination headers")
+	}
+
+	var response TaskCursorPage
 	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
 		t.Fatalf("Failed to decode response: %v", err)
 	}
-
-	if len(response) != 0 {
-		t.Errorf("Expected 0 tasks, got %d", len(response))
+	if len(response.Tasks) != 1 || response.NextCursor == nil {
+		t.Fatalf("Expected a task and a next cursor, got %+v", response)
+	}
+	if next, err := decodeTaskCursor(*response.NextCursor); err != nil || next.ID != "shared-task-2" {
+		t.Errorf("Expected the next cursor at shared-task-2, got %+v, %v", next, err)
 	}
 }
 
 func TestListSharedTasks_Error(t *testing.T) {
 	mockListShared := &mockListSharedTasksUseCase{
-		executeFunc: func(ctx context.Context, userID string) ([]*application.Task, error) {
+		executeFunc: func(ctx context.Context, userID string, query application.TaskListQuery) (*application.TaskPage, error) {
 			return nil, errors.New("database error")
 		},
 	}
 
-	handler := NewTaskHandler(nil, nil, nil, nil, nil, mockListShared)
+	handler := NewTaskHandler(nil, nil, nil, nil, nil, mockListShared, nil, &mockGetOwnerNamesUseCase{}, nil, time.Local)
 
 	req := httptest.NewRequest("GET", "/api/tasks/shared", nil)
 	ctx := context.WithValue(req.Context(), "userID", "user-123")
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// TaskOverviewHandler handles the overview of the tasks page over the API
+type TaskOverviewHandler struct {
+	getOverview usecases.GetTaskOverviewUseCaseInterface
+}
+
+// NewTaskOverviewHandler creates a new TaskOverviewHandler
+func NewTaskOverviewHandler(getOverview usecases.GetTaskOverviewUseCaseInterface) *TaskOverviewHandler {
+	return &TaskOverviewHandler{
+		getOverview: getOverview,
+	}
+}
+
+// TaskListItemResponse is a task of the overview, with whether the user owns it and the users
+// it's shared with
+type TaskListItemResponse struct {
+	TaskResponse
+	Owned      bool             `json:"owned"`
+	SharedWith []ShareeResponse `json:"shared_with"`
+}
+
+// ShareeResponse is a user a task is shared with
+type ShareeResponse struct {
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+}
+
+// TaskCountersResponse holds the open task counts of the overview
+type TaskCountersResponse struct {
+	Pending int `json:"pending"`
+	Overdue int `json:"overdue"`
+	Shared  int `json:"shared"`
+}
+
+// TaskOverviewResponse is the body of GET /api/tasks/overview
+type TaskOverviewResponse struct {
+	Items      []TaskListItemResponse `json:"items"`
+	Counters   TaskCountersResponse   `json:"counters"`
+	NextCursor *string                `json:"next_cursor"` // null on the last page
+}
+
+// GetOverview handles GET /api/tasks/overview?cursor=&limit=, returning a page of the tasks the
+// user owns and of those shared with them, as the tasks page lists them, with the counters
+func (h *TaskOverviewHandler) GetOverview(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	limit, err := queryInt(r.URL.Query(), "limit")
+	if err != nil {
+		writeAPIError(w, r, http.StatusBadRequest, application.ErrInvalidTaskLimit.Error())
+		return
+	}
+	after, err := decodeTaskCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		writeAPIError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	overview, err := h.getOverview.Execute(r.Context(), userID, after, limit)
+	if err != nil {
+		if errors.Is(err, application.ErrInvalidTaskLimit) {
+			writeAPIError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Printf("task overview failed: %v", err)
+		writeAPIError(w, r, http.StatusInternalServerError, "Failed to list tasks")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(toTaskOverviewResponse(overview))
+}
+
+// toTaskOverviewResponse maps an overview to its JSON representation
+func toTaskOverviewResponse(overview *application.TaskOverview) TaskOverviewResponse {
+	response := TaskOverviewResponse{
+		Items: make([]TaskListItemResponse, 0, len(overview.Items)),
+		Counters: TaskCountersResponse{
+			Pending: overview.Counters.Pending,
+			Overdue: overview.Counters.Overdue,
+			Shared:  overview.Counters.Shared,
+		},
+	}
+	for _, item := range overview.Items {
+		sharees := make([]ShareeResponse, 0, len(item.SharedWith))
+		for _, sharee := range item.SharedWith {
+			sharees = append(sharees, ShareeResponse{UserID: sharee.UserID, Name: sharee.Name})
+		}
+		response.Items = append(response.Items, TaskListItemResponse{
+			TaskResponse: toTaskResponse(item.Task, item.OwnerName),
+			Owned:        item.Owned,
+			SharedWith:   sharees,
+		})
+	}
+	if overview.NextCursor != nil {
+		encoded := encodeTaskCursor(overview.NextCursor)
+		response.NextCursor = &encoded
+	}
+	return response
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestGetOverview(t *testing.T) {
+	created := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	next := &application.TaskCursor{CreatedAt: created, ID: "task-2"}
+	views := func(ctx context.Context, userID string) ([]*application.TaskView, error) {
+		return []*application.TaskView{
+			{
+				Task:       &application.Task{ID: "task-1", Title: "Relatório", Status: application.StatusPending, OwnerID: userID, CreatedAt: created},
+				OwnerName:  "Ana",
+				SharedWith: []application.Sharee{{UserID: "user-2", Name: "Bia"}},
+			},
+			{
+				Task:      &application.Task{ID: "task-2", Title: "Orçamento", Status: application.StatusPending, OwnerID: "user-456", CreatedAt: created},
+				OwnerName: "Davi",
+			},
+		}, nil
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		err        error
+		wantStatus int
+		wantAfter  *application.TaskCursor
+	}{
+		{"first page", "", nil, http.StatusOK, nil},
+		{"page after a cursor", "?limit=2&cursor=" + encodeTaskCursor(next), nil, http.StatusOK, next},
+		{"invalid cursor", "?cursor=not-a-cursor", nil, http.StatusBadRequest, nil},
+		{"invalid limit", "?limit=many", nil, http.StatusBadRequest, nil},
+		{"limit out of range", "?limit=1000", application.ErrInvalidTaskLimit, http.StatusBadRequest, nil},
+		{"query failure", "", errors.New("db down"), http.StatusInternalServerError, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overview := &mockGetTaskOverviewUseCase{
+				executeFunc: func(ctx context.Context, userID string) ([]*application.TaskView, error) {
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return views(ctx, userID)
+				},
+				counters: application.TaskCounters{Pending: 1, Overdue: 0, Shared: 1},
+				next:     next,
+			}
+			h := NewTaskOverviewHandler(overview)
+
+			w := httptest.NewRecorder()
+			h.GetOverview(w, withUser(httptest.NewRequest("GET", "/api/tasks/overview"+tt.query, nil)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if (overview.after == nil) != (tt.wantAfter == nil) || (tt.wantAfter != nil && *overview.after != *tt.wantAfter) {
+				t.Errorf("after = %+v, want %+v", overview.after, tt.wantAfter)
+			}
+
+			var response TaskOverviewResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(response.Items) != 2 || !response.Items[0].Owned || response.Items[1].Owned {
+				t.Fatalf("items = %+v, want the owned task and the shared one", response.Items)
+			}
+			if response.Items[0].SharedWith[0].Name != "Bia" || response.Items[1].OwnerName != "Davi" || response.Items[1].SharedWith == nil {
+				t.Errorf("items = %+v, want the sharees and owner names", response.Items)
+			}
+			if response.Counters != (TaskCountersResponse{Pending: 1, Shared: 1}) {
+				t.Errorf("counters = %+v, want the counters of the overview", response.Counters)
+			}
+			if response.NextCursor == nil || *response.NextCursor != encodeTaskCursor(next) {
+				t.Errorf("next_cursor = %v, want %s", response.NextCursor, encodeTaskCursor(next))
+			}
+		})
+	}
+}
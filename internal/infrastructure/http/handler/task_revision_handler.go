@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// TaskRevisionHandler handles HTTP requests for the previous versions of a task
+type TaskRevisionHandler struct {
+	listRevisions usecases.ListTaskRevisionsUseCaseInterface
+	pages         *PageTemplates
+	location      *time.Location
+}
+
+// NewTaskRevisionHandler creates a new TaskRevisionHandler
+func NewTaskRevisionHandler(listRevisions usecases.ListTaskRevisionsUseCaseInterface, pages *PageTemplates, location *time.Location) *TaskRevisionHandler {
+	return &TaskRevisionHandler{listRevisions: listRevisions, pages: pages, location: location}
+}
+
+// TaskRevisionResponse is the JSON representation of a previous version of a task. EditedBy is
+// the user whose edit replaced it.
+type TaskRevisionResponse struct {
+	ID          int64     `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Status      string    `json:"status"`
+	EditedBy    string    `json:"edited_by"`
+	EditorName  string    `json:"editor_name,omitempty"`
+	ReplacedAt  time.Time `json:"replaced_at"`
+}
+
+// ListRevisions handles GET /api/tasks/{id}/revisions, listing the previous versions of the task,
+// newest first
+func (h *TaskRevisionHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	history, err := h.listRevisions.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		status, message := taskRevisionErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	response := make([]TaskRevisionResponse, 0, len(history.Revisions))
+	for _, revision := range history.Revisions {
+		response = append(response, TaskRevisionResponse{
+			ID:          revision.ID,
+			Title:       revision.Title,
+			Description: revision.Description,
+			Status:      string(revision.Status),
+			EditedBy:    revision.EditedBy,
+			EditorName:  revision.EditorName,
+			ReplacedAt:  revision.ReplacedAt,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// WebListRevisions handles GET /web/tasks/{id}/revisions, rendering the panel with the edits of
+// the task and what each one changed
+func (h *TaskRevisionHandler) WebListRevisions(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	locale := RequestLocale(r)
+	loc := requestLocation(r, h.location)
+
+	history, err := h.listRevisions.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		status, message := taskRevisionErrorStatus(err)
+		h.pages.writeWebError(w, r, status, i18n.Error(locale, message))
+		return
+	}
+
+	list := taskRevisionList{Title: history.Task.Title}
+	for _, change := range history.Changes() {
+		// A version records the edit that replaced it, who made it and when
+		item := taskRevisionItem{
+			EditorName:  change.Before.EditorName,
+			EditedAt:    formatDateTime(locale, change.Before.ReplacedAt.In(loc)),
+			Title:       renderWordDiff(change.Before.Title, change.After.Title),
+			Description: renderWordDiff(change.Before.Description, change.After.Description),
+		}
+		if change.Before.Status != change.After.Status {
+			item.StatusBefore = taskStatusLabel(locale, change.Before.Status)
+			item.StatusAfter = taskStatusLabel(locale, change.After.Status)
+		}
+		list.Changes = append(list.Changes, item)
+	}
+	html, err := renderTaskRevisions(list, locale)
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, i18n.Error(locale, "Internal server error"))
+		return
+	}
+	h.pages.writeWebFragment(w, r, http.StatusOK, html)
+}
+
+// taskStatusLabel returns the localized label of a task status
+func taskStatusLabel(locale application.Locale, status application.TaskStatus) string {
+	return i18n.T(locale, "task.status."+string(status))
+}
+
+// taskRevisionErrorStatus maps a revision use case error to an HTTP status and client message
+func taskRevisionErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecases.ErrTaskUnavailable):
+		return http.StatusNotFound, err.Error()
+	default:
+		log.Printf("listing task revisions failed: %v", err)
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
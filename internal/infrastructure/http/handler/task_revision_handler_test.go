@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockListTaskRevisionsUseCase struct {
+	history *application.TaskHistory
+	err     error
+}
+
+func (m *mockListTaskRevisionsUseCase) Execute(ctx context.Context, taskID, userID string) (*application.TaskHistory, error) {
+	return m.history, m.err
+}
+
+// newTaskHistory returns a task edited twice: Bia changed its title, then Ana completed it
+func newTaskHistory() *application.TaskHistory {
+	task, _ := application.NewTask("task-1", "Enviar relatório anual", "Revisar <anexos>", application.StatusCompleted, "user-1", "")
+	replacedAt := time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+	return &application.TaskHistory{Task: task, Revisions: []*application.TaskRevision{
+		{ID: 2, TaskID: "task-1", Title: "Enviar relatório anual", Description: "Revisar <anexos>", Status: application.StatusPending, EditedBy: "user-1", EditorName: "Ana", ReplacedAt: replacedAt.Add(time.Hour)},
+		{ID: 1, TaskID: "task-1", Title: "Enviar relatório mensal", Description: "Revisar <anexos>", Status: application.StatusPending, EditedBy: "user-bia", EditorName: "Bia", ReplacedAt: replacedAt},
+	}}
+}
+
+func TestListRevisions(t *testing.T) {
+	tests := []struct {
+		name       string
+		list       *mockListTaskRevisionsUseCase
+		wantStatus int
+		wantIDs    []int64
+	}{
+		{name: "revisions", list: &mockListTaskRevisionsUseCase{history: newTaskHistory()}, wantStatus: http.StatusOK, wantIDs: []int64{2, 1}},
+		{name: "never edited", list: &mockListTaskRevisionsUseCase{history: &application.TaskHistory{Task: &application.Task{ID: "task-1"}}}, wantStatus: http.StatusOK, wantIDs: []int64{}},
+		{name: "task not visible", list: &mockListTaskRevisionsUseCase{err: usecases.ErrTaskUnavailable}, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewTaskRevisionHandler(tt.list, NewPageTemplates(""), time.Local)
+			req := httptest.NewRequest("GET", "/api/tasks/task-1/revisions", nil)
+			req.SetPathValue("id", "task-1")
+
+			w := httptest.NewRecorder()
+			h.ListRevisions(w, withUser(req))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantIDs == nil {
+				return
+			}
+			var revisions []TaskRevisionResponse
+			if err := json.NewDecoder(w.Body).Decode(&revisions); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if len(revisions) != len(tt.wantIDs) {
+				t.Fatalf("got %d revisions, want %d", len(revisions), len(tt.wantIDs))
+			}
+			for i, id := range tt.wantIDs {
+				if revisions[i].ID != id {
+					t.Errorf("revision %d = %d, want %d", i, revisions[i].ID, id)
+				}
+			}
+			if len(revisions) > 0 && (revisions[1].Title != "Enviar relatório mensal" || revisions[1].EditorName != "Bia" || revisions[1].Status != "pending") {
+				t.Errorf("oldest revision = %+v", revisions[1])
+			}
+		})
+	}
+}
+
+func TestWebListRevisions(t *testing.T) {
+	tests := []struct {
+		name       string
+		list       *mockListTaskRevisionsUseCase
+		wantStatus int
+		want       []string
+		notWant    []string
+	}{
+		{
+			name:       "edits",
+			list:       &mockListTaskRevisionsUseCase{history: newTaskHistory()},
+			wantStatus: http.StatusOK,
+			want: []string{
+				`role="dialog"`,
+				"Ana, 10/03/2026",
+				`>Pendente</del> → <ins`, `>Concluída</ins>`,
+				"Enviar relatório <del", `>mensal</del><ins`, `>anual</ins>`,
+			},
+			// Neither edit changed the description
+			notWant: []string{"&lt;anexos&gt;", "<anexos>"},
+		},
+		{
+			name:       "never edited",
+			list:       &mockListTaskRevisionsUseCase{history: &application.TaskHistory{Task: &application.Task{ID: "task-1"}}},
+			wantStatus: http.StatusOK,
+			want:       []string{"Esta tarefa ainda não foi editada."},
+		},
+		{name: "task not visible", list: &mockListTaskRevisionsUseCase{err: usecases.ErrTaskUnavailable}, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewTaskRevisionHandler(tt.list, NewPageTemplates(""), time.Local)
+			req := htmx(httptest.NewRequest("GET", "/web/tasks/task-1/revisions", nil))
+			req.SetPathValue("id", "task-1")
+
+			w := httptest.NewRecorder()
+			h.WebListRevisions(w, withUser(req))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(w.Body.String(), want) {
+					t.Errorf("panel is missing %q: %s", want, w.Body.String())
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(w.Body.String(), notWant) {
+					t.Errorf("panel has %q: %s", notWant, w.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRenderWordDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want string
+	}{
+		{"unchanged", "Relatório", "Relatório", ""},
+		{"escaped", "a <b>", "a <i>", `a <del class="bg-red-100 text-red-800 dark:bg-red-900 dark:text-red-200">&lt;b&gt;</del><ins class="no-underline bg-green-100 text-green-800 dark:bg-green-900 dark:text-green-200">&lt;i&gt;</ins>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(renderWordDiff(tt.old, tt.new)); got != tt.want {
+				t.Errorf("renderWordDiff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
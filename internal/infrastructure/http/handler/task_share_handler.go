@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// TaskShareHandler handles the panel where the owner of a task manages who it is shared with
+type TaskShareHandler struct {
+	listShares  usecases.ListTaskSharesUseCaseInterface
+	unshareTask usecases.UnshareTaskUseCaseInterface
+	pages       *PageTemplates
+	location    *time.Location
+}
+
+// NewTaskShareHandler creates a new TaskShareHandler
+func NewTaskShareHandler(listShares usecases.ListTaskSharesUseCaseInterface, unshareTask usecases.UnshareTaskUseCaseInterface, pages *PageTemplates, location *time.Location) *TaskShareHandler {
+	return &TaskShareHandler{
+		listShares:  listShares,
+		unshareTask: unshareTask,
+		pages:       pages,
+		location:    location,
+	}
+}
+
+// TaskShareResponse is the JSON representation of a user a task is shared with
+type TaskShareResponse struct {
+	UserID   string    `json:"user_id"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	SharedAt time.Time `json:"shared_at"`
+}
+
+// ListShares handles GET /api/tasks/{id}/shares, listing the users the task is shared with in
+// the order they accepted. In JSON:API documents they are users resources, which the shares
+// relationship of the task refers to.
+func (h *TaskShareHandler) ListShares(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	taskID := r.PathValue("id")
+
+	users, err := h.listShares.Execute(r.Context(), taskID, userID)
+	if err != nil {
+		status, message := taskShareErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	response := make([]TaskShareResponse, 0, len(users))
+	for _, user := range users {
+		response = append(response, TaskShareResponse{
+			UserID:   user.UserID,
+			Name:     user.Name,
+			Email:    user.Email,
+			SharedAt: user.SharedAt,
+		})
+	}
+
+	if jsonAPIRequested(w, r) {
+		resources := make([]JSONAPIResource, 0, len(response))
+		for _, share := range response {
+			resources = append(resources, JSONAPIResource{
+				Type:       "users",
+				ID:         share.UserID,
+				Attributes: jsonAPIAttributes(share, "user_id"),
+			})
+		}
+		writeJSONAPI(w, http.StatusOK, JSONAPIDocument{
+			Data: resources,
+			Links: map[string]string{
+				"self":    taskResourceURL(taskID) + "/shares",
+				"related": taskResourceURL(taskID),
+			},
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// WebListShares handles GET /web/tasks/{id}/shares, rendering the panel with the users the task
+// is shared with
+func (h *TaskShareHandler) WebListShares(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	taskID := r.PathValue("id")
+	locale := RequestLocale(r)
+	loc := requestLocation(r, h.location)
+
+	users, err := h.listShares.Execute(r.Context(), taskID, userID)
+	if err != nil {
+		h.writeWebError(w, r, err)
+		return
+	}
+
+	list := taskShareList{TaskID: taskID}
+	for _, user := range users {
+		list.Users = append(list.Users, taskShareItem{
+			UserID:   user.UserID,
+			Name:     user.Name,
+			Email:    user.Email,
+			SharedAt: formatDateTime(locale, user.SharedAt.In(loc)),
+		})
+	}
+	html, err := renderTaskShares(list, locale)
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, i18n.Error(locale, "Internal server error"))
+		return
+	}
+	h.pages.writeWebFragment(w, r, http.StatusOK, html)
+}
+
+// WebUnshare handles DELETE /web/tasks/{id}/shares/{userID}, replacing the user in the share
+// panel with a confirmation
+func (h *TaskShareHandler) WebUnshare(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := h.unshareTask.Execute(r.Context(), r.PathValue("id"), userID, r.PathValue("userID")); err != nil {
+		h.writeWebError(w, r, err)
+		return
+	}
+
+	message := i18n.T(RequestLocale(r), "shares.removed")
+	h.pages.writeWebFragment(w, r, http.StatusOK, `<li role="status" class="py-3 text-sm text-gray-500 dark:text-gray-400">`+template.HTMLEscapeString(message)+`</li>`)
+}
+
+// writeWebError writes the error of a share management use case, localized
+func (h *TaskShareHandler) writeWebError(w http.ResponseWriter, r *http.Request, err error) {
+	status, message := taskShareErrorStatus(err)
+	h.pages.writeWebError(w, r, status, i18n.Error(RequestLocale(r), message))
+}
+
+// taskShareErrorStatus maps a share management use case error to an HTTP status and client message
+func taskShareErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecases.ErrTaskUnavailable):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, usecases.ErrSharePermissionDenied):
+		return http.StatusForbidden, err.Error()
+	default:
+		log.Printf("share management failed: %v", err)
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
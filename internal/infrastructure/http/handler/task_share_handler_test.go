@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockListTaskSharesUseCase struct {
+	users []*application.SharedUser
+	err   error
+}
+
+func (m *mockListTaskSharesUseCase) Execute(ctx context.Context, taskID, userID string) ([]*application.SharedUser, error) {
+	return m.users, m.err
+}
+
+type mockUnshareTaskUseCase struct {
+	unshared string
+	err      error
+}
+
+func (m *mockUnshareTaskUseCase) Execute(ctx context.Context, taskID, ownerID, userID string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.unshared = userID
+	return nil
+}
+
+func TestWebListShares(t *testing.T) {
+	sharedAt := time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+	users := []*application.SharedUser{{UserID: "user-bia", Name: "Bia <Souza>", Email: "bia@example.com", SharedAt: sharedAt}}
+
+	tests := []struct {
+		name       string
+		list       *mockListTaskSharesUseCase
+		wantStatus int
+		want       []string
+	}{
+		{
+			name:       "shared users",
+			list:       &mockListTaskSharesUseCase{users: users},
+			wantStatus: http.StatusOK,
+			want:       []string{`role="dialog"`, "Bia &lt;Souza&gt;", "bia@example.com", "Desde 10/03/2026", `hx-delete="/web/tasks/task-1/shares/user-bia"`},
+		},
+		{
+			name:       "not shared",
+			list:       &mockListTaskSharesUseCase{},
+			wantStatus: http.StatusOK,
+			want:       []string{"Esta tarefa não está compartilhada com ninguém."},
+		},
+		{name: "not the owner", list: &mockListTaskSharesUseCase{err: usecases.ErrSharePermissionDenied}, wantStatus: http.StatusForbidden},
+		{name: "task not visible", list: &mockListTaskSharesUseCase{err: usecases.ErrTaskUnavailable}, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewTaskShareHandler(tt.list, &mockUnshareTaskUseCase{}, NewPageTemplates(""), time.Local)
+			req := htmx(httptest.NewRequest("GET", "/web/tasks/task-1/shares", nil))
+			req.SetPathValue("id", "task-1")
+
+			w := httptest.NewRecorder()
+			h.WebListShares(w, withUser(req))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(w.Body.String(), want) {
+					t.Errorf("panel is missing %q: %s", want, w.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestListShares(t *testing.T) {
+	sharedAt := time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+	users := []*application.SharedUser{{UserID: "user-bia", Name: "Bia", Email: "bia@example.com", SharedAt: sharedAt}}
+
+	tests := []struct {
+		name            string
+		list            *mockListTaskSharesUseCase
+		accept          string
+		wantStatus      int
+		wantContentType string
+		want            string
+	}{
+		{
+			name:            "shared users",
+			list:            &mockListTaskSharesUseCase{users: users},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			want:            `[{"user_id":"user-bia","name":"Bia","email":"bia@example.com","shared_at":"2026-03-10T09:30:00Z"}]`,
+		},
+		{
+			name:            "not shared",
+			list:            &mockListTaskSharesUseCase{},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			want:            `[]`,
+		},
+		{
+			name:            "JSON:API",
+			list:            &mockListTaskSharesUseCase{users: users},
+			accept:          JSONAPIMediaType,
+			wantStatus:      http.StatusOK,
+			wantContentType: JSONAPIMediaType,
+			want:            `{"data":[{"type":"users","id":"user-bia","attributes":{"email":"bia@example.com","name":"Bia","shared_at":"2026-03-10T09:30:00Z"}}],"links":{"related":"/api/tasks/task-1","self":"/api/tasks/task-1/shares"}}`,
+		},
+		{name: "not the owner", list: &mockListTaskSharesUseCase{err: usecases.ErrSharePermissionDenied}, wantStatus: http.StatusForbidden},
+		{name: "task not visible", list: &mockListTaskSharesUseCase{err: usecases.ErrTaskUnavailable}, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewTaskShareHandler(tt.list, &mockUnshareTaskUseCase{}, NewPageTemplates(""), time.Local)
+			req := httptest.NewRequest("GET", "/api/tasks/task-1/shares", nil)
+			req.SetPathValue("id", "task-1")
+			req.Header.Set("Accept", tt.accept)
+
+			w := httptest.NewRecorder()
+			h.ListShares(w, withUser(req))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.want == "" {
+				return
+			}
+			if contentType := w.Header().Get("Content-Type"); contentType != tt.wantContentType {
+				t.Errorf("Content-Type = %q, want %q", contentType, tt.wantContentType)
+			}
+			if body := strings.TrimSpace(w.Body.String()); body != tt.want {
+				t.Errorf("body = %s, want %s", body, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebUnshare(t *testing.T) {
+	unshare := &mockUnshareTaskUseCase{}
+	h := NewTaskShareHandler(&mockListTaskSharesUseCase{}, unshare, NewPageTemplates(""), time.Local)
+	req := htmx(httptest.NewRequest("DELETE", "/web/tasks/task-1/shares/user-bia", nil))
+	req.SetPathValue("id", "task-1")
+	req.SetPathValue("userID", "user-bia")
+
+	w := httptest.NewRecorder()
+	h.WebUnshare(w, withUser(req))
+
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "Acesso removido.") {
+		t.Errorf("status = %d, body = %s; want the confirmation", w.Code, w.Body.String())
+	}
+	if unshare.unshared != "user-bia" {
+		t.Errorf("unshared %q, want user-bia", unshare.unshared)
+	}
+}
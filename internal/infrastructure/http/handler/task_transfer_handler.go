@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+var (
+	errTransferOwnerMissing = errors.New("new_owner_id is required")
+	errTransferNotConfirmed = errors.New("transfer must be confirmed with \"confirm\": true")
+)
+
+// TaskTransferHandler handles owners transferring their tasks to another user
+type TaskTransferHandler struct {
+	transferTask usecases.TransferTaskUseCaseInterface
+	ownerNames   usecases.GetOwnerNamesUseCaseInterface
+}
+
+// NewTaskTransferHandler creates a new TaskTransferHandler
+func NewTaskTransferHandler(transferTask usecases.TransferTaskUseCaseInterface, ownerNames usecases.GetOwnerNamesUseCaseInterface) *TaskTransferHandler {
+	return &TaskTransferHandler{
+		transferTask: transferTask,
+		ownerNames:   ownerNames,
+	}
+}
+
+// TransferTaskRequest is the body of POST /api/tasks/{id}/transfer. Confirm must be set, and
+// completed tasks also need force.
+type TransferTaskRequest struct {
+	NewOwnerID string `json:"new_owner_id"`
+	Confirm    bool   `json:"confirm"`
+	Force      bool   `json:"force"`
+}
+
+// Transfer handles POST /api/tasks/{id}/transfer, making a user the task is shared with its
+// owner. The previous owner keeps access as a sharee.
+func (h *TaskTransferHandler) Transfer(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req TransferTaskRequest
+	if !decodeJSON(w, r, &req, maxJSONBodySize) {
+		return
+	}
+	switch {
+	case req.NewOwnerID == "":
+		writeAPIError(w, r, http.StatusBadRequest, errTransferOwnerMissing.Error())
+		return
+	case !req.Confirm:
+		writeAPIError(w, r, http.StatusBadRequest, errTransferNotConfirmed.Error())
+		return
+	}
+
+	task, err := h.transferTask.Execute(r.Context(), r.PathValue("id"), userID, req.NewOwnerID, req.Force)
+	if err != nil {
+		status, message := transferErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	view := withOwnerName(r.Context(), h.ownerNames, task)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toTaskResponse(view.Task, view.OwnerName))
+}
+
+// transferErrorStatus maps a transfer error to an HTTP status and client message
+func transferErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecases.ErrTaskUnavailable):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, usecases.ErrTransferPermissionDenied):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, application.ErrCannotTransferToSelf), errors.Is(err, usecases.ErrTransferTargetNotSharee):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, application.ErrTransferCompletedTask):
+		return http.StatusConflict, err.Error()
+	default:
+		log.Printf("task transfer failed: %v", err)
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
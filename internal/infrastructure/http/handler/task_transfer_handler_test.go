@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockTransferTaskUseCase struct {
+	newOwnerID string
+	force      bool
+	err        error
+}
+
+func (m *mockTransferTaskUseCase) Execute(ctx context.Context, taskID, ownerID, newOwnerID string, force bool) (*application.Task, error) {
+	m.newOwnerID, m.force = newOwnerID, force
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &application.Task{ID: taskID, OwnerID: newOwnerID, Title: "Relatório"}, nil
+}
+
+func TestTaskTransferHandler_Transfer(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{"confirmed", `{"new_owner_id":"other-user","confirm":true}`, nil, http.StatusOK},
+		{"forced", `{"new_owner_id":"other-user","confirm":true,"force":true}`, nil, http.StatusOK},
+		{"not confirmed", `{"new_owner_id":"other-user"}`, nil, http.StatusBadRequest},
+		{"no new owner", `{"confirm":true}`, nil, http.StatusBadRequest},
+		{"unknown field", `{"new_owner_id":"other-user","confirm":true,"owner":"x"}`, nil, http.StatusBadRequest},
+		{"to the owner", `{"new_owner_id":"user-123","confirm":true}`, application.ErrCannotTransferToSelf, http.StatusBadRequest},
+		{"to a stranger", `{"new_owner_id":"stranger","confirm":true}`, usecases.ErrTransferTargetNotSharee, http.StatusBadRequest},
+		{"completed task", `{"new_owner_id":"other-user","confirm":true}`, application.ErrTransferCompletedTask, http.StatusConflict},
+		{"sharee", `{"new_owner_id":"other-user","confirm":true}`, usecases.ErrTransferPermissionDenied, http.StatusForbidden},
+		{"unknown task", `{"new_owner_id":"other-user","confirm":true}`, usecases.ErrTaskUnavailable, http.StatusNotFound},
+		{"storage failure", `{"new_owner_id":"other-user","confirm":true}`, errors.New("disk full"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transferTask := &mockTransferTaskUseCase{err: tt.err}
+			h := NewTaskTransferHandler(transferTask, &mockGetOwnerNamesUseCase{})
+
+			req := httptest.NewRequest("POST", "/api/tasks/task-1/transfer", strings.NewReader(tt.body))
+			req.SetPathValue("id", "task-1")
+			w := httptest.NewRecorder()
+			h.Transfer(w, withUser(req))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp TaskResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.OwnerID != "other-user" || resp.OwnerName != "Other User" {
+				t.Errorf("response = %+v, want the task owned by Other User", resp)
+			}
+			if transferTask.force != strings.Contains(tt.body, `"force":true`) {
+				t.Errorf("force = %v, want it from the body", transferTask.force)
+			}
+		})
+	}
+}
@@ -0,0 +1,376 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/cache"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/markdown"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// warmUpTimeout bounds how long a background pre-render may take
+const warmUpTimeout = 5 * time.Second
+
+// TaskListWarmer pre-renders the task list of the user who owns a freshly issued token, in the locale the page will be requested in
+type TaskListWarmer interface {
+	WarmUp(token string, locale application.Locale)
+}
+
+// TokenValidator validates auth tokens and returns their claims
+type TokenValidator interface {
+	ValidateToken(token string) (*service.JWTClaims, error)
+}
+
+// TasksPageHandler renders the tasks page, optionally serving pre-rendered copies from a short-lived cache
+type TasksPageHandler struct {
+	getOverview     usecases.GetTaskOverviewUseCaseInterface
+	listTaskViews   usecases.ListTaskViewsUseCaseInterface
+	listProjects    usecases.ListProjectsUseCaseInterface
+	listBrokenLinks usecases.ListBrokenLinksUseCaseInterface
+	listAttachments usecases.ListOwnerAttachmentsUseCaseInterface
+	listBlockers    usecases.ListOpenBlockersUseCaseInterface
+	listTaskTimes   usecases.ListTaskTimesUseCaseInterface
+	getTheme        usecases.GetUserThemeUseCaseInterface
+	pageCache       *cache.TTL[[]byte]
+	tokens          TokenValidator
+	pages           *PageTemplates
+}
+
+// NewTasksPageHandler creates a new TasksPageHandler. A nil pageCache disables pre-rendering.
+func NewTasksPageHandler(
+	getOverview usecases.GetTaskOverviewUseCaseInterface,
+	listTaskViews usecases.ListTaskViewsUseCaseInterface,
+	listProjects usecases.ListProjectsUseCaseInterface,
+	listBrokenLinks usecases.ListBrokenLinksUseCaseInterface,
+	listAttachments usecases.ListOwnerAttachmentsUseCaseInterface,
+	listBlockers usecases.ListOpenBlockersUseCaseInterface,
+	listTaskTimes usecases.ListTaskTimesUseCaseInterface,
+	getTheme usecases.GetUserThemeUseCaseInterface,
+	pageCache *cache.TTL[[]byte],
+	tokens TokenValidator,
+	pages *PageTemplates,
+) *TasksPageHandler {
+	return &TasksPageHandler{
+		getOverview:     getOverview,
+		listTaskViews:   listTaskViews,
+		listProjects:    listProjects,
+		listBrokenLinks: listBrokenLinks,
+		listAttachments: listAttachments,
+		listBlockers:    listBlockers,
+		listTaskTimes:   listTaskTimes,
+		getTheme:        getTheme,
+		pageCache:       pageCache,
+		tokens:          tokens,
+		pages:           pages,
+	}
+}
+
+// TasksPage handles GET /tasks
+func (h *TasksPageHandler) TasksPage(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok || userID == "" {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	locale := RequestLocale(r)
+
+	// Project and org pages are rendered on every request: only the default page of the
+	// personal workspace is pre-rendered
+	projectID := r.URL.Query().Get("project")
+	if orgID, _ := repository.OrgScope(r.Context()); projectID != "" || orgID != "" {
+		page, err := h.render(r.Context(), userID, projectID, locale)
+		if err != nil {
+			if errors.Is(err, application.ErrProjectNotFound) {
+				http.NotFound(w, r)
+				return
+			}
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+		return
+	}
+
+	page, cached := h.cached(userID, locale)
+	if !cached {
+		var err error
+		page, err = h.Render(r.Context(), userID, locale)
+		if err != nil {
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		h.store(userID, locale, page)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(page)
+}
+
+// Render renders the full tasks page of a user in a locale
+func (h *TasksPageHandler) Render(ctx context.Context, userID string, locale application.Locale) ([]byte, error) {
+	return h.render(ctx, userID, "", locale)
+}
+
+// render renders the tasks page of a user, listing the tasks of a project when projectID is set
+func (h *TasksPageHandler) render(ctx context.Context, userID, projectID string, locale application.Locale) ([]byte, error) {
+	projects, err := h.listProjects.Execute(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var project *application.Project
+	if projectID != "" {
+		project = findProject(projects, projectID)
+		if project == nil {
+			return nil, application.ErrProjectNotFound
+		}
+	}
+
+	data, counters, err := h.cardsData(ctx, userID, projectID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The preferred theme is rendered into the page so it doesn't flash the light theme on load
+	theme, err := h.getTheme.Execute(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := h.parse(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	data["Title"] = i18n.T(locale, "title.tasks")
+	data["Projects"] = projects
+	data["Project"] = project
+	data["Theme"] = string(theme)
+	// The counters come with the overview, so the navbar doesn't have to load them on its own
+	if counters != nil {
+		fragment, err := renderTaskCounters(counters, locale)
+		if err != nil {
+			return nil, err
+		}
+		data["Counters"] = template.HTML(fragment)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, PageData(locale, data)); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// TaskCards handles GET /web/tasks?cursor=&project=, rendering the task cards after the
+// cursor for the infinite scroll of the tasks page. The page ends with the sentinel loading
+// the next one when it's revealed, unless it's the last.
+func (h *TasksPageHandler) TaskCards(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	locale := RequestLocale(r)
+
+	after, err := decodeTaskCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, _, err := h.cardsData(r.Context(), userID, r.URL.Query().Get("project"), after)
+	if err != nil {
+		if errors.Is(err, application.ErrProjectNotFound) {
+			h.pages.writeWebError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Failed to list tasks")
+		return
+	}
+
+	tmpl, err := h.parse(locale)
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "task-cards", data); err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.pages.writeWebFragment(w, r, http.StatusOK, buf.String())
+}
+
+// cardsData loads a page of the task cards of a user, after the cursor, with what the cards
+// show about each task. Without a project the page mixes the tasks the user owns with those
+// shared with them, and comes with the navbar counters, nil otherwise.
+func (h *TasksPageHandler) cardsData(ctx context.Context, userID, projectID string, after *application.TaskCursor) (map[string]interface{}, *application.TaskCounters, error) {
+	// Views carry who each task is shared with, loaded in the same query as the tasks
+	var page *application.TaskPage
+	var counters *application.TaskCounters
+	if projectID == "" {
+		overview, err := h.getOverview.Execute(ctx, userID, after, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		page = &application.TaskPage{Views: overview.Views(), NextCursor: overview.NextCursor}
+		counters = &overview.Counters
+	} else {
+		var err error
+		if page, err = h.listTaskViews.Execute(ctx, userID, projectID, after, 0); err != nil {
+			return nil, nil, err
+		}
+	}
+	tasks := application.TasksOf(page.Views)
+
+	brokenLinks, err := h.listBrokenLinks.Execute(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachments, err := h.listAttachments.Execute(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blockers, err := h.listBlockers.Execute(ctx, tasks)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	taskTimes, err := h.listTaskTimes.Execute(ctx, userID, tasks)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return map[string]interface{}{
+		"Tasks":       page.Views,
+		"NextPage":    nextTaskCardsURL(page, projectID),
+		"UserID":      userID,
+		"BrokenLinks": brokenLinks,
+		"Attachments": attachments,
+		"Blockers":    blockers,
+		"TaskTimes":   taskTimes,
+	}, counters, nil
+}
+
+// parse parses the tasks page templates in a locale
+func (h *TasksPageHandler) parse(locale application.Locale) (*template.Template, error) {
+	return h.pages.Page(locale, template.FuncMap{
+		"markdown": markdown.Render,
+		"attachments": func(taskID string, list []*application.Attachment, canEdit bool) template.HTML {
+			return RenderAttachmentList(taskID, list, canEdit, "", locale)
+		},
+		"blockedBy": func(blockers []*application.Task) string {
+			return blockedByText(blockers, locale)
+		},
+		"sharedWith": func(view *application.TaskView) string {
+			return sharedWithText(view, locale)
+		},
+		"timer": func(taskID string, taskTime *application.TaskTime) template.HTML {
+			return renderTaskTimer(taskID, taskTime, locale)
+		},
+		"colorClass": func(color application.TaskColor) string {
+			class, _ := colorBorder(color, locale)
+			return class
+		},
+		"colorText": func(color application.TaskColor) string {
+			_, text := colorBorder(color, locale)
+			return text
+		},
+	},
+		"base.html", "tasks.html",
+	)
+}
+
+// nextTaskCardsURL returns the URL of the task cards after a page, or "" on the last page
+func nextTaskCardsURL(page *application.TaskPage, projectID string) string {
+	if page.NextCursor == nil {
+		return ""
+	}
+	values := url.Values{"cursor": {encodeTaskCursor(page.NextCursor)}}
+	if projectID != "" {
+		values.Set("project", projectID)
+	}
+	return "/web/tasks?" + values.Encode()
+}
+
+// WarmUp pre-renders the tasks page in the background so the first GET /tasks after login is served from cache
+func (h *TasksPageHandler) WarmUp(token string, locale application.Locale) {
+	if h.pageCache == nil || h.tokens == nil {
+		return
+	}
+
+	claims, err := h.tokens.ValidateToken(token)
+	if err != nil {
+		return
+	}
+
+	go func(userID string) {
+		ctx, cancel := context.WithTimeout(repository.WithOrgScope(context.Background(), ""), warmUpTimeout)
+		defer cancel()
+
+		page, err := h.Render(ctx, userID, locale)
+		if err != nil {
+			log.Printf("tasks page warm-up failed: %v", err)
+			return
+		}
+		h.store(userID, locale, page)
+	}(claims.UserID)
+}
+
+// Invalidate drops the pre-rendered pages of a user, in every locale
+func (h *TasksPageHandler) Invalidate(userID string) {
+	if h.pageCache == nil {
+		return
+	}
+	for _, locale := range i18n.Supported() {
+		h.pageCache.Delete(pageCacheKey(userID, locale))
+	}
+}
+
+// cached returns the pre-rendered page of a user in a locale, if any
+func (h *TasksPageHandler) cached(userID string, locale application.Locale) ([]byte, bool) {
+	if h.pageCache == nil {
+		return nil, false
+	}
+	return h.pageCache.Get(pageCacheKey(userID, locale))
+}
+
+// store saves a rendered page of a user in the cache
+func (h *TasksPageHandler) store(userID string, locale application.Locale, page []byte) {
+	if h.pageCache != nil {
+		h.pageCache.Set(pageCacheKey(userID, locale), page)
+	}
+}
+
+// findProject returns the project with the given ID among the owned and shared ones, if any
+func findProject(projects *usecases.ProjectList, projectID string) *application.Project {
+	for _, list := range [][]*application.Project{projects.Owned, projects.Shared} {
+		for _, project := range list {
+			if project.ID == projectID {
+				return project
+			}
+		}
+	}
+	return nil
+}
+
+// pageCacheKey is the cache key of the page of a user in a locale
+func pageCacheKey(userID string, locale application.Locale) string {
+	return userID + "|" + string(locale)
+}
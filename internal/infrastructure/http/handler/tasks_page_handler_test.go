@@ -0,0 +1,623 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/cache"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// =============================================================================
+// Mocks
+// =============================================================================
+
+type mockListBrokenLinksUseCase struct {
+	links map[string][]string
+}
+
+func (m *mockListBrokenLinksUseCase) Execute(ctx context.Context, ownerID string) (map[string][]string, error) {
+	return m.links, nil
+}
+
+type mockListOwnerAttachmentsUseCase struct {
+	attachments map[string][]*application.Attachment
+}
+
+func (m *mockListOwnerAttachmentsUseCase) Execute(ctx context.Context, ownerID string) (map[string][]*application.Attachment, error) {
+	return m.attachments, nil
+}
+
+type mockListOpenBlockersUseCase struct {
+	blockers map[string][]*application.Task
+}
+
+func (m *mockListOpenBlockersUseCase) Execute(ctx context.Context, tasks []*application.Task) (map[string][]*application.Task, error) {
+	return m.blockers, nil
+}
+
+type mockListTaskTimesUseCase struct {
+	taskTimes map[string]*application.TaskTime
+}
+
+func (m *mockListTaskTimesUseCase) Execute(ctx context.Context, userID string, tasks []*application.Task) (map[string]*application.TaskTime, error) {
+	return m.taskTimes, nil
+}
+
+type mockListTaskViewsUseCase struct {
+	executeFunc func(ctx context.Context, userID, projectID string) ([]*application.TaskView, error)
+	next        *application.TaskCursor // cursor of the page after the listed views
+	after       *application.TaskCursor // the last cursor asked for
+}
+
+func (m *mockListTaskViewsUseCase) Execute(ctx context.Context, userID, projectID string, after *application.TaskCursor, limit int) (*application.TaskPage, error) {
+	m.after = after
+	views, err := m.executeFunc(ctx, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return &application.TaskPage{Views: views, Page: 1, PerPage: limit, NextCursor: m.next}, nil
+}
+
+// mockGetTaskOverviewUseCase lists views as the items of the overview, owned when they belong
+// to the user
+type mockGetTaskOverviewUseCase struct {
+	executeFunc func(ctx context.Context, userID string) ([]*application.TaskView, error)
+	counters    application.TaskCounters
+	next        *application.TaskCursor // cursor of the page after the listed items
+	after       *application.TaskCursor // the last cursor asked for
+}
+
+func (m *mockGetTaskOverviewUseCase) Execute(ctx context.Context, userID string, after *application.TaskCursor, limit int) (*application.TaskOverview, error) {
+	m.after = after
+	views, err := m.executeFunc(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	overview := &application.TaskOverview{Counters: m.counters, NextCursor: m.next}
+	for _, view := range views {
+		overview.Items = append(overview.Items, &application.TaskListItem{TaskView: view, Owned: view.IsOwnedBy(userID)})
+	}
+	return overview, nil
+}
+
+type mockGetUserThemeUseCase struct {
+	theme application.Theme
+}
+
+func (m *mockGetUserThemeUseCase) Execute(ctx context.Context, userID string) (application.Theme, error) {
+	if m.theme == "" {
+		return application.ThemeLight, nil
+	}
+	return m.theme, nil
+}
+
+type mockTokenValidator struct {
+	userID string
+}
+
+func (m *mockTokenValidator) ValidateToken(token string) (*service.JWTClaims, error) {
+	if token != "valid-token" {
+		return nil, errors.New("invalid token")
+	}
+	return &service.JWTClaims{UserID: m.userID}, nil
+}
+
+func newTestTasksPageHandler(calls *atomic.Int32, pageCache *cache.TTL[[]byte]) *TasksPageHandler {
+	return newTestTasksPageHandlerWithTheme(calls, pageCache, application.ThemeLight)
+}
+
+func newTestTasksPageHandlerWithTheme(calls *atomic.Int32, pageCache *cache.TTL[[]byte], theme application.Theme) *TasksPageHandler {
+	overview := &mockGetTaskOverviewUseCase{
+		executeFunc: func(ctx context.Context, userID string) ([]*application.TaskView, error) {
+			calls.Add(1)
+			return []*application.TaskView{
+				{Task: &application.Task{ID: "task-1", Title: "Cached Task", Description: "See https://gone.example.com", Status: application.StatusPending, OwnerID: userID, CreatedAt: time.Now()}},
+			}, nil
+		},
+		counters: application.TaskCounters{Pending: 1},
+	}
+	listTaskViews := &mockListTaskViewsUseCase{
+		executeFunc: func(ctx context.Context, userID, projectID string) ([]*application.TaskView, error) {
+			return []*application.TaskView{
+				{Task: &application.Task{ID: "task-2", Title: "Project Task", Status: application.StatusPending, OwnerID: "user-456", CreatedAt: time.Now()}},
+			}, nil
+		},
+	}
+	brokenLinks := &mockListBrokenLinksUseCase{links: map[string][]string{"task-1": {"https://gone.example.com"}}}
+
+	attachments := &mockListOwnerAttachmentsUseCase{attachments: map[string][]*application.Attachment{
+		"task-1": {{ID: "att-1", TaskID: "task-1", Filename: "relatorio.pdf", Size: 2048}},
+	}}
+
+	projects := &mockListProjectsUseCase{list: &usecases.ProjectList{
+		Owned:  []*application.Project{{ID: "proj-1", Name: "Trabalho", Color: "#ef4444", OwnerID: "user-123"}},
+		Shared: []*application.Project{{ID: "proj-2", Name: "Casa", Color: "#22c55e", OwnerID: "user-456"}},
+	}}
+
+	h := NewTasksPageHandler(overview, listTaskViews, projects, brokenLinks, attachments, &mockListOpenBlockersUseCase{}, &mockListTaskTimesUseCase{}, &mockGetUserThemeUseCase{theme: theme}, pageCache, &mockTokenValidator{userID: "user-123"}, NewPageTemplates(""))
+	return h
+}
+
+func getTasksPage(h *TasksPageHandler, userID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/tasks", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	h.TasksPage(w, req)
+	return w
+}
+
+// =============================================================================
+// TasksPage Tests
+// =============================================================================
+
+func TestTasksPage_RendersTasksAndBrokenLinks(t *testing.T) {
+	var calls atomic.Int32
+	h := newTestTasksPageHandler(&calls, nil)
+
+	w := getTasksPage(h, "user-123")
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "Cached Task") {
+		t.Error("Expected page to contain task title")
+	}
+	if !strings.Contains(body, "Links quebrados") {
+		t.Error("Expected page to contain broken link warning")
+	}
+}
+
+func TestTasksPage_RendersBlockedTasks(t *testing.T) {
+	var calls atomic.Int32
+	h := newTestTasksPageHandler(&calls, nil)
+	h.listBlockers = &mockListOpenBlockersUseCase{blockers: map[string][]*application.Task{
+		"task-1": {{ID: "task-9", Title: "Aprovar <orçamento>"}, {ID: "task-8", Title: "Revisar"}},
+	}}
+
+	body := getTasksPage(h, "user-123").Body.String()
+
+	if !strings.Contains(body, `title="Bloqueada por: Aprovar &lt;orçamento&gt;, Revisar"`) {
+		t.Errorf("Expected the blocked badge to list the blockers:\n%s", body)
+	}
+	if strings.Contains(body, `hx-post="/web/tasks/task-1/complete"`) {
+		t.Error("Expected no complete button on a blocked task")
+	}
+}
+
+func TestTasksPage_RendersTrackedTime(t *testing.T) {
+	var calls atomic.Int32
+	h := newTestTasksPageHandler(&calls, nil)
+	since := time.Now().Add(-time.Hour)
+	h.listTaskTimes = &mockListTaskTimesUseCase{taskTimes: map[string]*application.TaskTime{
+		"task-1": {TaskID: "task-1", Tracked: 95 * time.Minute, RunningSince: &since},
+	}}
+
+	body := getTasksPage(h, "user-123").Body.String()
+
+	if !strings.Contains(body, "Tempo: 1h 35m") {
+		t.Errorf("Expected the card to show the tracked time:\n%s", body)
+	}
+	if !strings.Contains(body, `hx-post="/web/tasks/task-1/timer/stop"`) {
+		t.Error("Expected a stop button on a task with a running timer")
+	}
+}
+
+func TestTasksPage_RendersTaskColor(t *testing.T) {
+	var calls atomic.Int32
+	h := newTestTasksPageHandler(&calls, nil)
+	h.getOverview = &mockGetTaskOverviewUseCase{
+		executeFunc: func(ctx context.Context, userID string) ([]*application.TaskView, error) {
+			return []*application.TaskView{
+				{Task: &application.Task{ID: "task-1", Title: "Colorida", Status: application.StatusPending, OwnerID: userID, Color: application.ColorPurple}},
+				{Task: &application.Task{ID: "task-2", Title: "Sem cor", Status: application.StatusPending, OwnerID: userID}},
+			}, nil
+		},
+	}
+
+	body := getTasksPage(h, "user-123").Body.String()
+
+	if !strings.Contains(body, `p-6 border-l-4 border-purple-500" id="task-task-1"`) || !strings.Contains(body, "Cor: Roxo") {
+		t.Errorf("Expected a purple border on the colored task:\n%s", body)
+	}
+	if !strings.Contains(body, `p-6" id="task-task-2"`) {
+		t.Error("Expected no colored border on the task without a color")
+	}
+}
+
+func TestTasksPage_InfiniteScroll(t *testing.T) {
+	next := &application.TaskCursor{CreatedAt: time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC), ID: "task-1"}
+	nextURL := "/web/tasks?cursor=" + encodeTaskCursor(next)
+
+	t.Run("the page ends with a sentinel loading the next one", func(t *testing.T) {
+		var calls atomic.Int32
+		h := newTestTasksPageHandler(&calls, nil)
+		h.getOverview.(*mockGetTaskOverviewUseCase).next = next
+
+		body := getTasksPage(h, "user-123").Body.String()
+		if !strings.Contains(body, `id="task-list-more" hx-get="`+nextURL+`" hx-trigger="revealed" hx-swap="outerHTML"`) {
+			t.Errorf("Expected a sentinel loading %s:\n%s", nextURL, body)
+		}
+	})
+
+	t.Run("the last page has no sentinel", func(t *testing.T) {
+		var calls atomic.Int32
+		body := getTasksPage(newTestTasksPageHandler(&calls, nil), "user-123").Body.String()
+		if strings.Contains(body, "task-list-more") {
+			t.Error("Expected no sentinel on the last page")
+		}
+	})
+
+	t.Run("the cards after a cursor come alone", func(t *testing.T) {
+		var calls atomic.Int32
+		h := newTestTasksPageHandler(&calls, nil)
+		listTaskViews := h.listTaskViews.(*mockListTaskViewsUseCase)
+		listTaskViews.next = &application.TaskCursor{CreatedAt: next.CreatedAt, ID: "task-2"}
+
+		req := htmx(httptest.NewRequest("GET", nextURL+"&project=proj-1", nil))
+		w := httptest.NewRecorder()
+		h.TaskCards(w, withUser(req))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		body := w.Body.String()
+		if strings.Contains(body, "<!DOCTYPE html>") || !strings.Contains(body, `id="task-task-2"`) {
+			t.Errorf("Expected only the task cards:\n%s", body)
+		}
+		if listTaskViews.after == nil || *listTaskViews.after != *next {
+			t.Errorf("Expected the cards after %+v, got %+v", next, listTaskViews.after)
+		}
+		if !strings.Contains(body, `hx-get="/web/tasks?cursor=`) || !strings.Contains(body, `&amp;project=proj-1"`) {
+			t.Errorf("Expected a sentinel loading the next page of the project:\n%s", body)
+		}
+	})
+
+	t.Run("the cards of the task list after a cursor come from the overview", func(t *testing.T) {
+		var calls atomic.Int32
+		h := newTestTasksPageHandler(&calls, nil)
+
+		w := httptest.NewRecorder()
+		h.TaskCards(w, withUser(htmx(httptest.NewRequest("GET", nextURL, nil))))
+
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `id="task-task-1"`) {
+			t.Fatalf("Expected the cards of the task list, got %d: %s", w.Code, w.Body.String())
+		}
+		if overview := h.getOverview.(*mockGetTaskOverviewUseCase); overview.after == nil || *overview.after != *next {
+			t.Errorf("Expected the overview after %+v, got %+v", next, overview.after)
+		}
+	})
+
+	t.Run("an invalid cursor is rejected", func(t *testing.T) {
+		var calls atomic.Int32
+		h := newTestTasksPageHandler(&calls, nil)
+
+		w := httptest.NewRecorder()
+		h.TaskCards(w, withUser(htmx(httptest.NewRequest("GET", "/web/tasks?cursor=not-a-cursor", nil))))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+}
+
+func TestTasksPage_RendersOwnershipAndSharees(t *testing.T) {
+	var calls atomic.Int32
+	h := newTestTasksPageHandler(&calls, nil)
+	h.getOverview = &mockGetTaskOverviewUseCase{
+		executeFunc: func(ctx context.Context, userID string) ([]*application.TaskView, error) {
+			return []*application.TaskView{
+				{
+					Task:       &application.Task{ID: "task-1", Title: "Relatório", Status: application.StatusPending, OwnerID: userID, CreatedAt: time.Now()},
+					SharedWith: []application.Sharee{{UserID: "user-2", Name: "Bia"}, {UserID: "user-3", Name: "Caio <3>"}},
+				},
+				{
+					Task:      &application.Task{ID: "task-2", Title: "Orçamento", Status: application.StatusPending, OwnerID: "user-456", CreatedAt: time.Now()},
+					OwnerName: "Davi",
+				},
+			}, nil
+		},
+	}
+
+	body := getTasksPage(h, "user-123").Body.String()
+
+	if !strings.Contains(body, "Compartilhada com Bia, Caio &lt;3&gt;") {
+		t.Errorf("Expected the owned task to list its sharees:\n%s", body)
+	}
+	if strings.Count(body, "Compartilhada com") != 1 {
+		t.Error("Expected no sharee list on a task shared with nobody")
+	}
+	if !strings.Contains(body, "Compartilhada por Davi") {
+		t.Errorf("Expected the shared task to name its owner:\n%s", body)
+	}
+	if !strings.Contains(body, `src="/uploads/avatars/user-456"`) || strings.Count(body, `src="/uploads/avatars/`) != 2 {
+		t.Error("Expected the avatars of the user in the navbar and of the shared task's owner only")
+	}
+	// Only the owner can share a task
+	if !strings.Contains(body, `toggleShareForm('task-1')`) || strings.Contains(body, `toggleShareForm('task-2')`) {
+		t.Error("Expected only the owned task to be shareable")
+	}
+}
+
+func TestTasksPage_RendersCounters(t *testing.T) {
+	var calls atomic.Int32
+	h := newTestTasksPageHandler(&calls, nil)
+	h.getOverview.(*mockGetTaskOverviewUseCase).counters = application.TaskCounters{Pending: 4, Overdue: 2, Shared: 3}
+
+	body := getTasksPage(h, "user-123").Body.String()
+
+	// The navbar shows the counters of the overview and only polls for fresher ones
+	if !strings.Contains(body, `hx-trigger="every 60s"`) || !strings.Contains(body, `title="Compartilhadas com você">3</span>`) {
+		t.Errorf("Expected the counters rendered into the navbar:\n%s", body)
+	}
+
+	req := httptest.NewRequest("GET", "/tasks?project=proj-1", nil)
+	w := httptest.NewRecorder()
+	h.TasksPage(w, withUser(req))
+	if !strings.Contains(w.Body.String(), `hx-trigger="load, every 60s"`) {
+		t.Error("Expected project pages to load the counters on their own")
+	}
+}
+
+func TestTasksPage_RendersRequestLocale(t *testing.T) {
+	var calls atomic.Int32
+	pageCache := cache.NewTTL[[]byte](time.Minute)
+	h := newTestTasksPageHandler(&calls, pageCache)
+
+	getTasksPage(h, "user-123")
+
+	req := httptest.NewRequest("GET", "/tasks", nil)
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+	w := httptest.NewRecorder()
+	h.TasksPage(w, req)
+
+	body := w.Body.String()
+	for _, want := range []string{`<html lang="en"`, "My Tasks", "Broken links in this task:"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected English page to contain %q", want)
+		}
+	}
+	if strings.Contains(body, "Minhas Tarefas") {
+		t.Error("Expected the Portuguese page cached for the same user not to be served")
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Expected one render per locale, got %d calls", calls.Load())
+	}
+}
+
+func TestTasksPage_RendersAttachments(t *testing.T) {
+	var calls atomic.Int32
+	h := newTestTasksPageHandler(&calls, nil)
+
+	body := getTasksPage(h, "user-123").Body.String()
+
+	for _, want := range []string{
+		`href="/web/tasks/task-1/attachments/att-1"`,
+		"relatorio.pdf",
+		"(2 KB)",
+		`hx-post="/web/tasks/task-1/attachments"`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected page to contain %q", want)
+		}
+	}
+}
+
+func TestTasksPage_RendersPreferredTheme(t *testing.T) {
+	tests := []struct {
+		name     string
+		theme    application.Theme
+		wantDark bool
+	}{
+		{"light", application.ThemeLight, false},
+		{"dark", application.ThemeDark, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			h := newTestTasksPageHandlerWithTheme(&calls, nil, tt.theme)
+
+			body := getTasksPage(h, "user-123").Body.String()
+			if got := strings.Contains(body, `<html lang="pt-BR" class="dark">`); got != tt.wantDark {
+				t.Errorf("Expected dark class on <html> to be %v", tt.wantDark)
+			}
+		})
+	}
+}
+
+func TestTasksPage_RendersProjectSidebar(t *testing.T) {
+	var calls atomic.Int32
+	h := newTestTasksPageHandler(&calls, nil)
+
+	body := getTasksPage(h, "user-123").Body.String()
+
+	for _, want := range []string{`href="/tasks?project=proj-1"`, "Trabalho", `href="/tasks?project=proj-2"`, "Casa", `background-color: #ef4444`, `hx-post="/web/projects"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected page to contain %q", want)
+		}
+	}
+	if strings.Contains(body, `name="project_id"`) {
+		t.Error("Expected the default page not to create tasks into a project")
+	}
+}
+
+func TestTasksPage_ProjectTasks(t *testing.T) {
+	tests := []struct {
+		name        string
+		projectID   string
+		wantStatus  int
+		wantProject bool
+	}{
+		{"owned project", "proj-1", http.StatusOK, true},
+		{"shared project", "proj-2", http.StatusOK, false},
+		{"unknown project", "proj-9", http.StatusNotFound, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			pageCache := cache.NewTTL[[]byte](time.Minute)
+			h := newTestTasksPageHandler(&calls, pageCache)
+
+			req := httptest.NewRequest("GET", "/tasks?project="+tt.projectID, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+			w := httptest.NewRecorder()
+			h.TasksPage(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			body := w.Body.String()
+			if !strings.Contains(body, "Project Task") || strings.Contains(body, "Cached Task") {
+				t.Error("Expected page to list only the project tasks")
+			}
+			// Only the owner can add tasks to a project, so the create form is hidden in shared ones
+			hasProjectField := strings.Contains(body, `name="project_id" value="`+tt.projectID+`"`)
+			if hasProjectField != tt.wantProject {
+				t.Errorf("Expected project_id field to be %v", tt.wantProject)
+			}
+			if calls.Load() != 0 {
+				t.Errorf("Expected no call to list all tasks, got %d", calls.Load())
+			}
+			if _, cached := h.cached("user-123", i18n.Default); cached {
+				t.Error("Expected project pages not to be cached")
+			}
+		})
+	}
+}
+
+func TestTasksPage_Unauthorized(t *testing.T) {
+	var calls atomic.Int32
+	h := newTestTasksPageHandler(&calls, nil)
+
+	w := httptest.NewRecorder()
+	h.TasksPage(w, httptest.NewRequest("GET", "/tasks", nil))
+
+	if w.Code != http.StatusFound {
+		t.Errorf("Expected redirect 302, got %d", w.Code)
+	}
+}
+
+func TestTasksPage_WarmUpServesFromCache(t *testing.T) {
+	var calls atomic.Int32
+	pageCache := cache.NewTTL[[]byte](time.Minute)
+	h := newTestTasksPageHandler(&calls, pageCache)
+
+	h.WarmUp("valid-token", i18n.Default)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, ok := pageCache.Get(pageCacheKey("user-123", i18n.Default)); ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected warm-up to pre-render the page")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	w := getTasksPage(h, "user-123")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "Cached Task") {
+		t.Fatalf("Expected pre-rendered page, got %d: %s", w.Code, w.Body.String())
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected the list use case to run only during warm-up, got %d calls", calls.Load())
+	}
+}
+
+func TestTasksPage_WarmUpIgnoresInvalidToken(t *testing.T) {
+	var calls atomic.Int32
+	pageCache := cache.NewTTL[[]byte](time.Minute)
+	h := newTestTasksPageHandler(&calls, pageCache)
+
+	h.WarmUp("forged-token", i18n.Default)
+	time.Sleep(20 * time.Millisecond)
+
+	if calls.Load() != 0 {
+		t.Errorf("Expected no pre-render for an invalid token, got %d calls", calls.Load())
+	}
+}
+
+func TestTasksPage_InvalidateForcesRerender(t *testing.T) {
+	var calls atomic.Int32
+	pageCache := cache.NewTTL[[]byte](time.Minute)
+	h := newTestTasksPageHandler(&calls, pageCache)
+
+	getTasksPage(h, "user-123")
+	getTasksPage(h, "user-123")
+	if calls.Load() != 1 {
+		t.Fatalf("Expected second request to hit the cache, got %d calls", calls.Load())
+	}
+
+	h.Invalidate("user-123")
+	getTasksPage(h, "user-123")
+	if calls.Load() != 2 {
+		t.Errorf("Expected re-render after invalidation, got %d calls", calls.Load())
+	}
+}
+
+func TestWebLogin_WarmsTaskList(t *testing.T) {
+	warmer := &recordingWarmer{}
+	handler := &AuthHandler{loginUseCase: &mockLoginUseCase{}, taskListWarmer: warmer}
+
+	req := htmx(httptest.NewRequest("POST", "/web/auth/login", strings.NewReader("email=a@b.com&password=secret123")))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	handler.WebLogin(w, req)
+
+	if warmer.token != "mock-jwt-token" {
+		t.Errorf("Expected warm-up with issued token, got %q", warmer.token)
+	}
+	if warmer.locale != i18n.Default {
+		t.Errorf("Expected warm-up in the default locale, got %q", warmer.locale)
+	}
+}
+
+func TestWebLogin_RestoresPreferredLocale(t *testing.T) {
+	warmer := &recordingWarmer{}
+	handler := &AuthHandler{loginUseCase: &mockLoginUseCase{locale: application.LocaleEN}, taskListWarmer: warmer}
+
+	req := htmx(httptest.NewRequest("POST", "/web/auth/login", strings.NewReader("email=a@b.com&password=secret123")))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept-Language", "pt-BR")
+	w := httptest.NewRecorder()
+	handler.WebLogin(w, req)
+
+	var langCookie *http.Cookie
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == LocaleCookieName {
+			langCookie = cookie
+		}
+	}
+	if langCookie == nil || langCookie.Value != "en" {
+		t.Errorf("Expected lang cookie with the stored locale, got %v", langCookie)
+	}
+	if warmer.locale != application.LocaleEN {
+		t.Errorf("Expected warm-up in the stored locale, got %q", warmer.locale)
+	}
+}
+
+type recordingWarmer struct {
+	token  string
+	locale application.Locale
+}
+
+func (r *recordingWarmer) WarmUp(token string, locale application.Locale) {
+	r.token = token
+	r.locale = locale
+}
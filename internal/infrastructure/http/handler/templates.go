@@ -2,56 +2,136 @@ package handler
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"html/template"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/markdown"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/textdiff"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
 )
 
 // TaskTemplateData holds data for rendering task HTML fragments
 type TaskTemplateData struct {
 	ID             string
 	Title          string
-	Description    string
+	Description    template.HTML
 	Status         string
 	StatusClass    string
 	StatusText     string
+	PriorityClass  string
+	PriorityText   string // empty for normal priority, which has no badge
+	ColorClass     string // border of the card; empty when the task isn't colored
+	ColorText      string
+	Tags           []string
 	CreatedAt      string
+	DueDate        string
 	ShowComplete   bool
 	ShowShare      bool
 	OwnershipClass string
 	OwnershipText  string
+	OwnerAvatarURL string // empty on the user's own tasks
 	ImagePath      string
 	IsOwner        bool
+	Attachments    template.HTML
+	Timer          template.HTML
+	Progress       int    // Percent done of an open task; 0 hides the progress bar
+	CompletionNote string // How a completed task was done
+	JustCompleted  bool   // The task was completed by this request: the card confirms it
+	UndoSeconds    int    // Seconds left to undo the completion; 0 hides the undo button
+}
+
+// taskTimerTemplates are the templates for rendering the timer of a task card: the time
+// tracked on the task and the button starting or stopping the timer of the user
+var taskTimerTemplates = localizedTemplates("taskTimer", `<div class="mt-2 flex items-center space-x-2 text-sm" id="task-{{.TaskID}}-timer" aria-live="polite">
+		{{if or .Tracked .Running}}
+		<span class="inline-flex items-center px-2.5 py-0.5 rounded-full text-xs font-medium {{if .Running}}bg-green-100 text-green-800{{else}}bg-gray-100 text-gray-800{{end}}"
+			  {{if .Running}}title="{{t "timer.running"}}"{{end}}>
+			<svg class="w-3 h-3 mr-1" fill="none" stroke="currentColor" viewBox="0 0 24 24" aria-hidden="true">
+				<path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M12 8v4l3 3m6-3a9 9 0 11-18 0 9 9 0 0118 0z"/>
+			</svg>
+			{{t "timer.tracked" .Tracked}}
+			{{if .Running}}<span class="sr-only">{{t "timer.running"}}</span>{{end}}
+		</span>
+		{{end}}
+		{{$action := "start"}}{{if .Running}}{{$action = "stop"}}{{end}}
+		<form method="post" action="/web/tasks/{{.TaskID}}/timer/{{$action}}"
+			  hx-post="/web/tasks/{{.TaskID}}/timer/{{$action}}" hx-target="#task-{{.TaskID}}-timer" hx-swap="outerHTML">
+		<button type="submit" aria-describedby="task-{{.TaskID}}-title"
+				class="text-indigo-600 hover:text-indigo-800 dark:text-indigo-400 font-medium">
+			{{if .Running}}{{t "timer.stop"}}{{else}}{{t "timer.start"}}{{end}}
+		</button>
+		</form>
+	</div>`)
+
+// renderTaskTimer renders the timer of a task card. A nil taskTime renders a task without
+// tracked time.
+func renderTaskTimer(taskID string, taskTime *application.TaskTime, locale application.Locale) template.HTML {
+	data := map[string]interface{}{
+		"TaskID":  taskID,
+		"Tracked": "",
+		"Running": taskTime.IsRunning(),
+	}
+	if taskTime != nil && (taskTime.Tracked > 0 || taskTime.IsRunning()) {
+		data["Tracked"] = application.FormatDuration(taskTime.Tracked)
+	}
+
+	var buf bytes.Buffer
+	if err := localizedTemplate(taskTimerTemplates, locale).Execute(&buf, data); err != nil {
+		log.Printf("failed to render the timer of task %s: %v", taskID, err)
+		return ""
+	}
+
+	// Produced by html/template, so it is already escaped
+	return template.HTML(buf.String())
+}
+
+// AttachmentTemplateData holds data for rendering an attachment in a task card
+type AttachmentTemplateData struct {
+	Filename string
+	Size     string
+	URL      string
 }
 
 var (
-	// taskCardTemplate is the template for rendering a task card
-	taskCardTemplate = template.Must(template.New("taskCard").Parse(`<div class="bg-white shadow rounded-lg p-6" id="task-{{.ID}}">
+	// taskCardTemplates are the templates for rendering a task card
+	taskCardTemplates = localizedTemplates("taskCard", `<div class="bg-white dark:bg-gray-800 shadow rounded-lg p-6{{if .ColorClass}} border-l-4 {{.ColorClass}}{{end}}" id="task-{{.ID}}" role="article" aria-labelledby="task-{{.ID}}-title">
 		<div class="flex justify-between items-start">
 			<div class="flex-1">
-				<h3 class="text-lg font-semibold text-gray-900">{{.Title}}</h3>
-				<p class="text-gray-600 mt-1">{{.Description}}</p>
+				<h3 id="task-{{.ID}}-title" class="text-lg font-semibold text-gray-900 dark:text-gray-100">{{.Title}}{{if .ColorText}}<span class="sr-only"> ({{.ColorText}})</span>{{end}}</h3>
+				<div class="markdown text-gray-600 dark:text-gray-400 mt-1">{{.Description}}</div>
 				{{if .ImagePath}}
 				<div class="mt-3" id="task-{{.ID}}-image">
-					<img src="{{.ImagePath}}" alt="Task image" class="max-w-[200px] max-h-[200px] object-cover rounded-lg shadow-sm">
+					<img src="{{.ImagePath}}" alt="{{t "task.image_alt"}}" class="max-w-[200px] max-h-[200px] object-cover rounded-lg shadow-sm">
 					{{if .ShowComplete}}
 					{{if .IsOwner}}
 					<div class="mt-2 flex space-x-2">
-						<button hx-delete="/web/tasks/{{.ID}}/image"
-								hx-target="#task-{{.ID}}-image"
-								hx-swap="outerHTML"
-								hx-confirm="Tem certeza que deseja excluir esta imagem?"
+						<form method="post" action="/web/tasks/{{.ID}}/image?_method=DELETE"
+							  hx-delete="/web/tasks/{{.ID}}/image"
+							  hx-target="#task-{{.ID}}-image"
+							  hx-swap="outerHTML"
+							  hx-confirm="{{t "task.delete_image_confirm"}}">
+						<button type="submit" aria-describedby="task-{{.ID}}-title"
 								class="text-red-600 hover:text-red-800 text-sm">
-							<svg class="w-4 h-4 inline mr-1" fill="none" stroke="currentColor" viewBox="0 0 24 24">
+							<svg class="w-4 h-4 inline mr-1" fill="none" stroke="currentColor" viewBox="0 0 24 24" aria-hidden="true">
 								<path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M19 7l-.867 12.142A2 2 0 0116.138 21H7.862a2 2 0 01-1.995-1.858L5 7m5 4v6m4-6v6m1-10V4a1 1 0 00-1-1h-4a1 1 0 00-1 1v3M4 7h16"/>
 							</svg>
-							Excluir imagem
+							{{t "task.delete_image"}}
 						</button>
+						</form>
+						<form method="post" action="/web/tasks/{{.ID}}/image?_method=PUT" enctype="multipart/form-data">
 						<label class="text-blue-600 hover:text-blue-800 text-sm cursor-pointer">
-							<svg class="w-4 h-4 inline mr-1" fill="none" stroke="currentColor" viewBox="0 0 24 24">
+							<svg class="w-4 h-4 inline mr-1" fill="none" stroke="currentColor" viewBox="0 0 24 24" aria-hidden="true">
 								<path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M4 16v1a3 3 0 003 3h10a3 3 0 003-3v-1m-4-8l-4-4m0 0L8 8m4-4v12"/>
 							</svg>
-							Substituir imagem
+							{{t "task.replace_image"}}
 							<input type="file"
 								   accept="image/jpeg,image/jpg,image/png,image/gif,image/webp"
 								   hx-put="/web/tasks/{{.ID}}/image"
@@ -59,151 +139,843 @@ var (
 								   hx-target="#task-{{.ID}}-image"
 								   hx-swap="outerHTML"
 								   name="image"
+								   aria-describedby="task-{{.ID}}-title"
 								   class="hidden">
 						</label>
+						<noscript><button type="submit" class="text-blue-600 hover:text-blue-800 text-sm">{{t "task.replace_image"}}</button></noscript>
+						</form>
 					</div>
 					{{end}}
 					{{end}}
 				</div>
 				{{end}}
+				{{.Attachments}}
+				{{.Timer}}
+				{{if .Progress}}
+				<div class="mt-2 w-full bg-gray-200 dark:bg-gray-700 rounded-full h-2" role="progressbar"
+					 aria-valuenow="{{.Progress}}" aria-valuemin="0" aria-valuemax="100" aria-label="{{t "task.progress" .Progress}}" title="{{t "task.progress" .Progress}}">
+					<div class="bg-blue-600 h-2 rounded-full" style="width: {{.Progress}}%"></div>
+				</div>
+				{{end}}
+				{{if .CompletionNote}}
+				<p class="mt-2 text-sm text-gray-600 dark:text-gray-400">{{.CompletionNote}}</p>
+				{{end}}
 				<div class="mt-2 flex items-center space-x-2">
 					<span class="inline-flex items-center px-2.5 py-0.5 rounded-full text-xs font-medium {{.StatusClass}}">
 						{{.StatusText}}
 					</span>
+					{{if .PriorityText}}
+					<span class="inline-flex items-center px-2.5 py-0.5 rounded-full text-xs font-medium {{.PriorityClass}}">
+						{{.PriorityText}}
+					</span>
+					{{end}}
 					<span class="inline-flex items-center px-2.5 py-0.5 rounded-full text-xs font-medium {{.OwnershipClass}}">
+						{{if .OwnerAvatarURL}}<img src="{{.OwnerAvatarURL}}" alt="" class="w-4 h-4 rounded-full mr-1">{{end}}
 						{{.OwnershipText}}
 					</span>
-					<span class="text-sm text-gray-500">{{.CreatedAt}}</span>
+					<span class="text-sm text-gray-500 dark:text-gray-400">{{.CreatedAt}}</span>
+					{{if .DueDate}}
+					<span class="text-sm text-gray-700 dark:text-gray-300">{{t "task.due" .DueDate}}</span>
+					{{end}}
+					{{range .Tags}}
+					<span class="text-sm text-blue-700 dark:text-blue-300">#{{.}}</span>
+					{{end}}
+					{{if .JustCompleted}}
+					<span class="text-sm text-gray-500 dark:text-gray-400" role="status">{{t "task.completed_message"}}</span>
+					{{end}}
+					{{if .UndoSeconds}}
+					<form method="post" action="/web/tasks/{{.ID}}/undo" data-undo-seconds="{{.UndoSeconds}}"
+						  hx-post="/web/tasks/{{.ID}}/undo" hx-target="#task-{{.ID}}" hx-swap="outerHTML">
+					<button type="submit" aria-describedby="task-{{.ID}}-title"
+							class="text-sm text-indigo-600 hover:text-indigo-800 dark:text-indigo-400 font-medium">{{t "undo.button"}}</button>
+					</form>
+					{{end}}
 				</div>
 			</div>
 			<div class="flex space-x-2 ml-4">
 				{{if .ShowComplete}}
-				<button hx-post="/web/tasks/{{.ID}}/complete" hx-target="#task-{{.ID}}" hx-swap="outerHTML"
+				<form method="post" action="/web/tasks/{{.ID}}/complete"
+					  hx-post="/web/tasks/{{.ID}}/complete" hx-target="#task-{{.ID}}" hx-swap="outerHTML">
+				<input type="text" name="completion_note" maxlength="500" aria-label="{{t "task.completion_note"}}" placeholder="{{t "task.completion_note"}}"
+					   class="text-sm px-2 py-1 w-40 border border-gray-300 dark:border-gray-600 rounded-md dark:bg-gray-700 dark:text-gray-100">
+				<button type="submit" aria-describedby="task-{{.ID}}-title"
 						class="text-green-600 hover:text-green-800 font-medium">
-					<svg class="w-4 h-4 inline mr-1" fill="none" stroke="currentColor" viewBox="0 0 24 24">
+					<svg class="w-4 h-4 inline mr-1" fill="none" stroke="currentColor" viewBox="0 0 24 24" aria-hidden="true">
 						<path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M5 13l4 4L19 7"/>
 					</svg>
-					Concluir
+					{{t "task.complete"}}
 				</button>
+				</form>
 				{{end}}
 				{{if .ShowShare}}
-				<button hx-post="/web/tasks/{{.ID}}/share"
-						hx-target="#task-{{.ID}}"
-						hx-swap="outerHTML"
-						hx-prompt="Digite o email do usuário com quem deseja compartilhar:"
-						hx-vals='js:{share_with_user_id: prompt("Digite o email do usuário:")}'
+				<button type="button" onclick="toggleShareForm('{{.ID}}')"
+						aria-controls="share-{{.ID}}" aria-expanded="false" aria-describedby="task-{{.ID}}-title"
 						class="text-blue-600 hover:text-blue-800 font-medium">
-					<svg class="w-4 h-4 inline mr-1" fill="none" stroke="currentColor" viewBox="0 0 24 24">
+					<svg class="w-4 h-4 inline mr-1" fill="none" stroke="currentColor" viewBox="0 0 24 24" aria-hidden="true">
 						<path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M8.684 13.342C8.886 12.938 9 12.482 9 12c0-.482-.114-.938-.316-1.342m0 2.684a3 3 0 110-2.684m0 2.684l6.632 3.316m-6.632-6l6.632-3.316m0 0a3 3 0 105.367-2.684 3 3 0 00-5.367 2.684zm0 9.316a3 3 0 105.368 2.684 3 3 0 00-5.368-2.684z"/>
 					</svg>
-					Compartilhar
+					{{t "task.share"}}
 				</button>
+				<details class="relative">
+					<summary aria-describedby="task-{{.ID}}-title"
+							 class="cursor-pointer list-none text-indigo-600 hover:text-indigo-800 dark:text-indigo-400 font-medium">{{t "task.snooze"}}</summary>
+					<form method="post" action="/web/tasks/{{.ID}}/snooze"
+						  hx-post="/web/tasks/{{.ID}}/snooze" hx-target="#task-{{.ID}}" hx-swap="outerHTML"
+						  class="absolute right-0 z-10 mt-1 flex flex-col w-40 bg-white dark:bg-gray-700 border border-gray-200 dark:border-gray-600 rounded-md shadow-lg">
+						<button type="submit" name="preset" value="tomorrow" class="px-3 py-2 text-left text-sm hover:bg-gray-100 dark:hover:bg-gray-600">{{t "task.snooze.tomorrow"}}</button>
+						<button type="submit" name="preset" value="next_week" class="px-3 py-2 text-left text-sm hover:bg-gray-100 dark:hover:bg-gray-600">{{t "task.snooze.next_week"}}</button>
+					</form>
+				</details>
 				{{end}}
-				<button hx-delete="/web/tasks/{{.ID}}" hx-target="#task-{{.ID}}" hx-swap="outerHTML"
-						hx-confirm="Tem certeza que deseja excluir esta tarefa?"
-						class="text-red-600 hover:text-red-800">
-					<svg class="w-4 h-4 inline mr-1" fill="none" stroke="currentColor" viewBox="0 0 24 24">
-						<path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M19 7l-.867 12.142A2 2 0 0116.138 21H7.862a2 2 0 01-1.995-1.858L5 7m5 4v6m4-6v6m1-10V4a1 1 0 00-1-1h-4a1 1 0 00-1 1v3M4 7h16"/>
+				<a href="/web/tasks/{{.ID}}/export/pdf" download title="{{t "task.export_title"}}" aria-describedby="task-{{.ID}}-title"
+				   class="text-gray-600 hover:text-gray-800 dark:text-gray-300 dark:hover:text-gray-100 font-medium">
+					<svg class="w-4 h-4 inline mr-1" fill="none" stroke="currentColor" viewBox="0 0 24 24" aria-hidden="true">
+						<path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M12 10v6m0 0l-3-3m3 3l3-3m2 8H7a2 2 0 01-2-2V5a2 2 0 012-2h5.586a1 1 0 01.707.293l5.414 5.414a1 1 0 01.293.707V19a2 2 0 01-2 2z"/>
+					</svg>
+					{{t "task.export"}}
+				</a>
+				<button type="button" hx-get="/web/tasks/{{.ID}}/revisions" hx-target="body" hx-swap="beforeend"
+						aria-describedby="task-{{.ID}}-title"
+						class="text-gray-600 hover:text-gray-800 dark:text-gray-300 dark:hover:text-gray-100 font-medium">
+					<svg class="w-4 h-4 inline mr-1" fill="none" stroke="currentColor" viewBox="0 0 24 24" aria-hidden="true">
+						<path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M12 8v4l3 3m6-3a9 9 0 11-18 0 9 9 0 0118 0z"/>
 					</svg>
-					Excluir
+					{{t "task.history"}}
 				</button>
-			</div>
-		</div>
-	</div>`))
-
-	// completedTaskTemplate is the template for rendering a completed task
-	completedTaskTemplate = template.Must(template.New("completedTask").Parse(`<div class="bg-white shadow rounded-lg p-6" id="task-{{.ID}}">
-		<div class="flex justify-between items-start">
-			<div class="flex-1">
-				<div class="flex items-center space-x-2">
-					<span class="inline-flex items-center px-2.5 py-0.5 rounded-full text-xs font-medium bg-green-100 text-green-800">
-						Concluída
-					</span>
-					<span class="inline-flex items-center px-2.5 py-0.5 rounded-full text-xs font-medium {{.OwnershipClass}}">
-						{{.OwnershipText}}
-					</span>
-					<span class="text-sm text-gray-500">Tarefa concluída com sucesso!</span>
-				</div>
-			</div>
-			<div class="flex space-x-2 ml-4">
-				<button hx-delete="/web/tasks/{{.ID}}" hx-target="#task-{{.ID}}" hx-swap="outerHTML"
-						hx-confirm="Tem certeza que deseja excluir esta tarefa?"
+				<form method="post" action="/web/tasks/{{.ID}}?_method=DELETE"
+					  hx-delete="/web/tasks/{{.ID}}" hx-target="#task-{{.ID}}" hx-swap="outerHTML"
+					  hx-confirm="{{t "task.delete_confirm"}}">
+				<button type="submit" aria-describedby="task-{{.ID}}-title"
 						class="text-red-600 hover:text-red-800">
-					<svg class="w-4 h-4 inline mr-1" fill="none" stroke="currentColor" viewBox="0 0 24 24">
+					<svg class="w-4 h-4 inline mr-1" fill="none" stroke="currentColor" viewBox="0 0 24 24" aria-hidden="true">
 						<path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M19 7l-.867 12.142A2 2 0 0116.138 21H7.862a2 2 0 01-1.995-1.858L5 7m5 4v6m4-6v6m1-10V4a1 1 0 00-1-1h-4a1 1 0 00-1 1v3M4 7h16"/>
 					</svg>
-					Excluir
+					{{t "task.delete"}}
 				</button>
+				</form>
+			</div>
+		</div>
+		{{if .ShowShare}}
+		<form id="share-{{.ID}}" class="hidden mt-4 relative" method="post" action="/web/tasks/{{.ID}}/share"
+			  hx-post="/web/tasks/{{.ID}}/share" hx-target="#share-{{.ID}}-status" hx-swap="innerHTML">
+			<div id="share-{{.ID}}-errors" class="mb-2"></div>
+			<label for="share-search-{{.ID}}" class="block text-sm font-medium text-gray-700 dark:text-gray-300">{{t "share.with"}}</label>
+			<div class="mt-1 flex space-x-2">
+				<input type="search" id="share-search-{{.ID}}" name="q" autocomplete="off"
+					   placeholder="{{t "share.search_placeholder"}}"
+					   hx-get="/web/users/search" hx-trigger="input changed delay:300ms, search"
+					   hx-target="#share-results-{{.ID}}" hx-sync="this:replace"
+					   aria-describedby="share-search-{{.ID}}-error"
+					   class="flex-1 px-3 py-2 border border-gray-300 dark:border-gray-600 dark:bg-gray-700 dark:text-gray-100 rounded-md focus:outline-none focus:ring-blue-500 focus:border-blue-500">
+				<button type="submit" disabled class="px-4 py-2 bg-blue-600 text-white rounded-md hover:bg-blue-700 disabled:opacity-50 disabled:cursor-not-allowed">{{t "task.share"}}</button>
 			</div>
+			<p id="share-search-{{.ID}}-error" data-field-error="share-search-{{.ID}}" class="mt-1 text-sm text-red-600 dark:text-red-400" aria-live="polite"></p>
+			<div id="share-results-{{.ID}}" class="absolute z-10 w-full" aria-live="polite"></div>
+			<ul id="share-recipients-{{.ID}}" class="mt-2 flex flex-wrap gap-2" data-remove-label="{{t "share.remove_recipient"}}" aria-label="{{t "share.recipients"}}"></ul>
+			<div id="share-{{.ID}}-status" class="mt-2" aria-live="polite"></div>
+			<button type="button" hx-get="/web/tasks/{{.ID}}/shares" hx-target="body" hx-swap="beforeend"
+					class="mt-2 text-sm text-blue-600 hover:text-blue-800 dark:text-blue-400">{{t "shares.manage"}}</button>
+		</form>
+		<div id="share-link-{{.ID}}" class="hidden mt-4 pt-4 border-t border-gray-200 dark:border-gray-700">
+			<h4 class="text-sm font-medium text-gray-700 dark:text-gray-300">{{t "share_link.heading"}}</h4>
+			<p class="text-sm text-gray-500 dark:text-gray-400">{{t "share_link.hint"}}</p>
+			<form method="post" action="/web/tasks/{{.ID}}/share-links" class="mt-2 flex items-end space-x-2"
+				  hx-post="/web/tasks/{{.ID}}/share-links" hx-target="#share-link-{{.ID}}-list" hx-swap="afterbegin">
+				<div>
+					<label for="share-link-{{.ID}}-expiry" class="block text-sm font-medium text-gray-700 dark:text-gray-300">{{t "share_link.expires_in"}}</label>
+					<select id="share-link-{{.ID}}-expiry" name="expires_in_days"
+							class="mt-1 px-3 py-2 border border-gray-300 dark:border-gray-600 dark:bg-gray-700 dark:text-gray-100 rounded-md">
+						<option value="1">{{t "share_link.day"}}</option>
+						<option value="7" selected>{{t "share_link.days" 7}}</option>
+						<option value="30">{{t "share_link.days" 30}}</option>
+					</select>
+				</div>
+				<button type="submit" class="px-4 py-2 bg-blue-600 text-white rounded-md hover:bg-blue-700">{{t "share_link.create"}}</button>
+			</form>
+			<h5 class="mt-3 text-sm font-medium text-gray-700 dark:text-gray-300">{{t "share_link.active"}}</h5>
+			<div id="share-link-{{.ID}}-list" class="mt-2 space-y-2" aria-live="polite"
+				 hx-get="/web/tasks/{{.ID}}/share-links" hx-trigger="share-links-open once"></div>
 		</div>
-	</div>`))
+		{{end}}
+	</div>`)
+
+	// userSearchResultsTemplates are the templates for the share autocomplete options
+	userSearchResultsTemplates = localizedTemplates("userSearchResults", `{{if .Users}}<ul role="listbox" class="mt-1 bg-white dark:bg-gray-700 border border-gray-200 dark:border-gray-600 rounded-md shadow-lg max-h-60 overflow-auto">
+		{{range .Users}}
+		<li role="option">
+			<button type="button" data-user-id="{{.ID}}" data-user-label="{{.Name}} <{{.Email}}>" onclick="selectShareUser(this)"
+					class="w-full text-left px-3 py-2 hover:bg-blue-50 dark:hover:bg-gray-600">
+				<span class="block text-sm font-medium text-gray-900 dark:text-gray-100">{{.Name}}</span>
+				<span class="block text-xs text-gray-500 dark:text-gray-400">{{.Email}}</span>
+			</button>
+		</li>
+		{{end}}
+	</ul>{{else if .Searched}}<p class="mt-1 px-3 py-2 text-sm text-gray-500 dark:text-gray-400 bg-white dark:bg-gray-700 border border-gray-200 dark:border-gray-600 rounded-md shadow-lg">{{t "share.no_users"}}</p>{{end}}`)
+
+	// attachmentListTemplates are the templates for rendering the attachments of a task
+	attachmentListTemplates = localizedTemplates("attachmentList", `<div class="mt-3" id="task-{{.TaskID}}-attachments">
+		{{if .Error}}
+		<p class="text-sm text-red-600 dark:text-red-400" role="alert">{{.Error}}</p>
+		{{end}}
+		{{if .Attachments}}
+		<p class="text-sm font-medium text-gray-700 dark:text-gray-300">{{t "attachments.heading"}}</p>
+		<ul class="mt-1 space-y-1 text-sm">
+			{{range .Attachments}}
+			<li class="flex items-center space-x-2">
+				<a href="{{.URL}}" download class="text-blue-600 hover:text-blue-800 dark:text-blue-400 break-all">{{.Filename}}</a>
+				<span class="text-gray-500 dark:text-gray-400">({{.Size}})</span>
+				{{if $.CanEdit}}
+				<form method="post" action="{{.URL}}?_method=DELETE"
+					  hx-delete="{{.URL}}"
+					  hx-target="#task-{{$.TaskID}}-attachments"
+					  hx-swap="outerHTML"
+					  hx-confirm="{{t "attachments.remove_confirm"}}">
+				<button type="submit" aria-label="{{t "attachments.remove_label" .Filename}}"
+						class="text-red-600 hover:text-red-800 text-xs">
+					{{t "attachments.remove"}}
+				</button>
+				</form>
+				{{end}}
+			</li>
+			{{end}}
+		</ul>
+		{{end}}
+		{{if .CanEdit}}
+		<form method="post" action="/web/tasks/{{.TaskID}}/attachments" enctype="multipart/form-data">
+		<label class="mt-2 inline-block text-blue-600 hover:text-blue-800 text-sm cursor-pointer">
+			<svg class="w-4 h-4 inline mr-1" fill="none" stroke="currentColor" viewBox="0 0 24 24" aria-hidden="true">
+				<path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M15.172 7l-6.586 6.586a2 2 0 102.828 2.828l6.414-6.586a4 4 0 00-5.656-5.656l-6.415 6.585a6 6 0 108.486 8.486L20.5 13"/>
+			</svg>
+			{{t "attachments.add"}}
+			<input type="file"
+				   name="file"
+				   accept="{{.Accept}}"
+				   hx-post="/web/tasks/{{.TaskID}}/attachments"
+				   hx-encoding="multipart/form-data"
+				   hx-target="#task-{{.TaskID}}-attachments"
+				   hx-swap="outerHTML"
+				   class="hidden">
+		</label>
+		<noscript><button type="submit" class="text-blue-600 hover:text-blue-800 text-sm">{{t "attachments.add"}}</button></noscript>
+		</form>
+		{{end}}
+	</div>`)
 )
 
+// deletedTaskTemplates are the templates for the notice replacing the card of a task deleted
+// on the web while the deletion can be undone. The notice goes away when the undo expires.
+var deletedTaskTemplates = localizedTemplates("deletedTask", `<div class="bg-gray-50 dark:bg-gray-800 border border-dashed border-gray-300 dark:border-gray-600 rounded-lg p-4 flex justify-between items-center"
+		 id="task-{{.ID}}" data-undo-seconds="{{.UndoSeconds}}" role="status">
+		<span class="text-sm text-gray-600 dark:text-gray-300">{{t "undo.deleted" .Title}}</span>
+		<form method="post" action="/web/tasks/{{.ID}}/undo"
+			  hx-post="/web/tasks/{{.ID}}/undo" hx-target="#task-{{.ID}}" hx-swap="outerHTML">
+		<button type="submit" class="text-indigo-600 hover:text-indigo-800 dark:text-indigo-400 font-medium">{{t "undo.button"}}</button>
+		</form>
+	</div>`)
+
+// invitationListTemplates are the templates for the pending share invitations shown above the
+// task list. Answering one re-renders the list in place.
+var invitationListTemplates = localizedTemplates("invitationList", `{{if .}}<section class="mb-6 bg-blue-50 dark:bg-gray-800 border border-blue-200 dark:border-gray-700 rounded-lg p-4" aria-labelledby="invitations-heading">
+		<h3 id="invitations-heading" class="text-sm font-semibold uppercase text-gray-600 dark:text-gray-300 mb-2">{{t "invitations.heading"}}</h3>
+		<ul class="space-y-2">
+			{{range .}}
+			<li id="invitation-{{.TaskID}}" class="flex items-center justify-between">
+				<div class="min-w-0">
+					<p id="invitation-{{.TaskID}}-title" class="font-medium text-gray-900 dark:text-gray-100 truncate">{{.TaskTitle}}</p>
+					<p class="flex items-center text-sm text-gray-600 dark:text-gray-400"><img src="{{avatarURL .OwnerID}}" alt="" class="w-4 h-4 rounded-full mr-1">{{t "invitations.from" .OwnerName}}</p>
+				</div>
+				<div class="flex space-x-2 ml-4 shrink-0">
+					<form method="post" action="/web/invitations/{{.TaskID}}/accept"
+						  hx-post="/web/invitations/{{.TaskID}}/accept" hx-target="#share-invitations" hx-swap="innerHTML">
+					<button type="submit" aria-describedby="invitation-{{.TaskID}}-title"
+							class="px-3 py-1 bg-blue-600 text-white rounded-md text-sm hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-blue-500">
+						{{t "invitations.accept"}}
+					</button>
+					</form>
+					<form method="post" action="/web/invitations/{{.TaskID}}/decline"
+						  hx-post="/web/invitations/{{.TaskID}}/decline" hx-target="#share-invitations" hx-swap="innerHTML">
+					<button type="submit" aria-describedby="invitation-{{.TaskID}}-title"
+							class="px-3 py-1 border border-gray-300 dark:border-gray-600 text-gray-700 dark:text-gray-300 rounded-md text-sm hover:bg-gray-100 dark:hover:bg-gray-700 focus:outline-none focus:ring-2 focus:ring-gray-500">
+						{{t "invitations.decline"}}
+					</button>
+					</form>
+				</div>
+			</li>
+			{{end}}
+		</ul>
+	</section>{{end}}`)
+
+// renderInvitationList renders the pending share invitations, or nothing when there are none
+func renderInvitationList(invitations []*application.ShareInvitation, locale application.Locale) (string, error) {
+	var buf bytes.Buffer
+	if err := localizedTemplate(invitationListTemplates, locale).Execute(&buf, invitations); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// twoFactorFormTemplates are the templates for the second step of the web login, which
+// replaces the login form once the password is verified
+var twoFactorFormTemplates = localizedTemplates("twoFactorForm", `<form id="login-form" class="mt-8 space-y-6" method="post" action="/web/auth/2fa" hx-post="/web/auth/2fa" hx-target="#error-message" hx-swap="innerHTML">
+		<input type="hidden" name="two_factor_token" value="{{.Token}}">
+		<div>
+			<label for="code" class="block text-sm font-medium text-gray-700 dark:text-gray-300">{{t "two_factor.code_label"}}</label>
+			<input id="code" name="code" type="text" inputmode="numeric" autocomplete="one-time-code" required autofocus
+				   class="mt-1 appearance-none relative block w-full px-3 py-2 border border-gray-300 dark:border-gray-600 dark:bg-gray-700 dark:text-gray-100 placeholder-gray-500 dark:placeholder-gray-400 text-gray-900 rounded-md focus:outline-none focus:ring-blue-500 focus:border-blue-500 sm:text-sm">
+			<p class="mt-2 text-sm text-gray-500 dark:text-gray-400">{{t "two_factor.code_help"}}</p>
+		</div>
+		<div>
+			<button type="submit"
+					class="group relative w-full flex justify-center py-2 px-4 border border-transparent text-sm font-medium rounded-lg text-white bg-blue-600 hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-blue-500">
+				{{t "two_factor.verify"}}
+			</button>
+		</div>
+	</form>`)
+
+// renderTwoFactorForm renders the code form answering the login challenge identified by token
+func renderTwoFactorForm(token string, locale application.Locale) (string, error) {
+	var buf bytes.Buffer
+	err := localizedTemplate(twoFactorFormTemplates, locale).Execute(&buf, map[string]interface{}{
+		"Token": token,
+	})
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
 // renderTaskCard renders a task card HTML fragment with proper escaping
-func renderTaskCard(task *application.Task, currentUserID string) (string, error) {
-	isOwner := task.OwnerID == currentUserID
+func renderTaskCard(view *application.TaskView, currentUserID string, locale application.Locale) (string, error) {
+	return executeTaskCard(taskCardData(view, currentUserID, locale), locale)
+}
+
+// renderCompletedTask renders the card of a task just completed: the same card as the task
+// list, with the completion confirmed and, while undo lasts, the button undoing it
+func renderCompletedTask(view *application.TaskView, currentUserID string, undo *application.TaskUndo, locale application.Locale) (string, error) {
+	data := taskCardData(view, currentUserID, locale)
+	data.JustCompleted = true
+	if undo != nil {
+		data.UndoSeconds = undo.SecondsLeft(time.Now())
+	}
+	return executeTaskCard(data, locale)
+}
+
+// taskCardData returns the data of the card of a task seen by currentUserID
+func taskCardData(view *application.TaskView, currentUserID string, locale application.Locale) TaskTemplateData {
+	task := view.Task
+	isOwner := view.IsOwnedBy(currentUserID)
 
 	data := TaskTemplateData{
 		ID:           task.ID,
 		Title:        task.Title,
-		Description:  task.Description,
+		Description:  markdown.Render(task.Description),
 		Status:       string(task.Status),
-		CreatedAt:    task.CreatedAt.Format("02/01/2006 15:04"),
+		CreatedAt:    formatDateTime(locale, task.CreatedAt),
 		ShowComplete: task.Status == application.StatusPending,
 		ShowShare:    isOwner && task.Status != application.StatusCompleted,
 		ImagePath:    task.ImagePath,
+		Tags:         task.Tags,
 		IsOwner:      isOwner,
+		Attachments:  RenderAttachmentList(task.ID, nil, isOwner && task.Status != application.StatusCompleted, "", locale),
+		Timer:        renderTaskTimer(task.ID, nil, locale),
+	}
+	if task.Status == application.StatusCompleted {
+		data.CompletionNote = task.CompletionNote
+	} else {
+		data.Progress = task.Progress
+	}
+	if task.DueDate != nil {
+		data.DueDate = formatDateTime(locale, *task.DueDate)
 	}
 
 	// Set status badge styling based on status
 	switch task.Status {
 	case application.StatusPending:
 		data.StatusClass = "bg-yellow-100 text-yellow-800"
-		data.StatusText = "Pendente"
+		data.StatusText = i18n.T(locale, "task.status.pending")
 	case application.StatusCompleted:
 		data.StatusClass = "bg-green-100 text-green-800"
-		data.StatusText = "Concluída"
+		data.StatusText = i18n.T(locale, "task.status.completed")
 	default:
 		data.StatusClass = "bg-gray-100 text-gray-800"
 		data.StatusText = string(task.Status)
 	}
 
+	data.PriorityClass, data.PriorityText = priorityBadge(task.Priority, locale)
+	data.ColorClass, data.ColorText = colorBorder(task.Color, locale)
+
 	// Set ownership badge styling based on owner
-	if task.OwnerID == currentUserID {
-		data.OwnershipClass = "bg-blue-100 text-blue-800"
-		data.OwnershipText = "Própria"
-	} else {
-		data.OwnershipClass = "bg-purple-100 text-purple-800"
-		data.OwnershipText = "Compartilhada"
+	data.OwnershipClass, data.OwnershipText = ownershipBadge(view, currentUserID, locale)
+	if !isOwner {
+		data.OwnerAvatarURL = AvatarURL(task.OwnerID)
 	}
+	return data
+}
 
+// executeTaskCard renders the card of a task from its data
+func executeTaskCard(data TaskTemplateData, locale application.Locale) (string, error) {
 	var buf bytes.Buffer
-	if err := taskCardTemplate.Execute(&buf, data); err != nil {
+	if err := localizedTemplate(taskCardTemplates, locale).Execute(&buf, data); err != nil {
 		return "", err
 	}
 
 	return buf.String(), nil
 }
 
-// renderCompletedTask renders a completed task HTML fragment
-func renderCompletedTask(task *application.Task, currentUserID string) (string, error) {
+// renderDeletedTask renders the notice of a task deleted with undo
+func renderDeletedTask(task *application.Task, undo *application.TaskUndo, locale application.Locale) (string, error) {
 	data := TaskTemplateData{
-		ID: task.ID,
+		ID:          task.ID,
+		Title:       task.Title,
+		UndoSeconds: undo.SecondsLeft(time.Now()),
 	}
 
-	// Set ownership badge styling based on owner
-	if task.OwnerID == currentUserID {
-		data.OwnershipClass = "bg-blue-100 text-blue-800"
-		data.OwnershipText = "Própria"
-	} else {
-		data.OwnershipClass = "bg-purple-100 text-purple-800"
-		data.OwnershipText = "Compartilhada"
+	var buf bytes.Buffer
+	if err := localizedTemplate(deletedTaskTemplates, locale).Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// ownershipBadge returns the class and text of the badge telling whether a task is owned or
+// shared, and by whom when the name of the owner is known
+func ownershipBadge(view *application.TaskView, currentUserID string, locale application.Locale) (string, string) {
+	if view.IsOwnedBy(currentUserID) {
+		return "bg-blue-100 text-blue-800", i18n.T(locale, "task.own")
+	}
+	if view.OwnerName != "" {
+		return "bg-purple-100 text-purple-800", i18n.T(locale, "task.shared_by", view.OwnerName)
+	}
+	return "bg-purple-100 text-purple-800", i18n.T(locale, "task.shared")
+}
+
+// withOwnerName returns the view of a task with the name of its owner, for its card or API
+// response, leaving the name out when it can't be looked up
+func withOwnerName(ctx context.Context, ownerNames usecases.GetOwnerNamesUseCaseInterface, task *application.Task) *application.TaskView {
+	names, err := ownerNames.Execute(ctx, []*application.Task{task})
+	if err != nil {
+		log.Printf("failed to look up the owner name of task %s: %v", task.ID, err)
+	}
+	return &application.TaskView{Task: task, OwnerName: names[task.OwnerID]}
+}
+
+// priorityBadge returns the class and text of the priority badge; normal priority has none
+func priorityBadge(priority application.TaskPriority, locale application.Locale) (string, string) {
+	switch priority {
+	case application.PriorityHigh:
+		return "bg-red-100 text-red-800", i18n.T(locale, "task.priority.high")
+	case application.PriorityLow:
+		return "bg-gray-100 text-gray-700", i18n.T(locale, "task.priority.low")
+	default:
+		return "", ""
+	}
+}
+
+// taskColorBorders holds the class of the card border of each color of the palette
+var taskColorBorders = map[application.TaskColor]string{
+	application.ColorRed:    "border-red-500",
+	application.ColorOrange: "border-orange-500",
+	application.ColorYellow: "border-yellow-400",
+	application.ColorGreen:  "border-green-500",
+	application.ColorBlue:   "border-blue-500",
+	application.ColorPurple: "border-purple-500",
+	application.ColorPink:   "border-pink-500",
+	application.ColorGray:   "border-gray-400",
+}
+
+// colorBorder returns the class of the card border of a task color and its name for screen
+// readers; tasks without a color have neither
+func colorBorder(color application.TaskColor, locale application.Locale) (string, string) {
+	class, ok := taskColorBorders[color]
+	if !ok {
+		return "", ""
+	}
+	return class, i18n.T(locale, "task.color", i18n.T(locale, "task.color."+string(color)))
+}
+
+// blockedByText lists the titles of the open blockers of a task, for the blocked badge
+func blockedByText(blockers []*application.Task, locale application.Locale) string {
+	titles := make([]string, 0, len(blockers))
+	for _, blocker := range blockers {
+		titles = append(titles, blocker.Title)
+	}
+	return i18n.T(locale, "task.blocked_by", strings.Join(titles, ", "))
+}
+
+// sharedWithText lists the names of the users a task is shared with, for the shared badge
+func sharedWithText(view *application.TaskView, locale application.Locale) string {
+	return i18n.T(locale, "task.shared_with", strings.Join(view.ShareeNames(), ", "))
+}
+
+// RenderAttachmentList renders the attachment list of a task card. canEdit shows the upload and
+// remove controls, and errMessage is shown above the list after a failed change.
+func RenderAttachmentList(taskID string, attachments []*application.Attachment, canEdit bool, errMessage string, locale application.Locale) template.HTML {
+	items := make([]AttachmentTemplateData, 0, len(attachments))
+	for _, attachment := range attachments {
+		items = append(items, AttachmentTemplateData{
+			Filename: attachment.Filename,
+			Size:     formatFileSize(attachment.Size),
+			URL:      "/web/tasks/" + url.PathEscape(taskID) + "/attachments/" + url.PathEscape(attachment.ID),
+		})
+	}
+
+	var buf bytes.Buffer
+	err := localizedTemplate(attachmentListTemplates, locale).Execute(&buf, map[string]interface{}{
+		"TaskID":      taskID,
+		"Attachments": items,
+		"CanEdit":     canEdit,
+		"Error":       errMessage,
+		"Accept":      attachmentAccept,
+	})
+	if err != nil {
+		return ""
+	}
+
+	// Produced by html/template, so it is already escaped
+	return template.HTML(buf.String())
+}
+
+// attachmentAccept is the accept attribute of the attachment file input
+var attachmentAccept = func() string {
+	exts := make([]string, 0, len(attachmentTypes))
+	for ext := range attachmentTypes {
+		exts = append(exts, ext)
+	}
+	sort.Strings(exts)
+	return strings.Join(exts, ",")
+}()
+
+// formatFileSize formats a size in bytes for display
+func formatFileSize(size int64) string {
+	switch {
+	case size < 1<<10:
+		return fmt.Sprintf("%d B", size)
+	case size < 1<<20:
+		return fmt.Sprintf("%d KB", (size+1<<10-1)>>10)
+	default:
+		return strings.Replace(fmt.Sprintf("%.1f MB", float64(size)/(1<<20)), ".", ",", 1)
+	}
+}
+
+// exportStatusTemplates are the templates for the progress of a PDF export on the tasks page.
+// Unfinished exports poll their own status until the download link replaces them; without
+// JavaScript, a link reloads it.
+var exportStatusTemplates = localizedTemplates("exportStatus", `{{if eq .Status "done"}}<p role="status" class="text-sm text-green-700 dark:text-green-400">
+		{{t "export.ready"}} <a href="/web/exports/{{.ID}}/download" class="font-medium underline hover:text-green-800 dark:hover:text-green-300">{{t "export.download"}}</a>
+	</p>{{else if eq .Status "failed"}}<p role="alert" class="text-sm text-red-600 dark:text-red-400">{{t "export.failed"}}</p>{{else}}<p role="status" class="text-sm text-gray-600 dark:text-gray-400"
+		hx-get="/web/exports/{{.ID}}" hx-trigger="every 2s" hx-swap="outerHTML">{{t "export.generating"}}
+		<noscript><a href="/web/exports/{{.ID}}" class="underline">{{t "export.refresh"}}</a></noscript></p>{{end}}`)
+
+// renderExportStatus renders the progress of a PDF export
+func renderExportStatus(job *application.ExportJob, locale application.Locale) (string, error) {
+	var buf bytes.Buffer
+	if err := localizedTemplate(exportStatusTemplates, locale).Execute(&buf, job); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// adminUserRowsTemplates are the templates for the rows of the users table on the admin
+// dashboard. Each action replaces its row, and the last row loads the next page in its place.
+var adminUserRowsTemplates = localizedTemplates("adminUserRows", `{{$current := .CurrentUserID}}{{range .Users}}<tr id="admin-user-{{.ID}}">
+		<td class="py-2 pr-4"><span id="admin-user-{{.ID}}-name">{{.Name}}</span></td>
+		<td class="py-2 pr-4 break-all">{{.Email}}</td>
+		<td class="py-2 pr-4">{{.Role}}</td>
+		<td class="py-2 pr-4">{{if .Disabled}}<span class="text-red-700 dark:text-red-400">{{t "admin.disabled"}}</span>{{else}}<span class="text-green-700 dark:text-green-400">{{t "admin.active"}}</span>{{end}}</td>
+		<td class="py-2">{{if eq .ID $current}}<span class="text-gray-500 dark:text-gray-400">{{t "admin.you"}}</span>{{else}}<div class="flex flex-wrap gap-2">
+			{{if .Disabled}}<form method="post" action="/web/admin/users/{{.ID}}/enable"
+				  hx-post="/web/admin/users/{{.ID}}/enable" hx-target="#admin-user-{{.ID}}" hx-swap="outerHTML">
+			<button type="submit" aria-describedby="admin-user-{{.ID}}-name"
+					class="px-2 py-1 border border-gray-300 dark:border-gray-600 rounded-md text-xs text-gray-700 dark:text-gray-300 hover:bg-gray-100 dark:hover:bg-gray-700 focus:outline-none focus:ring-2 focus:ring-gray-500">{{t "admin.enable"}}</button>
+			</form>{{else}}<form method="post" action="/web/admin/users/{{.ID}}/disable"
+				  hx-post="/web/admin/users/{{.ID}}/disable" hx-target="#admin-user-{{.ID}}" hx-swap="outerHTML">
+			<button type="submit" aria-describedby="admin-user-{{.ID}}-name"
+					class="px-2 py-1 border border-gray-300 dark:border-gray-600 rounded-md text-xs text-gray-700 dark:text-gray-300 hover:bg-gray-100 dark:hover:bg-gray-700 focus:outline-none focus:ring-2 focus:ring-gray-500">{{t "admin.disable"}}</button>
+			</form>{{end}}
+			<form method="post" action="/web/admin/users/{{.ID}}/password"
+				  hx-post="/web/admin/users/{{.ID}}/password" hx-target="#admin-user-{{.ID}}" hx-swap="outerHTML"
+				  hx-confirm="{{t "admin.reset_password_confirm" .Email}}">
+			<button type="submit" aria-describedby="admin-user-{{.ID}}-name"
+					class="px-2 py-1 border border-gray-300 dark:border-gray-600 rounded-md text-xs text-gray-700 dark:text-gray-300 hover:bg-gray-100 dark:hover:bg-gray-700 focus:outline-none focus:ring-2 focus:ring-gray-500">{{t "admin.reset_password"}}</button>
+			</form>
+			<form method="post" action="/web/admin/users/{{.ID}}?_method=DELETE"
+				  hx-delete="/web/admin/users/{{.ID}}" hx-target="#admin-user-{{.ID}}" hx-swap="outerHTML"
+				  hx-confirm="{{t "admin.delete_confirm" .Email}}">
+			<button type="submit" aria-describedby="admin-user-{{.ID}}-name"
+					class="px-2 py-1 bg-red-600 text-white rounded-md text-xs hover:bg-red-700 focus:outline-none focus:ring-2 focus:ring-red-500">{{t "admin.delete"}}</button>
+			</form>
+		</div>{{end}}</td>
+	</tr>{{end}}{{with .TemporaryPassword}}<tr id="admin-user-{{$.TemporaryPasswordUser.ID}}-password">
+		<td colspan="5" class="py-2"><p role="status" class="text-sm text-blue-800 dark:text-blue-300">{{t "admin.temporary_password" $.TemporaryPasswordUser.Email .}}</p></td>
+	</tr>{{end}}{{if .NextOffset}}<tr id="admin-users-more">
+		<td colspan="5" class="py-2 text-center">
+			<a href="/admin?offset={{.NextOffset}}" hx-get="/web/admin/users?offset={{.NextOffset}}" hx-target="#admin-users-more" hx-swap="outerHTML"
+			   class="font-medium text-blue-600 hover:text-blue-500">{{t "admin.load_more"}}</a>
+		</td>
+	</tr>{{end}}`)
+
+// adminUserRows is what the rows of the users table on the admin dashboard show
+type adminUserRows struct {
+	Users                 []*application.User
+	CurrentUserID         string
+	NextOffset            int
+	TemporaryPassword     string            // shown once below the row of TemporaryPasswordUser
+	TemporaryPasswordUser *application.User // after a password reset
+}
+
+// renderAdminUserRows renders rows of the users table on the admin dashboard
+func renderAdminUserRows(rows adminUserRows, locale application.Locale) (string, error) {
+	var buf bytes.Buffer
+	if err := localizedTemplate(adminUserRowsTemplates, locale).Execute(&buf, rows); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// shareLinksTemplates are the templates for the public links of a task in its share menu. Only
+// a link just created has its URL: the others can be revoked but not copied again.
+var shareLinksTemplates = localizedTemplates("shareLinks", `{{range .Links}}<div id="share-link-item-{{.ID}}" class="p-3 border border-gray-200 dark:border-gray-700 rounded-md space-y-2">
+		{{if .URL}}<label for="share-link-item-{{.ID}}-url" class="block text-sm font-medium text-gray-700 dark:text-gray-300">{{t "share_link.url"}}</label>
+		<div class="flex space-x-2">
+			<input type="text" id="share-link-item-{{.ID}}-url" value="{{.URL}}" readonly onfocus="this.select()"
+				   class="flex-1 px-3 py-2 border border-gray-300 dark:border-gray-600 dark:bg-gray-700 dark:text-gray-100 rounded-md text-sm">
+			<button type="button" onclick="copyShareLink('share-link-item-{{.ID}}-url', this)" data-copied="{{t "share_link.copied"}}"
+					class="px-4 py-2 bg-blue-600 text-white rounded-md hover:bg-blue-700" aria-live="polite">{{t "share_link.copy"}}</button>
+		</div>{{end}}
+		<div class="flex items-center justify-between text-sm text-gray-500 dark:text-gray-400">
+			<span id="share-link-item-{{.ID}}-dates">{{t "share_link.dates" .CreatedAt .ExpiresAt}}</span>
+			<form method="post" action="/web/share-links/{{.ID}}?_method=DELETE"
+				  hx-delete="/web/share-links/{{.ID}}" hx-target="#share-link-item-{{.ID}}" hx-swap="outerHTML"
+				  hx-confirm="{{t "share_link.revoke_confirm"}}">
+			<button type="submit" aria-describedby="share-link-item-{{.ID}}-dates" class="text-red-600 hover:text-red-800">{{t "share_link.revoke"}}</button>
+			</form>
+		</div>
+	</div>{{end}}{{if .Listing}}<p class="hidden only:block text-sm text-gray-500 dark:text-gray-400">{{t "share_link.none"}}</p>{{end}}`)
+
+// shareLinkItem is a public link as its share menu shows it
+type shareLinkItem struct {
+	ID        string
+	URL       string // only set for a link just created
+	CreatedAt string
+	ExpiresAt string
+}
+
+// shareLinkList is what the share menu of a task shows of its public links. The listing ends
+// with a message shown while it has no links; a link just created is prepended to it.
+type shareLinkList struct {
+	Links   []shareLinkItem
+	Listing bool
+}
+
+// renderShareLinks renders public links of a task for its share menu
+func renderShareLinks(list shareLinkList, locale application.Locale) (string, error) {
+	var buf bytes.Buffer
+	if err := localizedTemplate(shareLinksTemplates, locale).Execute(&buf, list); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// shareResultsTemplates are the templates for the outcome of sharing a task with the users picked
+// in its share form, listed below the form. The users picked are cleared from the form.
+var shareResultsTemplates = localizedTemplates("shareResults", `<div role="status" class="text-sm">
+		{{if .Invited}}<p class="text-green-700 dark:text-green-400">{{t "share.success"}}</p>{{end}}
+		<ul class="mt-1 space-y-1">
+			{{range .Results}}
+			{{if .Error}}<li class="text-red-600 dark:text-red-400">{{t "share.not_invited" .Label (translateError .Error)}}</li>
+			{{else}}<li class="text-gray-700 dark:text-gray-300">{{t "share.invited" .Label}}</li>{{end}}
+			{{end}}
+		</ul>
+	</div>
+	<ul id="share-recipients-{{.TaskID}}" hx-swap-oob="innerHTML"></ul>`)
+
+// shareResultItem is the outcome for a user picked in the share form of a task. Error is empty
+// when the user was invited.
+type shareResultItem struct {
+	Label string
+	Error string
+}
+
+// shareResultList is the outcome of sharing a task with the users picked in its share form
+type shareResultList struct {
+	TaskID  string
+	Invited bool // whether any user was invited
+	Results []shareResultItem
+}
+
+// renderShareResults renders the outcome of sharing a task with the users picked in its share form
+func renderShareResults(list shareResultList, locale application.Locale) (string, error) {
+	var buf bytes.Buffer
+	if err := localizedTemplate(shareResultsTemplates, locale).Execute(&buf, list); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// taskSharesTemplates are the templates for the panel listing who a task is shared with, appended
+// to the page over everything else. Each user can be unshared from it.
+var taskSharesTemplates = localizedTemplates("taskShares", `<div id="shares-modal" class="fixed inset-0 z-50 flex items-center justify-center bg-black/50 p-4"
+		 role="dialog" aria-modal="true" aria-labelledby="shares-modal-title" onclick="if (event.target === this) closeSharesModal()">
+		<div class="bg-white dark:bg-gray-800 rounded-lg shadow-xl w-full max-w-md p-6">
+			<div class="flex items-center justify-between">
+				<h3 id="shares-modal-title" class="text-lg font-semibold text-gray-900 dark:text-gray-100">{{t "shares.heading"}}</h3>
+				<button type="button" onclick="closeSharesModal()" autofocus
+						class="text-gray-500 hover:text-gray-700 dark:text-gray-400 dark:hover:text-gray-200">{{t "shares.close"}}</button>
+			</div>
+			<ul class="mt-4 divide-y divide-gray-200 dark:divide-gray-700" aria-live="polite">
+				{{range .Users}}
+				<li id="task-share-{{$.TaskID}}-{{.UserID}}" class="py-3 flex items-center justify-between space-x-4">
+					<div class="min-w-0">
+						<p id="task-share-{{$.TaskID}}-{{.UserID}}-name" class="text-sm font-medium text-gray-900 dark:text-gray-100 truncate">{{.Name}}</p>
+						<p class="text-sm text-gray-500 dark:text-gray-400 truncate">{{.Email}}</p>
+						<p class="text-xs text-gray-400 dark:text-gray-500">{{t "shares.since" .SharedAt}}</p>
+					</div>
+					<form method="post" action="/web/tasks/{{$.TaskID}}/shares/{{.UserID}}?_method=DELETE"
+						  hx-delete="/web/tasks/{{$.TaskID}}/shares/{{.UserID}}" hx-target="#task-share-{{$.TaskID}}-{{.UserID}}" hx-swap="outerHTML"
+						  hx-confirm="{{t "shares.remove_confirm" .Name}}">
+					<button type="submit" aria-describedby="task-share-{{$.TaskID}}-{{.UserID}}-name" class="text-sm text-red-600 hover:text-red-800">{{t "shares.remove"}}</button>
+					</form>
+				</li>
+				{{else}}
+				<li class="py-3 text-sm text-gray-500 dark:text-gray-400">{{t "shares.none"}}</li>
+				{{end}}
+			</ul>
+		</div>
+	</div>`)
+
+// taskShareItem is a user a task is shared with, as its share panel shows them
+type taskShareItem struct {
+	UserID   string
+	Name     string
+	Email    string
+	SharedAt string
+}
+
+// taskShareList is what the share panel of a task lists
+type taskShareList struct {
+	TaskID string
+	Users  []taskShareItem
+}
+
+// renderTaskShares renders the panel listing who a task is shared with
+func renderTaskShares(list taskShareList, locale application.Locale) (string, error) {
+	var buf bytes.Buffer
+	if err := localizedTemplate(taskSharesTemplates, locale).Execute(&buf, list); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// taskRevisionsTemplates are the templates for the panel listing the edits of a task, newest
+// first, appended to the page over everything else. Each edit shows what it removed and added.
+var taskRevisionsTemplates = localizedTemplates("taskRevisions", `<div id="revisions-modal" class="fixed inset-0 z-50 flex items-center justify-center bg-black/50 p-4"
+		 role="dialog" aria-modal="true" aria-labelledby="revisions-modal-title" onclick="if (event.target === this) closeRevisionsModal()">
+		<div class="bg-white dark:bg-gray-800 rounded-lg shadow-xl w-full max-w-2xl max-h-full overflow-y-auto p-6">
+			<div class="flex items-center justify-between">
+				<h3 id="revisions-modal-title" class="text-lg font-semibold text-gray-900 dark:text-gray-100">{{t "revisions.heading" .Title}}</h3>
+				<button type="button" onclick="closeRevisionsModal()" autofocus
+						class="text-gray-500 hover:text-gray-700 dark:text-gray-400 dark:hover:text-gray-200">{{t "revisions.close"}}</button>
+			</div>
+			<ol class="mt-4 divide-y divide-gray-200 dark:divide-gray-700">
+				{{range .Changes}}
+				<li class="py-3 space-y-1 text-sm text-gray-700 dark:text-gray-300">
+					<p class="text-xs text-gray-500 dark:text-gray-400">{{if .EditorName}}{{t "revisions.by" .EditorName .EditedAt}}{{else}}{{.EditedAt}}{{end}}</p>
+					{{if .Title}}<p class="font-medium text-gray-900 dark:text-gray-100">{{.Title}}</p>{{end}}
+					{{if .Description}}<p class="whitespace-pre-wrap">{{.Description}}</p>{{end}}
+					{{if .StatusBefore}}<p><del class="bg-red-100 text-red-800 dark:bg-red-900 dark:text-red-200">{{.StatusBefore}}</del> → <ins class="no-underline bg-green-100 text-green-800 dark:bg-green-900 dark:text-green-200">{{.StatusAfter}}</ins></p>{{end}}
+				</li>
+				{{else}}
+				<li class="py-3 text-sm text-gray-500 dark:text-gray-400">{{t "revisions.none"}}</li>
+				{{end}}
+			</ol>
+		</div>
+	</div>`)
+
+// taskRevisionItem is an edit of a task as its history panel shows it. Title and Description
+// hold the word diff of the field, and are empty when the edit left it as it was; so are the
+// status labels.
+type taskRevisionItem struct {
+	EditorName   string
+	EditedAt     string
+	Title        template.HTML
+	Description  template.HTML
+	StatusBefore string
+	StatusAfter  string
+}
+
+// taskRevisionList is what the history panel of a task lists
+type taskRevisionList struct {
+	Title   string
+	Changes []taskRevisionItem
+}
+
+// renderTaskRevisions renders the panel listing the edits of a task
+func renderTaskRevisions(list taskRevisionList, locale application.Locale) (string, error) {
+	var buf bytes.Buffer
+	if err := localizedTemplate(taskRevisionsTemplates, locale).Execute(&buf, list); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// renderWordDiff renders the word diff from old to new, the removed words struck through and
+// the added ones highlighted. It's empty when both are the same.
+func renderWordDiff(old, new string) template.HTML {
+	if old == new {
+		return ""
 	}
+	var b strings.Builder
+	for _, op := range textdiff.Words(old, new) {
+		text := template.HTMLEscapeString(op.Text)
+		switch op.Kind {
+		case textdiff.Deleted:
+			b.WriteString(`<del class="bg-red-100 text-red-800 dark:bg-red-900 dark:text-red-200">` + text + `</del>`)
+		case textdiff.Inserted:
+			b.WriteString(`<ins class="no-underline bg-green-100 text-green-800 dark:bg-green-900 dark:text-green-200">` + text + `</ins>`)
+		default:
+			b.WriteString(text)
+		}
+	}
+	return template.HTML(b.String())
+}
+
+// taskCountersTemplates are the templates for the task counters in the navbar. The overdue
+// counter only stands out when there are overdue tasks.
+var taskCountersTemplates = localizedTemplates("taskCounters", `<a href="/tasks" class="flex items-center gap-1 text-xs font-medium" aria-label="{{t "counters.label" .Pending .Overdue .Shared}}">
+		<span class="px-2 py-0.5 rounded-full bg-blue-100 text-blue-800 dark:bg-blue-900 dark:text-blue-200" title="{{t "counters.pending"}}">{{.Pending}}</span>
+		<span class="px-2 py-0.5 rounded-full {{if .Overdue}}bg-red-100 text-red-800 dark:bg-red-900 dark:text-red-200{{else}}bg-gray-100 text-gray-600 dark:bg-gray-700 dark:text-gray-300{{end}}" title="{{t "counters.overdue"}}">{{.Overdue}}</span>
+		<span class="px-2 py-0.5 rounded-full bg-purple-100 text-purple-800 dark:bg-purple-900 dark:text-purple-200" title="{{t "counters.shared"}}">{{.Shared}}</span>
+	</a>`)
 
+// renderTaskCounters renders the task counters shown in the navbar
+func renderTaskCounters(counters *application.TaskCounters, locale application.Locale) (string, error) {
 	var buf bytes.Buffer
-	if err := completedTaskTemplate.Execute(&buf, data); err != nil {
+	if err := localizedTemplate(taskCountersTemplates, locale).Execute(&buf, counters); err != nil {
 		return "", err
 	}
+	return buf.String(), nil
+}
+
+// orgSwitcherTemplates are the templates for the workspace selector in the navbar. Without
+// JavaScript the form is submitted with its button.
+var orgSwitcherTemplates = localizedTemplates("orgSwitcher", `<form method="post" action="/web/preferences/org" class="flex items-center space-x-1">
+		<select name="org" aria-label="{{t "org.label"}}" title="{{t "org.label"}}" hx-post="/web/preferences/org" hx-trigger="change"
+				class="text-sm border border-gray-300 dark:border-gray-600 dark:bg-gray-700 dark:text-gray-100 rounded-md px-2 py-1">
+			<option value="">{{t "org.personal"}}</option>
+			{{range .Memberships}}<option value="{{.Org.ID}}"{{if eq .Org.ID $.Current}} selected{{end}}>{{.Org.Name}}</option>
+			{{end}}
+		</select>
+		<noscript><button type="submit" class="text-sm text-gray-700 dark:text-gray-300 underline">{{t "language.apply"}}</button></noscript>
+	</form>`)
 
+// renderOrgSwitcher renders the workspace selector shown in the navbar, with the current org
+// selected
+func renderOrgSwitcher(memberships []*application.OrgMembership, current string, locale application.Locale) (string, error) {
+	var buf bytes.Buffer
+	data := map[string]any{"Memberships": memberships, "Current": current}
+	if err := localizedTemplate(orgSwitcherTemplates, locale).Execute(&buf, data); err != nil {
+		return "", err
+	}
 	return buf.String(), nil
 }
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// ThemeHandler handles the theme preference of the web interface
+type ThemeHandler struct {
+	updateTheme usecases.UpdateUserThemeUseCaseInterface
+	pages       *PageTemplates
+	cookies     CookieConfig
+}
+
+// NewThemeHandler creates a new ThemeHandler
+func NewThemeHandler(updateTheme usecases.UpdateUserThemeUseCaseInterface, pages *PageTemplates, cookies CookieConfig) *ThemeHandler {
+	return &ThemeHandler{
+		updateTheme: updateTheme,
+		pages:       pages,
+		cookies:     cookies,
+	}
+}
+
+// UpdateTheme handles POST /web/preferences/theme, persisting the theme chosen in the navbar toggle
+func (h *ThemeHandler) UpdateTheme(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok || userID == "" {
+		h.pages.writeWebError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	theme := r.FormValue("theme")
+	if err := h.updateTheme.Execute(r.Context(), userID, theme); err != nil {
+		if errors.Is(err, application.ErrInvalidTheme) {
+			h.pages.writeWebError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Failed to update theme")
+		return
+	}
+
+	// Keep the cookie in sync so the login page uses the same theme after logout
+	http.SetCookie(w, h.cookies.createThemeCookie(application.Theme(theme)))
+	h.pages.writeWebFragment(w, r, http.StatusNoContent, "")
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+type mockUpdateUserThemeUseCase struct {
+	executeFunc func(ctx context.Context, userID, theme string) error
+}
+
+func (m *mockUpdateUserThemeUseCase) Execute(ctx context.Context, userID, theme string) error {
+	return m.executeFunc(ctx, userID, theme)
+}
+
+func postTheme(h *ThemeHandler, theme string, withUser bool) *httptest.ResponseRecorder {
+	req := htmx(httptest.NewRequest("POST", "/web/preferences/theme", strings.NewReader("theme="+theme)))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if withUser {
+		req = req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+	}
+	w := httptest.NewRecorder()
+	h.UpdateTheme(w, req)
+	return w
+}
+
+func TestUpdateTheme_Success(t *testing.T) {
+	h := NewThemeHandler(&mockUpdateUserThemeUseCase{
+		executeFunc: func(ctx context.Context, userID, theme string) error {
+			if userID != "user-123" || theme != "dark" {
+				t.Errorf("Unexpected arguments %q, %q", userID, theme)
+			}
+			return nil
+		},
+	}, NewPageTemplates(""), DefaultCookieConfig())
+
+	w := postTheme(h, "dark", true)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d", w.Code)
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != ThemeCookieName || cookies[0].Value != "dark" {
+		t.Errorf("Expected theme cookie set to dark, got %v", cookies)
+	}
+}
+
+func TestUpdateTheme_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		withUser   bool
+		wantStatus int
+	}{
+		{"unauthorized", nil, false, http.StatusUnauthorized},
+		{"invalid theme", application.ErrInvalidTheme, true, http.StatusBadRequest},
+		{"repository failure", errors.New("db down"), true, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewThemeHandler(&mockUpdateUserThemeUseCase{
+				executeFunc: func(ctx context.Context, userID, theme string) error {
+					return tt.err
+				},
+			}, NewPageTemplates(""), DefaultCookieConfig())
+
+			w := postTheme(h, "neon", tt.withUser)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if len(w.Result().Cookies()) != 0 {
+				t.Error("Expected no cookie on failure")
+			}
+		})
+	}
+}
+
+func TestThemeFromRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		cookie string
+		want   application.Theme
+	}{
+		{"no cookie", "", application.ThemeLight},
+		{"dark cookie", "dark", application.ThemeDark},
+		{"invalid cookie", "<script>", application.ThemeLight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/login", nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: ThemeCookieName, Value: tt.cookie})
+			}
+			if got := ThemeFromRequest(req); got != tt.want {
+				t.Errorf("ThemeFromRequest() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
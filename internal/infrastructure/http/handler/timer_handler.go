@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// TimerHandler handles HTTP requests for task time tracking
+type TimerHandler struct {
+	startTimer  usecases.StartTimerUseCaseInterface
+	stopTimer   usecases.StopTimerUseCaseInterface
+	getTaskTime usecases.GetTaskTimeUseCaseInterface
+	pages       *PageTemplates
+}
+
+// NewTimerHandler creates a new TimerHandler
+func NewTimerHandler(
+	startTimer usecases.StartTimerUseCaseInterface,
+	stopTimer usecases.StopTimerUseCaseInterface,
+	getTaskTime usecases.GetTaskTimeUseCaseInterface,
+	pages *PageTemplates,
+) *TimerHandler {
+	return &TimerHandler{
+		startTimer:  startTimer,
+		stopTimer:   stopTimer,
+		getTaskTime: getTaskTime,
+		pages:       pages,
+	}
+}
+
+// TaskTimeResponse is the JSON representation of the time tracked on a task
+type TaskTimeResponse struct {
+	TaskID         string     `json:"task_id"`
+	TrackedSeconds int64      `json:"tracked_seconds"` // By all users, up to now for the running timers
+	Tracked        string     `json:"tracked"`         // The same time, formatted like "2h 05m"
+	Running        bool       `json:"running"`         // Whether the timer of the user runs
+	RunningSince   *time.Time `json:"running_since,omitempty"`
+}
+
+func toTaskTimeResponse(taskTime *application.TaskTime) TaskTimeResponse {
+	return TaskTimeResponse{
+		TaskID:         taskTime.TaskID,
+		TrackedSeconds: int64(taskTime.Tracked / time.Second),
+		Tracked:        application.FormatDuration(taskTime.Tracked),
+		Running:        taskTime.IsRunning(),
+		RunningSince:   taskTime.RunningSince,
+	}
+}
+
+// StartTimer handles POST /api/tasks/{id}/timer/start
+func (h *TimerHandler) StartTimer(w http.ResponseWriter, r *http.Request) {
+	h.writeTaskTime(w, r, h.startTimer.Execute)
+}
+
+// StopTimer handles POST /api/tasks/{id}/timer/stop
+func (h *TimerHandler) StopTimer(w http.ResponseWriter, r *http.Request) {
+	h.writeTaskTime(w, r, h.stopTimer.Execute)
+}
+
+// GetTimer handles GET /api/tasks/{id}/timer
+func (h *TimerHandler) GetTimer(w http.ResponseWriter, r *http.Request) {
+	h.writeTaskTime(w, r, h.getTaskTime.Execute)
+}
+
+// writeTaskTime runs a timer use case on the task of the request and writes the time tracked
+// on it
+func (h *TimerHandler) writeTaskTime(w http.ResponseWriter, r *http.Request, execute func(ctx context.Context, taskID, userID string) (*application.TaskTime, error)) {
+	userID := r.Context().Value("userID").(string)
+
+	taskTime, err := execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		status, message := timerErrorStatus(err)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toTaskTimeResponse(taskTime))
+}
+
+// WebStartTimer handles POST /web/tasks/{id}/timer/start, replacing the timer of the task card
+func (h *TimerHandler) WebStartTimer(w http.ResponseWriter, r *http.Request) {
+	h.writeWebTimer(w, r, h.startTimer.Execute)
+}
+
+// WebStopTimer handles POST /web/tasks/{id}/timer/stop, replacing the timer of the task card
+func (h *TimerHandler) WebStopTimer(w http.ResponseWriter, r *http.Request) {
+	h.writeWebTimer(w, r, h.stopTimer.Execute)
+}
+
+// writeWebTimer runs a timer use case on the task of the request and renders the timer of its
+// card
+func (h *TimerHandler) writeWebTimer(w http.ResponseWriter, r *http.Request, execute func(ctx context.Context, taskID, userID string) (*application.TaskTime, error)) {
+	userID := r.Context().Value("userID").(string)
+	locale := RequestLocale(r)
+
+	taskTime, err := execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		status, message := timerErrorStatus(err)
+		h.pages.writeWebError(w, r, status, i18n.Error(locale, message))
+		return
+	}
+
+	h.pages.writeWebFragment(w, r, http.StatusOK, string(renderTaskTimer(r.PathValue("id"), taskTime, locale)))
+}
+
+// timerErrorStatus maps a time tracking use case error to an HTTP status and client message
+func timerErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecases.ErrTaskUnavailable):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, application.ErrTimerRunning), errors.Is(err, application.ErrTimerNotRunning):
+		return http.StatusConflict, err.Error()
+	default:
+		log.Printf("timer operation failed: %v", err)
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
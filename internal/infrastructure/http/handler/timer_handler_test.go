@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockTimerUseCase struct {
+	taskTime *application.TaskTime
+	err      error
+}
+
+func (m *mockTimerUseCase) Execute(ctx context.Context, taskID, userID string) (*application.TaskTime, error) {
+	return m.taskTime, m.err
+}
+
+func newTimerRequest(method, path string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+	req.SetPathValue("id", "task-1")
+	return withUser(req)
+}
+
+func TestStartTimer(t *testing.T) {
+	since := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	running := &application.TaskTime{TaskID: "task-1", Tracked: 125 * time.Minute, RunningSince: &since}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"started", nil, http.StatusOK},
+		{"already running", application.ErrTimerRunning, http.StatusConflict},
+		{"task not visible", usecases.ErrTaskUnavailable, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewTimerHandler(&mockTimerUseCase{taskTime: running, err: tt.err}, &mockTimerUseCase{}, &mockTimerUseCase{}, NewPageTemplates(""))
+
+			w := httptest.NewRecorder()
+			h.StartTimer(w, newTimerRequest("POST", "/api/tasks/task-1/timer/start"))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.err != nil {
+				return
+			}
+
+			var resp TaskTimeResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("invalid response: %v", err)
+			}
+			if resp.TaskID != "task-1" || resp.TrackedSeconds != 7500 || resp.Tracked != "2h 05m" || !resp.Running || !resp.RunningSince.Equal(since) {
+				t.Errorf("response = %+v", resp)
+			}
+		})
+	}
+}
+
+func TestStopTimer_NotRunning(t *testing.T) {
+	h := NewTimerHandler(&mockTimerUseCase{}, &mockTimerUseCase{err: application.ErrTimerNotRunning}, &mockTimerUseCase{}, NewPageTemplates(""))
+
+	w := httptest.NewRecorder()
+	h.StopTimer(w, newTimerRequest("POST", "/api/tasks/task-1/timer/stop"))
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("status = %d, want 409", w.Code)
+	}
+}
+
+func TestGetTimer_Stopped(t *testing.T) {
+	h := NewTimerHandler(&mockTimerUseCase{}, &mockTimerUseCase{}, &mockTimerUseCase{taskTime: &application.TaskTime{TaskID: "task-1", Tracked: 45 * time.Minute}}, NewPageTemplates(""))
+
+	w := httptest.NewRecorder()
+	h.GetTimer(w, newTimerRequest("GET", "/api/tasks/task-1/timer"))
+
+	var raw map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&raw); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if raw["tracked"] != "45m" || raw["running"] != false || raw["running_since"] != nil {
+		t.Errorf("response = %v", raw)
+	}
+}
+
+func TestWebTimer_RendersFragment(t *testing.T) {
+	since := time.Now()
+	h := NewTimerHandler(
+		&mockTimerUseCase{taskTime: &application.TaskTime{TaskID: "task-1", RunningSince: &since}},
+		&mockTimerUseCase{taskTime: &application.TaskTime{TaskID: "task-1", Tracked: 3 * time.Minute}},
+		&mockTimerUseCase{},
+		NewPageTemplates(""),
+	)
+
+	w := httptest.NewRecorder()
+	h.WebStartTimer(w, htmx(newTimerRequest("POST", "/web/tasks/task-1/timer/start")))
+	if body := w.Body.String(); !strings.Contains(body, `id="task-task-1-timer"`) || !strings.Contains(body, `hx-post="/web/tasks/task-1/timer/stop"`) {
+		t.Errorf("Expected a running timer with a stop button:\n%s", body)
+	}
+
+	w = httptest.NewRecorder()
+	h.WebStopTimer(w, htmx(newTimerRequest("POST", "/web/tasks/task-1/timer/stop")))
+	if body := w.Body.String(); !strings.Contains(body, "Tempo: 3m") || !strings.Contains(body, `hx-post="/web/tasks/task-1/timer/start"`) {
+		t.Errorf("Expected the tracked time with a start button:\n%s", body)
+	}
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// TwoFactorHandler handles the enrollment of TOTP two-factor authentication and the second
+// step of password logins of the users who enabled it
+type TwoFactorHandler struct {
+	verify         usecases.VerifyTwoFactorUseCaseInterface
+	enroll         usecases.BeginTwoFactorEnrollmentUseCaseInterface
+	enable         usecases.EnableTwoFactorUseCaseInterface
+	disable        usecases.DisableTwoFactorUseCaseInterface
+	status         usecases.GetTwoFactorStatusUseCaseInterface
+	taskListWarmer TaskListWarmer
+	recordLogin    usecases.RecordLoginEventUseCaseInterface
+	pages          *PageTemplates
+	cookies        CookieConfig
+}
+
+// NewTwoFactorHandler creates a new TwoFactorHandler. taskListWarmer and recordLogin are optional.
+func NewTwoFactorHandler(
+	verify usecases.VerifyTwoFactorUseCaseInterface,
+	enroll usecases.BeginTwoFactorEnrollmentUseCaseInterface,
+	enable usecases.EnableTwoFactorUseCaseInterface,
+	disable usecases.DisableTwoFactorUseCaseInterface,
+	status usecases.GetTwoFactorStatusUseCaseInterface,
+	taskListWarmer TaskListWarmer,
+	recordLogin usecases.RecordLoginEventUseCaseInterface,
+	pages *PageTemplates,
+	cookies CookieConfig,
+) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		verify:         verify,
+		enroll:         enroll,
+		enable:         enable,
+		disable:        disable,
+		status:         status,
+		taskListWarmer: taskListWarmer,
+		recordLogin:    recordLogin,
+		pages:          pages,
+		cookies:        cookies,
+	}
+}
+
+// VerifyTwoFactorRequest represents the second step of a login
+type VerifyTwoFactorRequest struct {
+	TwoFactorToken string `json:"two_factor_token"`
+	Code           string `json:"code"`
+}
+
+// TwoFactorCodeRequest represents a request confirmed with a TOTP or recovery code
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// TwoFactorStatusResponse represents the two-factor status of the user
+type TwoFactorStatusResponse struct {
+	Enabled           bool `json:"enabled"`
+	RecoveryCodesLeft int  `json:"recovery_codes_left"`
+}
+
+// TwoFactorEnrollmentResponse represents a pending enrollment. qr_code is a PNG data URI of
+// the provisioning URI.
+type TwoFactorEnrollmentResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+	QRCode string `json:"qr_code"`
+}
+
+// RecoveryCodesResponse represents the recovery codes issued when two-factor is enabled
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// Verify handles POST /api/auth/2fa/verify, answering the challenge returned by the login
+// API with a TOTP or recovery code
+func (h *TwoFactorHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	var req VerifyTwoFactorRequest
+	if !decodeJSON(w, r, &req, maxJSONBodySize) {
+		return
+	}
+
+	session, err := h.verify.Execute(r.Context(), req.TwoFactorToken, req.Code)
+	if err != nil {
+		status, message := twoFactorErrorStatus(err, http.StatusUnauthorized)
+		writeAPIError(w, r, status, message)
+		return
+	}
+	recordLoginAttempt(r, h.recordLogin, session.Email, true)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(LoginResponse{Token: session.Token, ExpiresAt: session.ExpiresAt})
+}
+
+// WebVerify handles the code form that replaces the web login form, setting the auth cookie
+// once the code verifies
+func (h *TwoFactorHandler) WebVerify(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.pages.writeFormErrors(w, r, http.StatusBadRequest, twoFactorForm, formError("", "Invalid form data"))
+		return
+	}
+
+	session, err := h.verify.Execute(r.Context(), r.FormValue("two_factor_token"), r.FormValue("code"))
+	if err != nil {
+		status, message := twoFactorErrorStatus(err, http.StatusUnauthorized)
+		// A dead challenge can't be retried: send the user back to the password step
+		if errors.Is(err, application.ErrTwoFactorChallengeExpired) {
+			w.Header().Set("HX-Redirect", "/login")
+		}
+		h.pages.writeFormErrors(w, r, status, twoFactorForm, formError("", message))
+		return
+	}
+	recordLoginAttempt(r, h.recordLogin, session.Email, true)
+
+	startWebSession(w, r, h.cookies, session, h.taskListWarmer)
+}
+
+// Status handles GET /api/auth/2fa
+func (h *TwoFactorHandler) Status(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	status, err := h.status.Execute(r.Context(), userID)
+	if err != nil {
+		code, message := twoFactorErrorStatus(err, http.StatusBadRequest)
+		writeAPIError(w, r, code, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(TwoFactorStatusResponse{
+		Enabled:           status.Enabled,
+		RecoveryCodesLeft: status.RecoveryCodesLeft,
+	})
+}
+
+// Setup handles POST /api/auth/2fa/setup, generating the secret to add to an authenticator app
+func (h *TwoFactorHandler) Setup(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	enrollment, err := h.enroll.Execute(r.Context(), userID)
+	if err != nil {
+		status, message := twoFactorErrorStatus(err, http.StatusBadRequest)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(TwoFactorEnrollmentResponse{
+		Secret: enrollment.Secret,
+		URI:    enrollment.URI,
+		QRCode: "data:image/png;base64," + base64.StdEncoding.EncodeToString(enrollment.QRCodePNG),
+	})
+}
+
+// Enable handles POST /api/auth/2fa/enable, confirming the enrollment with a first code and
+// returning the recovery codes
+func (h *TwoFactorHandler) Enable(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req TwoFactorCodeRequest
+	if !decodeJSON(w, r, &req, maxJSONBodySize) {
+		return
+	}
+
+	codes, err := h.enable.Execute(r.Context(), userID, req.Code)
+	if err != nil {
+		status, message := twoFactorErrorStatus(err, http.StatusBadRequest)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// Disable handles POST /api/auth/2fa/disable, which takes a TOTP or recovery code
+func (h *TwoFactorHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req TwoFactorCodeRequest
+	if !decodeJSON(w, r, &req, maxJSONBodySize) {
+		return
+	}
+
+	if err := h.disable.Execute(r.Context(), userID, req.Code); err != nil {
+		status, message := twoFactorErrorStatus(err, http.StatusBadRequest)
+		writeAPIError(w, r, status, message)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// twoFactorErrorStatus maps a two-factor error to an HTTP status and client message. Wrong
+// codes use failureStatus: 401 on login, 400 when an authenticated user confirms a change.
+func twoFactorErrorStatus(err error, failureStatus int) (int, string) {
+	switch {
+	case errors.Is(err, application.ErrInvalidTwoFactorCode):
+		return failureStatus, err.Error()
+	case errors.Is(err, application.ErrTwoFactorChallengeExpired):
+		return http.StatusUnauthorized, err.Error()
+	case errors.Is(err, application.ErrUserDisabled):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, application.ErrTwoFactorAlreadyEnabled),
+		errors.Is(err, application.ErrTwoFactorNotEnabled),
+		errors.Is(err, application.ErrTwoFactorNotEnrolled):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, application.ErrUserNotFound):
+		return http.StatusNotFound, err.Error()
+	default:
+		log.Printf("two-factor operation failed: %v", err)
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockVerifyTwoFactorUseCase struct {
+	token, code string
+	err         error
+}
+
+func (m *mockVerifyTwoFactorUseCase) Execute(ctx context.Context, token, code string) (*usecases.LoginResult, error) {
+	m.token, m.code = token, code
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &usecases.LoginResult{Token: "session-token", Duration: time.Hour, ExpiresAt: time.Now().Add(time.Hour), Email: "ana@example.com"}, nil
+}
+
+type mockBeginTwoFactorEnrollmentUseCase struct {
+	err error
+}
+
+func (m *mockBeginTwoFactorEnrollmentUseCase) Execute(ctx context.Context, userID string) (*usecases.TwoFactorEnrollment, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &usecases.TwoFactorEnrollment{Secret: "JBSWY3DPEHPK3PXP", URI: "otpauth://totp/Todo%20App:ana@example.com?secret=JBSWY3DPEHPK3PXP", QRCodePNG: []byte("png")}, nil
+}
+
+type mockEnableTwoFactorUseCase struct {
+	code string
+	err  error
+}
+
+func (m *mockEnableTwoFactorUseCase) Execute(ctx context.Context, userID, code string) ([]string, error) {
+	m.code = code
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []string{"aaaaa-bbbbb", "ccccc-ddddd"}, nil
+}
+
+type mockDisableTwoFactorUseCase struct {
+	err error
+}
+
+func (m *mockDisableTwoFactorUseCase) Execute(ctx context.Context, userID, code string) error {
+	return m.err
+}
+
+type mockGetTwoFactorStatusUseCase struct {
+	status *usecases.TwoFactorStatus
+}
+
+func (m *mockGetTwoFactorStatusUseCase) Execute(ctx context.Context, userID string) (*usecases.TwoFactorStatus, error) {
+	if m.status == nil {
+		return &usecases.TwoFactorStatus{}, nil
+	}
+	return m.status, nil
+}
+
+func newTestTwoFactorHandler(verify *mockVerifyTwoFactorUseCase, audit *mockRecordLoginEventUseCase) *TwoFactorHandler {
+	return NewTwoFactorHandler(
+		verify,
+		&mockBeginTwoFactorEnrollmentUseCase{},
+		&mockEnableTwoFactorUseCase{},
+		&mockDisableTwoFactorUseCase{},
+		&mockGetTwoFactorStatusUseCase{},
+		nil,
+		audit,
+		NewPageTemplates(""),
+		DefaultCookieConfig(),
+	)
+}
+
+func TestTwoFactorVerify(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "code verified", wantStatus: http.StatusOK},
+		{name: "wrong code", err: application.ErrInvalidTwoFactorCode, wantStatus: http.StatusUnauthorized},
+		{name: "expired challenge", err: application.ErrTwoFactorChallengeExpired, wantStatus: http.StatusUnauthorized},
+		{name: "storage failure", err: errors.New("database is locked"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audit := &mockRecordLoginEventUseCase{}
+			verify := &mockVerifyTwoFactorUseCase{err: tt.err}
+			h := newTestTwoFactorHandler(verify, audit)
+
+			req := httptest.NewRequest("POST", "/api/auth/2fa/verify", strings.NewReader(`{"two_factor_token":"challenge-1","code":"123456"}`))
+			w := httptest.NewRecorder()
+			h.Verify(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if verify.token != "challenge-1" || verify.code != "123456" {
+				t.Errorf("Execute() got token %q and code %q", verify.token, verify.code)
+			}
+			if strings.Contains(w.Body.String(), "database is locked") {
+				t.Errorf("body = %s, want internal details kept out", w.Body.String())
+			}
+			if tt.err != nil {
+				if len(audit.recorded) != 0 {
+					t.Errorf("recorded = %+v, want nothing for a failed code", audit.recorded)
+				}
+				return
+			}
+
+			var response LoginResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil || response.Token != "session-token" {
+				t.Errorf("response = %+v, %v; want the session token", response, err)
+			}
+			if len(audit.recorded) != 1 || audit.recorded[0].email != "ana@example.com" || !audit.recorded[0].success {
+				t.Errorf("recorded = %+v, want a successful login of ana@example.com", audit.recorded)
+			}
+		})
+	}
+}
+
+func TestTwoFactorVerify_InvalidJSON(t *testing.T) {
+	h := newTestTwoFactorHandler(&mockVerifyTwoFactorUseCase{}, &mockRecordLoginEventUseCase{})
+
+	w := httptest.NewRecorder()
+	h.Verify(w, httptest.NewRequest("POST", "/api/auth/2fa/verify", strings.NewReader(`{`)))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", w.Code)
+	}
+}
+
+func TestTwoFactorWebVerify(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantStatus   int
+		wantRedirect string
+		wantCookie   bool
+	}{
+		{name: "code verified", wantStatus: http.StatusOK, wantRedirect: "/tasks", wantCookie: true},
+		{name: "wrong code can be retried", err: application.ErrInvalidTwoFactorCode, wantStatus: http.StatusUnauthorized},
+		{name: "expired challenge restarts the login", err: application.ErrTwoFactorChallengeExpired, wantStatus: http.StatusUnauthorized, wantRedirect: "/login"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestTwoFactorHandler(&mockVerifyTwoFactorUseCase{err: tt.err}, &mockRecordLoginEventUseCase{})
+
+			form := url.Values{"two_factor_token": {"challenge-1"}, "code": {"123456"}}
+			req := htmx(httptest.NewRequest("POST", "/web/auth/2fa", strings.NewReader(form.Encode())))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			h.WebVerify(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if got := w.Header().Get("HX-Redirect"); got != tt.wantRedirect {
+				t.Errorf("HX-Redirect = %q, want %q", got, tt.wantRedirect)
+			}
+
+			cookie := findCookie(w, AuthCookieName)
+			if tt.wantCookie && (cookie == nil || cookie.Value != "session-token") {
+				t.Errorf("auth cookie = %+v, want the session token", cookie)
+			}
+			if !tt.wantCookie && cookie != nil {
+				t.Errorf("auth cookie = %+v, want none", cookie)
+			}
+			if tt.err != nil && !strings.Contains(w.Body.String(), tt.err.Error()) {
+				t.Errorf("body = %s, want the error message", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestTwoFactorSetup(t *testing.T) {
+	h := newTestTwoFactorHandler(&mockVerifyTwoFactorUseCase{}, &mockRecordLoginEventUseCase{})
+
+	w := httptest.NewRecorder()
+	h.Setup(w, withUser(httptest.NewRequest("POST", "/api/auth/2fa/setup", nil)))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Cache-Control") != "no-store" {
+		t.Error("the secret must not be cached")
+	}
+
+	var response TwoFactorEnrollmentResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if response.Secret != "JBSWY3DPEHPK3PXP" || !strings.HasPrefix(response.URI, "otpauth://totp/") {
+		t.Errorf("response = %+v, want the secret and provisioning URI", response)
+	}
+	if response.QRCode != "data:image/png;base64,cG5n" {
+		t.Errorf("qr_code = %q, want a PNG data URI", response.QRCode)
+	}
+}
+
+func TestTwoFactorSetup_AlreadyEnabled(t *testing.T) {
+	h := newTestTwoFactorHandler(&mockVerifyTwoFactorUseCase{}, &mockRecordLoginEventUseCase{})
+	h.enroll = &mockBeginTwoFactorEnrollmentUseCase{err: application.ErrTwoFactorAlreadyEnabled}
+
+	w := httptest.NewRecorder()
+	h.Setup(w, withUser(httptest.NewRequest("POST", "/api/auth/2fa/setup", nil)))
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("status = %d, want 409", w.Code)
+	}
+}
+
+func TestTwoFactorEnable(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "enabled", wantStatus: http.StatusOK},
+		{name: "wrong code", err: application.ErrInvalidTwoFactorCode, wantStatus: http.StatusBadRequest},
+		{name: "not enrolled", err: application.ErrTwoFactorNotEnrolled, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enable := &mockEnableTwoFactorUseCase{err: tt.err}
+			h := newTestTwoFactorHandler(&mockVerifyTwoFactorUseCase{}, &mockRecordLoginEventUseCase{})
+			h.enable = enable
+
+			w := httptest.NewRecorder()
+			h.Enable(w, withUser(httptest.NewRequest("POST", "/api/auth/2fa/enable", strings.NewReader(`{"code":"654321"}`))))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if enable.code != "654321" {
+				t.Errorf("Execute() got code %q", enable.code)
+			}
+			if tt.err == nil && !strings.Contains(w.Body.String(), `"recovery_codes":["aaaaa-bbbbb","ccccc-ddddd"]`) {
+				t.Errorf("body = %s, want the recovery codes", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestTwoFactorDisable(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "disabled", wantStatus: http.StatusNoContent},
+		{name: "wrong code", err: application.ErrInvalidTwoFactorCode, wantStatus: http.StatusBadRequest},
+		{name: "not enabled", err: application.ErrTwoFactorNotEnabled, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestTwoFactorHandler(&mockVerifyTwoFactorUseCase{}, &mockRecordLoginEventUseCase{})
+			h.disable = &mockDisableTwoFactorUseCase{err: tt.err}
+
+			w := httptest.NewRecorder()
+			h.Disable(w, withUser(httptest.NewRequest("POST", "/api/auth/2fa/disable", strings.NewReader(`{"code":"654321"}`))))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestTwoFactorStatus(t *testing.T) {
+	h := newTestTwoFactorHandler(&mockVerifyTwoFactorUseCase{}, &mockRecordLoginEventUseCase{})
+	h.status = &mockGetTwoFactorStatusUseCase{status: &usecases.TwoFactorStatus{Enabled: true, RecoveryCodesLeft: 7}}
+
+	w := httptest.NewRecorder()
+	h.Status(w, withUser(httptest.NewRequest("GET", "/api/auth/2fa", nil)))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if body := strings.TrimSpace(w.Body.String()); body != `{"enabled":true,"recovery_codes_left":7}` {
+		t.Errorf("body = %s", body)
+	}
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// UndoHandler handles the web deletions and completions of tasks that can be undone for a
+// short while. It takes over the routes of WebTaskHandler.DeleteTask and CompleteTask when
+// undo is enabled.
+type UndoHandler struct {
+	trashTask    usecases.TrashTaskUseCaseInterface
+	completeTask usecases.CompleteTaskWithUndoUseCaseInterface
+	undoTask     usecases.UndoTaskUseCaseInterface
+	ownerNames   usecases.GetOwnerNamesUseCaseInterface
+	pages        *PageTemplates
+}
+
+// NewUndoHandler creates a new UndoHandler
+func NewUndoHandler(
+	trashTask usecases.TrashTaskUseCaseInterface,
+	completeTask usecases.CompleteTaskWithUndoUseCaseInterface,
+	undoTask usecases.UndoTaskUseCaseInterface,
+	ownerNames usecases.GetOwnerNamesUseCaseInterface,
+	pages *PageTemplates,
+) *UndoHandler {
+	return &UndoHandler{
+		trashTask:    trashTask,
+		completeTask: completeTask,
+		undoTask:     undoTask,
+		ownerNames:   ownerNames,
+		pages:        pages,
+	}
+}
+
+// DeleteTask handles DELETE /web/tasks/{id}, replacing the card with a notice to undo the
+// deletion
+func (h *UndoHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	locale := RequestLocale(r)
+
+	task, undo, err := h.trashTask.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		status, message := undoErrorStatus(err, locale)
+		h.pages.writeWebError(w, r, status, message)
+		return
+	}
+
+	html, err := renderDeletedTask(task, undo, locale)
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.pages.writeWebFragment(w, r, http.StatusOK, html)
+}
+
+// CompleteTask handles POST /web/tasks/{id}/complete, replacing the card with the completed
+// task and a button to undo the completion. The form may carry a "completion_note".
+func (h *UndoHandler) CompleteTask(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	locale := RequestLocale(r)
+
+	task, undo, err := h.completeTask.Execute(r.Context(), r.PathValue("id"), userID, r.FormValue("completion_note"))
+	if err != nil {
+		status, message := undoErrorStatus(err, locale)
+		h.pages.writeWebError(w, r, status, message)
+		return
+	}
+
+	html, err := renderCompletedTask(withOwnerName(r.Context(), h.ownerNames, task), userID, undo, locale)
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.pages.writeWebFragment(w, r, http.StatusOK, html)
+}
+
+// Undo handles POST /web/tasks/{id}/undo, putting the card of the task back as it was
+func (h *UndoHandler) Undo(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	locale := RequestLocale(r)
+
+	task, err := h.undoTask.Execute(r.Context(), r.PathValue("id"), userID)
+	if err != nil {
+		status, message := undoErrorStatus(err, locale)
+		h.pages.writeWebError(w, r, status, message)
+		return
+	}
+
+	html, err := renderTaskCard(withOwnerName(r.Context(), h.ownerNames, task), userID, locale)
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.pages.writeWebFragment(w, r, http.StatusOK, html)
+}
+
+// undoErrorStatus maps an error of the undoable actions to an HTTP status and a localized
+// message. Like WebTaskHandler, other errors are refusals to act on the task.
+func undoErrorStatus(err error, locale application.Locale) (int, string) {
+	var invalid *application.ValidationError
+	switch {
+	case errors.Is(err, usecases.ErrTaskUnavailable):
+		return http.StatusNotFound, i18n.Error(locale, err.Error())
+	case errors.Is(err, application.ErrTaskBlocked):
+		return http.StatusConflict, i18n.Error(locale, err.Error())
+	case errors.Is(err, application.ErrUndoExpired):
+		return http.StatusGone, i18n.Error(locale, err.Error())
+	case errors.As(err, &invalid):
+		return http.StatusBadRequest, err.Error()
+	default:
+		return http.StatusForbidden, err.Error()
+	}
+}
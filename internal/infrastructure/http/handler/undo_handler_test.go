@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockUndoableUseCase struct {
+	task *application.Task
+	undo *application.TaskUndo
+	err  error
+}
+
+func (m *mockUndoableUseCase) Execute(ctx context.Context, taskID, userID string) (*application.Task, *application.TaskUndo, error) {
+	return m.task, m.undo, m.err
+}
+
+type mockCompleteWithUndoUseCase struct {
+	mockUndoableUseCase
+	note string
+}
+
+func (m *mockCompleteWithUndoUseCase) Execute(ctx context.Context, taskID, userID, note string) (*application.Task, *application.TaskUndo, error) {
+	m.note = note
+	return m.task, m.undo, m.err
+}
+
+type mockUndoTaskUseCase struct {
+	task *application.Task
+	err  error
+}
+
+func (m *mockUndoTaskUseCase) Execute(ctx context.Context, taskID, userID string) (*application.Task, error) {
+	return m.task, m.err
+}
+
+func newUndoRequest(method, path string) *http.Request {
+	req := htmx(httptest.NewRequest(method, path, nil))
+	req.SetPathValue("id", "task-1")
+	return withUser(req)
+}
+
+func TestUndoHandler_DeleteTask(t *testing.T) {
+	task := &application.Task{ID: "task-1", Title: "Relatório", Status: application.StatusPending, OwnerID: "user-123", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	undo := &application.TaskUndo{TaskID: "task-1", UserID: "user-123", Action: application.UndoDelete, ExpiresAt: time.Now().Add(10 * time.Second)}
+	h := NewUndoHandler(&mockUndoableUseCase{task: task, undo: undo}, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""))
+
+	w := httptest.NewRecorder()
+	h.DeleteTask(w, newUndoRequest("DELETE", "/web/tasks/task-1"))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	for _, want := range []string{`id="task-task-1"`, "Relatório", `hx-post="/web/tasks/task-1/undo"`, `data-undo-seconds="10"`, "Desfazer"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("notice is missing %q: %s", want, body)
+		}
+	}
+}
+
+func TestUndoHandler_CompleteTask(t *testing.T) {
+	task := &application.Task{ID: "task-1", Title: "Relatório", Status: application.StatusCompleted, CompletionNote: "Entregue ao cliente", OwnerID: "user-123", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	undo := &application.TaskUndo{TaskID: "task-1", UserID: "user-123", Action: application.UndoComplete, ExpiresAt: time.Now().Add(10 * time.Second)}
+	complete := &mockCompleteWithUndoUseCase{mockUndoableUseCase: mockUndoableUseCase{task: task, undo: undo}}
+	h := NewUndoHandler(nil, complete, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""))
+
+	req := newUndoRequest("POST", "/web/tasks/task-1/complete")
+	req.Form = url.Values{"completion_note": {"Entregue ao cliente"}}
+	w := httptest.NewRecorder()
+	h.CompleteTask(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	if complete.note != "Entregue ao cliente" {
+		t.Errorf("note = %q, want the one posted", complete.note)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, `hx-post="/web/tasks/task-1/undo"`) || !strings.Contains(body, "Desfazer") {
+		t.Errorf("expected the completed card with an undo button: %s", body)
+	}
+	if !strings.Contains(body, "Entregue ao cliente") {
+		t.Errorf("expected the completion note on the card: %s", body)
+	}
+}
+
+func TestUndoHandler_Undo(t *testing.T) {
+	task := &application.Task{ID: "task-1", Title: "Relatório", Status: application.StatusPending, OwnerID: "user-123", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"restored", nil, http.StatusOK},
+		{"expired", application.ErrUndoExpired, http.StatusGone},
+		{"task not visible", usecases.ErrTaskUnavailable, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewUndoHandler(nil, nil, &mockUndoTaskUseCase{task: task, err: tt.err}, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""))
+
+			w := httptest.NewRecorder()
+			h.Undo(w, newUndoRequest("POST", "/web/tasks/task-1/undo"))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.err == nil && (!strings.Contains(w.Body.String(), "Relatório") || strings.Contains(w.Body.String(), "/undo")) {
+				t.Errorf("expected the task card back without an undo button: %s", w.Body.String())
+			}
+		})
+	}
+}
@@ -1,21 +1,30 @@
 package handler
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"mime/multipart"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
 )
 
 const (
 	MaxFileSize = 10 * 1024 * 1024 // 10MB
+
+	// imageURLPrefix is the URL path of the uploaded images, stored as the image path of tasks
+	imageURLPrefix = "/uploads/images/"
 )
 
 var allowedMimeTypes = map[string]bool{
@@ -37,17 +46,22 @@ var allowedExtensions = map[string]bool{
 // UploadHandler handles file uploads
 type UploadHandler struct {
 	uploadDir string
+	quota     usecases.StorageQuotaUseCaseInterface
 }
 
-// NewUploadHandler creates a new UploadHandler
-func NewUploadHandler(uploadDir string) *UploadHandler {
+// NewUploadHandler creates a new UploadHandler. Images are charged to the storage quota of the
+// user who uploads them; a nil quota stores them without tracking.
+func NewUploadHandler(uploadDir string, quota usecases.StorageQuotaUseCaseInterface) *UploadHandler {
 	return &UploadHandler{
 		uploadDir: uploadDir,
+		quota:     quota,
 	}
 }
 
-// SaveImage saves an uploaded image and returns the relative path
-func (h *UploadHandler) SaveImage(file multipart.File, header *multipart.FileHeader) (string, error) {
+// SaveImage saves an image uploaded by a user and returns the relative path. It returns
+// *application.StorageQuotaExceededError when the image doesn't fit the storage quota, and
+// *application.CreationQuotaExceededError when the user reached the daily image quota.
+func (h *UploadHandler) SaveImage(ctx context.Context, userID string, file multipart.File, header *multipart.FileHeader) (string, error) {
 	// Validate file size
 	if header.Size > MaxFileSize {
 		return "", fmt.Errorf("file size exceeds 10MB limit")
@@ -91,23 +105,62 @@ func (h *UploadHandler) SaveImage(file multipart.File, header *multipart.FileHea
 		return "", fmt.Errorf("error creating upload directory")
 	}
 
+	// Charge the image to the user's quota before it takes any disk space
+	if h.quota != nil {
+		if err := h.quota.Reserve(ctx, userID, application.StoredFileImage, filename, header.Size); err != nil {
+			var exceeded *application.StorageQuotaExceededError
+			var creationExceeded *application.CreationQuotaExceededError
+			if errors.As(err, &exceeded) || errors.As(err, &creationExceeded) {
+				return "", err
+			}
+			log.Printf("failed to reserve storage for image: %v", err)
+			return "", fmt.Errorf("error saving file")
+		}
+	}
+
 	// Save file to disk
 	filepath := filepath.Join(h.uploadDir, filename)
 	dst, err := os.Create(filepath)
 	if err != nil {
+		releaseStoredFile(ctx, h.quota, application.StoredFileImage, filename)
 		return "", fmt.Errorf("error saving file")
 	}
 	defer dst.Close()
 
 	if _, err := io.Copy(dst, file); err != nil {
+		releaseStoredFile(ctx, h.quota, application.StoredFileImage, filename)
 		return "", fmt.Errorf("error saving file")
 	}
 
 	// Return the relative path
-	relativePath := "/uploads/images/" + filename
+	relativePath := imageURLPrefix + filename
 	return relativePath, nil
 }
 
+// IsUploadedImage reports whether imagePath is the path SaveImage returned for an image that
+// is still on disk, so API clients can only point tasks to images uploaded here
+func (h *UploadHandler) IsUploadedImage(imagePath string) bool {
+	name, ok := strings.CutPrefix(imagePath, imageURLPrefix)
+	if !ok {
+		return false
+	}
+	fullPath, ok := resolveImage(h.uploadDir, name)
+	if !ok {
+		return false
+	}
+	info, err := os.Stat(fullPath)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// IsOwnImage reports whether the uploaded image at imagePath was uploaded by userID. Without a
+// storage quota uploads aren't recorded, so every image is taken as the user's own.
+func (h *UploadHandler) IsOwnImage(ctx context.Context, userID, imagePath string) (bool, error) {
+	if h.quota == nil {
+		return true, nil
+	}
+	return h.quota.Owns(ctx, userID, application.StoredFileImage, strings.TrimPrefix(imagePath, imageURLPrefix))
+}
+
 // UploadImage handles image upload with security validations (HTTP endpoint)
 func (h *UploadHandler) UploadImage(w http.ResponseWriter, r *http.Request) {
 	// Limit request body size to prevent DoS
@@ -115,21 +168,22 @@ func (h *UploadHandler) UploadImage(w http.ResponseWriter, r *http.Request) {
 
 	// Parse multipart form with max memory limit
 	if err := r.ParseMultipartForm(10 << 20); err != nil { // 10MB in memory
-		http.Error(w, "File too large or invalid form data", http.StatusBadRequest)
+		writeAPIError(w, r, http.StatusBadRequest, "File too large or invalid form data")
 		return
 	}
 
 	// Get the file from the form
 	file, header, err := r.FormFile("image")
 	if err != nil {
-		http.Error(w, "No file uploaded", http.StatusBadRequest)
+		writeAPIError(w, r, http.StatusBadRequest, "No file uploaded")
 		return
 	}
 	defer file.Close()
 
-	path, err := h.SaveImage(file, header)
+	userID, _ := r.Context().Value("userID").(string)
+	path, err := h.SaveImage(r.Context(), userID, file, header)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeImageError(w, r, err)
 		return
 	}
 
@@ -139,8 +193,22 @@ func (h *UploadHandler) UploadImage(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// DeleteImage deletes an image file from the filesystem
-func (h *UploadHandler) DeleteImage(imagePath string) error {
+// writeImageError writes the API response for an image SaveImage rejected: 429 past the daily
+// image quota, 413 past the storage quota and 400 for an invalid image
+func writeImageError(w http.ResponseWriter, r *http.Request, err error) {
+	if writeCreationQuotaError(w, r, err) {
+		return
+	}
+	var exceeded *application.StorageQuotaExceededError
+	if errors.As(err, &exceeded) {
+		WriteJSONError(w, r, http.StatusRequestEntityTooLarge, CodeStorageQuotaExceeded, storageQuotaMessage(RequestLocale(r), exceeded))
+		return
+	}
+	writeAPIError(w, r, http.StatusBadRequest, err.Error())
+}
+
+// DeleteImage deletes an image file from the filesystem, giving its space back to the storage quota
+func (h *UploadHandler) DeleteImage(ctx context.Context, imagePath string) error {
 	if imagePath == "" {
 		return nil
 	}
@@ -159,5 +227,6 @@ func (h *UploadHandler) DeleteImage(imagePath string) error {
 		return fmt.Errorf("error deleting file: %w", err)
 	}
 
+	releaseStoredFile(ctx, h.quota, application.StoredFileImage, filename)
 	return nil
 }
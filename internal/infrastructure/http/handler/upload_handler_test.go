@@ -2,18 +2,24 @@ package handler
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
 )
 
 func TestUploadImage_Success(t *testing.T) {
 	// Create a temporary upload directory for testing
 	tempDir := t.TempDir()
 
-	handler := NewUploadHandler(tempDir)
+	handler := NewUploadHandler(tempDir, nil)
 
 	// Create a test image file with valid JPEG header
 	body := &bytes.Buffer{}
@@ -43,7 +49,7 @@ func TestUploadImage_Success(t *testing.T) {
 
 func TestUploadImage_FileTooLarge(t *testing.T) {
 	tempDir := t.TempDir()
-	handler := NewUploadHandler(tempDir)
+	handler := NewUploadHandler(tempDir, nil)
 
 	// Create a file larger than 10MB
 	body := &bytes.Buffer{}
@@ -66,7 +72,7 @@ func TestUploadImage_FileTooLarge(t *testing.T) {
 
 func TestUploadImage_InvalidFileType(t *testing.T) {
 	tempDir := t.TempDir()
-	handler := NewUploadHandler(tempDir)
+	handler := NewUploadHandler(tempDir, nil)
 
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -84,3 +90,59 @@ func TestUploadImage_InvalidFileType(t *testing.T) {
 		t.Errorf("Expected status 400, got %d", w.Code)
 	}
 }
+
+func TestUploadImage_StorageQuota(t *testing.T) {
+	newRequest := func() *http.Request {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("image", "test.jpg")
+		part.Write([]byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x10, 0x4A, 0x46, 0x49, 0x46})
+		part.Write(make([]byte, 1000))
+		writer.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/upload/image", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return withUser(req)
+	}
+
+	t.Run("exceeded", func(t *testing.T) {
+		tempDir := t.TempDir()
+		quota := &mockStorageQuota{err: &application.StorageQuotaExceededError{Limit: 100 << 20, Used: 100 << 20, Size: 1010}}
+		handler := NewUploadHandler(tempDir, quota)
+
+		w := httptest.NewRecorder()
+		handler.UploadImage(w, newRequest())
+
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Fatalf("Expected status 413, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), `"code":"storage_quota_exceeded"`) {
+			t.Errorf("Expected the storage quota error code, got %s", w.Body.String())
+		}
+		if entries, _ := os.ReadDir(tempDir); len(entries) != 0 {
+			t.Errorf("Expected nothing written, got %d files", len(entries))
+		}
+	})
+
+	t.Run("charged and released", func(t *testing.T) {
+		quota := &mockStorageQuota{}
+		handler := NewUploadHandler(t.TempDir(), quota)
+
+		w := httptest.NewRecorder()
+		handler.UploadImage(w, newRequest())
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+
+		var response map[string]string
+		json.NewDecoder(w.Body).Decode(&response)
+		if err := handler.DeleteImage(context.Background(), response["path"]); err != nil {
+			t.Fatalf("DeleteImage() error: %v", err)
+		}
+
+		name := "image/" + filepath.Base(response["path"])
+		if len(quota.reserved) != 1 || quota.reserved[0] != name || len(quota.released) != 1 || quota.released[0] != name {
+			t.Errorf("reserved %v and released %v, want %s", quota.reserved, quota.released, name)
+		}
+	})
+}
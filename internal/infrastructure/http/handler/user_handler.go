@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// UserResponse is the JSON representation of a user. Only the public profile is copied from
+// the entity, so the password hash and the account settings never reach a response; search
+// results leave the creation date out.
+type UserResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at,omitzero"`
+}
+
+func toUserResponse(user *application.User) UserResponse {
+	return UserResponse{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt,
+	}
+}
+
+// UserHandler handles HTTP requests for users
+type UserHandler struct {
+	searchUsers usecases.SearchUsersUseCaseInterface
+	pages       *PageTemplates
+}
+
+// NewUserHandler creates a new UserHandler
+func NewUserHandler(searchUsers usecases.SearchUsersUseCaseInterface, pages *PageTemplates) *UserHandler {
+	return &UserHandler{
+		searchUsers: searchUsers,
+		pages:       pages,
+	}
+}
+
+// SearchUsers handles GET /api/users/search?q=
+func (h *UserHandler) SearchUsers(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	users, err := h.searchUsers.Execute(r.Context(), userID, r.URL.Query().Get("q"))
+	if err != nil {
+		if errors.Is(err, usecases.ErrSearchQueryTooLong) {
+			writeAPIError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeAPIError(w, r, http.StatusInternalServerError, "Failed to search users")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(toUserSearchResults(users))
+}
+
+// WebSearchUsers handles GET /web/users/search?q=, rendering the share autocomplete options
+func (h *UserHandler) WebSearchUsers(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok || userID == "" {
+		h.pages.writeWebError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	query := r.URL.Query().Get("q")
+	users, err := h.searchUsers.Execute(r.Context(), userID, query)
+	if err != nil {
+		if errors.Is(err, usecases.ErrSearchQueryTooLong) {
+			h.pages.writeWebError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Failed to search users")
+		return
+	}
+
+	html, err := renderUserSearchResults(users, RequestLocale(r), utf8.RuneCountInString(strings.TrimSpace(query)) >= usecases.MinUserSearchLength)
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Failed to render results")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	h.pages.writeWebFragment(w, r, http.StatusOK, html)
+}
+
+// toUserSearchResults maps users to their public view
+func toUserSearchResults(users []*application.User) []UserResponse {
+	results := make([]UserResponse, 0, len(users))
+	for _, user := range users {
+		results = append(results, UserResponse{ID: user.ID, Name: user.Name, Email: user.Email})
+	}
+	return results
+}
+
+// renderUserSearchResults renders the autocomplete options. searched tells whether the query was
+// long enough to run, so an empty result only shows "no users found" for real searches.
+func renderUserSearchResults(users []*application.User, locale application.Locale, searched bool) (string, error) {
+	var buf bytes.Buffer
+	err := localizedTemplate(userSearchResultsTemplates, locale).Execute(&buf, map[string]interface{}{
+		"Users":    toUserSearchResults(users),
+		"Searched": searched,
+	})
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+type mockSearchUsersUseCase struct {
+	executeFunc func(ctx context.Context, requesterID, query string) ([]*application.User, error)
+}
+
+func (m *mockSearchUsersUseCase) Execute(ctx context.Context, requesterID, query string) ([]*application.User, error) {
+	return m.executeFunc(ctx, requesterID, query)
+}
+
+func newSearchRequest(target string) *http.Request {
+	req := htmx(httptest.NewRequest("GET", target, nil))
+	return req.WithContext(context.WithValue(req.Context(), "userID", "user-123"))
+}
+
+func TestSearchUsers_Success(t *testing.T) {
+	h := NewUserHandler(&mockSearchUsersUseCase{
+		executeFunc: func(ctx context.Context, requesterID, query string) ([]*application.User, error) {
+			if requesterID != "user-123" || query != "ana" {
+				t.Errorf("Unexpected arguments %q, %q", requesterID, query)
+			}
+			return []*application.User{
+				{ID: "user-2", Name: "Ana", Email: "ana@example.com", PasswordHash: "secret-hash"},
+			}, nil
+		},
+	}, NewPageTemplates(""))
+
+	w := httptest.NewRecorder()
+	h.SearchUsers(w, newSearchRequest("/users/search?q=ana"))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if strings.Contains(w.Body.String(), "secret-hash") {
+		t.Fatal("Response must not expose password hashes")
+	}
+
+	var results []map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &results); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	want := map[string]string{"id": "user-2", "name": "Ana", "email": "ana@example.com"}
+	if len(results) != 1 || len(results[0]) != len(want) {
+		t.Fatalf("Expected exactly id, name and email of one user, got %v", results)
+	}
+	for key, value := range want {
+		if results[0][key] != value {
+			t.Errorf("Expected %s %q, got %q", key, value, results[0][key])
+		}
+	}
+}
+
+func TestSearchUsers_EmptyResultIsArray(t *testing.T) {
+	h := NewUserHandler(&mockSearchUsersUseCase{
+		executeFunc: func(ctx context.Context, requesterID, query string) ([]*application.User, error) {
+			return []*application.User{}, nil
+		},
+	}, NewPageTemplates(""))
+
+	w := httptest.NewRecorder()
+	h.SearchUsers(w, newSearchRequest("/users/search?q=a"))
+
+	if body := strings.TrimSpace(w.Body.String()); body != "[]" {
+		t.Errorf("Expected empty JSON array, got %s", body)
+	}
+}
+
+func TestSearchUsers_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"query too long", usecases.ErrSearchQueryTooLong, http.StatusBadRequest},
+		{"repository error", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewUserHandler(&mockSearchUsersUseCase{
+				executeFunc: func(ctx context.Context, requesterID, query string) ([]*application.User, error) {
+					return nil, tt.err
+				},
+			}, NewPageTemplates(""))
+
+			w := httptest.NewRecorder()
+			h.SearchUsers(w, newSearchRequest("/users/search?q=ana"))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if body := decodeErrorResponse(t, w); strings.Contains(body.Message, "db down") {
+				t.Errorf("Internal error leaked to the client: %q", body.Message)
+			}
+		})
+	}
+}
+
+func TestWebSearchUsers(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		users     []*application.User
+		wantParts []string
+		wantEmpty bool
+	}{
+		{
+			name:      "renders escaped options",
+			query:     "ana",
+			users:     []*application.User{{ID: "user-2", Name: "<b>Ana</b>", Email: "ana@example.com"}},
+			wantParts: []string{`data-user-id="user-2"`, "&lt;b&gt;Ana&lt;/b&gt;", "ana@example.com", "selectShareUser(this)"},
+		},
+		{
+			name:      "no match",
+			query:     "zz",
+			wantParts: []string{"Nenhum usuário encontrado"},
+		},
+		{
+			name:      "short query renders nothing",
+			query:     "a",
+			wantEmpty: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewUserHandler(&mockSearchUsersUseCase{
+				executeFunc: func(ctx context.Context, requesterID, query string) ([]*application.User, error) {
+					return tt.users, nil
+				},
+			}, NewPageTemplates(""))
+
+			w := httptest.NewRecorder()
+			h.WebSearchUsers(w, newSearchRequest("/users/search?q="+tt.query))
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d", w.Code)
+			}
+			body := w.Body.String()
+			if tt.wantEmpty && strings.TrimSpace(body) != "" {
+				t.Errorf("Expected empty fragment, got %q", body)
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(body, part) {
+					t.Errorf("Expected fragment to contain %q, got %s", part, body)
+				}
+			}
+			if strings.Contains(body, "<b>Ana</b>") {
+				t.Error("User names must be escaped")
+			}
+		})
+	}
+}
+
+func TestWebSearchUsers_Unauthorized(t *testing.T) {
+	h := NewUserHandler(&mockSearchUsersUseCase{}, NewPageTemplates(""))
+
+	w := httptest.NewRecorder()
+	h.WebSearchUsers(w, htmx(httptest.NewRequest("GET", "/users/search?q=ana", nil)))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401, got %d", w.Code)
+	}
+}
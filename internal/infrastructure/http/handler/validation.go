@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// writeValidationError answers 400 to an API request whose entity the domain rejected, with the
+// code of each invalid field in fields and a localized detail per error. It returns false when
+// err isn't a *application.ValidationError, leaving the response to the caller.
+func writeValidationError(w http.ResponseWriter, r *http.Request, err error) bool {
+	var invalid *application.ValidationError
+	if !errors.As(err, &invalid) {
+		return false
+	}
+
+	body := ErrorBody{
+		Code:    CodeValidationFailed,
+		Fields:  make(map[string]string, len(invalid.Fields)),
+		Details: make([]ErrorDetail, len(invalid.Fields)),
+	}
+	messages := make([]string, len(invalid.Fields))
+	for i, f := range invalid.Fields {
+		// A field with several errors is reported with the first one, as in the form below it
+		if _, ok := body.Fields[f.Field]; !ok {
+			body.Fields[f.Field] = f.Code
+		}
+		messages[i] = LocalizeError(r, f.Message)
+		body.Details[i] = ErrorDetail{Field: f.Field, Code: f.Code, Message: messages[i]}
+	}
+	body.Message = strings.Join(messages, "; ")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(ErrorResponse{Error: body})
+	return true
+}
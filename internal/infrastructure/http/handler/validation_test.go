@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestWriteValidationError(t *testing.T) {
+	_, err := application.NewTask("task-1", "", strings.Repeat("a", 1001), application.StatusPending, "user-1", "")
+
+	req := httptest.NewRequest("POST", "/api/tasks", nil)
+	req.Header.Set("Accept-Language", "pt-BR")
+	w := httptest.NewRecorder()
+	if !writeValidationError(w, req, err) {
+		t.Fatal("writeValidationError() = false for a validation error")
+	}
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+	var resp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error.Code != CodeValidationFailed {
+		t.Errorf("code = %q, want %q", resp.Error.Code, CodeValidationFailed)
+	}
+	if want := map[string]string{"title": "required", "description": "too_long"}; !reflect.DeepEqual(resp.Error.Fields, want) {
+		t.Errorf("fields = %v, want %v", resp.Error.Fields, want)
+	}
+	if len(resp.Error.Details) != 2 || resp.Error.Details[0].Message == "task title cannot be empty" {
+		t.Errorf("details = %+v, want a localized detail per field", resp.Error.Details)
+	}
+
+	if writeValidationError(httptest.NewRecorder(), req, errors.New("task not found")) {
+		t.Error("writeValidationError() = true for another error")
+	}
+}
+
+func TestUseCaseFormError_ValidationError(t *testing.T) {
+	_, err := application.NewTask("task-1", "", strings.Repeat("a", 1001), "archived", "user-1", "")
+
+	got := useCaseFormError(createTaskForm, err)
+	want := FormErrors{
+		Message: "invalid task status", // The form has no status field
+		Fields: map[string]string{
+			"title":       "task title cannot be empty",
+			"description": "task description cannot exceed 1000 characters",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("useCaseFormError() = %+v, want %+v", got, want)
+	}
+}
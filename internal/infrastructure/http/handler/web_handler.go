@@ -1,8 +1,16 @@
 package handler
 
 import (
+	"errors"
+	"html"
+	"log"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/markdown"
 	"github.com/ia-edev-sindireceita/todo/internal/usecases"
 )
 
@@ -11,9 +19,13 @@ type WebTaskHandler struct {
 	createTask       usecases.CreateTaskUseCaseInterface
 	deleteTask       usecases.DeleteTaskUseCaseInterface
 	completeTask     usecases.CompleteTaskUseCaseInterface
-	shareTask        usecases.ShareTaskUseCaseInterface
+	shareTask        usecases.ShareTaskWithUsersUseCaseInterface
 	deleteTaskImage  usecases.DeleteTaskImageUseCaseInterface
 	replaceTaskImage usecases.ReplaceTaskImageUseCaseInterface
+	files            *TaskFileStorage
+	ownerNames       usecases.GetOwnerNamesUseCaseInterface
+	pages            *PageTemplates
+	location         *time.Location
 }
 
 // NewWebTaskHandler creates a new WebTaskHandler
@@ -21,9 +33,13 @@ func NewWebTaskHandler(
 	createTask usecases.CreateTaskUseCaseInterface,
 	deleteTask usecases.DeleteTaskUseCaseInterface,
 	completeTask usecases.CompleteTaskUseCaseInterface,
-	shareTask usecases.ShareTaskUseCaseInterface,
+	shareTask usecases.ShareTaskWithUsersUseCaseInterface,
 	deleteTaskImage usecases.DeleteTaskImageUseCaseInterface,
 	replaceTaskImage usecases.ReplaceTaskImageUseCaseInterface,
+	files *TaskFileStorage,
+	ownerNames usecases.GetOwnerNamesUseCaseInterface,
+	pages *PageTemplates,
+	location *time.Location,
 ) *WebTaskHandler {
 	return &WebTaskHandler{
 		createTask:       createTask,
@@ -32,15 +48,19 @@ func NewWebTaskHandler(
 		shareTask:        shareTask,
 		deleteTaskImage:  deleteTaskImage,
 		replaceTaskImage: replaceTaskImage,
+		files:            files,
+		ownerNames:       ownerNames,
+		pages:            pages,
+		location:         location,
 	}
 }
 
-// CreateTask handles web form submission
+// CreateTask handles web form submission. Validation errors are shown inline in the form.
 func (h *WebTaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("userID").(string)
 	if !ok || userID == "" {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		h.pages.writeWebError(w, r, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
@@ -48,7 +68,7 @@ func (h *WebTaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	if err := r.ParseMultipartForm(10 << 20); err != nil { // 10MB max
 		// Fallback to regular form parsing if not multipart
 		if err := r.ParseForm(); err != nil {
-			http.Error(w, "Invalid form data", http.StatusBadRequest)
+			h.pages.writeFormErrors(w, r, http.StatusBadRequest, createTaskForm, formError("", "Invalid form data"))
 			return
 		}
 	}
@@ -56,6 +76,12 @@ func (h *WebTaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	title := r.FormValue("title")
 	description := r.FormValue("description")
 
+	dueDate, err := parseDueDateInput(r, r.FormValue("due_date"), h.location)
+	if err != nil {
+		h.pages.writeFormErrors(w, r, http.StatusBadRequest, createTaskForm, formError("due_date", err.Error()))
+		return
+	}
+
 	var imagePath string
 
 	// Handle image upload if present
@@ -64,31 +90,34 @@ func (h *WebTaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 		defer file.Close()
 
 		// Process the image using upload handler logic
-		uploadHandler := NewUploadHandler("uploads/images")
-		path, err := uploadHandler.SaveImage(file, header)
+		path, err := h.files.images.SaveImage(r.Context(), userID, file, header)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			status, message := webImageError(w, err, RequestLocale(r))
+			h.pages.writeFormErrors(w, r, status, createTaskForm, formError("image", message))
 			return
 		}
 		imagePath = path
 	}
 
 	// Create task
-	task, err := h.createTask.Execute(r.Context(), title, description, userID, imagePath)
+	task, err := h.createTask.Execute(r.Context(), title, description, userID, imagePath, dueDate, r.FormValue("project_id"), application.TaskColor(r.FormValue("color")))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		if exceeded := creationQuotaExceeded(w, err); exceeded != nil {
+			h.pages.writeFormErrors(w, r, http.StatusTooManyRequests, createTaskForm, formError("", creationQuotaMessage(RequestLocale(r), exceeded)))
+			return
+		}
+		h.pages.writeFormErrors(w, r, http.StatusBadRequest, createTaskForm, useCaseFormError(createTaskForm, err))
 		return
 	}
 
-	// Return HTML fragment for HTMX
-	w.Header().Set("Content-Type", "text/html")
-	html, err := renderTaskCard(task, userID)
+	// Return HTML fragment for HTMX, clearing the errors of a previous attempt
+	html, err := renderTaskCard(&application.TaskView{Task: task}, userID, RequestLocale(r))
 	if err != nil {
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	w.Write([]byte(html))
+	h.pages.writeWebFragment(w, r, http.StatusOK, html+clearFormErrors(createTaskForm, RequestLocale(r)))
 }
 
 // DeleteTask handles task deletion
@@ -96,20 +125,22 @@ func (h *WebTaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("userID").(string)
 	if !ok || userID == "" {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		h.pages.writeWebError(w, r, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	taskID := r.PathValue("id")
 
-	err := h.deleteTask.Execute(r.Context(), taskID, userID)
+	files, err := h.deleteTask.Execute(r.Context(), taskID, userID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusForbidden)
+		h.pages.writeWebError(w, r, taskErrorStatus(err, http.StatusForbidden), err.Error())
 		return
 	}
 
+	h.files.RemoveDeleted(r.Context(), files)
+
 	// Return empty response for HTMX to swap out the element
-	w.WriteHeader(http.StatusOK)
+	h.pages.writeWebFragment(w, r, http.StatusOK, "")
 }
 
 // CompleteTask handles task completion
@@ -117,67 +148,106 @@ func (h *WebTaskHandler) CompleteTask(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("userID").(string)
 	if !ok || userID == "" {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		h.pages.writeWebError(w, r, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	taskID := r.PathValue("id")
 
-	task, err := h.completeTask.Execute(r.Context(), taskID, userID)
+	task, err := h.completeTask.Execute(r.Context(), taskID, userID, r.FormValue("completion_note"))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusForbidden)
+		if errors.Is(err, application.ErrTaskBlocked) {
+			h.pages.writeWebError(w, r, http.StatusConflict, i18n.Error(RequestLocale(r), err.Error()))
+			return
+		}
+		var invalid *application.ValidationError
+		if errors.As(err, &invalid) {
+			h.pages.writeWebError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.pages.writeWebError(w, r, taskErrorStatus(err, http.StatusForbidden), err.Error())
 		return
 	}
 
 	// Return updated HTML fragment for HTMX with completed status
-	w.Header().Set("Content-Type", "text/html")
-	html, err := renderCompletedTask(task, userID)
+	html, err := renderCompletedTask(withOwnerName(r.Context(), h.ownerNames, task), userID, nil, RequestLocale(r))
 	if err != nil {
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	w.Write([]byte(html))
+	h.pages.writeWebFragment(w, r, http.StatusOK, html)
 }
 
-// ShareTask handles task sharing via web form
+// ShareTask handles task sharing via web form, inviting every user picked at once. The outcome
+// for each of them is listed below the form; validation errors are shown inline in the form.
 func (h *WebTaskHandler) ShareTask(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("userID").(string)
 	if !ok || userID == "" {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		h.pages.writeWebError(w, r, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	taskID := r.PathValue("id")
+	form := shareForm(taskID)
+	userField := form.fields[0]
 
 	// Parse form data
 	if err := r.ParseForm(); err != nil {
-		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		h.pages.writeFormErrors(w, r, http.StatusBadRequest, form, formError("", "Invalid form data"))
 		return
 	}
 
-	shareWithUserID := r.FormValue("share_with_user_id")
-	if shareWithUserID == "" {
-		http.Error(w, "share_with_user_id is required", http.StatusBadRequest)
+	// The users picked are user IDs; emails are accepted too
+	recipients := r.Form["share_with_user_id"]
+	if strings.TrimSpace(strings.Join(recipients, "")) == "" {
+		h.pages.writeFormErrors(w, r, http.StatusBadRequest, form, formError(userField, "share_with_user_id is required"))
 		return
 	}
 
-	// Execute share use case
-	err := h.shareTask.Execute(r.Context(), taskID, userID, shareWithUserID)
-	if err != nil {
-		if err.Error() == "only the task owner can share the task" {
-			http.Error(w, err.Error(), http.StatusForbidden)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	results, err := h.shareTask.Execute(r.Context(), taskID, userID, recipients)
+	switch {
+	case errors.Is(err, usecases.ErrShareTaskPermissionDenied):
+		h.pages.writeFormErrors(w, r, http.StatusForbidden, form, formError("", err.Error()))
+		return
+	case errors.Is(err, usecases.ErrTaskUnavailable):
+		h.pages.writeFormErrors(w, r, http.StatusNotFound, form, formError("", err.Error()))
+		return
+	case errors.Is(err, usecases.ErrTooManyShareRecipients):
+		h.pages.writeFormErrors(w, r, http.StatusBadRequest, form, formError(userField, err.Error()))
+		return
+	case err != nil:
+		log.Printf("sharing task %s failed: %v", taskID, err)
+		h.pages.writeFormErrors(w, r, http.StatusInternalServerError, form, formError("", "Internal server error"))
 		return
 	}
 
-	// Return success message as HTML fragment
-	w.Header().Set("Content-Type", "text/html")
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(`<div class="bg-green-100 border border-green-400 text-green-700 px-4 py-3 rounded">Tarefa compartilhada com sucesso!</div>`))
+	// A single user who couldn't be invited is an error about the user picked
+	if len(results) == 1 && results[0].Err != nil {
+		h.pages.writeFormErrors(w, r, http.StatusBadRequest, form, formError(userField, results[0].Err.Error()))
+		return
+	}
+
+	list := shareResultList{TaskID: taskID}
+	for _, result := range results {
+		item := shareResultItem{Label: result.Recipient}
+		if result.User != nil {
+			item.Label = result.User.Name + " <" + result.User.Email + ">"
+		}
+		if result.Err != nil {
+			item.Error = result.Err.Error()
+		} else {
+			list.Invited = true
+		}
+		list.Results = append(list.Results, item)
+	}
+	fragment, err := renderShareResults(list, RequestLocale(r))
+	if err != nil {
+		h.pages.writeWebError(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	h.pages.writeWebFragment(w, r, http.StatusOK, fragment+clearFormErrors(form, RequestLocale(r)))
 }
 
 // DeleteTaskImage handles deleting an image from a task
@@ -185,7 +255,7 @@ func (h *WebTaskHandler) DeleteTaskImage(w http.ResponseWriter, r *http.Request)
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("userID").(string)
 	if !ok || userID == "" {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		h.pages.writeWebError(w, r, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
@@ -194,19 +264,17 @@ func (h *WebTaskHandler) DeleteTaskImage(w http.ResponseWriter, r *http.Request)
 	// Execute delete image use case
 	oldImagePath, err := h.deleteTaskImage.Execute(r.Context(), taskID, userID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.pages.writeWebError(w, r, taskErrorStatus(err, http.StatusBadRequest), err.Error())
 		return
 	}
 
 	// Delete the physical file
 	if oldImagePath != "" {
-		uploadHandler := NewUploadHandler("uploads/images")
-		uploadHandler.DeleteImage(oldImagePath)
+		h.files.images.DeleteImage(r.Context(), oldImagePath)
 	}
 
 	// Return empty response for HTMX to remove the image
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(""))
+	h.pages.writeWebFragment(w, r, http.StatusOK, "")
 }
 
 // ReplaceTaskImage handles replacing an image in a task
@@ -214,7 +282,7 @@ func (h *WebTaskHandler) ReplaceTaskImage(w http.ResponseWriter, r *http.Request
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("userID").(string)
 	if !ok || userID == "" {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		h.pages.writeWebError(w, r, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
@@ -222,23 +290,24 @@ func (h *WebTaskHandler) ReplaceTaskImage(w http.ResponseWriter, r *http.Request
 
 	// Parse multipart form for image upload
 	if err := r.ParseMultipartForm(10 << 20); err != nil { // 10MB max
-		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		h.pages.writeWebError(w, r, http.StatusBadRequest, "Invalid form data")
 		return
 	}
 
 	// Handle new image upload
 	file, header, err := r.FormFile("image")
 	if err != nil {
-		http.Error(w, "Image file is required", http.StatusBadRequest)
+		h.pages.writeWebError(w, r, http.StatusBadRequest, "Image file is required")
 		return
 	}
 	defer file.Close()
 
 	// Save the new image
-	uploadHandler := NewUploadHandler("uploads/images")
-	newImagePath, err := uploadHandler.SaveImage(file, header)
+	uploadHandler := h.files.images
+	newImagePath, err := uploadHandler.SaveImage(r.Context(), userID, file, header)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		status, message := webImageError(w, err, RequestLocale(r))
+		h.pages.writeWebError(w, r, status, message)
 		return
 	}
 
@@ -246,20 +315,52 @@ func (h *WebTaskHandler) ReplaceTaskImage(w http.ResponseWriter, r *http.Request
 	oldImagePath, err := h.replaceTaskImage.Execute(r.Context(), taskID, userID, newImagePath)
 	if err != nil {
 		// If use case fails, delete the newly uploaded image
-		uploadHandler.DeleteImage(newImagePath)
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		uploadHandler.DeleteImage(r.Context(), newImagePath)
+		h.pages.writeWebError(w, r, taskErrorStatus(err, http.StatusBadRequest), err.Error())
 		return
 	}
 
 	// Delete the old physical file
 	if oldImagePath != "" {
-		uploadHandler.DeleteImage(oldImagePath)
+		uploadHandler.DeleteImage(r.Context(), oldImagePath)
 	}
 
 	// Return HTML fragment with new image
-	w.Header().Set("Content-Type", "text/html")
-	html := `<div class="mt-3">
-		<img src="` + newImagePath + `" alt="Task image" class="max-w-[200px] max-h-[200px] object-cover rounded-lg shadow-sm">
-	</div>`
-	w.Write([]byte(html))
+	h.pages.writeWebFragment(w, r, http.StatusOK, `<div class="mt-3">
+		<img src="`+newImagePath+`" alt="Task image" class="max-w-[200px] max-h-[200px] object-cover rounded-lg shadow-sm">
+	</div>`)
+}
+
+// maxPreviewSize limits the form size accepted by PreviewDescription
+const maxPreviewSize = 64 << 10 // 64KB
+
+// PreviewDescription renders the Markdown of a description being typed in the task form
+func (h *WebTaskHandler) PreviewDescription(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxPreviewSize)
+	if err := r.ParseMultipartForm(maxPreviewSize); err != nil {
+		if err := r.ParseForm(); err != nil {
+			h.pages.writeWebError(w, r, http.StatusBadRequest, "Invalid form data")
+			return
+		}
+	}
+
+	if strings.TrimSpace(r.FormValue("description")) == "" {
+		h.pages.writeWebFragment(w, r, http.StatusOK, `<p class="text-gray-400">`+html.EscapeString(i18n.T(RequestLocale(r), "tasks.preview_empty"))+`</p>`)
+		return
+	}
+
+	h.pages.writeWebFragment(w, r, http.StatusOK, string(markdown.Render(r.FormValue("description"))))
+}
+
+// webImageError maps an image upload error to an HTTP status and the message shown in the web
+// interface, setting Retry-After when the daily image quota was reached
+func webImageError(w http.ResponseWriter, err error, locale application.Locale) (int, string) {
+	var exceeded *application.StorageQuotaExceededError
+	if errors.As(err, &exceeded) {
+		return http.StatusRequestEntityTooLarge, storageQuotaMessage(locale, exceeded)
+	}
+	if creationExceeded := creationQuotaExceeded(w, err); creationExceeded != nil {
+		return http.StatusTooManyRequests, creationQuotaMessage(locale, creationExceeded)
+	}
+	return http.StatusBadRequest, err.Error()
 }
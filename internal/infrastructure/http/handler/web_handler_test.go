@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
 )
 
 // =============================================================================
@@ -20,7 +21,7 @@ import (
 
 func TestWebCreateTask_Success(t *testing.T) {
 	mockCreate := &mockCreateTaskUseCase{
-		executeFunc: func(ctx context.Context, title, description, ownerID, imagePath string) (*application.Task, error) {
+		executeFunc: func(ctx context.Context, title, description, ownerID, imagePath string, dueDate *time.Time) (*application.Task, error) {
 			if title != "New Web Task" {
 				t.Errorf("Expected title 'New Web Task', got %s", title)
 			}
@@ -42,13 +43,13 @@ func TestWebCreateTask_Success(t *testing.T) {
 		},
 	}
 
-	handler := NewWebTaskHandler(mockCreate, nil, nil, nil, nil, nil)
+	handler := NewWebTaskHandler(mockCreate, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
 
 	formData := url.Values{}
 	formData.Set("title", "New Web Task")
 	formData.Set("description", "Web task description")
 
-	req := httptest.NewRequest("POST", "/web/tasks", strings.NewReader(formData.Encode()))
+	req := htmx(httptest.NewRequest("POST", "/web/tasks", strings.NewReader(formData.Encode())))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	ctx := context.WithValue(req.Context(), "userID", "user-123")
 	req = req.WithContext(ctx)
@@ -60,8 +61,8 @@ func TestWebCreateTask_Success(t *testing.T) {
 		t.Errorf("Expected status 200, got %d", w.Code)
 	}
 
-	if w.Header().Get("Content-Type") != "text/html" {
-		t.Errorf("Expected Content-Type text/html, got %s", w.Header().Get("Content-Type"))
+	if w.Header().Get("Content-Type") != "text/html; charset=utf-8" {
+		t.Errorf("Expected Content-Type text/html; charset=utf-8, got %s", w.Header().Get("Content-Type"))
 	}
 
 	body := w.Body.String()
@@ -112,7 +113,7 @@ func TestWebCreateTask_Success(t *testing.T) {
 
 func TestWebCreateTask_SharedTaskIndicator(t *testing.T) {
 	mockCreate := &mockCreateTaskUseCase{
-		executeFunc: func(ctx context.Context, title, description, ownerID, imagePath string) (*application.Task, error) {
+		executeFunc: func(ctx context.Context, title, description, ownerID, imagePath string, dueDate *time.Time) (*application.Task, error) {
 			// Simula que outro usuário criou a tarefa
 			return &application.Task{
 				ID:          "shared-task-789",
@@ -126,13 +127,13 @@ func TestWebCreateTask_SharedTaskIndicator(t *testing.T) {
 		},
 	}
 
-	handler := NewWebTaskHandler(mockCreate, nil, nil, nil, nil, nil)
+	handler := NewWebTaskHandler(mockCreate, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
 
 	formData := url.Values{}
 	formData.Set("title", "Shared Task")
 	formData.Set("description", "Task shared with me")
 
-	req := httptest.NewRequest("POST", "/web/tasks", strings.NewReader(formData.Encode()))
+	req := htmx(httptest.NewRequest("POST", "/web/tasks", strings.NewReader(formData.Encode())))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	ctx := context.WithValue(req.Context(), "userID", "user-123")
 	req = req.WithContext(ctx)
@@ -153,13 +154,13 @@ func TestWebCreateTask_SharedTaskIndicator(t *testing.T) {
 }
 
 func TestWebCreateTask_Unauthorized(t *testing.T) {
-	handler := NewWebTaskHandler(&mockCreateTaskUseCase{}, nil, nil, nil, nil, nil)
+	handler := NewWebTaskHandler(&mockCreateTaskUseCase{}, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
 
 	formData := url.Values{}
 	formData.Set("title", "Task")
 	formData.Set("description", "Description")
 
-	req := httptest.NewRequest("POST", "/web/tasks", strings.NewReader(formData.Encode()))
+	req := htmx(httptest.NewRequest("POST", "/web/tasks", strings.NewReader(formData.Encode())))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	// No userID in context
 
@@ -185,18 +186,18 @@ func TestWebCreateTask_InvalidForm(t *testing.T) {
 
 func TestWebCreateTask_ValidationError(t *testing.T) {
 	mockCreate := &mockCreateTaskUseCase{
-		executeFunc: func(ctx context.Context, title, description, ownerID, imagePath string) (*application.Task, error) {
+		executeFunc: func(ctx context.Context, title, description, ownerID, imagePath string, dueDate *time.Time) (*application.Task, error) {
 			return nil, errors.New("task title cannot be empty")
 		},
 	}
 
-	handler := NewWebTaskHandler(mockCreate, nil, nil, nil, nil, nil)
+	handler := NewWebTaskHandler(mockCreate, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
 
 	formData := url.Values{}
 	formData.Set("title", "")
 	formData.Set("description", "Description")
 
-	req := httptest.NewRequest("POST", "/web/tasks", strings.NewReader(formData.Encode()))
+	req := htmx(httptest.NewRequest("POST", "/web/tasks", strings.NewReader(formData.Encode())))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	ctx := context.WithValue(req.Context(), "userID", "user-123")
 	req = req.WithContext(ctx)
@@ -216,7 +217,7 @@ func TestWebCreateTask_ValidationError(t *testing.T) {
 
 func TestWebCreateTask_HTMLEscaping(t *testing.T) {
 	mockCreate := &mockCreateTaskUseCase{
-		executeFunc: func(ctx context.Context, title, description, ownerID, imagePath string) (*application.Task, error) {
+		executeFunc: func(ctx context.Context, title, description, ownerID, imagePath string, dueDate *time.Time) (*application.Task, error) {
 			return &application.Task{
 				ID:          "task-xss",
 				Title:       title,
@@ -229,14 +230,14 @@ func TestWebCreateTask_HTMLEscaping(t *testing.T) {
 		},
 	}
 
-	handler := NewWebTaskHandler(mockCreate, nil, nil, nil, nil, nil)
+	handler := NewWebTaskHandler(mockCreate, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
 
 	// Test with potentially malicious input
 	formData := url.Values{}
 	formData.Set("title", "<script>alert('xss')</script>")
 	formData.Set("description", "<img src=x onerror=alert('xss')>")
 
-	req := httptest.NewRequest("POST", "/web/tasks", strings.NewReader(formData.Encode()))
+	req := htmx(httptest.NewRequest("POST", "/web/tasks", strings.NewReader(formData.Encode())))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	ctx := context.WithValue(req.Context(), "userID", "user-123")
 	req = req.WithContext(ctx)
@@ -283,9 +284,9 @@ func TestWebDeleteTask_Success(t *testing.T) {
 		},
 	}
 
-	handler := NewWebTaskHandler(nil, mockDelete, nil, nil, nil, nil)
+	handler := NewWebTaskHandler(nil, mockDelete, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
 
-	req := httptest.NewRequest("DELETE", "/web/tasks/task-to-delete", nil)
+	req := htmx(httptest.NewRequest("DELETE", "/web/tasks/task-to-delete", nil))
 	req.SetPathValue("id", "task-to-delete")
 	ctx := context.WithValue(req.Context(), "userID", "user-123")
 	req = req.WithContext(ctx)
@@ -305,9 +306,9 @@ func TestWebDeleteTask_Success(t *testing.T) {
 }
 
 func TestWebDeleteTask_Unauthorized(t *testing.T) {
-	handler := NewWebTaskHandler(nil, &mockDeleteTaskUseCase{}, nil, nil, nil, nil)
+	handler := NewWebTaskHandler(nil, &mockDeleteTaskUseCase{}, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
 
-	req := httptest.NewRequest("DELETE", "/web/tasks/task-123", nil)
+	req := htmx(httptest.NewRequest("DELETE", "/web/tasks/task-123", nil))
 	req.SetPathValue("id", "task-123")
 	// No userID in context
 
@@ -326,9 +327,9 @@ func TestWebDeleteTask_NotFound(t *testing.T) {
 		},
 	}
 
-	handler := NewWebTaskHandler(nil, mockDelete, nil, nil, nil, nil)
+	handler := NewWebTaskHandler(nil, mockDelete, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
 
-	req := httptest.NewRequest("DELETE", "/web/tasks/nonexistent", nil)
+	req := htmx(httptest.NewRequest("DELETE", "/web/tasks/nonexistent", nil))
 	req.SetPathValue("id", "nonexistent")
 	ctx := context.WithValue(req.Context(), "userID", "user-123")
 	req = req.WithContext(ctx)
@@ -348,9 +349,9 @@ func TestWebDeleteTask_NoPermission(t *testing.T) {
 		},
 	}
 
-	handler := NewWebTaskHandler(nil, mockDelete, nil, nil, nil, nil)
+	handler := NewWebTaskHandler(nil, mockDelete, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
 
-	req := httptest.NewRequest("DELETE", "/web/tasks/task-123", nil)
+	req := htmx(httptest.NewRequest("DELETE", "/web/tasks/task-123", nil))
 	req.SetPathValue("id", "task-123")
 	ctx := context.WithValue(req.Context(), "userID", "other-user")
 	req = req.WithContext(ctx)
@@ -392,9 +393,9 @@ func TestWebCompleteTask_Success(t *testing.T) {
 		},
 	}
 
-	handler := NewWebTaskHandler(nil, nil, mockComplete, nil, nil, nil)
+	handler := NewWebTaskHandler(nil, nil, mockComplete, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
 
-	req := httptest.NewRequest("POST", "/web/tasks/task-to-complete/complete", nil)
+	req := htmx(httptest.NewRequest("POST", "/web/tasks/task-to-complete/complete", nil))
 	req.SetPathValue("id", "task-to-complete")
 	ctx := context.WithValue(req.Context(), "userID", "user-123")
 	req = req.WithContext(ctx)
@@ -406,8 +407,8 @@ func TestWebCompleteTask_Success(t *testing.T) {
 		t.Errorf("Expected status 200, got %d", w.Code)
 	}
 
-	if w.Header().Get("Content-Type") != "text/html" {
-		t.Errorf("Expected Content-Type text/html, got %s", w.Header().Get("Content-Type"))
+	if w.Header().Get("Content-Type") != "text/html; charset=utf-8" {
+		t.Errorf("Expected Content-Type text/html; charset=utf-8, got %s", w.Header().Get("Content-Type"))
 	}
 
 	body := w.Body.String()
@@ -468,9 +469,10 @@ func TestWebCompleteTask_SharedTaskIndicator(t *testing.T) {
 		},
 	}
 
-	handler := NewWebTaskHandler(nil, nil, mockComplete, nil, nil, nil)
+	ownerNames := &mockGetOwnerNamesUseCase{names: map[string]string{"other-user-456": "Ana"}}
+	handler := NewWebTaskHandler(nil, nil, mockComplete, nil, nil, nil, nil, ownerNames, NewPageTemplates(""), time.Local)
 
-	req := httptest.NewRequest("POST", "/web/tasks/shared-task-999/complete", nil)
+	req := htmx(httptest.NewRequest("POST", "/web/tasks/shared-task-999/complete", nil))
 	req.SetPathValue("id", "shared-task-999")
 	ctx := context.WithValue(req.Context(), "userID", "user-123")
 	req = req.WithContext(ctx)
@@ -481,8 +483,8 @@ func TestWebCompleteTask_SharedTaskIndicator(t *testing.T) {
 	body := w.Body.String()
 
 	// Verify shared ownership badge in completed task
-	if !strings.Contains(body, "Compartilhada") {
-		t.Error("Expected completed shared task to contain 'Compartilhada' ownership badge")
+	if !strings.Contains(body, "Compartilhada por Ana") {
+		t.Error("Expected completed shared task to contain 'Compartilhada por Ana' ownership badge")
 	}
 
 	if !strings.Contains(body, "bg-purple-100") {
@@ -491,9 +493,9 @@ func TestWebCompleteTask_SharedTaskIndicator(t *testing.T) {
 }
 
 func TestWebCompleteTask_Unauthorized(t *testing.T) {
-	handler := NewWebTaskHandler(nil, nil, &mockCompleteTaskUseCase{}, nil, nil, nil)
+	handler := NewWebTaskHandler(nil, nil, &mockCompleteTaskUseCase{}, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
 
-	req := httptest.NewRequest("POST", "/web/tasks/task-123/complete", nil)
+	req := htmx(httptest.NewRequest("POST", "/web/tasks/task-123/complete", nil))
 	req.SetPathValue("id", "task-123")
 	// No userID in context
 
@@ -512,9 +514,9 @@ func TestWebCompleteTask_NotFound(t *testing.T) {
 		},
 	}
 
-	handler := NewWebTaskHandler(nil, nil, mockComplete, nil, nil, nil)
+	handler := NewWebTaskHandler(nil, nil, mockComplete, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
 
-	req := httptest.NewRequest("POST", "/web/tasks/nonexistent/complete", nil)
+	req := htmx(httptest.NewRequest("POST", "/web/tasks/nonexistent/complete", nil))
 	req.SetPathValue("id", "nonexistent")
 	ctx := context.WithValue(req.Context(), "userID", "user-123")
 	req = req.WithContext(ctx)
@@ -534,9 +536,9 @@ func TestWebCompleteTask_NoPermission(t *testing.T) {
 		},
 	}
 
-	handler := NewWebTaskHandler(nil, nil, mockComplete, nil, nil, nil)
+	handler := NewWebTaskHandler(nil, nil, mockComplete, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
 
-	req := httptest.NewRequest("POST", "/web/tasks/task-123/complete", nil)
+	req := htmx(httptest.NewRequest("POST", "/web/tasks/task-123/complete", nil))
 	req.SetPathValue("id", "task-123")
 	ctx := context.WithValue(req.Context(), "userID", "other-user")
 	req = req.WithContext(ctx)
@@ -549,6 +551,31 @@ func TestWebCompleteTask_NoPermission(t *testing.T) {
 	}
 }
 
+func TestWebCompleteTask_Blocked(t *testing.T) {
+	mockComplete := &mockCompleteTaskUseCase{
+		executeFunc: func(ctx context.Context, taskID, userID string) (*application.Task, error) {
+			return nil, application.ErrTaskBlocked
+		},
+	}
+
+	handler := NewWebTaskHandler(nil, nil, mockComplete, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
+
+	req := htmx(httptest.NewRequest("POST", "/web/tasks/task-123/complete", nil))
+	req.SetPathValue("id", "task-123")
+	ctx := context.WithValue(req.Context(), "userID", "user-123")
+	req = req.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	handler.CompleteTask(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status 409, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "a tarefa está bloqueada por tarefas em aberto") {
+		t.Errorf("Expected localized blocked message, got %q", w.Body.String())
+	}
+}
+
 func TestWebCompleteTask_AlreadyCompleted(t *testing.T) {
 	mockComplete := &mockCompleteTaskUseCase{
 		executeFunc: func(ctx context.Context, taskID, userID string) (*application.Task, error) {
@@ -556,9 +583,9 @@ func TestWebCompleteTask_AlreadyCompleted(t *testing.T) {
 		},
 	}
 
-	handler := NewWebTaskHandler(nil, nil, mockComplete, nil, nil, nil)
+	handler := NewWebTaskHandler(nil, nil, mockComplete, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
 
-	req := httptest.NewRequest("POST", "/web/tasks/task-123/complete", nil)
+	req := htmx(httptest.NewRequest("POST", "/web/tasks/task-123/complete", nil))
 	req.SetPathValue("id", "task-123")
 	ctx := context.WithValue(req.Context(), "userID", "user-123")
 	req = req.WithContext(ctx)
@@ -576,12 +603,41 @@ func TestWebCompleteTask_AlreadyCompleted(t *testing.T) {
 	}
 }
 
+func TestWebCompleteTask_InvalidNote(t *testing.T) {
+	mockComplete := &mockCompleteTaskUseCase{
+		executeFunc: func(ctx context.Context, taskID, userID string) (*application.Task, error) {
+			task := &application.Task{Status: application.StatusCompleted}
+			return nil, task.SetCompletionNote(strings.Repeat("a", 501))
+		},
+	}
+
+	handler := NewWebTaskHandler(nil, nil, mockComplete, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
+
+	req := htmx(httptest.NewRequest("POST", "/web/tasks/task-123/complete", strings.NewReader("completion_note="+strings.Repeat("a", 501))))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetPathValue("id", "task-123")
+	ctx := context.WithValue(req.Context(), "userID", "user-123")
+	req = req.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	handler.CompleteTask(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+	if len(mockComplete.note) != 501 {
+		t.Errorf("Expected the posted note to reach the use case, got %d characters", len(mockComplete.note))
+	}
+}
+
 // Mock for CompleteTaskUseCase (needed for web handler tests)
 type mockCompleteTaskUseCase struct {
 	executeFunc func(ctx context.Context, taskID, userID string) (*application.Task, error)
+	note        string
 }
 
-func (m *mockCompleteTaskUseCase) Execute(ctx context.Context, taskID, userID string) (*application.Task, error) {
+func (m *mockCompleteTaskUseCase) Execute(ctx context.Context, taskID, userID, note string) (*application.Task, error) {
+	m.note = note
 	if m.executeFunc != nil {
 		return m.executeFunc(ctx, taskID, userID)
 	}
@@ -600,7 +656,7 @@ func TestWebShareTask_ShareButtonAppearsOnlyForOwnTasks(t *testing.T) {
 	task, _ := application.NewTask(taskID, "Test Task", "Description", application.StatusPending, ownerID, "")
 
 	// Render for owner - should show share button
-	html, err := renderTaskCard(task, ownerID)
+	html, err := renderTaskCard(&application.TaskView{Task: task}, ownerID, i18n.Default)
 	if err != nil {
 		t.Fatalf("Failed to render task card: %v", err)
 	}
@@ -610,7 +666,7 @@ func TestWebShareTask_ShareButtonAppearsOnlyForOwnTasks(t *testing.T) {
 	}
 
 	// Render for non-owner - should NOT show share button
-	htmlShared, err := renderTaskCard(task, "user-2")
+	htmlShared, err := renderTaskCard(&application.TaskView{Task: task}, "user-2", i18n.Default)
 	if err != nil {
 		t.Fatalf("Failed to render task card: %v", err)
 	}
@@ -620,6 +676,145 @@ func TestWebShareTask_ShareButtonAppearsOnlyForOwnTasks(t *testing.T) {
 	}
 }
 
+func TestRenderTaskCard_Localized(t *testing.T) {
+	dueDate := time.Date(2025, 12, 25, 14, 30, 0, 0, time.UTC)
+	task := &application.Task{
+		ID:        "task-1",
+		Title:     "Test Task",
+		Status:    application.StatusPending,
+		OwnerID:   "user-1",
+		DueDate:   &dueDate,
+		CreatedAt: time.Date(2025, 12, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		locale application.Locale
+		want   []string
+	}{
+		{application.LocalePtBR, []string{"Pendente", "Própria", "Prazo: 25/12/2025 14:30", "01/12/2025 09:00", "Excluir"}},
+		{application.LocaleEN, []string{"Pending", "Own", "Due: 2025-12-25 14:30", "2025-12-01 09:00", "Delete"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.locale), func(t *testing.T) {
+			html, err := renderTaskCard(&application.TaskView{Task: task}, "user-1", tt.locale)
+			if err != nil {
+				t.Fatalf("Failed to render task card: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(html, want) {
+					t.Errorf("Expected %q in the task card", want)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderCompletedTask_KeepsContent(t *testing.T) {
+	task, _ := application.NewTask("task-1", "Relatório", "Enviar **hoje**", application.StatusCompleted, "user-1", "/uploads/relatorio.png")
+	task.Tags = []string{"trabalho"}
+	task.CompletionNote = "Entregue ao cliente"
+
+	tests := []struct {
+		name     string
+		undo     *application.TaskUndo
+		wantUndo bool
+	}{
+		{"with undo", &application.TaskUndo{TaskID: "task-1", ExpiresAt: time.Now().Add(10 * time.Second)}, true},
+		{"without undo", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := renderCompletedTask(&application.TaskView{Task: task}, "user-1", tt.undo, i18n.Default)
+			if err != nil {
+				t.Fatalf("Failed to render the completed task: %v", err)
+			}
+			for _, want := range []string{"Relatório", "<strong>hoje</strong>", `src="/uploads/relatorio.png"`, "#trabalho", "Entregue ao cliente", "Concluída", "Tarefa concluída com sucesso"} {
+				if !strings.Contains(html, want) {
+					t.Errorf("Expected %q on the completed card: %s", want, html)
+				}
+			}
+			if strings.Contains(html, "/complete") || strings.Contains(html, "/snooze") {
+				t.Error("A completed task should not offer to complete or snooze it again")
+			}
+			if got := strings.Contains(html, `hx-post="/web/tasks/task-1/undo"`); got != tt.wantUndo {
+				t.Errorf("undo button shown = %v, want %v", got, tt.wantUndo)
+			}
+		})
+	}
+}
+
+func TestRenderTaskCard_Color(t *testing.T) {
+	task, _ := application.NewTask("task-1", "Test Task", "", application.StatusPending, "user-1", "")
+
+	html, err := renderTaskCard(&application.TaskView{Task: task}, "user-1", i18n.Default)
+	if err != nil {
+		t.Fatalf("Failed to render task card: %v", err)
+	}
+	if strings.Contains(html, "border-l-4") {
+		t.Error("A task without a color should have no colored border")
+	}
+
+	task.SetColor(application.ColorGreen)
+	html, err = renderTaskCard(&application.TaskView{Task: task}, "user-1", i18n.Default)
+	if err != nil {
+		t.Fatalf("Failed to render task card: %v", err)
+	}
+	for _, want := range []string{"border-l-4 border-green-500", "Cor: Verde"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected %q in the task card", want)
+		}
+	}
+}
+
+// recordingShareTaskUseCase records the users a task is shared with and answers with results
+type recordingShareTaskUseCase struct {
+	recipients []string
+	results    []*application.ShareResult
+}
+
+func (m *recordingShareTaskUseCase) Execute(ctx context.Context, taskID, ownerID string, recipients []string) ([]*application.ShareResult, error) {
+	m.recipients = recipients
+	return m.results, nil
+}
+
+func TestWebShareTask_SeveralUsers(t *testing.T) {
+	shareTask := &recordingShareTaskUseCase{results: []*application.ShareResult{
+		{Recipient: "user-bia", User: &application.User{ID: "user-bia", Name: "Bia", Email: "bia@example.com"}},
+		{Recipient: "caio@example.com", Err: application.ErrUserNotFound},
+		{Recipient: "user-davi", User: &application.User{ID: "user-davi", Name: "Davi <D>", Email: "davi@example.com"}, Err: application.ErrTaskAlreadyShared},
+	}}
+	h := NewWebTaskHandler(nil, nil, nil, shareTask, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
+
+	form := url.Values{"share_with_user_id": {"user-bia", "caio@example.com", "user-davi"}}
+	req := htmx(httptest.NewRequest("POST", "/web/tasks/task-1/share", strings.NewReader(form.Encode())))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetPathValue("id", "task-1")
+	w := httptest.NewRecorder()
+	h.ShareTask(w, withUser(req))
+
+	if w.Code != http.StatusOK || w.Header().Get("HX-Retarget") != "" {
+		t.Fatalf("status = %d, HX-Retarget = %q: %s", w.Code, w.Header().Get("HX-Retarget"), w.Body.String())
+	}
+	if len(shareTask.recipients) != 3 {
+		t.Errorf("recipients = %v, want the 3 users picked", shareTask.recipients)
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		"Convite enviado! A tarefa será compartilhada quando o convite for aceito.",
+		"Convite enviado para Bia &lt;bia@example.com&gt;",
+		"Convite não enviado para caio@example.com: usuário não encontrado",
+		"Convite não enviado para Davi &lt;D&gt; &lt;davi@example.com&gt;: tarefa já compartilhada",
+		`<ul id="share-recipients-task-1" hx-swap-oob="innerHTML"></ul>`,
+		`<div id="share-task-1-errors" hx-swap-oob="innerHTML"></div>`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %q in:\n%s", want, body)
+		}
+	}
+}
+
 func TestWebShareTask_ShareButtonNotPresentInStaticTemplate(t *testing.T) {
 	// This test will fail until we implement the share button in tasks.html
 	// It's a placeholder to remind us to add the button to the static template
@@ -662,7 +857,7 @@ func TestTaskCard_ContainsIconsInButtons(t *testing.T) {
 
 	task, _ := application.NewTask(taskID, "Test Task", "Description", application.StatusPending, ownerID, "")
 
-	html, err := renderTaskCard(task, ownerID)
+	html, err := renderTaskCard(&application.TaskView{Task: task}, ownerID, i18n.Default)
 	if err != nil {
 		t.Fatalf("Failed to render task card: %v", err)
 	}
@@ -694,7 +889,7 @@ func TestTaskCard_CompletedTaskHasNoCompleteButton(t *testing.T) {
 
 	task, _ := application.NewTask(taskID, "Test Task", "Description", application.StatusCompleted, ownerID, "")
 
-	html, err := renderTaskCard(task, ownerID)
+	html, err := renderTaskCard(&application.TaskView{Task: task}, ownerID, i18n.Default)
 	if err != nil {
 		t.Fatalf("Failed to render task card: %v", err)
 	}
@@ -722,7 +917,7 @@ func TestTaskCard_SharedTaskHasNoShareButton(t *testing.T) {
 
 	task, _ := application.NewTask(taskID, "Test Task", "Description", application.StatusPending, ownerID, "")
 
-	html, err := renderTaskCard(task, viewerID)
+	html, err := renderTaskCard(&application.TaskView{Task: task}, viewerID, i18n.Default)
 	if err != nil {
 		t.Fatalf("Failed to render task card: %v", err)
 	}
@@ -737,3 +932,98 @@ func TestTaskCard_SharedTaskHasNoShareButton(t *testing.T) {
 		t.Error("Shared tasks should not have share icon")
 	}
 }
+
+func TestTaskCard_SharedTaskNamesItsOwner(t *testing.T) {
+	task, _ := application.NewTask("shared-task", "Test Task", "", application.StatusPending, "user-1", "")
+
+	tests := []struct {
+		name      string
+		ownerName string
+		viewerID  string
+		want      string
+		notWant   string
+	}{
+		{"shared task with its owner's name", "Ana <b>", "user-2", "Compartilhada por Ana &lt;b&gt;", ""},
+		{"owner unknown", "", "user-2", "Compartilhada", "Compartilhada por"},
+		{"own task", "Ana", "user-1", "Própria", "Compartilhada por"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := renderTaskCard(&application.TaskView{Task: task, OwnerName: tt.ownerName}, tt.viewerID, i18n.Default)
+			if err != nil {
+				t.Fatalf("Failed to render task card: %v", err)
+			}
+			if !strings.Contains(html, tt.want) {
+				t.Errorf("Expected the card to contain %q, got %s", tt.want, html)
+			}
+			if tt.notWant != "" && strings.Contains(html, tt.notWant) {
+				t.Errorf("Expected the card not to contain %q", tt.notWant)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// Markdown Tests
+// =============================================================================
+
+func TestRenderTaskCard_Markdown(t *testing.T) {
+	html, err := renderTaskCard(&application.TaskView{Task: &application.Task{
+		ID:          "task-md",
+		Title:       "Markdown",
+		Description: "**importante** [link](javascript:alert(1)) <b>x</b>",
+		Status:      application.StatusPending,
+		OwnerID:     "user-123",
+		CreatedAt:   time.Now(),
+	}}, "user-123", i18n.Default)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(html, "<strong>importante</strong>") {
+		t.Error("Expected bold Markdown to be rendered")
+	}
+	if strings.Contains(html, `href="javascript`) {
+		t.Error("javascript: links must not be rendered")
+	}
+	if strings.Contains(html, "<b>x</b>") {
+		t.Error("Raw HTML in the description must be escaped")
+	}
+}
+
+func TestWebPreviewDescription(t *testing.T) {
+	handler := NewWebTaskHandler(nil, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
+
+	formData := url.Values{}
+	formData.Set("description", "- **a**\n- <script>alert(1)</script>")
+
+	req := htmx(httptest.NewRequest("POST", "/web/tasks/preview", strings.NewReader(formData.Encode())))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	handler.PreviewDescription(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "<ul><li><strong>a</strong></li>") {
+		t.Errorf("Expected rendered list, got %s", body)
+	}
+	if strings.Contains(body, "<script>") {
+		t.Error("Preview must escape raw HTML")
+	}
+}
+
+func TestWebPreviewDescription_Empty(t *testing.T) {
+	handler := NewWebTaskHandler(nil, nil, nil, nil, nil, nil, nil, &mockGetOwnerNamesUseCase{}, NewPageTemplates(""), time.Local)
+
+	req := htmx(httptest.NewRequest("POST", "/web/tasks/preview", strings.NewReader("description=+")))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	handler.PreviewDescription(w, req)
+
+	if !strings.Contains(w.Body.String(), "Nada para pré-visualizar") {
+		t.Errorf("Expected empty preview message, got %s", w.Body.String())
+	}
+}
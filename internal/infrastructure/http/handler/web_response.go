@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"bytes"
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+)
+
+// The web handlers answer HTMX with HTML fragments, which are swapped into the page that made
+// the request. Requests without HTMX — a /web/ URL opened directly, a form posted without
+// JavaScript, an HTMX history restore — would show a bare fragment, so the helpers below
+// answer them with a full page, rendered with the page templates the handler was given, or a
+// redirect instead.
+
+// IsHTMX reports whether r was sent by HTMX to swap a fragment into the page. History restores
+// also carry HX-Request, but replace the whole page.
+func IsHTMX(r *http.Request) bool {
+	return r.Header.Get("HX-Request") == "true" && r.Header.Get("HX-History-Restore-Request") != "true"
+}
+
+// writeWebFragment answers a web request with an HTML fragment. Without HTMX, GETs and errors
+// show it in a full page, and the forms that succeeded go back to the page they were posted
+// from with 303 See Other.
+func (p *PageTemplates) writeWebFragment(w http.ResponseWriter, r *http.Request, status int, fragment string) {
+	if IsHTMX(r) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(status)
+		w.Write([]byte(fragment))
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead && status < 400 {
+		http.Redirect(w, r, returnPath(r), http.StatusSeeOther)
+		return
+	}
+	p.writeWebPage(w, r, status, template.HTML(fragment))
+}
+
+// writeWebPage answers a web request with an HTML fragment that stands on its own, like the
+// two-factor step replacing the login form: a full page without HTMX
+func (p *PageTemplates) writeWebPage(w http.ResponseWriter, r *http.Request, status int, fragment template.HTML) {
+	if IsHTMX(r) {
+		p.writeWebFragment(w, r, status, string(fragment))
+		return
+	}
+
+	locale := RequestLocale(r)
+	tmpl, err := p.Page(locale, nil, "base.html", "fragment.html")
+	if err != nil {
+		log.Printf("failed to parse the fragment page: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	title := i18n.T(locale, "fallback.title")
+	if status >= 400 {
+		title = i18n.T(locale, "fallback.error_title")
+	}
+	userID, _ := r.Context().Value("userID").(string)
+	data := PageData(locale, map[string]interface{}{
+		"Title":    title,
+		"UserID":   userID,
+		"Theme":    string(ThemeFromRequest(r)),
+		"Fragment": fragment,
+		"Error":    status >= 400,
+		"Back":     returnPath(r),
+	})
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		log.Printf("failed to render the fragment page: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
+
+// writeWebError answers a web request with an error message, already localized by the caller:
+// plain text for HTMX, an error page otherwise
+func (p *PageTemplates) writeWebError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	if IsHTMX(r) {
+		http.Error(w, message, status)
+		return
+	}
+	p.writeWebPage(w, r, status, template.HTML("<p>"+template.HTMLEscapeString(message)+"</p>"))
+}
+
+// writeWebRedirect sends the browser to location: with HX-Redirect for HTMX, which follows it
+// itself, and 303 See Other otherwise
+func writeWebRedirect(w http.ResponseWriter, r *http.Request, location string) {
+	if IsHTMX(r) {
+		w.Header().Set("HX-Redirect", location)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	http.Redirect(w, r, location, http.StatusSeeOther)
+}
+
+// returnPath returns the path of the page a form was posted from, taken from the Referer when
+// it's on this host, or the tasks page. The routes under /web/auth/ only answer POSTs, so they
+// aren't returned to.
+func returnPath(r *http.Request) string {
+	referer, err := url.Parse(r.Referer())
+	if err != nil || referer.Host != r.Host || !strings.HasPrefix(referer.Path, "/") || strings.HasPrefix(referer.Path, "/web/auth/") {
+		return "/tasks"
+	}
+	return referer.RequestURI()
+}
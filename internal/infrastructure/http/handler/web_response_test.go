@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// htmx marks r as sent by HTMX, which the web handlers answer with fragments
+func htmx(r *http.Request) *http.Request {
+	r.Header.Set("HX-Request", "true")
+	return r
+}
+
+func TestIsHTMX(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    bool
+	}{
+		{name: "HTMX request", headers: map[string]string{"HX-Request": "true"}, want: true},
+		{name: "plain request", want: false},
+		{name: "history restore", headers: map[string]string{"HX-Request": "true", "HX-History-Restore-Request": "true"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/web/invitations", nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			if got := IsHTMX(req); got != tt.want {
+				t.Errorf("IsHTMX() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebResponses(t *testing.T) {
+	pages := NewPageTemplates("")
+
+	tests := []struct {
+		name         string
+		method       string
+		htmx         bool
+		referer      string
+		write        func(w http.ResponseWriter, r *http.Request)
+		wantStatus   int
+		wantLocation string
+		wantHeader   [2]string
+		wantBody     []string
+		notBody      []string
+	}{
+		{
+			name:   "fragment for HTMX",
+			method: "POST",
+			htmx:   true,
+			write: func(w http.ResponseWriter, r *http.Request) {
+				pages.writeWebFragment(w, r, http.StatusOK, `<div id="task-1"></div>`)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   []string{`<div id="task-1"></div>`},
+			notBody:    []string{"<!DOCTYPE html>"},
+		},
+		{
+			name:    "form post goes back to its page",
+			method:  "POST",
+			referer: "http://example.com/tasks?project=p1",
+			write: func(w http.ResponseWriter, r *http.Request) {
+				pages.writeWebFragment(w, r, http.StatusOK, `<div id="task-1"></div>`)
+			},
+			wantStatus:   http.StatusSeeOther,
+			wantLocation: "/tasks?project=p1",
+		},
+		{
+			name:         "referer of another host is ignored",
+			method:       "POST",
+			referer:      "http://evil.test/tasks",
+			write:        func(w http.ResponseWriter, r *http.Request) { pages.writeWebFragment(w, r, http.StatusNoContent, "") },
+			wantStatus:   http.StatusSeeOther,
+			wantLocation: "/tasks",
+		},
+		{
+			name:   "fragment fetched with GET is a page",
+			method: "GET",
+			write: func(w http.ResponseWriter, r *http.Request) {
+				pages.writeWebFragment(w, r, http.StatusOK, `<section id="invitations"></section>`)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   []string{"<!DOCTYPE html>", `<section id="invitations"></section>`, `href="/tasks"`},
+		},
+		{
+			name:    "error is a page",
+			method:  "POST",
+			referer: "http://example.com/tasks",
+			write: func(w http.ResponseWriter, r *http.Request) {
+				pages.writeWebError(w, r, http.StatusForbidden, "only the <owner>")
+			},
+			wantStatus: http.StatusForbidden,
+			wantBody:   []string{"<!DOCTYPE html>", `role="alert"><p>only the &lt;owner&gt;</p></div>`, `href="/tasks"`},
+		},
+		{
+			name:   "error for HTMX",
+			method: "POST",
+			htmx:   true,
+			write: func(w http.ResponseWriter, r *http.Request) {
+				pages.writeWebError(w, r, http.StatusForbidden, "only the owner")
+			},
+			wantStatus: http.StatusForbidden,
+			wantBody:   []string{"only the owner"},
+			notBody:    []string{"<!DOCTYPE html>"},
+		},
+		{
+			name:    "standalone fragment is a page",
+			method:  "POST",
+			referer: "http://example.com/web/auth/login",
+			write: func(w http.ResponseWriter, r *http.Request) {
+				pages.writeWebPage(w, r, http.StatusOK, `<form id="login-form"></form>`)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   []string{"<!DOCTYPE html>", `<form id="login-form"></form>`, `href="/tasks"`},
+		},
+		{
+			name:       "redirect for HTMX",
+			method:     "POST",
+			htmx:       true,
+			write:      func(w http.ResponseWriter, r *http.Request) { writeWebRedirect(w, r, "/login") },
+			wantStatus: http.StatusOK,
+			wantHeader: [2]string{"HX-Redirect", "/login"},
+		},
+		{
+			name:         "redirect without HTMX",
+			method:       "POST",
+			write:        func(w http.ResponseWriter, r *http.Request) { writeWebRedirect(w, r, "/login") },
+			wantStatus:   http.StatusSeeOther,
+			wantLocation: "/login",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/web/tasks/task-1/complete", nil)
+			if tt.htmx {
+				req = htmx(req)
+			}
+			if tt.referer != "" {
+				req.Header.Set("Referer", tt.referer)
+			}
+			w := httptest.NewRecorder()
+
+			tt.write(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantLocation != "" && w.Header().Get("Location") != tt.wantLocation {
+				t.Errorf("Expected Location %q, got %q", tt.wantLocation, w.Header().Get("Location"))
+			}
+			if tt.wantHeader[0] != "" && w.Header().Get(tt.wantHeader[0]) != tt.wantHeader[1] {
+				t.Errorf("Expected %s %q, got %q", tt.wantHeader[0], tt.wantHeader[1], w.Header().Get(tt.wantHeader[0]))
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(w.Body.String(), want) {
+					t.Errorf("Expected %q in:\n%s", want, w.Body.String())
+				}
+			}
+			for _, unwanted := range tt.notBody {
+				if strings.Contains(w.Body.String(), unwanted) {
+					t.Errorf("Expected no %q in:\n%s", unwanted, w.Body.String())
+				}
+			}
+		})
+	}
+}
@@ -2,42 +2,59 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/http/handler"
 )
 
-// AuthMiddleware provides JWT-based authentication
-func AuthMiddleware(jwtSecret string) func(http.Handler) http.Handler {
-	authService := service.NewAuthService(jwtSecret)
+// AuthMiddleware provides JWT-based authentication. Tokens are read from the Authorization
+// header or the authCookie cookie, and checked against the current keys of jwtKeys, so
+// replacing them takes effect on the next request, and against their user in users, so those
+// of deleted or disabled users, or revoked by a password reset, are refused.
+func AuthMiddleware(jwtKeys *service.JWTKeySet, users service.SessionUsers, authCookie string) func(http.Handler) http.Handler {
+	authService := service.NewAuthServiceWithKeys(jwtKeys, nil)
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Extract token from Authorization header or cookie
-			token := extractToken(r)
+			token := extractToken(r, authCookie)
 			if token == "" {
-				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				writeError(w, r, http.StatusUnauthorized, handler.ErrorCode(http.StatusUnauthorized), "Unauthorized")
 				return
 			}
 
 			// Validate token
 			claims, err := authService.ValidateToken(token)
 			if err != nil {
-				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				writeError(w, r, http.StatusUnauthorized, handler.ErrorCode(http.StatusUnauthorized), "Unauthorized")
+				return
+			}
+			if err := service.CheckSession(r.Context(), users, claims); err != nil {
+				if errors.Is(err, service.ErrSessionRevoked) {
+					writeError(w, r, http.StatusUnauthorized, handler.ErrorCode(http.StatusUnauthorized), "Unauthorized")
+					return
+				}
+				writeError(w, r, http.StatusInternalServerError, handler.ErrorCode(http.StatusInternalServerError), "Failed to check session")
 				return
 			}
 
 			// Add userID and email to context
 			ctx := context.WithValue(r.Context(), "userID", claims.UserID)
 			ctx = context.WithValue(ctx, "email", claims.Email)
+			trace.SpanFromContext(ctx).SetAttributes(attribute.String("enduser.id", claims.UserID))
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
 // extractToken extracts JWT token from Authorization header or cookie
-func extractToken(r *http.Request) string {
+func extractToken(r *http.Request, authCookie string) string {
 	// Try Authorization header first (Bearer token)
 	authHeader := r.Header.Get("Authorization")
 	if authHeader != "" {
@@ -48,7 +65,7 @@ func extractToken(r *http.Request) string {
 	}
 
 	// Try cookie as fallback
-	cookie, err := r.Cookie("auth_token")
+	cookie, err := r.Cookie(authCookie)
 	if err == nil && cookie.Value != "" {
 		return cookie.Value
 	}
@@ -56,17 +73,12 @@ func extractToken(r *http.Request) string {
 	return ""
 }
 
-// CORS middleware for development
+// CORS middleware for development. Preflight (OPTIONS) requests are answered by
+// MethodsMiddleware, which must run after it, with the methods of the requested route.
 func CORSMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-User-ID")
-
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-User-ID, X-Timezone, If-None-Match, X-Org-ID, Idempotency-Key, X-Captcha-Token")
 
 		next.ServeHTTP(w, r)
 	})
@@ -74,15 +86,33 @@ func CORSMiddleware(next http.Handler) http.Handler {
 
 // SecurityHeadersMiddleware adds security headers
 func SecurityHeadersMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("X-Content-Type-Options", "nosniff")
-		w.Header().Set("X-Frame-Options", "DENY")
-		w.Header().Set("X-XSS-Protection", "1; mode=block")
-		w.Header().Set("Strict-Transport-Security", "max-age=31536000; includeSubDomains")
-		w.Header().Set("Content-Security-Policy", "default-src 'self'; script-src 'self' 'unsafe-inline' https://unpkg.com https://cdn.tailwindcss.com; style-src 'self' 'unsafe-inline'; font-src 'self' data:; img-src 'self' data:")
+	return SecurityHeaders(nil)(next)
+}
 
-		next.ServeHTTP(w, r)
-	})
+// SecurityHeaders adds security headers, the Content-Security-Policy also letting the pages load
+// scripts, styles and frames from widgetOrigins, like those of a CAPTCHA provider
+func SecurityHeaders(widgetOrigins []string) func(http.Handler) http.Handler {
+	origins := ""
+	for _, origin := range widgetOrigins {
+		origins += " " + origin
+	}
+	csp := "default-src 'self'; script-src 'self' 'unsafe-inline' https://unpkg.com https://cdn.tailwindcss.com" + origins + "; style-src 'self' 'unsafe-inline'" + origins + "; font-src 'self' data:; img-src 'self' data:"
+	if origins != "" {
+		// The widgets run in frames of their origins, which call them back
+		csp += "; frame-src" + origins + "; connect-src 'self'" + origins
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Content-Type-Options", "nosniff")
+			w.Header().Set("X-Frame-Options", "DENY")
+			w.Header().Set("X-XSS-Protection", "1; mode=block")
+			w.Header().Set("Strict-Transport-Security", "max-age=31536000; includeSubDomains")
+			w.Header().Set("Content-Security-Policy", csp)
+
+			next.ServeHTTP(w, r)
+		})
+	}
 }
 
 // LoggingMiddleware logs HTTP requests
@@ -106,7 +136,7 @@ func RecoverMiddleware(next http.Handler) http.Handler {
 			if err := recover(); err != nil {
 				// Log the error
 				println("Panic recovered:", err)
-				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, handler.ErrorCode(http.StatusInternalServerError), "Internal Server Error")
 			}
 		}()
 
@@ -121,18 +151,3 @@ func Chain(h http.Handler, middlewares ...func(http.Handler) http.Handler) http.
 	}
 	return h
 }
-
-// ContentTypeJSON sets JSON content type
-func ContentTypeJSON(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Only check content-type for requests with body
-		if r.Method == "POST" || r.Method == "PUT" || r.Method == "PATCH" {
-			contentType := r.Header.Get("Content-Type")
-			if !strings.Contains(contentType, "application/json") {
-				http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
-				return
-			}
-		}
-		next.ServeHTTP(w, r)
-	})
-}
@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/database"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// mockSessionUsers holds the users by ID, failing every lookup when err is set
+type mockSessionUsers struct {
+	users map[string]*application.User
+	err   error
+}
+
+func (m *mockSessionUsers) FindByID(ctx context.Context, id string) (*application.User, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if user, ok := m.users[id]; ok {
+		return user, nil
+	}
+	return nil, application.ErrUserNotFound
+}
+
+func TestAuthMiddleware_RevokedSessions(t *testing.T) {
+	keys := service.NewJWTKeySet("secret")
+	authService := service.NewAuthServiceWithKeys(keys, nil)
+	users := &mockSessionUsers{users: map[string]*application.User{
+		"ana":  {ID: "ana", TokenVersion: 1},
+		"bia":  {ID: "bia"},
+		"caio": {ID: "caio", Disabled: true},
+	}}
+	h := AuthMiddleware(keys, users, "auth_token")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name       string
+		userID     string
+		version    int
+		lookupErr  error
+		wantStatus int
+	}{
+		{"current version", "ana", 1, nil, http.StatusOK},
+		{"issued before a password reset", "ana", 0, nil, http.StatusUnauthorized},
+		{"token without a version", "bia", 0, nil, http.StatusOK},
+		{"disabled user", "caio", 0, nil, http.StatusUnauthorized},
+		{"deleted user", "dani", 0, nil, http.StatusUnauthorized},
+		{"lookup failing", "ana", 1, errors.New("database is locked"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users.err = tt.lookupErr
+			token, err := authService.GenerateVersionedToken(tt.userID, tt.userID+"@example.com", tt.version, time.Hour)
+			if err != nil {
+				t.Fatalf("GenerateVersionedToken() error: %v", err)
+			}
+
+			req := httptest.NewRequest("GET", "/api/tasks", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_TokenIssuedBeforeDisable(t *testing.T) {
+	db, err := database.NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	userRepo := database.NewSQLiteUserRepository(db)
+	for _, user := range []*application.User{
+		{ID: "admin", Name: "Admin", Email: "admin@example.com", Role: application.RoleAdmin, CreatedAt: time.Now()},
+		{ID: "ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()},
+	} {
+		if err := userRepo.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	keys := service.NewJWTKeySet("secret")
+	h := AuthMiddleware(keys, userRepo, "auth_token")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	get := func(token string) int {
+		req := httptest.NewRequest("GET", "/api/tasks", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w.Code
+	}
+	login := func() string {
+		user, err := userRepo.FindByID(ctx, "ana")
+		if err != nil {
+			t.Fatalf("FindByID() error: %v", err)
+		}
+		token, err := service.NewAuthServiceWithKeys(keys, nil).GenerateVersionedToken(user.ID, user.Email, user.TokenVersion, time.Hour)
+		if err != nil {
+			t.Fatalf("GenerateVersionedToken() error: %v", err)
+		}
+		return token
+	}
+
+	token := login()
+	if code := get(token); code != http.StatusOK {
+		t.Fatalf("Expected the token accepted before the disable, got %d", code)
+	}
+
+	if _, err := usecases.NewSetUserDisabledUseCase(userRepo).Execute(ctx, "admin", "ana", true); err != nil {
+		t.Fatalf("SetUserDisabled() error: %v", err)
+	}
+	if code := get(token); code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for a token issued before the disable, got %d", code)
+	}
+
+	// Enabled again, the user needs a new login
+	if _, err := usecases.NewSetUserDisabledUseCase(userRepo).Execute(ctx, "admin", "ana", false); err != nil {
+		t.Fatalf("SetUserDisabled() error: %v", err)
+	}
+	if code := get(token); code != http.StatusUnauthorized {
+		t.Errorf("Expected the old token still refused once enabled, got %d", code)
+	}
+	if code := get(login()); code != http.StatusOK {
+		t.Errorf("Expected a new token accepted, got %d", code)
+	}
+
+	// A password reset and a deletion revoke the sessions too
+	token = login()
+	if _, _, err := usecases.NewResetUserPasswordUseCase(userRepo, nil).Execute(ctx, "admin", "ana"); err != nil {
+		t.Fatalf("ResetUserPassword() error: %v", err)
+	}
+	if code := get(token); code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for a token issued before the reset, got %d", code)
+	}
+	token = login()
+	if err := usecases.NewDeleteUserUseCase(userRepo).Execute(ctx, "admin", "ana"); err != nil {
+		t.Fatalf("DeleteUser() error: %v", err)
+	}
+	if code := get(token); code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for a token of a deleted user, got %d", code)
+	}
+}
@@ -0,0 +1,210 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/cache"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/http/handler"
+)
+
+const (
+	// maxLoginBody is the largest body read for the email of a login attempt; the login forms
+	// are far smaller. Attempts with larger bodies are only tracked by IP.
+	maxLoginBody = 64 << 10
+
+	// defaultBruteForceMaxKeys is how many IPs and emails are tracked when MaxKeys is 0
+	defaultBruteForceMaxKeys = 10000
+)
+
+// Messages of the CAPTCHA challenge, translated like the other errors
+const (
+	captchaRequiredMessage    = "Too many failed attempts: solve the CAPTCHA to try again"
+	captchaInvalidMessage     = "The CAPTCHA was not solved, please try again"
+	captchaUnavailableMessage = "The CAPTCHA could not be verified, please try again later"
+)
+
+// CaptchaVerifier checks the answers to CAPTCHA challenges, like a *captcha.Verifier
+type CaptchaVerifier interface {
+	// Verify reports whether token answers a challenge solved by the client at remoteIP; an
+	// error means the answer couldn't be checked
+	Verify(ctx context.Context, token, remoteIP string) (bool, error)
+	// ResponseField returns the form field the widget posts its answer in
+	ResponseField() string
+}
+
+// BruteForceConfig holds the configuration for the brute-force protection of the login routes
+type BruteForceConfig struct {
+	Threshold int           // Failed attempts from an IP or for an email before a CAPTCHA is required; below 1 means 1
+	Window    time.Duration // Failures are forgotten once there were none for this long
+	MaxKeys   int           // IPs and emails tracked at once, the least recently failed evicted beyond; 0 means 10000
+	Verifier  CaptchaVerifier
+	Widget    handler.CaptchaWidget  // Rendered in the login form, and told to API clients
+	Pages     *handler.PageTemplates // Render the challenge to web forms sent without HTMX
+}
+
+// BruteForceMiddleware requires a solved CAPTCHA from the clients failing to log in. Attempts
+// answered 401 count as failures of their IP and of the email they were for. Once either has
+// Threshold failures, each attempt needs the token of a solved challenge, in X-Captcha-Token or
+// in the form field of the widget, or is answered 428 with the challenge. Tokens are verified
+// before the handler runs. A successful attempt clears the failures of its email; those of its
+// IP, which an attacker could clear with an account of their own, only expire.
+func BruteForceMiddleware(config BruteForceConfig) func(http.Handler) http.Handler {
+	if config.Threshold < 1 {
+		config.Threshold = 1
+	}
+	if config.MaxKeys <= 0 {
+		config.MaxKeys = defaultBruteForceMaxKeys
+	}
+	failures := &failureCounter{counts: cache.NewLRU[int](config.MaxKeys, config.Window)}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxLoginBody+1))
+			if err != nil {
+				writeError(w, r, http.StatusBadRequest, handler.ErrorCode(http.StatusBadRequest), "Failed to read request body")
+				return
+			}
+			r.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+
+			ip := requestIP(r)
+			email, token := loginAttempt(r, body, config.Verifier.ResponseField())
+			keys := []string{"ip:" + ip}
+			if email != "" {
+				keys = append(keys, "email:"+email)
+			}
+
+			if failures.count(keys) >= config.Threshold {
+				if token == "" {
+					writeCaptchaChallenge(w, r, config.Pages, config.Widget, "captcha_required", captchaRequiredMessage)
+					return
+				}
+				solved, err := config.Verifier.Verify(r.Context(), token, ip)
+				if err != nil {
+					log.Printf("failed to verify CAPTCHA: %v", err)
+					w.Header().Set("Retry-After", "30")
+					writeError(w, r, http.StatusServiceUnavailable, handler.ErrorCode(http.StatusServiceUnavailable), captchaUnavailableMessage)
+					return
+				}
+				if !solved {
+					writeCaptchaChallenge(w, r, config.Pages, config.Widget, "captcha_invalid", captchaInvalidMessage)
+					return
+				}
+			}
+
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			switch {
+			case rec.status == http.StatusUnauthorized:
+				failures.add(keys)
+			case rec.status < 400 && email != "":
+				failures.clear("email:" + email)
+			}
+		})
+	}
+}
+
+// writeCaptchaChallenge answers 428 Precondition Required to an attempt needing a solved CAPTCHA:
+// the error with the provider and its site key in headers for API clients, the widget for web
+// forms
+func writeCaptchaChallenge(w http.ResponseWriter, r *http.Request, pages *handler.PageTemplates, widget handler.CaptchaWidget, code, message string) {
+	if !isAPIRequest(r) {
+		pages.WriteCaptchaChallenge(w, r, http.StatusPreconditionRequired, widget, message)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Captcha-Provider", widget.Provider)
+	w.Header().Set("X-Captcha-Site-Key", widget.SiteKey)
+	writeError(w, r, http.StatusPreconditionRequired, code, message)
+}
+
+// loginAttempt returns the email a login attempt is for, lowercased, and the CAPTCHA token sent
+// with it, read from a JSON or form body. A body cut at maxLoginBody gives neither.
+func loginAttempt(r *http.Request, body []byte, tokenField string) (email, token string) {
+	token = r.Header.Get(handler.CaptchaTokenHeader)
+	if len(body) > maxLoginBody {
+		return "", token
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		var attempt struct {
+			Email string `json:"email"`
+		}
+		json.Unmarshal(body, &attempt)
+		email = attempt.Email
+	case "application/x-www-form-urlencoded":
+		values, _ := url.ParseQuery(string(body))
+		email = values.Get("email")
+		if token == "" {
+			token = values.Get(tokenField)
+		}
+	}
+	return strings.ToLower(strings.TrimSpace(email)), token
+}
+
+// requestIP returns the client IP resolved by ClientIPMiddleware, falling back to the
+// connection address
+func requestIP(r *http.Request) string {
+	if ip, ok := r.Context().Value("clientIP").(string); ok && ip != "" {
+		return ip
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// failureCounter counts the failed login attempts by key, each count expiring once it wasn't
+// increased for a while
+type failureCounter struct {
+	mu     sync.Mutex // Makes the increments atomic
+	counts *cache.LRU[int]
+}
+
+// count returns the highest count of keys
+func (c *failureCounter) count(keys []string) int {
+	highest := 0
+	for _, key := range keys {
+		if n, ok := c.counts.Get(key); ok && n > highest {
+			highest = n
+		}
+	}
+	return highest
+}
+
+// add counts a failure for each of keys
+func (c *failureCounter) add(keys []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		n, _ := c.counts.Get(key)
+		c.counts.Set(key, n+1)
+	}
+}
+
+// clear forgets the failures of key
+func (c *failureCounter) clear(key string) {
+	c.counts.Delete(key)
+}
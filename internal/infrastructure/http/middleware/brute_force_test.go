@@ -0,0 +1,216 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/http/handler"
+)
+
+// fakeCaptchaVerifier accepts a single token, or fails every verification when err is set
+type fakeCaptchaVerifier struct {
+	err      error
+	verified []string
+}
+
+func (f *fakeCaptchaVerifier) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	f.verified = append(f.verified, token)
+	if f.err != nil {
+		return false, f.err
+	}
+	return token == "solved", nil
+}
+
+func (f *fakeCaptchaVerifier) ResponseField() string { return "h-captcha-response" }
+
+// newBruteForceHandler returns a login handler behind BruteForceMiddleware, accepting the
+// password "right" for any email
+func newBruteForceHandler(verifier *fakeCaptchaVerifier) http.Handler {
+	return BruteForceMiddleware(BruteForceConfig{
+		Threshold: 2,
+		Window:    time.Minute,
+		Verifier:  verifier,
+		Widget:    handler.CaptchaWidget{Provider: "hcaptcha", SiteKey: "site-key", ScriptURL: "https://js.hcaptcha.com/1/api.js", Global: "hcaptcha"},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"password":"right"`) && !strings.Contains(string(body), "password=right") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+// apiLogin posts a JSON login attempt to the API from ip
+func apiLogin(h http.Handler, ip, email, password, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/api/auth/login", strings.NewReader(`{"email":"`+email+`","password":"`+password+`"}`))
+	req.RemoteAddr = ip + ":12345"
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set(handler.CaptchaTokenHeader, token)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestBruteForceMiddleware_RequiresCaptchaAfterFailures(t *testing.T) {
+	verifier := &fakeCaptchaVerifier{}
+	h := newBruteForceHandler(verifier)
+
+	for i := 0; i < 2; i++ {
+		if w := apiLogin(h, "192.168.1.1", "ana@example.com", "wrong", ""); w.Code != http.StatusUnauthorized {
+			t.Fatalf("Attempt %d: expected 401, got %d", i+1, w.Code)
+		}
+	}
+	if len(verifier.verified) != 0 {
+		t.Fatalf("Expected no CAPTCHA verified below the threshold, got %v", verifier.verified)
+	}
+
+	tests := []struct {
+		name       string
+		ip         string
+		email      string
+		password   string
+		token      string
+		wantStatus int
+		wantCode   string
+	}{
+		{"same IP and email", "192.168.1.1", "ana@example.com", "right", "", http.StatusPreconditionRequired, "captcha_required"},
+		{"same email from another IP", "192.168.1.2", "ANA@example.com ", "right", "", http.StatusPreconditionRequired, "captcha_required"},
+		{"another email from the same IP", "192.168.1.1", "bia@example.com", "right", "", http.StatusPreconditionRequired, "captcha_required"},
+		{"unsolved CAPTCHA", "192.168.1.1", "ana@example.com", "right", "guessed", http.StatusPreconditionRequired, "captcha_invalid"},
+		{"another client", "192.168.1.3", "bia@example.com", "wrong", "", http.StatusUnauthorized, ""},
+		{"solved CAPTCHA", "192.168.1.1", "ana@example.com", "right", "solved", http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := apiLogin(h, tt.ip, tt.email, tt.password, tt.token)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantCode == "" {
+				return
+			}
+			if !strings.Contains(w.Body.String(), `"code":"`+tt.wantCode+`"`) {
+				t.Errorf("Expected code %s, got %s", tt.wantCode, w.Body.String())
+			}
+			if w.Header().Get("X-Captcha-Provider") != "hcaptcha" || w.Header().Get("X-Captcha-Site-Key") != "site-key" {
+				t.Errorf("Expected the provider and site key in headers, got %v", w.Header())
+			}
+		})
+	}
+}
+
+func TestBruteForceMiddleware_SuccessClearsOnlyTheEmail(t *testing.T) {
+	h := newBruteForceHandler(&fakeCaptchaVerifier{})
+
+	// Two IPs fail once each for the same email, which reaches the threshold
+	apiLogin(h, "192.168.1.1", "ana@example.com", "wrong", "")
+	apiLogin(h, "192.168.1.2", "ana@example.com", "wrong", "")
+	if w := apiLogin(h, "192.168.1.3", "ana@example.com", "right", ""); w.Code != http.StatusPreconditionRequired {
+		t.Fatalf("Expected the email to need a CAPTCHA, got %d", w.Code)
+	}
+	if w := apiLogin(h, "192.168.1.3", "ana@example.com", "right", "solved"); w.Code != http.StatusOK {
+		t.Fatalf("Expected the login with a solved CAPTCHA to succeed, got %d", w.Code)
+	}
+
+	// The email is cleared, so a fresh IP logs in without a CAPTCHA
+	if w := apiLogin(h, "192.168.1.4", "ana@example.com", "right", ""); w.Code != http.StatusOK {
+		t.Errorf("Expected no CAPTCHA once the email logged in, got %d", w.Code)
+	}
+
+	// The failures of an IP aren't cleared by logging in to another account
+	apiLogin(h, "192.168.1.5", "bia@example.com", "wrong", "")
+	apiLogin(h, "192.168.1.5", "cris@example.com", "wrong", "")
+	if w := apiLogin(h, "192.168.1.5", "dani@example.com", "right", "solved"); w.Code != http.StatusOK {
+		t.Fatalf("Expected the login with a solved CAPTCHA to succeed, got %d", w.Code)
+	}
+	if w := apiLogin(h, "192.168.1.5", "bia@example.com", "right", ""); w.Code != http.StatusPreconditionRequired {
+		t.Errorf("Expected the IP to still need a CAPTCHA, got %d", w.Code)
+	}
+}
+
+func TestBruteForceMiddleware_VerifierUnavailable(t *testing.T) {
+	verifier := &fakeCaptchaVerifier{}
+	h := newBruteForceHandler(verifier)
+	apiLogin(h, "192.168.1.1", "ana@example.com", "wrong", "")
+	apiLogin(h, "192.168.1.1", "ana@example.com", "wrong", "")
+
+	verifier.err = errors.New("provider down")
+	w := apiLogin(h, "192.168.1.1", "ana@example.com", "right", "solved")
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503 when the CAPTCHA can't be verified, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("Expected Retry-After")
+	}
+}
+
+func TestBruteForceMiddleware_WebForm(t *testing.T) {
+	verifier := &fakeCaptchaVerifier{}
+	h := newBruteForceHandler(verifier)
+
+	webLogin := func(form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/web/auth/login", strings.NewReader(form.Encode()))
+		req.RemoteAddr = "192.168.1.1:12345"
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("HX-Request", "true")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	webLogin(url.Values{"email": {"ana@example.com"}, "password": {"wrong"}})
+	webLogin(url.Values{"email": {"ana@example.com"}, "password": {"wrong"}})
+
+	w := webLogin(url.Values{"email": {"ana@example.com"}, "password": {"right"}})
+	if w.Code != http.StatusPreconditionRequired {
+		t.Fatalf("Expected 428, got %d", w.Code)
+	}
+	if got := w.Header().Get("HX-Retarget"); got != "#captcha-challenge" {
+		t.Errorf("Expected the challenge retargeted to its slot, got %q", got)
+	}
+	body := w.Body.String()
+	for _, want := range []string{`id="captcha-challenge"`, "data-captcha-widget", `"site-key"`, `id="error-message" hx-swap-oob="innerHTML"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %s in the challenge, got %s", want, body)
+		}
+	}
+
+	// The widget posts its answer in the form, which still reaches the handler
+	w = webLogin(url.Values{"email": {"ana@example.com"}, "password": {"right"}, "h-captcha-response": {"solved"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected the login with a solved CAPTCHA to succeed, got %d", w.Code)
+	}
+	if len(verifier.verified) != 1 || verifier.verified[0] != "solved" {
+		t.Errorf("Expected the form token verified, got %v", verifier.verified)
+	}
+}
+
+func TestBruteForceMiddleware_FailuresExpire(t *testing.T) {
+	h := BruteForceMiddleware(BruteForceConfig{
+		Threshold: 1,
+		Window:    50 * time.Millisecond,
+		Verifier:  &fakeCaptchaVerifier{},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+
+	apiLogin(h, "192.168.1.1", "ana@example.com", "wrong", "")
+	if w := apiLogin(h, "192.168.1.1", "ana@example.com", "wrong", ""); w.Code != http.StatusPreconditionRequired {
+		t.Fatalf("Expected 428 right after the failure, got %d", w.Code)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if w := apiLogin(h, "192.168.1.1", "ana@example.com", "wrong", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected the failures forgotten after the window, got %d", w.Code)
+	}
+}
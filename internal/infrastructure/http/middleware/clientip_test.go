@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIPMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		remoteAddr     string
+		forwardedFor   string
+		trustedProxies []string
+		wantIP         string
+	}{
+		{name: "uses the connection address", remoteAddr: "203.0.113.7:5000", wantIP: "203.0.113.7"},
+		{name: "ignores proxy headers from untrusted peers", remoteAddr: "203.0.113.7:5000", forwardedFor: "198.51.100.1", wantIP: "203.0.113.7"},
+		{name: "uses proxy headers from trusted proxies", remoteAddr: "10.0.0.1:5000", forwardedFor: "198.51.100.1, 10.0.0.1", trustedProxies: []string{"10.0.0.1"}, wantIP: "198.51.100.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := ClientIPMiddleware(tt.trustedProxies)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, _ = r.Context().Value("clientIP").(string)
+			}))
+
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.wantIP {
+				t.Errorf("clientIP = %q, want %q", got, tt.wantIP)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CompressConfig holds the configuration for response compression
+type CompressConfig struct {
+	MinSize int // Responses smaller than this (in bytes) are sent uncompressed; defaults to 1024
+	Level   int // gzip/zlib compression level; 0 uses gzip.DefaultCompression
+}
+
+// compressibleTypes lists the media types worth compressing. Images, PDFs, archives and
+// other already-compressed formats are left out, so they are always sent as they are.
+var compressibleTypes = map[string]bool{
+	"application/json":       true,
+	"application/javascript": true,
+	"application/xml":        true,
+	"application/atom+xml":   true,
+	"image/svg+xml":          true,
+}
+
+// isCompressible reports whether a Content-Type header value is worth compressing
+func isCompressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "text/") || compressibleTypes[mediaType]
+}
+
+// negotiateEncoding picks gzip or deflate from an Accept-Encoding header, honouring
+// q-values (q=0 refuses an encoding). It returns "" when neither is acceptable.
+func negotiateEncoding(acceptEncoding string) string {
+	best, bestQ := "", 0.0
+	wildcardQ := -1.0
+	q := map[string]float64{}
+
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		value := 1.0
+		if key, raw, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(key) == "q" {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+			if err != nil {
+				continue
+			}
+			value = parsed
+		}
+		if name == "*" {
+			wildcardQ = value
+			continue
+		}
+		q[name] = value
+	}
+
+	// gzip first: on a tie it wins, as it is the most widely supported
+	for _, encoding := range []string{"gzip", "deflate"} {
+		value, ok := q[encoding]
+		if !ok {
+			value = wildcardQ
+		}
+		if value > bestQ {
+			best, bestQ = encoding, value
+		}
+	}
+
+	return best
+}
+
+// compressorPools reuse gzip and zlib writers across responses, keyed by encoding and level
+var compressorPools sync.Map
+
+// compressor compresses a response body
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// getCompressor returns a pooled compressor writing to w
+func getCompressor(encoding string, level int, w io.Writer) compressor {
+	key := encoding + strconv.Itoa(level)
+	pool, _ := compressorPools.LoadOrStore(key, &sync.Pool{})
+	if c, ok := pool.(*sync.Pool).Get().(compressor); ok {
+		c.Reset(w)
+		return c
+	}
+
+	if encoding == "gzip" {
+		c, _ := gzip.NewWriterLevel(w, level)
+		return c
+	}
+	c, _ := zlib.NewWriterLevel(w, level)
+	return c
+}
+
+// putCompressor returns a compressor to its pool
+func putCompressor(encoding string, level int, c compressor) {
+	if pool, ok := compressorPools.Load(encoding + strconv.Itoa(level)); ok {
+		pool.(*sync.Pool).Put(c)
+	}
+}
+
+// compressWriter buffers the start of a response until it knows whether compressing it
+// pays off: the body must reach MinSize and have a compressible content type.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	config   CompressConfig
+
+	status      int
+	buf         bytes.Buffer
+	decided     bool
+	compressor  compressor
+	wroteHeader bool
+}
+
+// WriteHeader defers the status code until the compression decision is made
+func (cw *compressWriter) WriteHeader(code int) {
+	if cw.status != 0 || cw.decided {
+		return
+	}
+	// Informational responses are sent right away
+	if code < 200 {
+		cw.ResponseWriter.WriteHeader(code)
+		return
+	}
+	cw.status = code
+}
+
+// Write buffers the body until MinSize bytes are available, then starts streaming
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if cw.status == 0 {
+		cw.status = http.StatusOK
+	}
+
+	if !cw.decided {
+		cw.buf.Write(p)
+		if cw.buf.Len() < cw.config.MinSize {
+			return len(p), nil
+		}
+		if err := cw.decide(); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	if cw.compressor != nil {
+		return cw.compressor.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// decide chooses between compressing and passing the response through, then writes
+// the header and the buffered body
+func (cw *compressWriter) decide() error {
+	cw.decided = true
+	header := cw.Header()
+
+	if header.Get("Content-Type") == "" && cw.buf.Len() > 0 {
+		// Same sniffing net/http would do, done here so it runs on the uncompressed bytes
+		header.Set("Content-Type", http.DetectContentType(cw.buf.Bytes()))
+	}
+
+	if cw.shouldCompress() {
+		header.Set("Content-Encoding", cw.encoding)
+		header.Del("Content-Length")
+		// The compressed bytes differ from the original ones, so a strong validator would lie
+		if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			header.Set("ETag", "W/"+etag)
+		}
+		cw.compressor = getCompressor(cw.encoding, cw.config.Level, cw.ResponseWriter)
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.status)
+	cw.wroteHeader = true
+
+	if cw.buf.Len() == 0 {
+		return nil
+	}
+	var err error
+	if cw.compressor != nil {
+		_, err = cw.compressor.Write(cw.buf.Bytes())
+	} else {
+		_, err = cw.ResponseWriter.Write(cw.buf.Bytes())
+	}
+	cw.buf.Reset()
+	return err
+}
+
+// shouldCompress reports whether the buffered response qualifies for compression
+func (cw *compressWriter) shouldCompress() bool {
+	header := cw.Header()
+
+	switch {
+	case cw.buf.Len() < cw.config.MinSize:
+		return false
+	case cw.status == http.StatusNoContent, cw.status == http.StatusNotModified, cw.status == http.StatusPartialContent:
+		return false
+	case header.Get("Content-Encoding") != "", header.Get("Content-Range") != "":
+		return false
+	default:
+		return isCompressible(header.Get("Content-Type"))
+	}
+}
+
+// Flush sends what has been written so far, compressed if compression already started
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		cw.decide()
+	}
+	if cw.compressor != nil {
+		cw.compressor.Flush()
+	}
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// close finishes the response once the handler has returned
+func (cw *compressWriter) close() {
+	if !cw.decided {
+		if cw.status == 0 && cw.buf.Len() == 0 {
+			// The handler wrote nothing: let net/http send its implicit 200
+			return
+		}
+		cw.decide()
+	}
+
+	if cw.compressor != nil {
+		cw.compressor.Close()
+		putCompressor(cw.encoding, cw.config.Level, cw.compressor)
+		cw.compressor = nil
+	}
+}
+
+// CompressMiddleware compresses responses with gzip or deflate when the client accepts it,
+// the content type is compressible and the body is at least MinSize bytes
+func CompressMiddleware(config CompressConfig) func(http.Handler) http.Handler {
+	if config.MinSize <= 0 {
+		config.MinSize = 1024
+	}
+	if config.Level == 0 {
+		config.Level = gzip.DefaultCompression
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			// Byte ranges refer to the uncompressed body, so they are never compressed
+			if encoding == "" || r.Method == http.MethodHead || r.Header.Get("Range") != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{ResponseWriter: w, encoding: encoding, config: config}
+			next.ServeHTTP(cw, r)
+			// Not deferred: after a panic the buffered body is dropped so RecoverMiddleware
+			// can still write its error response
+			cw.close()
+		})
+	}
+}
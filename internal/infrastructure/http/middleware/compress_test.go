@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// serveCompressed runs handle behind CompressMiddleware with the given Accept-Encoding
+func serveCompressed(t *testing.T, handle http.HandlerFunc, acceptEncoding string, setup ...func(*http.Request)) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest("GET", "/api/tasks", nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	for _, fn := range setup {
+		fn(req)
+	}
+
+	w := httptest.NewRecorder()
+	CompressMiddleware(CompressConfig{MinSize: 100})(handle).ServeHTTP(w, req)
+	return w
+}
+
+// decodeBody returns the response body, decompressing it according to Content-Encoding
+func decodeBody(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	var reader io.Reader = w.Body
+	switch w.Header().Get("Content-Encoding") {
+	case "gzip":
+		gz, err := gzip.NewReader(w.Body)
+		if err != nil {
+			t.Fatalf("invalid gzip body: %v", err)
+		}
+		reader = gz
+	case "deflate":
+		zr, err := zlib.NewReader(w.Body)
+		if err != nil {
+			t.Fatalf("invalid deflate body: %v", err)
+		}
+		reader = zr
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	return string(body)
+}
+
+func writeBody(contentType, body string, status int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if contentType != "" {
+			w.Header().Set("Content-Type", contentType)
+		}
+		w.Header().Set("Content-Length", "999")
+		w.WriteHeader(status)
+		// Written in small chunks so the threshold is crossed mid-response
+		for i := 0; i < len(body); i += 7 {
+			end := i + 7
+			if end > len(body) {
+				end = len(body)
+			}
+			w.Write([]byte(body[i:end]))
+		}
+	}
+}
+
+func TestCompressMiddleware(t *testing.T) {
+	jsonBody := `[` + strings.Repeat(`{"title":"Comprar pão","status":"pending"},`, 20) + `{}]`
+	htmlBody := "<!DOCTYPE html><html><body>" + strings.Repeat("<p>Olá</p>", 30) + "</body></html>"
+	binary := string(bytes.Repeat([]byte{0x89, 'P', 'N', 'G'}, 100))
+
+	tests := []struct {
+		name           string
+		handler        http.HandlerFunc
+		acceptEncoding string
+		setup          func(*http.Request)
+		wantEncoding   string
+		wantStatus     int
+		wantBody       string
+	}{
+		{"json with gzip", writeBody("application/json", jsonBody, http.StatusCreated), "gzip, deflate, br", nil, "gzip", http.StatusCreated, jsonBody},
+		{"json with deflate only", writeBody("application/json", jsonBody, http.StatusOK), "deflate", nil, "deflate", http.StatusOK, jsonBody},
+		{"q-values prefer deflate", writeBody("application/json", jsonBody, http.StatusOK), "gzip;q=0.5, deflate", nil, "deflate", http.StatusOK, jsonBody},
+		{"html sniffed without content type", writeBody("", htmlBody, http.StatusOK), "gzip", nil, "gzip", http.StatusOK, htmlBody},
+		{"client without compression", writeBody("application/json", jsonBody, http.StatusOK), "", nil, "", http.StatusOK, jsonBody},
+		{"gzip refused", writeBody("application/json", jsonBody, http.StatusOK), "gzip;q=0, identity", nil, "", http.StatusOK, jsonBody},
+		{"below threshold", writeBody("application/json", `{"ok":true}`, http.StatusOK), "gzip", nil, "", http.StatusOK, `{"ok":true}`},
+		{"image is already compressed", writeBody("image/png", binary, http.StatusOK), "gzip", nil, "", http.StatusOK, binary},
+		{"pdf is already compressed", writeBody("application/pdf", binary, http.StatusOK), "gzip", nil, "", http.StatusOK, binary},
+		{"range request", writeBody("text/csv", htmlBody, http.StatusOK), "gzip", func(r *http.Request) { r.Header.Set("Range", "bytes=0-10") }, "", http.StatusOK, htmlBody},
+		{"head request", writeBody("application/json", "", http.StatusOK), "gzip", func(r *http.Request) { r.Method = http.MethodHead }, "", http.StatusOK, ""},
+		{"not modified", writeBody("application/json", "", http.StatusNotModified), "gzip", nil, "", http.StatusNotModified, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var setup []func(*http.Request)
+			if tt.setup != nil {
+				setup = append(setup, tt.setup)
+			}
+			w := serveCompressed(t, tt.handler, tt.acceptEncoding, setup...)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Errorf("Expected Content-Encoding %q, got %q", tt.wantEncoding, got)
+			}
+			if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("Expected Vary: Accept-Encoding, got %q", got)
+			}
+			if tt.wantEncoding != "" && w.Header().Get("Content-Length") != "" {
+				t.Error("Expected Content-Length to be removed from compressed responses")
+			}
+			if got := decodeBody(t, w); got != tt.wantBody {
+				t.Errorf("Expected body %q, got %q", tt.wantBody, got)
+			}
+		})
+	}
+}
+
+func TestCompressMiddleware_WeakensStrongETag(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(strings.Repeat("a", 200)))
+	}
+
+	w := serveCompressed(t, handler, "gzip")
+
+	if got := w.Header().Get("ETag"); got != `W/"v1"` {
+		t.Errorf("Expected weak ETag, got %q", got)
+	}
+}
+
+func TestCompressMiddleware_EmptyResponse(t *testing.T) {
+	w := serveCompressed(t, func(w http.ResponseWriter, r *http.Request) {}, "gzip")
+
+	if w.Code != http.StatusOK || w.Body.Len() != 0 || w.Header().Get("Content-Encoding") != "" {
+		t.Errorf("Expected an empty, uncompressed 200, got %d with %d bytes", w.Code, w.Body.Len())
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		want           string
+	}{
+		{"", ""},
+		{"gzip", "gzip"},
+		{"deflate, gzip", "gzip"},
+		{"br", ""},
+		{"*", "gzip"},
+		{"*;q=0", ""},
+		{"gzip;q=0, *", "deflate"},
+		{"GZIP;q=0.8, deflate;q=0.9", "deflate"},
+		{"gzip;q=abc", ""},
+	}
+
+	for _, tt := range tests {
+		if got := negotiateEncoding(tt.acceptEncoding); got != tt.want {
+			t.Errorf("negotiateEncoding(%q) = %q, want %q", tt.acceptEncoding, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// ErrorLog keeps the latest requests answered with a 5xx status, for the admin dashboard. It
+// only lives in memory, so it starts empty on every restart.
+type ErrorLog struct {
+	mu       sync.Mutex
+	entries  []application.RequestError // oldest first
+	capacity int
+}
+
+// NewErrorLog creates an ErrorLog keeping the latest capacity errors, at least one
+func NewErrorLog(capacity int) *ErrorLog {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &ErrorLog{capacity: capacity}
+}
+
+// Add records a failed request, dropping the oldest one when the log is full
+func (l *ErrorLog) Add(entry application.RequestError) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.entries) >= l.capacity {
+		l.entries = append(l.entries[:0], l.entries[len(l.entries)-l.capacity+1:]...)
+	}
+	l.entries = append(l.entries, entry)
+}
+
+// Recent returns the recorded errors, newest first
+func (l *ErrorLog) Recent() []application.RequestError {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	recent := make([]application.RequestError, len(l.entries))
+	for i, entry := range l.entries {
+		recent[len(l.entries)-1-i] = entry
+	}
+	return recent
+}
+
+// ErrorLogMiddleware records in errorLog the requests answered with a 5xx status. It must
+// run outside RecoverMiddleware to see the panics it turns into 500s.
+func ErrorLogMiddleware(errorLog *ErrorLog) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			if rec.status >= 500 {
+				errorLog.Add(application.RequestError{
+					At:     time.Now(),
+					Method: r.Method,
+					Path:   r.URL.Path,
+					Status: rec.status,
+				})
+			}
+		})
+	}
+}
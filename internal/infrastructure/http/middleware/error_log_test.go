@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestErrorLog_Recent(t *testing.T) {
+	tests := []struct {
+		name       string
+		capacity   int
+		statuses   []int
+		wantRecent []int
+	}{
+		{name: "empty", capacity: 3, wantRecent: []int{}},
+		{name: "newest first", capacity: 3, statuses: []int{500, 502}, wantRecent: []int{502, 500}},
+		{name: "oldest dropped when full", capacity: 3, statuses: []int{500, 501, 502, 503, 504}, wantRecent: []int{504, 503, 502}},
+		{name: "capacity of at least one", capacity: 0, statuses: []int{500, 503}, wantRecent: []int{503}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errorLog := NewErrorLog(tt.capacity)
+			for _, status := range tt.statuses {
+				errorLog.Add(application.RequestError{Status: status})
+			}
+
+			recent := errorLog.Recent()
+			if len(recent) != len(tt.wantRecent) {
+				t.Fatalf("Expected %d errors, got %d", len(tt.wantRecent), len(recent))
+			}
+			for i, status := range tt.wantRecent {
+				if recent[i].Status != status {
+					t.Errorf("Expected status %d at %d, got %d", status, i, recent[i].Status)
+				}
+			}
+		})
+	}
+}
+
+func TestErrorLogMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		wantLog bool
+	}{
+		{
+			name:    "success not recorded",
+			handler: func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("ok")) },
+		},
+		{
+			name:    "client error not recorded",
+			handler: func(w http.ResponseWriter, r *http.Request) { http.NotFound(w, r) },
+		},
+		{
+			name: "server error recorded",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "boom", http.StatusBadGateway)
+			},
+			wantLog: true,
+		},
+		{
+			name:    "recovered panic recorded",
+			handler: func(w http.ResponseWriter, r *http.Request) { panic("boom") },
+			wantLog: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errorLog := NewErrorLog(10)
+			handler := Chain(tt.handler, ErrorLogMiddleware(errorLog), RecoverMiddleware)
+
+			req := httptest.NewRequest("POST", "/web/tasks", nil)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			recent := errorLog.Recent()
+			if !tt.wantLog {
+				if len(recent) != 0 {
+					t.Errorf("Expected no error recorded, got %+v", recent)
+				}
+				return
+			}
+			if len(recent) != 1 {
+				t.Fatalf("Expected 1 error recorded, got %d", len(recent))
+			}
+			if recent[0].Method != "POST" || recent[0].Path != "/web/tasks" || recent[0].Status != w.Code {
+				t.Errorf("Unexpected error recorded: %+v, response status %d", recent[0], w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/http/handler"
+)
+
+// writeError writes an error response: the JSON error envelope for API routes and plain
+// text for web routes
+func writeError(w http.ResponseWriter, r *http.Request, status int, code, message string) {
+	if isAPIRequest(r) {
+		handler.WriteJSONError(w, r, status, code, message)
+		return
+	}
+	http.Error(w, handler.LocalizeError(r, message), status)
+}
+
+// isAPIRequest reports whether a request targets the JSON API. RequestURI is checked because
+// http.StripPrefix has already removed /api from URL.Path when API middlewares run.
+func isAPIRequest(r *http.Request) bool {
+	path := r.RequestURI
+	if path == "" {
+		path = r.URL.Path
+	}
+	return strings.HasPrefix(path, "/api/") || strings.HasPrefix(path, "/upload/")
+}
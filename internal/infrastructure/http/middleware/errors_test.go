@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+func withUserID(req *http.Request, userID string) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), "userID", userID))
+}
+
+func TestErrors_JSONEnvelopeOnAPIRoutes(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		name     string
+		handler  http.Handler
+		request  *http.Request
+		wantCode string
+	}{
+		{
+			name:     "missing token",
+			handler:  http.StripPrefix("/api", AuthMiddleware(service.NewJWTKeySet("secret"), nil, "auth_token")(ok)),
+			request:  httptest.NewRequest("GET", "/api/tasks", nil),
+			wantCode: "unauthorized",
+		},
+		{
+			name:     "wrong content type",
+			handler:  http.StripPrefix("/api", ContentNegotiation(JSONOnly)(ok)),
+			request:  httptest.NewRequest("POST", "/api/tasks", strings.NewReader("title=x")),
+			wantCode: "unsupported_media_type",
+		},
+		{
+			name:    "unacceptable response type",
+			handler: http.StripPrefix("/api", ContentNegotiation(JSONOnly)(ok)),
+			request: func() *http.Request {
+				req := httptest.NewRequest("GET", "/api/tasks", nil)
+				req.Header.Set("Accept", "text/html")
+				return req
+			}(),
+			wantCode: "not_acceptable",
+		},
+		{
+			name:     "export quota exceeded",
+			handler:  ExportQuotaMiddleware(&fakeExportQuota{}, "tasks_pdf", nil)(ok),
+			request:  withUserID(httptest.NewRequest("GET", "/api/tasks/export/pdf", nil), "user-123"),
+			wantCode: "export_quota_exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.handler.ServeHTTP(w, tt.request)
+
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Fatalf("Expected Content-Type application/json, got %q", ct)
+			}
+			var body struct {
+				Error struct {
+					Code    string `json:"code"`
+					Message string `json:"message"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Expected JSON error envelope, got %q", w.Body.String())
+			}
+			if body.Error.Code != tt.wantCode || body.Error.Message == "" {
+				t.Errorf("Unexpected error %+v", body.Error)
+			}
+		})
+	}
+}
+
+func TestErrors_PlainTextOnWebRoutes(t *testing.T) {
+	handler := AuthMiddleware(service.NewJWTKeySet("secret"), nil, "auth_token")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("POST", "/web/tasks", nil))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Expected plain text error for web routes, got %q", ct)
+	}
+}
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/http/handler"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/i18n"
+)
+
+// ExportQuota takes and gives back exports from a user's quota
+type ExportQuota interface {
+	Consume(ctx context.Context, userID, kind string) (string, error)
+	Release(ctx context.Context, usageID string) error
+}
+
+// ExportQuotaMiddleware charges each request to the user's export quota, answering 429 with
+// Retry-After and a message saying when exports will be available again once it's used up.
+// Requests that fail (4xx/5xx) are given back. applies selects which requests are
+// charged (nil charges all). It must run after AuthMiddleware.
+func ExportQuotaMiddleware(quota ExportQuota, kind string, applies func(*http.Request) bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := r.Context().Value("userID").(string)
+			if !ok || userID == "" || (applies != nil && !applies(r)) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			usageID, err := quota.Consume(r.Context(), userID, kind)
+			if err != nil {
+				var exceeded *application.QuotaExceededError
+				if errors.As(err, &exceeded) {
+					retryAfter := exceeded.RetryAfter(time.Now())
+					w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+					w.Header().Set("X-Export-Quota-Reset", exceeded.RetryAt.UTC().Format(time.RFC3339))
+					writeError(w, r, http.StatusTooManyRequests, "export_quota_exceeded", quotaExceededMessage(quotaLocale(r), exceeded, retryAfter))
+					return
+				}
+				writeError(w, r, http.StatusInternalServerError, handler.ErrorCode(http.StatusInternalServerError), "Failed to check export quota")
+				return
+			}
+
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			if rec.status >= 400 {
+				if err := quota.Release(context.WithoutCancel(r.Context()), usageID); err != nil {
+					log.Printf("failed to release export quota: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// quotaLocale returns the locale of the quota message. Unlike other API errors it was always
+// written in Portuguese, so it stays in the default locale when the client asks for none.
+func quotaLocale(r *http.Request) application.Locale {
+	if locale, ok := handler.NegotiatedLocale(r); ok {
+		return locale
+	}
+	return i18n.Default
+}
+
+// quotaExceededMessage explains the limit and when it will be released
+func quotaExceededMessage(locale application.Locale, exceeded *application.QuotaExceededError, retryAfter time.Duration) string {
+	minutes := int(math.Ceil(retryAfter.Minutes()))
+	if minutes < 1 {
+		minutes = 1
+	}
+	return i18n.T(locale, "export.quota_exceeded", exceeded.Limit, formatWindow(locale, exceeded.Window), minutes)
+}
+
+// formatWindow describes a quota window
+func formatWindow(locale application.Locale, window time.Duration) string {
+	if window == time.Hour {
+		return i18n.T(locale, "duration.hour")
+	}
+	if window%time.Hour == 0 {
+		return i18n.T(locale, "duration.hours", int(window.Hours()))
+	}
+	return i18n.T(locale, "duration.minutes", int(math.Ceil(window.Minutes())))
+}
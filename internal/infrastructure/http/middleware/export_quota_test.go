@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// fakeExportQuota allows a fixed number of exports and records releases
+type fakeExportQuota struct {
+	remaining int
+	released  []string
+}
+
+func (f *fakeExportQuota) Consume(ctx context.Context, userID, kind string) (string, error) {
+	if f.remaining == 0 {
+		return "", &application.QuotaExceededError{Limit: 5, Window: time.Hour, RetryAt: time.Now().Add(12 * time.Minute)}
+	}
+	f.remaining--
+	return "usage-1", nil
+}
+
+func (f *fakeExportQuota) Release(ctx context.Context, usageID string) error {
+	f.released = append(f.released, usageID)
+	return nil
+}
+
+func exportRequest(userID string) *http.Request {
+	req := httptest.NewRequest("GET", "/tasks/export/pdf", nil)
+	return req.WithContext(context.WithValue(req.Context(), "userID", userID))
+}
+
+func TestExportQuotaMiddleware(t *testing.T) {
+	quota := &fakeExportQuota{remaining: 1}
+	handler := ExportQuotaMiddleware(quota, "tasks_pdf", nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, exportRequest("user-1"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("first export: expected 200, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, exportRequest("user-1"))
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("second export: expected 429, got %d", w.Code)
+	}
+
+	retryAfter := w.Header().Get("Retry-After")
+	if retryAfter == "" || retryAfter == "0" {
+		t.Errorf("expected Retry-After header, got %q", retryAfter)
+	}
+	if w.Header().Get("X-Export-Quota-Reset") == "" {
+		t.Error("expected X-Export-Quota-Reset header")
+	}
+	if body := w.Body.String(); !strings.Contains(body, "Limite de 5 exportações a cada 1 hora") || !strings.Contains(body, "12 minuto(s)") {
+		t.Errorf("expected message explaining when the quota is freed, got %q", body)
+	}
+}
+
+func TestExportQuotaMiddleware_LocalizedMessage(t *testing.T) {
+	quota := &fakeExportQuota{remaining: 0}
+	handler := ExportQuotaMiddleware(quota, "tasks_pdf", nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := exportRequest("user-1")
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if body := w.Body.String(); !strings.Contains(body, "Limit of 5 exports every 1 hour reached") || !strings.Contains(body, "12 minute(s)") {
+		t.Errorf("expected the message in English, got %q", body)
+	}
+}
+
+func TestExportQuotaMiddleware_ReleasesFailedExports(t *testing.T) {
+	quota := &fakeExportQuota{remaining: 5}
+	handler := ExportQuotaMiddleware(quota, "tasks_pdf", nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Failed to generate PDF", http.StatusInternalServerError)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), exportRequest("user-1"))
+
+	if len(quota.released) != 1 || quota.released[0] != "usage-1" {
+		t.Errorf("expected failed export to be released, got %v", quota.released)
+	}
+}
+
+func TestExportQuotaMiddleware_SkipsRequestsNotCharged(t *testing.T) {
+	quota := &fakeExportQuota{remaining: 0}
+	handler := ExportQuotaMiddleware(quota, "report", func(r *http.Request) bool {
+		return r.URL.Query().Get("format") == "csv"
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, exportRequest("user-1"))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected uncharged request to pass, got %d", w.Code)
+	}
+}
@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/http/handler"
+)
+
+// IdempotencyHeader is the header clients send to make the retries of a POST safe
+const IdempotencyHeader = "Idempotency-Key"
+
+// maxIdempotentBody is the largest body a request may have to be kept under its key. The API
+// handlers refuse much smaller JSON bodies, so larger requests just run as usual.
+const maxIdempotentBody = 1 << 20
+
+// IdempotencyKeys claims the Idempotency-Key of requests and keeps their responses
+type IdempotencyKeys interface {
+	Begin(ctx context.Context, userID, key, requestHash string) (*application.IdempotencyRecord, error)
+	Finish(ctx context.Context, record *application.IdempotencyRecord) error
+	Release(ctx context.Context, userID, key string) error
+}
+
+// IdempotencyMiddleware makes the POSTs sent with an Idempotency-Key safe to retry. The first
+// request with a key runs and its response is kept; retries of the same request by the same
+// user get that response again, with Idempotent-Replayed: true, instead of running it. Using the
+// key for another method, path, workspace or body answers 422, and retrying while the first
+// request runs answers 409. Responses a retry may change, 429 and 5xx, aren't kept. It must run
+// after AuthMiddleware and OrgScopeMiddleware.
+func IdempotencyMiddleware(keys IdempotencyKeys) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyHeader)
+			userID, _ := r.Context().Value("userID").(string)
+			if r.Method != http.MethodPost || key == "" || userID == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxIdempotentBody+1))
+			if err != nil {
+				writeError(w, r, http.StatusBadRequest, handler.ErrorCode(http.StatusBadRequest), "Failed to read request body")
+				return
+			}
+			if len(body) > maxIdempotentBody {
+				r.Body = struct {
+					io.Reader
+					io.Closer
+				}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+				next.ServeHTTP(w, r)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			stored, err := keys.Begin(r.Context(), userID, key, idempotencyRequestHash(r, body))
+			switch {
+			case errors.Is(err, application.ErrInvalidIdempotencyKey):
+				writeError(w, r, http.StatusBadRequest, handler.ErrorCode(http.StatusBadRequest), err.Error())
+				return
+			case errors.Is(err, application.ErrIdempotencyKeyReused):
+				writeError(w, r, http.StatusUnprocessableEntity, handler.ErrorCode(http.StatusUnprocessableEntity), err.Error())
+				return
+			case errors.Is(err, application.ErrIdempotencyKeyInProgress):
+				w.Header().Set("Retry-After", "1")
+				writeError(w, r, http.StatusConflict, handler.ErrorCode(http.StatusConflict), err.Error())
+				return
+			case err != nil:
+				log.Printf("failed to check idempotency key: %v", err)
+				writeError(w, r, http.StatusInternalServerError, handler.ErrorCode(http.StatusInternalServerError), "Failed to check idempotency key")
+				return
+			case stored != nil:
+				replayResponse(w, stored)
+				return
+			}
+
+			// The key is freed when the handler panics, and the outcome is recorded even when the
+			// client is gone, since the retry is on its way
+			ctx := context.WithoutCancel(r.Context())
+			answered := false
+			defer func() {
+				if !answered {
+					releaseIdempotencyKey(ctx, keys, userID, key)
+				}
+			}()
+
+			rec := &responseCapture{statusRecorder: statusRecorder{ResponseWriter: w, status: http.StatusOK}}
+			next.ServeHTTP(rec, r)
+			answered = true
+
+			if rec.status == http.StatusTooManyRequests || rec.status >= 500 {
+				releaseIdempotencyKey(ctx, keys, userID, key)
+				return
+			}
+			err = keys.Finish(ctx, &application.IdempotencyRecord{
+				UserID:      userID,
+				Key:         key,
+				Status:      rec.status,
+				ContentType: rec.Header().Get("Content-Type"),
+				Location:    rec.Header().Get("Location"),
+				Body:        rec.body.Bytes(),
+			})
+			if err != nil {
+				log.Printf("failed to store idempotent response: %v", err)
+			}
+		})
+	}
+}
+
+// responseCapture keeps a copy of the status and body written by a handler
+type responseCapture struct {
+	statusRecorder
+	body bytes.Buffer
+}
+
+// Write copies the body before writing it
+func (rc *responseCapture) Write(b []byte) (int, error) {
+	rc.body.Write(b)
+	return rc.ResponseWriter.Write(b)
+}
+
+// idempotencyRequestHash identifies a request under its key: its method, its path and query as
+// the client sent them, its workspace, its Content-Type and its body
+func idempotencyRequestHash(r *http.Request, body []byte) string {
+	target := r.RequestURI
+	if target == "" {
+		target = r.URL.RequestURI()
+	}
+	orgID, _ := repository.OrgScope(r.Context())
+
+	hash := sha256.New()
+	for _, part := range []string{r.Method, target, orgID, r.Header.Get("Content-Type")} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// replayResponse writes the stored response of a request again
+func replayResponse(w http.ResponseWriter, record *application.IdempotencyRecord) {
+	if record.ContentType != "" {
+		w.Header().Set("Content-Type", record.ContentType)
+	}
+	if record.Location != "" {
+		w.Header().Set("Location", record.Location)
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(record.Status)
+	w.Write(record.Body)
+}
+
+// releaseIdempotencyKey frees a key whose response isn't kept, so the retry runs the request
+func releaseIdempotencyKey(ctx context.Context, keys IdempotencyKeys, userID, key string) {
+	if err := keys.Release(ctx, userID, key); err != nil {
+		log.Printf("failed to release idempotency key: %v", err)
+	}
+}
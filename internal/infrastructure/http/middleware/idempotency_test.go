@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// fakeIdempotencyKeys keeps the records in memory, by user and key
+type fakeIdempotencyKeys map[string]*application.IdempotencyRecord
+
+func (f fakeIdempotencyKeys) Begin(ctx context.Context, userID, key, requestHash string) (*application.IdempotencyRecord, error) {
+	if !application.ValidIdempotencyKey(key) {
+		return nil, application.ErrInvalidIdempotencyKey
+	}
+	existing, ok := f[userID+"/"+key]
+	switch {
+	case !ok:
+		f[userID+"/"+key] = &application.IdempotencyRecord{UserID: userID, Key: key, RequestHash: requestHash}
+		return nil, nil
+	case existing.RequestHash != requestHash:
+		return nil, application.ErrIdempotencyKeyReused
+	case !existing.Answered():
+		return nil, application.ErrIdempotencyKeyInProgress
+	}
+	return existing, nil
+}
+
+func (f fakeIdempotencyKeys) Finish(ctx context.Context, record *application.IdempotencyRecord) error {
+	existing := f[record.UserID+"/"+record.Key]
+	existing.Status, existing.ContentType, existing.Location, existing.Body = record.Status, record.ContentType, record.Location, record.Body
+	return nil
+}
+
+func (f fakeIdempotencyKeys) Release(ctx context.Context, userID, key string) error {
+	delete(f, userID+"/"+key)
+	return nil
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	keys := fakeIdempotencyKeys{}
+	created := 0
+	status := http.StatusCreated
+	h := IdempotencyMiddleware(keys)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		created++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "/api/tasks/task-1")
+		w.WriteHeader(status)
+		w.Write([]byte(`{"id":"task-1","body":` + string(body) + `}`))
+	}))
+
+	post := func(userID, orgID, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/tasks", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set(IdempotencyHeader, key)
+		}
+		ctx := repository.WithOrgScope(context.WithValue(req.Context(), "userID", userID), orgID)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req.WithContext(ctx))
+		return w
+	}
+
+	tests := []struct {
+		name         string
+		userID       string
+		orgID        string
+		key          string
+		body         string
+		wantStatus   int
+		wantCreated  int
+		wantReplayed bool
+	}{
+		{name: "first request runs", userID: "user-1", key: "key-1", body: `{"title":"a"}`, wantStatus: http.StatusCreated, wantCreated: 1},
+		{name: "retry is replayed", userID: "user-1", key: "key-1", body: `{"title":"a"}`, wantStatus: http.StatusCreated, wantCreated: 1, wantReplayed: true},
+		{name: "key reused with another body", userID: "user-1", key: "key-1", body: `{"title":"b"}`, wantStatus: http.StatusUnprocessableEntity, wantCreated: 1},
+		{name: "key reused in another workspace", userID: "user-1", orgID: "org-1", key: "key-1", body: `{"title":"a"}`, wantStatus: http.StatusUnprocessableEntity, wantCreated: 1},
+		{name: "same key of another user", userID: "user-2", key: "key-1", body: `{"title":"a"}`, wantStatus: http.StatusCreated, wantCreated: 2},
+		{name: "without a key", userID: "user-1", body: `{"title":"a"}`, wantStatus: http.StatusCreated, wantCreated: 3},
+		{name: "invalid key", userID: "user-1", key: strings.Repeat("k", 256), body: `{"title":"a"}`, wantStatus: http.StatusBadRequest, wantCreated: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := post(tt.userID, tt.orgID, tt.key, tt.body)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if created != tt.wantCreated {
+				t.Errorf("handler ran %d times, want %d", created, tt.wantCreated)
+			}
+			if replayed := w.Header().Get("Idempotent-Replayed") == "true"; replayed != tt.wantReplayed {
+				t.Errorf("replayed = %v, want %v", replayed, tt.wantReplayed)
+			}
+			if tt.wantStatus == http.StatusCreated {
+				if body := w.Body.String(); body != `{"id":"task-1","body":`+tt.body+`}` {
+					t.Errorf("body = %s, want the response of the first request", body)
+				}
+				if w.Header().Get("Location") != "/api/tasks/task-1" || w.Header().Get("Content-Type") != "application/json" {
+					t.Errorf("headers = %v, want the Location and Content-Type of the first request", w.Header())
+				}
+			}
+		})
+	}
+
+	t.Run("retry while running", func(t *testing.T) {
+		post("user-3", "", "key-1", `{"title":"a"}`)
+		keys["user-3/key-1"].Status = 0
+
+		w := post("user-3", "", "key-1", `{"title":"a"}`)
+		if w.Code != http.StatusConflict || w.Header().Get("Retry-After") == "" {
+			t.Fatalf("status = %d, Retry-After = %q; want 409 with Retry-After", w.Code, w.Header().Get("Retry-After"))
+		}
+	})
+
+	t.Run("failures are not kept", func(t *testing.T) {
+		status = http.StatusServiceUnavailable
+		if w := post("user-4", "", "key-1", `{}`); w.Code != http.StatusServiceUnavailable {
+			t.Fatalf("status = %d, want 503", w.Code)
+		}
+		if _, ok := keys["user-4/key-1"]; ok {
+			t.Fatal("Expected the key released after a 503")
+		}
+
+		status = http.StatusCreated
+		ran := created
+		if w := post("user-4", "", "key-1", `{}`); w.Code != http.StatusCreated || created != ran+1 {
+			t.Errorf("retry = %d, ran %d times; want the request to run again", w.Code, created-ran)
+		}
+	})
+
+	t.Run("other methods pass", func(t *testing.T) {
+		ran := created
+		for i := 0; i < 2; i++ {
+			req := httptest.NewRequest(http.MethodPut, "/api/tasks/task-1", strings.NewReader(`{}`))
+			req.Header.Set(IdempotencyHeader, "key-put")
+			h.ServeHTTP(httptest.NewRecorder(), req.WithContext(context.WithValue(req.Context(), "userID", "user-1")))
+		}
+		if created != ran+2 {
+			t.Errorf("PUT ran %d times, want 2", created-ran)
+		}
+	})
+}
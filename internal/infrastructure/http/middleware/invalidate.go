@@ -0,0 +1,38 @@
+package middleware
+
+import "net/http"
+
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status code before writing it
+func (sr *statusRecorder) WriteHeader(code int) {
+	sr.status = code
+	sr.ResponseWriter.WriteHeader(code)
+}
+
+// InvalidateOnWriteMiddleware calls invalidate with the authenticated user ID after every
+// successful mutating request, so per-user caches never outlive the user's own writes.
+// It must run after AuthMiddleware.
+func InvalidateOnWriteMiddleware(invalidate func(userID string)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			if rec.status < 400 {
+				if userID, ok := r.Context().Value("userID").(string); ok && userID != "" {
+					invalidate(userID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestInvalidateOnWriteMiddleware tests that per-user caches are invalidated after successful writes only
+func TestInvalidateOnWriteMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		status         int
+		userID         string
+		wantInvalidate bool
+	}{
+		{name: "successful POST invalidates", method: "POST", status: http.StatusCreated, userID: "user-1", wantInvalidate: true},
+		{name: "successful DELETE invalidates", method: "DELETE", status: http.StatusOK, userID: "user-1", wantInvalidate: true},
+		{name: "GET does not invalidate", method: "GET", status: http.StatusOK, userID: "user-1", wantInvalidate: false},
+		{name: "failed write does not invalidate", method: "PUT", status: http.StatusBadRequest, userID: "user-1", wantInvalidate: false},
+		{name: "anonymous write does not invalidate", method: "POST", status: http.StatusOK, userID: "", wantInvalidate: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var invalidated string
+			handler := InvalidateOnWriteMiddleware(func(userID string) {
+				invalidated = userID
+			})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+
+			req := httptest.NewRequest(tt.method, "/tasks", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", tt.userID))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if (invalidated != "") != tt.wantInvalidate {
+				t.Errorf("invalidated = %q, want invalidate=%v", invalidated, tt.wantInvalidate)
+			}
+			if tt.wantInvalidate && invalidated != tt.userID {
+				t.Errorf("invalidated user = %q, want %q", invalidated, tt.userID)
+			}
+			if w.Code != tt.status {
+				t.Errorf("Expected status %d to pass through, got %d", tt.status, w.Code)
+			}
+		})
+	}
+}
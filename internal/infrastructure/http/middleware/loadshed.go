@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/http/handler"
+)
+
+// LoadShedConfig holds the configuration for load shedding
+type LoadShedConfig struct {
+	MaxInFlight      int                      // Concurrent requests above which non-essential traffic is shed (0 disables)
+	MaxGoroutines    int                      // Goroutine count above which non-essential traffic is shed (0 disables)
+	LatencyThreshold time.Duration            // Average latency, without the routes under ExcludeFromLatency, above which non-essential traffic is shed (0 disables)
+	QueueDepth       func() int               // Optional backlog probe (e.g. busy database connections)
+	MaxQueueDepth    int                      // Backlog above which non-essential traffic is shed (0 disables)
+	RetryAfter       time.Duration            // Value of the Retry-After header sent with 503 responses
+	IsEssential      func(*http.Request) bool // Requests that are never shed; defaults to IsEssentialRequest
+}
+
+// loadShedder tracks server pressure signals
+type loadShedder struct {
+	config   LoadShedConfig
+	inFlight atomic.Int64
+	now      func() time.Time
+
+	mu           sync.Mutex
+	avgLatency   float64   // exponentially weighted moving average, in nanoseconds
+	lastObserved time.Time // when avgLatency was last updated, from which it decays
+}
+
+const (
+	// latencySmoothing is the weight of the newest sample in the latency average
+	latencySmoothing = 0.1
+
+	// latencyHalfLife is how long the latency average takes to halve without samples. Once the
+	// server sheds every non-essential request few samples come in, and without the decay a
+	// burst of slow requests would keep it shedding long after it recovered.
+	latencyHalfLife = 10 * time.Second
+)
+
+// decayedLatency returns the latency average decayed for the time since the last sample;
+// s.mu must be held
+func (s *loadShedder) decayedLatency(now time.Time) float64 {
+	elapsed := now.Sub(s.lastObserved)
+	if elapsed <= 0 {
+		return s.avgLatency
+	}
+	return s.avgLatency * math.Exp2(-float64(elapsed)/float64(latencyHalfLife))
+}
+
+// observe records the duration of a finished request
+func (s *loadShedder) observe(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.avgLatency == 0 {
+		s.avgLatency = float64(d)
+	} else {
+		s.avgLatency = latencySmoothing*float64(d) + (1-latencySmoothing)*s.decayedLatency(now)
+	}
+	s.lastObserved = now
+}
+
+// averageLatency returns the current moving average of request latency
+func (s *loadShedder) averageLatency() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Duration(math.Round(s.decayedLatency(s.now())))
+}
+
+// saturated reports whether any pressure signal is above its threshold
+func (s *loadShedder) saturated() bool {
+	if s.config.MaxInFlight > 0 && s.inFlight.Load() > int64(s.config.MaxInFlight) {
+		return true
+	}
+	if s.config.MaxGoroutines > 0 && runtime.NumGoroutine() > s.config.MaxGoroutines {
+		return true
+	}
+	if s.config.LatencyThreshold > 0 && s.averageLatency() > s.config.LatencyThreshold {
+		return true
+	}
+	if s.config.QueueDepth != nil && s.config.MaxQueueDepth > 0 && s.config.QueueDepth() > s.config.MaxQueueDepth {
+		return true
+	}
+	return false
+}
+
+// loginSteps are the POST routes a user goes through to log in: the password, then the second
+// factor, or a passkey. Shedding any of them locks users out as surely as shedding the first.
+var loginSteps = map[string]bool{
+	"/api/auth/login":                 true,
+	"/api/auth/2fa/verify":            true,
+	"/api/auth/webauthn/login/begin":  true,
+	"/api/auth/webauthn/login/finish": true,
+	"/web/auth/login":                 true,
+	"/web/auth/2fa":                   true,
+}
+
+// IsEssentialRequest reports whether a request must be served even under pressure:
+// every step of a login and plain reads are protected, while writes and heavy exports
+// are shed first.
+func IsEssentialRequest(r *http.Request) bool {
+	path := r.URL.Path
+
+	if r.Method == http.MethodPost && loginSteps[path] {
+		return true
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return !strings.Contains(path, "/export")
+	}
+
+	return false
+}
+
+// LoadSheddingMiddleware rejects non-essential requests with 503 while the server is saturated
+func LoadSheddingMiddleware(config LoadShedConfig) func(http.Handler) http.Handler {
+	if config.IsEssential == nil {
+		config.IsEssential = IsEssentialRequest
+	}
+	if config.RetryAfter <= 0 {
+		config.RetryAfter = 5 * time.Second
+	}
+	shedder := &loadShedder{config: config, now: time.Now}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			shedder.inFlight.Add(1)
+			defer shedder.inFlight.Add(-1)
+
+			if !config.IsEssential(r) && shedder.saturated() {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(config.RetryAfter.Seconds()))))
+				writeError(w, r, http.StatusServiceUnavailable, handler.ErrorCode(http.StatusServiceUnavailable), "Server is busy. Try again later.")
+				return
+			}
+
+			sample := &latencySample{}
+			r = r.WithContext(context.WithValue(r.Context(), "latencySample", sample))
+
+			start := time.Now()
+			next.ServeHTTP(w, r)
+			if !sample.excluded {
+				shedder.observe(time.Since(start))
+			}
+		})
+	}
+}
+
+// latencySample marks whether the duration of a request counts in the latency average
+type latencySample struct {
+	excluded bool
+}
+
+// ExcludeFromLatency keeps the requests it serves out of the latency average of
+// LoadSheddingMiddleware. It is meant for the routes given a long timeout, like exports,
+// uploads and backups: they are slow by design, and counting them would shed the quick
+// requests behind them.
+func ExcludeFromLatency(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if sample, ok := r.Context().Value("latencySample").(*latencySample); ok {
+			sample.excluded = true
+		}
+		next.ServeHTTP(w, r)
+	})
+}
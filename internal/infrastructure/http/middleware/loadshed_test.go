@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestLoadSheddingMiddleware_InFlight tests shedding based on concurrent requests
+func TestLoadSheddingMiddleware_InFlight(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+
+	handler := LoadSheddingMiddleware(LoadShedConfig{
+		MaxInFlight: 2,
+		RetryAfter:  3 * time.Second,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			started <- struct{}{}
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Saturate the server with two slow requests
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/slow", nil))
+		}()
+	}
+	<-started
+	<-started
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{name: "non-essential write is shed", method: "POST", path: "/api/tasks", wantStatus: http.StatusServiceUnavailable},
+		{name: "export is shed", method: "GET", path: "/api/tasks/export/pdf", wantStatus: http.StatusServiceUnavailable},
+		{name: "basic read is protected", method: "GET", path: "/api/tasks", wantStatus: http.StatusOK},
+		{name: "api login is protected", method: "POST", path: "/api/auth/login", wantStatus: http.StatusOK},
+		{name: "web login is protected", method: "POST", path: "/web/auth/login", wantStatus: http.StatusOK},
+		{name: "api second factor is protected", method: "POST", path: "/api/auth/2fa/verify", wantStatus: http.StatusOK},
+		{name: "web second factor is protected", method: "POST", path: "/web/auth/2fa", wantStatus: http.StatusOK},
+		{name: "passkey login start is protected", method: "POST", path: "/api/auth/webauthn/login/begin", wantStatus: http.StatusOK},
+		{name: "passkey login finish is protected", method: "POST", path: "/api/auth/webauthn/login/finish", wantStatus: http.StatusOK},
+		{name: "2fa setup is shed", method: "POST", path: "/api/auth/2fa/setup", wantStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus == http.StatusServiceUnavailable && w.Header().Get("Retry-After") != "3" {
+				t.Errorf("Expected Retry-After 3, got %q", w.Header().Get("Retry-After"))
+			}
+		})
+	}
+
+	close(release)
+	wg.Wait()
+
+	// Pressure is gone, writes are served again
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("POST", "/api/tasks", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 after pressure is relieved, got %d", w.Code)
+	}
+}
+
+// TestLoadSheddingMiddleware_Latency tests shedding based on average latency
+func TestLoadSheddingMiddleware_Latency(t *testing.T) {
+	handler := LoadSheddingMiddleware(LoadShedConfig{
+		LatencyThreshold: 5 * time.Millisecond,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// First slow request seeds the latency average
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/tasks", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/tasks/1", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503 when latency is high, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("Expected Retry-After header on 503")
+	}
+}
+
+// TestLoadSheddingMiddleware_QueueDepth tests shedding based on an external backlog probe
+func TestLoadSheddingMiddleware_QueueDepth(t *testing.T) {
+	depth := 0
+	handler := LoadSheddingMiddleware(LoadShedConfig{
+		QueueDepth:    func() int { return depth },
+		MaxQueueDepth: 3,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("POST", "/web/tasks", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 with empty queue, got %d", w.Code)
+	}
+
+	depth = 10
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("POST", "/web/tasks", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503 with deep queue, got %d", w.Code)
+	}
+}
+
+// TestLoadSheddingMiddleware_LatencyExcludesLongRoutes tests that the routes excluded from the
+// latency average don't make the server shed
+func TestLoadSheddingMiddleware_LatencyExcludesLongRoutes(t *testing.T) {
+	handler := LoadSheddingMiddleware(LoadShedConfig{
+		LatencyThreshold: 5 * time.Millisecond,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/tasks/export/pdf" {
+			ExcludeFromLatency(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(20 * time.Millisecond)
+			})).ServeHTTP(w, r)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/tasks/export/pdf", nil))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("POST", "/api/tasks", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 after a slow export, got %d", w.Code)
+	}
+}
+
+// TestLoadShedder_LatencyDecays tests that the latency average recovers without samples
+func TestLoadShedder_LatencyDecays(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	shedder := &loadShedder{
+		config: LoadShedConfig{LatencyThreshold: time.Second},
+		now:    func() time.Time { return now },
+	}
+
+	shedder.observe(4 * time.Second)
+	if !shedder.saturated() {
+		t.Fatal("Expected the server saturated right after a slow request")
+	}
+
+	now = now.Add(latencyHalfLife)
+	if got := shedder.averageLatency(); got != 2*time.Second {
+		t.Errorf("Expected the average halved after %v, got %v", latencyHalfLife, got)
+	}
+
+	now = now.Add(2 * latencyHalfLife)
+	if shedder.saturated() {
+		t.Errorf("Expected the server to recover without samples, average %v", shedder.averageLatency())
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// MethodOverrideMiddleware lets the forms of the HTMX routes under /web/ send DELETE and PUT
+// without JavaScript. A form can only POST, so it names the method in a _method query
+// parameter instead, e.g. action="/web/tasks/123?_method=DELETE"; the query is used, unlike a
+// form field, so the body is still read only by the handler, within its size limit. Requests
+// sent by HTMX use the real method and are left alone.
+func MethodOverrideMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasPrefix(r.URL.Path, "/web/") || r.Header.Get("HX-Request") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		query := r.URL.Query()
+		if method := strings.ToUpper(query.Get("_method")); method == http.MethodDelete || method == http.MethodPut {
+			query.Del("_method")
+			r = r.Clone(r.Context())
+			r.Method = method
+			r.URL.RawQuery = query.Encode()
+			r.RequestURI = r.URL.RequestURI()
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMethodOverrideMiddleware(t *testing.T) {
+	var gotMethod, gotURI string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotURI = r.RequestURI
+	})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		htmx       bool
+		wantMethod string
+		wantURI    string
+	}{
+		{name: "DELETE override", method: "POST", path: "/web/tasks/task-1?_method=DELETE", wantMethod: "DELETE", wantURI: "/web/tasks/task-1"},
+		{name: "PUT override is case insensitive", method: "POST", path: "/web/tasks/task-1/image?_method=put&x=1", wantMethod: "PUT", wantURI: "/web/tasks/task-1/image?x=1"},
+		{name: "only DELETE and PUT override", method: "POST", path: "/web/tasks/task-1?_method=GET", wantMethod: "POST", wantURI: "/web/tasks/task-1?_method=GET"},
+		{name: "only POST is overridden", method: "GET", path: "/web/tasks/task-1?_method=DELETE", wantMethod: "GET", wantURI: "/web/tasks/task-1?_method=DELETE"},
+		{name: "HTMX request is left alone", method: "POST", path: "/web/tasks/task-1?_method=DELETE", htmx: true, wantMethod: "POST", wantURI: "/web/tasks/task-1?_method=DELETE"},
+		{name: "routes outside /web are left alone", method: "POST", path: "/api/tasks/task-1?_method=DELETE", wantMethod: "POST", wantURI: "/api/tasks/task-1?_method=DELETE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.htmx {
+				req.Header.Set("HX-Request", "true")
+			}
+
+			MethodOverrideMiddleware(next).ServeHTTP(httptest.NewRecorder(), req)
+
+			if gotMethod != tt.wantMethod {
+				t.Errorf("Expected method %s, got %s", tt.wantMethod, gotMethod)
+			}
+			if gotURI != tt.wantURI {
+				t.Errorf("Expected URI %s, got %s", tt.wantURI, gotURI)
+			}
+		})
+	}
+}
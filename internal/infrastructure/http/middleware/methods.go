@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// routableMethods are the methods probed when resolving the methods routed for a path.
+// OPTIONS is answered by MethodsMiddleware itself and always allowed.
+var routableMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+// mount is a sub-mux served by the root mux under a pattern, with a prefix stripped
+type mount struct {
+	pattern string
+	prefix  string
+	mux     *http.ServeMux
+}
+
+// RouteTable resolves the methods routed for a path, following the sub-muxes mounted on the
+// root mux. Method patterns ("GET /tasks/{id}") of nested muxes are invisible from the root,
+// where the mount matches every method.
+type RouteTable struct {
+	root   *http.ServeMux
+	mounts []mount
+}
+
+// NewRouteTable creates a new RouteTable for the root mux of the server
+func NewRouteTable(root *http.ServeMux) *RouteTable {
+	return &RouteTable{root: root}
+}
+
+// Mount registers a sub-mux served under pattern of the root mux, which strips prefix from
+// the path before it (empty when no http.StripPrefix is used). It doesn't register the
+// route itself.
+func (t *RouteTable) Mount(pattern, prefix string, mux *http.ServeMux) {
+	t.mounts = append(t.mounts, mount{pattern: pattern, prefix: prefix, mux: mux})
+}
+
+// Allowed returns the methods routed for the path of r, in the order of routableMethods.
+// It's empty when no route matches the path.
+func (t *RouteTable) Allowed(r *http.Request) []string {
+	var allowed []string
+	for _, method := range routableMethods {
+		if t.routes(r, method) {
+			allowed = append(allowed, method)
+		}
+	}
+	return allowed
+}
+
+// routes reports whether a request to the path of r with the given method reaches a route
+func (t *RouteTable) routes(r *http.Request, method string) bool {
+	return t.match(r, method) != ""
+}
+
+// Route returns the pattern of the route r reaches, as seen from the root mux
+// ("POST /api/tasks/{id}/snooze"), or "" when it reaches none
+func (t *RouteTable) Route(r *http.Request) string {
+	return t.match(r, r.Method)
+}
+
+// match returns the pattern of the route a request to the path of r with the given method
+// reaches, with the prefix of its sub-mux put back
+func (t *RouteTable) match(r *http.Request, method string) string {
+	probe := *r
+	probe.Method = method
+
+	_, pattern := t.root.Handler(&probe)
+	if pattern == "" {
+		return ""
+	}
+
+	for _, m := range t.mounts {
+		if m.pattern != pattern {
+			continue
+		}
+
+		u := *r.URL
+		u.Path = strings.TrimPrefix(r.URL.Path, m.prefix)
+		u.RawPath = ""
+		probe.URL = &u
+
+		_, pattern = m.mux.Handler(&probe)
+		if pattern == "" {
+			return ""
+		}
+		if method, path, ok := strings.Cut(pattern, " "); ok {
+			return method + " " + m.prefix + path
+		}
+		return m.prefix + pattern
+	}
+
+	return pattern
+}
+
+// MethodsMiddleware answers OPTIONS requests, including CORS preflights, with the methods
+// routed for the path in Allow and Access-Control-Allow-Methods. Other methods that no route
+// accepts get 405 with Allow before authentication or content checks run, so clients see the
+// method error instead of 401 or 415. HEAD is routed wherever GET is and answered by the GET
+// handler without a body.
+func MethodsMiddleware(routes *RouteTable) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			allowed := routes.Allowed(r)
+			if len(allowed) == 0 {
+				// Unknown path: let the mux answer 404
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			allow := strings.Join(append(allowed, http.MethodOptions), ", ")
+
+			if r.Method == http.MethodOptions {
+				w.Header().Set("Allow", allow)
+				w.Header().Set("Access-Control-Allow-Methods", allow)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			if !containsMethod(allowed, r.Method) {
+				w.Header().Set("Allow", allow)
+				writeError(w, r, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// containsMethod reports whether method is in methods
+func containsMethod(methods []string, method string) bool {
+	for _, m := range methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
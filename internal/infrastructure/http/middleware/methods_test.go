@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestRoutes mirrors the layout of the server: an API sub-mux mounted with StripPrefix,
+// plus a route registered on the root mux under the same prefix
+func newTestRoutes() (http.Handler, *RouteTable) {
+	ok := func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("body")) }
+
+	apiMux := http.NewServeMux()
+	apiMux.HandleFunc("GET /tasks", ok)
+	apiMux.HandleFunc("POST /tasks", ok)
+	apiMux.HandleFunc("GET /tasks/{id}", ok)
+	apiMux.HandleFunc("PUT /tasks/{id}", ok)
+	apiMux.HandleFunc("DELETE /tasks/{id}", ok)
+
+	root := http.NewServeMux()
+	root.Handle("/api/", http.StripPrefix("/api", apiMux))
+	root.HandleFunc("POST /api/tasks/import", ok)
+	root.HandleFunc("/health", ok)
+
+	routes := NewRouteTable(root)
+	routes.Mount("/api/", "/api", apiMux)
+
+	return Chain(root, CORSMiddleware, MethodsMiddleware(routes)), routes
+}
+
+func TestRouteTable_Allowed(t *testing.T) {
+	_, routes := newTestRoutes()
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/api/tasks", "GET HEAD POST"},
+		{"/api/tasks/abc", "GET HEAD PUT DELETE"},
+		{"/api/tasks/import", "GET HEAD POST PUT DELETE"},
+		{"/health", "GET HEAD POST PUT PATCH DELETE"},
+		{"/api/unknown", ""},
+		{"/nowhere", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got := ""
+			for i, method := range routes.Allowed(httptest.NewRequest("GET", tt.path, nil)) {
+				if i > 0 {
+					got += " "
+				}
+				got += method
+			}
+			if got != tt.want {
+				t.Errorf("Allowed(%s) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMethodsMiddleware_Options(t *testing.T) {
+	h, _ := newTestRoutes()
+
+	req := httptest.NewRequest("OPTIONS", "/api/tasks/abc", nil)
+	req.Header.Set("Origin", "https://client.example.com")
+	req.Header.Set("Access-Control-Request-Method", "PUT")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d", w.Code)
+	}
+	want := "GET, HEAD, PUT, DELETE, OPTIONS"
+	if got := w.Header().Get("Allow"); got != want {
+		t.Errorf("Allow = %q, want %q", got, want)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Methods"); got != want {
+		t.Errorf("Access-Control-Allow-Methods = %q, want %q", got, want)
+	}
+	if w.Header().Get("Access-Control-Allow-Origin") == "" {
+		t.Error("Expected CORS headers on the preflight response")
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("Expected empty body, got %q", w.Body.String())
+	}
+}
+
+func TestMethodsMiddleware_MethodNotAllowed(t *testing.T) {
+	h, _ := newTestRoutes()
+
+	req := httptest.NewRequest("PATCH", "/api/tasks/abc", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("Expected status 405, got %d", w.Code)
+	}
+	if got := w.Header().Get("Allow"); got != "GET, HEAD, PUT, DELETE, OPTIONS" {
+		t.Errorf("Allow = %q", got)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected JSON error for API route, got Content-Type %q", ct)
+	}
+}
+
+func TestMethodsMiddleware_PassesThrough(t *testing.T) {
+	h, _ := newTestRoutes()
+
+	tests := []struct {
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{"GET", "/api/tasks/abc", http.StatusOK},
+		{"HEAD", "/api/tasks/abc", http.StatusOK},
+		{"POST", "/api/tasks/import", http.StatusOK},
+		{"GET", "/api/unknown", http.StatusNotFound},
+		{"OPTIONS", "/nowhere", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
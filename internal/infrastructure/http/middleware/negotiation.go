@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/http/handler"
+)
+
+// Negotiation lists the media types a group of API routes reads and writes
+type Negotiation struct {
+	Consumes []string // Media types accepted for request bodies; empty accepts any
+	Produces []string // Media types of the responses; empty skips the Accept check
+}
+
+// JSONOnly is the negotiation of the routes that only read and write JSON
+var JSONOnly = Negotiation{Consumes: []string{"application/json"}, Produces: []string{"application/json"}}
+
+// JSONOrJSONAPI is the negotiation of the routes that read JSON and also answer JSON:API
+// documents when asked; the routes without a JSON:API form answer plain JSON
+var JSONOrJSONAPI = Negotiation{Consumes: []string{"application/json"}, Produces: []string{"application/json", handler.JSONAPIMediaType}}
+
+// ContentNegotiation checks the media types of API requests. A request with a body must send
+// it as one of n.Consumes, answering 415 otherwise; requests without one, like most DELETEs
+// and the POSTs that only trigger an action, pass whatever their Content-Type. JSON bodies may
+// use a +json type and must be UTF-8. The Accept header, when sent, must allow one of
+// n.Produces, answering 406 otherwise.
+func ContentNegotiation(n Negotiation) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// ContentLength is -1 for chunked bodies, whose size isn't known yet
+			if r.ContentLength != 0 && len(n.Consumes) > 0 && !consumable(r.Header.Get("Content-Type"), n.Consumes) {
+				message := "Content-Type must be application/json"
+				if len(n.Consumes) > 1 {
+					message = "Content-Type is not supported"
+				}
+				writeError(w, r, http.StatusUnsupportedMediaType, handler.ErrorCode(http.StatusUnsupportedMediaType), message)
+				return
+			}
+
+			if accept := r.Header.Get("Accept"); accept != "" && len(n.Produces) > 0 && !acceptable(accept, n.Produces) {
+				writeError(w, r, http.StatusNotAcceptable, handler.ErrorCode(http.StatusNotAcceptable), "Accept header allows no available response type")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// consumable reports whether a Content-Type header value is one of types
+func consumable(contentType string, types []string) bool {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	isJSON := mediaType == "application/json" || (strings.HasPrefix(mediaType, "application/") && strings.HasSuffix(mediaType, "+json"))
+	for _, t := range types {
+		if t == mediaType || (t == "application/json" && isJSON) {
+			charset, ok := params["charset"]
+			return !isJSON || !ok || strings.EqualFold(charset, "utf-8")
+		}
+	}
+	return false
+}
+
+// acceptable reports whether an Accept header allows one of types. Each type takes the
+// quality of the most specific media range matching it, so "application/json;q=0, */*"
+// refuses JSON.
+func acceptable(accept string, types []string) bool {
+	for _, t := range types {
+		mainType, _, _ := strings.Cut(t, "/")
+		quality, specificity := 0.0, -1
+
+		for _, part := range strings.Split(accept, ",") {
+			mediaRange, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+			mediaRange = strings.ToLower(strings.TrimSpace(mediaRange))
+
+			var s int
+			switch mediaRange {
+			case t:
+				s = 2
+			case mainType + "/*":
+				s = 1
+			case "*/*":
+				s = 0
+			default:
+				continue
+			}
+			if s < specificity {
+				continue
+			}
+
+			q := 1.0
+			for _, param := range strings.Split(params, ";") {
+				if key, raw, ok := strings.Cut(strings.TrimSpace(param), "="); ok && strings.TrimSpace(key) == "q" {
+					if parsed, err := strconv.ParseFloat(strings.TrimSpace(raw), 64); err == nil {
+						q = parsed
+					}
+				}
+			}
+			quality, specificity = q, s
+		}
+
+		if quality > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestContentNegotiation(t *testing.T) {
+	uploads := Negotiation{
+		Consumes: []string{"multipart/form-data", "text/csv", "application/json"},
+		Produces: []string{"application/json"},
+	}
+	exports := Negotiation{
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json", "application/pdf", "text/csv"},
+	}
+
+	tests := []struct {
+		name        string
+		negotiation Negotiation
+		method      string
+		body        string
+		contentType string
+		accept      string
+		wantStatus  int
+	}{
+		{name: "json body", negotiation: JSONOnly, method: "POST", body: "{}", contentType: "application/json", wantStatus: http.StatusOK},
+		{name: "json body with utf-8 charset", negotiation: JSONOnly, method: "POST", body: "{}", contentType: "application/json; charset=UTF-8", wantStatus: http.StatusOK},
+		{name: "json body with another charset", negotiation: JSONOnly, method: "POST", body: "{}", contentType: "application/json; charset=latin1", wantStatus: http.StatusUnsupportedMediaType},
+		{name: "structured json suffix", negotiation: JSONOnly, method: "PATCH", body: "{}", contentType: "application/merge-patch+json", wantStatus: http.StatusOK},
+		{name: "content type mentioning json", negotiation: JSONOnly, method: "POST", body: "{}", contentType: "text/plain; note=application/json", wantStatus: http.StatusUnsupportedMediaType},
+		{name: "form body", negotiation: JSONOnly, method: "PUT", body: "title=x", contentType: "application/x-www-form-urlencoded", wantStatus: http.StatusUnsupportedMediaType},
+		{name: "body without content type", negotiation: JSONOnly, method: "POST", body: "{}", wantStatus: http.StatusUnsupportedMediaType},
+		{name: "body on a DELETE", negotiation: JSONOnly, method: "DELETE", body: "x", contentType: "text/plain", wantStatus: http.StatusUnsupportedMediaType},
+		{name: "POST without body", negotiation: JSONOnly, method: "POST", wantStatus: http.StatusOK},
+		{name: "DELETE without body", negotiation: JSONOnly, method: "DELETE", wantStatus: http.StatusOK},
+		{name: "csv upload", negotiation: uploads, method: "POST", body: "title\nx", contentType: "text/csv; charset=iso-8859-1", wantStatus: http.StatusOK},
+		{name: "multipart upload", negotiation: uploads, method: "PUT", body: "--b--", contentType: "multipart/form-data; boundary=b", wantStatus: http.StatusOK},
+		{name: "xml upload", negotiation: uploads, method: "POST", body: "<a/>", contentType: "application/xml", wantStatus: http.StatusUnsupportedMediaType},
+		{name: "no consumes accepts any body", negotiation: Negotiation{Produces: []string{"application/json"}}, method: "POST", body: "x", contentType: "text/plain", wantStatus: http.StatusOK},
+		{name: "accept json", negotiation: JSONOnly, method: "GET", accept: "application/json", wantStatus: http.StatusOK},
+		{name: "accept anything", negotiation: JSONOnly, method: "GET", accept: "*/*", wantStatus: http.StatusOK},
+		{name: "accept application wildcard", negotiation: JSONOnly, method: "GET", accept: "application/*;q=0.5", wantStatus: http.StatusOK},
+		{name: "accept html only", negotiation: JSONOnly, method: "GET", accept: "text/html", wantStatus: http.StatusNotAcceptable},
+		{name: "accept refusing json", negotiation: JSONOnly, method: "GET", accept: "application/json;q=0, */*", wantStatus: http.StatusNotAcceptable},
+		{name: "accept json:api only", negotiation: JSONOnly, method: "GET", accept: "application/vnd.api+json", wantStatus: http.StatusNotAcceptable},
+		{name: "accept json:api on the api", negotiation: JSONOrJSONAPI, method: "GET", accept: "application/vnd.api+json", wantStatus: http.StatusOK},
+		{name: "accept json on the api", negotiation: JSONOrJSONAPI, method: "GET", accept: "application/json", wantStatus: http.StatusOK},
+		{name: "accept pdf on exports", negotiation: exports, method: "GET", accept: "application/pdf", wantStatus: http.StatusOK},
+		{name: "accept csv on exports", negotiation: exports, method: "GET", accept: "text/html, text/csv;q=0.8", wantStatus: http.StatusOK},
+		{name: "accept xml on exports", negotiation: exports, method: "GET", accept: "application/xml", wantStatus: http.StatusNotAcceptable},
+		{name: "no produces accepts any accept", negotiation: Negotiation{Consumes: []string{"application/json"}}, method: "GET", accept: "image/png", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/tasks", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			ContentNegotiation(tt.negotiation)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/http/handler"
+)
+
+// OrgHeader is the header API clients use to pick the workspace of a request
+const OrgHeader = "X-Org-ID"
+
+// OrgMembers finds the membership of a user in an org
+type OrgMembers interface {
+	FindMember(ctx context.Context, orgID, userID string) (*application.OrgMember, error)
+}
+
+// OrgScopeMiddleware scopes the task lists of each request to a workspace: the org named by
+// the X-Org-ID header, or by the org cookie set by the navbar switcher, or the user's personal
+// tasks when there is neither. An org the user doesn't belong to is refused with 403 when
+// asked for in the header; a cookie left from an org the user has left falls back to the
+// personal tasks. It must run after AuthMiddleware.
+func OrgScopeMiddleware(members OrgMembers) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, _ := r.Context().Value("userID").(string)
+			orgID, fromHeader := r.Header.Get(OrgHeader), true
+			if orgID == "" {
+				orgID, fromHeader = handler.OrgFromRequest(r), false
+			}
+
+			if orgID != "" {
+				_, err := members.FindMember(r.Context(), orgID, userID)
+				switch {
+				case errors.Is(err, application.ErrOrgMemberNotFound) && fromHeader:
+					writeError(w, r, http.StatusForbidden, handler.ErrorCode(http.StatusForbidden), "You are not a member of this org")
+					return
+				case errors.Is(err, application.ErrOrgMemberNotFound):
+					orgID = ""
+				case err != nil:
+					writeError(w, r, http.StatusInternalServerError, handler.ErrorCode(http.StatusInternalServerError), "Failed to check org membership")
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r.WithContext(repository.WithOrgScope(r.Context(), orgID)))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/http/handler"
+)
+
+// fakeOrgMembers knows the orgs of each user
+type fakeOrgMembers map[string][]string // user ID -> org IDs
+
+func (f fakeOrgMembers) FindMember(ctx context.Context, orgID, userID string) (*application.OrgMember, error) {
+	for _, id := range f[userID] {
+		if id == orgID {
+			return &application.OrgMember{OrgID: orgID, UserID: userID, Role: application.OrgRoleMember}, nil
+		}
+	}
+	return nil, application.ErrOrgMemberNotFound
+}
+
+func TestOrgScopeMiddleware(t *testing.T) {
+	members := fakeOrgMembers{"user-1": {"org-1"}}
+	tests := []struct {
+		name       string
+		path       string
+		header     string
+		cookie     string
+		wantStatus int
+		wantOrg    string
+	}{
+		{"personal by default", "/api/tasks", "", "", http.StatusOK, ""},
+		{"org from the header", "/api/tasks", "org-1", "", http.StatusOK, "org-1"},
+		{"org from the cookie", "/tasks", "", "org-1", http.StatusOK, "org-1"},
+		{"header wins over the cookie", "/api/tasks", "org-1", "org-2", http.StatusOK, "org-1"},
+		{"foreign org in the header", "/api/tasks", "org-2", "", http.StatusForbidden, ""},
+		{"stale cookie falls back to personal", "/tasks", "", "org-2", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOrg string
+			var scoped bool
+			h := OrgScopeMiddleware(members)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotOrg, scoped = repository.OrgScope(r.Context())
+			}))
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", "user-1"))
+			if tt.header != "" {
+				req.Header.Set(OrgHeader, tt.header)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: handler.OrgCookieName, Value: tt.cookie})
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK && (!scoped || gotOrg != tt.wantOrg) {
+				t.Errorf("scope = %q (scoped %v), want %q", gotOrg, scoped, tt.wantOrg)
+			}
+		})
+	}
+}
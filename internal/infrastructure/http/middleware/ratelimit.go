@@ -1,98 +1,182 @@
 package middleware
 
 import (
+	"container/list"
 	"fmt"
+	"log"
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// Defaults of the rate limiter settings left at zero
+const (
+	defaultRateLimitMaxClients      = 10000
+	defaultRateLimitCleanupInterval = 5 * time.Minute
+)
+
 // RateLimitConfig holds the configuration for rate limiting
 type RateLimitConfig struct {
 	RequestsPerMinute int
 	Window            time.Duration
-	TrustedProxies    []string // List of trusted proxy IPs that can set X-Forwarded-For headers
+	TrustedProxies    []string          // List of trusted proxy IPs that can set X-Forwarded-For headers
+	Rejections        *RejectionCounter // Counts the refused requests and the evicted clients, optional
+	ExemptIPs         []string          // Client IPs and CIDR ranges never limited, like internal monitoring
+	ExemptPaths       []string          // Path prefixes never limited, like the health check
+	MaxClients        int               // Clients tracked at once, the least recently seen evicted beyond; 0 means 10000
+	CleanupInterval   time.Duration     // Time between sweeps of the idle clients; 0 means 5 minutes
+}
+
+// RejectionCounter counts the requests refused by the rate limiters it is given to, and the
+// clients they evicted to stay under their cap, for the admin dashboard
+type RejectionCounter struct {
+	count     atomic.Int64
+	evictions atomic.Int64
+}
+
+// Rejections returns the number of requests refused so far
+func (c *RejectionCounter) Rejections() int64 {
+	return c.count.Load()
+}
+
+// Evictions returns the number of clients evicted so far because a rate limiter was full.
+// Idle clients swept away aren't counted: they would get a full bucket back anyway.
+func (c *RejectionCounter) Evictions() int64 {
+	return c.evictions.Load()
 }
 
 // clientInfo stores rate limiting data for a specific client
 type clientInfo struct {
+	ip         string
 	tokens     int
 	lastRefill time.Time
 	mu         sync.Mutex
 }
 
-// rateLimiter implements token bucket algorithm for rate limiting
-type rateLimiter struct {
-	config  RateLimitConfig
-	clients map[string]*clientInfo
-	mu      sync.RWMutex
+// RateLimiter limits the requests of each client IP with a token bucket. It tracks at most
+// MaxClients clients, so rotating IPs can't grow it without bound: beyond, the least recently
+// seen client is evicted and starts over with a full bucket. A goroutine sweeps the idle
+// clients until Stop is called.
+type RateLimiter struct {
+	config    RateLimitConfig
+	exemptIPs []*net.IPNet
+	clients   map[string]*list.Element
+	order     *list.List // of *clientInfo, front is the most recently seen
+	evicted   int        // clients evicted for the cap since the last sweep
+	mu        sync.Mutex
+	stop      chan struct{}
+	stopOnce  sync.Once
 }
 
-// newRateLimiter creates a new rate limiter instance
-func newRateLimiter(config RateLimitConfig) *rateLimiter {
-	rl := &rateLimiter{
-		config:  config,
-		clients: make(map[string]*clientInfo),
+// NewRateLimiter creates a new rate limiter and starts sweeping its idle clients
+func NewRateLimiter(config RateLimitConfig) *RateLimiter {
+	if config.MaxClients <= 0 {
+		config.MaxClients = defaultRateLimitMaxClients
+	}
+	if config.CleanupInterval <= 0 {
+		config.CleanupInterval = defaultRateLimitCleanupInterval
+	}
+
+	rl := &RateLimiter{
+		config:    config,
+		exemptIPs: parseExemptIPs(config.ExemptIPs),
+		clients:   make(map[string]*list.Element),
+		order:     list.New(),
+		stop:      make(chan struct{}),
 	}
 
-	// Start cleanup goroutine to remove stale clients
 	go rl.cleanup()
 
 	return rl
 }
 
-// cleanup removes stale client entries to prevent memory leaks
-func (rl *rateLimiter) cleanup() {
-	ticker := time.NewTicker(5 * time.Minute)
+// Stop ends the sweeping of the idle clients. The limiter keeps limiting, but stops
+// forgetting idle clients other than by its cap. Calling Stop again does nothing.
+func (rl *RateLimiter) Stop() {
+	rl.stopOnce.Do(func() { close(rl.stop) })
+}
+
+// cleanup sweeps the idle clients every CleanupInterval, until Stop is called
+func (rl *RateLimiter) cleanup() {
+	ticker := time.NewTicker(rl.config.CleanupInterval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		rl.mu.Lock()
-		now := time.Now()
-		for ip, client := range rl.clients {
-			client.mu.Lock()
-			// Remove clients that haven't been accessed in 2x the window time
-			if now.Sub(client.lastRefill) > rl.config.Window*2 {
-				delete(rl.clients, ip)
-			}
-			client.mu.Unlock()
+	for {
+		select {
+		case <-rl.stop:
+			return
+		case now := <-ticker.C:
+			rl.sweep(now)
 		}
-		rl.mu.Unlock()
 	}
 }
 
-// getOrCreateClient gets existing client info or creates new one
-func (rl *rateLimiter) getOrCreateClient(ip string) *clientInfo {
-	rl.mu.RLock()
-	client, exists := rl.clients[ip]
-	rl.mu.RUnlock()
+// sweep removes the clients whose window has been over for a whole window, to prevent memory
+// leaks, and logs the clients evicted for the cap since the last sweep. Clients are checked from
+// the least recently seen, so the sweep doesn't depend on the map order.
+func (rl *RateLimiter) sweep(now time.Time) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	removed := 0
+	for elem := rl.order.Back(); elem != nil; {
+		prev := elem.Prev()
+		client := elem.Value.(*clientInfo)
+		client.mu.Lock()
+		// Remove clients that haven't been accessed in 2x the window time
+		if now.Sub(client.lastRefill) > rl.config.Window*2 {
+			rl.order.Remove(elem)
+			delete(rl.clients, client.ip)
+			removed++
+		}
+		client.mu.Unlock()
+		elem = prev
+	}
 
-	if exists {
-		return client
+	// Evictions are logged once per sweep, as an IP rotation can cause one per request
+	if rl.evicted > 0 {
+		log.Printf("rate limiter full: evicted %d clients over the cap of %d since the last sweep, swept %d idle, %d left", rl.evicted, rl.config.MaxClients, removed, rl.order.Len())
+		rl.evicted = 0
 	}
+}
 
-	// Client doesn't exist, create it
+// getOrCreateClient gets existing client info or creates new one, marking it as the most
+// recently seen. Creating a client beyond MaxClients evicts the least recently seen.
+func (rl *RateLimiter) getOrCreateClient(ip string) *clientInfo {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	// Double-check in case another goroutine created it
-	if client, exists := rl.clients[ip]; exists {
-		return client
+	if elem, exists := rl.clients[ip]; exists {
+		rl.order.MoveToFront(elem)
+		return elem.Value.(*clientInfo)
 	}
 
-	client = &clientInfo{
+	client := &clientInfo{
+		ip:         ip,
 		tokens:     rl.config.RequestsPerMinute,
 		lastRefill: time.Now(),
 	}
-	rl.clients[ip] = client
+	rl.clients[ip] = rl.order.PushFront(client)
+
+	for rl.order.Len() > rl.config.MaxClients {
+		oldest := rl.order.Back()
+		rl.order.Remove(oldest)
+		delete(rl.clients, oldest.Value.(*clientInfo).ip)
+		rl.evicted++
+		if rl.config.Rejections != nil {
+			rl.config.Rejections.evictions.Add(1)
+		}
+	}
 
 	return client
 }
 
 // allow checks if a request should be allowed and updates the token count
-func (rl *rateLimiter) allow(ip string) (allowed bool, remaining int, resetTime time.Time) {
+func (rl *RateLimiter) allow(ip string) (allowed bool, remaining int, resetTime time.Time) {
 	client := rl.getOrCreateClient(ip)
 
 	client.mu.Lock()
@@ -118,6 +202,57 @@ func (rl *rateLimiter) allow(ip string) (allowed bool, remaining int, resetTime
 	return false, 0, resetTime
 }
 
+// parseExemptIPs parses IPs and CIDR ranges into networks, a single IP being a network of
+// one address. Invalid entries are logged and ignored.
+func parseExemptIPs(entries []string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(entries))
+	for _, entry := range entries {
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				log.Printf("ignoring invalid rate limit exemption %q", entry)
+				continue
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			log.Printf("ignoring invalid rate limit exemption %q: %v", entry, err)
+			continue
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// exempt reports whether a request from ip is never limited, because of its path or its client
+func (rl *RateLimiter) exempt(r *http.Request, ip string) bool {
+	for _, prefix := range rl.config.ExemptPaths {
+		// Prefixes match whole segments: /health exempts /health/db but not /healthy
+		if r.URL.Path == prefix || strings.HasPrefix(r.URL.Path, strings.TrimSuffix(prefix, "/")+"/") {
+			return true
+		}
+	}
+	if len(rl.exemptIPs) == 0 {
+		return false
+	}
+	clientIP := net.ParseIP(ip)
+	if clientIP == nil {
+		return false
+	}
+	for _, network := range rl.exemptIPs {
+		if network.Contains(clientIP) {
+			return true
+		}
+	}
+	return false
+}
+
 // isTrustedProxy checks if the given IP is in the list of trusted proxies
 func isTrustedProxy(ip string, trustedProxies []string) bool {
 	for _, trusted := range trustedProxies {
@@ -216,29 +351,41 @@ func trimSpace(s string) string {
 	return s[start:end]
 }
 
-// RateLimitMiddleware creates a middleware that limits requests per IP address
+// RateLimitMiddleware creates a middleware that limits requests per IP address. Its limiter is
+// never stopped; use NewRateLimiter for one that is stopped on shutdown.
 func RateLimitMiddleware(config RateLimitConfig) func(http.Handler) http.Handler {
-	limiter := newRateLimiter(config)
+	return NewRateLimiter(config).Middleware
+}
 
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ip := extractIP(r, config.TrustedProxies)
+// Middleware limits the requests to next per IP address. Exempted requests go through without
+// consuming tokens or getting rate limit headers.
+func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
+	config := rl.config
 
-			allowed, remaining, resetTime := limiter.allow(ip)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := extractIP(r, config.TrustedProxies)
+		if rl.exempt(r, ip) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		allowed, remaining, resetTime := rl.allow(ip)
 
-			// Set rate limit headers
-			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(config.RequestsPerMinute))
-			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
-			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(resetTime.Unix(), 10))
+		// Set rate limit headers
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(config.RequestsPerMinute))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(resetTime.Unix(), 10))
 
-			if !allowed {
-				retryAfter := time.Until(resetTime).Seconds()
-				w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter)))
-				http.Error(w, fmt.Sprintf("Rate limit exceeded. Try again in %d seconds.", int(retryAfter)), http.StatusTooManyRequests)
-				return
+		if !allowed {
+			if config.Rejections != nil {
+				config.Rejections.count.Add(1)
 			}
+			retryAfter := time.Until(resetTime).Seconds()
+			w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter)))
+			writeError(w, r, http.StatusTooManyRequests, "rate_limit_exceeded", fmt.Sprintf("Rate limit exceeded. Try again in %d seconds.", int(retryAfter)))
+			return
+		}
 
-			next.ServeHTTP(w, r)
-		})
-	}
+		next.ServeHTTP(w, r)
+	})
 }
@@ -533,3 +533,196 @@ func TestRateLimitMiddleware_XForwardedForMultipleIPs(t *testing.T) {
 		t.Error("request from different client should succeed")
 	}
 }
+
+// TestRateLimitMiddleware_Rejections tests that refused requests are counted across limiters
+func TestRateLimitMiddleware_Rejections(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rejections := &RejectionCounter{}
+	general := RateLimitMiddleware(RateLimitConfig{RequestsPerMinute: 2, Window: time.Minute, Rejections: rejections})(handler)
+	auth := RateLimitMiddleware(RateLimitConfig{RequestsPerMinute: 1, Window: time.Minute, Rejections: rejections})(handler)
+
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest("GET", "/tasks", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		general.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest("POST", "/auth/login", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		auth.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if got := rejections.Rejections(); got != 3 {
+		t.Errorf("Expected 3 rejections, got %d", got)
+	}
+}
+
+func TestRateLimitMiddleware_Exemptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		path       string
+		exempt     bool
+	}{
+		{"exempt path", "192.168.1.1:12345", "/health", true},
+		{"below an exempt path", "192.168.1.1:12345", "/health/db", true},
+		{"path sharing a prefix", "192.168.1.1:12345", "/healthy", false},
+		{"exempt IP", "10.0.0.5:12345", "/tasks", true},
+		{"IP in an exempt range", "172.16.3.4:12345", "/tasks", true},
+		{"IPv6 in an exempt range", "[fd00::1]:12345", "/tasks", true},
+		{"other client", "192.168.1.1:12345", "/tasks", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := RateLimitMiddleware(RateLimitConfig{
+				RequestsPerMinute: 2,
+				Window:            time.Minute,
+				ExemptIPs:         []string{"10.0.0.5", "172.16.0.0/12", "fd00::/8", "not-an-ip"},
+				ExemptPaths:       []string{"/health", "/metrics"},
+			})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			blocked := 0
+			for i := 0; i < 10; i++ {
+				req := httptest.NewRequest("GET", tt.path, nil)
+				req.RemoteAddr = tt.remoteAddr
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, req)
+				if w.Code == http.StatusTooManyRequests {
+					blocked++
+				}
+				if tt.exempt && w.Header().Get("X-RateLimit-Limit") != "" {
+					t.Fatal("Exempted requests should not get rate limit headers")
+				}
+			}
+
+			if tt.exempt && blocked != 0 {
+				t.Errorf("Expected no exempted request blocked, got %d", blocked)
+			}
+			if !tt.exempt && blocked != 8 {
+				t.Errorf("Expected 8 requests blocked, got %d", blocked)
+			}
+		})
+	}
+}
+
+func TestRateLimitMiddleware_ExemptionsConsumeNoTokens(t *testing.T) {
+	handler := RateLimitMiddleware(RateLimitConfig{
+		RequestsPerMinute: 3,
+		Window:            time.Minute,
+		ExemptPaths:       []string{"/health"},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	request := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	for i := 0; i < 20; i++ {
+		request("/health")
+	}
+
+	// The health checks left the whole bucket of the client
+	w := request("/tasks")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if got := w.Header().Get("X-RateLimit-Remaining"); got != "2" {
+		t.Errorf("Expected 2 requests remaining after the health checks, got %s", got)
+	}
+}
+
+func TestRateLimiter_MaxClientsEvictsLeastRecentlySeen(t *testing.T) {
+	rejections := &RejectionCounter{}
+	limiter := NewRateLimiter(RateLimitConfig{RequestsPerMinute: 1, Window: time.Minute, MaxClients: 2, Rejections: rejections})
+	defer limiter.Stop()
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	request := func(ip string) int {
+		req := httptest.NewRequest("GET", "/tasks", nil)
+		req.RemoteAddr = ip + ":12345"
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	steps := []struct {
+		ip   string
+		want int
+	}{
+		{"192.168.1.1", http.StatusOK},
+		{"192.168.1.2", http.StatusOK},
+		{"192.168.1.1", http.StatusTooManyRequests}, // .1 is now the most recently seen
+		{"192.168.1.3", http.StatusOK},              // evicts .2
+		{"192.168.1.1", http.StatusTooManyRequests}, // kept, with its empty bucket
+		{"192.168.1.2", http.StatusOK},              // starts over, evicting .3
+		{"192.168.1.3", http.StatusOK},              // starts over, evicting .1
+	}
+	for i, step := range steps {
+		if got := request(step.ip); got != step.want {
+			t.Fatalf("Step %d (%s): expected status %d, got %d", i, step.ip, step.want, got)
+		}
+	}
+
+	if got := limiter.order.Len(); got != 2 {
+		t.Errorf("Expected 2 clients tracked, got %d", got)
+	}
+	if got := rejections.Evictions(); got != 3 {
+		t.Errorf("Expected 3 evictions, got %d", got)
+	}
+}
+
+func TestRateLimiter_SweepRemovesIdleClients(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{RequestsPerMinute: 5, Window: time.Minute, MaxClients: 10})
+	defer limiter.Stop()
+
+	for _, ip := range []string{"192.168.1.1", "192.168.1.2", "192.168.1.3"} {
+		limiter.allow(ip)
+	}
+	now := time.Now()
+	limiter.clients["192.168.1.1"].Value.(*clientInfo).lastRefill = now.Add(-3 * time.Minute)
+	limiter.clients["192.168.1.3"].Value.(*clientInfo).lastRefill = now.Add(-90 * time.Second) // within 2 windows
+
+	limiter.sweep(now)
+
+	if _, ok := limiter.clients["192.168.1.1"]; ok {
+		t.Error("Expected the idle client to be swept")
+	}
+	if got := limiter.order.Len(); got != 2 || len(limiter.clients) != 2 {
+		t.Errorf("Expected 2 clients left, got %d in the list and %d in the map", got, len(limiter.clients))
+	}
+	if got := limiter.order.Front().Value.(*clientInfo).ip; got != "192.168.1.3" {
+		t.Errorf("Expected the most recently seen client first, got %s", got)
+	}
+}
+
+func TestRateLimiter_Stop(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{RequestsPerMinute: 1, Window: time.Minute, CleanupInterval: time.Millisecond})
+	limiter.allow("192.168.1.1")
+
+	limiter.Stop()
+	limiter.Stop() // a second call does nothing
+
+	select {
+	case <-limiter.stop:
+	default:
+		t.Fatal("Expected the cleanup to be stopped")
+	}
+
+	// A stopped limiter keeps limiting
+	if allowed, _, _ := limiter.allow("192.168.1.1"); allowed {
+		t.Error("Expected the client to stay limited after Stop")
+	}
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ParseOrigins normalizes the origins of a list of URLs to scheme://host[:port] in lowercase,
+// the form browsers send in the Origin header. Paths are dropped, so a public URL can be given.
+func ParseOrigins(urls []string) ([]string, error) {
+	origins := make([]string, 0, len(urls))
+	for _, raw := range urls {
+		origin, ok := originOf(raw)
+		if !ok {
+			return nil, fmt.Errorf("invalid origin %q: must be an absolute http or https URL", raw)
+		}
+		origins = append(origins, origin)
+	}
+	return origins, nil
+}
+
+// originOf returns the origin of an absolute http or https URL
+func originOf(raw string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", false
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host), true
+}
+
+// SameOriginMiddleware rejects with 403 the mutations of the web routes under /web/ sent from
+// another site, as a defense in depth besides the SameSite session cookie. The Origin header
+// must be one of origins, as returned by ParseOrigins; without it, the origin of the Referer
+// is checked instead. Browsers send at least one of them with form posts and HTMX requests, so
+// mutations carrying neither are rejected too. GET, HEAD and OPTIONS are left alone.
+func SameOriginMiddleware(origins []string) func(http.Handler) http.Handler {
+	allowed := make(map[string]bool, len(origins))
+	for _, origin := range origins {
+		allowed[origin] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			safe := r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions
+			if safe || !strings.HasPrefix(r.URL.Path, "/web/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			source := r.Header.Get("Origin")
+			if source == "" {
+				source = r.Header.Get("Referer")
+			}
+			// "null" origins, sent by sandboxed frames and some redirects, never match
+			if origin, ok := originOf(source); !ok || !allowed[origin] {
+				writeError(w, r, http.StatusForbidden, "forbidden", "Cross-site request rejected")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
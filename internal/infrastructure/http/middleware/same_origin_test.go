@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSameOriginMiddleware(t *testing.T) {
+	origins, err := ParseOrigins([]string{"https://todo.example.com/app", "http://localhost:8080"})
+	if err != nil {
+		t.Fatalf("ParseOrigins() error: %v", err)
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	h := SameOriginMiddleware(origins)(next)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		origin     string
+		referer    string
+		wantStatus int
+	}{
+		{name: "same origin", method: "POST", path: "/web/tasks", origin: "https://todo.example.com", wantStatus: http.StatusNoContent},
+		{name: "origin is case insensitive", method: "DELETE", path: "/web/tasks/task-1", origin: "HTTPS://Todo.Example.com", wantStatus: http.StatusNoContent},
+		{name: "another configured origin", method: "POST", path: "/web/tasks", origin: "http://localhost:8080", wantStatus: http.StatusNoContent},
+		{name: "referer without origin", method: "POST", path: "/web/tasks", referer: "https://todo.example.com/tasks?project=p-1", wantStatus: http.StatusNoContent},
+		{name: "mismatched origin", method: "POST", path: "/web/tasks", origin: "https://evil.example.com", wantStatus: http.StatusForbidden},
+		{name: "mismatched scheme", method: "POST", path: "/web/tasks", origin: "http://todo.example.com", wantStatus: http.StatusForbidden},
+		{name: "mismatched port", method: "POST", path: "/web/tasks", origin: "https://todo.example.com:8443", wantStatus: http.StatusForbidden},
+		{name: "origin wins over referer", method: "POST", path: "/web/tasks", origin: "https://evil.example.com", referer: "https://todo.example.com/tasks", wantStatus: http.StatusForbidden},
+		{name: "mismatched referer", method: "PUT", path: "/web/tasks/task-1/image", referer: "https://evil.example.com/page", wantStatus: http.StatusForbidden},
+		{name: "null origin", method: "POST", path: "/web/tasks", origin: "null", wantStatus: http.StatusForbidden},
+		{name: "missing origin and referer", method: "POST", path: "/web/auth/login", wantStatus: http.StatusForbidden},
+		{name: "reads are left alone", method: "GET", path: "/web/invitations", origin: "https://evil.example.com", wantStatus: http.StatusNoContent},
+		{name: "routes outside /web are left alone", method: "POST", path: "/api/tasks", wantStatus: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.referer != "" {
+				req.Header.Set("Referer", tt.referer)
+			}
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestParseOrigins(t *testing.T) {
+	origins, err := ParseOrigins([]string{"https://Todo.Example.com/app/", " http://localhost:8080 "})
+	if err != nil {
+		t.Fatalf("ParseOrigins() error: %v", err)
+	}
+	if len(origins) != 2 || origins[0] != "https://todo.example.com" || origins[1] != "http://localhost:8080" {
+		t.Errorf("ParseOrigins() = %v", origins)
+	}
+
+	for _, invalid := range []string{"todo.example.com", "ftp://todo.example.com", "/tasks", ""} {
+		if _, err := ParseOrigins([]string{invalid}); err == nil {
+			t.Errorf("ParseOrigins(%q) expected an error", invalid)
+		}
+	}
+}
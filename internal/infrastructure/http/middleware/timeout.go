@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/http/handler"
+)
+
+// TimeoutMiddleware gives each request d to complete. The request context carries the
+// deadline, so database queries and outgoing calls made with it are cancelled, and a handler
+// still running when it expires is answered with 503 instead of holding the connection open
+// while a dependency stalls. As with http.TimeoutHandler, the response is buffered until the
+// handler returns; whatever it writes after the deadline is discarded. d <= 0 disables it.
+func TimeoutMiddleware(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if d <= 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+
+			tw := &timeoutWriter{header: make(http.Header)}
+			done := make(chan struct{})
+			panicked := make(chan any, 1)
+			go func() {
+				defer func() {
+					// Re-raised on the request goroutine, where RecoverMiddleware handles it
+					if p := recover(); p != nil {
+						panicked <- p
+						return
+					}
+					close(done)
+				}()
+				next.ServeHTTP(tw, r.WithContext(ctx))
+			}()
+
+			select {
+			case p := <-panicked:
+				panic(p)
+			case <-done:
+				tw.writeTo(w)
+			case <-ctx.Done():
+				tw.expire()
+				writeError(w, r, http.StatusServiceUnavailable, handler.CodeTimeout, "Request timed out. Try again later.")
+			}
+		})
+	}
+}
+
+// timeoutWriter buffers the response of a handler run by TimeoutMiddleware
+type timeoutWriter struct {
+	mu      sync.Mutex
+	header  http.Header
+	body    bytes.Buffer
+	status  int
+	expired bool
+}
+
+// Header returns the headers of the buffered response
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+// WriteHeader records the status of the buffered response
+func (tw *timeoutWriter) WriteHeader(status int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.expired || tw.status != 0 {
+		return
+	}
+	tw.status = status
+}
+
+// Write buffers the body, failing once the deadline has passed so the handler can stop
+func (tw *timeoutWriter) Write(p []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.expired {
+		return 0, http.ErrHandlerTimeout
+	}
+	if tw.status == 0 {
+		tw.status = http.StatusOK
+	}
+	return tw.body.Write(p)
+}
+
+// expire discards the buffered response; later writes fail
+func (tw *timeoutWriter) expire() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.expired = true
+}
+
+// writeTo sends the buffered response to w
+func (tw *timeoutWriter) writeTo(w http.ResponseWriter) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	dst := w.Header()
+	for key, values := range tw.header {
+		dst[key] = values
+	}
+	if tw.status == 0 {
+		tw.status = http.StatusOK
+	}
+	w.WriteHeader(tw.status)
+	w.Write(tw.body.Bytes())
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeoutMiddleware(t *testing.T) {
+	// The slow handler stalls like a stuck dependency: it ignores the deadline for a while
+	// and only then writes, which must not reach the client
+	writeErrs := make(chan error, 4)
+	handler := TimeoutMiddleware(20 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("slow") != "" {
+			<-r.Context().Done()
+			time.Sleep(10 * time.Millisecond)
+			_, err := w.Write([]byte("stale response"))
+			writeErrs <- err
+			return
+		}
+		if _, ok := r.Context().Deadline(); !ok {
+			t.Error("request context has no deadline")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "/api/tasks/task-1")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"task-1"}`))
+	}))
+
+	tests := []struct {
+		name            string
+		path            string
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{name: "fast handler is answered as written", path: "/api/tasks", wantStatus: http.StatusCreated, wantContentType: "application/json", wantBody: `{"id":"task-1"}`},
+		{name: "stalled API request gets a JSON 503", path: "/api/tasks?slow=1", wantStatus: http.StatusServiceUnavailable, wantContentType: "application/json", wantBody: `"code":"timeout"`},
+		{name: "stalled web request gets a text 503", path: "/tasks?slow=1", wantStatus: http.StatusServiceUnavailable, wantContentType: "text/plain; charset=utf-8", wantBody: "Request timed out"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest("POST", tt.path, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("Expected Content-Type %q, got %q", tt.wantContentType, got)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("Expected body containing %q, got %q", tt.wantBody, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusServiceUnavailable {
+				if err := <-writeErrs; !errors.Is(err, http.ErrHandlerTimeout) {
+					t.Errorf("Expected late write to fail with ErrHandlerTimeout, got %v", err)
+				}
+				if strings.Contains(w.Body.String(), "stale response") {
+					t.Error("Late write reached the client")
+				}
+			}
+		})
+	}
+}
+
+func TestTimeoutMiddleware_Disabled(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); ok {
+			t.Error("request context has a deadline with the timeout disabled")
+		}
+	})
+
+	handler := TimeoutMiddleware(0)(next)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/tasks", nil))
+}
+
+func TestTimeoutMiddleware_Panic(t *testing.T) {
+	// A panicking handler reaches RecoverMiddleware like it would without the timeout
+	handler := RecoverMiddleware(TimeoutMiddleware(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/tasks", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", w.Code)
+	}
+}
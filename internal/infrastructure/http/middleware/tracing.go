@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/tracing"
+)
+
+// TracingMiddleware records a server span per request with otelhttp, continuing the trace of
+// callers sending a traceparent header. Spans are named after the route the request reaches
+// in routes, or the method alone for unknown paths, so their names don't grow with the IDs in
+// the paths.
+func TracingMiddleware(provider trace.TracerProvider, routes *RouteTable) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		withRoute := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if route := routes.Route(r); route != "" {
+				trace.SpanFromContext(r.Context()).SetAttributes(attribute.String("http.route", route))
+			}
+			next.ServeHTTP(w, r)
+		})
+		return otelhttp.NewHandler(withRoute, "",
+			otelhttp.WithTracerProvider(provider),
+			otelhttp.WithPropagators(tracing.Propagator),
+			otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+				if route := routes.Route(r); route != "" {
+					return route
+				}
+				return r.Method
+			}),
+		)
+	}
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRouteTable_Route(t *testing.T) {
+	_, routes := newTestRoutes()
+
+	tests := []struct {
+		method, path string
+		want         string
+	}{
+		{"GET", "/api/tasks/abc", "GET /api/tasks/{id}"},
+		{"POST", "/api/tasks/import", "POST /api/tasks/import"},
+		{"GET", "/health", "/health"},
+		{"PATCH", "/api/tasks/abc", ""},
+		{"GET", "/nowhere", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			if got := routes.Route(httptest.NewRequest(tt.method, tt.path, nil)); got != tt.want {
+				t.Errorf("Route() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	handler, routes := newTestRoutes()
+	var inHandler context.Context
+	traced := TracingMiddleware(provider, routes)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inHandler = r.Context()
+		if r.URL.Path == "/health" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+
+	req := httptest.NewRequest("GET", "/api/tasks/abc", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	traced.ServeHTTP(httptest.NewRecorder(), req)
+	if !trace.SpanFromContext(inHandler).SpanContext().IsValid() {
+		t.Fatal("handlers should get the span of the request in their context")
+	}
+	traced.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/health", nil))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	if spans[0].Name != "GET /api/tasks/{id}" || spans[0].SpanContext.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" || spans[0].Parent.SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("span = %+v, want named after the route, continuing the trace of the caller", spans[0])
+	}
+	if !slices.Contains(spans[0].Attributes, attribute.String("http.route", "GET /api/tasks/{id}")) {
+		t.Errorf("span attributes = %v, want the route", spans[0].Attributes)
+	}
+	if spans[1].Name != "/health" || spans[1].Status.Code != codes.Error {
+		t.Errorf("span = %+v, want failed by the 503", spans[1])
+	}
+}
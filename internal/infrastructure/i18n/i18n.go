@@ -0,0 +1,150 @@
+// Package i18n holds the message catalogs of the interface and negotiates the
+// language of each request.
+//
+// Catalogs are JSON files embedded from locales/, one per supported locale, with two
+// sections: "messages", keyed by dotted identifiers used by templates and handlers,
+// and "errors", translating the English messages of domain and API errors.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// Default is the locale of the web interface when the client doesn't ask for another
+const Default = application.LocalePtBR
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// catalog holds the translations of a locale
+type catalog struct {
+	Messages map[string]string `json:"messages"`
+	Errors   map[string]string `json:"errors"`
+}
+
+// supported lists the locales with a catalog, in the order shown to users
+var supported = []application.Locale{application.LocalePtBR, application.LocaleEN}
+
+var catalogs = loadCatalogs()
+
+// loadCatalogs parses the embedded catalogs; a broken catalog is a build defect, so it panics
+func loadCatalogs() map[application.Locale]*catalog {
+	catalogs := make(map[application.Locale]*catalog, len(supported))
+	for _, locale := range supported {
+		data, err := localeFiles.ReadFile("locales/" + string(locale) + ".json")
+		if err != nil {
+			panic(fmt.Sprintf("i18n: missing catalog for %s: %v", locale, err))
+		}
+		var c catalog
+		if err := json.Unmarshal(data, &c); err != nil {
+			panic(fmt.Sprintf("i18n: invalid catalog for %s: %v", locale, err))
+		}
+		catalogs[locale] = &c
+	}
+	return catalogs
+}
+
+// Supported returns the supported locales
+func Supported() []application.Locale {
+	return append([]application.Locale(nil), supported...)
+}
+
+// T returns the message of a key in a locale, formatted with args. Keys missing from the
+// locale fall back to the default locale and then to the key itself.
+func T(locale application.Locale, key string, args ...any) string {
+	message, ok := lookup(locale, key)
+	if !ok {
+		message, ok = lookup(Default, key)
+	}
+	if !ok {
+		message = key
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// lookup finds a message key in the catalog of a locale
+func lookup(locale application.Locale, key string) (string, bool) {
+	c, ok := catalogs[locale]
+	if !ok {
+		return "", false
+	}
+	message, ok := c.Messages[key]
+	return message, ok
+}
+
+// Error translates the message of an error to a locale, returning it unchanged when the
+// catalog has no translation (English messages in the English locale, dynamic messages...)
+func Error(locale application.Locale, message string) string {
+	if c, ok := catalogs[locale]; ok {
+		if translated, ok := c.Errors[message]; ok {
+			return translated
+		}
+	}
+	return message
+}
+
+// Parse matches a language tag to a supported locale by its primary language,
+// so "pt", "pt-PT" and "pt_br" all select pt-BR
+func Parse(tag string) (application.Locale, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	language, _, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+	if language == "" {
+		return "", false
+	}
+
+	for _, locale := range supported {
+		primary, _, _ := strings.Cut(strings.ToLower(string(locale)), "-")
+		if primary == language {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// Negotiate picks the supported locale preferred by an Accept-Language header, honoring
+// quality values. It returns false when the header names no supported language.
+func Negotiate(acceptLanguage string) (application.Locale, bool) {
+	type candidate struct {
+		tag     string
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{tag: tag, quality: quality})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	for _, c := range candidates {
+		if locale, ok := Parse(c.tag); ok {
+			return locale, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,127 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestCatalogsHaveTheSameKeys(t *testing.T) {
+	reference := catalogs[Default]
+	for _, locale := range supported {
+		c := catalogs[locale]
+		for key := range reference.Messages {
+			if _, ok := c.Messages[key]; !ok {
+				t.Errorf("catalog %s is missing key %q", locale, key)
+			}
+		}
+		for key := range c.Messages {
+			if _, ok := reference.Messages[key]; !ok {
+				t.Errorf("catalog %s has key %q missing from %s", locale, key, Default)
+			}
+		}
+	}
+}
+
+func TestCatalogsHaveMatchingFormatVerbs(t *testing.T) {
+	for key, message := range catalogs[Default].Messages {
+		for _, locale := range supported {
+			translated := catalogs[locale].Messages[key]
+			if strings.Count(translated, "%") != strings.Count(message, "%") {
+				t.Errorf("catalog %s key %q has different format verbs: %q vs %q", locale, key, translated, message)
+			}
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale application.Locale
+		key    string
+		args   []any
+		want   string
+	}{
+		{"portuguese", application.LocalePtBR, "task.status.pending", nil, "Pendente"},
+		{"english", application.LocaleEN, "task.status.pending", nil, "Pending"},
+		{"with arguments", application.LocaleEN, "task.due", []any{"2025-12-25 10:00"}, "Due: 2025-12-25 10:00"},
+		{"unknown locale falls back to default", application.Locale("fr"), "task.status.pending", nil, "Pendente"},
+		{"unknown key returns the key", application.LocaleEN, "missing.key", nil, "missing.key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := T(tt.locale, tt.key, tt.args...); got != tt.want {
+				t.Errorf("T() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	if got := Error(application.LocalePtBR, "task not found"); got != "tarefa não encontrada" {
+		t.Errorf("Error() = %q, want Portuguese translation", got)
+	}
+	if got := Error(application.LocaleEN, "task not found"); got != "task not found" {
+		t.Errorf("Error() = %q, want the message unchanged", got)
+	}
+	if got := Error(application.LocalePtBR, "some dynamic message"); got != "some dynamic message" {
+		t.Errorf("Error() = %q, want unknown messages unchanged", got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		tag    string
+		want   application.Locale
+		wantOK bool
+	}{
+		{"pt-BR", application.LocalePtBR, true},
+		{"pt", application.LocalePtBR, true},
+		{"pt_br", application.LocalePtBR, true},
+		{"PT-PT", application.LocalePtBR, true},
+		{"en", application.LocaleEN, true},
+		{"en-US", application.LocaleEN, true},
+		{"fr-FR", "", false},
+		{"", "", false},
+		{"*", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, ok := Parse(tt.tag)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Parse(%q) = %q, %v, want %q, %v", tt.tag, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   application.Locale
+		wantOK bool
+	}{
+		{"single language", "en-US", application.LocaleEN, true},
+		{"first supported wins", "fr-FR, en;q=0.8, pt-BR;q=0.5", application.LocaleEN, true},
+		{"quality values reorder", "en;q=0.3, pt-BR;q=0.9", application.LocalePtBR, true},
+		{"browser header", "pt-BR,pt;q=0.9,en-US;q=0.8,en;q=0.7", application.LocalePtBR, true},
+		{"q=0 is not acceptable", "en;q=0, pt;q=0.1", application.LocalePtBR, true},
+		{"wildcard only", "*", "", false},
+		{"unsupported", "fr, de;q=0.5", "", false},
+		{"empty", "", "", false},
+		{"malformed quality is skipped", "en;q=abc, pt", application.LocalePtBR, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Negotiate(tt.header)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Negotiate(%q) = %q, %v, want %q, %v", tt.header, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package identicon
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestSVG(t *testing.T) {
+	ana := SVG("user-ana")
+
+	if !bytes.Equal(ana, SVG("user-ana")) {
+		t.Error("Expected the same seed to draw the same identicon")
+	}
+	if bytes.Equal(ana, SVG("user-bia")) {
+		t.Error("Expected different seeds to draw different identicons")
+	}
+	if !bytes.HasPrefix(ana, []byte(`<svg xmlns="http://www.w3.org/2000/svg"`)) {
+		t.Errorf("Expected an SVG document, got %s", ana)
+	}
+
+	// The seed never reaches the document, so it can't inject markup
+	injected := SVG(`"><script>alert(1)</script>`)
+	if strings.Contains(string(injected), "script") {
+		t.Errorf("Expected the seed to be left out of the SVG, got %s", injected)
+	}
+	if err := xml.Unmarshal(injected, new(struct{})); err != nil {
+		t.Errorf("Expected well-formed XML, got %v", err)
+	}
+}
+
+func TestSVG_IsSymmetric(t *testing.T) {
+	var doc struct {
+		Rects []struct {
+			X string `xml:"x,attr"`
+			Y string `xml:"y,attr"`
+		} `xml:"rect"`
+	}
+	if err := xml.Unmarshal(SVG("user-ana"), &doc); err != nil {
+		t.Fatalf("Failed to parse SVG: %v", err)
+	}
+
+	cells := make(map[[2]string]bool)
+	for _, rect := range doc.Rects[1:] { // the first rect is the background
+		cells[[2]string{rect.X, rect.Y}] = true
+	}
+	mirror := map[string]string{"5": "45", "15": "35", "25": "25", "35": "15", "45": "5"}
+	for cell := range cells {
+		if !cells[[2]string{mirror[cell[0]], cell[1]}] {
+			t.Errorf("Cell %v has no mirrored cell", cell)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package linkcheck
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/cache"
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+// UserAgent identifies the link checker to remote servers and robots.txt
+const UserAgent = "TodoLinkChecker/1.0"
+
+// maxRobotsSize limits how much of a robots.txt file is read
+const maxRobotsSize = 512 * 1024
+
+// Config holds the configuration for the HTTP link prober
+type Config struct {
+	Timeout         time.Duration // Timeout for each request
+	HostInterval    time.Duration // Minimum interval between requests to the same host
+	AllowPrivateIPs bool          // Allow probing loopback/private addresses (tests only)
+	RobotsTTL       time.Duration // How long the robots.txt rules of a host are reused
+	MaxHosts        int           // Maximum number of hosts whose robots.txt and last request are remembered
+}
+
+// HTTPProber checks links over HTTP, respecting robots.txt and per-host request limits
+type HTTPProber struct {
+	config Config
+	client *http.Client
+
+	mu          sync.Mutex // serializes the read-modify-write of lastRequest
+	robots      *cache.LRU[*robotsRules]
+	lastRequest *cache.LRU[time.Time]
+}
+
+// NewHTTPProber creates a new HTTPProber
+func NewHTTPProber(config Config) *HTTPProber {
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	if config.RobotsTTL <= 0 {
+		config.RobotsTTL = 24 * time.Hour
+	}
+	if config.MaxHosts <= 0 {
+		config.MaxHosts = 1000
+	}
+
+	dialer := &net.Dialer{Timeout: config.Timeout}
+	if !config.AllowPrivateIPs {
+		// Block requests to internal addresses so user-provided links can't be used for SSRF
+		dialer.Control = rejectPrivateAddresses
+	}
+
+	client := &http.Client{
+		Timeout: config.Timeout,
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   config.Timeout,
+			ResponseHeaderTimeout: config.Timeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 5 {
+				return errors.New("too many redirects")
+			}
+			return nil
+		},
+	}
+
+	// Probes run one at a time, so a host's next slot is at most one interval ahead and its
+	// last request is no longer needed once that slot's interval has passed
+	lastRequest := cache.NewLRU[time.Time](config.MaxHosts, 2*config.HostInterval)
+
+	return &HTTPProber{
+		config:      config,
+		client:      client,
+		robots:      cache.NewLRU[*robotsRules](config.MaxHosts, config.RobotsTTL),
+		lastRequest: lastRequest,
+	}
+}
+
+// Probe checks a URL and returns the HTTP status code of the response
+func (p *HTTPProber) Probe(ctx context.Context, rawURL string) (int, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return 0, fmt.Errorf("invalid url")
+	}
+
+	rules := p.robotsFor(ctx, target)
+	if !rules.allowed(target.RequestURI()) {
+		return 0, usecases.ErrLinkDisallowed
+	}
+
+	statusCode, err := p.request(ctx, http.MethodHead, target)
+	if err != nil {
+		return 0, err
+	}
+
+	// Some servers don't implement HEAD; retry with GET before declaring the link broken
+	if statusCode == http.StatusMethodNotAllowed || statusCode == http.StatusNotImplemented {
+		return p.request(ctx, http.MethodGet, target)
+	}
+
+	return statusCode, nil
+}
+
+// request performs a single throttled request and discards the body
+func (p *HTTPProber) request(ctx context.Context, method string, target *url.URL) (int, error) {
+	if err := p.wait(ctx, target.Host); err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", UserAgent)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	return resp.StatusCode, nil
+}
+
+// wait blocks until the minimum interval for a host has elapsed
+func (p *HTTPProber) wait(ctx context.Context, host string) error {
+	p.mu.Lock()
+	last, _ := p.lastRequest.Get(host)
+	next := last.Add(p.config.HostInterval)
+	now := time.Now()
+	if next.Before(now) {
+		next = now
+	}
+	p.lastRequest.Set(host, next)
+	p.mu.Unlock()
+
+	delay := time.Until(next)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// robotsFor returns the cached robots.txt rules for the host of a URL, fetching them if needed
+func (p *HTTPProber) robotsFor(ctx context.Context, target *url.URL) *robotsRules {
+	key := target.Scheme + "://" + target.Host
+
+	if rules, ok := p.robots.Get(key); ok {
+		return rules
+	}
+
+	rules := &robotsRules{}
+	robotsURL := &url.URL{Scheme: target.Scheme, Host: target.Host, Path: "/robots.txt"}
+	if err := p.wait(ctx, target.Host); err == nil {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL.String(), nil)
+		if err == nil {
+			req.Header.Set("User-Agent", UserAgent)
+			if resp, err := p.client.Do(req); err == nil {
+				if resp.StatusCode == http.StatusOK {
+					rules = parseRobots(io.LimitReader(resp.Body, maxRobotsSize), UserAgent)
+				}
+				resp.Body.Close()
+			}
+		}
+	}
+
+	p.robots.Set(key, rules)
+
+	return rules
+}
+
+// robotsRules holds the Allow/Disallow path prefixes that apply to the link checker
+type robotsRules struct {
+	allow    []string
+	disallow []string
+}
+
+// allowed reports whether a path may be fetched, using the longest matching rule
+func (r *robotsRules) allowed(path string) bool {
+	longestAllow, longestDisallow := -1, -1
+	for _, prefix := range r.allow {
+		if strings.HasPrefix(path, prefix) && len(prefix) > longestAllow {
+			longestAllow = len(prefix)
+		}
+	}
+	for _, prefix := range r.disallow {
+		if strings.HasPrefix(path, prefix) && len(prefix) > longestDisallow {
+			longestDisallow = len(prefix)
+		}
+	}
+	return longestDisallow < 0 || longestAllow >= longestDisallow
+}
+
+// parseRobots parses a robots.txt file, keeping the group for our user agent or "*"
+func parseRobots(r io.Reader, userAgent string) *robotsRules {
+	agent := strings.ToLower(strings.SplitN(userAgent, "/", 2)[0])
+
+	specific := &robotsRules{}
+	wildcard := &robotsRules{}
+	var current []*robotsRules
+	inAgentLines := false
+	foundSpecific := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if !inAgentLines {
+				current = nil
+			}
+			inAgentLines = true
+			name := strings.ToLower(value)
+			if name == "*" {
+				current = append(current, wildcard)
+			} else if strings.Contains(agent, name) || strings.Contains(name, agent) {
+				current = append(current, specific)
+				foundSpecific = true
+			}
+		case "allow", "disallow":
+			inAgentLines = false
+			if value == "" {
+				continue
+			}
+			for _, rules := range current {
+				if key == "allow" {
+					rules.allow = append(rules.allow, value)
+				} else {
+					rules.disallow = append(rules.disallow, value)
+				}
+			}
+		default:
+			inAgentLines = false
+		}
+	}
+
+	if foundSpecific {
+		return specific
+	}
+	return wildcard
+}
+
+// rejectPrivateAddresses refuses connections to loopback, private and link-local addresses
+func rejectPrivateAddresses(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast() {
+		return fmt.Errorf("connection to non-public address %s refused", host)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/usecases"
+)
+
+func TestParseRobots(t *testing.T) {
+	robots := `
+# comment
+User-agent: Googlebot
+Disallow: /
+
+User-agent: *
+Disallow: /private
+Allow: /private/public
+
+User-agent: TodoLinkChecker
+Disallow: /nochecker
+`
+
+	tests := []struct {
+		name      string
+		userAgent string
+		path      string
+		want      bool
+	}{
+		{name: "specific group applies to our agent", userAgent: UserAgent, path: "/nochecker/page", want: false},
+		{name: "wildcard is ignored when a specific group exists", userAgent: UserAgent, path: "/private", want: true},
+		{name: "wildcard group disallow", userAgent: "OtherBot/1.0", path: "/private/doc", want: false},
+		{name: "longest allow wins", userAgent: "OtherBot/1.0", path: "/private/public/doc", want: true},
+		{name: "unlisted path is allowed", userAgent: "OtherBot/1.0", path: "/docs", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := parseRobots(strings.NewReader(robots), tt.userAgent)
+			if got := rules.allowed(tt.path); got != tt.want {
+				t.Errorf("allowed(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPProber_Probe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			w.Write([]byte("User-agent: *\nDisallow: /secret\n"))
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	prober := NewHTTPProber(Config{Timeout: 2 * time.Second, AllowPrivateIPs: true})
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantErr    error
+	}{
+		{name: "reachable link", path: "/ok", wantStatus: http.StatusOK},
+		{name: "missing page", path: "/missing", wantStatus: http.StatusNotFound},
+		{name: "falls back to GET when HEAD is not allowed", path: "/no-head", wantStatus: http.StatusOK},
+		{name: "respects robots.txt", path: "/secret/page", wantErr: usecases.ErrLinkDisallowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := prober.Probe(context.Background(), server.URL+tt.path)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Probe() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Probe() unexpected error = %v", err)
+			}
+			if status != tt.wantStatus {
+				t.Errorf("Probe() status = %d, want %d", status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestHTTPProber_RefetchesExpiredRobots(t *testing.T) {
+	var robotsFetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			robotsFetches.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	prober := NewHTTPProber(Config{Timeout: 2 * time.Second, AllowPrivateIPs: true, RobotsTTL: 50 * time.Millisecond})
+
+	for range 2 {
+		if _, err := prober.Probe(context.Background(), server.URL+"/ok"); err != nil {
+			t.Fatalf("Probe() unexpected error = %v", err)
+		}
+	}
+	if got := robotsFetches.Load(); got != 1 {
+		t.Errorf("robots.txt fetched %d times, want 1 while cached", got)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := prober.Probe(context.Background(), server.URL+"/ok"); err != nil {
+		t.Fatalf("Probe() unexpected error = %v", err)
+	}
+	if got := robotsFetches.Load(); got != 2 {
+		t.Errorf("robots.txt fetched %d times, want 2 once the cached rules expired", got)
+	}
+}
+
+func TestHTTPProber_RejectsPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	prober := NewHTTPProber(Config{Timeout: 2 * time.Second})
+
+	if _, err := prober.Probe(context.Background(), server.URL+"/ok"); err == nil {
+		t.Error("Probe() should refuse to connect to a loopback address")
+	}
+}
@@ -0,0 +1,216 @@
+// Package mail sends emails through an SMTP server.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidHeader is returned when an address or subject would break the message headers
+var ErrInvalidHeader = errors.New("invalid email header")
+
+// Config holds the configuration for the SMTP sender
+type Config struct {
+	Host     string
+	Port     int
+	Username string // Empty disables authentication
+	Password string
+	From     string        // Sender address, optionally with a display name
+	Timeout  time.Duration // Timeout for the whole conversation with the server
+}
+
+// SMTPSender sends emails through an SMTP server, upgrading the connection with
+// STARTTLS whenever the server offers it
+type SMTPSender struct {
+	config Config
+}
+
+// NewSMTPSender creates a new SMTPSender
+func NewSMTPSender(config Config) *SMTPSender {
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &SMTPSender{config: config}
+}
+
+// Send sends a plain text email to a single recipient
+func (s *SMTPSender) Send(ctx context.Context, to, subject, body string) error {
+	return s.send(ctx, to, func(from, rcpt *mail.Address) ([]byte, error) {
+		return buildMessage(from, rcpt, subject, body, time.Now())
+	})
+}
+
+// SendHTML sends an email with an HTML body to a single recipient, along with its plain
+// text version for clients that don't show HTML
+func (s *SMTPSender) SendHTML(ctx context.Context, to, subject, text, html string) error {
+	return s.send(ctx, to, func(from, rcpt *mail.Address) ([]byte, error) {
+		return buildAlternativeMessage(from, rcpt, subject, text, html, time.Now())
+	})
+}
+
+// send delivers the message built for the sender and recipient addresses
+func (s *SMTPSender) send(ctx context.Context, to string, build func(from, rcpt *mail.Address) ([]byte, error)) error {
+	from, err := parseAddress(s.config.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender: %w", err)
+	}
+	rcpt, err := parseAddress(to)
+	if err != nil {
+		return fmt.Errorf("invalid recipient: %w", err)
+	}
+
+	msg, err := build(from, rcpt)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.config.Host}); err != nil {
+			return fmt.Errorf("starttls failed: %w", err)
+		}
+	}
+
+	if s.config.Username != "" {
+		// PlainAuth refuses to send the password over an unencrypted connection, except to localhost
+		if err := client.Auth(smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)); err != nil {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(rcpt.Address); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// parseAddress parses an address, rejecting line breaks that could inject headers
+func parseAddress(raw string) (*mail.Address, error) {
+	if strings.ContainsAny(raw, "\r\n") {
+		return nil, ErrInvalidHeader
+	}
+	return mail.ParseAddress(raw)
+}
+
+// buildMessage builds a UTF-8 plain text message with a quoted-printable body
+func buildMessage(from, to *mail.Address, subject, body string, date time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeHeaders(&buf, from, to, subject, date); err != nil {
+		return nil, err
+	}
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	buf.WriteString("\r\n")
+
+	if err := writeQuotedPrintable(&buf, body); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// buildAlternativeMessage builds a multipart/alternative message with a plain text and an
+// HTML version of the body, both UTF-8 and quoted-printable. Clients show the last part
+// they support, so the HTML goes last.
+func buildAlternativeMessage(from, to *mail.Address, subject, text, html string, date time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeHeaders(&buf, from, to, subject, date); err != nil {
+		return nil, err
+	}
+
+	parts := multipart.NewWriter(&buf)
+	buf.WriteString("Content-Type: multipart/alternative; boundary=" + parts.Boundary() + "\r\n")
+	buf.WriteString("\r\n")
+
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", text},
+		{"text/html; charset=UTF-8", html},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeHeaders writes the headers shared by every message, rejecting subjects that would
+// break them
+func writeHeaders(buf *bytes.Buffer, from, to *mail.Address, subject string, date time.Time) error {
+	if strings.ContainsAny(subject, "\r\n") {
+		return ErrInvalidHeader
+	}
+
+	buf.WriteString("From: " + from.String() + "\r\n")
+	buf.WriteString("To: " + to.String() + "\r\n")
+	buf.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	buf.WriteString("Date: " + date.Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	return nil
+}
+
+// writeQuotedPrintable writes body quoted-printable encoded, with CRLF line endings
+func writeQuotedPrintable(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))); err != nil {
+		return err
+	}
+	return qp.Close()
+}
@@ -0,0 +1,169 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildMessage(t *testing.T) {
+	from := &mail.Address{Name: "Todo", Address: "todo@example.com"}
+	to := &mail.Address{Address: "ana@example.com"}
+	date := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	msg, err := buildMessage(from, to, "Lembrete: ação", "Olá, Ana!\nPrazo: hoje\n", date)
+	if err != nil {
+		t.Fatalf("buildMessage() error: %v", err)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(msg)))
+	if err != nil {
+		t.Fatalf("generated message is invalid: %v", err)
+	}
+	if got := parsed.Header.Get("Subject"); got != "=?utf-8?q?Lembrete:_a=C3=A7=C3=A3o?=" {
+		t.Errorf("Subject = %q", got)
+	}
+	if got := parsed.Header.Get("To"); got != "<ana@example.com>" {
+		t.Errorf("To = %q", got)
+	}
+	body, _ := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	if string(body) != "Olá, Ana!\r\nPrazo: hoje\r\n" {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestBuildMessage_RejectsHeaderInjection(t *testing.T) {
+	from := &mail.Address{Address: "todo@example.com"}
+	to := &mail.Address{Address: "ana@example.com"}
+
+	if _, err := buildMessage(from, to, "Oi\r\nBcc: x@example.com", "corpo", time.Now()); !errors.Is(err, ErrInvalidHeader) {
+		t.Errorf("buildMessage() error = %v, want ErrInvalidHeader", err)
+	}
+
+	sender := NewSMTPSender(Config{Host: "127.0.0.1", Port: 1, From: "todo@example.com"})
+	err := sender.Send(context.Background(), "ana@example.com\r\nBcc: x@example.com", "Oi", "corpo")
+	if !errors.Is(err, ErrInvalidHeader) {
+		t.Errorf("Send() error = %v, want ErrInvalidHeader", err)
+	}
+}
+
+func TestBuildAlternativeMessage(t *testing.T) {
+	from := &mail.Address{Name: "Todo", Address: "todo@example.com"}
+	to := &mail.Address{Address: "ana@example.com"}
+
+	msg, err := buildAlternativeMessage(from, to, "Resumo semanal", "Pendentes: 3\n", "<p>Pendentes: <b>3</b></p>", time.Now())
+	if err != nil {
+		t.Fatalf("buildAlternativeMessage() error: %v", err)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(msg)))
+	if err != nil {
+		t.Fatalf("generated message is invalid: %v", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, want multipart/alternative", parsed.Header.Get("Content-Type"))
+	}
+
+	want := []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", "Pendentes: 3\r\n"},
+		{"text/html; charset=UTF-8", "<p>Pendentes: <b>3</b></p>"},
+	}
+	parts := multipart.NewReader(parsed.Body, params["boundary"])
+	for _, w := range want {
+		part, err := parts.NextRawPart()
+		if err != nil {
+			t.Fatalf("missing %s part: %v", w.contentType, err)
+		}
+		body, _ := io.ReadAll(quotedprintable.NewReader(part))
+		if part.Header.Get("Content-Type") != w.contentType || string(body) != w.body {
+			t.Errorf("part = %q %q, want %q %q", part.Header.Get("Content-Type"), body, w.contentType, w.body)
+		}
+	}
+	if _, err := parts.NextPart(); err != io.EOF {
+		t.Errorf("expected two parts, got error %v after them", err)
+	}
+
+	if _, err := buildAlternativeMessage(from, to, "Oi\r\nBcc: x@example.com", "", "", time.Now()); !errors.Is(err, ErrInvalidHeader) {
+		t.Errorf("buildAlternativeMessage() error = %v, want ErrInvalidHeader", err)
+	}
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	received := make(chan []string, 1)
+	go serveFakeSMTP(listener, received)
+
+	addr := listener.Addr().(*net.TCPAddr)
+	sender := NewSMTPSender(Config{Host: "127.0.0.1", Port: addr.Port, From: "Todo <todo@example.com>", Timeout: 5 * time.Second})
+
+	if err := sender.Send(context.Background(), "ana@example.com", "Lembrete", "Olá!"); err != nil {
+		t.Fatalf("Send() error: %v", err)
+	}
+
+	commands := <-received
+	joined := strings.Join(commands, "\n")
+	for _, want := range []string{"MAIL FROM:<todo@example.com>", "RCPT TO:<ana@example.com>", "Subject: Lembrete"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("conversation lacks %q:\n%s", want, joined)
+		}
+	}
+}
+
+// serveFakeSMTP accepts one connection and answers every command with success,
+// sending the lines it received once the client quits
+func serveFakeSMTP(listener net.Listener, received chan<- []string) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var lines []string
+	r := bufio.NewReader(conn)
+	io.WriteString(conn, "220 localhost ESMTP\r\n")
+
+	inData := false
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			break
+		}
+		line = strings.TrimRight(line, "\r\n")
+		lines = append(lines, line)
+
+		switch {
+		case inData:
+			if line == "." {
+				inData = false
+				io.WriteString(conn, "250 OK\r\n")
+			}
+		case strings.HasPrefix(line, "EHLO"):
+			io.WriteString(conn, "250 localhost\r\n")
+		case line == "DATA":
+			inData = true
+			io.WriteString(conn, "354 go ahead\r\n")
+		case line == "QUIT":
+			io.WriteString(conn, "221 bye\r\n")
+			received <- lines
+			return
+		default:
+			io.WriteString(conn, "250 OK\r\n")
+		}
+	}
+	received <- lines
+}
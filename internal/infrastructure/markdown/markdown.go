@@ -0,0 +1,162 @@
+// Package markdown renders the small Markdown subset allowed in task descriptions.
+//
+// Input is never passed through as HTML: every piece of user text is escaped and only
+// a fixed set of tags is emitted (strong, em, code, pre, ul, ol, li, p, br and a with
+// http, https or mailto links), so the output is safe to embed in pages.
+package markdown
+
+import (
+	"html"
+	"html/template"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	orderedItemRegex = regexp.MustCompile(`^\d{1,9}[.)]\s+`)
+	linkRegex        = regexp.MustCompile(`\[([^\[\]]+)\]\(([^()\s]+)\)`)
+	boldRegex        = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	italicRegex      = regexp.MustCompile(`\*([^*\s][^*]*)\*|\b_([^_\s][^_]*)_\b`)
+)
+
+// Render converts Markdown to sanitized HTML
+func Render(src string) template.HTML {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+
+	var out strings.Builder
+	var paragraph []string
+	listTag := ""
+
+	flushParagraph := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+		out.WriteString("<p>")
+		for i, line := range paragraph {
+			if i > 0 {
+				out.WriteString("<br>")
+			}
+			out.WriteString(renderInline(line))
+		}
+		out.WriteString("</p>")
+		paragraph = nil
+	}
+	closeList := func() {
+		if listTag != "" {
+			out.WriteString("</" + listTag + ">")
+			listTag = ""
+		}
+	}
+	openList := func(tag string) {
+		if listTag != tag {
+			closeList()
+			out.WriteString("<" + tag + ">")
+			listTag = tag
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			flushParagraph()
+			closeList()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			out.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>")
+		case trimmed == "":
+			flushParagraph()
+			closeList()
+		case strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* ") || strings.HasPrefix(trimmed, "+ "):
+			flushParagraph()
+			openList("ul")
+			out.WriteString("<li>" + renderInline(strings.TrimSpace(trimmed[2:])) + "</li>")
+		case orderedItemRegex.MatchString(trimmed):
+			flushParagraph()
+			openList("ol")
+			out.WriteString("<li>" + renderInline(orderedItemRegex.ReplaceAllString(trimmed, "")) + "</li>")
+		default:
+			closeList()
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flushParagraph()
+	closeList()
+
+	return template.HTML(out.String())
+}
+
+// renderInline renders code spans, links and emphasis of a single line
+func renderInline(text string) string {
+	var out strings.Builder
+
+	// Code spans are taken verbatim, so split them out before any other formatting
+	for {
+		start := strings.Index(text, "`")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(text[start+1:], "`")
+		if end < 0 {
+			break
+		}
+		out.WriteString(renderLinks(text[:start]))
+		out.WriteString("<code>" + html.EscapeString(text[start+1:start+1+end]) + "</code>")
+		text = text[start+1+end+1:]
+	}
+	out.WriteString(renderLinks(text))
+
+	return out.String()
+}
+
+// renderLinks renders [text](url) links with safe schemes; other text is escaped and emphasized
+func renderLinks(text string) string {
+	var out strings.Builder
+
+	last := 0
+	for _, m := range linkRegex.FindAllStringSubmatchIndex(text, -1) {
+		out.WriteString(renderEmphasis(text[last:m[0]]))
+		label, href := text[m[2]:m[3]], text[m[4]:m[5]]
+
+		if isSafeURL(href) {
+			out.WriteString(`<a href="` + html.EscapeString(href) + `" rel="nofollow noopener noreferrer" target="_blank">`)
+			out.WriteString(renderEmphasis(label))
+			out.WriteString("</a>")
+		} else {
+			out.WriteString(renderEmphasis(text[m[0]:m[1]]))
+		}
+		last = m[1]
+	}
+	out.WriteString(renderEmphasis(text[last:]))
+
+	return out.String()
+}
+
+// renderEmphasis escapes text and applies bold and italic markers
+func renderEmphasis(text string) string {
+	escaped := html.EscapeString(text)
+	escaped = boldRegex.ReplaceAllString(escaped, "<strong>$1$2</strong>")
+	escaped = italicRegex.ReplaceAllString(escaped, "<em>$1$2</em>")
+	return escaped
+}
+
+// isSafeURL allows only absolute http(s) and mailto links
+func isSafeURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		return u.Host != ""
+	case "mailto":
+		return u.Opaque != ""
+	default:
+		return false
+	}
+}
@@ -0,0 +1,79 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain text", "Comprar pão", "<p>Comprar pão</p>"},
+		{"empty", "", ""},
+		{"bold", "um **importante** item", "<p>um <strong>importante</strong> item</p>"},
+		{"italic", "um *leve* item", "<p>um <em>leve</em> item</p>"},
+		{"underscore inside words is kept", "snake_case_name", "<p>snake_case_name</p>"},
+		{"inline code", "rode `go test ./...`", "<p>rode <code>go test ./...</code></p>"},
+		{"code is not formatted", "`**x**`", "<p><code>**x**</code></p>"},
+		{"line breaks", "linha 1\nlinha 2", "<p>linha 1<br>linha 2</p>"},
+		{"paragraphs", "um\n\ndois", "<p>um</p><p>dois</p>"},
+		{"unordered list", "- a\n- **b**", "<ul><li>a</li><li><strong>b</strong></li></ul>"},
+		{"ordered list", "1. a\n2. b", "<ol><li>a</li><li>b</li></ol>"},
+		{"text after list", "- a\nfim", "<ul><li>a</li></ul><p>fim</p>"},
+		{"code block", "```\nx := 1\n```", "<pre><code>x := 1</code></pre>"},
+		{
+			"link",
+			"veja [o site](https://example.com/a?b=1&c=2)",
+			`<p>veja <a href="https://example.com/a?b=1&amp;c=2" rel="nofollow noopener noreferrer" target="_blank">o site</a></p>`,
+		},
+		{
+			"mailto link",
+			"[fale conosco](mailto:a@example.com)",
+			`<p><a href="mailto:a@example.com" rel="nofollow noopener noreferrer" target="_blank">fale conosco</a></p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(Render(tt.input))
+			if got != tt.want {
+				t.Errorf("Render(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender_XSS(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		forbidden []string
+	}{
+		{"script tag", "<script>alert(1)</script>", []string{"<script"}},
+		{"img onerror", "<img src=x onerror=alert(1)>", []string{"<img"}},
+		{"javascript link", "[clique](javascript:alert(1))", []string{"<a", "href"}},
+		{"javascript link with case", "[clique](JaVaScRiPt:alert(1))", []string{"<a", "href"}},
+		{"data link", "[clique](data:text/html;base64,PHNjcmlwdD4=)", []string{"<a", "href"}},
+		{"relative link", "[clique](//evil.example.com)", []string{"<a", "href"}},
+		{"attribute breakout", `[x](https://example.com/"onmouseover="alert(1))`, []string{`"onmouseover`}},
+		{"html in link text", "[<b>x</b>](https://example.com)", []string{"<b>"}},
+		{"html in bold", "**<svg onload=alert(1)>**", []string{"<svg"}},
+		{"html in code", "`<script>`", []string{"<script"}},
+		{"html in code block", "```\n<script>alert(1)</script>\n```", []string{"<script"}},
+		{"html in list", "- <iframe src=x>", []string{"<iframe"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(Render(tt.input))
+			for _, f := range tt.forbidden {
+				if strings.Contains(got, f) {
+					t.Errorf("Render(%q) = %q, must not contain %q", tt.input, got, f)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+// Package passkey runs the WebAuthn registration and login ceremonies of passkeys.
+//
+// A ceremony has two steps: Begin creates a challenge, stored server-side under a random
+// session ID, and returns the options for navigator.credentials; Finish verifies the
+// authenticator response against that challenge. Sessions are single-use and expire with
+// the ceremony timeout.
+package passkey
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/cache"
+)
+
+// DefaultTimeout is how long the user has to complete a ceremony when none is configured
+const DefaultTimeout = 5 * time.Minute
+
+// Config holds the relying party settings of the WebAuthn ceremonies
+type Config struct {
+	RPID          string   // Domain the passkeys are bound to, without scheme or port
+	RPDisplayName string   // Name shown by the authenticator
+	RPOrigins     []string // Fully qualified origins allowed to run the ceremonies
+	Timeout       time.Duration
+}
+
+// Service runs the passkey ceremonies and stores the registered credentials
+type Service struct {
+	webAuthn    *webauthn.WebAuthn
+	userRepo    repository.UserRepository
+	credentials repository.CredentialRepository
+	sessions    *cache.TTL[*webauthn.SessionData]
+	now         func() time.Time
+}
+
+// NewService creates a new Service. It fails when the relying party settings are invalid.
+func NewService(config Config, userRepo repository.UserRepository, credentials repository.CredentialRepository) (*Service, error) {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+
+	timeout := webauthn.TimeoutConfig{Enforce: true, Timeout: config.Timeout, TimeoutUVD: config.Timeout}
+	webAuthn, err := webauthn.New(&webauthn.Config{
+		RPID:          config.RPID,
+		RPDisplayName: config.RPDisplayName,
+		RPOrigins:     config.RPOrigins,
+		Timeouts:      webauthn.TimeoutsConfig{Login: timeout, Registration: timeout},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		webAuthn:    webAuthn,
+		userRepo:    userRepo,
+		credentials: credentials,
+		sessions:    cache.NewTTL[*webauthn.SessionData](config.Timeout),
+		now:         time.Now,
+	}, nil
+}
+
+// BeginRegistration starts the registration of a new passkey for a user. It returns the
+// options for navigator.credentials.create and the ID of the ceremony session.
+func (s *Service) BeginRegistration(ctx context.Context, userID string) (any, string, error) {
+	user, err := s.loadUser(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Passkeys must be discoverable, so login can start without an email, and must verify
+	// the user (PIN or biometrics), since they replace the password
+	options, session, err := s.webAuthn.BeginRegistration(user,
+		webauthn.WithExclusions(user.descriptors()),
+		webauthn.WithAuthenticatorSelection(protocol.AuthenticatorSelection{
+			RequireResidentKey: protocol.ResidentKeyRequired(),
+			ResidentKey:        protocol.ResidentKeyRequirementRequired,
+			UserVerification:   protocol.VerificationRequired,
+		}),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sessionID, err := s.storeSession(session)
+	if err != nil {
+		return nil, "", err
+	}
+	return options, sessionID, nil
+}
+
+// FinishRegistration verifies the response of navigator.credentials.create and stores the
+// new passkey under the given name
+func (s *Service) FinishRegistration(ctx context.Context, userID, sessionID, name string, response io.Reader) (*application.Credential, error) {
+	session, ok := s.sessions.Take(sessionID)
+	if !ok {
+		return nil, application.ErrPasskeySessionExpired
+	}
+
+	user, err := s.loadUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBody(response)
+	if err != nil {
+		return nil, verificationError(err)
+	}
+
+	created, err := s.webAuthn.CreateCredential(user, *session, parsed)
+	if err != nil {
+		return nil, verificationError(err)
+	}
+
+	credential, err := application.NewCredential(uuid.New().String(), userID, name, created.ID, created.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	credential.AttestationType = created.AttestationType
+	credential.AAGUID = created.Authenticator.AAGUID
+	credential.SignCount = created.Authenticator.SignCount
+	credential.BackupEligible = created.Flags.BackupEligible
+	credential.BackupState = created.Flags.BackupState
+	for _, transport := range created.Transport {
+		credential.Transports = append(credential.Transports, string(transport))
+	}
+
+	if err := s.credentials.Create(ctx, credential); err != nil {
+		return nil, err
+	}
+	return credential, nil
+}
+
+// BeginLogin starts a passkey login. The browser lets the user pick any passkey registered
+// for this site, so no email is needed. It returns the options for navigator.credentials.get
+// and the ID of the ceremony session.
+func (s *Service) BeginLogin(ctx context.Context) (any, string, error) {
+	options, session, err := s.webAuthn.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+	if err != nil {
+		return nil, "", err
+	}
+
+	sessionID, err := s.storeSession(session)
+	if err != nil {
+		return nil, "", err
+	}
+	return options, sessionID, nil
+}
+
+// FinishLogin verifies the response of navigator.credentials.get and returns the user the
+// passkey belongs to
+func (s *Service) FinishLogin(ctx context.Context, sessionID string, response io.Reader) (*application.User, error) {
+	session, ok := s.sessions.Take(sessionID)
+	if !ok {
+		return nil, application.ErrPasskeySessionExpired
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBody(response)
+	if err != nil {
+		return nil, verificationError(err)
+	}
+
+	// The user handle is the user ID given at registration
+	var owner *webAuthnUser
+	lookup := func(rawID, userHandle []byte) (webauthn.User, error) {
+		user, err := s.loadUser(ctx, string(userHandle))
+		if err != nil {
+			return nil, err
+		}
+		owner = user
+		return user, nil
+	}
+
+	validated, err := s.webAuthn.ValidateDiscoverableLogin(lookup, *session, parsed)
+	if err != nil {
+		return nil, verificationError(err)
+	}
+
+	// A signature counter that didn't move forward means the private key may have been cloned
+	if validated.Authenticator.CloneWarning {
+		return nil, fmt.Errorf("%w: signature counter went backwards", application.ErrPasskeyVerificationFailed)
+	}
+
+	credential := owner.credential(validated.ID)
+	if credential == nil {
+		return nil, application.ErrPasskeyVerificationFailed
+	}
+	credential.RecordUse(validated.Authenticator.SignCount, validated.Flags.BackupState, s.now())
+	if err := s.credentials.UpdateUsage(ctx, credential); err != nil {
+		return nil, err
+	}
+
+	return owner.user, nil
+}
+
+// storeSession keeps the session of a ceremony under a new random ID
+func (s *Service) storeSession(session *webauthn.SessionData) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+
+	sessionID := base64.RawURLEncoding.EncodeToString(raw)
+	s.sessions.Set(sessionID, session)
+	return sessionID, nil
+}
+
+// loadUser loads a user with their passkeys
+func (s *Service) loadUser(ctx context.Context, userID string) (*webAuthnUser, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := s.credentials.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webAuthnUser{user: user, credentials: credentials}, nil
+}
+
+// verificationError marks errors reported by the WebAuthn protocol checks, keeping their details
+func verificationError(err error) error {
+	var protocolErr *protocol.Error
+	if errors.As(err, &protocolErr) {
+		return fmt.Errorf("%w: %s", application.ErrPasskeyVerificationFailed, strings.TrimSpace(protocolErr.Details+" "+protocolErr.DevInfo))
+	}
+	return err
+}
@@ -0,0 +1,320 @@
+package passkey
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/database"
+)
+
+const (
+	testRPID   = "localhost"
+	testOrigin = "http://localhost:8080"
+)
+
+// authenticator is a software authenticator holding a single ES256 passkey
+type authenticator struct {
+	t            *testing.T
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	userHandle   []byte
+	signCount    uint32
+}
+
+func newAuthenticator(t *testing.T) *authenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	return &authenticator{t: t, key: key, credentialID: []byte("test-credential-id")}
+}
+
+// create answers navigator.credentials.create options with a "none" attestation
+func (a *authenticator) create(options any) []byte {
+	var creation struct {
+		PublicKey struct {
+			Challenge string `json:"challenge"`
+			User      struct {
+				ID string `json:"id"`
+			} `json:"user"`
+		} `json:"publicKey"`
+	}
+	a.decode(options, &creation)
+
+	userHandle, err := base64.RawURLEncoding.DecodeString(creation.PublicKey.User.ID)
+	if err != nil {
+		a.t.Fatalf("Failed to decode user handle: %v", err)
+	}
+	a.userHandle = userHandle
+
+	publicKey, err := cbor.Marshal(map[int]any{
+		1: 2, 3: -7, -1: 1,
+		-2: a.key.PublicKey.X.FillBytes(make([]byte, 32)),
+		-3: a.key.PublicKey.Y.FillBytes(make([]byte, 32)),
+	})
+	if err != nil {
+		a.t.Fatalf("Failed to encode public key: %v", err)
+	}
+
+	authData := a.authData(0x45) // user present, user verified, attested credential data
+	authData = append(authData, make([]byte, 16)...)
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.credentialID)))
+	authData = append(authData, a.credentialID...)
+	authData = append(authData, publicKey...)
+
+	attestation, err := cbor.Marshal(map[string]any{"fmt": "none", "attStmt": map[string]any{}, "authData": authData})
+	if err != nil {
+		a.t.Fatalf("Failed to encode attestation: %v", err)
+	}
+
+	return a.response(map[string]any{
+		"clientDataJSON":    a.clientData("webauthn.create", creation.PublicKey.Challenge),
+		"attestationObject": b64(attestation),
+	})
+}
+
+// get answers navigator.credentials.get options with a signed assertion
+func (a *authenticator) get(options any) []byte {
+	var assertion struct {
+		PublicKey struct {
+			Challenge string `json:"challenge"`
+		} `json:"publicKey"`
+	}
+	a.decode(options, &assertion)
+
+	a.signCount++
+	authData := a.authData(0x05) // user present, user verified
+	clientData := a.clientData("webauthn.get", assertion.PublicKey.Challenge)
+	rawClientData, _ := base64.RawURLEncoding.DecodeString(clientData)
+	clientDataHash := sha256.Sum256(rawClientData)
+	digest := sha256.Sum256(append(authData, clientDataHash[:]...))
+
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		a.t.Fatalf("Failed to sign assertion: %v", err)
+	}
+
+	return a.response(map[string]any{
+		"clientDataJSON":    clientData,
+		"authenticatorData": b64(authData),
+		"signature":         b64(signature),
+		"userHandle":        b64(a.userHandle),
+	})
+}
+
+func (a *authenticator) authData(flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(testRPID))
+	data := append(rpIDHash[:], flags)
+	return binary.BigEndian.AppendUint32(data, a.signCount)
+}
+
+func (a *authenticator) clientData(ceremony, challenge string) string {
+	data, _ := json.Marshal(map[string]string{"type": ceremony, "challenge": challenge, "origin": testOrigin})
+	return b64(data)
+}
+
+func (a *authenticator) response(response map[string]any) []byte {
+	body, _ := json.Marshal(map[string]any{
+		"id":       b64(a.credentialID),
+		"rawId":    b64(a.credentialID),
+		"type":     "public-key",
+		"response": response,
+	})
+	return body
+}
+
+func (a *authenticator) decode(options any, v any) {
+	data, err := json.Marshal(options)
+	if err != nil {
+		a.t.Fatalf("Failed to encode options: %v", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		a.t.Fatalf("Failed to decode options: %v", err)
+	}
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func newTestService(t *testing.T) (*Service, *database.SQLiteCredentialRepository) {
+	db, err := database.NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	users := database.NewSQLiteUserRepository(db)
+	if err := users.Create(context.Background(), &application.User{ID: "u-ana", Name: "Ana", Email: "ana@example.com", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	credentials := database.NewSQLiteCredentialRepository(db)
+	service, err := NewService(Config{RPID: testRPID, RPDisplayName: "Todo", RPOrigins: []string{testOrigin}}, users, credentials)
+	if err != nil {
+		t.Fatalf("NewService() error: %v", err)
+	}
+	return service, credentials
+}
+
+func TestService_RegisterAndLogin(t *testing.T) {
+	service, credentials := newTestService(t)
+	ctx := context.Background()
+	device := newAuthenticator(t)
+
+	options, sessionID, err := service.BeginRegistration(ctx, "u-ana")
+	if err != nil {
+		t.Fatalf("BeginRegistration() error: %v", err)
+	}
+	credential, err := service.FinishRegistration(ctx, "u-ana", sessionID, "Laptop", bytes.NewReader(device.create(options)))
+	if err != nil {
+		t.Fatalf("FinishRegistration() error: %v", err)
+	}
+	if credential.Name != "Laptop" || !bytes.Equal(credential.CredentialID, device.credentialID) {
+		t.Errorf("FinishRegistration() = %+v", credential)
+	}
+	if string(device.userHandle) != "u-ana" {
+		t.Errorf("user handle = %q, want the user ID", device.userHandle)
+	}
+
+	for i := 0; i < 2; i++ {
+		options, sessionID, err = service.BeginLogin(ctx)
+		if err != nil {
+			t.Fatalf("BeginLogin() error: %v", err)
+		}
+		user, err := service.FinishLogin(ctx, sessionID, bytes.NewReader(device.get(options)))
+		if err != nil {
+			t.Fatalf("FinishLogin() error: %v", err)
+		}
+		if user.ID != "u-ana" {
+			t.Errorf("FinishLogin() user = %q, want u-ana", user.ID)
+		}
+	}
+
+	stored, err := credentials.FindByID(ctx, credential.ID)
+	if err != nil || stored == nil {
+		t.Fatalf("FindByID() = %v, %v", stored, err)
+	}
+	if stored.SignCount != 2 || stored.LastUsedAt == nil {
+		t.Errorf("stored credential after login = %+v, want sign count 2 and last use set", stored)
+	}
+}
+
+func TestService_RegistrationExcludesRegisteredPasskeys(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+	device := newAuthenticator(t)
+
+	options, sessionID, err := service.BeginRegistration(ctx, "u-ana")
+	if err != nil {
+		t.Fatalf("BeginRegistration() error: %v", err)
+	}
+	if _, err := service.FinishRegistration(ctx, "u-ana", sessionID, "", bytes.NewReader(device.create(options))); err != nil {
+		t.Fatalf("FinishRegistration() error: %v", err)
+	}
+
+	options, _, err = service.BeginRegistration(ctx, "u-ana")
+	if err != nil {
+		t.Fatalf("BeginRegistration() error: %v", err)
+	}
+	var creation struct {
+		PublicKey struct {
+			ExcludeCredentials []struct {
+				ID string `json:"id"`
+			} `json:"excludeCredentials"`
+			AuthenticatorSelection struct {
+				ResidentKey      string `json:"residentKey"`
+				UserVerification string `json:"userVerification"`
+			} `json:"authenticatorSelection"`
+		} `json:"publicKey"`
+	}
+	device.decode(options, &creation)
+
+	if len(creation.PublicKey.ExcludeCredentials) != 1 || creation.PublicKey.ExcludeCredentials[0].ID != b64(device.credentialID) {
+		t.Errorf("excludeCredentials = %+v, want the registered passkey", creation.PublicKey.ExcludeCredentials)
+	}
+	if creation.PublicKey.AuthenticatorSelection.ResidentKey != "required" || creation.PublicKey.AuthenticatorSelection.UserVerification != "required" {
+		t.Errorf("authenticatorSelection = %+v, want discoverable credentials with user verification", creation.PublicKey.AuthenticatorSelection)
+	}
+}
+
+func TestService_SessionsAreSingleUse(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+	device := newAuthenticator(t)
+
+	options, sessionID, err := service.BeginRegistration(ctx, "u-ana")
+	if err != nil {
+		t.Fatalf("BeginRegistration() error: %v", err)
+	}
+	response := device.create(options)
+	if _, err := service.FinishRegistration(ctx, "u-ana", sessionID, "", bytes.NewReader(response)); err != nil {
+		t.Fatalf("FinishRegistration() error: %v", err)
+	}
+
+	_, err = service.FinishRegistration(ctx, "u-ana", sessionID, "", bytes.NewReader(response))
+	if !errors.Is(err, application.ErrPasskeySessionExpired) {
+		t.Errorf("FinishRegistration() replay error = %v, want ErrPasskeySessionExpired", err)
+	}
+
+	_, err = service.FinishLogin(ctx, "unknown-session", bytes.NewReader(device.get(options)))
+	if !errors.Is(err, application.ErrPasskeySessionExpired) {
+		t.Errorf("FinishLogin() with unknown session error = %v, want ErrPasskeySessionExpired", err)
+	}
+}
+
+func TestService_RejectsRegistrationForAnotherUser(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+
+	options, sessionID, err := service.BeginRegistration(ctx, "u-ana")
+	if err != nil {
+		t.Fatalf("BeginRegistration() error: %v", err)
+	}
+
+	// A session started by one user can't be finished by another one
+	_, err = service.FinishRegistration(ctx, "u-bob", sessionID, "", bytes.NewReader(newAuthenticator(t).create(options)))
+	if err == nil {
+		t.Fatal("FinishRegistration() for another user expected error but got nil")
+	}
+}
+
+func TestService_RejectsTamperedAssertion(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+	device := newAuthenticator(t)
+
+	options, sessionID, err := service.BeginRegistration(ctx, "u-ana")
+	if err != nil {
+		t.Fatalf("BeginRegistration() error: %v", err)
+	}
+	if _, err := service.FinishRegistration(ctx, "u-ana", sessionID, "", bytes.NewReader(device.create(options))); err != nil {
+		t.Fatalf("FinishRegistration() error: %v", err)
+	}
+
+	// An assertion signed by another key for the same credential ID must not verify
+	options, sessionID, err = service.BeginLogin(ctx)
+	if err != nil {
+		t.Fatalf("BeginLogin() error: %v", err)
+	}
+	impostor := newAuthenticator(t)
+	impostor.userHandle = device.userHandle
+
+	_, err = service.FinishLogin(ctx, sessionID, bytes.NewReader(impostor.get(options)))
+	if !errors.Is(err, application.ErrPasskeyVerificationFailed) {
+		t.Errorf("FinishLogin() with a forged signature error = %v, want ErrPasskeyVerificationFailed", err)
+	}
+}
@@ -0,0 +1,82 @@
+package passkey
+
+import (
+	"bytes"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// webAuthnUser adapts a user and their passkeys to webauthn.User
+type webAuthnUser struct {
+	user        *application.User
+	credentials []*application.Credential
+}
+
+// WebAuthnID returns the user handle stored by the authenticator: the user ID, which is stable and
+// carries no personal data
+func (u *webAuthnUser) WebAuthnID() []byte {
+	return []byte(u.user.ID)
+}
+
+// WebAuthnName returns the account name shown by the authenticator
+func (u *webAuthnUser) WebAuthnName() string {
+	return u.user.Email
+}
+
+// WebAuthnDisplayName returns the display name shown by the authenticator
+func (u *webAuthnUser) WebAuthnDisplayName() string {
+	return u.user.Name
+}
+
+// WebAuthnIcon is deprecated by the specification and left empty
+func (u *webAuthnUser) WebAuthnIcon() string {
+	return ""
+}
+
+// WebAuthnCredentials returns the registered passkeys of the user
+func (u *webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	credentials := make([]webauthn.Credential, 0, len(u.credentials))
+	for _, c := range u.credentials {
+		transports := make([]protocol.AuthenticatorTransport, 0, len(c.Transports))
+		for _, transport := range c.Transports {
+			transports = append(transports, protocol.AuthenticatorTransport(transport))
+		}
+
+		credentials = append(credentials, webauthn.Credential{
+			ID:              c.CredentialID,
+			PublicKey:       c.PublicKey,
+			AttestationType: c.AttestationType,
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				BackupEligible: c.BackupEligible,
+				BackupState:    c.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    c.AAGUID,
+				SignCount: c.SignCount,
+			},
+		})
+	}
+	return credentials
+}
+
+// descriptors lists the registered passkeys, so the authenticator doesn't register one twice
+func (u *webAuthnUser) descriptors() []protocol.CredentialDescriptor {
+	var descriptors []protocol.CredentialDescriptor
+	for _, credential := range u.WebAuthnCredentials() {
+		descriptors = append(descriptors, credential.Descriptor())
+	}
+	return descriptors
+}
+
+// credential finds a registered passkey by the authenticator's credential ID
+func (u *webAuthnUser) credential(credentialID []byte) *application.Credential {
+	for _, c := range u.credentials {
+		if bytes.Equal(c.CredentialID, credentialID) {
+			return c
+		}
+	}
+	return nil
+}
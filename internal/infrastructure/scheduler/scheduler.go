@@ -0,0 +1,26 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Every runs fn periodically until ctx is cancelled. A run that is still in
+// progress when the next tick fires delays that tick instead of overlapping.
+func Every(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			start := time.Now()
+			if err := fn(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("job %s failed after %s: %v", name, time.Since(start).Round(time.Millisecond), err)
+			}
+		}
+	}
+}
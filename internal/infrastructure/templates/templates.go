@@ -0,0 +1,10 @@
+// Package templates embeds the page templates in the binary, so production servers don't
+// depend on the working directory to find them
+package templates
+
+import "embed"
+
+// FS holds the page templates, named by their file names
+//
+//go:embed *.html
+var FS embed.FS
@@ -0,0 +1,125 @@
+// Package textdiff compares two versions of a text word by word, for the task history.
+package textdiff
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Kind tells whether a piece of text is in both versions, only the old one or only the new one
+type Kind int
+
+const (
+	Equal Kind = iota
+	Deleted
+	Inserted
+)
+
+// Op is a piece of text of the comparison
+type Op struct {
+	Kind Kind
+	Text string
+}
+
+// maxCells bounds the table comparing the words of both versions. Texts with more words
+// than that are compared as a whole, the old text deleted and the new one inserted.
+const maxCells = 1 << 22
+
+// Words compares old and new word by word, whitespace included, and returns the pieces of
+// both in reading order, consecutive pieces of the same kind merged
+func Words(old, new string) []Op {
+	a, b := split(old), split(new)
+
+	// Words shared at the start and the end are equal whatever lies between them
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var ops []Op
+	ops = appendOp(ops, Equal, strings.Join(a[:prefix], ""))
+	ops = appendMiddle(ops, a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])
+	ops = appendOp(ops, Equal, strings.Join(a[len(a)-suffix:], ""))
+	return ops
+}
+
+// appendMiddle appends the comparison of the words between the shared start and end, from
+// their longest common subsequence
+func appendMiddle(ops []Op, a, b []string) []Op {
+	if len(a)*len(b) > maxCells {
+		ops = appendOp(ops, Deleted, strings.Join(a, ""))
+		return appendOp(ops, Inserted, strings.Join(b, ""))
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = appendOp(ops, Equal, a[i])
+			i, j = i+1, j+1
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = appendOp(ops, Deleted, a[i])
+			i++
+		default:
+			ops = appendOp(ops, Inserted, b[j])
+			j++
+		}
+	}
+	ops = appendOp(ops, Deleted, strings.Join(a[i:], ""))
+	return appendOp(ops, Inserted, strings.Join(b[j:], ""))
+}
+
+// appendOp appends a piece of text, merged into the last one when it has the same kind
+func appendOp(ops []Op, kind Kind, text string) []Op {
+	if text == "" {
+		return ops
+	}
+	if n := len(ops); n > 0 && ops[n-1].Kind == kind {
+		ops[n-1].Text += text
+		return ops
+	}
+	return append(ops, Op{Kind: kind, Text: text})
+}
+
+// split splits a text into words and the runs of whitespace between them
+func split(s string) []string {
+	var tokens []string
+	start := 0
+	for i, r := range s {
+		if i > start && unicode.IsSpace(r) != isSpaceAt(s, start) {
+			tokens = append(tokens, s[start:i])
+			start = i
+		}
+	}
+	if start < len(s) {
+		tokens = append(tokens, s[start:])
+	}
+	return tokens
+}
+
+// isSpaceAt reports whether the rune starting at byte i of s is whitespace
+func isSpaceAt(s string, i int) bool {
+	for _, r := range s[i:] {
+		return unicode.IsSpace(r)
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package textdiff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want []Op
+	}{
+		{"unchanged", "Enviar relatório", "Enviar relatório", []Op{{Equal, "Enviar relatório"}}},
+		{"both empty", "", "", nil},
+		{"added", "", "Enviar", []Op{{Inserted, "Enviar"}}},
+		{"removed", "Enviar", "", []Op{{Deleted, "Enviar"}}},
+		{"word replaced", "Enviar relatório mensal", "Enviar relatório anual", []Op{
+			{Equal, "Enviar relatório "}, {Deleted, "mensal"}, {Inserted, "anual"},
+		}},
+		{"word inserted", "Enviar relatório", "Enviar o relatório", []Op{
+			{Equal, "Enviar "}, {Inserted, "o "}, {Equal, "relatório"},
+		}},
+		{"word removed", "a b c d", "a c d", []Op{
+			{Equal, "a "}, {Deleted, "b "}, {Equal, "c d"},
+		}},
+		{"words reworded", "Enviar o relatório hoje", "Revisar o relatório amanhã", []Op{
+			{Deleted, "Enviar"}, {Inserted, "Revisar"}, {Equal, " o relatório "}, {Deleted, "hoje"}, {Inserted, "amanhã"},
+		}},
+		{"unicode and line breaks", "Revisão\n- item", "Revisão\n- item novo", []Op{
+			{Equal, "Revisão\n- item"}, {Inserted, " novo"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Words(tt.old, tt.new)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Words(%q, %q) = %v, want %v", tt.old, tt.new, got, tt.want)
+			}
+			if old, new := join(got, Inserted), join(got, Deleted); old != tt.old || new != tt.new {
+				t.Errorf("Words() rebuilds %q and %q, want %q and %q", old, new, tt.old, tt.new)
+			}
+		})
+	}
+}
+
+func TestWords_LongTexts(t *testing.T) {
+	old := strings.Repeat("a ", 3000) + "a"
+	new := strings.Repeat("b ", 3000) + "b"
+
+	got := Words(old, new)
+	if len(got) != 2 || got[0] != (Op{Deleted, old}) || got[1] != (Op{Inserted, new}) {
+		t.Errorf("Words() of long texts = %d ops, want the old text deleted and the new one inserted", len(got))
+	}
+}
+
+// join rebuilds one version from the comparison, leaving out the pieces of kind skip
+func join(ops []Op, skip Kind) string {
+	var b strings.Builder
+	for _, op := range ops {
+		if op.Kind != skip {
+			b.WriteString(op.Text)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,160 @@
+package tracing
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"unicode"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Connector opens connections of driver d to dsn whose queries are recorded as children of
+// the span in their context, through the standard tracer API. Queries without a span in their
+// context, like the schema migrations, cost one context lookup.
+func Connector(d driver.Driver, dsn, system string) driver.Connector {
+	return &connector{driver: d, dsn: dsn, system: system}
+}
+
+type connector struct {
+	driver driver.Driver
+	dsn    string
+	system string // db.system of the spans, e.g. "sqlite"
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedConn{Conn: conn, system: c.system}, nil
+}
+
+func (c *connector) Driver() driver.Driver { return c.driver }
+
+// startQuery starts the span of a query, named after its SQL verb
+func startQuery(ctx context.Context, system, operation, query string) (context.Context, trace.Span) {
+	name := "sql " + operation
+	verb := strings.TrimSpace(query)
+	if end := strings.IndexFunc(verb, unicode.IsSpace); end > 0 {
+		verb = verb[:end]
+	}
+	if verb != "" {
+		name = "sql " + strings.ToUpper(verb)
+	}
+	return StartChild(ctx, name, trace.SpanKindClient,
+		attribute.String("db.system", system),
+		attribute.String("db.operation", operation),
+		attribute.String("db.statement", query),
+	)
+}
+
+// endQuery ends the span of a query; driver.ErrSkip only asks database/sql to retry the query
+// another way, so it isn't a failure
+func endQuery(span trace.Span, err error) {
+	if err == driver.ErrSkip {
+		err = nil
+	}
+	End(span, err)
+}
+
+// tracedConn traces the queries of a connection. The driver must implement the context-aware
+// interfaces of database/sql/driver, as go-sqlite3 does.
+type tracedConn struct {
+	driver.Conn
+	system string
+}
+
+func (c *tracedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	ctx, span := startQuery(ctx, c.system, "prepare", query)
+	stmt, err := c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+	endQuery(span, err)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedStmt{Stmt: stmt, system: c.system, query: query}, nil
+}
+
+func (c *tracedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	ctx, span := startQuery(ctx, c.system, "exec", query)
+	result, err := c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+	endQuery(span, err)
+	return result, err
+}
+
+func (c *tracedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	ctx, span := startQuery(ctx, c.system, "query", query)
+	rows, err := c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+	if err != nil {
+		endQuery(span, err)
+		return nil, err
+	}
+	if !span.IsRecording() {
+		span.End()
+		return rows, nil
+	}
+	return &tracedRows{Rows: rows, span: span}, nil
+}
+
+func (c *tracedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+func (c *tracedConn) Ping(ctx context.Context) error {
+	return c.Conn.(driver.Pinger).Ping(ctx)
+}
+
+// tracedStmt traces the executions of a prepared statement
+type tracedStmt struct {
+	driver.Stmt
+	system string
+	query  string
+}
+
+func (s *tracedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	ctx, span := startQuery(ctx, s.system, "exec", s.query)
+	result, err := s.Stmt.(driver.StmtExecContext).ExecContext(ctx, args)
+	endQuery(span, err)
+	return result, err
+}
+
+func (s *tracedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	ctx, span := startQuery(ctx, s.system, "query", s.query)
+	rows, err := s.Stmt.(driver.StmtQueryContext).QueryContext(ctx, args)
+	if err != nil {
+		endQuery(span, err)
+		return nil, err
+	}
+	if !span.IsRecording() {
+		span.End()
+		return rows, nil
+	}
+	return &tracedRows{Rows: rows, span: span}, nil
+}
+
+// tracedRows ends the span of a query once its rows are closed: SQLite steps through the query
+// while the rows are read, so that's when the time is spent
+type tracedRows struct {
+	driver.Rows
+	span trace.Span
+	err  error
+}
+
+func (r *tracedRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	if err != nil && err != io.EOF {
+		r.err = err
+	}
+	return err
+}
+
+func (r *tracedRows) Close() error {
+	err := r.Rows.Close()
+	if r.err == nil {
+		r.err = err
+	}
+	End(r.span, r.err)
+	return err
+}
@@ -0,0 +1,106 @@
+// Package tracing records traces of the requests, use cases, background jobs and SQL queries
+// of the app with OpenTelemetry and exports them to a collector over OTLP/HTTP. Traces follow
+// the W3C Trace Context, so they continue the traces of callers sending a traceparent header.
+package tracing
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// ScopeName is the instrumentation scope of the spans of the app
+const ScopeName = "github.com/ia-edev-sindireceita/todo"
+
+// Propagator reads and writes the traceparent header of the W3C Trace Context
+var Propagator = propagation.TraceContext{}
+
+// Config holds the configuration of the tracer provider and its OTLP exporter. The exporter
+// also reads the standard OTEL_EXPORTER_OTLP_* variables the fields don't set, like
+// OTEL_EXPORTER_OTLP_HEADERS.
+type Config struct {
+	Endpoint    string        // Base URL of the OTLP/HTTP collector; spans are posted to /v1/traces
+	ServiceName string        // service.name of the exported spans
+	SampleRatio float64       // Share of the new traces recorded, above 0 and up to 1
+	Timeout     time.Duration // Timeout for each export
+	QueueSize   int           // Spans waiting for export before new ones are dropped
+	BatchSize   int           // Spans per export
+	Interval    time.Duration // How long spans wait for a batch to fill before being exported
+}
+
+// New creates a tracer provider exporting the spans to config.Endpoint in batches, from a
+// background goroutine, so a slow or unavailable collector never delays the traced requests.
+// Shutting the provider down exports the queued spans.
+func New(config Config) (*sdktrace.TracerProvider, error) {
+	if config.ServiceName == "" {
+		config.ServiceName = "todo"
+	}
+	if config.SampleRatio <= 0 || config.SampleRatio > 1 {
+		config.SampleRatio = 1
+	}
+
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpointURL(strings.TrimSuffix(config.Endpoint, "/") + "/v1/traces")}
+	if config.Timeout > 0 {
+		options = append(options, otlptracehttp.WithTimeout(config.Timeout))
+	}
+	exporter, err := otlptracehttp.New(context.Background(), options...)
+	if err != nil {
+		return nil, err
+	}
+
+	var batch []sdktrace.BatchSpanProcessorOption
+	if config.QueueSize > 0 {
+		batch = append(batch, sdktrace.WithMaxQueueSize(config.QueueSize))
+	}
+	if config.BatchSize > 0 {
+		batch = append(batch, sdktrace.WithMaxExportBatchSize(config.BatchSize))
+	}
+	if config.Interval > 0 {
+		batch = append(batch, sdktrace.WithBatchTimeout(config.Interval))
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter, batch...),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(config.ServiceName))),
+	), nil
+}
+
+// End ends a span, marking it failed when err isn't nil
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// StartChild starts a span, child of the span in ctx, with the tracer provider of that span.
+// It returns ctx and a span recording nothing when ctx carries no span, so code deep in the
+// call stack, like SQL queries, is only traced as part of a traced request or job.
+func StartChild(ctx context.Context, name string, kind trace.SpanKind, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	parent := trace.SpanFromContext(ctx)
+	if !parent.SpanContext().IsValid() {
+		return ctx, noop.Span{}
+	}
+	return parent.TracerProvider().Tracer(ScopeName).Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attributes...))
+}
+
+// UseCases records the execution of use cases as children of the span in their context;
+// see usecases.SetTracer
+type UseCases struct{}
+
+// Start starts the span of a use case, returning the function ending it
+func (UseCases) Start(ctx context.Context, name string) (context.Context, func(err error)) {
+	ctx, span := StartChild(ctx, name, trace.SpanKindInternal)
+	return ctx, func(err error) { End(span, err) }
+}
@@ -0,0 +1,180 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// collector is an OTLP/HTTP endpoint keeping the requests it receives
+type collector struct {
+	mu       sync.Mutex
+	requests []*collectortrace.ExportTraceServiceRequest
+	apiKeys  []string
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v1/traces" {
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	req := &collectortrace.ExportTraceServiceRequest{}
+	if err := proto.Unmarshal(body, req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, req)
+	c.apiKeys = append(c.apiKeys, r.Header.Get("X-Api-Key"))
+}
+
+func TestNew_ExportsSpans(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "x-api-key=secret")
+	c := &collector{}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	provider, err := New(Config{Endpoint: server.URL + "/", ServiceName: "todo-test", Interval: time.Hour})
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	ctx, root := provider.Tracer(ScopeName).Start(context.Background(), "GET /api/tasks")
+	_, child := StartChild(ctx, "ListTasks", trace.SpanKindInternal)
+	End(child, errors.New("disk full"))
+	End(root, nil)
+	if err := provider.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error: %v", err)
+	}
+
+	if len(c.requests) != 1 || c.apiKeys[0] != "secret" {
+		t.Fatalf("got %d exports with api keys %v, want 1 with the key of OTEL_EXPORTER_OTLP_HEADERS", len(c.requests), c.apiKeys)
+	}
+	rs := c.requests[0].ResourceSpans[0]
+	if service := rs.Resource.Attributes[0]; service.Key != "service.name" || service.Value.GetStringValue() != "todo-test" {
+		t.Errorf("resource attribute = %v, want service.name todo-test", service)
+	}
+	spans := rs.ScopeSpans[0].Spans
+	if len(spans) != 2 || spans[0].Name != "ListTasks" || spans[0].Status.Message != "disk full" {
+		t.Errorf("exported spans = %v, want the failed child and its root", spans)
+	}
+}
+
+// newTestProvider returns a tracer provider keeping its spans in memory
+func newTestProvider() (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	return sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)), exporter
+}
+
+// byName returns the ended span named name
+func byName(t *testing.T, exporter *tracetest.InMemoryExporter, name string) tracetest.SpanStub {
+	t.Helper()
+	spans := exporter.GetSpans()
+	for _, span := range spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	t.Fatalf("no span %q among %d spans", name, len(spans))
+	return tracetest.SpanStub{}
+}
+
+func TestUseCases_Start(t *testing.T) {
+	provider, exporter := newTestProvider()
+	ctx, root := provider.Tracer(ScopeName).Start(context.Background(), "POST /api/tasks")
+
+	_, end := UseCases{}.Start(ctx, "CreateTask")
+	end(errors.New("task title cannot be empty"))
+	root.End()
+
+	span := byName(t, exporter, "CreateTask")
+	if span.Parent.SpanID() != root.SpanContext().SpanID() || span.SpanKind != trace.SpanKindInternal {
+		t.Errorf("use case span = %+v, want an internal span under the request", span)
+	}
+	if span.Status.Code != codes.Error || span.Status.Description != "task title cannot be empty" {
+		t.Errorf("use case status = %+v, want the error", span.Status)
+	}
+}
+
+func TestStartChild_WithoutSpan(t *testing.T) {
+	ctx := context.Background()
+	got, span := StartChild(ctx, "sql SELECT", trace.SpanKindClient)
+	if got != ctx || span.IsRecording() {
+		t.Fatalf("StartChild() = %v, %v; want ctx and a span recording nothing", got, span)
+	}
+	End(span, nil)
+}
+
+func TestConnector_TracesQueries(t *testing.T) {
+	provider, exporter := newTestProvider()
+	db := sql.OpenDB(Connector(&sqlite3.SQLiteDriver{}, filepath.Join(t.TempDir(), "test.db"), "sqlite"))
+	defer db.Close()
+
+	// Not traced: no span in the context
+	if _, err := db.Exec("CREATE TABLE tasks (id TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	stmt, err := db.Prepare("INSERT INTO tasks (id) VALUES (?)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	ctx, root := provider.Tracer(ScopeName).Start(context.Background(), "POST /api/tasks", trace.WithSpanKind(trace.SpanKindServer))
+	if _, err := stmt.ExecContext(ctx, "t-1"); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := db.QueryContext(ctx, "SELECT id\n  FROM tasks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for rows.Next() {
+	}
+	rows.Close()
+	if _, err := db.ExecContext(ctx, "INSERT INTO missing VALUES (1)"); err == nil {
+		t.Fatal("expected an error inserting into a missing table")
+	}
+	root.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 4 {
+		t.Fatalf("got %d spans, want the request and its 3 queries", len(spans))
+	}
+	for _, span := range spans {
+		if span.Name != "POST /api/tasks" && (span.Parent.SpanID() != root.SpanContext().SpanID() || span.SpanKind != trace.SpanKindClient) {
+			t.Errorf("query span %+v isn't a client span under the request", span)
+		}
+	}
+	var inserts []tracetest.SpanStub
+	for _, span := range spans {
+		if span.Name == "sql INSERT" {
+			inserts = append(inserts, span)
+		}
+	}
+	if len(inserts) != 2 || inserts[0].Status.Code == codes.Error || inserts[0].Attributes[2].Value.AsString() != "INSERT INTO tasks (id) VALUES (?)" {
+		t.Errorf("prepared insert span = %+v", inserts)
+	}
+	if inserts[1].Status.Code != codes.Error {
+		t.Errorf("insert into a missing table status = %+v, want an error", inserts[1].Status)
+	}
+	if byName(t, exporter, "sql SELECT").Status.Code == codes.Error {
+		t.Error("select span should not fail")
+	}
+}
@@ -0,0 +1,120 @@
+// Package ulid generates ULIDs, the IDs of tasks.
+//
+// A ULID is 26 characters of Crockford's base32: a 48-bit timestamp in milliseconds followed
+// by 80 random bits. IDs generated later sort after earlier ones as strings, including IDs
+// generated in the same millisecond by the same Generator, whose random part is incremented
+// instead of drawn again.
+package ulid
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Length is the length of a ULID string
+	Length = 26
+
+	// encoding is Crockford's base32 alphabet, which leaves out I, L, O and U
+	encoding = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+// ErrInvalid is returned by Time for a string that isn't a ULID
+var ErrInvalid = errors.New("invalid ULID")
+
+// Generator generates monotonic ULIDs; it is safe for concurrent use
+type Generator struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	entropy io.Reader
+	lastMs  uint64
+	last    [10]byte // Random part of the last ULID
+}
+
+// NewGenerator creates a Generator drawing its random bits from crypto/rand
+func NewGenerator() *Generator {
+	return &Generator{now: time.Now, entropy: rand.Reader}
+}
+
+// NewID returns a new ULID. The clock going backwards keeps the last timestamp, so IDs still
+// sort in generation order.
+func (g *Generator) NewID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := uint64(g.now().UnixMilli())
+	if ms > g.lastMs {
+		g.lastMs = ms
+		if _, err := io.ReadFull(g.entropy, g.last[:]); err != nil {
+			panic("ulid: reading random bits: " + err.Error())
+		}
+	} else if !increment(&g.last) {
+		// 2^80 IDs in one millisecond: borrow the next one
+		g.lastMs++
+	}
+
+	return encode(g.lastMs, g.last)
+}
+
+// increment adds one to the random part, reporting false when it overflows to zero
+func increment(random *[10]byte) bool {
+	for i := len(random) - 1; i >= 0; i-- {
+		random[i]++
+		if random[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// encode writes the timestamp in the first 10 characters and the random bits in the last 16
+func encode(ms uint64, random [10]byte) string {
+	var id [Length]byte
+	for i := 9; i >= 0; i-- {
+		id[i] = encoding[ms&0x1F]
+		ms >>= 5
+	}
+
+	// 80 bits are two groups of 40 bits, each 8 characters
+	for group := 0; group < 2; group++ {
+		var bits uint64
+		for _, b := range random[group*5 : group*5+5] {
+			bits = bits<<8 | uint64(b)
+		}
+		for i := 7; i >= 0; i-- {
+			id[10+group*8+i] = encoding[bits&0x1F]
+			bits >>= 5
+		}
+	}
+	return string(id[:])
+}
+
+// Time returns the time a ULID was generated, to the millisecond
+func Time(id string) (time.Time, error) {
+	if len(id) != Length {
+		return time.Time{}, ErrInvalid
+	}
+	// The first character holds only 3 of the 48 bits
+	if id[0] > '7' {
+		return time.Time{}, ErrInvalid
+	}
+
+	var ms int64
+	for _, c := range strings.ToUpper(id[:10]) {
+		i := strings.IndexRune(encoding, c)
+		if i < 0 {
+			return time.Time{}, ErrInvalid
+		}
+		ms = ms<<5 | int64(i)
+	}
+	for _, c := range strings.ToUpper(id[10:]) {
+		if !strings.ContainsRune(encoding, c) {
+			return time.Time{}, ErrInvalid
+		}
+	}
+	return time.UnixMilli(ms), nil
+}
@@ -0,0 +1,110 @@
+package ulid
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+	"time"
+)
+
+// fixedGenerator returns a Generator whose clock is *now and whose random bits are entropy
+func fixedGenerator(now *time.Time, entropy []byte) *Generator {
+	return &Generator{
+		now:     func() time.Time { return *now },
+		entropy: bytes.NewReader(entropy),
+	}
+}
+
+func TestGenerator_NewID(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_000)
+	entropy := append(bytes.Repeat([]byte{0}, 10), bytes.Repeat([]byte{0xFF}, 10)...)
+	g := fixedGenerator(&now, entropy)
+
+	first := g.NewID()
+	second := g.NewID() // Same millisecond: random part incremented
+	now = now.Add(time.Millisecond)
+	third := g.NewID()
+
+	if len(first) != Length {
+		t.Fatalf("Expected %d characters, got %q", Length, first)
+	}
+	if first != "01HF7YAT000000000000000000" {
+		t.Errorf("Unexpected first ID %q", first)
+	}
+	if second != "01HF7YAT000000000000000001" {
+		t.Errorf("Expected the random part incremented, got %q", second)
+	}
+	if third != "01HF7YAT01ZZZZZZZZZZZZZZZZ" {
+		t.Errorf("Expected a new timestamp and random part, got %q", third)
+	}
+
+	// Random part overflowing: the timestamp is borrowed from the next millisecond
+	overflow := g.NewID()
+	if overflow != "01HF7YAT020000000000000000" {
+		t.Errorf("Expected the next millisecond on overflow, got %q", overflow)
+	}
+
+	// Clock going backwards keeps the order
+	now = now.Add(-time.Hour)
+	if back := g.NewID(); back <= overflow {
+		t.Errorf("Expected %q after %q", back, overflow)
+	}
+}
+
+func TestGenerator_Sortable(t *testing.T) {
+	g := NewGenerator()
+
+	ids := make([]string, 1000)
+	for i := range ids {
+		ids[i] = g.NewID()
+	}
+
+	if !sort.StringsAreSorted(ids) {
+		t.Error("Expected IDs sorted in generation order")
+	}
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			t.Fatalf("Duplicate ID %q", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "valid", id: "01HF7YAT000000000000000000", want: time.UnixMilli(1_700_000_000_000)},
+		{name: "lowercase", id: "01hf7yat01zzzzzzzzzzzzzzzz", want: time.UnixMilli(1_700_000_000_001)},
+		{name: "uuid", id: "3f2b5c1e-8a9d-4e6f-b7c8-1d2e3f4a5b6c", wantErr: true},
+		{name: "invalid character", id: "01HF7YAT00000000000000000U", wantErr: true},
+		{name: "timestamp overflow", id: "8ZZZZZZZZZ0000000000000000", wantErr: true},
+		{name: "empty", id: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Time(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Time() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("Time() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTime_RoundTrip(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+	g := fixedGenerator(&now, bytes.Repeat([]byte{0xAB}, 10))
+
+	got, err := Time(g.NewID())
+	if err != nil || !got.Equal(now) {
+		t.Errorf("Time() = %v, %v; want %v", got, err, now)
+	}
+}
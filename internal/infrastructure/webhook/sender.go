@@ -0,0 +1,153 @@
+// Package webhook delivers domain events to an external HTTP endpoint.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/tracing"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of the body, as "sha256=<hex>"
+const SignatureHeader = "X-Webhook-Signature"
+
+// ErrQueueFull is returned when an event is dropped because deliveries are backed up
+var ErrQueueFull = errors.New("webhook queue is full, event dropped")
+
+// Config holds the configuration for the webhook sender
+type Config struct {
+	URL       string        // Endpoint receiving the events
+	Secret    string        // Key used to sign the body; empty disables the signature
+	Timeout   time.Duration // Timeout for each delivery
+	QueueSize int           // Events waiting for delivery before new ones are dropped
+}
+
+// Payload is the JSON body posted for each event
+type Payload struct {
+	Event      string            `json:"event"`
+	OccurredAt time.Time         `json:"occurred_at"`
+	Data       event.DomainEvent `json:"data"`
+}
+
+// delivery is a serialized event waiting to be posted
+type delivery struct {
+	name  string
+	body  []byte
+	trace propagation.MapCarrier // Trace of the request that emitted the event, passed on to the endpoint
+}
+
+// Sender posts events to a webhook in a background goroutine, so slow or unavailable
+// endpoints never delay the requests that emitted the events
+type Sender struct {
+	config Config
+	client *http.Client
+	queue  chan delivery
+	done   sync.WaitGroup
+	once   sync.Once
+}
+
+// NewSender creates a new Sender and starts its delivery goroutine
+func NewSender(config Config) *Sender {
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = 100
+	}
+
+	s := &Sender{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+		queue:  make(chan delivery, config.QueueSize),
+	}
+
+	s.done.Add(1)
+	go s.run()
+
+	return s
+}
+
+// Handle is an event.Handler queueing the event for delivery
+func (s *Sender) Handle(ctx context.Context, e event.DomainEvent) error {
+	body, err := json.Marshal(Payload{Event: e.EventName(), OccurredAt: e.OccurredAt(), Data: e})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	d := delivery{name: e.EventName(), body: body, trace: propagation.MapCarrier{}}
+	tracing.Propagator.Inject(ctx, d.trace)
+
+	select {
+	case s.queue <- d:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close stops accepting events and waits for the queued ones to be delivered
+func (s *Sender) Close() {
+	s.once.Do(func() { close(s.queue) })
+	s.done.Wait()
+}
+
+// run delivers queued events one at a time
+func (s *Sender) run() {
+	defer s.done.Done()
+
+	for d := range s.queue {
+		if err := s.deliver(d); err != nil {
+			log.Printf("webhook %s: %v", d.name, err)
+		}
+	}
+}
+
+// deliver posts a single event
+func (s *Sender) deliver(d delivery) error {
+	req, err := http.NewRequest(http.MethodPost, s.config.URL, bytes.NewReader(d.body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", d.name)
+	if s.config.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(s.config.Secret, d.body))
+	}
+	for key, value := range d.trace {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("endpoint responded %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign returns the signature header value of body, so receivers can verify it
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+	"github.com/ia-edev-sindireceita/todo/internal/infrastructure/tracing"
+)
+
+func TestSender_DeliversSignedEvents(t *testing.T) {
+	var mu sync.Mutex
+	var received []map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if got := r.Header.Get(SignatureHeader); got != Sign("s3cret", body) {
+			t.Errorf("Unexpected signature %q", got)
+		}
+		if r.Header.Get("X-Webhook-Event") != event.TaskSharedName {
+			t.Errorf("Unexpected event header %q", r.Header.Get("X-Webhook-Event"))
+		}
+
+		var payload map[string]interface{}
+		json.Unmarshal(body, &payload)
+		mu.Lock()
+		received = append(received, payload)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	sender := NewSender(Config{URL: server.URL, Secret: "s3cret"})
+	task := &application.Task{ID: "task-1", Title: "Relatório", OwnerID: "user-1"}
+	if err := sender.Handle(context.Background(), event.TaskShared{TaskEvent: event.NewTaskEvent(task, "user-1"), SharedWithID: "user-2"}); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	sender.Close()
+
+	if len(received) != 1 {
+		t.Fatalf("Expected 1 delivery, got %d", len(received))
+	}
+	data := received[0]["data"].(map[string]interface{})
+	if received[0]["event"] != event.TaskSharedName || data["task_id"] != "task-1" || data["shared_with_id"] != "user-2" {
+		t.Errorf("Unexpected payload %v", received[0])
+	}
+}
+
+func TestSender_DropsEventsWhenQueueIsFull(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+
+	sender := NewSender(Config{URL: server.URL, QueueSize: 1})
+	task := &application.Task{ID: "task-1", OwnerID: "user-1"}
+	e := event.TaskCreated{TaskEvent: event.NewTaskEvent(task, "user-1")}
+
+	var dropped error
+	for i := 0; i < 5 && dropped == nil; i++ {
+		dropped = sender.Handle(context.Background(), e)
+	}
+	close(release)
+	sender.Close()
+
+	if !errors.Is(dropped, ErrQueueFull) {
+		t.Errorf("Expected ErrQueueFull once the queue is backed up, got %v", dropped)
+	}
+}
+
+func TestSender_PassesOnTheTrace(t *testing.T) {
+	traceParents := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParents <- r.Header.Get("traceparent")
+	}))
+	defer server.Close()
+
+	provider := sdktrace.NewTracerProvider()
+	defer provider.Shutdown(context.Background())
+	ctx, span := provider.Tracer(tracing.ScopeName).Start(context.Background(), "POST /api/tasks/{id}/share")
+
+	sender := NewSender(Config{URL: server.URL})
+	task := &application.Task{ID: "task-1", OwnerID: "user-1"}
+	sender.Handle(ctx, event.TaskShared{TaskEvent: event.NewTaskEvent(task, "user-1"), SharedWithID: "user-2"})
+	sender.Handle(context.Background(), event.TaskShared{TaskEvent: event.NewTaskEvent(task, "user-1"), SharedWithID: "user-3"})
+	sender.Close()
+
+	sc := span.SpanContext()
+	if got, want := <-traceParents, "00-"+sc.TraceID().String()+"-"+sc.SpanID().String()+"-01"; got != want {
+		t.Errorf("traceparent = %q, want the trace of the request %q", got, want)
+	}
+	if got := <-traceParents; got != "" {
+		t.Errorf("traceparent = %q, want none for an event emitted outside a trace", got)
+	}
+}
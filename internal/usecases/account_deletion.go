@@ -0,0 +1,254 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// RequestAccountDeletionUseCase handles users asking for their account to be deleted. The
+// account is only purged after a grace period, during which the user can still download their
+// data and change their mind.
+type RequestAccountDeletionUseCase struct {
+	userRepo     repository.UserRepository
+	deletionRepo repository.AccountDeletionRepository
+	authService  *service.AuthService
+	grace        time.Duration
+	now          func() time.Time
+}
+
+// NewRequestAccountDeletionUseCase creates a new RequestAccountDeletionUseCase purging accounts
+// grace after the request. Passwords are verified with the hasher of their algorithm; hasher
+// is only used for the hashes of its own algorithm.
+func NewRequestAccountDeletionUseCase(
+	userRepo repository.UserRepository,
+	deletionRepo repository.AccountDeletionRepository,
+	hasher service.PasswordHasher,
+	grace time.Duration,
+) *RequestAccountDeletionUseCase {
+	return &RequestAccountDeletionUseCase{
+		userRepo:     userRepo,
+		deletionRepo: deletionRepo,
+		authService:  service.NewAuthServiceWithKeys(nil, hasher),
+		grace:        grace,
+		now:          time.Now,
+	}
+}
+
+// Execute schedules the deletion of the account of userID once the user confirmed it with their
+// password. Asking again returns the deletion already scheduled, without postponing it.
+func (uc *RequestAccountDeletionUseCase) Execute(ctx context.Context, userID, password string) (*application.AccountDeletion, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.authService.VerifyPassword(user.PasswordHash, password); err != nil {
+		return nil, ErrCurrentPasswordMismatch
+	}
+
+	deletion, err := application.NewAccountDeletion(userID, uc.now(), uc.grace)
+	if err != nil {
+		return nil, err
+	}
+	created, err := uc.deletionRepo.Create(ctx, deletion)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return uc.deletionRepo.FindByUserID(ctx, userID)
+	}
+	return deletion, nil
+}
+
+// GetAccountDeletionUseCase handles checking whether an account is scheduled for deletion
+type GetAccountDeletionUseCase struct {
+	deletionRepo repository.AccountDeletionRepository
+}
+
+// NewGetAccountDeletionUseCase creates a new GetAccountDeletionUseCase
+func NewGetAccountDeletionUseCase(deletionRepo repository.AccountDeletionRepository) *GetAccountDeletionUseCase {
+	return &GetAccountDeletionUseCase{deletionRepo: deletionRepo}
+}
+
+// Execute returns the scheduled deletion of the account of userID, or
+// application.ErrAccountDeletionNotFound
+func (uc *GetAccountDeletionUseCase) Execute(ctx context.Context, userID string) (*application.AccountDeletion, error) {
+	return uc.deletionRepo.FindByUserID(ctx, userID)
+}
+
+// CancelAccountDeletionUseCase handles users keeping their account during the grace period
+type CancelAccountDeletionUseCase struct {
+	deletionRepo repository.AccountDeletionRepository
+}
+
+// NewCancelAccountDeletionUseCase creates a new CancelAccountDeletionUseCase
+func NewCancelAccountDeletionUseCase(deletionRepo repository.AccountDeletionRepository) *CancelAccountDeletionUseCase {
+	return &CancelAccountDeletionUseCase{deletionRepo: deletionRepo}
+}
+
+// Execute cancels the scheduled deletion of the account of userID
+func (uc *CancelAccountDeletionUseCase) Execute(ctx context.Context, userID string) error {
+	deleted, err := uc.deletionRepo.Delete(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return application.ErrAccountDeletionNotFound
+	}
+	return nil
+}
+
+// AccountData is everything a user can download about their account
+type AccountData struct {
+	User  *application.User
+	Tasks []*application.Task // Owned by the user; the tasks shared with them belong to others
+}
+
+// ExportAccountDataUseCase handles users downloading their data, before deleting their account
+// or at any other time
+type ExportAccountDataUseCase struct {
+	userRepo repository.UserRepository
+	taskRepo repository.TaskRepository
+}
+
+// NewExportAccountDataUseCase creates a new ExportAccountDataUseCase
+func NewExportAccountDataUseCase(userRepo repository.UserRepository, taskRepo repository.TaskRepository) *ExportAccountDataUseCase {
+	return &ExportAccountDataUseCase{
+		userRepo: userRepo,
+		taskRepo: taskRepo,
+	}
+}
+
+// Execute returns the account of userID with the tasks they own in every workspace, whichever
+// the request is scoped to, as deleting the account deletes them all
+func (uc *ExportAccountDataUseCase) Execute(ctx context.Context, userID string) (_ *AccountData, err error) {
+	ctx, end := tracer.Start(ctx, "ExportAccountData")
+	defer func() { end(err) }()
+
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := uc.taskRepo.FindByOwnerID(repository.WithoutOrgScope(ctx), userID)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountData{User: user, Tasks: tasks}, nil
+}
+
+// PurgeAccountsUseCase deletes the accounts whose grace period is over. It is run
+// periodically by a background job.
+type PurgeAccountsUseCase struct {
+	deletionRepo repository.AccountDeletionRepository
+	userRepo     repository.UserRepository
+	taskRepo     repository.TaskRepository
+	deleteTask   *DeleteTaskUseCase
+	transactor   repository.Transactor
+	files        DeletedFileRemover
+	events       event.Publisher
+	batchSize    int
+	now          func() time.Time
+}
+
+// NewPurgeAccountsUseCase creates a new PurgeAccountsUseCase purging up to batchSize accounts
+// per run
+func NewPurgeAccountsUseCase(
+	deletionRepo repository.AccountDeletionRepository,
+	userRepo repository.UserRepository,
+	taskRepo repository.TaskRepository,
+	deleteTask *DeleteTaskUseCase,
+	transactor repository.Transactor,
+	files DeletedFileRemover,
+	events event.Publisher,
+	batchSize int,
+) *PurgeAccountsUseCase {
+	return &PurgeAccountsUseCase{
+		deletionRepo: deletionRepo,
+		userRepo:     userRepo,
+		taskRepo:     taskRepo,
+		deleteTask:   deleteTask,
+		transactor:   transactor,
+		files:        files,
+		events:       events,
+		batchSize:    batchSize,
+		now:          time.Now,
+	}
+}
+
+// Execute purges a batch of accounts and returns how many were purged. Each account is
+// deleted in its own transaction with its tasks, their shares and attachments, the shares it
+// received and the rest of its rows; an account whose deletion was cancelled meanwhile is
+// skipped. The uploaded files are removed once the transaction is committed.
+func (uc *PurgeAccountsUseCase) Execute(ctx context.Context) (_ int, err error) {
+	ctx, end := tracer.Start(ctx, "PurgeAccounts")
+	defer func() { end(err) }()
+
+	now := uc.now()
+	due, err := uc.deletionRepo.FindDue(ctx, now, uc.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, deletion := range due {
+		var user *application.User
+		var tasks []*application.Task
+		var files []*DeletedTaskFiles
+		err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			user, tasks, files = nil, nil, nil
+			current, err := uc.deletionRepo.FindByUserID(ctx, deletion.UserID)
+			if errors.Is(err, application.ErrAccountDeletionNotFound) {
+				return nil
+			}
+			if err != nil || current.PurgeAt.After(now) {
+				return err
+			}
+			user, err = uc.userRepo.FindByID(ctx, deletion.UserID)
+			if errors.Is(err, application.ErrUserNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			tasks, err = uc.taskRepo.FindByOwnerID(ctx, user.ID)
+			if err != nil {
+				return err
+			}
+			for _, task := range tasks {
+				taskFiles, err := uc.deleteTask.delete(ctx, task)
+				if err != nil {
+					return err
+				}
+				files = append(files, taskFiles)
+			}
+
+			// The remaining rows of the account, the deletion included, go with it (ON DELETE CASCADE)
+			return uc.userRepo.Delete(ctx, user.ID)
+		})
+		if err != nil {
+			return purged, err
+		}
+		if user == nil {
+			continue
+		}
+
+		purged++
+		for _, task := range tasks {
+			uc.events.Publish(ctx, event.TaskDeleted{TaskEvent: event.NewTaskEvent(task, user.ID)})
+		}
+		for _, taskFiles := range files {
+			uc.files.RemoveDeleted(ctx, taskFiles)
+		}
+		// The avatar is an uploaded image, kept with the task images
+		uc.files.RemoveDeleted(ctx, &DeletedTaskFiles{ImagePath: user.AvatarPath})
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,164 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// mockAccountDeletionRepository keeps scheduled deletions in memory
+type mockAccountDeletionRepository struct {
+	deletions map[string]*application.AccountDeletion
+}
+
+func newMockAccountDeletionRepository() *mockAccountDeletionRepository {
+	return &mockAccountDeletionRepository{deletions: map[string]*application.AccountDeletion{}}
+}
+
+func (m *mockAccountDeletionRepository) Create(ctx context.Context, deletion *application.AccountDeletion) (bool, error) {
+	if _, exists := m.deletions[deletion.UserID]; exists {
+		return false, nil
+	}
+	m.deletions[deletion.UserID] = deletion
+	return true, nil
+}
+
+func (m *mockAccountDeletionRepository) FindByUserID(ctx context.Context, userID string) (*application.AccountDeletion, error) {
+	deletion, ok := m.deletions[userID]
+	if !ok {
+		return nil, application.ErrAccountDeletionNotFound
+	}
+	return deletion, nil
+}
+
+func (m *mockAccountDeletionRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*application.AccountDeletion, error) {
+	var due []*application.AccountDeletion
+	for _, deletion := range m.deletions {
+		if !deletion.PurgeAt.After(now) && len(due) < limit {
+			due = append(due, deletion)
+		}
+	}
+	return due, nil
+}
+
+func (m *mockAccountDeletionRepository) Delete(ctx context.Context, userID string) (bool, error) {
+	_, exists := m.deletions[userID]
+	delete(m.deletions, userID)
+	return exists, nil
+}
+
+var deletionNow = time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+func TestRequestAccountDeletionUseCase_Execute(t *testing.T) {
+	hash, err := service.NewAuthServiceWithKeys(nil, nil).HashPassword("my-password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := newMockUserRepositoryForTheme(&application.User{ID: "user-1", PasswordHash: hash})
+	deletions := newMockAccountDeletionRepository()
+	uc := NewRequestAccountDeletionUseCase(users, deletions, nil, 7*24*time.Hour)
+	uc.now = func() time.Time { return deletionNow }
+
+	if _, err := uc.Execute(context.Background(), "user-1", "wrong"); !errors.Is(err, ErrCurrentPasswordMismatch) {
+		t.Fatalf("Execute() with a wrong password error = %v, want ErrCurrentPasswordMismatch", err)
+	}
+	if len(deletions.deletions) != 0 {
+		t.Fatal("expected nothing scheduled without the password")
+	}
+
+	deletion, err := uc.Execute(context.Background(), "user-1", "my-password")
+	if err != nil || !deletion.PurgeAt.Equal(deletionNow.Add(7*24*time.Hour)) {
+		t.Fatalf("Execute() = %+v, %v; want a purge in 7 days", deletion, err)
+	}
+
+	// Asking again doesn't postpone the purge
+	uc.now = func() time.Time { return deletionNow.Add(48 * time.Hour) }
+	again, err := uc.Execute(context.Background(), "user-1", "my-password")
+	if err != nil || !again.PurgeAt.Equal(deletion.PurgeAt) {
+		t.Errorf("Execute() again = %+v, %v; want the first deletion", again, err)
+	}
+
+	if _, err := uc.Execute(context.Background(), "missing", "my-password"); !errors.Is(err, application.ErrUserNotFound) {
+		t.Errorf("Execute() for an unknown user error = %v, want ErrUserNotFound", err)
+	}
+
+	cancel := NewCancelAccountDeletionUseCase(deletions)
+	if err := cancel.Execute(context.Background(), "user-1"); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if err := cancel.Execute(context.Background(), "user-1"); !errors.Is(err, application.ErrAccountDeletionNotFound) {
+		t.Errorf("Cancel() twice error = %v, want ErrAccountDeletionNotFound", err)
+	}
+	if _, err := NewGetAccountDeletionUseCase(deletions).Execute(context.Background(), "user-1"); !errors.Is(err, application.ErrAccountDeletionNotFound) {
+		t.Errorf("Get() after cancelling error = %v, want ErrAccountDeletionNotFound", err)
+	}
+}
+
+func TestPurgeAccountsUseCase_Execute(t *testing.T) {
+	task, _ := application.NewTask("task-1", "Relatório mensal", "", application.StatusPending, "user-1", "")
+	task.ImagePath = "/uploads/images/task.png"
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-2"}}}
+	taskService := service.NewTaskService(taskRepo, shareRepo)
+	other, _ := application.NewTask("task-2", "De outra pessoa", "", application.StatusPending, "user-2", "")
+	taskRepo.tasks["task-2"] = other
+	users := newMockUserRepositoryForTheme(
+		&application.User{ID: "user-1", AvatarPath: "/uploads/images/avatar.png"},
+		&application.User{ID: "user-2"},
+	)
+	deletions := newMockAccountDeletionRepository()
+	deletions.deletions["user-1"], _ = application.NewAccountDeletion("user-1", deletionNow, 24*time.Hour)
+
+	publisher := &recordingPublisher{}
+	files := &recordingFileRemover{}
+	purge := NewPurgeAccountsUseCase(deletions, users, taskRepo, newDeleteTaskUseCase(taskRepo, shareRepo, taskService, publisher), &mockTransactor{}, files, publisher, 10)
+
+	purge.now = func() time.Time { return deletionNow.Add(time.Hour) }
+	if purged, err := purge.Execute(context.Background()); err != nil || purged != 0 {
+		t.Fatalf("Execute() within the grace period = %d, %v; want nothing purged", purged, err)
+	}
+
+	purge.now = func() time.Time { return deletionNow.Add(24 * time.Hour) }
+	purged, err := purge.Execute(context.Background())
+	if err != nil || purged != 1 {
+		t.Fatalf("Execute() = %d, %v; want one account purged", purged, err)
+	}
+	if _, exists := users.users["user-1"]; exists {
+		t.Error("expected the account to be deleted")
+	}
+	if _, exists := taskRepo.tasks["task-1"]; exists {
+		t.Error("expected the tasks of the account to be deleted")
+	}
+	if _, exists := taskRepo.tasks["task-2"]; !exists {
+		t.Error("expected the tasks of other users to be kept")
+	}
+	if _, exists := shareRepo.shares["task-1"]; exists {
+		t.Error("expected the shares of the tasks to be deleted")
+	}
+	if len(files.removed) != 2 || files.removed[0].ImagePath != "/uploads/images/task.png" || files.removed[1].ImagePath != "/uploads/images/avatar.png" {
+		t.Errorf("expected the task image and the avatar to be removed, got %+v", files.removed)
+	}
+	if names := publisher.names(); len(names) != 1 || names[0] != event.TaskDeletedName {
+		t.Errorf("expected a single %s event, got %v", event.TaskDeletedName, names)
+	}
+}
+
+func TestExportAccountDataUseCase_Execute(t *testing.T) {
+	task, _ := application.NewTask("task-1", "Relatório mensal", "", application.StatusPending, "user-1", "")
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+	users := newMockUserRepositoryForTheme(&application.User{ID: "user-1", Name: "Ana"})
+	uc := NewExportAccountDataUseCase(users, taskRepo)
+
+	data, err := uc.Execute(context.Background(), "user-1")
+	if err != nil || data.User.Name != "Ana" || len(data.Tasks) != 1 || data.Tasks[0].ID != "task-1" {
+		t.Fatalf("Execute() = %+v, %v; want Ana with task-1", data, err)
+	}
+	if _, err := uc.Execute(context.Background(), "missing"); !errors.Is(err, application.ErrUserNotFound) {
+		t.Errorf("Execute() for an unknown user error = %v, want ErrUserNotFound", err)
+	}
+}
@@ -0,0 +1,291 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+const (
+	// AdminDashboardDays is how many days, today included, the tasks per day chart covers
+	AdminDashboardDays = 14
+
+	// AdminUserPageSize is how many users the admin dashboard lists at a time
+	AdminUserPageSize = 50
+)
+
+var (
+	// ErrAdminRequired is returned when a user who isn't an admin uses the admin dashboard
+	ErrAdminRequired = errors.New("admin access required")
+
+	// ErrCannotManageSelf is returned when admins disable or delete their own account, which
+	// could leave the server without an admin
+	ErrCannotManageSelf = errors.New("admins can't disable or delete their own account")
+)
+
+// RateLimitStats reports the requests refused by the rate limiters
+type RateLimitStats interface {
+	// Rejections returns the number of requests refused since the server started
+	Rejections() int64
+	// Evictions returns the number of clients evicted by full rate limiters since the server
+	// started, a sign of many client IPs, like in an IP rotation attack
+	Evictions() int64
+}
+
+// RequestErrorLog keeps the latest requests that failed on the server
+type RequestErrorLog interface {
+	// Recent returns the latest failed requests, newest first
+	Recent() []application.RequestError
+}
+
+// requireAdmin returns the user adminID if they are an admin, or ErrAdminRequired
+func requireAdmin(ctx context.Context, userRepo repository.UserRepository, adminID string) (*application.User, error) {
+	admin, err := userRepo.FindByID(ctx, adminID)
+	if errors.Is(err, application.ErrUserNotFound) {
+		return nil, ErrAdminRequired
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !admin.IsAdmin() {
+		return nil, ErrAdminRequired
+	}
+	return admin, nil
+}
+
+// GetAdminDashboardUseCase gathers the figures of the admin dashboard
+type GetAdminDashboardUseCase struct {
+	userRepo   repository.UserRepository
+	adminRepo  repository.AdminRepository
+	fileRepo   repository.StoredFileRepository
+	rateLimits RateLimitStats
+	errorLog   RequestErrorLog
+	now        func() time.Time
+}
+
+// NewGetAdminDashboardUseCase creates a new GetAdminDashboardUseCase. rateLimits and errorLog
+// are optional: without them the dashboard shows no rejections and no errors.
+func NewGetAdminDashboardUseCase(userRepo repository.UserRepository, adminRepo repository.AdminRepository, fileRepo repository.StoredFileRepository, rateLimits RateLimitStats, errorLog RequestErrorLog) *GetAdminDashboardUseCase {
+	return &GetAdminDashboardUseCase{
+		userRepo:   userRepo,
+		adminRepo:  adminRepo,
+		fileRepo:   fileRepo,
+		rateLimits: rateLimits,
+		errorLog:   errorLog,
+		now:        time.Now,
+	}
+}
+
+// Execute returns the dashboard, for admins only
+func (uc *GetAdminDashboardUseCase) Execute(ctx context.Context, adminID string) (*application.AdminDashboard, error) {
+	if _, err := requireAdmin(ctx, uc.userRepo, adminID); err != nil {
+		return nil, err
+	}
+
+	users, err := uc.adminRepo.CountUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	// Days without tasks are missing from the counts, and shown with zero
+	today := uc.now().UTC().Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, -(AdminDashboardDays - 1))
+	counts, err := uc.adminRepo.CountTasksCreatedSince(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tasks: %w", err)
+	}
+	tasksPerDay := make([]application.DailyCount, AdminDashboardDays)
+	for i := range tasksPerDay {
+		tasksPerDay[i].Day = since.AddDate(0, 0, i)
+	}
+	for _, count := range counts {
+		if i := int(count.Day.Sub(since).Hours() / 24); i >= 0 && i < len(tasksPerDay) {
+			tasksPerDay[i].Count = count.Count
+		}
+	}
+
+	storage, err := uc.fileRepo.TotalUsage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read storage usage: %w", err)
+	}
+
+	dashboard := &application.AdminDashboard{
+		Users:       users,
+		TasksPerDay: tasksPerDay,
+		Storage:     *storage,
+	}
+	if uc.rateLimits != nil {
+		dashboard.RateLimitRejections = uc.rateLimits.Rejections()
+		dashboard.RateLimitEvictions = uc.rateLimits.Evictions()
+	}
+	if uc.errorLog != nil {
+		dashboard.RecentErrors = uc.errorLog.Recent()
+	}
+	return dashboard, nil
+}
+
+// AdminUserPage is a page of the users listed on the admin dashboard
+type AdminUserPage struct {
+	Users      []*application.User
+	NextOffset int // zero on the last page
+}
+
+// ListUsersForAdminUseCase lists the users on the admin dashboard
+type ListUsersForAdminUseCase struct {
+	userRepo  repository.UserRepository
+	adminRepo repository.AdminRepository
+}
+
+// NewListUsersForAdminUseCase creates a new ListUsersForAdminUseCase
+func NewListUsersForAdminUseCase(userRepo repository.UserRepository, adminRepo repository.AdminRepository) *ListUsersForAdminUseCase {
+	return &ListUsersForAdminUseCase{
+		userRepo:  userRepo,
+		adminRepo: adminRepo,
+	}
+}
+
+// Execute returns up to AdminUserPageSize users, skipping the first offset, for admins only
+func (uc *ListUsersForAdminUseCase) Execute(ctx context.Context, adminID string, offset int) (*AdminUserPage, error) {
+	if _, err := requireAdmin(ctx, uc.userRepo, adminID); err != nil {
+		return nil, err
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	// One more user than the page tells whether there is a next page
+	users, err := uc.adminRepo.ListUsers(ctx, AdminUserPageSize+1, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	page := &AdminUserPage{Users: users}
+	if len(users) > AdminUserPageSize {
+		page.Users = users[:AdminUserPageSize]
+		page.NextOffset = offset + AdminUserPageSize
+	}
+	return page, nil
+}
+
+// SetUserDisabledUseCase disables or enables the account of a user
+type SetUserDisabledUseCase struct {
+	userRepo repository.UserRepository
+}
+
+// NewSetUserDisabledUseCase creates a new SetUserDisabledUseCase
+func NewSetUserDisabledUseCase(userRepo repository.UserRepository) *SetUserDisabledUseCase {
+	return &SetUserDisabledUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute disables or enables the account of userID, for admins only. Disabled users can't
+// log in, and the sessions they already have are revoked, so they stay out once enabled again.
+func (uc *SetUserDisabledUseCase) Execute(ctx context.Context, adminID, userID string, disabled bool) (*application.User, error) {
+	if _, err := requireAdmin(ctx, uc.userRepo, adminID); err != nil {
+		return nil, err
+	}
+	if userID == adminID {
+		return nil, ErrCannotManageSelf
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Disabled = disabled
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	if disabled {
+		if err := uc.userRepo.RevokeSessions(ctx, userID); err != nil {
+			return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+		}
+	}
+	return user, nil
+}
+
+// ResetUserPasswordUseCase replaces the password of a user with a temporary one
+type ResetUserPasswordUseCase struct {
+	userRepo    repository.UserRepository
+	authService *service.AuthService
+}
+
+// NewResetUserPasswordUseCase creates a new ResetUserPasswordUseCase hashing the temporary
+// passwords with hasher, or bcrypt at the default cost when nil
+func NewResetUserPasswordUseCase(userRepo repository.UserRepository, hasher service.PasswordHasher) *ResetUserPasswordUseCase {
+	return &ResetUserPasswordUseCase{
+		userRepo:    userRepo,
+		authService: service.NewAuthServiceWithKeys(nil, hasher),
+	}
+}
+
+// Execute sets a random password on the account of userID, for admins only, and returns it
+// so the admin can hand it to the user. It is never stored in clear. The sessions opened with
+// the old password are revoked.
+func (uc *ResetUserPasswordUseCase) Execute(ctx context.Context, adminID, userID string) (*application.User, string, error) {
+	if _, err := requireAdmin(ctx, uc.userRepo, adminID); err != nil {
+		return nil, "", err
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	password, err := service.GenerateTemporaryPassword()
+	if err != nil {
+		return nil, "", err
+	}
+	hash, err := uc.authService.HashPassword(password)
+	if err != nil {
+		return nil, "", err
+	}
+
+	user.PasswordHash = hash
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, "", fmt.Errorf("failed to update user: %w", err)
+	}
+	if err := uc.userRepo.RevokeSessions(ctx, userID); err != nil {
+		return nil, "", fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return user, password, nil
+}
+
+// DeleteUserUseCase deletes the account of a user with everything they own
+type DeleteUserUseCase struct {
+	userRepo repository.UserRepository
+}
+
+// NewDeleteUserUseCase creates a new DeleteUserUseCase
+func NewDeleteUserUseCase(userRepo repository.UserRepository) *DeleteUserUseCase {
+	return &DeleteUserUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute deletes the account of userID, for admins only. Their tasks, shares and the other
+// rows they own go with it; their uploaded files are left to the orphan cleanup. Their sessions
+// end with the account, as tokens of users who no longer exist are refused.
+func (uc *DeleteUserUseCase) Execute(ctx context.Context, adminID, userID string) error {
+	if _, err := requireAdmin(ctx, uc.userRepo, adminID); err != nil {
+		return err
+	}
+	if userID == adminID {
+		return ErrCannotManageSelf
+	}
+
+	if _, err := uc.userRepo.FindByID(ctx, userID); err != nil {
+		return err
+	}
+	if err := uc.userRepo.Delete(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	return nil
+}
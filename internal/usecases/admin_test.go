@@ -0,0 +1,295 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// mockUserRepositoryForAdmin finds no user with nil, nil like the SQLite repository
+type mockUserRepositoryForAdmin struct {
+	mockUserRepositoryForLogin
+}
+
+func (m *mockUserRepositoryForAdmin) FindByID(ctx context.Context, id string) (*application.User, error) {
+	if user, ok := m.users[id]; ok {
+		return user, nil
+	}
+	return nil, application.ErrUserNotFound
+}
+
+func newMockUserRepositoryForAdmin() *mockUserRepositoryForAdmin {
+	return &mockUserRepositoryForAdmin{mockUserRepositoryForLogin{users: map[string]*application.User{
+		"admin-1":  {ID: "admin-1", Email: "admin@example.com", Role: application.RoleAdmin},
+		"member-1": {ID: "member-1", Email: "member@example.com", Role: application.RoleMember, PasswordHash: "old"},
+	}}}
+}
+
+// mockAdminRepository returns fixed figures
+type mockAdminRepository struct {
+	users  []*application.User
+	counts []application.DailyCount
+	err    error
+	limit  int
+	offset int
+}
+
+func (m *mockAdminRepository) CountUsers(ctx context.Context) (int, error) {
+	return len(m.users), m.err
+}
+
+func (m *mockAdminRepository) ListUsers(ctx context.Context, limit, offset int) ([]*application.User, error) {
+	m.limit, m.offset = limit, offset
+	if offset >= len(m.users) {
+		return nil, m.err
+	}
+	end := min(offset+limit, len(m.users))
+	return m.users[offset:end], m.err
+}
+
+func (m *mockAdminRepository) CountTasksCreatedSince(ctx context.Context, since time.Time) ([]application.DailyCount, error) {
+	return m.counts, m.err
+}
+
+type fixedRateLimitStats int64
+
+func (s fixedRateLimitStats) Rejections() int64 { return int64(s) }
+
+func (s fixedRateLimitStats) Evictions() int64 { return int64(s) * 2 }
+
+type fixedRequestErrorLog []application.RequestError
+
+func (l fixedRequestErrorLog) Recent() []application.RequestError { return l }
+
+func TestGetAdminDashboardUseCase_Execute(t *testing.T) {
+	now := time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC)
+	today := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		adminID    string
+		repoErr    error
+		rateLimits RateLimitStats
+		errorLog   RequestErrorLog
+		wantErr    error
+	}{
+		{name: "admin", adminID: "admin-1", rateLimits: fixedRateLimitStats(7), errorLog: fixedRequestErrorLog{{Path: "/tasks", Status: 500}}},
+		{name: "without stats", adminID: "admin-1"},
+		{name: "member", adminID: "member-1", wantErr: ErrAdminRequired},
+		{name: "unknown user", adminID: "nobody", wantErr: ErrAdminRequired},
+		{name: "repository error", adminID: "admin-1", repoErr: errors.New("db down"), wantErr: errors.New("failed to count users: db down")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := newMockUserRepositoryForAdmin()
+			adminRepo := &mockAdminRepository{
+				users: []*application.User{userRepo.users["admin-1"], userRepo.users["member-1"]},
+				counts: []application.DailyCount{
+					{Day: today.AddDate(0, 0, -2), Count: 3},
+					{Day: today, Count: 5},
+				},
+				err: tt.repoErr,
+			}
+			fileRepo := newMockStoredFileRepository()
+			fileRepo.files["images/a.png"] = &application.StoredFile{UserID: "member-1", Size: 40}
+			uc := NewGetAdminDashboardUseCase(userRepo, adminRepo, fileRepo, tt.rateLimits, tt.errorLog)
+			uc.now = func() time.Time { return now }
+
+			dashboard, err := uc.Execute(context.Background(), tt.adminID)
+			if tt.wantErr != nil {
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+
+			if dashboard.Users != 2 {
+				t.Errorf("Expected 2 users, got %d", dashboard.Users)
+			}
+			if len(dashboard.TasksPerDay) != AdminDashboardDays {
+				t.Fatalf("Expected %d days, got %d", AdminDashboardDays, len(dashboard.TasksPerDay))
+			}
+			last := dashboard.TasksPerDay[AdminDashboardDays-1]
+			if !last.Day.Equal(today) || last.Count != 5 {
+				t.Errorf("Expected 5 tasks today, got %+v", last)
+			}
+			if got := dashboard.TasksPerDay[AdminDashboardDays-2].Count; got != 0 {
+				t.Errorf("Expected no tasks yesterday, got %d", got)
+			}
+			if got := dashboard.TasksPerDay[AdminDashboardDays-3].Count; got != 3 {
+				t.Errorf("Expected 3 tasks two days ago, got %d", got)
+			}
+			if dashboard.MaxTasksPerDay() != 5 {
+				t.Errorf("Expected a maximum of 5 tasks per day, got %d", dashboard.MaxTasksPerDay())
+			}
+			if dashboard.Storage.Bytes != 40 || dashboard.Storage.Files != 1 {
+				t.Errorf("Unexpected storage usage %+v", dashboard.Storage)
+			}
+
+			var wantRejections, wantEvictions int64
+			if tt.rateLimits != nil {
+				wantRejections = tt.rateLimits.Rejections()
+				wantEvictions = tt.rateLimits.Evictions()
+			}
+			if dashboard.RateLimitRejections != wantRejections {
+				t.Errorf("Expected %d rejections, got %d", wantRejections, dashboard.RateLimitRejections)
+			}
+			if dashboard.RateLimitEvictions != wantEvictions {
+				t.Errorf("Expected %d evictions, got %d", wantEvictions, dashboard.RateLimitEvictions)
+			}
+			wantErrors := 0
+			if tt.errorLog != nil {
+				wantErrors = len(tt.errorLog.Recent())
+			}
+			if len(dashboard.RecentErrors) != wantErrors {
+				t.Errorf("Expected %d recent errors, got %d", wantErrors, len(dashboard.RecentErrors))
+			}
+		})
+	}
+}
+
+func TestListUsersForAdminUseCase_Execute(t *testing.T) {
+	users := make([]*application.User, AdminUserPageSize+10)
+	for i := range users {
+		users[i] = &application.User{ID: fmt.Sprintf("user-%d", i)}
+	}
+
+	tests := []struct {
+		name           string
+		adminID        string
+		offset         int
+		wantUsers      int
+		wantNextOffset int
+		wantOffset     int
+		wantErr        error
+	}{
+		{name: "first page", adminID: "admin-1", wantUsers: AdminUserPageSize, wantNextOffset: AdminUserPageSize},
+		{name: "last page", adminID: "admin-1", offset: AdminUserPageSize, wantUsers: 10, wantOffset: AdminUserPageSize},
+		{name: "negative offset", adminID: "admin-1", offset: -5, wantUsers: AdminUserPageSize, wantNextOffset: AdminUserPageSize},
+		{name: "member", adminID: "member-1", wantErr: ErrAdminRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adminRepo := &mockAdminRepository{users: users}
+			uc := NewListUsersForAdminUseCase(newMockUserRepositoryForAdmin(), adminRepo)
+
+			page, err := uc.Execute(context.Background(), tt.adminID, tt.offset)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if len(page.Users) != tt.wantUsers {
+				t.Errorf("Expected %d users, got %d", tt.wantUsers, len(page.Users))
+			}
+			if page.NextOffset != tt.wantNextOffset {
+				t.Errorf("Expected next offset %d, got %d", tt.wantNextOffset, page.NextOffset)
+			}
+			if adminRepo.limit != AdminUserPageSize+1 || adminRepo.offset != tt.wantOffset {
+				t.Errorf("Expected limit %d and offset %d, got %d and %d", AdminUserPageSize+1, tt.wantOffset, adminRepo.limit, adminRepo.offset)
+			}
+		})
+	}
+}
+
+func TestAdminUserActions(t *testing.T) {
+	tests := []struct {
+		name    string
+		adminID string
+		userID  string
+		wantErr error
+	}{
+		{name: "admin on member", adminID: "admin-1", userID: "member-1"},
+		{name: "member on admin", adminID: "member-1", userID: "admin-1", wantErr: ErrAdminRequired},
+		{name: "unknown user", adminID: "admin-1", userID: "nobody", wantErr: application.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			t.Run("disable", func(t *testing.T) {
+				repo := newMockUserRepositoryForAdmin()
+				user, err := NewSetUserDisabledUseCase(repo).Execute(ctx, tt.adminID, tt.userID, true)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantErr == nil && (!user.Disabled || !repo.users[tt.userID].Disabled) {
+					t.Error("Expected the user to be disabled")
+				}
+				if tt.wantErr == nil && repo.users[tt.userID].TokenVersion != 1 {
+					t.Error("Expected the sessions of the user to be revoked")
+				}
+				if tt.wantErr != nil && (repo.users["member-1"].Disabled || repo.users["member-1"].TokenVersion != 0) {
+					t.Error("Expected the user to stay enabled")
+				}
+			})
+
+			t.Run("reset password", func(t *testing.T) {
+				repo := newMockUserRepositoryForAdmin()
+				_, password, err := NewResetUserPasswordUseCase(repo, nil).Execute(ctx, tt.adminID, tt.userID)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantErr != nil {
+					if repo.users["member-1"].PasswordHash != "old" || repo.users["member-1"].TokenVersion != 0 {
+						t.Error("Expected the password and sessions to stay unchanged")
+					}
+					return
+				}
+				if repo.users[tt.userID].TokenVersion != 1 {
+					t.Error("Expected the sessions opened with the old password to be revoked")
+				}
+				hash := repo.users[tt.userID].PasswordHash
+				if password == "" || hash == password {
+					t.Fatalf("Expected a hashed temporary password, got %q stored as %q", password, hash)
+				}
+				if err := service.NewAuthServiceWithKeys(nil, nil).VerifyPassword(hash, password); err != nil {
+					t.Errorf("Expected the temporary password to match its hash: %v", err)
+				}
+			})
+
+			t.Run("delete", func(t *testing.T) {
+				repo := newMockUserRepositoryForAdmin()
+				err := NewDeleteUserUseCase(repo).Execute(ctx, tt.adminID, tt.userID)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+				}
+				_, exists := repo.users[tt.userID]
+				if tt.wantErr == nil && exists {
+					t.Error("Expected the user to be deleted")
+				}
+				if len(repo.users) != 2 && tt.wantErr != nil {
+					t.Error("Expected no user to be deleted")
+				}
+			})
+		})
+	}
+}
+
+func TestAdminCannotManageSelf(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockUserRepositoryForAdmin()
+
+	if _, err := NewSetUserDisabledUseCase(repo).Execute(ctx, "admin-1", "admin-1", true); !errors.Is(err, ErrCannotManageSelf) {
+		t.Errorf("Expected ErrCannotManageSelf disabling self, got %v", err)
+	}
+	if err := NewDeleteUserUseCase(repo).Execute(ctx, "admin-1", "admin-1"); !errors.Is(err, ErrCannotManageSelf) {
+		t.Errorf("Expected ErrCannotManageSelf deleting self, got %v", err)
+	}
+	if repo.users["admin-1"] == nil || repo.users["admin-1"].Disabled {
+		t.Error("Expected the admin account to be untouched")
+	}
+}
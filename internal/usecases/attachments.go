@@ -0,0 +1,210 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// ErrAttachmentPermissionDenied is returned when a user who can see a task tries to change its attachments
+var ErrAttachmentPermissionDenied = errors.New("only the task owner can manage attachments")
+
+// checkAttachmentAccess checks that a user can see a task and, when modify is set, change its attachments
+func checkAttachmentAccess(ctx context.Context, taskRepo repository.TaskRepository, taskService TaskServiceInterface, taskID, userID string, modify bool) error {
+	err := checkTaskAccess(ctx, taskRepo, taskService, taskID, userID, modify)
+	if errors.Is(err, ErrTaskOwnerRequired) {
+		return ErrAttachmentPermissionDenied
+	}
+	return err
+}
+
+// findTaskAttachment finds an attachment and checks it belongs to the task
+func findTaskAttachment(ctx context.Context, attachmentRepo repository.AttachmentRepository, taskID, attachmentID string) (*application.Attachment, error) {
+	attachment, err := attachmentRepo.FindByID(ctx, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.TaskID != taskID {
+		return nil, application.ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+// AddAttachmentUseCase handles attaching a stored file to a task
+type AddAttachmentUseCase struct {
+	attachmentRepo repository.AttachmentRepository
+	taskRepo       repository.TaskRepository
+	taskService    TaskServiceInterface
+}
+
+// NewAddAttachmentUseCase creates a new AddAttachmentUseCase
+func NewAddAttachmentUseCase(
+	attachmentRepo repository.AttachmentRepository,
+	taskRepo repository.TaskRepository,
+	taskService TaskServiceInterface,
+) *AddAttachmentUseCase {
+	return &AddAttachmentUseCase{
+		attachmentRepo: attachmentRepo,
+		taskRepo:       taskRepo,
+		taskService:    taskService,
+	}
+}
+
+// Execute records a file already stored at path as an attachment of the task
+func (uc *AddAttachmentUseCase) Execute(ctx context.Context, taskID, userID, filename, mimeType string, size int64, path string) (*application.Attachment, error) {
+	if err := checkAttachmentAccess(ctx, uc.taskRepo, uc.taskService, taskID, userID, true); err != nil {
+		return nil, err
+	}
+
+	count, err := uc.attachmentRepo.CountByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= application.MaxAttachmentsPerTask {
+		return nil, application.ErrTooManyAttachments
+	}
+
+	attachment, err := application.NewAttachment(uuid.New().String(), taskID, filename, mimeType, size, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.attachmentRepo.Create(ctx, attachment); err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// ListAttachmentsUseCase handles listing the attachments of a task
+type ListAttachmentsUseCase struct {
+	attachmentRepo repository.AttachmentRepository
+	taskRepo       repository.TaskRepository
+	taskService    TaskServiceInterface
+}
+
+// NewListAttachmentsUseCase creates a new ListAttachmentsUseCase
+func NewListAttachmentsUseCase(
+	attachmentRepo repository.AttachmentRepository,
+	taskRepo repository.TaskRepository,
+	taskService TaskServiceInterface,
+) *ListAttachmentsUseCase {
+	return &ListAttachmentsUseCase{
+		attachmentRepo: attachmentRepo,
+		taskRepo:       taskRepo,
+		taskService:    taskService,
+	}
+}
+
+// Execute lists the attachments of a task visible to the user
+func (uc *ListAttachmentsUseCase) Execute(ctx context.Context, taskID, userID string) ([]*application.Attachment, error) {
+	if err := checkAttachmentAccess(ctx, uc.taskRepo, uc.taskService, taskID, userID, false); err != nil {
+		return nil, err
+	}
+
+	attachments, err := uc.attachmentRepo.FindByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if attachments == nil {
+		attachments = []*application.Attachment{}
+	}
+	return attachments, nil
+}
+
+// GetAttachmentUseCase handles reading an attachment for download
+type GetAttachmentUseCase struct {
+	attachmentRepo repository.AttachmentRepository
+	taskRepo       repository.TaskRepository
+	taskService    TaskServiceInterface
+}
+
+// NewGetAttachmentUseCase creates a new GetAttachmentUseCase
+func NewGetAttachmentUseCase(
+	attachmentRepo repository.AttachmentRepository,
+	taskRepo repository.TaskRepository,
+	taskService TaskServiceInterface,
+) *GetAttachmentUseCase {
+	return &GetAttachmentUseCase{
+		attachmentRepo: attachmentRepo,
+		taskRepo:       taskRepo,
+		taskService:    taskService,
+	}
+}
+
+// Execute returns an attachment of a task visible to the user
+func (uc *GetAttachmentUseCase) Execute(ctx context.Context, taskID, attachmentID, userID string) (*application.Attachment, error) {
+	if err := checkAttachmentAccess(ctx, uc.taskRepo, uc.taskService, taskID, userID, false); err != nil {
+		return nil, err
+	}
+
+	return findTaskAttachment(ctx, uc.attachmentRepo, taskID, attachmentID)
+}
+
+// DeleteAttachmentUseCase handles removing an attachment from a task
+type DeleteAttachmentUseCase struct {
+	attachmentRepo repository.AttachmentRepository
+	taskRepo       repository.TaskRepository
+	taskService    TaskServiceInterface
+}
+
+// NewDeleteAttachmentUseCase creates a new DeleteAttachmentUseCase
+func NewDeleteAttachmentUseCase(
+	attachmentRepo repository.AttachmentRepository,
+	taskRepo repository.TaskRepository,
+	taskService TaskServiceInterface,
+) *DeleteAttachmentUseCase {
+	return &DeleteAttachmentUseCase{
+		attachmentRepo: attachmentRepo,
+		taskRepo:       taskRepo,
+		taskService:    taskService,
+	}
+}
+
+// Execute deletes an attachment and returns it so the caller can remove the stored file
+func (uc *DeleteAttachmentUseCase) Execute(ctx context.Context, taskID, attachmentID, userID string) (*application.Attachment, error) {
+	if err := checkAttachmentAccess(ctx, uc.taskRepo, uc.taskService, taskID, userID, true); err != nil {
+		return nil, err
+	}
+
+	attachment, err := findTaskAttachment(ctx, uc.attachmentRepo, taskID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.attachmentRepo.Delete(ctx, attachment.ID); err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// ListOwnerAttachmentsUseCase handles listing the attachments of all tasks of a user
+type ListOwnerAttachmentsUseCase struct {
+	attachmentRepo repository.AttachmentRepository
+}
+
+// NewListOwnerAttachmentsUseCase creates a new ListOwnerAttachmentsUseCase
+func NewListOwnerAttachmentsUseCase(attachmentRepo repository.AttachmentRepository) *ListOwnerAttachmentsUseCase {
+	return &ListOwnerAttachmentsUseCase{
+		attachmentRepo: attachmentRepo,
+	}
+}
+
+// Execute returns the attachments of a user's tasks grouped by task ID
+func (uc *ListOwnerAttachmentsUseCase) Execute(ctx context.Context, ownerID string) (map[string][]*application.Attachment, error) {
+	attachments, err := uc.attachmentRepo.FindByOwnerID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	byTask := make(map[string][]*application.Attachment)
+	for _, attachment := range attachments {
+		byTask[attachment.TaskID] = append(byTask[attachment.TaskID], attachment)
+	}
+
+	return byTask, nil
+}
@@ -0,0 +1,261 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// mockTaskRepositoryForAttachments returns nil for unknown tasks, like the SQLite repository
+type mockTaskRepositoryForAttachments struct {
+	mockTaskRepositoryForReplaceImage
+}
+
+func (m *mockTaskRepositoryForAttachments) FindByID(ctx context.Context, id string) (*application.Task, error) {
+	task, ok := m.tasks[id]
+	if !ok {
+		return nil, application.ErrTaskNotFound
+	}
+	return task, nil
+}
+
+// mockTaskServiceForAttachments grants access to the users listed in viewers
+type mockTaskServiceForAttachments struct {
+	viewers map[string]bool
+}
+
+func (m *mockTaskServiceForAttachments) CanUserAccessTask(ctx context.Context, taskID, userID string) (bool, error) {
+	return m.viewers[userID], nil
+}
+
+func (m *mockTaskServiceForAttachments) CanUserModifyTask(ctx context.Context, taskID, userID string) (bool, error) {
+	return false, errors.New("attachments check ownership on the task")
+}
+
+type mockAttachmentRepository struct {
+	attachments map[string]*application.Attachment
+	deleted     []string
+}
+
+func (m *mockAttachmentRepository) Create(ctx context.Context, attachment *application.Attachment) error {
+	m.attachments[attachment.ID] = attachment
+	return nil
+}
+
+func (m *mockAttachmentRepository) FindByID(ctx context.Context, id string) (*application.Attachment, error) {
+	if attachment, ok := m.attachments[id]; ok {
+		return attachment, nil
+	}
+	return nil, application.ErrAttachmentNotFound
+}
+
+func (m *mockAttachmentRepository) FindByTaskID(ctx context.Context, taskID string) ([]*application.Attachment, error) {
+	var attachments []*application.Attachment
+	for _, attachment := range m.attachments {
+		if attachment.TaskID == taskID {
+			attachments = append(attachments, attachment)
+		}
+	}
+	return attachments, nil
+}
+
+func (m *mockAttachmentRepository) FindByOwnerID(ctx context.Context, ownerID string) ([]*application.Attachment, error) {
+	var attachments []*application.Attachment
+	for _, attachment := range m.attachments {
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+func (m *mockAttachmentRepository) CountByTaskID(ctx context.Context, taskID string) (int, error) {
+	attachments, _ := m.FindByTaskID(ctx, taskID)
+	return len(attachments), nil
+}
+
+func (m *mockAttachmentRepository) Delete(ctx context.Context, id string) error {
+	delete(m.attachments, id)
+	m.deleted = append(m.deleted, id)
+	return nil
+}
+
+func TestAddAttachmentUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name     string
+		taskID   string
+		userID   string
+		existing int
+		wantErr  error
+	}{
+		{"owner attaches", "task-1", "owner", 0, nil},
+		{"shared user cannot attach", "task-1", "viewer", 0, ErrAttachmentPermissionDenied},
+		{"stranger gets not found", "task-1", "stranger", 0, ErrTaskUnavailable},
+		{"missing task", "missing", "owner", 0, ErrTaskUnavailable},
+		{"limit reached", "task-1", "owner", application.MaxAttachmentsPerTask - 1, application.ErrTooManyAttachments},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachmentRepo := &mockAttachmentRepository{attachments: map[string]*application.Attachment{
+				"att-1": {ID: "att-1", TaskID: "task-1", Filename: "a.pdf", Path: "stored.pdf"},
+				"att-2": {ID: "att-2", TaskID: "task-2", Filename: "b.pdf", Path: "other.pdf"},
+			}}
+			taskRepo := &mockTaskRepositoryForAttachments{mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+				"task-1": {ID: "task-1", OwnerID: "owner"},
+			}}}
+			taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"owner": true, "viewer": true}}
+			for i := 0; i < tt.existing; i++ {
+				id := "extra-" + string(rune('a'+i))
+				attachmentRepo.attachments[id] = &application.Attachment{ID: id, TaskID: "task-1"}
+			}
+			uc := NewAddAttachmentUseCase(attachmentRepo, taskRepo, taskService)
+
+			attachment, err := uc.Execute(context.Background(), tt.taskID, tt.userID, "notes.txt", "text/plain", 42, "stored.txt")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if attachment.ID == "" || attachment.TaskID != "task-1" || attachment.Filename != "notes.txt" {
+				t.Errorf("Execute() = %+v", attachment)
+			}
+			if attachmentRepo.attachments[attachment.ID] == nil {
+				t.Error("Attachment was not persisted")
+			}
+		})
+	}
+}
+
+func TestListAttachmentsUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name      string
+		taskID    string
+		userID    string
+		wantCount int
+		wantErr   error
+	}{
+		{"owner lists", "task-1", "owner", 1, nil},
+		{"shared user lists", "task-1", "viewer", 1, nil},
+		{"stranger gets not found", "task-1", "stranger", 0, ErrTaskUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachmentRepo := &mockAttachmentRepository{attachments: map[string]*application.Attachment{
+				"att-1": {ID: "att-1", TaskID: "task-1", Filename: "a.pdf", Path: "stored.pdf"},
+				"att-2": {ID: "att-2", TaskID: "task-2", Filename: "b.pdf", Path: "other.pdf"},
+			}}
+			taskRepo := &mockTaskRepositoryForAttachments{mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+				"task-1": {ID: "task-1", OwnerID: "owner"},
+			}}}
+			taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"owner": true, "viewer": true}}
+			uc := NewListAttachmentsUseCase(attachmentRepo, taskRepo, taskService)
+
+			attachments, err := uc.Execute(context.Background(), tt.taskID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(attachments) != tt.wantCount {
+				t.Errorf("Execute() returned %d attachments, want %d", len(attachments), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestGetAttachmentUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name         string
+		taskID       string
+		attachmentID string
+		userID       string
+		wantErr      error
+	}{
+		{"shared user downloads", "task-1", "att-1", "viewer", nil},
+		{"attachment of another task", "task-1", "att-2", "owner", application.ErrAttachmentNotFound},
+		{"missing attachment", "task-1", "missing", "owner", application.ErrAttachmentNotFound},
+		{"stranger gets not found", "task-1", "att-1", "stranger", ErrTaskUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachmentRepo := &mockAttachmentRepository{attachments: map[string]*application.Attachment{
+				"att-1": {ID: "att-1", TaskID: "task-1", Filename: "a.pdf", Path: "stored.pdf"},
+				"att-2": {ID: "att-2", TaskID: "task-2", Filename: "b.pdf", Path: "other.pdf"},
+			}}
+			taskRepo := &mockTaskRepositoryForAttachments{mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+				"task-1": {ID: "task-1", OwnerID: "owner"},
+			}}}
+			taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"owner": true, "viewer": true}}
+			uc := NewGetAttachmentUseCase(attachmentRepo, taskRepo, taskService)
+
+			attachment, err := uc.Execute(context.Background(), tt.taskID, tt.attachmentID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && attachment.ID != tt.attachmentID {
+				t.Errorf("Execute() = %+v", attachment)
+			}
+		})
+	}
+}
+
+func TestDeleteAttachmentUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name         string
+		attachmentID string
+		userID       string
+		wantErr      error
+	}{
+		{"owner deletes", "att-1", "owner", nil},
+		{"shared user cannot delete", "att-1", "viewer", ErrAttachmentPermissionDenied},
+		{"attachment of another task", "att-2", "owner", application.ErrAttachmentNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachmentRepo := &mockAttachmentRepository{attachments: map[string]*application.Attachment{
+				"att-1": {ID: "att-1", TaskID: "task-1", Filename: "a.pdf", Path: "stored.pdf"},
+				"att-2": {ID: "att-2", TaskID: "task-2", Filename: "b.pdf", Path: "other.pdf"},
+			}}
+			taskRepo := &mockTaskRepositoryForAttachments{mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+				"task-1": {ID: "task-1", OwnerID: "owner"},
+			}}}
+			taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"owner": true, "viewer": true}}
+			uc := NewDeleteAttachmentUseCase(attachmentRepo, taskRepo, taskService)
+
+			attachment, err := uc.Execute(context.Background(), "task-1", tt.attachmentID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(attachmentRepo.deleted) != 0 {
+					t.Errorf("Expected nothing deleted, got %v", attachmentRepo.deleted)
+				}
+				return
+			}
+			if attachment.Path != "stored.pdf" {
+				t.Errorf("Expected the deleted attachment to be returned, got %+v", attachment)
+			}
+			if attachmentRepo.attachments["att-1"] != nil {
+				t.Error("Attachment was not deleted")
+			}
+		})
+	}
+}
+
+func TestListOwnerAttachmentsUseCase_Execute(t *testing.T) {
+	attachmentRepo := &mockAttachmentRepository{attachments: map[string]*application.Attachment{
+		"att-1": {ID: "att-1", TaskID: "task-1", Filename: "a.pdf", Path: "stored.pdf"},
+	}}
+	uc := NewListOwnerAttachmentsUseCase(attachmentRepo)
+
+	byTask, err := uc.Execute(context.Background(), "owner")
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if len(byTask["task-1"]) != 1 || byTask["task-1"][0].ID != "att-1" {
+		t.Errorf("Expected att-1 grouped under task-1, got %v", byTask)
+	}
+}
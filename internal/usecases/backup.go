@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// Backuper copies the data of the app while it runs
+type Backuper interface {
+	// Create writes a backup of the database and the uploaded files
+	Create(ctx context.Context) (*application.Backup, error)
+}
+
+// CreateBackupUseCase backs up the data of the app on request of an admin
+type CreateBackupUseCase struct {
+	userRepo repository.UserRepository
+	backuper Backuper
+}
+
+// NewCreateBackupUseCase creates a new CreateBackupUseCase
+func NewCreateBackupUseCase(userRepo repository.UserRepository, backuper Backuper) *CreateBackupUseCase {
+	return &CreateBackupUseCase{
+		userRepo: userRepo,
+		backuper: backuper,
+	}
+}
+
+// Execute writes a backup, for admins only
+func (uc *CreateBackupUseCase) Execute(ctx context.Context, adminID string) (*application.Backup, error) {
+	if _, err := requireAdmin(ctx, uc.userRepo, adminID); err != nil {
+		return nil, err
+	}
+	return uc.backuper.Create(ctx)
+}
@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// mockBackuper counts the backups it was asked for
+type mockBackuper struct {
+	calls int
+	err   error
+}
+
+func (m *mockBackuper) Create(ctx context.Context) (*application.Backup, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &application.Backup{Database: "backups/todo.db", Files: "backups/todo-files.tar.gz"}, nil
+}
+
+func TestCreateBackupUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name      string
+		adminID   string
+		backupErr error
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "admin", adminID: "admin-1", wantCalls: 1},
+		{name: "member", adminID: "member-1", wantErr: ErrAdminRequired},
+		{name: "backup error", adminID: "admin-1", backupErr: errors.New("disk full"), wantErr: errors.New("disk full"), wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backuper := &mockBackuper{err: tt.backupErr}
+			uc := NewCreateBackupUseCase(newMockUserRepositoryForAdmin(), backuper)
+
+			backup, err := uc.Execute(context.Background(), tt.adminID)
+			if tt.wantErr != nil {
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil || backup == nil {
+				t.Fatalf("Execute() = %v, %v", backup, err)
+			}
+			if backuper.calls != tt.wantCalls {
+				t.Errorf("Expected %d backups, got %d", tt.wantCalls, backuper.calls)
+			}
+		})
+	}
+}
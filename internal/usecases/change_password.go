@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// ErrCurrentPasswordMismatch is returned when the current password given to change it is wrong
+var ErrCurrentPasswordMismatch = errors.New("current password is incorrect")
+
+// ChangePasswordUseCase handles users changing their own password
+type ChangePasswordUseCase struct {
+	userRepo    repository.UserRepository
+	authService *service.AuthService
+	policy      service.PasswordPolicy
+}
+
+// NewChangePasswordUseCase creates a new ChangePasswordUseCase accepting the passwords that
+// follow policy. Passwords are hashed with hasher, or bcrypt at the default cost when nil.
+func NewChangePasswordUseCase(userRepo repository.UserRepository, hasher service.PasswordHasher, policy service.PasswordPolicy) *ChangePasswordUseCase {
+	return &ChangePasswordUseCase{
+		userRepo:    userRepo,
+		authService: service.NewAuthServiceWithKeys(nil, hasher),
+		policy:      policy,
+	}
+}
+
+// Execute replaces the password of userID once the current one is verified. The new password
+// must follow the policy; a *service.PasswordPolicyError lists the rules it breaks.
+func (uc *ChangePasswordUseCase) Execute(ctx context.Context, userID, currentPassword, newPassword string) error {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.authService.VerifyPassword(user.PasswordHash, currentPassword); err != nil {
+		return ErrCurrentPasswordMismatch
+	}
+	if err := uc.policy.Validate(newPassword, user.Email, user.Name); err != nil {
+		return err
+	}
+
+	hash, err := uc.authService.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = hash
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	return nil
+}
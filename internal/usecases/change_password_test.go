@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+func TestChangePasswordUseCase_Execute(t *testing.T) {
+	authService := service.NewAuthServiceWithKeys(nil, nil)
+	hash, err := authService.HashPassword("old-password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := service.PasswordPolicy{MinLength: 10, RejectPersonal: true}
+
+	tests := []struct {
+		name        string
+		userID      string
+		current     string
+		newPassword string
+		wantErr     error
+		wantRules   []service.PasswordRule
+	}{
+		{"changed", "user-123", "old-password", "tangerine-sky", nil, nil},
+		{"wrong current password", "user-123", "guess", "tangerine-sky", ErrCurrentPasswordMismatch, nil},
+		{"unknown user", "missing", "old-password", "tangerine-sky", application.ErrUserNotFound, nil},
+		{"new password breaks the policy", "user-123", "old-password", "alice", nil, []service.PasswordRule{service.RuleMinLength, service.RuleNotPersonal}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &application.User{ID: "user-123", Name: "Alice Moreira", Email: "alice@example.com", PasswordHash: hash}
+			repo := newMockUserRepositoryForTheme(user)
+			uc := NewChangePasswordUseCase(repo, nil, policy)
+
+			err := uc.Execute(context.Background(), tt.userID, tt.current, tt.newPassword)
+
+			if tt.wantRules != nil {
+				var policyErr *service.PasswordPolicyError
+				if !errors.As(err, &policyErr) {
+					t.Fatalf("Execute() error = %v, want a *service.PasswordPolicyError", err)
+				}
+				if len(policyErr.Violations) != len(tt.wantRules) {
+					t.Fatalf("Violations = %+v, want %v", policyErr.Violations, tt.wantRules)
+				}
+				for i, rule := range tt.wantRules {
+					if policyErr.Violations[i].Rule != rule {
+						t.Errorf("Violations[%d] = %q, want %q", i, policyErr.Violations[i].Rule, rule)
+					}
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil || tt.wantRules != nil {
+				if repo.updated != 0 || user.PasswordHash != hash {
+					t.Errorf("expected the password to be left unchanged")
+				}
+				return
+			}
+			if repo.updated != 1 {
+				t.Errorf("Expected 1 update, got %d", repo.updated)
+			}
+			if err := authService.VerifyPassword(user.PasswordHash, tt.newPassword); err != nil {
+				t.Errorf("new password doesn't verify: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// ErrLinkDisallowed is returned by a LinkProber when robots.txt forbids checking a URL
+var ErrLinkDisallowed = errors.New("link check disallowed by robots.txt")
+
+// LinkProber checks whether an external URL is reachable
+type LinkProber interface {
+	Probe(ctx context.Context, rawURL string) (statusCode int, err error)
+}
+
+// LinkCheckSummary reports the outcome of a link check run
+type LinkCheckSummary struct {
+	Checked  int
+	Broken   int
+	Skipped  int
+	Notified int
+}
+
+// CheckTaskLinksUseCase checks the external links of task descriptions
+type CheckTaskLinksUseCase struct {
+	linkRepo         repository.LinkRepository
+	notificationRepo repository.NotificationRepository
+	prober           LinkProber
+	maxLinksPerRun   int
+}
+
+// NewCheckTaskLinksUseCase creates a new CheckTaskLinksUseCase
+func NewCheckTaskLinksUseCase(
+	linkRepo repository.LinkRepository,
+	notificationRepo repository.NotificationRepository,
+	prober LinkProber,
+	maxLinksPerRun int,
+) *CheckTaskLinksUseCase {
+	return &CheckTaskLinksUseCase{
+		linkRepo:         linkRepo,
+		notificationRepo: notificationRepo,
+		prober:           prober,
+		maxLinksPerRun:   maxLinksPerRun,
+	}
+}
+
+// Execute checks links of all tasks, stores the results and notifies owners of newly broken links
+func (uc *CheckTaskLinksUseCase) Execute(ctx context.Context) (LinkCheckSummary, error) {
+	var summary LinkCheckSummary
+
+	tasks, err := uc.linkRepo.FindTasksWithLinks(ctx)
+	if err != nil {
+		return summary, fmt.Errorf("failed to retrieve tasks with links: %w", err)
+	}
+
+	for _, task := range tasks {
+		previous, err := uc.linkRepo.FindByTaskID(ctx, task.ID)
+		if err != nil {
+			return summary, fmt.Errorf("failed to retrieve links of task %s: %w", task.ID, err)
+		}
+		wasBroken := make(map[string]bool, len(previous))
+		checkedAt := make(map[string]time.Time, len(previous))
+		for _, link := range previous {
+			wasBroken[link.URL] = link.IsBroken()
+			checkedAt[link.URL] = link.CheckedAt
+		}
+
+		// Like the tasks, the links never checked go first, then the least recently checked
+		urls := service.ExtractURLs(task.Description)
+		sort.SliceStable(urls, func(i, j int) bool {
+			return checkedAt[urls[i]].Before(checkedAt[urls[j]])
+		})
+
+		for _, rawURL := range urls {
+			if uc.maxLinksPerRun > 0 && summary.Checked+summary.Skipped >= uc.maxLinksPerRun {
+				return summary, nil
+			}
+			if err := ctx.Err(); err != nil {
+				return summary, err
+			}
+
+			statusCode, probeErr := uc.prober.Probe(ctx, rawURL)
+			if errors.Is(probeErr, ErrLinkDisallowed) {
+				summary.Skipped++
+				continue
+			}
+
+			status := application.LinkStatusOK
+			if probeErr != nil || statusCode >= 400 {
+				status = application.LinkStatusBroken
+			}
+
+			link, err := application.NewTaskLink(task.ID, rawURL, status, statusCode)
+			if err != nil {
+				// Not a checkable URL (e.g. "http://" without host)
+				summary.Skipped++
+				continue
+			}
+
+			if err := uc.linkRepo.Save(ctx, link); err != nil {
+				return summary, fmt.Errorf("failed to save link check: %w", err)
+			}
+			summary.Checked++
+
+			if !link.IsBroken() {
+				continue
+			}
+			summary.Broken++
+
+			// Only notify on transitions so owners are not spammed every run
+			if wasBroken[rawURL] {
+				continue
+			}
+			if err := uc.notifyBrokenLink(ctx, task, rawURL); err != nil {
+				return summary, err
+			}
+			summary.Notified++
+		}
+	}
+
+	return summary, nil
+}
+
+// notifyBrokenLink creates an in-app notification for the task owner
+func (uc *CheckTaskLinksUseCase) notifyBrokenLink(ctx context.Context, task *application.Task, rawURL string) error {
+	message := notificationMessage(fmt.Sprintf("Link quebrado na tarefa \"%s\": %s", task.Title, rawURL))
+
+	notification, err := application.NewNotification(uuid.New().String(), task.OwnerID, application.NotificationBrokenLink, task.ID, message)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.notificationRepo.Create(ctx, notification); err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return nil
+}
+
+// notificationMessage truncates a message to the 500 bytes a notification accepts,
+// without splitting a multi-byte character
+func notificationMessage(message string) string {
+	if len(message) <= 500 {
+		return message
+	}
+	cut := 497
+	for cut > 0 && !utf8.RuneStart(message[cut]) {
+		cut--
+	}
+	return message[:cut] + "..."
+}
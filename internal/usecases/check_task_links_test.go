@@ -0,0 +1,217 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// Mock repositories for testing
+type mockLinkRepositoryForCheck struct {
+	tasks []*application.Task
+	links map[string]*application.TaskLink
+}
+
+func (m *mockLinkRepositoryForCheck) Save(ctx context.Context, link *application.TaskLink) error {
+	m.links[link.TaskID+"|"+link.URL] = link
+	return nil
+}
+
+func (m *mockLinkRepositoryForCheck) FindByTaskID(ctx context.Context, taskID string) ([]*application.TaskLink, error) {
+	var links []*application.TaskLink
+	for _, link := range m.links {
+		if link.TaskID == taskID {
+			links = append(links, link)
+		}
+	}
+	return links, nil
+}
+
+func (m *mockLinkRepositoryForCheck) FindBrokenByOwnerID(ctx context.Context, ownerID string) ([]*application.TaskLink, error) {
+	return nil, nil
+}
+
+// FindTasksWithLinks returns the tasks the least recently checked first, like the SQLite repository
+func (m *mockLinkRepositoryForCheck) FindTasksWithLinks(ctx context.Context) ([]*application.Task, error) {
+	oldest := make(map[string]time.Time, len(m.tasks))
+	for _, link := range m.links {
+		if at, ok := oldest[link.TaskID]; !ok || link.CheckedAt.Before(at) {
+			oldest[link.TaskID] = link.CheckedAt
+		}
+	}
+	tasks := append([]*application.Task(nil), m.tasks...)
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return oldest[tasks[i].ID].Before(oldest[tasks[j].ID])
+	})
+	return tasks, nil
+}
+
+type mockNotificationRepository struct {
+	notifications []*application.Notification
+}
+
+func (m *mockNotificationRepository) Create(ctx context.Context, notification *application.Notification) error {
+	m.notifications = append(m.notifications, notification)
+	return nil
+}
+
+func (m *mockNotificationRepository) FindByUserID(ctx context.Context, userID string, limit int) ([]*application.Notification, error) {
+	var notifications []*application.Notification
+	for _, n := range m.notifications {
+		if n.UserID == userID {
+			notifications = append(notifications, n)
+		}
+	}
+	return notifications, nil
+}
+
+func (m *mockNotificationRepository) MarkAsRead(ctx context.Context, id, userID string) error {
+	return nil
+}
+
+type mockLinkProber struct {
+	results map[string]int
+	errs    map[string]error
+	calls   []string
+}
+
+func (m *mockLinkProber) Probe(ctx context.Context, rawURL string) (int, error) {
+	m.calls = append(m.calls, rawURL)
+	if err, ok := m.errs[rawURL]; ok {
+		return 0, err
+	}
+	return m.results[rawURL], nil
+}
+
+func TestCheckTaskLinksUseCase_Execute(t *testing.T) {
+	task, _ := application.NewTask("task-1", "Estudar", "Ver https://ok.example.com e https://gone.example.com/x. Também https://down.example.com", application.StatusPending, "user-1", "")
+
+	tests := []struct {
+		name         string
+		existing     []*application.TaskLink
+		errs         map[string]error
+		maxPerRun    int
+		wantChecked  int
+		wantBroken   int
+		wantSkipped  int
+		wantNotified int
+	}{
+		{
+			name:         "should mark 4xx and network errors as broken and notify",
+			wantChecked:  3,
+			wantBroken:   2,
+			wantNotified: 2,
+		},
+		{
+			name: "should not notify again for links already broken",
+			existing: []*application.TaskLink{
+				{TaskID: "task-1", URL: "https://gone.example.com/x", Status: application.LinkStatusBroken},
+			},
+			wantChecked:  3,
+			wantBroken:   2,
+			wantNotified: 1,
+		},
+		{
+			name:         "should skip links disallowed by robots.txt",
+			errs:         map[string]error{"https://gone.example.com/x": ErrLinkDisallowed, "https://down.example.com": errors.New("connection refused")},
+			wantChecked:  2,
+			wantBroken:   1,
+			wantSkipped:  1,
+			wantNotified: 1,
+		},
+		{
+			name:         "should stop when the per-run limit is reached",
+			maxPerRun:    1,
+			wantChecked:  1,
+			wantBroken:   0,
+			wantNotified: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linkRepo := &mockLinkRepositoryForCheck{
+				tasks: []*application.Task{task},
+				links: map[string]*application.TaskLink{},
+			}
+			for _, link := range tt.existing {
+				linkRepo.links[link.TaskID+"|"+link.URL] = link
+			}
+			errs := tt.errs
+			if errs == nil {
+				errs = map[string]error{"https://down.example.com": errors.New("connection refused")}
+			}
+			prober := &mockLinkProber{
+				results: map[string]int{"https://ok.example.com": 200, "https://gone.example.com/x": 404},
+				errs:    errs,
+			}
+			notificationRepo := &mockNotificationRepository{}
+
+			uc := NewCheckTaskLinksUseCase(linkRepo, notificationRepo, prober, tt.maxPerRun)
+			summary, err := uc.Execute(context.Background())
+			if err != nil {
+				t.Fatalf("Execute() unexpected error = %v", err)
+			}
+
+			if summary.Checked != tt.wantChecked {
+				t.Errorf("Checked = %d, want %d", summary.Checked, tt.wantChecked)
+			}
+			if summary.Broken != tt.wantBroken {
+				t.Errorf("Broken = %d, want %d", summary.Broken, tt.wantBroken)
+			}
+			if summary.Skipped != tt.wantSkipped {
+				t.Errorf("Skipped = %d, want %d", summary.Skipped, tt.wantSkipped)
+			}
+			if len(notificationRepo.notifications) != tt.wantNotified {
+				t.Errorf("notifications = %d, want %d", len(notificationRepo.notifications), tt.wantNotified)
+			}
+			for _, n := range notificationRepo.notifications {
+				if n.UserID != "user-1" || n.TaskID != "task-1" || n.Type != application.NotificationBrokenLink {
+					t.Errorf("unexpected notification %+v", n)
+				}
+				if !strings.Contains(n.Message, "Estudar") {
+					t.Errorf("notification message should mention the task title, got %q", n.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckTaskLinksUseCase_Execute_RotatesCappedRuns(t *testing.T) {
+	first, _ := application.NewTask("task-1", "Estudar", "Ver https://a.example.com e https://b.example.com", application.StatusPending, "user-1", "")
+	second, _ := application.NewTask("task-2", "Ler", "Ver https://c.example.com e https://d.example.com", application.StatusPending, "user-1", "")
+	linkRepo := &mockLinkRepositoryForCheck{
+		tasks: []*application.Task{first, second},
+		links: map[string]*application.TaskLink{},
+	}
+	prober := &mockLinkProber{results: map[string]int{}}
+	uc := NewCheckTaskLinksUseCase(linkRepo, &mockNotificationRepository{}, prober, 3)
+
+	// The first run stops at the cap, before the last link of the second task
+	if _, err := uc.Execute(context.Background()); err != nil {
+		t.Fatalf("Execute() unexpected error = %v", err)
+	}
+	if len(prober.calls) != 3 {
+		t.Fatalf("Expected 3 links checked, got %v", prober.calls)
+	}
+	unchecked := "https://d.example.com"
+	if prober.calls[2] == unchecked {
+		t.Fatalf("Expected %s left for the next run, got %v", unchecked, prober.calls)
+	}
+
+	// The second run starts with the task checked least recently, then gets to the link left
+	// unchecked before any checked link of its task
+	prober.calls = nil
+	if _, err := uc.Execute(context.Background()); err != nil {
+		t.Fatalf("Execute() unexpected error = %v", err)
+	}
+	want := []string{"https://a.example.com", "https://b.example.com", unchecked}
+	if strings.Join(prober.calls, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected the second run to check %v, got %v", want, prober.calls)
+	}
+}
@@ -0,0 +1,314 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// MentionEmailJobType is the type of the queued jobs emailing the users mentioned in comments
+const MentionEmailJobType = "comment_mention_email"
+
+// JobEnqueuer queues background jobs, like JobQueue
+type JobEnqueuer interface {
+	Enqueue(ctx context.Context, jobType string, payload any) (*application.Job, error)
+}
+
+// mentionEmail is the payload of a MentionEmailJobType job
+type mentionEmail struct {
+	UserID     string `json:"user_id"`
+	TaskID     string `json:"task_id"`
+	TaskTitle  string `json:"task_title"`
+	AuthorName string `json:"author_name"`
+	Body       string `json:"body"`
+}
+
+// AddCommentUseCase handles leaving a comment on a task, notifying the users it mentions
+type AddCommentUseCase struct {
+	commentRepo      repository.CommentRepository
+	taskRepo         repository.TaskRepository
+	shareRepo        repository.ShareRepository
+	userRepo         repository.UserRepository
+	notificationRepo repository.NotificationRepository
+	settingsRepo     repository.UserSettingsRepository
+	transactor       repository.Transactor
+	taskService      TaskServiceInterface
+	emails           JobEnqueuer
+	now              func() time.Time
+}
+
+// NewAddCommentUseCase creates a new AddCommentUseCase. Mentions are emailed through jobs of
+// MentionEmailJobType queued on emails; a nil emails notifies them only in the app.
+func NewAddCommentUseCase(
+	commentRepo repository.CommentRepository,
+	taskRepo repository.TaskRepository,
+	shareRepo repository.ShareRepository,
+	userRepo repository.UserRepository,
+	notificationRepo repository.NotificationRepository,
+	settingsRepo repository.UserSettingsRepository,
+	transactor repository.Transactor,
+	taskService TaskServiceInterface,
+	emails JobEnqueuer,
+) *AddCommentUseCase {
+	return &AddCommentUseCase{
+		commentRepo:      commentRepo,
+		taskRepo:         taskRepo,
+		shareRepo:        shareRepo,
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+		settingsRepo:     settingsRepo,
+		transactor:       transactor,
+		taskService:      taskService,
+		emails:           emails,
+		now:              time.Now,
+	}
+}
+
+// Execute leaves a comment of the user on a task they can see. Users mentioned with @email or
+// with the handle of their name (see service.MentionHandle) are recorded in the comment and,
+// unless they turned mentions off, notified in the app and by email, as long as they can see
+// the task too; other mentions are left as plain text, so a comment can't ping, nor tell
+// apart, users outside the task. The author is never notified of their own mentions, and at
+// most application.MaxMentionsPerComment users are. The notifications and the email jobs are
+// saved in the transaction of the comment.
+func (uc *AddCommentUseCase) Execute(ctx context.Context, taskID, userID, body string) (_ *application.Comment, err error) {
+	ctx, end := tracer.Start(ctx, "AddComment")
+	defer func() { end(err) }()
+
+	if err := checkTaskAccess(ctx, uc.taskRepo, uc.taskService, taskID, userID, false); err != nil {
+		return nil, err
+	}
+
+	comment, err := application.NewComment(uuid.New().String(), taskID, userID, body, uc.now())
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := uc.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	comment.Mentions, err = uc.mentionedUsers(ctx, task, userID, comment.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	notified, err := uc.notifiedUsers(ctx, comment.Mentions)
+	if err != nil {
+		return nil, err
+	}
+
+	var notifications []*application.Notification
+	var emails []mentionEmail
+	if len(notified) > 0 {
+		author, err := uc.userRepo.FindByID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve the author: %w", err)
+		}
+		message := fmt.Sprintf("%s mencionou você na tarefa \"%s\"", author.Name, task.Title)
+		for _, mentionedID := range notified {
+			notification, err := application.NewNotification(uuid.New().String(), mentionedID, application.NotificationCommentMention, taskID, notificationMessage(message))
+			if err != nil {
+				return nil, err
+			}
+			notifications = append(notifications, notification)
+			emails = append(emails, mentionEmail{UserID: mentionedID, TaskID: taskID, TaskTitle: task.Title, AuthorName: author.Name, Body: comment.Body})
+		}
+	}
+
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.commentRepo.Create(ctx, comment); err != nil {
+			return err
+		}
+		for _, notification := range notifications {
+			if err := uc.notificationRepo.Create(ctx, notification); err != nil {
+				return fmt.Errorf("failed to create notification: %w", err)
+			}
+		}
+		if uc.emails == nil {
+			return nil
+		}
+		for _, email := range emails {
+			if _, err := uc.emails.Enqueue(ctx, MentionEmailJobType, email); err != nil {
+				return fmt.Errorf("failed to queue mention email: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// notifiedUsers returns the mentioned users who didn't turn mention notifications off
+func (uc *AddCommentUseCase) notifiedUsers(ctx context.Context, mentioned []string) ([]string, error) {
+	var notified []string
+	for _, userID := range mentioned {
+		settings, err := userSettings(ctx, uc.settingsRepo, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve notification settings: %w", err)
+		}
+		if settings.Notifications.Allows(application.NotificationCommentMention) {
+			notified = append(notified, userID)
+		}
+	}
+	return notified, nil
+}
+
+// mentionedUsers returns the IDs of the users mentioned in a comment of the author on a task,
+// in order of mention, keeping those who can see the task. Handles are matched against the
+// names of the owner and the users the task is shared with; emails against theirs first, then
+// against every user, as members of the project or org of the task can see it too.
+func (uc *AddCommentUseCase) mentionedUsers(ctx context.Context, task *application.Task, authorID, body string) ([]string, error) {
+	mentions := service.ExtractMentions(body)
+	if len(mentions) == 0 {
+		return nil, nil
+	}
+
+	collaborators, err := uc.collaborators(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+
+	var mentioned []string
+	seen := map[string]bool{authorID: true}
+	for _, mention := range mentions {
+		candidates := collaborators[mention]
+		if len(candidates) == 0 && service.IsEmailMention(mention) {
+			user, err := uc.userRepo.FindByEmail(ctx, mention)
+			if err != nil && !errors.Is(err, application.ErrUserNotFound) {
+				return nil, fmt.Errorf("failed to retrieve mentioned user: %w", err)
+			}
+			if user != nil {
+				candidates = []string{user.ID}
+			}
+		}
+
+		for _, candidateID := range candidates {
+			if seen[candidateID] {
+				continue
+			}
+			seen[candidateID] = true
+
+			canAccess, err := uc.taskService.CanUserAccessTask(ctx, task.ID, candidateID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check access of mentioned user: %w", err)
+			}
+			if !canAccess {
+				continue
+			}
+			mentioned = append(mentioned, candidateID)
+			if len(mentioned) == application.MaxMentionsPerComment {
+				return mentioned, nil
+			}
+		}
+	}
+
+	return mentioned, nil
+}
+
+// collaborators returns the IDs of the owner of a task and of the users it is shared with,
+// by the handle of their name and by their lowercased email
+func (uc *AddCommentUseCase) collaborators(ctx context.Context, task *application.Task) (map[string][]string, error) {
+	collaborators := make(map[string][]string)
+	add := func(userID, name, email string) {
+		handle := service.MentionHandle(name)
+		collaborators[handle] = append(collaborators[handle], userID)
+		email = strings.ToLower(email)
+		collaborators[email] = append(collaborators[email], userID)
+	}
+
+	owner, err := uc.userRepo.FindByID(ctx, task.OwnerID)
+	if err != nil && !errors.Is(err, application.ErrUserNotFound) {
+		return nil, fmt.Errorf("failed to retrieve the task owner: %w", err)
+	}
+	if owner != nil {
+		add(owner.ID, owner.Name, owner.Email)
+	}
+
+	shared, err := uc.shareRepo.FindSharedUsers(ctx, task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve shared users: %w", err)
+	}
+	for _, user := range shared {
+		add(user.UserID, user.Name, user.Email)
+	}
+
+	return collaborators, nil
+}
+
+// ListCommentsUseCase handles listing the comments of a task
+type ListCommentsUseCase struct {
+	commentRepo repository.CommentRepository
+	taskRepo    repository.TaskRepository
+	taskService TaskServiceInterface
+}
+
+// NewListCommentsUseCase creates a new ListCommentsUseCase
+func NewListCommentsUseCase(
+	commentRepo repository.CommentRepository,
+	taskRepo repository.TaskRepository,
+	taskService TaskServiceInterface,
+) *ListCommentsUseCase {
+	return &ListCommentsUseCase{
+		commentRepo: commentRepo,
+		taskRepo:    taskRepo,
+		taskService: taskService,
+	}
+}
+
+// Execute lists the comments of a task the user can see, oldest first
+func (uc *ListCommentsUseCase) Execute(ctx context.Context, taskID, userID string) ([]*application.Comment, error) {
+	if err := checkTaskAccess(ctx, uc.taskRepo, uc.taskService, taskID, userID, false); err != nil {
+		return nil, err
+	}
+
+	return uc.commentRepo.FindByTaskID(ctx, taskID)
+}
+
+// SendMentionEmailUseCase emails a user mentioned in a comment. It runs the jobs of
+// MentionEmailJobType queued by AddCommentUseCase.
+type SendMentionEmailUseCase struct {
+	userRepo repository.UserRepository
+	mailer   Mailer
+}
+
+// NewSendMentionEmailUseCase creates a new SendMentionEmailUseCase
+func NewSendMentionEmailUseCase(userRepo repository.UserRepository, mailer Mailer) *SendMentionEmailUseCase {
+	return &SendMentionEmailUseCase{
+		userRepo: userRepo,
+		mailer:   mailer,
+	}
+}
+
+// Execute sends the email of a queued mention; it is a JobHandler, so a failure to send is
+// retried. A user whose account was deleted meanwhile gets no email.
+func (uc *SendMentionEmailUseCase) Execute(ctx context.Context, payload []byte) error {
+	var email mentionEmail
+	if err := json.Unmarshal(payload, &email); err != nil {
+		return fmt.Errorf("invalid mention email payload: %w", err)
+	}
+
+	// A user deleted since the mention gets no email
+	user, err := uc.userRepo.FindByID(ctx, email.UserID)
+	if errors.Is(err, application.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to retrieve mentioned user: %w", err)
+	}
+
+	subject := fmt.Sprintf("%s mencionou você em \"%s\"", email.AuthorName, email.TaskTitle)
+	body := fmt.Sprintf("Olá, %s!\n\n%s mencionou você em um comentário na tarefa \"%s\":\n\n%s\n", user.Name, email.AuthorName, email.TaskTitle, email.Body)
+	return uc.mailer.Send(ctx, user.Email, subject, body)
+}
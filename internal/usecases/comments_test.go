@@ -0,0 +1,269 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+type mockCommentRepository struct {
+	comments []*application.Comment
+}
+
+func (m *mockCommentRepository) Create(ctx context.Context, comment *application.Comment) error {
+	m.comments = append(m.comments, comment)
+	return nil
+}
+
+func (m *mockCommentRepository) FindByTaskID(ctx context.Context, taskID string) ([]*application.Comment, error) {
+	var comments []*application.Comment
+	for _, comment := range m.comments {
+		if comment.TaskID == taskID {
+			comments = append(comments, comment)
+		}
+	}
+	return comments, nil
+}
+
+func (m *mockCommentRepository) FindByTaskIDs(ctx context.Context, taskIDs []string) (map[string][]*application.Comment, error) {
+	comments := make(map[string][]*application.Comment)
+	for _, comment := range m.comments {
+		if slices.Contains(taskIDs, comment.TaskID) {
+			comments[comment.TaskID] = append(comments[comment.TaskID], comment)
+		}
+	}
+	return comments, nil
+}
+
+// Task-1 of the comment tests is owned by user-1 (Ana Souza) and shared with user-2; user-5
+// sees it through its org, and user-3 can't.
+
+func TestAddCommentUseCase_Execute_Mentions(t *testing.T) {
+	task, _ := application.NewTask("task-1", "Pagar contas", "", application.StatusPending, "user-1", "")
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-2"}}}
+	userRepo := &mockUserRepositoryForLogin{users: map[string]*application.User{
+		"user-1": {ID: "user-1", Name: "Ana Souza", Email: "ana@example.com"},
+		"user-2": {ID: "user-2", Name: "Name of user-2", Email: "user-2@example.com"},
+		"user-3": {ID: "user-3", Name: "Bia", Email: "bia@example.com"},
+		"user-5": {ID: "user-5", Name: "Cris", Email: "cris@example.com"},
+	}}
+	taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"user-1": true, "user-2": true, "user-5": true}}
+	commentRepo := &mockCommentRepository{}
+	notificationRepo := &mockNotificationRepository{}
+	transactor := &mockTransactor{}
+	useCase := NewAddCommentUseCase(commentRepo, taskRepo, shareRepo, userRepo, notificationRepo, newMockUserSettingsRepository(), transactor, taskService, nil)
+
+	tests := []struct {
+		name         string
+		userID       string
+		body         string
+		wantMentions []string
+	}{
+		{"no mentions", "user-1", "Paguei a luz", nil},
+		{"name handle", "user-1", "@name.of.user-2 pode pagar a água?", []string{"user-2"}},
+		{"email of a collaborator", "user-2", "Feito, @ANA@example.com", []string{"user-1"}},
+		{"email of an org member", "user-1", "@cris@example.com confere?", []string{"user-5"}},
+		{"user without access", "user-1", "@bia@example.com e @bia", nil},
+		{"unknown users", "user-1", "@ninguem e @ninguem@example.com", nil},
+		{"author and duplicates", "user-1", "@ana.souza @user-2@example.com @name.of.user-2", []string{"user-2"}},
+		{"in order of mention", "user-2", "@cris@example.com @ana.souza", []string{"user-5", "user-1"}},
+	}
+
+	for i, tt := range tests {
+		notificationRepo.notifications = nil
+
+		comment, err := useCase.Execute(context.Background(), "task-1", tt.userID, tt.body)
+		if err != nil {
+			t.Fatalf("%s: Execute() error: %v", tt.name, err)
+		}
+		if !slices.Equal(comment.Mentions, tt.wantMentions) {
+			t.Errorf("%s: Mentions = %v, want %v", tt.name, comment.Mentions, tt.wantMentions)
+		}
+		if len(commentRepo.comments) != i+1 || transactor.calls != i+1 {
+			t.Fatalf("%s: Expected the comment saved in a transaction, got %d comments in %d transactions", tt.name, len(commentRepo.comments), transactor.calls)
+		}
+
+		var notified []string
+		for _, notification := range notificationRepo.notifications {
+			notified = append(notified, notification.UserID)
+			if notification.Type != application.NotificationCommentMention || notification.TaskID != "task-1" {
+				t.Errorf("%s: Unexpected notification %+v", tt.name, notification)
+			}
+			if want := userRepo.users[tt.userID].Name + ` mencionou você na tarefa "Pagar contas"`; notification.Message != want {
+				t.Errorf("%s: Message = %q, want %q", tt.name, notification.Message, want)
+			}
+		}
+		if !slices.Equal(notified, tt.wantMentions) {
+			t.Errorf("%s: Notified %v, want %v", tt.name, notified, tt.wantMentions)
+		}
+	}
+}
+
+func TestAddCommentUseCase_Execute_QueuesEmails(t *testing.T) {
+	task, _ := application.NewTask("task-1", "Pagar contas", "", application.StatusPending, "user-1", "")
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-2"}}}
+	userRepo := &mockUserRepositoryForLogin{users: map[string]*application.User{
+		"user-1": {ID: "user-1", Name: "Ana Souza", Email: "ana@example.com"},
+		"user-2": {ID: "user-2", Name: "Name of user-2", Email: "user-2@example.com"},
+		"user-5": {ID: "user-5", Name: "Cris", Email: "cris@example.com"},
+	}}
+	taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"user-1": true, "user-2": true, "user-5": true}}
+	notificationRepo := &mockNotificationRepository{}
+	settingsRepo := newMockUserSettingsRepository()
+	settingsRepo.settings["user-5"] = &application.UserSettings{UserID: "user-5", Notifications: application.NotificationSettings{CommentMention: false}}
+	jobRepo := &mockJobRepository{}
+	queue := NewJobQueue(jobRepo, 3)
+	queue.Register(MentionEmailJobType, func(ctx context.Context, payload []byte) error { return nil })
+	useCase := NewAddCommentUseCase(&mockCommentRepository{}, taskRepo, shareRepo, userRepo, notificationRepo, settingsRepo, &mockTransactor{}, taskService, queue)
+
+	comment, err := useCase.Execute(context.Background(), "task-1", "user-1", "@name.of.user-2 e @cris@example.com, confiram")
+	if err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+
+	// Cris turned mentions off: she is mentioned, but neither notified nor emailed
+	if !slices.Equal(comment.Mentions, []string{"user-2", "user-5"}) {
+		t.Errorf("Mentions = %v, want user-2 and user-5", comment.Mentions)
+	}
+	if len(notificationRepo.notifications) != 1 || notificationRepo.notifications[0].UserID != "user-2" {
+		t.Errorf("Expected only user-2 notified, got %+v", notificationRepo.notifications)
+	}
+	if len(jobRepo.jobs) != 1 || jobRepo.jobs[0].Type != MentionEmailJobType {
+		t.Fatalf("Expected one mention email queued, got %+v", jobRepo.jobs)
+	}
+	var email mentionEmail
+	if err := json.Unmarshal(jobRepo.jobs[0].Payload, &email); err != nil {
+		t.Fatalf("Invalid payload: %v", err)
+	}
+	want := mentionEmail{UserID: "user-2", TaskID: "task-1", TaskTitle: "Pagar contas", AuthorName: "Ana Souza", Body: comment.Body}
+	if email != want {
+		t.Errorf("payload = %+v, want %+v", email, want)
+	}
+}
+
+func TestSendMentionEmailUseCase_Execute(t *testing.T) {
+	users := &mockUserRepositoryForLogin{users: map[string]*application.User{
+		"user-2": {ID: "user-2", Name: "Bia", Email: "bia@example.com"},
+	}}
+	mailer := &mockMailer{}
+	uc := NewSendMentionEmailUseCase(users, mailer)
+
+	payload, _ := json.Marshal(mentionEmail{UserID: "user-2", TaskID: "task-1", TaskTitle: "Pagar contas", AuthorName: "Ana Souza", Body: "@bia.souza pode pagar?"})
+	if err := uc.Execute(context.Background(), payload); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if len(mailer.sent) != 1 {
+		t.Fatalf("Expected one email, got %d", len(mailer.sent))
+	}
+	sent := mailer.sent[0]
+	if sent.to != "bia@example.com" || sent.subject != `Ana Souza mencionou você em "Pagar contas"` || !strings.Contains(sent.body, "@bia.souza pode pagar?") {
+		t.Errorf("Unexpected email %+v", sent)
+	}
+
+	// A failure to send is returned, so the job is retried
+	mailer.err = errors.New("smtp down")
+	if err := uc.Execute(context.Background(), payload); err == nil {
+		t.Error("Expected the mailer error")
+	}
+
+	// A deleted user gets nothing
+	mailer.err = nil
+	gone, _ := json.Marshal(mentionEmail{UserID: "user-9", TaskID: "task-1"})
+	if err := uc.Execute(context.Background(), gone); err != nil || len(mailer.sent) != 1 {
+		t.Errorf("Execute() for a deleted user = %v after %d emails, want nil and no email", err, len(mailer.sent))
+	}
+}
+
+func TestAddCommentUseCase_Execute_MentionsCapped(t *testing.T) {
+	task, _ := application.NewTask("task-1", "Pagar contas", "", application.StatusPending, "user-1", "")
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{}}
+	userRepo := &mockUserRepositoryForLogin{users: map[string]*application.User{
+		"user-1": {ID: "user-1", Name: "Ana Souza", Email: "ana@example.com"},
+	}}
+	taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"user-1": true}}
+	notificationRepo := &mockNotificationRepository{}
+	useCase := NewAddCommentUseCase(&mockCommentRepository{}, taskRepo, shareRepo, userRepo, notificationRepo, newMockUserSettingsRepository(), &mockTransactor{}, taskService, nil)
+
+	var body strings.Builder
+	for i := 0; i < application.MaxMentionsPerComment+5; i++ {
+		id := "member-" + string(rune('a'+i))
+		userRepo.users[id] = &application.User{ID: id, Name: id, Email: id + "@example.com"}
+		taskService.viewers[id] = true
+		body.WriteString("@" + id + "@example.com ")
+	}
+
+	comment, err := useCase.Execute(context.Background(), "task-1", "user-1", body.String())
+	if err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if len(comment.Mentions) != application.MaxMentionsPerComment || len(notificationRepo.notifications) != application.MaxMentionsPerComment {
+		t.Errorf("Expected %d users mentioned and notified, got %d and %d", application.MaxMentionsPerComment, len(comment.Mentions), len(notificationRepo.notifications))
+	}
+}
+
+func TestAddCommentUseCase_Execute_Errors(t *testing.T) {
+	task, _ := application.NewTask("task-1", "Pagar contas", "", application.StatusPending, "user-1", "")
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{}}
+	userRepo := &mockUserRepositoryForLogin{users: map[string]*application.User{
+		"user-1": {ID: "user-1", Name: "Ana Souza", Email: "ana@example.com"},
+		"user-3": {ID: "user-3", Name: "Bia", Email: "bia@example.com"},
+	}}
+	taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"user-1": true}}
+	commentRepo := &mockCommentRepository{}
+	notificationRepo := &mockNotificationRepository{}
+	useCase := NewAddCommentUseCase(commentRepo, taskRepo, shareRepo, userRepo, notificationRepo, newMockUserSettingsRepository(), &mockTransactor{}, taskService, nil)
+
+	tests := []struct {
+		name    string
+		taskID  string
+		userID  string
+		body    string
+		wantErr error
+	}{
+		{"user without access", "task-1", "user-3", "@ana.souza oi", ErrTaskUnavailable},
+		{"missing task", "task-9", "user-1", "oi", ErrTaskUnavailable},
+		{"blank comment", "task-1", "user-1", "  ", application.ErrCommentEmpty},
+		{"comment too long", "task-1", "user-1", strings.Repeat("a", application.MaxCommentLength+1), application.ErrCommentTooLong},
+	}
+
+	for _, tt := range tests {
+		if _, err := useCase.Execute(context.Background(), tt.taskID, tt.userID, tt.body); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: Execute() error = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+	if len(commentRepo.comments) != 0 || len(notificationRepo.notifications) != 0 {
+		t.Errorf("Expected nothing saved, got %d comments and %d notifications", len(commentRepo.comments), len(notificationRepo.notifications))
+	}
+}
+
+func TestListCommentsUseCase_Execute(t *testing.T) {
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": {ID: "task-1", OwnerID: "user-1"}}}
+	taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"user-1": true, "user-2": true, "user-5": true}}
+	commentRepo := &mockCommentRepository{comments: []*application.Comment{
+		{ID: "c1", TaskID: "task-1", UserID: "user-2", Body: "primeiro"},
+		{ID: "c2", TaskID: "task-2", UserID: "user-2", Body: "de outra tarefa"},
+		{ID: "c3", TaskID: "task-1", UserID: "user-2", Body: "segundo"},
+	}}
+	useCase := NewListCommentsUseCase(commentRepo, taskRepo, taskService)
+
+	comments, err := useCase.Execute(context.Background(), "task-1", "user-5")
+	if err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if len(comments) != 2 || comments[0].Body != "primeiro" || comments[1].Body != "segundo" {
+		t.Errorf("Expected both comments of the task in order, got %v", comments)
+	}
+
+	if _, err := useCase.Execute(context.Background(), "task-1", "user-3"); !errors.Is(err, ErrTaskUnavailable) {
+		t.Errorf("Expected ErrTaskUnavailable for a user without access, got %v", err)
+	}
+}
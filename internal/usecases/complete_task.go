@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
 	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
 )
 
@@ -16,27 +17,40 @@ type TaskServiceInterface interface {
 
 // CompleteTaskUseCase handles completing a task
 type CompleteTaskUseCase struct {
-	taskRepo    repository.TaskRepository
-	taskService TaskServiceInterface
+	taskRepo       repository.TaskRepository
+	dependencyRepo repository.DependencyRepository
+	taskService    TaskServiceInterface
+	events         event.Publisher
+	revisions      *TaskRevisions
 }
 
 // NewCompleteTaskUseCase creates a new CompleteTaskUseCase
 func NewCompleteTaskUseCase(
 	taskRepo repository.TaskRepository,
+	dependencyRepo repository.DependencyRepository,
 	taskService TaskServiceInterface,
+	events event.Publisher,
+	revisions *TaskRevisions,
 ) *CompleteTaskUseCase {
 	return &CompleteTaskUseCase{
-		taskRepo:    taskRepo,
-		taskService: taskService,
+		taskRepo:       taskRepo,
+		dependencyRepo: dependencyRepo,
+		taskService:    taskService,
+		events:         events,
+		revisions:      revisions,
 	}
 }
 
-// Execute completes a task and returns the updated task
-func (uc *CompleteTaskUseCase) Execute(ctx context.Context, taskID, userID string) (*application.Task, error) {
+// Execute completes a task with an optional note on how it was done and returns the updated
+// task. It returns application.ErrTaskBlocked while the task has blockers that aren't completed.
+func (uc *CompleteTaskUseCase) Execute(ctx context.Context, taskID, userID, note string) (_ *application.Task, err error) {
+	ctx, end := tracer.Start(ctx, "CompleteTask")
+	defer func() { end(err) }()
+
 	// Find the task
 	task, err := uc.taskRepo.FindByID(ctx, taskID)
 	if err != nil {
-		return nil, errors.New("task not found")
+		return nil, err
 	}
 
 	// Check if user can modify the task (must be owner)
@@ -48,15 +62,29 @@ func (uc *CompleteTaskUseCase) Execute(ctx context.Context, taskID, userID strin
 		return nil, errors.New("user does not have permission to modify this task")
 	}
 
-	// Complete the task
+	// Complete the task, once nothing blocks it
+	if task.Status != application.StatusCompleted {
+		if err := checkNotBlocked(ctx, uc.dependencyRepo, taskID); err != nil {
+			return nil, err
+		}
+	}
+	revision := uc.revisions.before(task, userID)
 	if err := task.CompleteTask(); err != nil {
 		return nil, err
 	}
+	if err := task.SetCompletionNote(note); err != nil {
+		return nil, err
+	}
 
 	// Update in repository
 	if err := uc.taskRepo.Update(ctx, task); err != nil {
 		return nil, err
 	}
+	if err := uc.revisions.record(ctx, revision, task); err != nil {
+		return nil, err
+	}
+
+	uc.events.Publish(ctx, event.TaskCompleted{TaskEvent: event.NewTaskEvent(task, userID)})
 
 	return task, nil
 }
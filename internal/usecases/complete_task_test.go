@@ -3,6 +3,7 @@ package usecases
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
@@ -34,7 +35,7 @@ func (m *mockTaskRepositoryForComplete) Delete(ctx context.Context, id string) e
 func (m *mockTaskRepositoryForComplete) FindByID(ctx context.Context, id string) (*application.Task, error) {
 	task, exists := m.tasks[id]
 	if !exists {
-		return nil, errors.New("task not found")
+		return nil, application.ErrTaskNotFound
 	}
 	return task, nil
 }
@@ -53,6 +54,18 @@ func (m *mockTaskRepositoryForComplete) FindSharedWithUser(ctx context.Context,
 	return []*application.Task{}, nil
 }
 
+func (m *mockTaskRepositoryForComplete) FindByProjectID(ctx context.Context, projectID string) ([]*application.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskRepositoryForComplete) FindByImagePath(ctx context.Context, imagePath string) ([]*application.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskRepositoryForComplete) CreateMany(ctx context.Context, tasks []*application.Task) error {
+	return nil
+}
+
 type mockTaskServiceForComplete struct {
 	canAccess bool
 	canModify bool
@@ -71,10 +84,12 @@ func TestCompleteTaskUseCase_Execute(t *testing.T) {
 		name        string
 		taskID      string
 		userID      string
+		note        string
 		setupTask   func(*mockTaskRepositoryForComplete)
 		canModify   bool
 		wantErr     bool
 		wantStatus  application.TaskStatus
+		wantNote    string
 		errorMsg    string
 	}{
 		{
@@ -101,6 +116,33 @@ func TestCompleteTaskUseCase_Execute(t *testing.T) {
 			wantErr:    false,
 			wantStatus: application.StatusCompleted,
 		},
+		{
+			name:   "should complete task with a note",
+			taskID: "task-5",
+			userID: "user-1",
+			note:   "  Entregue ao cliente ",
+			setupTask: func(repo *mockTaskRepositoryForComplete) {
+				task, _ := application.NewTask("task-5", "Test Task", "Description", application.StatusPending, "user-1", "")
+				repo.tasks["task-5"] = task
+			},
+			canModify:  true,
+			wantErr:    false,
+			wantStatus: application.StatusCompleted,
+			wantNote:   "Entregue ao cliente",
+		},
+		{
+			name:   "should fail if the note is too long",
+			taskID: "task-6",
+			userID: "user-1",
+			note:   strings.Repeat("a", 501),
+			setupTask: func(repo *mockTaskRepositoryForComplete) {
+				task, _ := application.NewTask("task-6", "Test Task", "Description", application.StatusPending, "user-1", "")
+				repo.tasks["task-6"] = task
+			},
+			canModify: true,
+			wantErr:   true,
+			errorMsg:  "completion note cannot exceed 500 characters",
+		},
 		{
 			name:   "should fail if task not found",
 			taskID: "nonexistent",
@@ -149,8 +191,8 @@ func TestCompleteTaskUseCase_Execute(t *testing.T) {
 				canModify: tt.canModify,
 			}
 
-			useCase := NewCompleteTaskUseCase(mockRepo, mockService)
-			task, err := useCase.Execute(context.Background(), tt.taskID, tt.userID)
+			useCase := NewCompleteTaskUseCase(mockRepo, &mockDependencyRepository{}, mockService, &recordingPublisher{}, NewTaskRevisions(nil, 0))
+			task, err := useCase.Execute(context.Background(), tt.taskID, tt.userID, tt.note)
 
 			if tt.wantErr {
 				if err == nil {
@@ -180,6 +222,9 @@ func TestCompleteTaskUseCase_Execute(t *testing.T) {
 			if task.Status != tt.wantStatus {
 				t.Errorf("Execute() task status = %v, want %v", task.Status, tt.wantStatus)
 			}
+			if task.CompletionNote != tt.wantNote {
+				t.Errorf("Execute() completion note = %q, want %q", task.CompletionNote, tt.wantNote)
+			}
 		})
 	}
 }
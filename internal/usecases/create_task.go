@@ -2,39 +2,73 @@ package usecases
 
 import (
 	"context"
+	"time"
 
-	"github.com/google/uuid"
 	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
 	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
 )
 
 // CreateTaskUseCase handles task creation
 type CreateTaskUseCase struct {
-	taskRepo repository.TaskRepository
+	taskRepo    repository.TaskRepository
+	projectRepo repository.ProjectRepository
+	events      event.Publisher
+	ids         IDGenerator
+	quota       CreationQuotaUseCaseInterface
 }
 
-// NewCreateTaskUseCase creates a new CreateTaskUseCase
-func NewCreateTaskUseCase(taskRepo repository.TaskRepository) *CreateTaskUseCase {
+// NewCreateTaskUseCase creates a new CreateTaskUseCase charging the tasks to quota (nil for no
+// quota)
+func NewCreateTaskUseCase(taskRepo repository.TaskRepository, projectRepo repository.ProjectRepository, events event.Publisher, ids IDGenerator, quota CreationQuotaUseCaseInterface) *CreateTaskUseCase {
 	return &CreateTaskUseCase{
-		taskRepo: taskRepo,
+		taskRepo:    taskRepo,
+		projectRepo: projectRepo,
+		events:      events,
+		ids:         ids,
+		quota:       quota,
 	}
 }
 
-// Execute creates a new task
-func (uc *CreateTaskUseCase) Execute(ctx context.Context, title, description, ownerID, imagePath string) (*application.Task, error) {
+// Execute creates a new task; dueDate, projectID and color are optional. The project must
+// belong to the owner. It returns *application.CreationQuotaExceededError when the owner used up their
+// daily task quota.
+func (uc *CreateTaskUseCase) Execute(ctx context.Context, title, description, ownerID, imagePath string, dueDate *time.Time, projectID string, color application.TaskColor) (_ *application.Task, err error) {
+	ctx, end := tracer.Start(ctx, "CreateTask")
+	defer func() { end(err) }()
+
 	// Generate unique ID
-	id := uuid.New().String()
+	id := uc.ids.NewID()
 
 	// Create task entity with validation
 	task, err := application.NewTask(id, title, description, application.StatusPending, ownerID, imagePath)
 	if err != nil {
 		return nil, err
 	}
+	if err := task.SetDueDate(dueDate); err != nil {
+		return nil, err
+	}
+	if err := task.SetColor(color); err != nil {
+		return nil, err
+	}
+	if err := checkProjectOwner(ctx, uc.projectRepo, projectID, ownerID); err != nil {
+		return nil, err
+	}
+	task.SetProject(projectID)
+	inOrgScope(ctx, task)
+
+	usageID, err := consumeCreation(ctx, uc.quota, ownerID, application.CreationTask, 1)
+	if err != nil {
+		return nil, err
+	}
 
 	// Persist task
 	if err := uc.taskRepo.Create(ctx, task); err != nil {
+		releaseCreation(ctx, uc.quota, usageID)
 		return nil, err
 	}
 
+	uc.events.Publish(ctx, event.TaskCreated{TaskEvent: event.NewTaskEvent(task, ownerID)})
+
 	return task, nil
 }
@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
@@ -12,7 +13,7 @@ func TestCreateTaskUseCase_Execute(t *testing.T) {
 		tasks: make(map[string]*application.Task),
 	}
 
-	useCase := NewCreateTaskUseCase(mockRepo)
+	useCase := NewCreateTaskUseCase(mockRepo, nil, &recordingPublisher{}, &sequentialIDs{}, nil)
 
 	tests := []struct {
 		name        string
@@ -58,7 +59,7 @@ func TestCreateTaskUseCase_Execute(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			task, err := useCase.Execute(context.Background(), tt.title, tt.description, tt.ownerID, tt.imagePath)
+			task, err := useCase.Execute(context.Background(), tt.title, tt.description, tt.ownerID, tt.imagePath, nil, "", "")
 
 			if tt.wantErr {
 				if err == nil {
@@ -92,10 +93,23 @@ func TestCreateTaskUseCase_Execute(t *testing.T) {
 			if task.Status != application.StatusPending {
 				t.Errorf("Task.Status = %v, want %v", task.Status, application.StatusPending)
 			}
+			if mockRepo.tasks[task.ID] != task || task.ID[:3] != "id-" {
+				t.Errorf("Task.ID = %q, want an ID of the generator", task.ID)
+			}
 		})
 	}
 }
 
+// sequentialIDs is an IDGenerator returning id-1, id-2, ...
+type sequentialIDs struct {
+	n int
+}
+
+func (g *sequentialIDs) NewID() string {
+	g.n++
+	return fmt.Sprintf("id-%d", g.n)
+}
+
 // Mock repository
 type mockTaskRepository struct {
 	tasks map[string]*application.Task
@@ -117,7 +131,11 @@ func (m *mockTaskRepository) Delete(ctx context.Context, id string) error {
 }
 
 func (m *mockTaskRepository) FindByID(ctx context.Context, id string) (*application.Task, error) {
-	return m.tasks[id], nil
+	task, ok := m.tasks[id]
+	if !ok {
+		return nil, application.ErrTaskNotFound
+	}
+	return task, nil
 }
 
 func (m *mockTaskRepository) FindByOwnerID(ctx context.Context, ownerID string) ([]*application.Task, error) {
@@ -127,3 +145,18 @@ func (m *mockTaskRepository) FindByOwnerID(ctx context.Context, ownerID string)
 func (m *mockTaskRepository) FindSharedWithUser(ctx context.Context, userID string) ([]*application.Task, error) {
 	return nil, nil
 }
+
+func (m *mockTaskRepository) FindByProjectID(ctx context.Context, projectID string) ([]*application.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskRepository) FindByImagePath(ctx context.Context, imagePath string) ([]*application.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskRepository) CreateMany(ctx context.Context, tasks []*application.Task) error {
+	for _, task := range tasks {
+		m.tasks[task.ID] = task
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// CreationQuotaWindow is the window of the creation quotas: a user may create up to the limit
+// of each kind in any 24 hours
+const CreationQuotaWindow = 24 * time.Hour
+
+// CreationLimits are how many tasks and images each user may create per window. A limit of
+// zero or less is not enforced.
+type CreationLimits struct {
+	Tasks  int
+	Images int
+}
+
+// CreationQuotaUseCase enforces the per-user creation quotas, so a compromised account can't
+// fill the database and the disk. Unlike the HTTP rate limit it counts what is created, not
+// requests, and survives restarts.
+type CreationQuotaUseCase struct {
+	usageRepo repository.CreationUsageRepository
+	limits    CreationLimits
+	window    time.Duration
+	now       func() time.Time
+}
+
+// NewCreationQuotaUseCase creates a new CreationQuotaUseCase allowing limits per CreationQuotaWindow
+func NewCreationQuotaUseCase(usageRepo repository.CreationUsageRepository, limits CreationLimits) *CreationQuotaUseCase {
+	return &CreationQuotaUseCase{
+		usageRepo: usageRepo,
+		limits:    limits,
+		window:    CreationQuotaWindow,
+		now:       time.Now,
+	}
+}
+
+// limit returns the limit of a kind
+func (uc *CreationQuotaUseCase) limit(kind application.CreationKind) int {
+	if kind == application.CreationImage {
+		return uc.limits.Images
+	}
+	return uc.limits.Tasks
+}
+
+// Consume takes count creations of kind from the user's quota and returns the usage ID, which
+// can be passed to Release if the creation fails. It returns
+// *application.CreationQuotaExceededError when they don't fit the quota; the usage ID is empty
+// when the kind has no quota.
+func (uc *CreationQuotaUseCase) Consume(ctx context.Context, userID string, kind application.CreationKind, count int) (string, error) {
+	limit := uc.limit(kind)
+	if limit <= 0 {
+		return "", nil
+	}
+
+	usage, err := application.NewCreationUsage(uuid.New().String(), userID, kind, count)
+	if err != nil {
+		return "", err
+	}
+	usage.CreatedAt = uc.now()
+	since := usage.CreatedAt.Add(-uc.window)
+
+	// Expired records are never counted again
+	if err := uc.usageRepo.DeleteBefore(ctx, since); err != nil {
+		return "", fmt.Errorf("failed to prune creation usage: %w", err)
+	}
+
+	allowed, err := uc.usageRepo.CreateIfBelowLimit(ctx, usage, since, limit)
+	if err != nil {
+		return "", fmt.Errorf("failed to record creation usage: %w", err)
+	}
+	if allowed {
+		return usage.ID, nil
+	}
+
+	// Some of the quota frees up when the oldest usage in the window expires
+	oldest, err := uc.usageRepo.FindOldestSince(ctx, userID, kind, since)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve creation usage: %w", err)
+	}
+	retryAt := usage.CreatedAt.Add(uc.window)
+	if !oldest.IsZero() {
+		retryAt = oldest.Add(uc.window)
+	}
+
+	return "", &application.CreationQuotaExceededError{
+		Kind:    kind,
+		Limit:   limit,
+		Window:  uc.window,
+		RetryAt: retryAt,
+	}
+}
+
+// Release gives back the creations taken by Consume
+func (uc *CreationQuotaUseCase) Release(ctx context.Context, usageID string) error {
+	if usageID == "" {
+		return nil
+	}
+	return uc.usageRepo.Delete(ctx, usageID)
+}
+
+// consumeCreation takes count creations of kind from the quota of userID; a nil quota allows
+// everything
+func consumeCreation(ctx context.Context, quota CreationQuotaUseCaseInterface, userID string, kind application.CreationKind, count int) (string, error) {
+	if quota == nil {
+		return "", nil
+	}
+	return quota.Consume(ctx, userID, kind, count)
+}
+
+// releaseCreation gives back the creations of a usage that failed. The failure being reported
+// matters more than the quota, so an error releasing it is dropped.
+func releaseCreation(ctx context.Context, quota CreationQuotaUseCaseInterface, usageID string) {
+	if quota != nil && usageID != "" {
+		_ = quota.Release(context.WithoutCancel(ctx), usageID)
+	}
+}
@@ -0,0 +1,173 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// mockCreationUsageRepository is an in-memory CreationUsageRepository
+type mockCreationUsageRepository struct {
+	usages map[string]*application.CreationUsage
+}
+
+func newMockCreationUsageRepository() *mockCreationUsageRepository {
+	return &mockCreationUsageRepository{usages: make(map[string]*application.CreationUsage)}
+}
+
+func (m *mockCreationUsageRepository) CreateIfBelowLimit(ctx context.Context, usage *application.CreationUsage, since time.Time, limit int) (bool, error) {
+	count := 0
+	for _, u := range m.usages {
+		if u.UserID == usage.UserID && u.Kind == usage.Kind && u.CreatedAt.After(since) {
+			count += u.Count
+		}
+	}
+	if count+usage.Count > limit {
+		return false, nil
+	}
+	m.usages[usage.ID] = usage
+	return true, nil
+}
+
+func (m *mockCreationUsageRepository) FindOldestSince(ctx context.Context, userID string, kind application.CreationKind, since time.Time) (time.Time, error) {
+	var oldest time.Time
+	for _, u := range m.usages {
+		if u.UserID == userID && u.Kind == kind && u.CreatedAt.After(since) && (oldest.IsZero() || u.CreatedAt.Before(oldest)) {
+			oldest = u.CreatedAt
+		}
+	}
+	return oldest, nil
+}
+
+func (m *mockCreationUsageRepository) Delete(ctx context.Context, id string) error {
+	delete(m.usages, id)
+	return nil
+}
+
+func (m *mockCreationUsageRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	for id, u := range m.usages {
+		if !u.CreatedAt.After(before) {
+			delete(m.usages, id)
+		}
+	}
+	return nil
+}
+
+func TestCreationQuotaUseCase_Consume(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	now := start
+
+	useCase := NewCreationQuotaUseCase(newMockCreationUsageRepository(), CreationLimits{Tasks: 5, Images: 1})
+	useCase.now = func() time.Time { return now }
+
+	// An import of 3 tasks and 2 single tasks an hour apart use up the quota
+	for _, count := range []int{3, 1, 1} {
+		if _, err := useCase.Consume(ctx, "user-1", application.CreationTask, count); err != nil {
+			t.Fatalf("Consume(%d): unexpected error: %v", count, err)
+		}
+		now = now.Add(time.Hour)
+	}
+
+	_, err := useCase.Consume(ctx, "user-1", application.CreationTask, 1)
+	var exceeded *application.CreationQuotaExceededError
+	if !errors.As(err, &exceeded) {
+		t.Fatalf("expected CreationQuotaExceededError, got %v", err)
+	}
+	if want := start.Add(24 * time.Hour); !exceeded.RetryAt.Equal(want) || exceeded.Kind != application.CreationTask || exceeded.Limit != 5 {
+		t.Errorf("got %+v, want the task limit retrying at %v (oldest usage + window)", exceeded, want)
+	}
+
+	// Images and other users have their own quota
+	if _, err := useCase.Consume(ctx, "user-1", application.CreationImage, 1); err != nil {
+		t.Errorf("expected the image to be allowed, got %v", err)
+	}
+	if _, err := useCase.Consume(ctx, "user-2", application.CreationTask, 5); err != nil {
+		t.Errorf("expected other user to be allowed, got %v", err)
+	}
+
+	// Once the import leaves the window its tasks can be created again
+	now = start.Add(24*time.Hour + time.Second)
+	if _, err := useCase.Consume(ctx, "user-1", application.CreationTask, 3); err != nil {
+		t.Errorf("expected quota to be freed, got %v", err)
+	}
+}
+
+func TestCreationQuotaUseCase_Release(t *testing.T) {
+	ctx := context.Background()
+	useCase := NewCreationQuotaUseCase(newMockCreationUsageRepository(), CreationLimits{Tasks: 1})
+
+	usageID, err := useCase.Consume(ctx, "user-1", application.CreationTask, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := useCase.Consume(ctx, "user-1", application.CreationTask, 1); err == nil {
+		t.Fatal("expected quota to be exceeded")
+	}
+
+	if err := useCase.Release(ctx, usageID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := useCase.Consume(ctx, "user-1", application.CreationTask, 1); err != nil {
+		t.Errorf("expected released task to be available, got %v", err)
+	}
+
+	// Without a limit nothing is recorded
+	if usageID, err := useCase.Consume(ctx, "user-1", application.CreationImage, 100); err != nil || usageID != "" {
+		t.Errorf("Consume() without image limit = %q, %v; want no usage", usageID, err)
+	}
+}
+
+func TestCreateTaskUseCase_Execute_CreationQuota(t *testing.T) {
+	taskRepo := &mockTaskRepository{tasks: map[string]*application.Task{}}
+	quota := NewCreationQuotaUseCase(newMockCreationUsageRepository(), CreationLimits{Tasks: 1})
+	useCase := NewCreateTaskUseCase(taskRepo, nil, &recordingPublisher{}, &sequentialIDs{}, quota)
+	ctx := context.Background()
+
+	// An invalid task doesn't count
+	if _, err := useCase.Execute(ctx, "", "", "user-1", "", nil, "", ""); err == nil {
+		t.Fatal("expected the empty title to be rejected")
+	}
+	if _, err := useCase.Execute(ctx, "Primeira", "", "user-1", "", nil, "", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := useCase.Execute(ctx, "Segunda", "", "user-1", "", nil, "", "")
+	var exceeded *application.CreationQuotaExceededError
+	if !errors.As(err, &exceeded) {
+		t.Fatalf("expected CreationQuotaExceededError, got %v", err)
+	}
+	if len(taskRepo.tasks) != 1 {
+		t.Errorf("expected only the first task to be created, got %d", len(taskRepo.tasks))
+	}
+}
+
+func TestImportTasksUseCase_Execute_CreationQuota(t *testing.T) {
+	taskRepo := newMockTaskRepositoryForImport()
+	quota := NewCreationQuotaUseCase(newMockCreationUsageRepository(), CreationLimits{Tasks: 2})
+	useCase := NewImportTasksUseCase(taskRepo, &recordingPublisher{}, &sequentialIDs{}, quota)
+	rows := []ImportRow{{Line: 2, Title: "Um"}, {Line: 3, Title: "Dois"}, {Line: 4, Title: "Três"}}
+
+	// The whole import is rejected, not just the rows over the quota
+	_, err := useCase.Execute(context.Background(), "user-1", rows, time.UTC, false)
+	var exceeded *application.CreationQuotaExceededError
+	if !errors.As(err, &exceeded) {
+		t.Fatalf("expected CreationQuotaExceededError, got %v", err)
+	}
+	if len(taskRepo.tasks) != 0 {
+		t.Errorf("expected no task to be imported, got %d", len(taskRepo.tasks))
+	}
+
+	// A failed import gives its tasks back
+	taskRepo.createErr = errors.New("disk full")
+	if _, err := useCase.Execute(context.Background(), "user-1", rows[:2], time.UTC, false); err == nil {
+		t.Fatal("expected the repository error")
+	}
+	taskRepo.createErr = nil
+	if report, err := useCase.Execute(context.Background(), "user-1", rows[:2], time.UTC, false); err != nil || report.Created != 2 {
+		t.Errorf("Execute() = %+v, %v; want 2 tasks created", report, err)
+	}
+}
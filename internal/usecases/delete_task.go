@@ -4,35 +4,104 @@ import (
 	"context"
 	"errors"
 
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
 	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
 	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
 )
 
+// DeletedTaskFiles lists the files a deleted task leaves on disk, to be removed by the
+// caller once the deletion is committed
+type DeletedTaskFiles struct {
+	ImagePath       string
+	AttachmentPaths []string
+}
+
 // DeleteTaskUseCase handles task deletion
 type DeleteTaskUseCase struct {
-	taskRepo    repository.TaskRepository
-	taskService *service.TaskService
+	taskRepo       repository.TaskRepository
+	shareRepo      repository.ShareRepository
+	attachmentRepo repository.AttachmentRepository
+	transactor     repository.Transactor
+	taskService    *service.TaskService
+	events         event.Publisher
 }
 
 // NewDeleteTaskUseCase creates a new DeleteTaskUseCase
-func NewDeleteTaskUseCase(taskRepo repository.TaskRepository, taskService *service.TaskService) *DeleteTaskUseCase {
+func NewDeleteTaskUseCase(
+	taskRepo repository.TaskRepository,
+	shareRepo repository.ShareRepository,
+	attachmentRepo repository.AttachmentRepository,
+	transactor repository.Transactor,
+	taskService *service.TaskService,
+	events event.Publisher,
+) *DeleteTaskUseCase {
 	return &DeleteTaskUseCase{
-		taskRepo:    taskRepo,
-		taskService: taskService,
+		taskRepo:       taskRepo,
+		shareRepo:      shareRepo,
+		attachmentRepo: attachmentRepo,
+		transactor:     transactor,
+		taskService:    taskService,
+		events:         events,
 	}
 }
 
-// Execute deletes a task
-func (uc *DeleteTaskUseCase) Execute(ctx context.Context, taskID, userID string) error {
+// Execute deletes a task with its shares and attachment records in a single transaction
+// and returns the files left behind for cleanup
+func (uc *DeleteTaskUseCase) Execute(ctx context.Context, taskID, userID string) (_ *DeletedTaskFiles, err error) {
+	ctx, end := tracer.Start(ctx, "DeleteTask")
+	defer func() { end(err) }()
+
+	// Snapshot the task for the event and the cleanup before it is gone
+	task, err := uc.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if user can modify (delete) task
 	canModify, err := uc.taskService.CanUserModifyTask(ctx, taskID, userID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !canModify {
-		return errors.New("user does not have permission to delete this task")
+		return nil, errors.New("user does not have permission to delete this task")
+	}
+
+	var files *DeletedTaskFiles
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		files, err = uc.delete(ctx, task)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.events.Publish(ctx, event.TaskDeleted{TaskEvent: event.NewTaskEvent(task, userID)})
+
+	return files, nil
+}
+
+// delete deletes a task with its shares and attachment records, in the transaction carried by
+// ctx, and returns the files left behind
+func (uc *DeleteTaskUseCase) delete(ctx context.Context, task *application.Task) (*DeletedTaskFiles, error) {
+	files := &DeletedTaskFiles{ImagePath: task.ImagePath}
+
+	attachments, err := uc.attachmentRepo.FindByTaskID(ctx, task.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, attachment := range attachments {
+		files.AttachmentPaths = append(files.AttachmentPaths, attachment.Path)
+	}
+
+	if err := uc.shareRepo.DeleteAllShares(ctx, task.ID); err != nil {
+		return nil, err
+	}
+
+	// Attachment records go with the task (ON DELETE CASCADE)
+	if err := uc.taskRepo.Delete(ctx, task.ID); err != nil {
+		return nil, err
 	}
 
-	// Delete task
-	return uc.taskRepo.Delete(ctx, taskID)
+	return files, nil
 }
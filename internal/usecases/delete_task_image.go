@@ -29,7 +29,7 @@ func (uc *DeleteTaskImageUseCase) Execute(ctx context.Context, taskID, userID st
 	// Find the task
 	task, err := uc.taskRepo.FindByID(ctx, taskID)
 	if err != nil {
-		return "", errors.New("task not found")
+		return "", err
 	}
 
 	// Check if user can modify the task (must be owner)
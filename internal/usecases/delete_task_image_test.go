@@ -34,7 +34,7 @@ func (m *mockTaskRepositoryForDeleteImage) Delete(ctx context.Context, id string
 func (m *mockTaskRepositoryForDeleteImage) FindByID(ctx context.Context, id string) (*application.Task, error) {
 	task, exists := m.tasks[id]
 	if !exists {
-		return nil, errors.New("task not found")
+		return nil, application.ErrTaskNotFound
 	}
 	return task, nil
 }
@@ -53,6 +53,24 @@ func (m *mockTaskRepositoryForDeleteImage) FindSharedWithUser(ctx context.Contex
 	return []*application.Task{}, nil
 }
 
+func (m *mockTaskRepositoryForDeleteImage) FindByProjectID(ctx context.Context, projectID string) ([]*application.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskRepositoryForDeleteImage) FindByImagePath(ctx context.Context, imagePath string) ([]*application.Task, error) {
+	var tasks []*application.Task
+	for _, task := range m.tasks {
+		if task.ImagePath == imagePath {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+func (m *mockTaskRepositoryForDeleteImage) CreateMany(ctx context.Context, tasks []*application.Task) error {
+	return nil
+}
+
 type mockTaskServiceForDeleteImage struct {
 	canModify bool
 }
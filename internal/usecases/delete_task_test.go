@@ -0,0 +1,109 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// mockTransactor runs units of work directly, counting them
+type mockTransactor struct {
+	calls int
+}
+
+func (m *mockTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.calls++
+	return fn(ctx)
+}
+
+// failingShareRepository fails to delete shares
+type failingShareRepository struct {
+	mockShareRepositoryForShare
+}
+
+func (m *failingShareRepository) DeleteAllShares(ctx context.Context, taskID string) error {
+	return errors.New("database is locked")
+}
+
+// newDeleteTaskUseCase builds a DeleteTaskUseCase without attachments
+func newDeleteTaskUseCase(taskRepo repository.TaskRepository, shareRepo repository.ShareRepository, taskService *service.TaskService, publisher *recordingPublisher) *DeleteTaskUseCase {
+	attachmentRepo := &mockAttachmentRepository{attachments: map[string]*application.Attachment{}}
+	return NewDeleteTaskUseCase(taskRepo, shareRepo, attachmentRepo, &mockTransactor{}, taskService, publisher)
+}
+
+func TestDeleteTaskUseCase_Execute(t *testing.T) {
+	task, _ := application.NewTask("task-1", "Relatório mensal", "", application.StatusPending, "user-1", "")
+	task.ImagePath = "/uploads/images/abc.png"
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-2"}}}
+	taskService := service.NewTaskService(taskRepo, shareRepo)
+	attachmentRepo := &mockAttachmentRepository{attachments: map[string]*application.Attachment{
+		"att-1": {ID: "att-1", TaskID: "task-1", Path: "one.pdf"},
+		"att-2": {ID: "att-2", TaskID: "task-2", Path: "other.pdf"},
+	}}
+	transactor := &mockTransactor{}
+
+	useCase := NewDeleteTaskUseCase(taskRepo, shareRepo, attachmentRepo, transactor, taskService, &recordingPublisher{})
+	files, err := useCase.Execute(context.Background(), "task-1", "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := &DeletedTaskFiles{ImagePath: "/uploads/images/abc.png", AttachmentPaths: []string{"one.pdf"}}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("expected files %+v, got %+v", want, files)
+	}
+	if transactor.calls != 1 {
+		t.Errorf("expected the deletion to run in one transaction, got %d", transactor.calls)
+	}
+	if _, exists := taskRepo.tasks["task-1"]; exists {
+		t.Error("expected the task to be deleted")
+	}
+	if _, exists := shareRepo.shares["task-1"]; exists {
+		t.Error("expected the shares to be deleted")
+	}
+}
+
+func TestDeleteTaskUseCase_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		taskID  string
+		userID  string
+		failing bool
+	}{
+		{name: "not the owner", taskID: "task-1", userID: "user-2"},
+		{name: "missing task", taskID: "missing", userID: "user-1"},
+		{name: "shares cannot be deleted", taskID: "task-1", userID: "user-1", failing: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, _ := application.NewTask("task-1", "Relatório mensal", "", application.StatusPending, "user-1", "")
+			taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+			shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-2"}}}
+			var shares repository.ShareRepository = shareRepo
+			if tt.failing {
+				shares = &failingShareRepository{*shareRepo}
+			}
+			publisher := &recordingPublisher{}
+
+			files, err := newDeleteTaskUseCase(taskRepo, shares, service.NewTaskService(taskRepo, shares), publisher).
+				Execute(context.Background(), tt.taskID, tt.userID)
+
+			if err == nil || files != nil {
+				t.Fatalf("expected an error and no files, got %v and %+v", err, files)
+			}
+			if _, exists := taskRepo.tasks["task-1"]; !exists {
+				t.Error("expected the task to be kept")
+			}
+			if len(publisher.events) != 0 {
+				t.Errorf("expected no events, got %v", publisher.names())
+			}
+		})
+	}
+}
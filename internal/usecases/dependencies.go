@@ -0,0 +1,188 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// ErrDependencyPermissionDenied is returned when a user who doesn't own a task changes its dependencies
+var ErrDependencyPermissionDenied = errors.New("only the task owner can change its dependencies")
+
+// checkNotBlocked returns application.ErrTaskBlocked when a task has blockers that aren't completed
+func checkNotBlocked(ctx context.Context, dependencyRepo repository.DependencyRepository, taskID string) error {
+	blockers, err := dependencyRepo.FindOpenBlockers(ctx, []string{taskID})
+	if err != nil {
+		return err
+	}
+	if len(blockers[taskID]) > 0 {
+		return application.ErrTaskBlocked
+	}
+	return nil
+}
+
+// checkDependencyOwner checks the user can see a task and owns it
+func checkDependencyOwner(ctx context.Context, taskRepo repository.TaskRepository, taskService TaskServiceInterface, taskID, userID string) error {
+	if err := checkTaskAccess(ctx, taskRepo, taskService, taskID, userID, false); err != nil {
+		return err
+	}
+
+	canModify, err := taskService.CanUserModifyTask(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+	if !canModify {
+		return ErrDependencyPermissionDenied
+	}
+	return nil
+}
+
+// AddDependencyUseCase handles marking a task as blocked by another
+type AddDependencyUseCase struct {
+	dependencyRepo repository.DependencyRepository
+	taskRepo       repository.TaskRepository
+	taskService    TaskServiceInterface
+	now            func() time.Time
+}
+
+// NewAddDependencyUseCase creates a new AddDependencyUseCase
+func NewAddDependencyUseCase(
+	dependencyRepo repository.DependencyRepository,
+	taskRepo repository.TaskRepository,
+	taskService TaskServiceInterface,
+) *AddDependencyUseCase {
+	return &AddDependencyUseCase{
+		dependencyRepo: dependencyRepo,
+		taskRepo:       taskRepo,
+		taskService:    taskService,
+		now:            time.Now,
+	}
+}
+
+// Execute marks a task owned by the user as blocked by another task the user can see, and
+// returns the blockers of the task. It returns application.ErrDependencyCycle when the
+// blocker already depends on the task.
+func (uc *AddDependencyUseCase) Execute(ctx context.Context, taskID, blockerID, userID string) ([]*application.Task, error) {
+	if err := checkDependencyOwner(ctx, uc.taskRepo, uc.taskService, taskID, userID); err != nil {
+		return nil, err
+	}
+	if err := checkTaskAccess(ctx, uc.taskRepo, uc.taskService, blockerID, userID, false); err != nil {
+		return nil, err
+	}
+
+	dependency, err := application.NewTaskDependency(taskID, blockerID, uc.now())
+	if err != nil {
+		return nil, err
+	}
+
+	cycle, err := uc.dependencyRepo.IsBlockedBy(ctx, blockerID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if cycle {
+		return nil, application.ErrDependencyCycle
+	}
+
+	blockers, err := uc.dependencyRepo.FindBlockers(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	for _, blocker := range blockers {
+		if blocker.ID == blockerID {
+			return blockers, nil
+		}
+	}
+	if len(blockers) >= application.MaxBlockersPerTask {
+		return nil, application.ErrTooManyBlockers
+	}
+
+	if err := uc.dependencyRepo.Add(ctx, dependency); err != nil {
+		return nil, err
+	}
+
+	return uc.dependencyRepo.FindBlockers(ctx, taskID)
+}
+
+// ListDependenciesUseCase handles listing the tasks blocking a task
+type ListDependenciesUseCase struct {
+	dependencyRepo repository.DependencyRepository
+	taskRepo       repository.TaskRepository
+	taskService    TaskServiceInterface
+}
+
+// NewListDependenciesUseCase creates a new ListDependenciesUseCase
+func NewListDependenciesUseCase(
+	dependencyRepo repository.DependencyRepository,
+	taskRepo repository.TaskRepository,
+	taskService TaskServiceInterface,
+) *ListDependenciesUseCase {
+	return &ListDependenciesUseCase{
+		dependencyRepo: dependencyRepo,
+		taskRepo:       taskRepo,
+		taskService:    taskService,
+	}
+}
+
+// Execute lists the blockers of a task the user can see
+func (uc *ListDependenciesUseCase) Execute(ctx context.Context, taskID, userID string) ([]*application.Task, error) {
+	if err := checkTaskAccess(ctx, uc.taskRepo, uc.taskService, taskID, userID, false); err != nil {
+		return nil, err
+	}
+
+	return uc.dependencyRepo.FindBlockers(ctx, taskID)
+}
+
+// RemoveDependencyUseCase handles unblocking a task from one of its blockers
+type RemoveDependencyUseCase struct {
+	dependencyRepo repository.DependencyRepository
+	taskRepo       repository.TaskRepository
+	taskService    TaskServiceInterface
+}
+
+// NewRemoveDependencyUseCase creates a new RemoveDependencyUseCase
+func NewRemoveDependencyUseCase(
+	dependencyRepo repository.DependencyRepository,
+	taskRepo repository.TaskRepository,
+	taskService TaskServiceInterface,
+) *RemoveDependencyUseCase {
+	return &RemoveDependencyUseCase{
+		dependencyRepo: dependencyRepo,
+		taskRepo:       taskRepo,
+		taskService:    taskService,
+	}
+}
+
+// Execute removes the dependency of a task owned by the user on a blocker
+func (uc *RemoveDependencyUseCase) Execute(ctx context.Context, taskID, blockerID, userID string) error {
+	if err := checkDependencyOwner(ctx, uc.taskRepo, uc.taskService, taskID, userID); err != nil {
+		return err
+	}
+
+	return uc.dependencyRepo.Remove(ctx, taskID, blockerID)
+}
+
+// ListOpenBlockersUseCase handles finding which tasks of a list are blocked
+type ListOpenBlockersUseCase struct {
+	dependencyRepo repository.DependencyRepository
+}
+
+// NewListOpenBlockersUseCase creates a new ListOpenBlockersUseCase
+func NewListOpenBlockersUseCase(dependencyRepo repository.DependencyRepository) *ListOpenBlockersUseCase {
+	return &ListOpenBlockersUseCase{dependencyRepo: dependencyRepo}
+}
+
+// Execute returns the open blockers of tasks the caller already listed for the user, keyed by
+// task ID
+func (uc *ListOpenBlockersUseCase) Execute(ctx context.Context, tasks []*application.Task) (map[string][]*application.Task, error) {
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		if task.Status != application.StatusCompleted {
+			ids = append(ids, task.ID)
+		}
+	}
+
+	return uc.dependencyRepo.FindOpenBlockers(ctx, ids)
+}
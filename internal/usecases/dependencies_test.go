@@ -0,0 +1,206 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// mockDependencyRepository keeps dependencies in memory, looking up blockers in tasks
+type mockDependencyRepository struct {
+	tasks        map[string]*application.Task
+	dependencies []*application.TaskDependency
+}
+
+func (m *mockDependencyRepository) Add(ctx context.Context, dependency *application.TaskDependency) error {
+	for _, d := range m.dependencies {
+		if d.TaskID == dependency.TaskID && d.BlockerID == dependency.BlockerID {
+			return nil
+		}
+	}
+	m.dependencies = append(m.dependencies, dependency)
+	return nil
+}
+
+func (m *mockDependencyRepository) Remove(ctx context.Context, taskID, blockerID string) error {
+	for i, d := range m.dependencies {
+		if d.TaskID == taskID && d.BlockerID == blockerID {
+			m.dependencies = append(m.dependencies[:i], m.dependencies[i+1:]...)
+			return nil
+		}
+	}
+	return application.ErrDependencyNotFound
+}
+
+func (m *mockDependencyRepository) FindBlockers(ctx context.Context, taskID string) ([]*application.Task, error) {
+	var blockers []*application.Task
+	for _, d := range m.dependencies {
+		if d.TaskID == taskID {
+			blockers = append(blockers, m.tasks[d.BlockerID])
+		}
+	}
+	return blockers, nil
+}
+
+func (m *mockDependencyRepository) FindOpenBlockers(ctx context.Context, taskIDs []string) (map[string][]*application.Task, error) {
+	open := make(map[string][]*application.Task)
+	for _, taskID := range taskIDs {
+		blockers, _ := m.FindBlockers(ctx, taskID)
+		for _, blocker := range blockers {
+			if blocker.Status != application.StatusCompleted {
+				open[taskID] = append(open[taskID], blocker)
+			}
+		}
+	}
+	return open, nil
+}
+
+func (m *mockDependencyRepository) IsBlockedBy(ctx context.Context, taskID, blockerID string) (bool, error) {
+	for _, d := range m.dependencies {
+		if d.TaskID != taskID {
+			continue
+		}
+		if d.BlockerID == blockerID {
+			return true, nil
+		}
+		if blocked, _ := m.IsBlockedBy(ctx, d.BlockerID, blockerID); blocked {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestAddDependencyUseCase(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  [][2]string // task, blocker
+		taskID    string
+		blockerID string
+		userID    string
+		wantErr   error
+		wantIDs   []string
+	}{
+		{name: "owner adds a blocker", taskID: "a", blockerID: "b", userID: "user-1", wantIDs: []string{"b"}},
+		{name: "adding twice keeps one dependency", existing: [][2]string{{"a", "b"}}, taskID: "a", blockerID: "b", userID: "user-1", wantIDs: []string{"b"}},
+		{name: "blocked by itself", taskID: "a", blockerID: "a", userID: "user-1", wantErr: application.ErrDependencyCycle},
+		{name: "direct cycle", existing: [][2]string{{"b", "a"}}, taskID: "a", blockerID: "b", userID: "user-1", wantErr: application.ErrDependencyCycle},
+		{name: "transitive cycle", existing: [][2]string{{"b", "c"}, {"c", "a"}}, taskID: "a", blockerID: "b", userID: "user-1", wantErr: application.ErrDependencyCycle},
+		{name: "shared user cannot change dependencies", taskID: "a", blockerID: "b", userID: "user-2", wantErr: ErrDependencyPermissionDenied},
+		{name: "blocker the user cannot see", taskID: "a", blockerID: "private", userID: "user-1", wantErr: ErrTaskUnavailable},
+		{name: "task the user cannot see", taskID: "private", blockerID: "a", userID: "user-1", wantErr: ErrTaskUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := map[string]*application.Task{}
+			for _, id := range []string{"a", "b", "c"} {
+				tasks[id], _ = application.NewTask(id, "Tarefa "+id, "", application.StatusPending, "user-1", "")
+			}
+			// A task of user-3 the others can't see
+			tasks["private"], _ = application.NewTask("private", "Particular", "", application.StatusPending, "user-3", "")
+			taskRepo := &mockTaskRepositoryForShare{tasks: tasks}
+			shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"a": {"user-2"}, "b": {"user-2"}, "c": {"user-2"}}}
+			taskService := service.NewTaskService(taskRepo, shareRepo)
+			dependencyRepo := &mockDependencyRepository{tasks: tasks}
+			for _, d := range tt.existing {
+				dependencyRepo.dependencies = append(dependencyRepo.dependencies, &application.TaskDependency{TaskID: d[0], BlockerID: d[1]})
+			}
+
+			blockers, err := NewAddDependencyUseCase(dependencyRepo, taskRepo, taskService).Execute(context.Background(), tt.taskID, tt.blockerID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			var ids []string
+			for _, blocker := range blockers {
+				ids = append(ids, blocker.ID)
+			}
+			if len(ids) != len(tt.wantIDs) || ids[0] != tt.wantIDs[0] {
+				t.Errorf("Execute() blockers = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestAddDependencyUseCase_LimitsBlockers(t *testing.T) {
+	tasks := map[string]*application.Task{}
+	for _, id := range []string{"a", "b", "c"} {
+		tasks[id], _ = application.NewTask(id, "Tarefa "+id, "", application.StatusPending, "user-1", "")
+	}
+	taskRepo := &mockTaskRepositoryForShare{tasks: tasks}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"a": {"user-2"}, "b": {"user-2"}, "c": {"user-2"}}}
+	taskService := service.NewTaskService(taskRepo, shareRepo)
+	dependencyRepo := &mockDependencyRepository{tasks: tasks}
+	for i := 0; i < application.MaxBlockersPerTask; i++ {
+		dependencyRepo.dependencies = append(dependencyRepo.dependencies, &application.TaskDependency{TaskID: "a", BlockerID: "c"})
+	}
+
+	_, err := NewAddDependencyUseCase(dependencyRepo, taskRepo, taskService).Execute(context.Background(), "a", "b", "user-1")
+	if !errors.Is(err, application.ErrTooManyBlockers) {
+		t.Errorf("Execute() error = %v, want %v", err, application.ErrTooManyBlockers)
+	}
+}
+
+func TestRemoveDependencyUseCase(t *testing.T) {
+	tasks := map[string]*application.Task{}
+	for _, id := range []string{"a", "b", "c"} {
+		tasks[id], _ = application.NewTask(id, "Tarefa "+id, "", application.StatusPending, "user-1", "")
+	}
+	taskRepo := &mockTaskRepositoryForShare{tasks: tasks}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"a": {"user-2"}, "b": {"user-2"}, "c": {"user-2"}}}
+	taskService := service.NewTaskService(taskRepo, shareRepo)
+	dependencyRepo := &mockDependencyRepository{tasks: tasks, dependencies: []*application.TaskDependency{{TaskID: "a", BlockerID: "b"}}}
+	useCase := NewRemoveDependencyUseCase(dependencyRepo, taskRepo, taskService)
+
+	if err := useCase.Execute(context.Background(), "a", "b", "user-2"); !errors.Is(err, ErrDependencyPermissionDenied) {
+		t.Errorf("shared user: error = %v, want %v", err, ErrDependencyPermissionDenied)
+	}
+	if err := useCase.Execute(context.Background(), "a", "b", "user-1"); err != nil {
+		t.Fatalf("owner: unexpected error %v", err)
+	}
+	if err := useCase.Execute(context.Background(), "a", "b", "user-1"); !errors.Is(err, application.ErrDependencyNotFound) {
+		t.Errorf("removed twice: error = %v, want %v", err, application.ErrDependencyNotFound)
+	}
+}
+
+func TestCompletingBlockedTasks(t *testing.T) {
+	ctx := context.Background()
+	tasks := map[string]*application.Task{}
+	for _, id := range []string{"a", "b", "c"} {
+		tasks[id], _ = application.NewTask(id, "Tarefa "+id, "", application.StatusPending, "user-1", "")
+	}
+	taskRepo := &mockTaskRepositoryForShare{tasks: tasks}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"a": {"user-2"}, "b": {"user-2"}, "c": {"user-2"}}}
+	taskService := service.NewTaskService(taskRepo, shareRepo)
+	dependencyRepo := &mockDependencyRepository{tasks: tasks, dependencies: []*application.TaskDependency{{TaskID: "a", BlockerID: "b"}}}
+	completeTask := NewCompleteTaskUseCase(taskRepo, dependencyRepo, taskService, &recordingPublisher{}, NewTaskRevisions(nil, 0))
+	updateTask := NewUpdateTaskUseCase(taskRepo, nil, dependencyRepo, taskService, &recordingPublisher{}, NewTaskRevisions(nil, 0))
+
+	if _, err := completeTask.Execute(ctx, "a", "user-1", ""); !errors.Is(err, application.ErrTaskBlocked) {
+		t.Fatalf("complete while blocked: error = %v, want %v", err, application.ErrTaskBlocked)
+	}
+	if err := updateTask.Execute(ctx, "a", "Tarefa a", "", application.StatusCompleted, "", "user-1", nil, "", "", 0, ""); !errors.Is(err, application.ErrTaskBlocked) {
+		t.Fatalf("update to completed while blocked: error = %v, want %v", err, application.ErrTaskBlocked)
+	}
+	if stored, _ := taskRepo.FindByID(ctx, "a"); stored.Status != application.StatusPending {
+		t.Fatalf("blocked task status = %s, want pending", stored.Status)
+	}
+
+	blockers, _ := NewListOpenBlockersUseCase(dependencyRepo).Execute(ctx, []*application.Task{taskRepo.tasks["a"], taskRepo.tasks["c"]})
+	if len(blockers) != 1 || len(blockers["a"]) != 1 || blockers["a"][0].ID != "b" {
+		t.Errorf("open blockers = %v, want a blocked by b", blockers)
+	}
+
+	if _, err := completeTask.Execute(ctx, "b", "user-1", ""); err != nil {
+		t.Fatalf("complete blocker: unexpected error %v", err)
+	}
+	if _, err := completeTask.Execute(ctx, "a", "user-1", ""); err != nil {
+		t.Errorf("complete once unblocked: unexpected error %v", err)
+	}
+}
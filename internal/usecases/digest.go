@@ -0,0 +1,280 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// HTMLMailer sends emails with an HTML body and its plain text version
+type HTMLMailer interface {
+	SendHTML(ctx context.Context, to, subject, text, html string) error
+}
+
+// GetDigestSubscriptionUseCase handles reading whether and when a user gets the weekly digest
+type GetDigestSubscriptionUseCase struct {
+	digestRepo repository.DigestRepository
+}
+
+// NewGetDigestSubscriptionUseCase creates a new GetDigestSubscriptionUseCase
+func NewGetDigestSubscriptionUseCase(digestRepo repository.DigestRepository) *GetDigestSubscriptionUseCase {
+	return &GetDigestSubscriptionUseCase{
+		digestRepo: digestRepo,
+	}
+}
+
+// Execute returns the digest subscription of a user, or nil when they haven't opted in
+func (uc *GetDigestSubscriptionUseCase) Execute(ctx context.Context, userID string) (*application.DigestSubscription, error) {
+	subscription, err := uc.digestRepo.FindByUserID(ctx, userID)
+	if errors.Is(err, application.ErrDigestSubscriptionNotFound) {
+		return nil, nil
+	}
+	return subscription, err
+}
+
+// SubscribeDigestUseCase handles opting in to the weekly digest, or changing when it is sent
+type SubscribeDigestUseCase struct {
+	digestRepo repository.DigestRepository
+	userRepo   repository.UserRepository
+	loc        *time.Location
+	now        func() time.Time
+}
+
+// NewSubscribeDigestUseCase creates a new SubscribeDigestUseCase. Weekdays and hours are
+// taken in loc.
+func NewSubscribeDigestUseCase(digestRepo repository.DigestRepository, userRepo repository.UserRepository, loc *time.Location) *SubscribeDigestUseCase {
+	return &SubscribeDigestUseCase{
+		digestRepo: digestRepo,
+		userRepo:   userRepo,
+		loc:        loc,
+		now:        time.Now,
+	}
+}
+
+// Execute subscribes a user to the weekly digest sent on weekday at hour:00, scheduling the
+// next digest accordingly
+func (uc *SubscribeDigestUseCase) Execute(ctx context.Context, userID string, weekday time.Weekday, hour int) (*application.DigestSubscription, error) {
+	if _, err := uc.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	subscription, err := application.NewDigestSubscription(userID, weekday, hour, uc.loc, uc.now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.digestRepo.Save(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// UnsubscribeDigestUseCase handles opting out of the weekly digest
+type UnsubscribeDigestUseCase struct {
+	digestRepo repository.DigestRepository
+}
+
+// NewUnsubscribeDigestUseCase creates a new UnsubscribeDigestUseCase
+func NewUnsubscribeDigestUseCase(digestRepo repository.DigestRepository) *UnsubscribeDigestUseCase {
+	return &UnsubscribeDigestUseCase{
+		digestRepo: digestRepo,
+	}
+}
+
+// Execute stops the weekly digest of a user; it does nothing when they didn't opt in
+func (uc *UnsubscribeDigestUseCase) Execute(ctx context.Context, userID string) error {
+	return uc.digestRepo.Delete(ctx, userID)
+}
+
+// DigestSummary reports the outcome of a weekly digest run
+type DigestSummary struct {
+	Sent    int
+	Skipped int
+	Failed  int
+}
+
+// SendWeeklyDigestsUseCase emails the weekly digests that are due
+type SendWeeklyDigestsUseCase struct {
+	digestRepo repository.DigestRepository
+	userRepo   repository.UserRepository
+	mailer     HTMLMailer
+	publicURL  string
+	batchSize  int
+	loc        *time.Location
+	now        func() time.Time
+}
+
+// NewSendWeeklyDigestsUseCase creates a new SendWeeklyDigestsUseCase. publicURL is linked
+// from the emails; loc is the time zone of the schedules and of the dates in the emails.
+func NewSendWeeklyDigestsUseCase(
+	digestRepo repository.DigestRepository,
+	userRepo repository.UserRepository,
+	mailer HTMLMailer,
+	publicURL string,
+	batchSize int,
+	loc *time.Location,
+) *SendWeeklyDigestsUseCase {
+	return &SendWeeklyDigestsUseCase{
+		digestRepo: digestRepo,
+		userRepo:   userRepo,
+		mailer:     mailer,
+		publicURL:  strings.TrimSuffix(publicURL, "/"),
+		batchSize:  batchSize,
+		loc:        loc,
+		now:        time.Now,
+	}
+}
+
+// Execute sends up to batchSize due digests. Each subscription is rescheduled to the next
+// week before its digest is compiled, so it is never sent twice and a failure waits for the
+// next week instead of being retried every run. Users with nothing to report and disabled
+// accounts are skipped. Email failures don't stop the run and are returned together at the end.
+func (uc *SendWeeklyDigestsUseCase) Execute(ctx context.Context) (DigestSummary, error) {
+	var summary DigestSummary
+
+	now := uc.now()
+	subscriptions, err := uc.digestRepo.FindDue(ctx, now, uc.batchSize)
+	if err != nil {
+		return summary, fmt.Errorf("failed to retrieve due digests: %w", err)
+	}
+
+	var emailErrs []error
+	for _, subscription := range subscriptions {
+		if err := ctx.Err(); err != nil {
+			return summary, err
+		}
+
+		next := application.NextDigestAt(subscription.Weekday, subscription.Hour, uc.loc, now)
+		claimed, err := uc.digestRepo.Reschedule(ctx, subscription.UserID, subscription.NextSendAt, next, now)
+		if err != nil {
+			return summary, fmt.Errorf("failed to reschedule digest of user %s: %w", subscription.UserID, err)
+		}
+		if !claimed {
+			continue
+		}
+
+		user, err := uc.userRepo.FindByID(ctx, subscription.UserID)
+		if err != nil && !errors.Is(err, application.ErrUserNotFound) {
+			return summary, fmt.Errorf("failed to retrieve user %s: %w", subscription.UserID, err)
+		}
+		if user == nil || user.Disabled {
+			summary.Skipped++
+			continue
+		}
+
+		digest, err := uc.digestRepo.Summarize(ctx, user.ID, now.AddDate(0, 0, -7), now, application.DigestMaxUpcoming)
+		if err != nil {
+			return summary, fmt.Errorf("failed to summarize tasks of user %s: %w", user.ID, err)
+		}
+		if digest.IsEmpty() {
+			summary.Skipped++
+			continue
+		}
+
+		if err := uc.email(ctx, user, digest); err != nil {
+			summary.Failed++
+			emailErrs = append(emailErrs, fmt.Errorf("digest of user %s: %w", user.ID, err))
+			continue
+		}
+		summary.Sent++
+	}
+
+	return summary, errors.Join(emailErrs...)
+}
+
+// digestEmailData is what the digest email templates show
+type digestEmailData struct {
+	Name      string
+	From      string
+	To        string
+	Pending   int
+	Overdue   int
+	Completed int
+	Upcoming  []digestEmailTask
+	TasksURL  string
+}
+
+// digestEmailTask is a task coming due listed in the digest email
+type digestEmailTask struct {
+	Title   string
+	DueDate string
+}
+
+// email renders the digest of a user and sends it to their email address
+func (uc *SendWeeklyDigestsUseCase) email(ctx context.Context, user *application.User, digest *application.WeeklyDigest) error {
+	data := digestEmailData{
+		Name:      user.Name,
+		From:      digest.From.In(uc.loc).Format("02/01"),
+		To:        digest.To.In(uc.loc).Format("02/01"),
+		Pending:   digest.Pending,
+		Overdue:   digest.Overdue,
+		Completed: digest.Completed,
+		TasksURL:  uc.publicURL + "/tasks",
+	}
+	for _, task := range digest.Upcoming {
+		data.Upcoming = append(data.Upcoming, digestEmailTask{
+			Title:   task.Title,
+			DueDate: task.DueDate.In(uc.loc).Format("02/01 15:04"),
+		})
+	}
+
+	var text, html bytes.Buffer
+	if err := digestTextTemplate.Execute(&text, data); err != nil {
+		return err
+	}
+	if err := digestHTMLTemplate.Execute(&html, data); err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("Resumo semanal: %d pendentes, %d atrasadas", digest.Pending, digest.Overdue)
+	return uc.mailer.SendHTML(ctx, user.Email, subject, text.String(), html.String())
+}
+
+// digestTextTemplate is the plain text version of the digest email
+var digestTextTemplate = texttemplate.Must(texttemplate.New("digest.txt").Parse(`Olá, {{ .Name }}!
+
+Seu resumo da semana de {{ .From }} a {{ .To }}:
+
+Pendentes: {{ .Pending }}
+Atrasadas: {{ .Overdue }}
+Concluídas na semana: {{ .Completed }}
+{{ if .Upcoming }}
+Prazos nos próximos 7 dias:
+{{ range .Upcoming }}- {{ .Title }} ({{ .DueDate }})
+{{ end }}{{ end }}
+Ver tarefas: {{ .TasksURL }}
+`))
+
+// digestHTMLTemplate is the HTML version of the digest email, with inline styles since
+// email clients drop stylesheets
+var digestHTMLTemplate = template.Must(template.New("digest.html").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<body style="font-family: Arial, sans-serif; color: #111827; margin: 0; padding: 24px; background: #f3f4f6;">
+<div style="max-width: 560px; margin: 0 auto; background: #ffffff; border-radius: 8px; padding: 24px;">
+<p>Olá, {{ .Name }}!</p>
+<p>Seu resumo da semana de {{ .From }} a {{ .To }}:</p>
+<table style="width: 100%; border-collapse: collapse; text-align: center; margin: 16px 0;">
+<tr>
+<td style="padding: 12px; background: #eff6ff;"><div style="font-size: 24px; font-weight: bold; color: #2563eb;">{{ .Pending }}</div>Pendentes</td>
+<td style="padding: 12px; background: #fef2f2;"><div style="font-size: 24px; font-weight: bold; color: #dc2626;">{{ .Overdue }}</div>Atrasadas</td>
+<td style="padding: 12px; background: #f0fdf4;"><div style="font-size: 24px; font-weight: bold; color: #16a34a;">{{ .Completed }}</div>Concluídas na semana</td>
+</tr>
+</table>
+{{ if .Upcoming }}<h3 style="font-size: 16px;">Prazos nos próximos 7 dias</h3>
+<ul>
+{{ range .Upcoming }}<li>{{ .Title }} <span style="color: #6b7280;">({{ .DueDate }})</span></li>
+{{ end }}</ul>
+{{ end }}<p><a href="{{ .TasksURL }}" style="color: #2563eb;">Ver tarefas</a></p>
+</div>
+</body>
+</html>
+`))
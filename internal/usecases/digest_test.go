@@ -0,0 +1,194 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+type mockDigestRepository struct {
+	subscriptions map[string]*application.DigestSubscription
+	digests       map[string]*application.WeeklyDigest
+}
+
+func (m *mockDigestRepository) Save(ctx context.Context, subscription *application.DigestSubscription) error {
+	m.subscriptions[subscription.UserID] = subscription
+	return nil
+}
+
+func (m *mockDigestRepository) FindByUserID(ctx context.Context, userID string) (*application.DigestSubscription, error) {
+	subscription, ok := m.subscriptions[userID]
+	if !ok {
+		return nil, application.ErrDigestSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+func (m *mockDigestRepository) Delete(ctx context.Context, userID string) error {
+	delete(m.subscriptions, userID)
+	return nil
+}
+
+func (m *mockDigestRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*application.DigestSubscription, error) {
+	var due []*application.DigestSubscription
+	for _, subscription := range m.subscriptions {
+		if !subscription.NextSendAt.After(now) && len(due) < limit {
+			copied := *subscription
+			due = append(due, &copied)
+		}
+	}
+	return due, nil
+}
+
+func (m *mockDigestRepository) Reschedule(ctx context.Context, userID string, previous, next, sentAt time.Time) (bool, error) {
+	subscription, ok := m.subscriptions[userID]
+	if !ok || !subscription.NextSendAt.Equal(previous) {
+		return false, nil
+	}
+	subscription.NextSendAt = next
+	subscription.LastSentAt = &sentAt
+	return true, nil
+}
+
+func (m *mockDigestRepository) Summarize(ctx context.Context, userID string, since, now time.Time, limit int) (*application.WeeklyDigest, error) {
+	if digest, ok := m.digests[userID]; ok {
+		digest.From, digest.To = since, now
+		return digest, nil
+	}
+	return &application.WeeklyDigest{From: since, To: now}, nil
+}
+
+type sentHTMLEmail struct {
+	to, subject, text, html string
+}
+
+type mockHTMLMailer struct {
+	sent []sentHTMLEmail
+	err  error
+}
+
+func (m *mockHTMLMailer) SendHTML(ctx context.Context, to, subject, text, html string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, sentHTMLEmail{to, subject, text, html})
+	return nil
+}
+
+func TestSubscribeDigestUseCase_Execute(t *testing.T) {
+	digests := &mockDigestRepository{subscriptions: map[string]*application.DigestSubscription{}}
+	uc := NewSubscribeDigestUseCase(digests, newMockUserRepositoryForAdmin(), time.UTC)
+	uc.now = func() time.Time { return time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC) }
+
+	subscription, err := uc.Execute(context.Background(), "member-1", time.Friday, 18)
+	if err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if want := time.Date(2026, 3, 13, 18, 0, 0, 0, time.UTC); !subscription.NextSendAt.Equal(want) {
+		t.Errorf("NextSendAt = %v, want %v", subscription.NextSendAt, want)
+	}
+	if digests.subscriptions["member-1"] != subscription {
+		t.Error("subscription was not saved")
+	}
+
+	if _, err := uc.Execute(context.Background(), "member-1", time.Friday, 24); !errors.Is(err, application.ErrInvalidDigestHour) {
+		t.Errorf("Execute() with hour 24 error = %v, want ErrInvalidDigestHour", err)
+	}
+	if _, err := uc.Execute(context.Background(), "ghost", time.Friday, 18); !errors.Is(err, application.ErrUserNotFound) {
+		t.Errorf("Execute() for unknown user error = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestSendWeeklyDigestsUseCase_Execute(t *testing.T) {
+	now := time.Date(2026, 3, 16, 8, 5, 0, 0, time.UTC) // Monday
+	due := time.Date(2026, 3, 16, 8, 0, 0, 0, time.UTC)
+	dueDate := time.Date(2026, 3, 18, 17, 0, 0, 0, time.UTC)
+
+	users := newMockUserRepositoryForAdmin()
+	users.users["member-1"].Name = "Ana"
+	users.users["idle"] = &application.User{ID: "idle", Email: "idle@example.com"}
+	users.users["disabled"] = &application.User{ID: "disabled", Email: "disabled@example.com", Disabled: true}
+
+	digests := &mockDigestRepository{
+		subscriptions: map[string]*application.DigestSubscription{
+			"member-1": {UserID: "member-1", Weekday: time.Monday, Hour: 8, NextSendAt: due},
+			"idle":     {UserID: "idle", Weekday: time.Monday, Hour: 8, NextSendAt: due},
+			"disabled": {UserID: "disabled", Weekday: time.Monday, Hour: 8, NextSendAt: due},
+			"admin-1":  {UserID: "admin-1", Weekday: time.Friday, Hour: 8, NextSendAt: due.AddDate(0, 0, 4)},
+		},
+		digests: map[string]*application.WeeklyDigest{
+			"member-1": {Pending: 3, Overdue: 1, Completed: 2, Upcoming: []*application.Task{{Title: "Pagar <contas> & taxas", DueDate: &dueDate}}},
+			"disabled": {Pending: 1},
+		},
+	}
+	mailer := &mockHTMLMailer{}
+	uc := NewSendWeeklyDigestsUseCase(digests, users, mailer, "https://todo.example.com/", 10, time.UTC)
+	uc.now = func() time.Time { return now }
+
+	summary, err := uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if summary != (DigestSummary{Sent: 1, Skipped: 2}) {
+		t.Errorf("summary = %+v, want 1 sent and 2 skipped", summary)
+	}
+
+	if len(mailer.sent) != 1 {
+		t.Fatalf("sent %d emails, want 1", len(mailer.sent))
+	}
+	email := mailer.sent[0]
+	if email.to != "member@example.com" || email.subject != "Resumo semanal: 3 pendentes, 1 atrasadas" {
+		t.Errorf("email to %q with subject %q", email.to, email.subject)
+	}
+	for _, want := range []string{"Olá, Ana!", "Pendentes: 3", "Atrasadas: 1", "Concluídas na semana: 2", "- Pagar <contas> & taxas (18/03 17:00)", "https://todo.example.com/tasks"} {
+		if !strings.Contains(email.text, want) {
+			t.Errorf("text body misses %q:\n%s", want, email.text)
+		}
+	}
+	if !strings.Contains(email.html, "Pagar &lt;contas&gt; &amp; taxas") || strings.Contains(email.html, "<contas>") {
+		t.Errorf("HTML body should escape task titles:\n%s", email.html)
+	}
+
+	// Every due subscription moves to the next week, sent or not; the others don't move
+	nextWeek := due.AddDate(0, 0, 7)
+	for _, id := range []string{"member-1", "idle", "disabled"} {
+		if got := digests.subscriptions[id].NextSendAt; !got.Equal(nextWeek) {
+			t.Errorf("NextSendAt of %s = %v, want %v", id, got, nextWeek)
+		}
+	}
+	if digests.subscriptions["admin-1"].LastSentAt != nil {
+		t.Error("subscription not due yet was rescheduled")
+	}
+
+	// A second run in the same hour sends nothing
+	if summary, err := uc.Execute(context.Background()); err != nil || summary != (DigestSummary{}) {
+		t.Errorf("second Execute() = %+v, %v; want nothing done", summary, err)
+	}
+}
+
+func TestSendWeeklyDigestsUseCase_EmailFailure(t *testing.T) {
+	now := time.Date(2026, 3, 16, 8, 5, 0, 0, time.UTC)
+	digests := &mockDigestRepository{
+		subscriptions: map[string]*application.DigestSubscription{
+			"member-1": {UserID: "member-1", Weekday: time.Monday, Hour: 8, NextSendAt: now.Add(-5 * time.Minute)},
+		},
+		digests: map[string]*application.WeeklyDigest{"member-1": {Pending: 1}},
+	}
+	uc := NewSendWeeklyDigestsUseCase(digests, newMockUserRepositoryForAdmin(), &mockHTMLMailer{err: errors.New("smtp down")}, "http://localhost:8080", 10, time.UTC)
+	uc.now = func() time.Time { return now }
+
+	summary, err := uc.Execute(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "smtp down") {
+		t.Fatalf("Execute() error = %v, want the email failure", err)
+	}
+	if summary != (DigestSummary{Failed: 1}) {
+		t.Errorf("summary = %+v, want 1 failed", summary)
+	}
+	if digests.subscriptions["member-1"].NextSendAt.Before(now) {
+		t.Error("a failed digest should wait for the next week")
+	}
+}
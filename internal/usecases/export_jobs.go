@@ -0,0 +1,220 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// exportFailedMessage is the error shown to the owner of a failed export; the cause is returned
+// to the caller for the logs
+const exportFailedMessage = "Failed to generate PDF"
+
+// findUserExportJob finds a job and checks it belongs to the user
+func findUserExportJob(ctx context.Context, jobRepo repository.ExportJobRepository, jobID, userID string) (*application.ExportJob, error) {
+	job, err := jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.UserID != userID {
+		return nil, application.ErrExportJobNotFound
+	}
+	return job, nil
+}
+
+// RequestTaskExportUseCase handles requesting the PDF export of a user's tasks
+type RequestTaskExportUseCase struct {
+	jobRepo   repository.ExportJobRepository
+	taskRepo  repository.TaskRepository
+	exportPDF ExportTasksPDFUseCaseInterface
+	syncLimit int
+	now       func() time.Time
+}
+
+// NewRequestTaskExportUseCase creates a new RequestTaskExportUseCase. Lists of up to syncLimit
+// tasks are exported right away; larger ones are left to ProcessExportJobsUseCase.
+func NewRequestTaskExportUseCase(
+	jobRepo repository.ExportJobRepository,
+	taskRepo repository.TaskRepository,
+	exportPDF ExportTasksPDFUseCaseInterface,
+	syncLimit int,
+) *RequestTaskExportUseCase {
+	return &RequestTaskExportUseCase{
+		jobRepo:   jobRepo,
+		taskRepo:  taskRepo,
+		exportPDF: exportPDF,
+		syncLimit: syncLimit,
+		now:       time.Now,
+	}
+}
+
+// Execute creates an export job for the tasks of a user in the workspace of ctx. The job is
+// returned done when the list was small enough to export at once, and pending otherwise.
+func (uc *RequestTaskExportUseCase) Execute(ctx context.Context, userID string) (_ *application.ExportJob, err error) {
+	ctx, end := tracer.Start(ctx, "RequestTaskExport")
+	defer func() { end(err) }()
+
+	job, err := application.NewExportJob(uuid.New().String(), userID, application.ExportKindTasksPDF, uc.now())
+	if err != nil {
+		return nil, err
+	}
+	job.OrgID, _ = repository.OrgScope(ctx)
+
+	tasks, err := uc.taskRepo.FindByOwnerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) > uc.syncLimit {
+		if err := uc.jobRepo.Create(ctx, job); err != nil {
+			return nil, err
+		}
+		return job, nil
+	}
+
+	pdf, err := uc.exportPDF.Execute(ctx, userID, application.TaskExportFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	job.Start(uc.now())
+	if err := uc.jobRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+	if err := uc.jobRepo.Finish(ctx, job.ID, pdf, uc.now()); err != nil {
+		return nil, err
+	}
+
+	return uc.jobRepo.FindByID(ctx, job.ID)
+}
+
+// GetExportJobUseCase handles checking the progress of an export
+type GetExportJobUseCase struct {
+	jobRepo repository.ExportJobRepository
+}
+
+// NewGetExportJobUseCase creates a new GetExportJobUseCase
+func NewGetExportJobUseCase(jobRepo repository.ExportJobRepository) *GetExportJobUseCase {
+	return &GetExportJobUseCase{jobRepo: jobRepo}
+}
+
+// Execute returns an export job of the user
+func (uc *GetExportJobUseCase) Execute(ctx context.Context, jobID, userID string) (*application.ExportJob, error) {
+	return findUserExportJob(ctx, uc.jobRepo, jobID, userID)
+}
+
+// DownloadExportUseCase handles downloading the file of an export
+type DownloadExportUseCase struct {
+	jobRepo repository.ExportJobRepository
+}
+
+// NewDownloadExportUseCase creates a new DownloadExportUseCase
+func NewDownloadExportUseCase(jobRepo repository.ExportJobRepository) *DownloadExportUseCase {
+	return &DownloadExportUseCase{jobRepo: jobRepo}
+}
+
+// Execute returns an export job of the user with its file. It returns
+// application.ErrExportNotReady until the job is done.
+func (uc *DownloadExportUseCase) Execute(ctx context.Context, jobID, userID string) (_ *application.ExportJob, _ []byte, err error) {
+	ctx, end := tracer.Start(ctx, "DownloadExport")
+	defer func() { end(err) }()
+
+	job, err := findUserExportJob(ctx, uc.jobRepo, jobID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if job.Status != application.ExportJobDone {
+		return nil, nil, application.ErrExportNotReady
+	}
+
+	// Not found when it expired between the two queries
+	result, err := uc.jobRepo.FindResult(ctx, jobID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return job, result, nil
+}
+
+// ExportJobSummary reports the outcome of an export job run
+type ExportJobSummary struct {
+	Done    int
+	Failed  int
+	Expired int
+}
+
+// ProcessExportJobsUseCase generates the pending exports in the background
+type ProcessExportJobsUseCase struct {
+	jobRepo    repository.ExportJobRepository
+	exportPDF  ExportTasksPDFUseCaseInterface
+	staleAfter time.Duration
+	retention  time.Duration
+	now        func() time.Time
+}
+
+// NewProcessExportJobsUseCase creates a new ProcessExportJobsUseCase. Jobs still running after
+// staleAfter are retried, and finished jobs are deleted with their files after retention.
+func NewProcessExportJobsUseCase(
+	jobRepo repository.ExportJobRepository,
+	exportPDF ExportTasksPDFUseCaseInterface,
+	staleAfter time.Duration,
+	retention time.Duration,
+) *ProcessExportJobsUseCase {
+	return &ProcessExportJobsUseCase{
+		jobRepo:    jobRepo,
+		exportPDF:  exportPDF,
+		staleAfter: staleAfter,
+		retention:  retention,
+		now:        time.Now,
+	}
+}
+
+// Execute generates every pending export, each with the tasks of the workspace it was asked
+// in, then deletes the expired ones. A failed export is recorded on its job and doesn't stop
+// the run; its cause is returned once the run is over.
+func (uc *ProcessExportJobsUseCase) Execute(ctx context.Context) (_ ExportJobSummary, err error) {
+	ctx, end := tracer.Start(ctx, "ProcessExportJobs")
+	defer func() { end(err) }()
+
+	var summary ExportJobSummary
+	var firstErr error
+
+	for ctx.Err() == nil {
+		now := uc.now()
+		job, err := uc.jobRepo.ClaimNext(ctx, now, now.Add(-uc.staleAfter))
+		if errors.Is(err, application.ErrExportJobNotFound) {
+			break
+		}
+		if err != nil {
+			return summary, err
+		}
+
+		pdf, err := uc.exportPDF.Execute(repository.WithOrgScope(ctx, job.OrgID), job.UserID, application.TaskExportFilter{})
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			if err := uc.jobRepo.Fail(ctx, job.ID, exportFailedMessage, uc.now()); err != nil {
+				return summary, err
+			}
+			summary.Failed++
+			continue
+		}
+
+		if err := uc.jobRepo.Finish(ctx, job.ID, pdf, uc.now()); err != nil {
+			return summary, err
+		}
+		summary.Done++
+	}
+
+	expired, err := uc.jobRepo.DeleteFinishedBefore(ctx, uc.now().Add(-uc.retention))
+	summary.Expired = expired
+	if err != nil {
+		return summary, err
+	}
+
+	return summary, firstErr
+}
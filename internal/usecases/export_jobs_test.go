@@ -0,0 +1,253 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// mockExportJobRepository keeps export jobs in memory
+type mockExportJobRepository struct {
+	jobs    map[string]*application.ExportJob
+	results map[string][]byte
+	order   []string
+}
+
+func newMockExportJobRepository() *mockExportJobRepository {
+	return &mockExportJobRepository{jobs: map[string]*application.ExportJob{}, results: map[string][]byte{}}
+}
+
+func (m *mockExportJobRepository) Create(ctx context.Context, job *application.ExportJob) error {
+	stored := *job
+	m.jobs[job.ID] = &stored
+	m.order = append(m.order, job.ID)
+	return nil
+}
+
+func (m *mockExportJobRepository) FindByID(ctx context.Context, id string) (*application.ExportJob, error) {
+	job, ok := m.jobs[id]
+	if !ok {
+		return nil, application.ErrExportJobNotFound
+	}
+	found := *job
+	return &found, nil
+}
+
+func (m *mockExportJobRepository) FindResult(ctx context.Context, id string) ([]byte, error) {
+	if job, ok := m.jobs[id]; !ok || job.Status != application.ExportJobDone {
+		return nil, application.ErrExportJobNotFound
+	}
+	return m.results[id], nil
+}
+
+func (m *mockExportJobRepository) ClaimNext(ctx context.Context, now, staleBefore time.Time) (*application.ExportJob, error) {
+	for _, id := range m.order {
+		job, ok := m.jobs[id]
+		if ok && (job.Status == application.ExportJobPending || (job.Status == application.ExportJobRunning && job.StartedAt.Before(staleBefore))) {
+			job.Start(now)
+			claimed := *job
+			return &claimed, nil
+		}
+	}
+	return nil, application.ErrExportJobNotFound
+}
+
+func (m *mockExportJobRepository) Finish(ctx context.Context, id string, result []byte, finishedAt time.Time) error {
+	if job, ok := m.jobs[id]; ok && job.Status == application.ExportJobRunning {
+		job.Status = application.ExportJobDone
+		job.Size = len(result)
+		job.FinishedAt = &finishedAt
+		m.results[id] = result
+	}
+	return nil
+}
+
+func (m *mockExportJobRepository) Fail(ctx context.Context, id, message string, finishedAt time.Time) error {
+	if job, ok := m.jobs[id]; ok && job.Status == application.ExportJobRunning {
+		job.Status = application.ExportJobFailed
+		job.Error = message
+		job.FinishedAt = &finishedAt
+	}
+	return nil
+}
+
+func (m *mockExportJobRepository) DeleteFinishedBefore(ctx context.Context, before time.Time) (int, error) {
+	deleted := 0
+	for id, job := range m.jobs {
+		if job.FinishedAt != nil && job.FinishedAt.Before(before) {
+			delete(m.jobs, id)
+			delete(m.results, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// stubExportTasksPDF returns a fixed PDF, or fails for the users in failFor. It records the
+// workspace of each export.
+type stubExportTasksPDF struct {
+	calls   int
+	failFor map[string]bool
+	orgIDs  []string
+}
+
+func (s *stubExportTasksPDF) Execute(ctx context.Context, ownerID string, filter application.TaskExportFilter) ([]byte, error) {
+	s.calls++
+	orgID, _ := repository.OrgScope(ctx)
+	s.orgIDs = append(s.orgIDs, orgID)
+	if s.failFor[ownerID] {
+		return nil, errors.New("render failed")
+	}
+	return []byte("%PDF " + ownerID), nil
+}
+
+func newExportTaskRepo(t *testing.T, ownerID string, count int) *mockTaskRepositoryForShare {
+	t.Helper()
+	repo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{}}
+	for i := 0; i < count; i++ {
+		task, err := application.NewTask(ownerID+"-task-"+string(rune('a'+i)), "Tarefa", "", application.StatusPending, ownerID, "")
+		if err != nil {
+			t.Fatalf("NewTask() error: %v", err)
+		}
+		repo.tasks[task.ID] = task
+	}
+	return repo
+}
+
+func TestRequestTaskExportUseCase(t *testing.T) {
+	tests := []struct {
+		name       string
+		tasks      int
+		wantStatus application.ExportJobStatus
+		wantCalls  int
+	}{
+		{name: "small list is exported at once", tasks: 2, wantStatus: application.ExportJobDone, wantCalls: 1},
+		{name: "list at the limit is exported at once", tasks: 3, wantStatus: application.ExportJobDone, wantCalls: 1},
+		{name: "large list is left to the worker", tasks: 4, wantStatus: application.ExportJobPending, wantCalls: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobRepo := newMockExportJobRepository()
+			pdf := &stubExportTasksPDF{}
+			uc := NewRequestTaskExportUseCase(jobRepo, newExportTaskRepo(t, "user-1", tt.tasks), pdf, 3)
+
+			job, err := uc.Execute(context.Background(), "user-1")
+			if err != nil {
+				t.Fatalf("Execute() error: %v", err)
+			}
+			if job.Status != tt.wantStatus || pdf.calls != tt.wantCalls {
+				t.Errorf("status = %s after %d exports, want %s after %d", job.Status, pdf.calls, tt.wantStatus, tt.wantCalls)
+			}
+			if job.UserID != "user-1" || job.Kind != application.ExportKindTasksPDF {
+				t.Errorf("job = %+v", job)
+			}
+			if _, ok := jobRepo.jobs[job.ID]; !ok {
+				t.Error("job was not persisted")
+			}
+		})
+	}
+}
+
+func TestDownloadExportUseCase(t *testing.T) {
+	ctx := context.Background()
+	jobRepo := newMockExportJobRepository()
+	request := NewRequestTaskExportUseCase(jobRepo, newExportTaskRepo(t, "user-1", 1), &stubExportTasksPDF{}, 0)
+	pending, err := request.Execute(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+
+	download := NewDownloadExportUseCase(jobRepo)
+	if _, _, err := download.Execute(ctx, pending.ID, "user-1"); !errors.Is(err, application.ErrExportNotReady) {
+		t.Errorf("download of a pending export error = %v, want ErrExportNotReady", err)
+	}
+	if _, err := NewGetExportJobUseCase(jobRepo).Execute(ctx, pending.ID, "user-2"); !errors.Is(err, application.ErrExportJobNotFound) {
+		t.Errorf("another user's export error = %v, want ErrExportJobNotFound", err)
+	}
+
+	process := NewProcessExportJobsUseCase(jobRepo, &stubExportTasksPDF{}, 10*time.Minute, time.Hour)
+	if _, err := process.Execute(ctx); err != nil {
+		t.Fatalf("process error: %v", err)
+	}
+
+	job, pdf, err := download.Execute(ctx, pending.ID, "user-1")
+	if err != nil {
+		t.Fatalf("download error: %v", err)
+	}
+	if job.Status != application.ExportJobDone || string(pdf) != "%PDF user-1" {
+		t.Errorf("download = %s %q", job.Status, pdf)
+	}
+	if _, _, err := download.Execute(ctx, pending.ID, "user-2"); !errors.Is(err, application.ErrExportJobNotFound) {
+		t.Errorf("download by another user error = %v, want ErrExportJobNotFound", err)
+	}
+}
+
+func TestProcessExportJobsUseCase(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	jobRepo := newMockExportJobRepository()
+
+	for _, userID := range []string{"user-1", "user-2", "user-3"} {
+		job, _ := application.NewExportJob("job-"+userID, userID, application.ExportKindTasksPDF, now)
+		jobRepo.Create(ctx, job)
+	}
+	expired, _ := application.NewExportJob("job-old", "user-1", application.ExportKindTasksPDF, now.Add(-48*time.Hour))
+	expired.Start(now.Add(-48 * time.Hour))
+	jobRepo.Create(ctx, expired)
+	jobRepo.Finish(ctx, expired.ID, []byte("old"), now.Add(-48*time.Hour))
+
+	pdf := &stubExportTasksPDF{failFor: map[string]bool{"user-2": true}}
+	uc := NewProcessExportJobsUseCase(jobRepo, pdf, 10*time.Minute, 24*time.Hour)
+	uc.now = func() time.Time { return now }
+
+	summary, err := uc.Execute(ctx)
+	if err == nil {
+		t.Error("Execute() error = nil, want the cause of the failed export")
+	}
+	if summary != (ExportJobSummary{Done: 2, Failed: 1, Expired: 1}) {
+		t.Errorf("summary = %+v", summary)
+	}
+
+	if job := jobRepo.jobs["job-user-2"]; job.Status != application.ExportJobFailed || job.Error != exportFailedMessage {
+		t.Errorf("failed job = %+v", job)
+	}
+	for _, id := range []string{"job-user-1", "job-user-3"} {
+		if job := jobRepo.jobs[id]; job.Status != application.ExportJobDone {
+			t.Errorf("%s status = %s, want done", id, job.Status)
+		}
+	}
+	if _, ok := jobRepo.jobs["job-old"]; ok {
+		t.Error("expired job was not deleted")
+	}
+
+	// Nothing left to do on the next run
+	summary, err = uc.Execute(ctx)
+	if err != nil || summary != (ExportJobSummary{}) || pdf.calls != 3 {
+		t.Errorf("second run = %+v, %v after %d exports", summary, err, pdf.calls)
+	}
+}
+
+func TestProcessExportJobsUseCase_KeepsTheWorkspace(t *testing.T) {
+	ctx := repository.WithOrgScope(context.Background(), "org-1")
+	jobRepo := newMockExportJobRepository()
+	request := NewRequestTaskExportUseCase(jobRepo, newExportTaskRepo(t, "user-1", 2), &stubExportTasksPDF{}, 1)
+
+	pending, err := request.Execute(ctx, "user-1")
+	if err != nil || pending.Status != application.ExportJobPending || pending.OrgID != "org-1" {
+		t.Fatalf("Execute() = %+v, %v; want a pending job in org-1", pending, err)
+	}
+
+	// The worker runs unscoped, but exports the workspace the job was asked in
+	pdf := &stubExportTasksPDF{}
+	if _, err := NewProcessExportJobsUseCase(jobRepo, pdf, 10*time.Minute, time.Hour).Execute(context.Background()); err != nil {
+		t.Fatalf("process error: %v", err)
+	}
+	if len(pdf.orgIDs) != 1 || pdf.orgIDs[0] != "org-1" {
+		t.Errorf("exported workspaces = %q, want org-1", pdf.orgIDs)
+	}
+}
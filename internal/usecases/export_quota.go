@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// ExportQuotaUseCase enforces a persisted per-user quota on heavy exports, independent of the HTTP rate limit
+type ExportQuotaUseCase struct {
+	usageRepo repository.ExportUsageRepository
+	limit     int
+	window    time.Duration
+	now       func() time.Time
+}
+
+// NewExportQuotaUseCase creates a new ExportQuotaUseCase allowing limit exports per window for each user
+func NewExportQuotaUseCase(usageRepo repository.ExportUsageRepository, limit int, window time.Duration) *ExportQuotaUseCase {
+	return &ExportQuotaUseCase{
+		usageRepo: usageRepo,
+		limit:     limit,
+		window:    window,
+		now:       time.Now,
+	}
+}
+
+// Consume takes one export from the user's quota and returns its usage ID, which can be passed
+// to Release if the export fails. It returns *application.QuotaExceededError when the quota is used up.
+func (uc *ExportQuotaUseCase) Consume(ctx context.Context, userID, kind string) (string, error) {
+	usage, err := application.NewExportUsage(uuid.New().String(), userID, kind)
+	if err != nil {
+		return "", err
+	}
+	usage.CreatedAt = uc.now()
+	since := usage.CreatedAt.Add(-uc.window)
+
+	// Expired records are never counted again
+	if err := uc.usageRepo.DeleteBefore(ctx, since); err != nil {
+		return "", fmt.Errorf("failed to prune export usage: %w", err)
+	}
+
+	allowed, err := uc.usageRepo.CreateIfBelowLimit(ctx, usage, since, uc.limit)
+	if err != nil {
+		return "", fmt.Errorf("failed to record export usage: %w", err)
+	}
+	if allowed {
+		return usage.ID, nil
+	}
+
+	// The quota frees up when the oldest export in the window expires
+	oldest, err := uc.usageRepo.FindOldestSince(ctx, userID, since)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve export usage: %w", err)
+	}
+	retryAt := usage.CreatedAt.Add(uc.window)
+	if !oldest.IsZero() {
+		retryAt = oldest.Add(uc.window)
+	}
+
+	return "", &application.QuotaExceededError{
+		Limit:   uc.limit,
+		Window:  uc.window,
+		RetryAt: retryAt,
+	}
+}
+
+// Release gives back an export taken by Consume
+func (uc *ExportQuotaUseCase) Release(ctx context.Context, usageID string) error {
+	return uc.usageRepo.Delete(ctx, usageID)
+}
@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// mockExportUsageRepository is an in-memory ExportUsageRepository
+type mockExportUsageRepository struct {
+	usages map[string]*application.ExportUsage
+}
+
+func newMockExportUsageRepository() *mockExportUsageRepository {
+	return &mockExportUsageRepository{usages: make(map[string]*application.ExportUsage)}
+}
+
+func (m *mockExportUsageRepository) CreateIfBelowLimit(ctx context.Context, usage *application.ExportUsage, since time.Time, limit int) (bool, error) {
+	count := 0
+	for _, u := range m.usages {
+		if u.UserID == usage.UserID && u.CreatedAt.After(since) {
+			count++
+		}
+	}
+	if count >= limit {
+		return false, nil
+	}
+	m.usages[usage.ID] = usage
+	return true, nil
+}
+
+func (m *mockExportUsageRepository) FindOldestSince(ctx context.Context, userID string, since time.Time) (time.Time, error) {
+	var oldest time.Time
+	for _, u := range m.usages {
+		if u.UserID == userID && u.CreatedAt.After(since) && (oldest.IsZero() || u.CreatedAt.Before(oldest)) {
+			oldest = u.CreatedAt
+		}
+	}
+	return oldest, nil
+}
+
+func (m *mockExportUsageRepository) Delete(ctx context.Context, id string) error {
+	delete(m.usages, id)
+	return nil
+}
+
+func (m *mockExportUsageRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	for id, u := range m.usages {
+		if !u.CreatedAt.After(before) {
+			delete(m.usages, id)
+		}
+	}
+	return nil
+}
+
+func TestExportQuotaUseCase_Consume(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, 6, 11, 12, 0, 0, 0, time.UTC)
+	now := start
+
+	repo := newMockExportUsageRepository()
+	useCase := NewExportQuotaUseCase(repo, 3, time.Hour)
+	useCase.now = func() time.Time { return now }
+
+	// Three exports ten minutes apart use up the quota
+	for i := 0; i < 3; i++ {
+		if _, err := useCase.Consume(ctx, "user-1", "tasks_pdf"); err != nil {
+			t.Fatalf("export %d: unexpected error: %v", i+1, err)
+		}
+		now = now.Add(10 * time.Minute)
+	}
+
+	_, err := useCase.Consume(ctx, "user-1", "tasks_pdf")
+	var exceeded *application.QuotaExceededError
+	if !errors.As(err, &exceeded) {
+		t.Fatalf("expected QuotaExceededError, got %v", err)
+	}
+	if want := start.Add(time.Hour); !exceeded.RetryAt.Equal(want) {
+		t.Errorf("RetryAt = %v, want %v (oldest export + window)", exceeded.RetryAt, want)
+	}
+	if exceeded.Limit != 3 || exceeded.Window != time.Hour {
+		t.Errorf("unexpected limit/window %d/%s", exceeded.Limit, exceeded.Window)
+	}
+
+	// Other users have their own quota
+	if _, err := useCase.Consume(ctx, "user-2", "tasks_pdf"); err != nil {
+		t.Errorf("expected other user to be allowed, got %v", err)
+	}
+
+	// Once the oldest export leaves the window a new one is allowed
+	now = start.Add(time.Hour + time.Second)
+	if _, err := useCase.Consume(ctx, "user-1", "tasks_pdf"); err != nil {
+		t.Errorf("expected quota to be freed, got %v", err)
+	}
+}
+
+func TestExportQuotaUseCase_Release(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockExportUsageRepository()
+	useCase := NewExportQuotaUseCase(repo, 1, time.Hour)
+
+	usageID, err := useCase.Consume(ctx, "user-1", "tasks_pdf")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := useCase.Consume(ctx, "user-1", "tasks_pdf"); err == nil {
+		t.Fatal("expected quota to be exceeded")
+	}
+
+	if err := useCase.Release(ctx, usageID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := useCase.Consume(ctx, "user-1", "tasks_pdf"); err != nil {
+		t.Errorf("expected released export to be available, got %v", err)
+	}
+}
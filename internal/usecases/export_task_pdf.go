@@ -0,0 +1,155 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// ExportTaskPDFUseCase handles exporting a single task to PDF, for printing
+type ExportTaskPDFUseCase struct {
+	taskRepo       repository.TaskRepository
+	attachmentRepo repository.AttachmentRepository
+	dependencyRepo repository.DependencyRepository
+	timeEntryRepo  repository.TimeEntryRepository
+	taskService    TaskServiceInterface
+}
+
+// NewExportTaskPDFUseCase creates a new ExportTaskPDFUseCase
+func NewExportTaskPDFUseCase(
+	taskRepo repository.TaskRepository,
+	attachmentRepo repository.AttachmentRepository,
+	dependencyRepo repository.DependencyRepository,
+	timeEntryRepo repository.TimeEntryRepository,
+	taskService TaskServiceInterface,
+) *ExportTaskPDFUseCase {
+	return &ExportTaskPDFUseCase{
+		taskRepo:       taskRepo,
+		attachmentRepo: attachmentRepo,
+		dependencyRepo: dependencyRepo,
+		timeEntryRepo:  timeEntryRepo,
+		taskService:    taskService,
+	}
+}
+
+// Execute generates a PDF with a task the user can see: its details, the time tracked on it,
+// image, the names of its attachments and the tasks blocking it. Tasks the user can't see are reported as
+// ErrTaskUnavailable, like missing ones.
+func (uc *ExportTaskPDFUseCase) Execute(ctx context.Context, taskID, userID string) (_ []byte, err error) {
+	ctx, end := tracer.Start(ctx, "ExportTaskPDF")
+	defer func() { end(err) }()
+
+	task, err := uc.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	canAccess, err := uc.taskService.CanUserAccessTask(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, ErrTaskUnavailable
+	}
+
+	attachments, err := uc.attachmentRepo.FindByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve attachments: %w", err)
+	}
+	blockers, err := uc.dependencyRepo.FindBlockers(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve dependencies: %w", err)
+	}
+	tracked, err := uc.timeEntryRepo.SumByTaskIDs(ctx, []string{taskID}, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tracked time: %w", err)
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.AddPage()
+
+	pdf.SetFont("Arial", "B", 20)
+	pdf.MultiCell(190, 9, tr(task.Title), "", "L", false)
+	pdf.SetFont("Arial", "I", 9)
+	pdf.CellFormat(190, 5, tr(fmt.Sprintf("Gerado em: %s", time.Now().Format("02/01/2006 15:04:05"))), "", 1, "L", false, 0, "")
+	pdf.Ln(5)
+
+	pdf.SetFont("Arial", "", 11)
+	pdf.CellFormat(190, 6, tr(fmt.Sprintf("Status: %s", getStatusText(task.Status))), "", 1, "L", false, 0, "")
+	pdf.CellFormat(190, 6, tr(fmt.Sprintf("Progresso: %d%%", task.PercentComplete())), "", 1, "L", false, 0, "")
+	if task.CompletionNote != "" {
+		pdf.MultiCell(190, 6, tr("Nota de conclusao: "+task.CompletionNote), "", "L", false)
+	}
+	pdf.CellFormat(190, 6, tr(fmt.Sprintf("Prioridade: %s", getPriorityText(task.Priority))), "", 1, "L", false, 0, "")
+	if task.DueDate != nil {
+		pdf.CellFormat(190, 6, tr(fmt.Sprintf("Prazo: %s", task.DueDate.Format("02/01/2006 15:04"))), "", 1, "L", false, 0, "")
+	}
+	if len(task.Tags) > 0 {
+		pdf.CellFormat(190, 6, tr("Tags: #"+strings.Join(task.Tags, " #")), "", 1, "L", false, 0, "")
+	}
+	if tracked[taskID] > 0 {
+		pdf.CellFormat(190, 6, tr("Tempo registrado: "+application.FormatDuration(tracked[taskID])), "", 1, "L", false, 0, "")
+	}
+
+	if task.Description != "" {
+		pdf.Ln(4)
+		pdf.SetFont("Arial", "B", 12)
+		pdf.CellFormat(190, 7, tr("Descricao"), "", 1, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 11)
+		pdf.MultiCell(190, 5, tr(task.Description), "", "L", false)
+	}
+
+	if task.ImagePath != "" {
+		pdf.Ln(4)
+		addPDFImage(pdf, task.ImagePath)
+	}
+
+	if len(blockers) > 0 {
+		pdf.Ln(4)
+		pdf.SetFont("Arial", "B", 12)
+		pdf.CellFormat(190, 7, tr("Bloqueada por"), "", 1, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 11)
+		for _, blocker := range blockers {
+			pdf.MultiCell(190, 5, tr(fmt.Sprintf("- %s (%s)", blocker.Title, getStatusText(blocker.Status))), "", "L", false)
+		}
+	}
+
+	if len(attachments) > 0 {
+		pdf.Ln(4)
+		pdf.SetFont("Arial", "B", 12)
+		pdf.CellFormat(190, 7, tr("Anexos"), "", 1, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 11)
+		for _, attachment := range attachments {
+			pdf.MultiCell(190, 5, tr("- "+attachment.Filename), "", "L", false)
+		}
+	}
+
+	pdf.Ln(6)
+	pdf.SetFont("Arial", "I", 9)
+	pdf.CellFormat(190, 5, tr(fmt.Sprintf("Criada em: %s", task.CreatedAt.Format("02/01/2006 15:04"))), "", 1, "L", false, 0, "")
+	pdf.CellFormat(190, 5, tr(fmt.Sprintf("Atualizada em: %s", task.UpdatedAt.Format("02/01/2006 15:04"))), "", 1, "L", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// getPriorityText converts task priority to Portuguese text
+func getPriorityText(priority application.TaskPriority) string {
+	switch priority {
+	case application.PriorityLow:
+		return "Baixa"
+	case application.PriorityHigh:
+		return "Alta"
+	default:
+		return "Normal"
+	}
+}
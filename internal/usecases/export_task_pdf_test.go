@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestExportTaskPDFUseCase_Execute(t *testing.T) {
+	due := time.Now().Add(24 * time.Hour)
+	attachmentRepo := &mockAttachmentRepository{attachments: map[string]*application.Attachment{
+		"att-1": {ID: "att-1", TaskID: "task-1", Filename: "a.pdf", Path: "stored.pdf"},
+	}}
+	taskRepo := &mockTaskRepositoryForAttachments{mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+		"task-1": {ID: "task-1", OwnerID: "owner", Title: "Relatório mensal", Description: "Consolidar os números", DueDate: &due, Tags: []string{"trabalho"}, Progress: 40},
+		"task-2": {ID: "task-2", OwnerID: "someone-else"},
+	}}}
+	taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"owner": true, "viewer": true}}
+	dependencyRepo := &mockDependencyRepository{
+		tasks:        taskRepo.tasks,
+		dependencies: []*application.TaskDependency{{TaskID: "task-1", BlockerID: "task-2"}},
+	}
+	stopped := timerNow.Add(-90 * time.Minute)
+	timeEntryRepo := newMockTimeEntryRepository(
+		&application.TimeEntry{ID: "entry-owner", TaskID: "task-1", UserID: "owner", StartedAt: timerNow.Add(-2 * time.Hour), StoppedAt: &stopped},
+		&application.TimeEntry{ID: "entry-viewer", TaskID: "task-1", UserID: "viewer", StartedAt: timerNow.Add(-time.Hour)},
+	)
+	useCase := NewExportTaskPDFUseCase(taskRepo, attachmentRepo, dependencyRepo, timeEntryRepo, taskService)
+
+	tests := []struct {
+		name    string
+		taskID  string
+		userID  string
+		wantErr error
+	}{
+		{"owner exports", "task-1", "owner", nil},
+		{"shared user exports", "task-1", "viewer", nil},
+		{"stranger gets not found", "task-1", "stranger", ErrTaskUnavailable},
+		{"missing task", "task-9", "owner", ErrTaskUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pdf, err := useCase.Execute(context.Background(), tt.taskID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !bytes.HasPrefix(pdf, []byte("%PDF-")) {
+				t.Errorf("Expected a PDF, got %q", pdf[:min(len(pdf), 20)])
+			}
+			if tt.wantErr == nil && !strings.Contains(pdfText(t, pdf), "Tempo registrado: ") {
+				t.Error("Expected the PDF to show the tracked time")
+			}
+			if tt.wantErr == nil && !strings.Contains(pdfText(t, pdf), "Progresso: 40%") {
+				t.Error("Expected the PDF to show the progress")
+			}
+		})
+	}
+}
@@ -16,29 +16,171 @@ import (
 
 // ExportTasksPDFUseCase handles exporting tasks to PDF
 type ExportTasksPDFUseCase struct {
-	taskRepo repository.TaskRepository
+	taskRepo       repository.TaskRepository
+	attachmentRepo repository.AttachmentRepository
+	projectRepo    repository.ProjectRepository
+	timeEntryRepo  repository.TimeEntryRepository
 }
 
 // NewExportTasksPDFUseCase creates a new ExportTasksPDFUseCase
-func NewExportTasksPDFUseCase(taskRepo repository.TaskRepository) *ExportTasksPDFUseCase {
+func NewExportTasksPDFUseCase(
+	taskRepo repository.TaskRepository,
+	attachmentRepo repository.AttachmentRepository,
+	projectRepo repository.ProjectRepository,
+	timeEntryRepo repository.TimeEntryRepository,
+) *ExportTasksPDFUseCase {
 	return &ExportTasksPDFUseCase{
-		taskRepo: taskRepo,
+		taskRepo:       taskRepo,
+		attachmentRepo: attachmentRepo,
+		projectRepo:    projectRepo,
+		timeEntryRepo:  timeEntryRepo,
 	}
 }
 
-// Execute generates a PDF with all tasks for a user
-func (uc *ExportTasksPDFUseCase) Execute(ctx context.Context, ownerID string) ([]byte, error) {
-	// Get all tasks for the user
-	tasks, err := uc.taskRepo.FindByOwnerID(ctx, ownerID)
+// pdfSection is a section of a task export: the tasks of a project, or the ones without one
+type pdfSection struct {
+	title string
+	tasks []*application.Task
+}
+
+// Execute generates a PDF with the tasks of a user in the scope of filter: a summary cover
+// page, then a section per project, each starting on a new page, and the tasks without a
+// project last. Filtering by project exports a project the user owns or that is shared with
+// them, and returns application.ErrProjectNotFound for any other.
+func (uc *ExportTasksPDFUseCase) Execute(ctx context.Context, ownerID string, filter application.TaskExportFilter) (_ []byte, err error) {
+	ctx, end := tracer.Start(ctx, "ExportTasksPDF")
+	defer func() { end(err) }()
+
+	tasks, projects, tasksOwnerID, err := uc.findTasks(ctx, ownerID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the attachments of all tasks at once, grouped by task
+	attachments, err := uc.attachmentRepo.FindByOwnerID(ctx, tasksOwnerID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve tasks: %w", err)
+		return nil, fmt.Errorf("failed to retrieve attachments: %w", err)
 	}
+	attachmentsByTask := make(map[string][]string)
+	for _, attachment := range attachments {
+		attachmentsByTask[attachment.TaskID] = append(attachmentsByTask[attachment.TaskID], attachment.Filename)
+	}
+
+	// Time tracked on the tasks by all of their users, up to now for the running timers
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	tracked, err := uc.timeEntryRepo.SumByTaskIDs(ctx, ids, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tracked time: %w", err)
+	}
+
+	sections := groupTasksByProject(tasks, projects)
 
 	// Create PDF with UTF-8 support
 	pdf := gofpdf.New("P", "mm", "A4", "")
 
 	// Add UTF-8 font support
 	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont("Arial", "I", 8)
+		pdf.CellFormat(190, 10, tr(fmt.Sprintf("Página %d de {nb}", pdf.PageNo())), "", 0, "C", false, 0, "")
+	})
+
+	writePDFCover(pdf, tr, filter, projects, tasks, sections, tracked)
+
+	for _, section := range sections {
+		pdf.AddPage()
+		pdf.SetFont("Arial", "B", 18)
+		pdf.CellFormat(190, 10, tr(section.title), "B", 1, "L", false, 0, "")
+		pdf.Ln(6)
+
+		for i, task := range section.tasks {
+			writePDFTask(pdf, tr, i+1, task, attachmentsByTask[task.ID], tracked[task.ID])
+		}
+	}
+
+	// Output PDF to buffer
+	var buf bytes.Buffer
+	err = pdf.Output(&buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// findTasks returns the tasks in the scope of filter, the projects they may belong to and the
+// owner of the tasks, who is the owner of the project when exporting a shared one
+func (uc *ExportTasksPDFUseCase) findTasks(ctx context.Context, userID string, filter application.TaskExportFilter) ([]*application.Task, []*application.Project, string, error) {
+	var tasks []*application.Task
+	var projects []*application.Project
+	ownerID := userID
+
+	if filter.ProjectID != "" {
+		project, err := findUserProject(ctx, uc.projectRepo, filter.ProjectID, userID, false)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		if tasks, err = uc.taskRepo.FindByProjectID(ctx, project.ID); err != nil {
+			return nil, nil, "", fmt.Errorf("failed to retrieve tasks: %w", err)
+		}
+		projects, ownerID = []*application.Project{project}, project.OwnerID
+	} else {
+		var err error
+		if tasks, err = uc.taskRepo.FindByOwnerID(ctx, userID); err != nil {
+			return nil, nil, "", fmt.Errorf("failed to retrieve tasks: %w", err)
+		}
+		if projects, err = uc.projectRepo.FindByOwnerID(ctx, userID); err != nil {
+			return nil, nil, "", fmt.Errorf("failed to retrieve projects: %w", err)
+		}
+	}
+
+	matching := make([]*application.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if filter.Matches(task) {
+			matching = append(matching, task)
+		}
+	}
+	return matching, projects, ownerID, nil
+}
+
+// groupTasksByProject splits tasks in a section per project, in the order of projects,
+// followed by the tasks without a project. Projects without tasks are left out.
+func groupTasksByProject(tasks []*application.Task, projects []*application.Project) []pdfSection {
+	byProject := make(map[string][]*application.Task)
+	for _, task := range tasks {
+		byProject[task.ProjectID] = append(byProject[task.ProjectID], task)
+	}
+
+	var sections []pdfSection
+	for _, project := range projects {
+		if projectTasks := byProject[project.ID]; len(projectTasks) > 0 {
+			sections = append(sections, pdfSection{title: project.Name, tasks: projectTasks})
+			delete(byProject, project.ID)
+		}
+	}
+
+	// Tasks only go into projects of their owner, so every project is known; should one be
+	// missing, its tasks are still exported with the ones without a project
+	var unassigned []*application.Task
+	for _, task := range tasks {
+		if _, ok := byProject[task.ProjectID]; ok {
+			unassigned = append(unassigned, task)
+		}
+	}
+	if len(unassigned) > 0 {
+		sections = append(sections, pdfSection{title: "Sem projeto", tasks: unassigned})
+	}
+	return sections
+}
+
+// writePDFCover writes the summary cover page of a task export: the scope of the export,
+// the task count per status, and the task count and tracked time of each section
+func writePDFCover(pdf *gofpdf.Fpdf, tr func(string) string, filter application.TaskExportFilter, projects []*application.Project, tasks []*application.Task, sections []pdfSection, tracked map[string]time.Duration) {
 	pdf.AddPage()
 
 	// Set title
@@ -51,73 +193,125 @@ func (uc *ExportTasksPDFUseCase) Execute(ctx context.Context, ownerID string) ([
 	pdf.CellFormat(190, 6, tr(fmt.Sprintf("Gerado em: %s", time.Now().Format("02/01/2006 15:04:05"))), "", 1, "C", false, 0, "")
 	pdf.Ln(10)
 
-	// Add tasks
+	// Scope of the export
+	pdf.SetFont("Arial", "", 11)
+	if filter.ProjectID != "" && len(projects) == 1 {
+		pdf.CellFormat(190, 6, tr(fmt.Sprintf("Projeto: %s", projects[0].Name)), "", 1, "L", false, 0, "")
+	}
+	if filter.Tag != "" {
+		pdf.CellFormat(190, 6, tr(fmt.Sprintf("Tag: #%s", filter.Tag)), "", 1, "L", false, 0, "")
+	}
+	if filter.Status != "" {
+		pdf.CellFormat(190, 6, tr(fmt.Sprintf("Status: %s", getStatusText(filter.Status))), "", 1, "L", false, 0, "")
+	}
+
 	if len(tasks) == 0 {
+		pdf.Ln(4)
 		pdf.SetFont("Arial", "", 12)
 		pdf.CellFormat(190, 10, tr("Nenhuma tarefa encontrada."), "", 1, "L", false, 0, "")
-	} else {
-		for i, task := range tasks {
-			// Task number and title
-			pdf.SetFont("Arial", "B", 14)
-			pdf.CellFormat(190, 8, tr(fmt.Sprintf("%d. %s", i+1, task.Title)), "", 1, "L", false, 0, "")
-			pdf.Ln(2)
-
-			// Status
-			pdf.SetFont("Arial", "", 11)
-			statusText := getStatusText(task.Status)
-			pdf.CellFormat(190, 6, tr(fmt.Sprintf("Status: %s", statusText)), "", 1, "L", false, 0, "")
-
-			// Description
-			if task.Description != "" {
-				pdf.SetFont("Arial", "", 11)
-				pdf.MultiCell(190, 5, tr(fmt.Sprintf("Descricao: %s", task.Description)), "", "L", false)
-			}
-
-			// Image (if present)
-			if task.ImagePath != "" {
-				// Convert relative path to absolute path
-				imagePath := strings.TrimPrefix(task.ImagePath, "/")
-
-				// Check if file exists
-				if _, err := os.Stat(imagePath); err == nil {
-					// Get current Y position
-					currentY := pdf.GetY()
-
-					// Register image and get dimensions
-					opt := gofpdf.ImageOptions{
-						ImageType: getImageType(imagePath),
-						ReadDpi:   true,
-					}
-
-					// Calculate image size (max 200x200px = ~70x70mm at 72dpi)
-					imgWidth := 70.0  // 200px at 72dpi ≈ 70mm
-					imgHeight := 70.0
-
-					// Add image with size constraints
-					pdf.ImageOptions(imagePath, 10, currentY+2, imgWidth, imgHeight, false, opt, 0, "")
-
-					// Move Y position after image
-					pdf.SetY(currentY + imgHeight + 4)
-				}
-			}
-
-			// Created date
-			pdf.SetFont("Arial", "I", 9)
-			pdf.CellFormat(190, 5, tr(fmt.Sprintf("Criada em: %s", task.CreatedAt.Format("02/01/2006 15:04"))), "", 1, "L", false, 0, "")
-
-			// Add spacing between tasks
-			pdf.Ln(8)
-		}
+		return
 	}
 
-	// Output PDF to buffer
-	var buf bytes.Buffer
-	err = pdf.Output(&buf)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	// Task count per status
+	pdf.Ln(4)
+	pdf.SetFont("Arial", "B", 14)
+	pdf.CellFormat(190, 8, tr(fmt.Sprintf("Total de tarefas: %d", len(tasks))), "", 1, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 11)
+	pdf.CellFormat(190, 6, tr(statusSummary(tasks)), "", 1, "L", false, 0, "")
+	pdf.CellFormat(190, 6, tr("Tempo registrado: "+application.FormatDuration(trackedTime(tasks, tracked))), "", 1, "L", false, 0, "")
+	pdf.Ln(6)
+
+	// Task count and tracked time per section
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(120, 7, tr("Projeto"), "B", 0, "L", false, 0, "")
+	pdf.CellFormat(30, 7, tr("Tarefas"), "B", 0, "R", false, 0, "")
+	pdf.CellFormat(40, 7, tr("Tempo"), "B", 1, "R", false, 0, "")
+	pdf.SetFont("Arial", "", 11)
+	for _, section := range sections {
+		pdf.CellFormat(120, 6, tr(section.title), "", 0, "L", false, 0, "")
+		pdf.CellFormat(30, 6, fmt.Sprintf("%d", len(section.tasks)), "", 0, "R", false, 0, "")
+		pdf.CellFormat(40, 6, application.FormatDuration(trackedTime(section.tasks, tracked)), "", 1, "R", false, 0, "")
 	}
+}
 
-	return buf.Bytes(), nil
+// trackedTime sums the time tracked on tasks
+func trackedTime(tasks []*application.Task, tracked map[string]time.Duration) time.Duration {
+	var total time.Duration
+	for _, task := range tasks {
+		total += tracked[task.ID]
+	}
+	return total
+}
+
+// statusSummary returns the task count per status, in Portuguese
+func statusSummary(tasks []*application.Task) string {
+	counts := make(map[application.TaskStatus]int)
+	for _, task := range tasks {
+		counts[task.Status]++
+	}
+
+	statuses := []application.TaskStatus{application.StatusPending, application.StatusInProgress, application.StatusCompleted}
+	parts := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		parts = append(parts, fmt.Sprintf("%s: %d", getStatusText(status), counts[status]))
+	}
+	return strings.Join(parts, "  |  ")
+}
+
+// writePDFTask writes a task of an export, numbered within its section
+func writePDFTask(pdf *gofpdf.Fpdf, tr func(string) string, number int, task *application.Task, attachments []string, tracked time.Duration) {
+	// Task number and title
+	pdf.SetFont("Arial", "B", 14)
+	pdf.CellFormat(190, 8, tr(fmt.Sprintf("%d. %s", number, task.Title)), "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	// Status
+	pdf.SetFont("Arial", "", 11)
+	statusText := getStatusText(task.Status)
+	pdf.CellFormat(190, 6, tr(fmt.Sprintf("Status: %s", statusText)), "", 1, "L", false, 0, "")
+	pdf.CellFormat(190, 6, tr(fmt.Sprintf("Progresso: %d%%", task.PercentComplete())), "", 1, "L", false, 0, "")
+	if task.CompletionNote != "" {
+		pdf.MultiCell(190, 5, tr(fmt.Sprintf("Nota de conclusao: %s", task.CompletionNote)), "", "L", false)
+	}
+
+	// Description
+	if task.Description != "" {
+		pdf.SetFont("Arial", "", 11)
+		pdf.MultiCell(190, 5, tr(fmt.Sprintf("Descricao: %s", task.Description)), "", "L", false)
+	}
+
+	// Tags
+	if len(task.Tags) > 0 {
+		pdf.SetFont("Arial", "", 11)
+		pdf.MultiCell(190, 5, tr(fmt.Sprintf("Tags: #%s", strings.Join(task.Tags, " #"))), "", "L", false)
+	}
+
+	// Tracked time
+	if tracked > 0 {
+		pdf.SetFont("Arial", "", 11)
+		pdf.CellFormat(190, 6, tr("Tempo registrado: "+application.FormatDuration(tracked)), "", 1, "L", false, 0, "")
+	}
+
+	// Image (if present)
+	if task.ImagePath != "" {
+		addPDFImage(pdf, task.ImagePath)
+	}
+
+	// Attachment file names (the files themselves are not embedded)
+	if len(attachments) > 0 {
+		pdf.SetFont("Arial", "", 11)
+		pdf.MultiCell(190, 5, tr(fmt.Sprintf("Anexos: %s", strings.Join(attachments, ", "))), "", "L", false)
+	}
+
+	// Created date
+	pdf.SetFont("Arial", "I", 9)
+	pdf.CellFormat(190, 5, tr(fmt.Sprintf("Criada em: %s", task.CreatedAt.Format("02/01/2006 15:04"))), "", 1, "L", false, 0, "")
+	if task.DueDate != nil {
+		pdf.CellFormat(190, 5, tr(fmt.Sprintf("Prazo: %s", task.DueDate.Format("02/01/2006 15:04"))), "", 1, "L", false, 0, "")
+	}
+
+	// Add spacing between tasks
+	pdf.Ln(8)
 }
 
 // getStatusText converts task status to Portuguese text
@@ -134,6 +328,25 @@ func getStatusText(status application.TaskStatus) string {
 	}
 }
 
+// addPDFImage adds a task image below the current position, scaled to 70x70mm (about
+// 200x200px at 72dpi), and moves below it. Images missing from disk are skipped.
+func addPDFImage(pdf *gofpdf.Fpdf, taskImagePath string) {
+	// Convert relative path to absolute path
+	imagePath := strings.TrimPrefix(taskImagePath, "/")
+	if _, err := os.Stat(imagePath); err != nil {
+		return
+	}
+
+	currentY := pdf.GetY()
+	opt := gofpdf.ImageOptions{
+		ImageType: getImageType(imagePath),
+		ReadDpi:   true,
+	}
+	imgWidth, imgHeight := 70.0, 70.0
+	pdf.ImageOptions(imagePath, 10, currentY+2, imgWidth, imgHeight, false, opt, 0, "")
+	pdf.SetY(currentY + imgHeight + 4)
+}
+
 // getImageType returns the image type for gofpdf based on file extension
 func getImageType(imagePath string) string {
 	ext := strings.ToLower(filepath.Ext(imagePath))
@@ -2,7 +2,12 @@ package usecases
 
 import (
 	"bytes"
+	"compress/zlib"
 	"context"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -35,13 +40,31 @@ func (m *MockExportTaskRepository) Delete(ctx context.Context, id string) error
 }
 
 func (m *MockExportTaskRepository) FindByID(ctx context.Context, id string) (*application.Task, error) {
-	return nil, nil
+	return nil, application.ErrTaskNotFound
 }
 
 func (m *MockExportTaskRepository) FindSharedWithUser(ctx context.Context, userID string) ([]*application.Task, error) {
 	return nil, nil
 }
 
+func (m *MockExportTaskRepository) FindByProjectID(ctx context.Context, projectID string) ([]*application.Task, error) {
+	var tasks []*application.Task
+	for _, task := range m.tasks {
+		if task.ProjectID == projectID {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+func (m *MockExportTaskRepository) FindByImagePath(ctx context.Context, imagePath string) ([]*application.Task, error) {
+	return nil, nil
+}
+
+func (m *MockExportTaskRepository) CreateMany(ctx context.Context, tasks []*application.Task) error {
+	return nil
+}
+
 func TestExportTasksPDFUseCase_Execute(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -104,10 +127,10 @@ func TestExportTasksPDFUseCase_Execute(t *testing.T) {
 				tasks: tt.tasks,
 			}
 
-			useCase := NewExportTasksPDFUseCase(mockRepo)
+			useCase := NewExportTasksPDFUseCase(mockRepo, &mockAttachmentRepository{attachments: map[string]*application.Attachment{}}, newMockProjectRepository(), newMockTimeEntryRepository())
 			ctx := context.Background()
 
-			pdfBytes, err := useCase.Execute(ctx, tt.ownerID)
+			pdfBytes, err := useCase.Execute(ctx, tt.ownerID, application.TaskExportFilter{})
 
 			if tt.wantError && err == nil {
 				t.Error("Expected error but got none")
@@ -134,3 +157,175 @@ func TestExportTasksPDFUseCase_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestExportTasksPDFUseCase_IncludesAttachments(t *testing.T) {
+	taskRepo := &MockExportTaskRepository{tasks: []*application.Task{
+		{ID: "task-1", Title: "Relatório", Status: application.StatusPending, OwnerID: "user-1", CreatedAt: time.Now()},
+	}}
+	attachmentRepo := &mockAttachmentRepository{attachments: map[string]*application.Attachment{
+		"att-1": {ID: "att-1", TaskID: "task-1", Filename: "planilha.xlsx"},
+	}}
+
+	pdfBytes, err := NewExportTasksPDFUseCase(taskRepo, attachmentRepo, newMockProjectRepository(), newMockTimeEntryRepository()).Execute(context.Background(), "user-1", application.TaskExportFilter{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(pdfText(t, pdfBytes), "Anexos: planilha.xlsx") {
+		t.Error("Expected the PDF to list the attachment file names")
+	}
+}
+
+// pdfStreamRegex matches the content streams of a PDF
+var pdfStreamRegex = regexp.MustCompile(`(?s)stream\r?\n(.*?)endstream`)
+
+// pdfText returns the decompressed content streams of a PDF
+func pdfText(t *testing.T, pdf []byte) string {
+	t.Helper()
+
+	var text strings.Builder
+	for _, m := range pdfStreamRegex.FindAllSubmatch(pdf, -1) {
+		r, err := zlib.NewReader(bytes.NewReader(m[1]))
+		if err != nil {
+			continue
+		}
+		content, _ := io.ReadAll(r)
+		text.Write(content)
+	}
+	return text.String()
+}
+
+func TestExportTasksPDFUseCase_AttachmentError(t *testing.T) {
+	taskRepo := &MockExportTaskRepository{tasks: []*application.Task{}}
+
+	_, err := NewExportTasksPDFUseCase(taskRepo, &failingAttachmentRepository{}, newMockProjectRepository(), newMockTimeEntryRepository()).Execute(context.Background(), "user-1", application.TaskExportFilter{})
+	if err == nil {
+		t.Fatal("Expected error when attachments can't be loaded")
+	}
+}
+
+// failingAttachmentRepository fails every lookup
+type failingAttachmentRepository struct {
+	mockAttachmentRepository
+}
+
+func (m *failingAttachmentRepository) FindByOwnerID(ctx context.Context, ownerID string) ([]*application.Attachment, error) {
+	return nil, errors.New("db down")
+}
+
+func TestExportTasksPDFUseCase_SectionsPerProject(t *testing.T) {
+	now := time.Now()
+	taskRepo := &MockExportTaskRepository{tasks: []*application.Task{
+		{ID: "task-1", Title: "Ofício", Status: application.StatusPending, OwnerID: "user-1", ProjectID: "project-1", Tags: []string{"receita"}, CreatedAt: now},
+		{ID: "task-2", Title: "Parecer", Status: application.StatusCompleted, OwnerID: "user-1", ProjectID: "project-2", Tags: []string{"receita"}, CreatedAt: now},
+		{ID: "task-3", Title: "Avulsa", Status: application.StatusPending, OwnerID: "user-1", Tags: []string{"receita"}, CreatedAt: now},
+		{ID: "task-4", Title: "Sem tag", Status: application.StatusPending, OwnerID: "user-1", ProjectID: "project-1", CreatedAt: now},
+	}}
+	projectRepo := newMockProjectRepository(
+		&application.Project{ID: "project-1", Name: "Fiscalização", OwnerID: "user-1"},
+		&application.Project{ID: "project-2", Name: "Arrecadação", OwnerID: "user-1"},
+	)
+	stopped := now.Add(-time.Hour)
+	timeEntryRepo := newMockTimeEntryRepository(
+		&application.TimeEntry{ID: "entry-1", TaskID: "task-1", UserID: "user-1", StartedAt: stopped.Add(-time.Hour), StoppedAt: &stopped},
+		&application.TimeEntry{ID: "entry-2", TaskID: "task-2", UserID: "user-1", StartedAt: stopped.Add(-30 * time.Minute), StoppedAt: &stopped},
+	)
+	useCase := NewExportTasksPDFUseCase(taskRepo, &mockAttachmentRepository{attachments: map[string]*application.Attachment{}}, projectRepo, timeEntryRepo)
+
+	tests := []struct {
+		name      string
+		filter    application.TaskExportFilter
+		wantPages int
+		want      []string
+		notWant   []string
+	}{
+		{
+			name:      "every task",
+			wantPages: 4, // The cover, two projects and the tasks without a project
+			want:      []string{"Total de tarefas: 4", "Fiscaliza", "Arrecada", "Sem projeto", "Pendente: 3", "Tempo registrado: 1h 30m"},
+		},
+		{
+			name:      "tag and status",
+			filter:    application.TaskExportFilter{Tag: "receita", Status: application.StatusPending},
+			wantPages: 3,
+			want:      []string{"Total de tarefas: 2", "Tag: #receita", "Avulsa"},
+			notWant:   []string{"Parecer", "Sem tag"},
+		},
+		{
+			name:      "project",
+			filter:    application.TaskExportFilter{ProjectID: "project-1"},
+			wantPages: 2,
+			want:      []string{"Total de tarefas: 2", "Sem tag"},
+			notWant:   []string{"Avulsa", "Sem projeto"},
+		},
+		{
+			name:      "nothing in scope",
+			filter:    application.TaskExportFilter{Status: application.StatusInProgress},
+			wantPages: 1,
+			want:      []string{"Nenhuma tarefa encontrada."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pdfBytes, err := useCase.Execute(context.Background(), "user-1", tt.filter)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if pages := pdfPageCount(pdfBytes); pages != tt.wantPages {
+				t.Errorf("Expected %d pages, got %d", tt.wantPages, pages)
+			}
+			text := pdfText(t, pdfBytes)
+			for _, want := range tt.want {
+				if !strings.Contains(text, want) {
+					t.Errorf("Expected the PDF to contain %q", want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(text, notWant) {
+					t.Errorf("Expected the PDF not to contain %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestExportTasksPDFUseCase_ProjectAccess(t *testing.T) {
+	taskRepo := &MockExportTaskRepository{tasks: []*application.Task{
+		{ID: "task-1", Title: "Compartilhada", Status: application.StatusPending, OwnerID: "owner", ProjectID: "project-1", CreatedAt: time.Now()},
+	}}
+	projectRepo := newMockProjectRepository(&application.Project{ID: "project-1", Name: "Equipe", OwnerID: "owner"})
+	projectRepo.shares["project-1"] = []string{"member"}
+	useCase := NewExportTasksPDFUseCase(taskRepo, &mockAttachmentRepository{attachments: map[string]*application.Attachment{}}, projectRepo, newMockTimeEntryRepository())
+
+	tests := []struct {
+		name      string
+		userID    string
+		projectID string
+		wantErr   error
+	}{
+		{name: "owner", userID: "owner", projectID: "project-1"},
+		{name: "shared with the user", userID: "member", projectID: "project-1"},
+		{name: "not shared", userID: "stranger", projectID: "project-1", wantErr: application.ErrProjectNotFound},
+		{name: "unknown project", userID: "owner", projectID: "project-2", wantErr: application.ErrProjectNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pdfBytes, err := useCase.Execute(context.Background(), tt.userID, application.TaskExportFilter{ProjectID: tt.projectID})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !strings.Contains(pdfText(t, pdfBytes), "Compartilhada") {
+				t.Error("Expected the PDF to list the tasks of the project")
+			}
+		})
+	}
+}
+
+// pdfPageRegex matches the page objects of a PDF, but not the page tree
+var pdfPageRegex = regexp.MustCompile(`/Type /Page\b[^s]`)
+
+// pdfPageCount returns the number of pages of a PDF
+func pdfPageCount(pdf []byte) int {
+	return len(pdfPageRegex.FindAll(pdf, -1))
+}
@@ -0,0 +1,33 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// GetTaskCountersUseCase handles counting the pending, overdue and shared tasks shown in the
+// navbar
+type GetTaskCountersUseCase struct {
+	viewRepo repository.TaskViewRepository
+	now      func() time.Time
+}
+
+// NewGetTaskCountersUseCase creates a new GetTaskCountersUseCase
+func NewGetTaskCountersUseCase(viewRepo repository.TaskViewRepository) *GetTaskCountersUseCase {
+	return &GetTaskCountersUseCase{
+		viewRepo: viewRepo,
+		now:      time.Now,
+	}
+}
+
+// Execute counts the open tasks of a user. Snoozed tasks are left out of the pending and
+// overdue counts, as they are of the task list.
+func (uc *GetTaskCountersUseCase) Execute(ctx context.Context, userID string) (_ *application.TaskCounters, err error) {
+	ctx, end := tracer.Start(ctx, "GetTaskCounters")
+	defer func() { end(err) }()
+
+	return uc.viewRepo.CountForUser(ctx, userID, uc.now())
+}
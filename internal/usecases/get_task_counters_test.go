@@ -0,0 +1,44 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestGetTaskCountersUseCase(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	yesterday := now.AddDate(0, 0, -1)
+	tomorrow := now.AddDate(0, 0, 1)
+
+	viewRepo := &mockTaskViewRepository{views: []*application.TaskView{
+		{Task: &application.Task{ID: "task-1", OwnerID: "user-1", Status: application.StatusPending}},
+		{Task: &application.Task{ID: "task-2", OwnerID: "user-1", Status: application.StatusInProgress, DueDate: &yesterday}},
+		{Task: &application.Task{ID: "task-3", OwnerID: "user-1", Status: application.StatusPending, DueDate: &yesterday, SnoozedUntil: &tomorrow}},
+		{Task: &application.Task{ID: "task-4", OwnerID: "user-1", Status: application.StatusCompleted, DueDate: &yesterday}},
+		{Task: &application.Task{ID: "task-5", OwnerID: "user-2", Status: application.StatusPending}},
+	}}
+	useCase := NewGetTaskCountersUseCase(viewRepo)
+	useCase.now = func() time.Time { return now }
+
+	counters, err := useCase.Execute(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	want := application.TaskCounters{Pending: 2, Overdue: 1, Shared: 1}
+	if *counters != want {
+		t.Errorf("Execute() = %+v, want %+v", *counters, want)
+	}
+}
+
+func TestGetTaskCountersUseCase_Error(t *testing.T) {
+	repoErr := errors.New("database error")
+	useCase := NewGetTaskCountersUseCase(&mockTaskViewRepository{countErr: repoErr})
+
+	if _, err := useCase.Execute(context.Background(), "user-1"); !errors.Is(err, repoErr) {
+		t.Errorf("Expected %v, got %v", repoErr, err)
+	}
+}
@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// GetTaskOverviewUseCase handles reading the tasks page of a user: the tasks they own and those
+// shared with them, with the counters of the whole list
+type GetTaskOverviewUseCase struct {
+	overviewQuery repository.TaskOverviewQuery
+	settingsRepo  repository.UserSettingsRepository
+	now           func() time.Time
+}
+
+// NewGetTaskOverviewUseCase creates a new GetTaskOverviewUseCase
+func NewGetTaskOverviewUseCase(overviewQuery repository.TaskOverviewQuery, settingsRepo repository.UserSettingsRepository) *GetTaskOverviewUseCase {
+	return &GetTaskOverviewUseCase{
+		overviewQuery: overviewQuery,
+		settingsRepo:  settingsRepo,
+		now:           time.Now,
+	}
+}
+
+// Execute returns a page of up to limit items, in the default sort of the user, starting after
+// the cursor (nil for the first page). Snoozed tasks of the user are left out, as they are of
+// the counters. limit 0 takes application.DefaultTasksPerPage. The overview has the cursor of
+// the next page, if any.
+func (uc *GetTaskOverviewUseCase) Execute(ctx context.Context, userID string, after *application.TaskCursor, limit int) (_ *application.TaskOverview, err error) {
+	ctx, end := tracer.Start(ctx, "GetTaskOverview")
+	defer func() { end(err) }()
+
+	if limit == 0 {
+		limit = application.DefaultTasksPerPage
+	}
+	if limit < 1 || limit > application.MaxTasksPerPage {
+		return nil, application.ErrInvalidTaskLimit
+	}
+
+	settings, err := userSettings(ctx, uc.settingsRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// One more item tells whether there is a next page
+	overview, err := uc.overviewQuery.FindOverview(ctx, userID, uc.now(), settings.DefaultSort, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(overview.Items) > limit {
+		overview.Items = overview.Items[:limit]
+		overview.NextCursor = overview.Items[limit-1].Cursor()
+	}
+	return overview, nil
+}
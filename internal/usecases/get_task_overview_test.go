@@ -0,0 +1,104 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// mockTaskOverviewQuery lists the views of the fixture a user can see, leaving the query
+// itself to the database tests: their own that aren't snoozed and those of other owners
+type mockTaskOverviewQuery struct {
+	views []*application.TaskView
+	err   error
+}
+
+func (m *mockTaskOverviewQuery) FindOverview(ctx context.Context, userID string, now time.Time, sort application.TaskSort, after *application.TaskCursor, limit int) (*application.TaskOverview, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	var views []*application.TaskView
+	for _, view := range m.views {
+		if !view.IsOwnedBy(userID) || !view.IsSnoozed(now) {
+			views = append(views, view)
+		}
+	}
+	counters, _ := (&mockTaskViewRepository{views: m.views}).CountForUser(ctx, userID, now)
+
+	overview := &application.TaskOverview{Items: []*application.TaskListItem{}, Counters: *counters}
+	for _, view := range pageAfter(views, sort, after, limit) {
+		overview.Items = append(overview.Items, &application.TaskListItem{TaskView: view, Owned: view.IsOwnedBy(userID)})
+	}
+	return overview, nil
+}
+
+func TestGetTaskOverviewUseCase_Execute(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tomorrow := now.AddDate(0, 0, 1)
+	query := &mockTaskOverviewQuery{}
+	for i, spec := range []struct{ id, owner string }{
+		{"task-5", "user-1"}, {"task-4", "user-1"}, {"task-3", "user-2"}, {"task-2", "user-1"}, {"task-1", "user-2"},
+	} {
+		task := &application.Task{ID: spec.id, OwnerID: spec.owner, Status: application.StatusPending, CreatedAt: now.Add(-time.Duration(i) * time.Hour)}
+		if spec.id == "task-4" {
+			task.SnoozedUntil = &tomorrow
+		}
+		query.views = append(query.views, &application.TaskView{Task: task})
+	}
+	settingsRepo := newMockUserSettingsRepository()
+	useCase := NewGetTaskOverviewUseCase(query, settingsRepo)
+	useCase.now = func() time.Time { return now }
+
+	pages := func(userID string) []string {
+		t.Helper()
+		var ids []string
+		var after *application.TaskCursor
+		for pages := 0; ; pages++ {
+			if pages == 3 {
+				t.Fatalf("Execute() kept returning cursors after %v", ids)
+			}
+			overview, err := useCase.Execute(context.Background(), userID, after, 2)
+			if err != nil {
+				t.Fatalf("Execute() error: %v", err)
+			}
+			if want := (application.TaskCounters{Pending: 2, Shared: 2}); overview.Counters != want {
+				t.Errorf("Counters = %+v, want %+v on every page", overview.Counters, want)
+			}
+			for _, item := range overview.Items {
+				ids = append(ids, item.ID)
+			}
+			if overview.NextCursor == nil {
+				return ids
+			}
+			after = overview.NextCursor
+		}
+	}
+
+	want := []string{"task-5", "task-3", "task-2", "task-1"}
+	if ids := pages("user-1"); !slices.Equal(ids, want) {
+		t.Errorf("pages = %v, want %v without the snoozed task", ids, want)
+	}
+
+	// The list follows the default sort of the user
+	settingsRepo.settings["user-1"] = &application.UserSettings{UserID: "user-1", DefaultSort: application.SortOldest}
+	want = []string{"task-1", "task-2", "task-3", "task-5"}
+	if ids := pages("user-1"); !slices.Equal(ids, want) {
+		t.Errorf("pages sorted oldest first = %v, want %v", ids, want)
+	}
+
+	for _, limit := range []int{-1, application.MaxTasksPerPage + 1} {
+		if _, err := useCase.Execute(context.Background(), "user-1", nil, limit); !errors.Is(err, application.ErrInvalidTaskLimit) {
+			t.Errorf("Execute(limit %d) error = %v, want %v", limit, err, application.ErrInvalidTaskLimit)
+		}
+	}
+
+	query.err = errors.New("db down")
+	if _, err := useCase.Execute(context.Background(), "user-1", nil, 0); !errors.Is(err, query.err) {
+		t.Errorf("Execute() error = %v, want %v", err, query.err)
+	}
+}
@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// idempotencyStaleAfter is how long a request holds its key without answering before a retry
+// takes it over, so a key isn't stuck when the process dies mid-request. It outlasts the
+// timeout of the API routes.
+const idempotencyStaleAfter = 5 * time.Minute
+
+// IdempotencyUseCase handles the requests sent with an Idempotency-Key: the first one runs and
+// its response is kept for ttl, replayed to the retries of the same request by the same user
+type IdempotencyUseCase struct {
+	keyRepo repository.IdempotencyRepository
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+// NewIdempotencyUseCase creates a new IdempotencyUseCase keeping responses for ttl
+func NewIdempotencyUseCase(keyRepo repository.IdempotencyRepository, ttl time.Duration) *IdempotencyUseCase {
+	return &IdempotencyUseCase{
+		keyRepo: keyRepo,
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// Begin claims a key for a request, identified by requestHash. It returns nil when the request
+// should run, followed by Finish or Release, or the stored record whose response answers a
+// retry. It returns application.ErrIdempotencyKeyReused when the key was used with another
+// request, application.ErrIdempotencyKeyInProgress while the first request is still running,
+// and application.ErrInvalidIdempotencyKey for a malformed key.
+func (uc *IdempotencyUseCase) Begin(ctx context.Context, userID, key, requestHash string) (_ *application.IdempotencyRecord, err error) {
+	ctx, end := tracer.Start(ctx, "BeginIdempotentRequest")
+	defer func() { end(err) }()
+
+	now := uc.now()
+	record, err := application.NewIdempotencyRecord(userID, key, requestHash, now, uc.ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.keyRepo.Reserve(ctx, record, now.Add(-idempotencyStaleAfter))
+	switch {
+	case err != nil || existing == nil:
+		return nil, err
+	case existing.RequestHash != requestHash:
+		return nil, application.ErrIdempotencyKeyReused
+	case !existing.Answered():
+		return nil, application.ErrIdempotencyKeyInProgress
+	}
+	return existing, nil
+}
+
+// Finish stores the response of a request started with Begin, for its retries
+func (uc *IdempotencyUseCase) Finish(ctx context.Context, record *application.IdempotencyRecord) (err error) {
+	ctx, end := tracer.Start(ctx, "FinishIdempotentRequest")
+	defer func() { end(err) }()
+
+	return uc.keyRepo.Complete(ctx, record)
+}
+
+// Release frees the key of a request started with Begin without storing its response, so a
+// retry runs the request again
+func (uc *IdempotencyUseCase) Release(ctx context.Context, userID, key string) (err error) {
+	ctx, end := tracer.Start(ctx, "ReleaseIdempotencyKey")
+	defer func() { end(err) }()
+
+	return uc.keyRepo.Delete(ctx, userID, key)
+}
+
+// Purge deletes the expired keys; the cleanup job runs it periodically
+func (uc *IdempotencyUseCase) Purge(ctx context.Context) (_ int, err error) {
+	ctx, end := tracer.Start(ctx, "PurgeIdempotencyKeys")
+	defer func() { end(err) }()
+
+	return uc.keyRepo.DeleteExpired(ctx, uc.now())
+}
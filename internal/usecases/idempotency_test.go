@@ -0,0 +1,134 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// mockIdempotencyRepository keeps the records in memory, by user and key
+type mockIdempotencyRepository struct {
+	records     map[string]*application.IdempotencyRecord
+	staleBefore time.Time
+}
+
+func (m *mockIdempotencyRepository) Reserve(ctx context.Context, record *application.IdempotencyRecord, staleBefore time.Time) (*application.IdempotencyRecord, error) {
+	m.staleBefore = staleBefore
+	if m.records == nil {
+		m.records = make(map[string]*application.IdempotencyRecord)
+	}
+	if existing, ok := m.records[record.UserID+"/"+record.Key]; ok && existing.ExpiresAt.After(record.CreatedAt) {
+		return existing, nil
+	}
+	m.records[record.UserID+"/"+record.Key] = record
+	return nil, nil
+}
+
+func (m *mockIdempotencyRepository) Complete(ctx context.Context, record *application.IdempotencyRecord) error {
+	m.records[record.UserID+"/"+record.Key] = record
+	return nil
+}
+
+func (m *mockIdempotencyRepository) Delete(ctx context.Context, userID, key string) error {
+	delete(m.records, userID+"/"+key)
+	return nil
+}
+
+func (m *mockIdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	deleted := 0
+	for id, record := range m.records {
+		if !record.ExpiresAt.After(now) {
+			delete(m.records, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func TestIdempotencyUseCase_Begin(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	answered := &application.IdempotencyRecord{UserID: "user-1", Key: "key-1", RequestHash: "hash-1", Status: 201, ExpiresAt: now.Add(time.Hour)}
+	running := &application.IdempotencyRecord{UserID: "user-1", Key: "key-1", RequestHash: "hash-1", ExpiresAt: now.Add(time.Hour)}
+
+	tests := []struct {
+		name     string
+		existing *application.IdempotencyRecord
+		key      string
+		hash     string
+		want     *application.IdempotencyRecord
+		wantErr  error
+	}{
+		{name: "new key runs the request", key: "key-1", hash: "hash-1"},
+		{name: "retry gets the stored response", existing: answered, key: "key-1", hash: "hash-1", want: answered},
+		{name: "retry while running", existing: running, key: "key-1", hash: "hash-1", wantErr: application.ErrIdempotencyKeyInProgress},
+		{name: "key reused with another request", existing: answered, key: "key-1", hash: "hash-2", wantErr: application.ErrIdempotencyKeyReused},
+		{name: "key of another user", existing: &application.IdempotencyRecord{UserID: "user-2", Key: "key-1", RequestHash: "hash-2", Status: 201, ExpiresAt: now.Add(time.Hour)}, key: "key-1", hash: "hash-1"},
+		{name: "empty key", key: "", hash: "hash-1", wantErr: application.ErrInvalidIdempotencyKey},
+		{name: "key with control characters", key: "key\n1", hash: "hash-1", wantErr: application.ErrInvalidIdempotencyKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockIdempotencyRepository{}
+			if tt.existing != nil {
+				repo.records = map[string]*application.IdempotencyRecord{tt.existing.UserID + "/" + tt.existing.Key: tt.existing}
+			}
+			uc := NewIdempotencyUseCase(repo, time.Hour)
+			uc.now = func() time.Time { return now }
+
+			got, err := uc.Begin(context.Background(), "user-1", tt.key, tt.hash)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Begin() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Begin() = %+v, want %+v", got, tt.want)
+			}
+			if tt.wantErr == nil && tt.want == nil {
+				reserved := repo.records["user-1/"+tt.key]
+				if reserved == nil || reserved.Answered() || !reserved.ExpiresAt.Equal(now.Add(time.Hour)) {
+					t.Errorf("Expected the key reserved for an hour, got %+v", reserved)
+				}
+				if !repo.staleBefore.Equal(now.Add(-idempotencyStaleAfter)) {
+					t.Errorf("staleBefore = %v, want %v", repo.staleBefore, now.Add(-idempotencyStaleAfter))
+				}
+			}
+		})
+	}
+}
+
+func TestIdempotencyUseCase_FinishAndRelease(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	repo := &mockIdempotencyRepository{}
+	uc := NewIdempotencyUseCase(repo, time.Hour)
+	uc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if _, err := uc.Begin(ctx, "user-1", "key-1", "hash-1"); err != nil {
+		t.Fatalf("Begin() error: %v", err)
+	}
+	response := &application.IdempotencyRecord{UserID: "user-1", Key: "key-1", RequestHash: "hash-1", Status: 201, Body: []byte(`{}`), ExpiresAt: now.Add(time.Hour)}
+	if err := uc.Finish(ctx, response); err != nil {
+		t.Fatalf("Finish() error: %v", err)
+	}
+	if got, err := uc.Begin(ctx, "user-1", "key-1", "hash-1"); err != nil || got != response {
+		t.Errorf("Begin() after Finish() = %+v, %v; want the stored response", got, err)
+	}
+
+	if _, err := uc.Begin(ctx, "user-1", "key-2", "hash-2"); err != nil {
+		t.Fatalf("Begin() error: %v", err)
+	}
+	if err := uc.Release(ctx, "user-1", "key-2"); err != nil {
+		t.Fatalf("Release() error: %v", err)
+	}
+	if got, err := uc.Begin(ctx, "user-1", "key-2", "hash-2"); err != nil || got != nil {
+		t.Errorf("Begin() after Release() = %+v, %v; want the request to run again", got, err)
+	}
+
+	uc.now = func() time.Time { return now.Add(time.Hour) }
+	if purged, err := uc.Purge(ctx); err != nil || purged != 2 {
+		t.Errorf("Purge() = %d, %v; want both keys expired", purged, err)
+	}
+}
@@ -0,0 +1,7 @@
+package usecases
+
+// IDGenerator generates the IDs of new entities. Task IDs sort by creation time, so task
+// lists can be paginated with the ID of the last task seen as cursor.
+type IDGenerator interface {
+	NewID() string
+}
@@ -0,0 +1,275 @@
+package usecases
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// MaxImportRows limits how many tasks a single import may create
+const MaxImportRows = 1000
+
+var (
+	// ErrImportEmpty is returned when an import file has no rows
+	ErrImportEmpty = errors.New("import file has no tasks")
+	// ErrImportTooLarge is returned when an import file exceeds MaxImportRows
+	ErrImportTooLarge = fmt.Errorf("import file cannot exceed %d tasks", MaxImportRows)
+)
+
+// ImportRow holds the raw values of a task read from an import file
+type ImportRow struct {
+	Line        int    `json:"-"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	DueDate     string `json:"due_date"`
+}
+
+// ImportRowResult reports the outcome of importing a single row
+type ImportRowResult struct {
+	Line    int    `json:"line"`
+	Title   string `json:"title"`
+	TaskID  string `json:"task_id,omitempty"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ImportReport summarizes a task import
+type ImportReport struct {
+	Total   int                 `json:"total"`
+	Created int                 `json:"created"`
+	Failed  int                 `json:"failed"`
+	Rows    []ImportRowResult   `json:"rows"`
+	Tasks   []*application.Task `json:"-"`
+}
+
+// ImportTasksUseCase handles importing tasks from CSV or JSON files
+type ImportTasksUseCase struct {
+	taskRepo repository.TaskRepository
+	events   event.Publisher
+	ids      IDGenerator
+	quota    CreationQuotaUseCaseInterface
+}
+
+// NewImportTasksUseCase creates a new ImportTasksUseCase charging the tasks to quota (nil for no
+// quota)
+func NewImportTasksUseCase(taskRepo repository.TaskRepository, events event.Publisher, ids IDGenerator, quota CreationQuotaUseCaseInterface) *ImportTasksUseCase {
+	return &ImportTasksUseCase{
+		taskRepo: taskRepo,
+		events:   events,
+		ids:      ids,
+		quota:    quota,
+	}
+}
+
+// Execute validates every row against the domain rules and creates the valid tasks in a
+// single transaction. Invalid rows are reported and skipped; when strict is true a single
+// invalid row aborts the whole import. Due dates are interpreted in loc. The valid rows are
+// charged to the daily task quota all at once: when they don't fit, nothing is imported and
+// *application.CreationQuotaExceededError is returned.
+func (uc *ImportTasksUseCase) Execute(ctx context.Context, ownerID string, rows []ImportRow, loc *time.Location, strict bool) (_ *ImportReport, err error) {
+	ctx, end := tracer.Start(ctx, "ImportTasks")
+	defer func() { end(err) }()
+
+	if len(rows) == 0 {
+		return nil, ErrImportEmpty
+	}
+	if len(rows) > MaxImportRows {
+		return nil, ErrImportTooLarge
+	}
+
+	report := &ImportReport{Total: len(rows)}
+	now := time.Now()
+
+	for _, row := range rows {
+		result := ImportRowResult{Line: row.Line, Title: row.Title}
+
+		task, err := newImportedTask(uc.ids.NewID(), row, ownerID, now, loc)
+		if err != nil {
+			result.Error = err.Error()
+			report.Failed++
+		} else {
+			inOrgScope(ctx, task)
+			result.TaskID = task.ID
+			result.Success = true
+			report.Tasks = append(report.Tasks, task)
+		}
+
+		report.Rows = append(report.Rows, result)
+	}
+
+	if strict && report.Failed > 0 {
+		return discardImport(report, "import aborted: other rows are invalid"), nil
+	}
+
+	if len(report.Tasks) > 0 {
+		usageID, err := consumeCreation(ctx, uc.quota, ownerID, application.CreationTask, len(report.Tasks))
+		if err != nil {
+			return nil, err
+		}
+		if err := uc.taskRepo.CreateMany(ctx, report.Tasks); err != nil {
+			releaseCreation(ctx, uc.quota, usageID)
+			return nil, fmt.Errorf("failed to import tasks: %w", err)
+		}
+
+		created := make([]event.DomainEvent, len(report.Tasks))
+		for i, task := range report.Tasks {
+			created[i] = event.TaskCreated{TaskEvent: event.NewTaskEvent(task, ownerID)}
+		}
+		uc.events.Publish(ctx, created...)
+	}
+	report.Created = len(report.Tasks)
+
+	return report, nil
+}
+
+// newImportedTask builds a task from an import row using the same rules as task creation
+func newImportedTask(id string, row ImportRow, ownerID string, now time.Time, loc *time.Location) (*application.Task, error) {
+	status := application.TaskStatus(strings.TrimSpace(row.Status))
+	if status == "" {
+		status = application.StatusPending
+	}
+
+	task, err := application.NewTask(id, strings.TrimSpace(row.Title), row.Description, status, ownerID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(row.DueDate) != "" {
+		dueDate, err := service.ParseDueDate(row.DueDate, now, loc)
+		if err != nil {
+			return nil, err
+		}
+		if err := task.SetDueDate(&dueDate); err != nil {
+			return nil, err
+		}
+	}
+
+	return task, nil
+}
+
+// discardImport marks every valid row of a report as not created
+func discardImport(report *ImportReport, reason string) *ImportReport {
+	for i := range report.Rows {
+		if report.Rows[i].Success {
+			report.Rows[i].Success = false
+			report.Rows[i].TaskID = ""
+			report.Rows[i].Error = reason
+		}
+	}
+	report.Failed = report.Total
+	report.Tasks = nil
+	return report
+}
+
+// ParseImportCSV reads import rows from a CSV file with a header row. Recognized columns are
+// title (required), description, status and due_date; Portuguese names (titulo, descricao,
+// prazo) are accepted too. Commas and semicolons are accepted as separators.
+func ParseImportCSV(r io.Reader) ([]ImportRow, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimPrefix(string(content), "\ufeff") // Excel adds a BOM
+
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if firstLine, _, _ := strings.Cut(text, "\n"); strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		reader.Comma = ';'
+	}
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, ErrImportEmpty
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[csvColumnName(name)] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("invalid CSV: missing title column")
+	}
+
+	field := func(record []string, column string) string {
+		if i, ok := columns[column]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	var rows []ImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		if len(rows) == MaxImportRows {
+			return nil, ErrImportTooLarge
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, ImportRow{
+			Line:        line,
+			Title:       field(record, "title"),
+			Description: field(record, "description"),
+			Status:      field(record, "status"),
+			DueDate:     field(record, "due_date"),
+		})
+	}
+
+	return rows, nil
+}
+
+// csvColumnName normalizes a CSV header to its column key
+func csvColumnName(name string) string {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "title", "titulo", "título":
+		return "title"
+	case "description", "descricao", "descrição":
+		return "description"
+	case "status":
+		return "status"
+	case "due_date", "due date", "prazo":
+		return "due_date"
+	default:
+		return ""
+	}
+}
+
+// ParseImportJSON reads import rows from a JSON array of tasks
+func ParseImportJSON(r io.Reader) ([]ImportRow, error) {
+	var rows []ImportRow
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		return nil, fmt.Errorf("invalid JSON: expected an array of tasks: %w", err)
+	}
+	if len(rows) > MaxImportRows {
+		return nil, ErrImportTooLarge
+	}
+
+	for i := range rows {
+		rows[i].Line = i + 1
+	}
+
+	return rows, nil
+}
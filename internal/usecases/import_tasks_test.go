@@ -0,0 +1,208 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// mockTaskRepositoryForImport is a mock TaskRepository recording batch creations
+type mockTaskRepositoryForImport struct {
+	mockTaskRepository
+	batches   int
+	createErr error
+}
+
+func (m *mockTaskRepositoryForImport) CreateMany(ctx context.Context, tasks []*application.Task) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	m.batches++
+	return m.mockTaskRepository.CreateMany(ctx, tasks)
+}
+
+func newMockTaskRepositoryForImport() *mockTaskRepositoryForImport {
+	return &mockTaskRepositoryForImport{mockTaskRepository: mockTaskRepository{tasks: make(map[string]*application.Task)}}
+}
+
+func TestImportTasksUseCase_Execute(t *testing.T) {
+	rows := []ImportRow{
+		{Line: 2, Title: "Pagar contas", Status: "pending", DueDate: "25/12/2030"},
+		{Line: 3, Title: "", Description: "sem título"},
+		{Line: 4, Title: "Revisar contrato", Status: "archived"},
+		{Line: 5, Title: "Ligar para cliente", DueDate: "semana que vem"},
+		{Line: 6, Title: "Estudar Go", Status: "in_progress"},
+	}
+
+	taskRepo := newMockTaskRepositoryForImport()
+	useCase := NewImportTasksUseCase(taskRepo, &recordingPublisher{}, &sequentialIDs{}, nil)
+
+	report, err := useCase.Execute(context.Background(), "user-1", rows, time.UTC, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.Total != 5 || report.Created != 2 || report.Failed != 3 {
+		t.Errorf("expected 5 total, 2 created, 3 failed, got %+v", report)
+	}
+	if taskRepo.batches != 1 {
+		t.Errorf("expected valid tasks to be created in a single batch, got %d", taskRepo.batches)
+	}
+	if len(taskRepo.tasks) != 2 {
+		t.Errorf("expected 2 persisted tasks, got %d", len(taskRepo.tasks))
+	}
+
+	wantErrors := map[int]string{
+		3: "task title cannot be empty",
+		4: "invalid task status",
+		5: "unrecognized date",
+	}
+	for _, row := range report.Rows {
+		want, shouldFail := wantErrors[row.Line]
+		if shouldFail {
+			if row.Success || !strings.Contains(row.Error, want) {
+				t.Errorf("line %d: expected error containing %q, got %+v", row.Line, want, row)
+			}
+			continue
+		}
+		if !row.Success || row.TaskID == "" {
+			t.Errorf("line %d: expected success, got %+v", row.Line, row)
+		}
+	}
+
+	for _, task := range taskRepo.tasks {
+		if task.OwnerID != "user-1" {
+			t.Errorf("expected owner user-1, got %s", task.OwnerID)
+		}
+		if task.Title == "Pagar contas" && (task.DueDate == nil || task.DueDate.Year() != 2030) {
+			t.Errorf("expected due date in 2030, got %v", task.DueDate)
+		}
+	}
+}
+
+func TestImportTasksUseCase_Execute_Strict(t *testing.T) {
+	rows := []ImportRow{
+		{Line: 1, Title: "Válida"},
+		{Line: 2, Title: ""},
+	}
+
+	taskRepo := newMockTaskRepositoryForImport()
+	useCase := NewImportTasksUseCase(taskRepo, &recordingPublisher{}, &sequentialIDs{}, nil)
+
+	report, err := useCase.Execute(context.Background(), "user-1", rows, time.UTC, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.Created != 0 || report.Failed != 2 {
+		t.Errorf("expected nothing created, got %+v", report)
+	}
+	if taskRepo.batches != 0 || len(taskRepo.tasks) != 0 {
+		t.Error("expected no tasks to be persisted in strict mode")
+	}
+}
+
+func TestImportTasksUseCase_Execute_Errors(t *testing.T) {
+	tooMany := make([]ImportRow, MaxImportRows+1)
+
+	tests := []struct {
+		name      string
+		rows      []ImportRow
+		createErr error
+		wantErr   error
+	}{
+		{name: "empty", rows: nil, wantErr: ErrImportEmpty},
+		{name: "too many rows", rows: tooMany, wantErr: ErrImportTooLarge},
+		{name: "repository failure", rows: []ImportRow{{Title: "Tarefa"}}, createErr: errors.New("database locked")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskRepo := newMockTaskRepositoryForImport()
+			taskRepo.createErr = tt.createErr
+			useCase := NewImportTasksUseCase(taskRepo, &recordingPublisher{}, &sequentialIDs{}, nil)
+
+			_, err := useCase.Execute(context.Background(), "user-1", tt.rows, time.UTC, false)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseImportCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []ImportRow
+		wantErr bool
+	}{
+		{
+			name:  "english header",
+			input: "title,description,status,due_date\nComprar pão,Padaria,pending,amanhã\n",
+			want:  []ImportRow{{Line: 2, Title: "Comprar pão", Description: "Padaria", Status: "pending", DueDate: "amanhã"}},
+		},
+		{
+			name:  "portuguese header with semicolons and BOM",
+			input: "\ufefftitulo;prazo\nPagar aluguel;05/01/2030\n\nLer livro;\n",
+			want: []ImportRow{
+				{Line: 2, Title: "Pagar aluguel", DueDate: "05/01/2030"},
+				{Line: 4, Title: "Ler livro"},
+			},
+		},
+		{
+			name:  "quoted multi-line description keeps line numbers",
+			input: "title,description\n\"A\",\"linha 1\nlinha 2\"\nB,x\n",
+			want: []ImportRow{
+				{Line: 2, Title: "A", Description: "linha 1\nlinha 2"},
+				{Line: 4, Title: "B", Description: "x"},
+			},
+		},
+		{name: "missing title column", input: "description\nx\n", wantErr: true},
+		{name: "empty file", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := ParseImportCSV(strings.NewReader(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(rows) != len(tt.want) {
+				t.Fatalf("expected %d rows, got %d: %+v", len(tt.want), len(rows), rows)
+			}
+			for i := range rows {
+				if rows[i] != tt.want[i] {
+					t.Errorf("row %d = %+v, want %+v", i, rows[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseImportJSON(t *testing.T) {
+	rows, err := ParseImportJSON(strings.NewReader(`[{"title":"A","due_date":"sexta"},{"title":"B","status":"completed"}]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Line != 1 || rows[0].DueDate != "sexta" || rows[1].Status != "completed" {
+		t.Errorf("unexpected rows %+v", rows)
+	}
+
+	if _, err := ParseImportJSON(strings.NewReader(`{"title":"not an array"}`)); err == nil {
+		t.Error("expected error for non-array JSON")
+	}
+}
@@ -2,13 +2,14 @@ package usecases
 
 import (
 	"context"
+	"time"
 
 	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
 )
 
 // LoginUseCaseInterface defines the interface for login operations
 type LoginUseCaseInterface interface {
-	Execute(ctx context.Context, email, password string) (string, error)
+	Execute(ctx context.Context, email, password string, rememberMe bool) (*LoginResult, error)
 }
 
 // RegisterUseCaseInterface defines the interface for registration operations
@@ -18,7 +19,12 @@ type RegisterUseCaseInterface interface {
 
 // CreateTaskUseCaseInterface defines the interface for creating tasks
 type CreateTaskUseCaseInterface interface {
-	Execute(ctx context.Context, title, description, ownerID, imagePath string) (*application.Task, error)
+	Execute(ctx context.Context, title, description, ownerID, imagePath string, dueDate *time.Time, projectID string, color application.TaskColor) (*application.Task, error)
+}
+
+// QuickAddTaskUseCaseInterface defines the interface for creating tasks from a line of text
+type QuickAddTaskUseCaseInterface interface {
+	Execute(ctx context.Context, ownerID, text, projectID string, loc *time.Location) (*application.Task, error)
 }
 
 // GetTaskUseCaseInterface defines the interface for getting a single task
@@ -28,12 +34,12 @@ type GetTaskUseCaseInterface interface {
 
 // UpdateTaskUseCaseInterface defines the interface for updating tasks
 type UpdateTaskUseCaseInterface interface {
-	Execute(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string) error
+	Execute(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string, dueDate *time.Time, projectID string, color application.TaskColor, progress int, completionNote string) error
 }
 
 // DeleteTaskUseCaseInterface defines the interface for deleting tasks
 type DeleteTaskUseCaseInterface interface {
-	Execute(ctx context.Context, taskID, userID string) error
+	Execute(ctx context.Context, taskID, userID string) (*DeletedTaskFiles, error)
 }
 
 // ListTasksUseCaseInterface defines the interface for listing user's tasks
@@ -41,13 +47,53 @@ type ListTasksUseCaseInterface interface {
 	Execute(ctx context.Context, userID string) ([]*application.Task, error)
 }
 
+// ListTaskViewsUseCaseInterface defines the interface for listing tasks with their sharing details
+type ListTaskViewsUseCaseInterface interface {
+	Execute(ctx context.Context, userID, projectID string, after *application.TaskCursor, limit int) (*application.TaskPage, error)
+}
+
+// GetTaskOverviewUseCaseInterface defines the interface for reading the tasks page of a user
+type GetTaskOverviewUseCaseInterface interface {
+	Execute(ctx context.Context, userID string, after *application.TaskCursor, limit int) (*application.TaskOverview, error)
+}
+
+// GetTaskCountersUseCaseInterface defines the interface for counting the tasks shown in the navbar
+type GetTaskCountersUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) (*application.TaskCounters, error)
+}
+
 // ListSharedTasksUseCaseInterface defines the interface for listing shared tasks
 type ListSharedTasksUseCaseInterface interface {
-	Execute(ctx context.Context, userID string) ([]*application.Task, error)
+	Execute(ctx context.Context, userID string, query application.TaskListQuery) (*application.TaskPage, error)
+}
+
+// GetOwnerNamesUseCaseInterface defines the interface for looking up the names of task owners
+type GetOwnerNamesUseCaseInterface interface {
+	Execute(ctx context.Context, tasks []*application.Task) (map[string]string, error)
+}
+
+// GetShareeIDsUseCaseInterface defines the interface for looking up the users tasks are shared with
+type GetShareeIDsUseCaseInterface interface {
+	Execute(ctx context.Context, userID string, tasks []*application.Task) (map[string][]string, error)
 }
 
 // CompleteTaskUseCaseInterface defines the interface for completing tasks
 type CompleteTaskUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID, note string) (*application.Task, error)
+}
+
+// TrashTaskUseCaseInterface defines the interface for deleting tasks with undo
+type TrashTaskUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID string) (*application.Task, *application.TaskUndo, error)
+}
+
+// CompleteTaskWithUndoUseCaseInterface defines the interface for completing tasks with undo
+type CompleteTaskWithUndoUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID, note string) (*application.Task, *application.TaskUndo, error)
+}
+
+// UndoTaskUseCaseInterface defines the interface for undoing the deletion or completion of tasks
+type UndoTaskUseCaseInterface interface {
 	Execute(ctx context.Context, taskID, userID string) (*application.Task, error)
 }
 
@@ -56,9 +102,49 @@ type ShareTaskUseCaseInterface interface {
 	Execute(ctx context.Context, taskID, ownerID, shareWithUserID string) error
 }
 
+// ShareTaskWithUsersUseCaseInterface defines the interface for sharing tasks with several users at once
+type ShareTaskWithUsersUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, ownerID string, recipients []string) ([]*application.ShareResult, error)
+}
+
+// ListShareInvitationsUseCaseInterface defines the interface for listing pending share invitations
+type ListShareInvitationsUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) ([]*application.ShareInvitation, error)
+}
+
+// AcceptShareInvitationUseCaseInterface defines the interface for accepting share invitations
+type AcceptShareInvitationUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID string) (*application.Task, error)
+}
+
+// DeclineShareInvitationUseCaseInterface defines the interface for declining share invitations
+type DeclineShareInvitationUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID string) error
+}
+
 // ExportTasksPDFUseCaseInterface defines the interface for exporting tasks to PDF
 type ExportTasksPDFUseCaseInterface interface {
-	Execute(ctx context.Context, ownerID string) ([]byte, error)
+	Execute(ctx context.Context, ownerID string, filter application.TaskExportFilter) ([]byte, error)
+}
+
+// ExportTaskPDFUseCaseInterface defines the interface for exporting a single task to PDF
+type ExportTaskPDFUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID string) ([]byte, error)
+}
+
+// RequestTaskExportUseCaseInterface defines the interface for requesting a background PDF export
+type RequestTaskExportUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) (*application.ExportJob, error)
+}
+
+// GetExportJobUseCaseInterface defines the interface for checking the progress of an export
+type GetExportJobUseCaseInterface interface {
+	Execute(ctx context.Context, jobID, userID string) (*application.ExportJob, error)
+}
+
+// DownloadExportUseCaseInterface defines the interface for downloading the file of an export
+type DownloadExportUseCaseInterface interface {
+	Execute(ctx context.Context, jobID, userID string) (*application.ExportJob, []byte, error)
 }
 
 // DeleteTaskImageUseCaseInterface defines the interface for deleting task images
@@ -70,3 +156,457 @@ type DeleteTaskImageUseCaseInterface interface {
 type ReplaceTaskImageUseCaseInterface interface {
 	Execute(ctx context.Context, taskID, userID, newImagePath string) (string, error)
 }
+
+// ListNotificationsUseCaseInterface defines the interface for listing notifications
+type ListNotificationsUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) ([]*application.Notification, error)
+}
+
+// ListBrokenLinksUseCaseInterface defines the interface for listing broken links of a user's tasks
+type ListBrokenLinksUseCaseInterface interface {
+	Execute(ctx context.Context, ownerID string) (map[string][]string, error)
+}
+
+// OverdueReportUseCaseInterface defines the interface for the overdue tasks report of a unit
+type OverdueReportUseCaseInterface interface {
+	Execute(ctx context.Context, requesterID, unit string) (*application.OverdueReport, error)
+}
+
+// ImportTasksUseCaseInterface defines the interface for importing tasks
+type ImportTasksUseCaseInterface interface {
+	Execute(ctx context.Context, ownerID string, rows []ImportRow, loc *time.Location, strict bool) (*ImportReport, error)
+}
+
+// ExportQuotaUseCaseInterface defines the interface for the per-user export quota
+type ExportQuotaUseCaseInterface interface {
+	Consume(ctx context.Context, userID, kind string) (string, error)
+	Release(ctx context.Context, usageID string) error
+}
+
+// GetUserThemeUseCaseInterface defines the interface for reading a user's theme
+type GetUserThemeUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) (application.Theme, error)
+}
+
+// UpdateUserThemeUseCaseInterface defines the interface for changing a user's theme
+type UpdateUserThemeUseCaseInterface interface {
+	Execute(ctx context.Context, userID, theme string) error
+}
+
+// GetUserAvatarUseCaseInterface defines the interface for reading a user's avatar
+type GetUserAvatarUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) (string, error)
+}
+
+// UpdateUserAvatarUseCaseInterface defines the interface for changing a user's avatar
+type UpdateUserAvatarUseCaseInterface interface {
+	Execute(ctx context.Context, userID, avatarPath string) (string, error)
+}
+
+// UpdateUserLocaleUseCaseInterface defines the interface for changing a user's language
+type UpdateUserLocaleUseCaseInterface interface {
+	Execute(ctx context.Context, userID, locale string) error
+}
+
+// GetUserPreferencesUseCaseInterface defines the interface for reading every preference of a user
+type GetUserPreferencesUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) (*UserPreferences, error)
+}
+
+// UpdateUserPreferencesUseCaseInterface defines the interface for changing the preferences of a user
+type UpdateUserPreferencesUseCaseInterface interface {
+	Execute(ctx context.Context, userID string, changes UserPreferencesChanges) (*UserPreferences, error)
+}
+
+// SearchUsersUseCaseInterface defines the interface for searching users to share tasks with
+type SearchUsersUseCaseInterface interface {
+	Execute(ctx context.Context, requesterID, query string) ([]*application.User, error)
+}
+
+// AddAttachmentUseCaseInterface defines the interface for attaching a file to a task
+type AddAttachmentUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID, filename, mimeType string, size int64, path string) (*application.Attachment, error)
+}
+
+// ListAttachmentsUseCaseInterface defines the interface for listing the attachments of a task
+type ListAttachmentsUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID string) ([]*application.Attachment, error)
+}
+
+// GetAttachmentUseCaseInterface defines the interface for reading an attachment
+type GetAttachmentUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, attachmentID, userID string) (*application.Attachment, error)
+}
+
+// DeleteAttachmentUseCaseInterface defines the interface for removing an attachment
+type DeleteAttachmentUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, attachmentID, userID string) (*application.Attachment, error)
+}
+
+// ListOwnerAttachmentsUseCaseInterface defines the interface for listing the attachments of a user's tasks
+type ListOwnerAttachmentsUseCaseInterface interface {
+	Execute(ctx context.Context, ownerID string) (map[string][]*application.Attachment, error)
+}
+
+// AddDependencyUseCaseInterface defines the interface for marking a task as blocked by another
+type AddDependencyUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, blockerID, userID string) ([]*application.Task, error)
+}
+
+// ListDependenciesUseCaseInterface defines the interface for listing the tasks blocking a task
+type ListDependenciesUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID string) ([]*application.Task, error)
+}
+
+// RemoveDependencyUseCaseInterface defines the interface for unblocking a task from a blocker
+type RemoveDependencyUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, blockerID, userID string) error
+}
+
+// ListOpenBlockersUseCaseInterface defines the interface for finding the open blockers of listed tasks
+type ListOpenBlockersUseCaseInterface interface {
+	Execute(ctx context.Context, tasks []*application.Task) (map[string][]*application.Task, error)
+}
+
+// RecordLoginEventUseCaseInterface defines the interface for recording login attempts
+type RecordLoginEventUseCaseInterface interface {
+	Execute(ctx context.Context, email, ip, userAgent string, success bool) error
+}
+
+// ListLoginEventsUseCaseInterface defines the interface for listing the login attempts of a user
+type ListLoginEventsUseCaseInterface interface {
+	Execute(ctx context.Context, userID string, limit int) ([]*application.LoginEvent, error)
+}
+
+// GetLastLoginUseCaseInterface defines the interface for reading the last successful login of a user
+type GetLastLoginUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) (*application.LoginEvent, error)
+}
+
+// CreateReminderUseCaseInterface defines the interface for setting a reminder on a task
+type CreateReminderUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID string, remindAt time.Time) (*application.Reminder, error)
+}
+
+// ListRemindersUseCaseInterface defines the interface for listing the reminders of a user on a task
+type ListRemindersUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID string) ([]*application.Reminder, error)
+}
+
+// DeleteReminderUseCaseInterface defines the interface for removing a reminder
+type DeleteReminderUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, reminderID, userID string) error
+}
+
+// CreateShareLinkUseCaseInterface defines the interface for creating a public link to a task
+type CreateShareLinkUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID string, expiryDays int) (*application.ShareLink, string, error)
+}
+
+// ListShareLinksUseCaseInterface defines the interface for listing the public links of a task
+type ListShareLinksUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID string) ([]*application.ShareLink, error)
+}
+
+// RevokeShareLinkUseCaseInterface defines the interface for revoking a public link
+type RevokeShareLinkUseCaseInterface interface {
+	Execute(ctx context.Context, linkID, userID string) error
+}
+
+// ViewSharedTaskUseCaseInterface defines the interface for opening a task through its public link
+type ViewSharedTaskUseCaseInterface interface {
+	Execute(ctx context.Context, token string) (*application.Task, *application.ShareLink, error)
+}
+
+// PasskeyLoginUseCaseInterface defines the interface for issuing a session after a passkey login
+type PasskeyLoginUseCaseInterface interface {
+	Execute(ctx context.Context, userID string, rememberMe bool) (*LoginResult, error)
+}
+
+// ListCredentialsUseCaseInterface defines the interface for listing the passkeys of a user
+type ListCredentialsUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) ([]*application.Credential, error)
+}
+
+// DeleteCredentialUseCaseInterface defines the interface for removing a passkey
+type DeleteCredentialUseCaseInterface interface {
+	Execute(ctx context.Context, credentialID, userID string) error
+}
+
+// BeginTwoFactorEnrollmentUseCaseInterface defines the interface for generating the TOTP secret of a user
+type BeginTwoFactorEnrollmentUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) (*TwoFactorEnrollment, error)
+}
+
+// EnableTwoFactorUseCaseInterface defines the interface for confirming a two-factor enrollment
+type EnableTwoFactorUseCaseInterface interface {
+	Execute(ctx context.Context, userID, code string) ([]string, error)
+}
+
+// DisableTwoFactorUseCaseInterface defines the interface for turning two-factor authentication off
+type DisableTwoFactorUseCaseInterface interface {
+	Execute(ctx context.Context, userID, code string) error
+}
+
+// GetTwoFactorStatusUseCaseInterface defines the interface for reading the two-factor status of a user
+type GetTwoFactorStatusUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) (*TwoFactorStatus, error)
+}
+
+// VerifyTwoFactorUseCaseInterface defines the interface for answering a login challenge
+type VerifyTwoFactorUseCaseInterface interface {
+	Execute(ctx context.Context, token, code string) (*LoginResult, error)
+}
+
+// CreateProjectUseCaseInterface defines the interface for creating projects
+type CreateProjectUseCaseInterface interface {
+	Execute(ctx context.Context, ownerID, name, color string) (*application.Project, error)
+}
+
+// ListProjectsUseCaseInterface defines the interface for listing the projects of a user
+type ListProjectsUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) (*ProjectList, error)
+}
+
+// GetProjectUseCaseInterface defines the interface for getting a single project
+type GetProjectUseCaseInterface interface {
+	Execute(ctx context.Context, projectID, userID string) (*application.Project, error)
+}
+
+// UpdateProjectUseCaseInterface defines the interface for renaming and recoloring projects
+type UpdateProjectUseCaseInterface interface {
+	Execute(ctx context.Context, projectID, userID, name, color string) (*application.Project, error)
+}
+
+// DeleteProjectUseCaseInterface defines the interface for deleting projects
+type DeleteProjectUseCaseInterface interface {
+	Execute(ctx context.Context, projectID, userID string) error
+}
+
+// ListProjectTasksUseCaseInterface defines the interface for listing the tasks of a project
+type ListProjectTasksUseCaseInterface interface {
+	Execute(ctx context.Context, projectID, userID string) ([]*application.Task, error)
+}
+
+// ShareProjectUseCaseInterface defines the interface for sharing projects
+type ShareProjectUseCaseInterface interface {
+	Execute(ctx context.Context, projectID, ownerID, shareWithUserID string) error
+}
+
+// UnshareProjectUseCaseInterface defines the interface for removing the access of a user to a project
+type UnshareProjectUseCaseInterface interface {
+	Execute(ctx context.Context, projectID, ownerID, userID string) error
+}
+
+// GetOverduePreferenceUseCaseInterface defines the interface for reading whether a user's late tasks are flagged
+type GetOverduePreferenceUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) (bool, error)
+}
+
+// UpdateOverduePreferenceUseCaseInterface defines the interface for turning the overdue flagging on or off
+type UpdateOverduePreferenceUseCaseInterface interface {
+	Execute(ctx context.Context, userID string, enabled bool) error
+}
+
+// StorageQuotaUseCaseInterface defines the interface for charging uploads to the storage quotas
+type StorageQuotaUseCaseInterface interface {
+	Reserve(ctx context.Context, userID string, kind application.StoredFileKind, name string, size int64) error
+	Release(ctx context.Context, kind application.StoredFileKind, name string) error
+	Owns(ctx context.Context, userID string, kind application.StoredFileKind, name string) (bool, error)
+}
+
+// GetStorageUsageUseCaseInterface defines the interface for reading the storage usage of a user
+type GetStorageUsageUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) (*StorageStatus, error)
+}
+
+// CanViewTaskImageUseCaseInterface defines the interface for checking whether a user can see an uploaded task image
+type CanViewTaskImageUseCaseInterface interface {
+	Execute(ctx context.Context, imagePath, userID string) (bool, error)
+}
+
+// GetAdminDashboardUseCaseInterface defines the interface for reading the figures of the admin dashboard
+type GetAdminDashboardUseCaseInterface interface {
+	Execute(ctx context.Context, adminID string) (*application.AdminDashboard, error)
+}
+
+// ListUsersForAdminUseCaseInterface defines the interface for listing the users on the admin dashboard
+type ListUsersForAdminUseCaseInterface interface {
+	Execute(ctx context.Context, adminID string, offset int) (*AdminUserPage, error)
+}
+
+// SetUserDisabledUseCaseInterface defines the interface for disabling or enabling a user account
+type SetUserDisabledUseCaseInterface interface {
+	Execute(ctx context.Context, adminID, userID string, disabled bool) (*application.User, error)
+}
+
+// ResetUserPasswordUseCaseInterface defines the interface for giving a user a temporary password
+type ResetUserPasswordUseCaseInterface interface {
+	Execute(ctx context.Context, adminID, userID string) (*application.User, string, error)
+}
+
+// DeleteUserUseCaseInterface defines the interface for deleting a user account
+type DeleteUserUseCaseInterface interface {
+	Execute(ctx context.Context, adminID, userID string) error
+}
+
+// CreateBackupUseCaseInterface defines the interface for backing up the data of the app
+type CreateBackupUseCaseInterface interface {
+	Execute(ctx context.Context, adminID string) (*application.Backup, error)
+}
+
+// ListFailedJobsUseCaseInterface defines the interface for listing the background jobs that failed
+type ListFailedJobsUseCaseInterface interface {
+	Execute(ctx context.Context, adminID string) ([]*application.Job, error)
+}
+
+// RetryJobUseCaseInterface defines the interface for putting a failed background job back in the queue
+type RetryJobUseCaseInterface interface {
+	Execute(ctx context.Context, adminID, jobID string) (*application.Job, error)
+}
+
+// CreateOrgUseCaseInterface defines the interface for creating an org
+type CreateOrgUseCaseInterface interface {
+	Execute(ctx context.Context, userID, name string) (*application.Org, error)
+}
+
+// ListOrgsUseCaseInterface defines the interface for listing the orgs of a user
+type ListOrgsUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) ([]*application.OrgMembership, error)
+}
+
+// UpdateOrgUseCaseInterface defines the interface for updating an org
+type UpdateOrgUseCaseInterface interface {
+	Execute(ctx context.Context, orgID, userID, name string, shareTasks bool) (*application.Org, error)
+}
+
+// ListOrgMembersUseCaseInterface defines the interface for listing the members of an org
+type ListOrgMembersUseCaseInterface interface {
+	Execute(ctx context.Context, orgID, userID string) ([]*application.OrgMember, error)
+}
+
+// SaveOrgMemberUseCaseInterface defines the interface for adding a member to an org or changing their role
+type SaveOrgMemberUseCaseInterface interface {
+	Execute(ctx context.Context, orgID, actorID, email string, role application.OrgRole) (*application.OrgMember, error)
+}
+
+// RemoveOrgMemberUseCaseInterface defines the interface for removing a member from an org
+type RemoveOrgMemberUseCaseInterface interface {
+	Execute(ctx context.Context, orgID, actorID, userID string) error
+}
+
+// StartTimerUseCaseInterface defines the interface for starting a user's timer on a task
+type StartTimerUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID string) (*application.TaskTime, error)
+}
+
+// StopTimerUseCaseInterface defines the interface for stopping a user's timer on a task
+type StopTimerUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID string) (*application.TaskTime, error)
+}
+
+// GetTaskTimeUseCaseInterface defines the interface for checking the time tracked on a task
+type GetTaskTimeUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID string) (*application.TaskTime, error)
+}
+
+// ListTaskTimesUseCaseInterface defines the interface for finding the time tracked on listed tasks
+type ListTaskTimesUseCaseInterface interface {
+	Execute(ctx context.Context, userID string, tasks []*application.Task) (map[string]*application.TaskTime, error)
+}
+
+// ListTaskSharesUseCaseInterface defines the interface for listing the users a task is shared with
+type ListTaskSharesUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID string) ([]*application.SharedUser, error)
+}
+
+// AddCommentUseCaseInterface defines the interface for leaving a comment on a task
+type AddCommentUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID, body string) (*application.Comment, error)
+}
+
+// ListCommentsUseCaseInterface defines the interface for listing the comments of a task
+type ListCommentsUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID string) ([]*application.Comment, error)
+}
+
+// ListTaskRevisionsUseCaseInterface defines the interface for listing the previous versions of a task
+type ListTaskRevisionsUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID string) (*application.TaskHistory, error)
+}
+
+// UnshareTaskUseCaseInterface defines the interface for removing the access of a user to a task
+type UnshareTaskUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, ownerID, userID string) error
+}
+
+// CreateFeedTokenUseCaseInterface defines the interface for creating the feed token of a user
+type CreateFeedTokenUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) (string, error)
+}
+
+// RevokeFeedTokenUseCaseInterface defines the interface for revoking the feed token of a user
+type RevokeFeedTokenUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) error
+}
+
+// GetTaskFeedUseCaseInterface defines the interface for reading the task feed of a user
+type GetTaskFeedUseCaseInterface interface {
+	Execute(ctx context.Context, token string) (*application.User, []*application.Task, error)
+}
+
+// ChangePasswordUseCaseInterface defines the interface for users changing their own password
+type ChangePasswordUseCaseInterface interface {
+	Execute(ctx context.Context, userID, currentPassword, newPassword string) error
+}
+
+// RequestAccountDeletionUseCaseInterface defines the interface for users asking for their account to be deleted
+type RequestAccountDeletionUseCaseInterface interface {
+	Execute(ctx context.Context, userID, password string) (*application.AccountDeletion, error)
+}
+
+// GetAccountDeletionUseCaseInterface defines the interface for checking the scheduled deletion of an account
+type GetAccountDeletionUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) (*application.AccountDeletion, error)
+}
+
+// CancelAccountDeletionUseCaseInterface defines the interface for cancelling the deletion of an account
+type CancelAccountDeletionUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) error
+}
+
+// ExportAccountDataUseCaseInterface defines the interface for users downloading their data
+type ExportAccountDataUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) (*AccountData, error)
+}
+
+// CreationQuotaUseCaseInterface defines the interface for the per-user daily creation quotas
+type CreationQuotaUseCaseInterface interface {
+	Consume(ctx context.Context, userID string, kind application.CreationKind, count int) (string, error)
+	Release(ctx context.Context, usageID string) error
+}
+
+// SnoozeTaskUseCaseInterface defines the interface for snoozing and waking tasks
+type SnoozeTaskUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, userID string, until *time.Time) (*application.Task, error)
+}
+
+// TransferTaskUseCaseInterface defines the interface for transferring a task to another owner
+type TransferTaskUseCaseInterface interface {
+	Execute(ctx context.Context, taskID, ownerID, newOwnerID string, force bool) (*application.Task, error)
+}
+
+// GetDigestSubscriptionUseCaseInterface defines the interface for reading the weekly digest subscription of a user
+type GetDigestSubscriptionUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) (*application.DigestSubscription, error)
+}
+
+// SubscribeDigestUseCaseInterface defines the interface for opting in to the weekly digest
+type SubscribeDigestUseCaseInterface interface {
+	Execute(ctx context.Context, userID string, weekday time.Weekday, hour int) (*application.DigestSubscription, error)
+}
+
+// UnsubscribeDigestUseCaseInterface defines the interface for opting out of the weekly digest
+type UnsubscribeDigestUseCaseInterface interface {
+	Execute(ctx context.Context, userID string) error
+}
@@ -0,0 +1,240 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+const (
+	// FailedJobsLimit is how many failed jobs the admins can inspect at a time
+	FailedJobsLimit = 100
+
+	// maxJobBackoff bounds the wait before a retry, however many attempts failed before
+	maxJobBackoff = 24 * time.Hour
+)
+
+// JobHandler runs a job of one type with its JSON payload. An error retries the job later,
+// until it runs out of attempts.
+type JobHandler func(ctx context.Context, payload []byte) error
+
+// JobQueue queues background jobs and keeps the handler of each job type. Handlers are
+// registered when the app is wired, before the workers start.
+type JobQueue struct {
+	jobRepo     repository.JobRepository
+	handlers    map[string]JobHandler
+	maxAttempts int
+	now         func() time.Time
+}
+
+// NewJobQueue creates a new JobQueue whose jobs are run up to maxAttempts times
+func NewJobQueue(jobRepo repository.JobRepository, maxAttempts int) *JobQueue {
+	return &JobQueue{
+		jobRepo:     jobRepo,
+		handlers:    make(map[string]JobHandler),
+		maxAttempts: maxAttempts,
+		now:         time.Now,
+	}
+}
+
+// Register sets the handler of a job type. Registering a type twice is a wiring mistake and
+// panics.
+func (q *JobQueue) Register(jobType string, handler JobHandler) {
+	if _, ok := q.handlers[jobType]; ok {
+		panic("usecases: job type registered twice: " + jobType)
+	}
+	q.handlers[jobType] = handler
+}
+
+// Enqueue queues a job of a registered type, with payload encoded as JSON. Within a
+// transaction, the job only runs if the transaction commits.
+func (q *JobQueue) Enqueue(ctx context.Context, jobType string, payload any) (_ *application.Job, err error) {
+	ctx, end := tracer.Start(ctx, "EnqueueJob")
+	defer func() { end(err) }()
+
+	if _, ok := q.handlers[jobType]; !ok {
+		return nil, fmt.Errorf("%w: %s", application.ErrUnknownJobType, jobType)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := application.NewJob(uuid.New().String(), jobType, data, q.maxAttempts, q.now())
+	if err != nil {
+		return nil, err
+	}
+	if err := q.jobRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// JobRunSummary reports the outcome of a worker run
+type JobRunSummary struct {
+	Done    int
+	Retried int
+	Failed  int
+	Expired int
+}
+
+// RunJobsUseCase runs the queued jobs; each worker of the pool executes it periodically
+type RunJobsUseCase struct {
+	queue      *JobQueue
+	backoff    time.Duration
+	staleAfter time.Duration
+	retention  time.Duration
+	now        func() time.Time
+}
+
+// NewRunJobsUseCase creates a new RunJobsUseCase. A failed job is retried after backoff,
+// doubled on each later attempt. A run is cut short after staleAfter, when another worker
+// would claim the job again, and done jobs are deleted after retention.
+func NewRunJobsUseCase(queue *JobQueue, backoff, staleAfter, retention time.Duration) *RunJobsUseCase {
+	return &RunJobsUseCase{
+		queue:      queue,
+		backoff:    backoff,
+		staleAfter: staleAfter,
+		retention:  retention,
+		now:        time.Now,
+	}
+}
+
+// Execute runs the due jobs one at a time until there are none left, then deletes the expired
+// ones. Once ctx is cancelled no other job is claimed, but the running one is given time to
+// finish, so a shutdown doesn't count as a failed attempt. A failed job doesn't stop the run;
+// its cause is returned once the run is over.
+func (uc *RunJobsUseCase) Execute(ctx context.Context) (_ JobRunSummary, err error) {
+	ctx, end := tracer.Start(ctx, "RunJobs")
+	defer func() { end(err) }()
+
+	var summary JobRunSummary
+	var firstErr error
+	jobRepo := uc.queue.jobRepo
+
+	for ctx.Err() == nil {
+		now := uc.now()
+		job, err := jobRepo.ClaimNext(ctx, now, now.Add(-uc.staleAfter))
+		if errors.Is(err, application.ErrJobNotFound) {
+			break
+		}
+		if err != nil {
+			return summary, err
+		}
+
+		// The job runs, and its outcome is recorded, even when ctx is cancelled meanwhile
+		detached := context.WithoutCancel(ctx)
+		runErr := uc.run(detached, job)
+		switch {
+		case runErr == nil:
+			if err := jobRepo.Finish(detached, job.ID, uc.now()); err != nil {
+				return summary, err
+			}
+			summary.Done++
+			continue
+		case job.CanRetry():
+			if err := jobRepo.Retry(detached, job.ID, runErr.Error(), uc.now().Add(uc.retryDelay(job.Attempts))); err != nil {
+				return summary, err
+			}
+			summary.Retried++
+		default:
+			if err := jobRepo.Fail(detached, job.ID, runErr.Error(), uc.now()); err != nil {
+				return summary, err
+			}
+			summary.Failed++
+		}
+		if firstErr == nil {
+			firstErr = fmt.Errorf("job %s (%s), attempt %d of %d: %w", job.ID, job.Type, job.Attempts, job.MaxAttempts, runErr)
+		}
+	}
+
+	expired, err := jobRepo.DeleteDoneBefore(ctx, uc.now().Add(-uc.retention))
+	summary.Expired = expired
+	if err != nil && ctx.Err() == nil {
+		return summary, err
+	}
+
+	return summary, firstErr
+}
+
+// run runs a job with the handler of its type, turning a panic into an error
+func (uc *RunJobsUseCase) run(ctx context.Context, job *application.Job) (err error) {
+	handler, ok := uc.queue.handlers[job.Type]
+	if !ok {
+		return fmt.Errorf("%w: %s", application.ErrUnknownJobType, job.Type)
+	}
+	if job.Attempts > job.MaxAttempts {
+		// Claimed again after runs interrupted by restarts
+		return errors.New("job was interrupted after its last attempt")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.staleAfter)
+	defer cancel()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job panicked: %v", recovered)
+		}
+	}()
+	return handler(ctx, job.Payload)
+}
+
+// retryDelay returns the wait before running a job again after its attempt failed
+func (uc *RunJobsUseCase) retryDelay(attempt int) time.Duration {
+	delay := uc.backoff
+	for i := 1; i < attempt && delay < maxJobBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxJobBackoff)
+}
+
+// ListFailedJobsUseCase handles listing the jobs that failed for good, for the admins
+type ListFailedJobsUseCase struct {
+	userRepo repository.UserRepository
+	jobRepo  repository.JobRepository
+}
+
+// NewListFailedJobsUseCase creates a new ListFailedJobsUseCase
+func NewListFailedJobsUseCase(userRepo repository.UserRepository, jobRepo repository.JobRepository) *ListFailedJobsUseCase {
+	return &ListFailedJobsUseCase{userRepo: userRepo, jobRepo: jobRepo}
+}
+
+// Execute returns the latest FailedJobsLimit failed jobs, most recently failed first
+func (uc *ListFailedJobsUseCase) Execute(ctx context.Context, adminID string) (_ []*application.Job, err error) {
+	ctx, end := tracer.Start(ctx, "ListFailedJobs")
+	defer func() { end(err) }()
+
+	if _, err := requireAdmin(ctx, uc.userRepo, adminID); err != nil {
+		return nil, err
+	}
+	return uc.jobRepo.FindFailed(ctx, FailedJobsLimit)
+}
+
+// RetryJobUseCase handles an admin putting a failed job back in the queue
+type RetryJobUseCase struct {
+	userRepo repository.UserRepository
+	jobRepo  repository.JobRepository
+	now      func() time.Time
+}
+
+// NewRetryJobUseCase creates a new RetryJobUseCase
+func NewRetryJobUseCase(userRepo repository.UserRepository, jobRepo repository.JobRepository) *RetryJobUseCase {
+	return &RetryJobUseCase{userRepo: userRepo, jobRepo: jobRepo, now: time.Now}
+}
+
+// Execute queues a failed job to run right away, with all its attempts again. It returns
+// application.ErrJobNotFound when there is no failed job with the ID.
+func (uc *RetryJobUseCase) Execute(ctx context.Context, adminID, jobID string) (_ *application.Job, err error) {
+	ctx, end := tracer.Start(ctx, "RetryJob")
+	defer func() { end(err) }()
+
+	if _, err := requireAdmin(ctx, uc.userRepo, adminID); err != nil {
+		return nil, err
+	}
+	return uc.jobRepo.Requeue(ctx, jobID, uc.now())
+}
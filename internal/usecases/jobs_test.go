@@ -0,0 +1,278 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// mockJobRepository keeps jobs in memory, claimed in the order they were queued
+type mockJobRepository struct {
+	jobs []*application.Job
+}
+
+func (m *mockJobRepository) Create(ctx context.Context, job *application.Job) error {
+	m.jobs = append(m.jobs, job)
+	return nil
+}
+
+func (m *mockJobRepository) ClaimNext(ctx context.Context, now, staleBefore time.Time) (*application.Job, error) {
+	for _, job := range m.jobs {
+		due := job.Status == application.JobPending && !job.RunAt.After(now)
+		stale := job.Status == application.JobRunning && job.StartedAt.Before(staleBefore)
+		if due || stale {
+			job.Status = application.JobRunning
+			job.Attempts++
+			job.StartedAt = &now
+			claimed := *job
+			return &claimed, nil
+		}
+	}
+	return nil, application.ErrJobNotFound
+}
+
+func (m *mockJobRepository) find(id string) *application.Job {
+	for _, job := range m.jobs {
+		if job.ID == id {
+			return job
+		}
+	}
+	return nil
+}
+
+func (m *mockJobRepository) Finish(ctx context.Context, id string, finishedAt time.Time) error {
+	job := m.find(id)
+	job.Status, job.Error, job.FinishedAt = application.JobDone, "", &finishedAt
+	return nil
+}
+
+func (m *mockJobRepository) Retry(ctx context.Context, id, message string, runAt time.Time) error {
+	job := m.find(id)
+	job.Status, job.Error, job.RunAt = application.JobPending, message, runAt
+	return nil
+}
+
+func (m *mockJobRepository) Fail(ctx context.Context, id, message string, finishedAt time.Time) error {
+	job := m.find(id)
+	job.Status, job.Error, job.FinishedAt = application.JobFailed, message, &finishedAt
+	return nil
+}
+
+func (m *mockJobRepository) FindFailed(ctx context.Context, limit int) ([]*application.Job, error) {
+	var failed []*application.Job
+	for _, job := range m.jobs {
+		if job.Status == application.JobFailed {
+			failed = append(failed, job)
+		}
+	}
+	return failed, nil
+}
+
+func (m *mockJobRepository) Requeue(ctx context.Context, id string, runAt time.Time) (*application.Job, error) {
+	job := m.find(id)
+	if job == nil || job.Status != application.JobFailed {
+		return nil, application.ErrJobNotFound
+	}
+	job.Status, job.Attempts, job.RunAt, job.StartedAt, job.FinishedAt = application.JobPending, 0, runAt, nil, nil
+	return job, nil
+}
+
+func (m *mockJobRepository) DeleteDoneBefore(ctx context.Context, before time.Time) (int, error) {
+	var kept []*application.Job
+	deleted := 0
+	for _, job := range m.jobs {
+		if job.Status == application.JobDone && job.FinishedAt.Before(before) {
+			deleted++
+			continue
+		}
+		kept = append(kept, job)
+	}
+	m.jobs = kept
+	return deleted, nil
+}
+
+func TestJobQueue_Enqueue(t *testing.T) {
+	jobRepo := &mockJobRepository{}
+	queue := NewJobQueue(jobRepo, 3)
+	queue.Register("send_email", func(ctx context.Context, payload []byte) error { return nil })
+
+	job, err := queue.Enqueue(context.Background(), "send_email", map[string]string{"to": "ana@example.com"})
+	if err != nil {
+		t.Fatalf("Enqueue() error: %v", err)
+	}
+	if job.Status != application.JobPending || job.MaxAttempts != 3 || string(job.Payload) != `{"to":"ana@example.com"}` {
+		t.Errorf("Enqueue() = %+v, want a pending job with the payload as JSON", job)
+	}
+
+	if _, err := queue.Enqueue(context.Background(), "send_sms", nil); !errors.Is(err, application.ErrUnknownJobType) {
+		t.Errorf("Enqueue() of an unregistered type error = %v, want ErrUnknownJobType", err)
+	}
+	if len(jobRepo.jobs) != 1 {
+		t.Errorf("Expected 1 queued job, got %d", len(jobRepo.jobs))
+	}
+}
+
+func TestRunJobsUseCase_Execute(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		handler     JobHandler
+		attempts    int // Runs before this one
+		wantStatus  application.JobStatus
+		wantSummary JobRunSummary
+		wantRunAt   time.Time
+	}{
+		{
+			name:        "done",
+			handler:     func(ctx context.Context, payload []byte) error { return nil },
+			wantStatus:  application.JobDone,
+			wantSummary: JobRunSummary{Done: 1},
+		},
+		{
+			name:        "retried after the backoff",
+			handler:     func(ctx context.Context, payload []byte) error { return errors.New("smtp unavailable") },
+			wantStatus:  application.JobPending,
+			wantSummary: JobRunSummary{Retried: 1},
+			wantRunAt:   now.Add(time.Minute),
+		},
+		{
+			name:        "backoff doubled on later attempts",
+			handler:     func(ctx context.Context, payload []byte) error { return errors.New("smtp unavailable") },
+			attempts:    1,
+			wantStatus:  application.JobPending,
+			wantSummary: JobRunSummary{Retried: 1},
+			wantRunAt:   now.Add(2 * time.Minute),
+		},
+		{
+			name:        "failed after the last attempt",
+			handler:     func(ctx context.Context, payload []byte) error { return errors.New("smtp unavailable") },
+			attempts:    2,
+			wantStatus:  application.JobFailed,
+			wantSummary: JobRunSummary{Failed: 1},
+		},
+		{
+			name:        "panic",
+			handler:     func(ctx context.Context, payload []byte) error { panic("nil map") },
+			attempts:    2,
+			wantStatus:  application.JobFailed,
+			wantSummary: JobRunSummary{Failed: 1},
+		},
+		{
+			name:        "interrupted after the last attempt",
+			handler:     func(ctx context.Context, payload []byte) error { return nil },
+			attempts:    3,
+			wantStatus:  application.JobFailed,
+			wantSummary: JobRunSummary{Failed: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobRepo := &mockJobRepository{}
+			queue := NewJobQueue(jobRepo, 3)
+			queue.Register("send_email", tt.handler)
+			queue.now = func() time.Time { return now }
+			if _, err := queue.Enqueue(context.Background(), "send_email", nil); err != nil {
+				t.Fatalf("Enqueue() error: %v", err)
+			}
+			jobRepo.jobs[0].Attempts = tt.attempts
+
+			uc := NewRunJobsUseCase(queue, time.Minute, 10*time.Minute, time.Hour)
+			uc.now = func() time.Time { return now }
+
+			summary, err := uc.Execute(context.Background())
+			if (err != nil) != (tt.wantStatus != application.JobDone) {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if summary != tt.wantSummary {
+				t.Errorf("Execute() = %+v, want %+v", summary, tt.wantSummary)
+			}
+			job := jobRepo.jobs[0]
+			if job.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", job.Status, tt.wantStatus)
+			}
+			if tt.wantStatus != application.JobDone && job.Error == "" {
+				t.Error("Expected the error of the run to be kept")
+			}
+			if !tt.wantRunAt.IsZero() && !job.RunAt.Equal(tt.wantRunAt) {
+				t.Errorf("RunAt = %v, want %v", job.RunAt, tt.wantRunAt)
+			}
+		})
+	}
+}
+
+func TestRunJobsUseCase_Shutdown(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	ctx, cancel := context.WithCancel(context.Background())
+	jobRepo := &mockJobRepository{}
+	queue := NewJobQueue(jobRepo, 3)
+	queue.now = func() time.Time { return now }
+
+	// The first job is let finish although the workers are stopped while it runs; the second
+	// one stays queued
+	runs := 0
+	queue.Register("send_email", func(ctx context.Context, payload []byte) error {
+		runs++
+		cancel()
+		return ctx.Err()
+	})
+	for range 2 {
+		if _, err := queue.Enqueue(context.Background(), "send_email", nil); err != nil {
+			t.Fatalf("Enqueue() error: %v", err)
+		}
+	}
+
+	uc := NewRunJobsUseCase(queue, time.Minute, 10*time.Minute, time.Hour)
+	uc.now = func() time.Time { return now }
+	summary, err := uc.Execute(ctx)
+	if err != nil || summary.Done != 1 || runs != 1 {
+		t.Fatalf("Execute() = %+v, %v after %d runs; want 1 done", summary, err, runs)
+	}
+	if jobRepo.jobs[1].Status != application.JobPending {
+		t.Errorf("Status of the second job = %s, want pending", jobRepo.jobs[1].Status)
+	}
+}
+
+func TestRetryJobUseCase_Execute(t *testing.T) {
+	jobRepo := &mockJobRepository{jobs: []*application.Job{
+		{ID: "failed", Status: application.JobFailed, Attempts: 3, MaxAttempts: 3},
+		{ID: "done", Status: application.JobDone, Attempts: 1, MaxAttempts: 3},
+	}}
+
+	tests := []struct {
+		name    string
+		adminID string
+		jobID   string
+		wantErr error
+	}{
+		{name: "member", adminID: "member-1", jobID: "failed", wantErr: ErrAdminRequired},
+		{name: "done job", adminID: "admin-1", jobID: "done", wantErr: application.ErrJobNotFound},
+		{name: "failed job", adminID: "admin-1", jobID: "failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewRetryJobUseCase(newMockUserRepositoryForAdmin(), jobRepo)
+
+			job, err := uc.Execute(context.Background(), tt.adminID, tt.jobID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (job.Status != application.JobPending || job.Attempts != 0) {
+				t.Errorf("Execute() = %+v, want a pending job with its attempts reset", job)
+			}
+		})
+	}
+
+	list := NewListFailedJobsUseCase(newMockUserRepositoryForAdmin(), jobRepo)
+	if _, err := list.Execute(context.Background(), "member-1"); !errors.Is(err, ErrAdminRequired) {
+		t.Errorf("ListFailedJobs by a member error = %v, want ErrAdminRequired", err)
+	}
+	if failed, err := list.Execute(context.Background(), "admin-1"); err != nil || len(failed) != 0 {
+		t.Errorf("ListFailedJobs after the retry = %+v, %v; want none", failed, err)
+	}
+}
@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// ListBrokenLinksUseCase handles listing broken links of a user's tasks
+type ListBrokenLinksUseCase struct {
+	linkRepo repository.LinkRepository
+}
+
+// NewListBrokenLinksUseCase creates a new ListBrokenLinksUseCase
+func NewListBrokenLinksUseCase(linkRepo repository.LinkRepository) *ListBrokenLinksUseCase {
+	return &ListBrokenLinksUseCase{
+		linkRepo: linkRepo,
+	}
+}
+
+// Execute returns the broken URLs of a user's tasks grouped by task ID
+func (uc *ListBrokenLinksUseCase) Execute(ctx context.Context, ownerID string) (map[string][]string, error) {
+	links, err := uc.linkRepo.FindBrokenByOwnerID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	byTask := make(map[string][]string)
+	for _, link := range links {
+		byTask[link.TaskID] = append(byTask[link.TaskID], link.URL)
+	}
+
+	return byTask, nil
+}
@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// defaultNotificationLimit caps how many notifications are returned per request
+const defaultNotificationLimit = 50
+
+// ListNotificationsUseCase handles listing notifications of a user
+type ListNotificationsUseCase struct {
+	notificationRepo repository.NotificationRepository
+}
+
+// NewListNotificationsUseCase creates a new ListNotificationsUseCase
+func NewListNotificationsUseCase(notificationRepo repository.NotificationRepository) *ListNotificationsUseCase {
+	return &ListNotificationsUseCase{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// Execute lists the most recent notifications of a user
+func (uc *ListNotificationsUseCase) Execute(ctx context.Context, userID string) ([]*application.Notification, error) {
+	return uc.notificationRepo.FindByUserID(ctx, userID, defaultNotificationLimit)
+}
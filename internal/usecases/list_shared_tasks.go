@@ -9,17 +9,40 @@ import (
 
 // ListSharedTasksUseCase handles listing tasks shared with a user
 type ListSharedTasksUseCase struct {
-	taskRepo repository.TaskRepository
+	viewRepo repository.TaskViewRepository
 }
 
 // NewListSharedTasksUseCase creates a new ListSharedTasksUseCase
-func NewListSharedTasksUseCase(taskRepo repository.TaskRepository) *ListSharedTasksUseCase {
+func NewListSharedTasksUseCase(viewRepo repository.TaskViewRepository) *ListSharedTasksUseCase {
 	return &ListSharedTasksUseCase{
-		taskRepo: taskRepo,
+		viewRepo: viewRepo,
 	}
 }
 
-// Execute lists all tasks shared with a user
-func (uc *ListSharedTasksUseCase) Execute(ctx context.Context, userID string) ([]*application.Task, error) {
-	return uc.taskRepo.FindSharedWithUser(ctx, userID)
+// Execute lists a page of the tasks shared with a user that match query, with their owner's
+// name. In cursor pagination the page has the cursor of the next one.
+func (uc *ListSharedTasksUseCase) Execute(ctx context.Context, userID string, query application.TaskListQuery) (_ *application.TaskPage, err error) {
+	ctx, end := tracer.Start(ctx, "ListSharedTasks")
+	defer func() { end(err) }()
+
+	repoQuery := query
+	if query.Cursor {
+		// One more task tells whether there is a next page
+		repoQuery.PerPage++
+	}
+
+	views, total, err := uc.viewRepo.FindSharedWithUser(ctx, userID, repoQuery)
+	if err != nil {
+		return nil, err
+	}
+	if views == nil {
+		views = []*application.TaskView{}
+	}
+
+	page := &application.TaskPage{Views: views, Total: total, Page: query.Page, PerPage: query.PerPage}
+	if query.Cursor && len(views) > query.PerPage {
+		page.Views = views[:query.PerPage]
+		page.NextCursor = page.Views[query.PerPage-1].Cursor()
+	}
+	return page, nil
 }
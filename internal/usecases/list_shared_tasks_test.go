@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestListSharedTasksUseCase(t *testing.T) {
+	viewRepo := &mockTaskViewRepository{views: []*application.TaskView{
+		{Task: &application.Task{ID: "task-1", OwnerID: "user-2"}, OwnerName: "Bia"},
+		{Task: &application.Task{ID: "task-2", OwnerID: "user-2"}, OwnerName: "Bia"},
+		{Task: &application.Task{ID: "task-3", OwnerID: "user-3"}, OwnerName: "Caio"},
+	}}
+	useCase := NewListSharedTasksUseCase(viewRepo)
+
+	tests := []struct {
+		name      string
+		userID    string
+		page      int
+		wantIDs   []string
+		wantTotal int
+		wantNext  bool
+	}{
+		{"first page", "user-1", 1, []string{"task-1", "task-2"}, 3, true},
+		{"last page", "user-1", 2, []string{"task-3"}, 3, false},
+		{"past the last page", "user-9", 3, []string{}, 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := application.NewTaskListQuery("", "", "", "", tt.page, 2)
+			if err != nil {
+				t.Fatalf("NewTaskListQuery failed: %v", err)
+			}
+
+			page, err := useCase.Execute(context.Background(), tt.userID, query)
+			if err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+			if viewRepo.lastQuery != query {
+				t.Errorf("Expected the query to reach the repository, got %+v", viewRepo.lastQuery)
+			}
+			if page.Views == nil {
+				t.Fatal("Expected an empty page, got nil views")
+			}
+			if len(page.Views) != len(tt.wantIDs) {
+				t.Fatalf("Expected %d tasks, got %d", len(tt.wantIDs), len(page.Views))
+			}
+			for i, id := range tt.wantIDs {
+				if page.Views[i].ID != id {
+					t.Errorf("Expected task %d to be %s, got %s", i, id, page.Views[i].ID)
+				}
+			}
+			if page.Total != tt.wantTotal || page.HasNext() != tt.wantNext {
+				t.Errorf("Expected total %d and next %v, got %d and %v", tt.wantTotal, tt.wantNext, page.Total, page.HasNext())
+			}
+		})
+	}
+}
+
+func TestListSharedTasksUseCase_Error(t *testing.T) {
+	repoErr := errors.New("database error")
+	useCase := NewListSharedTasksUseCase(&mockTaskViewRepository{sharedErr: repoErr})
+
+	query, _ := application.NewTaskListQuery("", "", "", "", 0, 0)
+	if _, err := useCase.Execute(context.Background(), "user-1", query); !errors.Is(err, repoErr) {
+		t.Errorf("Expected %v, got %v", repoErr, err)
+	}
+}
+
+func TestListSharedTasksUseCase_Cursor(t *testing.T) {
+	viewRepo := &mockTaskViewRepository{views: []*application.TaskView{
+		{Task: &application.Task{ID: "task-1", OwnerID: "user-2"}},
+		{Task: &application.Task{ID: "task-2", OwnerID: "user-2"}},
+		{Task: &application.Task{ID: "task-3", OwnerID: "user-3"}},
+	}}
+	useCase := NewListSharedTasksUseCase(viewRepo)
+
+	tests := []struct {
+		name     string
+		limit    int
+		wantIDs  []string
+		wantNext string
+	}{
+		{name: "more tasks after the page", limit: 2, wantIDs: []string{"task-1", "task-2"}, wantNext: "task-2"},
+		{name: "last page", limit: 3, wantIDs: []string{"task-1", "task-2", "task-3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _ := application.NewTaskListQuery("", "", "", "", 0, 0)
+			query, err := query.WithCursor(nil, tt.limit)
+			if err != nil {
+				t.Fatalf("WithCursor failed: %v", err)
+			}
+
+			page, err := useCase.Execute(context.Background(), "user-1", query)
+			if err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+			if viewRepo.lastQuery.PerPage != tt.limit+1 {
+				t.Errorf("Expected the repository asked for %d tasks, got %d", tt.limit+1, viewRepo.lastQuery.PerPage)
+			}
+			var ids []string
+			for _, view := range page.Views {
+				ids = append(ids, view.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("Expected %v, got %v", tt.wantIDs, ids)
+			}
+			var next string
+			if page.NextCursor != nil {
+				next = page.NextCursor.ID
+			}
+			if next != tt.wantNext {
+				t.Errorf("Expected next cursor at %q, got %q", tt.wantNext, next)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// ErrSharePermissionDenied is returned when a user who can see a task tries to manage who it
+// is shared with
+var ErrSharePermissionDenied = errors.New("only the task owner can manage shares")
+
+// checkShareOwner checks that a user owns a task, returning ErrTaskUnavailable when they can't
+// even see it
+func checkShareOwner(ctx context.Context, taskRepo repository.TaskRepository, taskService TaskServiceInterface, taskID, userID string) error {
+	err := checkTaskAccess(ctx, taskRepo, taskService, taskID, userID, true)
+	if errors.Is(err, ErrTaskOwnerRequired) {
+		return ErrSharePermissionDenied
+	}
+	return err
+}
+
+// ListTaskSharesUseCase handles listing the users a task is shared with, for its owner
+type ListTaskSharesUseCase struct {
+	taskRepo    repository.TaskRepository
+	shareRepo   repository.ShareRepository
+	taskService TaskServiceInterface
+}
+
+// NewListTaskSharesUseCase creates a new ListTaskSharesUseCase
+func NewListTaskSharesUseCase(taskRepo repository.TaskRepository, shareRepo repository.ShareRepository, taskService TaskServiceInterface) *ListTaskSharesUseCase {
+	return &ListTaskSharesUseCase{
+		taskRepo:    taskRepo,
+		shareRepo:   shareRepo,
+		taskService: taskService,
+	}
+}
+
+// Execute lists the users that accepted to share a task of the owner, in the order they accepted
+func (uc *ListTaskSharesUseCase) Execute(ctx context.Context, taskID, userID string) ([]*application.SharedUser, error) {
+	if err := checkShareOwner(ctx, uc.taskRepo, uc.taskService, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	return uc.shareRepo.FindSharedUsers(ctx, taskID)
+}
@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+func TestListTaskSharesUseCase(t *testing.T) {
+	tests := []struct {
+		name    string
+		taskID  string
+		userID  string
+		wantErr error
+	}{
+		{name: "owner", taskID: "task-1", userID: "user-1"},
+		{name: "shared user", taskID: "task-1", userID: "user-2", wantErr: ErrSharePermissionDenied},
+		{name: "stranger", taskID: "task-1", userID: "user-3", wantErr: ErrTaskUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, _ := application.NewTask("task-1", "Relatório mensal", "", application.StatusPending, "user-1", "")
+			taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+			shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-2"}}}
+			taskService := service.NewTaskService(taskRepo, shareRepo)
+
+			users, err := NewListTaskSharesUseCase(taskRepo, shareRepo, taskService).Execute(context.Background(), tt.taskID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (len(users) != 1 || users[0].UserID != "user-2" || users[0].Email != "user-2@example.com") {
+				t.Errorf("Execute() = %+v, want user-2 with their email", users)
+			}
+		})
+	}
+}
+
+func TestUnshareTaskUseCase_OnlyOwner(t *testing.T) {
+	tests := []struct {
+		name    string
+		taskID  string
+		ownerID string
+		wantErr error
+	}{
+		{name: "shared user", taskID: "task-1", ownerID: "user-2", wantErr: ErrSharePermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, _ := application.NewTask("task-1", "Relatório mensal", "", application.StatusPending, "user-1", "")
+			taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+			shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-2"}}}
+			taskService := service.NewTaskService(taskRepo, shareRepo)
+			publisher := &recordingPublisher{}
+
+			err := NewUnshareTaskUseCase(taskRepo, shareRepo, taskService, publisher).Execute(context.Background(), tt.taskID, tt.ownerID, "user-2")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(publisher.events) != 0 {
+				t.Errorf("published %v, want nothing", publisher.events)
+			}
+		})
+	}
+}
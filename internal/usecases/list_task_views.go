@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// ListTaskViewsUseCase handles listing tasks with the ownership and sharing details shown on
+// their cards
+type ListTaskViewsUseCase struct {
+	projectRepo  repository.ProjectRepository
+	viewRepo     repository.TaskViewRepository
+	settingsRepo repository.UserSettingsRepository
+	now          func() time.Time
+}
+
+// NewListTaskViewsUseCase creates a new ListTaskViewsUseCase
+func NewListTaskViewsUseCase(projectRepo repository.ProjectRepository, viewRepo repository.TaskViewRepository, settingsRepo repository.UserSettingsRepository) *ListTaskViewsUseCase {
+	return &ListTaskViewsUseCase{
+		projectRepo:  projectRepo,
+		viewRepo:     viewRepo,
+		settingsRepo: settingsRepo,
+		now:          time.Now,
+	}
+}
+
+// Execute lists a page of up to limit views, in the default sort of the user, starting after
+// the cursor (nil for the first page): of the tasks owned by a user, leaving out the snoozed
+// ones, or, when projectID is set, of the tasks of a project owned by or shared with the user.
+// limit 0 takes application.DefaultTasksPerPage. The page has the cursor of the next one, if any.
+func (uc *ListTaskViewsUseCase) Execute(ctx context.Context, userID, projectID string, after *application.TaskCursor, limit int) (_ *application.TaskPage, err error) {
+	ctx, end := tracer.Start(ctx, "ListTaskViews")
+	defer func() { end(err) }()
+
+	if limit == 0 {
+		limit = application.DefaultTasksPerPage
+	}
+	if limit < 1 || limit > application.MaxTasksPerPage {
+		return nil, application.ErrInvalidTaskLimit
+	}
+
+	settings, err := userSettings(ctx, uc.settingsRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// One more view tells whether there is a next page
+	var views []*application.TaskView
+	if projectID == "" {
+		views, err = uc.viewRepo.FindByOwnerID(ctx, userID, uc.now(), settings.DefaultSort, after, limit+1)
+	} else {
+		if _, err := findUserProject(ctx, uc.projectRepo, projectID, userID, false); err != nil {
+			return nil, err
+		}
+		views, err = uc.viewRepo.FindByProjectID(ctx, projectID, settings.DefaultSort, after, limit+1)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if views == nil {
+		views = []*application.TaskView{}
+	}
+
+	page := &application.TaskPage{Views: views, Page: 1, PerPage: limit}
+	if len(views) > limit {
+		page.Views = views[:limit]
+		page.NextCursor = page.Views[limit-1].Cursor()
+	}
+	return page, nil
+}
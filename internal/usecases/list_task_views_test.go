@@ -0,0 +1,205 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+type mockTaskViewRepository struct {
+	views     []*application.TaskView
+	sharedErr error
+	countErr  error
+	lastQuery application.TaskListQuery
+}
+
+// FindByOwnerID pages through the views of an owner that aren't snoozed, in the order of the
+// fixture, which lists them newest first
+func (m *mockTaskViewRepository) FindByOwnerID(ctx context.Context, ownerID string, now time.Time, sort application.TaskSort, after *application.TaskCursor, limit int) ([]*application.TaskView, error) {
+	var views []*application.TaskView
+	for _, view := range m.views {
+		if view.OwnerID == ownerID && !view.IsSnoozed(now) {
+			views = append(views, view)
+		}
+	}
+	return pageAfter(views, sort, after, limit), nil
+}
+
+func (m *mockTaskViewRepository) FindByProjectID(ctx context.Context, projectID string, sort application.TaskSort, after *application.TaskCursor, limit int) ([]*application.TaskView, error) {
+	var views []*application.TaskView
+	for _, view := range m.views {
+		if view.ProjectID == projectID {
+			views = append(views, view)
+		}
+	}
+	return pageAfter(views, sort, after, limit), nil
+}
+
+// pageAfter returns up to limit views following the one after points to, nil for the first.
+// The views are newest first, reversed for the oldest first sort.
+func pageAfter(views []*application.TaskView, sort application.TaskSort, after *application.TaskCursor, limit int) []*application.TaskView {
+	if sort == application.SortOldest {
+		views = slices.Clone(views)
+		slices.Reverse(views)
+	}
+	if after != nil {
+		for i, view := range views {
+			if view.ID == after.ID {
+				views = views[i+1:]
+				break
+			}
+		}
+	}
+	return views[:min(limit, len(views))]
+}
+
+// FindSharedWithUser pages through the views of other owners; the filters are left to the
+// database tests
+func (m *mockTaskViewRepository) FindSharedWithUser(ctx context.Context, userID string, query application.TaskListQuery) ([]*application.TaskView, int, error) {
+	m.lastQuery = query
+	if m.sharedErr != nil {
+		return nil, 0, m.sharedErr
+	}
+
+	var views []*application.TaskView
+	for _, view := range m.views {
+		if view.OwnerID != userID {
+			views = append(views, view)
+		}
+	}
+	total := len(views)
+	start := min(query.Offset(), total)
+	end := min(start+query.PerPage, total)
+	return views[start:end], total, nil
+}
+
+// CountForUser counts the views by owner, leaving the query itself to the database tests
+func (m *mockTaskViewRepository) CountForUser(ctx context.Context, userID string, now time.Time) (*application.TaskCounters, error) {
+	if m.countErr != nil {
+		return nil, m.countErr
+	}
+
+	var counters application.TaskCounters
+	for _, view := range m.views {
+		if view.Status == application.StatusCompleted {
+			continue
+		}
+		switch {
+		case view.OwnerID != userID:
+			counters.Shared++
+		case !view.IsSnoozed(now):
+			counters.Pending++
+			if view.DueDate != nil && view.DueDate.Before(now) {
+				counters.Overdue++
+			}
+		}
+	}
+	return &counters, nil
+}
+
+func TestListTaskViewsUseCase(t *testing.T) {
+	viewRepo := &mockTaskViewRepository{views: []*application.TaskView{
+		{Task: &application.Task{ID: "task-1", OwnerID: "user-1", ProjectID: "proj-1"}, SharedWith: []application.Sharee{{UserID: "user-3", Name: "Caio"}}},
+		{Task: &application.Task{ID: "task-2", OwnerID: "user-2"}},
+	}}
+
+	tests := []struct {
+		name      string
+		userID    string
+		projectID string
+		wantIDs   []string
+		wantErr   error
+	}{
+		{"owned tasks", "user-2", "", []string{"task-2"}, nil},
+		{"project of the owner", "user-1", "proj-1", []string{"task-1"}, nil},
+		{"project shared with the user", "user-2", "proj-1", []string{"task-1"}, nil},
+		{"project of someone else", "user-3", "proj-1", nil, application.ErrProjectNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectRepo := newMockProjectRepository(&application.Project{ID: "proj-1", Name: "Trabalho", Color: "#3b82f6", OwnerID: "user-1"})
+			projectRepo.shares["proj-1"] = []string{"user-2"}
+			page, err := NewListTaskViewsUseCase(projectRepo, viewRepo, newMockUserSettingsRepository()).Execute(context.Background(), tt.userID, tt.projectID, nil, 0)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(page.Views) != len(tt.wantIDs) {
+				t.Fatalf("Execute() = %d views, want %v", len(page.Views), tt.wantIDs)
+			}
+			for i, view := range page.Views {
+				if view.ID != tt.wantIDs[i] {
+					t.Errorf("views[%d] = %s, want %s", i, view.ID, tt.wantIDs[i])
+				}
+			}
+			if page.NextCursor != nil {
+				t.Errorf("NextCursor = %+v, want nil on the only page", page.NextCursor)
+			}
+		})
+	}
+}
+
+func TestListTaskViewsUseCase_Pages(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tomorrow := now.AddDate(0, 0, 1)
+	viewRepo := &mockTaskViewRepository{}
+	for i, id := range []string{"task-5", "task-4", "task-3", "task-2", "task-1"} {
+		task := &application.Task{ID: id, OwnerID: "user-1", CreatedAt: now.Add(-time.Duration(i) * time.Hour)}
+		if id == "task-4" {
+			task.SnoozedUntil = &tomorrow
+		}
+		viewRepo.views = append(viewRepo.views, &application.TaskView{Task: task})
+	}
+	settingsRepo := newMockUserSettingsRepository()
+	projectRepo := newMockProjectRepository(&application.Project{ID: "proj-1", Name: "Trabalho", Color: "#3b82f6", OwnerID: "user-1"})
+	projectRepo.shares["proj-1"] = []string{"user-2"}
+	useCase := NewListTaskViewsUseCase(projectRepo, viewRepo, settingsRepo)
+	useCase.now = func() time.Time { return now }
+
+	pages := func(userID string) []string {
+		t.Helper()
+		var ids []string
+		var after *application.TaskCursor
+		for pages := 0; ; pages++ {
+			if pages == 3 {
+				t.Fatalf("Execute() kept returning cursors after %v", ids)
+			}
+			page, err := useCase.Execute(context.Background(), userID, "", after, 2)
+			if err != nil {
+				t.Fatalf("Execute() error: %v", err)
+			}
+			for _, view := range page.Views {
+				ids = append(ids, view.ID)
+			}
+			if page.NextCursor == nil {
+				return ids
+			}
+			after = page.NextCursor
+		}
+	}
+
+	want := []string{"task-5", "task-3", "task-2", "task-1"}
+	if ids := pages("user-1"); !slices.Equal(ids, want) {
+		t.Errorf("pages = %v, want %v without the snoozed task", ids, want)
+	}
+
+	// The list follows the default sort of the user
+	settingsRepo.settings["user-1"] = &application.UserSettings{UserID: "user-1", DefaultSort: application.SortOldest}
+	want = []string{"task-1", "task-2", "task-3", "task-5"}
+	if ids := pages("user-1"); !slices.Equal(ids, want) {
+		t.Errorf("pages sorted oldest first = %v, want %v", ids, want)
+	}
+
+	for _, limit := range []int{-1, application.MaxTasksPerPage + 1} {
+		if _, err := useCase.Execute(context.Background(), "user-1", "", nil, limit); !errors.Is(err, application.ErrInvalidTaskLimit) {
+			t.Errorf("Execute(limit %d) error = %v, want %v", limit, err, application.ErrInvalidTaskLimit)
+		}
+	}
+}
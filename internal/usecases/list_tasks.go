@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"context"
+	"time"
 
 	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
 	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
@@ -10,16 +11,25 @@ import (
 // ListTasksUseCase handles listing tasks owned by a user
 type ListTasksUseCase struct {
 	taskRepo repository.TaskRepository
+	now      func() time.Time
 }
 
 // NewListTasksUseCase creates a new ListTasksUseCase
 func NewListTasksUseCase(taskRepo repository.TaskRepository) *ListTasksUseCase {
 	return &ListTasksUseCase{
 		taskRepo: taskRepo,
+		now:      time.Now,
 	}
 }
 
-// Execute lists all tasks owned by a user
-func (uc *ListTasksUseCase) Execute(ctx context.Context, ownerID string) ([]*application.Task, error) {
-	return uc.taskRepo.FindByOwnerID(ctx, ownerID)
+// Execute lists the tasks owned by a user, leaving out the snoozed ones
+func (uc *ListTasksUseCase) Execute(ctx context.Context, ownerID string) (_ []*application.Task, err error) {
+	ctx, end := tracer.Start(ctx, "ListTasks")
+	defer func() { end(err) }()
+
+	tasks, err := uc.taskRepo.FindByOwnerID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return application.WithoutSnoozed(tasks, uc.now()), nil
 }
@@ -5,49 +5,212 @@ import (
 	"errors"
 	"time"
 
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
 	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
 	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
 )
 
+const (
+	// DefaultSessionDuration is the lifetime of a regular login session
+	DefaultSessionDuration = 24 * time.Hour
+
+	// DefaultRememberMeDuration is the lifetime of a "remember me" login session
+	DefaultRememberMeDuration = 30 * 24 * time.Hour
+
+	// TwoFactorChallengeDuration is how long a login waits for the second factor
+	TwoFactorChallengeDuration = 5 * time.Minute
+)
+
+// SessionDurations configures the lifetime of the tokens issued on login.
+// Zero values fall back to DefaultSessionDuration and DefaultRememberMeDuration.
+type SessionDurations struct {
+	Default    time.Duration
+	RememberMe time.Duration
+}
+
+// LoginResult is the token issued on login, how long it is valid and the language preferred
+// by the user (empty when none was chosen). When the user has two-factor authentication
+// enabled, no session is issued yet: TwoFactorToken identifies the login challenge to answer
+// with VerifyTwoFactorUseCase and ExpiresAt is when it expires.
+type LoginResult struct {
+	Token          string
+	Duration       time.Duration
+	ExpiresAt      time.Time
+	Locale         application.Locale
+	Email          string
+	TwoFactorToken string
+}
+
+// TwoFactorRequired reports whether the login still waits for the second factor
+func (r *LoginResult) TwoFactorRequired() bool {
+	return r.TwoFactorToken != ""
+}
+
 // LoginUseCase handles user login
 type LoginUseCase struct {
-	userRepo    repository.UserRepository
-	authService *service.AuthService
+	userRepo        repository.UserRepository
+	twoFactorRepo   repository.TwoFactorRepository
+	authService     *service.AuthService
+	durations       SessionDurations
+	minFailureDelay time.Duration
 }
 
-// NewLoginUseCase creates a new LoginUseCase
-func NewLoginUseCase(userRepo repository.UserRepository, jwtSecret string) *LoginUseCase {
+// NewLoginUseCase creates a new LoginUseCase. twoFactorRepo is optional: without it the
+// second factor is never enforced. Passwords hashed other than with hasher (nil means bcrypt
+// at the default cost) are rehashed on login. Failed logins take at least minFailureDelay
+// (0 disables it), which hides the remaining timing differences between failures.
+func NewLoginUseCase(userRepo repository.UserRepository, twoFactorRepo repository.TwoFactorRepository, jwtKeys *service.JWTKeySet, hasher service.PasswordHasher, durations SessionDurations, minFailureDelay time.Duration) *LoginUseCase {
 	return &LoginUseCase{
-		userRepo:    userRepo,
-		authService: service.NewAuthService(jwtSecret),
+		userRepo:        userRepo,
+		twoFactorRepo:   twoFactorRepo,
+		authService:     service.NewAuthServiceWithKeys(jwtKeys, hasher),
+		durations:       durations.withDefaults(),
+		minFailureDelay: minFailureDelay,
 	}
 }
 
-// Execute performs user login and returns a JWT token
-func (uc *LoginUseCase) Execute(ctx context.Context, email, password string) (string, error) {
-	if email == "" {
-		return "", errors.New("email cannot be empty")
+// withDefaults fills the zero durations with DefaultSessionDuration and DefaultRememberMeDuration
+func (d SessionDurations) withDefaults() SessionDurations {
+	if d.Default <= 0 {
+		d.Default = DefaultSessionDuration
 	}
-	if password == "" {
-		return "", errors.New("password cannot be empty")
+	if d.RememberMe <= 0 {
+		d.RememberMe = DefaultRememberMeDuration
 	}
+	return d
+}
 
-	// Find user by email
+// Execute performs user login and returns a JWT token. rememberMe issues a longer-lived token.
+// Users with two-factor authentication get a login challenge instead of the token. Missing
+// fields, unknown emails and wrong passwords all fail with application.ErrInvalidCredentials.
+func (uc *LoginUseCase) Execute(ctx context.Context, email, password string, rememberMe bool) (*LoginResult, error) {
+	start := time.Now()
+	result, err := uc.login(ctx, email, password, rememberMe)
+	if err != nil {
+		uc.delayFailure(ctx, start)
+	}
+	return result, err
+}
+
+// login checks the credentials and starts the session or the second factor challenge
+func (uc *LoginUseCase) login(ctx context.Context, email, password string, rememberMe bool) (*LoginResult, error) {
+	if email == "" || password == "" {
+		return nil, application.ErrInvalidCredentials
+	}
+
+	// An unknown email still pays for a password check, so it fails as slowly as a wrong password
 	user, err := uc.userRepo.FindByEmail(ctx, email)
+	if errors.Is(err, application.ErrUserNotFound) {
+		uc.authService.VerifyDecoyPassword(password)
+		return nil, application.ErrInvalidCredentials
+	}
 	if err != nil {
-		return "", errors.New("invalid credentials")
+		return nil, err
 	}
 
-	// Verify password
 	if err := uc.authService.VerifyPassword(user.PasswordHash, password); err != nil {
-		return "", errors.New("invalid credentials")
+		return nil, application.ErrInvalidCredentials
+	}
+
+	if uc.authService.NeedsRehash(user.PasswordHash) {
+		uc.rehashPassword(ctx, user, password)
+	}
+
+	// Only told once the password is right, so it doesn't reveal which accounts exist
+	if user.Disabled {
+		return nil, application.ErrUserDisabled
+	}
+
+	if uc.twoFactorRepo != nil {
+		_, enabled, err := findEnabledTwoFactor(ctx, uc.twoFactorRepo, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if enabled {
+			return uc.challenge(ctx, user, rememberMe)
+		}
+	}
+
+	return issueSession(uc.authService, uc.durations, user, rememberMe)
+}
+
+// delayFailure waits until a failed login started at start has taken minFailureDelay, or
+// until ctx is done
+func (uc *LoginUseCase) delayFailure(ctx context.Context, start time.Time) {
+	wait := uc.minFailureDelay - time.Since(start)
+	if wait <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+// rehashPassword upgrades the stored hash of a user to the configured algorithm and
+// parameters. Failures are ignored: the old hash still works and the next login tries again.
+func (uc *LoginUseCase) rehashPassword(ctx context.Context, user *application.User, password string) {
+	hash, err := uc.authService.HashPassword(password)
+	if err != nil {
+		return
+	}
+
+	previous := user.PasswordHash
+	user.PasswordHash = hash
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		user.PasswordHash = previous
+	}
+}
+
+// challenge starts the second step of the login of a user with two-factor authentication
+func (uc *LoginUseCase) challenge(ctx context.Context, user *application.User, rememberMe bool) (*LoginResult, error) {
+	token, hash, err := service.NewChallengeToken()
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := application.NewTwoFactorChallenge(hash, user.ID, rememberMe, TwoFactorChallengeDuration)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.twoFactorRepo.CreateChallenge(ctx, challenge); err != nil {
+		return nil, err
+	}
+
+	return &LoginResult{
+		ExpiresAt:      challenge.ExpiresAt,
+		Locale:         user.Locale,
+		Email:          user.Email,
+		TwoFactorToken: token,
+	}, nil
+}
+
+// issueSession generates the JWT token of an authenticated user. rememberMe issues a
+// longer-lived token. Disabled users get application.ErrUserDisabled instead.
+func issueSession(authService *service.AuthService, durations SessionDurations, user *application.User, rememberMe bool) (*LoginResult, error) {
+	if user.Disabled {
+		return nil, application.ErrUserDisabled
+	}
+
+	duration := durations.Default
+	if rememberMe {
+		duration = durations.RememberMe
 	}
 
 	// Generate JWT token
-	token, err := uc.authService.GenerateToken(user.ID, user.Email, 24*time.Hour)
+	token, err := authService.GenerateVersionedToken(user.ID, user.Email, user.TokenVersion, duration)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return token, nil
+	return &LoginResult{
+		Token:     token,
+		Duration:  duration,
+		ExpiresAt: time.Now().Add(duration),
+		Locale:    user.Locale,
+		Email:     user.Email,
+	}, nil
 }
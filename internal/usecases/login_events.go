@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+const (
+	// defaultLoginEventLimit is how many login attempts are listed when no limit is given
+	defaultLoginEventLimit = 20
+
+	// maxLoginEventLimit caps how many login attempts are listed per request
+	maxLoginEventLimit = 100
+)
+
+// RecordLoginEventUseCase handles recording login attempts for incident investigation
+type RecordLoginEventUseCase struct {
+	userRepo       repository.UserRepository
+	loginEventRepo repository.LoginEventRepository
+}
+
+// NewRecordLoginEventUseCase creates a new RecordLoginEventUseCase
+func NewRecordLoginEventUseCase(userRepo repository.UserRepository, loginEventRepo repository.LoginEventRepository) *RecordLoginEventUseCase {
+	return &RecordLoginEventUseCase{
+		userRepo:       userRepo,
+		loginEventRepo: loginEventRepo,
+	}
+}
+
+// Execute records a login attempt. Failed attempts against an existing account are linked
+// to it, so its owner can see them; attempts with unknown emails are kept unlinked.
+func (uc *RecordLoginEventUseCase) Execute(ctx context.Context, email, ip, userAgent string, success bool) error {
+	var userID string
+	if email != "" {
+		if user, err := uc.userRepo.FindByEmail(ctx, email); err == nil {
+			userID = user.ID
+		}
+	}
+
+	event, err := application.NewLoginEvent(uuid.New().String(), userID, email, ip, userAgent, success)
+	if err != nil {
+		return err
+	}
+
+	return uc.loginEventRepo.Create(ctx, event)
+}
+
+// ListLoginEventsUseCase handles listing the login attempts of a user
+type ListLoginEventsUseCase struct {
+	loginEventRepo repository.LoginEventRepository
+}
+
+// NewListLoginEventsUseCase creates a new ListLoginEventsUseCase
+func NewListLoginEventsUseCase(loginEventRepo repository.LoginEventRepository) *ListLoginEventsUseCase {
+	return &ListLoginEventsUseCase{
+		loginEventRepo: loginEventRepo,
+	}
+}
+
+// Execute lists the most recent login attempts of a user. Non-positive limits use the
+// default and larger ones are capped.
+func (uc *ListLoginEventsUseCase) Execute(ctx context.Context, userID string, limit int) ([]*application.LoginEvent, error) {
+	if limit <= 0 {
+		limit = defaultLoginEventLimit
+	}
+	if limit > maxLoginEventLimit {
+		limit = maxLoginEventLimit
+	}
+
+	return uc.loginEventRepo.FindByUserID(ctx, userID, limit)
+}
+
+// GetLastLoginUseCase handles reading the last successful login of a user
+type GetLastLoginUseCase struct {
+	loginEventRepo repository.LoginEventRepository
+}
+
+// NewGetLastLoginUseCase creates a new GetLastLoginUseCase
+func NewGetLastLoginUseCase(loginEventRepo repository.LoginEventRepository) *GetLastLoginUseCase {
+	return &GetLastLoginUseCase{
+		loginEventRepo: loginEventRepo,
+	}
+}
+
+// Execute returns the last successful login of a user, or nil if none was recorded
+func (uc *GetLastLoginUseCase) Execute(ctx context.Context, userID string) (*application.LoginEvent, error) {
+	event, err := uc.loginEventRepo.FindLastSuccessful(ctx, userID)
+	if errors.Is(err, application.ErrLoginEventNotFound) {
+		return nil, nil
+	}
+	return event, err
+}
@@ -0,0 +1,125 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// mockLoginEventRepository keeps login events in memory
+type mockLoginEventRepository struct {
+	events    []*application.LoginEvent
+	lastLimit int
+}
+
+func (m *mockLoginEventRepository) Create(ctx context.Context, event *application.LoginEvent) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *mockLoginEventRepository) FindByUserID(ctx context.Context, userID string, limit int) ([]*application.LoginEvent, error) {
+	m.lastLimit = limit
+	var events []*application.LoginEvent
+	for i := len(m.events) - 1; i >= 0 && len(events) < limit; i-- {
+		if m.events[i].UserID == userID {
+			events = append(events, m.events[i])
+		}
+	}
+	return events, nil
+}
+
+func (m *mockLoginEventRepository) FindLastSuccessful(ctx context.Context, userID string) (*application.LoginEvent, error) {
+	for i := len(m.events) - 1; i >= 0; i-- {
+		if m.events[i].UserID == userID && m.events[i].Success {
+			return m.events[i], nil
+		}
+	}
+	return nil, application.ErrLoginEventNotFound
+}
+
+func TestRecordLoginEventUseCase_Execute(t *testing.T) {
+	users := &mockUserRepositoryForLogin{users: map[string]*application.User{
+		"user-1": {ID: "user-1", Email: "ana@example.com"},
+	}}
+
+	tests := []struct {
+		name       string
+		email      string
+		success    bool
+		wantUserID string
+	}{
+		{name: "successful login is linked to the user", email: "ana@example.com", success: true, wantUserID: "user-1"},
+		{name: "failed login of an existing account is linked to it", email: "ana@example.com", success: false, wantUserID: "user-1"},
+		{name: "failed login of an unknown email is unlinked", email: "nobody@example.com", success: false, wantUserID: ""},
+		{name: "failed login without email is recorded", email: "", success: false, wantUserID: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &mockLoginEventRepository{}
+			uc := NewRecordLoginEventUseCase(users, events)
+
+			if err := uc.Execute(context.Background(), tt.email, "10.0.0.1", "Mozilla/5.0", tt.success); err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+
+			if len(events.events) != 1 {
+				t.Fatalf("expected 1 recorded event, got %d", len(events.events))
+			}
+			event := events.events[0]
+			if event.UserID != tt.wantUserID {
+				t.Errorf("UserID = %q, want %q", event.UserID, tt.wantUserID)
+			}
+			if event.Email != tt.email || event.IP != "10.0.0.1" || event.UserAgent != "Mozilla/5.0" || event.Success != tt.success {
+				t.Errorf("unexpected event %+v", event)
+			}
+		})
+	}
+}
+
+func TestListLoginEventsUseCase_Limit(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		wantLimit int
+	}{
+		{name: "zero uses the default", limit: 0, wantLimit: defaultLoginEventLimit},
+		{name: "negative uses the default", limit: -1, wantLimit: defaultLoginEventLimit},
+		{name: "within range is kept", limit: 5, wantLimit: 5},
+		{name: "above the maximum is capped", limit: 1000, wantLimit: maxLoginEventLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &mockLoginEventRepository{}
+			if _, err := NewListLoginEventsUseCase(events).Execute(context.Background(), "user-1", tt.limit); err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+			if events.lastLimit != tt.wantLimit {
+				t.Errorf("limit = %d, want %d", events.lastLimit, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestGetLastLoginUseCase_Execute(t *testing.T) {
+	events := &mockLoginEventRepository{events: []*application.LoginEvent{
+		{ID: "login-1", UserID: "user-1", Success: true},
+		{ID: "login-2", UserID: "user-1", Success: false},
+	}}
+	uc := NewGetLastLoginUseCase(events)
+
+	last, err := uc.Execute(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if last == nil || last.ID != "login-1" {
+		t.Errorf("Execute() = %+v, want login-1", last)
+	}
+
+	last, err = uc.Execute(context.Background(), "user-2")
+	if err != nil || last != nil {
+		t.Errorf("Execute() for user without logins = %+v, %v; want nil, nil", last, err)
+	}
+}
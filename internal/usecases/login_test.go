@@ -2,9 +2,14 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // Mock UserRepository for testing
@@ -43,13 +48,28 @@ func (m *mockUserRepositoryForLogin) Delete(ctx context.Context, id string) erro
 	return nil
 }
 
+func (m *mockUserRepositoryForLogin) RevokeSessions(ctx context.Context, id string) error {
+	if user, ok := m.users[id]; ok {
+		user.TokenVersion++
+	}
+	return nil
+}
+
+func (m *mockUserRepositoryForLogin) Search(ctx context.Context, query, excludeID string, limit int) ([]*application.User, error) {
+	return nil, nil
+}
+
+func (m *mockUserRepositoryForLogin) FindByIDs(ctx context.Context, ids []string) ([]*application.User, error) {
+	return nil, nil
+}
+
 func TestLoginUseCase_Execute(t *testing.T) {
 	// Setup
 	mockRepo := &mockUserRepositoryForLogin{
 		users: make(map[string]*application.User),
 	}
 
-	loginUseCase := NewLoginUseCase(mockRepo, "test-secret-key")
+	loginUseCase := NewLoginUseCase(mockRepo, nil, service.NewJWTKeySet("test-secret-key"), nil, SessionDurations{}, 0)
 
 	// Create test user with properly hashed password
 	// We need to hash the password using the same auth service
@@ -106,23 +126,271 @@ func TestLoginUseCase_Execute(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			token, err := loginUseCase.Execute(context.Background(), tt.email, tt.password)
+			result, err := loginUseCase.Execute(context.Background(), tt.email, tt.password, false)
 
 			if tt.wantError {
 				if err == nil {
 					t.Errorf("Execute() expected error but got nil")
 				}
-				if token != "" {
-					t.Errorf("Execute() expected empty token on error")
+				if result != nil {
+					t.Errorf("Execute() expected nil result on error")
 				}
 			} else {
 				if err != nil {
 					t.Errorf("Execute() unexpected error: %v", err)
 				}
-				if token == "" {
+				if result == nil || result.Token == "" {
 					t.Errorf("Execute() expected token but got empty string")
 				}
 			}
 		})
 	}
 }
+
+func TestLoginUseCase_SessionDuration(t *testing.T) {
+	mockRepo := &mockUserRepositoryForLogin{
+		users: make(map[string]*application.User),
+	}
+
+	loginUseCase := NewLoginUseCase(mockRepo, nil, service.NewJWTKeySet("test-secret-key"), nil, SessionDurations{
+		Default:    2 * time.Hour,
+		RememberMe: 7 * 24 * time.Hour,
+	}, 0)
+
+	passwordHash, err := loginUseCase.authService.HashPassword("password123")
+	if err != nil {
+		t.Fatal("Failed to hash password:", err)
+	}
+	mockRepo.users["user-1"] = &application.User{
+		ID:           "user-1",
+		Email:        "test@example.com",
+		PasswordHash: passwordHash,
+	}
+
+	tests := []struct {
+		name         string
+		rememberMe   bool
+		wantDuration time.Duration
+	}{
+		{name: "regular session uses the default duration", rememberMe: false, wantDuration: 2 * time.Hour},
+		{name: "remember me uses the longer duration", rememberMe: true, wantDuration: 7 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := loginUseCase.Execute(context.Background(), "test@example.com", "password123", tt.rememberMe)
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+
+			if result.Duration != tt.wantDuration {
+				t.Errorf("Duration = %v, want %v", result.Duration, tt.wantDuration)
+			}
+
+			claims, err := loginUseCase.authService.ValidateToken(result.Token)
+			if err != nil {
+				t.Fatalf("ValidateToken() unexpected error: %v", err)
+			}
+			lifetime := claims.ExpiresAt.Sub(claims.IssuedAt.Time)
+			if lifetime != tt.wantDuration {
+				t.Errorf("token lifetime = %v, want %v", lifetime, tt.wantDuration)
+			}
+		})
+	}
+}
+
+func TestNewLoginUseCase_DefaultDurations(t *testing.T) {
+	uc := NewLoginUseCase(&mockUserRepositoryForLogin{}, nil, service.NewJWTKeySet("test-secret-key"), nil, SessionDurations{}, 0)
+
+	if uc.durations.Default != DefaultSessionDuration {
+		t.Errorf("Default = %v, want %v", uc.durations.Default, DefaultSessionDuration)
+	}
+	if uc.durations.RememberMe != DefaultRememberMeDuration {
+		t.Errorf("RememberMe = %v, want %v", uc.durations.RememberMe, DefaultRememberMeDuration)
+	}
+}
+
+func TestLoginUseCase_ReturnsLocale(t *testing.T) {
+	mockRepo := &mockUserRepositoryForLogin{
+		users: make(map[string]*application.User),
+	}
+	loginUseCase := NewLoginUseCase(mockRepo, nil, service.NewJWTKeySet("test-secret-key"), nil, SessionDurations{}, 0)
+
+	passwordHash, err := loginUseCase.authService.HashPassword("password123")
+	if err != nil {
+		t.Fatal("Failed to hash password:", err)
+	}
+	mockRepo.users["user-1"] = &application.User{
+		ID:           "user-1",
+		Email:        "test@example.com",
+		PasswordHash: passwordHash,
+		Locale:       application.LocaleEN,
+	}
+
+	result, err := loginUseCase.Execute(context.Background(), "test@example.com", "password123", false)
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if result.Locale != application.LocaleEN {
+		t.Errorf("Locale = %q, want %q", result.Locale, application.LocaleEN)
+	}
+}
+
+func TestLoginUseCase_DisabledUser(t *testing.T) {
+	mockRepo := &mockUserRepositoryForLogin{
+		users: make(map[string]*application.User),
+	}
+	loginUseCase := NewLoginUseCase(mockRepo, nil, service.NewJWTKeySet("test-secret-key"), nil, SessionDurations{}, 0)
+
+	passwordHash, err := loginUseCase.authService.HashPassword("password123")
+	if err != nil {
+		t.Fatal("Failed to hash password:", err)
+	}
+	mockRepo.users["user-1"] = &application.User{
+		ID:           "user-1",
+		Email:        "test@example.com",
+		PasswordHash: passwordHash,
+		Disabled:     true,
+	}
+
+	if _, err := loginUseCase.Execute(context.Background(), "test@example.com", "password123", false); !errors.Is(err, application.ErrUserDisabled) {
+		t.Errorf("Execute() error = %v, want ErrUserDisabled", err)
+	}
+	if _, err := loginUseCase.Execute(context.Background(), "test@example.com", "wrong", false); errors.Is(err, application.ErrUserDisabled) {
+		t.Error("Execute() told a wrong password that the account is disabled")
+	}
+}
+
+func TestLoginUseCase_RehashesLegacyPassword(t *testing.T) {
+	mockRepo := &mockUserRepositoryForLogin{
+		users: make(map[string]*application.User),
+	}
+	hasher, err := service.NewPasswordHasher(service.AlgorithmArgon2id, service.PasswordHashParams{
+		Argon2MemoryKiB:   64,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	loginUseCase := NewLoginUseCase(mockRepo, nil, service.NewJWTKeySet("test-secret-key"), hasher, SessionDurations{}, 0)
+
+	// A hash stored before the algorithm prefix existed
+	legacyHash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mockRepo.users["user-1"] = &application.User{
+		ID:           "user-1",
+		Email:        "test@example.com",
+		PasswordHash: string(legacyHash),
+	}
+
+	if _, err := loginUseCase.Execute(context.Background(), "test@example.com", "wrong-password", false); err == nil {
+		t.Fatal("Execute() with a wrong password returned nil error")
+	}
+	if mockRepo.users["user-1"].PasswordHash != string(legacyHash) {
+		t.Fatal("hash was replaced after a failed login")
+	}
+
+	if _, err := loginUseCase.Execute(context.Background(), "test@example.com", "password123", false); err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	rehashed := mockRepo.users["user-1"].PasswordHash
+	if !strings.HasPrefix(rehashed, "{argon2id}") {
+		t.Fatalf("PasswordHash = %q, want it rehashed with argon2id", rehashed)
+	}
+
+	// The new hash keeps working and isn't rehashed again
+	if _, err := loginUseCase.Execute(context.Background(), "test@example.com", "password123", false); err != nil {
+		t.Fatalf("Execute() with the new hash unexpected error: %v", err)
+	}
+	if mockRepo.users["user-1"].PasswordHash != rehashed {
+		t.Error("hash changed on the second login")
+	}
+}
+
+// countingHasher is a PasswordHasher counting the password checks
+type countingHasher struct {
+	verifies int
+}
+
+func (h *countingHasher) Algorithm() string { return "counting" }
+
+func (h *countingHasher) Hash(password string) (string, error) { return "hash:" + password, nil }
+
+func (h *countingHasher) Verify(hash, password string) error {
+	h.verifies++
+	if hash != "hash:"+password {
+		return service.ErrPasswordMismatch
+	}
+	return nil
+}
+
+func (h *countingHasher) NeedsRehash(hash string) bool { return false }
+
+func TestLoginUseCase_FailuresLookAlike(t *testing.T) {
+	hasher := &countingHasher{}
+	repo := &mockUserRepositoryForLogin{users: map[string]*application.User{
+		"user-1": {ID: "user-1", Email: "test@example.com", PasswordHash: "{counting}hash:password123"},
+	}}
+	loginUseCase := NewLoginUseCase(repo, nil, service.NewJWTKeySet("test-secret-key"), hasher, SessionDurations{}, 0)
+
+	tests := []struct {
+		name         string
+		email        string
+		password     string
+		wantVerifies int
+	}{
+		{"wrong password", "test@example.com", "wrong-password", 1},
+		{"unknown email pays for a password check", "nobody@example.com", "password123", 1},
+		{"empty email", "", "password123", 0},
+		{"empty password", "test@example.com", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hasher.verifies = 0
+
+			result, err := loginUseCase.Execute(context.Background(), tt.email, tt.password, false)
+			if !errors.Is(err, application.ErrInvalidCredentials) || result != nil {
+				t.Fatalf("Execute() = %v, %v, want ErrInvalidCredentials", result, err)
+			}
+			if hasher.verifies != tt.wantVerifies {
+				t.Errorf("password checks = %d, want %d", hasher.verifies, tt.wantVerifies)
+			}
+		})
+	}
+}
+
+func TestLoginUseCase_MinFailureDelay(t *testing.T) {
+	repo := &mockUserRepositoryForLogin{users: map[string]*application.User{}}
+	loginUseCase := NewLoginUseCase(repo, nil, service.NewJWTKeySet("test-secret-key"), &countingHasher{}, SessionDurations{}, 50*time.Millisecond)
+
+	start := time.Now()
+	if _, err := loginUseCase.Execute(context.Background(), "nobody@example.com", "password123", false); err == nil {
+		t.Fatal("Execute() with an unknown email returned nil error")
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("failed login took %v, want at least 50ms", elapsed)
+	}
+
+	// A cancelled request stops waiting
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start = time.Now()
+	loginUseCase.Execute(ctx, "nobody@example.com", "password123", false)
+	if elapsed := time.Since(start); elapsed >= 50*time.Millisecond {
+		t.Errorf("cancelled failed login took %v, want it to return right away", elapsed)
+	}
+
+	// Successful logins aren't delayed
+	repo.users["user-1"] = &application.User{ID: "user-1", Email: "test@example.com", PasswordHash: "{counting}hash:password123"}
+	start = time.Now()
+	if _, err := loginUseCase.Execute(context.Background(), "test@example.com", "password123", false); err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= 50*time.Millisecond {
+		t.Errorf("successful login took %v, want no delay", elapsed)
+	}
+}
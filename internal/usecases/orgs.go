@@ -0,0 +1,271 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// CreateOrgUseCase handles a user creating an org, which they own
+type CreateOrgUseCase struct {
+	orgRepo    repository.OrgRepository
+	transactor repository.Transactor
+}
+
+// NewCreateOrgUseCase creates a new CreateOrgUseCase
+func NewCreateOrgUseCase(orgRepo repository.OrgRepository, transactor repository.Transactor) *CreateOrgUseCase {
+	return &CreateOrgUseCase{orgRepo: orgRepo, transactor: transactor}
+}
+
+// Execute creates an org with the user as its owner. Its tasks are shared with all its members.
+func (uc *CreateOrgUseCase) Execute(ctx context.Context, userID, name string) (_ *application.Org, err error) {
+	ctx, end := tracer.Start(ctx, "CreateOrg")
+	defer func() { end(err) }()
+
+	now := time.Now()
+	org, err := application.NewOrg(uuid.New().String(), name, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.orgRepo.Create(ctx, org); err != nil {
+			return err
+		}
+		return uc.orgRepo.SaveMember(ctx, &application.OrgMember{
+			OrgID:    org.ID,
+			UserID:   userID,
+			Role:     application.OrgRoleOwner,
+			JoinedAt: now,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// ListOrgsUseCase handles listing the orgs of a user
+type ListOrgsUseCase struct {
+	orgRepo repository.OrgRepository
+}
+
+// NewListOrgsUseCase creates a new ListOrgsUseCase
+func NewListOrgsUseCase(orgRepo repository.OrgRepository) *ListOrgsUseCase {
+	return &ListOrgsUseCase{orgRepo: orgRepo}
+}
+
+// Execute returns the orgs the user belongs to, with their role, ordered by name
+func (uc *ListOrgsUseCase) Execute(ctx context.Context, userID string) (_ []*application.OrgMembership, err error) {
+	ctx, end := tracer.Start(ctx, "ListOrgs")
+	defer func() { end(err) }()
+
+	return uc.orgRepo.FindByUserID(ctx, userID)
+}
+
+// UpdateOrgUseCase handles the owners and admins of an org renaming it or changing whether its
+// tasks are shared with all its members
+type UpdateOrgUseCase struct {
+	orgRepo repository.OrgRepository
+}
+
+// NewUpdateOrgUseCase creates a new UpdateOrgUseCase
+func NewUpdateOrgUseCase(orgRepo repository.OrgRepository) *UpdateOrgUseCase {
+	return &UpdateOrgUseCase{orgRepo: orgRepo}
+}
+
+// Execute updates the org. It returns application.ErrOrgNotFound when the user isn't a member
+// and application.ErrOrgAdminRequired when they can't manage it.
+func (uc *UpdateOrgUseCase) Execute(ctx context.Context, orgID, userID, name string, shareTasks bool) (_ *application.Org, err error) {
+	ctx, end := tracer.Start(ctx, "UpdateOrg")
+	defer func() { end(err) }()
+
+	if _, err := requireOrgManager(ctx, uc.orgRepo, orgID, userID); err != nil {
+		return nil, err
+	}
+	org, err := uc.orgRepo.FindByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if err := org.Update(name, shareTasks); err != nil {
+		return nil, err
+	}
+	if err := uc.orgRepo.Update(ctx, org); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// ListOrgMembersUseCase handles the members of an org listing who belongs to it
+type ListOrgMembersUseCase struct {
+	orgRepo repository.OrgRepository
+}
+
+// NewListOrgMembersUseCase creates a new ListOrgMembersUseCase
+func NewListOrgMembersUseCase(orgRepo repository.OrgRepository) *ListOrgMembersUseCase {
+	return &ListOrgMembersUseCase{orgRepo: orgRepo}
+}
+
+// Execute returns the members of the org, ordered by name. It returns
+// application.ErrOrgNotFound when the user isn't a member.
+func (uc *ListOrgMembersUseCase) Execute(ctx context.Context, orgID, userID string) (_ []*application.OrgMember, err error) {
+	ctx, end := tracer.Start(ctx, "ListOrgMembers")
+	defer func() { end(err) }()
+
+	if _, err := findOrgMember(ctx, uc.orgRepo, orgID, userID); err != nil {
+		return nil, err
+	}
+	return uc.orgRepo.FindMembers(ctx, orgID)
+}
+
+// SaveOrgMemberUseCase handles the owners and admins of an org adding users to it and changing
+// their roles
+type SaveOrgMemberUseCase struct {
+	orgRepo    repository.OrgRepository
+	userRepo   repository.UserRepository
+	transactor repository.Transactor
+}
+
+// NewSaveOrgMemberUseCase creates a new SaveOrgMemberUseCase
+func NewSaveOrgMemberUseCase(orgRepo repository.OrgRepository, userRepo repository.UserRepository, transactor repository.Transactor) *SaveOrgMemberUseCase {
+	return &SaveOrgMemberUseCase{orgRepo: orgRepo, userRepo: userRepo, transactor: transactor}
+}
+
+// Execute adds the user with the email to the org with the role, or gives them the role when
+// they already belong to it. Only owners grant or take the owner role, and the org keeps at
+// least one owner.
+func (uc *SaveOrgMemberUseCase) Execute(ctx context.Context, orgID, actorID, email string, role application.OrgRole) (_ *application.OrgMember, err error) {
+	ctx, end := tracer.Start(ctx, "SaveOrgMember")
+	defer func() { end(err) }()
+
+	if !role.IsValid() {
+		return nil, application.ErrInvalidOrgRole
+	}
+	actor, err := requireOrgManager(ctx, uc.orgRepo, orgID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := uc.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	var saved *application.OrgMember
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		member, err := uc.orgRepo.FindMember(ctx, orgID, user.ID)
+		if errors.Is(err, application.ErrOrgMemberNotFound) {
+			member = &application.OrgMember{OrgID: orgID, UserID: user.ID, JoinedAt: time.Now()}
+		} else if err != nil {
+			return err
+		}
+
+		if (role == application.OrgRoleOwner || member.Role == application.OrgRoleOwner) && actor.Role != application.OrgRoleOwner {
+			return application.ErrOrgOwnerRequired
+		}
+		if member.Role == application.OrgRoleOwner && role != application.OrgRoleOwner {
+			if err := requireAnotherOwner(ctx, uc.orgRepo, orgID); err != nil {
+				return err
+			}
+		}
+
+		member.Role = role
+		if err := uc.orgRepo.SaveMember(ctx, member); err != nil {
+			return err
+		}
+		member.Name, member.Email = user.Name, user.Email
+		saved = member
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// RemoveOrgMemberUseCase handles members leaving an org, and its owners and admins removing
+// members
+type RemoveOrgMemberUseCase struct {
+	orgRepo    repository.OrgRepository
+	transactor repository.Transactor
+}
+
+// NewRemoveOrgMemberUseCase creates a new RemoveOrgMemberUseCase
+func NewRemoveOrgMemberUseCase(orgRepo repository.OrgRepository, transactor repository.Transactor) *RemoveOrgMemberUseCase {
+	return &RemoveOrgMemberUseCase{orgRepo: orgRepo, transactor: transactor}
+}
+
+// Execute removes the user from the org. Members may remove themselves; removing others takes
+// an owner or admin, and removing an owner takes another owner. The last owner can't leave.
+func (uc *RemoveOrgMemberUseCase) Execute(ctx context.Context, orgID, actorID, userID string) (err error) {
+	ctx, end := tracer.Start(ctx, "RemoveOrgMember")
+	defer func() { end(err) }()
+
+	actor, err := findOrgMember(ctx, uc.orgRepo, orgID, actorID)
+	if err != nil {
+		return err
+	}
+	if userID != actorID && !actor.Role.CanManage() {
+		return application.ErrOrgAdminRequired
+	}
+
+	return uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		member, err := uc.orgRepo.FindMember(ctx, orgID, userID)
+		if err != nil {
+			return err
+		}
+		if member.Role == application.OrgRoleOwner {
+			if userID != actorID && actor.Role != application.OrgRoleOwner {
+				return application.ErrOrgOwnerRequired
+			}
+			if err := requireAnotherOwner(ctx, uc.orgRepo, orgID); err != nil {
+				return err
+			}
+		}
+		return uc.orgRepo.RemoveMember(ctx, orgID, userID)
+	})
+}
+
+// findOrgMember returns the membership of a user in an org, or application.ErrOrgNotFound when
+// they aren't a member, so users can't tell which orgs exist
+func findOrgMember(ctx context.Context, orgRepo repository.OrgRepository, orgID, userID string) (*application.OrgMember, error) {
+	member, err := orgRepo.FindMember(ctx, orgID, userID)
+	if errors.Is(err, application.ErrOrgMemberNotFound) {
+		return nil, application.ErrOrgNotFound
+	}
+	return member, err
+}
+
+// requireOrgManager returns the membership of a user who manages an org, or
+// application.ErrOrgAdminRequired for the other members
+func requireOrgManager(ctx context.Context, orgRepo repository.OrgRepository, orgID, userID string) (*application.OrgMember, error) {
+	member, err := findOrgMember(ctx, orgRepo, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !member.Role.CanManage() {
+		return nil, application.ErrOrgAdminRequired
+	}
+	return member, nil
+}
+
+// requireAnotherOwner returns application.ErrLastOrgOwner when an org has a single owner, who
+// can't stop being one
+func requireAnotherOwner(ctx context.Context, orgRepo repository.OrgRepository, orgID string) error {
+	owners, err := orgRepo.CountOwners(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if owners < 2 {
+		return application.ErrLastOrgOwner
+	}
+	return nil
+}
+
+// inOrgScope puts a new task in the workspace of ctx: the org the request is scoped to, if any
+func inOrgScope(ctx context.Context, task *application.Task) {
+	task.OrgID, _ = repository.OrgScope(ctx)
+}
@@ -0,0 +1,279 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// mockOrgRepository keeps orgs and their members in memory
+type mockOrgRepository struct {
+	orgs    map[string]*application.Org
+	members map[string]map[string]*application.OrgMember // org ID -> user ID -> member
+}
+
+func newMockOrgRepository() *mockOrgRepository {
+	return &mockOrgRepository{orgs: map[string]*application.Org{}, members: map[string]map[string]*application.OrgMember{}}
+}
+
+func (m *mockOrgRepository) Create(ctx context.Context, org *application.Org) error {
+	stored := *org
+	m.orgs[org.ID] = &stored
+	m.members[org.ID] = map[string]*application.OrgMember{}
+	return nil
+}
+
+func (m *mockOrgRepository) Update(ctx context.Context, org *application.Org) error {
+	stored := *org
+	m.orgs[org.ID] = &stored
+	return nil
+}
+
+func (m *mockOrgRepository) FindByID(ctx context.Context, id string) (*application.Org, error) {
+	org, ok := m.orgs[id]
+	if !ok {
+		return nil, application.ErrOrgNotFound
+	}
+	found := *org
+	return &found, nil
+}
+
+func (m *mockOrgRepository) FindByUserID(ctx context.Context, userID string) ([]*application.OrgMembership, error) {
+	var memberships []*application.OrgMembership
+	for orgID, members := range m.members {
+		if member, ok := members[userID]; ok {
+			memberships = append(memberships, &application.OrgMembership{Org: m.orgs[orgID], Role: member.Role})
+		}
+	}
+	return memberships, nil
+}
+
+func (m *mockOrgRepository) FindMember(ctx context.Context, orgID, userID string) (*application.OrgMember, error) {
+	member, ok := m.members[orgID][userID]
+	if !ok {
+		return nil, application.ErrOrgMemberNotFound
+	}
+	found := *member
+	return &found, nil
+}
+
+func (m *mockOrgRepository) FindMembers(ctx context.Context, orgID string) ([]*application.OrgMember, error) {
+	var members []*application.OrgMember
+	for _, member := range m.members[orgID] {
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func (m *mockOrgRepository) SaveMember(ctx context.Context, member *application.OrgMember) error {
+	stored := *member
+	m.members[member.OrgID][member.UserID] = &stored
+	return nil
+}
+
+func (m *mockOrgRepository) RemoveMember(ctx context.Context, orgID, userID string) error {
+	delete(m.members[orgID], userID)
+	return nil
+}
+
+func (m *mockOrgRepository) CountOwners(ctx context.Context, orgID string) (int, error) {
+	owners := 0
+	for _, member := range m.members[orgID] {
+		if member.Role == application.OrgRoleOwner {
+			owners++
+		}
+	}
+	return owners, nil
+}
+
+// newOrgWithMembers creates org-1 with an owner, an admin and a member
+func newOrgWithMembers(t *testing.T) *mockOrgRepository {
+	t.Helper()
+	repo := newMockOrgRepository()
+	org, _ := application.NewOrg("org-1", "Receita", time.Now())
+	repo.Create(context.Background(), org)
+	for userID, role := range map[string]application.OrgRole{
+		"owner-1":  application.OrgRoleOwner,
+		"admin-1":  application.OrgRoleAdmin,
+		"member-1": application.OrgRoleMember,
+	} {
+		repo.SaveMember(context.Background(), &application.OrgMember{OrgID: "org-1", UserID: userID, Role: role})
+	}
+	return repo
+}
+
+func newMockUserRepositoryForOrgs() *mockUserRepositoryForLogin {
+	return &mockUserRepositoryForLogin{users: map[string]*application.User{
+		"owner-1":  {ID: "owner-1", Name: "Ana", Email: "ana@example.com"},
+		"admin-1":  {ID: "admin-1", Name: "Bia", Email: "bia@example.com"},
+		"member-1": {ID: "member-1", Name: "Caio", Email: "caio@example.com"},
+		"user-1":   {ID: "user-1", Name: "Davi", Email: "davi@example.com"},
+	}}
+}
+
+func TestCreateOrgUseCase(t *testing.T) {
+	repo := newMockOrgRepository()
+	transactor := &mockTransactor{}
+	uc := NewCreateOrgUseCase(repo, transactor)
+
+	if _, err := uc.Execute(context.Background(), "user-1", "  "); !errors.Is(err, application.ErrOrgNameRequired) {
+		t.Fatalf("Execute() with a blank name error = %v, want ErrOrgNameRequired", err)
+	}
+
+	org, err := uc.Execute(context.Background(), "user-1", "Receita")
+	if err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if !org.ShareTasks || transactor.calls != 1 {
+		t.Errorf("Execute() = %+v in %d transactions, want its tasks shared, in one transaction", org, transactor.calls)
+	}
+	member, err := repo.FindMember(context.Background(), org.ID, "user-1")
+	if err != nil || member.Role != application.OrgRoleOwner {
+		t.Errorf("creator membership = %+v, %v; want owner", member, err)
+	}
+}
+
+func TestUpdateOrgUseCase(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		orgName string
+		wantErr error
+	}{
+		{"owner", "owner-1", "Receita Federal", nil},
+		{"admin", "admin-1", "Receita Federal", nil},
+		{"member", "member-1", "Receita Federal", application.ErrOrgAdminRequired},
+		{"outsider", "user-1", "Receita Federal", application.ErrOrgNotFound},
+		{"blank name", "owner-1", " ", application.ErrOrgNameRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newOrgWithMembers(t)
+			org, err := NewUpdateOrgUseCase(repo).Execute(context.Background(), "org-1", tt.userID, tt.orgName, false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (org.Name != tt.orgName || org.ShareTasks) {
+				t.Errorf("Execute() = %+v", org)
+			}
+		})
+	}
+}
+
+func TestListOrgMembersUseCase(t *testing.T) {
+	repo := newOrgWithMembers(t)
+	uc := NewListOrgMembersUseCase(repo)
+
+	if members, err := uc.Execute(context.Background(), "org-1", "member-1"); err != nil || len(members) != 3 {
+		t.Errorf("Execute() by a member = %d members, %v; want 3", len(members), err)
+	}
+	if _, err := uc.Execute(context.Background(), "org-1", "user-1"); !errors.Is(err, application.ErrOrgNotFound) {
+		t.Errorf("Execute() by an outsider error = %v, want ErrOrgNotFound", err)
+	}
+}
+
+func TestSaveOrgMemberUseCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		actorID  string
+		email    string
+		role     application.OrgRole
+		wantErr  error
+		wantRole application.OrgRole // of the user with the email afterwards
+	}{
+		{"admin adds a member", "admin-1", "davi@example.com", application.OrgRoleMember, nil, application.OrgRoleMember},
+		{"admin promotes a member", "admin-1", "caio@example.com", application.OrgRoleAdmin, nil, application.OrgRoleAdmin},
+		{"owner grants the owner role", "owner-1", "caio@example.com", application.OrgRoleOwner, nil, application.OrgRoleOwner},
+		{"admin can't grant the owner role", "admin-1", "davi@example.com", application.OrgRoleOwner, application.ErrOrgOwnerRequired, ""},
+		{"admin can't demote an owner", "admin-1", "ana@example.com", application.OrgRoleMember, application.ErrOrgOwnerRequired, application.OrgRoleOwner},
+		{"last owner can't step down", "owner-1", "ana@example.com", application.OrgRoleAdmin, application.ErrLastOrgOwner, application.OrgRoleOwner},
+		{"member can't add", "member-1", "davi@example.com", application.OrgRoleMember, application.ErrOrgAdminRequired, ""},
+		{"outsider can't add", "user-1", "davi@example.com", application.OrgRoleMember, application.ErrOrgNotFound, ""},
+		{"unknown email", "owner-1", "ninguem@example.com", application.OrgRoleMember, application.ErrUserNotFound, ""},
+		{"invalid role", "owner-1", "davi@example.com", application.OrgRole("guest"), application.ErrInvalidOrgRole, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newOrgWithMembers(t)
+			users := newMockUserRepositoryForOrgs()
+			uc := NewSaveOrgMemberUseCase(repo, users, &mockTransactor{})
+
+			member, err := uc.Execute(context.Background(), "org-1", tt.actorID, tt.email, tt.role)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (member.Role != tt.role || member.Email != tt.email) {
+				t.Errorf("Execute() = %+v", member)
+			}
+
+			user, _ := users.FindByEmail(context.Background(), tt.email)
+			if user == nil {
+				return
+			}
+			var role application.OrgRole
+			if saved, err := repo.FindMember(context.Background(), "org-1", user.ID); err == nil {
+				role = saved.Role
+			}
+			if role != tt.wantRole {
+				t.Errorf("role afterwards = %q, want %q", role, tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestRemoveOrgMemberUseCase(t *testing.T) {
+	tests := []struct {
+		name    string
+		actorID string
+		userID  string
+		owners  int // owners of the org before the removal
+		wantErr error
+	}{
+		{"member leaves", "member-1", "member-1", 1, nil},
+		{"admin removes a member", "admin-1", "member-1", 1, nil},
+		{"member can't remove others", "member-1", "admin-1", 1, application.ErrOrgAdminRequired},
+		{"admin can't remove an owner", "admin-1", "owner-1", 2, application.ErrOrgOwnerRequired},
+		{"owner removes another owner", "owner-1", "owner-2", 2, nil},
+		{"last owner can't leave", "owner-1", "owner-1", 1, application.ErrLastOrgOwner},
+		{"owner leaves another owner behind", "owner-1", "owner-1", 2, nil},
+		{"unknown member", "owner-1", "user-1", 1, application.ErrOrgMemberNotFound},
+		{"outsider", "user-1", "member-1", 1, application.ErrOrgNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newOrgWithMembers(t)
+			if tt.owners == 2 {
+				repo.SaveMember(context.Background(), &application.OrgMember{OrgID: "org-1", UserID: "owner-2", Role: application.OrgRoleOwner})
+			}
+
+			err := NewRemoveOrgMemberUseCase(repo, &mockTransactor{}).Execute(context.Background(), "org-1", tt.actorID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			_, findErr := repo.FindMember(context.Background(), "org-1", tt.userID)
+			if removed := errors.Is(findErr, application.ErrOrgMemberNotFound); removed != (err == nil) && tt.wantErr != application.ErrOrgMemberNotFound {
+				t.Errorf("member removed = %v, want %v", removed, err == nil)
+			}
+		})
+	}
+}
+
+func TestInOrgScope(t *testing.T) {
+	task := &application.Task{}
+	inOrgScope(context.Background(), task)
+	if task.OrgID != "" {
+		t.Errorf("OrgID without a scope = %q, want personal", task.OrgID)
+	}
+
+	inOrgScope(repository.WithOrgScope(context.Background(), "org-1"), task)
+	if task.OrgID != "org-1" {
+		t.Errorf("OrgID = %q, want the org of the scope", task.OrgID)
+	}
+}
@@ -0,0 +1,144 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// UploadedFile is a file found in an upload directory
+type UploadedFile struct {
+	Name    string
+	Size    int64
+	ModTime time.Time
+}
+
+// FileStore lists and removes the files of an upload directory
+type FileStore interface {
+	List(ctx context.Context) ([]UploadedFile, error)
+	Remove(name string) error
+}
+
+// OrphanFile is an uploaded file no task or user refers to
+type OrphanFile struct {
+	Kind application.StoredFileKind
+	Name string
+	Size int64
+}
+
+// OrphanCleanupSummary reports the outcome of an orphaned file cleanup run
+type OrphanCleanupSummary struct {
+	Scanned int
+	Orphans []OrphanFile // Removed, or only found in a dry run
+	Removed int
+	Failed  int
+	Bytes   int64 // Size of the orphans
+}
+
+// CleanOrphanFilesUseCase removes the uploaded images and attachments that nothing refers to
+// anymore, such as the images of failed task creations. It is run periodically by a
+// background job. Files younger than the grace period are kept, since an image is uploaded
+// before the task that uses it is saved.
+type CleanOrphanFilesUseCase struct {
+	refRepo     repository.UploadReferenceRepository
+	fileRepo    repository.StoredFileRepository
+	images      FileStore
+	attachments FileStore
+	gracePeriod time.Duration
+	dryRun      bool
+	now         func() time.Time
+}
+
+// NewCleanOrphanFilesUseCase creates a new CleanOrphanFilesUseCase. In a dry run the orphans
+// are reported but neither removed nor released from the storage quota.
+func NewCleanOrphanFilesUseCase(
+	refRepo repository.UploadReferenceRepository,
+	fileRepo repository.StoredFileRepository,
+	images, attachments FileStore,
+	gracePeriod time.Duration,
+	dryRun bool,
+) *CleanOrphanFilesUseCase {
+	return &CleanOrphanFilesUseCase{
+		refRepo:     refRepo,
+		fileRepo:    fileRepo,
+		images:      images,
+		attachments: attachments,
+		gracePeriod: gracePeriod,
+		dryRun:      dryRun,
+		now:         time.Now,
+	}
+}
+
+// Execute removes the orphaned images and attachments. A file that fails to be removed is
+// counted and the run goes on; the failures are returned together.
+func (uc *CleanOrphanFilesUseCase) Execute(ctx context.Context) (OrphanCleanupSummary, error) {
+	var summary OrphanCleanupSummary
+
+	imageErr := uc.clean(ctx, &summary, application.StoredFileImage, uc.images, uc.refRepo.ImagePaths)
+	attachmentErr := uc.clean(ctx, &summary, application.StoredFileAttachment, uc.attachments, uc.refRepo.AttachmentPaths)
+	return summary, errors.Join(imageErr, attachmentErr)
+}
+
+// clean removes the orphans of one upload directory. The files are listed before the
+// references are read, so a file saved with its reference during the run is never taken
+// for an orphan.
+func (uc *CleanOrphanFilesUseCase) clean(
+	ctx context.Context,
+	summary *OrphanCleanupSummary,
+	kind application.StoredFileKind,
+	store FileStore,
+	references func(ctx context.Context) ([]string, error),
+) error {
+	files, err := store.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list %s files: %w", kind, err)
+	}
+
+	paths, err := references(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve %s references: %w", kind, err)
+	}
+	referenced := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		referenced[path.Base(strings.ReplaceAll(p, `\`, "/"))] = true
+	}
+
+	cutoff := uc.now().Add(-uc.gracePeriod)
+	var errs []error
+	for _, file := range files {
+		if ctx.Err() != nil {
+			return errors.Join(append(errs, ctx.Err())...)
+		}
+
+		summary.Scanned++
+		if referenced[file.Name] || file.ModTime.After(cutoff) {
+			continue
+		}
+
+		summary.Orphans = append(summary.Orphans, OrphanFile{Kind: kind, Name: file.Name, Size: file.Size})
+		summary.Bytes += file.Size
+		if uc.dryRun {
+			continue
+		}
+
+		if err := store.Remove(file.Name); err != nil {
+			summary.Failed++
+			errs = append(errs, fmt.Errorf("failed to remove %s %s: %w", kind, file.Name, err))
+			continue
+		}
+		summary.Removed++
+
+		// The space of a file that never reached a task may still be charged to its uploader
+		if err := uc.fileRepo.Delete(ctx, kind, file.Name); err != nil {
+			errs = append(errs, fmt.Errorf("failed to release %s %s: %w", kind, file.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
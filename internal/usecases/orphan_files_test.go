@@ -0,0 +1,174 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// mockFileStore keeps the files of an upload directory in memory
+type mockFileStore struct {
+	files     map[string]UploadedFile
+	listErr   error
+	removeErr map[string]error
+}
+
+func newMockFileStore(files ...UploadedFile) *mockFileStore {
+	store := &mockFileStore{files: map[string]UploadedFile{}, removeErr: map[string]error{}}
+	for _, file := range files {
+		store.files[file.Name] = file
+	}
+	return store
+}
+
+func (m *mockFileStore) List(ctx context.Context) ([]UploadedFile, error) {
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	files := make([]UploadedFile, 0, len(m.files))
+	for _, file := range m.files {
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+func (m *mockFileStore) Remove(name string) error {
+	if err := m.removeErr[name]; err != nil {
+		return err
+	}
+	delete(m.files, name)
+	return nil
+}
+
+// mockUploadReferenceRepository returns fixed references
+type mockUploadReferenceRepository struct {
+	images      []string
+	attachments []string
+	err         error
+}
+
+func (m *mockUploadReferenceRepository) ImagePaths(ctx context.Context) ([]string, error) {
+	return m.images, m.err
+}
+
+func (m *mockUploadReferenceRepository) AttachmentPaths(ctx context.Context) ([]string, error) {
+	return m.attachments, m.err
+}
+
+func TestCleanOrphanFilesUseCase_Execute(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-48 * time.Hour)
+
+	tests := []struct {
+		name            string
+		dryRun          bool
+		removeErr       map[string]error
+		refErr          error
+		wantErr         bool
+		wantImages      []string // Left in the images directory
+		wantAttachments []string // Left in the attachments directory
+		wantOrphans     int
+		wantRemoved     int
+		wantFailed      int
+		wantReleased    []string // Stored files no longer charged
+	}{
+		{
+			name:            "removes old unreferenced files and releases their space",
+			wantImages:      []string{"1_task.png", "2_avatar.png", "4_recent.png"},
+			wantAttachments: []string{"a1.pdf"},
+			wantOrphans:     2,
+			wantRemoved:     2,
+			wantReleased:    []string{"image/3_orphan.png", "attachment/a2.pdf"},
+		},
+		{
+			name:            "dry run keeps everything",
+			dryRun:          true,
+			wantImages:      []string{"1_task.png", "2_avatar.png", "3_orphan.png", "4_recent.png"},
+			wantAttachments: []string{"a1.pdf", "a2.pdf"},
+			wantOrphans:     2,
+		},
+		{
+			name:            "failed removal is counted and the run goes on",
+			removeErr:       map[string]error{"3_orphan.png": errors.New("permission denied")},
+			wantErr:         true,
+			wantImages:      []string{"1_task.png", "2_avatar.png", "3_orphan.png", "4_recent.png"},
+			wantAttachments: []string{"a1.pdf"},
+			wantOrphans:     2,
+			wantRemoved:     1,
+			wantFailed:      1,
+			wantReleased:    []string{"attachment/a2.pdf"},
+		},
+		{
+			name:            "nothing is removed without the references",
+			refErr:          errors.New("database is locked"),
+			wantErr:         true,
+			wantImages:      []string{"1_task.png", "2_avatar.png", "3_orphan.png", "4_recent.png"},
+			wantAttachments: []string{"a1.pdf", "a2.pdf"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			images := newMockFileStore(
+				UploadedFile{Name: "1_task.png", Size: 10, ModTime: old},
+				UploadedFile{Name: "2_avatar.png", Size: 20, ModTime: old},
+				UploadedFile{Name: "3_orphan.png", Size: 30, ModTime: old},
+				UploadedFile{Name: "4_recent.png", Size: 40, ModTime: now.Add(-time.Hour)},
+			)
+			images.removeErr = tt.removeErr
+			attachments := newMockFileStore(
+				UploadedFile{Name: "a1.pdf", Size: 100, ModTime: old},
+				UploadedFile{Name: "a2.pdf", Size: 200, ModTime: old},
+			)
+			refRepo := &mockUploadReferenceRepository{
+				images:      []string{"/uploads/images/1_task.png", "/uploads/images/2_avatar.png"},
+				attachments: []string{"a1.pdf"},
+				err:         tt.refErr,
+			}
+			fileRepo := newMockStoredFileRepository()
+			for _, key := range []string{"image/3_orphan.png", "image/4_recent.png", "attachment/a2.pdf"} {
+				fileRepo.files[key] = &application.StoredFile{UserID: "user-1", Size: 1}
+			}
+
+			uc := NewCleanOrphanFilesUseCase(refRepo, fileRepo, images, attachments, 24*time.Hour, tt.dryRun)
+			uc.now = func() time.Time { return now }
+
+			summary, err := uc.Execute(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if len(summary.Orphans) != tt.wantOrphans || summary.Removed != tt.wantRemoved || summary.Failed != tt.wantFailed {
+				t.Errorf("Expected orphans=%d removed=%d failed=%d, got %+v", tt.wantOrphans, tt.wantRemoved, tt.wantFailed, summary)
+			}
+			for _, dir := range []struct {
+				name  string
+				store *mockFileStore
+				want  []string
+			}{
+				{"images", images, tt.wantImages},
+				{"attachments", attachments, tt.wantAttachments},
+			} {
+				if len(dir.store.files) != len(dir.want) {
+					t.Errorf("Expected %s %v, got %v", dir.name, dir.want, dir.store.files)
+				}
+				for _, file := range dir.want {
+					if _, ok := dir.store.files[file]; !ok {
+						t.Errorf("Expected %s to keep %s", dir.name, file)
+					}
+				}
+			}
+			for _, key := range tt.wantReleased {
+				if _, ok := fileRepo.files[key]; ok {
+					t.Errorf("Expected %s to be released from the storage quota", key)
+				}
+			}
+			if len(fileRepo.files) != 3-len(tt.wantReleased) {
+				t.Errorf("Expected %d stored files left, got %d", 3-len(tt.wantReleased), len(fileRepo.files))
+			}
+		})
+	}
+}
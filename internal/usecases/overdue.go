@@ -0,0 +1,89 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// FlagOverdueTasksUseCase flags pending tasks past their due date as overdue. It is run
+// periodically by a background job; each flagged task publishes a TaskOverdue event, which
+// notifies the owner and the users the task is shared with.
+type FlagOverdueTasksUseCase struct {
+	overdueRepo repository.OverdueRepository
+	events      event.Publisher
+	batchSize   int
+}
+
+// NewFlagOverdueTasksUseCase creates a new FlagOverdueTasksUseCase. Each run flags at most
+// batchSize tasks; the rest are left for the next runs.
+func NewFlagOverdueTasksUseCase(overdueRepo repository.OverdueRepository, events event.Publisher, batchSize int) *FlagOverdueTasksUseCase {
+	return &FlagOverdueTasksUseCase{
+		overdueRepo: overdueRepo,
+		events:      events,
+		batchSize:   batchSize,
+	}
+}
+
+// Execute flags a batch of overdue tasks and returns how many were flagged
+func (uc *FlagOverdueTasksUseCase) Execute(ctx context.Context) (int, error) {
+	tasks, err := uc.overdueRepo.FlagOverdue(ctx, time.Now(), uc.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, task := range tasks {
+		// The job has no actor: nobody is skipped when the owner and shared users are notified
+		uc.events.Publish(ctx, event.TaskOverdue{TaskEvent: event.NewTaskEvent(task, ""), DueDate: *task.DueDate})
+	}
+
+	return len(tasks), nil
+}
+
+// GetOverduePreferenceUseCase handles reading whether the overdue job flags a user's tasks
+type GetOverduePreferenceUseCase struct {
+	userRepo repository.UserRepository
+}
+
+// NewGetOverduePreferenceUseCase creates a new GetOverduePreferenceUseCase
+func NewGetOverduePreferenceUseCase(userRepo repository.UserRepository) *GetOverduePreferenceUseCase {
+	return &GetOverduePreferenceUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute reports whether the late tasks of a user are flagged as overdue
+func (uc *GetOverduePreferenceUseCase) Execute(ctx context.Context, userID string) (bool, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return !user.OverdueOptOut, nil
+}
+
+// UpdateOverduePreferenceUseCase handles turning the overdue flagging on or off for a user
+type UpdateOverduePreferenceUseCase struct {
+	userRepo repository.UserRepository
+}
+
+// NewUpdateOverduePreferenceUseCase creates a new UpdateOverduePreferenceUseCase
+func NewUpdateOverduePreferenceUseCase(userRepo repository.UserRepository) *UpdateOverduePreferenceUseCase {
+	return &UpdateOverduePreferenceUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute persists whether the late tasks of a user are flagged as overdue. Turning it off
+// keeps the tasks already flagged.
+func (uc *UpdateOverduePreferenceUseCase) Execute(ctx context.Context, userID string, enabled bool) error {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	user.OverdueOptOut = !enabled
+	return uc.userRepo.Update(ctx, user)
+}
@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+var (
+	// ErrReportForbidden is returned when the requester may not see reports of a unit
+	ErrReportForbidden = errors.New("user is not allowed to view reports of this unit")
+	// ErrUnitRequired is returned when no unit is given and the requester has none
+	ErrUnitRequired = errors.New("unit is required")
+)
+
+// OverdueReportUseCase builds the overdue tasks report of a unit for its managers
+type OverdueReportUseCase struct {
+	userRepo      repository.UserRepository
+	reportRepo    repository.ReportRepository
+	includeTitles bool
+	now           func() time.Time
+}
+
+// NewOverdueReportUseCase creates a new OverdueReportUseCase. Task descriptions are never
+// part of the report; includeTitles controls whether task titles are shown to managers.
+func NewOverdueReportUseCase(userRepo repository.UserRepository, reportRepo repository.ReportRepository, includeTitles bool) *OverdueReportUseCase {
+	return &OverdueReportUseCase{
+		userRepo:      userRepo,
+		reportRepo:    reportRepo,
+		includeTitles: includeTitles,
+		now:           time.Now,
+	}
+}
+
+// Execute returns the overdue tasks of a unit grouped by user. An empty unit means the requester's own unit.
+func (uc *OverdueReportUseCase) Execute(ctx context.Context, requesterID, unit string) (_ *application.OverdueReport, err error) {
+	ctx, end := tracer.Start(ctx, "OverdueReport")
+	defer func() { end(err) }()
+
+	requester, err := uc.userRepo.FindByID(ctx, requesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if unit == "" {
+		unit = requester.Unit
+	}
+	if unit == "" {
+		return nil, ErrUnitRequired
+	}
+	if !requester.CanViewUnitReports(unit) {
+		return nil, ErrReportForbidden
+	}
+
+	now := uc.now()
+	tasks, err := uc.reportRepo.FindOverdueByUnit(ctx, unit, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve overdue tasks: %w", err)
+	}
+
+	if !uc.includeTitles {
+		for _, task := range tasks {
+			task.Title = ""
+		}
+	}
+
+	return application.NewOverdueReport(unit, tasks, now), nil
+}
@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// OverdueReportCSV renders an overdue report as CSV, one row per overdue task
+func OverdueReportCSV(report *application.OverdueReport) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	header := []string{"unidade", "usuario", "email", "total_atrasadas", "tarefa_id", "titulo", "status", "prazo", "dias_atraso"}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, user := range report.Users {
+		for _, task := range user.Tasks {
+			row := []string{
+				report.Unit,
+				csvSafe(user.Name),
+				csvSafe(user.Email),
+				strconv.Itoa(user.OverdueCount),
+				task.TaskID,
+				csvSafe(task.Title),
+				string(task.Status),
+				task.DueDate.Format("02/01/2006 15:04"),
+				strconv.Itoa(task.DaysOverdue(report.GeneratedAt)),
+			}
+			if err := writer.Write(row); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// csvSafe prevents spreadsheet formula injection from user-provided values
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsAny(value[:1], "=+-@\t\r") {
+		return "'" + value
+	}
+	return value
+}
+
+// OverdueReportPDF renders an overdue report as PDF
+func OverdueReportPDF(report *application.OverdueReport) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.AddPage()
+
+	// Set title
+	pdf.SetFont("Arial", "B", 20)
+	pdf.CellFormat(190, 10, tr(fmt.Sprintf("Tarefas atrasadas - %s", report.Unit)), "", 1, "C", false, 0, "")
+	pdf.Ln(3)
+
+	pdf.SetFont("Arial", "I", 10)
+	pdf.CellFormat(190, 6, tr(fmt.Sprintf("Gerado em: %s", report.GeneratedAt.Format("02/01/2006 15:04:05"))), "", 1, "C", false, 0, "")
+	pdf.CellFormat(190, 6, tr(fmt.Sprintf("Total de tarefas atrasadas: %d", report.TotalOverdue())), "", 1, "C", false, 0, "")
+	pdf.Ln(8)
+
+	if len(report.Users) == 0 {
+		pdf.SetFont("Arial", "", 12)
+		pdf.CellFormat(190, 10, tr("Nenhuma tarefa atrasada."), "", 1, "L", false, 0, "")
+	}
+
+	for _, user := range report.Users {
+		// User header
+		pdf.SetFont("Arial", "B", 13)
+		pdf.CellFormat(190, 8, tr(fmt.Sprintf("%s <%s> - %d atrasada(s)", user.Name, user.Email, user.OverdueCount)), "", 1, "L", false, 0, "")
+
+		pdf.SetFont("Arial", "", 10)
+		for _, task := range user.Tasks {
+			label := task.Title
+			if label == "" {
+				label = task.TaskID
+			}
+			line := fmt.Sprintf("- %s | prazo %s | %d dia(s) de atraso", label, task.DueDate.Format("02/01/2006 15:04"), task.DaysOverdue(report.GeneratedAt))
+			pdf.MultiCell(190, 5, tr(line), "", "L", false)
+		}
+		pdf.Ln(4)
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,177 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// mockUserRepositoryForReport is a mock implementation of UserRepository
+type mockUserRepositoryForReport struct {
+	users map[string]*application.User
+}
+
+func (m *mockUserRepositoryForReport) Create(ctx context.Context, user *application.User) error {
+	return nil
+}
+
+func (m *mockUserRepositoryForReport) FindByID(ctx context.Context, id string) (*application.User, error) {
+	if user, ok := m.users[id]; ok {
+		return user, nil
+	}
+	return nil, application.ErrUserNotFound
+}
+
+func (m *mockUserRepositoryForReport) FindByEmail(ctx context.Context, email string) (*application.User, error) {
+	return nil, application.ErrUserNotFound
+}
+
+func (m *mockUserRepositoryForReport) Update(ctx context.Context, user *application.User) error {
+	return nil
+}
+
+func (m *mockUserRepositoryForReport) Delete(ctx context.Context, id string) error {
+	return nil
+}
+
+func (m *mockUserRepositoryForReport) RevokeSessions(ctx context.Context, id string) error {
+	return nil
+}
+
+func (m *mockUserRepositoryForReport) Search(ctx context.Context, query, excludeID string, limit int) ([]*application.User, error) {
+	return nil, nil
+}
+
+func (m *mockUserRepositoryForReport) FindByIDs(ctx context.Context, ids []string) ([]*application.User, error) {
+	return nil, nil
+}
+
+// mockReportRepository is a mock implementation of ReportRepository
+type mockReportRepository struct {
+	tasks     []*application.OverdueTask
+	err       error
+	gotUnit   string
+	queryUsed bool
+}
+
+func (m *mockReportRepository) FindOverdueByUnit(ctx context.Context, unit string, now time.Time) ([]*application.OverdueTask, error) {
+	m.gotUnit = unit
+	m.queryUsed = true
+	return m.tasks, m.err
+}
+
+func TestOverdueReportUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name        string
+		requesterID string
+		unit        string
+		wantErr     error
+		wantUnit    string
+	}{
+		{name: "manager defaults to own unit", requesterID: "manager", wantUnit: "fiscal"},
+		{name: "manager of the unit", requesterID: "manager", unit: "fiscal", wantUnit: "fiscal"},
+		{name: "manager of another unit", requesterID: "manager", unit: "juridico", wantErr: ErrReportForbidden},
+		{name: "admin any unit", requesterID: "admin", unit: "juridico", wantUnit: "juridico"},
+		{name: "admin without unit", requesterID: "admin", wantErr: ErrUnitRequired},
+		{name: "member is forbidden", requesterID: "member", wantErr: ErrReportForbidden},
+		{name: "unknown requester", requesterID: "ghost", wantErr: application.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &mockUserRepositoryForReport{users: map[string]*application.User{
+				"manager": {ID: "manager", Role: application.RoleManager, Unit: "fiscal"},
+				"admin":   {ID: "admin", Role: application.RoleAdmin},
+				"member":  {ID: "member", Role: application.RoleMember, Unit: "fiscal"},
+			}}
+			reports := &mockReportRepository{tasks: []*application.OverdueTask{
+				{TaskID: "t1", Title: "Relatório trimestral", OwnerID: "member", OwnerName: "Membro", DueDate: time.Now().Add(-48 * time.Hour)},
+			}}
+			useCase := NewOverdueReportUseCase(users, reports, true)
+
+			report, err := useCase.Execute(context.Background(), tt.requesterID, tt.unit)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				if reports.queryUsed {
+					t.Error("repository should not be queried when access is denied")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if reports.gotUnit != tt.wantUnit || report.Unit != tt.wantUnit {
+				t.Errorf("expected unit %q, got query %q report %q", tt.wantUnit, reports.gotUnit, report.Unit)
+			}
+			if report.TotalOverdue() != 1 {
+				t.Errorf("expected 1 overdue task, got %d", report.TotalOverdue())
+			}
+		})
+	}
+}
+
+func TestOverdueReportUseCase_HidesTitlesByDefault(t *testing.T) {
+	users := &mockUserRepositoryForReport{users: map[string]*application.User{
+		"manager": {ID: "manager", Role: application.RoleManager, Unit: "fiscal"},
+	}}
+	reports := &mockReportRepository{tasks: []*application.OverdueTask{
+		{TaskID: "t1", Title: "Relatório trimestral", OwnerID: "member", OwnerName: "Membro", DueDate: time.Now().Add(-48 * time.Hour)},
+	}}
+	useCase := NewOverdueReportUseCase(users, reports, false)
+
+	report, err := useCase.Execute(context.Background(), "manager", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if title := report.Users[0].Tasks[0].Title; title != "" {
+		t.Errorf("expected title to be hidden, got %q", title)
+	}
+}
+
+func TestOverdueReportCSV(t *testing.T) {
+	now := time.Date(2025, 6, 11, 12, 0, 0, 0, time.UTC)
+	report := application.NewOverdueReport("fiscal", []*application.OverdueTask{
+		{TaskID: "t1", Title: "=HYPERLINK(\"x\")", Status: application.StatusPending, OwnerID: "u1", OwnerName: "Ana", OwnerEmail: "ana@example.com", DueDate: now.Add(-72 * time.Hour)},
+	}, now)
+
+	data, err := OverdueReportCSV(report)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected header and 1 row, got %d lines", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "unidade,usuario,email") {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "ana@example.com") || !strings.HasSuffix(lines[1], ",3") {
+		t.Errorf("unexpected row %q", lines[1])
+	}
+	if strings.Contains(lines[1], ",=HYPERLINK") || strings.Contains(lines[1], ",\"=HYPERLINK") {
+		t.Errorf("formula should be neutralized, got %q", lines[1])
+	}
+}
+
+func TestOverdueReportPDF(t *testing.T) {
+	report := application.NewOverdueReport("fiscal", []*application.OverdueTask{
+		{TaskID: "t1", OwnerID: "u1", OwnerName: "Ana", DueDate: time.Now().Add(-time.Hour)},
+	}, time.Now())
+
+	data, err := OverdueReportPDF(report)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "%PDF") {
+		t.Error("expected PDF output")
+	}
+}
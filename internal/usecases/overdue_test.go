@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+)
+
+type mockOverdueRepository struct {
+	tasks []*application.Task
+	err   error
+	limit int
+}
+
+func (m *mockOverdueRepository) FlagOverdue(ctx context.Context, now time.Time, limit int) ([]*application.Task, error) {
+	m.limit = limit
+	return m.tasks, m.err
+}
+
+func TestFlagOverdueTasksUseCase_Execute(t *testing.T) {
+	due := time.Now().Add(-time.Hour)
+	task, _ := application.NewTask("task-1", "Relatório mensal", "", application.StatusPending, "user-1", "")
+	task.SetDueDate(&due)
+
+	repo := &mockOverdueRepository{tasks: []*application.Task{task}}
+	publisher := &recordingPublisher{}
+
+	flagged, err := NewFlagOverdueTasksUseCase(repo, publisher, 50).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if flagged != 1 || repo.limit != 50 {
+		t.Errorf("flagged %d tasks with limit %d, want 1 with limit 50", flagged, repo.limit)
+	}
+	if len(publisher.events) != 1 {
+		t.Fatalf("expected one event, got %v", publisher.names())
+	}
+	overdue := publisher.events[0].(event.TaskOverdue)
+	if overdue.TaskID != "task-1" || overdue.OwnerID != "user-1" || overdue.ActorID != "" || !overdue.DueDate.Equal(due) {
+		t.Errorf("unexpected event %+v", overdue)
+	}
+}
+
+func TestFlagOverdueTasksUseCase_RepositoryError(t *testing.T) {
+	publisher := &recordingPublisher{}
+	repo := &mockOverdueRepository{err: errors.New("database is locked")}
+
+	if _, err := NewFlagOverdueTasksUseCase(repo, publisher, 50).Execute(context.Background()); err == nil {
+		t.Fatal("expected the repository error")
+	}
+	if len(publisher.events) != 0 {
+		t.Errorf("expected no events, got %v", publisher.names())
+	}
+}
+
+func TestOverduePreferenceUseCases(t *testing.T) {
+	repo := newMockUserRepositoryForTheme(&application.User{ID: "user-1"})
+	get := NewGetOverduePreferenceUseCase(repo)
+	update := NewUpdateOverduePreferenceUseCase(repo)
+	ctx := context.Background()
+
+	if enabled, err := get.Execute(ctx, "user-1"); err != nil || !enabled {
+		t.Fatalf("Get() = %v, %v; want enabled by default", enabled, err)
+	}
+
+	if err := update.Execute(ctx, "user-1", false); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	if enabled, _ := get.Execute(ctx, "user-1"); enabled || !repo.users["user-1"].OverdueOptOut {
+		t.Error("expected the user to opt out")
+	}
+
+	if _, err := get.Execute(ctx, "missing"); !errors.Is(err, application.ErrUserNotFound) {
+		t.Errorf("Get() error = %v, want ErrUserNotFound", err)
+	}
+	if err := update.Execute(ctx, "missing", true); !errors.Is(err, application.ErrUserNotFound) {
+		t.Errorf("Update() error = %v, want ErrUserNotFound", err)
+	}
+}
@@ -0,0 +1,46 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// GetOwnerNamesUseCase handles looking up the display names of the owners of tasks, so the
+// users a task is shared with see who shared it instead of an ID
+type GetOwnerNamesUseCase struct {
+	userRepo repository.UserRepository
+}
+
+// NewGetOwnerNamesUseCase creates a new GetOwnerNamesUseCase
+func NewGetOwnerNamesUseCase(userRepo repository.UserRepository) *GetOwnerNamesUseCase {
+	return &GetOwnerNamesUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute returns the names of the owners of tasks by owner ID, looking each owner up once.
+// Owners that no longer exist are left out.
+func (uc *GetOwnerNamesUseCase) Execute(ctx context.Context, tasks []*application.Task) (map[string]string, error) {
+	names := make(map[string]string)
+	seen := make(map[string]bool)
+	for _, task := range tasks {
+		if seen[task.OwnerID] {
+			continue
+		}
+		seen[task.OwnerID] = true
+
+		user, err := uc.userRepo.FindByID(ctx, task.OwnerID)
+		if errors.Is(err, application.ErrUserNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		names[task.OwnerID] = user.Name
+	}
+
+	return names, nil
+}
@@ -0,0 +1,46 @@
+package usecases
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// countingUserRepository counts the lookups of a mockUserRepositoryForTheme
+type countingUserRepository struct {
+	*mockUserRepositoryForTheme
+	lookups int
+}
+
+func (m *countingUserRepository) FindByID(ctx context.Context, id string) (*application.User, error) {
+	m.lookups++
+	return m.mockUserRepositoryForTheme.FindByID(ctx, id)
+}
+
+func TestGetOwnerNamesUseCase_Execute(t *testing.T) {
+	repo := &countingUserRepository{mockUserRepositoryForTheme: newMockUserRepositoryForTheme(
+		&application.User{ID: "user-1", Name: "Ana"},
+		&application.User{ID: "user-2", Name: "Bia"},
+	)}
+	uc := NewGetOwnerNamesUseCase(repo)
+
+	names, err := uc.Execute(context.Background(), []*application.Task{
+		{ID: "task-1", OwnerID: "user-1"},
+		{ID: "task-2", OwnerID: "user-2"},
+		{ID: "task-3", OwnerID: "user-1"},
+		{ID: "task-4", OwnerID: "deleted-user"},
+	})
+	if err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+
+	want := map[string]string{"user-1": "Ana", "user-2": "Bia"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Execute() = %v, want %v", names, want)
+	}
+	if repo.lookups != 3 {
+		t.Errorf("Expected one lookup per owner (3), got %d", repo.lookups)
+	}
+}
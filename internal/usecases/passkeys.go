@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// PasskeyLoginUseCase handles issuing a session for a user authenticated with a passkey
+type PasskeyLoginUseCase struct {
+	userRepo    repository.UserRepository
+	authService *service.AuthService
+	durations   SessionDurations
+}
+
+// NewPasskeyLoginUseCase creates a new PasskeyLoginUseCase. Sessions last as long as the
+// ones issued by LoginUseCase.
+func NewPasskeyLoginUseCase(userRepo repository.UserRepository, jwtKeys *service.JWTKeySet, durations SessionDurations) *PasskeyLoginUseCase {
+	return &PasskeyLoginUseCase{
+		userRepo:    userRepo,
+		authService: service.NewAuthServiceWithKeys(jwtKeys, nil),
+		durations:   durations.withDefaults(),
+	}
+}
+
+// Execute returns a JWT token for a user whose passkey assertion was already verified.
+// rememberMe issues a longer-lived token.
+func (uc *PasskeyLoginUseCase) Execute(ctx context.Context, userID string, rememberMe bool) (*LoginResult, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return issueSession(uc.authService, uc.durations, user, rememberMe)
+}
+
+// ListCredentialsUseCase handles listing the passkeys of a user
+type ListCredentialsUseCase struct {
+	credentialRepo repository.CredentialRepository
+}
+
+// NewListCredentialsUseCase creates a new ListCredentialsUseCase
+func NewListCredentialsUseCase(credentialRepo repository.CredentialRepository) *ListCredentialsUseCase {
+	return &ListCredentialsUseCase{
+		credentialRepo: credentialRepo,
+	}
+}
+
+// Execute lists the passkeys registered by a user, oldest first
+func (uc *ListCredentialsUseCase) Execute(ctx context.Context, userID string) ([]*application.Credential, error) {
+	return uc.credentialRepo.FindByUserID(ctx, userID)
+}
+
+// DeleteCredentialUseCase handles removing a passkey
+type DeleteCredentialUseCase struct {
+	credentialRepo repository.CredentialRepository
+}
+
+// NewDeleteCredentialUseCase creates a new DeleteCredentialUseCase
+func NewDeleteCredentialUseCase(credentialRepo repository.CredentialRepository) *DeleteCredentialUseCase {
+	return &DeleteCredentialUseCase{
+		credentialRepo: credentialRepo,
+	}
+}
+
+// Execute removes a passkey of the user. Passkeys of other users are reported as not found,
+// so their IDs can't be probed. The password keeps working, so removing the last passkey
+// never locks the user out.
+func (uc *DeleteCredentialUseCase) Execute(ctx context.Context, credentialID, userID string) error {
+	credential, err := uc.credentialRepo.FindByID(ctx, credentialID)
+	if err != nil {
+		return err
+	}
+	if credential.UserID != userID {
+		return application.ErrCredentialNotFound
+	}
+
+	return uc.credentialRepo.Delete(ctx, credentialID)
+}
@@ -0,0 +1,120 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// Mock CredentialRepository for testing
+type mockCredentialRepository struct {
+	credentials map[string]*application.Credential
+}
+
+func (m *mockCredentialRepository) Create(ctx context.Context, credential *application.Credential) error {
+	m.credentials[credential.ID] = credential
+	return nil
+}
+
+func (m *mockCredentialRepository) FindByID(ctx context.Context, id string) (*application.Credential, error) {
+	if credential, ok := m.credentials[id]; ok {
+		return credential, nil
+	}
+	return nil, application.ErrCredentialNotFound
+}
+
+func (m *mockCredentialRepository) FindByUserID(ctx context.Context, userID string) ([]*application.Credential, error) {
+	var credentials []*application.Credential
+	for _, credential := range m.credentials {
+		if credential.UserID == userID {
+			credentials = append(credentials, credential)
+		}
+	}
+	return credentials, nil
+}
+
+func (m *mockCredentialRepository) UpdateUsage(ctx context.Context, credential *application.Credential) error {
+	m.credentials[credential.ID] = credential
+	return nil
+}
+
+func (m *mockCredentialRepository) Delete(ctx context.Context, id string) error {
+	delete(m.credentials, id)
+	return nil
+}
+
+func TestPasskeyLoginUseCase_Execute(t *testing.T) {
+	repo := &mockUserRepositoryForLogin{users: map[string]*application.User{
+		"user-1": {ID: "user-1", Email: "ana@example.com", Locale: application.LocaleEN},
+	}}
+	uc := NewPasskeyLoginUseCase(repo, service.NewJWTKeySet("test-secret-key"), SessionDurations{Default: time.Hour})
+
+	tests := []struct {
+		name         string
+		rememberMe   bool
+		wantDuration time.Duration
+	}{
+		{name: "regular session", wantDuration: time.Hour},
+		{name: "remember me", rememberMe: true, wantDuration: DefaultRememberMeDuration},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session, err := uc.Execute(context.Background(), "user-1", tt.rememberMe)
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+			if session.Duration != tt.wantDuration || session.Locale != application.LocaleEN {
+				t.Errorf("Execute() = %+v, want duration %v and the user's locale", session, tt.wantDuration)
+			}
+
+			claims, err := service.NewAuthService("test-secret-key").ValidateToken(session.Token)
+			if err != nil {
+				t.Fatalf("ValidateToken() error: %v", err)
+			}
+			if claims.UserID != "user-1" || claims.Email != "ana@example.com" {
+				t.Errorf("token claims = %+v", claims)
+			}
+		})
+	}
+
+	if _, err := uc.Execute(context.Background(), "missing", false); err == nil {
+		t.Error("Execute() for a missing user expected error but got nil")
+	}
+}
+
+func TestDeleteCredentialUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name         string
+		credentialID string
+		userID       string
+		wantErr      error
+	}{
+		{name: "owner removes passkey", credentialID: "cred-1", userID: "user-1"},
+		{name: "passkey of another user", credentialID: "cred-1", userID: "user-2", wantErr: application.ErrCredentialNotFound},
+		{name: "missing passkey", credentialID: "missing", userID: "user-1", wantErr: application.ErrCredentialNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockCredentialRepository{credentials: map[string]*application.Credential{
+				"cred-1": {ID: "cred-1", UserID: "user-1"},
+			}}
+			uc := NewDeleteCredentialUseCase(repo)
+
+			err := uc.Execute(context.Background(), tt.credentialID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+
+			_, kept := repo.credentials["cred-1"]
+			if kept != (tt.wantErr != nil) {
+				t.Errorf("Execute() kept passkey = %v, want %v", kept, tt.wantErr != nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// ProjectList holds the projects a user owns and the ones shared with them
+type ProjectList struct {
+	Owned  []*application.Project
+	Shared []*application.Project
+}
+
+// findUserProject finds a project the user can see: their own or one shared with them. When
+// modify is set only the owner is accepted. Projects the user can't see are reported as not
+// found, so project routes don't reveal which IDs exist.
+func findUserProject(ctx context.Context, projectRepo repository.ProjectRepository, projectID, userID string, modify bool) (*application.Project, error) {
+	project, err := projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.OwnerID == userID {
+		return project, nil
+	}
+
+	shared, err := projectRepo.IsSharedWith(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !shared {
+		return nil, application.ErrProjectNotFound
+	}
+	if modify {
+		return nil, application.ErrProjectPermissionDenied
+	}
+	return project, nil
+}
+
+// checkProjectOwner checks that a task of ownerID can be put in a project: tasks only go into
+// projects of their owner. An empty projectID, meaning no project, is always accepted.
+func checkProjectOwner(ctx context.Context, projectRepo repository.ProjectRepository, projectID, ownerID string) error {
+	if projectID == "" {
+		return nil
+	}
+
+	project, err := projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if project.OwnerID != ownerID {
+		return application.ErrProjectNotFound
+	}
+	return nil
+}
+
+// CreateProjectUseCase handles project creation
+type CreateProjectUseCase struct {
+	projectRepo repository.ProjectRepository
+}
+
+// NewCreateProjectUseCase creates a new CreateProjectUseCase
+func NewCreateProjectUseCase(projectRepo repository.ProjectRepository) *CreateProjectUseCase {
+	return &CreateProjectUseCase{
+		projectRepo: projectRepo,
+	}
+}
+
+// Execute creates a new project in the workspace of ctx; an empty color uses the default one
+func (uc *CreateProjectUseCase) Execute(ctx context.Context, ownerID, name, color string) (*application.Project, error) {
+	project, err := application.NewProject(uuid.New().String(), name, color, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	project.OrgID, _ = repository.OrgScope(ctx)
+
+	if err := uc.projectRepo.Create(ctx, project); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// ListProjectsUseCase handles listing the projects of a user
+type ListProjectsUseCase struct {
+	projectRepo repository.ProjectRepository
+}
+
+// NewListProjectsUseCase creates a new ListProjectsUseCase
+func NewListProjectsUseCase(projectRepo repository.ProjectRepository) *ListProjectsUseCase {
+	return &ListProjectsUseCase{
+		projectRepo: projectRepo,
+	}
+}
+
+// Execute lists the projects a user owns and the ones shared with them
+func (uc *ListProjectsUseCase) Execute(ctx context.Context, userID string) (*ProjectList, error) {
+	owned, err := uc.projectRepo.FindByOwnerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := uc.projectRepo.FindSharedWithUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProjectList{Owned: owned, Shared: shared}, nil
+}
+
+// GetProjectUseCase handles retrieving a single project
+type GetProjectUseCase struct {
+	projectRepo repository.ProjectRepository
+}
+
+// NewGetProjectUseCase creates a new GetProjectUseCase
+func NewGetProjectUseCase(projectRepo repository.ProjectRepository) *GetProjectUseCase {
+	return &GetProjectUseCase{
+		projectRepo: projectRepo,
+	}
+}
+
+// Execute retrieves a project owned by or shared with the user
+func (uc *GetProjectUseCase) Execute(ctx context.Context, projectID, userID string) (*application.Project, error) {
+	return findUserProject(ctx, uc.projectRepo, projectID, userID, false)
+}
+
+// UpdateProjectUseCase handles renaming and recoloring projects
+type UpdateProjectUseCase struct {
+	projectRepo repository.ProjectRepository
+}
+
+// NewUpdateProjectUseCase creates a new UpdateProjectUseCase
+func NewUpdateProjectUseCase(projectRepo repository.ProjectRepository) *UpdateProjectUseCase {
+	return &UpdateProjectUseCase{
+		projectRepo: projectRepo,
+	}
+}
+
+// Execute updates the name and color of a project of the user
+func (uc *UpdateProjectUseCase) Execute(ctx context.Context, projectID, userID, name, color string) (*application.Project, error) {
+	project, err := findUserProject(ctx, uc.projectRepo, projectID, userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := project.Update(name, color); err != nil {
+		return nil, err
+	}
+
+	if err := uc.projectRepo.Update(ctx, project); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// DeleteProjectUseCase handles project deletion
+type DeleteProjectUseCase struct {
+	projectRepo repository.ProjectRepository
+}
+
+// NewDeleteProjectUseCase creates a new DeleteProjectUseCase
+func NewDeleteProjectUseCase(projectRepo repository.ProjectRepository) *DeleteProjectUseCase {
+	return &DeleteProjectUseCase{
+		projectRepo: projectRepo,
+	}
+}
+
+// Execute deletes a project of the user. Its tasks are kept, without a project.
+func (uc *DeleteProjectUseCase) Execute(ctx context.Context, projectID, userID string) error {
+	if _, err := findUserProject(ctx, uc.projectRepo, projectID, userID, true); err != nil {
+		return err
+	}
+
+	return uc.projectRepo.Delete(ctx, projectID)
+}
+
+// ListProjectTasksUseCase handles listing the tasks of a project
+type ListProjectTasksUseCase struct {
+	projectRepo repository.ProjectRepository
+	taskRepo    repository.TaskRepository
+}
+
+// NewListProjectTasksUseCase creates a new ListProjectTasksUseCase
+func NewListProjectTasksUseCase(projectRepo repository.ProjectRepository, taskRepo repository.TaskRepository) *ListProjectTasksUseCase {
+	return &ListProjectTasksUseCase{
+		projectRepo: projectRepo,
+		taskRepo:    taskRepo,
+	}
+}
+
+// Execute lists the tasks of a project owned by or shared with the user
+func (uc *ListProjectTasksUseCase) Execute(ctx context.Context, projectID, userID string) ([]*application.Task, error) {
+	if _, err := findUserProject(ctx, uc.projectRepo, projectID, userID, false); err != nil {
+		return nil, err
+	}
+
+	return uc.taskRepo.FindByProjectID(ctx, projectID)
+}
+
+// ShareProjectUseCase handles sharing a project, and so all its tasks, with another user
+type ShareProjectUseCase struct {
+	projectRepo repository.ProjectRepository
+	userRepo    repository.UserRepository
+}
+
+// NewShareProjectUseCase creates a new ShareProjectUseCase
+func NewShareProjectUseCase(projectRepo repository.ProjectRepository, userRepo repository.UserRepository) *ShareProjectUseCase {
+	return &ShareProjectUseCase{
+		projectRepo: projectRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// Execute shares a project of the owner with a user. Sharing it again is a no-op.
+func (uc *ShareProjectUseCase) Execute(ctx context.Context, projectID, ownerID, shareWithUserID string) error {
+	if _, err := findUserProject(ctx, uc.projectRepo, projectID, ownerID, true); err != nil {
+		return err
+	}
+	if shareWithUserID == ownerID {
+		return application.ErrCannotShareProjectToSelf
+	}
+
+	if _, err := uc.userRepo.FindByID(ctx, shareWithUserID); err != nil {
+		return err
+	}
+
+	shared, err := uc.projectRepo.IsSharedWith(ctx, projectID, shareWithUserID)
+	if err != nil || shared {
+		return err
+	}
+
+	return uc.projectRepo.Share(ctx, projectID, shareWithUserID)
+}
+
+// UnshareProjectUseCase handles removing the access of a user to a project
+type UnshareProjectUseCase struct {
+	projectRepo repository.ProjectRepository
+}
+
+// NewUnshareProjectUseCase creates a new UnshareProjectUseCase
+func NewUnshareProjectUseCase(projectRepo repository.ProjectRepository) *UnshareProjectUseCase {
+	return &UnshareProjectUseCase{
+		projectRepo: projectRepo,
+	}
+}
+
+// Execute stops sharing a project of the owner with a user
+func (uc *UnshareProjectUseCase) Execute(ctx context.Context, projectID, ownerID, userID string) error {
+	if _, err := findUserProject(ctx, uc.projectRepo, projectID, ownerID, true); err != nil {
+		return err
+	}
+
+	return uc.projectRepo.Unshare(ctx, projectID, userID)
+}
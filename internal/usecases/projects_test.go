@@ -0,0 +1,296 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+type mockProjectRepository struct {
+	projects map[string]*application.Project
+	shares   map[string][]string // project ID -> user IDs
+}
+
+func newMockProjectRepository(projects ...*application.Project) *mockProjectRepository {
+	repo := &mockProjectRepository{projects: map[string]*application.Project{}, shares: map[string][]string{}}
+	for _, project := range projects {
+		repo.projects[project.ID] = project
+	}
+	return repo
+}
+
+func (m *mockProjectRepository) Create(ctx context.Context, project *application.Project) error {
+	m.projects[project.ID] = project
+	return nil
+}
+
+func (m *mockProjectRepository) Update(ctx context.Context, project *application.Project) error {
+	m.projects[project.ID] = project
+	return nil
+}
+
+func (m *mockProjectRepository) Delete(ctx context.Context, id string) error {
+	delete(m.projects, id)
+	delete(m.shares, id)
+	return nil
+}
+
+func (m *mockProjectRepository) FindByID(ctx context.Context, id string) (*application.Project, error) {
+	if project, ok := m.projects[id]; ok {
+		return project, nil
+	}
+	return nil, application.ErrProjectNotFound
+}
+
+func (m *mockProjectRepository) FindByOwnerID(ctx context.Context, ownerID string) ([]*application.Project, error) {
+	var projects []*application.Project
+	for _, project := range m.projects {
+		if project.OwnerID == ownerID {
+			projects = append(projects, project)
+		}
+	}
+	return projects, nil
+}
+
+func (m *mockProjectRepository) FindSharedWithUser(ctx context.Context, userID string) ([]*application.Project, error) {
+	var projects []*application.Project
+	for projectID, userIDs := range m.shares {
+		for _, id := range userIDs {
+			if id == userID {
+				projects = append(projects, m.projects[projectID])
+			}
+		}
+	}
+	return projects, nil
+}
+
+func (m *mockProjectRepository) Share(ctx context.Context, projectID, userID string) error {
+	m.shares[projectID] = append(m.shares[projectID], userID)
+	return nil
+}
+
+func (m *mockProjectRepository) Unshare(ctx context.Context, projectID, userID string) error {
+	var kept []string
+	for _, id := range m.shares[projectID] {
+		if id != userID {
+			kept = append(kept, id)
+		}
+	}
+	m.shares[projectID] = kept
+	return nil
+}
+
+func (m *mockProjectRepository) IsSharedWith(ctx context.Context, projectID, userID string) (bool, error) {
+	for _, id := range m.shares[projectID] {
+		if id == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestCreateProjectUseCase(t *testing.T) {
+	repo := newMockProjectRepository()
+
+	project, err := NewCreateProjectUseCase(repo).Execute(context.Background(), "user-1", "Casa", "")
+	if err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if project.OwnerID != "user-1" || project.Color != application.DefaultProjectColor || repo.projects[project.ID] == nil {
+		t.Errorf("Execute() = %+v, want a stored project of user-1 with the default color", project)
+	}
+
+	if _, err := NewCreateProjectUseCase(repo).Execute(context.Background(), "user-1", "Casa", "blue"); !errors.Is(err, application.ErrInvalidProjectColor) {
+		t.Errorf("Execute() with an invalid color error = %v, want ErrInvalidProjectColor", err)
+	}
+
+	// Like tasks, projects go in the workspace the request is scoped to
+	project, err = NewCreateProjectUseCase(repo).Execute(repository.WithOrgScope(context.Background(), "org-1"), "user-1", "Receita", "")
+	if err != nil || project.OrgID != "org-1" {
+		t.Errorf("Execute() in an org = %+v, %v; want a project of org-1", project, err)
+	}
+}
+
+func TestGetProjectUseCase(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		userID  string
+		wantErr error
+	}{
+		{"owner", "proj-1", "user-1", nil},
+		{"shared with user", "proj-1", "user-2", nil},
+		{"not shared", "proj-1", "user-3", application.ErrProjectNotFound},
+		{"missing project", "proj-9", "user-1", application.ErrProjectNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockProjectRepository(&application.Project{ID: "proj-1", Name: "Trabalho", Color: "#3b82f6", OwnerID: "user-1"})
+			repo.shares["proj-1"] = []string{"user-2"}
+			project, err := NewGetProjectUseCase(repo).Execute(context.Background(), tt.id, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && project.ID != tt.id {
+				t.Errorf("Execute() = %+v", project)
+			}
+		})
+	}
+}
+
+func TestUpdateAndDeleteProjectUseCases_OnlyOwner(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		wantErr error
+	}{
+		{"owner", "user-1", nil},
+		{"shared with user", "user-2", application.ErrProjectPermissionDenied},
+		{"stranger", "user-3", application.ErrProjectNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockProjectRepository(&application.Project{ID: "proj-1", Name: "Trabalho", Color: "#3b82f6", OwnerID: "user-1"})
+			repo.shares["proj-1"] = []string{"user-2"}
+			project, err := NewUpdateProjectUseCase(repo).Execute(context.Background(), "proj-1", tt.userID, "Pessoal", "#ef4444")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Update Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (project.Name != "Pessoal" || repo.projects["proj-1"].Color != "#ef4444") {
+				t.Errorf("Update Execute() = %+v, want the renamed project", project)
+			}
+
+			err = NewDeleteProjectUseCase(repo).Execute(context.Background(), "proj-1", tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Delete Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if deleted := repo.projects["proj-1"] == nil; deleted != (tt.wantErr == nil) {
+				t.Errorf("project deleted = %v", deleted)
+			}
+		})
+	}
+}
+
+func TestListProjectsUseCase(t *testing.T) {
+	repo := newMockProjectRepository(&application.Project{ID: "proj-1", Name: "Trabalho", Color: "#3b82f6", OwnerID: "user-1"})
+	repo.shares["proj-1"] = []string{"user-2"}
+
+	list, err := NewListProjectsUseCase(repo).Execute(context.Background(), "user-2")
+	if err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if len(list.Owned) != 0 || len(list.Shared) != 1 || list.Shared[0].ID != "proj-1" {
+		t.Errorf("Execute() = %+v, want proj-1 as shared", list)
+	}
+}
+
+func TestShareProjectUseCase(t *testing.T) {
+	users := &mockUserRepositoryForLogin{users: map[string]*application.User{
+		"user-1": {ID: "user-1"},
+		"user-2": {ID: "user-2"},
+		"user-3": {ID: "user-3"},
+	}}
+
+	tests := []struct {
+		name      string
+		ownerID   string
+		shareWith string
+		wantErr   error
+	}{
+		{"owner shares", "user-1", "user-3", nil},
+		{"sharing again is a no-op", "user-1", "user-2", nil},
+		{"with self", "user-1", "user-1", application.ErrCannotShareProjectToSelf},
+		{"unknown user", "user-1", "user-9", application.ErrUserNotFound},
+		{"shared user can't reshare", "user-2", "user-3", application.ErrProjectPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockProjectRepository(&application.Project{ID: "proj-1", Name: "Trabalho", Color: "#3b82f6", OwnerID: "user-1"})
+			repo.shares["proj-1"] = []string{"user-2"}
+
+			err := NewShareProjectUseCase(repo, users).Execute(context.Background(), "proj-1", tt.ownerID, tt.shareWith)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil {
+				if shared, _ := repo.IsSharedWith(context.Background(), "proj-1", tt.shareWith); !shared {
+					t.Errorf("project not shared with %s", tt.shareWith)
+				}
+				if len(repo.shares["proj-1"]) > 2 {
+					t.Errorf("shares = %v, want no duplicates", repo.shares["proj-1"])
+				}
+			}
+		})
+	}
+}
+
+func TestUnshareProjectUseCase(t *testing.T) {
+	repo := newMockProjectRepository(&application.Project{ID: "proj-1", Name: "Trabalho", Color: "#3b82f6", OwnerID: "user-1"})
+	repo.shares["proj-1"] = []string{"user-2"}
+
+	if err := NewUnshareProjectUseCase(repo).Execute(context.Background(), "proj-1", "user-2", "user-2"); !errors.Is(err, application.ErrProjectPermissionDenied) {
+		t.Errorf("Execute() by the shared user error = %v, want ErrProjectPermissionDenied", err)
+	}
+	if err := NewUnshareProjectUseCase(repo).Execute(context.Background(), "proj-1", "user-1", "user-2"); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if len(repo.shares["proj-1"]) != 0 {
+		t.Errorf("shares = %v, want none", repo.shares["proj-1"])
+	}
+}
+
+func TestCreateTaskUseCase_Project(t *testing.T) {
+	projects := newMockProjectRepository(&application.Project{ID: "proj-1", Name: "Trabalho", Color: "#3b82f6", OwnerID: "user-1"})
+	projects.shares["proj-1"] = []string{"user-2"}
+	useCase := NewCreateTaskUseCase(&mockTaskRepository{tasks: map[string]*application.Task{}}, projects, &recordingPublisher{}, &sequentialIDs{}, nil)
+
+	task, err := useCase.Execute(context.Background(), "Relatório", "", "user-1", "", nil, "proj-1", "")
+	if err != nil || task.ProjectID != "proj-1" {
+		t.Fatalf("Execute() = %+v, %v; want the task in proj-1", task, err)
+	}
+
+	// Users the project is shared with can't add their own tasks to it
+	if _, err := useCase.Execute(context.Background(), "Relatório", "", "user-2", "", nil, "proj-1", ""); !errors.Is(err, application.ErrProjectNotFound) {
+		t.Errorf("Execute() into a shared project error = %v, want ErrProjectNotFound", err)
+	}
+	if _, err := useCase.Execute(context.Background(), "Relatório", "", "user-1", "", nil, "proj-9", ""); !errors.Is(err, application.ErrProjectNotFound) {
+		t.Errorf("Execute() into a missing project error = %v, want ErrProjectNotFound", err)
+	}
+}
+
+func TestUpdateTaskUseCase_MovesBetweenProjects(t *testing.T) {
+	task, _ := application.NewTask("task-1", "Relatório mensal", "", application.StatusPending, "user-1", "")
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-2"}}}
+	taskService := service.NewTaskService(taskRepo, shareRepo)
+	projects := newMockProjectRepository(&application.Project{ID: "proj-1", Name: "Trabalho", Color: "#3b82f6", OwnerID: "user-1"})
+	projects.shares["proj-1"] = []string{"user-2"}
+	useCase := NewUpdateTaskUseCase(taskRepo, projects, &mockDependencyRepository{}, taskService, &recordingPublisher{}, NewTaskRevisions(nil, 0))
+
+	due := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := useCase.Execute(context.Background(), "task-1", "Relatório", "", application.StatusPending, "", "user-1", &due, "proj-1", "", 0, ""); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if got := taskRepo.tasks["task-1"].ProjectID; got != "proj-1" {
+		t.Fatalf("ProjectID = %q, want proj-1", got)
+	}
+
+	if err := useCase.Execute(context.Background(), "task-1", "Relatório", "", application.StatusPending, "", "user-1", &due, "", "", 0, ""); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if got := taskRepo.tasks["task-1"].ProjectID; got != "" {
+		t.Errorf("ProjectID = %q, want the task out of the project", got)
+	}
+
+	if err := useCase.Execute(context.Background(), "task-1", "Relatório", "", application.StatusPending, "", "user-1", &due, "proj-9", "", 0, ""); !errors.Is(err, application.ErrProjectNotFound) {
+		t.Errorf("Execute() into a missing project error = %v, want ErrProjectNotFound", err)
+	}
+}
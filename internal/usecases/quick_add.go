@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/quickadd"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// QuickAddTaskUseCase handles creating a task from a single line of text
+type QuickAddTaskUseCase struct {
+	taskRepo    repository.TaskRepository
+	projectRepo repository.ProjectRepository
+	events      event.Publisher
+	ids         IDGenerator
+	quota       CreationQuotaUseCaseInterface
+}
+
+// NewQuickAddTaskUseCase creates a new QuickAddTaskUseCase charging the tasks to quota (nil for
+// no quota)
+func NewQuickAddTaskUseCase(taskRepo repository.TaskRepository, projectRepo repository.ProjectRepository, events event.Publisher, ids IDGenerator, quota CreationQuotaUseCaseInterface) *QuickAddTaskUseCase {
+	return &QuickAddTaskUseCase{
+		taskRepo:    taskRepo,
+		projectRepo: projectRepo,
+		events:      events,
+		ids:         ids,
+		quota:       quota,
+	}
+}
+
+// Execute parses text with quickadd.Parse, reading due dates in loc, and creates the task it
+// describes; projectID is optional and the project must belong to the owner. Parse errors are
+// returned as is.
+func (uc *QuickAddTaskUseCase) Execute(ctx context.Context, ownerID, text, projectID string, loc *time.Location) (*application.Task, error) {
+	parsed, err := quickadd.Parse(text, time.Now(), loc)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := application.NewTask(uc.ids.NewID(), parsed.Title, "", application.StatusPending, ownerID, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := task.SetDueDate(parsed.DueDate); err != nil {
+		return nil, err
+	}
+	if err := task.SetPriority(parsed.Priority); err != nil {
+		return nil, err
+	}
+	if err := task.SetTags(parsed.Tags); err != nil {
+		return nil, err
+	}
+	if err := checkProjectOwner(ctx, uc.projectRepo, projectID, ownerID); err != nil {
+		return nil, err
+	}
+	task.SetProject(projectID)
+	inOrgScope(ctx, task)
+
+	usageID, err := consumeCreation(ctx, uc.quota, ownerID, application.CreationTask, 1)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.taskRepo.Create(ctx, task); err != nil {
+		releaseCreation(ctx, uc.quota, usageID)
+		return nil, err
+	}
+
+	uc.events.Publish(ctx, event.TaskCreated{TaskEvent: event.NewTaskEvent(task, ownerID)})
+
+	return task, nil
+}
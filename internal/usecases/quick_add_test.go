@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/quickadd"
+)
+
+func TestQuickAddTaskUseCase_Execute(t *testing.T) {
+	project, _ := application.NewProject("p-home", "Casa", "", "user-1")
+	projects := newMockProjectRepository(project)
+
+	tests := []struct {
+		name      string
+		text      string
+		projectID string
+		wantErr   error
+	}{
+		{name: "creates the parsed task", text: "Comprar pão amanhã !alta #Compras", projectID: "p-home"},
+		{name: "parse error", text: "#compras", wantErr: quickadd.ErrEmptyTitle},
+		{name: "project of another user", text: "Comprar pão", projectID: "p-other", wantErr: application.ErrProjectNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockTaskRepository{tasks: map[string]*application.Task{}}
+			publisher := &recordingPublisher{}
+			uc := NewQuickAddTaskUseCase(repo, projects, publisher, &sequentialIDs{}, nil)
+
+			task, err := uc.Execute(context.Background(), "user-1", tt.text, tt.projectID, time.UTC)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(repo.tasks) != 0 || len(publisher.events) != 0 {
+					t.Error("a failed quick add should not create a task")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if task.Title != "Comprar pão" || task.OwnerID != "user-1" || task.ProjectID != "p-home" || task.Status != application.StatusPending {
+				t.Errorf("task = %+v", task)
+			}
+			if task.DueDate == nil || task.Priority != application.PriorityHigh || strings.Join(task.Tags, ",") != "compras" {
+				t.Errorf("due date %v, priority %q, tags %q; want tomorrow, high and compras", task.DueDate, task.Priority, task.Tags)
+			}
+			if repo.tasks[task.ID] != task {
+				t.Error("task was not persisted")
+			}
+			if names := publisher.names(); len(names) != 1 || names[0] != event.TaskCreatedName {
+				t.Errorf("published %v, want %s", names, event.TaskCreatedName)
+			}
+		})
+	}
+}
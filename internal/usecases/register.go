@@ -14,26 +14,29 @@ import (
 type RegisterUseCase struct {
 	userRepo    repository.UserRepository
 	authService *service.AuthService
+	policy      service.PasswordPolicy
 }
 
-// NewRegisterUseCase creates a new RegisterUseCase
-func NewRegisterUseCase(userRepo repository.UserRepository, jwtSecret string) *RegisterUseCase {
+// NewRegisterUseCase creates a new RegisterUseCase accepting the passwords that follow policy.
+// Passwords are hashed with hasher, or bcrypt at the default cost when nil.
+func NewRegisterUseCase(userRepo repository.UserRepository, jwtKeys *service.JWTKeySet, hasher service.PasswordHasher, policy service.PasswordPolicy) *RegisterUseCase {
 	return &RegisterUseCase{
 		userRepo:    userRepo,
-		authService: service.NewAuthService(jwtSecret),
+		authService: service.NewAuthServiceWithKeys(jwtKeys, hasher),
+		policy:      policy,
 	}
 }
 
 // Execute registers a new user
 func (uc *RegisterUseCase) Execute(ctx context.Context, name, email, password string) (*application.User, error) {
-	// Validate password length
-	if len(password) < 8 {
-		return nil, errors.New("password must be at least 8 characters")
+	// Validate password against the policy, reporting every rule it breaks
+	if err := uc.policy.Validate(password, email, name); err != nil {
+		return nil, err
 	}
 
 	// Check if email already exists
-	existingUser, err := uc.userRepo.FindByEmail(ctx, email)
-	if err == nil && existingUser != nil {
+	_, err := uc.userRepo.FindByEmail(ctx, email)
+	if err == nil {
 		return nil, errors.New("email already registered")
 	}
 
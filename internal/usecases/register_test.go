@@ -2,9 +2,11 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
 )
 
 // Mock UserRepository for testing
@@ -43,6 +45,18 @@ func (m *mockUserRepositoryForRegister) Delete(ctx context.Context, id string) e
 	return nil
 }
 
+func (m *mockUserRepositoryForRegister) RevokeSessions(ctx context.Context, id string) error {
+	return nil
+}
+
+func (m *mockUserRepositoryForRegister) Search(ctx context.Context, query, excludeID string, limit int) ([]*application.User, error) {
+	return nil, nil
+}
+
+func (m *mockUserRepositoryForRegister) FindByIDs(ctx context.Context, ids []string) ([]*application.User, error) {
+	return nil, nil
+}
+
 func TestRegisterUseCase_Execute(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -102,7 +116,7 @@ func TestRegisterUseCase_Execute(t *testing.T) {
 			mockRepo := &mockUserRepositoryForRegister{
 				users: make(map[string]*application.User),
 			}
-			registerUseCase := NewRegisterUseCase(mockRepo, "test-secret-key")
+			registerUseCase := NewRegisterUseCase(mockRepo, service.NewJWTKeySet("test-secret-key"), nil, service.DefaultPasswordPolicy)
 
 			user, err := registerUseCase.Execute(context.Background(), tt.userName, tt.email, tt.password)
 
@@ -143,7 +157,7 @@ func TestRegisterUseCase_Execute_DuplicateEmail(t *testing.T) {
 	mockRepo := &mockUserRepositoryForRegister{
 		users: make(map[string]*application.User),
 	}
-	registerUseCase := NewRegisterUseCase(mockRepo, "test-secret-key")
+	registerUseCase := NewRegisterUseCase(mockRepo, service.NewJWTKeySet("test-secret-key"), nil, service.DefaultPasswordPolicy)
 
 	// Register first user
 	_, err := registerUseCase.Execute(context.Background(), "User One", "duplicate@example.com", "password123")
@@ -160,3 +174,27 @@ func TestRegisterUseCase_Execute_DuplicateEmail(t *testing.T) {
 		t.Errorf("Execute() error = %v, want 'email already registered'", err.Error())
 	}
 }
+
+func TestRegisterUseCase_Execute_PasswordPolicy(t *testing.T) {
+	mockRepo := &mockUserRepositoryForRegister{
+		users: make(map[string]*application.User),
+	}
+	policy := service.PasswordPolicy{MinLength: 8, RequireDigit: true, RejectPersonal: true}
+	registerUseCase := NewRegisterUseCase(mockRepo, service.NewJWTKeySet("test-secret-key"), nil, policy)
+
+	_, err := registerUseCase.Execute(context.Background(), "John Doe", "johndoe@example.com", "johndoe-forever")
+	var policyErr *service.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("Execute() error = %v, want a *service.PasswordPolicyError", err)
+	}
+	if len(policyErr.Violations) != 2 || policyErr.Violations[0].Rule != service.RuleDigit || policyErr.Violations[1].Rule != service.RuleNotPersonal {
+		t.Errorf("Violations = %+v, want digit and not_personal", policyErr.Violations)
+	}
+	if len(mockRepo.users) != 0 {
+		t.Errorf("expected no user to be created")
+	}
+
+	if _, err := registerUseCase.Execute(context.Background(), "John Doe", "johndoe@example.com", "tangerine-42"); err != nil {
+		t.Errorf("Execute() unexpected error: %v", err)
+	}
+}
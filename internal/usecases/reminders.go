@@ -0,0 +1,286 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// Mailer sends plain text emails
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// findUserReminder finds a reminder and checks it was set by the user on the task
+func findUserReminder(ctx context.Context, reminderRepo repository.ReminderRepository, taskID, reminderID, userID string) (*application.Reminder, error) {
+	reminder, err := reminderRepo.FindByID(ctx, reminderID)
+	if err != nil {
+		return nil, err
+	}
+	if reminder.TaskID != taskID || reminder.UserID != userID {
+		return nil, application.ErrReminderNotFound
+	}
+	return reminder, nil
+}
+
+// CreateReminderUseCase handles setting a reminder on a task
+type CreateReminderUseCase struct {
+	reminderRepo repository.ReminderRepository
+	taskRepo     repository.TaskRepository
+	taskService  TaskServiceInterface
+	now          func() time.Time
+}
+
+// NewCreateReminderUseCase creates a new CreateReminderUseCase
+func NewCreateReminderUseCase(
+	reminderRepo repository.ReminderRepository,
+	taskRepo repository.TaskRepository,
+	taskService TaskServiceInterface,
+) *CreateReminderUseCase {
+	return &CreateReminderUseCase{
+		reminderRepo: reminderRepo,
+		taskRepo:     taskRepo,
+		taskService:  taskService,
+		now:          time.Now,
+	}
+}
+
+// Execute sets a reminder for the user on a task they can see. Reminders are personal:
+// the owner and the users the task is shared with each manage their own.
+func (uc *CreateReminderUseCase) Execute(ctx context.Context, taskID, userID string, remindAt time.Time) (*application.Reminder, error) {
+	if err := checkTaskAccess(ctx, uc.taskRepo, uc.taskService, taskID, userID, false); err != nil {
+		return nil, err
+	}
+
+	reminder, err := application.NewReminder(uuid.New().String(), taskID, userID, remindAt, uc.now())
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := uc.reminderRepo.CountPending(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= application.MaxRemindersPerTask {
+		return nil, application.ErrTooManyReminders
+	}
+
+	if err := uc.reminderRepo.Create(ctx, reminder); err != nil {
+		return nil, err
+	}
+
+	return reminder, nil
+}
+
+// ListRemindersUseCase handles listing the reminders a user set on a task
+type ListRemindersUseCase struct {
+	reminderRepo repository.ReminderRepository
+	taskRepo     repository.TaskRepository
+	taskService  TaskServiceInterface
+}
+
+// NewListRemindersUseCase creates a new ListRemindersUseCase
+func NewListRemindersUseCase(
+	reminderRepo repository.ReminderRepository,
+	taskRepo repository.TaskRepository,
+	taskService TaskServiceInterface,
+) *ListRemindersUseCase {
+	return &ListRemindersUseCase{
+		reminderRepo: reminderRepo,
+		taskRepo:     taskRepo,
+		taskService:  taskService,
+	}
+}
+
+// Execute lists the reminders of the user on a task they can see
+func (uc *ListRemindersUseCase) Execute(ctx context.Context, taskID, userID string) ([]*application.Reminder, error) {
+	if err := checkTaskAccess(ctx, uc.taskRepo, uc.taskService, taskID, userID, false); err != nil {
+		return nil, err
+	}
+
+	return uc.reminderRepo.FindByTaskID(ctx, taskID, userID)
+}
+
+// DeleteReminderUseCase handles removing a reminder
+type DeleteReminderUseCase struct {
+	reminderRepo repository.ReminderRepository
+	taskRepo     repository.TaskRepository
+	taskService  TaskServiceInterface
+}
+
+// NewDeleteReminderUseCase creates a new DeleteReminderUseCase
+func NewDeleteReminderUseCase(
+	reminderRepo repository.ReminderRepository,
+	taskRepo repository.TaskRepository,
+	taskService TaskServiceInterface,
+) *DeleteReminderUseCase {
+	return &DeleteReminderUseCase{
+		reminderRepo: reminderRepo,
+		taskRepo:     taskRepo,
+		taskService:  taskService,
+	}
+}
+
+// Execute removes one of the user's reminders on a task
+func (uc *DeleteReminderUseCase) Execute(ctx context.Context, taskID, reminderID, userID string) error {
+	if err := checkTaskAccess(ctx, uc.taskRepo, uc.taskService, taskID, userID, false); err != nil {
+		return err
+	}
+
+	if _, err := findUserReminder(ctx, uc.reminderRepo, taskID, reminderID, userID); err != nil {
+		return err
+	}
+
+	return uc.reminderRepo.Delete(ctx, reminderID)
+}
+
+// ReminderDeliverySummary reports the outcome of a reminder delivery run
+type ReminderDeliverySummary struct {
+	Delivered   int
+	Skipped     int
+	Emailed     int
+	EmailFailed int
+}
+
+// DeliverRemindersUseCase delivers the reminders that are due
+type DeliverRemindersUseCase struct {
+	reminderRepo     repository.ReminderRepository
+	taskRepo         repository.TaskRepository
+	userRepo         repository.UserRepository
+	notificationRepo repository.NotificationRepository
+	taskService      TaskServiceInterface
+	mailer           Mailer
+	batchSize        int
+	loc              *time.Location
+	now              func() time.Time
+}
+
+// NewDeliverRemindersUseCase creates a new DeliverRemindersUseCase. A nil mailer delivers
+// reminders only as in-app notifications; loc is the time zone of due dates in emails.
+func NewDeliverRemindersUseCase(
+	reminderRepo repository.ReminderRepository,
+	taskRepo repository.TaskRepository,
+	userRepo repository.UserRepository,
+	notificationRepo repository.NotificationRepository,
+	taskService TaskServiceInterface,
+	mailer Mailer,
+	batchSize int,
+	loc *time.Location,
+) *DeliverRemindersUseCase {
+	return &DeliverRemindersUseCase{
+		reminderRepo:     reminderRepo,
+		taskRepo:         taskRepo,
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+		taskService:      taskService,
+		mailer:           mailer,
+		batchSize:        batchSize,
+		loc:              loc,
+		now:              time.Now,
+	}
+}
+
+// Execute delivers up to batchSize due reminders as in-app notifications and emails.
+// Each reminder is marked as sent before delivery, so it is never delivered twice; reminders
+// of completed tasks or of tasks the user can no longer see are dropped. Email failures
+// don't stop the run and are returned together at the end.
+func (uc *DeliverRemindersUseCase) Execute(ctx context.Context) (ReminderDeliverySummary, error) {
+	var summary ReminderDeliverySummary
+
+	now := uc.now()
+	reminders, err := uc.reminderRepo.FindDue(ctx, now, uc.batchSize)
+	if err != nil {
+		return summary, fmt.Errorf("failed to retrieve due reminders: %w", err)
+	}
+
+	var emailErrs []error
+	for _, reminder := range reminders {
+		if err := ctx.Err(); err != nil {
+			return summary, err
+		}
+
+		claimed, err := uc.reminderRepo.MarkSent(ctx, reminder.ID, now)
+		if err != nil {
+			return summary, fmt.Errorf("failed to mark reminder %s as sent: %w", reminder.ID, err)
+		}
+		if !claimed {
+			continue
+		}
+
+		task, err := uc.deliverableTask(ctx, reminder)
+		if err != nil {
+			return summary, err
+		}
+		if task == nil {
+			summary.Skipped++
+			continue
+		}
+
+		message := fmt.Sprintf("Lembrete: tarefa \"%s\"", task.Title)
+		notification, err := application.NewNotification(uuid.New().String(), reminder.UserID, application.NotificationTaskReminder, task.ID, notificationMessage(message))
+		if err != nil {
+			return summary, err
+		}
+		if err := uc.notificationRepo.Create(ctx, notification); err != nil {
+			return summary, fmt.Errorf("failed to create notification: %w", err)
+		}
+		summary.Delivered++
+
+		if uc.mailer == nil {
+			continue
+		}
+		if err := uc.email(ctx, reminder.UserID, task); err != nil {
+			summary.EmailFailed++
+			emailErrs = append(emailErrs, fmt.Errorf("reminder %s: %w", reminder.ID, err))
+			continue
+		}
+		summary.Emailed++
+	}
+
+	return summary, errors.Join(emailErrs...)
+}
+
+// deliverableTask returns the task of a reminder, or nil when it shouldn't be delivered
+func (uc *DeliverRemindersUseCase) deliverableTask(ctx context.Context, reminder *application.Reminder) (*application.Task, error) {
+	task, err := uc.taskRepo.FindByID(ctx, reminder.TaskID)
+	if errors.Is(err, application.ErrTaskNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve task %s: %w", reminder.TaskID, err)
+	}
+	if task.Status == application.StatusCompleted {
+		return nil, nil
+	}
+
+	canAccess, err := uc.taskService.CanUserAccessTask(ctx, task.ID, reminder.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check access to task %s: %w", task.ID, err)
+	}
+	if !canAccess {
+		return nil, nil
+	}
+
+	return task, nil
+}
+
+// email sends the reminder of a task to the user's email address
+func (uc *DeliverRemindersUseCase) email(ctx context.Context, userID string, task *application.Task) error {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	subject := fmt.Sprintf("Lembrete: %s", task.Title)
+	body := fmt.Sprintf("Olá, %s!\n\nVocê pediu para ser lembrado da tarefa \"%s\".\n", user.Name, task.Title)
+	if task.DueDate != nil {
+		body += fmt.Sprintf("Prazo: %s\n", task.DueDate.In(uc.loc).Format("02/01/2006 15:04"))
+	}
+
+	return uc.mailer.Send(ctx, user.Email, subject, body)
+}
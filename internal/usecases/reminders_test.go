@@ -0,0 +1,278 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+type mockReminderRepository struct {
+	reminders map[string]*application.Reminder
+}
+
+func (m *mockReminderRepository) Create(ctx context.Context, reminder *application.Reminder) error {
+	m.reminders[reminder.ID] = reminder
+	return nil
+}
+
+func (m *mockReminderRepository) FindByID(ctx context.Context, id string) (*application.Reminder, error) {
+	if reminder, ok := m.reminders[id]; ok {
+		return reminder, nil
+	}
+	return nil, application.ErrReminderNotFound
+}
+
+func (m *mockReminderRepository) FindByTaskID(ctx context.Context, taskID, userID string) ([]*application.Reminder, error) {
+	var reminders []*application.Reminder
+	for _, reminder := range m.reminders {
+		if reminder.TaskID == taskID && reminder.UserID == userID {
+			reminders = append(reminders, reminder)
+		}
+	}
+	return reminders, nil
+}
+
+func (m *mockReminderRepository) CountPending(ctx context.Context, taskID, userID string) (int, error) {
+	reminders, _ := m.FindByTaskID(ctx, taskID, userID)
+	count := 0
+	for _, reminder := range reminders {
+		if !reminder.IsSent() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockReminderRepository) Delete(ctx context.Context, id string) error {
+	delete(m.reminders, id)
+	return nil
+}
+
+func (m *mockReminderRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*application.Reminder, error) {
+	var reminders []*application.Reminder
+	for _, reminder := range m.reminders {
+		if !reminder.IsSent() && !reminder.RemindAt.After(now) {
+			reminders = append(reminders, reminder)
+		}
+	}
+	sort.Slice(reminders, func(i, j int) bool { return reminders[i].RemindAt.Before(reminders[j].RemindAt) })
+	if len(reminders) > limit {
+		reminders = reminders[:limit]
+	}
+	return reminders, nil
+}
+
+func (m *mockReminderRepository) MarkSent(ctx context.Context, id string, sentAt time.Time) (bool, error) {
+	reminder, ok := m.reminders[id]
+	if !ok || reminder.IsSent() {
+		return false, nil
+	}
+	reminder.SentAt = &sentAt
+	return true, nil
+}
+
+type sentEmail struct {
+	to, subject, body string
+}
+
+type mockMailer struct {
+	sent []sentEmail
+	err  error
+}
+
+func (m *mockMailer) Send(ctx context.Context, to, subject, body string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, sentEmail{to, subject, body})
+	return nil
+}
+
+var reminderNow = time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func TestCreateReminderUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name     string
+		taskID   string
+		userID   string
+		remindAt time.Time
+		existing int
+		wantErr  error
+	}{
+		{"owner sets reminder", "task-1", "owner", reminderNow.Add(time.Hour), 0, nil},
+		{"shared user sets reminder", "task-1", "viewer", reminderNow.Add(time.Hour), 0, nil},
+		{"stranger gets not found", "task-1", "stranger", reminderNow.Add(time.Hour), 0, ErrTaskUnavailable},
+		{"missing task", "missing", "owner", reminderNow.Add(time.Hour), 0, ErrTaskUnavailable},
+		{"time in the past", "task-1", "owner", reminderNow.Add(-time.Hour), 0, application.ErrReminderInPast},
+		{"limit reached", "task-1", "owner", reminderNow.Add(time.Hour), application.MaxRemindersPerTask, application.ErrTooManyReminders},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskRepo := &mockTaskRepositoryForAttachments{mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+				"task-1": {ID: "task-1", OwnerID: "owner"},
+			}}}
+			taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"owner": true, "viewer": true}}
+			reminderRepo := &mockReminderRepository{reminders: map[string]*application.Reminder{}}
+			for i := 0; i < tt.existing; i++ {
+				id := "existing-" + string(rune('a'+i))
+				reminderRepo.reminders[id] = &application.Reminder{ID: id, TaskID: "task-1", UserID: tt.userID, RemindAt: reminderNow.Add(time.Hour)}
+			}
+			uc := NewCreateReminderUseCase(reminderRepo, taskRepo, taskService)
+			uc.now = func() time.Time { return reminderNow }
+
+			reminder, err := uc.Execute(context.Background(), tt.taskID, tt.userID, tt.remindAt)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if reminder.UserID != tt.userID || reminderRepo.reminders[reminder.ID] == nil {
+				t.Errorf("reminder not stored for %s: %+v", tt.userID, reminder)
+			}
+		})
+	}
+}
+
+func TestDeleteReminderUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name       string
+		reminderID string
+		userID     string
+		wantErr    error
+	}{
+		{"user deletes own reminder", "rem-viewer", "viewer", nil},
+		{"cannot delete reminder of another user", "rem-viewer", "owner", application.ErrReminderNotFound},
+		{"stranger gets not found", "rem-viewer", "stranger", ErrTaskUnavailable},
+		{"missing reminder", "missing", "viewer", application.ErrReminderNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskRepo := &mockTaskRepositoryForAttachments{mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+				"task-1": {ID: "task-1", OwnerID: "owner"},
+			}}}
+			taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"owner": true, "viewer": true}}
+			reminderRepo := &mockReminderRepository{reminders: map[string]*application.Reminder{
+				"rem-viewer": {ID: "rem-viewer", TaskID: "task-1", UserID: "viewer"},
+			}}
+			uc := NewDeleteReminderUseCase(reminderRepo, taskRepo, taskService)
+
+			err := uc.Execute(context.Background(), "task-1", tt.reminderID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if _, exists := reminderRepo.reminders["rem-viewer"]; exists == (tt.wantErr == nil) {
+				t.Errorf("reminder exists = %v after Execute() error %v", exists, err)
+			}
+		})
+	}
+}
+
+func TestDeliverRemindersUseCase_Execute(t *testing.T) {
+	taskRepo := &mockTaskRepositoryForAttachments{mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+		"task-1":    {ID: "task-1", OwnerID: "owner", Title: "Pagar contas"},
+		"task-done": {ID: "task-done", OwnerID: "owner", Status: application.StatusCompleted},
+	}}}
+	taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"owner": true, "viewer": true}}
+	reminderRepo := &mockReminderRepository{reminders: map[string]*application.Reminder{
+		"rem-owner":    {ID: "rem-owner", TaskID: "task-1", UserID: "owner", RemindAt: reminderNow.Add(-time.Minute)},
+		"rem-viewer":   {ID: "rem-viewer", TaskID: "task-1", UserID: "viewer", RemindAt: reminderNow.Add(-time.Hour)},
+		"rem-unshared": {ID: "rem-unshared", TaskID: "task-1", UserID: "stranger", RemindAt: reminderNow.Add(-time.Hour)},
+		"rem-done":     {ID: "rem-done", TaskID: "task-done", UserID: "owner", RemindAt: reminderNow.Add(-time.Hour)},
+		"rem-future":   {ID: "rem-future", TaskID: "task-1", UserID: "owner", RemindAt: reminderNow.Add(time.Hour)},
+	}}
+	userRepo := &mockUserRepositoryForLogin{users: map[string]*application.User{
+		"owner":  {ID: "owner", Name: "Ana", Email: "ana@example.com"},
+		"viewer": {ID: "viewer", Name: "Bia", Email: "bia@example.com"},
+	}}
+	notificationRepo := &mockNotificationRepository{}
+	mailer := &mockMailer{}
+	uc := NewDeliverRemindersUseCase(reminderRepo, taskRepo, userRepo, notificationRepo, taskService, mailer, 10, time.UTC)
+	uc.now = func() time.Time { return reminderNow }
+
+	summary, err := uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	want := ReminderDeliverySummary{Delivered: 2, Skipped: 2, Emailed: 2}
+	if summary != want {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+
+	if len(notificationRepo.notifications) != 2 {
+		t.Fatalf("notifications = %d, want 2", len(notificationRepo.notifications))
+	}
+	for _, n := range notificationRepo.notifications {
+		if n.Type != application.NotificationTaskReminder || n.TaskID != "task-1" {
+			t.Errorf("unexpected notification %+v", n)
+		}
+	}
+	if len(mailer.sent) != 2 || mailer.sent[0].to != "bia@example.com" || mailer.sent[0].subject != "Lembrete: Pagar contas" {
+		t.Errorf("emails = %+v", mailer.sent)
+	}
+	if reminderRepo.reminders["rem-future"].IsSent() {
+		t.Error("future reminder should not be sent")
+	}
+	if !reminderRepo.reminders["rem-done"].IsSent() {
+		t.Error("skipped reminder should be marked as sent")
+	}
+
+	// Reminders are delivered once
+	summary, err = uc.Execute(context.Background())
+	if err != nil || summary != (ReminderDeliverySummary{}) {
+		t.Errorf("second Execute() = %+v, %v; want nothing delivered", summary, err)
+	}
+}
+
+func TestDeliverRemindersUseCase_Execute_EmailFailure(t *testing.T) {
+	taskRepo := &mockTaskRepositoryForAttachments{mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+		"task-1": {ID: "task-1", OwnerID: "owner", Title: "Pagar contas"},
+	}}}
+	taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"owner": true, "viewer": true}}
+	reminderRepo := &mockReminderRepository{reminders: map[string]*application.Reminder{
+		"rem-owner":  {ID: "rem-owner", TaskID: "task-1", UserID: "owner", RemindAt: reminderNow.Add(-time.Minute)},
+		"rem-viewer": {ID: "rem-viewer", TaskID: "task-1", UserID: "viewer", RemindAt: reminderNow.Add(-time.Hour)},
+	}}
+	userRepo := &mockUserRepositoryForLogin{users: map[string]*application.User{
+		"owner":  {ID: "owner", Name: "Ana", Email: "ana@example.com"},
+		"viewer": {ID: "viewer", Name: "Bia", Email: "bia@example.com"},
+	}}
+	notificationRepo := &mockNotificationRepository{}
+	uc := NewDeliverRemindersUseCase(reminderRepo, taskRepo, userRepo, notificationRepo, taskService, &mockMailer{err: errors.New("smtp down")}, 10, time.UTC)
+	uc.now = func() time.Time { return reminderNow }
+
+	// Email failures are reported, the in-app notifications are kept
+	summary, err := uc.Execute(context.Background())
+	if err == nil {
+		t.Fatal("Execute() should report email failures")
+	}
+	if summary.Delivered != 2 || summary.EmailFailed != 2 || len(notificationRepo.notifications) != 2 {
+		t.Errorf("summary = %+v, notifications = %d", summary, len(notificationRepo.notifications))
+	}
+}
+
+func TestDeliverRemindersUseCase_Execute_WithoutMailer(t *testing.T) {
+	taskRepo := &mockTaskRepositoryForAttachments{mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+		"task-1": {ID: "task-1", OwnerID: "owner", Title: "Pagar contas"},
+	}}}
+	taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"owner": true, "viewer": true}}
+	reminderRepo := &mockReminderRepository{reminders: map[string]*application.Reminder{
+		"rem-owner":  {ID: "rem-owner", TaskID: "task-1", UserID: "owner", RemindAt: reminderNow.Add(-time.Minute)},
+		"rem-viewer": {ID: "rem-viewer", TaskID: "task-1", UserID: "viewer", RemindAt: reminderNow.Add(-time.Hour)},
+	}}
+	userRepo := &mockUserRepositoryForLogin{users: map[string]*application.User{}}
+	notificationRepo := &mockNotificationRepository{}
+	uc := NewDeliverRemindersUseCase(reminderRepo, taskRepo, userRepo, notificationRepo, taskService, nil, 10, time.UTC)
+	uc.now = func() time.Time { return reminderNow }
+
+	summary, err := uc.Execute(context.Background())
+	if err != nil || summary.Delivered != 2 || summary.Emailed != 0 || len(notificationRepo.notifications) != 2 {
+		t.Errorf("Execute() = %+v, %v", summary, err)
+	}
+}
@@ -34,7 +34,7 @@ func (m *mockTaskRepositoryForReplaceImage) Delete(ctx context.Context, id strin
 func (m *mockTaskRepositoryForReplaceImage) FindByID(ctx context.Context, id string) (*application.Task, error) {
 	task, exists := m.tasks[id]
 	if !exists {
-		return nil, errors.New("task not found")
+		return nil, application.ErrTaskNotFound
 	}
 	return task, nil
 }
@@ -53,6 +53,18 @@ func (m *mockTaskRepositoryForReplaceImage) FindSharedWithUser(ctx context.Conte
 	return []*application.Task{}, nil
 }
 
+func (m *mockTaskRepositoryForReplaceImage) FindByProjectID(ctx context.Context, projectID string) ([]*application.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskRepositoryForReplaceImage) FindByImagePath(ctx context.Context, imagePath string) ([]*application.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskRepositoryForReplaceImage) CreateMany(ctx context.Context, tasks []*application.Task) error {
+	return nil
+}
+
 type mockTaskServiceForReplaceImage struct {
 	canModify bool
 }
@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+const (
+	// MinUserSearchLength is the shortest query that is searched; shorter ones return no users,
+	// so the endpoint can't be used to list every account
+	MinUserSearchLength = 2
+
+	// maxUserSearchLength bounds the query sent to the database
+	maxUserSearchLength = 100
+
+	// userSearchLimit is the maximum number of users returned by a search
+	userSearchLimit = 10
+)
+
+// ErrSearchQueryTooLong is returned when the search query exceeds maxUserSearchLength
+var ErrSearchQueryTooLong = errors.New("search query is too long")
+
+// SearchUsersUseCase handles finding users to share tasks with
+type SearchUsersUseCase struct {
+	userRepo repository.UserRepository
+}
+
+// NewSearchUsersUseCase creates a new SearchUsersUseCase
+func NewSearchUsersUseCase(userRepo repository.UserRepository) *SearchUsersUseCase {
+	return &SearchUsersUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute returns the users whose name or email contains the query, excluding the requester
+func (uc *SearchUsersUseCase) Execute(ctx context.Context, requesterID, query string) ([]*application.User, error) {
+	query = strings.TrimSpace(query)
+
+	length := utf8.RuneCountInString(query)
+	if length > maxUserSearchLength {
+		return nil, ErrSearchQueryTooLong
+	}
+	if length < MinUserSearchLength {
+		return []*application.User{}, nil
+	}
+
+	users, err := uc.userRepo.Search(ctx, query, requesterID, userSearchLimit)
+	if err != nil {
+		return nil, err
+	}
+	if users == nil {
+		users = []*application.User{}
+	}
+	return users, nil
+}
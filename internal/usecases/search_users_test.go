@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// mockUserRepositoryForSearch records the arguments of the last search
+type mockUserRepositoryForSearch struct {
+	mockUserRepositoryForLogin
+	results   []*application.User
+	err       error
+	calls     int
+	query     string
+	excludeID string
+	limit     int
+}
+
+func (m *mockUserRepositoryForSearch) Search(ctx context.Context, query, excludeID string, limit int) ([]*application.User, error) {
+	m.calls++
+	m.query, m.excludeID, m.limit = query, excludeID, limit
+	return m.results, m.err
+}
+
+func (m *mockUserRepositoryForSearch) FindByIDs(ctx context.Context, ids []string) ([]*application.User, error) {
+	return nil, nil
+}
+
+func TestSearchUsersUseCase_Execute(t *testing.T) {
+	ana := &application.User{ID: "user-2", Name: "Ana", Email: "ana@example.com"}
+
+	tests := []struct {
+		name      string
+		query     string
+		results   []*application.User
+		repoErr   error
+		wantUsers int
+		wantCalls int
+		wantQuery string
+		wantErr   error
+	}{
+		{"match", "ana", []*application.User{ana}, nil, 1, 1, "ana", nil},
+		{"query is trimmed", "  ana  ", []*application.User{ana}, nil, 1, 1, "ana", nil},
+		{"no match returns empty list", "zz", nil, nil, 0, 1, "zz", nil},
+		{"short query skips the search", "a", nil, nil, 0, 0, "", nil},
+		{"blank query skips the search", "   ", nil, nil, 0, 0, "", nil},
+		{"long query", strings.Repeat("a", maxUserSearchLength+1), nil, nil, 0, 0, "", ErrSearchQueryTooLong},
+		{"repository error", "ana", nil, errors.New("db down"), 0, 1, "ana", errors.New("db down")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockUserRepositoryForSearch{results: tt.results, err: tt.repoErr}
+			uc := NewSearchUsersUseCase(repo)
+
+			users, err := uc.Execute(context.Background(), "user-1", tt.query)
+			if tt.wantErr != nil {
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+			if users == nil {
+				t.Error("Execute() returned nil, want empty slice")
+			}
+			if len(users) != tt.wantUsers {
+				t.Errorf("Execute() returned %d users, want %d", len(users), tt.wantUsers)
+			}
+			if repo.calls != tt.wantCalls {
+				t.Fatalf("Search called %d times, want %d", repo.calls, tt.wantCalls)
+			}
+			if tt.wantCalls > 0 {
+				if repo.query != tt.wantQuery {
+					t.Errorf("Search query = %q, want %q", repo.query, tt.wantQuery)
+				}
+				if repo.excludeID != "user-1" {
+					t.Errorf("Search excludeID = %q, want the requester", repo.excludeID)
+				}
+				if repo.limit != userSearchLimit {
+					t.Errorf("Search limit = %d, want %d", repo.limit, userSearchLimit)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// ListShareInvitationsUseCase handles listing the share invitations a user hasn't answered
+type ListShareInvitationsUseCase struct {
+	shareRepo repository.ShareRepository
+}
+
+// NewListShareInvitationsUseCase creates a new ListShareInvitationsUseCase
+func NewListShareInvitationsUseCase(shareRepo repository.ShareRepository) *ListShareInvitationsUseCase {
+	return &ListShareInvitationsUseCase{shareRepo: shareRepo}
+}
+
+// Execute returns the pending invitations of a user, newest first
+func (uc *ListShareInvitationsUseCase) Execute(ctx context.Context, userID string) ([]*application.ShareInvitation, error) {
+	return uc.shareRepo.FindPendingInvitations(ctx, userID)
+}
+
+// AcceptShareInvitationUseCase handles accepting the invitation to a task
+type AcceptShareInvitationUseCase struct {
+	taskRepo  repository.TaskRepository
+	shareRepo repository.ShareRepository
+	events    event.Publisher
+}
+
+// NewAcceptShareInvitationUseCase creates a new AcceptShareInvitationUseCase
+func NewAcceptShareInvitationUseCase(taskRepo repository.TaskRepository, shareRepo repository.ShareRepository, events event.Publisher) *AcceptShareInvitationUseCase {
+	return &AcceptShareInvitationUseCase{
+		taskRepo:  taskRepo,
+		shareRepo: shareRepo,
+		events:    events,
+	}
+}
+
+// Execute accepts the pending invitation of a user to a task and returns the task, which is
+// shared with the user from then on. It returns application.ErrInvitationNotFound when the
+// user has no pending invitation to the task.
+func (uc *AcceptShareInvitationUseCase) Execute(ctx context.Context, taskID, userID string) (*application.Task, error) {
+	if err := uc.shareRepo.AcceptInvitation(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	task, err := uc.taskRepo.FindByID(ctx, taskID)
+	if errors.Is(err, application.ErrTaskNotFound) {
+		return nil, application.ErrInvitationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	uc.events.Publish(ctx, event.TaskShareAccepted{TaskEvent: event.NewTaskEvent(task, userID)})
+
+	return task, nil
+}
+
+// DeclineShareInvitationUseCase handles declining the invitation to a task
+type DeclineShareInvitationUseCase struct {
+	taskRepo  repository.TaskRepository
+	shareRepo repository.ShareRepository
+	events    event.Publisher
+}
+
+// NewDeclineShareInvitationUseCase creates a new DeclineShareInvitationUseCase
+func NewDeclineShareInvitationUseCase(taskRepo repository.TaskRepository, shareRepo repository.ShareRepository, events event.Publisher) *DeclineShareInvitationUseCase {
+	return &DeclineShareInvitationUseCase{
+		taskRepo:  taskRepo,
+		shareRepo: shareRepo,
+		events:    events,
+	}
+}
+
+// Execute declines the pending invitation of a user to a task. The owner may invite the user
+// again. It returns application.ErrInvitationNotFound when the user has no pending invitation
+// to the task.
+func (uc *DeclineShareInvitationUseCase) Execute(ctx context.Context, taskID, userID string) error {
+	if err := uc.shareRepo.DeclineInvitation(ctx, taskID, userID); err != nil {
+		return err
+	}
+
+	task, err := uc.taskRepo.FindByID(ctx, taskID)
+	if err == nil {
+		uc.events.Publish(ctx, event.TaskShareDeclined{TaskEvent: event.NewTaskEvent(task, userID)})
+	}
+
+	return nil
+}
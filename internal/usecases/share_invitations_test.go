@@ -0,0 +1,93 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+func TestShareInvitations(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*mockShareRepositoryForShare, *service.TaskService, *recordingPublisher, *AcceptShareInvitationUseCase, *DeclineShareInvitationUseCase) {
+		t.Helper()
+		task, _ := application.NewTask("task-1", "Relatório", "", application.StatusPending, "owner", "")
+		taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+		shareRepo := &mockShareRepositoryForShare{}
+		taskService := service.NewTaskService(taskRepo, shareRepo)
+		events := &recordingPublisher{}
+
+		if err := NewShareTaskUseCase(taskRepo, shareRepo, taskService, events).Execute(ctx, "task-1", "owner", "guest"); err != nil {
+			t.Fatalf("share error = %v", err)
+		}
+		return shareRepo, taskService, events,
+			NewAcceptShareInvitationUseCase(taskRepo, shareRepo, events),
+			NewDeclineShareInvitationUseCase(taskRepo, shareRepo, events)
+	}
+
+	t.Run("sharing only invites", func(t *testing.T) {
+		shareRepo, taskService, _, _, _ := setup(t)
+
+		if canAccess, _ := taskService.CanUserAccessTask(ctx, "task-1", "guest"); canAccess {
+			t.Error("invited user can access the task before accepting")
+		}
+		invitations, err := NewListShareInvitationsUseCase(shareRepo).Execute(ctx, "guest")
+		if err != nil || len(invitations) != 1 || invitations[0].TaskID != "task-1" {
+			t.Errorf("invitations = %v, %v; want the invitation to task-1", invitations, err)
+		}
+	})
+
+	t.Run("accepting grants access", func(t *testing.T) {
+		_, taskService, events, accept, _ := setup(t)
+
+		task, err := accept.Execute(ctx, "task-1", "guest")
+		if err != nil || task.ID != "task-1" {
+			t.Fatalf("Execute() = %v, %v", task, err)
+		}
+		if canAccess, _ := taskService.CanUserAccessTask(ctx, "task-1", "guest"); !canAccess {
+			t.Error("user can't access the task after accepting")
+		}
+		if got := strings.Join(events.names(), ","); got != "task.shared,task.share_accepted" {
+			t.Errorf("events = %s", got)
+		}
+
+		if _, err := accept.Execute(ctx, "task-1", "guest"); !errors.Is(err, application.ErrInvitationNotFound) {
+			t.Errorf("accepting twice error = %v, want ErrInvitationNotFound", err)
+		}
+	})
+
+	t.Run("declining removes the invitation", func(t *testing.T) {
+		shareRepo, taskService, events, accept, decline := setup(t)
+
+		if err := decline.Execute(ctx, "task-1", "guest"); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if canAccess, _ := taskService.CanUserAccessTask(ctx, "task-1", "guest"); canAccess {
+			t.Error("user can access the task after declining")
+		}
+		if invitations, _ := shareRepo.FindPendingInvitations(ctx, "guest"); len(invitations) != 0 {
+			t.Errorf("invitations = %v after declining, want none", invitations)
+		}
+		if got := strings.Join(events.names(), ","); got != "task.shared,task.share_declined" {
+			t.Errorf("events = %s", got)
+		}
+		if _, err := accept.Execute(ctx, "task-1", "guest"); !errors.Is(err, application.ErrInvitationNotFound) {
+			t.Errorf("accepting a declined invitation error = %v, want ErrInvitationNotFound", err)
+		}
+	})
+
+	t.Run("only the invited user can answer", func(t *testing.T) {
+		_, _, _, accept, decline := setup(t)
+
+		if _, err := accept.Execute(ctx, "task-1", "intruder"); !errors.Is(err, application.ErrInvitationNotFound) {
+			t.Errorf("accept error = %v, want ErrInvitationNotFound", err)
+		}
+		if err := decline.Execute(ctx, "task-1", "intruder"); !errors.Is(err, application.ErrInvitationNotFound) {
+			t.Errorf("decline error = %v, want ErrInvitationNotFound", err)
+		}
+	})
+}
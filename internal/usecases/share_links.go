@@ -0,0 +1,191 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// ErrShareLinkPermissionDenied is returned when a user who can see a task tries to manage its public links
+var ErrShareLinkPermissionDenied = errors.New("only the task owner can manage public links")
+
+// checkShareLinkOwner checks that a user owns a task, returning ErrTaskUnavailable when they
+// can't even see it
+func checkShareLinkOwner(ctx context.Context, taskRepo repository.TaskRepository, taskService TaskServiceInterface, taskID, userID string) error {
+	err := checkTaskAccess(ctx, taskRepo, taskService, taskID, userID, true)
+	if errors.Is(err, ErrTaskOwnerRequired) {
+		return ErrShareLinkPermissionDenied
+	}
+	return err
+}
+
+// CreateShareLinkUseCase handles creating a public, read-only link to a task
+type CreateShareLinkUseCase struct {
+	linkRepo    repository.ShareLinkRepository
+	taskRepo    repository.TaskRepository
+	taskService TaskServiceInterface
+	now         func() time.Time
+}
+
+// NewCreateShareLinkUseCase creates a new CreateShareLinkUseCase
+func NewCreateShareLinkUseCase(
+	linkRepo repository.ShareLinkRepository,
+	taskRepo repository.TaskRepository,
+	taskService TaskServiceInterface,
+) *CreateShareLinkUseCase {
+	return &CreateShareLinkUseCase{
+		linkRepo:    linkRepo,
+		taskRepo:    taskRepo,
+		taskService: taskService,
+		now:         time.Now,
+	}
+}
+
+// Execute creates a link to a task of the owner, expiring in expiryDays, and returns it with
+// its token. The token is only available here: the link stores its hash.
+func (uc *CreateShareLinkUseCase) Execute(ctx context.Context, taskID, userID string, expiryDays int) (*application.ShareLink, string, error) {
+	if err := checkShareLinkOwner(ctx, uc.taskRepo, uc.taskService, taskID, userID); err != nil {
+		return nil, "", err
+	}
+
+	// Links are as unguessable as login challenges, and stored the same way
+	token, hash, err := service.NewChallengeToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	link, err := application.NewShareLink(uuid.New().String(), taskID, userID, hash, expiryDays, uc.now())
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := uc.linkRepo.Create(ctx, link); err != nil {
+		return nil, "", err
+	}
+
+	return link, token, nil
+}
+
+// ListShareLinksUseCase handles listing the public links of a task
+type ListShareLinksUseCase struct {
+	linkRepo    repository.ShareLinkRepository
+	taskRepo    repository.TaskRepository
+	taskService TaskServiceInterface
+	now         func() time.Time
+}
+
+// NewListShareLinksUseCase creates a new ListShareLinksUseCase
+func NewListShareLinksUseCase(
+	linkRepo repository.ShareLinkRepository,
+	taskRepo repository.TaskRepository,
+	taskService TaskServiceInterface,
+) *ListShareLinksUseCase {
+	return &ListShareLinksUseCase{
+		linkRepo:    linkRepo,
+		taskRepo:    taskRepo,
+		taskService: taskService,
+		now:         time.Now,
+	}
+}
+
+// Execute lists the links of a task of the owner that still open it, newest first
+func (uc *ListShareLinksUseCase) Execute(ctx context.Context, taskID, userID string) ([]*application.ShareLink, error) {
+	if err := checkShareLinkOwner(ctx, uc.taskRepo, uc.taskService, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	return uc.linkRepo.FindActiveByTaskID(ctx, taskID, uc.now())
+}
+
+// RevokeShareLinkUseCase handles revoking a public link
+type RevokeShareLinkUseCase struct {
+	linkRepo repository.ShareLinkRepository
+	taskRepo repository.TaskRepository
+	now      func() time.Time
+}
+
+// NewRevokeShareLinkUseCase creates a new RevokeShareLinkUseCase
+func NewRevokeShareLinkUseCase(linkRepo repository.ShareLinkRepository, taskRepo repository.TaskRepository) *RevokeShareLinkUseCase {
+	return &RevokeShareLinkUseCase{
+		linkRepo: linkRepo,
+		taskRepo: taskRepo,
+		now:      time.Now,
+	}
+}
+
+// Execute revokes a link to a task of the user; the link stops opening the task at once.
+// Links of other users' tasks, and links already revoked, are reported as
+// application.ErrShareLinkNotFound.
+func (uc *RevokeShareLinkUseCase) Execute(ctx context.Context, linkID, userID string) error {
+	link, err := uc.linkRepo.FindByID(ctx, linkID)
+	if err != nil {
+		return err
+	}
+
+	task, err := uc.taskRepo.FindByID(ctx, link.TaskID)
+	if errors.Is(err, application.ErrTaskNotFound) {
+		return application.ErrShareLinkNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if task.OwnerID != userID {
+		return application.ErrShareLinkNotFound
+	}
+
+	revoked, err := uc.linkRepo.Revoke(ctx, linkID, uc.now())
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return application.ErrShareLinkNotFound
+	}
+	return nil
+}
+
+// ViewSharedTaskUseCase handles opening a task through its public link, without signing in
+type ViewSharedTaskUseCase struct {
+	linkRepo repository.ShareLinkRepository
+	taskRepo repository.TaskRepository
+	now      func() time.Time
+}
+
+// NewViewSharedTaskUseCase creates a new ViewSharedTaskUseCase
+func NewViewSharedTaskUseCase(linkRepo repository.ShareLinkRepository, taskRepo repository.TaskRepository) *ViewSharedTaskUseCase {
+	return &ViewSharedTaskUseCase{
+		linkRepo: linkRepo,
+		taskRepo: taskRepo,
+		now:      time.Now,
+	}
+}
+
+// Execute returns the task a link token opens, with the link. Unknown, expired and revoked
+// tokens all return application.ErrShareLinkNotFound.
+func (uc *ViewSharedTaskUseCase) Execute(ctx context.Context, token string) (*application.Task, *application.ShareLink, error) {
+	if token == "" {
+		return nil, nil, application.ErrShareLinkNotFound
+	}
+
+	link, err := uc.linkRepo.FindByTokenHash(ctx, service.HashChallengeToken(token))
+	if err != nil {
+		return nil, nil, err
+	}
+	if !link.IsActive(uc.now()) {
+		return nil, nil, application.ErrShareLinkNotFound
+	}
+
+	task, err := uc.taskRepo.FindByID(ctx, link.TaskID)
+	if errors.Is(err, application.ErrTaskNotFound) {
+		return nil, nil, application.ErrShareLinkNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return task, link, nil
+}
@@ -0,0 +1,198 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+type mockShareLinkRepository struct {
+	links map[string]*application.ShareLink
+}
+
+func (m *mockShareLinkRepository) Create(ctx context.Context, link *application.ShareLink) error {
+	m.links[link.ID] = link
+	return nil
+}
+
+func (m *mockShareLinkRepository) FindByID(ctx context.Context, id string) (*application.ShareLink, error) {
+	if link, ok := m.links[id]; ok {
+		return link, nil
+	}
+	return nil, application.ErrShareLinkNotFound
+}
+
+func (m *mockShareLinkRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*application.ShareLink, error) {
+	for _, link := range m.links {
+		if link.TokenHash == tokenHash {
+			return link, nil
+		}
+	}
+	return nil, application.ErrShareLinkNotFound
+}
+
+func (m *mockShareLinkRepository) FindActiveByTaskID(ctx context.Context, taskID string, now time.Time) ([]*application.ShareLink, error) {
+	var links []*application.ShareLink
+	for _, link := range m.links {
+		if link.TaskID == taskID && link.IsActive(now) {
+			links = append(links, link)
+		}
+	}
+	return links, nil
+}
+
+func (m *mockShareLinkRepository) Revoke(ctx context.Context, id string, revokedAt time.Time) (bool, error) {
+	link, ok := m.links[id]
+	if !ok || link.RevokedAt != nil {
+		return false, nil
+	}
+	link.RevokedAt = &revokedAt
+	return true, nil
+}
+
+var shareLinkNow = time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func TestCreateShareLinkUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name       string
+		taskID     string
+		userID     string
+		expiryDays int
+		wantErr    error
+	}{
+		{"owner creates link", "task-1", "owner", 7, nil},
+		{"shared user cannot create link", "task-1", "viewer", 7, ErrShareLinkPermissionDenied},
+		{"stranger gets not found", "task-1", "stranger", 7, ErrTaskUnavailable},
+		{"missing task", "missing", "owner", 7, ErrTaskUnavailable},
+		{"unsupported expiry", "task-1", "owner", 3, application.ErrInvalidShareLinkExpiry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskRepo := &mockTaskRepositoryForAttachments{mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+				"task-1": {ID: "task-1", OwnerID: "owner"},
+			}}}
+			taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"owner": true, "viewer": true}}
+			linkRepo := &mockShareLinkRepository{links: map[string]*application.ShareLink{}}
+			uc := NewCreateShareLinkUseCase(linkRepo, taskRepo, taskService)
+			uc.now = func() time.Time { return shareLinkNow }
+
+			link, token, err := uc.Execute(context.Background(), tt.taskID, tt.userID, tt.expiryDays)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(linkRepo.links) != 0 {
+					t.Errorf("link stored despite error: %v", linkRepo.links)
+				}
+				return
+			}
+			if token == "" || link.TokenHash != service.HashChallengeToken(token) {
+				t.Errorf("link hash %q doesn't match token %q", link.TokenHash, token)
+			}
+			if want := shareLinkNow.AddDate(0, 0, tt.expiryDays); !link.ExpiresAt.Equal(want) {
+				t.Errorf("ExpiresAt = %v, want %v", link.ExpiresAt, want)
+			}
+			if linkRepo.links[link.ID] == nil {
+				t.Error("link not stored")
+			}
+		})
+	}
+}
+
+func TestRevokeShareLinkUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name    string
+		linkID  string
+		userID  string
+		wantErr error
+	}{
+		{"owner revokes link", "link-1", "owner", nil},
+		{"shared user gets not found", "link-1", "viewer", application.ErrShareLinkNotFound},
+		{"missing link", "missing", "owner", application.ErrShareLinkNotFound},
+		{"link of a deleted task", "link-orphan", "owner", application.ErrShareLinkNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskRepo := &mockTaskRepositoryForAttachments{mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+				"task-1": {ID: "task-1", OwnerID: "owner"},
+			}}}
+			linkRepo := &mockShareLinkRepository{links: map[string]*application.ShareLink{
+				"link-1":      {ID: "link-1", TaskID: "task-1", ExpiresAt: shareLinkNow.Add(time.Hour)},
+				"link-orphan": {ID: "link-orphan", TaskID: "deleted", ExpiresAt: shareLinkNow.Add(time.Hour)},
+			}}
+			uc := NewRevokeShareLinkUseCase(linkRepo, taskRepo)
+			uc.now = func() time.Time { return shareLinkNow }
+
+			err := uc.Execute(context.Background(), tt.linkID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if revoked := linkRepo.links["link-1"].RevokedAt != nil; revoked != (tt.wantErr == nil) {
+				t.Errorf("link-1 revoked = %v after Execute() error %v", revoked, err)
+			}
+		})
+	}
+
+	t.Run("revoking twice", func(t *testing.T) {
+		taskRepo := &mockTaskRepositoryForAttachments{mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+			"task-1": {ID: "task-1", OwnerID: "owner"},
+		}}}
+		linkRepo := &mockShareLinkRepository{links: map[string]*application.ShareLink{
+			"link-1": {ID: "link-1", TaskID: "task-1", ExpiresAt: shareLinkNow.Add(time.Hour)},
+		}}
+		uc := NewRevokeShareLinkUseCase(linkRepo, taskRepo)
+
+		if err := uc.Execute(context.Background(), "link-1", "owner"); err != nil {
+			t.Fatalf("first Execute() error: %v", err)
+		}
+		if err := uc.Execute(context.Background(), "link-1", "owner"); !errors.Is(err, application.ErrShareLinkNotFound) {
+			t.Errorf("second Execute() error = %v, want %v", err, application.ErrShareLinkNotFound)
+		}
+	})
+}
+
+func TestViewSharedTaskUseCase_Execute(t *testing.T) {
+	revokedAt := shareLinkNow.Add(-time.Minute)
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"active link", "active", nil},
+		{"expired link", "expired", application.ErrShareLinkNotFound},
+		{"revoked link", "revoked", application.ErrShareLinkNotFound},
+		{"link of a deleted task", "orphan", application.ErrShareLinkNotFound},
+		{"unknown token", "unknown", application.ErrShareLinkNotFound},
+		{"empty token", "", application.ErrShareLinkNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskRepo := &mockTaskRepositoryForAttachments{mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+				"task-1": {ID: "task-1", OwnerID: "owner"},
+			}}}
+			linkRepo := &mockShareLinkRepository{links: map[string]*application.ShareLink{
+				"l1": {ID: "l1", TaskID: "task-1", TokenHash: service.HashChallengeToken("active"), ExpiresAt: shareLinkNow.Add(time.Hour)},
+				"l2": {ID: "l2", TaskID: "task-1", TokenHash: service.HashChallengeToken("expired"), ExpiresAt: shareLinkNow.Add(-time.Hour)},
+				"l3": {ID: "l3", TaskID: "task-1", TokenHash: service.HashChallengeToken("revoked"), ExpiresAt: shareLinkNow.Add(time.Hour), RevokedAt: &revokedAt},
+				"l4": {ID: "l4", TaskID: "deleted", TokenHash: service.HashChallengeToken("orphan"), ExpiresAt: shareLinkNow.Add(time.Hour)},
+			}}
+			uc := NewViewSharedTaskUseCase(linkRepo, taskRepo)
+			uc.now = func() time.Time { return shareLinkNow }
+
+			task, link, err := uc.Execute(context.Background(), tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (task.ID != "task-1" || link.ID != "l1") {
+				t.Errorf("Execute() = %v, %v; want task-1 through l1", task.ID, link.ID)
+			}
+		})
+	}
+}
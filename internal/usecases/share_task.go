@@ -3,36 +3,60 @@ package usecases
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
 	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
 	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
 )
 
+// maxShareRecipients bounds how many users a task is shared with in a single request
+const maxShareRecipients = 20
+
+var (
+	// ErrShareTaskPermissionDenied is returned when a user who isn't the owner of a task tries to
+	// share it
+	ErrShareTaskPermissionDenied = errors.New("only the task owner can share the task")
+
+	// ErrTooManyShareRecipients is returned when a task is shared with more than
+	// maxShareRecipients users at once
+	ErrTooManyShareRecipients = fmt.Errorf("a task can be shared with at most %d users at once", maxShareRecipients)
+)
+
 // ShareTaskUseCase handles sharing a task with another user
 type ShareTaskUseCase struct {
 	taskRepo    repository.TaskRepository
 	shareRepo   repository.ShareRepository
 	taskService *service.TaskService
+	events      event.Publisher
 }
 
 // NewShareTaskUseCase creates a new ShareTaskUseCase
-func NewShareTaskUseCase(taskRepo repository.TaskRepository, shareRepo repository.ShareRepository, taskService *service.TaskService) *ShareTaskUseCase {
+func NewShareTaskUseCase(taskRepo repository.TaskRepository, shareRepo repository.ShareRepository, taskService *service.TaskService, events event.Publisher) *ShareTaskUseCase {
 	return &ShareTaskUseCase{
 		taskRepo:    taskRepo,
 		shareRepo:   shareRepo,
 		taskService: taskService,
+		events:      events,
 	}
 }
 
-// Execute shares a task with a user
-func (uc *ShareTaskUseCase) Execute(ctx context.Context, taskID, ownerID, shareWithUserID string) error {
+// Execute shares a task with a user. Sharing it again with the same user succeeds without
+// changing anything.
+func (uc *ShareTaskUseCase) Execute(ctx context.Context, taskID, ownerID, shareWithUserID string) (err error) {
+	ctx, end := tracer.Start(ctx, "ShareTask")
+	defer func() { end(err) }()
+
 	// Check if requesting user is the owner
 	canModify, err := uc.taskService.CanUserModifyTask(ctx, taskID, ownerID)
 	if err != nil {
 		return err
 	}
 	if !canModify {
-		return errors.New("only the task owner can share the task")
+		return ErrShareTaskPermissionDenied
 	}
 
 	// Cannot share with self
@@ -41,9 +65,155 @@ func (uc *ShareTaskUseCase) Execute(ctx context.Context, taskID, ownerID, shareW
 		return err
 	}
 	if task.OwnerID == shareWithUserID {
-		return errors.New("cannot share task with yourself")
+		return application.ErrCannotShareTaskToSelf
+	}
+
+	// Sharing again changes nothing, so the user isn't notified again
+	invited, err := uc.shareRepo.FindInvitedUserIDs(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(invited, shareWithUserID) {
+		return nil
 	}
 
 	// Share the task
-	return uc.shareRepo.Share(ctx, taskID, shareWithUserID)
+	if err := uc.shareRepo.Share(ctx, taskID, shareWithUserID); err != nil {
+		return err
+	}
+
+	uc.events.Publish(ctx, event.TaskShared{TaskEvent: event.NewTaskEvent(task, ownerID), SharedWithID: shareWithUserID})
+
+	return nil
+}
+
+// ShareTaskWithUsersUseCase handles inviting several users to a task at once
+type ShareTaskWithUsersUseCase struct {
+	taskRepo    repository.TaskRepository
+	shareRepo   repository.ShareRepository
+	userRepo    repository.UserRepository
+	transactor  repository.Transactor
+	taskService TaskServiceInterface
+	events      event.Publisher
+}
+
+// NewShareTaskWithUsersUseCase creates a new ShareTaskWithUsersUseCase
+func NewShareTaskWithUsersUseCase(
+	taskRepo repository.TaskRepository,
+	shareRepo repository.ShareRepository,
+	userRepo repository.UserRepository,
+	transactor repository.Transactor,
+	taskService TaskServiceInterface,
+	events event.Publisher,
+) *ShareTaskWithUsersUseCase {
+	return &ShareTaskWithUsersUseCase{
+		taskRepo:    taskRepo,
+		shareRepo:   shareRepo,
+		userRepo:    userRepo,
+		transactor:  transactor,
+		taskService: taskService,
+		events:      events,
+	}
+}
+
+// Execute invites the users given by recipients, emails or user IDs, to a task of ownerID and
+// returns the outcome for each of them, in order. Users who don't exist, the owner and users
+// already invited are left out with the reason in their result; the others are all invited in
+// one transaction. The error is only about the request as a whole.
+func (uc *ShareTaskWithUsersUseCase) Execute(ctx context.Context, taskID, ownerID string, recipients []string) (results []*application.ShareResult, err error) {
+	ctx, end := tracer.Start(ctx, "ShareTaskWithUsers")
+	defer func() { end(err) }()
+
+	recipients = uniqueRecipients(recipients)
+	if len(recipients) > maxShareRecipients {
+		return nil, ErrTooManyShareRecipients
+	}
+
+	var task *application.Task
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		results = nil
+
+		err := checkTaskAccess(ctx, uc.taskRepo, uc.taskService, taskID, ownerID, true)
+		if errors.Is(err, ErrTaskOwnerRequired) {
+			return ErrShareTaskPermissionDenied
+		}
+		if err != nil {
+			return err
+		}
+		if task, err = uc.taskRepo.FindByID(ctx, taskID); err != nil {
+			return err
+		}
+
+		invitedIDs, err := uc.shareRepo.FindInvitedUserIDs(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		invited := make(map[string]bool, len(invitedIDs))
+		for _, userID := range invitedIDs {
+			invited[userID] = true
+		}
+
+		for _, recipient := range recipients {
+			result := &application.ShareResult{Recipient: recipient}
+			results = append(results, result)
+
+			if result.User, err = uc.findRecipient(ctx, recipient); err != nil {
+				return err
+			}
+			switch {
+			case result.User == nil:
+				result.Err = application.ErrUserNotFound
+			case result.User.ID == task.OwnerID:
+				result.Err = application.ErrCannotShareTaskToSelf
+			case invited[result.User.ID]:
+				// Also the same user given by both email and ID
+				result.Err = application.ErrTaskAlreadyShared
+			default:
+				if err := uc.shareRepo.Share(ctx, taskID, result.User.ID); err != nil {
+					return err
+				}
+				invited[result.User.ID] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		if result.Err == nil {
+			uc.events.Publish(ctx, event.TaskShared{TaskEvent: event.NewTaskEvent(task, ownerID), SharedWithID: result.User.ID})
+		}
+	}
+	return results, nil
+}
+
+// findRecipient finds the user a recipient stands for: by email when it has an @, by ID
+// otherwise. It returns nil when there is no such user.
+func (uc *ShareTaskWithUsersUseCase) findRecipient(ctx context.Context, recipient string) (user *application.User, err error) {
+	if strings.Contains(recipient, "@") {
+		user, err = uc.userRepo.FindByEmail(ctx, recipient)
+	} else {
+		user, err = uc.userRepo.FindByID(ctx, recipient)
+	}
+	if errors.Is(err, application.ErrUserNotFound) {
+		return nil, nil
+	}
+	return user, err
+}
+
+// uniqueRecipients returns the recipients trimmed, without blanks and repetitions, in order
+func uniqueRecipients(recipients []string) []string {
+	seen := make(map[string]bool, len(recipients))
+	var unique []string
+	for _, recipient := range recipients {
+		recipient = strings.TrimSpace(recipient)
+		if recipient == "" || seen[recipient] {
+			continue
+		}
+		seen[recipient] = true
+		unique = append(unique, recipient)
+	}
+	return unique
 }
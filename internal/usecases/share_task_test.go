@@ -3,6 +3,7 @@ package usecases
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
 
 	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
@@ -25,7 +26,7 @@ func TestShareTaskUseCase_Execute_Success(t *testing.T) {
 	shareRepo := &mockShareRepositoryForShare{}
 	taskService := service.NewTaskService(taskRepo, shareRepo)
 
-	useCase := NewShareTaskUseCase(taskRepo, shareRepo, taskService)
+	useCase := NewShareTaskUseCase(taskRepo, shareRepo, taskService, &recordingPublisher{})
 
 	err := useCase.Execute(ctx, taskID, ownerID, shareWithUserID)
 	if err != nil {
@@ -38,6 +39,28 @@ func TestShareTaskUseCase_Execute_Success(t *testing.T) {
 	}
 }
 
+func TestShareTaskUseCase_Execute_SharedTwice(t *testing.T) {
+	ctx := context.Background()
+	task, _ := application.NewTask("task-1", "Test Task", "Description", application.StatusPending, "user-1", "")
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-3"}}}
+	publisher := &recordingPublisher{}
+	useCase := NewShareTaskUseCase(taskRepo, shareRepo, service.NewTaskService(taskRepo, shareRepo), publisher)
+
+	// Inviting a user twice, or a user who accepted, succeeds and notifies nobody again
+	for _, userID := range []string{"user-2", "user-2", "user-3"} {
+		if err := useCase.Execute(ctx, "task-1", "user-1", userID); err != nil {
+			t.Fatalf("Execute(%s) error: %v", userID, err)
+		}
+	}
+	if got := shareRepo.pending["task-1"]; !slices.Equal(got, []string{"user-2"}) {
+		t.Errorf("pending = %v, want a single invitation of user-2", got)
+	}
+	if len(publisher.events) != 1 {
+		t.Errorf("published %d events, want 1", len(publisher.events))
+	}
+}
+
 func TestShareTaskUseCase_Execute_OnlyOwnerCanShare(t *testing.T) {
 	ctx := context.Background()
 	taskID := "task-1"
@@ -55,7 +78,7 @@ func TestShareTaskUseCase_Execute_OnlyOwnerCanShare(t *testing.T) {
 	shareRepo := &mockShareRepositoryForShare{}
 	taskService := service.NewTaskService(taskRepo, shareRepo)
 
-	useCase := NewShareTaskUseCase(taskRepo, shareRepo, taskService)
+	useCase := NewShareTaskUseCase(taskRepo, shareRepo, taskService, &recordingPublisher{})
 
 	// Non-owner tries to share
 	err := useCase.Execute(ctx, taskID, nonOwnerID, shareWithUserID)
@@ -87,7 +110,7 @@ func TestShareTaskUseCase_Execute_CannotShareWithSelf(t *testing.T) {
 	shareRepo := &mockShareRepositoryForShare{}
 	taskService := service.NewTaskService(taskRepo, shareRepo)
 
-	useCase := NewShareTaskUseCase(taskRepo, shareRepo, taskService)
+	useCase := NewShareTaskUseCase(taskRepo, shareRepo, taskService, &recordingPublisher{})
 
 	// Try to share with self
 	err := useCase.Execute(ctx, taskID, ownerID, ownerID)
@@ -116,11 +139,11 @@ func TestShareTaskUseCase_Execute_TaskNotFound(t *testing.T) {
 	shareRepo := &mockShareRepositoryForShare{}
 	taskService := service.NewTaskService(taskRepo, shareRepo)
 
-	useCase := NewShareTaskUseCase(taskRepo, shareRepo, taskService)
+	useCase := NewShareTaskUseCase(taskRepo, shareRepo, taskService, &recordingPublisher{})
 
 	err := useCase.Execute(ctx, taskID, ownerID, shareWithUserID)
-	if err == nil {
-		t.Error("Expected error when task not found")
+	if !errors.Is(err, application.ErrTaskNotFound) {
+		t.Errorf("Expected ErrTaskNotFound, got %v", err)
 	}
 }
 
@@ -150,7 +173,7 @@ func (m *mockTaskRepositoryForShare) Delete(ctx context.Context, id string) erro
 func (m *mockTaskRepositoryForShare) FindByID(ctx context.Context, id string) (*application.Task, error) {
 	task, exists := m.tasks[id]
 	if !exists {
-		return nil, errors.New("task not found")
+		return nil, application.ErrTaskNotFound
 	}
 	return task, nil
 }
@@ -169,13 +192,55 @@ func (m *mockTaskRepositoryForShare) FindSharedWithUser(ctx context.Context, use
 	return []*application.Task{}, nil
 }
 
+func (m *mockTaskRepositoryForShare) FindByProjectID(ctx context.Context, projectID string) ([]*application.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskRepositoryForShare) FindByImagePath(ctx context.Context, imagePath string) ([]*application.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskRepositoryForShare) CreateMany(ctx context.Context, tasks []*application.Task) error {
+	return nil
+}
+
 type mockShareRepositoryForShare struct {
-	shared bool
-	shares map[string][]string
+	shared  bool
+	shares  map[string][]string
+	pending map[string][]string
 }
 
 func (m *mockShareRepositoryForShare) Share(ctx context.Context, taskID, userID string) error {
 	m.shared = true
+	if m.pending == nil {
+		m.pending = make(map[string][]string)
+	}
+	if !slices.Contains(m.shares[taskID], userID) && !slices.Contains(m.pending[taskID], userID) {
+		m.pending[taskID] = append(m.pending[taskID], userID)
+	}
+	return nil
+}
+
+func (m *mockShareRepositoryForShare) FindInvitedUserIDs(ctx context.Context, taskID string) ([]string, error) {
+	return append(slices.Clone(m.shares[taskID]), m.pending[taskID]...), nil
+}
+
+func (m *mockShareRepositoryForShare) FindPendingInvitations(ctx context.Context, userID string) ([]*application.ShareInvitation, error) {
+	var invitations []*application.ShareInvitation
+	for taskID, users := range m.pending {
+		for _, u := range users {
+			if u == userID {
+				invitations = append(invitations, &application.ShareInvitation{TaskID: taskID, UserID: userID})
+			}
+		}
+	}
+	return invitations, nil
+}
+
+func (m *mockShareRepositoryForShare) AcceptInvitation(ctx context.Context, taskID, userID string) error {
+	if err := m.DeclineInvitation(ctx, taskID, userID); err != nil {
+		return err
+	}
 	if m.shares == nil {
 		m.shares = make(map[string][]string)
 	}
@@ -183,15 +248,43 @@ func (m *mockShareRepositoryForShare) Share(ctx context.Context, taskID, userID
 	return nil
 }
 
+func (m *mockShareRepositoryForShare) DeclineInvitation(ctx context.Context, taskID, userID string) error {
+	for i, u := range m.pending[taskID] {
+		if u == userID {
+			m.pending[taskID] = append(m.pending[taskID][:i], m.pending[taskID][i+1:]...)
+			return nil
+		}
+	}
+	return application.ErrInvitationNotFound
+}
+
 func (m *mockShareRepositoryForShare) Unshare(ctx context.Context, taskID, userID string) error {
+	isUser := func(u string) bool { return u == userID }
+	if users, ok := m.shares[taskID]; ok {
+		m.shares[taskID] = slices.DeleteFunc(users, isUser)
+	}
+	if users, ok := m.pending[taskID]; ok {
+		m.pending[taskID] = slices.DeleteFunc(users, isUser)
+	}
 	return nil
 }
 
-func (m *mockShareRepositoryForShare) FindSharedUsers(ctx context.Context, taskID string) ([]string, error) {
-	if users, ok := m.shares[taskID]; ok {
-		return users, nil
+func (m *mockShareRepositoryForShare) FindSharedUsers(ctx context.Context, taskID string) ([]*application.SharedUser, error) {
+	users := []*application.SharedUser{}
+	for _, userID := range m.shares[taskID] {
+		users = append(users, &application.SharedUser{UserID: userID, Name: "Name of " + userID, Email: userID + "@example.com"})
 	}
-	return []string{}, nil
+	return users, nil
+}
+
+func (m *mockShareRepositoryForShare) FindSharedUsersByTaskIDs(ctx context.Context, taskIDs []string) (map[string][]string, error) {
+	shared := make(map[string][]string)
+	for _, taskID := range taskIDs {
+		if users, ok := m.shares[taskID]; ok {
+			shared[taskID] = users
+		}
+	}
+	return shared, nil
 }
 
 func (m *mockShareRepositoryForShare) IsSharedWith(ctx context.Context, taskID, userID string) (bool, error) {
@@ -209,3 +302,87 @@ func (m *mockShareRepositoryForShare) DeleteAllShares(ctx context.Context, taskI
 	delete(m.shares, taskID)
 	return nil
 }
+
+func TestShareTaskWithUsersUseCase_Execute(t *testing.T) {
+	newUseCase := func() (*ShareTaskWithUsersUseCase, *mockShareRepositoryForShare, *recordingPublisher) {
+		task, _ := application.NewTask("task-1", "Relatório", "", application.StatusPending, "user-1", "")
+		taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+		shareRepo := &mockShareRepositoryForShare{
+			shares:  map[string][]string{"task-1": {"user-2"}},
+			pending: map[string][]string{"task-1": {"user-3"}},
+		}
+		userRepo := &mockUserRepositoryForLogin{users: map[string]*application.User{
+			"user-1": {ID: "user-1", Email: "ana@example.com"},
+			"user-2": {ID: "user-2", Email: "bia@example.com"},
+			"user-3": {ID: "user-3", Email: "caio@example.com"},
+			"user-4": {ID: "user-4", Email: "davi@example.com"},
+			"user-5": {ID: "user-5", Email: "eva@example.com"},
+		}}
+		publisher := &recordingPublisher{}
+		uc := NewShareTaskWithUsersUseCase(taskRepo, shareRepo, userRepo, &mockTransactor{}, service.NewTaskService(taskRepo, shareRepo), publisher)
+		return uc, shareRepo, publisher
+	}
+
+	tests := []struct {
+		name        string
+		userID      string
+		recipients  []string
+		wantErr     error
+		want        []error
+		wantInvited []string
+	}{
+		{
+			name:        "by email and ID",
+			userID:      "user-1",
+			recipients:  []string{"davi@example.com", " user-5 ", ""},
+			want:        []error{nil, nil},
+			wantInvited: []string{"user-3", "user-4", "user-5"},
+		},
+		{
+			name:        "some left out",
+			userID:      "user-1",
+			recipients:  []string{"nobody@example.com", "ana@example.com", "user-2", "caio@example.com", "user-4", "davi@example.com", "user-4"},
+			want:        []error{application.ErrUserNotFound, application.ErrCannotShareTaskToSelf, application.ErrTaskAlreadyShared, application.ErrTaskAlreadyShared, nil, application.ErrTaskAlreadyShared},
+			wantInvited: []string{"user-3", "user-4"},
+		},
+		{
+			name:        "not the owner",
+			userID:      "user-2",
+			recipients:  []string{"user-4"},
+			wantErr:     ErrShareTaskPermissionDenied,
+			wantInvited: []string{"user-3"},
+		},
+		{
+			name:        "too many",
+			userID:      "user-1",
+			recipients:  []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "20", "21"},
+			wantErr:     ErrTooManyShareRecipients,
+			wantInvited: []string{"user-3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, shareRepo, publisher := newUseCase()
+
+			results, err := uc.Execute(context.Background(), "task-1", tt.userID, tt.recipients)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(results) != len(tt.want) {
+				t.Fatalf("Execute() = %d results, want %d", len(results), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !errors.Is(results[i].Err, want) {
+					t.Errorf("result %d (%s) error = %v, want %v", i, results[i].Recipient, results[i].Err, want)
+				}
+			}
+			if got := shareRepo.pending["task-1"]; !slices.Equal(got, tt.wantInvited) {
+				t.Errorf("invited = %v, want %v", got, tt.wantInvited)
+			}
+			if len(publisher.events) != len(tt.wantInvited)-1 {
+				t.Errorf("published %d events, want one per user invited", len(publisher.events))
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// GetShareeIDsUseCase handles looking up the users tasks are shared with, so clients can link a
+// task to its shares without asking for each task apart
+type GetShareeIDsUseCase struct {
+	shareRepo repository.ShareRepository
+}
+
+// NewGetShareeIDsUseCase creates a new GetShareeIDsUseCase
+func NewGetShareeIDsUseCase(shareRepo repository.ShareRepository) *GetShareeIDsUseCase {
+	return &GetShareeIDsUseCase{
+		shareRepo: shareRepo,
+	}
+}
+
+// Execute returns the IDs of the users that accepted to share each task, by task ID. Only the
+// owner manages who a task is shared with, so tasks of other owners are left out, as are tasks
+// without shares.
+func (uc *GetShareeIDsUseCase) Execute(ctx context.Context, userID string, tasks []*application.Task) (map[string][]string, error) {
+	var taskIDs []string
+	for _, task := range tasks {
+		if task.OwnerID == userID {
+			taskIDs = append(taskIDs, task.ID)
+		}
+	}
+	if len(taskIDs) == 0 {
+		return map[string][]string{}, nil
+	}
+
+	return uc.shareRepo.FindSharedUsersByTaskIDs(ctx, taskIDs)
+}
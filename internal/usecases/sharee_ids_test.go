@@ -0,0 +1,54 @@
+package usecases
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestGetShareeIDsUseCase_Execute(t *testing.T) {
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{
+		"task-1": {"user-2", "user-3"},
+		"task-2": {"user-1"},
+	}}
+	uc := NewGetShareeIDsUseCase(shareRepo)
+
+	tests := []struct {
+		name  string
+		tasks []*application.Task
+		want  map[string][]string
+	}{
+		{
+			name: "owned tasks",
+			tasks: []*application.Task{
+				{ID: "task-1", OwnerID: "user-1"},
+				{ID: "task-3", OwnerID: "user-1"},
+			},
+			want: map[string][]string{"task-1": {"user-2", "user-3"}},
+		},
+		{
+			name:  "tasks of other owners are left out",
+			tasks: []*application.Task{{ID: "task-2", OwnerID: "user-4"}},
+			want:  map[string][]string{},
+		},
+		{
+			name:  "no tasks",
+			tasks: nil,
+			want:  map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.Execute(context.Background(), "user-1", tt.tasks)
+			if err != nil {
+				t.Fatalf("Execute() error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Execute() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// ErrSnoozePermissionDenied is returned when a user who can see a task tries to snooze it; only
+// the owner's list hides snoozed tasks
+var ErrSnoozePermissionDenied = errors.New("only the owner can snooze a task")
+
+// SnoozeTaskUseCase handles owners snoozing tasks, hiding them from their task list until the
+// snooze ends
+type SnoozeTaskUseCase struct {
+	taskRepo    repository.TaskRepository
+	taskService TaskServiceInterface
+	events      event.Publisher
+	now         func() time.Time
+}
+
+// NewSnoozeTaskUseCase creates a new SnoozeTaskUseCase
+func NewSnoozeTaskUseCase(taskRepo repository.TaskRepository, taskService TaskServiceInterface, events event.Publisher) *SnoozeTaskUseCase {
+	return &SnoozeTaskUseCase{
+		taskRepo:    taskRepo,
+		taskService: taskService,
+		events:      events,
+		now:         time.Now,
+	}
+}
+
+// Execute snoozes a task of userID until until, or wakes it when until is nil, and returns the
+// task. It returns application.ErrInvalidSnooze when until isn't in the future.
+func (uc *SnoozeTaskUseCase) Execute(ctx context.Context, taskID, userID string, until *time.Time) (*application.Task, error) {
+	if err := checkTaskAccess(ctx, uc.taskRepo, uc.taskService, taskID, userID, false); err != nil {
+		return nil, err
+	}
+	task, err := uc.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.OwnerID != userID {
+		return nil, ErrSnoozePermissionDenied
+	}
+
+	now := uc.now()
+	if until == nil {
+		task.Wake(now)
+	} else if err := task.Snooze(*until, now); err != nil {
+		return nil, err
+	}
+
+	if err := uc.taskRepo.Update(ctx, task); err != nil {
+		return nil, err
+	}
+
+	uc.events.Publish(ctx, event.TaskUpdated{TaskEvent: event.NewTaskEvent(task, userID)})
+
+	return task, nil
+}
@@ -0,0 +1,89 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestSnoozeTaskUseCase_Execute(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	tomorrow := now.Add(24 * time.Hour)
+	past := now.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		taskID  string
+		userID  string
+		until   *time.Time
+		wantErr error
+	}{
+		{"owner snoozes", "task-1", "owner", &tomorrow, nil},
+		{"owner wakes", "task-1", "owner", nil, nil},
+		{"snooze in the past", "task-1", "owner", &past, application.ErrInvalidSnooze},
+		{"shared user", "task-1", "viewer", &tomorrow, ErrSnoozePermissionDenied},
+		{"no access", "task-1", "stranger", &tomorrow, ErrTaskUnavailable},
+		{"unknown task", "missing", "owner", &tomorrow, ErrTaskUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskRepo := &mockTaskRepositoryForAttachments{mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+				"task-1": {ID: "task-1", OwnerID: "owner"},
+			}}}
+			taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"owner": true, "viewer": true}}
+			publisher := &recordingPublisher{}
+			useCase := NewSnoozeTaskUseCase(taskRepo, taskService, publisher)
+			useCase.now = func() time.Time { return now }
+
+			task, err := useCase.Execute(context.Background(), tt.taskID, tt.userID, tt.until)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(publisher.events) != 0 {
+					t.Errorf("events = %v, want none", publisher.names())
+				}
+				return
+			}
+
+			if task.IsSnoozed(now) != (tt.until != nil) {
+				t.Errorf("IsSnoozed() = %v, want %v", task.IsSnoozed(now), tt.until != nil)
+			}
+			if stored := taskRepo.tasks[tt.taskID]; stored.IsSnoozed(now) != task.IsSnoozed(now) {
+				t.Error("snooze was not saved")
+			}
+			if names := publisher.names(); len(names) != 1 || names[0] != "task.updated" {
+				t.Errorf("events = %v, want [task.updated]", names)
+			}
+		})
+	}
+}
+
+func TestListTasksUseCase_LeavesOutSnoozed(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	later, earlier := now.Add(time.Hour), now.Add(-time.Hour)
+	taskRepo := &mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+		"awake":   {ID: "awake", OwnerID: "owner"},
+		"snoozed": {ID: "snoozed", OwnerID: "owner", SnoozedUntil: &later},
+		"expired": {ID: "expired", OwnerID: "owner", SnoozedUntil: &earlier},
+	}}
+	useCase := NewListTasksUseCase(taskRepo)
+	useCase.now = func() time.Time { return now }
+
+	tasks, err := useCase.Execute(context.Background(), "owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("got %d tasks, want the awake and expired ones", len(tasks))
+	}
+	for _, task := range tasks {
+		if task.ID == "snoozed" {
+			t.Error("snoozed task should be left out of the list")
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// StorageQuotaUseCase tracks the disk space taken by the uploads of each user and enforces the
+// per-user and server storage quotas, and the daily image quota
+type StorageQuotaUseCase struct {
+	fileRepo    repository.StoredFileRepository
+	userLimit   int64
+	globalLimit int64
+	creations   CreationQuotaUseCaseInterface
+}
+
+// NewStorageQuotaUseCase creates a new StorageQuotaUseCase allowing userLimit bytes for each user
+// and globalLimit bytes for all users together. A limit of zero or less is not enforced. Images
+// are also charged to the image quota of creations (nil for no quota).
+func NewStorageQuotaUseCase(fileRepo repository.StoredFileRepository, userLimit, globalLimit int64, creations CreationQuotaUseCaseInterface) *StorageQuotaUseCase {
+	return &StorageQuotaUseCase{
+		fileRepo:    fileRepo,
+		userLimit:   userLimit,
+		globalLimit: globalLimit,
+		creations:   creations,
+	}
+}
+
+// Reserve records a file about to be written for a user. It returns
+// *application.StorageQuotaExceededError when the file doesn't fit the quotas, and
+// *application.CreationQuotaExceededError when an image doesn't fit the daily image quota.
+// Releasing an image gives back its space but not the image quota: it was uploaded all the same.
+func (uc *StorageQuotaUseCase) Reserve(ctx context.Context, userID string, kind application.StoredFileKind, name string, size int64) error {
+	file, err := application.NewStoredFile(kind, name, userID, size)
+	if err != nil {
+		return err
+	}
+
+	var usageID string
+	if kind == application.StoredFileImage {
+		if usageID, err = consumeCreation(ctx, uc.creations, userID, application.CreationImage, 1); err != nil {
+			return err
+		}
+	}
+
+	created, err := uc.fileRepo.CreateIfWithinQuota(ctx, file, uc.userLimit, uc.globalLimit)
+	if err != nil {
+		releaseCreation(ctx, uc.creations, usageID)
+		return fmt.Errorf("failed to record stored file: %w", err)
+	}
+	if created {
+		return nil
+	}
+	releaseCreation(ctx, uc.creations, usageID)
+
+	usage, err := uc.fileRepo.UsageByUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve storage usage: %w", err)
+	}
+	if uc.userLimit > 0 && usage.Bytes+size > uc.userLimit {
+		return &application.StorageQuotaExceededError{Limit: uc.userLimit, Used: usage.Bytes, Size: size}
+	}
+	return &application.StorageQuotaExceededError{Limit: uc.globalLimit, Used: usage.Bytes, Size: size, Global: true}
+}
+
+// Release forgets a file that was deleted, or never written, giving its space back
+func (uc *StorageQuotaUseCase) Release(ctx context.Context, kind application.StoredFileKind, name string) error {
+	return uc.fileRepo.Delete(ctx, kind, name)
+}
+
+// Owns reports whether a file was uploaded by a user. A file with no record belongs to nobody.
+func (uc *StorageQuotaUseCase) Owns(ctx context.Context, userID string, kind application.StoredFileKind, name string) (bool, error) {
+	file, err := uc.fileRepo.FindByName(ctx, kind, name)
+	if errors.Is(err, application.ErrStoredFileNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to retrieve stored file: %w", err)
+	}
+	return file.UserID == userID, nil
+}
+
+// TotalUsage returns the space taken by the uploads of all users
+func (uc *StorageQuotaUseCase) TotalUsage(ctx context.Context) (*application.StorageUsage, error) {
+	return uc.fileRepo.TotalUsage(ctx)
+}
+
+// StorageStatus is the storage usage of a user and their quota
+type StorageStatus struct {
+	UsedBytes  int64
+	Files      int
+	QuotaBytes int64 // zero when there is no quota
+}
+
+// GetStorageUsageUseCase handles reading the storage usage of a user
+type GetStorageUsageUseCase struct {
+	fileRepo  repository.StoredFileRepository
+	userLimit int64
+}
+
+// NewGetStorageUsageUseCase creates a new GetStorageUsageUseCase reporting userLimit as the quota
+func NewGetStorageUsageUseCase(fileRepo repository.StoredFileRepository, userLimit int64) *GetStorageUsageUseCase {
+	return &GetStorageUsageUseCase{
+		fileRepo:  fileRepo,
+		userLimit: userLimit,
+	}
+}
+
+// Execute returns the space taken by the uploads of a user and their quota
+func (uc *GetStorageUsageUseCase) Execute(ctx context.Context, userID string) (*StorageStatus, error) {
+	usage, err := uc.fileRepo.UsageByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &StorageStatus{UsedBytes: usage.Bytes, Files: usage.Files}
+	if uc.userLimit > 0 {
+		status.QuotaBytes = uc.userLimit
+	}
+	return status, nil
+}
@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// mockStoredFileRepository keeps the stored files in memory, enforcing the quotas like the SQLite repository
+type mockStoredFileRepository struct {
+	files map[string]*application.StoredFile
+}
+
+func newMockStoredFileRepository() *mockStoredFileRepository {
+	return &mockStoredFileRepository{files: map[string]*application.StoredFile{}}
+}
+
+func (m *mockStoredFileRepository) CreateIfWithinQuota(ctx context.Context, file *application.StoredFile, userLimit, globalLimit int64) (bool, error) {
+	user, _ := m.UsageByUser(ctx, file.UserID)
+	total, _ := m.TotalUsage(ctx)
+	if (userLimit > 0 && user.Bytes+file.Size > userLimit) || (globalLimit > 0 && total.Bytes+file.Size > globalLimit) {
+		return false, nil
+	}
+	m.files[string(file.Kind)+"/"+file.Name] = file
+	return true, nil
+}
+
+func (m *mockStoredFileRepository) FindByName(ctx context.Context, kind application.StoredFileKind, name string) (*application.StoredFile, error) {
+	file, ok := m.files[string(kind)+"/"+name]
+	if !ok {
+		return nil, application.ErrStoredFileNotFound
+	}
+	return file, nil
+}
+
+func (m *mockStoredFileRepository) Delete(ctx context.Context, kind application.StoredFileKind, name string) error {
+	delete(m.files, string(kind)+"/"+name)
+	return nil
+}
+
+func (m *mockStoredFileRepository) UsageByUser(ctx context.Context, userID string) (*application.StorageUsage, error) {
+	usage := &application.StorageUsage{}
+	for _, file := range m.files {
+		if file.UserID == userID {
+			usage.Bytes += file.Size
+			usage.Files++
+		}
+	}
+	return usage, nil
+}
+
+func (m *mockStoredFileRepository) TotalUsage(ctx context.Context) (*application.StorageUsage, error) {
+	usage := &application.StorageUsage{}
+	for _, file := range m.files {
+		usage.Bytes += file.Size
+		usage.Files++
+	}
+	return usage, nil
+}
+
+func TestStorageQuotaUseCase_Reserve(t *testing.T) {
+	repo := newMockStoredFileRepository()
+	uc := NewStorageQuotaUseCase(repo, 100, 150, nil)
+	ctx := context.Background()
+
+	if err := uc.Reserve(ctx, "user-1", application.StoredFileImage, "a.png", 80); err != nil {
+		t.Fatalf("Reserve() error: %v", err)
+	}
+
+	var exceeded *application.StorageQuotaExceededError
+	err := uc.Reserve(ctx, "user-1", application.StoredFileAttachment, "b.pdf", 30)
+	if !errors.As(err, &exceeded) || exceeded.Global || exceeded.Used != 80 || exceeded.Size != 30 || exceeded.Limit != 100 {
+		t.Fatalf("Reserve() error = %v, want the user quota exceeded", err)
+	}
+
+	if err := uc.Reserve(ctx, "user-2", application.StoredFileImage, "c.png", 60); err != nil {
+		t.Fatalf("Reserve() error: %v", err)
+	}
+	err = uc.Reserve(ctx, "user-2", application.StoredFileImage, "d.png", 20)
+	if !errors.As(err, &exceeded) || !exceeded.Global || exceeded.Limit != 150 {
+		t.Fatalf("Reserve() error = %v, want the server quota exceeded", err)
+	}
+
+	if err := uc.Release(ctx, application.StoredFileImage, "a.png"); err != nil {
+		t.Fatalf("Release() error: %v", err)
+	}
+	if err := uc.Reserve(ctx, "user-1", application.StoredFileAttachment, "b.pdf", 30); err != nil {
+		t.Errorf("Reserve() after Release() error: %v", err)
+	}
+
+	if err := uc.Reserve(ctx, "user-1", "video", "e.mp4", 1); err == nil || errors.As(err, &exceeded) {
+		t.Errorf("Reserve() error = %v, want a validation error", err)
+	}
+}
+
+func TestStorageQuotaUseCase_Reserve_ImageQuota(t *testing.T) {
+	creations := NewCreationQuotaUseCase(newMockCreationUsageRepository(), CreationLimits{Images: 2})
+	uc := NewStorageQuotaUseCase(newMockStoredFileRepository(), 100, 0, creations)
+	ctx := context.Background()
+
+	// The image over the storage quota doesn't count against the image quota, attachments never do
+	if err := uc.Reserve(ctx, "user-1", application.StoredFileImage, "big.png", 200); err == nil {
+		t.Fatal("Reserve() of an image over the storage quota succeeded")
+	}
+	for _, name := range []string{"a.png", "b.png"} {
+		if err := uc.Reserve(ctx, "user-1", application.StoredFileImage, name, 10); err != nil {
+			t.Fatalf("Reserve(%s) error: %v", name, err)
+		}
+	}
+	if err := uc.Reserve(ctx, "user-1", application.StoredFileAttachment, "c.pdf", 10); err != nil {
+		t.Fatalf("Reserve() of an attachment error: %v", err)
+	}
+
+	// Releasing an image gives its space back, but it was uploaded all the same
+	if err := uc.Release(ctx, application.StoredFileImage, "a.png"); err != nil {
+		t.Fatalf("Release() error: %v", err)
+	}
+	var exceeded *application.CreationQuotaExceededError
+	if err := uc.Reserve(ctx, "user-1", application.StoredFileImage, "d.png", 10); !errors.As(err, &exceeded) || exceeded.Kind != application.CreationImage {
+		t.Errorf("Reserve() error = %v, want the image quota exceeded", err)
+	}
+}
+
+func TestGetStorageUsageUseCase_Execute(t *testing.T) {
+	repo := newMockStoredFileRepository()
+	repo.files["image/a.png"] = &application.StoredFile{Kind: application.StoredFileImage, Name: "a.png", UserID: "user-1", Size: 300}
+	repo.files["attachment/b.pdf"] = &application.StoredFile{Kind: application.StoredFileAttachment, Name: "b.pdf", UserID: "user-1", Size: 200}
+	repo.files["image/c.png"] = &application.StoredFile{Kind: application.StoredFileImage, Name: "c.png", UserID: "user-2", Size: 900}
+
+	tests := []struct {
+		name  string
+		limit int64
+		want  StorageStatus
+	}{
+		{"with quota", 1000, StorageStatus{UsedBytes: 500, Files: 2, QuotaBytes: 1000}},
+		{"without quota", 0, StorageStatus{UsedBytes: 500, Files: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := NewGetStorageUsageUseCase(repo, tt.limit).Execute(context.Background(), "user-1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *status != tt.want {
+				t.Errorf("Execute() = %+v, want %+v", *status, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+var (
+	// ErrTaskUnavailable is returned when a task doesn't exist or isn't visible to the user. It is
+	// application.ErrTaskNotFound, so both answer 404 without revealing which task IDs exist.
+	ErrTaskUnavailable = application.ErrTaskNotFound
+
+	// ErrTaskOwnerRequired is returned by checkTaskAccess when a user who can see a task asks for
+	// what only its owner may do. Each feature maps it to its own permission error.
+	ErrTaskOwnerRequired = errors.New("only the task owner can do this")
+)
+
+// checkTaskAccess checks that a user can see a task and, when ownerOnly is set, that they own it
+func checkTaskAccess(ctx context.Context, taskRepo repository.TaskRepository, taskService TaskServiceInterface, taskID, userID string, ownerOnly bool) error {
+	task, err := taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	canAccess, err := taskService.CanUserAccessTask(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+	if !canAccess {
+		return ErrTaskUnavailable
+	}
+
+	if ownerOnly && task.OwnerID != userID {
+		return ErrTaskOwnerRequired
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestCheckTaskAccess(t *testing.T) {
+	taskRepo := &mockTaskRepositoryForAttachments{mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+		"task-1": {ID: "task-1", OwnerID: "owner"},
+	}}}
+	taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"owner": true, "viewer": true}}
+
+	tests := []struct {
+		name      string
+		taskID    string
+		userID    string
+		ownerOnly bool
+		wantErr   error
+	}{
+		{"owner", "task-1", "owner", true, nil},
+		{"viewer reading", "task-1", "viewer", false, nil},
+		{"viewer changing", "task-1", "viewer", true, ErrTaskOwnerRequired},
+		{"stranger", "task-1", "stranger", false, ErrTaskUnavailable},
+		{"unknown task", "task-9", "owner", false, application.ErrTaskNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTaskAccess(context.Background(), taskRepo, taskService, tt.taskID, tt.userID, tt.ownerOnly)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkTaskAccess() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Attachments keep answering with their own error
+	err := checkAttachmentAccess(context.Background(), taskRepo, taskService, "task-1", "viewer", true)
+	if !errors.Is(err, ErrAttachmentPermissionDenied) {
+		t.Errorf("checkAttachmentAccess() error = %v, want %v", err, ErrAttachmentPermissionDenied)
+	}
+}
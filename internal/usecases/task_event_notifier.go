@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// TaskEventNotifier turns task events into in-app notifications, skipping the users who
+// turned them off in their settings. Its methods are event.Handler functions registered on
+// the dispatcher at startup.
+type TaskEventNotifier struct {
+	notificationRepo repository.NotificationRepository
+	shareRepo        repository.ShareRepository
+	settingsRepo     repository.UserSettingsRepository
+}
+
+// NewTaskEventNotifier creates a new TaskEventNotifier
+func NewTaskEventNotifier(notificationRepo repository.NotificationRepository, shareRepo repository.ShareRepository, settingsRepo repository.UserSettingsRepository) *TaskEventNotifier {
+	return &TaskEventNotifier{
+		notificationRepo: notificationRepo,
+		shareRepo:        shareRepo,
+		settingsRepo:     settingsRepo,
+	}
+}
+
+// NotifyTaskShared tells a user they were invited to a task, which they can accept or decline
+func (n *TaskEventNotifier) NotifyTaskShared(ctx context.Context, e event.DomainEvent) error {
+	shared, ok := e.(event.TaskShared)
+	if !ok {
+		return fmt.Errorf("unexpected event %T", e)
+	}
+
+	message := fmt.Sprintf("Você foi convidado para colaborar na tarefa \"%s\"", shared.Title)
+	return n.notify(ctx, shared.SharedWithID, application.NotificationTaskShared, shared.TaskID, message)
+}
+
+// NotifyTaskTransferred tells the new owner of a task it was transferred to them
+func (n *TaskEventNotifier) NotifyTaskTransferred(ctx context.Context, e event.DomainEvent) error {
+	transferred, ok := e.(event.TaskTransferred)
+	if !ok {
+		return fmt.Errorf("unexpected event %T", e)
+	}
+
+	message := fmt.Sprintf("A tarefa \"%s\" foi transferida para você", transferred.Title)
+	return n.notify(ctx, transferred.OwnerID, application.NotificationTaskTransferred, transferred.TaskID, message)
+}
+
+// NotifyTaskCompleted tells the users a task is shared with that it was completed
+func (n *TaskEventNotifier) NotifyTaskCompleted(ctx context.Context, e event.DomainEvent) error {
+	completed, ok := e.(event.TaskCompleted)
+	if !ok {
+		return fmt.Errorf("unexpected event %T", e)
+	}
+
+	users, err := n.shareRepo.FindSharedUsers(ctx, completed.TaskID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve shared users: %w", err)
+	}
+
+	message := fmt.Sprintf("A tarefa \"%s\" foi concluída", completed.Title)
+	for _, user := range users {
+		if user.UserID == completed.ActorID {
+			continue
+		}
+		if err := n.notify(ctx, user.UserID, application.NotificationTaskCompleted, completed.TaskID, message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NotifyTaskOverdue tells the owner of a task and the users it is shared with that it is overdue
+func (n *TaskEventNotifier) NotifyTaskOverdue(ctx context.Context, e event.DomainEvent) error {
+	overdue, ok := e.(event.TaskOverdue)
+	if !ok {
+		return fmt.Errorf("unexpected event %T", e)
+	}
+
+	users, err := n.shareRepo.FindSharedUsers(ctx, overdue.TaskID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve shared users: %w", err)
+	}
+
+	message := fmt.Sprintf("A tarefa \"%s\" está atrasada", overdue.Title)
+	if err := n.notify(ctx, overdue.OwnerID, application.NotificationTaskOverdue, overdue.TaskID, message); err != nil {
+		return err
+	}
+	for _, user := range users {
+		if user.UserID == overdue.OwnerID {
+			continue
+		}
+		if err := n.notify(ctx, user.UserID, application.NotificationTaskOverdue, overdue.TaskID, message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// notify creates a single notification, unless the user turned its type off
+func (n *TaskEventNotifier) notify(ctx context.Context, userID string, notificationType application.NotificationType, taskID, message string) error {
+	settings, err := userSettings(ctx, n.settingsRepo, userID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve notification settings: %w", err)
+	}
+	if !settings.Notifications.Allows(notificationType) {
+		return nil
+	}
+
+	notification, err := application.NewNotification(uuid.New().String(), userID, notificationType, taskID, notificationMessage(message))
+	if err != nil {
+		return err
+	}
+
+	if err := n.notificationRepo.Create(ctx, notification); err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return nil
+}
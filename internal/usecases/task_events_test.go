@@ -0,0 +1,194 @@
+package usecases
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// recordingPublisher is an event.Publisher that records the published events
+type recordingPublisher struct {
+	events []event.DomainEvent
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, events ...event.DomainEvent) {
+	p.events = append(p.events, events...)
+}
+
+func (p *recordingPublisher) names() []string {
+	names := []string{}
+	for _, e := range p.events {
+		names = append(names, e.EventName())
+	}
+	return names
+}
+
+func TestTaskUseCases_PublishEvents(t *testing.T) {
+	ctx := context.Background()
+	task, _ := application.NewTask("task-1", "Relatório mensal", "", application.StatusPending, "user-1", "")
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-2"}}}
+	taskService := service.NewTaskService(taskRepo, shareRepo)
+	publisher := &recordingPublisher{}
+	expectEvents := func(step string, want ...string) {
+		t.Helper()
+		if got := publisher.names(); !reflect.DeepEqual(got, append([]string{}, want...)) {
+			t.Errorf("%s: expected events %v, got %v", step, want, got)
+		}
+		publisher.events = nil
+	}
+
+	_, err := NewCreateTaskUseCase(&mockTaskRepository{tasks: map[string]*application.Task{}}, nil, publisher, &sequentialIDs{}, nil).
+		Execute(ctx, "Nova", "", "user-1", "", nil, "", "")
+	if err != nil {
+		t.Fatalf("create: unexpected error: %v", err)
+	}
+	expectEvents("create", event.TaskCreatedName)
+
+	rows := []ImportRow{{Line: 2, Title: "A"}, {Line: 3, Title: ""}, {Line: 4, Title: "B"}}
+	if _, err := NewImportTasksUseCase(newMockTaskRepositoryForImport(), publisher, &sequentialIDs{}, nil).Execute(ctx, "user-1", rows, time.UTC, false); err != nil {
+		t.Fatalf("import: unexpected error: %v", err)
+	}
+	expectEvents("import", event.TaskCreatedName, event.TaskCreatedName)
+
+	update := NewUpdateTaskUseCase(taskRepo, nil, &mockDependencyRepository{}, taskService, publisher, NewTaskRevisions(nil, 0))
+	if err := update.Execute(ctx, "task-1", "Relatório", "", application.StatusInProgress, "", "user-1", nil, "", "", 0, ""); err != nil {
+		t.Fatalf("update: unexpected error: %v", err)
+	}
+	expectEvents("update", event.TaskUpdatedName)
+
+	// Updating to completed also publishes the completion
+	if err := update.Execute(ctx, "task-1", "Relatório", "", application.StatusCompleted, "", "user-1", nil, "", "", 0, ""); err != nil {
+		t.Fatalf("update to completed: unexpected error: %v", err)
+	}
+	expectEvents("update to completed", event.TaskUpdatedName, event.TaskCompletedName)
+
+	if err := NewShareTaskUseCase(taskRepo, shareRepo, taskService, publisher).Execute(ctx, "task-1", "user-1", "user-3"); err != nil {
+		t.Fatalf("share: unexpected error: %v", err)
+	}
+	expectEvents("share", event.TaskSharedName)
+
+	if err := NewUnshareTaskUseCase(taskRepo, shareRepo, taskService, publisher).Execute(ctx, "task-1", "user-1", "user-2"); err != nil {
+		t.Fatalf("unshare: unexpected error: %v", err)
+	}
+	expectEvents("unshare", event.TaskUnsharedName)
+
+	// Denied operations publish nothing
+	newDeleteTaskUseCase(taskRepo, shareRepo, taskService, publisher).Execute(ctx, "task-1", "user-2")
+	NewShareTaskUseCase(taskRepo, shareRepo, taskService, publisher).Execute(ctx, "task-1", "user-1", "user-1")
+	expectEvents("denied operations")
+
+	if _, err := newDeleteTaskUseCase(taskRepo, shareRepo, taskService, publisher).Execute(ctx, "task-1", "user-1"); err != nil {
+		t.Fatalf("delete: unexpected error: %v", err)
+	}
+	expectEvents("delete", event.TaskDeletedName)
+}
+
+func TestDeleteTaskUseCase_EventSnapshotsDeletedTask(t *testing.T) {
+	task, _ := application.NewTask("task-1", "Relatório mensal", "", application.StatusPending, "user-1", "")
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-2"}}}
+	taskService := service.NewTaskService(taskRepo, shareRepo)
+	publisher := &recordingPublisher{}
+
+	if _, err := newDeleteTaskUseCase(taskRepo, shareRepo, taskService, publisher).Execute(context.Background(), "task-1", "user-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	deleted := publisher.events[0].(event.TaskDeleted)
+	if deleted.TaskID != "task-1" || deleted.OwnerID != "user-1" || deleted.Title != "Relatório mensal" {
+		t.Errorf("unexpected event %+v", deleted)
+	}
+}
+
+func TestTaskEventNotifier(t *testing.T) {
+	task, _ := application.NewTask("task-1", "Relatório mensal", "", application.StatusCompleted, "user-1", "")
+	base := event.NewTaskEvent(task, "user-1")
+
+	tests := []struct {
+		name     string
+		settings []*application.UserSettings
+		notify   func(n *TaskEventNotifier) error
+		want     map[string]application.NotificationType
+	}{
+		{
+			name: "shared task notifies the recipient",
+			notify: func(n *TaskEventNotifier) error {
+				return n.NotifyTaskShared(context.Background(), event.TaskShared{TaskEvent: base, SharedWithID: "user-3"})
+			},
+			want: map[string]application.NotificationType{"user-3": application.NotificationTaskShared},
+		},
+		{
+			name: "completed task notifies shared users but not the actor",
+			notify: func(n *TaskEventNotifier) error {
+				return n.NotifyTaskCompleted(context.Background(), event.TaskCompleted{TaskEvent: base})
+			},
+			want: map[string]application.NotificationType{
+				"user-2": application.NotificationTaskCompleted,
+				"user-4": application.NotificationTaskCompleted,
+			},
+		},
+		{
+			name: "transferred task notifies the new owner",
+			notify: func(n *TaskEventNotifier) error {
+				transferred := *task
+				transferred.OwnerID = "user-2"
+				return n.NotifyTaskTransferred(context.Background(), event.TaskTransferred{TaskEvent: event.NewTaskEvent(&transferred, "user-1"), PreviousOwnerID: "user-1"})
+			},
+			want: map[string]application.NotificationType{"user-2": application.NotificationTaskTransferred},
+		},
+		{
+			name: "overdue task notifies the owner and shared users",
+			notify: func(n *TaskEventNotifier) error {
+				return n.NotifyTaskOverdue(context.Background(), event.TaskOverdue{TaskEvent: event.NewTaskEvent(task, ""), DueDate: time.Now()})
+			},
+			want: map[string]application.NotificationType{
+				"user-1": application.NotificationTaskOverdue,
+				"user-2": application.NotificationTaskOverdue,
+				"user-4": application.NotificationTaskOverdue,
+			},
+		},
+		{
+			name: "users who turned a notification off don't get it",
+			settings: []*application.UserSettings{
+				{UserID: "user-1", Notifications: application.NotificationSettings{TaskCompleted: true}},
+				{UserID: "user-4", Notifications: application.NotificationSettings{TaskOverdue: true}},
+			},
+			notify: func(n *TaskEventNotifier) error {
+				return n.NotifyTaskOverdue(context.Background(), event.TaskOverdue{TaskEvent: event.NewTaskEvent(task, ""), DueDate: time.Now()})
+			},
+			want: map[string]application.NotificationType{
+				"user-2": application.NotificationTaskOverdue,
+				"user-4": application.NotificationTaskOverdue,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notificationRepo := &mockNotificationRepository{}
+			shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-2", "user-1", "user-4"}}}
+			settingsRepo := newMockUserSettingsRepository(tt.settings...)
+
+			if err := tt.notify(NewTaskEventNotifier(notificationRepo, shareRepo, settingsRepo)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := map[string]application.NotificationType{}
+			for _, n := range notificationRepo.notifications {
+				got[n.UserID] = n.Type
+				if n.TaskID != "task-1" || n.Message == "" {
+					t.Errorf("unexpected notification %+v", n)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected notifications %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
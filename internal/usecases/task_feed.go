@@ -0,0 +1,133 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// TaskFeedSize is how many of the most recently changed tasks the feed of a user has
+const TaskFeedSize = 50
+
+// CreateFeedTokenUseCase handles creating the token a user reads their task feed with
+type CreateFeedTokenUseCase struct {
+	tokenRepo repository.FeedTokenRepository
+	now       func() time.Time
+}
+
+// NewCreateFeedTokenUseCase creates a new CreateFeedTokenUseCase
+func NewCreateFeedTokenUseCase(tokenRepo repository.FeedTokenRepository) *CreateFeedTokenUseCase {
+	return &CreateFeedTokenUseCase{
+		tokenRepo: tokenRepo,
+		now:       time.Now,
+	}
+}
+
+// Execute creates a feed token for a user, replacing the one they had, and returns it. The
+// token is only available here: only its hash is stored.
+func (uc *CreateFeedTokenUseCase) Execute(ctx context.Context, userID string) (string, error) {
+	// Feed tokens are as unguessable as public links, and stored the same way
+	token, hash, err := service.NewChallengeToken()
+	if err != nil {
+		return "", err
+	}
+
+	feedToken, err := application.NewFeedToken(userID, hash, uc.now())
+	if err != nil {
+		return "", err
+	}
+
+	if err := uc.tokenRepo.Save(ctx, feedToken); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// RevokeFeedTokenUseCase handles revoking the feed token of a user
+type RevokeFeedTokenUseCase struct {
+	tokenRepo repository.FeedTokenRepository
+}
+
+// NewRevokeFeedTokenUseCase creates a new RevokeFeedTokenUseCase
+func NewRevokeFeedTokenUseCase(tokenRepo repository.FeedTokenRepository) *RevokeFeedTokenUseCase {
+	return &RevokeFeedTokenUseCase{tokenRepo: tokenRepo}
+}
+
+// Execute revokes the feed token of a user, so their feed can't be read until they create
+// another. It returns application.ErrFeedTokenNotFound when they have none.
+func (uc *RevokeFeedTokenUseCase) Execute(ctx context.Context, userID string) error {
+	deleted, err := uc.tokenRepo.Delete(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return application.ErrFeedTokenNotFound
+	}
+	return nil
+}
+
+// GetTaskFeedUseCase handles reading the task feed of a user with their feed token
+type GetTaskFeedUseCase struct {
+	tokenRepo repository.FeedTokenRepository
+	userRepo  repository.UserRepository
+	taskRepo  repository.TaskRepository
+}
+
+// NewGetTaskFeedUseCase creates a new GetTaskFeedUseCase
+func NewGetTaskFeedUseCase(tokenRepo repository.FeedTokenRepository, userRepo repository.UserRepository, taskRepo repository.TaskRepository) *GetTaskFeedUseCase {
+	return &GetTaskFeedUseCase{
+		tokenRepo: tokenRepo,
+		userRepo:  userRepo,
+		taskRepo:  taskRepo,
+	}
+}
+
+// Execute returns the user a feed token belongs to and their TaskFeedSize most recently
+// created or updated tasks, owned or shared with them, newest first. Unknown and replaced
+// tokens, and tokens of disabled users, all return application.ErrFeedTokenNotFound.
+func (uc *GetTaskFeedUseCase) Execute(ctx context.Context, token string) (*application.User, []*application.Task, error) {
+	if token == "" {
+		return nil, nil, application.ErrFeedTokenNotFound
+	}
+
+	feedToken, err := uc.tokenRepo.FindByTokenHash(ctx, service.HashChallengeToken(token))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, feedToken.UserID)
+	if errors.Is(err, application.ErrUserNotFound) {
+		return nil, nil, application.ErrFeedTokenNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if user.Disabled {
+		return nil, nil, application.ErrFeedTokenNotFound
+	}
+
+	owned, err := uc.taskRepo.FindByOwnerID(ctx, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	shared, err := uc.taskRepo.FindSharedWithUser(ctx, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tasks := append(owned, shared...)
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].UpdatedAt.After(tasks[j].UpdatedAt)
+	})
+	if len(tasks) > TaskFeedSize {
+		tasks = tasks[:TaskFeedSize]
+	}
+
+	return user, tasks, nil
+}
@@ -0,0 +1,93 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+type mockFeedTokenRepository struct {
+	tokens map[string]*application.FeedToken // by user ID
+}
+
+func (m *mockFeedTokenRepository) Save(ctx context.Context, token *application.FeedToken) error {
+	m.tokens[token.UserID] = token
+	return nil
+}
+
+func (m *mockFeedTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*application.FeedToken, error) {
+	for _, token := range m.tokens {
+		if token.TokenHash == tokenHash {
+			return token, nil
+		}
+	}
+	return nil, application.ErrFeedTokenNotFound
+}
+
+func (m *mockFeedTokenRepository) Delete(ctx context.Context, userID string) (bool, error) {
+	_, ok := m.tokens[userID]
+	delete(m.tokens, userID)
+	return ok, nil
+}
+
+func TestTaskFeed(t *testing.T) {
+	ctx := context.Background()
+	tokens := &mockFeedTokenRepository{tokens: map[string]*application.FeedToken{}}
+	users := &mockUserRepositoryForLogin{users: map[string]*application.User{"ana": {ID: "ana", Name: "Ana"}}}
+	base := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{}}
+	for i := 0; i < TaskFeedSize+5; i++ {
+		id := fmt.Sprintf("task-%02d", i)
+		taskRepo.tasks[id] = &application.Task{ID: id, Title: id, OwnerID: "ana", UpdatedAt: base.Add(time.Duration(i) * time.Minute)}
+	}
+	feed := NewGetTaskFeedUseCase(tokens, users, taskRepo)
+
+	first, err := NewCreateFeedTokenUseCase(tokens).Execute(ctx, "ana")
+	if err != nil || first == "" {
+		t.Fatalf("CreateFeedToken() = %q, %v", first, err)
+	}
+	if tokens.tokens["ana"].TokenHash == first {
+		t.Error("the token itself was stored, want only its hash")
+	}
+
+	user, tasks, err := feed.Execute(ctx, first)
+	if err != nil || user.ID != "ana" {
+		t.Fatalf("Execute() = %v, %v; want the feed of ana", user, err)
+	}
+	if len(tasks) != TaskFeedSize || tasks[0].ID != fmt.Sprintf("task-%02d", TaskFeedSize+4) || tasks[TaskFeedSize-1].ID != "task-05" {
+		t.Errorf("Execute() returned %d tasks from %s, want the %d most recently updated, newest first", len(tasks), tasks[0].ID, TaskFeedSize)
+	}
+
+	// A new token replaces the previous one
+	second, _ := NewCreateFeedTokenUseCase(tokens).Execute(ctx, "ana")
+	for name, token := range map[string]string{"replaced": first, "empty": "", "unknown": "nope"} {
+		if _, _, err := feed.Execute(ctx, token); !errors.Is(err, application.ErrFeedTokenNotFound) {
+			t.Errorf("Execute() with %s token error = %v, want ErrFeedTokenNotFound", name, err)
+		}
+	}
+
+	if err := NewRevokeFeedTokenUseCase(tokens).Execute(ctx, "ana"); err != nil {
+		t.Fatalf("RevokeFeedToken() error: %v", err)
+	}
+	if _, _, err := feed.Execute(ctx, second); !errors.Is(err, application.ErrFeedTokenNotFound) {
+		t.Errorf("Execute() with a revoked token error = %v, want ErrFeedTokenNotFound", err)
+	}
+	if err := NewRevokeFeedTokenUseCase(tokens).Execute(ctx, "ana"); !errors.Is(err, application.ErrFeedTokenNotFound) {
+		t.Errorf("RevokeFeedToken() without a token error = %v, want ErrFeedTokenNotFound", err)
+	}
+}
+
+func TestTaskFeed_DisabledUser(t *testing.T) {
+	tokens := &mockFeedTokenRepository{tokens: map[string]*application.FeedToken{}}
+	users := &mockUserRepositoryForLogin{users: map[string]*application.User{"caio": {ID: "caio", Name: "Caio", Disabled: true}}}
+	token, _ := NewCreateFeedTokenUseCase(tokens).Execute(context.Background(), "caio")
+
+	_, _, err := NewGetTaskFeedUseCase(tokens, users, &mockTaskRepositoryForShare{}).Execute(context.Background(), token)
+	if !errors.Is(err, application.ErrFeedTokenNotFound) {
+		t.Errorf("Execute() for a disabled user error = %v, want ErrFeedTokenNotFound", err)
+	}
+}
@@ -0,0 +1,41 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// CanViewTaskImageUseCase handles checking whether a user can see an uploaded task image
+type CanViewTaskImageUseCase struct {
+	taskRepo    repository.TaskRepository
+	taskService TaskServiceInterface
+}
+
+// NewCanViewTaskImageUseCase creates a new CanViewTaskImageUseCase
+func NewCanViewTaskImageUseCase(taskRepo repository.TaskRepository, taskService TaskServiceInterface) *CanViewTaskImageUseCase {
+	return &CanViewTaskImageUseCase{
+		taskRepo:    taskRepo,
+		taskService: taskService,
+	}
+}
+
+// Execute reports whether the user can access a task whose image is stored at imagePath.
+// Images not used by any task can't be seen by anyone.
+func (uc *CanViewTaskImageUseCase) Execute(ctx context.Context, imagePath, userID string) (bool, error) {
+	tasks, err := uc.taskRepo.FindByImagePath(ctx, imagePath)
+	if err != nil {
+		return false, err
+	}
+
+	for _, task := range tasks {
+		canAccess, err := uc.taskService.CanUserAccessTask(ctx, task.ID, userID)
+		if err != nil {
+			return false, err
+		}
+		if canAccess {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+type mockTaskServiceForImageAccess struct {
+	accessible map[string]bool // task IDs the user can access
+}
+
+func (m *mockTaskServiceForImageAccess) CanUserAccessTask(ctx context.Context, taskID, userID string) (bool, error) {
+	return m.accessible[taskID], nil
+}
+
+func (m *mockTaskServiceForImageAccess) CanUserModifyTask(ctx context.Context, taskID, userID string) (bool, error) {
+	return false, nil
+}
+
+func TestCanViewTaskImageUseCase_Execute(t *testing.T) {
+	repo := &mockTaskRepositoryForDeleteImage{tasks: map[string]*application.Task{}}
+	task, _ := application.NewTask("task-1", "Test Task", "", application.StatusPending, "user-1", "/uploads/images/a.png")
+	repo.tasks[task.ID] = task
+	other, _ := application.NewTask("task-2", "Other Task", "", application.StatusPending, "user-2", "/uploads/images/b.png")
+	repo.tasks[other.ID] = other
+
+	tests := []struct {
+		name       string
+		imagePath  string
+		accessible map[string]bool
+		want       bool
+	}{
+		{"image of an accessible task", "/uploads/images/a.png", map[string]bool{"task-1": true}, true},
+		{"image of another user's task", "/uploads/images/b.png", map[string]bool{"task-1": true}, false},
+		{"image not used by any task", "/uploads/images/c.png", map[string]bool{"task-1": true, "task-2": true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewCanViewTaskImageUseCase(repo, &mockTaskServiceForImageAccess{accessible: tt.accessible})
+
+			got, err := uc.Execute(context.Background(), tt.imagePath, "user-1")
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Execute() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
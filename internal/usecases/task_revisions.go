@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// TaskRevisions keeps the version of a task an edit replaces, when the edit changes its title,
+// description or status. Only the newest revisions of each task are kept.
+type TaskRevisions struct {
+	revisionRepo repository.TaskRevisionRepository
+	keep         int
+	now          func() time.Time
+}
+
+// NewTaskRevisions creates a TaskRevisions keeping up to keep revisions of each task; 0 keeps
+// none
+func NewTaskRevisions(revisionRepo repository.TaskRevisionRepository, keep int) *TaskRevisions {
+	return &TaskRevisions{revisionRepo: revisionRepo, keep: keep, now: time.Now}
+}
+
+// before returns the version of a task an edit of userID is about to replace
+func (r *TaskRevisions) before(task *application.Task, userID string) *application.TaskRevision {
+	return application.NewTaskRevision(task, userID, r.now())
+}
+
+// record keeps revision, taken by before, once the edit is saved in task. Nothing is kept when
+// the edit left the title, description and status as they were.
+func (r *TaskRevisions) record(ctx context.Context, revision *application.TaskRevision, task *application.Task) error {
+	if r.keep <= 0 || !revision.ChangedBy(task) {
+		return nil
+	}
+	return r.revisionRepo.Add(ctx, revision, r.keep)
+}
+
+// ListTaskRevisionsUseCase handles listing the previous versions of a task, for the users who
+// can see it
+type ListTaskRevisionsUseCase struct {
+	taskRepo     repository.TaskRepository
+	revisionRepo repository.TaskRevisionRepository
+	userRepo     repository.UserRepository
+	taskService  TaskServiceInterface
+}
+
+// NewListTaskRevisionsUseCase creates a new ListTaskRevisionsUseCase
+func NewListTaskRevisionsUseCase(taskRepo repository.TaskRepository, revisionRepo repository.TaskRevisionRepository, userRepo repository.UserRepository, taskService TaskServiceInterface) *ListTaskRevisionsUseCase {
+	return &ListTaskRevisionsUseCase{
+		taskRepo:     taskRepo,
+		revisionRepo: revisionRepo,
+		userRepo:     userRepo,
+		taskService:  taskService,
+	}
+}
+
+// Execute returns a task with its revisions, newest first, named after the users who edited
+// them. It returns ErrTaskUnavailable when the task doesn't exist or the user can't see it.
+func (uc *ListTaskRevisionsUseCase) Execute(ctx context.Context, taskID, userID string) (_ *application.TaskHistory, err error) {
+	ctx, end := tracer.Start(ctx, "ListTaskRevisions")
+	defer func() { end(err) }()
+
+	if err := checkTaskAccess(ctx, uc.taskRepo, uc.taskService, taskID, userID, false); err != nil {
+		return nil, err
+	}
+	task, err := uc.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := uc.revisionRepo.FindByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	var editorIDs []string
+	seen := make(map[string]bool)
+	for _, revision := range revisions {
+		if !seen[revision.EditedBy] {
+			seen[revision.EditedBy] = true
+			editorIDs = append(editorIDs, revision.EditedBy)
+		}
+	}
+	editors, err := uc.userRepo.FindByIDs(ctx, editorIDs)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(editors))
+	for _, editor := range editors {
+		names[editor.ID] = editor.Name
+	}
+	for _, revision := range revisions {
+		revision.EditorName = names[revision.EditedBy]
+	}
+
+	return &application.TaskHistory{Task: task, Revisions: revisions}, nil
+}
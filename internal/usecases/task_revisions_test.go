@@ -0,0 +1,169 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// mockTaskRevisionRepository keeps revisions in memory, newest first, up to keep per task
+type mockTaskRevisionRepository struct {
+	revisions []*application.TaskRevision
+	nextID    int64
+}
+
+func (m *mockTaskRevisionRepository) Add(ctx context.Context, revision *application.TaskRevision, keep int) error {
+	m.nextID++
+	revision.ID = m.nextID
+	kept := []*application.TaskRevision{revision}
+	count := 1
+	for _, r := range m.revisions {
+		if r.TaskID == revision.TaskID {
+			if count == keep {
+				continue
+			}
+			count++
+		}
+		kept = append(kept, r)
+	}
+	m.revisions = kept
+	return nil
+}
+
+func (m *mockTaskRevisionRepository) FindByTaskID(ctx context.Context, taskID string) ([]*application.TaskRevision, error) {
+	var found []*application.TaskRevision
+	for _, r := range m.revisions {
+		if r.TaskID == taskID {
+			found = append(found, r)
+		}
+	}
+	return found, nil
+}
+
+// mockUserRepositoryForRevisions finds the users it holds by ID
+type mockUserRepositoryForRevisions struct {
+	mockUserRepositoryForLogin
+}
+
+func (m *mockUserRepositoryForRevisions) FindByIDs(ctx context.Context, ids []string) ([]*application.User, error) {
+	var users []*application.User
+	for _, id := range ids {
+		if user, ok := m.users[id]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func TestTaskRevisions_RecordEdits(t *testing.T) {
+	task, _ := application.NewTask("task-1", "Relatório mensal", "", application.StatusPending, "user-1", "")
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-2"}}}
+	taskService := service.NewTaskService(taskRepo, shareRepo)
+	revisionRepo := &mockTaskRevisionRepository{}
+	revisions := NewTaskRevisions(revisionRepo, 2)
+	ctx := context.Background()
+
+	update := NewUpdateTaskUseCase(taskRepo, nil, &mockDependencyRepository{}, taskService, &recordingPublisher{}, revisions)
+	titles := func() []string {
+		var titles []string
+		for _, r := range revisionRepo.revisions {
+			titles = append(titles, string(r.Status)+":"+r.Title)
+		}
+		return titles
+	}
+
+	// Only edits of the title, description or status are kept
+	if err := update.Execute(ctx, "task-1", "Relatório mensal", "", application.StatusPending, "", "user-1", nil, "", "", 40, ""); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if len(revisionRepo.revisions) != 0 {
+		t.Fatalf("revisions after a progress edit = %v, want none", titles())
+	}
+	if err := update.Execute(ctx, "task-1", "Relatório de março", "", application.StatusPending, "", "user-1", nil, "", "", 40, ""); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+
+	complete := NewCompleteTaskUseCase(taskRepo, &mockDependencyRepository{}, taskService, &recordingPublisher{}, revisions)
+	if _, err := complete.Execute(ctx, "task-1", "user-1", ""); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+
+	if err := update.Execute(ctx, "task-1", "Relatório de março", "Enviado", application.StatusCompleted, "", "user-1", nil, "", "", 0, ""); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+
+	// The oldest revision beyond the 2 kept is gone
+	got := titles()
+	want := []string{"completed:Relatório de março", "pending:Relatório de março"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("revisions = %v, want %v", got, want)
+	}
+	if revisionRepo.revisions[0].EditedBy != "user-1" {
+		t.Errorf("EditedBy = %q, want the user who edited", revisionRepo.revisions[0].EditedBy)
+	}
+}
+
+func TestTaskRevisions_WithoutRetention(t *testing.T) {
+	task, _ := application.NewTask("task-1", "Relatório mensal", "", application.StatusPending, "user-1", "")
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-2"}}}
+	taskService := service.NewTaskService(taskRepo, shareRepo)
+	revisionRepo := &mockTaskRevisionRepository{}
+	complete := NewCompleteTaskUseCase(taskRepo, &mockDependencyRepository{}, taskService, &recordingPublisher{}, NewTaskRevisions(revisionRepo, 0))
+
+	if _, err := complete.Execute(context.Background(), "task-1", "user-1", ""); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if len(revisionRepo.revisions) != 0 {
+		t.Errorf("revisions without retention = %v, want none", revisionRepo.revisions)
+	}
+}
+
+func TestListTaskRevisionsUseCase_Execute(t *testing.T) {
+	task, _ := application.NewTask("task-1", "Relatório mensal", "", application.StatusPending, "user-1", "")
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-2"}}}
+	taskService := service.NewTaskService(taskRepo, shareRepo)
+	revisionRepo := &mockTaskRevisionRepository{}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	revisionRepo.Add(context.Background(), &application.TaskRevision{TaskID: "task-1", Title: "v1", EditedBy: "user-1", ReplacedAt: now}, 10)
+	revisionRepo.Add(context.Background(), &application.TaskRevision{TaskID: "task-1", Title: "v2", EditedBy: "user-gone", ReplacedAt: now}, 10)
+	userRepo := &mockUserRepositoryForRevisions{mockUserRepositoryForLogin{users: map[string]*application.User{
+		"user-1": {ID: "user-1", Name: "Ana"},
+	}}}
+	uc := NewListTaskRevisionsUseCase(taskRepo, revisionRepo, userRepo, taskService)
+
+	tests := []struct {
+		name    string
+		taskID  string
+		userID  string
+		wantErr error
+	}{
+		{"owner", "task-1", "user-1", nil},
+		{"shared with the user", "task-1", "user-2", nil},
+		{"not shared", "task-1", "user-3", ErrTaskUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history, err := uc.Execute(context.Background(), tt.taskID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if history.Task.ID != "task-1" || len(history.Revisions) != 2 {
+				t.Fatalf("Execute() = %+v, want the task with its 2 revisions", history)
+			}
+			if history.Revisions[0].Title != "v2" || history.Revisions[0].EditorName != "" || history.Revisions[1].EditorName != "Ana" {
+				t.Errorf("revisions = %+v, %+v; want newest first, named after their editors", history.Revisions[0], history.Revisions[1])
+			}
+		})
+	}
+}
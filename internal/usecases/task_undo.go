@@ -0,0 +1,292 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// TrashTaskUseCase deletes a task so the deletion can be undone for a while: the task is
+// hidden right away and deleted for good by FinalizeTaskUndosUseCase once the undo expires
+type TrashTaskUseCase struct {
+	taskRepo    repository.TaskRepository
+	undoRepo    repository.TaskUndoRepository
+	transactor  repository.Transactor
+	taskService TaskServiceInterface
+	window      time.Duration
+	now         func() time.Time
+}
+
+// NewTrashTaskUseCase creates a new TrashTaskUseCase whose deletions can be undone for window
+func NewTrashTaskUseCase(
+	taskRepo repository.TaskRepository,
+	undoRepo repository.TaskUndoRepository,
+	transactor repository.Transactor,
+	taskService TaskServiceInterface,
+	window time.Duration,
+) *TrashTaskUseCase {
+	return &TrashTaskUseCase{
+		taskRepo:    taskRepo,
+		undoRepo:    undoRepo,
+		transactor:  transactor,
+		taskService: taskService,
+		window:      window,
+		now:         time.Now,
+	}
+}
+
+// Execute moves a task to the trash and returns it with the undo of the deletion
+func (uc *TrashTaskUseCase) Execute(ctx context.Context, taskID, userID string) (*application.Task, *application.TaskUndo, error) {
+	task, err := uc.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	canModify, err := uc.taskService.CanUserModifyTask(ctx, taskID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !canModify {
+		return nil, nil, errors.New("user does not have permission to delete this task")
+	}
+
+	now := uc.now()
+	undo, err := application.NewTaskUndo(taskID, userID, application.UndoDelete, task.Status, now, uc.window)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.undoRepo.Trash(ctx, taskID, now); err != nil {
+			return err
+		}
+		return uc.undoRepo.Save(ctx, undo)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return task, undo, nil
+}
+
+// CompleteTaskWithUndoUseCase completes a task so the completion can be undone for a while
+type CompleteTaskWithUndoUseCase struct {
+	completeTask CompleteTaskUseCaseInterface
+	taskRepo     repository.TaskRepository
+	undoRepo     repository.TaskUndoRepository
+	window       time.Duration
+	now          func() time.Time
+}
+
+// NewCompleteTaskWithUndoUseCase creates a new CompleteTaskWithUndoUseCase whose completions
+// can be undone for window
+func NewCompleteTaskWithUndoUseCase(
+	completeTask CompleteTaskUseCaseInterface,
+	taskRepo repository.TaskRepository,
+	undoRepo repository.TaskUndoRepository,
+	window time.Duration,
+) *CompleteTaskWithUndoUseCase {
+	return &CompleteTaskWithUndoUseCase{
+		completeTask: completeTask,
+		taskRepo:     taskRepo,
+		undoRepo:     undoRepo,
+		window:       window,
+		now:          time.Now,
+	}
+}
+
+// Execute completes a task with an optional note and returns it with the undo of the completion
+func (uc *CompleteTaskWithUndoUseCase) Execute(ctx context.Context, taskID, userID, note string) (*application.Task, *application.TaskUndo, error) {
+	// The status the undo goes back to, read before the completion changes it
+	before, err := uc.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	previousStatus := before.Status
+
+	task, err := uc.completeTask.Execute(ctx, taskID, userID, note)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	undo, err := application.NewTaskUndo(taskID, userID, application.UndoComplete, previousStatus, uc.now(), uc.window)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := uc.undoRepo.Save(ctx, undo); err != nil {
+		return nil, nil, err
+	}
+
+	return task, undo, nil
+}
+
+// UndoTaskUseCase takes back the last deletion or completion of a task while it can be undone
+type UndoTaskUseCase struct {
+	taskRepo   repository.TaskRepository
+	undoRepo   repository.TaskUndoRepository
+	transactor repository.Transactor
+	revisions  *TaskRevisions
+	now        func() time.Time
+}
+
+// NewUndoTaskUseCase creates a new UndoTaskUseCase
+func NewUndoTaskUseCase(
+	taskRepo repository.TaskRepository,
+	undoRepo repository.TaskUndoRepository,
+	transactor repository.Transactor,
+	revisions *TaskRevisions,
+) *UndoTaskUseCase {
+	return &UndoTaskUseCase{
+		taskRepo:   taskRepo,
+		undoRepo:   undoRepo,
+		transactor: transactor,
+		revisions:  revisions,
+		now:        time.Now,
+	}
+}
+
+// Execute undoes the last action the user took on a task and returns the task as it is
+// again. It returns application.ErrUndoExpired when there is nothing left to undo: the undo
+// expired, was already taken, or belongs to another user.
+func (uc *UndoTaskUseCase) Execute(ctx context.Context, taskID, userID string) (*application.Task, error) {
+	var task *application.Task
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		undo, err := uc.undoRepo.Take(ctx, taskID, userID, uc.now())
+		if err != nil {
+			return err
+		}
+
+		if undo.Action == application.UndoDelete {
+			if err := uc.undoRepo.Restore(ctx, taskID); err != nil {
+				return err
+			}
+		}
+
+		task, err = uc.taskRepo.FindByID(ctx, taskID)
+		if errors.Is(err, application.ErrTaskNotFound) {
+			return application.ErrUndoExpired
+		}
+		if err != nil {
+			return err
+		}
+
+		if undo.Action == application.UndoComplete && task.Status == application.StatusCompleted {
+			revision := uc.revisions.before(task, userID)
+			if err := task.Update(task.Title, task.Description, undo.PreviousStatus, task.ImagePath); err != nil {
+				return err
+			}
+			if err := uc.taskRepo.Update(ctx, task); err != nil {
+				return err
+			}
+			return uc.revisions.record(ctx, revision, task)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// TaskUndoSummary reports what a run of FinalizeTaskUndosUseCase finalized
+type TaskUndoSummary struct {
+	Deleted   int // Trashed tasks deleted for good
+	Completed int // Completions that can't be undone anymore
+}
+
+// FinalizeTaskUndosUseCase makes the actions whose undo expired final: trashed tasks are
+// deleted for good, with their shares and attachments, and their files removed
+type FinalizeTaskUndosUseCase struct {
+	undoRepo   repository.TaskUndoRepository
+	deleteTask *DeleteTaskUseCase
+	transactor repository.Transactor
+	files      DeletedFileRemover
+	events     event.Publisher
+	batchSize  int
+	now        func() time.Time
+}
+
+// DeletedFileRemover removes the files of deleted tasks once the deletion is committed
+type DeletedFileRemover interface {
+	RemoveDeleted(ctx context.Context, files *DeletedTaskFiles)
+}
+
+// NewFinalizeTaskUndosUseCase creates a new FinalizeTaskUndosUseCase finalizing up to
+// batchSize actions per run
+func NewFinalizeTaskUndosUseCase(
+	undoRepo repository.TaskUndoRepository,
+	deleteTask *DeleteTaskUseCase,
+	transactor repository.Transactor,
+	files DeletedFileRemover,
+	events event.Publisher,
+	batchSize int,
+) *FinalizeTaskUndosUseCase {
+	return &FinalizeTaskUndosUseCase{
+		undoRepo:   undoRepo,
+		deleteTask: deleteTask,
+		transactor: transactor,
+		files:      files,
+		events:     events,
+		batchSize:  batchSize,
+		now:        time.Now,
+	}
+}
+
+// Execute finalizes a batch of expired actions. Each one is finalized in its own transaction,
+// which skips it when it was undone meanwhile.
+func (uc *FinalizeTaskUndosUseCase) Execute(ctx context.Context) (TaskUndoSummary, error) {
+	var summary TaskUndoSummary
+
+	now := uc.now()
+	undos, err := uc.undoRepo.FindExpired(ctx, now, uc.batchSize)
+	if err != nil {
+		return summary, err
+	}
+
+	for _, undo := range undos {
+		var finalized bool
+		var deleted *application.Task
+		var files *DeletedTaskFiles
+		err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			deleted, files = nil, nil
+			var err error
+			finalized, err = uc.undoRepo.DeleteExpired(ctx, undo.TaskID, now)
+			if err != nil || !finalized || undo.Action != application.UndoDelete {
+				return err
+			}
+
+			deleted, err = uc.undoRepo.FindTrashed(ctx, undo.TaskID)
+			if errors.Is(err, application.ErrTaskNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			files, err = uc.deleteTask.delete(ctx, deleted)
+			return err
+		})
+		if err != nil {
+			return summary, err
+		}
+
+		if !finalized {
+			continue
+		}
+		if deleted == nil {
+			if undo.Action == application.UndoComplete {
+				summary.Completed++
+			}
+			continue
+		}
+		summary.Deleted++
+		uc.events.Publish(ctx, event.TaskDeleted{TaskEvent: event.NewTaskEvent(deleted, undo.UserID)})
+		uc.files.RemoveDeleted(ctx, files)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,210 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// mockTaskUndoRepository keeps undos in memory and trashes tasks by moving them out of a task
+// repository
+type mockTaskUndoRepository struct {
+	taskRepo *mockTaskRepositoryForShare
+	trashed  map[string]*application.Task
+	undos    map[string]*application.TaskUndo
+}
+
+func newMockTaskUndoRepository(taskRepo *mockTaskRepositoryForShare) *mockTaskUndoRepository {
+	return &mockTaskUndoRepository{
+		taskRepo: taskRepo,
+		trashed:  map[string]*application.Task{},
+		undos:    map[string]*application.TaskUndo{},
+	}
+}
+
+func (m *mockTaskUndoRepository) Save(ctx context.Context, undo *application.TaskUndo) error {
+	m.undos[undo.TaskID] = undo
+	return nil
+}
+
+func (m *mockTaskUndoRepository) Take(ctx context.Context, taskID, userID string, now time.Time) (*application.TaskUndo, error) {
+	undo, ok := m.undos[taskID]
+	if !ok || undo.UserID != userID || undo.IsExpired(now) {
+		return nil, application.ErrUndoExpired
+	}
+	delete(m.undos, taskID)
+	return undo, nil
+}
+
+func (m *mockTaskUndoRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]*application.TaskUndo, error) {
+	var expired []*application.TaskUndo
+	for _, undo := range m.undos {
+		if undo.IsExpired(now) && len(expired) < limit {
+			expired = append(expired, undo)
+		}
+	}
+	return expired, nil
+}
+
+func (m *mockTaskUndoRepository) DeleteExpired(ctx context.Context, taskID string, now time.Time) (bool, error) {
+	undo, ok := m.undos[taskID]
+	if !ok || !undo.IsExpired(now) {
+		return false, nil
+	}
+	delete(m.undos, taskID)
+	return true, nil
+}
+
+func (m *mockTaskUndoRepository) Trash(ctx context.Context, taskID string, deletedAt time.Time) error {
+	m.trashed[taskID] = m.taskRepo.tasks[taskID]
+	delete(m.taskRepo.tasks, taskID)
+	return nil
+}
+
+func (m *mockTaskUndoRepository) Restore(ctx context.Context, taskID string) error {
+	m.taskRepo.tasks[taskID] = m.trashed[taskID]
+	delete(m.trashed, taskID)
+	return nil
+}
+
+func (m *mockTaskUndoRepository) FindTrashed(ctx context.Context, taskID string) (*application.Task, error) {
+	task, ok := m.trashed[taskID]
+	if !ok {
+		return nil, application.ErrTaskNotFound
+	}
+	return task, nil
+}
+
+// recordingFileRemover records the files of the deleted tasks
+type recordingFileRemover struct {
+	removed []*DeletedTaskFiles
+}
+
+func (r *recordingFileRemover) RemoveDeleted(ctx context.Context, files *DeletedTaskFiles) {
+	r.removed = append(r.removed, files)
+}
+
+var undoNow = time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+func TestTrashTaskUseCase_UndoAndFinalize(t *testing.T) {
+	task, _ := application.NewTask("task-1", "Relatório mensal", "", application.StatusPending, "user-1", "")
+	task.ImagePath = "/uploads/images/abc.png"
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-2"}}}
+	taskService := service.NewTaskService(taskRepo, shareRepo)
+	undoRepo := newMockTaskUndoRepository(taskRepo)
+
+	trash := NewTrashTaskUseCase(taskRepo, undoRepo, &mockTransactor{}, taskService, 10*time.Second)
+	trash.now = func() time.Time { return undoNow }
+
+	if _, _, err := trash.Execute(context.Background(), "task-1", "user-2"); err == nil {
+		t.Fatal("expected a user who can't modify the task to be refused")
+	}
+	_, undo, err := trash.Execute(context.Background(), "task-1", "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if undo.Action != application.UndoDelete || !undo.ExpiresAt.Equal(undoNow.Add(10*time.Second)) {
+		t.Errorf("unexpected undo %+v", undo)
+	}
+	if _, visible := taskRepo.tasks["task-1"]; visible {
+		t.Error("expected the trashed task to be hidden")
+	}
+
+	// Undone within the window, the task is back with its shares
+	undoTask := NewUndoTaskUseCase(taskRepo, undoRepo, &mockTransactor{}, NewTaskRevisions(nil, 0))
+	undoTask.now = func() time.Time { return undoNow.Add(5 * time.Second) }
+	restored, err := undoTask.Execute(context.Background(), "task-1", "user-1")
+	if err != nil || restored.ID != "task-1" {
+		t.Fatalf("Execute() = %v, %v; want the restored task", restored, err)
+	}
+	if _, err := undoTask.Execute(context.Background(), "task-1", "user-1"); !errors.Is(err, application.ErrUndoExpired) {
+		t.Errorf("expected a second undo to fail with ErrUndoExpired, got %v", err)
+	}
+
+	// Trashed again and left alone, the task is deleted for good once the undo expires
+	if _, _, err := trash.Execute(context.Background(), "task-1", "user-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	publisher := &recordingPublisher{}
+	files := &recordingFileRemover{}
+	finalize := NewFinalizeTaskUndosUseCase(undoRepo, newDeleteTaskUseCase(taskRepo, shareRepo, taskService, publisher), &mockTransactor{}, files, publisher, 10)
+
+	finalize.now = func() time.Time { return undoNow.Add(5 * time.Second) }
+	if summary, err := finalize.Execute(context.Background()); err != nil || summary != (TaskUndoSummary{}) {
+		t.Fatalf("Execute() before expiry = %+v, %v; want nothing finalized", summary, err)
+	}
+
+	finalize.now = func() time.Time { return undoNow.Add(10 * time.Second) }
+	summary, err := finalize.Execute(context.Background())
+	if err != nil || summary != (TaskUndoSummary{Deleted: 1}) {
+		t.Fatalf("Execute() = %+v, %v; want one deletion", summary, err)
+	}
+	if _, exists := shareRepo.shares["task-1"]; exists {
+		t.Error("expected the shares to be deleted")
+	}
+	if len(files.removed) != 1 || files.removed[0].ImagePath != "/uploads/images/abc.png" {
+		t.Errorf("expected the image to be removed, got %+v", files.removed)
+	}
+	if names := publisher.names(); len(names) != 1 || names[0] != event.TaskDeletedName {
+		t.Errorf("expected a single %s event, got %v", event.TaskDeletedName, names)
+	}
+
+	undoTask.now = func() time.Time { return undoNow.Add(11 * time.Second) }
+	if _, err := undoTask.Execute(context.Background(), "task-1", "user-1"); !errors.Is(err, application.ErrUndoExpired) {
+		t.Errorf("expected an undo after the deletion to fail with ErrUndoExpired, got %v", err)
+	}
+}
+
+func TestCompleteTaskWithUndoUseCase(t *testing.T) {
+	task, _ := application.NewTask("task-1", "Relatório mensal", "", application.StatusInProgress, "user-1", "")
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-2"}}}
+	taskService := service.NewTaskService(taskRepo, shareRepo)
+	undoRepo := newMockTaskUndoRepository(taskRepo)
+
+	complete := NewCompleteTaskWithUndoUseCase(
+		NewCompleteTaskUseCase(taskRepo, &mockDependencyRepository{}, taskService, &recordingPublisher{}, NewTaskRevisions(nil, 0)),
+		taskRepo, undoRepo, 10*time.Second,
+	)
+	complete.now = func() time.Time { return undoNow }
+
+	task, undo, err := complete.Execute(context.Background(), "task-1", "user-1", "Entregue")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.Status != application.StatusCompleted || task.CompletionNote != "Entregue" || undo.PreviousStatus != application.StatusInProgress {
+		t.Errorf("unexpected task status %s with note %q and undo %+v", task.Status, task.CompletionNote, undo)
+	}
+
+	tests := []struct {
+		name    string
+		userID  string
+		at      time.Duration
+		wantErr error
+	}{
+		{"another user", "user-2", time.Second, application.ErrUndoExpired},
+		{"after the window", "user-1", 10 * time.Second, application.ErrUndoExpired},
+		{"within the window", "user-1", 9 * time.Second, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			undoTask := NewUndoTaskUseCase(taskRepo, undoRepo, &mockTransactor{}, NewTaskRevisions(nil, 0))
+			undoTask.now = func() time.Time { return undoNow.Add(tt.at) }
+
+			task, err := undoTask.Execute(context.Background(), "task-1", tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (task.Status != application.StatusInProgress || task.CompletionNote != "") {
+				t.Errorf("expected the task back in progress without its note, got %s with %q", task.Status, task.CompletionNote)
+			}
+		})
+	}
+}
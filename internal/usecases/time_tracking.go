@@ -0,0 +1,200 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// findTaskTime returns the time tracked on a task by all of its users, with the running timer
+// of userID
+func findTaskTime(ctx context.Context, timeEntryRepo repository.TimeEntryRepository, taskID, userID string, now time.Time) (*application.TaskTime, error) {
+	totals, err := timeEntryRepo.SumByTaskIDs(ctx, []string{taskID}, now)
+	if err != nil {
+		return nil, err
+	}
+	taskTime := &application.TaskTime{TaskID: taskID, Tracked: totals[taskID]}
+	running, err := timeEntryRepo.FindRunning(ctx, taskID, userID)
+	if errors.Is(err, application.ErrTimerNotRunning) {
+		return taskTime, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	taskTime.RunningSince = &running.StartedAt
+	return taskTime, nil
+}
+
+// StartTimerUseCase handles starting a user's timer on a task
+type StartTimerUseCase struct {
+	timeEntryRepo repository.TimeEntryRepository
+	taskRepo      repository.TaskRepository
+	taskService   TaskServiceInterface
+	now           func() time.Time
+}
+
+// NewStartTimerUseCase creates a new StartTimerUseCase
+func NewStartTimerUseCase(
+	timeEntryRepo repository.TimeEntryRepository,
+	taskRepo repository.TaskRepository,
+	taskService TaskServiceInterface,
+) *StartTimerUseCase {
+	return &StartTimerUseCase{
+		timeEntryRepo: timeEntryRepo,
+		taskRepo:      taskRepo,
+		taskService:   taskService,
+		now:           time.Now,
+	}
+}
+
+// Execute starts the timer of the user on a task they can see and returns the time tracked on
+// it. Timers are personal, like reminders: the owner and the users the task is shared with
+// each track their own time. It returns application.ErrTimerRunning when the user's timer
+// already runs.
+func (uc *StartTimerUseCase) Execute(ctx context.Context, taskID, userID string) (*application.TaskTime, error) {
+	if err := checkTaskAccess(ctx, uc.taskRepo, uc.taskService, taskID, userID, false); err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	entry, err := application.NewTimeEntry(uuid.New().String(), taskID, userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	started, err := uc.timeEntryRepo.Start(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	if !started {
+		return nil, application.ErrTimerRunning
+	}
+
+	return findTaskTime(ctx, uc.timeEntryRepo, taskID, userID, now)
+}
+
+// StopTimerUseCase handles stopping a user's timer on a task
+type StopTimerUseCase struct {
+	timeEntryRepo repository.TimeEntryRepository
+	taskRepo      repository.TaskRepository
+	taskService   TaskServiceInterface
+	now           func() time.Time
+}
+
+// NewStopTimerUseCase creates a new StopTimerUseCase
+func NewStopTimerUseCase(
+	timeEntryRepo repository.TimeEntryRepository,
+	taskRepo repository.TaskRepository,
+	taskService TaskServiceInterface,
+) *StopTimerUseCase {
+	return &StopTimerUseCase{
+		timeEntryRepo: timeEntryRepo,
+		taskRepo:      taskRepo,
+		taskService:   taskService,
+		now:           time.Now,
+	}
+}
+
+// Execute stops the timer of the user on a task, adding its time to the task, and returns the
+// time tracked on it. It returns application.ErrTimerNotRunning when the user has no timer
+// running on the task.
+func (uc *StopTimerUseCase) Execute(ctx context.Context, taskID, userID string) (*application.TaskTime, error) {
+	if err := checkTaskAccess(ctx, uc.taskRepo, uc.taskService, taskID, userID, false); err != nil {
+		return nil, err
+	}
+
+	entry, err := uc.timeEntryRepo.FindRunning(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	if err := entry.Stop(now); err != nil {
+		return nil, err
+	}
+	stopped, err := uc.timeEntryRepo.Stop(ctx, entry.ID, *entry.StoppedAt)
+	if err != nil {
+		return nil, err
+	}
+	if !stopped {
+		// Stopped by a concurrent request
+		return nil, application.ErrTimerNotRunning
+	}
+
+	return findTaskTime(ctx, uc.timeEntryRepo, taskID, userID, now)
+}
+
+// GetTaskTimeUseCase handles checking the time tracked on a task
+type GetTaskTimeUseCase struct {
+	timeEntryRepo repository.TimeEntryRepository
+	taskRepo      repository.TaskRepository
+	taskService   TaskServiceInterface
+	now           func() time.Time
+}
+
+// NewGetTaskTimeUseCase creates a new GetTaskTimeUseCase
+func NewGetTaskTimeUseCase(
+	timeEntryRepo repository.TimeEntryRepository,
+	taskRepo repository.TaskRepository,
+	taskService TaskServiceInterface,
+) *GetTaskTimeUseCase {
+	return &GetTaskTimeUseCase{
+		timeEntryRepo: timeEntryRepo,
+		taskRepo:      taskRepo,
+		taskService:   taskService,
+		now:           time.Now,
+	}
+}
+
+// Execute returns the time tracked on a task the user can see, with their running timer
+func (uc *GetTaskTimeUseCase) Execute(ctx context.Context, taskID, userID string) (*application.TaskTime, error) {
+	if err := checkTaskAccess(ctx, uc.taskRepo, uc.taskService, taskID, userID, false); err != nil {
+		return nil, err
+	}
+
+	return findTaskTime(ctx, uc.timeEntryRepo, taskID, userID, uc.now())
+}
+
+// ListTaskTimesUseCase handles finding the time tracked on the tasks of a list
+type ListTaskTimesUseCase struct {
+	timeEntryRepo repository.TimeEntryRepository
+	now           func() time.Time
+}
+
+// NewListTaskTimesUseCase creates a new ListTaskTimesUseCase
+func NewListTaskTimesUseCase(timeEntryRepo repository.TimeEntryRepository) *ListTaskTimesUseCase {
+	return &ListTaskTimesUseCase{timeEntryRepo: timeEntryRepo, now: time.Now}
+}
+
+// Execute returns the time tracked on tasks the caller already listed for the user, with the
+// running timers of the user, keyed by task ID. Tasks without tracked time are left out.
+func (uc *ListTaskTimesUseCase) Execute(ctx context.Context, userID string, tasks []*application.Task) (map[string]*application.TaskTime, error) {
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+
+	totals, err := uc.timeEntryRepo.SumByTaskIDs(ctx, ids, uc.now())
+	if err != nil {
+		return nil, err
+	}
+	running, err := uc.timeEntryRepo.FindRunningByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	times := make(map[string]*application.TaskTime, len(totals))
+	for taskID, tracked := range totals {
+		times[taskID] = &application.TaskTime{TaskID: taskID, Tracked: tracked}
+	}
+	for _, entry := range running {
+		if taskTime, ok := times[entry.TaskID]; ok {
+			taskTime.RunningSince = &entry.StartedAt
+		}
+	}
+	return times, nil
+}
@@ -0,0 +1,221 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+type mockTimeEntryRepository struct {
+	entries map[string]*application.TimeEntry
+}
+
+func newMockTimeEntryRepository(entries ...*application.TimeEntry) *mockTimeEntryRepository {
+	repo := &mockTimeEntryRepository{entries: map[string]*application.TimeEntry{}}
+	for _, entry := range entries {
+		repo.entries[entry.ID] = entry
+	}
+	return repo
+}
+
+func (m *mockTimeEntryRepository) Start(ctx context.Context, entry *application.TimeEntry) (bool, error) {
+	if running, _ := m.FindRunning(ctx, entry.TaskID, entry.UserID); running != nil {
+		return false, nil
+	}
+	m.entries[entry.ID] = entry
+	return true, nil
+}
+
+func (m *mockTimeEntryRepository) FindRunning(ctx context.Context, taskID, userID string) (*application.TimeEntry, error) {
+	for _, entry := range m.entries {
+		if entry.TaskID == taskID && entry.UserID == userID && entry.IsRunning() {
+			copied := *entry
+			return &copied, nil
+		}
+	}
+	return nil, application.ErrTimerNotRunning
+}
+
+func (m *mockTimeEntryRepository) FindRunningByUserID(ctx context.Context, userID string) ([]*application.TimeEntry, error) {
+	var entries []*application.TimeEntry
+	for _, entry := range m.entries {
+		if entry.UserID == userID && entry.IsRunning() {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func (m *mockTimeEntryRepository) Stop(ctx context.Context, id string, stoppedAt time.Time) (bool, error) {
+	entry, ok := m.entries[id]
+	if !ok || !entry.IsRunning() {
+		return false, nil
+	}
+	entry.StoppedAt = &stoppedAt
+	return true, nil
+}
+
+func (m *mockTimeEntryRepository) SumByTaskIDs(ctx context.Context, taskIDs []string, now time.Time) (map[string]time.Duration, error) {
+	totals := make(map[string]time.Duration)
+	for _, id := range taskIDs {
+		for _, entry := range m.entries {
+			if entry.TaskID == id {
+				totals[id] += entry.Duration(now)
+			}
+		}
+	}
+	return totals, nil
+}
+
+var timerNow = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func TestStartTimerUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name        string
+		taskID      string
+		userID      string
+		wantErr     error
+		wantTracked time.Duration
+	}{
+		{name: "owner starts a timer", taskID: "task-1", userID: "owner", wantTracked: 90 * time.Minute},
+		{name: "timer already running", taskID: "task-1", userID: "viewer", wantErr: application.ErrTimerRunning},
+		{name: "stranger gets not found", taskID: "task-1", userID: "stranger", wantErr: ErrTaskUnavailable},
+		{name: "missing task", taskID: "missing", userID: "owner", wantErr: ErrTaskUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskRepo := &mockTaskRepositoryForAttachments{mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+				"task-1": {ID: "task-1", OwnerID: "owner"},
+			}}}
+			taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"owner": true, "viewer": true}}
+			stopped := timerNow.Add(-90 * time.Minute)
+			timeEntryRepo := newMockTimeEntryRepository(
+				&application.TimeEntry{ID: "entry-owner", TaskID: "task-1", UserID: "owner", StartedAt: timerNow.Add(-2 * time.Hour), StoppedAt: &stopped},
+				&application.TimeEntry{ID: "entry-viewer", TaskID: "task-1", UserID: "viewer", StartedAt: timerNow.Add(-time.Hour)},
+			)
+			uc := NewStartTimerUseCase(timeEntryRepo, taskRepo, taskService)
+			uc.now = func() time.Time { return timerNow }
+
+			taskTime, err := uc.Execute(context.Background(), tt.taskID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if taskTime.Tracked != tt.wantTracked || !taskTime.IsRunning() || !taskTime.RunningSince.Equal(timerNow) {
+				t.Errorf("Execute() = %+v, want %v tracked and a timer running since now", taskTime, tt.wantTracked)
+			}
+			if len(timeEntryRepo.entries) != 3 {
+				t.Errorf("Expected a new entry, got %d entries", len(timeEntryRepo.entries))
+			}
+		})
+	}
+}
+
+func TestStopTimerUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		wantErr error
+	}{
+		{name: "viewer stops their timer", userID: "viewer"},
+		{name: "no timer running", userID: "owner", wantErr: application.ErrTimerNotRunning},
+		{name: "stranger gets not found", userID: "stranger", wantErr: ErrTaskUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskRepo := &mockTaskRepositoryForAttachments{mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+				"task-1": {ID: "task-1", OwnerID: "owner"},
+			}}}
+			taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"owner": true, "viewer": true}}
+			stopped := timerNow.Add(-90 * time.Minute)
+			timeEntryRepo := newMockTimeEntryRepository(
+				&application.TimeEntry{ID: "entry-owner", TaskID: "task-1", UserID: "owner", StartedAt: timerNow.Add(-2 * time.Hour), StoppedAt: &stopped},
+				&application.TimeEntry{ID: "entry-viewer", TaskID: "task-1", UserID: "viewer", StartedAt: timerNow.Add(-time.Hour)},
+			)
+			uc := NewStopTimerUseCase(timeEntryRepo, taskRepo, taskService)
+			uc.now = func() time.Time { return timerNow }
+
+			taskTime, err := uc.Execute(context.Background(), "task-1", tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if taskTime.Tracked != 90*time.Minute || taskTime.IsRunning() {
+				t.Errorf("Execute() = %+v, want 1h30m tracked and no timer running", taskTime)
+			}
+			if entry := timeEntryRepo.entries["entry-viewer"]; entry.IsRunning() || !entry.StoppedAt.Equal(timerNow) {
+				t.Errorf("Expected the entry to be stopped at now, got %+v", entry)
+			}
+		})
+	}
+}
+
+func TestGetTaskTimeUseCase_Execute(t *testing.T) {
+	taskRepo := &mockTaskRepositoryForAttachments{mockTaskRepositoryForReplaceImage{tasks: map[string]*application.Task{
+		"task-1": {ID: "task-1", OwnerID: "owner"},
+	}}}
+	taskService := &mockTaskServiceForAttachments{viewers: map[string]bool{"owner": true, "viewer": true}}
+	stopped := timerNow.Add(-90 * time.Minute)
+	timeEntryRepo := newMockTimeEntryRepository(
+		&application.TimeEntry{ID: "entry-owner", TaskID: "task-1", UserID: "owner", StartedAt: timerNow.Add(-2 * time.Hour), StoppedAt: &stopped},
+		&application.TimeEntry{ID: "entry-viewer", TaskID: "task-1", UserID: "viewer", StartedAt: timerNow.Add(-time.Hour)},
+	)
+	uc := NewGetTaskTimeUseCase(timeEntryRepo, taskRepo, taskService)
+	uc.now = func() time.Time { return timerNow }
+
+	taskTime, err := uc.Execute(context.Background(), "task-1", "owner")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if taskTime.Tracked != 90*time.Minute || taskTime.IsRunning() {
+		t.Errorf("Execute() = %+v, want 1h30m tracked without a timer of the owner", taskTime)
+	}
+
+	if _, err := uc.Execute(context.Background(), "task-1", "stranger"); !errors.Is(err, ErrTaskUnavailable) {
+		t.Errorf("Execute() by a stranger error = %v, want %v", err, ErrTaskUnavailable)
+	}
+}
+
+func TestListTaskTimesUseCase_Execute(t *testing.T) {
+	stopped := timerNow.Add(-90 * time.Minute)
+	timeEntryRepo := newMockTimeEntryRepository(
+		&application.TimeEntry{ID: "entry-owner", TaskID: "task-1", UserID: "owner", StartedAt: timerNow.Add(-2 * time.Hour), StoppedAt: &stopped},
+		&application.TimeEntry{ID: "entry-viewer", TaskID: "task-1", UserID: "viewer", StartedAt: timerNow.Add(-time.Hour)},
+	)
+	uc := NewListTaskTimesUseCase(timeEntryRepo)
+	uc.now = func() time.Time { return timerNow }
+	tasks := []*application.Task{{ID: "task-1"}, {ID: "task-2"}}
+
+	tests := []struct {
+		name        string
+		userID      string
+		wantRunning bool
+	}{
+		{name: "user with a running timer", userID: "viewer", wantRunning: true},
+		{name: "user without timers", userID: "owner"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			times, err := uc.Execute(context.Background(), tt.userID, tasks)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if len(times) != 1 || times["task-1"] == nil {
+				t.Fatalf("Execute() = %v, want only task-1", times)
+			}
+			if got := times["task-1"]; got.Tracked != 90*time.Minute || got.IsRunning() != tt.wantRunning {
+				t.Errorf("task-1 time = %+v, want 1h30m tracked, running %v", got, tt.wantRunning)
+			}
+		})
+	}
+}
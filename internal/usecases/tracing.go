@@ -0,0 +1,25 @@
+package usecases
+
+import "context"
+
+// Tracer records the execution of use cases as spans of the trace in their context
+type Tracer interface {
+	// Start starts the span of a use case, returning the context of the execution and the
+	// function ending the span with its error
+	Start(ctx context.Context, name string) (context.Context, func(err error))
+}
+
+// noTracer records nothing, until SetTracer is called
+type noTracer struct{}
+
+func (noTracer) Start(ctx context.Context, name string) (context.Context, func(err error)) {
+	return ctx, func(error) {}
+}
+
+var tracer Tracer = noTracer{}
+
+// SetTracer makes the use cases record their execution with t. It must be called before
+// the use cases run, at startup.
+func SetTracer(t Tracer) {
+	tracer = t
+}
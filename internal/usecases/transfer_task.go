@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+var (
+	// ErrTransferPermissionDenied is returned when a user who can see a task tries to transfer it
+	ErrTransferPermissionDenied = errors.New("only the task owner can transfer the task")
+
+	// ErrTransferTargetNotSharee is returned when a task is transferred to a user it isn't shared
+	// with, so tasks can't be handed to strangers
+	ErrTransferTargetNotSharee = errors.New("tasks can only be transferred to a user they are shared with")
+)
+
+// TransferTaskUseCase handles owners handing their tasks over to a user the task is shared with
+type TransferTaskUseCase struct {
+	taskRepo    repository.TaskRepository
+	shareRepo   repository.ShareRepository
+	transactor  repository.Transactor
+	taskService TaskServiceInterface
+	events      event.Publisher
+	now         func() time.Time
+}
+
+// NewTransferTaskUseCase creates a new TransferTaskUseCase
+func NewTransferTaskUseCase(
+	taskRepo repository.TaskRepository,
+	shareRepo repository.ShareRepository,
+	transactor repository.Transactor,
+	taskService TaskServiceInterface,
+	events event.Publisher,
+) *TransferTaskUseCase {
+	return &TransferTaskUseCase{
+		taskRepo:    taskRepo,
+		shareRepo:   shareRepo,
+		transactor:  transactor,
+		taskService: taskService,
+		events:      events,
+		now:         time.Now,
+	}
+}
+
+// Execute transfers a task of ownerID to newOwnerID, who must already share it, and returns the
+// task. The share of the new owner is dropped and the previous owner becomes an accepted sharee,
+// all in one transaction. Completed tasks are only transferred when force is set.
+func (uc *TransferTaskUseCase) Execute(ctx context.Context, taskID, ownerID, newOwnerID string, force bool) (task *application.Task, err error) {
+	ctx, end := tracer.Start(ctx, "TransferTask")
+	defer func() { end(err) }()
+
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		task = nil
+
+		err := checkTaskAccess(ctx, uc.taskRepo, uc.taskService, taskID, ownerID, true)
+		if errors.Is(err, ErrTaskOwnerRequired) {
+			return ErrTransferPermissionDenied
+		}
+		if err != nil {
+			return err
+		}
+
+		found, err := uc.taskRepo.FindByID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if newOwnerID == ownerID {
+			return application.ErrCannotTransferToSelf
+		}
+
+		shared, err := uc.shareRepo.IsSharedWith(ctx, taskID, newOwnerID)
+		if err != nil {
+			return err
+		}
+		if !shared {
+			return ErrTransferTargetNotSharee
+		}
+
+		if err := found.TransferTo(newOwnerID, force, uc.now()); err != nil {
+			return err
+		}
+		if err := uc.taskRepo.Update(ctx, found); err != nil {
+			return err
+		}
+
+		// The new owner no longer needs a share, and the previous owner keeps access through one
+		if err := uc.shareRepo.Unshare(ctx, taskID, newOwnerID); err != nil {
+			return err
+		}
+		if err := uc.shareRepo.Share(ctx, taskID, ownerID); err != nil {
+			return err
+		}
+		if err := uc.shareRepo.AcceptInvitation(ctx, taskID, ownerID); err != nil {
+			return err
+		}
+
+		task = found
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.events.Publish(ctx, event.TaskTransferred{TaskEvent: event.NewTaskEvent(task, ownerID), PreviousOwnerID: ownerID})
+
+	return task, nil
+}
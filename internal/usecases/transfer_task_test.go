@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+func TestTransferTaskUseCase_Execute(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	task, _ := application.NewTask("task-1", "Relatório mensal", "", application.StatusPending, "user-1", "")
+	task.ProjectID = "project-1"
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-2"}}}
+	taskService := service.NewTaskService(taskRepo, shareRepo)
+	publisher := &recordingPublisher{}
+	transactor := &mockTransactor{}
+	useCase := NewTransferTaskUseCase(taskRepo, shareRepo, transactor, taskService, publisher)
+	useCase.now = func() time.Time { return now }
+
+	task, err := useCase.Execute(context.Background(), "task-1", "user-1", "user-2", false)
+	if err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+
+	if task.OwnerID != "user-2" || task.ProjectID != "" || !task.UpdatedAt.Equal(now) {
+		t.Errorf("task = %+v, want it owned by user-2 out of the project", task)
+	}
+	if stored := taskRepo.tasks["task-1"]; stored.OwnerID != "user-2" {
+		t.Errorf("stored owner = %s, want user-2", stored.OwnerID)
+	}
+	// The previous owner keeps access as a sharee, and the new owner no longer needs a share
+	if got := shareRepo.shares["task-1"]; !reflect.DeepEqual(got, []string{"user-1"}) {
+		t.Errorf("shares = %v, want [user-1]", got)
+	}
+	if transactor.calls != 1 {
+		t.Errorf("Expected 1 transaction, got %d", transactor.calls)
+	}
+
+	if len(publisher.events) != 1 {
+		t.Fatalf("events = %v, want [task.transferred]", publisher.names())
+	}
+	transferred, ok := publisher.events[0].(event.TaskTransferred)
+	if !ok || transferred.OwnerID != "user-2" || transferred.ActorID != "user-1" || transferred.PreviousOwnerID != "user-1" {
+		t.Errorf("event = %+v, want user-1 transferring to user-2", publisher.events[0])
+	}
+}
+
+func TestTransferTaskUseCase_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   string
+		newOwner string
+		status   application.TaskStatus
+		force    bool
+		wantErr  error
+	}{
+		{"sharee", "user-2", "user-1", application.StatusPending, false, ErrTransferPermissionDenied},
+		{"no access", "user-3", "user-2", application.StatusPending, false, ErrTaskUnavailable},
+		{"to the owner", "user-1", "user-1", application.StatusPending, false, application.ErrCannotTransferToSelf},
+		{"to a user the task isn't shared with", "user-1", "user-3", application.StatusPending, false, ErrTransferTargetNotSharee},
+		{"completed task", "user-1", "user-2", application.StatusCompleted, false, application.ErrTransferCompletedTask},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, _ := application.NewTask("task-1", "Relatório mensal", "", tt.status, "user-1", "")
+			taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+			shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-2"}}}
+			taskService := service.NewTaskService(taskRepo, shareRepo)
+			publisher := &recordingPublisher{}
+			useCase := NewTransferTaskUseCase(taskRepo, shareRepo, &mockTransactor{}, taskService, publisher)
+
+			if _, err := useCase.Execute(context.Background(), "task-1", tt.userID, tt.newOwner, tt.force); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if taskRepo.tasks["task-1"].OwnerID != "user-1" || !reflect.DeepEqual(shareRepo.shares["task-1"], []string{"user-2"}) {
+				t.Errorf("task owned by %s shared with %v, want it unchanged", taskRepo.tasks["task-1"].OwnerID, shareRepo.shares["task-1"])
+			}
+			if len(publisher.events) != 0 {
+				t.Errorf("events = %v, want none", publisher.names())
+			}
+		})
+	}
+}
+
+func TestTransferTaskUseCase_ForcesCompletedTask(t *testing.T) {
+	task, _ := application.NewTask("task-1", "Relatório mensal", "", application.StatusCompleted, "user-1", "")
+	taskRepo := &mockTaskRepositoryForShare{tasks: map[string]*application.Task{"task-1": task}}
+	shareRepo := &mockShareRepositoryForShare{shares: map[string][]string{"task-1": {"user-2"}}}
+	taskService := service.NewTaskService(taskRepo, shareRepo)
+	useCase := NewTransferTaskUseCase(taskRepo, shareRepo, &mockTransactor{}, taskService, &recordingPublisher{})
+
+	task, err := useCase.Execute(context.Background(), "task-1", "user-1", "user-2", true)
+	if err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if task.OwnerID != "user-2" || task.Status != application.StatusCompleted {
+		t.Errorf("task = %+v, want it completed and owned by user-2", task)
+	}
+}
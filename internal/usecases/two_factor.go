@@ -0,0 +1,322 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+)
+
+// qrCodeSize is the side in pixels of the provisioning QR code shown on enrollment
+const qrCodeSize = 200
+
+// TwoFactorEnrollment is the secret of a pending two-factor enrollment, to be added to an
+// authenticator app by scanning the QR code or typing the secret
+type TwoFactorEnrollment struct {
+	Secret    string
+	URI       string // otpauth:// provisioning URI
+	QRCodePNG []byte
+}
+
+// TwoFactorStatus tells whether a user has two-factor authentication enabled and how many
+// recovery codes are left
+type TwoFactorStatus struct {
+	Enabled           bool
+	RecoveryCodesLeft int
+}
+
+// BeginTwoFactorEnrollmentUseCase handles generating the TOTP secret of a user
+type BeginTwoFactorEnrollmentUseCase struct {
+	userRepo      repository.UserRepository
+	twoFactorRepo repository.TwoFactorRepository
+	totp          *service.TOTPService
+}
+
+// NewBeginTwoFactorEnrollmentUseCase creates a new BeginTwoFactorEnrollmentUseCase
+func NewBeginTwoFactorEnrollmentUseCase(userRepo repository.UserRepository, twoFactorRepo repository.TwoFactorRepository, totp *service.TOTPService) *BeginTwoFactorEnrollmentUseCase {
+	return &BeginTwoFactorEnrollmentUseCase{
+		userRepo:      userRepo,
+		twoFactorRepo: twoFactorRepo,
+		totp:          totp,
+	}
+}
+
+// Execute generates a new secret for the user. It isn't enforced until EnableTwoFactorUseCase
+// confirms it with a first code; starting over replaces a pending secret.
+func (uc *BeginTwoFactorEnrollmentUseCase) Execute(ctx context.Context, userID string) (*TwoFactorEnrollment, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, enabled, err := findEnabledTwoFactor(ctx, uc.twoFactorRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, application.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, uri, err := uc.totp.GenerateSecret(user.Email)
+	if err != nil {
+		return nil, err
+	}
+	qrCode, err := uc.totp.QRCodePNG(uri, qrCodeSize)
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := application.NewTwoFactor(userID, secret)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.twoFactorRepo.Save(ctx, twoFactor); err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorEnrollment{Secret: secret, URI: uri, QRCodePNG: qrCode}, nil
+}
+
+// EnableTwoFactorUseCase handles confirming a pending enrollment
+type EnableTwoFactorUseCase struct {
+	twoFactorRepo repository.TwoFactorRepository
+	totp          *service.TOTPService
+	now           func() time.Time
+}
+
+// NewEnableTwoFactorUseCase creates a new EnableTwoFactorUseCase
+func NewEnableTwoFactorUseCase(twoFactorRepo repository.TwoFactorRepository, totp *service.TOTPService) *EnableTwoFactorUseCase {
+	return &EnableTwoFactorUseCase{
+		twoFactorRepo: twoFactorRepo,
+		totp:          totp,
+		now:           time.Now,
+	}
+}
+
+// Execute enables two-factor authentication once code proves the authenticator app holds the
+// pending secret, and returns the recovery codes. They are only stored hashed, so this is the
+// only time they can be shown.
+func (uc *EnableTwoFactorUseCase) Execute(ctx context.Context, userID, code string) ([]string, error) {
+	twoFactor, err := uc.twoFactorRepo.FindByUserID(ctx, userID)
+	if errors.Is(err, application.ErrTwoFactorNotFound) {
+		return nil, application.ErrTwoFactorNotEnrolled
+	}
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor.Enabled {
+		return nil, application.ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok := uc.totp.Verify(twoFactor.Secret, code, uc.now())
+	if !ok {
+		return nil, application.ErrInvalidTwoFactorCode
+	}
+
+	codes, err := service.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]string, len(codes))
+	for i, recoveryCode := range codes {
+		hashes[i] = service.HashRecoveryCode(recoveryCode)
+	}
+
+	// Store the codes before enabling, so an enabled second factor always has them
+	if err := uc.twoFactorRepo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	twoFactor.Enabled = true
+	twoFactor.LastStep = step
+	if err := uc.twoFactorRepo.Save(ctx, twoFactor); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// DisableTwoFactorUseCase handles turning two-factor authentication off
+type DisableTwoFactorUseCase struct {
+	twoFactorRepo repository.TwoFactorRepository
+	totp          *service.TOTPService
+	now           func() time.Time
+}
+
+// NewDisableTwoFactorUseCase creates a new DisableTwoFactorUseCase
+func NewDisableTwoFactorUseCase(twoFactorRepo repository.TwoFactorRepository, totp *service.TOTPService) *DisableTwoFactorUseCase {
+	return &DisableTwoFactorUseCase{
+		twoFactorRepo: twoFactorRepo,
+		totp:          totp,
+		now:           time.Now,
+	}
+}
+
+// Execute disables two-factor authentication. A current code or a recovery code is required,
+// so a stolen session alone can't remove the second factor.
+func (uc *DisableTwoFactorUseCase) Execute(ctx context.Context, userID, code string) error {
+	twoFactor, enabled, err := findEnabledTwoFactor(ctx, uc.twoFactorRepo, userID)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return application.ErrTwoFactorNotEnabled
+	}
+
+	ok, err := verifySecondFactor(ctx, uc.twoFactorRepo, uc.totp, twoFactor, code, uc.now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return application.ErrInvalidTwoFactorCode
+	}
+
+	return uc.twoFactorRepo.Delete(ctx, userID)
+}
+
+// GetTwoFactorStatusUseCase handles reading the two-factor status of a user
+type GetTwoFactorStatusUseCase struct {
+	twoFactorRepo repository.TwoFactorRepository
+}
+
+// NewGetTwoFactorStatusUseCase creates a new GetTwoFactorStatusUseCase
+func NewGetTwoFactorStatusUseCase(twoFactorRepo repository.TwoFactorRepository) *GetTwoFactorStatusUseCase {
+	return &GetTwoFactorStatusUseCase{
+		twoFactorRepo: twoFactorRepo,
+	}
+}
+
+// Execute returns the two-factor status of a user. Pending enrollments count as disabled.
+func (uc *GetTwoFactorStatusUseCase) Execute(ctx context.Context, userID string) (*TwoFactorStatus, error) {
+	_, enabled, err := findEnabledTwoFactor(ctx, uc.twoFactorRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return &TwoFactorStatus{}, nil
+	}
+
+	left, err := uc.twoFactorRepo.CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &TwoFactorStatus{Enabled: true, RecoveryCodesLeft: left}, nil
+}
+
+// VerifyTwoFactorUseCase handles the second step of the login of users with two-factor
+// authentication
+type VerifyTwoFactorUseCase struct {
+	userRepo      repository.UserRepository
+	twoFactorRepo repository.TwoFactorRepository
+	totp          *service.TOTPService
+	authService   *service.AuthService
+	durations     SessionDurations
+	now           func() time.Time
+}
+
+// NewVerifyTwoFactorUseCase creates a new VerifyTwoFactorUseCase. Sessions last as long as
+// the ones issued by LoginUseCase.
+func NewVerifyTwoFactorUseCase(
+	userRepo repository.UserRepository,
+	twoFactorRepo repository.TwoFactorRepository,
+	totp *service.TOTPService,
+	jwtKeys *service.JWTKeySet,
+	durations SessionDurations,
+) *VerifyTwoFactorUseCase {
+	return &VerifyTwoFactorUseCase{
+		userRepo:      userRepo,
+		twoFactorRepo: twoFactorRepo,
+		totp:          totp,
+		authService:   service.NewAuthServiceWithKeys(jwtKeys, nil),
+		durations:     durations.withDefaults(),
+		now:           time.Now,
+	}
+}
+
+// Execute answers the login challenge identified by token with a TOTP or recovery code and
+// returns the JWT token of the session. Each challenge accepts MaxTwoFactorAttempts wrong
+// codes and a single right one.
+func (uc *VerifyTwoFactorUseCase) Execute(ctx context.Context, token, code string) (*LoginResult, error) {
+	if token == "" {
+		return nil, application.ErrTwoFactorChallengeExpired
+	}
+	tokenHash := service.HashChallengeToken(token)
+
+	challenge, err := uc.twoFactorRepo.FindChallenge(ctx, tokenHash)
+	if errors.Is(err, application.ErrTwoFactorChallengeNotFound) {
+		return nil, application.ErrTwoFactorChallengeExpired
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !challenge.Usable(uc.now()) {
+		if _, err := uc.twoFactorRepo.DeleteChallenge(ctx, tokenHash); err != nil {
+			return nil, err
+		}
+		return nil, application.ErrTwoFactorChallengeExpired
+	}
+
+	twoFactor, enabled, err := findEnabledTwoFactor(ctx, uc.twoFactorRepo, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		// Disabled since the password step: the user just has to sign in again
+		if _, err := uc.twoFactorRepo.DeleteChallenge(ctx, tokenHash); err != nil {
+			return nil, err
+		}
+		return nil, application.ErrTwoFactorChallengeExpired
+	}
+
+	ok, err := verifySecondFactor(ctx, uc.twoFactorRepo, uc.totp, twoFactor, code, uc.now())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err := uc.twoFactorRepo.RecordChallengeAttempt(ctx, tokenHash); err != nil {
+			return nil, err
+		}
+		return nil, application.ErrInvalidTwoFactorCode
+	}
+
+	// Deleting the challenge is what consumes it, so concurrent answers can't both succeed
+	deleted, err := uc.twoFactorRepo.DeleteChallenge(ctx, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return nil, application.ErrTwoFactorChallengeExpired
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return issueSession(uc.authService, uc.durations, user, challenge.RememberMe)
+}
+
+// findEnabledTwoFactor finds the second factor of a user and reports whether it is enforced:
+// users who never enrolled, or haven't confirmed the enrollment yet, sign in with the password
+func findEnabledTwoFactor(ctx context.Context, twoFactorRepo repository.TwoFactorRepository, userID string) (*application.TwoFactor, bool, error) {
+	twoFactor, err := twoFactorRepo.FindByUserID(ctx, userID)
+	if errors.Is(err, application.ErrTwoFactorNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return twoFactor, twoFactor.Enabled, nil
+}
+
+// verifySecondFactor checks code as a TOTP code, refusing time steps already used, or else
+// as a recovery code, which is consumed
+func verifySecondFactor(ctx context.Context, twoFactorRepo repository.TwoFactorRepository, totp *service.TOTPService, twoFactor *application.TwoFactor, code string, now time.Time) (bool, error) {
+	if step, ok := totp.Verify(twoFactor.Secret, code, now); ok {
+		return twoFactorRepo.AdvanceLastStep(ctx, twoFactor.UserID, step)
+	}
+	return twoFactorRepo.UseRecoveryCode(ctx, twoFactor.UserID, service.HashRecoveryCode(code))
+}
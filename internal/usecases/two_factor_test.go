@@ -0,0 +1,430 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
+	"github.com/pquerna/otp/totp"
+)
+
+// Mock TwoFactorRepository for testing
+type mockTwoFactorRepository struct {
+	twoFactors    map[string]*application.TwoFactor
+	recoveryCodes map[string]map[string]bool
+	challenges    map[string]*application.TwoFactorChallenge
+}
+
+func newMockTwoFactorRepository() *mockTwoFactorRepository {
+	return &mockTwoFactorRepository{
+		twoFactors:    make(map[string]*application.TwoFactor),
+		recoveryCodes: make(map[string]map[string]bool),
+		challenges:    make(map[string]*application.TwoFactorChallenge),
+	}
+}
+
+func (m *mockTwoFactorRepository) FindByUserID(ctx context.Context, userID string) (*application.TwoFactor, error) {
+	if twoFactor, ok := m.twoFactors[userID]; ok {
+		copied := *twoFactor
+		return &copied, nil
+	}
+	return nil, application.ErrTwoFactorNotFound
+}
+
+func (m *mockTwoFactorRepository) Save(ctx context.Context, twoFactor *application.TwoFactor) error {
+	copied := *twoFactor
+	m.twoFactors[twoFactor.UserID] = &copied
+	return nil
+}
+
+func (m *mockTwoFactorRepository) AdvanceLastStep(ctx context.Context, userID string, step int64) (bool, error) {
+	twoFactor, ok := m.twoFactors[userID]
+	if !ok || twoFactor.LastStep >= step {
+		return false, nil
+	}
+	twoFactor.LastStep = step
+	return true, nil
+}
+
+func (m *mockTwoFactorRepository) Delete(ctx context.Context, userID string) error {
+	delete(m.twoFactors, userID)
+	delete(m.recoveryCodes, userID)
+	return nil
+}
+
+func (m *mockTwoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	m.recoveryCodes[userID] = make(map[string]bool)
+	for _, hash := range codeHashes {
+		m.recoveryCodes[userID][hash] = true
+	}
+	return nil
+}
+
+func (m *mockTwoFactorRepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	if !m.recoveryCodes[userID][codeHash] {
+		return false, nil
+	}
+	delete(m.recoveryCodes[userID], codeHash)
+	return true, nil
+}
+
+func (m *mockTwoFactorRepository) CountRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	return len(m.recoveryCodes[userID]), nil
+}
+
+func (m *mockTwoFactorRepository) CreateChallenge(ctx context.Context, challenge *application.TwoFactorChallenge) error {
+	m.challenges[challenge.TokenHash] = challenge
+	return nil
+}
+
+func (m *mockTwoFactorRepository) FindChallenge(ctx context.Context, tokenHash string) (*application.TwoFactorChallenge, error) {
+	if challenge, ok := m.challenges[tokenHash]; ok {
+		copied := *challenge
+		return &copied, nil
+	}
+	return nil, application.ErrTwoFactorChallengeNotFound
+}
+
+func (m *mockTwoFactorRepository) RecordChallengeAttempt(ctx context.Context, tokenHash string) error {
+	if challenge, ok := m.challenges[tokenHash]; ok {
+		challenge.Attempts++
+	}
+	return nil
+}
+
+func (m *mockTwoFactorRepository) DeleteChallenge(ctx context.Context, tokenHash string) (bool, error) {
+	_, ok := m.challenges[tokenHash]
+	delete(m.challenges, tokenHash)
+	return ok, nil
+}
+
+// user-1 (ana@example.com / password123) of the login tests enabled two-factor with
+// twoFactorSecret at twoFactorEnabledAt, and has the recovery code abcde-fghij left
+const twoFactorSecret = "JBSWY3DPEHPK3PXP"
+
+var twoFactorEnabledAt = time.Unix(1700000000, 0)
+
+// loginChallenge logs user-1 in with the password and returns the token of the login challenge
+func loginChallenge(t *testing.T, login *LoginUseCase, rememberMe bool) string {
+	t.Helper()
+	result, err := login.Execute(context.Background(), "ana@example.com", "password123", rememberMe)
+	if err != nil {
+		t.Fatalf("Login.Execute() error: %v", err)
+	}
+	if !result.TwoFactorRequired() || result.Token != "" {
+		t.Fatalf("Login.Execute() = %+v, want a challenge and no session token", result)
+	}
+	return result.TwoFactorToken
+}
+
+func TestBeginTwoFactorEnrollmentUseCase_Execute(t *testing.T) {
+	users := &mockUserRepositoryForLogin{users: map[string]*application.User{
+		"user-1": {ID: "user-1", Email: "ana@example.com"},
+	}}
+	repo := newMockTwoFactorRepository()
+	uc := NewBeginTwoFactorEnrollmentUseCase(users, repo, service.NewTOTPService("Todo App"))
+
+	enrollment, err := uc.Execute(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if enrollment.Secret == "" || enrollment.URI == "" || len(enrollment.QRCodePNG) == 0 {
+		t.Errorf("Execute() = %+v, want secret, URI and QR code", enrollment)
+	}
+
+	pending := repo.twoFactors["user-1"]
+	if pending == nil || pending.Secret != enrollment.Secret || pending.Enabled {
+		t.Fatalf("stored two-factor = %+v, want the pending secret", pending)
+	}
+
+	// Starting over replaces the pending secret
+	again, err := uc.Execute(context.Background(), "user-1")
+	if err != nil || again.Secret == enrollment.Secret || repo.twoFactors["user-1"].Secret != again.Secret {
+		t.Errorf("Execute() again = %+v, %v; want a new pending secret", again, err)
+	}
+
+	repo.twoFactors["user-1"].Enabled = true
+	if _, err := uc.Execute(context.Background(), "user-1"); !errors.Is(err, application.ErrTwoFactorAlreadyEnabled) {
+		t.Errorf("Execute() with two-factor enabled error = %v, want ErrTwoFactorAlreadyEnabled", err)
+	}
+}
+
+func TestEnableTwoFactorUseCase_Execute(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	repo := newMockTwoFactorRepository()
+	uc := NewEnableTwoFactorUseCase(repo, service.NewTOTPService("Todo App"))
+	uc.now = func() time.Time { return now }
+
+	if _, err := uc.Execute(context.Background(), "user-1", "123456"); !errors.Is(err, application.ErrTwoFactorNotEnrolled) {
+		t.Errorf("Execute() without enrollment error = %v, want ErrTwoFactorNotEnrolled", err)
+	}
+
+	secret, _, err := service.NewTOTPService("Todo App").GenerateSecret("ana@example.com")
+	if err != nil {
+		t.Fatalf("GenerateSecret() error: %v", err)
+	}
+	pending, _ := application.NewTwoFactor("user-1", secret)
+	repo.Save(context.Background(), pending)
+
+	wrong, _ := totp.GenerateCode(secret, now.Add(-time.Hour))
+	if _, err := uc.Execute(context.Background(), "user-1", wrong); !errors.Is(err, application.ErrInvalidTwoFactorCode) {
+		t.Errorf("Execute() with a stale code error = %v, want ErrInvalidTwoFactorCode", err)
+	}
+	if repo.twoFactors["user-1"].Enabled {
+		t.Fatal("a wrong code must not enable two-factor")
+	}
+
+	code, _ := totp.GenerateCode(secret, now)
+	codes, err := uc.Execute(context.Background(), "user-1", code)
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if len(codes) != service.RecoveryCodeCount {
+		t.Errorf("Execute() returned %d recovery codes, want %d", len(codes), service.RecoveryCodeCount)
+	}
+
+	stored := repo.twoFactors["user-1"]
+	if !stored.Enabled || stored.LastStep != now.Unix()/30 {
+		t.Errorf("stored two-factor = %+v, want enabled with the step of the code", stored)
+	}
+	for _, recoveryCode := range codes {
+		if !repo.recoveryCodes["user-1"][service.HashRecoveryCode(recoveryCode)] {
+			t.Errorf("recovery code %q is not stored hashed", recoveryCode)
+		}
+		if repo.recoveryCodes["user-1"][recoveryCode] {
+			t.Errorf("recovery code %q is stored in clear", recoveryCode)
+		}
+	}
+
+	if _, err := uc.Execute(context.Background(), "user-1", code); !errors.Is(err, application.ErrTwoFactorAlreadyEnabled) {
+		t.Errorf("Execute() twice error = %v, want ErrTwoFactorAlreadyEnabled", err)
+	}
+}
+
+func TestLoginUseCase_Execute_WithoutTwoFactorIssuesSession(t *testing.T) {
+	users := &mockUserRepositoryForLogin{users: map[string]*application.User{}}
+	login := NewLoginUseCase(users, newMockTwoFactorRepository(), service.NewJWTKeySet("test-secret-key"), nil, SessionDurations{}, 0)
+	passwordHash, _ := login.authService.HashPassword("password123")
+	users.users["user-1"] = &application.User{ID: "user-1", Email: "ana@example.com", PasswordHash: passwordHash}
+
+	result, err := login.Execute(context.Background(), "ana@example.com", "password123", false)
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if result.TwoFactorRequired() || result.Token == "" {
+		t.Errorf("Execute() = %+v, want a session token", result)
+	}
+}
+
+func TestLoginUseCase_Execute_WrongPasswordNeverStartsChallenge(t *testing.T) {
+	users := &mockUserRepositoryForLogin{users: map[string]*application.User{}}
+	repo := newMockTwoFactorRepository()
+	login := NewLoginUseCase(users, repo, service.NewJWTKeySet("test-secret-key"), nil, SessionDurations{}, 0)
+	passwordHash, _ := login.authService.HashPassword("password123")
+	users.users["user-1"] = &application.User{ID: "user-1", Email: "ana@example.com", PasswordHash: passwordHash, Locale: application.LocaleEN}
+	twoFactor, _ := application.NewTwoFactor("user-1", twoFactorSecret)
+	twoFactor.Enabled, twoFactor.LastStep = true, twoFactorEnabledAt.Unix()/30
+	repo.Save(context.Background(), twoFactor)
+	repo.ReplaceRecoveryCodes(context.Background(), "user-1", []string{service.HashRecoveryCode("abcde-fghij")})
+
+	if _, err := login.Execute(context.Background(), "ana@example.com", "wrong", false); err == nil {
+		t.Fatal("Execute() with a wrong password expected error but got nil")
+	}
+	if len(repo.challenges) != 0 {
+		t.Errorf("a wrong password created %d challenges, want 0", len(repo.challenges))
+	}
+}
+
+func TestVerifyTwoFactorUseCase_Execute(t *testing.T) {
+	users := &mockUserRepositoryForLogin{users: map[string]*application.User{}}
+	repo := newMockTwoFactorRepository()
+	login := NewLoginUseCase(users, repo, service.NewJWTKeySet("test-secret-key"), nil, SessionDurations{}, 0)
+	passwordHash, _ := login.authService.HashPassword("password123")
+	users.users["user-1"] = &application.User{ID: "user-1", Email: "ana@example.com", PasswordHash: passwordHash, Locale: application.LocaleEN}
+	twoFactor, _ := application.NewTwoFactor("user-1", twoFactorSecret)
+	twoFactor.Enabled, twoFactor.LastStep = true, twoFactorEnabledAt.Unix()/30
+	repo.Save(context.Background(), twoFactor)
+	repo.ReplaceRecoveryCodes(context.Background(), "user-1", []string{service.HashRecoveryCode("abcde-fghij")})
+	verify := NewVerifyTwoFactorUseCase(users, repo, service.NewTOTPService("Todo App"), service.NewJWTKeySet("test-secret-key"), SessionDurations{})
+	later := twoFactorEnabledAt.Add(time.Minute)
+	verify.now = func() time.Time { return later }
+	code, _ := totp.GenerateCode(twoFactorSecret, later)
+
+	token := loginChallenge(t, login, true)
+
+	if _, err := verify.Execute(context.Background(), token, "000000"); !errors.Is(err, application.ErrInvalidTwoFactorCode) {
+		t.Errorf("Execute() with a wrong code error = %v, want ErrInvalidTwoFactorCode", err)
+	}
+
+	session, err := verify.Execute(context.Background(), token, code)
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if session.Token == "" || session.Duration != DefaultRememberMeDuration || session.Locale != application.LocaleEN {
+		t.Errorf("Execute() = %+v, want a remember me session in the user's locale", session)
+	}
+	claims, err := service.NewAuthService("test-secret-key").ValidateToken(session.Token)
+	if err != nil || claims.UserID != "user-1" {
+		t.Errorf("session token claims = %+v, %v", claims, err)
+	}
+
+	// The challenge is consumed
+	if _, err := verify.Execute(context.Background(), token, code); !errors.Is(err, application.ErrTwoFactorChallengeExpired) {
+		t.Errorf("Execute() with a used challenge error = %v, want ErrTwoFactorChallengeExpired", err)
+	}
+}
+
+func TestVerifyTwoFactorUseCase_Execute_RejectsReplayedCode(t *testing.T) {
+	users := &mockUserRepositoryForLogin{users: map[string]*application.User{}}
+	repo := newMockTwoFactorRepository()
+	login := NewLoginUseCase(users, repo, service.NewJWTKeySet("test-secret-key"), nil, SessionDurations{}, 0)
+	passwordHash, _ := login.authService.HashPassword("password123")
+	users.users["user-1"] = &application.User{ID: "user-1", Email: "ana@example.com", PasswordHash: passwordHash, Locale: application.LocaleEN}
+	twoFactor, _ := application.NewTwoFactor("user-1", twoFactorSecret)
+	twoFactor.Enabled, twoFactor.LastStep = true, twoFactorEnabledAt.Unix()/30
+	repo.Save(context.Background(), twoFactor)
+	repo.ReplaceRecoveryCodes(context.Background(), "user-1", []string{service.HashRecoveryCode("abcde-fghij")})
+	verify := NewVerifyTwoFactorUseCase(users, repo, service.NewTOTPService("Todo App"), service.NewJWTKeySet("test-secret-key"), SessionDurations{})
+	verify.now = func() time.Time { return twoFactorEnabledAt }
+
+	// The code that enabled two-factor was already used
+	token := loginChallenge(t, login, false)
+	code, _ := totp.GenerateCode(twoFactorSecret, twoFactorEnabledAt)
+	if _, err := verify.Execute(context.Background(), token, code); !errors.Is(err, application.ErrInvalidTwoFactorCode) {
+		t.Errorf("Execute() with a replayed code error = %v, want ErrInvalidTwoFactorCode", err)
+	}
+}
+
+func TestVerifyTwoFactorUseCase_Execute_RecoveryCode(t *testing.T) {
+	users := &mockUserRepositoryForLogin{users: map[string]*application.User{}}
+	repo := newMockTwoFactorRepository()
+	login := NewLoginUseCase(users, repo, service.NewJWTKeySet("test-secret-key"), nil, SessionDurations{}, 0)
+	passwordHash, _ := login.authService.HashPassword("password123")
+	users.users["user-1"] = &application.User{ID: "user-1", Email: "ana@example.com", PasswordHash: passwordHash, Locale: application.LocaleEN}
+	twoFactor, _ := application.NewTwoFactor("user-1", twoFactorSecret)
+	twoFactor.Enabled, twoFactor.LastStep = true, twoFactorEnabledAt.Unix()/30
+	repo.Save(context.Background(), twoFactor)
+	repo.ReplaceRecoveryCodes(context.Background(), "user-1", []string{service.HashRecoveryCode("abcde-fghij")})
+	repo.ReplaceRecoveryCodes(context.Background(), "user-1", []string{service.HashRecoveryCode("abcde-fghij"), service.HashRecoveryCode("klmno-pqrst")})
+	verify := NewVerifyTwoFactorUseCase(users, repo, service.NewTOTPService("Todo App"), service.NewJWTKeySet("test-secret-key"), SessionDurations{})
+
+	token := loginChallenge(t, login, false)
+	if _, err := verify.Execute(context.Background(), token, "ABCDE FGHIJ"); err != nil {
+		t.Fatalf("Execute() with a recovery code unexpected error: %v", err)
+	}
+
+	if left, _ := NewGetTwoFactorStatusUseCase(repo).Execute(context.Background(), "user-1"); left.RecoveryCodesLeft != 1 {
+		t.Errorf("RecoveryCodesLeft = %d, want 1", left.RecoveryCodesLeft)
+	}
+
+	token = loginChallenge(t, login, false)
+	if _, err := verify.Execute(context.Background(), token, "abcde-fghij"); !errors.Is(err, application.ErrInvalidTwoFactorCode) {
+		t.Errorf("Execute() with a used recovery code error = %v, want ErrInvalidTwoFactorCode", err)
+	}
+}
+
+func TestVerifyTwoFactorUseCase_Execute_UnusableChallenges(t *testing.T) {
+	users := &mockUserRepositoryForLogin{users: map[string]*application.User{}}
+	repo := newMockTwoFactorRepository()
+	login := NewLoginUseCase(users, repo, service.NewJWTKeySet("test-secret-key"), nil, SessionDurations{}, 0)
+	passwordHash, _ := login.authService.HashPassword("password123")
+	users.users["user-1"] = &application.User{ID: "user-1", Email: "ana@example.com", PasswordHash: passwordHash, Locale: application.LocaleEN}
+	twoFactor, _ := application.NewTwoFactor("user-1", twoFactorSecret)
+	twoFactor.Enabled, twoFactor.LastStep = true, twoFactorEnabledAt.Unix()/30
+	repo.Save(context.Background(), twoFactor)
+	repo.ReplaceRecoveryCodes(context.Background(), "user-1", []string{service.HashRecoveryCode("abcde-fghij")})
+	verify := NewVerifyTwoFactorUseCase(users, repo, service.NewTOTPService("Todo App"), service.NewJWTKeySet("test-secret-key"), SessionDurations{})
+	expectExpired := func(step, token string) {
+		t.Helper()
+		if _, err := verify.Execute(context.Background(), token, "abcde-fghij"); !errors.Is(err, application.ErrTwoFactorChallengeExpired) {
+			t.Errorf("%s: Execute() error = %v, want ErrTwoFactorChallengeExpired", step, err)
+		}
+		if len(repo.challenges) != 0 {
+			t.Errorf("%s: an unusable challenge should be deleted", step)
+		}
+	}
+
+	for _, token := range []string{"", "unknown"} {
+		expectExpired("token "+token, token)
+	}
+
+	token := loginChallenge(t, login, false)
+	verify.now = func() time.Time { return time.Now().Add(TwoFactorChallengeDuration + time.Second) }
+	expectExpired("expired", token)
+	verify.now = time.Now
+
+	token = loginChallenge(t, login, false)
+	for i := 0; i < application.MaxTwoFactorAttempts; i++ {
+		verify.Execute(context.Background(), token, "000000")
+	}
+	expectExpired("attempts exhausted", token)
+
+	token = loginChallenge(t, login, false)
+	repo.Delete(context.Background(), "user-1")
+	expectExpired("two-factor disabled since the password step", token)
+}
+
+func TestDisableTwoFactorUseCase_Execute(t *testing.T) {
+	users := &mockUserRepositoryForLogin{users: map[string]*application.User{}}
+	repo := newMockTwoFactorRepository()
+	login := NewLoginUseCase(users, repo, service.NewJWTKeySet("test-secret-key"), nil, SessionDurations{}, 0)
+	passwordHash, _ := login.authService.HashPassword("password123")
+	users.users["user-1"] = &application.User{ID: "user-1", Email: "ana@example.com", PasswordHash: passwordHash, Locale: application.LocaleEN}
+	twoFactor, _ := application.NewTwoFactor("user-1", twoFactorSecret)
+	twoFactor.Enabled, twoFactor.LastStep = true, twoFactorEnabledAt.Unix()/30
+	repo.Save(context.Background(), twoFactor)
+	repo.ReplaceRecoveryCodes(context.Background(), "user-1", []string{service.HashRecoveryCode("abcde-fghij")})
+	disable := NewDisableTwoFactorUseCase(repo, service.NewTOTPService("Todo App"))
+	later := twoFactorEnabledAt.Add(time.Minute)
+	disable.now = func() time.Time { return later }
+	code, _ := totp.GenerateCode(twoFactorSecret, later)
+
+	if err := disable.Execute(context.Background(), "user-1", "000000"); !errors.Is(err, application.ErrInvalidTwoFactorCode) {
+		t.Errorf("Execute() with a wrong code error = %v, want ErrInvalidTwoFactorCode", err)
+	}
+
+	if err := disable.Execute(context.Background(), "user-1", code); err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	status, err := NewGetTwoFactorStatusUseCase(repo).Execute(context.Background(), "user-1")
+	if err != nil || status.Enabled || status.RecoveryCodesLeft != 0 {
+		t.Errorf("status after disabling = %+v, %v", status, err)
+	}
+
+	if err := disable.Execute(context.Background(), "user-1", code); !errors.Is(err, application.ErrTwoFactorNotEnabled) {
+		t.Errorf("Execute() twice error = %v, want ErrTwoFactorNotEnabled", err)
+	}
+
+	result, err := login.Execute(context.Background(), "ana@example.com", "password123", false)
+	if err != nil || result.TwoFactorRequired() {
+		t.Errorf("login after disabling = %+v, %v; want a session", result, err)
+	}
+}
+
+func TestGetTwoFactorStatusUseCase_Execute(t *testing.T) {
+	repo := newMockTwoFactorRepository()
+	uc := NewGetTwoFactorStatusUseCase(repo)
+
+	status, err := uc.Execute(context.Background(), "user-1")
+	if err != nil || status.Enabled {
+		t.Errorf("Execute() without two-factor = %+v, %v", status, err)
+	}
+
+	pending, _ := application.NewTwoFactor("user-1", "SECRET")
+	repo.Save(context.Background(), pending)
+	if status, _ := uc.Execute(context.Background(), "user-1"); status.Enabled {
+		t.Error("a pending enrollment should count as disabled")
+	}
+
+	pending.Enabled = true
+	repo.Save(context.Background(), pending)
+	repo.ReplaceRecoveryCodes(context.Background(), "user-1", []string{"a", "b"})
+	status, err = uc.Execute(context.Background(), "user-1")
+	if err != nil || !status.Enabled || status.RecoveryCodesLeft != 2 {
+		t.Errorf("Execute() = %+v, %v; want enabled with 2 recovery codes", status, err)
+	}
+}
@@ -2,36 +2,46 @@ package usecases
 
 import (
 	"context"
-	"errors"
+	"time"
 
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
 	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
 	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
 )
 
 // UnshareTaskUseCase handles removing task sharing
 type UnshareTaskUseCase struct {
+	taskRepo    repository.TaskRepository
 	shareRepo   repository.ShareRepository
 	taskService *service.TaskService
+	events      event.Publisher
 }
 
 // NewUnshareTaskUseCase creates a new UnshareTaskUseCase
-func NewUnshareTaskUseCase(shareRepo repository.ShareRepository, taskService *service.TaskService) *UnshareTaskUseCase {
+func NewUnshareTaskUseCase(taskRepo repository.TaskRepository, shareRepo repository.ShareRepository, taskService *service.TaskService, events event.Publisher) *UnshareTaskUseCase {
 	return &UnshareTaskUseCase{
+		taskRepo:    taskRepo,
 		shareRepo:   shareRepo,
 		taskService: taskService,
+		events:      events,
 	}
 }
 
-// Execute removes sharing of a task
+// Execute removes sharing of a task, accepted or still pending. Only the owner can unshare:
+// others get ErrSharePermissionDenied, or ErrTaskUnavailable when they can't see the task.
 func (uc *UnshareTaskUseCase) Execute(ctx context.Context, taskID, ownerID, userID string) error {
-	// Check if requesting user is the owner
-	canModify, err := uc.taskService.CanUserModifyTask(ctx, taskID, ownerID)
-	if err != nil {
+	if err := checkShareOwner(ctx, uc.taskRepo, uc.taskService, taskID, ownerID); err != nil {
 		return err
 	}
-	if !canModify {
-		return errors.New("only the task owner can unshare the task")
+
+	if err := uc.shareRepo.Unshare(ctx, taskID, userID); err != nil {
+		return err
 	}
 
-	return uc.shareRepo.Unshare(ctx, taskID, userID)
+	uc.events.Publish(ctx, event.TaskUnshared{
+		TaskEvent:      event.TaskEvent{TaskID: taskID, OwnerID: ownerID, ActorID: ownerID, Timestamp: time.Now()},
+		UnsharedWithID: userID,
+	})
+
+	return nil
 }
@@ -3,28 +3,43 @@ package usecases
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/event"
 	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
 	"github.com/ia-edev-sindireceita/todo/internal/domain/service"
 )
 
 // UpdateTaskUseCase handles task updates
 type UpdateTaskUseCase struct {
-	taskRepo    repository.TaskRepository
-	taskService *service.TaskService
+	taskRepo       repository.TaskRepository
+	projectRepo    repository.ProjectRepository
+	dependencyRepo repository.DependencyRepository
+	taskService    *service.TaskService
+	events         event.Publisher
+	revisions      *TaskRevisions
 }
 
 // NewUpdateTaskUseCase creates a new UpdateTaskUseCase
-func NewUpdateTaskUseCase(taskRepo repository.TaskRepository, taskService *service.TaskService) *UpdateTaskUseCase {
+func NewUpdateTaskUseCase(taskRepo repository.TaskRepository, projectRepo repository.ProjectRepository, dependencyRepo repository.DependencyRepository, taskService *service.TaskService, events event.Publisher, revisions *TaskRevisions) *UpdateTaskUseCase {
 	return &UpdateTaskUseCase{
-		taskRepo:    taskRepo,
-		taskService: taskService,
+		taskRepo:       taskRepo,
+		projectRepo:    projectRepo,
+		dependencyRepo: dependencyRepo,
+		taskService:    taskService,
+		events:         events,
+		revisions:      revisions,
 	}
 }
 
-// Execute updates a task
-func (uc *UpdateTaskUseCase) Execute(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string) error {
+// Execute updates a task; a nil dueDate clears the due date, an empty projectID takes the
+// task out of its project and application.ColorNone clears its color. Completing a task returns application.ErrTaskBlocked while it has
+// blockers that aren't completed. The completion note is only kept on completed tasks.
+func (uc *UpdateTaskUseCase) Execute(ctx context.Context, taskID, title, description string, status application.TaskStatus, imagePath, userID string, dueDate *time.Time, projectID string, color application.TaskColor, progress int, completionNote string) (err error) {
+	ctx, end := tracer.Start(ctx, "UpdateTask")
+	defer func() { end(err) }()
+
 	// Check if user can modify task
 	canModify, err := uc.taskService.CanUserModifyTask(ctx, taskID, userID)
 	if err != nil {
@@ -41,10 +56,48 @@ func (uc *UpdateTaskUseCase) Execute(ctx context.Context, taskID, title, descrip
 	}
 
 	// Update task with validation
+	revision := uc.revisions.before(task, userID)
+	wasCompleted := task.Status == application.StatusCompleted
+	if !wasCompleted && status == application.StatusCompleted {
+		if err := checkNotBlocked(ctx, uc.dependencyRepo, taskID); err != nil {
+			return err
+		}
+	}
 	if err := task.Update(title, description, status, imagePath); err != nil {
 		return err
 	}
+	if err := task.SetDueDate(dueDate); err != nil {
+		return err
+	}
+	if err := task.SetColor(color); err != nil {
+		return err
+	}
+	if err := task.SetProgress(progress); err != nil {
+		return err
+	}
+	if err := task.SetCompletionNote(completionNote); err != nil {
+		return err
+	}
+	if projectID != task.ProjectID {
+		if err := checkProjectOwner(ctx, uc.projectRepo, projectID, task.OwnerID); err != nil {
+			return err
+		}
+		task.SetProject(projectID)
+	}
 
 	// Persist changes
-	return uc.taskRepo.Update(ctx, task)
+	if err := uc.taskRepo.Update(ctx, task); err != nil {
+		return err
+	}
+	if err := uc.revisions.record(ctx, revision, task); err != nil {
+		return err
+	}
+
+	events := []event.DomainEvent{event.TaskUpdated{TaskEvent: event.NewTaskEvent(task, userID)}}
+	if !wasCompleted && task.Status == application.StatusCompleted {
+		events = append(events, event.TaskCompleted{TaskEvent: event.NewTaskEvent(task, userID)})
+	}
+	uc.events.Publish(ctx, events...)
+
+	return nil
 }
@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// GetUserAvatarUseCase handles reading the avatar uploaded by a user
+type GetUserAvatarUseCase struct {
+	userRepo repository.UserRepository
+}
+
+// NewGetUserAvatarUseCase creates a new GetUserAvatarUseCase
+func NewGetUserAvatarUseCase(userRepo repository.UserRepository) *GetUserAvatarUseCase {
+	return &GetUserAvatarUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute returns the path of the avatar of a user, empty when none was uploaded
+func (uc *GetUserAvatarUseCase) Execute(ctx context.Context, userID string) (string, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	return user.AvatarPath, nil
+}
+
+// UpdateUserAvatarUseCase handles changing the avatar of a user
+type UpdateUserAvatarUseCase struct {
+	userRepo repository.UserRepository
+}
+
+// NewUpdateUserAvatarUseCase creates a new UpdateUserAvatarUseCase
+func NewUpdateUserAvatarUseCase(userRepo repository.UserRepository) *UpdateUserAvatarUseCase {
+	return &UpdateUserAvatarUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute persists the path of the new avatar of a user, already stored by the caller, and
+// returns the path of the avatar it replaces so its file can be removed
+func (uc *UpdateUserAvatarUseCase) Execute(ctx context.Context, userID, avatarPath string) (string, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	previous := user.AvatarPath
+	user.AvatarPath = avatarPath
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return "", err
+	}
+	return previous, nil
+}
@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestGetUserAvatarUseCase_Execute(t *testing.T) {
+	repo := newMockUserRepositoryForTheme(
+		&application.User{ID: "with-avatar", AvatarPath: "/uploads/images/1_abc.png"},
+		&application.User{ID: "without-avatar"},
+	)
+	uc := NewGetUserAvatarUseCase(repo)
+
+	tests := []struct {
+		name    string
+		userID  string
+		want    string
+		wantErr error
+	}{
+		{"uploaded avatar", "with-avatar", "/uploads/images/1_abc.png", nil},
+		{"no avatar", "without-avatar", "", nil},
+		{"unknown user", "missing", "", application.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.Execute(context.Background(), tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Execute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateUserAvatarUseCase_Execute(t *testing.T) {
+	user := &application.User{ID: "user-123", AvatarPath: "/uploads/images/1_old.png"}
+	repo := newMockUserRepositoryForTheme(user)
+	uc := NewUpdateUserAvatarUseCase(repo)
+
+	previous, err := uc.Execute(context.Background(), "user-123", "/uploads/images/2_new.png")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if previous != "/uploads/images/1_old.png" {
+		t.Errorf("Expected the replaced avatar to be returned, got %q", previous)
+	}
+	if user.AvatarPath != "/uploads/images/2_new.png" || repo.updated != 1 {
+		t.Errorf("Expected the new avatar to be saved once, got %q after %d updates", user.AvatarPath, repo.updated)
+	}
+
+	if _, err := uc.Execute(context.Background(), "missing", "/uploads/images/3.png"); !errors.Is(err, application.ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+}
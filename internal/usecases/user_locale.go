@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// UpdateUserLocaleUseCase handles changing the language preferred by a user
+type UpdateUserLocaleUseCase struct {
+	userRepo repository.UserRepository
+}
+
+// NewUpdateUserLocaleUseCase creates a new UpdateUserLocaleUseCase
+func NewUpdateUserLocaleUseCase(userRepo repository.UserRepository) *UpdateUserLocaleUseCase {
+	return &UpdateUserLocaleUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute validates and persists the language of a user
+func (uc *UpdateUserLocaleUseCase) Execute(ctx context.Context, userID, locale string) error {
+	parsed, err := application.ParseLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	user.Locale = parsed
+	return uc.userRepo.Update(ctx, user)
+}
@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+func TestUpdateUserLocaleUseCase_Execute(t *testing.T) {
+	user := &application.User{ID: "user-123"}
+	repo := newMockUserRepositoryForTheme(user)
+	uc := NewUpdateUserLocaleUseCase(repo)
+
+	if err := uc.Execute(context.Background(), "user-123", "en"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if user.Locale != application.LocaleEN {
+		t.Errorf("Expected locale en, got %q", user.Locale)
+	}
+	if repo.updated != 1 {
+		t.Errorf("Expected 1 update, got %d", repo.updated)
+	}
+}
+
+func TestUpdateUserLocaleUseCase_Errors(t *testing.T) {
+	repo := newMockUserRepositoryForTheme(&application.User{ID: "user-123"})
+	uc := NewUpdateUserLocaleUseCase(repo)
+
+	if err := uc.Execute(context.Background(), "user-123", "fr"); !errors.Is(err, application.ErrInvalidLocale) {
+		t.Errorf("Expected ErrInvalidLocale, got %v", err)
+	}
+	if err := uc.Execute(context.Background(), "missing", "en"); !errors.Is(err, application.ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+	if repo.updated != 0 {
+		t.Errorf("Expected no updates, got %d", repo.updated)
+	}
+}
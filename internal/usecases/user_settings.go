@@ -0,0 +1,151 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// UserPreferences gathers every preference of a user: the theme and language, kept with the
+// account, and their UserSettings
+type UserPreferences struct {
+	Theme    application.Theme
+	Locale   application.Locale // empty until the user picks a language
+	Settings *application.UserSettings
+}
+
+// UserPreferencesChanges holds the preferences to change; nil fields and notification types
+// left out keep their value, so the preferences read from GET /api/me/settings can be put back
+// whole or in part
+type UserPreferencesChanges struct {
+	Theme         *string
+	Locale        *string
+	DefaultSort   *string
+	Notifications map[string]bool
+}
+
+// GetUserPreferencesUseCase handles reading the preferences of a user
+type GetUserPreferencesUseCase struct {
+	userRepo     repository.UserRepository
+	settingsRepo repository.UserSettingsRepository
+}
+
+// NewGetUserPreferencesUseCase creates a new GetUserPreferencesUseCase
+func NewGetUserPreferencesUseCase(userRepo repository.UserRepository, settingsRepo repository.UserSettingsRepository) *GetUserPreferencesUseCase {
+	return &GetUserPreferencesUseCase{
+		userRepo:     userRepo,
+		settingsRepo: settingsRepo,
+	}
+}
+
+// Execute returns the preferences of a user, with the default settings when they never saved any
+func (uc *GetUserPreferencesUseCase) Execute(ctx context.Context, userID string) (*UserPreferences, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := userSettings(ctx, uc.settingsRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newUserPreferences(user, settings), nil
+}
+
+// UpdateUserPreferencesUseCase handles changing the preferences of a user
+type UpdateUserPreferencesUseCase struct {
+	userRepo     repository.UserRepository
+	settingsRepo repository.UserSettingsRepository
+	transactor   repository.Transactor
+	now          func() time.Time
+}
+
+// NewUpdateUserPreferencesUseCase creates a new UpdateUserPreferencesUseCase
+func NewUpdateUserPreferencesUseCase(
+	userRepo repository.UserRepository,
+	settingsRepo repository.UserSettingsRepository,
+	transactor repository.Transactor,
+) *UpdateUserPreferencesUseCase {
+	return &UpdateUserPreferencesUseCase{
+		userRepo:     userRepo,
+		settingsRepo: settingsRepo,
+		transactor:   transactor,
+		now:          time.Now,
+	}
+}
+
+// Execute validates every change before saving any, then saves the account and the settings
+// together and returns the resulting preferences
+func (uc *UpdateUserPreferencesUseCase) Execute(ctx context.Context, userID string, changes UserPreferencesChanges) (*UserPreferences, error) {
+	var preferences *UserPreferences
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := uc.userRepo.FindByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		settings, err := userSettings(ctx, uc.settingsRepo, userID)
+		if err != nil {
+			return err
+		}
+
+		if changes.Theme != nil {
+			if user.Theme, err = application.ParseTheme(*changes.Theme); err != nil {
+				return err
+			}
+		}
+		if changes.Locale != nil {
+			if user.Locale, err = application.ParseLocale(*changes.Locale); err != nil {
+				return err
+			}
+		}
+		if changes.DefaultSort != nil {
+			if settings.DefaultSort, err = application.ParseDefaultSort(*changes.DefaultSort); err != nil {
+				return err
+			}
+		}
+		for notificationType, enabled := range changes.Notifications {
+			if err := settings.Notifications.Set(application.NotificationType(notificationType), enabled); err != nil {
+				return err
+			}
+		}
+		settings.UpdatedAt = uc.now()
+
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		if err := uc.settingsRepo.Save(ctx, settings); err != nil {
+			return err
+		}
+
+		preferences = newUserPreferences(user, settings)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+// userSettings returns the saved settings of a user, or the defaults when they never saved any
+func userSettings(ctx context.Context, settingsRepo repository.UserSettingsRepository, userID string) (*application.UserSettings, error) {
+	settings, err := settingsRepo.FindByUserID(ctx, userID)
+	if errors.Is(err, application.ErrUserSettingsNotFound) {
+		return application.DefaultUserSettings(userID), nil
+	}
+	return settings, err
+}
+
+// newUserPreferences gathers the preferences of a user from their account and settings
+func newUserPreferences(user *application.User, settings *application.UserSettings) *UserPreferences {
+	theme := user.Theme
+	if theme == "" {
+		theme = application.ThemeLight
+	}
+	return &UserPreferences{Theme: theme, Locale: user.Locale, Settings: settings}
+}
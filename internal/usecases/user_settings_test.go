@@ -0,0 +1,141 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// mockUserSettingsRepository keeps settings in memory
+type mockUserSettingsRepository struct {
+	settings map[string]*application.UserSettings
+	saved    int
+}
+
+func newMockUserSettingsRepository(settings ...*application.UserSettings) *mockUserSettingsRepository {
+	repo := &mockUserSettingsRepository{settings: make(map[string]*application.UserSettings)}
+	for _, s := range settings {
+		repo.settings[s.UserID] = s
+	}
+	return repo
+}
+
+func (m *mockUserSettingsRepository) Save(ctx context.Context, settings *application.UserSettings) error {
+	m.saved++
+	copied := *settings
+	m.settings[settings.UserID] = &copied
+	return nil
+}
+
+func (m *mockUserSettingsRepository) FindByUserID(ctx context.Context, userID string) (*application.UserSettings, error) {
+	settings, ok := m.settings[userID]
+	if !ok {
+		return nil, application.ErrUserSettingsNotFound
+	}
+	copied := *settings
+	return &copied, nil
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestGetUserPreferencesUseCase_Execute(t *testing.T) {
+	users := newMockUserRepositoryForTheme(
+		&application.User{ID: "user-123", Theme: application.ThemeDark, Locale: application.LocaleEN},
+		&application.User{ID: "new-user"},
+	)
+	saved := &application.UserSettings{UserID: "user-123", DefaultSort: application.SortOldest}
+	uc := NewGetUserPreferencesUseCase(users, newMockUserSettingsRepository(saved))
+
+	preferences, err := uc.Execute(context.Background(), "user-123")
+	if err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if preferences.Theme != application.ThemeDark || preferences.Locale != application.LocaleEN || preferences.Settings.DefaultSort != application.SortOldest {
+		t.Errorf("Execute() = %+v, want the saved preferences", preferences)
+	}
+
+	// Users who never saved settings get the defaults
+	preferences, err = uc.Execute(context.Background(), "new-user")
+	if err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if preferences.Theme != application.ThemeLight || *preferences.Settings != *application.DefaultUserSettings("new-user") {
+		t.Errorf("Execute() = %+v, %+v; want the defaults", preferences, preferences.Settings)
+	}
+
+	if _, err := uc.Execute(context.Background(), "missing"); !errors.Is(err, application.ErrUserNotFound) {
+		t.Errorf("Execute() of an unknown user error = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestUpdateUserPreferencesUseCase_Execute(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	user := &application.User{ID: "user-123", Theme: application.ThemeLight}
+	users := newMockUserRepositoryForTheme(user)
+	settingsRepo := newMockUserSettingsRepository()
+	transactor := &mockTransactor{}
+	uc := NewUpdateUserPreferencesUseCase(users, settingsRepo, transactor)
+	uc.now = func() time.Time { return now }
+
+	preferences, err := uc.Execute(context.Background(), "user-123", UserPreferencesChanges{
+		Theme:         stringPtr("dark"),
+		DefaultSort:   stringPtr("created_at"),
+		Notifications: map[string]bool{"task_completed": false},
+	})
+	if err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+
+	want := application.DefaultUserSettings("user-123")
+	want.DefaultSort = application.SortOldest
+	want.Notifications.TaskCompleted = false
+	want.UpdatedAt = now
+	if *settingsRepo.settings["user-123"] != *want || *preferences.Settings != *want {
+		t.Errorf("saved settings = %+v, want %+v", settingsRepo.settings["user-123"], want)
+	}
+	if user.Theme != application.ThemeDark || preferences.Theme != application.ThemeDark {
+		t.Errorf("theme = %q, want dark", user.Theme)
+	}
+	// The locale was left out, so it stays unset
+	if user.Locale != "" {
+		t.Errorf("locale = %q, want it unchanged", user.Locale)
+	}
+	if transactor.calls != 1 {
+		t.Errorf("Expected 1 transaction, got %d", transactor.calls)
+	}
+}
+
+func TestUpdateUserPreferencesUseCase_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		changes UserPreferencesChanges
+		wantErr error
+	}{
+		{"invalid theme", "user-123", UserPreferencesChanges{Theme: stringPtr("neon")}, application.ErrInvalidTheme},
+		{"invalid locale", "user-123", UserPreferencesChanges{Locale: stringPtr("fr")}, application.ErrInvalidLocale},
+		{"sort the task list can't page through", "user-123", UserPreferencesChanges{DefaultSort: stringPtr("due_date")}, application.ErrInvalidDefaultSort},
+		{"reminders can't be turned off", "user-123", UserPreferencesChanges{Notifications: map[string]bool{"task_reminder": false}}, application.ErrUnknownNotificationSetting},
+		{"unknown user", "missing", UserPreferencesChanges{}, application.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := newMockUserRepositoryForTheme(&application.User{ID: "user-123"})
+			settingsRepo := newMockUserSettingsRepository()
+			uc := NewUpdateUserPreferencesUseCase(users, settingsRepo, &mockTransactor{})
+
+			if _, err := uc.Execute(context.Background(), tt.userID, tt.changes); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if users.updated != 0 || settingsRepo.saved != 0 {
+				t.Errorf("Expected nothing saved, got %d user updates and %d settings saves", users.updated, settingsRepo.saved)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+	"github.com/ia-edev-sindireceita/todo/internal/domain/repository"
+)
+
+// GetUserThemeUseCase handles reading the theme preferred by a user
+type GetUserThemeUseCase struct {
+	userRepo repository.UserRepository
+}
+
+// NewGetUserThemeUseCase creates a new GetUserThemeUseCase
+func NewGetUserThemeUseCase(userRepo repository.UserRepository) *GetUserThemeUseCase {
+	return &GetUserThemeUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute returns the theme of a user, falling back to light when none was chosen
+func (uc *GetUserThemeUseCase) Execute(ctx context.Context, userID string) (application.Theme, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	if user.Theme == "" {
+		return application.ThemeLight, nil
+	}
+	return user.Theme, nil
+}
+
+// UpdateUserThemeUseCase handles changing the theme preferred by a user
+type UpdateUserThemeUseCase struct {
+	userRepo repository.UserRepository
+}
+
+// NewUpdateUserThemeUseCase creates a new UpdateUserThemeUseCase
+func NewUpdateUserThemeUseCase(userRepo repository.UserRepository) *UpdateUserThemeUseCase {
+	return &UpdateUserThemeUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute validates and persists the theme of a user
+func (uc *UpdateUserThemeUseCase) Execute(ctx context.Context, userID, theme string) error {
+	parsed, err := application.ParseTheme(theme)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	user.Theme = parsed
+	return uc.userRepo.Update(ctx, user)
+}
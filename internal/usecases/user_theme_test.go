@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ia-edev-sindireceita/todo/internal/domain/application"
+)
+
+// mockUserRepositoryForTheme returns nil for unknown users, like the SQLite repository
+type mockUserRepositoryForTheme struct {
+	mockUserRepositoryForLogin
+	updated int
+}
+
+func (m *mockUserRepositoryForTheme) FindByID(ctx context.Context, id string) (*application.User, error) {
+	if user, ok := m.users[id]; ok {
+		return user, nil
+	}
+	return nil, application.ErrUserNotFound
+}
+
+func (m *mockUserRepositoryForTheme) Update(ctx context.Context, user *application.User) error {
+	m.updated++
+	return m.mockUserRepositoryForLogin.Update(ctx, user)
+}
+
+func newMockUserRepositoryForTheme(users ...*application.User) *mockUserRepositoryForTheme {
+	repo := &mockUserRepositoryForTheme{mockUserRepositoryForLogin: mockUserRepositoryForLogin{users: make(map[string]*application.User)}}
+	for _, user := range users {
+		repo.users[user.ID] = user
+	}
+	return repo
+}
+
+func TestGetUserThemeUseCase_Execute(t *testing.T) {
+	repo := newMockUserRepositoryForTheme(
+		&application.User{ID: "dark-user", Theme: application.ThemeDark},
+		&application.User{ID: "legacy-user"},
+	)
+	uc := NewGetUserThemeUseCase(repo)
+
+	tests := []struct {
+		name    string
+		userID  string
+		want    application.Theme
+		wantErr error
+	}{
+		{"stored theme", "dark-user", application.ThemeDark, nil},
+		{"no theme defaults to light", "legacy-user", application.ThemeLight, nil},
+		{"unknown user", "missing", "", application.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.Execute(context.Background(), tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Execute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateUserThemeUseCase_Execute(t *testing.T) {
+	user := &application.User{ID: "user-123", Theme: application.ThemeLight}
+	repo := newMockUserRepositoryForTheme(user)
+	uc := NewUpdateUserThemeUseCase(repo)
+
+	if err := uc.Execute(context.Background(), "user-123", "dark"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if user.Theme != application.ThemeDark {
+		t.Errorf("Expected theme dark, got %q", user.Theme)
+	}
+	if repo.updated != 1 {
+		t.Errorf("Expected 1 update, got %d", repo.updated)
+	}
+}
+
+func TestUpdateUserThemeUseCase_Errors(t *testing.T) {
+	repo := newMockUserRepositoryForTheme(&application.User{ID: "user-123"})
+	uc := NewUpdateUserThemeUseCase(repo)
+
+	if err := uc.Execute(context.Background(), "user-123", "neon"); !errors.Is(err, application.ErrInvalidTheme) {
+		t.Errorf("Expected ErrInvalidTheme, got %v", err)
+	}
+	if err := uc.Execute(context.Background(), "missing", "dark"); !errors.Is(err, application.ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+	if repo.updated != 0 {
+		t.Errorf("Expected no updates, got %d", repo.updated)
+	}
+}
@@ -0,0 +1,5 @@
+// Package todov1 holds the messages and the service stubs of the gRPC API, generated from
+// tasks.proto by protoc-gen-go and protoc-gen-go-grpc. Run go generate after changing it.
+package todov1
+
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative todo/v1/tasks.proto